@@ -39,6 +39,8 @@ func openDBConnection(driver string) (err error) {
 			return fmt.Errorf("database failed to connect: %v, some features that utilise a database will be unavailable", err)
 		}
 		return nil
+	} else if driver == database.DBBolt {
+		return errors.New("bolt databases are schemaless and do not require migrations")
 	}
 	return errors.New("no connection established")
 }
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
@@ -15,6 +17,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/urfave/cli"
@@ -498,6 +501,186 @@ func getExchangeInfo(c *cli.Context) error {
 	return nil
 }
 
+var getExchangeHealthCommand = cli.Command{
+	Name:      "getexchangehealth",
+	Usage:     "gets the health of each exchange, or of a specific exchange, as measured by the exchange health monitor",
+	ArgsUsage: "<exchange>",
+	Action:    getExchangeHealth,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the optional exchange to get the health of",
+		},
+	},
+}
+
+func getExchangeHealth(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetExchangeHealth(context.Background(),
+		&gctrpc.GetExchangeHealthRequest{
+			Exchange: exchangeName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getClockDriftCommand = cli.Command{
+	Name:      "getclockdrift",
+	Usage:     "gets the drift of the local clock from each exchange's server time, or from a specific exchange's, as measured by the time sync checker",
+	ArgsUsage: "<exchange>",
+	Action:    getClockDrift,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the optional exchange to get the clock drift of",
+		},
+	},
+}
+
+func getClockDrift(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetClockDrift(context.Background(),
+		&gctrpc.GetClockDriftRequest{
+			Exchange: exchangeName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getMaintenanceWindowsCommand = cli.Command{
+	Name:      "getmaintenancewindows",
+	Usage:     "gets the current and upcoming maintenance windows of each exchange, or a specific exchange, during which orders are not placed",
+	ArgsUsage: "<exchange>",
+	Action:    getMaintenanceWindows,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the optional exchange to get the maintenance windows of",
+		},
+	},
+}
+
+func getMaintenanceWindows(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetMaintenanceWindows(context.Background(),
+		&gctrpc.GetMaintenanceWindowsRequest{
+			Exchange: exchangeName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var createDiagnosticsBundleCommand = cli.Command{
+	Name:      "creatediagnosticsbundle",
+	Usage:     "writes the redacted requests and responses of failed exchange calls captured with -exchangehttpcapture to a zip file for bug reports",
+	ArgsUsage: "<exchange>",
+	Action:    createDiagnosticsBundle,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the optional exchange to write the captured requests of, defaults to all exchanges",
+		},
+	},
+}
+
+func createDiagnosticsBundle(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.CreateDiagnosticsBundle(context.Background(),
+		&gctrpc.CreateDiagnosticsBundleRequest{
+			Exchange: exchangeName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var getTickerCommand = cli.Command{
 	Name:      "getticker",
 	Usage:     "gets the ticker for a specific currency pair and exchange",
@@ -724,34 +907,73 @@ func getOrderbooks(_ *cli.Context) error {
 	return nil
 }
 
-var getAccountInfoCommand = cli.Command{
-	Name:      "getaccountinfo",
-	Usage:     "gets the exchange account balance info",
-	ArgsUsage: "<exchange>",
-	Action:    getAccountInfo,
+var getOrderbookAnalyticsCommand = cli.Command{
+	Name:      "getorderbookanalytics",
+	Usage:     "gets the spread, imbalance and depth of stored orderbooks, all are returned when no exchange, pair or asset is set",
+	ArgsUsage: "<exchange> <pair> <asset>",
+	Action:    getOrderbookAnalytics,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to get the account info for",
+			Usage: "the exchange to get the orderbook analytics for",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to get the orderbook analytics for",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair to get the orderbook analytics for",
+		},
+		cli.StringSliceFlag{
+			Name:  "depth_percent",
+			Usage: "the percentage of the mid price to measure the orderbook depth within (repeatable)",
 		},
 	},
 }
 
-func getAccountInfo(c *cli.Context) error {
-	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getaccountinfo")
-		return nil
+func getOrderbookAnalytics(c *cli.Context) error {
+	exchangeName := c.String("exchange")
+	if exchangeName == "" {
+		exchangeName = c.Args().First()
+	}
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
 	}
 
-	var exchange string
-	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else {
-		exchange = c.Args().First()
+	currencyPair := c.String("pair")
+	if currencyPair == "" {
+		currencyPair = c.Args().Get(1)
+	}
+	var pair *gctrpc.CurrencyPair
+	if currencyPair != "" {
+		if !validPair(currencyPair) {
+			return errInvalidPair
+		}
+		p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+		pair = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		}
 	}
 
-	if !validExchange(exchange) {
-		return errInvalidExchange
+	assetType := c.String("asset")
+	if assetType == "" {
+		assetType = c.Args().Get(2)
+	}
+	assetType = strings.ToLower(assetType)
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	var depthPercents []float64
+	for _, v := range c.StringSlice("depth_percent") {
+		d, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid depth percent %s: %v", v, err)
+		}
+		depthPercents = append(depthPercents, d)
 	}
 
 	conn, err := setupClient()
@@ -761,9 +983,12 @@ func getAccountInfo(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetAccountInfo(context.Background(),
-		&gctrpc.GetAccountInfoRequest{
-			Exchange: exchange,
+	result, err := client.GetOrderbookAnalytics(context.Background(),
+		&gctrpc.GetOrderbookAnalyticsRequest{
+			Exchange:      exchangeName,
+			Pair:          pair,
+			AssetType:     assetType,
+			DepthPercents: depthPercents,
 		},
 	)
 	if err != nil {
@@ -774,64 +999,224 @@ func getAccountInfo(c *cli.Context) error {
 	return nil
 }
 
-var getAccountInfoStreamCommand = cli.Command{
-	Name:      "getaccountinfostream",
-	Usage:     "gets the account info stream for a specific exchange",
-	ArgsUsage: "<exchange>",
-	Action:    getAccountInfoStream,
+var estimateSlippageCommand = cli.Command{
+	Name:      "estimateslippage",
+	Usage:     "estimates the average fill price and slippage of a market order against the stored orderbook",
+	ArgsUsage: "<exchange> <pair> <asset> <side> <amount>",
+	Action:    estimateSlippage,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to get the account info stream from",
+			Usage: "the exchange to estimate the slippage on",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to estimate the slippage of",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair, defaults to spot",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side, buy or sell",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the base currency amount of the order",
 		},
 	},
 }
 
-func getAccountInfoStream(c *cli.Context) error {
+func estimateSlippage(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getaccountinfostream")
-		return nil
+		return cli.ShowCommandHelp(c, "estimateslippage")
 	}
 
-	var exchangeName string
-
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
-	} else {
+	exchangeName := c.String("exchange")
+	if exchangeName == "" {
 		exchangeName = c.Args().First()
 	}
-
 	if !validExchange(exchangeName) {
 		return errInvalidExchange
 	}
 
-	conn, err := setupClient()
-	if err != nil {
-		return err
+	currencyPair := c.String("pair")
+	if currencyPair == "" {
+		currencyPair = c.Args().Get(1)
 	}
-	defer conn.Close()
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 
-	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetAccountInfoStream(context.Background(),
-		&gctrpc.GetAccountInfoRequest{Exchange: exchangeName})
-	if err != nil {
-		return err
+	assetType := c.String("asset")
+	if assetType == "" {
+		assetType = c.Args().Get(2)
+	}
+	assetType = strings.ToLower(assetType)
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
 	}
 
-	for {
-		resp, err := result.Recv()
-		if err != nil {
-			return err
-		}
+	side := c.String("side")
+	if side == "" {
+		side = c.Args().Get(3)
+	}
+	if side == "" {
+		return errors.New("order side must be set")
+	}
 
-		err = clearScreen()
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(4), 64)
 		if err != nil {
 			return err
 		}
-
-		fmt.Printf("Account balance stream for %s:\n\n", exchangeName)
-
-		fmt.Printf("%+v", resp)
+	}
+	if amount <= 0 {
+		return errors.New("amount must be set")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.EstimateSlippage(context.Background(),
+		&gctrpc.EstimateSlippageRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType: assetType,
+			Side:      side,
+			Amount:    amount,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getAccountInfoCommand = cli.Command{
+	Name:      "getaccountinfo",
+	Usage:     "gets the exchange account balance info",
+	ArgsUsage: "<exchange>",
+	Action:    getAccountInfo,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the account info for",
+		},
+	},
+}
+
+func getAccountInfo(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getaccountinfo")
+		return nil
+	}
+
+	var exchange string
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().First()
+	}
+
+	if !validExchange(exchange) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetAccountInfo(context.Background(),
+		&gctrpc.GetAccountInfoRequest{
+			Exchange: exchange,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getAccountInfoStreamCommand = cli.Command{
+	Name:      "getaccountinfostream",
+	Usage:     "gets the account info stream for a specific exchange",
+	ArgsUsage: "<exchange>",
+	Action:    getAccountInfoStream,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the account info stream from",
+		},
+	},
+}
+
+func getAccountInfoStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getaccountinfostream")
+		return nil
+	}
+
+	var exchangeName string
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetAccountInfoStream(context.Background(),
+		&gctrpc.GetAccountInfoRequest{Exchange: exchangeName})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		err = clearScreen()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Account balance stream for %s:\n\n", exchangeName)
+
+		fmt.Printf("%+v", resp)
 	}
 }
 
@@ -882,12 +1267,26 @@ func getPortfolio(_ *cli.Context) error {
 }
 
 var getPortfolioSummaryCommand = cli.Command{
-	Name:   "getportfoliosummary",
-	Usage:  "gets the portfolio summary",
-	Action: getPortfolioSummary,
+	Name:      "getportfoliosummary",
+	Usage:     "gets the portfolio summary with each holding valued in a base currency",
+	ArgsUsage: "<base_currency>",
+	Action:    getPortfolioSummary,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "base_currency",
+			Usage: "the currency to value holdings in, defaults to the fiat display currency",
+		},
+	},
 }
 
-func getPortfolioSummary(_ *cli.Context) error {
+func getPortfolioSummary(c *cli.Context) error {
+	var baseCurrency string
+	if c.IsSet("base_currency") {
+		baseCurrency = c.String("base_currency")
+	} else {
+		baseCurrency = c.Args().First()
+	}
+
 	conn, err := setupClient()
 	if err != nil {
 		return err
@@ -895,7 +1294,11 @@ func getPortfolioSummary(_ *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetPortfolioSummary(context.Background(), &gctrpc.GetPortfolioSummaryRequest{})
+	result, err := client.GetPortfolioSummary(context.Background(),
+		&gctrpc.GetPortfolioSummaryRequest{
+			BaseCurrency: baseCurrency,
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -1313,6 +1716,46 @@ var submitOrderCommand = cli.Command{
 			Name:  "client_id",
 			Usage: "the optional client order ID",
 		},
+		cli.Int64Flag{
+			Name:  "chase_timeout",
+			Usage: "the optional amount of seconds a limit order rests before being re-priced towards the market",
+		},
+		cli.Float64Flag{
+			Name:  "chase_max_deviation",
+			Usage: "the maximum percentage a chased order's price can move from its original price",
+		},
+		cli.Int64Flag{
+			Name:  "chase_max_attempts",
+			Usage: "the maximum amount of times a chased order is re-priced",
+		},
+		cli.BoolFlag{
+			Name:  "chase_convert_to_market",
+			Usage: "submits the remainder of a chased order as a market order once it can no longer be re-priced",
+		},
+		cli.StringFlag{
+			Name:  "self_trade_prevention",
+			Usage: "the optional self trade prevention mode (CANCEL_NEWEST, CANCEL_OLDEST OR CANCEL_BOTH)",
+		},
+		cli.StringSliceFlag{
+			Name:  "fallback",
+			Usage: "an exchange to place the order on if the exchange is offline, in order of preference (repeatable)",
+		},
+		cli.BoolFlag{
+			Name:  "post_only",
+			Usage: "cancels the limit order instead of taking liquidity from the order book",
+		},
+		cli.BoolFlag{
+			Name:  "immediate_or_cancel",
+			Usage: "cancels any amount of the order which cannot be filled immediately",
+		},
+		cli.BoolFlag{
+			Name:  "fill_or_kill",
+			Usage: "cancels the order unless it can be filled entirely immediately",
+		},
+		cli.BoolFlag{
+			Name:  "auction_only",
+			Usage: "only fills the limit order in the exchange's next auction",
+		},
 	},
 }
 
@@ -1401,6 +1844,16 @@ func submitOrder(c *cli.Context) error {
 		clientID = c.Args().Get(6)
 	}
 
+	var chase *gctrpc.ChaseOptions
+	if c.IsSet("chase_timeout") {
+		chase = &gctrpc.ChaseOptions{
+			TimeoutSeconds:  c.Int64("chase_timeout"),
+			MaxDeviation:    c.Float64("chase_max_deviation"),
+			MaxAttempts:     c.Int64("chase_max_attempts"),
+			ConvertToMarket: c.Bool("chase_convert_to_market"),
+		}
+	}
+
 	conn, err := setupClient()
 	if err != nil {
 		return err
@@ -1416,11 +1869,18 @@ func submitOrder(c *cli.Context) error {
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
-		Side:      orderSide,
-		OrderType: orderType,
-		Amount:    amount,
-		Price:     price,
-		ClientId:  clientID,
+		Side:                orderSide,
+		OrderType:           orderType,
+		Amount:              amount,
+		Price:               price,
+		ClientId:            clientID,
+		Chase:               chase,
+		SelfTradePrevention: c.String("self_trade_prevention"),
+		FallbackExchanges:   c.StringSlice("fallback"),
+		PostOnly:            c.Bool("post_only"),
+		ImmediateOrCancel:   c.Bool("immediate_or_cancel"),
+		FillOrKill:          c.Bool("fill_or_kill"),
+		AuctionOnly:         c.Bool("auction_only"),
 	})
 	if err != nil {
 		return err
@@ -1430,15 +1890,15 @@ func submitOrder(c *cli.Context) error {
 	return nil
 }
 
-var simulateOrderCommand = cli.Command{
-	Name:      "simulateorder",
-	Usage:     "simulate order simulates an exchange order",
-	ArgsUsage: "<exchange> <pair> <side> <amount>",
-	Action:    simulateOrder,
+var tradeCommand = cli.Command{
+	Name:      "trade",
+	Usage:     "interactively builds an order ticket, previewing the live top of book, estimated fee and slippage before confirming submission",
+	ArgsUsage: "<exchange> <pair> <side> <type> <amount> <price>",
+	Action:    trade,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to simulate the order for",
+			Usage: "the exchange to submit the order for",
 		},
 		cli.StringFlag{
 			Name:  "pair",
@@ -1448,68 +1908,91 @@ var simulateOrderCommand = cli.Command{
 			Name:  "side",
 			Usage: "the order side to use (BUY OR SELL)",
 		},
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "the order type (MARKET OR LIMIT)",
+		},
 		cli.Float64Flag{
 			Name:  "amount",
 			Usage: "the amount for the order",
 		},
+		cli.Float64Flag{
+			Name:  "price",
+			Usage: "the price for limit orders",
+		},
 	},
 }
 
-func simulateOrder(c *cli.Context) error {
-	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "simulateorder")
-		return nil
+func trade(c *cli.Context) error {
+	in := bufio.NewReader(os.Stdin)
+	argOrPrompt := func(flag string, arg int, label string) (string, error) {
+		if c.IsSet(flag) {
+			return c.String(flag), nil
+		}
+		if v := c.Args().Get(arg); v != "" {
+			return v, nil
+		}
+		return prompt(in, label)
 	}
 
-	var exchangeName string
-	var currencyPair string
-	var orderSide string
-	var amount float64
-
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
-	} else {
-		exchangeName = c.Args().First()
+	exchangeName, err := argOrPrompt("exchange", 0, "Exchange")
+	if err != nil {
+		return err
 	}
-
 	if !validExchange(exchangeName) {
 		return errInvalidExchange
 	}
 
-	if c.IsSet("pair") {
-		currencyPair = c.String("pair")
-	} else {
-		currencyPair = c.Args().Get(1)
+	currencyPair, err := argOrPrompt("pair", 1, "Pair (e.g. BTC"+pairDelimiter+"USD)")
+	if err != nil {
+		return err
 	}
-
 	if !validPair(currencyPair) {
 		return errInvalidPair
 	}
 
-	if c.IsSet("side") {
-		orderSide = c.String("side")
-	} else {
-		orderSide = c.Args().Get(2)
+	orderSide, err := argOrPrompt("side", 2, "Side (BUY or SELL)")
+	if err != nil {
+		return err
 	}
-
 	if orderSide == "" {
-		return errors.New("side must be set")
+		return errors.New("order side must be set")
 	}
 
-	if c.IsSet("amount") {
-		amount = c.Float64("amount")
-	} else if c.Args().Get(3) != "" {
-		var err error
-		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
-		if err != nil {
-			return err
-		}
+	orderType, err := argOrPrompt("type", 3, "Type (MARKET or LIMIT)")
+	if err != nil {
+		return err
+	}
+	if orderType == "" {
+		return errors.New("order type must be set")
 	}
 
-	if amount == 0 {
+	amountStr, err := argOrPrompt("amount", 4, "Amount")
+	if err != nil {
+		return err
+	}
+	amount, err := strconv.ParseFloat(amountStr, 64)
+	if err != nil {
+		return err
+	}
+	if amount <= 0 {
 		return errors.New("amount must be set")
 	}
 
+	// price is only required for limit orders
+	var price float64
+	if strings.EqualFold(orderType, "LIMIT") || c.IsSet("price") || c.Args().Get(5) != "" {
+		var priceStr string
+		priceStr, err = argOrPrompt("price", 5, "Price")
+		if err != nil {
+			return err
+		}
+		price, err = strconv.ParseFloat(priceStr, 64)
+		if err != nil {
+			return err
+		}
+	}
+
 	conn, err := setupClient()
 	if err != nil {
 		return err
@@ -1517,16 +2000,127 @@ func simulateOrder(c *cli.Context) error {
 	defer conn.Close()
 
 	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	rpcPair := &gctrpc.CurrencyPair{
+		Delimiter: p.Delimiter,
+		Base:      p.Base.String(),
+		Quote:     p.Quote.String(),
+	}
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.SimulateOrder(context.Background(), &gctrpc.SimulateOrderRequest{
-		Exchange: exchangeName,
-		Pair: &gctrpc.CurrencyPair{
-			Delimiter: p.Delimiter,
-			Base:      p.Base.String(),
-			Quote:     p.Quote.String(),
-		},
-		Side:   orderSide,
-		Amount: amount,
+	for {
+		preview, err := client.PreviewOrder(context.Background(), &gctrpc.PreviewOrderRequest{
+			Exchange:  exchangeName,
+			Pair:      rpcPair,
+			Side:      orderSide,
+			OrderType: orderType,
+			Amount:    amount,
+			Price:     price,
+		})
+		if err != nil {
+			return err
+		}
+		printOrderTicket(exchangeName, p, orderSide, orderType, amount, price, preview)
+
+		answer, err := prompt(in, "Submit order? (y)es, (r)efresh or (n)o")
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+		case "r", "refresh":
+			continue
+		default:
+			fmt.Println("Order cancelled.")
+			return nil
+		}
+
+		result, err := client.SubmitOrder(context.Background(), &gctrpc.SubmitOrderRequest{
+			Exchange:  exchangeName,
+			Pair:      rpcPair,
+			Side:      orderSide,
+			OrderType: orderType,
+			Amount:    amount,
+			Price:     price,
+		})
+		if err != nil {
+			return err
+		}
+
+		jsonOutput(result)
+		return nil
+	}
+}
+
+// prompt prints the label and returns the trimmed line entered
+func prompt(in *bufio.Reader, label string) (string, error) {
+	fmt.Printf("%s: ", label)
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func printOrderTicket(exchangeName string, p currency.Pair, side, orderType string, amount, price float64, preview *gctrpc.PreviewOrderResponse) {
+	quote := p.Quote.String()
+	fmt.Println()
+	fmt.Printf("Order:           %s %s %v %s on %s", strings.ToUpper(orderType), strings.ToUpper(side), amount, p, exchangeName)
+	if price > 0 {
+		fmt.Printf(" @ %v", price)
+	}
+	fmt.Println()
+	fmt.Printf("Best bid:        %v (%v)\n", preview.BestBid, preview.BestBidAmount)
+	fmt.Printf("Best ask:        %v (%v)\n", preview.BestAsk, preview.BestAskAmount)
+	if preview.Fill != nil {
+		fmt.Printf("Average price:   %v\n", preview.Fill.AveragePrice)
+		fmt.Printf("Slippage:        %.4f%%\n", preview.Fill.Slippage)
+	}
+	liquidity := "taker"
+	if preview.IsMaker {
+		liquidity = "maker"
+	}
+	fmt.Printf("Estimated fee:   %v %s (%s)\n", preview.Fee, quote, liquidity)
+	fmt.Printf("Estimated total: %v %s\n", preview.Total, quote)
+	for x := range preview.Warnings {
+		fmt.Printf("Warning: %s\n", preview.Warnings[x])
+	}
+	fmt.Println()
+}
+
+var getChaseCommand = cli.Command{
+	Name:      "getchase",
+	Usage:     "gets the status of a chased order",
+	ArgsUsage: "<id>",
+	Action:    getChase,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the chase id",
+		},
+	},
+}
+
+func getChase(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getchase")
+		return nil
+	}
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetChase(context.Background(), &gctrpc.GetChaseRequest{
+		Id: id,
 	})
 	if err != nil {
 		return err
@@ -1536,15 +2130,38 @@ func simulateOrder(c *cli.Context) error {
 	return nil
 }
 
-var whaleBombCommand = cli.Command{
-	Name:      "whalebomb",
-	Usage:     "whale bomb finds the amount required to reach a price target",
-	ArgsUsage: "<exchange> <pair> <side> <price>",
-	Action:    whaleBomb,
+var getChasesCommand = cli.Command{
+	Name:   "getchases",
+	Usage:  "gets the status of all chased orders",
+	Action: getChases,
+}
+
+func getChases(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetChases(context.Background(), &gctrpc.GetChasesRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var submitAlgoOrderCommand = cli.Command{
+	Name:      "submitalgoorder",
+	Usage:     "executes an order as timed TWAP slices or refreshing iceberg slices",
+	ArgsUsage: "<exchange> <pair> <side> <type> <amount> <price> <algo>",
+	Action:    submitAlgoOrder,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to whale bomb",
+			Usage: "the exchange to submit the order for",
 		},
 		cli.StringFlag{
 			Name:  "pair",
@@ -1554,24 +2171,61 @@ var whaleBombCommand = cli.Command{
 			Name:  "side",
 			Usage: "the order side to use (BUY OR SELL)",
 		},
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "the child order type (MARKET OR LIMIT)",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the total amount for the order",
+		},
 		cli.Float64Flag{
 			Name:  "price",
-			Usage: "the price target",
+			Usage: "the price for limit child orders",
+		},
+		cli.StringFlag{
+			Name:  "algo",
+			Usage: "the execution algo (TWAP OR ICEBERG)",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+			Value: "spot",
+		},
+		cli.Int64Flag{
+			Name:  "duration",
+			Usage: "the amount of seconds TWAP slices are spread over",
+		},
+		cli.Int64Flag{
+			Name:  "slices",
+			Usage: "the amount of TWAP slices",
+		},
+		cli.Float64Flag{
+			Name:  "slice_size",
+			Usage: "the visible amount of each iceberg slice",
+		},
+		cli.Float64Flag{
+			Name:  "jitter",
+			Usage: "the maximum percentage each slice amount is randomly varied by",
+		},
+		cli.Float64Flag{
+			Name:  "max_adverse_move",
+			Usage: "the maximum percentage the market can move against the order before the algo is stopped",
+		},
+		cli.Int64Flag{
+			Name:  "refresh_interval",
+			Usage: "the amount of seconds between iceberg slice fill checks",
 		},
 	},
 }
 
-func whaleBomb(c *cli.Context) error {
+func submitAlgoOrder(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "whalebomb")
+		cli.ShowCommandHelp(c, "submitalgoorder")
 		return nil
 	}
 
 	var exchangeName string
-	var currencyPair string
-	var orderSide string
-	var price float64
-
 	if c.IsSet("exchange") {
 		exchangeName = c.String("exchange")
 	} else {
@@ -1582,6 +2236,7 @@ func whaleBomb(c *cli.Context) error {
 		return errInvalidExchange
 	}
 
+	var currencyPair string
 	if c.IsSet("pair") {
 		currencyPair = c.String("pair")
 	} else {
@@ -1592,6 +2247,7 @@ func whaleBomb(c *cli.Context) error {
 		return errInvalidPair
 	}
 
+	var orderSide string
 	if c.IsSet("side") {
 		orderSide = c.String("side")
 	} else {
@@ -1599,19 +2255,62 @@ func whaleBomb(c *cli.Context) error {
 	}
 
 	if orderSide == "" {
-		return errors.New("order side must be set")
+		return errors.New("side must be set")
+	}
+
+	var orderType string
+	if c.IsSet("type") {
+		orderType = c.String("type")
+	} else {
+		orderType = c.Args().Get(3)
+	}
+
+	if orderType == "" {
+		return errors.New("type must be set")
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(4), 64)
+		if err != nil {
+			return err
+		}
 	}
 
+	if amount <= 0 {
+		return errors.New("amount must be set")
+	}
+
+	var price float64
 	if c.IsSet("price") {
 		price = c.Float64("price")
-	} else if c.Args().Get(3) != "" {
+	} else if c.Args().Get(5) != "" {
 		var err error
-		price, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		price, err = strconv.ParseFloat(c.Args().Get(5), 64)
 		if err != nil {
 			return err
 		}
 	}
 
+	var algoType string
+	if c.IsSet("algo") {
+		algoType = c.String("algo")
+	} else {
+		algoType = c.Args().Get(6)
+	}
+
+	if algoType == "" {
+		return errors.New("algo must be set")
+	}
+
+	assetType := strings.ToLower(c.String("asset"))
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
 	conn, err := setupClient()
 	if err != nil {
 		return err
@@ -1620,15 +2319,27 @@ func whaleBomb(c *cli.Context) error {
 
 	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.WhaleBomb(context.Background(), &gctrpc.WhaleBombRequest{
+	result, err := client.SubmitAlgoOrder(context.Background(), &gctrpc.SubmitAlgoOrderRequest{
 		Exchange: exchangeName,
 		Pair: &gctrpc.CurrencyPair{
 			Delimiter: p.Delimiter,
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
-		Side:        orderSide,
-		PriceTarget: price,
+		AssetType: assetType,
+		Side:      orderSide,
+		OrderType: orderType,
+		Amount:    amount,
+		Price:     price,
+		AlgoType:  algoType,
+		Options: &gctrpc.AlgoOptions{
+			DurationSeconds:        c.Int64("duration"),
+			Slices:                 c.Int64("slices"),
+			SliceSize:              c.Float64("slice_size"),
+			Jitter:                 c.Float64("jitter"),
+			MaxAdverseMove:         c.Float64("max_adverse_move"),
+			RefreshIntervalSeconds: c.Int64("refresh_interval"),
+		},
 	})
 	if err != nil {
 		return err
@@ -1638,119 +2349,97 @@ func whaleBomb(c *cli.Context) error {
 	return nil
 }
 
-var cancelOrderCommand = cli.Command{
-	Name:      "cancelorder",
-	Usage:     "cancel order cancels an exchange order",
-	ArgsUsage: "<exchange> <account_id> <order_id> <pair> <asset> <wallet_address> <side>",
-	Action:    cancelOrder,
+var getAlgoOrderCommand = cli.Command{
+	Name:      "getalgoorder",
+	Usage:     "gets the status of an execution algo",
+	ArgsUsage: "<id>",
+	Action:    getAlgoOrder,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to cancel the order for",
-		},
-		cli.StringFlag{
-			Name:  "account_id",
-			Usage: "the account id",
-		},
-		cli.StringFlag{
-			Name:  "order_id",
-			Usage: "the order id",
-		},
-		cli.StringFlag{
-			Name:  "pair",
-			Usage: "the currency pair to cancel the order for",
-		},
-		cli.StringFlag{
-			Name:  "asset",
-			Usage: "the asset type",
-		},
-		cli.Float64Flag{
-			Name:  "wallet_address",
-			Usage: "the wallet address",
-		},
-		cli.StringFlag{
-			Name:  "side",
-			Usage: "the order side",
+			Name:  "id",
+			Usage: "the execution algo id",
 		},
 	},
 }
 
-func cancelOrder(c *cli.Context) error {
+func getAlgoOrder(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "cancelorder")
+		cli.ShowCommandHelp(c, "getalgoorder")
 		return nil
 	}
 
-	var exchangeName string
-	var accountID string
-	var orderID string
-	var currencyPair string
-	var assetType string
-	var walletAddress string
-	var orderSide string
-
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
 	} else {
-		exchangeName = c.Args().First()
+		id = c.Args().First()
 	}
 
-	if !validExchange(exchangeName) {
-		return errInvalidExchange
+	conn, err := setupClient()
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
 
-	if c.IsSet("account_id") {
-		accountID = c.String("account_id")
-	} else {
-		accountID = c.Args().Get(1)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetAlgoOrder(context.Background(), &gctrpc.GetAlgoOrderRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
 	}
 
-	if c.IsSet("order_id") {
-		orderID = c.String("order_id")
-	} else {
-		orderID = c.Args().Get(2)
+	jsonOutput(result)
+	return nil
+}
+
+var getAlgoOrdersCommand = cli.Command{
+	Name:   "getalgoorders",
+	Usage:  "gets the status of all execution algos",
+	Action: getAlgoOrders,
+}
+
+func getAlgoOrders(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
 
-	if orderID == "" {
-		return errors.New("an order ID must be set")
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetAlgoOrders(context.Background(), &gctrpc.GetAlgoOrdersRequest{})
+	if err != nil {
+		return err
 	}
 
-	if c.IsSet("pair") {
-		currencyPair = c.String("pair")
-	} else {
-		currencyPair = c.Args().Get(3)
+	jsonOutput(result)
+	return nil
+}
+
+var cancelAlgoOrderCommand = cli.Command{
+	Name:      "cancelalgoorder",
+	Usage:     "stops an execution algo and cancels its working child order",
+	ArgsUsage: "<id>",
+	Action:    cancelAlgoOrder,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the execution algo id",
+		},
+	},
+}
+
+func cancelAlgoOrder(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "cancelalgoorder")
+		return nil
 	}
 
-	if c.IsSet("asset") {
-		assetType = c.String("asset")
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
 	} else {
-		assetType = c.Args().Get(4)
-	}
-
-	assetType = strings.ToLower(assetType)
-	if !validAsset(assetType) {
-		return errInvalidAsset
-	}
-
-	if c.IsSet("wallet_address") {
-		walletAddress = c.String("wallet_address")
-	} else {
-		walletAddress = c.Args().Get(5)
-	}
-
-	if c.IsSet("side") {
-		orderSide = c.String("side")
-	} else {
-		orderSide = c.Args().Get(6)
-	}
-
-	// pair is optional, but if it's set, do a validity check
-	var p currency.Pair
-	if len(currencyPair) > 0 {
-		if !validPair(currencyPair) {
-			return errInvalidPair
-		}
-		p = currency.NewPairDelimiter(currencyPair, pairDelimiter)
+		id = c.Args().First()
 	}
 
 	conn, err := setupClient()
@@ -1760,18 +2449,8 @@ func cancelOrder(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.CancelOrder(context.Background(), &gctrpc.CancelOrderRequest{
-		Exchange:  exchangeName,
-		AccountId: accountID,
-		OrderId:   orderID,
-		Pair: &gctrpc.CurrencyPair{
-			Delimiter: p.Delimiter,
-			Base:      p.Base.String(),
-			Quote:     p.Quote.String(),
-		},
-		AssetType:     assetType,
-		WalletAddress: walletAddress,
-		Side:          orderSide,
+	result, err := client.CancelAlgoOrder(context.Background(), &gctrpc.CancelAlgoOrderRequest{
+		Id: id,
 	})
 	if err != nil {
 		return err
@@ -1781,202 +2460,159 @@ func cancelOrder(c *cli.Context) error {
 	return nil
 }
 
-var cancelAllOrdersCommand = cli.Command{
-	Name:      "cancelallorders",
-	Usage:     "cancels all orders (all or by exchange name)",
-	ArgsUsage: "<exchange>",
-	Action:    cancelAllOrders,
+var addConditionalOrderCommand = cli.Command{
+	Name:      "addconditionalorder",
+	Usage:     "adds a stop-loss, take-profit or trailing stop order which is submitted once the ticker reaches its trigger price",
+	ArgsUsage: "<exchange> <pair> <side> <type> <amount> <condition> <trigger_price>",
+	Action:    addConditionalOrder,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to cancel all orders on",
+			Usage: "the exchange to submit the order for",
 		},
-	},
-}
-
-func cancelAllOrders(c *cli.Context) error {
-	var exchangeName string
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
-	} else {
-		exchangeName = c.Args().First()
-	}
-
-	// exchange name is an optional param
-	if exchangeName != "" {
-		if !validExchange(exchangeName) {
-			return errInvalidExchange
-		}
-	}
-
-	conn, err := setupClient()
-	if err != nil {
-		return err
-	}
-	defer conn.Close()
-
-	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.CancelAllOrders(context.Background(), &gctrpc.CancelAllOrdersRequest{
-		Exchange: exchangeName,
-	})
-	if err != nil {
-		return err
-	}
-
-	jsonOutput(result)
-	return nil
-}
-
-var getEventsCommand = cli.Command{
-	Name:   "getevents",
-	Usage:  "gets all events",
-	Action: getEvents,
-}
-
-func getEvents(_ *cli.Context) error {
-	conn, err := setupClient()
-	if err != nil {
-		return err
-	}
-	defer conn.Close()
-
-	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetEvents(context.Background(), &gctrpc.GetEventsRequest{})
-	if err != nil {
-		return err
-	}
-
-	jsonOutput(result)
-	return nil
-}
-
-var addEventCommand = cli.Command{
-	Name:      "addevent",
-	Usage:     "adds an event",
-	ArgsUsage: "<exchange> <item> <condition> <price> <check_bids> <check_bids_and_asks> <orderbook_amount> <pair> <asset> <action>",
-	Action:    addEvent,
-	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to add an event for",
+			Name:  "pair",
+			Usage: "the currency pair",
 		},
 		cli.StringFlag{
-			Name:  "item",
-			Usage: "the item to trigger the event",
+			Name:  "side",
+			Usage: "the order side to use (BUY OR SELL)",
+		},
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "the order type submitted when triggered (MARKET OR LIMIT)",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount for the order",
 		},
 		cli.StringFlag{
 			Name:  "condition",
-			Usage: "the condition for the event",
+			Usage: "the conditional order type (STOP_LOSS, TAKE_PROFIT OR TRAILING_STOP)",
 		},
 		cli.Float64Flag{
-			Name:  "price",
-			Usage: "the price to trigger the event",
+			Name:  "trigger_price",
+			Usage: "the ticker price which triggers the order, unused by trailing stops",
 		},
-		cli.BoolFlag{
-			Name:  "check_bids",
-			Usage: "whether to check the bids (if false, asks will be used)",
+		cli.Float64Flag{
+			Name:  "trail_offset",
+			Usage: "the retracement from the best price which triggers a trailing stop",
 		},
 		cli.BoolFlag{
-			Name:  "check_bids_and_asks",
-			Usage: "the wallet address",
+			Name:  "trail_percent",
+			Usage: "whether the trail offset is a percentage of the best price",
 		},
 		cli.Float64Flag{
-			Name:  "orderbook_amount",
-			Usage: "the orderbook amount to trigger the event",
-		},
-		cli.StringFlag{
-			Name:  "pair",
-			Usage: "the currency pair",
+			Name:  "price",
+			Usage: "the price for limit orders",
 		},
 		cli.StringFlag{
 			Name:  "asset",
-			Usage: "the asset type",
-		},
-		cli.StringFlag{
-			Name:  "action",
-			Usage: "the action for the event to perform upon trigger",
+			Usage: "the asset type of the currency pair",
+			Value: "spot",
 		},
 	},
 }
 
-func addEvent(c *cli.Context) error {
+func addConditionalOrder(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "addevent")
+		cli.ShowCommandHelp(c, "addconditionalorder")
 		return nil
 	}
 
 	var exchangeName string
-	var item string
-	var condition string
-	var price float64
-	var checkBids bool
-	var checkBidsAndAsks bool
-	var orderbookAmount float64
-	var currencyPair string
-	var assetType string
-	var action string
-
 	if c.IsSet("exchange") {
 		exchangeName = c.String("exchange")
 	} else {
-		return fmt.Errorf("exchange name is required")
+		exchangeName = c.Args().First()
 	}
 
 	if !validExchange(exchangeName) {
 		return errInvalidExchange
 	}
 
-	if c.IsSet("item") {
-		item = c.String("item")
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
 	} else {
-		return fmt.Errorf("item is required")
+		currencyPair = c.Args().Get(1)
 	}
 
-	if c.IsSet("condition") {
-		condition = c.String("condition")
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	var orderSide string
+	if c.IsSet("side") {
+		orderSide = c.String("side")
 	} else {
-		return fmt.Errorf("condition is required")
+		orderSide = c.Args().Get(2)
 	}
 
-	if c.IsSet("price") {
-		price = c.Float64("price")
+	if orderSide == "" {
+		return errors.New("side must be set")
 	}
 
-	if c.IsSet("check_bids") {
-		checkBids = c.Bool("check_bids")
+	var orderType string
+	if c.IsSet("type") {
+		orderType = c.String("type")
+	} else {
+		orderType = c.Args().Get(3)
 	}
 
-	if c.IsSet("check_bids_and_asks") {
-		checkBids = c.Bool("check_bids_and_asks")
+	if orderType == "" {
+		return errors.New("type must be set")
 	}
 
-	if c.IsSet("orderbook_amount") {
-		orderbookAmount = c.Float64("orderbook_amount")
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(4), 64)
+		if err != nil {
+			return err
+		}
 	}
 
-	if c.IsSet("pair") {
-		currencyPair = c.String("pair")
+	if amount <= 0 {
+		return errors.New("amount must be set")
+	}
+
+	var condition string
+	if c.IsSet("condition") {
+		condition = c.String("condition")
 	} else {
-		return fmt.Errorf("currency pair is required")
+		condition = c.Args().Get(5)
 	}
 
-	if !validPair(currencyPair) {
-		return errInvalidPair
+	if condition == "" {
+		return errors.New("condition must be set")
 	}
 
-	if c.IsSet("asset") {
-		assetType = c.String("asset")
+	var triggerPrice float64
+	if c.IsSet("trigger_price") {
+		triggerPrice = c.Float64("trigger_price")
+	} else if c.Args().Get(6) != "" {
+		var err error
+		triggerPrice, err = strconv.ParseFloat(c.Args().Get(6), 64)
+		if err != nil {
+			return err
+		}
 	}
 
-	assetType = strings.ToLower(assetType)
-	if !validAsset(assetType) {
-		return errInvalidAsset
+	trailing := strings.EqualFold(condition, "TRAILING_STOP")
+	if trailing && c.Float64("trail_offset") <= 0 {
+		return errors.New("trail offset must be set")
 	}
 
-	if c.IsSet("action") {
-		action = c.String("action")
-	} else {
-		return fmt.Errorf("action is required")
+	if !trailing && triggerPrice <= 0 {
+		return errors.New("trigger price must be set")
+	}
+
+	assetType := strings.ToLower(c.String("asset"))
+	if !validAsset(assetType) {
+		return errInvalidAsset
 	}
 
 	conn, err := setupClient()
@@ -1987,23 +2623,22 @@ func addEvent(c *cli.Context) error {
 
 	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.AddEvent(context.Background(), &gctrpc.AddEventRequest{
+	result, err := client.AddConditionalOrder(context.Background(), &gctrpc.AddConditionalOrderRequest{
 		Exchange: exchangeName,
-		Item:     item,
-		ConditionParams: &gctrpc.ConditionParams{
-			Condition:        condition,
-			Price:            price,
-			CheckBids:        checkBids,
-			CheckBidsAndAsks: checkBidsAndAsks,
-			OrderbookAmount:  orderbookAmount,
-		},
 		Pair: &gctrpc.CurrencyPair{
 			Delimiter: p.Delimiter,
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
-		AssetType: assetType,
-		Action:    action,
+		AssetType:       assetType,
+		Side:            orderSide,
+		OrderType:       orderType,
+		Amount:          amount,
+		Price:           c.Float64("price"),
+		ConditionalType: condition,
+		TriggerPrice:    triggerPrice,
+		TrailOffset:     c.Float64("trail_offset"),
+		TrailPercent:    c.Bool("trail_percent"),
 	})
 	if err != nil {
 		return err
@@ -2013,38 +2648,53 @@ func addEvent(c *cli.Context) error {
 	return nil
 }
 
-var removeEventCommand = cli.Command{
-	Name:      "removeevent",
-	Usage:     "removes an event",
-	ArgsUsage: "<event_id>",
-	Action:    removeEvent,
-	Flags: []cli.Flag{
-		cli.StringFlag{
-			Name:  "event_id",
-			Usage: "the event id to remove",
-		},
-	},
+var getConditionalOrdersCommand = cli.Command{
+	Name:   "getconditionalorders",
+	Usage:  "gets all stop-loss and take-profit conditional orders",
+	Action: getConditionalOrders,
 }
 
-func removeEvent(c *cli.Context) error {
-	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "removeevent")
-		return nil
+func getConditionalOrders(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
 
-	var eventID int64
-	if c.IsSet("event_id") {
-		eventID = c.Int64("event_id")
-	} else if c.Args().Get(0) != "" {
-		var err error
-		eventID, err = strconv.ParseInt(c.Args().Get(0), 10, 64)
-		if err != nil {
-			return err
-		}
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetConditionalOrders(context.Background(), &gctrpc.GetConditionalOrdersRequest{})
+	if err != nil {
+		return err
 	}
 
-	if eventID == 0 {
-		return errors.New("event id must be specified")
+	jsonOutput(result)
+	return nil
+}
+
+var cancelConditionalOrderCommand = cli.Command{
+	Name:      "cancelconditionalorder",
+	Usage:     "cancels a pending conditional order",
+	ArgsUsage: "<id>",
+	Action:    cancelConditionalOrder,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the conditional order id",
+		},
+	},
+}
+
+func cancelConditionalOrder(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "cancelconditionalorder")
+		return nil
+	}
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
 	}
 
 	conn, err := setupClient()
@@ -2054,8 +2704,9 @@ func removeEvent(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.RemoveEvent(context.Background(),
-		&gctrpc.RemoveEventRequest{Id: eventID})
+	result, err := client.CancelConditionalOrder(context.Background(), &gctrpc.CancelConditionalOrderRequest{
+		Id: id,
+	})
 	if err != nil {
 		return err
 	}
@@ -2064,36 +2715,59 @@ func removeEvent(c *cli.Context) error {
 	return nil
 }
 
-var getCryptocurrencyDepositAddressesCommand = cli.Command{
-	Name:      "getcryptocurrencydepositaddresses",
-	Usage:     "gets the cryptocurrency deposit addresses for an exchange",
-	ArgsUsage: "<exchange>",
-	Action:    getCryptocurrencyDepositAddresses,
-	Flags: []cli.Flag{
-		cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to get the cryptocurrency deposit addresses for",
-		},
-	},
+var getAutomationsCommand = cli.Command{
+	Name:   "getautomations",
+	Usage:  "gets all declarative automations and their state",
+	Action: getAutomations,
 }
 
-func getCryptocurrencyDepositAddresses(c *cli.Context) error {
-	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getcryptocurrencydepositaddresses")
-		return nil
+func getAutomations(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
 
-	var exchangeName string
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
-	} else {
-		exchangeName = c.Args().First()
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetAutomations(context.Background(), &gctrpc.GetAutomationsRequest{})
+	if err != nil {
+		return err
 	}
 
-	if !validExchange(exchangeName) {
-		return errInvalidExchange
+	jsonOutput(result)
+	return nil
+}
+
+var reloadAutomationsCommand = cli.Command{
+	Name:   "reloadautomations",
+	Usage:  "reloads the declarative automations from their YAML file",
+	Action: reloadAutomations,
+}
+
+func reloadAutomations(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ReloadAutomations(context.Background(), &gctrpc.ReloadAutomationsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getStrategiesCommand = cli.Command{
+	Name:   "getstrategies",
+	Usage:  "gets the configured strategies, their state and the strategies available to run",
+	Action: getStrategies,
+}
 
+func getStrategies(_ *cli.Context) error {
 	conn, err := setupClient()
 	if err != nil {
 		return err
@@ -2101,8 +2775,7 @@ func getCryptocurrencyDepositAddresses(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetCryptocurrencyDepositAddresses(context.Background(),
-		&gctrpc.GetCryptocurrencyDepositAddressesRequest{Exchange: exchangeName})
+	result, err := client.GetStrategies(context.Background(), &gctrpc.GetStrategiesRequest{})
 	if err != nil {
 		return err
 	}
@@ -2111,50 +2784,51 @@ func getCryptocurrencyDepositAddresses(c *cli.Context) error {
 	return nil
 }
 
-var getCryptocurrencyDepositAddressCommand = cli.Command{
-	Name:      "getcryptocurrencydepositaddress",
-	Usage:     "gets the cryptocurrency deposit address for an exchange and cryptocurrency",
-	ArgsUsage: "<exchange> <cryptocurrency>",
-	Action:    getCryptocurrencyDepositAddress,
+var startStrategyCommand = cli.Command{
+	Name:      "startstrategy",
+	Usage:     "initialises and starts a configured strategy",
+	ArgsUsage: "<name>",
+	Action:    startStrategy,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to get the cryptocurrency deposit address for",
+			Name:  "name",
+			Usage: "the configured name of the strategy",
 		},
+	},
+}
+
+func startStrategy(c *cli.Context) error {
+	return strategyCommand(c, "startstrategy", true)
+}
+
+var stopStrategyCommand = cli.Command{
+	Name:      "stopstrategy",
+	Usage:     "stops a running strategy",
+	ArgsUsage: "<name>",
+	Action:    stopStrategy,
+	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "cryptocurrency",
-			Usage: "the cryptocurrency to get the deposit address for",
+			Name:  "name",
+			Usage: "the configured name of the strategy",
 		},
 	},
 }
 
-func getCryptocurrencyDepositAddress(c *cli.Context) error {
+func stopStrategy(c *cli.Context) error {
+	return strategyCommand(c, "stopstrategy", false)
+}
+
+func strategyCommand(c *cli.Context, command string, start bool) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getcryptocurrencydepositaddresses")
+		cli.ShowCommandHelp(c, command)
 		return nil
 	}
 
-	var exchangeName string
-	var cryptocurrency string
-
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
+	var name string
+	if c.IsSet("name") {
+		name = c.String("name")
 	} else {
-		exchangeName = c.Args().First()
-	}
-
-	if !validExchange(exchangeName) {
-		return errInvalidExchange
-	}
-
-	if c.IsSet("cryptocurrency") {
-		cryptocurrency = c.String("cryptocurrency")
-	} else if c.Args().Get(1) != "" {
-		cryptocurrency = c.Args().Get(1)
-	}
-
-	if cryptocurrency == "" {
-		return errors.New("cryptocurrency must be set")
+		name = c.Args().First()
 	}
 
 	conn, err := setupClient()
@@ -2164,12 +2838,13 @@ func getCryptocurrencyDepositAddress(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetCryptocurrencyDepositAddress(context.Background(),
-		&gctrpc.GetCryptocurrencyDepositAddressRequest{
-			Exchange:       exchangeName,
-			Cryptocurrency: cryptocurrency,
-		},
-	)
+	req := &gctrpc.StrategyRequest{Name: name}
+	var result *gctrpc.StrategyDetails
+	if start {
+		result, err = client.StartStrategy(context.Background(), req)
+	} else {
+		result, err = client.StopStrategy(context.Background(), req)
+	}
 	if err != nil {
 		return err
 	}
@@ -2178,102 +2853,132 @@ func getCryptocurrencyDepositAddress(c *cli.Context) error {
 	return nil
 }
 
-var withdrawCryptocurrencyFundsCommand = cli.Command{
-	Name:      "withdrawcryptofunds",
-	Usage:     "withdraws cryptocurrency funds from the desired exchange",
-	ArgsUsage: "<exchange> <currency>  <amount> <address> <addresstag> <fee> <description>",
-	Action:    withdrawCryptocurrencyFunds,
+var submitOCOCommand = cli.Command{
+	Name:      "submitoco",
+	Usage:     "submits two orders which are linked so that when one executes the other is cancelled",
+	ArgsUsage: "<exchange> <pair> <side> <amount>",
+	Action:    submitOCO,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to withdraw from",
+			Usage: "the exchange to submit the orders for",
 		},
 		cli.StringFlag{
-			Name:  "currency",
-			Usage: "the cryptocurrency to withdraw funds from",
+			Name:  "pair",
+			Usage: "the currency pair",
 		},
 		cli.StringFlag{
-			Name:  "address",
-			Usage: "address to withdraw to",
+			Name:  "side",
+			Usage: "the order side of both orders (BUY OR SELL)",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount of both orders",
 		},
 		cli.StringFlag{
-			Name:  "addresstag",
-			Usage: "address tag/memo",
+			Name:  "first_type",
+			Usage: "the first order type (MARKET OR LIMIT)",
+			Value: "LIMIT",
 		},
 		cli.Float64Flag{
-			Name:  "amount",
-			Usage: "amount of funds to withdraw",
+			Name:  "first_price",
+			Usage: "the first order price for limit orders",
+		},
+		cli.StringFlag{
+			Name:  "first_condition",
+			Usage: "holds the first order until its trigger price is reached (STOP_LOSS OR TAKE_PROFIT), it rests on the order book if not set",
 		},
 		cli.Float64Flag{
-			Name:  "fee",
-			Usage: "fee to submit with request",
+			Name:  "first_trigger_price",
+			Usage: "the ticker price which triggers the first order",
 		},
 		cli.StringFlag{
-			Name:  "description",
-			Usage: "description to submit with request",
+			Name:  "second_type",
+			Usage: "the second order type (MARKET OR LIMIT)",
+			Value: "MARKET",
+		},
+		cli.Float64Flag{
+			Name:  "second_price",
+			Usage: "the second order price for limit orders",
+		},
+		cli.StringFlag{
+			Name:  "second_condition",
+			Usage: "holds the second order until its trigger price is reached (STOP_LOSS OR TAKE_PROFIT), it rests on the order book if not set",
+			Value: "STOP_LOSS",
+		},
+		cli.Float64Flag{
+			Name:  "second_trigger_price",
+			Usage: "the ticker price which triggers the second order",
+		},
+		cli.Int64Flag{
+			Name:  "refresh_interval",
+			Usage: "how often in seconds the orders are checked when the exchange does not support oco orders natively",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+			Value: "spot",
 		},
 	},
 }
 
-func withdrawCryptocurrencyFunds(c *cli.Context) error {
+func submitOCO(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "withdrawcryptofunds")
+		cli.ShowCommandHelp(c, "submitoco")
 		return nil
 	}
 
-	var exchange, cur, address, addressTag, description string
-	var amount, fee float64
-
+	var exchangeName string
 	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else if c.Args().Get(0) != "" {
-		exchange = c.Args().Get(0)
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
 	}
 
-	if !validExchange(exchange) {
+	if !validExchange(exchangeName) {
 		return errInvalidExchange
 	}
 
-	if c.IsSet("currency") {
-		cur = c.String("currency")
-	} else if c.Args().Get(1) != "" {
-		cur = c.Args().Get(1)
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
 	}
 
-	if c.IsSet("amount") {
-		amount = c.Float64("amount")
-	} else if c.Args().Get(2) != "" {
-		amountStr, err := strconv.ParseFloat(c.Args().Get(2), 64)
-		if err == nil {
-			amount = amountStr
-		}
+	if !validPair(currencyPair) {
+		return errInvalidPair
 	}
 
-	if c.IsSet("address") {
-		address = c.String("address")
-	} else if c.Args().Get(3) != "" {
-		address = c.Args().Get(3)
+	var orderSide string
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(2)
 	}
 
-	if c.IsSet("addresstag") {
-		addressTag = c.String("addresstag")
-	} else if c.Args().Get(4) != "" {
-		addressTag = c.Args().Get(4)
+	if orderSide == "" {
+		return errors.New("side must be set")
 	}
 
-	if c.IsSet("fee") {
-		fee = c.Float64("fee")
-	} else if c.Args().Get(5) != "" {
-		feeStr, err := strconv.ParseFloat(c.Args().Get(5), 64)
-		if err == nil {
-			fee = feeStr
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
 		}
 	}
 
-	if c.IsSet("description") {
-		description = c.String("description")
-	} else if c.Args().Get(6) != "" {
-		description = c.Args().Get(6)
+	if amount <= 0 {
+		return errors.New("amount must be set")
+	}
+
+	assetType := strings.ToLower(c.String("asset"))
+	if !validAsset(assetType) {
+		return errInvalidAsset
 	}
 
 	conn, err := setupClient()
@@ -2282,99 +2987,206 @@ func withdrawCryptocurrencyFunds(c *cli.Context) error {
 	}
 	defer conn.Close()
 
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	var legs []*gctrpc.OCOLeg
+	for _, leg := range []string{"first", "second"} {
+		legs = append(legs, &gctrpc.OCOLeg{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:       assetType,
+			Side:            orderSide,
+			OrderType:       c.String(leg + "_type"),
+			Amount:          amount,
+			Price:           c.Float64(leg + "_price"),
+			ConditionalType: c.String(leg + "_condition"),
+			TriggerPrice:    c.Float64(leg + "_trigger_price"),
+		})
+	}
+
 	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.SubmitOCO(context.Background(), &gctrpc.SubmitOCORequest{
+		Legs:                   legs,
+		RefreshIntervalSeconds: c.Int64("refresh_interval"),
+	})
+	if err != nil {
+		return err
+	}
 
-	result, err := client.WithdrawCryptocurrencyFunds(context.Background(),
-		&gctrpc.WithdrawCryptoRequest{
-			Exchange:    exchange,
-			Currency:    cur,
-			Address:     address,
-			AddressTag:  addressTag,
-			Amount:      amount,
-			Fee:         fee,
-			Description: description,
+	jsonOutput(result)
+	return nil
+}
+
+var getOCOCommand = cli.Command{
+	Name:      "getoco",
+	Usage:     "gets the status of an oco order",
+	ArgsUsage: "<id>",
+	Action:    getOCO,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the oco order id",
 		},
-	)
+	},
+}
+
+func getOCO(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getoco")
+		return nil
+	}
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetOCO(context.Background(), &gctrpc.GetOCORequest{
+		Id: id,
+	})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var withdrawFiatFundsCommand = cli.Command{
-	Name:      "withdrawfiatfunds",
-	Usage:     "withdraws fiat funds from the desired exchange",
-	ArgsUsage: "<exchange> <currency> <amount> <bankaccount id> <description>",
-	Action:    withdrawFiatFunds,
+var getOCOsCommand = cli.Command{
+	Name:   "getocos",
+	Usage:  "gets the status of all oco orders",
+	Action: getOCOs,
+}
+
+func getOCOs(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetOCOs(context.Background(), &gctrpc.GetOCOsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var cancelOCOCommand = cli.Command{
+	Name:      "canceloco",
+	Usage:     "cancels both orders of an active oco order",
+	ArgsUsage: "<id>",
+	Action:    cancelOCO,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to withdraw from",
+			Name:  "id",
+			Usage: "the oco order id",
 		},
+	},
+}
+
+func cancelOCO(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "canceloco")
+		return nil
+	}
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.CancelOCO(context.Background(), &gctrpc.CancelOCORequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var submitIntentCommand = cli.Command{
+	Name:      "submitintent",
+	Usage:     "submits a multi-leg intent which is unwound if any leg fails",
+	ArgsUsage: "<description> <unwind_rule> <leg> <leg>...",
+	Action:    submitIntent,
+	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "currency",
-			Usage: "the fiat currency to withdraw funds from",
-		},
-		cli.Float64Flag{
-			Name:  "amount",
-			Usage: "amount of funds to withdraw",
+			Name:  "description",
+			Usage: "an optional description for the intent",
 		},
 		cli.StringFlag{
-			Name:  "bankaccountid",
-			Usage: "ID of bank account to use",
+			Name:  "unwind_rule",
+			Usage: "the action to take on placed legs if a leg fails (NONE, CANCEL or REVERSE)",
+			Value: "CANCEL",
 		},
-		cli.StringFlag{
-			Name:  "description",
-			Usage: "description to submit with request",
+		cli.StringSliceFlag{
+			Name:  "leg",
+			Usage: "an intent leg in the format exchange,pair,side,type,amount,price (repeatable)",
 		},
 	},
 }
 
-func withdrawFiatFunds(c *cli.Context) error {
+func submitIntent(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "withdrawfiatfunds")
+		cli.ShowCommandHelp(c, "submitintent")
 		return nil
 	}
 
-	var exchange, cur, description, bankAccountID string
-	var amount float64
-
-	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else if c.Args().Get(0) != "" {
-		exchange = c.Args().Get(0)
-	}
-
-	if !validExchange(exchange) {
-		return errInvalidExchange
+	var description string
+	if c.IsSet("description") {
+		description = c.String("description")
+	} else {
+		description = c.Args().First()
 	}
 
-	if c.IsSet("currency") {
-		cur = c.String("currency")
-	} else if c.Args().Get(1) != "" {
-		cur = c.Args().Get(1)
+	unwindRule := c.String("unwind_rule")
+	if !c.IsSet("unwind_rule") && c.Args().Get(1) != "" {
+		unwindRule = c.Args().Get(1)
 	}
 
-	if c.IsSet("amount") {
-		amount = c.Float64("amount")
-	} else if c.Args().Get(2) != "" {
-		amountStr, err := strconv.ParseFloat(c.Args().Get(2), 64)
-		if err == nil {
-			amount = amountStr
-		}
+	legs := c.StringSlice("leg")
+	if len(legs) == 0 && c.NArg() > 2 {
+		legs = c.Args()[2:]
 	}
 
-	if c.IsSet("bankaccountid") {
-		bankAccountID = c.String("bankaccountid")
-	} else if c.Args().Get(3) != "" {
-		bankAccountID = c.Args().Get(3)
+	if len(legs) < 2 {
+		return errors.New("at least two legs must be set")
 	}
 
-	if c.IsSet("description") {
-		description = c.String("description")
-	} else if c.Args().Get(4) != "" {
-		description = c.Args().Get(4)
+	var rpcLegs []*gctrpc.IntentLeg
+	for x := range legs {
+		leg, err := parseIntentLeg(legs[x])
+		if err != nil {
+			return fmt.Errorf("leg %d: %v", x, err)
+		}
+		rpcLegs = append(rpcLegs, leg)
 	}
 
 	conn, err := setupClient()
@@ -2384,178 +3196,3091 @@ func withdrawFiatFunds(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.WithdrawFiatFunds(context.Background(),
-		&gctrpc.WithdrawFiatRequest{
-			Exchange:      exchange,
-			Currency:      cur,
-			Amount:        amount,
-			Description:   description,
-			BankAccountId: bankAccountID,
-		},
-	)
+	result, err := client.SubmitIntent(context.Background(), &gctrpc.SubmitIntentRequest{
+		Description: description,
+		UnwindRule:  unwindRule,
+		Legs:        rpcLegs,
+	})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var withdrawalRequestCommand = cli.Command{
-	Name:      "withdrawalrequesthistory",
-	Usage:     "retrieve previous withdrawal request details",
-	ArgsUsage: "<type> <args>",
-	Subcommands: []cli.Command{
-		{
-			Name:      "byid",
-			Usage:     "id",
-			ArgsUsage: "<id>",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:  "id",
-					Usage: "<id>",
-				},
-			},
-			Action: withdrawlRequestByID,
-		},
-		{
-			Name:      "byexchangeid",
-			Usage:     "exchange id",
-			ArgsUsage: "<id>",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:  "exchange",
-					Usage: "<exchange>",
-				},
-				cli.StringFlag{
-					Name:  "id",
-					Usage: "<id>",
-				},
-			},
-			Action: withdrawlRequestByExchangeID,
-		},
-		{
-			Name:      "byexchange",
-			Usage:     "exchange limit",
-			ArgsUsage: "<id>",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:  "exchange",
-					Usage: "<exchange>",
-				},
-				cli.Int64Flag{
-					Name:  "limit",
-					Usage: "<limit>",
-				},
-			},
-			Action: withdrawlRequestByExchangeID,
+func parseIntentLeg(leg string) (*gctrpc.IntentLeg, error) {
+	params := strings.Split(leg, ",")
+	if len(params) != 6 {
+		return nil, errors.New("leg must be in the format exchange,pair,side,type,amount,price")
+	}
+
+	if !validExchange(params[0]) {
+		return nil, errInvalidExchange
+	}
+
+	if !validPair(params[1]) {
+		return nil, errInvalidPair
+	}
+
+	amount, err := strconv.ParseFloat(params[4], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := strconv.ParseFloat(params[5], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	p := currency.NewPairDelimiter(params[1], pairDelimiter)
+	return &gctrpc.IntentLeg{
+		Exchange: params[0],
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
 		},
-		{
-			Name:      "bydate",
-			Usage:     "exchange start end limit",
-			ArgsUsage: "<exchange> <start> <end> <limit>",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:  "exchange",
-					Usage: "<exchange>",
-				},
-				cli.StringFlag{
-					Name:        "start",
-					Usage:       "<start>",
-					Value:       time.Now().AddDate(0, -1, 0).Format(common.SimpleTimeFormat),
-					Destination: &startTime,
-				},
-				cli.StringFlag{
-					Name:        "end",
-					Usage:       "<end>",
-					Value:       time.Now().Format(common.SimpleTimeFormat),
-					Destination: &endTime,
-				},
-				cli.Int64Flag{
-					Name:  "limit",
-					Usage: "<limit>",
-				},
-			},
-			Action: withdrawlRequestByDate,
+		Side:      params[2],
+		OrderType: params[3],
+		Amount:    amount,
+		Price:     price,
+	}, nil
+}
+
+var getIntentCommand = cli.Command{
+	Name:      "getintent",
+	Usage:     "gets the leg and intent level status of an intent",
+	ArgsUsage: "<id>",
+	Action:    getIntent,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the intent id",
 		},
 	},
 }
 
-func withdrawlRequestByID(c *cli.Context) error {
+func getIntent(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowSubcommandHelp(c)
+		cli.ShowCommandHelp(c, "getintent")
 		return nil
 	}
 
-	var ID string
+	var id string
 	if c.IsSet("id") {
-		ID = c.String("id")
+		id = c.String("id")
 	} else {
-		ID = c.Args().First()
+		id = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetIntent(context.Background(), &gctrpc.GetIntentRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getIntentsCommand = cli.Command{
+	Name:   "getintents",
+	Usage:  "gets the leg and intent level status of all intents",
+	Action: getIntents,
+}
+
+func getIntents(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetIntents(context.Background(), &gctrpc.GetIntentsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getTriangularArbitrageCommand = cli.Command{
+	Name:      "gettriangulararbitrage",
+	Usage:     "gets the triangular arbitrage cycles of the most recent scan sorted by implied profit",
+	ArgsUsage: "<exchange>",
+	Action:    getTriangularArbitrage,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the cycles of, all exchanges when not set",
+		},
+		cli.BoolFlag{
+			Name:  "opportunities_only",
+			Usage: "only returns the cycles with an implied profit above the configured minimum",
+		},
+	},
+}
+
+func getTriangularArbitrage(c *cli.Context) error {
+	exchangeName := c.String("exchange")
+	if exchangeName == "" {
+		exchangeName = c.Args().First()
+	}
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetTriangularArbitrage(context.Background(),
+		&gctrpc.GetTriangularArbitrageRequest{
+			Exchange:          exchangeName,
+			OpportunitiesOnly: c.Bool("opportunities_only"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var executeTriangularArbitrageCommand = cli.Command{
+	Name:      "executetriangulararbitrage",
+	Usage:     "submits the legs of a triangular arbitrage cycle as market orders through an intent",
+	ArgsUsage: "<exchange> <cycle> <amount>",
+	Action:    executeTriangularArbitrage,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange of the cycle",
+		},
+		cli.StringFlag{
+			Name:  "cycle",
+			Usage: "the currencies of the cycle in order, such as BTC-ETH-USD-BTC",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount of the start currency to trade, defaults to its configured trade amount",
+		},
+	},
+}
+
+func executeTriangularArbitrage(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "executetriangulararbitrage")
+		return nil
+	}
+
+	exchangeName := c.String("exchange")
+	if exchangeName == "" {
+		exchangeName = c.Args().First()
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	cycle := c.String("cycle")
+	if cycle == "" {
+		cycle = c.Args().Get(1)
+	}
+	if cycle == "" {
+		return errors.New("cycle must be specified")
+	}
+
+	amount := c.Float64("amount")
+	if !c.IsSet("amount") && c.Args().Get(2) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(2), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ExecuteTriangularArbitrage(context.Background(),
+		&gctrpc.ExecuteTriangularArbitrageRequest{
+			Exchange: exchangeName,
+			Cycle:    cycle,
+			Amount:   amount,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var addStrategyOrderCommand = cli.Command{
+	Name:      "addstrategyorder",
+	Usage:     "registers the amount a strategy intends to trade so fills on a shared account can be allocated to it",
+	ArgsUsage: "<strategy> <exchange> <pair> <side> <amount>",
+	Action:    addStrategyOrder,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "strategy",
+			Usage: "the strategy name",
+		},
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange the strategy trades on",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side (BUY or SELL)",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount the strategy intends to trade",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the optional asset type, defaults to spot",
+		},
+	},
+}
+
+func addStrategyOrder(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "addstrategyorder")
+		return nil
+	}
+
+	var strategy string
+	var exchangeName string
+	var currencyPair string
+	var orderSide string
+	var amount float64
+
+	if c.IsSet("strategy") {
+		strategy = c.String("strategy")
+	} else {
+		strategy = c.Args().First()
+	}
+
+	if strategy == "" {
+		return errors.New("strategy must be set")
+	}
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().Get(1)
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(2)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(3)
+	}
+
+	if orderSide == "" {
+		return errors.New("order side must be set")
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(4), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if amount == 0 {
+		return errors.New("amount must be set")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.AddStrategyOrder(context.Background(), &gctrpc.AddStrategyOrderRequest{
+		Strategy: strategy,
+		Exchange: exchangeName,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		AssetType: c.String("asset"),
+		Side:      orderSide,
+		Amount:    amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var removeStrategyOrderCommand = cli.Command{
+	Name:      "removestrategyorder",
+	Usage:     "stops any further fills being allocated to a strategy order",
+	ArgsUsage: "<id>",
+	Action:    removeStrategyOrder,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the strategy order id",
+		},
+	},
+}
+
+func removeStrategyOrder(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "removestrategyorder")
+		return nil
+	}
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.RemoveStrategyOrder(context.Background(), &gctrpc.RemoveStrategyOrderRequest{
+		Id: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var allocateFillCommand = cli.Command{
+	Name:      "allocatefill",
+	Usage:     "allocates a fill on a shared account between the strategies trading the same pair and side",
+	ArgsUsage: "<exchange> <pair> <side> <amount> <price>",
+	Action:    allocateFill,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange the fill occurred on",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side (BUY or SELL)",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the filled amount",
+		},
+		cli.Float64Flag{
+			Name:  "price",
+			Usage: "the fill price",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the optional asset type, defaults to spot",
+		},
+		cli.StringFlag{
+			Name:  "rule",
+			Usage: "the allocation rule (PRO_RATA or FIFO), defaults to PRO_RATA",
+		},
+	},
+}
+
+func allocateFill(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "allocatefill")
+		return nil
+	}
+
+	var exchangeName string
+	var currencyPair string
+	var orderSide string
+	var amount float64
+	var price float64
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(2)
+	}
+
+	if orderSide == "" {
+		return errors.New("order side must be set")
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if amount == 0 {
+		return errors.New("amount must be set")
+	}
+
+	if c.IsSet("price") {
+		price = c.Float64("price")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		price, err = strconv.ParseFloat(c.Args().Get(4), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if price == 0 {
+		return errors.New("price must be set")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.AllocateFill(context.Background(), &gctrpc.AllocateFillRequest{
+		Exchange: exchangeName,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		AssetType: c.String("asset"),
+		Side:      orderSide,
+		Amount:    amount,
+		Price:     price,
+		Rule:      c.String("rule"),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getStrategyPositionsCommand = cli.Command{
+	Name:      "getstrategypositions",
+	Usage:     "gets each strategy's allocated positions and realised profit and loss",
+	ArgsUsage: "<strategy>",
+	Action:    getStrategyPositions,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "strategy",
+			Usage: "the optional strategy to filter by",
+		},
+	},
+}
+
+func getStrategyPositions(c *cli.Context) error {
+	var strategy string
+	if c.IsSet("strategy") {
+		strategy = c.String("strategy")
+	} else {
+		strategy = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetStrategyPositions(context.Background(), &gctrpc.GetStrategyPositionsRequest{
+		Strategy: strategy,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getPositionsCommand = cli.Command{
+	Name:      "getpositions",
+	Usage:     "gets the account's positions with average entry price and realised and unrealised profit and loss",
+	ArgsUsage: "<exchange> <pair>",
+	Action:    getPositions,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the optional exchange to filter by",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the optional currency pair to filter by",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the optional asset type to filter by",
+		},
+		cli.StringFlag{
+			Name:  "currency",
+			Usage: "the optional currency, such as BTC, to value profit and loss in instead of the quote currency",
+		},
+	},
+}
+
+func getPositions(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	var pair *gctrpc.CurrencyPair
+	if currencyPair != "" {
+		if !validPair(currencyPair) {
+			return errInvalidPair
+		}
+		p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+		pair = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		}
+	}
+
+	assetType := strings.ToLower(c.String("asset"))
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetPositions(context.Background(), &gctrpc.GetPositionsRequest{
+		Exchange:  exchangeName,
+		Pair:      pair,
+		AssetType: assetType,
+		Currency:  c.String("currency"),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var syncTradeHistoryCommand = cli.Command{
+	Name:      "synctradehistory",
+	Usage:     "pages through the account's trades of a pair from where the previous sync finished, backfilling the complete history on the first sync",
+	ArgsUsage: "<exchange> <pair> <asset>",
+	Action:    syncTradeHistory,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to sync the trade history of",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to sync the trade history of",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair, defaults to spot",
+		},
+	},
+}
+
+func syncTradeHistory(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "synctradehistory")
+		return nil
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+
+	assetType = strings.ToLower(assetType)
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.SyncTradeHistory(context.Background(),
+		&gctrpc.SyncTradeHistoryRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType: assetType,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var simulateOrderCommand = cli.Command{
+	Name:      "simulateorder",
+	Usage:     "simulate order simulates an exchange order",
+	ArgsUsage: "<exchange> <pair> <side> <amount>",
+	Action:    simulateOrder,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to simulate the order for",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side to use (BUY OR SELL)",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount for the order",
+		},
+	},
+}
+
+func simulateOrder(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "simulateorder")
+		return nil
+	}
+
+	var exchangeName string
+	var currencyPair string
+	var orderSide string
+	var amount float64
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(2)
+	}
+
+	if orderSide == "" {
+		return errors.New("side must be set")
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if amount == 0 {
+		return errors.New("amount must be set")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.SimulateOrder(context.Background(), &gctrpc.SimulateOrderRequest{
+		Exchange: exchangeName,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		Side:   orderSide,
+		Amount: amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var whaleBombCommand = cli.Command{
+	Name:      "whalebomb",
+	Usage:     "whale bomb finds the amount required to reach a price target",
+	ArgsUsage: "<exchange> <pair> <side> <price>",
+	Action:    whaleBomb,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to whale bomb",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side to use (BUY OR SELL)",
+		},
+		cli.Float64Flag{
+			Name:  "price",
+			Usage: "the price target",
+		},
+	},
+}
+
+func whaleBomb(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "whalebomb")
+		return nil
+	}
+
+	var exchangeName string
+	var currencyPair string
+	var orderSide string
+	var price float64
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(2)
+	}
+
+	if orderSide == "" {
+		return errors.New("order side must be set")
+	}
+
+	if c.IsSet("price") {
+		price = c.Float64("price")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		price, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.WhaleBomb(context.Background(), &gctrpc.WhaleBombRequest{
+		Exchange: exchangeName,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		Side:        orderSide,
+		PriceTarget: price,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var simulatePortfolioImpactCommand = cli.Command{
+	Name:      "simulateportfolioimpact",
+	Usage:     "simulates the impact of an order on portfolio exposure, margin usage and risk limits without placing it",
+	ArgsUsage: "<exchange> <pair> <side> <type> <amount> <price>",
+	Action:    simulatePortfolioImpact,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to simulate the order for",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side to use (BUY OR SELL)",
+		},
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "the order type (MARKET OR LIMIT)",
+			Value: "MARKET",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount for the order",
+		},
+		cli.Float64Flag{
+			Name:  "price",
+			Usage: "the price for a limit order",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+			Value: "spot",
+		},
+	},
+}
+
+func simulatePortfolioImpact(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "simulateportfolioimpact")
+		return nil
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	var orderSide string
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(2)
+	}
+
+	if orderSide == "" {
+		return errors.New("side must be set")
+	}
+
+	orderType := c.String("type")
+	if !c.IsSet("type") && c.Args().Get(3) != "" {
+		orderType = c.Args().Get(3)
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(4), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if amount <= 0 {
+		return errors.New("amount must be set")
+	}
+
+	var price float64
+	if c.IsSet("price") {
+		price = c.Float64("price")
+	} else if c.Args().Get(5) != "" {
+		var err error
+		price, err = strconv.ParseFloat(c.Args().Get(5), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	assetType := strings.ToLower(c.String("asset"))
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.SimulatePortfolioImpact(context.Background(), &gctrpc.SimulatePortfolioImpactRequest{
+		Orders: []*gctrpc.WhatIfOrder{
+			{
+				Exchange: exchangeName,
+				Pair: &gctrpc.CurrencyPair{
+					Delimiter: p.Delimiter,
+					Base:      p.Base.String(),
+					Quote:     p.Quote.String(),
+				},
+				AssetType: assetType,
+				Side:      orderSide,
+				OrderType: orderType,
+				Amount:    amount,
+				Price:     price,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var cancelOrderCommand = cli.Command{
+	Name:      "cancelorder",
+	Usage:     "cancel order cancels an exchange order",
+	ArgsUsage: "<exchange> <account_id> <order_id> <pair> <asset> <wallet_address> <side>",
+	Action:    cancelOrder,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to cancel the order for",
+		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the account id",
+		},
+		cli.StringFlag{
+			Name:  "order_id",
+			Usage: "the order id",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to cancel the order for",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type",
+		},
+		cli.Float64Flag{
+			Name:  "wallet_address",
+			Usage: "the wallet address",
+		},
+		cli.StringFlag{
+			Name:  "side",
+			Usage: "the order side",
+		},
+	},
+}
+
+func cancelOrder(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "cancelorder")
+		return nil
+	}
+
+	var exchangeName string
+	var accountID string
+	var orderID string
+	var currencyPair string
+	var assetType string
+	var walletAddress string
+	var orderSide string
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("account_id") {
+		accountID = c.String("account_id")
+	} else {
+		accountID = c.Args().Get(1)
+	}
+
+	if c.IsSet("order_id") {
+		orderID = c.String("order_id")
+	} else {
+		orderID = c.Args().Get(2)
+	}
+
+	if orderID == "" {
+		return errors.New("an order ID must be set")
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(3)
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(4)
+	}
+
+	assetType = strings.ToLower(assetType)
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	if c.IsSet("wallet_address") {
+		walletAddress = c.String("wallet_address")
+	} else {
+		walletAddress = c.Args().Get(5)
+	}
+
+	if c.IsSet("side") {
+		orderSide = c.String("side")
+	} else {
+		orderSide = c.Args().Get(6)
+	}
+
+	// pair is optional, but if it's set, do a validity check
+	var p currency.Pair
+	if len(currencyPair) > 0 {
+		if !validPair(currencyPair) {
+			return errInvalidPair
+		}
+		p = currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.CancelOrder(context.Background(), &gctrpc.CancelOrderRequest{
+		Exchange:  exchangeName,
+		AccountId: accountID,
+		OrderId:   orderID,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		AssetType:     assetType,
+		WalletAddress: walletAddress,
+		Side:          orderSide,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var cancelAllOrdersCommand = cli.Command{
+	Name:      "cancelallorders",
+	Usage:     "cancels all orders (all or by exchange name)",
+	ArgsUsage: "<exchange>",
+	Action:    cancelAllOrders,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to cancel all orders on",
+		},
+	},
+}
+
+func cancelAllOrders(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	// exchange name is an optional param
+	if exchangeName != "" {
+		if !validExchange(exchangeName) {
+			return errInvalidExchange
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.CancelAllOrders(context.Background(), &gctrpc.CancelAllOrdersRequest{
+		Exchange: exchangeName,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getEventsCommand = cli.Command{
+	Name:   "getevents",
+	Usage:  "gets all events",
+	Action: getEvents,
+}
+
+func getEvents(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetEvents(context.Background(), &gctrpc.GetEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var addEventCommand = cli.Command{
+	Name:      "addevent",
+	Usage:     "adds an event",
+	ArgsUsage: "<exchange> <item> <condition> <price> <check_bids> <check_bids_and_asks> <orderbook_amount> <threshold> <depth_percent> <pair> <asset> <action>",
+	Action:    addEvent,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to add an event for",
+		},
+		cli.StringFlag{
+			Name:  "item",
+			Usage: "the item to trigger the event (PRICE, ORDERBOOK, SPREAD, IMBALANCE or DEPTH)",
+		},
+		cli.StringFlag{
+			Name:  "condition",
+			Usage: "the condition for the event",
+		},
+		cli.Float64Flag{
+			Name:  "price",
+			Usage: "the price to trigger the event",
+		},
+		cli.BoolFlag{
+			Name:  "check_bids",
+			Usage: "whether to check the bids (if false, asks will be used)",
+		},
+		cli.BoolFlag{
+			Name:  "check_bids_and_asks",
+			Usage: "the wallet address",
+		},
+		cli.Float64Flag{
+			Name:  "orderbook_amount",
+			Usage: "the orderbook amount to trigger the event",
+		},
+		cli.Float64Flag{
+			Name:  "threshold",
+			Usage: "the spread percent, imbalance or depth to trigger SPREAD, IMBALANCE and DEPTH events",
+		},
+		cli.Float64Flag{
+			Name:  "depth_percent",
+			Usage: "the percentage of the mid price DEPTH events measure the orderbook depth within",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type",
+		},
+		cli.StringFlag{
+			Name:  "action",
+			Usage: "the action for the event to perform upon trigger",
+		},
+	},
+}
+
+func addEvent(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "addevent")
+		return nil
+	}
+
+	var exchangeName string
+	var item string
+	var condition string
+	var price float64
+	var checkBids bool
+	var checkBidsAndAsks bool
+	var orderbookAmount float64
+	var threshold float64
+	var depthPercent float64
+	var currencyPair string
+	var assetType string
+	var action string
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		return fmt.Errorf("exchange name is required")
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("item") {
+		item = c.String("item")
+	} else {
+		return fmt.Errorf("item is required")
+	}
+
+	if c.IsSet("condition") {
+		condition = c.String("condition")
+	} else {
+		return fmt.Errorf("condition is required")
+	}
+
+	if c.IsSet("price") {
+		price = c.Float64("price")
+	}
+
+	if c.IsSet("check_bids") {
+		checkBids = c.Bool("check_bids")
+	}
+
+	if c.IsSet("check_bids_and_asks") {
+		checkBids = c.Bool("check_bids_and_asks")
+	}
+
+	if c.IsSet("orderbook_amount") {
+		orderbookAmount = c.Float64("orderbook_amount")
+	}
+
+	if c.IsSet("threshold") {
+		threshold = c.Float64("threshold")
+	}
+
+	if c.IsSet("depth_percent") {
+		depthPercent = c.Float64("depth_percent")
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		return fmt.Errorf("currency pair is required")
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	}
+
+	assetType = strings.ToLower(assetType)
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	if c.IsSet("action") {
+		action = c.String("action")
+	} else {
+		return fmt.Errorf("action is required")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.AddEvent(context.Background(), &gctrpc.AddEventRequest{
+		Exchange: exchangeName,
+		Item:     item,
+		ConditionParams: &gctrpc.ConditionParams{
+			Condition:        condition,
+			Price:            price,
+			CheckBids:        checkBids,
+			CheckBidsAndAsks: checkBidsAndAsks,
+			OrderbookAmount:  orderbookAmount,
+			Threshold:        threshold,
+			DepthPercent:     depthPercent,
+		},
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		AssetType: assetType,
+		Action:    action,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var removeEventCommand = cli.Command{
+	Name:      "removeevent",
+	Usage:     "removes an event",
+	ArgsUsage: "<event_id>",
+	Action:    removeEvent,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "event_id",
+			Usage: "the event id to remove",
+		},
+	},
+}
+
+func removeEvent(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "removeevent")
+		return nil
+	}
+
+	var eventID int64
+	if c.IsSet("event_id") {
+		eventID = c.Int64("event_id")
+	} else if c.Args().Get(0) != "" {
+		var err error
+		eventID, err = strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if eventID == 0 {
+		return errors.New("event id must be specified")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.RemoveEvent(context.Background(),
+		&gctrpc.RemoveEventRequest{Id: eventID})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getCryptocurrencyDepositAddressesCommand = cli.Command{
+	Name:      "getcryptocurrencydepositaddresses",
+	Usage:     "gets the cryptocurrency deposit addresses for an exchange",
+	ArgsUsage: "<exchange>",
+	Action:    getCryptocurrencyDepositAddresses,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the cryptocurrency deposit addresses for",
+		},
+	},
+}
+
+func getCryptocurrencyDepositAddresses(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getcryptocurrencydepositaddresses")
+		return nil
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetCryptocurrencyDepositAddresses(context.Background(),
+		&gctrpc.GetCryptocurrencyDepositAddressesRequest{Exchange: exchangeName})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getCryptocurrencyDepositAddressCommand = cli.Command{
+	Name:      "getcryptocurrencydepositaddress",
+	Usage:     "gets the cryptocurrency deposit address for an exchange and cryptocurrency",
+	ArgsUsage: "<exchange> <cryptocurrency>",
+	Action:    getCryptocurrencyDepositAddress,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the cryptocurrency deposit address for",
+		},
+		cli.StringFlag{
+			Name:  "cryptocurrency",
+			Usage: "the cryptocurrency to get the deposit address for",
+		},
+	},
+}
+
+func getCryptocurrencyDepositAddress(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getcryptocurrencydepositaddresses")
+		return nil
+	}
+
+	var exchangeName string
+	var cryptocurrency string
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("cryptocurrency") {
+		cryptocurrency = c.String("cryptocurrency")
+	} else if c.Args().Get(1) != "" {
+		cryptocurrency = c.Args().Get(1)
+	}
+
+	if cryptocurrency == "" {
+		return errors.New("cryptocurrency must be set")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetCryptocurrencyDepositAddress(context.Background(),
+		&gctrpc.GetCryptocurrencyDepositAddressRequest{
+			Exchange:       exchangeName,
+			Cryptocurrency: cryptocurrency,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var withdrawCryptocurrencyFundsCommand = cli.Command{
+	Name:      "withdrawcryptofunds",
+	Usage:     "withdraws cryptocurrency funds from the desired exchange",
+	ArgsUsage: "<exchange> <currency>  <amount> <address> <addresstag> <fee> <description>",
+	Action:    withdrawCryptocurrencyFunds,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to withdraw from",
+		},
+		cli.StringFlag{
+			Name:  "currency",
+			Usage: "the cryptocurrency to withdraw funds from",
+		},
+		cli.StringFlag{
+			Name:  "address",
+			Usage: "address to withdraw to",
+		},
+		cli.StringFlag{
+			Name:  "addresstag",
+			Usage: "address tag/memo",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "amount of funds to withdraw",
+		},
+		cli.Float64Flag{
+			Name:  "fee",
+			Usage: "fee to submit with request",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "description to submit with request",
+		},
+	},
+}
+
+func withdrawCryptocurrencyFunds(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "withdrawcryptofunds")
+		return nil
+	}
+
+	var exchange, cur, address, addressTag, description string
+	var amount, fee float64
+
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else if c.Args().Get(0) != "" {
+		exchange = c.Args().Get(0)
+	}
+
+	if !validExchange(exchange) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("currency") {
+		cur = c.String("currency")
+	} else if c.Args().Get(1) != "" {
+		cur = c.Args().Get(1)
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(2) != "" {
+		amountStr, err := strconv.ParseFloat(c.Args().Get(2), 64)
+		if err == nil {
+			amount = amountStr
+		}
+	}
+
+	if c.IsSet("address") {
+		address = c.String("address")
+	} else if c.Args().Get(3) != "" {
+		address = c.Args().Get(3)
+	}
+
+	if c.IsSet("addresstag") {
+		addressTag = c.String("addresstag")
+	} else if c.Args().Get(4) != "" {
+		addressTag = c.Args().Get(4)
+	}
+
+	if c.IsSet("fee") {
+		fee = c.Float64("fee")
+	} else if c.Args().Get(5) != "" {
+		feeStr, err := strconv.ParseFloat(c.Args().Get(5), 64)
+		if err == nil {
+			fee = feeStr
+		}
+	}
+
+	if c.IsSet("description") {
+		description = c.String("description")
+	} else if c.Args().Get(6) != "" {
+		description = c.Args().Get(6)
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.WithdrawCryptocurrencyFunds(context.Background(),
+		&gctrpc.WithdrawCryptoRequest{
+			Exchange:    exchange,
+			Currency:    cur,
+			Address:     address,
+			AddressTag:  addressTag,
+			Amount:      amount,
+			Fee:         fee,
+			Description: description,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var withdrawFiatFundsCommand = cli.Command{
+	Name:      "withdrawfiatfunds",
+	Usage:     "withdraws fiat funds from the desired exchange",
+	ArgsUsage: "<exchange> <currency> <amount> <bankaccount id> <description>",
+	Action:    withdrawFiatFunds,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to withdraw from",
+		},
+		cli.StringFlag{
+			Name:  "currency",
+			Usage: "the fiat currency to withdraw funds from",
+		},
+		cli.Float64Flag{
+			Name:  "amount",
+			Usage: "amount of funds to withdraw",
+		},
+		cli.StringFlag{
+			Name:  "bankaccountid",
+			Usage: "ID of bank account to use",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "description to submit with request",
+		},
+	},
+}
+
+func withdrawFiatFunds(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "withdrawfiatfunds")
+		return nil
+	}
+
+	var exchange, cur, description, bankAccountID string
+	var amount float64
+
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else if c.Args().Get(0) != "" {
+		exchange = c.Args().Get(0)
+	}
+
+	if !validExchange(exchange) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("currency") {
+		cur = c.String("currency")
+	} else if c.Args().Get(1) != "" {
+		cur = c.Args().Get(1)
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(2) != "" {
+		amountStr, err := strconv.ParseFloat(c.Args().Get(2), 64)
+		if err == nil {
+			amount = amountStr
+		}
+	}
+
+	if c.IsSet("bankaccountid") {
+		bankAccountID = c.String("bankaccountid")
+	} else if c.Args().Get(3) != "" {
+		bankAccountID = c.Args().Get(3)
+	}
+
+	if c.IsSet("description") {
+		description = c.String("description")
+	} else if c.Args().Get(4) != "" {
+		description = c.Args().Get(4)
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.WithdrawFiatFunds(context.Background(),
+		&gctrpc.WithdrawFiatRequest{
+			Exchange:      exchange,
+			Currency:      cur,
+			Amount:        amount,
+			Description:   description,
+			BankAccountId: bankAccountID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var withdrawalRequestCommand = cli.Command{
+	Name:      "withdrawalrequesthistory",
+	Usage:     "retrieve previous withdrawal request details",
+	ArgsUsage: "<type> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:      "byid",
+			Usage:     "id",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "id",
+					Usage: "<id>",
+				},
+			},
+			Action: withdrawlRequestByID,
+		},
+		{
+			Name:      "byexchangeid",
+			Usage:     "exchange id",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "exchange",
+					Usage: "<exchange>",
+				},
+				cli.StringFlag{
+					Name:  "id",
+					Usage: "<id>",
+				},
+			},
+			Action: withdrawlRequestByExchangeID,
+		},
+		{
+			Name:      "byexchange",
+			Usage:     "exchange limit",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "exchange",
+					Usage: "<exchange>",
+				},
+				cli.Int64Flag{
+					Name:  "limit",
+					Usage: "<limit>",
+				},
+			},
+			Action: withdrawlRequestByExchangeID,
+		},
+		{
+			Name:      "bydate",
+			Usage:     "exchange start end limit",
+			ArgsUsage: "<exchange> <start> <end> <limit>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "exchange",
+					Usage: "<exchange>",
+				},
+				cli.StringFlag{
+					Name:        "start",
+					Usage:       "<start>",
+					Value:       time.Now().AddDate(0, -1, 0).Format(common.SimpleTimeFormat),
+					Destination: &startTime,
+				},
+				cli.StringFlag{
+					Name:        "end",
+					Usage:       "<end>",
+					Value:       time.Now().Format(common.SimpleTimeFormat),
+					Destination: &endTime,
+				},
+				cli.Int64Flag{
+					Name:  "limit",
+					Usage: "<limit>",
+				},
+			},
+			Action: withdrawlRequestByDate,
+		},
+	},
+}
+
+func withdrawlRequestByID(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowSubcommandHelp(c)
+		return nil
+	}
+
+	var ID string
+	if c.IsSet("id") {
+		ID = c.String("id")
+	} else {
+		ID = c.Args().First()
+	}
+
+	if ID == "" {
+		return errors.New("an ID must be specified")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.WithdrawalEventByID(context.Background(),
+		&gctrpc.WithdrawalEventByIDRequest{
+			Id: ID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+func withdrawlRequestByExchangeID(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowSubcommandHelp(c)
+		return nil
+	}
+
+	var exchange string
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().First()
+	}
+
+	var limit, limitStr int64
+	var ID string
+	var err error
+	if c.Command.Name == "byexchangeid" {
+		if c.IsSet("id") {
+			ID = c.String("id")
+		} else {
+			ID = c.Args().Get(1)
+		}
+		if ID == "" {
+			return errors.New("an ID must be specified")
+		}
+		limit = 1
+	} else {
+		if c.IsSet("limit") {
+			limit = c.Int64("limit")
+		} else if c.Args().Get(1) != "" {
+			limitStr, err = strconv.ParseInt(c.Args().Get(1), 10, 64)
+			if err != nil {
+				return err
+			}
+			if limitStr > math.MaxInt32 {
+				return fmt.Errorf("limit greater than max size: %v", math.MaxInt32)
+			}
+			limit = limitStr
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.WithdrawalEventsByExchange(context.Background(),
+		&gctrpc.WithdrawalEventsByExchangeRequest{
+			Exchange: exchange,
+			Id:       ID,
+			Limit:    int32(limit),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+func withdrawlRequestByDate(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowSubcommandHelp(c)
+		return nil
+	}
+
+	var exchange string
+	var limit, limitStr int64
+	var err error
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().First()
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
+	}
+
+	if c.IsSet("limit") {
+		limit = c.Int64("limit")
+	} else if c.Args().Get(3) != "" {
+		limitStr, err = strconv.ParseInt(c.Args().Get(3), 10, 64)
+		if err != nil {
+			return err
+		}
+		if limitStr > math.MaxInt32 {
+			return fmt.Errorf("limit greater than max size: %v", math.MaxInt32)
+		}
+		limit = limitStr
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after before")
+	}
+
+	_, offset := time.Now().Zone()
+	loc := time.FixedZone("", -offset)
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.WithdrawalEventsByDate(context.Background(),
+		&gctrpc.WithdrawalEventsByDateRequest{
+			Exchange: exchange,
+			Start:    s.In(loc).Format(common.SimpleTimeFormat),
+			End:      e.In(loc).Format(common.SimpleTimeFormat),
+			Limit:    int32(limit),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var getLoggerDetailsCommand = cli.Command{
+	Name:      "getloggerdetails",
+	Usage:     "gets an individual loggers details",
+	ArgsUsage: "<logger>",
+	Action:    getLoggerDetails,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "logger",
+			Usage: "logger to get level details of",
+		},
+	},
+}
+
+func getLoggerDetails(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getloggerdetails")
+		return nil
+	}
+
+	var logger string
+	if c.IsSet("logger") {
+		logger = c.String("logger")
+	} else {
+		logger = c.Args().First()
+	}
+
+	if logger == "" {
+		return errors.New("a logger must be specified")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.GetLoggerDetails(context.Background(),
+		&gctrpc.GetLoggerDetailsRequest{
+			Logger: logger,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var setLoggerDetailsCommand = cli.Command{
+	Name:      "setloggerdetails",
+	Usage:     "sets an individual loggers details",
+	ArgsUsage: "<logger> <flags>",
+	Action:    setLoggerDetails,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "logger",
+			Usage: "logger to get level details of",
+		},
+		cli.StringFlag{
+			Name:  "flags",
+			Usage: "pipe separated value of loggers e.g INFO|WARN",
+		},
+	},
+}
+
+func setLoggerDetails(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "setloggerdetails")
+		return nil
+	}
+
+	var logger string
+	var level string
+
+	if c.IsSet("logger") {
+		logger = c.String("logger")
+	} else {
+		logger = c.Args().First()
+	}
+
+	if logger == "" {
+		return errors.New("a logger must be specified")
+	}
+
+	if c.IsSet("level") {
+		level = c.String("level")
+	} else {
+		level = c.Args().Get(1)
+	}
+
+	if level == "" {
+		return errors.New("level must be specified")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.SetLoggerDetails(context.Background(),
+		&gctrpc.SetLoggerDetailsRequest{
+			Logger: logger,
+			Level:  level,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var getExchangePairsCommand = cli.Command{
+	Name:      "getexchangepairs",
+	Usage:     "gets an exchanges supported currency pairs (available and enabled) plus asset types",
+	ArgsUsage: "<exchange> <asset>",
+	Action:    getExchangePairs,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to list of the currency pairs of",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type to filter by",
+		},
+	},
+}
+
+func getExchangePairs(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getexchangepairs")
+		return nil
+	}
+
+	var exchange string
+	var asset string
+
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().First()
+	}
+
+	if !validExchange(exchange) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("asset") {
+		asset = c.String("asset")
+	} else {
+		asset = c.Args().Get(1)
+	}
+
+	asset = strings.ToLower(asset)
+	if !validAsset(asset) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetExchangePairs(context.Background(),
+		&gctrpc.GetExchangePairsRequest{
+			Exchange: exchange,
+			Asset:    asset,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var enableExchangePairCommand = cli.Command{
+	Name:      "enableexchangepair",
+	Usage:     "enables an exchange currency pair",
+	ArgsUsage: "<exchange> <pair> <asset>",
+	Action:    enableExchangePair,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to enable the currency pair for",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to enable",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type to enable the currency pair for",
+		},
+	},
+}
+
+func enableExchangePair(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "enableexchangepair")
+		return nil
+	}
+
+	var exchange string
+	var pair string
+	var asset string
+
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().First()
+	}
+
+	if !validExchange(exchange) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		pair = c.String("pair")
+	} else {
+		pair = c.Args().Get(1)
+	}
+
+	if !validPair(pair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("asset") {
+		asset = c.String("asset")
+	} else {
+		asset = c.Args().Get(2)
+	}
+
+	asset = strings.ToLower(asset)
+	if !validAsset(asset) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(pair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.EnableExchangePair(context.Background(),
+		&gctrpc.ExchangePairRequest{
+			Exchange: exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType: asset,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var disableExchangePairCommand = cli.Command{
+	Name:      "disableexchangepair",
+	Usage:     "disables a previously enabled exchange currency pair",
+	ArgsUsage: "<exchange> <pair> <asset>",
+	Action:    disableExchangePair,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to disable the currency pair for",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to disable",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type to disable the currency pair for",
+		},
+	},
+}
+
+func disableExchangePair(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "disableexchangepair")
+		return nil
+	}
+
+	var exchange string
+	var pair string
+	var asset string
+
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().First()
+	}
+
+	if !validExchange(exchange) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		pair = c.String("pair")
+	} else {
+		pair = c.Args().Get(1)
+	}
+
+	if !validPair(pair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("asset") {
+		asset = c.String("asset")
+	} else {
+		asset = c.Args().Get(2)
+	}
+
+	asset = strings.ToLower(asset)
+	if !validAsset(asset) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(pair, pairDelimiter)
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.DisableExchangePair(context.Background(),
+		&gctrpc.ExchangePairRequest{
+			Exchange: exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType: asset,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+var getOrderbookStreamCommand = cli.Command{
+	Name:      "getorderbookstream",
+	Usage:     "gets the orderbook stream for a specific currency pair and exchange",
+	ArgsUsage: "<exchange> <pair> <asset>",
+	Action:    getOrderbookStream,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the orderbook from",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "currency pair",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+		},
+	},
+}
+
+func getOrderbookStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getorderbookstream")
+		return nil
+	}
+
+	var exchangeName string
+	var pair string
+	var assetType string
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		pair = c.String("pair")
+	} else {
+		pair = c.Args().Get(1)
+	}
+
+	if !validPair(pair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+
+	assetType = strings.ToLower(assetType)
+
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(pair, pairDelimiter)
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetOrderbookStream(context.Background(),
+		&gctrpc.GetOrderbookStreamRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+				Delimiter: p.Delimiter,
+			},
+			AssetType: assetType,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		err = clearScreen()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Orderbook stream for %s %s:\n\n", exchangeName,
+			resp.Pair.String())
+		fmt.Println("\t\tBids\t\t\t\tAsks")
+		fmt.Println()
+
+		bidLen := len(resp.Bids) - 1
+		askLen := len(resp.Asks) - 1
+
+		var maxLen int
+		if bidLen >= askLen {
+			maxLen = bidLen
+		} else {
+			maxLen = askLen
+		}
+
+		for i := 0; i < maxLen; i++ {
+			var bidAmount, bidPrice float64
+			if i <= bidLen {
+				bidAmount = resp.Bids[i].Amount
+				bidPrice = resp.Bids[i].Price
+			}
+
+			var askAmount, askPrice float64
+			if i <= askLen {
+				askAmount = resp.Asks[i].Amount
+				askPrice = resp.Asks[i].Price
+			}
+
+			fmt.Printf("%f %s @ %f %s\t\t%f %s @ %f %s\n",
+				bidAmount,
+				resp.Pair.Base,
+				bidPrice,
+				resp.Pair.Quote,
+				askAmount,
+				resp.Pair.Base,
+				askPrice,
+				resp.Pair.Quote)
+
+			if i >= 49 {
+				// limits orderbook display output
+				break
+			}
+		}
+	}
+}
+
+var getExchangeOrderbookStreamCommand = cli.Command{
+	Name:      "getexchangeorderbookstream",
+	Usage:     "gets a stream for all orderbooks associated with an exchange",
+	ArgsUsage: "<exchange>",
+	Action:    getExchangeOrderbookStream,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the orderbook from",
+		},
+	},
+}
+
+func getExchangeOrderbookStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getexchangeorderbookstream")
+		return nil
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetExchangeOrderbookStream(context.Background(),
+		&gctrpc.GetExchangeOrderbookStreamRequest{
+			Exchange: exchangeName,
+		})
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		err = clearScreen()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Orderbook streamed for %s %s",
+			exchangeName,
+			resp.Pair.String())
+	}
+}
+
+var getTickerStreamCommand = cli.Command{
+	Name:      "gettickerstream",
+	Usage:     "gets the ticker stream for a specific currency pair and exchange",
+	ArgsUsage: "<exchange> <pair> <asset>",
+	Action:    getTickerStream,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the ticker from",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "currency pair",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+		},
+	},
+}
+
+func getTickerStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "gettickerstream")
+		return nil
+	}
+
+	var exchangeName string
+	var pair string
+	var assetType string
+
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	if c.IsSet("pair") {
+		pair = c.String("pair")
+	} else {
+		pair = c.Args().Get(1)
+	}
+
+	if !validPair(pair) {
+		return errInvalidPair
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+
+	assetType = strings.ToLower(assetType)
+
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	p := currency.NewPairDelimiter(pair, pairDelimiter)
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetTickerStream(context.Background(),
+		&gctrpc.GetTickerStreamRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+				Delimiter: p.Delimiter,
+			},
+			AssetType: assetType,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		err = clearScreen()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Ticker stream for %s %s:\n", exchangeName,
+			resp.Pair.String())
+		fmt.Println()
+
+		fmt.Printf("LAST: %f\n HIGH: %f\n LOW: %f\n BID: %f\n ASK: %f\n VOLUME: %f\n PRICEATH: %f\n LASTUPDATED: %d\n",
+			resp.Last,
+			resp.High,
+			resp.Low,
+			resp.Bid,
+			resp.Ask,
+			resp.Volume,
+			resp.PriceAth,
+			resp.LastUpdated)
+	}
+}
+
+var getExchangeTickerStreamCommand = cli.Command{
+	Name:      "getexchangetickerstream",
+	Usage:     "gets a stream for all tickers associated with an exchange",
+	ArgsUsage: "<exchange>",
+	Action:    getExchangeTickerStream,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the ticker from",
+		},
+	},
+}
+
+func getExchangeTickerStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getexchangetickerstream")
+		return nil
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetExchangeTickerStream(context.Background(),
+		&gctrpc.GetExchangeTickerStreamRequest{
+			Exchange: exchangeName,
+		})
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Ticker stream for %s %s:\n",
+			exchangeName,
+			resp.Pair.String())
+
+		fmt.Printf("LAST: %f HIGH: %f LOW: %f BID: %f ASK: %f VOLUME: %f PRICEATH: %f LASTUPDATED: %d\n",
+			resp.Last,
+			resp.High,
+			resp.Low,
+			resp.Bid,
+			resp.Ask,
+			resp.Volume,
+			resp.PriceAth,
+			resp.LastUpdated)
+	}
+}
+
+func clearScreen() error {
+	switch runtime.GOOS {
+	case "windows":
+		cmd := exec.Command("cmd", "/c", "cls")
+		cmd.Stdout = os.Stdout
+		return cmd.Run()
+	default:
+		cmd := exec.Command("clear")
+		cmd.Stdout = os.Stdout
+		return cmd.Run()
+	}
+}
+
+var getAuditEventCommand = cli.Command{
+	Name:      "getauditevent",
+	Usage:     "gets audit events matching query parameters",
+	ArgsUsage: "<starttime> <endtime> <orderby> <limit>",
+	Action:    getAuditEvent,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date to search",
+			Value:       time.Now().Add(-time.Hour).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end time to search",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+		cli.StringFlag{
+			Name:        "order, o",
+			Usage:       "order results by ascending/descending",
+			Value:       "asc",
+			Destination: &order,
+		},
+		cli.IntFlag{
+			Name:        "limit, l",
+			Usage:       "how many results to retrieve",
+			Value:       100,
+			Destination: &limit,
+		},
+	},
+}
+
+func getAuditEvent(c *cli.Context) error {
+	if !c.IsSet("start") {
+		if c.Args().Get(0) != "" {
+			startTime = c.Args().Get(0)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(1) != "" {
+			endTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("order") {
+		if c.Args().Get(2) != "" {
+			order = c.Args().Get(2)
+		}
+	}
+
+	if !c.IsSet("limit") {
+		if c.Args().Get(3) != "" {
+			limitStr, err := strconv.ParseInt(c.Args().Get(3), 10, 64)
+			if err == nil {
+				limit = int(limitStr)
+			}
+		}
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after before")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	_, offset := time.Now().Zone()
+	loc := time.FixedZone("", -offset)
+
+	result, err := client.GetAuditEvent(context.Background(),
+		&gctrpc.GetAuditEventRequest{
+			StartDate: s.In(loc).Format(common.SimpleTimeFormat),
+			EndDate:   e.In(loc).Format(common.SimpleTimeFormat),
+			Limit:     int32(limit),
+			OrderBy:   order,
+			Offset:    int32(offset),
+		})
+
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getEquityCurveCommand = cli.Command{
+	Name:      "getequitycurve",
+	Usage:     "gets the stored equity snapshots, return and max drawdown of the portfolio or a strategy",
+	ArgsUsage: "<portfolio> <starttime> <endtime>",
+	Action:    getEquityCurve,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "portfolio, p",
+			Usage: "the portfolio, or a strategy as strategy:<name>",
+			Value: "portfolio",
+		},
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date to search",
+			Value:       time.Now().Add(-time.Hour * 24).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end time to search",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+		cli.StringFlag{
+			Name:  "currency, c",
+			Usage: "the currency the snapshots are valued in, such as BTC, defaults to the configured snapshot currency",
+		},
+		cli.StringFlag{
+			Name:  "benchmark, b",
+			Usage: "the optional currency to compare the return against holding, such as BTC",
+		},
+	},
+}
+
+func getEquityCurve(c *cli.Context) error {
+	portfolio := c.String("portfolio")
+	if !c.IsSet("portfolio") && c.Args().Get(0) != "" {
+		portfolio = c.Args().Get(0)
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
 	}
 
-	if ID == "" {
-		return errors.New("an ID must be specified")
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
 	}
 
-	conn, err := setupClient()
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid time format for start: %v", err)
 	}
-	defer conn.Close()
-
-	client := gctrpc.NewGoCryptoTraderClient(conn)
 
-	result, err := client.WithdrawalEventByID(context.Background(),
-		&gctrpc.WithdrawalEventByIDRequest{
-			Id: ID,
-		},
-	)
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
 	if err != nil {
-		return err
-	}
-	jsonOutput(result)
-	return nil
-}
-
-func withdrawlRequestByExchangeID(c *cli.Context) error {
-	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowSubcommandHelp(c)
-		return nil
-	}
-
-	var exchange string
-	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else {
-		exchange = c.Args().First()
+		return fmt.Errorf("invalid time format for end: %v", err)
 	}
 
-	var limit, limitStr int64
-	var ID string
-	var err error
-	if c.Command.Name == "byexchangeid" {
-		if c.IsSet("id") {
-			ID = c.String("id")
-		} else {
-			ID = c.Args().Get(1)
-		}
-		if ID == "" {
-			return errors.New("an ID must be specified")
-		}
-		limit = 1
-	} else {
-		if c.IsSet("limit") {
-			limit = c.Int64("limit")
-		} else if c.Args().Get(1) != "" {
-			limitStr, err = strconv.ParseInt(c.Args().Get(1), 10, 64)
-			if err != nil {
-				return err
-			}
-			if limitStr > math.MaxInt32 {
-				return fmt.Errorf("limit greater than max size: %v", math.MaxInt32)
-			}
-			limit = limitStr
-		}
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
 	}
 
 	conn, err := setupClient()
@@ -2565,34 +6290,67 @@ func withdrawlRequestByExchangeID(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-
-	result, err := client.WithdrawalEventsByExchange(context.Background(),
-		&gctrpc.WithdrawalEventsByExchangeRequest{
-			Exchange: exchange,
-			Id:       ID,
-			Limit:    int32(limit),
-		},
-	)
+	result, err := client.GetEquityCurve(context.Background(),
+		&gctrpc.GetEquityCurveRequest{
+			Portfolio: portfolio,
+			StartDate: s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:   e.UTC().Format(common.SimpleTimeFormat),
+			Currency:  c.String("currency"),
+			Benchmark: c.String("benchmark"),
+		})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-func withdrawlRequestByDate(c *cli.Context) error {
-	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowSubcommandHelp(c)
-		return nil
-	}
+var getStrategyPerformanceCommand = cli.Command{
+	Name:      "getstrategyperformance",
+	Usage:     "gets the Sharpe and Sortino ratios, max drawdown, win rate, average win and loss and exposure time of a strategy or all strategies allocated fills",
+	ArgsUsage: "<strategy> <starttime> <endtime>",
+	Action:    getStrategyPerformance,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "strategy",
+			Usage: "the strategy, all strategies allocated fills if unset",
+		},
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date of the period",
+			Value:       time.Now().AddDate(0, -1, 0).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end date of the period",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+		cli.StringFlag{
+			Name:  "currency, c",
+			Usage: "the currency the equity snapshots are valued in, defaults to the configured snapshot currency",
+		},
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "the optional export format, json or csv",
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "the file to write the export to, printed if unset",
+		},
+		cli.Float64Flag{
+			Name:  "capital",
+			Usage: "the capital the strategy trades with, which returns for the Sharpe and Sortino ratios are measured against",
+		},
+	},
+}
 
-	var exchange string
-	var limit, limitStr int64
-	var err error
-	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else {
-		exchange = c.Args().First()
+func getStrategyPerformance(c *cli.Context) error {
+	strategy := c.String("strategy")
+	if !c.IsSet("strategy") && c.Args().Get(0) != "" {
+		strategy = c.Args().Get(0)
 	}
 
 	if !c.IsSet("start") {
@@ -2607,35 +6365,23 @@ func withdrawlRequestByDate(c *cli.Context) error {
 		}
 	}
 
-	if c.IsSet("limit") {
-		limit = c.Int64("limit")
-	} else if c.Args().Get(3) != "" {
-		limitStr, err = strconv.ParseInt(c.Args().Get(3), 10, 64)
-		if err != nil {
-			return err
-		}
-		if limitStr > math.MaxInt32 {
-			return fmt.Errorf("limit greater than max size: %v", math.MaxInt32)
-		}
-		limit = limitStr
-	}
-
-	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
 	if err != nil {
 		return fmt.Errorf("invalid time format for start: %v", err)
 	}
 
-	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
 	if err != nil {
 		return fmt.Errorf("invalid time format for end: %v", err)
 	}
 
 	if e.Before(s) {
-		return errors.New("start cannot be after before")
+		return errors.New("start cannot be after end")
 	}
 
-	_, offset := time.Now().Zone()
-	loc := time.FixedZone("", -offset)
+	if c.String("output") != "" && c.String("format") == "" {
+		return errors.New("an export format must be specified to write to a file")
+	}
 
 	conn, err := setupClient()
 	if err != nil {
@@ -2644,49 +6390,182 @@ func withdrawlRequestByDate(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.WithdrawalEventsByDate(context.Background(),
-		&gctrpc.WithdrawalEventsByDateRequest{
-			Exchange: exchange,
-			Start:    s.In(loc).Format(common.SimpleTimeFormat),
-			End:      e.In(loc).Format(common.SimpleTimeFormat),
-			Limit:    int32(limit),
-		},
-	)
+	result, err := client.GetStrategyPerformance(context.Background(),
+		&gctrpc.GetStrategyPerformanceRequest{
+			Strategy:  strategy,
+			StartDate: s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:   e.UTC().Format(common.SimpleTimeFormat),
+			Currency:  c.String("currency"),
+			Format:    c.String("format"),
+			Capital:   c.Float64("capital"),
+		})
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+
+	if result.Format == "" {
+		jsonOutput(result)
+		return nil
+	}
+
+	if c.String("output") == "" {
+		fmt.Print(result.Data)
+		return nil
+	}
+
+	err = file.Write(c.String("output"), []byte(result.Data))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported the performance of %d strategies as %s to %s\n", len(result.Strategies), result.Format, c.String("output"))
 	return nil
 }
 
-var getLoggerDetailsCommand = cli.Command{
-	Name:      "getloggerdetails",
-	Usage:     "gets an individual loggers details",
-	ArgsUsage: "<logger>",
-	Action:    getLoggerDetails,
+var optimiseStrategyCommand = cli.Command{
+	Name:      "optimisestrategy",
+	Usage:     "backtests a grid of a strategy's parameters over stored candles with walk-forward splits, ranking the parameter sets by their out-of-sample performance",
+	ArgsUsage: "<strategy> <exchange> <pair> <asset>",
+	Action:    optimiseStrategy,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "logger",
-			Usage: "logger to get level details of",
+			Name:  "strategy",
+			Usage: "the registered strategy to optimise",
+		},
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange of the stored candles",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair of the stored candles",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+			Value: "spot",
+		},
+		cli.Int64Flag{
+			Name:  "granularity, g",
+			Usage: "the candle interval in seconds",
+			Value: 3600,
+		},
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date of the stored candles",
+			Value:       time.Now().AddDate(0, -3, 0).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end date of the stored candles",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+		cli.StringSliceFlag{
+			Name:  "param, p",
+			Usage: "a fixed strategy parameter as name=value (repeatable)",
+		},
+		cli.StringSliceFlag{
+			Name:  "grid",
+			Usage: "the values of a parameter to try as name=value1,value2 (repeatable)",
+		},
+		cli.Int64Flag{
+			Name:  "folds, f",
+			Usage: "the amount of walk-forward windows",
+			Value: 3,
+		},
+		cli.Float64Flag{
+			Name:  "train_ratio",
+			Usage: "the share of each window used in-sample",
+			Value: 0.7,
+		},
+		cli.StringFlag{
+			Name:  "metric, m",
+			Usage: "the metric parameter sets are ranked by, sharpe, sortino or pnl",
+			Value: "sharpe",
+		},
+		cli.Float64Flag{
+			Name:  "fee",
+			Usage: "the fee rate charged on the value of each fill, such as 0.001 for 0.1%",
+		},
+		cli.Float64Flag{
+			Name:  "capital",
+			Usage: "the capital the strategy trades with, required to rank by sharpe or sortino",
 		},
 	},
 }
 
-func getLoggerDetails(c *cli.Context) error {
+func optimiseStrategy(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getloggerdetails")
-		return nil
+		return cli.ShowCommandHelp(c, "optimisestrategy")
 	}
 
-	var logger string
-	if c.IsSet("logger") {
-		logger = c.String("logger")
-	} else {
-		logger = c.Args().First()
+	strategy := c.String("strategy")
+	if !c.IsSet("strategy") {
+		strategy = c.Args().First()
+	}
+	if strategy == "" {
+		return errors.New("strategy must be set")
 	}
 
-	if logger == "" {
-		return errors.New("a logger must be specified")
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().Get(1)
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	currencyPair := c.String("pair")
+	if !c.IsSet("pair") {
+		currencyPair = c.Args().Get(2)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+
+	assetType := c.String("asset")
+	if !c.IsSet("asset") && c.Args().Get(3) != "" {
+		assetType = c.Args().Get(3)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	params := make(map[string]string)
+	for _, v := range c.StringSlice("param") {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("invalid parameter %s, must be name=value", v)
+		}
+		params[kv[0]] = kv[1]
+	}
+
+	grid := make(map[string]string)
+	for _, v := range c.StringSlice("grid") {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return fmt.Errorf("invalid grid parameter %s, must be name=value1,value2", v)
+		}
+		grid[kv[0]] = kv[1]
+	}
+	if len(grid) == 0 {
+		return errors.New("at least one grid parameter must be set")
 	}
 
 	conn, err := setupClient()
@@ -2696,63 +6575,168 @@ func getLoggerDetails(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-
-	result, err := client.GetLoggerDetails(context.Background(),
-		&gctrpc.GetLoggerDetailsRequest{
-			Logger: logger,
-		},
-	)
+	result, err := client.OptimiseStrategy(context.Background(),
+		&gctrpc.OptimiseStrategyRequest{
+			Strategy: strategy,
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:  assetType,
+			Interval:   int64(time.Duration(c.Int64("granularity")) * time.Second),
+			StartDate:  s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:    e.UTC().Format(common.SimpleTimeFormat),
+			Parameters: params,
+			Grid:       grid,
+			Folds:      c.Int64("folds"),
+			TrainRatio: c.Float64("train_ratio"),
+			Metric:     c.String("metric"),
+			Fee:        c.Float64("fee"),
+			Capital:    c.Float64("capital"),
+		})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var setLoggerDetailsCommand = cli.Command{
-	Name:      "setloggerdetails",
-	Usage:     "sets an individual loggers details",
-	ArgsUsage: "<logger> <flags>",
-	Action:    setLoggerDetails,
+var simulateStrategyCommand = cli.Command{
+	Name:      "simulatestrategy",
+	Usage:     "backtests a strategy over stored candles and resamples its trades into Monte Carlo simulations, returning confidence intervals of its final equity and max drawdown",
+	ArgsUsage: "<strategy> <exchange> <pair> <asset>",
+	Action:    simulateStrategy,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "logger",
-			Usage: "logger to get level details of",
+			Name:  "strategy",
+			Usage: "the registered strategy to backtest",
+		},
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange of the stored candles",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair of the stored candles",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+			Value: "spot",
+		},
+		cli.Int64Flag{
+			Name:  "granularity, g",
+			Usage: "the candle interval in seconds",
+			Value: 3600,
 		},
 		cli.StringFlag{
-			Name:  "flags",
-			Usage: "pipe separated value of loggers e.g INFO|WARN",
+			Name:        "start, s",
+			Usage:       "start date of the stored candles",
+			Value:       time.Now().AddDate(0, -3, 0).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end date of the stored candles",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+		cli.StringSliceFlag{
+			Name:  "param, p",
+			Usage: "a strategy parameter as name=value (repeatable)",
+		},
+		cli.Float64Flag{
+			Name:  "fee",
+			Usage: "the fee rate charged on the value of each fill, such as 0.001 for 0.1%",
+		},
+		cli.Int64Flag{
+			Name:  "simulations, n",
+			Usage: "the amount of resampled trade sequences",
+			Value: 1000,
+		},
+		cli.Float64Flag{
+			Name:  "confidence",
+			Usage: "the share of simulations held by the confidence intervals",
+			Value: 0.95,
+		},
+		cli.Float64Flag{
+			Name:  "starting_equity",
+			Usage: "the equity each simulation starts from",
+		},
+		cli.Float64Flag{
+			Name:  "capital",
+			Usage: "the capital the strategy trades with, which returns for the backtest's Sharpe and Sortino ratios are measured against",
+		},
+
+		cli.Int64Flag{
+			Name:  "seed",
+			Usage: "seeds the simulations so they can be reproduced, chosen by the server when not set",
 		},
 	},
 }
 
-func setLoggerDetails(c *cli.Context) error {
+func simulateStrategy(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "setloggerdetails")
-		return nil
+		return cli.ShowCommandHelp(c, "simulatestrategy")
 	}
 
-	var logger string
-	var level string
+	strategy := c.String("strategy")
+	if !c.IsSet("strategy") {
+		strategy = c.Args().First()
+	}
+	if strategy == "" {
+		return errors.New("strategy must be set")
+	}
 
-	if c.IsSet("logger") {
-		logger = c.String("logger")
-	} else {
-		logger = c.Args().First()
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().Get(1)
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
 	}
 
-	if logger == "" {
-		return errors.New("a logger must be specified")
+	currencyPair := c.String("pair")
+	if !c.IsSet("pair") {
+		currencyPair = c.Args().Get(2)
 	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 
-	if c.IsSet("level") {
-		level = c.String("level")
-	} else {
-		level = c.Args().Get(1)
+	assetType := c.String("asset")
+	if !c.IsSet("asset") && c.Args().Get(3) != "" {
+		assetType = c.Args().Get(3)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
 	}
 
-	if level == "" {
-		return errors.New("level must be specified")
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	params := make(map[string]string)
+	for _, v := range c.StringSlice("param") {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("invalid parameter %s, must be name=value", v)
+		}
+		params[kv[0]] = kv[1]
 	}
 
 	conn, err := setupClient()
@@ -2762,65 +6746,59 @@ func setLoggerDetails(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-
-	result, err := client.SetLoggerDetails(context.Background(),
-		&gctrpc.SetLoggerDetailsRequest{
-			Logger: logger,
-			Level:  level,
-		},
-	)
+	result, err := client.SimulateStrategy(context.Background(),
+		&gctrpc.SimulateStrategyRequest{
+			Strategy: strategy,
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:      assetType,
+			Interval:       int64(time.Duration(c.Int64("granularity")) * time.Second),
+			StartDate:      s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:        e.UTC().Format(common.SimpleTimeFormat),
+			Parameters:     params,
+			Fee:            c.Float64("fee"),
+			Simulations:    c.Int64("simulations"),
+			Confidence:     c.Float64("confidence"),
+			StartingEquity: c.Float64("starting_equity"),
+			Seed:           c.Int64("seed"),
+			Capital:        c.Float64("capital"),
+		})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var getExchangePairsCommand = cli.Command{
-	Name:      "getexchangepairs",
-	Usage:     "gets an exchanges supported currency pairs (available and enabled) plus asset types",
-	ArgsUsage: "<exchange> <asset>",
-	Action:    getExchangePairs,
+var getDCAReportCommand = cli.Command{
+	Name:      "getdcareport",
+	Usage:     "gets the amount a dollar-cost averaging strategy has bought and its average cost over time",
+	ArgsUsage: "<name>",
+	Action:    getDCAReport,
 	Flags: []cli.Flag{
 		cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to list of the currency pairs of",
-		},
-		cli.StringFlag{
-			Name:  "asset",
-			Usage: "the asset type to filter by",
+			Name:  "name",
+			Usage: "the configured name of the strategy",
 		},
 	},
 }
 
-func getExchangePairs(c *cli.Context) error {
+func getDCAReport(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getexchangepairs")
-		return nil
-	}
-
-	var exchange string
-	var asset string
-
-	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else {
-		exchange = c.Args().First()
-	}
-
-	if !validExchange(exchange) {
-		return errInvalidExchange
+		return cli.ShowCommandHelp(c, "getdcareport")
 	}
 
-	if c.IsSet("asset") {
-		asset = c.String("asset")
-	} else {
-		asset = c.Args().Get(1)
+	name := c.String("name")
+	if !c.IsSet("name") {
+		name = c.Args().First()
 	}
-
-	asset = strings.ToLower(asset)
-	if !validAsset(asset) {
-		return errInvalidAsset
+	if name == "" {
+		return errors.New("name must be set")
 	}
 
 	conn, err := setupClient()
@@ -2830,79 +6808,97 @@ func getExchangePairs(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetExchangePairs(context.Background(),
-		&gctrpc.GetExchangePairsRequest{
-			Exchange: exchange,
-			Asset:    asset,
-		},
-	)
+	result, err := client.GetDCAReport(context.Background(),
+		&gctrpc.StrategyRequest{Name: name})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var enableExchangePairCommand = cli.Command{
-	Name:      "enableexchangepair",
-	Usage:     "enables an exchange currency pair",
-	ArgsUsage: "<exchange> <pair> <asset>",
-	Action:    enableExchangePair,
+var getAuctionHistoryCommand = cli.Command{
+	Name:      "getauctionhistory",
+	Usage:     "gets the collected auction history of an exchange pair",
+	ArgsUsage: "<exchange> <pair> <starttime> <endtime>",
+	Action:    getAuctionHistory,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to enable the currency pair for",
+			Usage: "the exchange to get the auction history for",
 		},
 		cli.StringFlag{
 			Name:  "pair",
-			Usage: "the currency pair to enable",
+			Usage: "the currency pair",
 		},
 		cli.StringFlag{
-			Name:  "asset",
-			Usage: "the asset type to enable the currency pair for",
+			Name:        "start, s",
+			Usage:       "start date to search",
+			Value:       time.Now().Add(-time.Hour * 24).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end time to search",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
 		},
 	},
 }
 
-func enableExchangePair(c *cli.Context) error {
+func getAuctionHistory(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "enableexchangepair")
+		cli.ShowCommandHelp(c, "getauctionhistory")
 		return nil
 	}
 
-	var exchange string
-	var pair string
-	var asset string
-
+	var exchangeName string
 	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
+		exchangeName = c.String("exchange")
 	} else {
-		exchange = c.Args().First()
+		exchangeName = c.Args().First()
 	}
 
-	if !validExchange(exchange) {
+	if !validExchange(exchangeName) {
 		return errInvalidExchange
 	}
 
+	var currencyPair string
 	if c.IsSet("pair") {
-		pair = c.String("pair")
+		currencyPair = c.String("pair")
 	} else {
-		pair = c.Args().Get(1)
+		currencyPair = c.Args().Get(1)
 	}
 
-	if !validPair(pair) {
+	if !validPair(currencyPair) {
 		return errInvalidPair
 	}
 
-	if c.IsSet("asset") {
-		asset = c.String("asset")
-	} else {
-		asset = c.Args().Get(2)
+	if !c.IsSet("start") {
+		if c.Args().Get(2) != "" {
+			startTime = c.Args().Get(2)
+		}
 	}
 
-	asset = strings.ToLower(asset)
-	if !validAsset(asset) {
-		return errInvalidAsset
+	if !c.IsSet("end") {
+		if c.Args().Get(3) != "" {
+			endTime = c.Args().Get(3)
+		}
+	}
+
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
 	}
 
 	conn, err := setupClient()
@@ -2911,86 +6907,93 @@ func enableExchangePair(c *cli.Context) error {
 	}
 	defer conn.Close()
 
-	p := currency.NewPairDelimiter(pair, pairDelimiter)
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.EnableExchangePair(context.Background(),
-		&gctrpc.ExchangePairRequest{
-			Exchange: exchange,
+	result, err := client.GetAuctionHistory(context.Background(),
+		&gctrpc.GetAuctionHistoryRequest{
+			Exchange: exchangeName,
 			Pair: &gctrpc.CurrencyPair{
 				Delimiter: p.Delimiter,
 				Base:      p.Base.String(),
 				Quote:     p.Quote.String(),
 			},
-			AssetType: asset,
-		},
-	)
+			StartDate: s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:   e.UTC().Format(common.SimpleTimeFormat),
+		})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var disableExchangePairCommand = cli.Command{
-	Name:      "disableexchangepair",
-	Usage:     "disables a previously enabled exchange currency pair",
-	ArgsUsage: "<exchange> <pair> <asset>",
-	Action:    disableExchangePair,
+var getCashFlowCommand = cli.Command{
+	Name:      "getcashflow",
+	Usage:     "gets the synced deposits and withdrawals of an exchange reconciled with its portfolio balances",
+	ArgsUsage: "<exchange> <starttime> <endtime>",
+	Action:    getCashFlow,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to disable the currency pair for",
+			Usage: "the exchange to get the cash flow for",
 		},
 		cli.StringFlag{
-			Name:  "pair",
-			Usage: "the currency pair to disable",
+			Name:        "start, s",
+			Usage:       "start date to search",
+			Value:       time.Now().AddDate(-1, 0, 0).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
 		},
 		cli.StringFlag{
-			Name:  "asset",
-			Usage: "the asset type to disable the currency pair for",
+			Name:        "end, e",
+			Usage:       "end time to search",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
 		},
 	},
 }
 
-func disableExchangePair(c *cli.Context) error {
+func getCashFlow(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "disableexchangepair")
+		cli.ShowCommandHelp(c, "getcashflow")
 		return nil
 	}
 
-	var exchange string
-	var pair string
-	var asset string
-
+	var exchangeName string
 	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
+		exchangeName = c.String("exchange")
 	} else {
-		exchange = c.Args().First()
+		exchangeName = c.Args().First()
 	}
 
-	if !validExchange(exchange) {
+	if !validExchange(exchangeName) {
 		return errInvalidExchange
 	}
 
-	if c.IsSet("pair") {
-		pair = c.String("pair")
-	} else {
-		pair = c.Args().Get(1)
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
 	}
 
-	if !validPair(pair) {
-		return errInvalidPair
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
 	}
 
-	if c.IsSet("asset") {
-		asset = c.String("asset")
-	} else {
-		asset = c.Args().Get(2)
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
 	}
 
-	asset = strings.ToLower(asset)
-	if !validAsset(asset) {
-		return errInvalidAsset
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
 	}
 
 	conn, err := setupClient()
@@ -2999,57 +7002,49 @@ func disableExchangePair(c *cli.Context) error {
 	}
 	defer conn.Close()
 
-	p := currency.NewPairDelimiter(pair, pairDelimiter)
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.DisableExchangePair(context.Background(),
-		&gctrpc.ExchangePairRequest{
-			Exchange: exchange,
-			Pair: &gctrpc.CurrencyPair{
-				Delimiter: p.Delimiter,
-				Base:      p.Base.String(),
-				Quote:     p.Quote.String(),
-			},
-			AssetType: asset,
-		},
-	)
+	result, err := client.GetCashFlow(context.Background(),
+		&gctrpc.GetCashFlowRequest{
+			Exchange:  exchangeName,
+			StartDate: s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:   e.UTC().Format(common.SimpleTimeFormat),
+		})
 	if err != nil {
 		return err
 	}
+
 	jsonOutput(result)
 	return nil
 }
 
-var getOrderbookStreamCommand = cli.Command{
-	Name:      "getorderbookstream",
-	Usage:     "gets the orderbook stream for a specific currency pair and exchange",
+var getOpenInterestCommand = cli.Command{
+	Name:      "getopeninterest",
+	Usage:     "gets the collected open interest of a derivatives contract",
 	ArgsUsage: "<exchange> <pair> <asset>",
-	Action:    getOrderbookStream,
+	Action:    getOpenInterest,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to get the orderbook from",
+			Usage: "the exchange to get the open interest for",
 		},
 		cli.StringFlag{
 			Name:  "pair",
-			Usage: "currency pair",
+			Usage: "the currency pair of the contract",
 		},
 		cli.StringFlag{
 			Name:  "asset",
-			Usage: "the asset type of the currency pair",
+			Usage: "the asset type of the contract",
 		},
 	},
 }
 
-func getOrderbookStream(c *cli.Context) error {
+func getOpenInterest(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getorderbookstream")
+		cli.ShowCommandHelp(c, "getopeninterest")
 		return nil
 	}
 
 	var exchangeName string
-	var pair string
-	var assetType string
-
 	if c.IsSet("exchange") {
 		exchangeName = c.String("exchange")
 	} else {
@@ -3060,16 +7055,18 @@ func getOrderbookStream(c *cli.Context) error {
 		return errInvalidExchange
 	}
 
+	var currencyPair string
 	if c.IsSet("pair") {
-		pair = c.String("pair")
+		currencyPair = c.String("pair")
 	} else {
-		pair = c.Args().Get(1)
+		currencyPair = c.Args().Get(1)
 	}
 
-	if !validPair(pair) {
+	if !validPair(currencyPair) {
 		return errInvalidPair
 	}
 
+	var assetType string
 	if c.IsSet("asset") {
 		assetType = c.String("asset")
 	} else {
@@ -3077,7 +7074,6 @@ func getOrderbookStream(c *cli.Context) error {
 	}
 
 	assetType = strings.ToLower(assetType)
-
 	if !validAsset(assetType) {
 		return errInvalidAsset
 	}
@@ -3088,98 +7084,56 @@ func getOrderbookStream(c *cli.Context) error {
 	}
 	defer conn.Close()
 
-	p := currency.NewPairDelimiter(pair, pairDelimiter)
-
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetOrderbookStream(context.Background(),
-		&gctrpc.GetOrderbookStreamRequest{
+	result, err := client.GetOpenInterest(context.Background(),
+		&gctrpc.GetOpenInterestRequest{
 			Exchange: exchangeName,
 			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
 				Base:      p.Base.String(),
 				Quote:     p.Quote.String(),
-				Delimiter: p.Delimiter,
 			},
 			AssetType: assetType,
-		},
-	)
-
+		})
 	if err != nil {
 		return err
 	}
 
-	for {
-		resp, err := result.Recv()
-		if err != nil {
-			return err
-		}
-
-		err = clearScreen()
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Orderbook stream for %s %s:\n\n", exchangeName,
-			resp.Pair.String())
-		fmt.Println("\t\tBids\t\t\t\tAsks")
-		fmt.Println()
-
-		bidLen := len(resp.Bids) - 1
-		askLen := len(resp.Asks) - 1
-
-		var maxLen int
-		if bidLen >= askLen {
-			maxLen = bidLen
-		} else {
-			maxLen = askLen
-		}
-
-		for i := 0; i < maxLen; i++ {
-			var bidAmount, bidPrice float64
-			if i <= bidLen {
-				bidAmount = resp.Bids[i].Amount
-				bidPrice = resp.Bids[i].Price
-			}
-
-			var askAmount, askPrice float64
-			if i <= askLen {
-				askAmount = resp.Asks[i].Amount
-				askPrice = resp.Asks[i].Price
-			}
-
-			fmt.Printf("%f %s @ %f %s\t\t%f %s @ %f %s\n",
-				bidAmount,
-				resp.Pair.Base,
-				bidPrice,
-				resp.Pair.Quote,
-				askAmount,
-				resp.Pair.Base,
-				askPrice,
-				resp.Pair.Quote)
-
-			if i >= 49 {
-				// limits orderbook display output
-				break
-			}
-		}
-	}
+	jsonOutput(result)
+	return nil
 }
 
-var getExchangeOrderbookStreamCommand = cli.Command{
-	Name:      "getexchangeorderbookstream",
-	Usage:     "gets a stream for all orderbooks associated with an exchange",
-	ArgsUsage: "<exchange>",
-	Action:    getExchangeOrderbookStream,
+var getLiquidationsCommand = cli.Command{
+	Name:      "getliquidations",
+	Usage:     "gets the collected liquidations of a derivatives contract",
+	ArgsUsage: "<exchange> <pair> <asset> <starttime>",
+	Action:    getLiquidations,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to get the orderbook from",
+			Usage: "the exchange to get the liquidations for",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair of the contract",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the contract",
+		},
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date to search",
+			Value:       time.Now().Add(-time.Hour * 24).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
 		},
 	},
 }
 
-func getExchangeOrderbookStream(c *cli.Context) error {
+func getLiquidations(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getexchangeorderbookstream")
+		cli.ShowCommandHelp(c, "getliquidations")
 		return nil
 	}
 
@@ -3194,70 +7148,87 @@ func getExchangeOrderbookStream(c *cli.Context) error {
 		return errInvalidExchange
 	}
 
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+
+	assetType = strings.ToLower(assetType)
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(3) != "" {
+			startTime = c.Args().Get(3)
+		}
+	}
+
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
 	conn, err := setupClient()
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetExchangeOrderbookStream(context.Background(),
-		&gctrpc.GetExchangeOrderbookStreamRequest{
+	result, err := client.GetLiquidations(context.Background(),
+		&gctrpc.GetLiquidationsRequest{
 			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType: assetType,
+			StartDate: s.UTC().Format(common.SimpleTimeFormat),
 		})
-
 	if err != nil {
 		return err
 	}
 
-	for {
-		resp, err := result.Recv()
-		if err != nil {
-			return err
-		}
-
-		err = clearScreen()
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Orderbook streamed for %s %s",
-			exchangeName,
-			resp.Pair.String())
-	}
+	jsonOutput(result)
+	return nil
 }
 
-var getTickerStreamCommand = cli.Command{
-	Name:      "gettickerstream",
-	Usage:     "gets the ticker stream for a specific currency pair and exchange",
-	ArgsUsage: "<exchange> <pair> <asset>",
-	Action:    getTickerStream,
+var getLiquidationStreamCommand = cli.Command{
+	Name:      "getliquidationstream",
+	Usage:     "gets a stream of the liquidations collected for an exchange's derivatives contracts",
+	ArgsUsage: "<exchange>",
+	Action:    getLiquidationStream,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to get the ticker from",
-		},
-		cli.StringFlag{
-			Name:  "pair",
-			Usage: "currency pair",
-		},
-		cli.StringFlag{
-			Name:  "asset",
-			Usage: "the asset type of the currency pair",
+			Usage: "the exchange to get the liquidations from",
 		},
 	},
 }
 
-func getTickerStream(c *cli.Context) error {
+func getLiquidationStream(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "gettickerstream")
+		cli.ShowCommandHelp(c, "getliquidationstream")
 		return nil
 	}
 
 	var exchangeName string
-	var pair string
-	var assetType string
-
 	if c.IsSet("exchange") {
 		exchangeName = c.String("exchange")
 	} else {
@@ -3268,97 +7239,75 @@ func getTickerStream(c *cli.Context) error {
 		return errInvalidExchange
 	}
 
-	if c.IsSet("pair") {
-		pair = c.String("pair")
-	} else {
-		pair = c.Args().Get(1)
-	}
-
-	if !validPair(pair) {
-		return errInvalidPair
-	}
-
-	if c.IsSet("asset") {
-		assetType = c.String("asset")
-	} else {
-		assetType = c.Args().Get(2)
-	}
-
-	assetType = strings.ToLower(assetType)
-
-	if !validAsset(assetType) {
-		return errInvalidAsset
-	}
-
 	conn, err := setupClient()
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
-	p := currency.NewPairDelimiter(pair, pairDelimiter)
-
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetTickerStream(context.Background(),
-		&gctrpc.GetTickerStreamRequest{
+	result, err := client.GetLiquidationStream(context.Background(),
+		&gctrpc.GetLiquidationStreamRequest{
 			Exchange: exchangeName,
-			Pair: &gctrpc.CurrencyPair{
-				Base:      p.Base.String(),
-				Quote:     p.Quote.String(),
-				Delimiter: p.Delimiter,
-			},
-			AssetType: assetType,
-		},
-	)
-
+		})
 	if err != nil {
 		return err
 	}
 
 	for {
 		resp, err := result.Recv()
-		if err != nil {
-			return err
-		}
-
-		err = clearScreen()
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Ticker stream for %s %s:\n", exchangeName,
-			resp.Pair.String())
-		fmt.Println()
+		if err != nil {
+			return err
+		}
 
-		fmt.Printf("LAST: %f\n HIGH: %f\n LOW: %f\n BID: %f\n ASK: %f\n VOLUME: %f\n PRICEATH: %f\n LASTUPDATED: %d\n",
-			resp.Last,
-			resp.High,
-			resp.Low,
-			resp.Bid,
-			resp.Ask,
-			resp.Volume,
-			resp.PriceAth,
-			resp.LastUpdated)
+		fmt.Printf("%s %s %s liquidation: SIDE: %s PRICE: %f AMOUNT: %f TIME: %s\n",
+			resp.Exchange,
+			resp.Pair,
+			resp.AssetType,
+			resp.Side,
+			resp.Price,
+			resp.Amount,
+			resp.Timestamp)
 	}
 }
 
-var getExchangeTickerStreamCommand = cli.Command{
-	Name:      "getexchangetickerstream",
-	Usage:     "gets a stream for all tickers associated with an exchange",
-	ArgsUsage: "<exchange>",
-	Action:    getExchangeTickerStream,
+var exportHistoryCommand = cli.Command{
+	Name:      "exporthistory",
+	Usage:     "exports an exchange's fills and transfers in a portfolio tracker's import format",
+	ArgsUsage: "<exchange> <format> <starttime> <endtime>",
+	Action:    exportHistory,
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to get the ticker from",
+			Usage: "the exchange to export",
+		},
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "the import format, koinly or cointracking",
+			Value: "koinly",
+		},
+		cli.StringFlag{
+			Name:        "start, s",
+			Usage:       "start date to export from",
+			Value:       time.Now().AddDate(-1, 0, 0).Format(common.SimpleTimeFormat),
+			Destination: &startTime,
+		},
+		cli.StringFlag{
+			Name:        "end, e",
+			Usage:       "end date to export to",
+			Value:       time.Now().Format(common.SimpleTimeFormat),
+			Destination: &endTime,
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "the file to write the export to, printed if unset",
 		},
 	},
 }
 
-func getExchangeTickerStream(c *cli.Context) error {
+func exportHistory(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
-		cli.ShowCommandHelp(c, "getexchangetickerstream")
-		return nil
+		return cli.ShowCommandHelp(c, "exporthistory")
 	}
 
 	var exchangeName string
@@ -3372,6 +7321,37 @@ func getExchangeTickerStream(c *cli.Context) error {
 		return errInvalidExchange
 	}
 
+	format := c.String("format")
+	if !c.IsSet("format") && c.Args().Get(1) != "" {
+		format = c.Args().Get(1)
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(2) != "" {
+			startTime = c.Args().Get(2)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(3) != "" {
+			endTime = c.Args().Get(3)
+		}
+	}
+
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
 	conn, err := setupClient()
 	if err != nil {
 		return err
@@ -3379,151 +7359,139 @@ func getExchangeTickerStream(c *cli.Context) error {
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-	result, err := client.GetExchangeTickerStream(context.Background(),
-		&gctrpc.GetExchangeTickerStreamRequest{
-			Exchange: exchangeName,
+	result, err := client.ExportHistory(context.Background(),
+		&gctrpc.ExportHistoryRequest{
+			Exchange:  exchangeName,
+			Format:    format,
+			StartDate: s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:   e.UTC().Format(common.SimpleTimeFormat),
 		})
-
 	if err != nil {
 		return err
 	}
 
-	for {
-		resp, err := result.Recv()
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Ticker stream for %s %s:\n",
-			exchangeName,
-			resp.Pair.String())
-
-		fmt.Printf("LAST: %f HIGH: %f LOW: %f BID: %f ASK: %f VOLUME: %f PRICEATH: %f LASTUPDATED: %d\n",
-			resp.Last,
-			resp.High,
-			resp.Low,
-			resp.Bid,
-			resp.Ask,
-			resp.Volume,
-			resp.PriceAth,
-			resp.LastUpdated)
+	if c.String("output") == "" {
+		fmt.Print(result.Data)
+		return nil
 	}
-}
 
-func clearScreen() error {
-	switch runtime.GOOS {
-	case "windows":
-		cmd := exec.Command("cmd", "/c", "cls")
-		cmd.Stdout = os.Stdout
-		return cmd.Run()
-	default:
-		cmd := exec.Command("clear")
-		cmd.Stdout = os.Stdout
-		return cmd.Run()
+	err = file.Write(c.String("output"), []byte(result.Data))
+	if err != nil {
+		return err
 	}
+	fmt.Printf("Exported %d %s records to %s\n", result.Records, result.Format, c.String("output"))
+	return nil
 }
 
-var getAuditEventCommand = cli.Command{
-	Name:      "getauditevent",
-	Usage:     "gets audit events matching query parameters",
-	ArgsUsage: "<starttime> <endtime> <orderby> <limit>",
-	Action:    getAuditEvent,
+var exportTaxReportCommand = cli.Command{
+	Name:      "exporttaxreport",
+	Usage:     "exports the disposals of all authenticated exchanges' trade history matched into FIFO or LIFO lots as CSV",
+	ArgsUsage: "<method> <starttime> <endtime>",
+	Action:    exportTaxReport,
 	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "method, m",
+			Usage: "the lot matching method, fifo or lifo",
+			Value: "fifo",
+		},
 		cli.StringFlag{
 			Name:        "start, s",
-			Usage:       "start date to search",
-			Value:       time.Now().Add(-time.Hour).Format(common.SimpleTimeFormat),
+			Usage:       "start date of the reporting period",
+			Value:       time.Now().AddDate(-1, 0, 0).Format(common.SimpleTimeFormat),
 			Destination: &startTime,
 		},
 		cli.StringFlag{
 			Name:        "end, e",
-			Usage:       "end time to search",
+			Usage:       "end date of the reporting period",
 			Value:       time.Now().Format(common.SimpleTimeFormat),
 			Destination: &endTime,
 		},
 		cli.StringFlag{
-			Name:        "order, o",
-			Usage:       "order results by ascending/descending",
-			Value:       "asc",
-			Destination: &order,
+			Name:  "history",
+			Usage: "the optional date to match lots from, so sales in the period are matched against earlier acquisitions, defaults to start",
 		},
-		cli.IntFlag{
-			Name:        "limit, l",
-			Usage:       "how many results to retrieve",
-			Value:       100,
-			Destination: &limit,
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "the file to write the report to, printed if unset",
 		},
 	},
 }
 
-func getAuditEvent(c *cli.Context) error {
-	if !c.IsSet("start") {
-		if c.Args().Get(0) != "" {
-			startTime = c.Args().Get(0)
-		}
+func exportTaxReport(c *cli.Context) error {
+	method := c.String("method")
+	if !c.IsSet("method") && c.Args().First() != "" {
+		method = c.Args().First()
 	}
 
-	if !c.IsSet("end") {
+	if !c.IsSet("start") {
 		if c.Args().Get(1) != "" {
-			endTime = c.Args().Get(1)
+			startTime = c.Args().Get(1)
 		}
 	}
 
-	if !c.IsSet("order") {
+	if !c.IsSet("end") {
 		if c.Args().Get(2) != "" {
-			order = c.Args().Get(2)
-		}
-	}
-
-	if !c.IsSet("limit") {
-		if c.Args().Get(3) != "" {
-			limitStr, err := strconv.ParseInt(c.Args().Get(3), 10, 64)
-			if err == nil {
-				limit = int(limitStr)
-			}
+			endTime = c.Args().Get(2)
 		}
 	}
 
-	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
 	if err != nil {
 		return fmt.Errorf("invalid time format for start: %v", err)
 	}
 
-	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
 	if err != nil {
 		return fmt.Errorf("invalid time format for end: %v", err)
 	}
 
 	if e.Before(s) {
-		return errors.New("start cannot be after before")
+		return errors.New("start cannot be after end")
+	}
+
+	var historyStart string
+	if c.String("history") != "" {
+		h, err := time.ParseInLocation(common.SimpleTimeFormat, c.String("history"), time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for history: %v", err)
+		}
+		if h.After(s) {
+			return errors.New("history cannot be after start")
+		}
+		historyStart = h.UTC().Format(common.SimpleTimeFormat)
 	}
 
 	conn, err := setupClient()
 	if err != nil {
 		return err
 	}
-
 	defer conn.Close()
 
 	client := gctrpc.NewGoCryptoTraderClient(conn)
-
-	_, offset := time.Now().Zone()
-	loc := time.FixedZone("", -offset)
-
-	result, err := client.GetAuditEvent(context.Background(),
-		&gctrpc.GetAuditEventRequest{
-			StartDate: s.In(loc).Format(common.SimpleTimeFormat),
-			EndDate:   e.In(loc).Format(common.SimpleTimeFormat),
-			Limit:     int32(limit),
-			OrderBy:   order,
-			Offset:    int32(offset),
+	result, err := client.ExportTaxReport(context.Background(),
+		&gctrpc.ExportTaxReportRequest{
+			Method:           method,
+			HistoryStartDate: historyStart,
+			StartDate:        s.UTC().Format(common.SimpleTimeFormat),
+			EndDate:          e.UTC().Format(common.SimpleTimeFormat),
 		})
-
 	if err != nil {
 		return err
 	}
 
-	jsonOutput(result)
+	if c.String("output") == "" {
+		fmt.Print(result.Data)
+		return nil
+	}
+
+	err = file.Write(c.String("output"), []byte(result.Data))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d %s disposals to %s\n", result.Disposals, result.Method, c.String("output"))
+	for code, gain := range result.Gains {
+		fmt.Printf("Total gain: %v %s\n", gain, code)
+	}
 	return nil
 }
 
@@ -3991,6 +7959,10 @@ var getHistoricCandlesCommand = cli.Command{
 			Value:       86400,
 			Destination: &candleGranularity,
 		},
+		cli.BoolFlag{
+			Name:  "fill_missing",
+			Usage: "inserts healed candles at the previous close for intervals the exchange returned no candle for",
+		},
 	},
 }
 
@@ -4076,6 +8048,108 @@ func getHistoricCandles(c *cli.Context) error {
 			Start:        start.Unix(),
 			End:          end.Unix(),
 			TimeInterval: int64(candleInterval),
+			FillMissing:  c.Bool("fill_missing"),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var getLiveCandlesCommand = cli.Command{
+	Name:      "getlivecandles",
+	Usage:     "gets the rolling candles built from the trade feed of a currency pair",
+	ArgsUsage: "<exchange> <pair> <asset> <granularity>",
+	Action:    getLiveCandles,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange, e",
+			Usage: "the exchange to get the candles from",
+		},
+		cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to get the candles for",
+		},
+		cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+		},
+		cli.Int64Flag{
+			Name:        "granularity, g",
+			Usage:       "the candle interval in seconds, one of the intervals configured for the candle builder e.g. {60 (1 Minute), 300 (5 Minute), 3600 (1 Hour)}",
+			Value:       60,
+			Destination: &candleGranularity,
+		},
+	},
+}
+
+func getLiveCandles(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		cli.ShowCommandHelp(c, "getlivecandles")
+		return nil
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	if c.IsSet("granularity") {
+		candleGranularity = c.Int64("granularity")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		candleGranularity, err = strconv.ParseInt(c.Args().Get(3), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetLiveCandles(context.Background(),
+		&gctrpc.GetLiveCandlesRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:    assetType,
+			TimeInterval: int64(time.Duration(candleGranularity) * time.Second),
 		})
 	if err != nil {
 		return err
@@ -20,6 +20,7 @@ var (
 	host          string
 	username      string
 	password      string
+	certPath      string
 	pairDelimiter string
 )
 
@@ -32,8 +33,7 @@ func jsonOutput(in interface{}) {
 }
 
 func setupClient() (*grpc.ClientConn, error) {
-	targetPath := filepath.Join(common.GetDefaultDataDir(runtime.GOOS), "tls", "cert.pem")
-	creds, err := credentials.NewClientTLSFromFile(targetPath, "")
+	creds, err := credentials.NewClientTLSFromFile(certPath, "")
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +77,12 @@ func main() {
 			Usage:       "the gRPC password",
 			Destination: &password,
 		},
+		cli.StringFlag{
+			Name:        "rpccert",
+			Value:       filepath.Join(common.GetDefaultDataDir(runtime.GOOS), "tls", "cert.pem"),
+			Usage:       "the path to the gRPC server's TLS certificate",
+			Destination: &certPath,
+		},
 		cli.StringFlag{
 			Name:        "delimiter",
 			Value:       "-",
@@ -97,10 +103,16 @@ func main() {
 		getExchangeOTPCommand,
 		getExchangeOTPsCommand,
 		getExchangeInfoCommand,
+		getExchangeHealthCommand,
+		getClockDriftCommand,
+		getMaintenanceWindowsCommand,
+		createDiagnosticsBundleCommand,
 		getTickerCommand,
 		getTickersCommand,
 		getOrderbookCommand,
 		getOrderbooksCommand,
+		getOrderbookAnalyticsCommand,
+		estimateSlippageCommand,
 		getAccountInfoCommand,
 		getAccountInfoStreamCommand,
 		getConfigCommand,
@@ -113,8 +125,39 @@ func main() {
 		getOrdersCommand,
 		getOrderCommand,
 		submitOrderCommand,
+		tradeCommand,
+		getChaseCommand,
+		getChasesCommand,
+		submitAlgoOrderCommand,
+		getAlgoOrderCommand,
+		getAlgoOrdersCommand,
+		cancelAlgoOrderCommand,
+		addConditionalOrderCommand,
+		getConditionalOrdersCommand,
+		cancelConditionalOrderCommand,
+		getAutomationsCommand,
+		reloadAutomationsCommand,
+		getStrategiesCommand,
+		startStrategyCommand,
+		stopStrategyCommand,
+		submitOCOCommand,
+		getOCOCommand,
+		getOCOsCommand,
+		cancelOCOCommand,
+		submitIntentCommand,
+		getIntentCommand,
+		getIntentsCommand,
+		getTriangularArbitrageCommand,
+		executeTriangularArbitrageCommand,
+		addStrategyOrderCommand,
+		removeStrategyOrderCommand,
+		allocateFillCommand,
+		getStrategyPositionsCommand,
+		getPositionsCommand,
+		syncTradeHistoryCommand,
 		simulateOrderCommand,
 		whaleBombCommand,
+		simulatePortfolioImpactCommand,
 		cancelOrderCommand,
 		cancelAllOrdersCommand,
 		getEventsCommand,
@@ -135,7 +178,20 @@ func main() {
 		getTickerStreamCommand,
 		getExchangeTickerStreamCommand,
 		getAuditEventCommand,
+		getEquityCurveCommand,
+		getStrategyPerformanceCommand,
+		optimiseStrategyCommand,
+		simulateStrategyCommand,
+		getDCAReportCommand,
+		getAuctionHistoryCommand,
+		getCashFlowCommand,
+		getOpenInterestCommand,
+		getLiquidationsCommand,
+		getLiquidationStreamCommand,
+		exportHistoryCommand,
+		exportTaxReportCommand,
 		getHistoricCandlesCommand,
+		getLiveCandlesCommand,
 		gctScriptCommand,
 	}
 
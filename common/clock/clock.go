@@ -0,0 +1,87 @@
+package clock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	system  = new(System)
+	mtx     sync.RWMutex
+	current Clock = system
+)
+
+// Now returns the current time of the clock in use
+func Now() time.Time {
+	mtx.RLock()
+	c := current
+	mtx.RUnlock()
+	return c.Now()
+}
+
+// Since returns the time elapsed since t by the clock in use
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Until returns the duration until t by the clock in use
+func Until(t time.Time) time.Duration {
+	return t.Sub(Now())
+}
+
+// Set replaces the clock used by all subsystems and returns the previous
+// clock so that it can be restored
+func Set(c Clock) Clock {
+	mtx.Lock()
+	defer mtx.Unlock()
+	prev := current
+	current = c
+	return prev
+}
+
+// Reset restores the system clock
+func Reset() {
+	Set(system)
+}
+
+// Now returns the wall time in UTC, or the most recent time returned if the
+// wall clock has since been stepped backwards
+func (s *System) Now() time.Time {
+	for {
+		now := time.Now().UnixNano()
+		last := atomic.LoadInt64(&s.last)
+		if now <= last {
+			return time.Unix(0, last).UTC()
+		}
+		if atomic.CompareAndSwapInt64(&s.last, last, now) {
+			return time.Unix(0, now).UTC()
+		}
+	}
+}
+
+// NewManual returns a manual clock set to t
+func NewManual(t time.Time) *Manual {
+	return &Manual{now: t.UTC()}
+}
+
+// Now returns the manual clock's time
+func (m *Manual) Now() time.Time {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	return m.now
+}
+
+// Set moves the manual clock to t, which may be before its current time
+func (m *Manual) Set(t time.Time) {
+	m.m.Lock()
+	m.now = t.UTC()
+	m.m.Unlock()
+}
+
+// Advance moves the manual clock forward by d
+func (m *Manual) Advance(d time.Duration) {
+	m.m.Lock()
+	m.now = m.now.Add(d)
+	m.m.Unlock()
+}
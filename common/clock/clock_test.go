@@ -0,0 +1,64 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSystem(t *testing.T) {
+	var s System
+	first := s.Now()
+	if first.Location() != time.UTC {
+		t.Errorf("expected UTC, got %s", first.Location())
+	}
+	if d := time.Since(first); d < 0 || d > time.Second {
+		t.Errorf("expected the wall time, got %s", first)
+	}
+
+	// a wall clock stepped backwards does not move the clock back
+	s.last = first.Add(time.Hour).UnixNano()
+	if now := s.Now(); !now.Equal(first.Add(time.Hour)) {
+		t.Errorf("expected %s, got %s", first.Add(time.Hour), now)
+	}
+}
+
+func TestManual(t *testing.T) {
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.FixedZone("AEDT", 11*60*60))
+	c := NewManual(start)
+	if !c.Now().Equal(start) || c.Now().Location() != time.UTC {
+		t.Errorf("expected %s in UTC, got %s", start, c.Now())
+	}
+	c.Advance(time.Minute)
+	if !c.Now().Equal(start.Add(time.Minute)) {
+		t.Errorf("expected %s, got %s", start.Add(time.Minute), c.Now())
+	}
+	c.Set(start.Add(-time.Hour))
+	if !c.Now().Equal(start.Add(-time.Hour)) {
+		t.Errorf("expected %s, got %s", start.Add(-time.Hour), c.Now())
+	}
+}
+
+func TestSet(t *testing.T) {
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := NewManual(start)
+	if prev := Set(c); prev != system {
+		t.Errorf("expected the system clock to be replaced, got %v", prev)
+	}
+	defer Reset()
+
+	c.Advance(time.Second * 5)
+	if !Now().Equal(start.Add(time.Second * 5)) {
+		t.Errorf("expected %s, got %s", start.Add(time.Second*5), Now())
+	}
+	if d := Since(start); d != time.Second*5 {
+		t.Errorf("expected 5s, got %s", d)
+	}
+	if d := Until(start.Add(time.Minute)); d != time.Second*55 {
+		t.Errorf("expected 55s, got %s", d)
+	}
+
+	Reset()
+	if d := time.Since(Now()); d < 0 || d > time.Second {
+		t.Errorf("expected the system clock to be restored, got %s", Now())
+	}
+}
@@ -0,0 +1,25 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. The system clock is used unless replaced,
+// for example by a manual clock in tests or when replaying historic data
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the system's wall clock in UTC, which never runs backwards so
+// nonces, staleness checks and durations between readings remain valid when
+// the wall clock is stepped back, for example by NTP
+type System struct {
+	last int64
+}
+
+// Manual is a clock which only moves when set or advanced
+type Manual struct {
+	m   sync.RWMutex
+	now time.Time
+}
@@ -1,11 +1,14 @@
 package common
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -44,6 +47,11 @@ const (
 // SimpleTimeFormat a common, but non-implemented time format in golang
 const SimpleTimeFormat = "2006-01-02 15:04:05"
 
+const (
+	defaultDialTimeout         = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+)
+
 func initialiseHTTPClient() {
 	// If the HTTPClient isn't set, start a new client with a default timeout of 15 seconds
 	if HTTPClient == nil {
@@ -58,6 +66,83 @@ func NewHTTPClientWithTimeout(t time.Duration) *http.Client {
 	return h
 }
 
+// HTTPClientOptions defines the settings used to build a HTTP client
+type HTTPClientOptions struct {
+	Timeout             time.Duration
+	Proxy               string
+	CACertFile          string
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	TLSHandshakeTimeout time.Duration
+	DisableKeepAlives   bool
+}
+
+// NewHTTPClient initialises a new HTTP client from the supplied options. The
+// proxy can be a HTTP, HTTPS or SOCKS5 URL and the CA certificate file is
+// trusted in addition to the system certificate pool
+func NewHTTPClient(o *HTTPClientOptions) (*http.Client, error) {
+	if o == nil {
+		return nil, errors.New("HTTP client options cannot be nil")
+	}
+
+	tlsTimeout := o.TLSHandshakeTimeout
+	if tlsTimeout <= 0 {
+		tlsTimeout = defaultTLSHandshakeTimeout
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   defaultDialTimeout,
+		KeepAlive: o.KeepAlive,
+	}
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		MaxIdleConns:        o.MaxIdleConns,
+		MaxIdleConnsPerHost: o.MaxIdleConnsPerHost,
+		IdleConnTimeout:     o.IdleConnTimeout,
+		TLSHandshakeTimeout: tlsTimeout,
+		DisableKeepAlives:   o.DisableKeepAlives,
+	}
+
+	if o.Proxy != "" {
+		proxy, err := url.Parse(o.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q", proxy.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if o.CACertFile != "" {
+		pem, err := ioutil.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.CACertFile)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   o.Timeout,
+		Transport: transport,
+	}, nil
+}
+
 // StringSliceDifference concatenates slices together based on its index and
 // returns an individual string array
 func StringSliceDifference(slice1, slice2 []string) []string {
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"os/user"
@@ -10,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestIsEnabled(t *testing.T) {
@@ -174,6 +177,61 @@ func TestYesOrNo(t *testing.T) {
 	}
 }
 
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+	_, err := NewHTTPClient(nil)
+	if err == nil {
+		t.Error("expected error on nil options")
+	}
+
+	c, err := NewHTTPClient(&HTTPClientOptions{
+		Timeout:             time.Second,
+		Proxy:               "socks5://127.0.0.1:1080",
+		MaxIdleConnsPerHost: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport, ok := c.Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("expected HTTP transport")
+	}
+	if c.Timeout != time.Second ||
+		transport.MaxIdleConnsPerHost != 5 ||
+		transport.TLSHandshakeTimeout != defaultTLSHandshakeTimeout {
+		t.Error("HTTP client options not applied")
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://www.google.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy.String() != "socks5://127.0.0.1:1080" {
+		t.Errorf("expected SOCKS5 proxy, got %v %v", proxy, err)
+	}
+
+	_, err = NewHTTPClient(&HTTPClientOptions{Proxy: "ftp://127.0.0.1"})
+	if err == nil {
+		t.Error("expected error on unsupported proxy scheme")
+	}
+
+	_, err = NewHTTPClient(&HTTPClientOptions{CACertFile: "nonexistent.pem"})
+	if err == nil {
+		t.Error("expected error on missing CA certificate file")
+	}
+
+	f, err := ioutil.TempFile("", "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+	_, err = NewHTTPClient(&HTTPClientOptions{CACertFile: f.Name()})
+	if err == nil {
+		t.Error("expected error on CA certificate file without certificates")
+	}
+}
+
 func TestSendHTTPRequest(t *testing.T) {
 	methodPost := "pOst"
 	methodGet := "GeT"
@@ -0,0 +1,322 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var errDivisionByZero = errors.New("decimal division by zero")
+
+// maxParseScale bounds the decimal places, or powers of ten when negative,
+// of a parsed decimal so malformed input cannot allocate huge numbers
+const maxParseScale = 1000
+
+// rounding modes of a coefficient's discarded digits
+const (
+	roundTruncate = iota
+	roundHalfUp
+	roundFloor
+	roundCeil
+)
+
+// New returns the decimal value * 10^-scale, so New(12345, 2) is 123.45
+func New(value int64, scale int32) Decimal {
+	coef := big.NewInt(value)
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// NewFromFloat returns the shortest decimal which converts back to f, so 0.1
+// is exactly 0.1. NaN and infinities are returned as zero
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+	d, err := NewFromString(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// NewFromString parses a decimal in plain or exponent notation, such as
+// "-123.45" or "1.5e-8"
+func NewFromString(s string) (Decimal, error) {
+	digits := s
+	var exp int64
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(digits[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("%v %q", errInvalidDecimal, s)
+		}
+		digits = digits[:i]
+	}
+	var scale int64
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		scale = int64(len(digits) - i - 1)
+		digits = digits[:i] + digits[i+1:]
+	}
+	unsigned := digits
+	if unsigned != "" && (unsigned[0] == '-' || unsigned[0] == '+') {
+		unsigned = unsigned[1:]
+	}
+	if unsigned == "" || strings.TrimLeft(unsigned, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("%v %q", errInvalidDecimal, s)
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%v %q", errInvalidDecimal, s)
+	}
+
+	scale -= exp
+	if scale > maxParseScale || scale < -maxParseScale {
+		return Decimal{}, fmt.Errorf("%v %q: exponent out of range", errInvalidDecimal, s)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// Add returns d + d2
+func (d Decimal) Add(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return Decimal{coef: x.Add(x, y), scale: scale}
+}
+
+// Sub returns d - d2
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return Decimal{coef: x.Sub(x, y), scale: scale}
+}
+
+// Mul returns d * d2
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{
+		coef:  new(big.Int).Mul(d.value(), d2.value()),
+		scale: d.scale + d2.scale,
+	}
+}
+
+// Div returns d / d2 rounded half away from zero to places decimal places
+func (d Decimal) Div(d2 Decimal, places int32) (Decimal, error) {
+	if d2.IsZero() {
+		return Decimal{}, errDivisionByZero
+	}
+	if places < 0 {
+		places = 0
+	}
+	// d / d2 = (d.coef * 10^(d2.scale + places + 1) / d2.coef) *
+	// 10^-(d.scale + places + 1), the extra place is used for rounding
+	num := new(big.Int).Mul(d.value(), pow10(d2.scale+places+1))
+	q := num.Quo(num, d2.value())
+	return Decimal{coef: q, scale: d.scale + places + 1}.round(places, roundHalfUp), nil
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+// Abs returns the absolute value of d
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.value()), scale: d.scale}
+}
+
+// Cmp returns -1, 0 or 1 when d is less than, equal to or greater than d2
+func (d Decimal) Cmp(d2 Decimal) int {
+	x, y, _ := align(d, d2)
+	return x.Cmp(y)
+}
+
+// Equal returns whether d and d2 have the same value, regardless of scale
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// Sign returns -1, 0 or 1 when d is negative, zero or positive
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero returns whether d is zero
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Round returns d rounded half away from zero to places decimal places
+func (d Decimal) Round(places int32) Decimal {
+	return d.round(places, roundHalfUp)
+}
+
+// Truncate returns d with the digits after places decimal places discarded
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.round(places, roundTruncate)
+}
+
+// FloorToStep returns the greatest multiple of step which is not greater
+// than d, such as an amount rounded down to an exchange's quantity increment.
+// d is returned as is when step is not positive
+func (d Decimal) FloorToStep(step Decimal) Decimal {
+	return d.roundToStep(step, roundFloor)
+}
+
+// CeilToStep returns the least multiple of step which is not less than d,
+// such as a sell price rounded up to an exchange's tick size. d is returned as
+// is when step is not positive
+func (d Decimal) CeilToStep(step Decimal) Decimal {
+	return d.roundToStep(step, roundCeil)
+}
+
+// Float64 returns the nearest float64 to d
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.value(), pow10(d.scale)).Float64()
+	return f
+}
+
+// String returns d in plain notation without trailing zeros, such as 0.3
+func (d Decimal) String() string {
+	s := d.format()
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// StringFixed returns d rounded half away from zero to places decimal places
+// in plain notation, padded with trailing zeros, such as 0.30
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	return d.Round(places).format()
+}
+
+// MarshalJSON encodes d as a string so that its precision is retained
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a decimal from a string or number. Null and empty
+// strings are decoded as zero
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := NewFromString(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) value() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at a scale which is not less than its
+// own
+func (d Decimal) rescale(scale int32) *big.Int {
+	c := new(big.Int).Set(d.value())
+	if scale > d.scale {
+		c.Mul(c, pow10(scale-d.scale))
+	}
+	return c
+}
+
+// align returns the coefficients of two decimals at their greatest scale
+func align(d, d2 Decimal) (x, y *big.Int, scale int32) {
+	scale = d.scale
+	if d2.scale > scale {
+		scale = d2.scale
+	}
+	return d.rescale(scale), d2.rescale(scale), scale
+}
+
+// round returns d at exactly places decimal places, rounding its discarded
+// digits by mode
+func (d Decimal) round(places int32, mode int) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return Decimal{coef: d.rescale(places), scale: places}
+	}
+	divisor := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.value(), divisor, new(big.Int))
+	adjustRemainder(q, r, divisor, d.value().Sign(), mode)
+	return Decimal{coef: q, scale: places}
+}
+
+func (d Decimal) roundToStep(step Decimal, mode int) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	x, y, scale := align(d, step)
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	adjustRemainder(q, r, y, x.Sign(), mode)
+	return Decimal{coef: q.Mul(q, y), scale: scale}
+}
+
+// adjustRemainder adjusts a quotient truncated towards zero by the rounding
+// mode of its remainder, where sign is the sign of the dividend
+func adjustRemainder(q, r, divisor *big.Int, sign, mode int) {
+	if r.Sign() == 0 {
+		return
+	}
+	switch mode {
+	case roundHalfUp:
+		twice := new(big.Int).Abs(r)
+		if twice.Lsh(twice, 1).Cmp(divisor) >= 0 {
+			q.Add(q, big.NewInt(int64(sign)))
+		}
+	case roundFloor:
+		if sign < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case roundCeil:
+		if sign > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+}
+
+// format returns d in plain notation at its scale
+func (d Decimal) format() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,157 @@
+package decimal
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewFromString(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{"0", "0"},
+		{"-0", "0"},
+		{"123.450", "123.45"},
+		{"-0.001", "-0.001"},
+		{"+12", "12"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"1.5e-8", "0.000000015"},
+		{"1.5E3", "1500"},
+		{"00012.3400", "12.34"},
+		{"1e1000", "1" + strings.Repeat("0", 1000)},
+	} {
+		d, err := NewFromString(tc.in)
+		if err != nil {
+			t.Errorf("%s: %v", tc.in, err)
+			continue
+		}
+		if d.String() != tc.out {
+			t.Errorf("%s: expected %s, got %s", tc.in, tc.out, d.String())
+		}
+	}
+	for _, in := range []string{"", ".", "-", "1.2.3", "abc", "1e", "1e1.5", "1_000", "--1", "0x10", "1e2000000000", "1e-2000000000", "1e1001"} {
+		if _, err := NewFromString(in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
+
+func TestNewFromFloat(t *testing.T) {
+	if d := NewFromFloat(0.1); d.String() != "0.1" {
+		t.Errorf("expected 0.1, got %s", d)
+	}
+	if d := NewFromFloat(-1234.5678); d.String() != "-1234.5678" {
+		t.Errorf("expected -1234.5678, got %s", d)
+	}
+	if d := NewFromFloat(math.NaN()); !d.IsZero() {
+		t.Errorf("expected NaN to be zero, got %s", d)
+	}
+	if d := NewFromFloat(math.Inf(1)); !d.IsZero() {
+		t.Errorf("expected infinity to be zero, got %s", d)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := NewFromFloat(0.1), NewFromFloat(0.2)
+	if sum := a.Add(b); sum.String() != "0.3" || sum.Float64() != 0.3 {
+		t.Errorf("expected 0.1 + 0.2 to be 0.3, got %s", sum)
+	}
+	if diff := a.Sub(b); diff.String() != "-0.1" {
+		t.Errorf("expected -0.1, got %s", diff)
+	}
+	if product := New(15, 1).Mul(New(-25, 2)); product.String() != "-0.375" {
+		t.Errorf("expected -0.375, got %s", product)
+	}
+	if product := New(3, -2); product.String() != "300" {
+		t.Errorf("expected 300, got %s", product)
+	}
+	q, err := New(2, 0).Div(New(3, 0), 4)
+	if err != nil || q.String() != "0.6667" {
+		t.Errorf("expected 0.6667, got %s %v", q, err)
+	}
+	if q, err = New(-1, 0).Div(New(8, 0), 2); err != nil || q.String() != "-0.13" {
+		t.Errorf("expected -0.13, got %s %v", q, err)
+	}
+	if _, err = a.Div(Decimal{}, 2); err != errDivisionByZero {
+		t.Errorf("expected %v, got %v", errDivisionByZero, err)
+	}
+
+	var zero Decimal
+	if !zero.IsZero() || zero.Sign() != 0 || zero.String() != "0" || zero.Add(a).String() != "0.1" {
+		t.Error("expected the zero value to be zero")
+	}
+	if a.Neg().Abs().Cmp(a) != 0 || a.Cmp(b) != -1 || b.Cmp(a) != 1 || !New(10, 1).Equal(New(1, 0)) {
+		t.Error("unexpected comparison")
+	}
+}
+
+func TestRounding(t *testing.T) {
+	d := NewFromFloat(-2.345)
+	if r := d.Round(2); r.String() != "-2.35" {
+		t.Errorf("expected -2.35, got %s", r)
+	}
+	if r := d.Truncate(2); r.String() != "-2.34" {
+		t.Errorf("expected -2.34, got %s", r)
+	}
+	if s := NewFromFloat(0.3).StringFixed(4); s != "0.3000" {
+		t.Errorf("expected 0.3000, got %s", s)
+	}
+	if s := NewFromFloat(0.005).StringFixed(2); s != "0.01" {
+		t.Errorf("expected 0.01, got %s", s)
+	}
+	if s := NewFromFloat(1.5).StringFixed(-1); s != "2" {
+		t.Errorf("expected 2, got %s", s)
+	}
+
+	step := NewFromFloat(0.00025)
+	for _, tc := range []struct {
+		in          float64
+		floor, ceil string
+	}{
+		{1.00026, "1.00025", "1.0005"},
+		{1.0005, "1.0005", "1.0005"},
+		{-1.00026, "-1.0005", "-1.00025"},
+		{0.0001, "0", "0.00025"},
+	} {
+		d := NewFromFloat(tc.in)
+		if r := d.FloorToStep(step); r.String() != tc.floor {
+			t.Errorf("%v: expected floor %s, got %s", tc.in, tc.floor, r)
+		}
+		if r := d.CeilToStep(step); r.String() != tc.ceil {
+			t.Errorf("%v: expected ceil %s, got %s", tc.in, tc.ceil, r)
+		}
+	}
+	if r := d.FloorToStep(Decimal{}); !r.Equal(d) {
+		t.Errorf("expected an unset step to be ignored, got %s", r)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Price  Decimal `json:"price"`
+		Amount Decimal `json:"amount"`
+		Fee    Decimal `json:"fee"`
+		Empty  Decimal `json:"empty"`
+	}
+	err := json.Unmarshal([]byte(`{"price":"9000.10","amount":0.30000000000000004,"fee":null,"empty":""}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Price.String() != "9000.1" || v.Amount.String() != "0.30000000000000004" ||
+		!v.Fee.IsZero() || !v.Empty.IsZero() {
+		t.Errorf("unexpected decimals %+v", v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"price":"9000.1","amount":"0.30000000000000004","fee":"0","empty":"0"}` {
+		t.Errorf("unexpected JSON %s", b)
+	}
+	if err = json.Unmarshal([]byte(`{"price":"abc"}`), &v); err == nil {
+		t.Error("expected an error for an invalid decimal")
+	}
+}
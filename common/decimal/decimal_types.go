@@ -0,0 +1,18 @@
+package decimal
+
+import (
+	"errors"
+	"math/big"
+)
+
+var errInvalidDecimal = errors.New("invalid decimal")
+
+// Decimal is an exact base 10 number of arbitrary precision, used for prices
+// and amounts which must not pick up binary floating point error such as
+// 0.1 + 0.2 = 0.30000000000000004. Its value is its coefficient multiplied by
+// ten to the power of its negated scale. Decimals are immutable and the zero
+// value is zero
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
@@ -24,6 +24,14 @@ type Event struct {
 	Message string
 }
 
+// Email is a message with alternative plaintext and HTML bodies sent by the
+// communication packages which send email
+type Email struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
 // CommsStatus stores the status of a comms relayer
 type CommsStatus struct {
 	Enabled   bool `json:"enabled"`
@@ -2,6 +2,7 @@ package base
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -21,6 +22,15 @@ type ICommunicate interface {
 	GetName() string
 }
 
+// ErrNoEmailMediums is returned when no enabled and connected communication
+// package sends email
+var ErrNoEmailMediums = errors.New("no email communication mediums are enabled")
+
+// Emailer is implemented by communication packages which send email
+type Emailer interface {
+	SendEmail(Email) error
+}
+
 // Setup sets up communication variables and intiates a connection to the
 // communication mediums
 func (c IComm) Setup() {
@@ -50,6 +60,31 @@ func (c IComm) PushEvent(event Event) {
 	}
 }
 
+// SendEmail sends an email through each enabled and connected communication
+// package which sends email
+func (c IComm) SendEmail(e Email) error {
+	var sent int
+	var errs []string
+	for i := range c {
+		m, ok := c[i].(Emailer)
+		if !ok || !c[i].IsEnabled() || !c[i].IsConnected() {
+			continue
+		}
+		if err := m.SendEmail(e); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", c[i].GetName(), err))
+			continue
+		}
+		sent++
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to send email %s", errs)
+	}
+	if sent == 0 {
+		return ErrNoEmailMediums
+	}
+	return nil
+}
+
 // GetStatus returns the status of the comms relayers
 func (c IComm) GetStatus() map[string]CommsStatus {
 	result := make(map[string]CommsStatus)
@@ -127,3 +127,30 @@ func TestPushEvent(t *testing.T) {
 		}
 	}
 }
+
+type emailProvider struct {
+	CommunicationProvider
+	sent []Email
+}
+
+func (p *emailProvider) SendEmail(e Email) error {
+	p.sent = append(p.sent, e)
+	return nil
+}
+
+func TestSendEmail(t *testing.T) {
+	ic := IComm{&CommunicationProvider{isEnabled: true, isConnected: true}}
+	if err := ic.SendEmail(Email{}); err != ErrNoEmailMediums {
+		t.Errorf("expected %v, got %v", ErrNoEmailMediums, err)
+	}
+
+	email := &emailProvider{CommunicationProvider: CommunicationProvider{isEnabled: true, isConnected: true}}
+	disabled := &emailProvider{CommunicationProvider: CommunicationProvider{isConnected: true}}
+	ic = append(ic, email, disabled)
+	if err := ic.SendEmail(Email{Subject: "report"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(email.sent) != 1 || email.sent[0].Subject != "report" || len(disabled.sent) != 0 {
+		t.Error("expected the email to be sent by the enabled email provider only")
+	}
+}
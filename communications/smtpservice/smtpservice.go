@@ -1,9 +1,12 @@
 package smtpservice
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
 	"strings"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
@@ -74,14 +77,65 @@ func (s *SMTPservice) Send(subject, msg string) error {
 		mime,
 		msg)
 
-	err := smtp.SendMail(
+	return s.sendMail([]byte(messageToSend))
+}
+
+// SendEmail sends an email with plaintext and HTML alternative bodies to the
+// recipient list via your SMTP host
+func (s *SMTPservice) SendEmail(e base.Email) error {
+	if e.Subject == "" || (e.Text == "" && e.HTML == "") {
+		return errors.New("STMPservice SendEmail() please add subject and body")
+	}
+
+	log.Debugf(log.CommunicationMgr, "SMTP: Sending email to %v. Subject: %s [From: %s]\n", s.RecipientList,
+		e.Subject, s.From)
+	msg, err := s.multipartMessage(&e)
+	if err != nil {
+		return err
+	}
+	return s.sendMail(msg)
+}
+
+// multipartMessage returns an email with its bodies as multipart/alternative
+// parts, ordered plaintext first so clients display the HTML body when they
+// are able to
+func (s *SMTPservice) multipartMessage(e *base.Email) ([]byte, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType, content string
+	}{
+		{"text/plain", e.Text},
+		{"text/html", e.HTML},
+	} {
+		if part.content == "" {
+			continue
+		}
+		p, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {part.contentType + `; charset="UTF-8"`},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("To: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n",
+		s.RecipientList,
+		e.Subject,
+		w.Boundary())
+	return append([]byte(header), body.Bytes()...), nil
+}
+
+func (s *SMTPservice) sendMail(msg []byte) error {
+	return smtp.SendMail(
 		s.Host+":"+s.Port,
 		smtp.PlainAuth("", s.AccountName, s.AccountPassword, s.Host),
 		s.From,
 		strings.Split(s.RecipientList, ","),
-		[]byte(messageToSend))
-	if err != nil {
-		return err
-	}
-	return nil
+		msg)
 }
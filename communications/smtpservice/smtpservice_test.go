@@ -1,6 +1,11 @@
 package smtpservice
 
 import (
+	"io/ioutil"
+	mimetype "mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
@@ -43,3 +48,52 @@ func TestSend(t *testing.T) {
 		t.Error("smtpservice Send() error cannot be nil")
 	}
 }
+
+func TestSendEmail(t *testing.T) {
+	err := s.SendEmail(base.Email{Subject: "subject"})
+	if err == nil {
+		t.Error("smtpservice SendEmail() error cannot be nil")
+	}
+	err = s.SendEmail(base.Email{Subject: "subject", Text: "text", HTML: "<p>html</p>"})
+	if err == nil {
+		t.Error("smtpservice SendEmail() error cannot be nil")
+	}
+}
+
+func TestMultipartMessage(t *testing.T) {
+	svc := SMTPservice{RecipientList: "a@example.com,b@example.com"}
+	b, err := svc.multipartMessage(&base.Email{Subject: "Daily report", Text: "text", HTML: "<p>html</p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Header.Get("Subject") != "Daily report" || msg.Header.Get("To") != svc.RecipientList {
+		t.Errorf("unexpected headers %v", msg.Header)
+	}
+	mediaType, params, err := mimetype.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %s %v", mediaType, err)
+	}
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for _, expected := range []struct {
+		contentType, content string
+	}{
+		{"text/plain", "text"},
+		{"text/html", "<p>html</p>"},
+	} {
+		p, err := r.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(p.Header.Get("Content-Type"), expected.contentType) || string(content) != expected.content {
+			t.Errorf("expected %s part %s, got %s %s", expected.contentType, expected.content, p.Header.Get("Content-Type"), content)
+		}
+	}
+}
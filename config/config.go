@@ -2,6 +2,7 @@ package config
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -21,10 +22,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider"
 	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/eventbus"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/secrets"
+	"github.com/thrasher-corp/gocryptotrader/timeseries"
 )
 
 // GetCurrencyConfig returns currency configurations
@@ -166,6 +172,11 @@ func (c *Config) PurgeExchangeAPICredentials() {
 
 		c.Exchanges[x].API.Credentials.PEMKey = ""
 		c.Exchanges[x].API.Credentials.OTPSecret = ""
+		c.Exchanges[x].API.Keys = nil
+		c.Exchanges[x].API.Accounts = nil
+		if c.Exchanges[x].API.CredentialsProvider != nil {
+			c.Exchanges[x].API.CredentialsProvider.Encrypted = nil
+		}
 	}
 }
 
@@ -741,6 +752,306 @@ func (c *Config) UpdateExchangeConfig(e *ExchangeConfig) error {
 	return fmt.Errorf(ErrExchangeNotFound, e.Name)
 }
 
+// checkExchangeAccounts removes additional accounts which are unnamed, share a
+// name, have a name containing the account delimiter or have empty or default
+// credentials
+func (c *Config) checkExchangeAccounts(exch *ExchangeConfig) {
+	accounts := exch.API.Accounts[:0]
+	names := make(map[string]bool)
+	for x := range exch.API.Accounts {
+		a := exch.API.Accounts[x]
+		name := strings.ToLower(strings.TrimSpace(a.Name))
+		switch {
+		case name == "", strings.Contains(name, AccountDelimiter):
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s account name %q is invalid, removing.\n",
+				exch.Name,
+				a.Name)
+			continue
+		case names[name]:
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s account %q is duplicated, removing.\n",
+				exch.Name,
+				a.Name)
+			continue
+		case a.Credentials.Key == "" || a.Credentials.Key == DefaultAPIKey:
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s account %q API key is empty or default, removing.\n",
+				exch.Name,
+				a.Name)
+			continue
+		}
+		names[name] = true
+		a.Name = strings.TrimSpace(a.Name)
+		accounts = append(accounts, a)
+	}
+	exch.API.Accounts = accounts
+	if len(accounts) == 0 {
+		exch.API.Accounts = nil
+	}
+}
+
+// hasAccount returns whether an account exchange name refers to one of the
+// exchange's additional accounts
+func (e *ExchangeConfig) hasAccount(name string) bool {
+	for x := range e.API.Accounts {
+		if strings.EqualFold(e.Name+AccountDelimiter+e.API.Accounts[x].Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkExchangeAPIKeys removes additional API keys which are empty, default or
+// have an unknown permission. When only additional keys are set the first
+// which can trade becomes the exchange's credentials, which are used for
+// requests the keys cannot be selected for
+func (c *Config) checkExchangeAPIKeys(exch *ExchangeConfig) {
+	keys := exch.API.Keys[:0]
+	for x := range exch.API.Keys {
+		k := &exch.API.Keys[x]
+		if k.Key == "" || k.Key == DefaultAPIKey {
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s API key %q is empty or default, removing.\n",
+				exch.Name,
+				k.Name)
+			continue
+		}
+		valid := true
+		for y := range k.Permissions {
+			k.Permissions[y] = strings.ToLower(k.Permissions[y])
+			if k.Permissions[y] != APIKeyPermissionRead &&
+				k.Permissions[y] != APIKeyPermissionTrade {
+				log.Warnf(log.ExchangeSys,
+					"Exchange %s API key %q has invalid permission %q, removing.\n",
+					exch.Name,
+					k.Name,
+					k.Permissions[y])
+				valid = false
+				break
+			}
+		}
+		if valid {
+			keys = append(keys, *k)
+		}
+	}
+	exch.API.Keys = keys
+	if len(keys) == 0 {
+		exch.API.Keys = nil
+		return
+	}
+
+	if exch.API.Credentials.Key != "" && exch.API.Credentials.Key != DefaultAPIKey {
+		return
+	}
+	for x := range keys {
+		if len(keys[x].Permissions) != 0 &&
+			!common.StringDataCompare(keys[x].Permissions, APIKeyPermissionTrade) {
+			continue
+		}
+		exch.API.Credentials.Key = keys[x].Key
+		exch.API.Credentials.Secret = keys[x].Secret
+		exch.API.Credentials.ClientID = keys[x].ClientID
+		exch.API.Credentials.PEMKey = keys[x].PEMKey
+		return
+	}
+}
+
+// checkCredentialsProvider removes an unknown credentials provider or one
+// reading from the config file and sets the default environment variable
+// prefix and Vault path of the exchange
+func (c *Config) checkCredentialsProvider(exch *ExchangeConfig) {
+	p := exch.API.CredentialsProvider
+	if p == nil {
+		return
+	}
+	p.Type = strings.ToLower(strings.TrimSpace(p.Type))
+	switch p.Type {
+	case "", secrets.ConfigProvider:
+		exch.API.CredentialsProvider = nil
+	case secrets.EnvProvider:
+		if p.EnvPrefix == "" {
+			p.EnvPrefix = secrets.DefaultEnvPrefix(exch.Name)
+		}
+	case secrets.VaultProvider:
+		if p.VaultPath == "" {
+			p.VaultPath = "gocryptotrader/" + strings.ToLower(exch.Name)
+		}
+	case secrets.KMSProvider:
+		if p.Encrypted == nil || p.Encrypted.Key == "" {
+			log.Warnf(log.ConfigMgr,
+				"Exchange %s kms credentials provider has no encrypted API key.\n",
+				exch.Name)
+		}
+	default:
+		log.Warnf(log.ConfigMgr,
+			"Exchange %s credentials provider %q is not supported, using the config file credentials.\n",
+			exch.Name,
+			p.Type)
+		exch.API.CredentialsProvider = nil
+	}
+}
+
+// isPollingPriority returns whether a priority is a pair polling tier
+func isPollingPriority(priority string) bool {
+	return priority == PollingPriorityHigh ||
+		priority == PollingPriorityNormal ||
+		priority == PollingPriorityLow
+}
+
+// checkPairPolling sets the default intervals of the high and low priority
+// tiers and removes unknown tiers and pairs which are invalid or set neither a
+// priority nor an interval
+func (c *Config) checkPairPolling(exch *ExchangeConfig) {
+	p := exch.PairPolling
+	if p == nil {
+		return
+	}
+
+	intervals := make(map[string]time.Duration, len(p.Intervals))
+	for tier, interval := range p.Intervals {
+		tier = strings.ToLower(tier)
+		if !isPollingPriority(tier) || interval < 0 {
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s pair polling tier %s interval %s is invalid, removing.\n",
+				exch.Name,
+				tier,
+				interval)
+			continue
+		}
+		intervals[tier] = interval
+	}
+	if intervals[PollingPriorityHigh] == 0 {
+		intervals[PollingPriorityHigh] = defaultHighPriorityPolling
+	}
+	if intervals[PollingPriorityLow] == 0 {
+		intervals[PollingPriorityLow] = defaultLowPriorityPolling
+	}
+	p.Intervals = intervals
+
+	p.DefaultPriority = strings.ToLower(p.DefaultPriority)
+	if p.DefaultPriority == "" {
+		p.DefaultPriority = PollingPriorityNormal
+	} else if !isPollingPriority(p.DefaultPriority) {
+		log.Warnf(log.ExchangeSys,
+			"Exchange %s pair polling default priority %s is invalid, defaulting to %s.\n",
+			exch.Name,
+			p.DefaultPriority,
+			PollingPriorityNormal)
+		p.DefaultPriority = PollingPriorityNormal
+	}
+
+	pairs := p.Pairs[:0]
+	for x := range p.Pairs {
+		pp := p.Pairs[x]
+		pp.Priority = strings.ToLower(pp.Priority)
+		pp.Asset = asset.Item(strings.ToLower(pp.Asset.String()))
+		if pp.Asset == "" {
+			pp.Asset = asset.Spot
+		}
+		switch {
+		case pp.Pair.IsEmpty(), !asset.IsValid(pp.Asset):
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s pair polling entry #%d requires a pair and valid asset, removing.\n",
+				exch.Name,
+				x)
+			continue
+		case pp.Priority == "" && pp.Interval == 0,
+			pp.Priority != "" && !isPollingPriority(pp.Priority),
+			pp.Interval < 0:
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s pair polling %s %s requires a valid priority or interval, removing.\n",
+				exch.Name,
+				pp.Pair,
+				pp.Asset)
+			continue
+		}
+		pairs = append(pairs, pp)
+	}
+	p.Pairs = pairs
+}
+
+// PollingInterval returns the polling priority tier and interval of a pair.
+// Normal priority pairs without an interval return zero and poll at the
+// syncer's timeout
+func (p *PairPollingConfig) PollingInterval(pair currency.Pair, a asset.Item) (string, time.Duration) {
+	priority := p.DefaultPriority
+	if priority == "" {
+		priority = PollingPriorityNormal
+	}
+	for x := range p.Pairs {
+		if !p.Pairs[x].Pair.Equal(pair) || p.Pairs[x].Asset != a {
+			continue
+		}
+		if p.Pairs[x].Priority != "" {
+			priority = p.Pairs[x].Priority
+		}
+		if p.Pairs[x].Interval > 0 {
+			return priority, p.Pairs[x].Interval
+		}
+		break
+	}
+	return priority, p.Intervals[priority]
+}
+
+// checkMaintenance removes maintenance windows which do not end after they
+// start or which repeat before they end
+func (c *Config) checkMaintenance(exch *ExchangeConfig) {
+	mt := exch.Maintenance
+	if mt == nil {
+		return
+	}
+
+	mt.StatusPage = strings.TrimSuffix(mt.StatusPage, "/")
+	windows := mt.Windows[:0]
+	for x := range mt.Windows {
+		w := mt.Windows[x]
+		w.Repeat = strings.ToLower(w.Repeat)
+		if w.Repeat != "" && w.Repeat != MaintenanceRepeatDaily && w.Repeat != MaintenanceRepeatWeekly {
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s maintenance window repeat %s is invalid, removing.\n",
+				exch.Name,
+				w.Repeat)
+			continue
+		}
+		period := w.period()
+		if !w.End.After(w.Start) || (period > 0 && w.End.Sub(w.Start) >= period) {
+			log.Warnf(log.ExchangeSys,
+				"Exchange %s maintenance window %s to %s is invalid, removing.\n",
+				exch.Name,
+				w.Start,
+				w.End)
+			continue
+		}
+		windows = append(windows, w)
+	}
+	mt.Windows = windows
+}
+
+// Occurrence returns the start and end of the occurrence of the window which
+// contains or follows t, false is returned when a window which does not
+// repeat has ended
+func (w *MaintenanceWindow) Occurrence(t time.Time) (start, end time.Time, ok bool) {
+	period := w.period()
+	start, end = w.Start, w.End
+	if period > 0 && !t.Before(end) {
+		n := t.Sub(end)/period + 1
+		start = start.Add(n * period)
+		end = end.Add(n * period)
+	}
+	return start, end, t.Before(end)
+}
+
+func (w *MaintenanceWindow) period() time.Duration {
+	switch w.Repeat {
+	case MaintenanceRepeatDaily:
+		return time.Hour * 24
+	case MaintenanceRepeatWeekly:
+		return time.Hour * 24 * 7
+	}
+	return 0
+}
+
 // CheckExchangeConfigValues returns configuation values for all enabled
 // exchanges
 func (c *Config) CheckExchangeConfigValues() error {
@@ -879,13 +1190,20 @@ func (c *Config) CheckExchangeConfigValues() error {
 			c.Exchanges[i].EnabledPairs = nil
 		}
 
+		c.checkCredentialsProvider(&c.Exchanges[i])
 		if c.Exchanges[i].Enabled {
 			if c.Exchanges[i].Name == "" {
 				log.Errorf(log.ConfigMgr, ErrExchangeNameEmpty, i)
 				c.Exchanges[i].Enabled = false
 				continue
 			}
-			if (c.Exchanges[i].API.AuthenticatedSupport || c.Exchanges[i].API.AuthenticatedWebsocketSupport) && c.Exchanges[i].API.CredentialsValidator != nil {
+			c.checkExchangeAPIKeys(&c.Exchanges[i])
+			c.checkExchangeAccounts(&c.Exchanges[i])
+			c.checkPairPolling(&c.Exchanges[i])
+			c.checkMaintenance(&c.Exchanges[i])
+			// credentials read from a secret store are validated when the
+			// exchange is loaded
+			if (c.Exchanges[i].API.AuthenticatedSupport || c.Exchanges[i].API.AuthenticatedWebsocketSupport) && c.Exchanges[i].API.CredentialsValidator != nil && c.Exchanges[i].API.CredentialsProvider == nil {
 				var failed bool
 				if c.Exchanges[i].API.CredentialsValidator.RequiresKey && (c.Exchanges[i].API.Credentials.Key == "" || c.Exchanges[i].API.Credentials.Key == DefaultAPIKey) {
 					failed = true
@@ -938,6 +1256,16 @@ func (c *Config) CheckExchangeConfigValues() error {
 					c.Exchanges[i].Name, defaultWebsocketOrderbookBufferLimit)
 				c.Exchanges[i].WebsocketOrderbookBufferLimit = defaultWebsocketOrderbookBufferLimit
 			}
+			if c.Exchanges[i].MarketOrderProtection < 0 {
+				log.Warnf(log.ExchangeSys, "Exchange %s market order protection cannot be negative, disabling.",
+					c.Exchanges[i].Name)
+				c.Exchanges[i].MarketOrderProtection = 0
+			}
+			if c.Exchanges[i].HeartbeatInterval < 0 {
+				log.Warnf(log.ExchangeSys, "Exchange %s heartbeat interval cannot be negative, disabling.",
+					c.Exchanges[i].Name)
+				c.Exchanges[i].HeartbeatInterval = 0
+			}
 			err := c.CheckPairConsistency(c.Exchanges[i].Name)
 			if err != nil {
 				log.Errorf(log.ExchangeSys, "Exchange %s: CheckPairConsistency error: %s\n", c.Exchanges[i].Name, err)
@@ -981,7 +1309,7 @@ func (c *Config) CheckCurrencyConfigValues() error {
 				log.Warnf(log.Global, "%s forex provider not found, adding to config..\n", fxProviders[x])
 				c.Currency.ForexProviders = append(c.Currency.ForexProviders, currency.FXSettings{
 					Name:             fxProviders[x],
-					RESTPollingDelay: 600,
+					RESTPollingDelay: defaultForexRESTPollingDelay,
 					APIKey:           DefaultUnsetAPIKey,
 					APIKeyLvl:        -1,
 				})
@@ -1090,9 +1418,107 @@ func (c *Config) CheckCurrencyConfigValues() error {
 		c.FiatDisplayCurrency = nil
 	}
 
+	c.checkPegConfig()
+	c.checkTranslationConfig()
 	return nil
 }
 
+// checkTranslationConfig removes currency translations without a native or
+// canonical code
+func (c *Config) checkTranslationConfig() {
+	translations := c.Currency.Translations[:0]
+	for x := range c.Currency.Translations {
+		t := c.Currency.Translations[x]
+		if t.Native.IsEmpty() || t.Canonical.IsEmpty() {
+			log.Warnf(log.ConfigMgr, "Currency translation %q to %q is incomplete, removing translation.\n",
+				t.Native,
+				t.Canonical)
+			continue
+		}
+		translations = append(translations, t)
+	}
+	c.Currency.Translations = translations
+}
+
+// checkPegConfig sets the default peg groups when pegs are used without any
+// and removes invalid groups and currencies pegged in more than one group
+func (c *Config) checkPegConfig() {
+	pegs := &c.Currency.Pegs
+	if len(pegs.Groups) == 0 {
+		if pegs.Valuation || pegs.Arbitrage {
+			for _, g := range currency.DefaultPegGroups() {
+				pegs.Groups = append(pegs.Groups, PegGroupConfig{
+					Anchor:    g.Anchor,
+					Members:   g.Members,
+					Tolerance: g.Tolerance,
+				})
+			}
+		}
+		return
+	}
+
+	pegged := make(map[*currency.Item]bool)
+	groups := pegs.Groups[:0]
+	for x := range pegs.Groups {
+		g := pegs.Groups[x]
+		if g.Anchor.IsEmpty() || pegged[g.Anchor.Item] {
+			log.Warnf(log.ConfigMgr, "Peg group anchor %q is unset or already pegged, removing group.\n", g.Anchor)
+			continue
+		}
+		pegged[g.Anchor.Item] = true
+		members := g.Members[:0]
+		for y := range g.Members {
+			if g.Members[y].IsEmpty() || pegged[g.Members[y].Item] {
+				log.Warnf(log.ConfigMgr, "Peg group %s member %q is unset or already pegged, removing member.\n",
+					g.Anchor,
+					g.Members[y])
+				continue
+			}
+			pegged[g.Members[y].Item] = true
+			members = append(members, g.Members[y])
+		}
+		if len(members) == 0 {
+			log.Warnf(log.ConfigMgr, "Peg group %s has no members, removing group.\n", g.Anchor)
+			continue
+		}
+		g.Members = members
+		if g.Tolerance <= 0 {
+			log.Warnf(log.ConfigMgr, "Peg group %s tolerance not set, defaulting to %v%%.\n",
+				g.Anchor,
+				currency.DefaultPegTolerance)
+			g.Tolerance = currency.DefaultPegTolerance
+		}
+		groups = append(groups, g)
+	}
+	pegs.Groups = groups
+}
+
+// PegGroups returns the configured peg groups
+func (p *PegConfig) PegGroups() []currency.PegGroup {
+	groups := make([]currency.PegGroup, len(p.Groups))
+	for x := range p.Groups {
+		groups[x] = currency.PegGroup{
+			Anchor:    p.Groups[x].Anchor,
+			Members:   p.Groups[x].Members,
+			Tolerance: p.Groups[x].Tolerance,
+		}
+	}
+	return groups
+}
+
+// CodeTranslations returns the configured currency translations
+func (c *CurrencyConfig) CodeTranslations() []currency.CodeTranslation {
+	translations := make([]currency.CodeTranslation, len(c.Translations))
+	for x := range c.Translations {
+		translations[x] = currency.CodeTranslation{
+			Exchange:  c.Translations[x].Exchange,
+			Native:    c.Translations[x].Native,
+			Canonical: c.Translations[x].Canonical,
+		}
+	}
+	return translations
+}
+
 // RetrieveConfigCurrencyPairs splits, assigns and verifies enabled currency
 // pairs either cryptoCurrencies or fiatCurrencies
 func (c *Config) RetrieveConfigCurrencyPairs(enabledOnly bool, assetType asset.Item) error {
@@ -1242,7 +1668,13 @@ func (c *Config) checkDatabaseConfig() error {
 		return fmt.Errorf("unsupported database driver %v, database disabled", c.Database.Driver)
 	}
 
-	if c.Database.Driver == database.DBSQLite || c.Database.Driver == database.DBSQLite3 {
+	if c.Database.Driver == database.DBBolt && c.Database.Database == "" {
+		c.Database.Database = database.DefaultBoltDatabase
+	}
+
+	if c.Database.Driver == database.DBSQLite ||
+		c.Database.Driver == database.DBSQLite3 ||
+		c.Database.Driver == database.DBBolt {
 		databaseDir := filepath.Join(common.GetDefaultDataDir(runtime.GOOS), "/database")
 		err := common.CreateDir(databaseDir)
 		if err != nil {
@@ -1334,6 +1766,726 @@ func (c *Config) CheckConnectionMonitorConfig() {
 	}
 }
 
+// CheckStartupConfig checks and if zero value assigns default values to the
+// startup staggering config. A negative window disables staggering
+func (c *Config) CheckStartupConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Startup.Window < 0 {
+		c.Startup.Window = 0
+		return
+	}
+	if c.Startup.Window == 0 {
+		c.Startup.Window = defaultStartupWindow
+	}
+	if c.Startup.MaxConcurrent <= 0 {
+		c.Startup.MaxConcurrent = defaultStartupMaxConcurrent
+	}
+	if c.Startup.ExchangeStagger <= 0 {
+		c.Startup.ExchangeStagger = defaultStartupExchangeStagger
+	}
+}
+
+// CheckColdStorageSweepConfig checks and if zero value assigns default values
+// to the cold storage sweep config, disabling any invalid rules
+func (c *Config) CheckColdStorageSweepConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ColdStorageSweep.Interval <= 0 {
+		c.ColdStorageSweep.Interval = defaultColdStorageSweepInterval
+	}
+
+	rules := c.ColdStorageSweep.Rules[:0]
+	for i := range c.ColdStorageSweep.Rules {
+		r := c.ColdStorageSweep.Rules[i]
+		if r.Exchange == "" || r.Currency.String() == "" || r.Address == "" {
+			log.Warnf(log.ConfigMgr,
+				"Cold storage sweep rule #%d requires an exchange, currency and address, disabling.\n",
+				i)
+			continue
+		}
+		if r.Threshold <= 0 {
+			log.Warnf(log.ConfigMgr,
+				"Cold storage sweep rule %s %s threshold must be greater than zero, disabling.\n",
+				r.Exchange,
+				r.Currency)
+			continue
+		}
+		if r.Retain < 0 || r.Retain > r.Threshold {
+			log.Warnf(log.ConfigMgr,
+				"Cold storage sweep rule %s %s retain amount is invalid, setting to threshold %v.\n",
+				r.Exchange,
+				r.Currency,
+				r.Threshold)
+			r.Retain = r.Threshold
+		}
+		rules = append(rules, r)
+	}
+	c.ColdStorageSweep.Rules = rules
+}
+
+// CheckLiquidityScreenConfig checks and if zero value assigns default values
+// to the liquidity screen config, resetting any invalid thresholds
+func (c *Config) CheckLiquidityScreenConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.LiquidityScreen.Interval <= 0 {
+		c.LiquidityScreen.Interval = defaultLiquidityScreenInterval
+	}
+	if c.LiquidityScreen.MinVolume < 0 {
+		log.Warnln(log.ConfigMgr, "Liquidity screen minimum volume cannot be negative, setting to zero.")
+		c.LiquidityScreen.MinVolume = 0
+	}
+	if c.LiquidityScreen.MaxSpread < 0 {
+		log.Warnln(log.ConfigMgr, "Liquidity screen maximum spread cannot be negative, setting to zero.")
+		c.LiquidityScreen.MaxSpread = 0
+	}
+	if c.LiquidityScreen.MinDepth < 0 {
+		log.Warnln(log.ConfigMgr, "Liquidity screen minimum depth cannot be negative, setting to zero.")
+		c.LiquidityScreen.MinDepth = 0
+	}
+}
+
+// CheckTriangularArbConfig checks and if zero value assigns default values
+// to the triangular arbitrage config, resetting any invalid thresholds and
+// removing invalid trade amounts
+func (c *Config) CheckTriangularArbConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.TriangularArb.Interval <= 0 {
+		c.TriangularArb.Interval = defaultTriangularArbitrageInterval
+	}
+	if c.TriangularArb.MinProfit < 0 {
+		log.Warnln(log.ConfigMgr, "Triangular arbitrage minimum profit cannot be negative, setting to zero.")
+		c.TriangularArb.MinProfit = 0
+	}
+	if c.TriangularArb.DefaultFee < 0 || c.TriangularArb.DefaultFee >= 100 {
+		log.Warnln(log.ConfigMgr, "Triangular arbitrage default fee must be between zero and 100 percent, setting to zero.")
+		c.TriangularArb.DefaultFee = 0
+	}
+	for k, v := range c.TriangularArb.TradeAmounts {
+		if v <= 0 {
+			log.Warnf(log.ConfigMgr, "Triangular arbitrage %s trade amount must be greater than zero, removing.\n", k)
+			delete(c.TriangularArb.TradeAmounts, k)
+		}
+	}
+}
+
+// CheckEquitySnapshotConfig checks and if zero value assigns default values
+// to the equity snapshot config
+func (c *Config) CheckEquitySnapshotConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.EquitySnapshot.Interval <= 0 {
+		c.EquitySnapshot.Interval = defaultEquitySnapshotInterval
+	}
+	if c.EquitySnapshot.Currency.IsEmpty() {
+		c.EquitySnapshot.Currency = c.Currency.FiatDisplayCurrency
+	}
+
+	var numeraires currency.Currencies
+	for i := range c.EquitySnapshot.Numeraires {
+		if c.EquitySnapshot.Numeraires[i].IsEmpty() ||
+			c.EquitySnapshot.Numeraires[i].Match(c.EquitySnapshot.Currency) ||
+			numeraires.Contains(c.EquitySnapshot.Numeraires[i]) {
+			continue
+		}
+		numeraires = append(numeraires, c.EquitySnapshot.Numeraires[i])
+	}
+	c.EquitySnapshot.Numeraires = numeraires
+}
+
+// CheckSettlementConfig checks and if zero value assigns default values to the
+// settlement config
+func (c *Config) CheckSettlementConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, err := time.Parse(SettlementCloseTimeFormat, c.Settlement.CloseTime); err != nil {
+		if c.Settlement.CloseTime != "" {
+			log.Warnf(log.ConfigMgr,
+				"Settlement close time %s is invalid, defaulting to %s.\n",
+				c.Settlement.CloseTime,
+				defaultSettlementCloseTime)
+		}
+		c.Settlement.CloseTime = defaultSettlementCloseTime
+	}
+	if c.Settlement.Currency.IsEmpty() {
+		c.Settlement.Currency = c.Currency.FiatDisplayCurrency
+	}
+	if c.Settlement.Tolerance <= 0 {
+		c.Settlement.Tolerance = defaultSettlementTolerance
+	}
+}
+
+// CheckDailyReportConfig checks and if zero value assigns default values to
+// the daily report config
+func (c *Config) CheckDailyReportConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, err := time.Parse(SettlementCloseTimeFormat, c.DailyReport.SendTime); err != nil {
+		if c.DailyReport.SendTime != "" {
+			log.Warnf(log.ConfigMgr,
+				"Daily report send time %s is invalid, defaulting to %s.\n",
+				c.DailyReport.SendTime,
+				defaultDailyReportSendTime)
+		}
+		c.DailyReport.SendTime = defaultDailyReportSendTime
+	}
+	if c.DailyReport.Currency.IsEmpty() {
+		c.DailyReport.Currency = c.Currency.FiatDisplayCurrency
+	}
+}
+
+// CheckEventBusConfig checks and if zero value assigns default values to the
+// event bus config, removing outputs without a sink or address
+func (c *Config) CheckEventBusConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.EventBus.OrderbookDepth == 0 {
+		c.EventBus.OrderbookDepth = defaultEventBusOrderbookDepth
+	}
+
+	outputs := c.EventBus.Outputs[:0]
+	for i := range c.EventBus.Outputs {
+		o := c.EventBus.Outputs[i]
+		if o.Name == "" {
+			o.Name = fmt.Sprintf("output %d", i+1)
+		}
+		o.Sink = strings.ToLower(o.Sink)
+		if o.Sink == "" || o.Address == "" {
+			log.Warnf(log.ConfigMgr,
+				"Event bus output %s has no sink or address, removing.\n",
+				o.Name)
+			continue
+		}
+		o.Encoding = strings.ToLower(o.Encoding)
+		if o.Encoding == "" {
+			o.Encoding = eventbus.JSONEncoding
+		}
+		if o.Subject == "" {
+			o.Subject = defaultEventBusSubject
+		}
+		if o.BufferSize <= 0 {
+			o.BufferSize = eventbus.DefaultBufferSize
+		}
+		var events []string
+		for x := range o.Events {
+			e := strings.ToLower(o.Events[x])
+			switch e {
+			case eventbus.TickerEvent,
+				eventbus.TradeEvent,
+				eventbus.OrderbookEvent,
+				eventbus.OrderEvent,
+				eventbus.AlertEvent:
+				if !common.StringDataCompare(events, e) {
+					events = append(events, e)
+				}
+			default:
+				log.Warnf(log.ConfigMgr,
+					"Event bus output %s event %s is unsupported, removing.\n",
+					o.Name,
+					o.Events[x])
+			}
+		}
+		o.Events = events
+		outputs = append(outputs, o)
+	}
+	c.EventBus.Outputs = outputs
+}
+
+// CheckTimeSeriesConfig checks and if zero value assigns default values to
+// the time-series config, disabling it when its database is not configured
+func (c *Config) CheckTimeSeriesConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.TimeSeries.Interval <= 0 {
+		c.TimeSeries.Interval = defaultTimeSeriesInterval
+	}
+	if c.TimeSeries.Currency.IsEmpty() {
+		c.TimeSeries.Currency = c.Currency.FiatDisplayCurrency
+	}
+	c.TimeSeries.Driver = strings.ToLower(c.TimeSeries.Driver)
+	switch c.TimeSeries.Driver {
+	case "":
+		c.TimeSeries.Driver = timeseries.InfluxDB
+	case timeseries.InfluxDB, timeseries.TimescaleDB:
+	default:
+		log.Warnf(log.ConfigMgr,
+			"Time-series driver %s is unsupported, defaulting to %s.\n",
+			c.TimeSeries.Driver,
+			timeseries.InfluxDB)
+		c.TimeSeries.Driver = timeseries.InfluxDB
+	}
+	if !c.TimeSeries.Enabled {
+		return
+	}
+	switch {
+	case c.TimeSeries.Driver == timeseries.InfluxDB &&
+		(c.TimeSeries.InfluxDB.URL == "" || c.TimeSeries.InfluxDB.Database == ""):
+		log.Warnln(log.ConfigMgr, "Time-series InfluxDB URL or database is not set, disabling.")
+		c.TimeSeries.Enabled = false
+	case c.TimeSeries.Driver == timeseries.TimescaleDB && c.TimeSeries.TimescaleDB.Host == "":
+		log.Warnln(log.ConfigMgr, "Time-series TimescaleDB host is not set, disabling.")
+		c.TimeSeries.Enabled = false
+	}
+}
+
+// CheckBalanceCacheConfig checks and if zero value assigns default values to
+// the balance cache config
+func (c *Config) CheckBalanceCacheConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.BalanceCache.TTL <= 0 {
+		c.BalanceCache.TTL = defaultBalanceCacheTTL
+	}
+}
+
+// CheckSnapshotExportConfig checks and if zero value assigns default values to
+// the snapshot export config
+func (c *Config) CheckSnapshotExportConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.SnapshotExport.Interval <= 0 {
+		c.SnapshotExport.Interval = defaultSnapshotExportInterval
+	}
+	c.SnapshotExport.Format = strings.ToLower(c.SnapshotExport.Format)
+	if c.SnapshotExport.Format != SnapshotExportCSV && c.SnapshotExport.Format != SnapshotExportJSON {
+		if c.SnapshotExport.Format != "" {
+			log.Warnf(log.ConfigMgr,
+				"Snapshot export format %s is invalid, defaulting to %s.\n",
+				c.SnapshotExport.Format,
+				SnapshotExportCSV)
+		}
+		c.SnapshotExport.Format = SnapshotExportCSV
+	}
+	if c.SnapshotExport.Rotation < c.SnapshotExport.Interval {
+		if c.SnapshotExport.Rotation != 0 {
+			log.Warnf(log.ConfigMgr,
+				"Snapshot export rotation %s is shorter than its interval, defaulting to %s.\n",
+				c.SnapshotExport.Rotation,
+				defaultSnapshotExportRotation)
+		}
+		c.SnapshotExport.Rotation = defaultSnapshotExportRotation
+	}
+	if c.SnapshotExport.Retention <= 0 {
+		c.SnapshotExport.Retention = defaultSnapshotExportRetention
+	}
+}
+
+// CheckOrderReconcileConfig checks and if zero value assigns default values
+// to the order reconciliation config
+func (c *Config) CheckOrderReconcileConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.OrderReconcile.Retention <= 0 {
+		c.OrderReconcile.Retention = defaultOrderReconcileRetention
+	}
+}
+
+// CheckCandleBuilderConfig checks and if zero value assigns default values to
+// the candle builder config
+func (c *Config) CheckCandleBuilderConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(c.CandleBuilder.Intervals) == 0 {
+		c.CandleBuilder.Intervals = []time.Duration{
+			kline.OneMin,
+			kline.FiveMin,
+			kline.OneHour,
+		}
+	}
+	var intervals []time.Duration
+	seen := make(map[time.Duration]bool)
+	for i := range c.CandleBuilder.Intervals {
+		if c.CandleBuilder.Intervals[i] <= 0 {
+			log.Warnf(log.ConfigMgr,
+				"Candle builder interval %v is invalid, skipping.\n",
+				c.CandleBuilder.Intervals[i])
+			continue
+		}
+		if seen[c.CandleBuilder.Intervals[i]] {
+			continue
+		}
+		seen[c.CandleBuilder.Intervals[i]] = true
+		intervals = append(intervals, c.CandleBuilder.Intervals[i])
+	}
+	c.CandleBuilder.Intervals = intervals
+	if c.CandleBuilder.Length <= 0 {
+		c.CandleBuilder.Length = defaultCandleBuilderLength
+	}
+}
+
+// CheckStrategiesConfig checks and if zero value assigns default values to the
+// strategies config, disabling strategy instances which are invalid
+func (c *Config) CheckStrategiesConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	names := make(map[string]bool)
+	for i := range c.Strategies.Strategies {
+		s := &c.Strategies.Strategies[i]
+		if s.AssetType == "" {
+			s.AssetType = asset.Spot
+		}
+		if s.Interval <= 0 {
+			s.Interval = kline.OneMin
+		}
+		if s.Name == "" {
+			s.Name = strings.ToLower(s.Strategy + "-" + s.Exchange + "-" +
+				s.Pair.Base.String() + s.Pair.Quote.String() + "-" + s.AssetType.String())
+		}
+		if !s.Enabled {
+			continue
+		}
+		var reason string
+		switch {
+		case s.Strategy == "":
+			reason = "strategy is not set"
+		case s.Exchange == "":
+			reason = "exchange is not set"
+		case s.Pair.IsEmpty():
+			reason = "pair is not set"
+		case !asset.IsValid(s.AssetType):
+			reason = "asset type is invalid"
+		case names[strings.ToLower(s.Name)]:
+			reason = "name is duplicated"
+		}
+		if reason != "" {
+			log.Warnf(log.ConfigMgr,
+				"Strategy %s %s, disabling.\n",
+				s.Name,
+				reason)
+			s.Enabled = false
+			continue
+		}
+		names[strings.ToLower(s.Name)] = true
+	}
+}
+
+// CheckConditionalOrdersConfig checks and if zero value assigns default values
+// to the conditional orders config
+func (c *Config) CheckConditionalOrdersConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ConditionalOrders.Interval <= 0 {
+		c.ConditionalOrders.Interval = defaultConditionalOrdersInterval
+	}
+}
+
+// CheckBotStateConfig checks and if zero value assigns default values to the
+// bot state config
+func (c *Config) CheckBotStateConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.BotState.Interval <= 0 {
+		c.BotState.Interval = defaultBotStateInterval
+	}
+}
+
+// CheckAuctionHistoryConfig checks and if zero value assigns default values to
+// the auction history config
+func (c *Config) CheckAuctionHistoryConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.AuctionHistory.Interval <= 0 {
+		c.AuctionHistory.Interval = defaultAuctionHistoryInterval
+	}
+}
+
+// CheckFundingHistoryConfig checks and if zero value assigns default values to
+// the funding history config
+func (c *Config) CheckFundingHistoryConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.FundingHistory.Interval <= 0 {
+		c.FundingHistory.Interval = defaultFundingHistoryInterval
+	}
+}
+
+// CheckPositionsConfig checks and if zero value assigns default values to the
+// positions config
+func (c *Config) CheckPositionsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Positions.Interval <= 0 {
+		c.Positions.Interval = defaultPositionsInterval
+	}
+
+	if c.Positions.Lookback <= 0 {
+		c.Positions.Lookback = defaultPositionsLookback
+	}
+}
+
+// CheckDerivativesDataConfig checks and if zero value assigns default values
+// to the derivatives data config
+func (c *Config) CheckDerivativesDataConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.DerivativesData.Interval <= 0 {
+		c.DerivativesData.Interval = defaultDerivativesDataInterval
+	}
+
+	if c.DerivativesData.OpenInterestHistory <= 0 {
+		c.DerivativesData.OpenInterestHistory = derivative.DefaultOpenInterestHistory
+	}
+
+	if c.DerivativesData.LiquidationHistory <= 0 {
+		c.DerivativesData.LiquidationHistory = derivative.DefaultLiquidationHistory
+	}
+}
+
+// CheckExchangeHealthConfig checks and if zero value assigns default values to
+// the exchange health config
+func (c *Config) CheckExchangeHealthConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ExchangeHealth.Interval <= 0 {
+		c.ExchangeHealth.Interval = defaultExchangeHealthInterval
+	}
+	if c.ExchangeHealth.MinRequests <= 0 {
+		c.ExchangeHealth.MinRequests = defaultExchangeHealthMinRequests
+	}
+	if c.ExchangeHealth.MaxLatency <= 0 {
+		c.ExchangeHealth.MaxLatency = defaultExchangeHealthMaxLatency
+	}
+	if c.ExchangeHealth.DegradedErrorRate <= 0 || c.ExchangeHealth.DegradedErrorRate > 1 {
+		c.ExchangeHealth.DegradedErrorRate = defaultExchangeHealthDegradedErrors
+	}
+	if c.ExchangeHealth.OfflineErrorRate <= 0 || c.ExchangeHealth.OfflineErrorRate > 1 {
+		c.ExchangeHealth.OfflineErrorRate = defaultExchangeHealthOfflineErrors
+	}
+	if c.ExchangeHealth.OfflineErrorRate < c.ExchangeHealth.DegradedErrorRate {
+		log.Warnln(log.ConfigMgr, "Exchange health offline error rate cannot be below the degraded error rate, setting to the degraded error rate.")
+		c.ExchangeHealth.OfflineErrorRate = c.ExchangeHealth.DegradedErrorRate
+	}
+	if c.ExchangeHealth.MaxDisconnects <= 0 {
+		c.ExchangeHealth.MaxDisconnects = defaultExchangeHealthMaxDisconnects
+	}
+}
+
+// CheckTimeSyncConfig checks and if zero value assigns default values to the
+// time sync config
+func (c *Config) CheckTimeSyncConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.TimeSync.Interval <= 0 {
+		c.TimeSync.Interval = defaultTimeSyncInterval
+	}
+	if c.TimeSync.MaxDrift <= 0 {
+		c.TimeSync.MaxDrift = defaultTimeSyncMaxDrift
+	}
+}
+
+// CheckMaintenanceConfig checks and if zero value assigns default values to
+// the maintenance config
+func (c *Config) CheckMaintenanceConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Maintenance.Interval <= 0 {
+		c.Maintenance.Interval = defaultMaintenanceInterval
+	}
+}
+
+// CheckFIXGatewayConfig checks and if zero value assigns default values to the
+// FIX gateway config. Sessions without a TargetCompID or sharing one are
+// removed
+func (c *Config) CheckFIXGatewayConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.FIXGateway.ListenAddress == "" {
+		c.FIXGateway.ListenAddress = defaultFIXGatewayListenAddress
+	}
+	if c.FIXGateway.SenderCompID == "" {
+		c.FIXGateway.SenderCompID = defaultFIXGatewaySenderCompID
+	}
+
+	compIDs := make(map[string]bool)
+	sessions := c.FIXGateway.Sessions[:0]
+	for i := range c.FIXGateway.Sessions {
+		s := c.FIXGateway.Sessions[i]
+		if s.TargetCompID == "" {
+			log.Warnf(log.ConfigMgr,
+				"FIX gateway session #%d requires a target comp ID, removing.\n",
+				i)
+			continue
+		}
+		if s.TargetCompID == c.FIXGateway.SenderCompID || compIDs[s.TargetCompID] {
+			log.Warnf(log.ConfigMgr,
+				"FIX gateway session %s target comp ID is already in use, removing.\n",
+				s.TargetCompID)
+			continue
+		}
+		compIDs[s.TargetCompID] = true
+		sessions = append(sessions, s)
+	}
+	c.FIXGateway.Sessions = sessions
+}
+
+// CheckSecretStoresConfig checks and if zero value assigns default values to
+// the secret stores config
+func (c *Config) CheckSecretStoresConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.SecretStores.Vault.Mount == "" {
+		c.SecretStores.Vault.Mount = secrets.DefaultVaultMount
+	}
+}
+
+// CheckAutomationsConfig checks and if zero value assigns default values to
+// the automations config
+func (c *Config) CheckAutomationsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.Automations.Interval <= 0 {
+		c.Automations.Interval = defaultAutomationsInterval
+	}
+}
+
+// CheckResourceMonitorConfig checks and if zero value assigns default values
+// to the resource monitor config, removing unknown and duplicate tiers
+func (c *Config) CheckResourceMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ResourceMonitor.Interval <= 0 {
+		c.ResourceMonitor.Interval = defaultResourceMonitorInterval
+	}
+	if c.ResourceMonitor.MaxCPU <= 0 || c.ResourceMonitor.MaxCPU > 100 {
+		c.ResourceMonitor.MaxCPU = defaultResourceMonitorMaxCPU
+	}
+	if c.ResourceMonitor.OrderbookDepth <= 0 {
+		c.ResourceMonitor.OrderbookDepth = defaultResourceMonitorOrderbookDepth
+	}
+	if c.ResourceMonitor.PollingMultiplier <= 1 {
+		c.ResourceMonitor.PollingMultiplier = defaultResourceMonitorPollingFactor
+	}
+	if len(c.ResourceMonitor.Tiers) == 0 {
+		c.ResourceMonitor.Tiers = []string{
+			DegradeOrderbookDepth,
+			DegradePollingIntervals,
+			DegradeAnalytics,
+		}
+		return
+	}
+
+	var tiers []string
+	for x := range c.ResourceMonitor.Tiers {
+		tier := strings.ToLower(c.ResourceMonitor.Tiers[x])
+		switch tier {
+		case DegradeOrderbookDepth, DegradePollingIntervals, DegradeAnalytics:
+		default:
+			log.Warnf(log.ConfigMgr, "Resource monitor tier %s is invalid, removing.\n", c.ResourceMonitor.Tiers[x])
+			continue
+		}
+		if common.StringDataCompare(tiers, tier) {
+			log.Warnf(log.ConfigMgr, "Resource monitor tier %s is duplicated, removing.\n", tier)
+			continue
+		}
+		tiers = append(tiers, tier)
+	}
+	c.ResourceMonitor.Tiers = tiers
+}
+
+// CheckRiskLimitsConfig checks and if zero value assigns default values to
+// the risk limits config, disabling any invalid limits
+func (c *Config) CheckRiskLimitsConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.RiskLimits.Currency.IsEmpty() {
+		c.RiskLimits.Currency = c.Currency.FiatDisplayCurrency
+	}
+	if c.RiskLimits.MaxOrderValue < 0 {
+		log.Warnln(log.ConfigMgr, "Risk limits maximum order value cannot be negative, disabling.")
+		c.RiskLimits.MaxOrderValue = 0
+	}
+	if c.RiskLimits.MaxPositionValue < 0 {
+		log.Warnln(log.ConfigMgr, "Risk limits maximum position value cannot be negative, disabling.")
+		c.RiskLimits.MaxPositionValue = 0
+	}
+	if c.RiskLimits.MaxGrossExposure < 0 {
+		log.Warnln(log.ConfigMgr, "Risk limits maximum gross exposure cannot be negative, disabling.")
+		c.RiskLimits.MaxGrossExposure = 0
+	}
+	if c.RiskLimits.MaxLeverage < 0 {
+		log.Warnln(log.ConfigMgr, "Risk limits maximum leverage cannot be negative, disabling.")
+		c.RiskLimits.MaxLeverage = 0
+	}
+}
+
+// CheckRiskManagementConfig checks and if zero value assigns default values to
+// the risk management config, disabling any invalid rules
+func (c *Config) CheckRiskManagementConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.RiskManagement.Currency.IsEmpty() {
+		c.RiskManagement.Currency = c.Currency.FiatDisplayCurrency
+	}
+	rules := c.RiskManagement.Exchanges[:0]
+	seen := make(map[string]bool)
+	for i := range c.RiskManagement.Exchanges {
+		r := c.RiskManagement.Exchanges[i]
+		if r.Exchange == "" {
+			log.Warnln(log.ConfigMgr, "Risk management rules exchange name not set, removing.")
+			continue
+		}
+		name := strings.ToLower(r.Exchange)
+		if seen[name] {
+			log.Warnf(log.ConfigMgr, "Risk management rules for %s are duplicated, removing.\n", r.Exchange)
+			continue
+		}
+		seen[name] = true
+		if r.MaxPositionSize < 0 {
+			log.Warnf(log.ConfigMgr, "Risk management %s maximum position size cannot be negative, disabling.\n", r.Exchange)
+			r.MaxPositionSize = 0
+		}
+		if r.MaxOrderNotional < 0 {
+			log.Warnf(log.ConfigMgr, "Risk management %s maximum order notional cannot be negative, disabling.\n", r.Exchange)
+			r.MaxOrderNotional = 0
+		}
+		if r.MaxDailyLoss < 0 {
+			log.Warnf(log.ConfigMgr, "Risk management %s maximum daily loss cannot be negative, disabling.\n", r.Exchange)
+			r.MaxDailyLoss = 0
+		}
+		if r.MaxOpenOrders < 0 {
+			log.Warnf(log.ConfigMgr, "Risk management %s maximum open orders cannot be negative, disabling.\n", r.Exchange)
+			r.MaxOpenOrders = 0
+		}
+		if r.MaxSlippage < 0 {
+			log.Warnf(log.ConfigMgr, "Risk management %s maximum slippage cannot be negative, disabling.\n", r.Exchange)
+			r.MaxSlippage = 0
+		}
+		rules = append(rules, r)
+	}
+	c.RiskManagement.Exchanges = rules
+}
+
 // DefaultFilePath returns the default config file path
 // MacOS/Linux: $HOME/.gocryptotrader/config.json or config.dat
 // Windows: %APPDATA%\GoCryptoTrader\config.json or config.dat
@@ -1475,11 +2627,19 @@ func (c *Config) ReadConfig(configPath string, dryrun bool) error {
 	}
 
 	if !ConfirmECS(fileData) {
-		err = ConfirmConfigJSON(fileData, &c)
+		var from int
+		from, err = c.decodeConfigFile(fileData)
 		if err != nil {
 			return err
 		}
 
+		if from != Version {
+			err = c.upgradeConfigFile(defaultPath, fileData, from, dryrun)
+			if err != nil {
+				return err
+			}
+		}
+
 		if c.EncryptConfig == fileEncryptionDisabled {
 			return nil
 		}
@@ -1515,20 +2675,80 @@ func (c *Config) ReadConfig(configPath string, dryrun bool) error {
 				continue
 			}
 
-			err = ConfirmConfigJSON(data, &c)
-			if err != nil {
+			if !json.Valid(data) {
 				if errCounter < maxAuthFailures {
 					log.Error(log.ConfigMgr, "Invalid password.")
 				}
 				errCounter++
 				continue
 			}
+
+			from, err := c.decodeConfigFile(data)
+			if err != nil {
+				return err
+			}
+			if from != Version {
+				// saved encrypted with the key the config was decrypted with
+				c.EncryptConfig = fileEncryptionEnabled
+				if err = c.upgradeConfigFile(defaultPath, fileData, from, dryrun); err != nil {
+					return err
+				}
+			}
 			break
 		}
 	}
 	return nil
 }
 
+// decodeConfigFile upgrades the JSON of a config file to the current version,
+// validates it and decodes it into the config. It returns the version the
+// config file was upgraded from
+func (c *Config) decodeConfigFile(data []byte) (int, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	// numbers are retained as written when the upgraded JSON is encoded
+	d.UseNumber()
+	var raw map[string]interface{}
+	if err := d.Decode(&raw); err != nil {
+		return 0, err
+	}
+
+	from, err := migrateConfigJSON(raw)
+	if err != nil {
+		return from, err
+	}
+
+	unknown, invalid := validateConfigJSON(raw)
+	for x := range unknown {
+		log.Warnf(log.ConfigMgr, "Config %s, ignoring.\n", unknown[x])
+	}
+	if len(invalid) > 0 {
+		return from, invalid
+	}
+
+	if from != Version {
+		data, err = json.Marshal(raw)
+		if err != nil {
+			return from, err
+		}
+	}
+	return from, ConfirmConfigJSON(data, &c)
+}
+
+// upgradeConfigFile saves an upgraded config file in place, keeping a backup
+// of the file before it was upgraded
+func (c *Config) upgradeConfigFile(configPath string, original []byte, from int, dryrun bool) error {
+	log.Infof(log.ConfigMgr, "Config upgraded from version %d to %d.\n", from, Version)
+	if dryrun {
+		return nil
+	}
+	backup := fmt.Sprintf("%s.v%d.bak", configPath, from)
+	if err := file.Write(backup, original); err != nil {
+		return err
+	}
+	log.Infof(log.ConfigMgr, "Config before the upgrade saved to %s.\n", backup)
+	return c.SaveConfig(configPath, dryrun)
+}
+
 // SaveConfig saves your configuration to your desired path
 func (c *Config) SaveConfig(configPath string, dryrun bool) error {
 	if dryrun {
@@ -1540,7 +2760,7 @@ func (c *Config) SaveConfig(configPath string, dryrun bool) error {
 		return err
 	}
 
-	payload, err := json.MarshalIndent(c, "", " ")
+	payload, err := json.MarshalIndent(c.withoutProvidedCredentials(), "", " ")
 	if err != nil {
 		return err
 	}
@@ -1564,8 +2784,25 @@ func (c *Config) SaveConfig(configPath string, dryrun bool) error {
 	return file.Write(defaultPath, payload)
 }
 
+// withoutProvidedCredentials returns a copy of the config without the API
+// credentials read from secret stores, so they are not saved to the config
+// file
+func (c *Config) withoutProvidedCredentials() *Config {
+	cfg := *c
+	cfg.Exchanges = make([]ExchangeConfig, len(c.Exchanges))
+	copy(cfg.Exchanges, c.Exchanges)
+	for x := range cfg.Exchanges {
+		if cfg.Exchanges[x].API.CredentialsProvider != nil {
+			cfg.Exchanges[x].API.Credentials = APICredentialsConfig{}
+		}
+	}
+	return &cfg
+}
+
 // CheckRemoteControlConfig checks to see if the old c.Webserver field is used
-// and migrates the existing settings to the new RemoteControl struct
+// and migrates the existing settings to the new RemoteControl struct. A TLS
+// certificate configured without its key, or a key without its certificate,
+// is ignored in favour of a generated self-signed certificate
 func (c *Config) CheckRemoteControlConfig() {
 	m.Lock()
 	defer m.Unlock()
@@ -1605,6 +2842,71 @@ func (c *Config) CheckRemoteControlConfig() {
 		// Then flush the old webserver settings
 		c.Webserver = nil
 	}
+
+	if (c.RemoteControl.TLS.CertFile == "") != (c.RemoteControl.TLS.KeyFile == "") {
+		log.Warnf(log.ConfigMgr,
+			"Remote control TLS requires both a certificate and key file, using a self-signed certificate.\n")
+		c.RemoteControl.TLS = TLSConfig{}
+	}
+}
+
+// CheckTenantConfig checks the tenant config, disabling tenants with missing
+// or conflicting credentials and removing exchanges which are unknown or
+// already owned by another tenant
+func (c *Config) CheckTenantConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	owners := make(map[string]string)
+	usernames := make(map[string]bool)
+	tenants := c.Tenants[:0]
+	for i := range c.Tenants {
+		t := c.Tenants[i]
+		if t.Name == "" || t.Username == "" || t.Password == "" {
+			log.Warnf(log.ConfigMgr,
+				"Tenant #%d requires a name, username and password, disabling.\n",
+				i)
+			continue
+		}
+		if t.Username == c.RemoteControl.Username || usernames[t.Username] {
+			log.Warnf(log.ConfigMgr,
+				"Tenant %s username is already in use, disabling.\n",
+				t.Name)
+			continue
+		}
+		usernames[t.Username] = true
+
+		exchanges := t.Exchanges[:0]
+		for _, exch := range t.Exchanges {
+			var found bool
+			for x := range c.Exchanges {
+				if strings.EqualFold(c.Exchanges[x].Name, exch) || c.Exchanges[x].hasAccount(exch) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				log.Warnf(log.ConfigMgr,
+					"Tenant %s exchange %s not found, removing.\n",
+					t.Name,
+					exch)
+				continue
+			}
+			if owner, ok := owners[strings.ToLower(exch)]; ok {
+				log.Warnf(log.ConfigMgr,
+					"Tenant %s exchange %s is already owned by tenant %s, removing.\n",
+					t.Name,
+					exch,
+					owner)
+				continue
+			}
+			owners[strings.ToLower(exch)] = t.Name
+			exchanges = append(exchanges, exch)
+		}
+		t.Exchanges = exchanges
+		tenants = append(tenants, t)
+	}
+	c.Tenants = tenants
 }
 
 // CheckConfig checks all config settings
@@ -1630,15 +2932,45 @@ func (c *Config) CheckConfig() error {
 	}
 
 	c.CheckConnectionMonitorConfig()
+	c.CheckStartupConfig()
+	c.CheckColdStorageSweepConfig()
+	c.CheckLiquidityScreenConfig()
+	c.CheckTriangularArbConfig()
+	c.CheckConditionalOrdersConfig()
+	c.CheckAuctionHistoryConfig()
+	c.CheckFundingHistoryConfig()
+	c.CheckPositionsConfig()
+	c.CheckDerivativesDataConfig()
+	c.CheckExchangeHealthConfig()
+	c.CheckTimeSyncConfig()
+	c.CheckMaintenanceConfig()
+	c.CheckFIXGatewayConfig()
+	c.CheckSecretStoresConfig()
+	c.CheckAutomationsConfig()
+	c.CheckResourceMonitorConfig()
 	c.CheckCommunicationsConfig()
 	c.CheckClientBankAccounts()
 	c.CheckBankAccountConfig()
 	c.CheckRemoteControlConfig()
+	c.CheckTenantConfig()
 
 	err = c.CheckCurrencyConfigValues()
 	if err != nil {
 		return err
 	}
+	c.CheckEquitySnapshotConfig()
+	c.CheckSettlementConfig()
+	c.CheckDailyReportConfig()
+	c.CheckEventBusConfig()
+	c.CheckTimeSeriesConfig()
+	c.CheckBalanceCacheConfig()
+	c.CheckCandleBuilderConfig()
+	c.CheckSnapshotExportConfig()
+	c.CheckOrderReconcileConfig()
+	c.CheckStrategiesConfig()
+	c.CheckBotStateConfig()
+	c.CheckRiskLimitsConfig()
+	c.CheckRiskManagementConfig()
 
 	if c.GlobalHTTPTimeout <= 0 {
 		log.Warnf(log.ConfigMgr, "Global HTTP Timeout value not set, defaulting to %v.\n", defaultHTTPTimeout)
@@ -1673,6 +3005,7 @@ func (c *Config) UpdateConfig(configPath string, newCfg *Config, dryrun bool) er
 	c.EncryptConfig = newCfg.EncryptConfig
 	c.Currency = newCfg.Currency
 	c.GlobalHTTPTimeout = newCfg.GlobalHTTPTimeout
+	c.GlobalHTTPClient = newCfg.GlobalHTTPClient
 	c.Portfolio = newCfg.Portfolio
 	c.Communications = newCfg.Communications
 	c.Webserver = newCfg.Webserver
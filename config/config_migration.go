@@ -0,0 +1,186 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Version is the version of the config file format, there is a migration to
+// each version
+const Version = 2
+
+// configMigration upgrades the decoded JSON of a config file from the
+// previous version
+type configMigration struct {
+	description string
+	migrate     func(cfg map[string]interface{}) error
+}
+
+// configMigrations are applied in order when a config file is read, the
+// migration at index n upgrades a config file from version n to n+1. Config
+// files without a version are version 0
+var configMigrations = []configMigration{
+	{
+		description: "move legacy exchange API and feature settings into the api and features sections",
+		migrate:     migrateExchangeAPISettings,
+	},
+	{
+		description: "convert forex provider REST polling delays set in seconds to nanoseconds",
+		migrate:     migrateForexPollingDelays,
+	},
+}
+
+// migrateConfigJSON upgrades the decoded JSON of a config file to the current
+// version and returns the version it was upgraded from
+func migrateConfigJSON(cfg map[string]interface{}) (int, error) {
+	var from int
+	if v, ok := cfg["version"]; ok {
+		n, ok := v.(json.Number)
+		if !ok {
+			return 0, fmt.Errorf("config version %v is not a number", v)
+		}
+		i, err := n.Int64()
+		if err != nil || i < 0 {
+			return 0, fmt.Errorf("config version %v is invalid", v)
+		}
+		from = int(i)
+	}
+	if from > Version {
+		return from, fmt.Errorf("config version %d is newer than the supported version %d, please update the bot",
+			from,
+			Version)
+	}
+
+	for x := from; x < Version; x++ {
+		if err := configMigrations[x].migrate(cfg); err != nil {
+			return from, fmt.Errorf("unable to upgrade config from version %d to %d, %s: %v",
+				x,
+				x+1,
+				configMigrations[x].description,
+				err)
+		}
+	}
+	cfg["version"] = Version
+	return from, nil
+}
+
+// migrateExchangeAPISettings moves the exchange API and feature settings
+// stored at the top level of exchange configs before version 1
+func migrateExchangeAPISettings(cfg map[string]interface{}) error {
+	exchanges, _ := cfg["exchanges"].([]interface{})
+	for x := range exchanges {
+		exch, ok := exchanges[x].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _ := exch["name"].(string); strings.EqualFold(name, "GDAX") {
+			exch["name"] = "CoinbasePro"
+		}
+
+		api, err := configObject(exch, "api")
+		if err != nil {
+			return err
+		}
+		moveConfigKey(exch, "authenticatedApiSupport", api, "authenticatedSupport")
+		moveConfigKey(exch, "authenticatedWebsocketApiSupport", api, "authenticatedWebsocketApiSupport")
+		moveConfigKey(exch, "apiAuthPemKeySupport", api, "pemKeySupport")
+
+		credentials, err := configObject(api, "credentials")
+		if err != nil {
+			return err
+		}
+		moveConfigKey(exch, "apiKey", credentials, "key")
+		moveConfigKey(exch, "apiSecret", credentials, "secret")
+		moveConfigKey(exch, "clientId", credentials, "clientID")
+		moveConfigKey(exch, "apiAuthPemKey", credentials, "pemKey")
+
+		endpoints, err := configObject(api, "endpoints")
+		if err != nil {
+			return err
+		}
+		moveConfigKey(exch, "apiUrl", endpoints, "url")
+		moveConfigKey(exch, "apiUrlSecondary", endpoints, "urlSecondary")
+		moveConfigKey(exch, "websocketUrl", endpoints, "websocketURL")
+
+		features, err := configObject(exch, "features")
+		if err != nil {
+			return err
+		}
+		enabled, err := configObject(features, "enabled")
+		if err != nil {
+			return err
+		}
+		if autoPairUpdates, ok := exch["supportsAutoPairUpdates"]; ok {
+			supports, err := configObject(features, "supports")
+			if err != nil {
+				return err
+			}
+			restCapabilities, err := configObject(supports, "restCapabilities")
+			if err != nil {
+				return err
+			}
+			restCapabilities["autoPairUpdates"] = autoPairUpdates
+			enabled["autoPairUpdates"] = autoPairUpdates
+			delete(exch, "supportsAutoPairUpdates")
+		}
+		moveConfigKey(exch, "websocket", enabled, "websocketAPI")
+	}
+	return nil
+}
+
+// migrateForexPollingDelays converts the forex provider REST polling delays
+// which were set in seconds before version 2, as durations in nanoseconds
+// below the minimum polling interval
+func migrateForexPollingDelays(cfg map[string]interface{}) error {
+	currencyConfig, _ := cfg["currencyConfig"].(map[string]interface{})
+	providers, _ := currencyConfig["forexProviders"].([]interface{})
+	for x := range providers {
+		provider, ok := providers[x].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		n, ok := provider["restPollingDelay"].(json.Number)
+		if !ok {
+			continue
+		}
+		delay, err := n.Int64()
+		if err != nil {
+			return fmt.Errorf("forex provider REST polling delay %s is invalid", n)
+		}
+		if delay > 0 && time.Duration(delay) < minPollingInterval {
+			provider["restPollingDelay"] = time.Duration(delay) * time.Second
+		}
+	}
+	return nil
+}
+
+// configObject returns the object at a key, adding it if it does not exist
+func configObject(parent map[string]interface{}, key string) (map[string]interface{}, error) {
+	v, ok := parent[key]
+	if !ok || v == nil {
+		obj := make(map[string]interface{})
+		parent[key] = obj
+		return obj, nil
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object", key)
+	}
+	return obj, nil
+}
+
+// moveConfigKey moves a value to a new key, the existing value of the new key
+// is retained if it is set
+func moveConfigKey(from map[string]interface{}, fromKey string, to map[string]interface{}, toKey string) {
+	v, ok := from[fromKey]
+	if !ok {
+		return
+	}
+	delete(from, fromKey)
+	if existing, ok := to[toKey]; ok && existing != nil && existing != "" {
+		return
+	}
+	to[toKey] = v
+}
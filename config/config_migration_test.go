@@ -0,0 +1,185 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const legacyTestConfig = `{
+	"name": "legacy",
+	"encryptConfig": -1,
+	"currencyConfig": {
+		"forexProviders": [{"name": "ExchangeRates", "enabled": true, "primaryProvider": true, "restPollingDelay": 600}]
+	},
+	"exchanges": [
+		{
+			"name": "GDAX",
+			"enabled": true,
+			"authenticatedApiSupport": true,
+			"apiKey": "key",
+			"apiSecret": "secret",
+			"clientId": "client",
+			"apiUrl": "NON_DEFAULT_HTTP_LINK_TO_EXCHANGE_API",
+			"apiUrlSecondary": "NON_DEFAULT_HTTP_LINK_TO_EXCHANGE_API",
+			"supportsAutoPairUpdates": true,
+			"websocket": true,
+			"availablePairs": "BTC-USD,LTC-USD",
+			"enabledPairs": "BTC-USD",
+			"configCurrencyPairFormat": {"uppercase": true, "delimiter": "-"}
+		}
+	]
+}`
+
+func TestConfigMigrations(t *testing.T) {
+	t.Parallel()
+
+	if len(configMigrations) != Version {
+		t.Errorf("expected a migration to each of the %d versions, got %d", Version, len(configMigrations))
+	}
+
+	cfg := decodeTestConfigJSON(t, legacyTestConfig)
+	from, err := migrateConfigJSON(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 || cfg["version"] != Version {
+		t.Errorf("expected the config to be upgraded from version 0 to %d, got %d to %v", Version, from, cfg["version"])
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c Config
+	if err = json.Unmarshal(data, &c); err != nil {
+		t.Fatal(err)
+	}
+	e := c.Exchanges[0]
+	if e.Name != "CoinbasePro" ||
+		!e.API.AuthenticatedSupport ||
+		e.API.Credentials.Key != "key" ||
+		e.API.Credentials.Secret != "secret" ||
+		e.API.Credentials.ClientID != "client" ||
+		e.API.Endpoints.URL != APIURLNonDefaultMessage ||
+		!e.Features.Supports.RESTCapabilities.AutoPairUpdates ||
+		!e.Features.Enabled.AutoPairUpdates ||
+		!e.Features.Enabled.Websocket {
+		t.Errorf("expected the legacy exchange settings to be migrated, got %+v", e)
+	}
+	if e.APIKey != nil || e.SupportsAutoPairUpdates != nil || e.Websocket != nil {
+		t.Error("expected the legacy exchange settings to be removed")
+	}
+	if d := c.Currency.ForexProviders[0].RESTPollingDelay; d != time.Minute*10 {
+		t.Errorf("expected the forex polling delay to be converted to seconds, got %s", d)
+	}
+
+	// current configs are not migrated again
+	from, err = migrateConfigJSON(decodeTestConfigJSON(t, `{"version": 2}`))
+	if err != nil || from != Version {
+		t.Errorf("expected a current config to be unchanged, got version %d %v", from, err)
+	}
+	if _, err = migrateConfigJSON(decodeTestConfigJSON(t, `{"version": 99}`)); err == nil {
+		t.Error("expected configs newer than the supported version to be refused")
+	}
+	if _, err = migrateConfigJSON(decodeTestConfigJSON(t, `{"version": "one"}`)); err == nil {
+		t.Error("expected an invalid version to be refused")
+	}
+	if _, err = migrateConfigJSON(decodeTestConfigJSON(t, `{"exchanges": [{"name": "Bitstamp", "api": true}]}`)); err == nil {
+		t.Error("expected a migration error when a section is not an object")
+	}
+}
+
+func TestReadConfigUpgrade(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configupgrade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, File)
+	if err = ioutil.WriteFile(path, []byte(legacyTestConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var c Config
+	if err = c.ReadConfig(path, false); err != nil {
+		t.Fatal(err)
+	}
+	if c.Version != Version || c.Exchanges[0].Name != "CoinbasePro" {
+		t.Errorf("expected the config to be upgraded, got version %d", c.Version)
+	}
+
+	backup, err := ioutil.ReadFile(path + ".v0.bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(backup) != legacyTestConfig {
+		t.Error("expected the config before the upgrade to be backed up")
+	}
+	upgraded, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(upgraded), `"version": 2`) ||
+		strings.Contains(string(upgraded), "apiSecret") {
+		t.Errorf("expected the upgraded config to be saved in place, got %s", upgraded)
+	}
+
+	// encrypted configs are upgraded in place with the key they were
+	// decrypted with
+	sessionDK, storedSalt = nil, nil
+	defer func() { sessionDK, storedSalt = nil, nil }()
+	key := []byte("upgrade")
+	encrypted, err := EncryptConfigFile([]byte(strings.Replace(legacyTestConfig, `"encryptConfig": -1`, `"encryptConfig": 1`, 1)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionDK = nil
+	if err = ioutil.WriteFile(path, encrypted, 0600); err != nil {
+		t.Fatal(err)
+	}
+	stdin := filepath.Join(dir, "stdin")
+	if err = ioutil.WriteFile(stdin, append(key, '\n'), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	prompt := os.Stdin
+	os.Stdin = f
+	err = new(Config).ReadConfig(path, false)
+	os.Stdin = prompt
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup, err = ioutil.ReadFile(path + ".v0.bak"); err != nil || string(backup) != string(encrypted) {
+		t.Errorf("expected the encrypted config before the upgrade to be backed up, got %v", err)
+	}
+	if upgraded, err = ioutil.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if !ConfirmECS(upgraded) {
+		t.Fatal("expected the upgraded config to be saved encrypted")
+	}
+	if upgraded, err = DecryptConfigFile(upgraded, key); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(upgraded), `"version": 2`) {
+		t.Errorf("expected the upgraded config to be saved in place, got %s", upgraded)
+	}
+
+	// invalid settings are refused with the path of each setting
+	if err = ioutil.WriteFile(path, []byte(`{"version": 2, "exchanges": [{"name": "Bitstamp", "httpTimeout": 15}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err = c.ReadConfig(path, true)
+	if _, ok := err.(ValidationErrors); !ok || !strings.Contains(err.Error(), "exchanges[Bitstamp].httpTimeout") {
+		t.Errorf("expected the invalid HTTP timeout to be refused, got %v", err)
+	}
+}
@@ -1,18 +1,25 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/eventbus"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/ntpclient"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/timeseries"
 )
 
 const (
@@ -201,6 +208,7 @@ func TestPurgeExchangeCredentials(t *testing.T) {
 					OTPSecret: "otp",
 					PEMKey:    "aaa",
 				},
+				Keys: []APIKeyConfig{{Key: "reader", Secret: "s"}},
 			},
 		},
 		{
@@ -231,6 +239,9 @@ func TestPurgeExchangeCredentials(t *testing.T) {
 		exchCfg.API.Credentials.PEMKey != "" {
 		t.Error("unexpected values")
 	}
+	if exchCfg.API.Keys != nil {
+		t.Error("additional API keys should be purged")
+	}
 
 	exchCfg, err = c.GetExchangeConfig("test123")
 	if err != nil {
@@ -1155,6 +1166,132 @@ func TestUpdateExchangeConfig(t *testing.T) {
 }
 
 // TestCheckExchangeConfigValues logic test
+func TestCheckExchangeAPIKeys(t *testing.T) {
+	t.Parallel()
+	var c Config
+	exch := ExchangeConfig{
+		Name: "test",
+		API: APIConfig{
+			Credentials: APICredentialsConfig{Key: DefaultAPIKey},
+			Keys: []APIKeyConfig{
+				{Name: "empty"},
+				{Name: "default", Key: DefaultAPIKey},
+				{Name: "invalid", Key: "i", Permissions: []string{"withdraw"}},
+				{Name: "reader", Key: "r", Permissions: []string{"READ"}},
+				{Name: "trader", Key: "t", Secret: "s", Permissions: []string{"read", "trade"}},
+			},
+		},
+	}
+	c.checkExchangeAPIKeys(&exch)
+	if len(exch.API.Keys) != 2 ||
+		exch.API.Keys[0].Name != "reader" ||
+		exch.API.Keys[1].Name != "trader" {
+		t.Fatalf("unexpected keys %+v", exch.API.Keys)
+	}
+	if exch.API.Keys[0].Permissions[0] != APIKeyPermissionRead {
+		t.Error("permissions should be lower cased")
+	}
+	if exch.API.Credentials.Key != "t" || exch.API.Credentials.Secret != "s" {
+		t.Error("the first key which can trade should become the credentials")
+	}
+
+	exch.API.Credentials.Key = "primary"
+	exch.API.Keys = []APIKeyConfig{{Key: "other"}}
+	c.checkExchangeAPIKeys(&exch)
+	if exch.API.Credentials.Key != "primary" {
+		t.Error("set credentials should not be replaced")
+	}
+
+	exch.API.Keys = []APIKeyConfig{{Name: "empty"}}
+	c.checkExchangeAPIKeys(&exch)
+	if exch.API.Keys != nil {
+		t.Error("expected no keys")
+	}
+}
+
+func TestCheckExchangeAccounts(t *testing.T) {
+	t.Parallel()
+	var c Config
+	exch := ExchangeConfig{
+		Name: "test",
+		API: APIConfig{
+			Accounts: []APIAccountConfig{
+				{Name: " alice ", Credentials: APICredentialsConfig{Key: "a"}},
+				{Name: "ALICE", Credentials: APICredentialsConfig{Key: "b"}},
+				{Name: "", Credentials: APICredentialsConfig{Key: "c"}},
+				{Name: "bob:sub", Credentials: APICredentialsConfig{Key: "d"}},
+				{Name: "carol", Credentials: APICredentialsConfig{Key: DefaultAPIKey}},
+				{Name: "dave", Credentials: APICredentialsConfig{Key: "e", Secret: "s"}},
+			},
+		},
+	}
+	c.checkExchangeAccounts(&exch)
+	if len(exch.API.Accounts) != 2 ||
+		exch.API.Accounts[0].Name != "alice" ||
+		exch.API.Accounts[0].Credentials.Key != "a" ||
+		exch.API.Accounts[1].Name != "dave" {
+		t.Fatalf("unexpected accounts %+v", exch.API.Accounts)
+	}
+
+	exch.API.Accounts = []APIAccountConfig{{Name: "empty"}}
+	c.checkExchangeAccounts(&exch)
+	if exch.API.Accounts != nil {
+		t.Error("expected no accounts")
+	}
+}
+
+func TestCheckPairPolling(t *testing.T) {
+	t.Parallel()
+	var c Config
+	btc := currency.NewPairFromString(testPair)
+	exch := ExchangeConfig{
+		Name: "test",
+		PairPolling: &PairPollingConfig{
+			Intervals: map[string]time.Duration{
+				"HIGH":    0,
+				"normal":  time.Second * 10,
+				"instant": time.Millisecond,
+			},
+			DefaultPriority: "urgent",
+			Pairs: []PairPriority{
+				{Pair: btc, Priority: "High"},
+				{Pair: btc, Asset: asset.Futures, Interval: time.Second * 5},
+				{Pair: btc, Asset: "moon", Priority: PollingPriorityLow},
+				{Priority: PollingPriorityLow},
+				{Pair: btc, Asset: asset.Spot},
+				{Pair: btc, Asset: asset.Spot, Priority: "urgent"},
+			},
+		},
+	}
+	c.checkPairPolling(&exch)
+	p := exch.PairPolling
+	if len(p.Intervals) != 3 ||
+		p.Intervals[PollingPriorityHigh] != defaultHighPriorityPolling ||
+		p.Intervals[PollingPriorityNormal] != time.Second*10 ||
+		p.Intervals[PollingPriorityLow] != defaultLowPriorityPolling {
+		t.Errorf("unexpected tier intervals %v", p.Intervals)
+	}
+	if p.DefaultPriority != PollingPriorityNormal {
+		t.Errorf("expected an invalid default priority to be normal, got %s", p.DefaultPriority)
+	}
+	if len(p.Pairs) != 2 ||
+		p.Pairs[0].Priority != PollingPriorityHigh ||
+		p.Pairs[0].Asset != asset.Spot ||
+		p.Pairs[1].Asset != asset.Futures {
+		t.Fatalf("unexpected pairs %+v", p.Pairs)
+	}
+
+	if priority, interval := p.PollingInterval(btc, asset.Spot); priority != PollingPriorityHigh || interval != defaultHighPriorityPolling {
+		t.Errorf("expected the high priority interval, got %s %s", priority, interval)
+	}
+	if priority, interval := p.PollingInterval(btc, asset.Futures); priority != PollingPriorityNormal || interval != time.Second*5 {
+		t.Errorf("expected the pair's interval, got %s %s", priority, interval)
+	}
+	if priority, interval := p.PollingInterval(currency.NewPairFromString("LTC-USD"), asset.Spot); priority != PollingPriorityNormal || interval != time.Second*10 {
+		t.Errorf("expected the default priority interval, got %s %s", priority, interval)
+	}
+}
+
 func TestCheckExchangeConfigValues(t *testing.T) {
 	var cfg Config
 	if err := cfg.CheckExchangeConfigValues(); err == nil {
@@ -1524,6 +1661,700 @@ func TestCheckConnectionMonitorConfig(t *testing.T) {
 	}
 }
 
+func TestCheckStartupConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckStartupConfig()
+	if c.Startup.Window != defaultStartupWindow ||
+		c.Startup.MaxConcurrent != defaultStartupMaxConcurrent ||
+		c.Startup.ExchangeStagger != defaultStartupExchangeStagger {
+		t.Errorf("unexpected defaults %+v", c.Startup)
+	}
+
+	c.Startup = StartupConfig{Window: -1, MaxConcurrent: -1}
+	c.CheckStartupConfig()
+	if c.Startup.Window != 0 || c.Startup.MaxConcurrent != -1 {
+		t.Errorf("expected staggering to be disabled, received %+v", c.Startup)
+	}
+}
+
+func TestCheckColdStorageSweepConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.ColdStorageSweep.Rules = []SweepRule{
+		{Exchange: "Bitstamp", Currency: currency.BTC, Threshold: 10, Retain: 2, Address: "1337"},
+		{Exchange: "Bitstamp", Currency: currency.LTC, Threshold: 10, Retain: 20, Address: "1337"},
+		{Exchange: "Bitstamp", Currency: currency.ETH, Threshold: 0, Address: "1337"},
+		{Exchange: "Bitstamp", Currency: currency.XRP, Threshold: 10},
+	}
+	c.CheckColdStorageSweepConfig()
+
+	if c.ColdStorageSweep.Interval != defaultColdStorageSweepInterval {
+		t.Error("expected default interval to be set")
+	}
+	if len(c.ColdStorageSweep.Rules) != 2 {
+		t.Fatalf("expected 2 valid rules, got %d", len(c.ColdStorageSweep.Rules))
+	}
+	if c.ColdStorageSweep.Rules[0].Retain != 2 {
+		t.Error("expected valid retain amount to be kept")
+	}
+	if c.ColdStorageSweep.Rules[1].Retain != 10 {
+		t.Error("expected invalid retain amount to be set to the threshold")
+	}
+}
+
+func TestCheckLiquidityScreenConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.LiquidityScreen.MinVolume = -1
+	c.LiquidityScreen.MaxSpread = 0.5
+	c.LiquidityScreen.MinDepth = -1
+	c.CheckLiquidityScreenConfig()
+
+	if c.LiquidityScreen.Interval != defaultLiquidityScreenInterval {
+		t.Error("expected default interval to be set")
+	}
+	if c.LiquidityScreen.MinVolume != 0 || c.LiquidityScreen.MinDepth != 0 {
+		t.Error("expected negative thresholds to be reset")
+	}
+	if c.LiquidityScreen.MaxSpread != 0.5 {
+		t.Error("expected valid threshold to be kept")
+	}
+}
+
+func TestCheckTriangularArbConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.TriangularArb.MinProfit = -1
+	c.TriangularArb.DefaultFee = 100
+	c.TriangularArb.TradeAmounts = map[string]float64{"BTC": 0.1, "USD": -1}
+	c.CheckTriangularArbConfig()
+
+	if c.TriangularArb.Interval != defaultTriangularArbitrageInterval {
+		t.Error("expected default interval to be set")
+	}
+	if c.TriangularArb.MinProfit != 0 || c.TriangularArb.DefaultFee != 0 {
+		t.Error("expected invalid thresholds to be reset")
+	}
+	if len(c.TriangularArb.TradeAmounts) != 1 || c.TriangularArb.TradeAmounts["BTC"] != 0.1 {
+		t.Errorf("expected the invalid trade amount to be removed, got %v", c.TriangularArb.TradeAmounts)
+	}
+}
+
+func TestCheckPegConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.checkPegConfig()
+	if len(c.Currency.Pegs.Groups) != 0 {
+		t.Error("expected no groups when pegs are unused")
+	}
+	c.Currency.Pegs.Valuation = true
+	c.checkPegConfig()
+	if len(c.Currency.Pegs.Groups) != 1 || c.Currency.Pegs.Groups[0].Anchor != currency.USD {
+		t.Errorf("expected the default peg group to be set, got %+v", c.Currency.Pegs.Groups)
+	}
+
+	c.Currency.Pegs.Groups = []PegGroupConfig{
+		{Anchor: currency.USD, Members: []currency.Code{currency.USDT, currency.USDC}},
+		{Anchor: currency.EUR, Members: []currency.Code{currency.USDT}},
+		{Members: []currency.Code{currency.TUSD}},
+	}
+	c.checkPegConfig()
+	groups := c.Currency.Pegs.PegGroups()
+	if len(groups) != 1 {
+		t.Fatalf("expected invalid groups to be removed, got %+v", groups)
+	}
+	if len(groups[0].Members) != 2 || groups[0].Tolerance != currency.DefaultPegTolerance {
+		t.Errorf("unexpected peg group %+v", groups[0])
+	}
+}
+
+func TestCheckTranslationConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.Translations = []TranslationConfig{
+		{Exchange: "Bitstamp", Native: currency.XBT, Canonical: currency.BTC},
+		{Native: currency.DSH},
+		{Canonical: currency.DASH},
+	}
+	c.checkTranslationConfig()
+	translations := c.Currency.CodeTranslations()
+	if len(translations) != 1 || translations[0].Exchange != "Bitstamp" || translations[0].Canonical != currency.BTC {
+		t.Errorf("expected incomplete translations to be removed, got %+v", translations)
+	}
+}
+
+func TestCheckEquitySnapshotConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.CheckEquitySnapshotConfig()
+	if c.EquitySnapshot.Interval != defaultEquitySnapshotInterval {
+		t.Error("expected default interval to be set")
+	}
+	if c.EquitySnapshot.Currency != currency.AUD {
+		t.Error("expected currency to default to the fiat display currency")
+	}
+
+	c.EquitySnapshot.Numeraires = currency.Currencies{currency.BTC, currency.AUD, {}, currency.NewCode("btc"), currency.ETH}
+	c.CheckEquitySnapshotConfig()
+	if len(c.EquitySnapshot.Numeraires) != 2 ||
+		!c.EquitySnapshot.Numeraires[0].Match(currency.BTC) ||
+		!c.EquitySnapshot.Numeraires[1].Match(currency.ETH) {
+		t.Errorf("expected duplicate and snapshot currency numeraires to be removed, got %v", c.EquitySnapshot.Numeraires)
+	}
+}
+
+func TestCheckBalanceCacheConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckBalanceCacheConfig()
+	if c.BalanceCache.TTL != defaultBalanceCacheTTL {
+		t.Errorf("expected the default TTL, got %v", c.BalanceCache.TTL)
+	}
+
+	c.BalanceCache.TTL = time.Minute
+	c.CheckBalanceCacheConfig()
+	if c.BalanceCache.TTL != time.Minute {
+		t.Errorf("expected the TTL to be retained, got %v", c.BalanceCache.TTL)
+	}
+}
+
+func TestCheckSettlementConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.CheckSettlementConfig()
+	if c.Settlement.CloseTime != defaultSettlementCloseTime ||
+		c.Settlement.Currency != currency.AUD ||
+		c.Settlement.Tolerance != defaultSettlementTolerance {
+		t.Errorf("expected defaults to be set, got %+v", c.Settlement)
+	}
+
+	c.Settlement.CloseTime = "25:00"
+	c.CheckSettlementConfig()
+	if c.Settlement.CloseTime != defaultSettlementCloseTime {
+		t.Errorf("expected an invalid close time to be defaulted, got %s", c.Settlement.CloseTime)
+	}
+
+	c.Settlement.CloseTime = "17:30"
+	c.CheckSettlementConfig()
+	if c.Settlement.CloseTime != "17:30" {
+		t.Errorf("expected the close time to be retained, got %s", c.Settlement.CloseTime)
+	}
+}
+
+func TestCheckDailyReportConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.CheckDailyReportConfig()
+	if c.DailyReport.SendTime != defaultDailyReportSendTime || c.DailyReport.Currency != currency.AUD {
+		t.Errorf("expected defaults to be set, got %+v", c.DailyReport)
+	}
+
+	c.DailyReport.SendTime = "8am"
+	c.CheckDailyReportConfig()
+	if c.DailyReport.SendTime != defaultDailyReportSendTime {
+		t.Errorf("expected an invalid send time to be defaulted, got %s", c.DailyReport.SendTime)
+	}
+
+	c.DailyReport.SendTime = "08:00"
+	c.CheckDailyReportConfig()
+	if c.DailyReport.SendTime != "08:00" {
+		t.Errorf("expected the send time to be retained, got %s", c.DailyReport.SendTime)
+	}
+}
+
+func TestCheckEventBusConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.EventBus.Outputs = []EventBusOutputConfig{
+		{Sink: "NATS", Address: "localhost:4222", Events: []string{"Trade", "trade", "quote", "orderbook"}},
+		{Name: "missing address", Sink: "tcp"},
+		{Name: "custom", Sink: "kafka", Address: "localhost:9092", Encoding: "MsgPack", Subject: "bot", BufferSize: 10},
+	}
+	c.CheckEventBusConfig()
+	if len(c.EventBus.Outputs) != 2 {
+		t.Fatalf("expected output without an address to be removed, got %+v", c.EventBus.Outputs)
+	}
+	o := c.EventBus.Outputs[0]
+	if o.Name != "output 1" ||
+		o.Sink != "nats" ||
+		o.Encoding != eventbus.JSONEncoding ||
+		o.Subject != defaultEventBusSubject ||
+		o.BufferSize != eventbus.DefaultBufferSize {
+		t.Errorf("expected defaults to be set, got %+v", o)
+	}
+	if c.EventBus.OrderbookDepth != defaultEventBusOrderbookDepth {
+		t.Errorf("expected default orderbook depth, got %d", c.EventBus.OrderbookDepth)
+	}
+	if len(o.Events) != 2 || o.Events[0] != eventbus.TradeEvent || o.Events[1] != eventbus.OrderbookEvent {
+		t.Errorf("expected unsupported and duplicate events to be removed, got %v", o.Events)
+	}
+	o = c.EventBus.Outputs[1]
+	if o.Sink != "kafka" || o.Encoding != eventbus.MsgpackEncoding || o.Subject != "bot" || o.BufferSize != 10 {
+		t.Errorf("expected values to be retained, got %+v", o)
+	}
+}
+
+func TestCheckTimeSeriesConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.USD
+	c.TimeSeries.Enabled = true
+	c.CheckTimeSeriesConfig()
+	if c.TimeSeries.Interval != defaultTimeSeriesInterval ||
+		c.TimeSeries.Driver != timeseries.InfluxDB ||
+		c.TimeSeries.Currency != currency.USD {
+		t.Errorf("expected defaults to be set, got %+v", c.TimeSeries)
+	}
+	if c.TimeSeries.Enabled {
+		t.Error("expected time-series to be disabled without an InfluxDB URL")
+	}
+
+	c.TimeSeries.Enabled = true
+	c.TimeSeries.Driver = "TimescaleDB"
+	c.TimeSeries.TimescaleDB.Host = "localhost"
+	c.TimeSeries.Interval = time.Second * 10
+	c.CheckTimeSeriesConfig()
+	if !c.TimeSeries.Enabled ||
+		c.TimeSeries.Driver != timeseries.TimescaleDB ||
+		c.TimeSeries.Interval != time.Second*10 {
+		t.Errorf("expected values to be retained, got %+v", c.TimeSeries)
+	}
+
+	c.TimeSeries.Driver = "graphite"
+	c.CheckTimeSeriesConfig()
+	if c.TimeSeries.Driver != timeseries.InfluxDB || c.TimeSeries.Enabled {
+		t.Errorf("expected unsupported driver to default to InfluxDB and be disabled, got %+v", c.TimeSeries)
+	}
+}
+
+func TestCheckSnapshotExportConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckSnapshotExportConfig()
+	if c.SnapshotExport.Interval != defaultSnapshotExportInterval ||
+		c.SnapshotExport.Format != SnapshotExportCSV ||
+		c.SnapshotExport.Rotation != defaultSnapshotExportRotation ||
+		c.SnapshotExport.Retention != defaultSnapshotExportRetention {
+		t.Errorf("expected defaults to be set, got %+v", c.SnapshotExport)
+	}
+
+	c.SnapshotExport.Format = "JSON"
+	c.SnapshotExport.Interval = time.Hour * 2
+	c.CheckSnapshotExportConfig()
+	if c.SnapshotExport.Format != SnapshotExportJSON {
+		t.Errorf("expected the format to be retained, got %s", c.SnapshotExport.Format)
+	}
+	if c.SnapshotExport.Rotation != defaultSnapshotExportRotation {
+		t.Errorf("expected the rotation to be retained, got %v", c.SnapshotExport.Rotation)
+	}
+
+	c.SnapshotExport.Format = "xml"
+	c.SnapshotExport.Rotation = time.Hour
+	c.CheckSnapshotExportConfig()
+	if c.SnapshotExport.Format != SnapshotExportCSV {
+		t.Errorf("expected an invalid format to be defaulted, got %s", c.SnapshotExport.Format)
+	}
+	if c.SnapshotExport.Rotation != defaultSnapshotExportRotation {
+		t.Errorf("expected a rotation shorter than the interval to be defaulted, got %v", c.SnapshotExport.Rotation)
+	}
+}
+
+func TestCheckOrderReconcileConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckOrderReconcileConfig()
+	if c.OrderReconcile.Retention != defaultOrderReconcileRetention {
+		t.Errorf("expected the default retention, got %v", c.OrderReconcile.Retention)
+	}
+
+	c.OrderReconcile.Retention = time.Hour
+	c.CheckOrderReconcileConfig()
+	if c.OrderReconcile.Retention != time.Hour {
+		t.Errorf("expected the retention to be retained, got %v", c.OrderReconcile.Retention)
+	}
+}
+
+func TestCheckCandleBuilderConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckCandleBuilderConfig()
+	if len(c.CandleBuilder.Intervals) != 3 ||
+		c.CandleBuilder.Length != defaultCandleBuilderLength {
+		t.Errorf("expected defaults to be set, got %+v", c.CandleBuilder)
+	}
+
+	c.CandleBuilder.Intervals = []time.Duration{time.Minute, -time.Minute, time.Minute, time.Hour}
+	c.CheckCandleBuilderConfig()
+	if len(c.CandleBuilder.Intervals) != 2 ||
+		c.CandleBuilder.Intervals[0] != time.Minute ||
+		c.CandleBuilder.Intervals[1] != time.Hour {
+		t.Errorf("expected invalid and duplicate intervals to be removed, got %v", c.CandleBuilder.Intervals)
+	}
+}
+
+func TestCheckStrategiesConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Strategies.Strategies = []StrategyConfig{
+		{Strategy: "smacross", Enabled: true, Exchange: "Bitstamp", Pair: currency.NewPair(currency.BTC, currency.USD)},
+		{Strategy: "smacross", Enabled: true, Exchange: "Bitstamp", Pair: currency.NewPair(currency.BTC, currency.USD)},
+		{Name: "nopair", Strategy: "smacross", Enabled: true, Exchange: "Bitstamp"},
+		{Name: "badasset", Strategy: "smacross", Enabled: true, Exchange: "Bitstamp", Pair: currency.NewPair(currency.BTC, currency.USD), AssetType: "nope"},
+	}
+	c.CheckStrategiesConfig()
+	s := c.Strategies.Strategies
+	if s[0].Name != "smacross-bitstamp-btcusd-spot" ||
+		s[0].AssetType != asset.Spot ||
+		s[0].Interval != time.Minute ||
+		!s[0].Enabled {
+		t.Errorf("expected defaults to be set, got %+v", s[0])
+	}
+	if s[1].Enabled {
+		t.Error("expected a duplicated name to be disabled")
+	}
+	if s[2].Enabled || s[3].Enabled {
+		t.Error("expected invalid strategies to be disabled")
+	}
+}
+
+func TestCheckConditionalOrdersConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckConditionalOrdersConfig()
+	if c.ConditionalOrders.Interval != defaultConditionalOrdersInterval {
+		t.Error("expected default interval to be set")
+	}
+}
+
+func TestCheckBotStateConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckBotStateConfig()
+	if c.BotState.Interval != defaultBotStateInterval {
+		t.Error("expected default interval to be set")
+	}
+}
+
+func TestCheckAuctionHistoryConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckAuctionHistoryConfig()
+	if c.AuctionHistory.Interval != defaultAuctionHistoryInterval {
+		t.Error("expected default interval to be set")
+	}
+}
+
+func TestCheckFundingHistoryConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckFundingHistoryConfig()
+	if c.FundingHistory.Interval != defaultFundingHistoryInterval {
+		t.Error("expected default interval to be set")
+	}
+}
+
+func TestCheckPositionsConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckPositionsConfig()
+	if c.Positions.Interval != defaultPositionsInterval {
+		t.Error("expected default interval to be set")
+	}
+	if c.Positions.Lookback != defaultPositionsLookback {
+		t.Error("expected default lookback to be set")
+	}
+}
+
+func TestCheckDerivativesDataConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckDerivativesDataConfig()
+	if c.DerivativesData.Interval != defaultDerivativesDataInterval {
+		t.Error("expected default interval to be set")
+	}
+	if c.DerivativesData.OpenInterestHistory != derivative.DefaultOpenInterestHistory ||
+		c.DerivativesData.LiquidationHistory != derivative.DefaultLiquidationHistory {
+		t.Error("expected default history lengths to be set")
+	}
+}
+
+func TestCheckExchangeHealthConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckExchangeHealthConfig()
+	if c.ExchangeHealth.Interval != defaultExchangeHealthInterval ||
+		c.ExchangeHealth.MinRequests != defaultExchangeHealthMinRequests ||
+		c.ExchangeHealth.MaxLatency != defaultExchangeHealthMaxLatency ||
+		c.ExchangeHealth.DegradedErrorRate != defaultExchangeHealthDegradedErrors ||
+		c.ExchangeHealth.OfflineErrorRate != defaultExchangeHealthOfflineErrors ||
+		c.ExchangeHealth.MaxDisconnects != defaultExchangeHealthMaxDisconnects {
+		t.Errorf("expected defaults to be set, got %+v", c.ExchangeHealth)
+	}
+
+	c.ExchangeHealth.DegradedErrorRate = 0.4
+	c.ExchangeHealth.OfflineErrorRate = 0.2
+	c.CheckExchangeHealthConfig()
+	if c.ExchangeHealth.OfflineErrorRate != 0.4 {
+		t.Error("expected the offline error rate to be raised to the degraded error rate")
+	}
+}
+
+func TestCheckTimeSyncConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.TimeSync.MaxDrift = -time.Second
+	c.CheckTimeSyncConfig()
+	if c.TimeSync.Interval != defaultTimeSyncInterval {
+		t.Error("expected default interval to be set")
+	}
+	if c.TimeSync.MaxDrift != defaultTimeSyncMaxDrift {
+		t.Error("expected invalid maximum drift to be reset")
+	}
+}
+
+func TestCheckMaintenanceConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckMaintenanceConfig()
+	if c.Maintenance.Interval != defaultMaintenanceInterval {
+		t.Error("expected default interval to be set")
+	}
+}
+
+func TestCheckFIXGatewayConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.FIXGateway.Sessions = []FIXSessionConfig{
+		{TargetCompID: "FUND"},
+		{},
+		{TargetCompID: "FUND"},
+		{TargetCompID: defaultFIXGatewaySenderCompID},
+		{TargetCompID: "DESK", Exchanges: []string{"Bitstamp"}},
+	}
+	c.CheckFIXGatewayConfig()
+	if c.FIXGateway.ListenAddress != defaultFIXGatewayListenAddress ||
+		c.FIXGateway.SenderCompID != defaultFIXGatewaySenderCompID {
+		t.Error("expected defaults to be set")
+	}
+	if len(c.FIXGateway.Sessions) != 2 ||
+		c.FIXGateway.Sessions[0].TargetCompID != "FUND" ||
+		c.FIXGateway.Sessions[1].TargetCompID != "DESK" {
+		t.Errorf("unexpected sessions %+v", c.FIXGateway.Sessions)
+	}
+}
+
+func TestCheckMaintenance(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 5, 26, 2, 0, 0, 0, time.UTC)
+	var c Config
+	exch := ExchangeConfig{
+		Name: "test",
+		Maintenance: &ExchangeMaintenance{
+			StatusPage: "https://status.gemini.com/",
+			Windows: []MaintenanceWindow{
+				{Start: start, End: start.Add(time.Hour), Repeat: "Weekly"},
+				{Start: start, End: start},
+				{Start: start, End: start.Add(time.Hour), Repeat: "monthly"},
+				{Start: start, End: start.Add(time.Hour * 25), Repeat: MaintenanceRepeatDaily},
+				{Start: start, End: start.Add(time.Hour * 25)},
+			},
+		},
+	}
+	c.checkMaintenance(&exch)
+	mt := exch.Maintenance
+	if mt.StatusPage != "https://status.gemini.com" {
+		t.Errorf("unexpected status page %s", mt.StatusPage)
+	}
+	if len(mt.Windows) != 2 || mt.Windows[0].Repeat != MaintenanceRepeatWeekly {
+		t.Fatalf("unexpected windows %+v", mt.Windows)
+	}
+
+	s, e, ok := mt.Windows[0].Occurrence(start.Add(time.Minute))
+	if !ok || !s.Equal(start) || !e.Equal(start.Add(time.Hour)) {
+		t.Errorf("unexpected occurrence %s %s %v", s, e, ok)
+	}
+	s, e, ok = mt.Windows[0].Occurrence(start.Add(time.Hour * 24 * 15))
+	if !ok || !s.Equal(start.Add(time.Hour*24*21)) || !e.Equal(start.Add(time.Hour*24*21+time.Hour)) {
+		t.Errorf("unexpected repeated occurrence %s %s %v", s, e, ok)
+	}
+	if _, _, ok = mt.Windows[1].Occurrence(start.Add(time.Hour * 26)); ok {
+		t.Error("expected window which does not repeat to have ended")
+	}
+}
+
+func TestCheckCredentialsProvider(t *testing.T) {
+	t.Parallel()
+
+	c := Config{
+		Exchanges: []ExchangeConfig{
+			{Name: "Binance", API: APIConfig{CredentialsProvider: &APICredentialsProviderConfig{Type: "ENV"}}},
+			{Name: "Bitstamp", API: APIConfig{CredentialsProvider: &APICredentialsProviderConfig{Type: "vault"}}},
+			{Name: "Kraken", API: APIConfig{CredentialsProvider: &APICredentialsProviderConfig{Type: "config"}}},
+			{Name: "Poloniex", API: APIConfig{CredentialsProvider: &APICredentialsProviderConfig{Type: "keychain"}}},
+		},
+	}
+	for x := range c.Exchanges {
+		c.Exchanges[x].API.Credentials.Key = "key"
+		c.checkCredentialsProvider(&c.Exchanges[x])
+	}
+	if p := c.Exchanges[0].API.CredentialsProvider; p == nil || p.Type != "env" || p.EnvPrefix != "GCT_BINANCE" {
+		t.Errorf("unexpected env provider %+v", p)
+	}
+	if p := c.Exchanges[1].API.CredentialsProvider; p == nil || p.VaultPath != "gocryptotrader/bitstamp" {
+		t.Errorf("unexpected vault provider %+v", p)
+	}
+	if c.Exchanges[2].API.CredentialsProvider != nil || c.Exchanges[3].API.CredentialsProvider != nil {
+		t.Error("expected config and unknown providers to be removed")
+	}
+
+	saved := c.withoutProvidedCredentials()
+	if saved.Exchanges[0].API.Credentials.Key != "" || saved.Exchanges[2].API.Credentials.Key != "key" {
+		t.Error("expected only provided credentials to be removed when saved")
+	}
+	if c.Exchanges[0].API.Credentials.Key != "key" {
+		t.Error("expected the loaded credentials to be retained")
+	}
+}
+
+func TestCheckResourceMonitorConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckResourceMonitorConfig()
+	if c.ResourceMonitor.Interval != defaultResourceMonitorInterval ||
+		c.ResourceMonitor.MaxCPU != defaultResourceMonitorMaxCPU ||
+		c.ResourceMonitor.OrderbookDepth != defaultResourceMonitorOrderbookDepth ||
+		c.ResourceMonitor.PollingMultiplier != defaultResourceMonitorPollingFactor ||
+		len(c.ResourceMonitor.Tiers) != 3 {
+		t.Errorf("expected defaults to be set, got %+v", c.ResourceMonitor)
+	}
+
+	c.ResourceMonitor.Tiers = []string{"Analytics", "bogus", DegradeAnalytics, DegradeOrderbookDepth}
+	c.CheckResourceMonitorConfig()
+	if len(c.ResourceMonitor.Tiers) != 2 ||
+		c.ResourceMonitor.Tiers[0] != DegradeAnalytics ||
+		c.ResourceMonitor.Tiers[1] != DegradeOrderbookDepth {
+		t.Errorf("expected invalid and duplicate tiers to be removed, got %v", c.ResourceMonitor.Tiers)
+	}
+}
+
+func TestCheckAutomationsConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckAutomationsConfig()
+	if c.Automations.Interval != defaultAutomationsInterval {
+		t.Error("expected default interval to be set")
+	}
+}
+
+func TestCheckRiskLimitsConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.RiskLimits.MaxOrderValue = -1
+	c.RiskLimits.MaxLeverage = 3
+	c.CheckRiskLimitsConfig()
+	if c.RiskLimits.Currency != currency.AUD {
+		t.Error("expected currency to default to the fiat display currency")
+	}
+	if c.RiskLimits.MaxOrderValue != 0 {
+		t.Error("expected negative limit to be disabled")
+	}
+	if c.RiskLimits.MaxLeverage != 3 {
+		t.Error("expected valid limit to be retained")
+	}
+}
+
+func TestCheckRiskManagementConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Currency.FiatDisplayCurrency = currency.AUD
+	c.RiskManagement.Exchanges = []ExchangeRiskConfig{
+		{Exchange: "Bitstamp", MaxOrderNotional: -1, MaxOpenOrders: 5, MaxSlippage: -1},
+		{MaxOpenOrders: 1},
+		{Exchange: "bitstamp", MaxOpenOrders: 1},
+	}
+	c.CheckRiskManagementConfig()
+	if c.RiskManagement.Currency != currency.AUD {
+		t.Error("expected currency to default to the fiat display currency")
+	}
+	if len(c.RiskManagement.Exchanges) != 1 {
+		t.Fatalf("expected unnamed and duplicate rules to be removed, got %+v", c.RiskManagement.Exchanges)
+	}
+	if c.RiskManagement.Exchanges[0].MaxOrderNotional != 0 || c.RiskManagement.Exchanges[0].MaxSlippage != 0 {
+		t.Error("expected negative limits to be disabled")
+	}
+	if c.RiskManagement.Exchanges[0].MaxOpenOrders != 5 {
+		t.Error("expected valid limit to be retained")
+	}
+}
+
+func TestCheckTenantConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.RemoteControl.Username = "admin"
+	c.Exchanges = []ExchangeConfig{
+		{Name: "Bitstamp"},
+		{Name: "Kraken", API: APIConfig{Accounts: []APIAccountConfig{{Name: "carol"}}}},
+	}
+	c.Tenants = []TenantConfig{
+		{Name: "alice", Username: "alice", Password: "pw", Exchanges: []string{"Bitstamp", "Bitfinex"}},
+		{Name: "bob", Username: "bob", Password: "pw", Exchanges: []string{"bitstamp", "Kraken", "Kraken:carol", "Kraken:dave"}},
+		{Name: "admin", Username: "admin", Password: "pw"},
+		{Name: "bob2", Username: "bob", Password: "pw"},
+		{Name: "nopassword", Username: "nopassword"},
+	}
+	c.CheckTenantConfig()
+
+	if len(c.Tenants) != 2 {
+		t.Fatalf("expected 2 valid tenants, got %d", len(c.Tenants))
+	}
+	if len(c.Tenants[0].Exchanges) != 1 || c.Tenants[0].Exchanges[0] != "Bitstamp" {
+		t.Errorf("expected unknown exchange to be removed, got %v", c.Tenants[0].Exchanges)
+	}
+	if len(c.Tenants[1].Exchanges) != 2 || c.Tenants[1].Exchanges[0] != "Kraken" {
+		t.Errorf("expected exchange owned by another tenant to be removed, got %v", c.Tenants[1].Exchanges)
+	}
+	if c.Tenants[1].Exchanges[1] != "Kraken:carol" {
+		t.Errorf("expected known account to be retained, got %v", c.Tenants[1].Exchanges)
+	}
+}
+
 func TestDefaultFilePath(t *testing.T) {
 	// This is tricky to test because we're dealing with a config file stored
 	// in a persons default directory and to properly test it, it would
@@ -1585,6 +2416,17 @@ func TestCheckRemoteControlConfig(t *testing.T) {
 		t.Error("unexpected results")
 	}
 
+	c.RemoteControl.TLS.CertFile = "cert.pem"
+	c.CheckRemoteControlConfig()
+	if c.RemoteControl.TLS.CertFile != "" {
+		t.Error("expected a certificate without a key to be ignored")
+	}
+	c.RemoteControl.TLS = TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"}
+	c.CheckRemoteControlConfig()
+	if c.RemoteControl.TLS.CertFile != "cert.pem" || c.RemoteControl.TLS.KeyFile != "key.pem" {
+		t.Error("expected the certificate and key to be kept")
+	}
+
 	// Now test to ensure the previous settings are flushed
 	if c.Webserver != nil {
 		t.Error("old webserver settings should be nil")
@@ -1749,6 +2591,15 @@ func TestCheckDatabaseConfig(t *testing.T) {
 	if err := c.checkDatabaseConfig(); err != nil {
 		t.Error(err)
 	}
+
+	c.Database.Driver = database.DBBolt
+	c.Database.Database = ""
+	if err := c.checkDatabaseConfig(); err != nil {
+		t.Error(err)
+	}
+	if c.Database.Database != database.DefaultBoltDatabase {
+		t.Errorf("expected %s, got %s", database.DefaultBoltDatabase, c.Database.Database)
+	}
 }
 
 func TestCheckNTPConfig(t *testing.T) {
@@ -1839,8 +2690,26 @@ func TestCheckCurrencyConfigValues(t *testing.T) {
 }
 
 func TestPreengineConfigUpgrade(t *testing.T) {
+	// the config is upgraded in place so a copy is loaded
+	data, err := ioutil.ReadFile("../testdata/preengine_config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "preengine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, File)
+	if err = ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
 	var c Config
-	if err := c.LoadConfig("../testdata/preengine_config.json", false); err != nil {
+	if err = c.LoadConfig(path, false); err != nil {
 		t.Fatal(err)
 	}
+	if c.Version != Version {
+		t.Errorf("expected the config to be upgraded to version %d, got %d", Version, c.Version)
+	}
 }
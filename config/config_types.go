@@ -6,6 +6,8 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -24,18 +26,76 @@ const (
 	fileEncryptionDisabled               = -1
 	pairsLastUpdatedWarningThreshold     = 30 // 30 days
 	defaultHTTPTimeout                   = time.Second * 15
+	defaultForexRESTPollingDelay         = time.Minute * 10
 	defaultWebsocketResponseCheckTimeout = time.Millisecond * 30
 	defaultWebsocketResponseMaxLimit     = time.Second * 7
 	defaultWebsocketOrderbookBufferLimit = 5
 	defaultWebsocketTrafficTimeout       = time.Second * 30
 	maxAuthFailures                      = 3
+	defaultStartupWindow                 = time.Minute * 2
+	defaultStartupMaxConcurrent          = 4
+	defaultStartupExchangeStagger        = time.Millisecond * 250
 	defaultNTPAllowedDifference          = 50000000
 	defaultNTPAllowedNegativeDifference  = 50000000
+	defaultColdStorageSweepInterval      = time.Hour
+	defaultLiquidityScreenInterval       = time.Minute * 15
+	defaultTriangularArbitrageInterval   = time.Second * 10
+	defaultEquitySnapshotInterval        = time.Minute
+	defaultConditionalOrdersInterval     = time.Second
+	defaultAuctionHistoryInterval        = time.Minute * 15
+	defaultFundingHistoryInterval        = time.Minute * 15
+	defaultPositionsInterval             = time.Minute
+	defaultPositionsLookback             = time.Hour * 24 * 7
+	defaultDerivativesDataInterval       = time.Minute
+	defaultExchangeHealthInterval        = time.Minute
+	defaultExchangeHealthMinRequests     = 5
+	defaultExchangeHealthMaxLatency      = time.Second * 2
+	defaultExchangeHealthDegradedErrors  = 0.1
+	defaultExchangeHealthOfflineErrors   = 0.5
+	defaultExchangeHealthMaxDisconnects  = 3
+	defaultTimeSyncInterval              = time.Minute * 15
+	defaultTimeSyncMaxDrift              = time.Second
+	defaultMaintenanceInterval           = time.Minute * 15
+	defaultFIXGatewayListenAddress       = "localhost:9880"
+	defaultFIXGatewaySenderCompID        = "GCT"
+	defaultAutomationsInterval           = time.Minute
+	defaultResourceMonitorInterval       = time.Second * 10
+	defaultResourceMonitorMaxCPU         = 80
+	defaultResourceMonitorOrderbookDepth = 20
+	defaultResourceMonitorPollingFactor  = 3
+	defaultSettlementCloseTime           = "00:00"
+	defaultSettlementTolerance           = 0.00000001
+	defaultCandleBuilderLength           = 500
+	defaultHighPriorityPolling           = time.Second * 2
+	defaultLowPriorityPolling            = time.Minute
+	defaultBalanceCacheTTL               = time.Second * 30
+	defaultSnapshotExportInterval        = time.Minute
+	defaultSnapshotExportRotation        = time.Hour * 24
+	defaultSnapshotExportRetention       = time.Hour * 24 * 7
+	defaultOrderReconcileRetention       = time.Hour * 24 * 7
+	defaultBotStateInterval              = time.Minute
+	defaultDailyReportSendTime           = "00:00"
+	defaultEventBusSubject               = "gct"
+	defaultEventBusOrderbookDepth        = 20
+	defaultTimeSeriesInterval            = time.Minute
 	DefaultAPIKey                        = "Key"
 	DefaultAPISecret                     = "Secret"
 	DefaultAPIClientID                   = "ClientID"
 )
 
+// Pair polling priority tiers
+const (
+	PollingPriorityHigh   = "high"
+	PollingPriorityNormal = "normal"
+	PollingPriorityLow    = "low"
+)
+
+// API key permissions
+const (
+	APIKeyPermissionRead  = "read"
+	APIKeyPermissionTrade = "trade"
+)
+
 // Constants here hold some messages
 const (
 	ErrExchangeNameEmpty                       = "exchange #%d name is empty"
@@ -77,12 +137,15 @@ var (
 // prestart management of Portfolio, Communications, Webserver and Enabled
 // Exchanges
 type Config struct {
+	Version           int                     `json:"version"`
 	Name              string                  `json:"name"`
 	EncryptConfig     int                     `json:"encryptConfig"`
 	GlobalHTTPTimeout time.Duration           `json:"globalHTTPTimeout"`
+	GlobalHTTPClient  HTTPClientConfig        `json:"globalHTTPClient"`
 	Database          database.Config         `json:"database"`
 	Logging           log.Config              `json:"logging"`
 	ConnectionMonitor ConnectionMonitorConfig `json:"connectionMonitor"`
+	Startup           StartupConfig           `json:"startup"`
 	Profiler          Profiler                `json:"profiler"`
 	NTPClient         NTPClientConfig         `json:"ntpclient"`
 	GCTScript         gctscript.Config        `json:"gctscript"`
@@ -90,8 +153,38 @@ type Config struct {
 	Communications    CommunicationsConfig    `json:"communications"`
 	RemoteControl     RemoteControlConfig     `json:"remoteControl"`
 	Portfolio         portfolio.Base          `json:"portfolioAddresses"`
+	ColdStorageSweep  ColdStorageSweepConfig  `json:"coldStorageSweep"`
+	LiquidityScreen   LiquidityScreenConfig   `json:"liquidityScreen"`
+	TriangularArb     TriangularArbConfig     `json:"triangularArbitrage"`
+	EquitySnapshot    EquitySnapshotConfig    `json:"equitySnapshot"`
+	RiskLimits        RiskLimitsConfig        `json:"riskLimits"`
+	RiskManagement    RiskManagementConfig    `json:"riskManagement"`
+	ConditionalOrders ConditionalOrdersConfig `json:"conditionalOrders"`
+	AuctionHistory    AuctionHistoryConfig    `json:"auctionHistory"`
+	FundingHistory    FundingHistoryConfig    `json:"fundingHistory"`
+	Positions         PositionsConfig         `json:"positions"`
+	DerivativesData   DerivativesDataConfig   `json:"derivativesData"`
+	ExchangeHealth    ExchangeHealthConfig    `json:"exchangeHealth"`
+	TimeSync          TimeSyncConfig          `json:"timeSync"`
+	Maintenance       MaintenanceConfig       `json:"maintenance"`
+	FIXGateway        FIXGatewayConfig        `json:"fixGateway"`
+	SecretStores      SecretStoresConfig      `json:"secretStores"`
+	Automations       AutomationsConfig       `json:"automations"`
+	ResourceMonitor   ResourceMonitorConfig   `json:"resourceMonitor"`
+	Settlement        SettlementConfig        `json:"settlement"`
+	BalanceCache      BalanceCacheConfig      `json:"balanceCache"`
+	CandleBuilder     CandleBuilderConfig     `json:"candleBuilder"`
+	SnapshotExport    SnapshotExportConfig    `json:"snapshotExport"`
+	OrderReconcile    OrderReconcileConfig    `json:"orderReconciliation"`
+	Strategies        StrategiesConfig        `json:"strategies"`
+	RequestAudit      RequestAuditConfig      `json:"requestAudit"`
+	BotState          BotStateConfig          `json:"botState"`
+	DailyReport       DailyReportConfig       `json:"dailyReport"`
+	EventBus          EventBusConfig          `json:"eventBus"`
+	TimeSeries        TimeSeriesConfig        `json:"timeSeries"`
 	Exchanges         []ExchangeConfig        `json:"exchanges"`
 	BankAccounts      []banking.Account       `json:"bankAccounts"`
+	Tenants           []TenantConfig          `json:"tenants,omitempty"`
 
 	// Deprecated config settings, will be removed at a future date
 	Webserver           *WebserverConfig          `json:"webserver,omitempty"`
@@ -109,14 +202,508 @@ type ConnectionMonitorConfig struct {
 	CheckInterval    time.Duration `json:"checkInterval"`
 }
 
+// StartupConfig defines how the requests exchanges make as the bot starts,
+// such as fetching symbols, connecting websockets and fetching the initial
+// snapshots of each pair, are staggered to stay within their rate limits
+type StartupConfig struct {
+	// Window is how long after the bot starts requests are staggered, zero
+	// disables staggering
+	Window time.Duration `json:"window"`
+	// MaxConcurrent is the amount of startup steps run at once across all
+	// exchanges
+	MaxConcurrent int `json:"maxConcurrent"`
+	// ExchangeStagger is the minimum time between the startup steps of an
+	// exchange
+	ExchangeStagger time.Duration `json:"exchangeStagger"`
+}
+
+// ColdStorageSweepConfig defines the policies used to automatically sweep
+// exchange balances to whitelisted cold storage addresses
+type ColdStorageSweepConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	Rules    []SweepRule   `json:"rules"`
+}
+
+// SweepRule sweeps any balance of a currency held on an exchange above
+// Threshold down to Retain, sending the difference to Address
+type SweepRule struct {
+	Exchange   string        `json:"exchange"`
+	Currency   currency.Code `json:"currency"`
+	Threshold  float64       `json:"threshold"`
+	Retain     float64       `json:"retain"`
+	Address    string        `json:"address"`
+	AddressTag string        `json:"addressTag,omitempty"`
+}
+
+// LiquidityScreenConfig defines the minimum liquidity a pair must have before
+// strategies are allowed to trade it. Pairs are re-evaluated every Interval and
+// suspended when they no longer meet the thresholds
+type LiquidityScreenConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// MinVolume is the minimum 24 hour base currency volume
+	MinVolume float64 `json:"minVolume"`
+	// MaxSpread is the maximum bid ask spread as a percentage of the mid price
+	MaxSpread float64 `json:"maxSpread"`
+	// MinDepth is the minimum base currency amount on each side of the
+	// orderbook
+	MinDepth float64 `json:"minDepth"`
+}
+
+// TriangularArbConfig defines the detection of triangular arbitrage cycles,
+// such as BTC to ETH to USD and back to BTC, within the spot markets of each
+// exchange. Cycles are evaluated every Interval from the stored tickers after
+// taker fees
+type TriangularArbConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// Exchanges limits detection to the named exchanges, all loaded
+	// exchanges are scanned when empty
+	Exchanges []string `json:"exchanges,omitempty"`
+	// StartCurrencies are the currencies cycles start and end in, when empty
+	// each cycle is reported once starting from its first currency
+	// alphabetically
+	StartCurrencies []string `json:"startCurrencies,omitempty"`
+	// MinProfit is the implied profit, as a percentage, a cycle must exceed
+	// to be reported as an opportunity
+	MinProfit float64 `json:"minProfit"`
+	// DefaultFee is the taker fee, as a percentage, used when an exchange
+	// is unable to provide its fee
+	DefaultFee float64 `json:"defaultFee"`
+	// Execute submits the legs of opportunities through the order manager
+	// when they are first detected
+	Execute bool `json:"execute"`
+	// TradeAmounts are the amounts of each start currency traded when
+	// executing a cycle, cycles starting in other currencies are not executed
+	TradeAmounts map[string]float64 `json:"tradeAmounts,omitempty"`
+}
+
+// EquitySnapshotConfig defines how often the equity and balance of the
+// portfolio and each strategy are stored to the database
+type EquitySnapshotConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// Currency is the currency snapshots are valued in, defaulting to the
+	// fiat display currency
+	Currency currency.Code `json:"currency"`
+	// Numeraires are additional currencies, such as BTC or ETH, snapshots are
+	// also valued in so that reports can be requested in their terms
+	Numeraires currency.Currencies `json:"numeraires,omitempty"`
+}
+
+// ConditionalOrdersConfig defines how often locally held stop-loss and
+// take-profit orders are checked against the ticker
+type ConditionalOrdersConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+}
+
+// BotStateConfig defines how often the bot's runtime state, such as strategy
+// state and the portfolio, is persisted so it can be resumed after a restart
+type BotStateConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+}
+
+// AuctionHistoryConfig defines how often exchange auction events are collected
+// into the database
+type AuctionHistoryConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// IncludeIndicative also collects the indicative price and quantity
+	// publications which precede each auction
+	IncludeIndicative bool `json:"includeIndicative"`
+}
+
+// FundingHistoryConfig defines how often the deposits and withdrawals of
+// authenticated exchanges are synced into the database
+type FundingHistoryConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+}
+
+// PositionsConfig defines how often account fills are read from the order
+// manager and exchange trade history to track positions
+type PositionsConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// Lookback is how far back trade history is read on startup to rebuild
+	// the positions
+	Lookback time.Duration `json:"lookback"`
+}
+
+// DerivativesDataConfig defines how often the open interest and liquidations
+// of enabled derivatives contracts are collected and how many are retained
+// for each contract
+type DerivativesDataConfig struct {
+	Enabled             bool          `json:"enabled"`
+	Interval            time.Duration `json:"interval"`
+	OpenInterestHistory int           `json:"openInterestHistory"`
+	LiquidationHistory  int           `json:"liquidationHistory"`
+}
+
+// ExchangeHealthConfig defines how often each exchange's request latency,
+// error rate and websocket disconnects are checked and the thresholds at
+// which an exchange is marked degraded or offline. Orders are not routed to
+// offline exchanges
+type ExchangeHealthConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// MinRequests is the amount of requests required before the latency and
+	// error rate are evaluated, requests accumulate across checks until it
+	// is reached
+	MinRequests int64 `json:"minRequests"`
+	// MaxLatency is the average request latency above which an exchange is
+	// degraded
+	MaxLatency time.Duration `json:"maxLatency"`
+	// DegradedErrorRate and OfflineErrorRate are the ratios of failed
+	// requests at which an exchange is degraded or offline
+	DegradedErrorRate float64 `json:"degradedErrorRate"`
+	OfflineErrorRate  float64 `json:"offlineErrorRate"`
+	// MaxDisconnects is the amount of websocket disconnects within a check
+	// at which an exchange is degraded
+	MaxDisconnects int64 `json:"maxDisconnects"`
+}
+
+// TimeSyncConfig defines how often the local clock is checked against the
+// server time of exchanges with authenticated API support. Drift beyond
+// MaxDrift is warned about and, when AdjustTimestamps is set, the exchange's
+// request timestamps and nonces are offset to match its clock
+type TimeSyncConfig struct {
+	Enabled          bool          `json:"enabled"`
+	Interval         time.Duration `json:"interval"`
+	MaxDrift         time.Duration `json:"maxDrift"`
+	AdjustTimestamps bool          `json:"adjustTimestamps"`
+}
+
+// MaintenanceConfig defines how often the status pages of exchanges are polled
+// for scheduled maintenance. Orders are not placed on exchanges during their
+// maintenance windows and their health alerts are suppressed
+type MaintenanceConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+}
+
+// FIXGatewayConfig defines the FIX 4.4 order entry gateway. Sessions logon
+// with their TargetCompID as their SenderCompID and route NewOrderSingle and
+// OrderCancelRequest messages to the order manager
+type FIXGatewayConfig struct {
+	Enabled       bool               `json:"enabled"`
+	ListenAddress string             `json:"listenAddress"`
+	SenderCompID  string             `json:"senderCompID"`
+	Sessions      []FIXSessionConfig `json:"sessions,omitempty"`
+}
+
+// FIXSessionConfig defines a counterparty permitted to logon to the FIX
+// gateway. The session may only route orders to its exchanges when set
+type FIXSessionConfig struct {
+	TargetCompID string   `json:"targetCompID"`
+	Password     string   `json:"password,omitempty"`
+	Exchanges    []string `json:"exchanges,omitempty"`
+}
+
+// SecretStoresConfig defines the secret stores exchange API credentials may be
+// read from. Vault tokens and AWS access keys are read from the VAULT_TOKEN,
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment
+// variables so they are not stored alongside the credentials they protect
+type SecretStoresConfig struct {
+	Vault VaultConfig `json:"vault"`
+	KMS   KMSConfig   `json:"kms"`
+}
+
+// VaultConfig defines the HashiCorp Vault server and the mount of its KV
+// version 2 secrets engine. The address defaults to the VAULT_ADDR environment
+// variable
+type VaultConfig struct {
+	Address string `json:"address,omitempty"`
+	Mount   string `json:"mount,omitempty"`
+}
+
+// KMSConfig defines the AWS region of the KMS key exchange API credentials
+// are encrypted with. The region defaults to the AWS_REGION environment
+// variable
+type KMSConfig struct {
+	Region   string `json:"region,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// AutomationsConfig defines the YAML file declarative automations are loaded
+// from and how often their conditions are evaluated. The file defaults to
+// automations.yaml in the data directory
+type AutomationsConfig struct {
+	Enabled  bool          `json:"enabled"`
+	File     string        `json:"file,omitempty"`
+	Interval time.Duration `json:"interval"`
+}
+
+// RequestAuditConfig defines where each attempt of an authenticated exchange
+// request and its raw response are recorded. File defaults to requests.audit
+// in the data directory and is only ever appended to
+type RequestAuditConfig struct {
+	Enabled bool   `json:"enabled"`
+	File    string `json:"file,omitempty"`
+	// Database additionally records requests as audit events while the
+	// database is connected
+	Database bool `json:"database"`
+}
+
+// Resource monitor degradation tiers
+const (
+	DegradeOrderbookDepth   = "orderbook_depth"
+	DegradePollingIntervals = "polling_intervals"
+	DegradeAnalytics        = "analytics"
+)
+
+// ResourceMonitorConfig defines the process CPU and memory usage at which the
+// bot is under resource pressure and the tiers of degradation applied, one
+// per check in the configured order, while the pressure persists. Tiers are
+// lifted in reverse order once the pressure subsides
+type ResourceMonitorConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// MaxCPU is the percentage of the machine's total CPU capacity used by
+	// the process above which it is under pressure
+	MaxCPU float64 `json:"maxCPU"`
+	// MaxMemoryMB is the heap memory in megabytes above which the process is
+	// under pressure, zero disables the memory check
+	MaxMemoryMB uint64   `json:"maxMemoryMB"`
+	Tiers       []string `json:"tiers"`
+	// OrderbookDepth is the amount of levels per side orderbooks are
+	// truncated to while the orderbook depth tier is applied
+	OrderbookDepth int `json:"orderbookDepth"`
+	// PollingMultiplier is the factor REST polling intervals are widened by
+	// while the polling intervals tier is applied
+	PollingMultiplier float64 `json:"pollingMultiplier"`
+}
+
+// SettlementCloseTimeFormat is the layout of the settlement close time
+const SettlementCloseTimeFormat = "15:04"
+
+// SettlementConfig defines when the trading day is closed each day and how
+// venue balances are reconciled at the close
+type SettlementConfig struct {
+	Enabled bool `json:"enabled"`
+	// CloseTime is the UTC time of day, formatted as HH:MM, the trading day
+	// closes at
+	CloseTime string `json:"closeTime"`
+	// Currency is the currency realised profit and loss is rolled into the
+	// ledger in, defaulting to the fiat display currency
+	Currency currency.Code `json:"currency"`
+	// Tolerance is the difference between a venue's balance and the balance
+	// expected from the previous close and the day's fills and transfers
+	// which is not reported as a break
+	Tolerance float64 `json:"tolerance"`
+}
+
+// BalanceCacheConfig defines how long exchange balances are served from the
+// cache before they are refreshed from the exchange
+type BalanceCacheConfig struct {
+	Enabled bool          `json:"enabled"`
+	TTL     time.Duration `json:"ttl"`
+}
+
+// Snapshot export file formats
+const (
+	SnapshotExportCSV  = "csv"
+	SnapshotExportJSON = "json"
+)
+
+// SnapshotExportConfig defines how often ticker and top of book snapshots of
+// each enabled pair are written to files, how long each file covers before a
+// new one is started and how long files are kept
+type SnapshotExportConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// Format is csv or json, json files hold a snapshot object per line
+	Format string `json:"format"`
+	// Directory defaults to snapshots in the data directory
+	Directory string        `json:"directory,omitempty"`
+	Rotation  time.Duration `json:"rotation"`
+	// Retention is how long files are kept after they were last written to
+	Retention time.Duration `json:"retention"`
+}
+
+// OrderReconcileConfig defines whether the order manager persists its orders
+// and reconciles them against each exchange's open orders and order history
+// on startup, and how long finished orders are persisted for
+type OrderReconcileConfig struct {
+	Enabled   bool          `json:"enabled"`
+	Retention time.Duration `json:"retention"`
+}
+
+// CandleBuilderConfig defines the intervals rolling candles are built at from
+// the trade feed of each exchange, pair and asset
+type CandleBuilderConfig struct {
+	Enabled   bool            `json:"enabled"`
+	Intervals []time.Duration `json:"intervals"`
+	// Length is the amount of candles retained for each interval
+	Length int `json:"length"`
+}
+
+// StrategiesConfig defines the strategy instances run by the strategy manager
+// and the Go plugins strategies are loaded from
+type StrategiesConfig struct {
+	Enabled bool `json:"enabled"`
+	// Plugins are the paths of strategies built with -buildmode=plugin,
+	// each is registered under its file name without the extension
+	Plugins    []string         `json:"plugins,omitempty"`
+	Strategies []StrategyConfig `json:"strategies"`
+}
+
+// StrategyConfig is an instance of a registered strategy run on an exchange's
+// currency pair
+type StrategyConfig struct {
+	// Name identifies the instance, defaulting to the strategy, exchange,
+	// pair and asset
+	Name string `json:"name"`
+	// Strategy is the name of the registered strategy
+	Strategy  string        `json:"strategy"`
+	Enabled   bool          `json:"enabled"`
+	Exchange  string        `json:"exchange"`
+	Pair      currency.Pair `json:"pair"`
+	AssetType asset.Item    `json:"assetType"`
+	// Interval is the interval of the candles passed to the strategy
+	Interval   time.Duration     `json:"interval"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// CancelOrdersOnStop cancels the instance's open orders when it is
+	// stopped, including when the bot shuts down
+	CancelOrdersOnStop bool `json:"cancelOrdersOnStop,omitempty"`
+}
+
+// DailyReportConfig defines when the daily summary report is emailed through
+// the communications relayers which send email
+type DailyReportConfig struct {
+	Enabled bool `json:"enabled"`
+	// SendTime is the UTC time of day, formatted as HH:MM, the report
+	// covering the previous 24 hours is sent
+	SendTime string `json:"sendTime"`
+	// Currency is the currency balances and profit and loss are valued in,
+	// defaulting to the fiat display currency
+	Currency currency.Code `json:"currency"`
+}
+
+// EventBusConfig defines the outputs tickers, trades, orderbooks, order
+// updates and alerts are forwarded to by the internal event bus
+type EventBusConfig struct {
+	Enabled bool `json:"enabled"`
+	// OrderbookDepth is the amount of levels of each side of an orderbook
+	// published, all levels when negative
+	OrderbookDepth int                    `json:"orderbookDepth"`
+	Outputs        []EventBusOutputConfig `json:"outputs"`
+}
+
+// EventBusOutputConfig defines an external consumer events are forwarded to
+type EventBusOutputConfig struct {
+	Name string `json:"name"`
+	// Sink is nats, kafka, tcp or a sink registered with the event bus
+	Sink string `json:"sink"`
+	// Address is the server's host and port, followed by /topic for kafka
+	Address string `json:"address"`
+	// Encoding is json, msgpack, protobuf or an encoding registered with the
+	// event bus
+	Encoding string `json:"encoding"`
+	// Subject prefixes the subject each event is sent under, which is
+	// followed by the event type and exchange
+	Subject string `json:"subject"`
+	// Events are the event types forwarded, all when empty
+	Events []string `json:"events,omitempty"`
+	// BufferSize is the amount of events queued for the output before
+	// further events are dropped
+	BufferSize int `json:"bufferSize"`
+}
+
+// TimeSeriesConfig defines the time-series database ticker prices, spreads
+// and portfolio valuations are written to for dashboards such as Grafana
+type TimeSeriesConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+	// Driver is influxdb or timescaledb
+	Driver string `json:"driver"`
+	// Currency is the currency the portfolio is valued in, defaulting to the
+	// fiat display currency
+	Currency    currency.Code             `json:"currency"`
+	InfluxDB    TimeSeriesInfluxDBConfig  `json:"influxDB"`
+	TimescaleDB drivers.ConnectionDetails `json:"timescaleDB"`
+}
+
+// TimeSeriesInfluxDBConfig defines the InfluxDB server points are written to.
+// The v2 API is used with Token when Organisation is set and Database is the
+// bucket, otherwise the v1 API is used with the optional Username and Password
+type TimeSeriesInfluxDBConfig struct {
+	URL          string `json:"url"`
+	Database     string `json:"database"`
+	Organisation string `json:"organisation,omitempty"`
+	Token        string `json:"token,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+}
+
+// RiskLimitsConfig defines the limits the portfolio's exposure is measured
+// against. All limits are valued in Currency and a zero value disables the
+// limit
+type RiskLimitsConfig struct {
+	// Currency is the currency exposure is valued in, defaulting to the fiat
+	// display currency
+	Currency currency.Code `json:"currency"`
+	// MaxOrderValue is the maximum value of a single order
+	MaxOrderValue float64 `json:"maxOrderValue"`
+	// MaxPositionValue is the maximum absolute value held in any one currency
+	// other than Currency
+	MaxPositionValue float64 `json:"maxPositionValue"`
+	// MaxGrossExposure is the maximum sum of the absolute values held in
+	// currencies other than Currency
+	MaxGrossExposure float64 `json:"maxGrossExposure"`
+	// MaxLeverage is the maximum ratio of gross exposure to equity
+	MaxLeverage float64 `json:"maxLeverage"`
+}
+
+// RiskManagementConfig defines the pre-trade checks every order must pass
+// before it is submitted. Rules are set per exchange, values are in Currency
+// and a zero value disables a check
+type RiskManagementConfig struct {
+	Enabled bool `json:"enabled"`
+	// Currency is the currency notional, position and loss limits are valued
+	// in, defaulting to the fiat display currency
+	Currency currency.Code `json:"currency"`
+	// AlertViolations sends rejected orders to the communications subsystem
+	AlertViolations bool                 `json:"alertViolations"`
+	Exchanges       []ExchangeRiskConfig `json:"exchanges"`
+}
+
+// ExchangeRiskConfig is the set of risk rules applied to an exchange's orders
+type ExchangeRiskConfig struct {
+	Exchange string `json:"exchange"`
+	// MaxPositionSize is the maximum absolute value of the net position in
+	// any one pair once the order fills
+	MaxPositionSize float64 `json:"maxPositionSize"`
+	// MaxOrderNotional is the maximum value of a single order
+	MaxOrderNotional float64 `json:"maxOrderNotional"`
+	// MaxDailyLoss is the loss since midnight UTC at which orders which do not
+	// reduce a position are rejected
+	MaxDailyLoss  float64 `json:"maxDailyLoss"`
+	MaxOpenOrders int     `json:"maxOpenOrders"`
+	// MaxSlippage is the maximum percentage the estimated average fill price
+	// of a market order may be from the mid price of the stored orderbook,
+	// market orders the orderbook is unable to fill are also rejected
+	MaxSlippage float64        `json:"maxSlippage"`
+	BannedPairs currency.Pairs `json:"bannedPairs,omitempty"`
+}
+
 // ExchangeConfig holds all the information needed for each enabled Exchange.
 type ExchangeConfig struct {
 	Name                          string                 `json:"name"`
 	Enabled                       bool                   `json:"enabled"`
 	Verbose                       bool                   `json:"verbose"`
 	UseSandbox                    bool                   `json:"useSandbox,omitempty"`
+	Region                        string                 `json:"region,omitempty"`
 	HTTPTimeout                   time.Duration          `json:"httpTimeout"`
 	HTTPUserAgent                 string                 `json:"httpUserAgent,omitempty"`
+	HTTPHeaders                   map[string]string      `json:"httpHeaders,omitempty"`
+	BrokerID                      string                 `json:"brokerID,omitempty"`
+	MarketOrderProtection         float64                `json:"marketOrderProtection,omitempty"`
+	HeartbeatInterval             time.Duration          `json:"heartbeatInterval,omitempty"`
+	CancelOnDisconnect            bool                   `json:"cancelOnDisconnect,omitempty"`
 	HTTPDebugging                 bool                   `json:"httpDebugging,omitempty"`
 	WebsocketResponseCheckTimeout time.Duration          `json:"websocketResponseCheckTimeout"`
 	WebsocketResponseMaxLimit     time.Duration          `json:"websocketResponseMaxLimit"`
@@ -127,6 +714,8 @@ type ExchangeConfig struct {
 	CurrencyPairs                 *currency.PairsManager `json:"currencyPairs"`
 	API                           APIConfig              `json:"api"`
 	Features                      *FeaturesConfig        `json:"features"`
+	PairPolling                   *PairPollingConfig     `json:"pairPolling,omitempty"`
+	Maintenance                   *ExchangeMaintenance   `json:"maintenance,omitempty"`
 	BankAccounts                  []banking.Account      `json:"bankAccounts,omitempty"`
 
 	// Deprecated settings which will be removed in a future update
@@ -171,12 +760,15 @@ type GRPCConfig struct {
 	ListenAddress          string `json:"listenAddress"`
 	GRPCProxyEnabled       bool   `json:"grpcProxyEnabled"`
 	GRPCProxyListenAddress string `json:"grpcProxyListenAddress"`
+	// GRPCProxyTLS serves the gRPC proxy over https
+	GRPCProxyTLS bool `json:"grpcProxyTLS"`
 }
 
 // DepcrecatedRPCConfig stores the deprecatedRPCConfig settings
 type DepcrecatedRPCConfig struct {
 	Enabled       bool   `json:"enabled"`
 	ListenAddress string `json:"listenAddress"`
+	TLS           bool   `json:"tls"`
 }
 
 // WebsocketRPCConfig stores the websocket config info
@@ -186,6 +778,9 @@ type WebsocketRPCConfig struct {
 	ConnectionLimit     int    `json:"connectionLimit"`
 	MaxAuthFailures     int    `json:"maxAuthFailures"`
 	AllowInsecureOrigin bool   `json:"allowInsecureOrigin"`
+	TLS                 bool   `json:"tls"`
+	// Dashboard serves the web dashboard from the websocket RPC server
+	Dashboard bool `json:"dashboard"`
 }
 
 // RemoteControlConfig stores the RPC services config
@@ -196,6 +791,30 @@ type RemoteControlConfig struct {
 	GRPC          GRPCConfig           `json:"gRPC"`
 	DeprecatedRPC DepcrecatedRPCConfig `json:"deprecatedRPC"`
 	WebsocketRPC  WebsocketRPCConfig   `json:"websocketRPC"`
+	TLS           TLSConfig            `json:"tls"`
+}
+
+// TLSConfig stores the certificate the remote control servers are served
+// with. The gRPC server is always served over TLS, the other servers when
+// their TLS setting is enabled. When no certificate is configured a
+// self-signed certificate is generated in the data directory
+type TLSConfig struct {
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// TenantConfig defines a logical user sharing the bot. A tenant authenticates
+// to the management API with its own credentials and can only access the
+// exchanges it owns, the strategies trading on them and its own portfolio
+// addresses. Exchange credentials are those of the owned exchanges or
+// exchange accounts, and database records are only reachable through the
+// methods scoped to an owned exchange
+type TenantConfig struct {
+	Name      string         `json:"name"`
+	Username  string         `json:"username"`
+	Password  string         `json:"password"`
+	Exchanges []string       `json:"exchanges"`
+	Portfolio portfolio.Base `json:"portfolioAddresses"`
 }
 
 // WebserverConfig stores the old webserver config
@@ -238,6 +857,36 @@ type CurrencyConfig struct {
 	FiatDisplayCurrency           currency.Code             `json:"fiatDisplayCurrency"`
 	CurrencyFileUpdateDuration    time.Duration             `json:"currencyFileUpdateDuration"`
 	ForeignExchangeUpdateDuration time.Duration             `json:"foreignExchangeUpdateDuration"`
+	Pegs                          PegConfig                 `json:"pegs"`
+	Translations                  []TranslationConfig       `json:"translations,omitempty"`
+}
+
+// TranslationConfig maps the code an exchange uses for a currency to the
+// canonical code, overriding the default translations. An empty exchange
+// applies to every exchange and a code translated to itself disables its
+// default translation
+type TranslationConfig struct {
+	Exchange  string        `json:"exchange,omitempty"`
+	Native    currency.Code `json:"native"`
+	Canonical currency.Code `json:"canonical"`
+}
+
+// PegConfig sets whether pegged currencies, such as USD stablecoins, are
+// treated as equivalent to their anchor when valuing holdings and scanning for
+// arbitrage. Exchange pairs are not modified
+type PegConfig struct {
+	Valuation bool             `json:"valuation"`
+	Arbitrage bool             `json:"arbitrage"`
+	Groups    []PegGroupConfig `json:"groups"`
+}
+
+// PegGroupConfig is a set of currencies pegged to an anchor currency, a member
+// priced outside the tolerance percentage of par is no longer treated as
+// pegged
+type PegGroupConfig struct {
+	Anchor    currency.Code   `json:"anchor"`
+	Members   []currency.Code `json:"members"`
+	Tolerance float64         `json:"tolerance"`
 }
 
 // CryptocurrencyProvider defines coinmarketcap tools
@@ -330,7 +979,10 @@ type FeaturesSupportedConfig struct {
 // FeaturesEnabledConfig stores the exchanges enabled features
 type FeaturesEnabledConfig struct {
 	AutoPairUpdates bool `json:"autoPairUpdates"`
-	Websocket       bool `json:"websocketAPI"`
+	// AutoEnableQuotes are the quote currencies of newly listed pairs which
+	// are enabled when the available pairs are updated
+	AutoEnableQuotes currency.Currencies `json:"autoEnableQuotes,omitempty"`
+	Websocket        bool                `json:"websocketAPI"`
 }
 
 // FeaturesConfig stores the exchanges supported and enabled features
@@ -355,6 +1007,73 @@ type APICredentialsConfig struct {
 	OTPSecret string `json:"otpSecret,omitempty"`
 }
 
+// PairPollingConfig defines how often the syncer refreshes the REST data of an
+// exchange's pairs. Each pair polls at the interval of its priority tier, the
+// normal tier defaults to the syncer's timeout
+type PairPollingConfig struct {
+	Intervals map[string]time.Duration `json:"intervals,omitempty"`
+	// DefaultPriority is the tier of pairs which are not set
+	DefaultPriority string         `json:"defaultPriority,omitempty"`
+	Pairs           []PairPriority `json:"pairs,omitempty"`
+}
+
+// Maintenance window repeat intervals
+const (
+	MaintenanceRepeatDaily  = "daily"
+	MaintenanceRepeatWeekly = "weekly"
+)
+
+// ExchangeMaintenance defines the planned downtime of an exchange, set as
+// windows and fetched from the exchange's Statuspage status page
+type ExchangeMaintenance struct {
+	// StatusPage is the base URL of the exchange's status page, such as
+	// https://status.gemini.com
+	StatusPage string              `json:"statusPage,omitempty"`
+	Windows    []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// MaintenanceWindow is a period of planned downtime, repeating daily or
+// weekly from its first occurrence when Repeat is set
+type MaintenanceWindow struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Repeat string    `json:"repeat,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// PairPriority sets the polling priority tier of a pair, an interval
+// overrides the tier's interval
+type PairPriority struct {
+	Pair     currency.Pair `json:"pair"`
+	Asset    asset.Item    `json:"asset"`
+	Priority string        `json:"priority,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+}
+
+// APIKeyConfig stores an additional API key pair and the permissions of the
+// requests it signs. A key without permissions signs all requests
+type APIKeyConfig struct {
+	Name        string   `json:"name,omitempty"`
+	Key         string   `json:"key"`
+	Secret      string   `json:"secret,omitempty"`
+	ClientID    string   `json:"clientID,omitempty"`
+	PEMKey      string   `json:"pemKey,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// AccountDelimiter separates an exchange's name from the name of one of its
+// additional accounts, such as Binance:alice
+const AccountDelimiter = ":"
+
+// APIAccountConfig stores the credentials of an additional account on an
+// exchange, such as a sub-account or another user's account. Each account's
+// orders and balances are kept separate from the exchange's and are referred
+// to by the exchange's name and the account's name joined by AccountDelimiter
+type APIAccountConfig struct {
+	Name        string               `json:"name"`
+	Credentials APICredentialsConfig `json:"credentials"`
+}
+
 // APICredentialsValidatorConfig stores the API credentials validator settings
 type APICredentialsValidatorConfig struct {
 	// For Huobi (optional)
@@ -374,5 +1093,38 @@ type APIConfig struct {
 
 	Endpoints            APIEndpointsConfig             `json:"endpoints"`
 	Credentials          APICredentialsConfig           `json:"credentials"`
+	Keys                 []APIKeyConfig                 `json:"keys,omitempty"`
+	Accounts             []APIAccountConfig             `json:"accounts,omitempty"`
 	CredentialsValidator *APICredentialsValidatorConfig `json:"credentialsValidator,omitempty"`
+	CredentialsProvider  *APICredentialsProviderConfig  `json:"credentialsProvider,omitempty"`
+}
+
+// APICredentialsProviderConfig selects the secret store an exchange's API
+// credentials are read from when it is loaded, credentials read from a
+// secret store are not saved to the config file
+type APICredentialsProviderConfig struct {
+	// Type is config, env, vault or kms
+	Type string `json:"type"`
+	// EnvPrefix prefixes the environment variables of the env provider,
+	// such as GCT_BINANCE_API_KEY
+	EnvPrefix string `json:"envPrefix,omitempty"`
+	// VaultPath is the path of the secret holding the credentials
+	VaultPath string `json:"vaultPath,omitempty"`
+	// Encrypted are the base64 ciphertexts of the credentials encrypted with
+	// the KMS key
+	Encrypted *APICredentialsConfig `json:"encrypted,omitempty"`
+}
+
+// HTTPClientConfig stores the network settings for the common HTTP client
+// used for requests outside of the exchange wrappers. Proxy supports HTTP,
+// HTTPS and SOCKS5 URLs
+type HTTPClientConfig struct {
+	Proxy               string        `json:"proxy,omitempty"`
+	CACertFile          string        `json:"caCertFile,omitempty"`
+	MaxIdleConns        int           `json:"maxIdleConns,omitempty"`
+	MaxIdleConnsPerHost int           `json:"maxIdleConnsPerHost,omitempty"`
+	IdleConnTimeout     time.Duration `json:"idleConnTimeout,omitempty"`
+	KeepAlive           time.Duration `json:"keepAlive,omitempty"`
+	TLSHandshakeTimeout time.Duration `json:"tlsHandshakeTimeout,omitempty"`
+	DisableKeepAlives   bool          `json:"disableKeepAlives,omitempty"`
 }
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Bounds of the durations set in the config
+const (
+	minConfigDuration  = time.Millisecond
+	minPollingInterval = time.Second
+	maxPollingInterval = time.Hour * 24 * 7
+)
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+	// pollingIntervalKeys are the keys of durations which set how often a
+	// subsystem polls
+	pollingIntervalKeys = map[string]bool{
+		"interval":         true,
+		"checkinterval":    true,
+		"restpollingdelay": true,
+	}
+)
+
+// ValidationError is a problem found at a path of the config file
+type ValidationError struct {
+	Path    string
+	Problem string
+}
+
+// Error implements the error interface
+func (v ValidationError) Error() string {
+	return v.Path + ": " + v.Problem
+}
+
+// ValidationErrors are the problems found when validating the config file
+type ValidationErrors []ValidationError
+
+// Error implements the error interface
+func (v ValidationErrors) Error() string {
+	problems := make([]string, len(v))
+	for x := range v {
+		problems[x] = v[x].Error()
+	}
+	return fmt.Sprintf("config file has %d invalid settings: %s",
+		len(v),
+		strings.Join(problems, "; "))
+}
+
+func (v ValidationErrors) sort() {
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Path < v[j].Path
+	})
+}
+
+type configValidator struct {
+	unknown ValidationErrors
+	invalid ValidationErrors
+}
+
+// validateConfigJSON checks the decoded JSON of a config file for unknown keys,
+// which are returned separately as they are ignored when the config is
+// decoded, and for missing required fields, invalid currency pairs and
+// durations outside of their bounds
+func validateConfigJSON(cfg map[string]interface{}) (unknown, invalid ValidationErrors) {
+	var v configValidator
+	v.walk("", "", cfg, reflect.TypeOf(Config{}))
+	v.checkExchanges(cfg)
+	v.unknown.sort()
+	v.invalid.sort()
+	return v.unknown, v.invalid
+}
+
+func (v *configValidator) addInvalid(path, problem string, args ...interface{}) {
+	v.invalid = append(v.invalid, ValidationError{
+		Path:    path,
+		Problem: fmt.Sprintf(problem, args...),
+	})
+}
+
+// walk compares a JSON value against the type it is decoded into
+func (v *configValidator) walk(path, key string, value interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == durationType {
+		v.checkDuration(path, key, value)
+		return
+	}
+	// types decoding themselves are checked when decoded
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for k, val := range obj {
+			// keys are matched case insensitively when decoded
+			f, ok := fields[strings.ToLower(k)]
+			if !ok {
+				v.unknown = append(v.unknown, ValidationError{
+					Path:    joinConfigPath(path, k),
+					Problem: "unknown key",
+				})
+				continue
+			}
+			v.walk(joinConfigPath(path, k), k, val, f)
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, val := range obj {
+			v.walk(joinConfigPath(path, k), key, val, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for x := range arr {
+			v.walk(elementConfigPath(path, x, arr[x]), key, arr[x], t.Elem())
+		}
+	}
+}
+
+// checkDuration checks a duration, set in nanoseconds, against its bounds.
+// Zero durations are replaced with their defaults
+func (v *configValidator) checkDuration(path, key string, value interface{}) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return
+	}
+	i, err := n.Int64()
+	if err != nil {
+		return
+	}
+	d := time.Duration(i)
+	if d == 0 {
+		return
+	}
+	switch {
+	case d < 0:
+		v.addInvalid(path, "duration %s must not be negative", d)
+	case pollingIntervalKeys[strings.ToLower(key)]:
+		if d < minPollingInterval || d > maxPollingInterval {
+			v.addInvalid(path,
+				"polling interval %s must be between %s and %s, durations are set in nanoseconds",
+				d,
+				minPollingInterval,
+				maxPollingInterval)
+		}
+	case d < minConfigDuration:
+		v.addInvalid(path,
+			"duration %s must be at least %s, durations are set in nanoseconds",
+			d,
+			minConfigDuration)
+	}
+}
+
+// checkExchanges checks the exchanges are named and their currency pairs are
+// valid
+func (v *configValidator) checkExchanges(cfg map[string]interface{}) {
+	exchanges, _ := cfg["exchanges"].([]interface{})
+	if len(exchanges) == 0 {
+		v.addInvalid("exchanges", "at least one exchange is required")
+		return
+	}
+	for x := range exchanges {
+		path := elementConfigPath("exchanges", x, exchanges[x])
+		exch, ok := exchanges[x].(map[string]interface{})
+		if !ok {
+			v.addInvalid(path, "must be an object")
+			continue
+		}
+		if name, _ := exch["name"].(string); strings.TrimSpace(name) == "" {
+			v.addInvalid(joinConfigPath(path, "name"), "is required")
+		}
+		if pairs, ok := exch["currencyPairs"].(map[string]interface{}); ok {
+			v.checkCurrencyPairs(joinConfigPath(path, "currencyPairs"), pairs)
+		}
+	}
+}
+
+// checkCurrencyPairs checks the asset types are supported and the pairs of
+// each asset are delimited by its config format
+func (v *configValidator) checkCurrencyPairs(path string, cp map[string]interface{}) {
+	useGlobal, _ := cp["useGlobalFormat"].(bool)
+	globalDelimiter := pairFormatDelimiter(cp)
+	if assets, ok := cp["assetTypes"].([]interface{}); ok {
+		for x := range assets {
+			a, _ := assets[x].(string)
+			if !asset.IsValid(asset.Item(strings.ToLower(a))) {
+				v.addInvalid(joinConfigPath(path, "assetTypes"), "asset type %q is not supported", a)
+			}
+		}
+	}
+
+	stores, _ := cp["pairs"].(map[string]interface{})
+	for a, s := range stores {
+		storePath := joinConfigPath(joinConfigPath(path, "pairs"), a)
+		if !asset.IsValid(asset.Item(strings.ToLower(a))) {
+			v.addInvalid(storePath, "asset type %q is not supported", a)
+		}
+		store, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delimiter := globalDelimiter
+		if !useGlobal {
+			delimiter = pairFormatDelimiter(store)
+		}
+		for _, list := range []string{"available", "enabled"} {
+			pairs, _ := store[list].(string)
+			if pairs == "" {
+				continue
+			}
+			for _, p := range strings.Split(pairs, ",") {
+				if problem := checkPair(p, delimiter); problem != "" {
+					v.addInvalid(joinConfigPath(storePath, list), "%s", problem)
+				}
+			}
+		}
+	}
+}
+
+// checkPair returns the problem with a pair of a comma separated pair list
+func checkPair(p, delimiter string) string {
+	switch {
+	case strings.TrimSpace(p) == "":
+		return "contains an empty pair, pairs are separated by single commas"
+	case strings.ContainsAny(p, " \t\r\n"):
+		return fmt.Sprintf("pair %q must not contain whitespace", p)
+	case delimiter != "":
+		i := strings.Index(p, delimiter)
+		if i <= 0 || i+len(delimiter) >= len(p) {
+			return fmt.Sprintf("pair %q is not delimited by the config format delimiter %q", p, delimiter)
+		}
+	}
+	return ""
+}
+
+// pairFormatDelimiter returns the delimiter of the config format of a pairs
+// manager or store
+func pairFormatDelimiter(v map[string]interface{}) string {
+	format, _ := v["configFormat"].(map[string]interface{})
+	delimiter, _ := format["delimiter"].(string)
+	return delimiter
+}
+
+// jsonFields returns the types of a struct's fields by their lowercased JSON
+// keys, including the fields of embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for x := 0; x < t.NumField(); x++ {
+		f := t.Field(x)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFields(embedded) {
+					fields[k] = v
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// elementConfigPath returns the path of an array element, named elements are
+// referred to by their name
+func elementConfigPath(path string, index int, element interface{}) string {
+	if obj, ok := element.(map[string]interface{}); ok {
+		if name, ok := obj["name"].(string); ok && name != "" {
+			return path + "[" + name + "]"
+		}
+	}
+	return path + "[" + strconv.Itoa(index) + "]"
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func decodeTestConfigJSON(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	d := json.NewDecoder(strings.NewReader(data))
+	d.UseNumber()
+	var cfg map[string]interface{}
+	if err := d.Decode(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestValidateConfigJSON(t *testing.T) {
+	t.Parallel()
+
+	cfg := decodeTestConfigJSON(t, `{
+		"name": "test",
+		"nmae": "typo",
+		"connectionMonitor": {"checkInterval": 30},
+		"currencyConfig": {"forexProviders": [{"name": "Fixer", "restPollingDelay": -1}]},
+		"equitySnapshot": {"enabled": true, "interval": 60000000000},
+		"exchanges": [
+			{
+				"name": "Bitstamp",
+				"httpTimeout": 15,
+				"api": {"credentials": {"kye": "k"}},
+				"currencyPairs": {
+					"useGlobalFormat": true,
+					"configFormat": {"uppercase": true, "delimiter": "-"},
+					"assetTypes": ["spot", "moon"],
+					"pairs": {
+						"spot": {"available": "BTC-USD,LTCUSD,,ETH- USD", "enabled": "BTC-USD"}
+					}
+				}
+			},
+			{"enabled": true}
+		]
+	}`)
+	unknown, invalid := validateConfigJSON(cfg)
+
+	expectedUnknown := []string{
+		"exchanges[Bitstamp].api.credentials.kye",
+		"nmae",
+	}
+	if len(unknown) != len(expectedUnknown) {
+		t.Fatalf("expected unknown keys %v, got %v", expectedUnknown, unknown)
+	}
+	for x := range expectedUnknown {
+		if unknown[x].Path != expectedUnknown[x] {
+			t.Errorf("expected unknown key %s, got %s", expectedUnknown[x], unknown[x].Path)
+		}
+	}
+
+	expectedInvalid := []string{
+		"connectionMonitor.checkInterval",
+		"currencyConfig.forexProviders[Fixer].restPollingDelay",
+		"exchanges[1].name",
+		"exchanges[Bitstamp].currencyPairs.assetTypes",
+		"exchanges[Bitstamp].currencyPairs.pairs.spot.available",
+		"exchanges[Bitstamp].currencyPairs.pairs.spot.available",
+		"exchanges[Bitstamp].currencyPairs.pairs.spot.available",
+		"exchanges[Bitstamp].httpTimeout",
+	}
+	if len(invalid) != len(expectedInvalid) {
+		t.Fatalf("expected invalid settings %v, got %v", expectedInvalid, invalid)
+	}
+	for x := range expectedInvalid {
+		if invalid[x].Path != expectedInvalid[x] {
+			t.Errorf("expected invalid setting %s, got %s", expectedInvalid[x], invalid[x].Path)
+		}
+	}
+	if !strings.Contains(invalid.Error(), "durations are set in nanoseconds") {
+		t.Errorf("expected the duration unit to be explained, got %s", invalid)
+	}
+
+	_, invalid = validateConfigJSON(decodeTestConfigJSON(t, `{"name": "test"}`))
+	if len(invalid) != 1 || invalid[0].Path != "exchanges" {
+		t.Errorf("expected exchanges to be required, got %v", invalid)
+	}
+}
+
+func TestValidateConfigFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{TestFile, "../config_example.json"} {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		var cfg map[string]interface{}
+		if err = d.Decode(&cfg); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = migrateConfigJSON(cfg); err != nil {
+			t.Fatal(err)
+		}
+		unknown, invalid := validateConfigJSON(cfg)
+		if len(unknown) != 0 || len(invalid) != 0 {
+			t.Errorf("%s: expected a valid config, got unknown keys %v and invalid settings %v",
+				path,
+				unknown,
+				invalid)
+		}
+	}
+}
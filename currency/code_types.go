@@ -106,6 +106,7 @@ var (
 	PPT        = NewCode("PPT")
 	KMD        = NewCode("KMD")
 	TUSD       = NewCode("TUSD")
+	USDC       = NewCode("USDC")
 	CNX        = NewCode("CNX")
 	LINK       = NewCode("LINK")
 	WTC        = NewCode("WTC")
@@ -55,6 +55,15 @@ func TestPairUnmarshalJSON(t *testing.T) {
 		t.Errorf("Pairs UnmarshalJSON() error expected %s but received %s",
 			configPair, unmarshalHere)
 	}
+
+	err = json.Unmarshal([]byte(`""`), &unmarshalHere)
+	if err != nil {
+		t.Fatal("Pair UnmarshalJSON() error", err)
+	}
+	if !unmarshalHere.IsEmpty() {
+		t.Errorf("Pairs UnmarshalJSON() error expected an empty pair but received %s",
+			unmarshalHere)
+	}
 }
 
 func TestPairMarshalJSON(t *testing.T) {
@@ -0,0 +1,115 @@
+package currency
+
+import (
+	"math"
+	"sync"
+)
+
+// DefaultPegTolerance is the percentage a pegged currency's price may deviate
+// from par before it is no longer treated as equivalent to its anchor
+const DefaultPegTolerance = 0.5
+
+var (
+	pegGroups []PegGroup
+	pegMtx    sync.RWMutex
+)
+
+// PegGroup is a set of currencies pegged to an anchor currency, such as the
+// stablecoins pegged to USD, which may be treated as equivalent to the anchor
+type PegGroup struct {
+	Anchor  Code
+	Members []Code
+	// Tolerance is the percentage a member's price in the anchor may deviate
+	// from par while it is treated as pegged
+	Tolerance float64
+}
+
+// DefaultPegGroups returns the USD stablecoin peg group
+func DefaultPegGroups() []PegGroup {
+	return []PegGroup{
+		{
+			Anchor:    USD,
+			Members:   []Code{USDT, USDC, TUSD},
+			Tolerance: DefaultPegTolerance,
+		},
+	}
+}
+
+// SetPegGroups sets the peg groups currencies are translated through, nil
+// stops currencies being treated as equivalent
+func SetPegGroups(groups []PegGroup) {
+	g := make([]PegGroup, len(groups))
+	for x := range groups {
+		g[x] = PegGroup{
+			Anchor:    groups[x].Anchor,
+			Members:   append([]Code(nil), groups[x].Members...),
+			Tolerance: groups[x].Tolerance,
+		}
+	}
+	pegMtx.Lock()
+	pegGroups = g
+	pegMtx.Unlock()
+}
+
+// GetPegGroup returns the peg group a currency anchors or is a member of
+func GetPegGroup(c Code) (PegGroup, bool) {
+	pegMtx.RLock()
+	defer pegMtx.RUnlock()
+	for x := range pegGroups {
+		if pegGroups[x].Contains(c) {
+			return pegGroups[x], true
+		}
+	}
+	return PegGroup{}, false
+}
+
+// PegAnchor returns the anchor of a currency's peg group, or the currency when
+// it is not pegged
+func PegAnchor(c Code) Code {
+	g, ok := GetPegGroup(c)
+	if !ok {
+		return c
+	}
+	return g.Anchor
+}
+
+// IsPegEquivalent returns whether two currencies are in the same peg group
+func IsPegEquivalent(a, b Code) bool {
+	if a.Match(b) {
+		return true
+	}
+	g, ok := GetPegGroup(a)
+	return ok && g.Contains(b)
+}
+
+// NormalisePair returns a pair with its currencies translated to their peg
+// anchors, the pair passed in is not modified so exchange symbols are retained
+func NormalisePair(p Pair) Pair {
+	return Pair{
+		Base:      PegAnchor(p.Base),
+		Quote:     PegAnchor(p.Quote),
+		Delimiter: p.Delimiter,
+	}
+}
+
+// Contains returns whether a currency anchors or is a member of the group
+func (g *PegGroup) Contains(c Code) bool {
+	if g.Anchor.Match(c) {
+		return true
+	}
+	for x := range g.Members {
+		if g.Members[x].Match(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// InTolerance returns whether a member's price in the anchor is within the
+// group's tolerance of par
+func (g *PegGroup) InTolerance(price float64) bool {
+	if price <= 0 {
+		return false
+	}
+	return math.Abs(price-1)*100 <= g.Tolerance
+}
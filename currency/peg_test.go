@@ -0,0 +1,43 @@
+package currency
+
+import "testing"
+
+func TestPegGroups(t *testing.T) {
+	SetPegGroups(DefaultPegGroups())
+	defer SetPegGroups(nil)
+
+	if a := PegAnchor(USDT); a != USD {
+		t.Errorf("expected USDT to be anchored to USD, got %s", a)
+	}
+	if a := PegAnchor(BTC); a != BTC {
+		t.Errorf("expected BTC to be unpegged, got %s", a)
+	}
+	if !IsPegEquivalent(USDC, TUSD) || !IsPegEquivalent(USD, USDT) {
+		t.Error("expected USD stablecoins to be equivalent")
+	}
+	if IsPegEquivalent(USDT, EUR) {
+		t.Error("expected USDT and EUR not to be equivalent")
+	}
+
+	p := NewPairWithDelimiter("BTC", "USDT", "-")
+	n := NormalisePair(p)
+	if n.Base != BTC || n.Quote != USD || n.Delimiter != "-" {
+		t.Errorf("unexpected normalised pair %+v", n)
+	}
+	if p.Quote != USDT {
+		t.Error("expected the exchange pair to be retained")
+	}
+
+	g, ok := GetPegGroup(TUSD)
+	if !ok {
+		t.Fatal("expected TUSD to be pegged")
+	}
+	if !g.InTolerance(1.004) || g.InTolerance(0.99) || g.InTolerance(0) {
+		t.Error("unexpected tolerance result")
+	}
+
+	SetPegGroups(nil)
+	if IsPegEquivalent(USD, USDT) {
+		t.Error("expected pegs to be cleared")
+	}
+}
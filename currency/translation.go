@@ -1,5 +1,10 @@
 package currency
 
+import (
+	"strings"
+	"sync"
+)
+
 // GetTranslation returns similar strings for a particular currency if not found
 // returns the code back
 func GetTranslation(currency Code) (Code, bool) {
@@ -20,3 +25,96 @@ var translations = map[Code]Code{
 	XDG:  DOGE,
 	USDT: USD,
 }
+
+// CodeTranslation maps the code an exchange uses for a currency to the
+// canonical code used by the bot. An empty exchange applies the translation to
+// every exchange
+type CodeTranslation struct {
+	Exchange  string
+	Native    Code
+	Canonical Code
+}
+
+var (
+	codeTranslations    = buildCodeTranslations(nil)
+	codeTranslationsMtx sync.RWMutex
+)
+
+// DefaultCodeTranslations returns the well known exchange codes which differ
+// from the canonical code
+func DefaultCodeTranslations() []CodeTranslation {
+	return []CodeTranslation{
+		{Native: XBT, Canonical: BTC},
+		{Native: XDG, Canonical: DOGE},
+		{Native: DSH, Canonical: DASH},
+		{Exchange: "bitfinex", Native: QTM, Canonical: QTUM},
+		{Exchange: "kraken", Native: XXBT, Canonical: BTC},
+		{Exchange: "kraken", Native: XETH, Canonical: ETH},
+		{Exchange: "kraken", Native: NewCode("XXDG"), Canonical: DOGE},
+		{Exchange: "kraken", Native: NewCode("XXRP"), Canonical: XRP},
+		{Exchange: "kraken", Native: NewCode("XLTC"), Canonical: LTC},
+		{Exchange: "kraken", Native: NewCode("XXLM"), Canonical: XLM},
+		{Exchange: "kraken", Native: NewCode("XXMR"), Canonical: XMR},
+		{Exchange: "kraken", Native: NewCode("XETC"), Canonical: ETC},
+		{Exchange: "kraken", Native: NewCode("XZEC"), Canonical: ZEC},
+		{Exchange: "kraken", Native: ZUSD, Canonical: USD},
+		{Exchange: "kraken", Native: ZEUR, Canonical: EUR},
+		{Exchange: "kraken", Native: NewCode("ZGBP"), Canonical: GBP},
+		{Exchange: "kraken", Native: ZJPY, Canonical: JPY},
+		{Exchange: "kraken", Native: ZCAD, Canonical: CAD},
+	}
+}
+
+// buildCodeTranslations indexes the default translations and the overrides by
+// exchange, an override replaces the default translation of the same code
+func buildCodeTranslations(overrides []CodeTranslation) map[string]map[*Item]Code {
+	t := make(map[string]map[*Item]Code)
+	for _, tr := range append(DefaultCodeTranslations(), overrides...) {
+		if tr.Native.IsEmpty() || tr.Canonical.IsEmpty() {
+			continue
+		}
+		exch := strings.ToLower(tr.Exchange)
+		if t[exch] == nil {
+			t[exch] = make(map[*Item]Code)
+		}
+		t[exch][tr.Native.Item] = tr.Canonical
+	}
+	return t
+}
+
+// SetCodeTranslations sets the translations applied on top of the defaults. A
+// translation of a code to itself disables its default translation
+func SetCodeTranslations(overrides []CodeTranslation) {
+	t := buildCodeTranslations(overrides)
+	codeTranslationsMtx.Lock()
+	codeTranslations = t
+	codeTranslationsMtx.Unlock()
+}
+
+// Canonical returns the canonical code of a currency code used by an
+// exchange, or the code when it is not translated. Translations for the
+// exchange take precedence over translations for every exchange
+func Canonical(exchange string, c Code) Code {
+	if c.IsEmpty() {
+		return c
+	}
+	codeTranslationsMtx.RLock()
+	defer codeTranslationsMtx.RUnlock()
+	canonical, ok := codeTranslations[strings.ToLower(exchange)][c.Item]
+	if !ok {
+		canonical, ok = codeTranslations[""][c.Item]
+		if !ok {
+			return c
+		}
+	}
+	canonical.UpperCase = c.UpperCase
+	return canonical
+}
+
+// CanonicalPair returns an exchange's currency pair with its currencies
+// translated to their canonical codes
+func CanonicalPair(exchange string, p Pair) Pair {
+	p.Base = Canonical(exchange, p.Base)
+	p.Quote = Canonical(exchange, p.Quote)
+	return p
+}
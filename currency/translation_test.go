@@ -32,3 +32,35 @@ func TestGetTranslation(t *testing.T) {
 		t.Error("GetTranslation: translation result was different to expected result")
 	}
 }
+
+func TestCanonical(t *testing.T) {
+	defer SetCodeTranslations(nil)
+
+	if c := Canonical("Bitmex", NewCode("xbt")); !c.Match(BTC) || c.UpperCase {
+		t.Errorf("expected XBT to be translated to BTC for every exchange, got %v", c)
+	}
+	if c := Canonical("Kraken", NewCode("XXBT")); !c.Match(BTC) {
+		t.Errorf("expected Kraken's XXBT to be translated to BTC, got %v", c)
+	}
+	if c := Canonical("Bitstamp", NewCode("XXBT")); !c.Match(NewCode("XXBT")) {
+		t.Errorf("expected Kraken's translations not to apply to other exchanges, got %v", c)
+	}
+	if c := Canonical("Bitstamp", LTC); !c.Match(LTC) {
+		t.Errorf("expected a code without a translation to be unchanged, got %v", c)
+	}
+
+	SetCodeTranslations([]CodeTranslation{
+		{Exchange: "Bitstamp", Native: XBT, Canonical: XBT},
+		{Exchange: "Bitstamp", Native: NewCode("BCHABC"), Canonical: BCH},
+	})
+	if c := Canonical("bitstamp", XBT); !c.Match(XBT) {
+		t.Errorf("expected the override to disable the default translation, got %v", c)
+	}
+	if c := Canonical("Bitmex", XBT); !c.Match(BTC) {
+		t.Errorf("expected the default translation to apply to other exchanges, got %v", c)
+	}
+	p := CanonicalPair("Bitstamp", NewPairWithDelimiter("BCHABC", "ZUSD", "-"))
+	if !p.Base.Match(BCH) || !p.Quote.Match(ZUSD) || p.Delimiter != "-" {
+		t.Errorf("expected the override to translate the pair's base, got %v", p)
+	}
+}
@@ -7,11 +7,14 @@ import (
 	"sync"
 
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	bolt "go.etcd.io/bbolt"
 )
 
-// Instance holds all information for a database instance
+// Instance holds all information for a database instance. Embedded key-value
+// drivers connect KV instead of SQL
 type Instance struct {
 	SQL       *sql.DB
+	KV        *bolt.DB
 	DataPath  string
 	Config    *Config
 	Connected bool
@@ -40,10 +43,13 @@ var (
 	ErrDatabaseSupportDisabled = errors.New("database support is disabled")
 
 	// SupportedDrivers slice of supported database driver types
-	SupportedDrivers = []string{DBSQLite, DBSQLite3, DBPostgreSQL}
+	SupportedDrivers = []string{DBSQLite, DBSQLite3, DBPostgreSQL, DBBolt}
 
 	// DefaultSQLiteDatabase is the default sqlite3 database name to use
 	DefaultSQLiteDatabase = "gocryptotrader.db"
+
+	// DefaultBoltDatabase is the default bolt database name to use
+	DefaultBoltDatabase = "gocryptotrader.bolt"
 )
 
 const (
@@ -53,4 +59,7 @@ const (
 	DBSQLite3 = "sqlite3"
 	// DBPostgreSQL const string for PostgreSQL across code base
 	DBPostgreSQL = "postgres"
+	// DBBolt const string for the embedded bolt key-value store across code
+	// base
+	DBBolt = "bolt"
 )
@@ -0,0 +1,31 @@
+package bolt
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTimeout is how long to wait for another process to release its lock on
+// the database file
+const openTimeout = time.Second * 5
+
+// Connect opens the bolt database file and returns a pointer to database.DB
+func Connect() (*database.Instance, error) {
+	if database.DB.Config.Database == "" {
+		return nil, database.ErrNoDatabaseProvided
+	}
+
+	databaseFullLocation := filepath.Join(database.DB.DataPath, database.DB.Config.Database)
+
+	db, err := bolt.Open(databaseFullLocation, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	database.DB.KV = db
+
+	return database.DB, nil
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS equity_snapshot
+(
+    id bigserial PRIMARY KEY NOT NULL,
+    portfolio  varchar(255)     NOT NULL,
+    currency   varchar(30)      NOT NULL,
+    equity     DOUBLE PRECISION NOT NULL,
+    balance    DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc')
+);
+CREATE INDEX equity_snapshot_portfolio_created_at ON equity_snapshot (portfolio, created_at);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE equity_snapshot;
\ No newline at end of file
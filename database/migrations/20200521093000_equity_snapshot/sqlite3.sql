@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE "equity_snapshot" (
+    id	        integer not null primary key,
+    portfolio   text not null,
+    currency    text not null,
+    equity      real not null,
+    balance     real not null,
+    created_at  timestamp not null default CURRENT_TIMESTAMP
+);
+CREATE INDEX equity_snapshot_portfolio_created_at ON equity_snapshot (portfolio, created_at);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE equity_snapshot;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS auction_history
+(
+    id bigserial PRIMARY KEY NOT NULL,
+    exchange          varchar(255)     NOT NULL,
+    pair              varchar(30)      NOT NULL,
+    auction_id        bigint           NOT NULL,
+    event_id          bigint           NOT NULL,
+    auction_price     DOUBLE PRECISION NOT NULL,
+    auction_quantity  DOUBLE PRECISION NOT NULL,
+    highest_bid_price DOUBLE PRECISION NOT NULL,
+    lowest_ask_price  DOUBLE PRECISION NOT NULL,
+    auction_result    varchar(30)      NOT NULL,
+    event_type        varchar(30)      NOT NULL,
+    timestamp         TIMESTAMP        NOT NULL,
+    CONSTRAINT auction_history_exchange_event_id UNIQUE (exchange, event_id)
+);
+CREATE INDEX auction_history_exchange_pair_timestamp ON auction_history (exchange, pair, timestamp);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE auction_history;
\ No newline at end of file
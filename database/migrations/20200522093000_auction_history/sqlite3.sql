@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE "auction_history" (
+    id	              integer not null primary key,
+    exchange          text not null,
+    pair              text not null,
+    auction_id        integer not null,
+    event_id          integer not null,
+    auction_price     real not null,
+    auction_quantity  real not null,
+    highest_bid_price real not null,
+    lowest_ask_price  real not null,
+    auction_result    text not null,
+    event_type        text not null,
+    timestamp         timestamp not null,
+    UNIQUE(exchange, event_id)
+);
+CREATE INDEX auction_history_exchange_pair_timestamp ON auction_history (exchange, pair, timestamp);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE auction_history;
\ No newline at end of file
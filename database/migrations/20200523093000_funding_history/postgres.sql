@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS funding_history
+(
+    id bigserial PRIMARY KEY NOT NULL,
+    exchange      varchar(255)     NOT NULL,
+    transfer_id   varchar(255)     NOT NULL,
+    transfer_type varchar(30)      NOT NULL,
+    currency      varchar(30)      NOT NULL,
+    amount        DOUBLE PRECISION NOT NULL,
+    fee           DOUBLE PRECISION NOT NULL,
+    status        varchar(255)     NOT NULL,
+    description   text             NOT NULL,
+    address       text             NOT NULL,
+    tx_id         text             NOT NULL,
+    timestamp     TIMESTAMP        NOT NULL,
+    CONSTRAINT funding_history_exchange_transfer UNIQUE (exchange, transfer_type, transfer_id)
+);
+CREATE INDEX funding_history_exchange_timestamp ON funding_history (exchange, timestamp);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE funding_history;
\ No newline at end of file
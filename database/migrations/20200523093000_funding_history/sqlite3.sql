@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE "funding_history" (
+    id	          integer not null primary key,
+    exchange      text not null,
+    transfer_id   text not null,
+    transfer_type text not null,
+    currency      text not null,
+    amount        real not null,
+    fee           real not null,
+    status        text not null,
+    description   text not null,
+    address       text not null,
+    tx_id         text not null,
+    timestamp     timestamp not null,
+    UNIQUE(exchange, transfer_type, transfer_id)
+);
+CREATE INDEX funding_history_exchange_timestamp ON funding_history (exchange, timestamp);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE funding_history;
\ No newline at end of file
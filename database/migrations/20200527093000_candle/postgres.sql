@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS candle
+(
+    id bigserial PRIMARY KEY NOT NULL,
+    exchange         varchar(255)     NOT NULL,
+    base             varchar(30)      NOT NULL,
+    quote            varchar(30)      NOT NULL,
+    asset            varchar(30)      NOT NULL,
+    interval_seconds bigint           NOT NULL,
+    timestamp        TIMESTAMP        NOT NULL,
+    open             DOUBLE PRECISION NOT NULL,
+    high             DOUBLE PRECISION NOT NULL,
+    low              DOUBLE PRECISION NOT NULL,
+    close            DOUBLE PRECISION NOT NULL,
+    volume           DOUBLE PRECISION NOT NULL,
+    CONSTRAINT candle_series_timestamp UNIQUE (exchange, base, quote, asset, interval_seconds, timestamp)
+);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE candle;
\ No newline at end of file
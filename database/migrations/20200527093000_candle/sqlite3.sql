@@ -0,0 +1,20 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE "candle" (
+    id	             integer not null primary key,
+    exchange         text not null,
+    base             text not null,
+    quote            text not null,
+    asset            text not null,
+    interval_seconds integer not null,
+    timestamp        timestamp not null,
+    open             real not null,
+    high             real not null,
+    low              real not null,
+    close            real not null,
+    volume           real not null,
+    UNIQUE(exchange, base, quote, asset, interval_seconds, timestamp)
+);
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE candle;
\ No newline at end of file
@@ -12,74 +12,126 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistories)
 	t.Run("AuditEvents", testAuditEvents)
+	t.Run("Candles", testCandles)
+	t.Run("EquitySnapshots", testEquitySnapshots)
+	t.Run("FundingHistories", testFundingHistories)
 	t.Run("Scripts", testScripts)
 	t.Run("WithdrawalHistories", testWithdrawalHistories)
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesDelete)
 	t.Run("AuditEvents", testAuditEventsDelete)
+	t.Run("Candles", testCandlesDelete)
+	t.Run("EquitySnapshots", testEquitySnapshotsDelete)
+	t.Run("FundingHistories", testFundingHistoriesDelete)
 	t.Run("Scripts", testScriptsDelete)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesQueryDeleteAll)
 	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
+	t.Run("Candles", testCandlesQueryDeleteAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsQueryDeleteAll)
+	t.Run("FundingHistories", testFundingHistoriesQueryDeleteAll)
 	t.Run("Scripts", testScriptsQueryDeleteAll)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesSliceDeleteAll)
 	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
+	t.Run("Candles", testCandlesSliceDeleteAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsSliceDeleteAll)
+	t.Run("FundingHistories", testFundingHistoriesSliceDeleteAll)
 	t.Run("Scripts", testScriptsSliceDeleteAll)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesExists)
 	t.Run("AuditEvents", testAuditEventsExists)
+	t.Run("Candles", testCandlesExists)
+	t.Run("EquitySnapshots", testEquitySnapshotsExists)
+	t.Run("FundingHistories", testFundingHistoriesExists)
 	t.Run("Scripts", testScriptsExists)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesExists)
 }
 
 func TestFind(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesFind)
 	t.Run("AuditEvents", testAuditEventsFind)
+	t.Run("Candles", testCandlesFind)
+	t.Run("EquitySnapshots", testEquitySnapshotsFind)
+	t.Run("FundingHistories", testFundingHistoriesFind)
 	t.Run("Scripts", testScriptsFind)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesFind)
 }
 
 func TestBind(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesBind)
 	t.Run("AuditEvents", testAuditEventsBind)
+	t.Run("Candles", testCandlesBind)
+	t.Run("EquitySnapshots", testEquitySnapshotsBind)
+	t.Run("FundingHistories", testFundingHistoriesBind)
 	t.Run("Scripts", testScriptsBind)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesBind)
 }
 
 func TestOne(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesOne)
 	t.Run("AuditEvents", testAuditEventsOne)
+	t.Run("Candles", testCandlesOne)
+	t.Run("EquitySnapshots", testEquitySnapshotsOne)
+	t.Run("FundingHistories", testFundingHistoriesOne)
 	t.Run("Scripts", testScriptsOne)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesOne)
 }
 
 func TestAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesAll)
 	t.Run("AuditEvents", testAuditEventsAll)
+	t.Run("Candles", testCandlesAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsAll)
+	t.Run("FundingHistories", testFundingHistoriesAll)
 	t.Run("Scripts", testScriptsAll)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesAll)
 }
 
 func TestCount(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesCount)
 	t.Run("AuditEvents", testAuditEventsCount)
+	t.Run("Candles", testCandlesCount)
+	t.Run("EquitySnapshots", testEquitySnapshotsCount)
+	t.Run("FundingHistories", testFundingHistoriesCount)
 	t.Run("Scripts", testScriptsCount)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesCount)
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesHooks)
 	t.Run("AuditEvents", testAuditEventsHooks)
+	t.Run("Candles", testCandlesHooks)
+	t.Run("EquitySnapshots", testEquitySnapshotsHooks)
+	t.Run("FundingHistories", testFundingHistoriesHooks)
 	t.Run("Scripts", testScriptsHooks)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesHooks)
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesInsert)
 	t.Run("AuditEvents", testAuditEventsInsert)
+	t.Run("Candles", testCandlesInsert)
+	t.Run("EquitySnapshots", testEquitySnapshotsInsert)
+	t.Run("FundingHistories", testFundingHistoriesInsert)
+	t.Run("AuctionHistories", testAuctionHistoriesInsertWhitelist)
 	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
+	t.Run("Candles", testCandlesInsertWhitelist)
+	t.Run("EquitySnapshots", testEquitySnapshotsInsertWhitelist)
+	t.Run("FundingHistories", testFundingHistoriesInsertWhitelist)
 	t.Run("Scripts", testScriptsInsert)
 	t.Run("Scripts", testScriptsInsertWhitelist)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesInsert)
@@ -127,31 +179,51 @@ func TestToManySet(t *testing.T) {}
 func TestToManyRemove(t *testing.T) {}
 
 func TestReload(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesReload)
 	t.Run("AuditEvents", testAuditEventsReload)
+	t.Run("Candles", testCandlesReload)
+	t.Run("EquitySnapshots", testEquitySnapshotsReload)
+	t.Run("FundingHistories", testFundingHistoriesReload)
 	t.Run("Scripts", testScriptsReload)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesReload)
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesReloadAll)
 	t.Run("AuditEvents", testAuditEventsReloadAll)
+	t.Run("Candles", testCandlesReloadAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsReloadAll)
+	t.Run("FundingHistories", testFundingHistoriesReloadAll)
 	t.Run("Scripts", testScriptsReloadAll)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesReloadAll)
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesSelect)
 	t.Run("AuditEvents", testAuditEventsSelect)
+	t.Run("Candles", testCandlesSelect)
+	t.Run("EquitySnapshots", testEquitySnapshotsSelect)
+	t.Run("FundingHistories", testFundingHistoriesSelect)
 	t.Run("Scripts", testScriptsSelect)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesSelect)
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesUpdate)
 	t.Run("AuditEvents", testAuditEventsUpdate)
+	t.Run("Candles", testCandlesUpdate)
+	t.Run("EquitySnapshots", testEquitySnapshotsUpdate)
+	t.Run("FundingHistories", testFundingHistoriesUpdate)
 	t.Run("Scripts", testScriptsUpdate)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesSliceUpdateAll)
 	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
+	t.Run("Candles", testCandlesSliceUpdateAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsSliceUpdateAll)
+	t.Run("FundingHistories", testFundingHistoriesSliceUpdateAll)
 	t.Run("Scripts", testScriptsSliceUpdateAll)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesSliceUpdateAll)
 }
@@ -4,14 +4,22 @@
 package postgres
 
 var TableNames = struct {
+	AuctionHistory    string
 	AuditEvent        string
+	Candle            string
+	EquitySnapshot    string
+	FundingHistory    string
 	Script            string
 	ScriptExecution   string
 	WithdrawalCrypto  string
 	WithdrawalFiat    string
 	WithdrawalHistory string
 }{
+	AuctionHistory:    "auction_history",
 	AuditEvent:        "audit_event",
+	Candle:            "candle",
+	EquitySnapshot:    "equity_snapshot",
+	FundingHistory:    "funding_history",
 	Script:            "script",
 	ScriptExecution:   "script_execution",
 	WithdrawalCrypto:  "withdrawal_crypto",
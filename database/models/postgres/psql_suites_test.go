@@ -6,7 +6,11 @@ package postgres
 import "testing"
 
 func TestUpsert(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesUpsert)
 	t.Run("AuditEvents", testAuditEventsUpsert)
+	t.Run("Candles", testCandlesUpsert)
+	t.Run("EquitySnapshots", testEquitySnapshotsUpsert)
+	t.Run("FundingHistories", testFundingHistoriesUpsert)
 	t.Run("Scripts", testScriptsUpsert)
 	t.Run("WithdrawalHistories", testWithdrawalHistoriesUpsert)
 }
@@ -12,7 +12,11 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistories)
 	t.Run("AuditEvents", testAuditEvents)
+	t.Run("Candles", testCandles)
+	t.Run("EquitySnapshots", testEquitySnapshots)
+	t.Run("FundingHistories", testFundingHistories)
 	t.Run("Scripts", testScripts)
 	t.Run("ScriptExecutions", testScriptExecutions)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptos)
@@ -21,7 +25,11 @@ func TestParent(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesDelete)
 	t.Run("AuditEvents", testAuditEventsDelete)
+	t.Run("Candles", testCandlesDelete)
+	t.Run("EquitySnapshots", testEquitySnapshotsDelete)
+	t.Run("FundingHistories", testFundingHistoriesDelete)
 	t.Run("Scripts", testScriptsDelete)
 	t.Run("ScriptExecutions", testScriptExecutionsDelete)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosDelete)
@@ -30,7 +38,11 @@ func TestDelete(t *testing.T) {
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesQueryDeleteAll)
 	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
+	t.Run("Candles", testCandlesQueryDeleteAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsQueryDeleteAll)
+	t.Run("FundingHistories", testFundingHistoriesQueryDeleteAll)
 	t.Run("Scripts", testScriptsQueryDeleteAll)
 	t.Run("ScriptExecutions", testScriptExecutionsQueryDeleteAll)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosQueryDeleteAll)
@@ -39,7 +51,11 @@ func TestQueryDeleteAll(t *testing.T) {
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesSliceDeleteAll)
 	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
+	t.Run("Candles", testCandlesSliceDeleteAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsSliceDeleteAll)
+	t.Run("FundingHistories", testFundingHistoriesSliceDeleteAll)
 	t.Run("Scripts", testScriptsSliceDeleteAll)
 	t.Run("ScriptExecutions", testScriptExecutionsSliceDeleteAll)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosSliceDeleteAll)
@@ -48,7 +64,11 @@ func TestSliceDeleteAll(t *testing.T) {
 }
 
 func TestExists(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesExists)
 	t.Run("AuditEvents", testAuditEventsExists)
+	t.Run("Candles", testCandlesExists)
+	t.Run("EquitySnapshots", testEquitySnapshotsExists)
+	t.Run("FundingHistories", testFundingHistoriesExists)
 	t.Run("Scripts", testScriptsExists)
 	t.Run("ScriptExecutions", testScriptExecutionsExists)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosExists)
@@ -57,7 +77,11 @@ func TestExists(t *testing.T) {
 }
 
 func TestFind(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesFind)
 	t.Run("AuditEvents", testAuditEventsFind)
+	t.Run("Candles", testCandlesFind)
+	t.Run("EquitySnapshots", testEquitySnapshotsFind)
+	t.Run("FundingHistories", testFundingHistoriesFind)
 	t.Run("Scripts", testScriptsFind)
 	t.Run("ScriptExecutions", testScriptExecutionsFind)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosFind)
@@ -66,7 +90,11 @@ func TestFind(t *testing.T) {
 }
 
 func TestBind(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesBind)
 	t.Run("AuditEvents", testAuditEventsBind)
+	t.Run("Candles", testCandlesBind)
+	t.Run("EquitySnapshots", testEquitySnapshotsBind)
+	t.Run("FundingHistories", testFundingHistoriesBind)
 	t.Run("Scripts", testScriptsBind)
 	t.Run("ScriptExecutions", testScriptExecutionsBind)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosBind)
@@ -75,7 +103,11 @@ func TestBind(t *testing.T) {
 }
 
 func TestOne(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesOne)
 	t.Run("AuditEvents", testAuditEventsOne)
+	t.Run("Candles", testCandlesOne)
+	t.Run("EquitySnapshots", testEquitySnapshotsOne)
+	t.Run("FundingHistories", testFundingHistoriesOne)
 	t.Run("Scripts", testScriptsOne)
 	t.Run("ScriptExecutions", testScriptExecutionsOne)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosOne)
@@ -84,7 +116,11 @@ func TestOne(t *testing.T) {
 }
 
 func TestAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesAll)
 	t.Run("AuditEvents", testAuditEventsAll)
+	t.Run("Candles", testCandlesAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsAll)
+	t.Run("FundingHistories", testFundingHistoriesAll)
 	t.Run("Scripts", testScriptsAll)
 	t.Run("ScriptExecutions", testScriptExecutionsAll)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosAll)
@@ -93,7 +129,11 @@ func TestAll(t *testing.T) {
 }
 
 func TestCount(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesCount)
 	t.Run("AuditEvents", testAuditEventsCount)
+	t.Run("Candles", testCandlesCount)
+	t.Run("EquitySnapshots", testEquitySnapshotsCount)
+	t.Run("FundingHistories", testFundingHistoriesCount)
 	t.Run("Scripts", testScriptsCount)
 	t.Run("ScriptExecutions", testScriptExecutionsCount)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosCount)
@@ -102,7 +142,11 @@ func TestCount(t *testing.T) {
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesHooks)
 	t.Run("AuditEvents", testAuditEventsHooks)
+	t.Run("Candles", testCandlesHooks)
+	t.Run("EquitySnapshots", testEquitySnapshotsHooks)
+	t.Run("FundingHistories", testFundingHistoriesHooks)
 	t.Run("Scripts", testScriptsHooks)
 	t.Run("ScriptExecutions", testScriptExecutionsHooks)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosHooks)
@@ -111,8 +155,16 @@ func TestHooks(t *testing.T) {
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesInsert)
 	t.Run("AuditEvents", testAuditEventsInsert)
+	t.Run("Candles", testCandlesInsert)
+	t.Run("EquitySnapshots", testEquitySnapshotsInsert)
+	t.Run("FundingHistories", testFundingHistoriesInsert)
+	t.Run("AuctionHistories", testAuctionHistoriesInsertWhitelist)
 	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
+	t.Run("Candles", testCandlesInsertWhitelist)
+	t.Run("EquitySnapshots", testEquitySnapshotsInsertWhitelist)
+	t.Run("FundingHistories", testFundingHistoriesInsertWhitelist)
 	t.Run("Scripts", testScriptsInsert)
 	t.Run("Scripts", testScriptsInsertWhitelist)
 	t.Run("ScriptExecutions", testScriptExecutionsInsert)
@@ -182,7 +234,11 @@ func TestToManySet(t *testing.T) {}
 func TestToManyRemove(t *testing.T) {}
 
 func TestReload(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesReload)
 	t.Run("AuditEvents", testAuditEventsReload)
+	t.Run("Candles", testCandlesReload)
+	t.Run("EquitySnapshots", testEquitySnapshotsReload)
+	t.Run("FundingHistories", testFundingHistoriesReload)
 	t.Run("Scripts", testScriptsReload)
 	t.Run("ScriptExecutions", testScriptExecutionsReload)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosReload)
@@ -191,7 +247,11 @@ func TestReload(t *testing.T) {
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesReloadAll)
 	t.Run("AuditEvents", testAuditEventsReloadAll)
+	t.Run("Candles", testCandlesReloadAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsReloadAll)
+	t.Run("FundingHistories", testFundingHistoriesReloadAll)
 	t.Run("Scripts", testScriptsReloadAll)
 	t.Run("ScriptExecutions", testScriptExecutionsReloadAll)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosReloadAll)
@@ -200,7 +260,11 @@ func TestReloadAll(t *testing.T) {
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesSelect)
 	t.Run("AuditEvents", testAuditEventsSelect)
+	t.Run("Candles", testCandlesSelect)
+	t.Run("EquitySnapshots", testEquitySnapshotsSelect)
+	t.Run("FundingHistories", testFundingHistoriesSelect)
 	t.Run("Scripts", testScriptsSelect)
 	t.Run("ScriptExecutions", testScriptExecutionsSelect)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosSelect)
@@ -209,7 +273,11 @@ func TestSelect(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesUpdate)
 	t.Run("AuditEvents", testAuditEventsUpdate)
+	t.Run("Candles", testCandlesUpdate)
+	t.Run("EquitySnapshots", testEquitySnapshotsUpdate)
+	t.Run("FundingHistories", testFundingHistoriesUpdate)
 	t.Run("Scripts", testScriptsUpdate)
 	t.Run("ScriptExecutions", testScriptExecutionsUpdate)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosUpdate)
@@ -218,7 +286,11 @@ func TestUpdate(t *testing.T) {
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("AuctionHistories", testAuctionHistoriesSliceUpdateAll)
 	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
+	t.Run("Candles", testCandlesSliceUpdateAll)
+	t.Run("EquitySnapshots", testEquitySnapshotsSliceUpdateAll)
+	t.Run("FundingHistories", testFundingHistoriesSliceUpdateAll)
 	t.Run("Scripts", testScriptsSliceUpdateAll)
 	t.Run("ScriptExecutions", testScriptExecutionsSliceUpdateAll)
 	t.Run("WithdrawalCryptos", testWithdrawalCryptosSliceUpdateAll)
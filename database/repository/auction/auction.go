@@ -0,0 +1,232 @@
+package auction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+var errEventIncomplete = errors.New("auction event exchange and pair must be specified")
+
+// Event is an auction result or indicative price publication
+type Event struct {
+	Exchange        string
+	Pair            string
+	AuctionID       int64
+	EventID         int64
+	AuctionPrice    float64
+	AuctionQuantity float64
+	HighestBidPrice float64
+	LowestAskPrice  float64
+	AuctionResult   string
+	EventType       string
+	Time            time.Time
+}
+
+// Insert stores events in a single transaction, skipping events which have
+// already been stored for the exchange. Returns the amount of events stored
+func Insert(events ...Event) (int, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var inserted int
+	seen := make(map[string]map[int64]bool)
+	for i := range events {
+		if events[i].Exchange == "" || events[i].Pair == "" {
+			err = errEventIncomplete
+			break
+		}
+		if seen[events[i].Exchange] == nil {
+			seen[events[i].Exchange] = make(map[int64]bool)
+		}
+		if seen[events[i].Exchange][events[i].EventID] {
+			continue
+		}
+		seen[events[i].Exchange][events[i].EventID] = true
+
+		var exists bool
+		exists, err = stored(ctx, tx, events[i].Exchange, events[i].EventID)
+		if err != nil {
+			break
+		}
+		if exists {
+			continue
+		}
+		if repository.GetSQLDialect() == database.DBSQLite3 {
+			var tempEvent = modelSQLite.AuctionHistory{
+				Exchange:        events[i].Exchange,
+				Pair:            events[i].Pair,
+				AuctionID:       events[i].AuctionID,
+				EventID:         events[i].EventID,
+				AuctionPrice:    events[i].AuctionPrice,
+				AuctionQuantity: events[i].AuctionQuantity,
+				HighestBidPrice: events[i].HighestBidPrice,
+				LowestAskPrice:  events[i].LowestAskPrice,
+				AuctionResult:   events[i].AuctionResult,
+				EventType:       events[i].EventType,
+				Timestamp:       events[i].Time.UTC().Format(repository.SQLiteTimeFormat),
+			}
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		} else {
+			var tempEvent = modelPSQL.AuctionHistory{
+				Exchange:        events[i].Exchange,
+				Pair:            events[i].Pair,
+				AuctionID:       events[i].AuctionID,
+				EventID:         events[i].EventID,
+				AuctionPrice:    events[i].AuctionPrice,
+				AuctionQuantity: events[i].AuctionQuantity,
+				HighestBidPrice: events[i].HighestBidPrice,
+				LowestAskPrice:  events[i].LowestAskPrice,
+				AuctionResult:   events[i].AuctionResult,
+				EventType:       events[i].EventType,
+				Timestamp:       events[i].Time.UTC(),
+			}
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			break
+		}
+		inserted++
+	}
+
+	if err != nil {
+		errRB := tx.Rollback()
+		if errRB != nil {
+			log.Errorf(log.DatabaseMgr, "Auction history transaction rollback failed: %v", errRB)
+		}
+		return 0, err
+	}
+	return inserted, tx.Commit()
+}
+
+// stored returns true if the exchange's event has already been stored
+func stored(ctx context.Context, tx boil.ContextExecutor, exchange string, eventID int64) (bool, error) {
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		return modelSQLite.AuctionHistories(
+			qm.Where("exchange = ? AND event_id = ?", exchange, eventID),
+		).Exists(ctx, tx)
+	}
+	return modelPSQL.AuctionHistories(
+		qm.Where("exchange = ? AND event_id = ?", exchange, eventID),
+	).Exists(ctx, tx)
+}
+
+// LastEventTime returns the time of the most recent stored event for the
+// exchange and pair, which is zero if none have been stored
+func LastEventTime(exchange, pair string) (time.Time, error) {
+	if database.DB.SQL == nil {
+		return time.Time{}, database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.AuctionHistories(
+			qm.Where("exchange = ? AND pair = ?", exchange, pair),
+			qm.OrderBy("timestamp DESC"),
+		).One(ctx, database.DB.SQL)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return time.Time{}, nil
+			}
+			return time.Time{}, err
+		}
+		return time.Parse(time.RFC3339, v.Timestamp)
+	}
+
+	v, err := modelPSQL.AuctionHistories(
+		qm.Where("exchange = ? AND pair = ?", exchange, pair),
+		qm.OrderBy("timestamp DESC"),
+	).One(ctx, database.DB.SQL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return v.Timestamp, nil
+}
+
+// GetEvents returns an exchange pair's events between start and end in
+// ascending time order
+func GetEvents(exchange, pair string, start, end time.Time) ([]Event, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+
+	var resp []Event
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.AuctionHistories(
+			qm.Where("exchange = ? AND pair = ?", exchange, pair),
+			qm.And("timestamp BETWEEN ? AND ?",
+				start.UTC().Format(repository.SQLiteTimeFormat),
+				end.UTC().Format(repository.SQLiteTimeFormat)),
+			qm.OrderBy("timestamp, event_id"),
+		).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for x := range v {
+			ts, err := time.Parse(time.RFC3339, v[x].Timestamp)
+			if err != nil {
+				log.Errorf(log.DatabaseMgr, "auction history: %v has an incorrect time format ( %v ) - defaulting to empty time: %v", v[x].ID, v[x].Timestamp, err)
+			}
+			resp = append(resp, Event{
+				Exchange:        v[x].Exchange,
+				Pair:            v[x].Pair,
+				AuctionID:       v[x].AuctionID,
+				EventID:         v[x].EventID,
+				AuctionPrice:    v[x].AuctionPrice,
+				AuctionQuantity: v[x].AuctionQuantity,
+				HighestBidPrice: v[x].HighestBidPrice,
+				LowestAskPrice:  v[x].LowestAskPrice,
+				AuctionResult:   v[x].AuctionResult,
+				EventType:       v[x].EventType,
+				Time:            ts,
+			})
+		}
+		return resp, nil
+	}
+
+	v, err := modelPSQL.AuctionHistories(
+		qm.Where("exchange = ? AND pair = ?", exchange, pair),
+		qm.And("timestamp BETWEEN ? AND ?", start.UTC(), end.UTC()),
+		qm.OrderBy("timestamp, event_id"),
+	).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	for x := range v {
+		resp = append(resp, Event{
+			Exchange:        v[x].Exchange,
+			Pair:            v[x].Pair,
+			AuctionID:       v[x].AuctionID,
+			EventID:         v[x].EventID,
+			AuctionPrice:    v[x].AuctionPrice,
+			AuctionQuantity: v[x].AuctionQuantity,
+			HighestBidPrice: v[x].HighestBidPrice,
+			LowestAskPrice:  v[x].LowestAskPrice,
+			AuctionResult:   v[x].AuctionResult,
+			EventType:       v[x].EventType,
+			Time:            v[x].Timestamp,
+		})
+	}
+	return resp, nil
+}
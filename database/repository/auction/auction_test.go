@@ -0,0 +1,158 @@
+package auction
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/goose"
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		fmt.Printf("failed to create temp file: %v", err)
+		os.Exit(1)
+	}
+
+	t := m.Run()
+
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestAuction(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		runner func(t *testing.T)
+		closer func(dbConn *database.Instance) error
+	}{
+		{
+			"SQLite",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			auctionHelper,
+			testhelpers.CloseDatabase,
+		},
+		{
+			"Postgres",
+			testhelpers.PostgresTestDatabase,
+			auctionHelper,
+			nil,
+		},
+	}
+
+	for _, tests := range testCases {
+		test := tests
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("..", "..", "migrations")
+			err = goose.Run("up", dbConn.SQL, repository.GetSQLDialect(), path, "")
+			if err != nil {
+				t.Fatalf("failed to run migrations %v", err)
+			}
+
+			if test.runner != nil {
+				test.runner(t)
+			}
+
+			if test.closer != nil {
+				err = test.closer(dbConn)
+				if err != nil {
+					t.Log(err)
+				}
+			}
+		})
+	}
+}
+
+func auctionHelper(t *testing.T) {
+	t.Helper()
+
+	exch := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	last, err := LastEventTime(exch, "BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.IsZero() {
+		t.Errorf("expected no stored events, got %v", last)
+	}
+
+	var events []Event
+	for x := 0; x < 3; x++ {
+		events = append(events, Event{
+			Exchange:        exch,
+			Pair:            "BTCUSD",
+			AuctionID:       1,
+			EventID:         int64(x + 1),
+			AuctionPrice:    float64(100 + x),
+			AuctionQuantity: 1,
+			AuctionResult:   "success",
+			EventType:       "auction",
+			Time:            start.Add(time.Minute * time.Duration(x)),
+		})
+	}
+	inserted, err := Insert(events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inserted != 3 {
+		t.Errorf("expected 3 events to be stored, got %d", inserted)
+	}
+
+	// events already stored or repeated in the batch are skipped
+	inserted, err = Insert(events[2], events[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inserted != 0 {
+		t.Errorf("expected duplicate events to be skipped, got %d", inserted)
+	}
+	if _, err = Insert(Event{}); err != errEventIncomplete {
+		t.Errorf("expected %v, got %v", errEventIncomplete, err)
+	}
+
+	resp, err := GetEvents(exch, "BTCUSD", start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(resp))
+	}
+	if resp[0].EventID != 1 || resp[2].AuctionPrice != 102 || !resp[1].Time.Equal(events[1].Time) {
+		t.Errorf("unexpected events %+v", resp)
+	}
+
+	last, err = LastEventTime(exch, "BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.Equal(events[2].Time) {
+		t.Errorf("expected last event time %v, got %v", events[2].Time, last)
+	}
+}
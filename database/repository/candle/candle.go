@@ -0,0 +1,277 @@
+package candle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+const seriesQuery = "exchange = ? AND base = ? AND quote = ? AND asset = ? AND interval_seconds = ?"
+
+var errSeriesIncomplete = errors.New("candle exchange, base, quote, asset and interval must be specified")
+
+// Series identifies the candles of an exchange pair at an interval
+type Series struct {
+	Exchange string
+	Base     string
+	Quote    string
+	Asset    string
+	Interval time.Duration
+}
+
+// Candle is an OHLCV candle of a series opened at Time
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+func (s *Series) validate() error {
+	if s.Exchange == "" || s.Base == "" || s.Quote == "" || s.Asset == "" || s.Interval <= 0 {
+		return errSeriesIncomplete
+	}
+	return nil
+}
+
+func (s *Series) args() []interface{} {
+	return []interface{}{s.Exchange, s.Base, s.Quote, s.Asset, int64(s.Interval / time.Second)}
+}
+
+// Upsert stores the candles of a series in a single transaction. Candles
+// already stored are updated when they have changed, such as a candle which
+// was still open when it was last stored. Returns the amount of candles stored
+// or updated
+func Upsert(s *Series, candles ...Candle) (int, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var changed int
+	for i := range candles {
+		var stored bool
+		if repository.GetSQLDialect() == database.DBSQLite3 {
+			stored, err = upsertSQLite(ctx, tx, s, &candles[i])
+		} else {
+			stored, err = upsertPSQL(ctx, tx, s, &candles[i])
+		}
+		if err != nil {
+			break
+		}
+		if stored {
+			changed++
+		}
+	}
+
+	if err != nil {
+		errRB := tx.Rollback()
+		if errRB != nil {
+			log.Errorf(log.DatabaseMgr, "Candle transaction rollback failed: %v", errRB)
+		}
+		return 0, err
+	}
+	return changed, tx.Commit()
+}
+
+// upsertSQLite inserts a candle or updates the stored candle when it has
+// changed, returning whether the candle was written
+func upsertSQLite(ctx context.Context, tx boil.ContextExecutor, s *Series, c *Candle) (bool, error) {
+	ts := c.Time.UTC().Format(repository.SQLiteTimeFormat)
+	existing, err := modelSQLite.Candles(
+		qm.Where(seriesQuery, s.args()...),
+		qm.And("timestamp = ?", ts),
+	).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		var tempCandle = modelSQLite.Candle{
+			Exchange:        s.Exchange,
+			Base:            s.Base,
+			Quote:           s.Quote,
+			Asset:           s.Asset,
+			IntervalSeconds: int64(s.Interval / time.Second),
+			Timestamp:       ts,
+			Open:            c.Open,
+			High:            c.High,
+			Low:             c.Low,
+			Close:           c.Close,
+			Volume:          c.Volume,
+		}
+		return true, tempCandle.Insert(ctx, tx, boil.Infer())
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.Open == c.Open && existing.High == c.High && existing.Low == c.Low &&
+		existing.Close == c.Close && existing.Volume == c.Volume {
+		return false, nil
+	}
+	existing.Open = c.Open
+	existing.High = c.High
+	existing.Low = c.Low
+	existing.Close = c.Close
+	existing.Volume = c.Volume
+	_, err = existing.Update(ctx, tx, boil.Whitelist("open", "high", "low", "close", "volume"))
+	return err == nil, err
+}
+
+// upsertPSQL inserts a candle or updates the stored candle when it has
+// changed, returning whether the candle was written
+func upsertPSQL(ctx context.Context, tx boil.ContextExecutor, s *Series, c *Candle) (bool, error) {
+	existing, err := modelPSQL.Candles(
+		qm.Where(seriesQuery, s.args()...),
+		qm.And("timestamp = ?", c.Time.UTC()),
+	).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		var tempCandle = modelPSQL.Candle{
+			Exchange:        s.Exchange,
+			Base:            s.Base,
+			Quote:           s.Quote,
+			Asset:           s.Asset,
+			IntervalSeconds: int64(s.Interval / time.Second),
+			Timestamp:       c.Time.UTC(),
+			Open:            c.Open,
+			High:            c.High,
+			Low:             c.Low,
+			Close:           c.Close,
+			Volume:          c.Volume,
+		}
+		return true, tempCandle.Insert(ctx, tx, boil.Infer())
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.Open == c.Open && existing.High == c.High && existing.Low == c.Low &&
+		existing.Close == c.Close && existing.Volume == c.Volume {
+		return false, nil
+	}
+	existing.Open = c.Open
+	existing.High = c.High
+	existing.Low = c.Low
+	existing.Close = c.Close
+	existing.Volume = c.Volume
+	_, err = existing.Update(ctx, tx, boil.Whitelist("open", "high", "low", "close", "volume"))
+	return err == nil, err
+}
+
+// GetCandles returns the candles of a series opened between start and end in
+// ascending time order
+func GetCandles(s *Series, start, end time.Time) ([]Candle, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	var resp []Candle
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.Candles(
+			qm.Where(seriesQuery, s.args()...),
+			qm.And("timestamp BETWEEN ? AND ?",
+				start.UTC().Format(repository.SQLiteTimeFormat),
+				end.UTC().Format(repository.SQLiteTimeFormat)),
+			qm.OrderBy("timestamp"),
+		).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for x := range v {
+			resp = append(resp, Candle{
+				Time:   parseSQLiteTime(v[x]),
+				Open:   v[x].Open,
+				High:   v[x].High,
+				Low:    v[x].Low,
+				Close:  v[x].Close,
+				Volume: v[x].Volume,
+			})
+		}
+		return resp, nil
+	}
+
+	v, err := modelPSQL.Candles(
+		qm.Where(seriesQuery, s.args()...),
+		qm.And("timestamp BETWEEN ? AND ?", start.UTC(), end.UTC()),
+		qm.OrderBy("timestamp"),
+	).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	for x := range v {
+		resp = append(resp, Candle{
+			Time:   v[x].Timestamp,
+			Open:   v[x].Open,
+			High:   v[x].High,
+			Low:    v[x].Low,
+			Close:  v[x].Close,
+			Volume: v[x].Volume,
+		})
+	}
+	return resp, nil
+}
+
+// GetLastTime returns the open time of the latest stored candle of a series,
+// or a zero time when none are stored
+func GetLastTime(s *Series) (time.Time, error) {
+	if database.DB.SQL == nil {
+		return time.Time{}, database.ErrDatabaseSupportDisabled
+	}
+	if err := s.validate(); err != nil {
+		return time.Time{}, err
+	}
+
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.Candles(
+			qm.Where(seriesQuery, s.args()...),
+			qm.OrderBy("timestamp DESC"),
+		).One(ctx, database.DB.SQL)
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		return parseSQLiteTime(v), nil
+	}
+
+	v, err := modelPSQL.Candles(
+		qm.Where(seriesQuery, s.args()...),
+		qm.OrderBy("timestamp DESC"),
+	).One(ctx, database.DB.SQL)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return v.Timestamp, nil
+}
+
+func parseSQLiteTime(c *modelSQLite.Candle) time.Time {
+	ts, err := time.Parse(time.RFC3339, c.Timestamp)
+	if err != nil {
+		log.Errorf(log.DatabaseMgr, "candle: %v has an incorrect time format ( %v ) - defaulting to empty time: %v", c.ID, c.Timestamp, err)
+	}
+	return ts
+}
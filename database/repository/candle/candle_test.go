@@ -0,0 +1,155 @@
+package candle
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/goose"
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		fmt.Printf("failed to create temp file: %v", err)
+		os.Exit(1)
+	}
+
+	t := m.Run()
+
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestCandle(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		runner func(t *testing.T)
+		closer func(dbConn *database.Instance) error
+	}{
+		{
+			"SQLite",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			candleHelper,
+			testhelpers.CloseDatabase,
+		},
+		{
+			"Postgres",
+			testhelpers.PostgresTestDatabase,
+			candleHelper,
+			nil,
+		},
+	}
+
+	for _, tests := range testCases {
+		test := tests
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("..", "..", "migrations")
+			err = goose.Run("up", dbConn.SQL, repository.GetSQLDialect(), path, "")
+			if err != nil {
+				t.Fatalf("failed to run migrations %v", err)
+			}
+
+			if test.runner != nil {
+				test.runner(t)
+			}
+
+			if test.closer != nil {
+				err = test.closer(dbConn)
+				if err != nil {
+					t.Log(err)
+				}
+			}
+		})
+	}
+}
+
+func candleHelper(t *testing.T) {
+	t.Helper()
+
+	s := &Series{
+		Exchange: fmt.Sprintf("test-%d", time.Now().UnixNano()),
+		Base:     "BTC",
+		Quote:    "USD",
+		Asset:    "spot",
+		Interval: time.Hour,
+	}
+	last, err := GetLastTime(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.IsZero() {
+		t.Errorf("expected no stored candles, got %v", last)
+	}
+
+	start := time.Now().Add(-time.Hour * 3).Truncate(time.Hour)
+	candles := []Candle{
+		{Time: start, Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10},
+		{Time: start.Add(time.Hour), Open: 1.5, High: 3, Low: 1, Close: 2, Volume: 20},
+	}
+	changed, err := Upsert(s, candles...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 2 {
+		t.Errorf("expected 2 candles to be stored, got %d", changed)
+	}
+
+	// unchanged candles are skipped and changed candles are updated
+	candles[1].Close = 2.5
+	changed, err = Upsert(s, candles...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 1 {
+		t.Errorf("expected 1 candle to be updated, got %d", changed)
+	}
+	if _, err = Upsert(&Series{}, candles...); err != errSeriesIncomplete {
+		t.Errorf("expected %v, got %v", errSeriesIncomplete, err)
+	}
+
+	resp, err := GetCandles(s, start, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("expected 2 candles, got %d", len(resp))
+	}
+	if resp[1].Close != 2.5 || !resp[0].Time.Equal(start) {
+		t.Errorf("unexpected candles %+v", resp)
+	}
+
+	last, err = GetLastTime(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.Equal(candles[1].Time) {
+		t.Errorf("expected last candle time %v, got %v", candles[1].Time, last)
+	}
+}
@@ -0,0 +1,204 @@
+package equity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+var errPortfolioUnset = errors.New("portfolio name must be specified")
+
+// Snapshot is the equity and balance of a portfolio or strategy at a point in
+// time, valued in Currency
+type Snapshot struct {
+	Portfolio string
+	Currency  string
+	Equity    float64
+	Balance   float64
+	Time      time.Time
+}
+
+// Insert stores snapshots in a single transaction
+func Insert(snapshots ...Snapshot) error {
+	if database.DB.KV != nil {
+		return insertKV(snapshots)
+	}
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range snapshots {
+		if snapshots[i].Portfolio == "" {
+			err = errPortfolioUnset
+			break
+		}
+		if repository.GetSQLDialect() == database.DBSQLite3 {
+			var tempSnapshot = modelSQLite.EquitySnapshot{
+				Portfolio: snapshots[i].Portfolio,
+				Currency:  snapshots[i].Currency,
+				Equity:    snapshots[i].Equity,
+				Balance:   snapshots[i].Balance,
+				CreatedAt: snapshots[i].Time.UTC().Format(repository.SQLiteTimeFormat),
+			}
+			err = tempSnapshot.Insert(ctx, tx, boil.Infer())
+		} else {
+			var tempSnapshot = modelPSQL.EquitySnapshot{
+				Portfolio: snapshots[i].Portfolio,
+				Currency:  snapshots[i].Currency,
+				Equity:    snapshots[i].Equity,
+				Balance:   snapshots[i].Balance,
+				CreatedAt: snapshots[i].Time.UTC(),
+			}
+			err = tempSnapshot.Insert(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		errRB := tx.Rollback()
+		if errRB != nil {
+			log.Errorf(log.DatabaseMgr, "Equity snapshot transaction rollback failed: %v", errRB)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSnapshots returns a portfolio's snapshots valued in a currency between
+// start and end in ascending time order
+func GetSnapshots(portfolio, currency string, start, end time.Time) ([]Snapshot, error) {
+	if database.DB.KV != nil {
+		return getSnapshotsKV(portfolio, currency, start, end)
+	}
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+
+	var resp []Snapshot
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.EquitySnapshots(
+			qm.Where("portfolio = ?", portfolio),
+			qm.And("currency = ?", currency),
+			qm.And("created_at BETWEEN ? AND ?",
+				start.UTC().Format(repository.SQLiteTimeFormat),
+				end.UTC().Format(repository.SQLiteTimeFormat)),
+			qm.OrderBy("created_at"),
+		).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for x := range v {
+			createdAt, err := time.Parse(time.RFC3339, v[x].CreatedAt)
+			if err != nil {
+				log.Errorf(log.DatabaseMgr, "equity snapshot: %v has an incorrect time format ( %v ) - defaulting to empty time: %v", v[x].ID, v[x].CreatedAt, err)
+			}
+			resp = append(resp, Snapshot{
+				Portfolio: v[x].Portfolio,
+				Currency:  v[x].Currency,
+				Equity:    v[x].Equity,
+				Balance:   v[x].Balance,
+				Time:      createdAt,
+			})
+		}
+		return resp, nil
+	}
+
+	v, err := modelPSQL.EquitySnapshots(
+		qm.Where("portfolio = ?", portfolio),
+		qm.And("currency = ?", currency),
+		qm.And("created_at BETWEEN ? AND ?", start.UTC(), end.UTC()),
+		qm.OrderBy("created_at"),
+	).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	for x := range v {
+		resp = append(resp, Snapshot{
+			Portfolio: v[x].Portfolio,
+			Currency:  v[x].Currency,
+			Equity:    v[x].Equity,
+			Balance:   v[x].Balance,
+			Time:      v[x].CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// MaxDrawdown returns the largest peak to trough decline in equity as a
+// percentage of the peak
+func MaxDrawdown(snapshots []Snapshot) float64 {
+	var peak, drawdown float64
+	for i := range snapshots {
+		if snapshots[i].Equity > peak {
+			peak = snapshots[i].Equity
+			continue
+		}
+		if peak <= 0 {
+			continue
+		}
+		if d := (peak - snapshots[i].Equity) / peak * 100; d > drawdown {
+			drawdown = d
+		}
+	}
+	return drawdown
+}
+
+// Return returns the change in equity from the first to the last snapshot as a
+// percentage of the first
+func Return(snapshots []Snapshot) float64 {
+	if len(snapshots) < 2 || snapshots[0].Equity <= 0 {
+		return 0
+	}
+	last := snapshots[len(snapshots)-1].Equity
+	return (last - snapshots[0].Equity) / snapshots[0].Equity * 100
+}
+
+// BenchmarkReturn returns the percentage return of holding the benchmark
+// currency over the period of the snapshots, valued in the snapshots' currency.
+// The benchmark snapshots are the same portfolio valued in the benchmark
+// currency, the ratio of the equities taken at the same time giving the price
+// of the benchmark. False is returned when fewer than two times match
+func BenchmarkReturn(snapshots, benchmark []Snapshot) (float64, bool) {
+	equities := make(map[int64]float64, len(benchmark))
+	for i := range benchmark {
+		if benchmark[i].Equity > 0 {
+			equities[benchmark[i].Time.Unix()] = benchmark[i].Equity
+		}
+	}
+
+	var first, last float64
+	for i := range snapshots {
+		b, ok := equities[snapshots[i].Time.Unix()]
+		if !ok || snapshots[i].Equity <= 0 {
+			continue
+		}
+		price := snapshots[i].Equity / b
+		if first == 0 {
+			first = price
+			continue
+		}
+		last = price
+	}
+	if first == 0 || last == 0 {
+		return 0, false
+	}
+	return (last - first) / first * 100, true
+}
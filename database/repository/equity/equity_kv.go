@@ -0,0 +1,83 @@
+package equity
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+// snapshotsBucket holds a bucket for each portfolio, which holds a bucket for
+// each currency of snapshots keyed by time
+var snapshotsBucket = []byte("equity_snapshots")
+
+// timeKey orders keys by time when compared as bytes
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return key
+}
+
+func insertKV(snapshots []Snapshot) error {
+	return database.DB.KV.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(snapshotsBucket)
+		if err != nil {
+			return err
+		}
+		for i := range snapshots {
+			if snapshots[i].Portfolio == "" {
+				return errPortfolioUnset
+			}
+			portfolio, err := root.CreateBucketIfNotExists([]byte(snapshots[i].Portfolio))
+			if err != nil {
+				return err
+			}
+			currency, err := portfolio.CreateBucketIfNotExists([]byte(snapshots[i].Currency))
+			if err != nil {
+				return err
+			}
+			snapshot := snapshots[i]
+			snapshot.Time = snapshot.Time.UTC()
+			payload, err := json.Marshal(&snapshot)
+			if err != nil {
+				return err
+			}
+			if err = currency.Put(timeKey(snapshot.Time), payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func getSnapshotsKV(portfolio, currency string, start, end time.Time) ([]Snapshot, error) {
+	var resp []Snapshot
+	err := database.DB.KV.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(snapshotsBucket)
+		if root == nil {
+			return nil
+		}
+		p := root.Bucket([]byte(portfolio))
+		if p == nil {
+			return nil
+		}
+		c := p.Bucket([]byte(currency))
+		if c == nil {
+			return nil
+		}
+		last := timeKey(end)
+		cursor := c.Cursor()
+		for k, v := cursor.Seek(timeKey(start)); k != nil && bytes.Compare(k, last) <= 0; k, v = cursor.Next() {
+			var s Snapshot
+			if err := json.Unmarshal(v, &s); err != nil {
+				return err
+			}
+			resp = append(resp, s)
+		}
+		return nil
+	})
+	return resp, err
+}
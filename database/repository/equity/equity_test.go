@@ -0,0 +1,189 @@
+package equity
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/goose"
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		fmt.Printf("failed to create temp file: %v", err)
+		os.Exit(1)
+	}
+
+	t := m.Run()
+
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestEquity(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		runner func(t *testing.T)
+		closer func(dbConn *database.Instance) error
+	}{
+		{
+			"SQLite",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			equityHelper,
+			testhelpers.CloseDatabase,
+		},
+		{
+			"Bolt",
+			&database.Config{
+				Driver:            database.DBBolt,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb.bolt"},
+			},
+			equityHelper,
+			testhelpers.CloseDatabase,
+		},
+		{
+			"Postgres",
+			testhelpers.PostgresTestDatabase,
+			equityHelper,
+			nil,
+		},
+	}
+
+	for _, tests := range testCases {
+		test := tests
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dbConn.KV == nil {
+				path := filepath.Join("..", "..", "migrations")
+				err = goose.Run("up", dbConn.SQL, repository.GetSQLDialect(), path, "")
+				if err != nil {
+					t.Fatalf("failed to run migrations %v", err)
+				}
+			}
+
+			if test.runner != nil {
+				test.runner(t)
+			}
+
+			if test.closer != nil {
+				err = test.closer(dbConn)
+				if err != nil {
+					t.Log(err)
+				}
+			}
+		})
+	}
+}
+
+func equityHelper(t *testing.T) {
+	t.Helper()
+
+	name := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var snapshots []Snapshot
+	for x := 0; x < 3; x++ {
+		snapshots = append(snapshots, Snapshot{
+			Portfolio: name,
+			Currency:  "USD",
+			Equity:    float64(100 + x),
+			Balance:   50,
+			Time:      start.Add(time.Minute * time.Duration(x)),
+		})
+	}
+	if err := Insert(snapshots...); err != nil {
+		t.Fatal(err)
+	}
+	if err := Insert(Snapshot{}); err != errPortfolioUnset {
+		t.Errorf("expected %v, got %v", errPortfolioUnset, err)
+	}
+
+	resp, err := GetSnapshots(name, "USD", start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 3 {
+		t.Fatalf("expected 3 snapshots, got %d", len(resp))
+	}
+	if resp[0].Equity != 100 || resp[2].Equity != 102 || !resp[1].Time.Equal(snapshots[1].Time) {
+		t.Errorf("unexpected snapshots %+v", resp)
+	}
+
+	resp, err = GetSnapshots(name, "BTC", start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 0 {
+		t.Errorf("expected no snapshots valued in BTC, got %d", len(resp))
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	snapshots := []Snapshot{{Equity: 100}, {Equity: 120}, {Equity: 90}, {Equity: 130}, {Equity: 110}}
+	if d := MaxDrawdown(snapshots); d != 25 {
+		t.Errorf("expected 25, got %v", d)
+	}
+	if d := MaxDrawdown(nil); d != 0 {
+		t.Errorf("expected 0, got %v", d)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	if r := Return([]Snapshot{{Equity: 100}, {Equity: 90}, {Equity: 125}}); r != 25 {
+		t.Errorf("expected 25, got %v", r)
+	}
+	if r := Return([]Snapshot{{Equity: 100}}); r != 0 {
+		t.Errorf("expected 0, got %v", r)
+	}
+}
+
+func TestBenchmarkReturn(t *testing.T) {
+	now := time.Now()
+	usd := []Snapshot{
+		{Equity: 1000, Time: now},
+		{Equity: 1500, Time: now.Add(time.Minute)},
+		{Equity: 3000, Time: now.Add(time.Minute * 2)},
+	}
+	// the portfolio is valued at 10, 15 and 20 BTC, so BTC's price rises
+	// from 100 USD to 150 USD
+	btc := []Snapshot{
+		{Equity: 10, Time: now},
+		{Equity: 15, Time: now.Add(time.Minute)},
+		{Equity: 20, Time: now.Add(time.Minute * 2)},
+	}
+	r, ok := BenchmarkReturn(usd, btc)
+	if !ok || r != 50 {
+		t.Errorf("expected a 50%% benchmark return, got %v %v", r, ok)
+	}
+	if r, ok = BenchmarkReturn(btc, btc); !ok || r != 0 {
+		t.Errorf("expected no return benchmarking a currency against itself, got %v %v", r, ok)
+	}
+	if _, ok = BenchmarkReturn(usd, btc[:1]); ok {
+		t.Error("expected a single matching time not to return a benchmark")
+	}
+}
@@ -0,0 +1,217 @@
+package funding
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+var errRecordIncomplete = errors.New("funding record exchange, transfer ID and transfer type must be specified")
+
+// Record is a deposit or withdrawal of an exchange account
+type Record struct {
+	Exchange     string
+	TransferID   string
+	TransferType string
+	Currency     string
+	Amount       float64
+	Fee          float64
+	Status       string
+	Description  string
+	Address      string
+	TxID         string
+	Time         time.Time
+}
+
+// Upsert stores records in a single transaction. Records already stored for
+// the exchange have their status, fee and transaction ID updated as transfers
+// progress. Returns the amount of records stored or updated
+func Upsert(records ...Record) (int, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+
+	ctx := context.Background()
+	ctx = boil.SkipTimestamps(ctx)
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var changed int
+	for i := range records {
+		if records[i].Exchange == "" || records[i].TransferID == "" || records[i].TransferType == "" {
+			err = errRecordIncomplete
+			break
+		}
+		var stored bool
+		if repository.GetSQLDialect() == database.DBSQLite3 {
+			stored, err = upsertSQLite(ctx, tx, &records[i])
+		} else {
+			stored, err = upsertPSQL(ctx, tx, &records[i])
+		}
+		if err != nil {
+			break
+		}
+		if stored {
+			changed++
+		}
+	}
+
+	if err != nil {
+		errRB := tx.Rollback()
+		if errRB != nil {
+			log.Errorf(log.DatabaseMgr, "Funding history transaction rollback failed: %v", errRB)
+		}
+		return 0, err
+	}
+	return changed, tx.Commit()
+}
+
+// upsertSQLite inserts a record or updates the stored record when it has
+// changed, returning whether the record was written
+func upsertSQLite(ctx context.Context, tx boil.ContextExecutor, r *Record) (bool, error) {
+	existing, err := modelSQLite.FundingHistories(
+		qm.Where("exchange = ? AND transfer_type = ? AND transfer_id = ?", r.Exchange, r.TransferType, r.TransferID),
+	).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		var tempRecord = modelSQLite.FundingHistory{
+			Exchange:     r.Exchange,
+			TransferID:   r.TransferID,
+			TransferType: r.TransferType,
+			Currency:     r.Currency,
+			Amount:       r.Amount,
+			Fee:          r.Fee,
+			Status:       r.Status,
+			Description:  r.Description,
+			Address:      r.Address,
+			TxID:         r.TxID,
+			Timestamp:    r.Time.UTC().Format(repository.SQLiteTimeFormat),
+		}
+		return true, tempRecord.Insert(ctx, tx, boil.Infer())
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.Status == r.Status && existing.Fee == r.Fee && existing.TxID == r.TxID {
+		return false, nil
+	}
+	existing.Status = r.Status
+	existing.Fee = r.Fee
+	existing.TxID = r.TxID
+	// only the progressed columns are written so the stored time is retained
+	_, err = existing.Update(ctx, tx, boil.Whitelist("status", "fee", "tx_id"))
+	return err == nil, err
+}
+
+// upsertPSQL inserts a record or updates the stored record when it has
+// changed, returning whether the record was written
+func upsertPSQL(ctx context.Context, tx boil.ContextExecutor, r *Record) (bool, error) {
+	existing, err := modelPSQL.FundingHistories(
+		qm.Where("exchange = ? AND transfer_type = ? AND transfer_id = ?", r.Exchange, r.TransferType, r.TransferID),
+	).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		var tempRecord = modelPSQL.FundingHistory{
+			Exchange:     r.Exchange,
+			TransferID:   r.TransferID,
+			TransferType: r.TransferType,
+			Currency:     r.Currency,
+			Amount:       r.Amount,
+			Fee:          r.Fee,
+			Status:       r.Status,
+			Description:  r.Description,
+			Address:      r.Address,
+			TxID:         r.TxID,
+			Timestamp:    r.Time.UTC(),
+		}
+		return true, tempRecord.Insert(ctx, tx, boil.Infer())
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.Status == r.Status && existing.Fee == r.Fee && existing.TxID == r.TxID {
+		return false, nil
+	}
+	existing.Status = r.Status
+	existing.Fee = r.Fee
+	existing.TxID = r.TxID
+	_, err = existing.Update(ctx, tx, boil.Whitelist("status", "fee", "tx_id"))
+	return err == nil, err
+}
+
+// GetRecords returns an exchange's records between start and end in ascending
+// time order
+func GetRecords(exchange string, start, end time.Time) ([]Record, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+
+	var resp []Record
+	ctx := context.Background()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		v, err := modelSQLite.FundingHistories(
+			qm.Where("exchange = ?", exchange),
+			qm.And("timestamp BETWEEN ? AND ?",
+				start.UTC().Format(repository.SQLiteTimeFormat),
+				end.UTC().Format(repository.SQLiteTimeFormat)),
+			qm.OrderBy("timestamp, id"),
+		).All(ctx, database.DB.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for x := range v {
+			ts, err := time.Parse(time.RFC3339, v[x].Timestamp)
+			if err != nil {
+				log.Errorf(log.DatabaseMgr, "funding history: %v has an incorrect time format ( %v ) - defaulting to empty time: %v", v[x].ID, v[x].Timestamp, err)
+			}
+			resp = append(resp, Record{
+				Exchange:     v[x].Exchange,
+				TransferID:   v[x].TransferID,
+				TransferType: v[x].TransferType,
+				Currency:     v[x].Currency,
+				Amount:       v[x].Amount,
+				Fee:          v[x].Fee,
+				Status:       v[x].Status,
+				Description:  v[x].Description,
+				Address:      v[x].Address,
+				TxID:         v[x].TxID,
+				Time:         ts,
+			})
+		}
+		return resp, nil
+	}
+
+	v, err := modelPSQL.FundingHistories(
+		qm.Where("exchange = ?", exchange),
+		qm.And("timestamp BETWEEN ? AND ?", start.UTC(), end.UTC()),
+		qm.OrderBy("timestamp, id"),
+	).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	for x := range v {
+		resp = append(resp, Record{
+			Exchange:     v[x].Exchange,
+			TransferID:   v[x].TransferID,
+			TransferType: v[x].TransferType,
+			Currency:     v[x].Currency,
+			Amount:       v[x].Amount,
+			Fee:          v[x].Fee,
+			Status:       v[x].Status,
+			Description:  v[x].Description,
+			Address:      v[x].Address,
+			TxID:         v[x].TxID,
+			Time:         v[x].Timestamp,
+		})
+	}
+	return resp, nil
+}
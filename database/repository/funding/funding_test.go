@@ -0,0 +1,151 @@
+package funding
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/goose"
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		fmt.Printf("failed to create temp file: %v", err)
+		os.Exit(1)
+	}
+
+	t := m.Run()
+
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestFunding(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		runner func(t *testing.T)
+		closer func(dbConn *database.Instance) error
+	}{
+		{
+			"SQLite",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			fundingHelper,
+			testhelpers.CloseDatabase,
+		},
+		{
+			"Postgres",
+			testhelpers.PostgresTestDatabase,
+			fundingHelper,
+			nil,
+		},
+	}
+
+	for _, tests := range testCases {
+		test := tests
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("..", "..", "migrations")
+			err = goose.Run("up", dbConn.SQL, repository.GetSQLDialect(), path, "")
+			if err != nil {
+				t.Fatalf("failed to run migrations %v", err)
+			}
+
+			if test.runner != nil {
+				test.runner(t)
+			}
+
+			if test.closer != nil {
+				err = test.closer(dbConn)
+				if err != nil {
+					t.Log(err)
+				}
+			}
+		})
+	}
+}
+
+func fundingHelper(t *testing.T) {
+	t.Helper()
+
+	exch := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	records := []Record{
+		{
+			Exchange:     exch,
+			TransferID:   "1",
+			TransferType: "DEPOSIT",
+			Currency:     "USD",
+			Amount:       1000,
+			Status:       "Pending",
+			Time:         start,
+		},
+		{
+			Exchange:     exch,
+			TransferID:   "1",
+			TransferType: "WITHDRAWAL",
+			Currency:     "BTC",
+			Amount:       0.5,
+			Fee:          0.0005,
+			Status:       "Complete",
+			Address:      "address",
+			Time:         start.Add(time.Minute),
+		},
+	}
+	changed, err := Upsert(records...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 2 {
+		t.Errorf("expected 2 records to be stored, got %d", changed)
+	}
+
+	// unchanged records are skipped and progressed transfers are updated
+	records[0].Status = "Complete"
+	changed, err = Upsert(records...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 1 {
+		t.Errorf("expected 1 record to be updated, got %d", changed)
+	}
+	if _, err = Upsert(Record{}); err != errRecordIncomplete {
+		t.Errorf("expected %v, got %v", errRecordIncomplete, err)
+	}
+
+	resp, err := GetRecords(exch, start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(resp))
+	}
+	if resp[0].Status != "Complete" || resp[1].Fee != 0.0005 || !resp[1].Time.Equal(records[1].Time) {
+		t.Errorf("unexpected records %+v", resp)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 )
 
+// SQLiteTimeFormat matches the format of CURRENT_TIMESTAMP so that stored
+// times compare correctly as strings
+const SQLiteTimeFormat = "2006-01-02 15:04:05"
+
 // GetSQLDialect returns current SQL Dialect based on enabled driver
 func GetSQLDialect() string {
 	switch database.DB.Config.Driver {
@@ -11,6 +15,8 @@ func GetSQLDialect() string {
 		return database.DBSQLite3
 	case "psql", "postgres", "postgresql":
 		return database.DBPostgreSQL
+	case "bolt", "bbolt":
+		return database.DBBolt
 	}
 	return "invalid driver"
 }
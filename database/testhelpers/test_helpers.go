@@ -6,6 +6,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	boltConn "github.com/thrasher-corp/gocryptotrader/database/drivers/bolt"
 	psqlConn "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
 	sqliteConn "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
 )
@@ -78,6 +79,12 @@ func ConnectToDatabase(conn *database.Config) (dbConn *database.Instance, err er
 		database.DB.DataPath = TempDir
 		dbConn, err = sqliteConn.Connect()
 
+		if err != nil {
+			return nil, err
+		}
+	} else if conn.Driver == database.DBBolt {
+		database.DB.DataPath = TempDir
+		dbConn, err = boltConn.Connect()
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +96,11 @@ func ConnectToDatabase(conn *database.Config) (dbConn *database.Instance, err er
 // CloseDatabase closes database connection
 func CloseDatabase(conn *database.Instance) (err error) {
 	if conn != nil {
+		if conn.KV != nil {
+			err = conn.KV.Close()
+			conn.KV = nil
+			return err
+		}
 		return conn.SQL.Close()
 	}
 	return nil
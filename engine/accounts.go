@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// AccountExchangeName returns the name an exchange's additional account is
+// referred to by, which is the exchange's name when the account is empty
+func AccountExchangeName(exchName, account string) string {
+	if account == "" {
+		return exchName
+	}
+	return exchName + config.AccountDelimiter + account
+}
+
+// SplitAccountExchangeName returns the exchange and account of an account
+// exchange name, the account is empty for the exchange's own credentials
+func SplitAccountExchangeName(name string) (exchName, account string) {
+	i := strings.Index(name, config.AccountDelimiter)
+	if i < 0 {
+		return name, ""
+	}
+	return name[:i], name[i+len(config.AccountDelimiter):]
+}
+
+// GetExchangeAccounts returns the instances of the loaded exchanges
+// authenticated as their additional accounts
+func GetExchangeAccounts() []exchange.IBotExchange {
+	return Bot.exchangeManager.getAccounts()
+}
+
+// getAuthenticatedExchanges returns the loaded exchanges and their additional
+// accounts which support authenticated REST requests
+func getAuthenticatedExchanges() []exchange.IBotExchange {
+	var authenticated []exchange.IBotExchange
+	exchanges := append(GetExchanges(), GetExchangeAccounts()...)
+	for x := range exchanges {
+		if exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+			authenticated = append(authenticated, exchanges[x])
+		}
+	}
+	return authenticated
+}
+
+// loadExchangeAccounts loads an instance of the exchange for each of its
+// additional accounts. Accounts which fail to load are skipped
+func loadExchangeAccounts(exchCfg *config.ExchangeConfig) {
+	for x := range exchCfg.API.Accounts {
+		err := loadExchangeAccount(exchCfg, &exchCfg.API.Accounts[x])
+		if err != nil {
+			log.Warnf(log.ExchangeSys, "%s: Cannot load account %s, Error: %s\n",
+				exchCfg.Name,
+				exchCfg.API.Accounts[x].Name,
+				err)
+			continue
+		}
+		log.Debugf(log.ExchangeSys, "%s: Account %s loaded.\n",
+			exchCfg.Name,
+			exchCfg.API.Accounts[x].Name)
+	}
+}
+
+// loadExchangeAccount sets up an instance of the exchange authenticated with
+// the account's credentials. The instance makes REST requests only, the
+// exchange's instance maintains its websocket and market data
+func loadExchangeAccount(exchCfg *config.ExchangeConfig, acct *config.APIAccountConfig) error {
+	exch, err := newExchange(exchCfg.Name)
+	if err != nil {
+		return err
+	}
+	exch.SetDefaults()
+
+	cfg := *exchCfg
+	cfg.API.AuthenticatedSupport = true
+	cfg.API.AuthenticatedWebsocketSupport = false
+	cfg.API.Credentials = acct.Credentials
+	cfg.API.Keys = nil
+	cfg.API.Accounts = nil
+	cfg.API.CredentialsProvider = nil
+	if exchCfg.Features != nil {
+		features := *exchCfg.Features
+		features.Enabled.Websocket = false
+		cfg.Features = &features
+	}
+	if err = exch.Setup(&cfg); err != nil {
+		return err
+	}
+	exch.GetBase().Name = AccountExchangeName(exchCfg.Name, acct.Name)
+
+	if !Bot.Settings.EnableExchangeHTTPRateLimiter {
+		if err = exch.DisableRateLimiter(); err != nil {
+			return err
+		}
+	}
+	if err = exch.ValidateCredentials(); err != nil {
+		return fmt.Errorf("cannot validate credentials: %v", err)
+	}
+	Bot.exchangeManager.addAccount(exch)
+	return nil
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"testing"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+)
+
+// testAccount returns an instance of the Bitstamp exchange named as an
+// additional account
+func testAccount(t *testing.T, name string) exchange.IBotExchange {
+	t.Helper()
+	exch, err := newExchange(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	exch.GetBase().Name = name
+	exch.GetBase().API.AuthenticatedSupport = true
+	return exch
+}
+
+func TestAccountExchangeName(t *testing.T) {
+	if n := AccountExchangeName("Bitstamp", ""); n != "Bitstamp" {
+		t.Errorf("expected Bitstamp, got %s", n)
+	}
+	name := AccountExchangeName("Bitstamp", "alice")
+	if name != "Bitstamp:alice" {
+		t.Errorf("expected Bitstamp:alice, got %s", name)
+	}
+	exchName, account := SplitAccountExchangeName(name)
+	if exchName != "Bitstamp" || account != "alice" {
+		t.Errorf("unexpected split %s %s", exchName, account)
+	}
+	exchName, account = SplitAccountExchangeName("Bitstamp")
+	if exchName != "Bitstamp" || account != "" {
+		t.Errorf("unexpected split %s %s", exchName, account)
+	}
+}
+
+func TestExchangeManagerAccounts(t *testing.T) {
+	var e exchangeManager
+	e.add(testAccount(t, "Fake"))
+	e.addAccount(testAccount(t, "Fake:alice"))
+
+	if len(e.getExchanges()) != 1 {
+		t.Error("expected accounts not to be returned as exchanges")
+	}
+	if len(e.getAccounts()) != 1 {
+		t.Error("expected one account")
+	}
+	exch := e.getExchangeByName("fake:ALICE")
+	if exch == nil || exch.GetName() != "Fake:alice" {
+		t.Fatal("expected account to be found by its account exchange name")
+	}
+
+	if err := e.removeExchange("Fake"); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.getAccounts()) != 0 {
+		t.Error("expected accounts to be removed with their exchange")
+	}
+}
+
+func TestGetAuthenticatedExchanges(t *testing.T) {
+	SetupTestHelpers(t)
+	name := AccountExchangeName(testExchange, "alice")
+	Bot.exchangeManager.addAccount(testAccount(t, name))
+	defer func() {
+		Bot.exchangeManager.m.Lock()
+		delete(Bot.exchangeManager.accounts, "bitstamp:alice")
+		Bot.exchangeManager.m.Unlock()
+	}()
+
+	if GetExchangeByName(name) == nil {
+		t.Fatal("expected account to be loaded")
+	}
+	var found bool
+	exchanges := getAuthenticatedExchanges()
+	for x := range exchanges {
+		if exchanges[x].GetName() == name {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected account to be returned as an authenticated exchange")
+	}
+}
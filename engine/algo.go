@@ -0,0 +1,433 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the execution algo manager
+var (
+	ErrAlgoNotFound           = errors.New("execution algo does not exist")
+	errAlgoIsNil              = errors.New("execution algo is nil")
+	errAlgoTypeInvalid        = errors.New("execution algo type is invalid")
+	errAlgoNotActive          = errors.New("execution algo is not active")
+	errAlgoInvalidDuration    = errors.New("TWAP duration must be greater than zero")
+	errAlgoInvalidSlices      = errors.New("TWAP slices cannot be negative")
+	errAlgoInvalidSliceSize   = errors.New("iceberg slice size must be greater than zero and less than the order amount")
+	errAlgoIcebergNotLimit    = errors.New("iceberg orders must be limit orders")
+	errAlgoInvalidJitter      = errors.New("slice jitter must be between 0 and 100 percent")
+	errAlgoInvalidAdverseMove = errors.New("max adverse move cannot be negative")
+	errAlgoNoMarketPrice      = errors.New("no market price available")
+
+	// DefaultTWAPSlices is the amount of child orders a TWAP is split into
+	// when no amount is specified
+	DefaultTWAPSlices int64 = 10
+	// DefaultAlgoRefreshInterval is how often an iceberg child order is
+	// checked when no interval is specified
+	DefaultAlgoRefreshInterval = time.Second * 5
+)
+
+// Submit validates the execution algo and places its first child order. The
+// remaining child orders are placed through the order manager as TWAP slices
+// elapse or iceberg slices fill
+func (a *algoManager) Submit(algo *Algo) (*Algo, error) {
+	if algo == nil {
+		return nil, errAlgoIsNil
+	}
+	if algo.Order.AssetType == "" {
+		algo.Order.AssetType = asset.Spot
+	}
+	if err := algo.Order.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch algo.Type {
+	case AlgoTWAP:
+		if algo.Options.Duration <= 0 {
+			return nil, errAlgoInvalidDuration
+		}
+		if algo.Options.Slices < 0 {
+			return nil, errAlgoInvalidSlices
+		}
+		if algo.Options.Slices == 0 {
+			algo.Options.Slices = DefaultTWAPSlices
+		}
+	case AlgoIceberg:
+		if algo.Order.Type != order.Limit {
+			return nil, errAlgoIcebergNotLimit
+		}
+		if algo.Options.SliceSize <= 0 || algo.Options.SliceSize >= algo.Order.Amount {
+			return nil, errAlgoInvalidSliceSize
+		}
+		if algo.Options.RefreshInterval <= 0 {
+			algo.Options.RefreshInterval = DefaultAlgoRefreshInterval
+		}
+	default:
+		return nil, errAlgoTypeInvalid
+	}
+	if algo.Options.Jitter < 0 || algo.Options.Jitter >= 100 {
+		return nil, errAlgoInvalidJitter
+	}
+	if algo.Options.MaxAdverseMove < 0 {
+		return nil, errAlgoInvalidAdverseMove
+	}
+
+	if algo.Options.MaxAdverseMove > 0 {
+		price, err := algoMarketPrice(algo)
+		if err != nil {
+			return nil, err
+		}
+		algo.ArrivalPrice = price
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	algo.ID = id.String()
+	algo.Status = AlgoActive
+	algo.Filled = 0
+	algo.Remaining = algo.Order.Amount
+	algo.Working = 0
+	algo.Slices = 0
+	algo.Created = clock.Now()
+	algo.LastUpdated = algo.Created
+	algo.shutdown = make(chan struct{})
+
+	if err = a.nextSlice(algo); err != nil {
+		return nil, err
+	}
+
+	a.m.Lock()
+	if a.algos == nil {
+		a.algos = make(map[string]*Algo)
+	}
+	a.algos[algo.ID] = algo
+	a.m.Unlock()
+
+	go a.run(algo)
+	return a.GetByID(algo.ID)
+}
+
+// GetByID returns a copy of the execution algo matching the supplied ID
+func (a *algoManager) GetByID(id string) (*Algo, error) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	algo, ok := a.algos[id]
+	if !ok {
+		return nil, ErrAlgoNotFound
+	}
+	cp := *algo
+	return &cp, nil
+}
+
+// GetAll returns a copy of all execution algos
+func (a *algoManager) GetAll() []Algo {
+	a.m.Lock()
+	defer a.m.Unlock()
+	var algos []Algo
+	for _, v := range a.algos {
+		algos = append(algos, *v)
+	}
+	return algos
+}
+
+// Cancel stops an active execution algo and cancels its working child order
+func (a *algoManager) Cancel(id string) error {
+	a.m.Lock()
+	algo, ok := a.algos[id]
+	if !ok {
+		a.m.Unlock()
+		return ErrAlgoNotFound
+	}
+	if algo.Status != AlgoActive {
+		a.m.Unlock()
+		return errAlgoNotActive
+	}
+	algo.Status = AlgoCancelled
+	close(algo.shutdown)
+	a.m.Unlock()
+
+	err := a.cancelChild(algo)
+	a.finish(algo, AlgoCancelled)
+	return err
+}
+
+// run steps the execution algo each slice interval until it completes, is
+// cancelled or the order manager is shut down
+func (a *algoManager) run(algo *Algo) {
+	interval := algo.Options.RefreshInterval
+	if algo.Type == AlgoTWAP {
+		interval = algo.Options.Duration / time.Duration(algo.Options.Slices)
+	}
+	for {
+		select {
+		case <-Bot.OrderManager.shutdown:
+			return
+		case <-algo.shutdown:
+			return
+		case <-time.After(interval):
+			if a.step(algo) {
+				return
+			}
+		}
+	}
+}
+
+// step updates the fill state of the working child order, checks for an
+// adverse market move and places the next child order when due. Returns true
+// once the algo has completed
+func (a *algoManager) step(algo *Algo) bool {
+	a.m.Lock()
+	active := algo.Status == AlgoActive
+	a.m.Unlock()
+	if !active {
+		return true
+	}
+
+	filled, err := a.reconcile(algo)
+	if err != nil {
+		a.fail(algo, err)
+		return true
+	}
+	if algo.Remaining <= 0 {
+		a.finish(algo, AlgoCompleted)
+		return true
+	}
+
+	if algo.Options.MaxAdverseMove > 0 {
+		price, err := algoMarketPrice(algo)
+		if err != nil {
+			a.fail(algo, err)
+			return true
+		}
+		if adverseMove(algo.Order.Side, algo.ArrivalPrice, price, algo.Options.MaxAdverseMove) {
+			if err = a.cancelChild(algo); err != nil {
+				a.fail(algo, err)
+				return true
+			}
+			a.finish(algo, AlgoStopped)
+			return true
+		}
+	}
+
+	switch algo.Type {
+	case AlgoTWAP:
+		// an unfilled TWAP slice is cancelled and its remainder spread over
+		// the slices which follow
+		if !filled {
+			if err = a.cancelChild(algo); err != nil {
+				a.fail(algo, err)
+				return true
+			}
+		}
+		if algo.Slices >= algo.Options.Slices {
+			a.finish(algo, AlgoCompleted)
+			return true
+		}
+	case AlgoIceberg:
+		if !filled {
+			return false
+		}
+	}
+
+	if err = a.nextSlice(algo); err != nil {
+		a.fail(algo, err)
+		return true
+	}
+	a.notify(algo, fmt.Sprintf("placed slice %v for %v", algo.Slices, algo.Working))
+	return false
+}
+
+// nextSlice places the next child order for the amount not yet working on the
+// book
+func (a *algoManager) nextSlice(algo *Algo) error {
+	unsent := algo.Remaining - algo.Working
+	size := unsent
+	switch algo.Type {
+	case AlgoTWAP:
+		if left := algo.Options.Slices - algo.Slices; left > 1 {
+			size = unsent / float64(left)
+		}
+	case AlgoIceberg:
+		size = math.Min(algo.Options.SliceSize, unsent)
+	}
+	size = jitterSize(size, unsent, algo.Options.Jitter, rand.Float64()) // nolint:gosec // jitter does not require a secure source
+
+	child := algo.Order
+	child.Amount = size
+	// each child order requires its own client order ID
+	child.ClientID = ""
+	resp, err := Bot.OrderManager.Submit(&child)
+	if err != nil {
+		return err
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+	algo.Slices++
+	algo.LastUpdated = clock.Now()
+	if resp.FullyMatched {
+		algo.Filled += size
+		algo.Remaining -= size
+		return nil
+	}
+	algo.Working = size
+	algo.workingFilled = 0
+	algo.OrderID = resp.OrderID
+	algo.InternalOrderID = resp.InternalOrderID
+	return nil
+}
+
+// reconcile adds any executed amount of the working child order to the algo.
+// Returns true once the child order has been fully filled or when no child
+// order is working
+func (a *algoManager) reconcile(algo *Algo) (bool, error) {
+	if algo.Working <= 0 {
+		return true, nil
+	}
+	exch := GetExchangeByName(algo.Order.Exchange)
+	if exch == nil {
+		return false, ErrExchangeNotFound
+	}
+	od, err := exch.GetOrderInfo(algo.OrderID)
+	if err != nil {
+		return false, err
+	}
+
+	executed := od.ExecutedAmount
+	if od.Status == order.Filled || executed > algo.Working {
+		executed = algo.Working
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+	if executed > algo.workingFilled {
+		algo.Filled += executed - algo.workingFilled
+		algo.Remaining -= executed - algo.workingFilled
+		algo.workingFilled = executed
+		algo.LastUpdated = clock.Now()
+	}
+	if executed < algo.Working {
+		return false, nil
+	}
+	algo.Working = 0
+	algo.workingFilled = 0
+	return true, nil
+}
+
+// cancelChild cancels the working child order, returning its unfilled amount
+// to the algo
+func (a *algoManager) cancelChild(algo *Algo) error {
+	if algo.Working <= 0 {
+		return nil
+	}
+	err := Bot.OrderManager.Cancel(&order.Cancel{
+		Exchange:  algo.Order.Exchange,
+		ID:        algo.OrderID,
+		AccountID: algo.Order.AccountID,
+		ClientID:  algo.Order.ClientID,
+		Type:      algo.Order.Type,
+		Side:      algo.Order.Side,
+		Pair:      algo.Order.Pair,
+		AssetType: algo.Order.AssetType,
+	})
+	if err != nil {
+		return err
+	}
+	a.m.Lock()
+	algo.Working = 0
+	algo.workingFilled = 0
+	algo.LastUpdated = clock.Now()
+	a.m.Unlock()
+	return nil
+}
+
+func (a *algoManager) finish(algo *Algo, status AlgoStatus) {
+	a.m.Lock()
+	algo.Status = status
+	algo.LastUpdated = clock.Now()
+	a.m.Unlock()
+	a.notify(algo, string(status))
+}
+
+func (a *algoManager) fail(algo *Algo, err error) {
+	a.m.Lock()
+	algo.Error = err.Error()
+	a.m.Unlock()
+	log.Warnf(log.OrderMgr, "Algo manager: %s %s failed: %s", algo.Type, algo.ID, err)
+	a.finish(algo, AlgoFailed)
+}
+
+func (a *algoManager) notify(algo *Algo, action string) {
+	a.m.Lock()
+	msg := fmt.Sprintf("Algo manager: %s %s %s %s %s %s. Filled %v remaining %v slices %v",
+		algo.Type,
+		algo.ID,
+		algo.Order.Exchange,
+		algo.Order.Side,
+		algo.Order.Pair,
+		action,
+		algo.Filled,
+		algo.Remaining,
+		algo.Slices)
+	a.m.Unlock()
+	log.Debugln(log.OrderMgr, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "algo",
+		Message: msg,
+	})
+}
+
+// algoMarketPrice returns the mid price of the algo's pair, falling back to
+// the last traded price
+func algoMarketPrice(algo *Algo) (float64, error) {
+	exch := GetExchangeByName(algo.Order.Exchange)
+	if exch == nil {
+		return 0, ErrExchangeNotFound
+	}
+	tick, err := exch.FetchTicker(algo.Order.Pair, algo.Order.AssetType)
+	if err != nil {
+		return 0, err
+	}
+	if tick == nil {
+		return 0, errAlgoNoMarketPrice
+	}
+	if tick.Bid > 0 && tick.Ask > 0 {
+		return (tick.Bid + tick.Ask) / 2, nil
+	}
+	if tick.Last > 0 {
+		return tick.Last, nil
+	}
+	return 0, errAlgoNoMarketPrice
+}
+
+// adverseMove returns true when the price has moved against the order side by
+// more than the max percentage from the arrival price
+func adverseMove(side order.Side, arrival, price, maxMove float64) bool {
+	if arrival <= 0 || maxMove <= 0 {
+		return false
+	}
+	if isBuySide(side) {
+		return price > arrival*(1+maxMove/100)
+	}
+	return price < arrival*(1-maxMove/100)
+}
+
+// jitterSize varies the slice size by up to the jitter percentage using r in
+// the range [0, 1), never exceeding the unsent amount
+func jitterSize(size, unsent, jitter, r float64) float64 {
+	if jitter > 0 {
+		size *= 1 + (r*2-1)*jitter/100
+	}
+	return math.Min(size, unsent)
+}
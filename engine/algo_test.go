@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testAlgo(exch string, algoType AlgoType) *Algo {
+	return &Algo{
+		Type: algoType,
+		Order: order.Submit{
+			Exchange:  exch,
+			Pair:      currency.NewPairFromString("BTCUSD"),
+			AssetType: asset.Spot,
+			Side:      order.Buy,
+			Type:      order.Limit,
+			Amount:    1,
+			Price:     100,
+		},
+		Options: AlgoOptions{
+			Duration:  time.Millisecond * 10,
+			Slices:    1,
+			SliceSize: 0.5,
+		},
+	}
+}
+
+func TestAlgoSubmit(t *testing.T) {
+	OrdersSetup(t)
+	var a algoManager
+	if _, err := a.Submit(nil); err != errAlgoIsNil {
+		t.Errorf("expected %v, got %v", errAlgoIsNil, err)
+	}
+
+	if _, err := a.Submit(testAlgo(fakePassExchange, "NOPE")); err != errAlgoTypeInvalid {
+		t.Errorf("expected %v, got %v", errAlgoTypeInvalid, err)
+	}
+
+	algo := testAlgo(fakePassExchange, AlgoTWAP)
+	algo.Options.Duration = 0
+	if _, err := a.Submit(algo); err != errAlgoInvalidDuration {
+		t.Errorf("expected %v, got %v", errAlgoInvalidDuration, err)
+	}
+
+	algo = testAlgo(fakePassExchange, AlgoIceberg)
+	algo.Order.Type = order.Market
+	if _, err := a.Submit(algo); err != errAlgoIcebergNotLimit {
+		t.Errorf("expected %v, got %v", errAlgoIcebergNotLimit, err)
+	}
+
+	algo = testAlgo(fakePassExchange, AlgoIceberg)
+	algo.Options.SliceSize = 1
+	if _, err := a.Submit(algo); err != errAlgoInvalidSliceSize {
+		t.Errorf("expected %v, got %v", errAlgoInvalidSliceSize, err)
+	}
+
+	algo = testAlgo(fakePassExchange, AlgoTWAP)
+	algo.Options.Jitter = 100
+	if _, err := a.Submit(algo); err != errAlgoInvalidJitter {
+		t.Errorf("expected %v, got %v", errAlgoInvalidJitter, err)
+	}
+
+	algo = testAlgo(fakePassExchange, AlgoTWAP)
+	algo.Options.MaxAdverseMove = -1
+	if _, err := a.Submit(algo); err != errAlgoInvalidAdverseMove {
+		t.Errorf("expected %v, got %v", errAlgoInvalidAdverseMove, err)
+	}
+
+	resp, err := a.Submit(testAlgo(fakePassExchange, AlgoTWAP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		// the fake exchange always returns the same order ID
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+	if resp.Status != AlgoActive || resp.Slices != 1 || resp.Filled != 1 || resp.Remaining != 0 {
+		t.Errorf("unexpected algo %+v", resp)
+	}
+
+	for i := 0; i < 100; i++ {
+		if resp, err = a.GetByID(resp.ID); err != nil || resp.Status != AlgoActive {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != AlgoCompleted {
+		t.Errorf("expected algo to complete, got %v", resp.Status)
+	}
+
+	if err = a.Cancel(resp.ID); err != errAlgoNotActive {
+		t.Errorf("expected %v, got %v", errAlgoNotActive, err)
+	}
+	if err = a.Cancel("nope"); err != ErrAlgoNotFound {
+		t.Errorf("expected %v, got %v", ErrAlgoNotFound, err)
+	}
+	if len(a.GetAll()) != 1 {
+		t.Error("expected one algo")
+	}
+}
+
+func TestAdverseMove(t *testing.T) {
+	if !adverseMove(order.Buy, 100, 102, 1) {
+		t.Error("expected rising price to be adverse for a buy")
+	}
+	if adverseMove(order.Buy, 100, 98, 1) {
+		t.Error("expected falling price not to be adverse for a buy")
+	}
+	if !adverseMove(order.Sell, 100, 98, 1) {
+		t.Error("expected falling price to be adverse for a sell")
+	}
+	if adverseMove(order.Sell, 100, 99.5, 1) {
+		t.Error("expected move within the limit not to be adverse")
+	}
+	if adverseMove(order.Buy, 0, 200, 1) {
+		t.Error("expected missing arrival price to be ignored")
+	}
+}
+
+func TestJitterSize(t *testing.T) {
+	if size := jitterSize(10, 100, 0, 0.9); size != 10 {
+		t.Errorf("expected no jitter, got %v", size)
+	}
+	if size := jitterSize(10, 100, 20, 0); size != 8 {
+		t.Errorf("expected size to be reduced by the jitter, got %v", size)
+	}
+	if size := jitterSize(10, 100, 20, 0.75); size != 11 {
+		t.Errorf("expected size to be increased by the jitter, got %v", size)
+	}
+	if size := jitterSize(10, 10.5, 20, 0.75); size != 10.5 {
+		t.Errorf("expected size to be capped at the unsent amount, got %v", size)
+	}
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// AlgoType defines how an execution algo slices its parent order
+type AlgoType string
+
+// All execution algo types
+const (
+	// AlgoTWAP spreads child orders evenly over a duration
+	AlgoTWAP AlgoType = "TWAP"
+	// AlgoIceberg only shows a slice of the parent order on the book and
+	// refreshes it once filled
+	AlgoIceberg AlgoType = "ICEBERG"
+)
+
+// AlgoStatus defines the state of an execution algo
+type AlgoStatus string
+
+// All execution algo status types
+const (
+	AlgoActive    AlgoStatus = "ACTIVE"
+	AlgoCompleted AlgoStatus = "COMPLETED"
+	AlgoCancelled AlgoStatus = "CANCELLED"
+	AlgoStopped   AlgoStatus = "STOPPED_ADVERSE_MOVE"
+	AlgoFailed    AlgoStatus = "FAILED"
+)
+
+// AlgoOptions determines how an execution algo slices its parent order
+type AlgoOptions struct {
+	// Duration is the period TWAP child orders are spread over
+	Duration time.Duration
+	// Slices is the amount of TWAP child orders
+	Slices int64
+	// SliceSize is the visible amount of each iceberg child order
+	SliceSize float64
+	// Jitter is the maximum percentage each child order amount is randomly
+	// varied by
+	Jitter float64
+	// MaxAdverseMove is the maximum percentage the market can move against
+	// the order from its arrival price before the algo is stopped and its
+	// working child order cancelled. Zero disables the protection
+	MaxAdverseMove float64
+	// RefreshInterval is how often the fill state of an iceberg child order
+	// is checked
+	RefreshInterval time.Duration
+}
+
+// Algo is a parent order executed as a series of child orders
+type Algo struct {
+	ID           string
+	Type         AlgoType
+	Order        order.Submit
+	Options      AlgoOptions
+	Status       AlgoStatus
+	ArrivalPrice float64
+	Filled       float64
+	Remaining    float64
+	// Working is the amount of the current child order on the book
+	Working         float64
+	Slices          int64
+	OrderID         string
+	InternalOrderID string
+	Error           string
+	Created         time.Time
+	LastUpdated     time.Time
+
+	// workingFilled is the amount of the current child order already added
+	// to Filled
+	workingFilled float64
+	shutdown      chan struct{}
+}
+
+type algoManager struct {
+	m     sync.Mutex
+	algos map[string]*Algo
+}
@@ -0,0 +1,294 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+)
+
+// vars for the allocation manager
+var (
+	ErrStrategyOrderNotFound   = errors.New("strategy order does not exist")
+	errStrategyOrderIsNil      = errors.New("strategy order is nil")
+	errStrategyNameUnset       = errors.New("strategy name must be specified")
+	errAllocationAmount        = errors.New("amount must be greater than zero")
+	errAllocationPrice         = errors.New("price must be greater than zero")
+	errAllocationRuleInvalid   = errors.New("allocation rule is invalid")
+	errAllocationExchangeUnset = errors.New("exchange name must be specified")
+)
+
+// Add registers the amount a strategy intends to trade so that subsequent
+// fills on the same exchange, pair, asset and side can be allocated to it
+func (a *allocationManager) Add(s *StrategyOrder) (*StrategyOrder, error) {
+	if s == nil {
+		return nil, errStrategyOrderIsNil
+	}
+	if s.Strategy == "" {
+		return nil, errStrategyNameUnset
+	}
+	if s.Exchange == "" {
+		return nil, errAllocationExchangeUnset
+	}
+	if s.Pair.IsEmpty() {
+		return nil, order.ErrPairIsEmpty
+	}
+	if s.AssetType == "" {
+		s.AssetType = asset.Spot
+	}
+	switch s.Side {
+	case order.Buy, order.Bid, order.Sell, order.Ask:
+	default:
+		return nil, order.ErrSideIsInvalid
+	}
+	if s.Amount <= 0 {
+		return nil, errAllocationAmount
+	}
+	if Bot != nil {
+		if err := Bot.LiquidityScreener.Allowed(s.Exchange, s.Pair, s.AssetType); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	s.ID = id.String()
+	s.Filled = 0
+	s.Created = clock.Now()
+
+	a.m.Lock()
+	defer a.m.Unlock()
+	if a.orders == nil {
+		a.orders = make(map[string]*StrategyOrder)
+	}
+	a.orders[s.ID] = s
+	cp := *s
+	return &cp, nil
+}
+
+// Remove stops any further fills being allocated to a strategy order
+func (a *allocationManager) Remove(id string) error {
+	a.m.Lock()
+	defer a.m.Unlock()
+	if _, ok := a.orders[id]; !ok {
+		return ErrStrategyOrderNotFound
+	}
+	delete(a.orders, id)
+	return nil
+}
+
+// GetOrders returns a copy of all strategy orders which have not been fully
+// allocated
+func (a *allocationManager) GetOrders() []StrategyOrder {
+	a.m.Lock()
+	defer a.m.Unlock()
+	var orders []StrategyOrder
+	for _, v := range a.orders {
+		orders = append(orders, *v)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Created.Before(orders[j].Created)
+	})
+	return orders
+}
+
+// GetPositions returns a copy of each strategy's positions
+func (a *allocationManager) GetPositions() []StrategyPosition {
+	a.m.Lock()
+	defer a.m.Unlock()
+	var positions []StrategyPosition
+	for _, v := range a.positions {
+		positions = append(positions, *v)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Strategy != positions[j].Strategy {
+			return positions[i].Strategy < positions[j].Strategy
+		}
+		return positions[i].Pair.String() < positions[j].Pair.String()
+	})
+	return positions
+}
+
+// Allocate splits a fill on the account between the strategy orders waiting
+// on the same exchange, pair, asset and side according to the allocation rule
+// and updates each strategy's position. Any amount exceeding the unfilled
+// amount of the strategy orders is left unallocated
+func (a *allocationManager) Allocate(exchName string, p currency.Pair, assetType asset.Item, side order.Side, amount, price float64, rule AllocationRule) ([]Allocation, error) {
+	if amount <= 0 {
+		return nil, errAllocationAmount
+	}
+	if price <= 0 {
+		return nil, errAllocationPrice
+	}
+	if rule == "" {
+		rule = AllocateProRata
+	}
+	if rule != AllocateProRata && rule != AllocateFIFO {
+		return nil, errAllocationRuleInvalid
+	}
+	if assetType == "" {
+		assetType = asset.Spot
+	}
+	buy := isBuySide(side)
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	var waiting []*StrategyOrder
+	var unfilled float64
+	for _, v := range a.orders {
+		if !strings.EqualFold(v.Exchange, exchName) ||
+			!v.Pair.Equal(p) ||
+			v.AssetType != assetType ||
+			isBuySide(v.Side) != buy {
+			continue
+		}
+		waiting = append(waiting, v)
+		unfilled += v.Amount - v.Filled
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		return waiting[i].Created.Before(waiting[j].Created)
+	})
+
+	var allocations []Allocation
+	remaining := amount
+	for x := range waiting {
+		left := waiting[x].Amount - waiting[x].Filled
+		var allocated float64
+		switch rule {
+		case AllocateFIFO:
+			allocated = math.Min(left, remaining)
+		case AllocateProRata:
+			allocated = left
+			if amount < unfilled {
+				allocated = amount * left / unfilled
+			}
+		}
+		if allocated <= 0 {
+			continue
+		}
+		remaining -= allocated
+		waiting[x].Filled += allocated
+		if waiting[x].Filled >= waiting[x].Amount {
+			delete(a.orders, waiting[x].ID)
+		}
+		a.updatePosition(waiting[x], allocated, price)
+		allocations = append(allocations, Allocation{
+			StrategyOrderID: waiting[x].ID,
+			Strategy:        waiting[x].Strategy,
+			Amount:          allocated,
+			Price:           price,
+		})
+	}
+
+	// allow for floating point error in pro rata allocations
+	if remaining > amount*1e-9 {
+		log.Warnf(log.OrderMgr,
+			"Allocation manager: %v of %s %s %s fill at %v could not be allocated to a strategy.\n",
+			remaining,
+			exchName,
+			p,
+			side,
+			price)
+	}
+	return allocations, nil
+}
+
+// updatePosition applies an allocated fill to the strategy's position,
+// realising profit and loss on any amount which reduces the position. Must be
+// called with the lock held
+func (a *allocationManager) updatePosition(s *StrategyOrder, amount, price float64) {
+	if a.positions == nil {
+		a.positions = make(map[string]*StrategyPosition)
+	}
+	key := strings.ToLower(s.Strategy + ":" + s.Exchange + ":" + s.Pair.String() + ":" + s.AssetType.String())
+	pos, ok := a.positions[key]
+	if !ok {
+		pos = &StrategyPosition{
+			Strategy:  s.Strategy,
+			Exchange:  s.Exchange,
+			Pair:      s.Pair,
+			AssetType: s.AssetType,
+		}
+		a.positions[key] = pos
+	}
+	now := clock.Now()
+	pos.LastUpdated = now
+
+	previous := pos.Amount
+	var realised float64
+	pos.Amount, pos.AveragePrice, realised = applyFill(pos.Amount, pos.AveragePrice, s.Side, amount, price)
+	pos.RealisedPNL += realised
+	pos.tripPNL += realised
+	if previous == 0 {
+		pos.Opened = now
+		return
+	}
+	if pos.Amount != 0 && (pos.Amount > 0) == (previous > 0) {
+		return
+	}
+
+	// the position has been closed or reversed, completing a trade
+	if a.trades == nil {
+		a.trades = make(map[string][]performance.Trade)
+	}
+	trades := append(a.trades[pos.Strategy], performance.Trade{
+		Opened: pos.Opened,
+		Closed: now,
+		PNL:    pos.tripPNL,
+	})
+	if len(trades) > maxStrategyTrades {
+		trades = trades[len(trades)-maxStrategyTrades:]
+	}
+	a.trades[pos.Strategy] = trades
+	pos.tripPNL = 0
+	pos.Opened = time.Time{}
+	if pos.Amount != 0 {
+		pos.Opened = now
+	}
+}
+
+// GetTrades returns a copy of a strategy's closed trades, oldest first
+func (a *allocationManager) GetTrades(strategy string) []performance.Trade {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return append([]performance.Trade(nil), a.trades[strategy]...)
+}
+
+// Strategies returns the names of the strategies which have positions or
+// closed trades
+func (a *allocationManager) Strategies() []string {
+	a.m.Lock()
+	defer a.m.Unlock()
+	seen := make(map[string]struct{})
+	var names []string
+	for _, v := range a.positions {
+		if _, ok := seen[v.Strategy]; !ok {
+			seen[v.Strategy] = struct{}{}
+			names = append(names, v.Strategy)
+		}
+	}
+	for name := range a.trades {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isBuySide(s order.Side) bool {
+	return s == order.Buy || s == order.Bid
+}
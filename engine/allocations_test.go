@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testStrategyOrder(strategy string, side order.Side, amount float64) *StrategyOrder {
+	return &StrategyOrder{
+		Strategy: strategy,
+		Exchange: testExchange,
+		Pair:     currency.NewPairFromString("BTCUSD"),
+		Side:     side,
+		Amount:   amount,
+	}
+}
+
+func TestAllocationAdd(t *testing.T) {
+	var a allocationManager
+	if _, err := a.Add(nil); err != errStrategyOrderIsNil {
+		t.Errorf("expected %v, got %v", errStrategyOrderIsNil, err)
+	}
+	if _, err := a.Add(testStrategyOrder("", order.Buy, 1)); err != errStrategyNameUnset {
+		t.Errorf("expected %v, got %v", errStrategyNameUnset, err)
+	}
+	if _, err := a.Add(testStrategyOrder("a", order.AnySide, 1)); err != order.ErrSideIsInvalid {
+		t.Errorf("expected %v, got %v", order.ErrSideIsInvalid, err)
+	}
+	if _, err := a.Add(testStrategyOrder("a", order.Buy, 0)); err != errAllocationAmount {
+		t.Errorf("expected %v, got %v", errAllocationAmount, err)
+	}
+
+	s, err := a.Add(testStrategyOrder("a", order.Buy, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ID == "" || s.AssetType != asset.Spot {
+		t.Errorf("unexpected strategy order %+v", s)
+	}
+	if err = a.Remove(s.ID); err != nil {
+		t.Error(err)
+	}
+	if err = a.Remove(s.ID); err != ErrStrategyOrderNotFound {
+		t.Errorf("expected %v, got %v", ErrStrategyOrderNotFound, err)
+	}
+}
+
+func TestAllocateProRata(t *testing.T) {
+	var a allocationManager
+	first, err := a.Add(testStrategyOrder("a", order.Buy, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := a.Add(testStrategyOrder("b", order.Buy, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = a.Add(testStrategyOrder("c", order.Sell, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	p := currency.NewPairFromString("BTCUSD")
+	_, err = a.Allocate(testExchange, p, asset.Spot, order.Buy, 1, 100, "NOPE")
+	if err != errAllocationRuleInvalid {
+		t.Errorf("expected %v, got %v", errAllocationRuleInvalid, err)
+	}
+
+	allocations, err := a.Allocate(testExchange, p, asset.Spot, order.Buy, 2, 100, AllocateProRata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 2 ||
+		allocations[0].StrategyOrderID != first.ID || allocations[0].Amount != 1.5 ||
+		allocations[1].StrategyOrderID != second.ID || allocations[1].Amount != 0.5 {
+		t.Errorf("unexpected allocations %+v", allocations)
+	}
+
+	// exceeds the unfilled amount, each order is filled and the remainder is
+	// left unallocated
+	allocations, err = a.Allocate(testExchange, p, asset.Spot, order.Buy, 5, 100, AllocateProRata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 2 || allocations[0].Amount != 1.5 || allocations[1].Amount != 0.5 {
+		t.Errorf("unexpected allocations %+v", allocations)
+	}
+	if orders := a.GetOrders(); len(orders) != 1 || orders[0].Strategy != "c" {
+		t.Errorf("expected filled strategy orders to be removed, got %+v", orders)
+	}
+}
+
+func TestAllocateFIFO(t *testing.T) {
+	var a allocationManager
+	_, err := a.Add(testStrategyOrder("a", order.Sell, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = a.Add(testStrategyOrder("b", order.Ask, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	p := currency.NewPairFromString("BTCUSD")
+	allocations, err := a.Allocate(testExchange, p, asset.Spot, order.Sell, 1.5, 100, AllocateFIFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 2 ||
+		allocations[0].Strategy != "a" || allocations[0].Amount != 1 ||
+		allocations[1].Strategy != "b" || allocations[1].Amount != 0.5 {
+		t.Errorf("unexpected allocations %+v", allocations)
+	}
+}
+
+func TestStrategyPositionPNL(t *testing.T) {
+	var a allocationManager
+	p := currency.NewPairFromString("BTCUSD")
+	allocate := func(side order.Side, amount, price float64) {
+		if _, err := a.Add(testStrategyOrder("a", side, amount)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Allocate(testExchange, p, asset.Spot, side, amount, price, AllocateFIFO); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	allocate(order.Buy, 1, 100)
+	allocate(order.Buy, 1, 200)
+	pos := a.GetPositions()
+	if len(pos) != 1 || pos[0].Amount != 2 || pos[0].AveragePrice != 150 {
+		t.Fatalf("unexpected position %+v", pos)
+	}
+
+	allocate(order.Sell, 1, 170)
+	pos = a.GetPositions()
+	if pos[0].Amount != 1 || pos[0].AveragePrice != 150 || pos[0].RealisedPNL != 20 {
+		t.Errorf("unexpected position after reducing %+v", pos[0])
+	}
+
+	// reverses the position into a short at the fill price
+	allocate(order.Sell, 2, 140)
+	pos = a.GetPositions()
+	if pos[0].Amount != -1 || pos[0].AveragePrice != 140 || pos[0].RealisedPNL != 10 {
+		t.Errorf("unexpected position after reversing %+v", pos[0])
+	}
+
+	allocate(order.Buy, 1, 120)
+	pos = a.GetPositions()
+	if pos[0].Amount != 0 || pos[0].AveragePrice != 0 || math.Abs(pos[0].RealisedPNL-30) > 1e-9 {
+		t.Errorf("unexpected position after closing %+v", pos[0])
+	}
+	if !pos[0].Opened.IsZero() {
+		t.Errorf("expected a closed position to have no open time, received %v", pos[0].Opened)
+	}
+
+	trades := a.GetTrades("a")
+	if len(trades) != 2 ||
+		trades[0].PNL != 10 ||
+		math.Abs(trades[1].PNL-20) > 1e-9 ||
+		trades[0].Opened.IsZero() ||
+		trades[1].Opened.Before(trades[0].Closed) {
+		t.Errorf("expected the reversed and closed positions to be trades, received %+v", trades)
+	}
+	if s := a.Strategies(); len(s) != 1 || s[0] != "a" {
+		t.Errorf("unexpected strategies %v", s)
+	}
+}
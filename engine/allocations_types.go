@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+)
+
+// maxStrategyTrades is the amount of each strategy's closed trades retained
+// for its performance statistics
+const maxStrategyTrades = 10000
+
+// AllocationRule determines how a fill is split between the strategy orders
+// waiting on the same exchange, pair, asset and side
+type AllocationRule string
+
+// All allocation rules
+const (
+	// AllocateProRata splits a fill in proportion to the unfilled amount of
+	// each strategy order
+	AllocateProRata AllocationRule = "PRO_RATA"
+	// AllocateFIFO fills the oldest strategy order first
+	AllocateFIFO AllocationRule = "FIFO"
+)
+
+// StrategyOrder is the amount a strategy intends to trade on an account shared
+// with other strategies. Fills on the account are allocated against it
+type StrategyOrder struct {
+	ID        string
+	Strategy  string
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Side      order.Side
+	Amount    float64
+	Filled    float64
+	Created   time.Time
+}
+
+// Allocation is the portion of a fill allocated to a strategy order
+type Allocation struct {
+	StrategyOrderID string
+	Strategy        string
+	Amount          float64
+	Price           float64
+}
+
+// StrategyPosition is a strategy's net position and realised profit and loss
+// on a pair built from its allocated fills. A negative amount is a short
+// position
+type StrategyPosition struct {
+	Strategy     string
+	Exchange     string
+	Pair         currency.Pair
+	AssetType    asset.Item
+	Amount       float64
+	AveragePrice float64
+	RealisedPNL  float64
+	LastUpdated  time.Time
+	// Opened is when the position was opened from flat or reversed, zero
+	// when flat
+	Opened time.Time
+	// tripPNL is the profit and loss realised since the position was opened
+	tripPNL float64
+}
+
+type allocationManager struct {
+	m         sync.Mutex
+	orders    map[string]*StrategyOrder
+	positions map[string]*StrategyPosition
+	// trades are each strategy's positions which have been closed or
+	// reversed, oldest first
+	trades map[string][]performance.Trade
+}
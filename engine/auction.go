@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/auction"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/gemini"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errAuctionsUnsupported = errors.New("exchange does not hold auctions")
+
+func (a *auctionCollector) Started() bool {
+	return atomic.LoadInt32(&a.started) == 1
+}
+
+func (a *auctionCollector) Start() error {
+	if !Bot.DatabaseManager.Started() {
+		return errors.New("auction history collector requires the database manager")
+	}
+	if atomic.AddInt32(&a.started, 1) != 1 {
+		return errors.New("auction history collector already started")
+	}
+
+	log.Debugln(log.SyncMgr, "Auction history collector starting...")
+	a.shutdown = make(chan struct{})
+	go a.run()
+	return nil
+}
+
+func (a *auctionCollector) Stop() error {
+	if atomic.AddInt32(&a.stopped, 1) != 1 {
+		return errors.New("auction history collector is already stopped")
+	}
+
+	log.Debugln(log.SyncMgr, "Auction history collector shutting down...")
+	close(a.shutdown)
+	return nil
+}
+
+func (a *auctionCollector) run() {
+	log.Debugln(log.SyncMgr, "Auction history collector started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.AuctionHistory.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&a.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&a.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.SyncMgr, "Auction history collector shutdown.")
+	}()
+
+	// collect immediately so the record is brought up to date on startup
+	a.collectAll()
+	for {
+		select {
+		case <-a.shutdown:
+			return
+		case <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			a.collectAll()
+		}
+	}
+}
+
+// collectAll collects the auction history of every enabled spot pair of each
+// loaded exchange which holds auctions
+func (a *auctionCollector) collectAll() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		g, ok := exchanges[x].(*gemini.Gemini)
+		if !ok {
+			continue
+		}
+		pairs := g.GetEnabledPairs(asset.Spot)
+		for y := range pairs {
+			select {
+			case <-a.shutdown:
+				return
+			default:
+			}
+			if err := a.collect(g, pairs[y]); err != nil {
+				log.Errorf(log.SyncMgr, "Auction history collector: %s %s unable to collect: %v",
+					g.GetName(),
+					pairs[y],
+					err)
+			}
+		}
+	}
+}
+
+// collect requests the pair's auction events since the most recent stored
+// event and stores those not yet recorded
+func (a *auctionCollector) collect(g *gemini.Gemini, p currency.Pair) error {
+	symbol := auctionSymbol(g, p)
+	since, err := auction.LastEventTime(g.GetName(), symbol)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("limit_auction_results", strconv.Itoa(auctionHistoryLimit))
+	params.Set("include_indicative", strconv.FormatBool(Bot.Config.AuctionHistory.IncludeIndicative))
+	if !since.IsZero() {
+		params.Set("since", strconv.FormatInt(since.Unix(), 10))
+	}
+	history, err := g.GetAuctionHistory(symbol, params)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	inserted, err := auction.Insert(auctionEvents(g.GetName(), symbol, history)...)
+	if err != nil {
+		return err
+	}
+	if inserted > 0 && Bot.Settings.Verbose {
+		log.Debugf(log.SyncMgr, "Auction history collector: %s %s stored %d auction events\n",
+			g.GetName(),
+			symbol,
+			inserted)
+	}
+	if len(history) >= auctionHistoryLimit {
+		log.Warnf(log.SyncMgr, "Auction history collector: %s %s returned the maximum of %d events, shorten the collection interval to avoid gaps\n",
+			g.GetName(),
+			symbol,
+			auctionHistoryLimit)
+	}
+	return nil
+}
+
+// auctionSymbol returns the symbol auction events of the pair are requested
+// and stored under
+func auctionSymbol(g *gemini.Gemini, p currency.Pair) string {
+	return g.FormatExchangeCurrency(p, asset.Spot).String()
+}
+
+// auctionEvents converts Gemini auction history to stored auction events
+func auctionEvents(exchName, symbol string, history []gemini.AuctionHistory) []auction.Event {
+	events := make([]auction.Event, len(history))
+	for x := range history {
+		ts := time.Unix(history[x].Timestamp, 0)
+		if history[x].TimestampMS > 0 {
+			ts = time.Unix(0, history[x].TimestampMS*int64(time.Millisecond))
+		}
+		events[x] = auction.Event{
+			Exchange:        exchName,
+			Pair:            symbol,
+			AuctionID:       history[x].AuctionID,
+			EventID:         history[x].EID,
+			AuctionPrice:    history[x].AuctionPrice.Float64(),
+			AuctionQuantity: history[x].AuctionQuantity.Float64(),
+			HighestBidPrice: history[x].HighestBidPrice.Float64(),
+			LowestAskPrice:  history[x].LowestAskPrice.Float64(),
+			AuctionResult:   history[x].AuctionResult,
+			EventType:       history[x].EventType,
+			Time:            ts,
+		}
+	}
+	return events
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/decimal"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/gemini"
+)
+
+func TestAuctionEvents(t *testing.T) {
+	history := []gemini.AuctionHistory{
+		{
+			AuctionID:     1,
+			EID:           10,
+			AuctionPrice:  decimal.New(100, 0),
+			AuctionResult: "success",
+			EventType:     "auction",
+			Timestamp:     1500000000,
+			TimestampMS:   1500000000500,
+		},
+		{
+			AuctionID:   2,
+			EID:         11,
+			EventType:   "indicative",
+			Timestamp:   1500000001,
+			TimestampMS: 0,
+		},
+	}
+	events := auctionEvents("Gemini", "BTCUSD", history)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Exchange != "Gemini" ||
+		events[0].Pair != "BTCUSD" ||
+		events[0].EventID != 10 ||
+		events[0].AuctionPrice != 100 ||
+		!events[0].Time.Equal(time.Unix(1500000000, int64(time.Millisecond)*500)) {
+		t.Errorf("unexpected event %+v", events[0])
+	}
+	if !events[1].Time.Equal(time.Unix(1500000001, 0)) {
+		t.Errorf("expected the timestamp in seconds to be used, got %v", events[1].Time)
+	}
+}
+
+func TestAuctionCollectorStart(t *testing.T) {
+	SetupTestHelpers(t)
+	var a auctionCollector
+	if Bot.DatabaseManager.Started() {
+		t.Skip("database manager is running")
+	}
+	if err := a.Start(); err == nil {
+		t.Error("expected the collector to require the database manager")
+	}
+}
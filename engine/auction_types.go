@@ -0,0 +1,11 @@
+package engine
+
+// auctionHistoryLimit is the maximum amount of auction events requested per
+// pair each collection
+const auctionHistoryLimit = 500
+
+type auctionCollector struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+}
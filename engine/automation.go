@@ -0,0 +1,564 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline/indicators"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"gopkg.in/yaml.v2"
+)
+
+// vars for the automation manager
+var (
+	errAutomationManagerNotStarted = errors.New("automation manager is not started")
+	errAutomationNameEmpty         = errors.New("automation name is empty")
+	errAutomationNameDuplicate     = errors.New("automation name is duplicated")
+	errAutomationInvalidPair       = errors.New("automation pair is invalid")
+	errAutomationInvalidAsset      = errors.New("automation asset type is invalid")
+	errAutomationInvalidCondition  = errors.New("automation condition must be in the format PRICE < 100 or INDICATOR(period,interval) < 100")
+	errAutomationInvalidIndicator  = errors.New("automation indicator must be PRICE, RSI, SMA or EMA")
+	errAutomationInvalidPeriod     = errors.New("automation indicator period must be at least 2")
+	errAutomationInvalidSide       = errors.New("automation order side must be buy or sell")
+	errAutomationInvalidType       = errors.New("automation order type must be market or limit")
+	errAutomationInvalidAmount     = errors.New("automation order must have either an amount or a quote amount")
+	errAutomationInvalidLimit      = errors.New("automation max must be accompanied by a valid per period")
+	errAutomationInsufficientData  = errors.New("insufficient candles to calculate indicator")
+)
+
+const (
+	automationsFileName      = "automations.yaml"
+	automationsStateFileName = "automation_executions.json"
+)
+
+// automationConditionRegex matches conditions such as "RSI(14,1h) < 30" and
+// "PRICE >= 10000"
+var automationConditionRegex = regexp.MustCompile(`^\s*([A-Za-z]+)\s*(?:\(\s*(\d+)\s*,\s*(\w+)\s*\))?\s*(<=|>=|<|>)\s*(-?[0-9]*\.?[0-9]+)\s*$`)
+
+func (a *automationManager) Started() bool {
+	return atomic.LoadInt32(&a.started) == 1
+}
+
+func (a *automationManager) Start() error {
+	if atomic.AddInt32(&a.started, 1) != 1 {
+		return errors.New("automation manager already started")
+	}
+
+	log.Debugln(log.OrderMgr, "Automation manager starting...")
+	if a.path == "" {
+		a.path = Bot.Config.Automations.File
+		if a.path == "" {
+			a.path = filepath.Join(Bot.Settings.DataDir, automationsFileName)
+		}
+	}
+	if a.statePath == "" {
+		a.statePath = filepath.Join(Bot.Settings.DataDir, automationsStateFileName)
+	}
+	if _, err := a.load(); err != nil {
+		atomic.CompareAndSwapInt32(&a.started, 1, 0)
+		return err
+	}
+	a.interval = Bot.Config.Automations.Interval
+	a.shutdown = make(chan struct{})
+	a.done = make(chan struct{})
+	go a.run()
+	return nil
+}
+
+func (a *automationManager) Stop() error {
+	if atomic.AddInt32(&a.stopped, 1) != 1 {
+		return errors.New("automation manager is already stopped")
+	}
+
+	log.Debugln(log.OrderMgr, "Automation manager shutting down...")
+	close(a.shutdown)
+	<-a.done
+	return nil
+}
+
+func (a *automationManager) run() {
+	log.Debugln(log.OrderMgr, "Automation manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(a.interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&a.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&a.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.OrderMgr, "Automation manager shutdown.")
+		close(a.done)
+	}()
+
+	for {
+		select {
+		case <-a.shutdown:
+			return
+		case <-tick.C:
+			a.check()
+		}
+	}
+}
+
+// Reload recompiles the automations YAML file, retaining the executions and
+// state of automations whose names are unchanged, and returns the amount of
+// automations loaded
+func (a *automationManager) Reload() (int, error) {
+	if !a.Started() {
+		return 0, errAutomationManagerNotStarted
+	}
+	return a.load()
+}
+
+// GetAll returns a copy of all automations
+func (a *automationManager) GetAll() []Automation {
+	a.m.Lock()
+	defer a.m.Unlock()
+	automations := make([]Automation, 0, len(a.automations))
+	for x := range a.automations {
+		cp := *a.automations[x]
+		cp.Executions = append([]time.Time(nil), cp.Executions...)
+		automations = append(automations, cp)
+	}
+	return automations
+}
+
+// ParseAutomations compiles the automations declared in YAML
+func ParseAutomations(data []byte) ([]*Automation, error) {
+	var f automationsFile
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{})
+	automations := make([]*Automation, 0, len(f.Automations))
+	for x := range f.Automations {
+		auto, err := compileAutomation(&f.Automations[x])
+		if err != nil {
+			return nil, fmt.Errorf("automation #%d %s: %v", x+1, f.Automations[x].Name, err)
+		}
+		if _, ok := names[auto.Name]; ok {
+			return nil, fmt.Errorf("automation #%d %s: %v", x+1, auto.Name, errAutomationNameDuplicate)
+		}
+		names[auto.Name] = struct{}{}
+		automations = append(automations, auto)
+	}
+	return automations, nil
+}
+
+// compileAutomation validates a definition and compiles its condition and
+// order
+func compileAutomation(d *AutomationDefinition) (*Automation, error) {
+	auto := &Automation{
+		Name:      strings.TrimSpace(d.Name),
+		Condition: strings.TrimSpace(d.When),
+		Max:       d.Max,
+	}
+	if auto.Name == "" {
+		return nil, errAutomationNameEmpty
+	}
+
+	var err error
+	auto.Indicator, auto.Period, auto.Interval, auto.Operator, auto.Threshold, err = parseAutomationCondition(d.When)
+	if err != nil {
+		return nil, err
+	}
+
+	pair := strings.TrimSpace(d.Pair)
+	if len(pair) < 4 {
+		return nil, errAutomationInvalidPair
+	}
+	p := currency.NewPairFromString(pair)
+	if p.Base.IsEmpty() || p.Quote.IsEmpty() {
+		return nil, errAutomationInvalidPair
+	}
+
+	a := asset.Item(strings.ToLower(d.Asset))
+	if a == "" {
+		a = asset.Spot
+	}
+	if !asset.IsValid(a) {
+		return nil, errAutomationInvalidAsset
+	}
+
+	side := order.Side(strings.ToUpper(d.Then.Side))
+	switch side {
+	case order.Buy, order.Sell:
+	default:
+		return nil, errAutomationInvalidSide
+	}
+	orderType := order.Type(strings.ToUpper(d.Then.Type))
+	switch orderType {
+	case "":
+		orderType = order.Market
+	case order.Market, order.Limit:
+	default:
+		return nil, errAutomationInvalidType
+	}
+	if (d.Then.Amount > 0) == (d.Then.QuoteAmount > 0) || d.Then.Amount < 0 || d.Then.QuoteAmount < 0 {
+		return nil, errAutomationInvalidAmount
+	}
+
+	if d.Max < 0 || (d.Max > 0) != (d.Per != "") {
+		return nil, errAutomationInvalidLimit
+	}
+	if d.Per != "" {
+		auto.Per, err = parseAutomationPeriod(d.Per)
+		if err != nil || auto.Per <= 0 {
+			return nil, errAutomationInvalidLimit
+		}
+	}
+
+	auto.QuoteAmount = d.Then.QuoteAmount
+	auto.Order = order.Submit{
+		Exchange:  d.Exchange,
+		Pair:      p,
+		AssetType: a,
+		Side:      side,
+		Type:      orderType,
+		Amount:    d.Then.Amount,
+		Price:     d.Then.Price,
+	}
+	if orderType == order.Market {
+		auto.Order.Price = 0
+	}
+	return auto, nil
+}
+
+// parseAutomationCondition parses a condition such as "RSI(14,1h) < 30"
+func parseAutomationCondition(condition string) (indicator string, period int, interval time.Duration, operator string, threshold float64, err error) {
+	m := automationConditionRegex.FindStringSubmatch(condition)
+	if m == nil {
+		err = errAutomationInvalidCondition
+		return
+	}
+	indicator = strings.ToUpper(m[1])
+	operator = m[4]
+	threshold, err = strconv.ParseFloat(m[5], 64)
+	if err != nil {
+		return
+	}
+
+	switch indicator {
+	case AutomationPrice:
+		if m[2] != "" {
+			err = errAutomationInvalidCondition
+		}
+		return
+	case AutomationRSI, AutomationSMA, AutomationEMA:
+	default:
+		err = errAutomationInvalidIndicator
+		return
+	}
+	if m[2] == "" {
+		err = errAutomationInvalidCondition
+		return
+	}
+	period, err = strconv.Atoi(m[2])
+	if err != nil {
+		return
+	}
+	if period < 2 {
+		err = errAutomationInvalidPeriod
+		return
+	}
+	interval, err = parseAutomationPeriod(m[3])
+	if err == nil && interval < time.Minute {
+		err = errAutomationInvalidCondition
+	}
+	return
+}
+
+// parseAutomationPeriod parses a duration, additionally accepting days and
+// weeks such as 1d and 1w or the words day and week
+func parseAutomationPeriod(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "day":
+		return time.Hour * 24, nil
+	case "week":
+		return time.Hour * 24 * 7, nil
+	}
+	for suffix, unit := range map[string]time.Duration{
+		"d": time.Hour * 24,
+		"w": time.Hour * 24 * 7,
+	} {
+		if !strings.HasSuffix(s, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * unit, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// load compiles the automations YAML file and restores their executions.
+// A missing file loads no automations
+func (a *automationManager) load() (int, error) {
+	var automations []*Automation
+	if file.Exists(a.path) {
+		data, err := ioutil.ReadFile(a.path)
+		if err != nil {
+			return 0, err
+		}
+		automations, err = ParseAutomations(data)
+		if err != nil {
+			return 0, fmt.Errorf("unable to load automations from %s: %v", a.path, err)
+		}
+	}
+	for x := range automations {
+		if GetExchangeByName(automations[x].Order.Exchange) == nil {
+			return 0, fmt.Errorf("automation %s: %v", automations[x].Name, ErrExchangeNotFound)
+		}
+	}
+
+	executions := make(map[string][]time.Time)
+	if file.Exists(a.statePath) {
+		data, err := ioutil.ReadFile(a.statePath)
+		if err != nil {
+			return 0, err
+		}
+		if err = json.Unmarshal(data, &executions); err != nil {
+			return 0, fmt.Errorf("unable to load automation executions from %s: %v", a.statePath, err)
+		}
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+	previous := make(map[string]*Automation)
+	for x := range a.automations {
+		previous[a.automations[x].Name] = a.automations[x]
+	}
+	for x := range automations {
+		if prev, ok := previous[automations[x].Name]; ok {
+			automations[x].Executions = prev.Executions
+			automations[x].Matched = prev.Matched
+			automations[x].LastValue = prev.LastValue
+			automations[x].LastChecked = prev.LastChecked
+			automations[x].LastOrderID = prev.LastOrderID
+			automations[x].LastError = prev.LastError
+			continue
+		}
+		automations[x].Executions = executions[automations[x].Name]
+	}
+	a.automations = automations
+	log.Debugf(log.OrderMgr, "Automation manager: loaded %d automations from %s", len(automations), a.path)
+	return len(automations), nil
+}
+
+// save persists the executions of each automation. Must be called with the
+// lock held
+func (a *automationManager) save() error {
+	executions := make(map[string][]time.Time)
+	for x := range a.automations {
+		if len(a.automations[x].Executions) > 0 {
+			executions[a.automations[x].Name] = a.automations[x].Executions
+		}
+	}
+	data, err := json.MarshalIndent(executions, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(a.statePath, data)
+}
+
+// check evaluates the condition of every automation and triggers those whose
+// condition has begun to hold
+func (a *automationManager) check() {
+	a.m.Lock()
+	automations := append([]*Automation(nil), a.automations...)
+	a.m.Unlock()
+
+	for x := range automations {
+		select {
+		case <-a.shutdown:
+			return
+		default:
+		}
+		value, err := automationValue(automations[x])
+		now := clock.Now()
+		a.m.Lock()
+		auto := automations[x]
+		auto.LastChecked = now
+		if err != nil {
+			auto.LastError = err.Error()
+			a.m.Unlock()
+			if Bot.Settings.Verbose {
+				log.Debugf(log.OrderMgr, "Automation manager: %s unable to evaluate %s: %s",
+					auto.Name,
+					auto.Condition,
+					err)
+			}
+			continue
+		}
+		auto.LastValue = value
+		matched := automationMatched(auto, value)
+		fire := matched && !auto.Matched && auto.allowed(now)
+		auto.Matched = matched
+		a.m.Unlock()
+		if fire {
+			a.trigger(auto, value)
+		}
+	}
+}
+
+// allowed prunes executions outside the limit period and returns whether the
+// automation may trigger again. Must be called with the lock held
+func (auto *Automation) allowed(now time.Time) bool {
+	if auto.Max <= 0 {
+		return true
+	}
+	executions := auto.Executions[:0]
+	for x := range auto.Executions {
+		if now.Sub(auto.Executions[x]) < auto.Per {
+			executions = append(executions, auto.Executions[x])
+		}
+	}
+	auto.Executions = executions
+	return len(auto.Executions) < auto.Max
+}
+
+// trigger submits the automation's order through the order manager
+func (a *automationManager) trigger(auto *Automation, value float64) {
+	a.m.Lock()
+	s := auto.Order
+	quoteAmount := auto.QuoteAmount
+	a.m.Unlock()
+
+	var err error
+	if quoteAmount > 0 || (s.Type == order.Limit && s.Price <= 0) {
+		var price float64
+		price, err = conditionalPrice(&s)
+		if err == nil {
+			if s.Type == order.Limit && s.Price <= 0 {
+				s.Price = price
+			}
+			if quoteAmount > 0 {
+				if s.Type == order.Limit {
+					price = s.Price
+				}
+				s.Amount = quoteAmount / price
+			}
+		}
+	}
+
+	var resp *orderSubmitResponse
+	if err == nil {
+		resp, err = Bot.OrderManager.Submit(&s)
+	}
+
+	a.m.Lock()
+	// executions are recorded for failed orders so a failing automation
+	// cannot exceed its limit by retrying
+	auto.Executions = append(auto.Executions, clock.Now())
+	if err != nil {
+		auto.LastError = err.Error()
+	} else {
+		auto.LastError = ""
+		auto.LastOrderID = resp.OrderID
+	}
+	saveErr := a.save()
+	a.m.Unlock()
+
+	msg := fmt.Sprintf("Automation manager: %s %s at %v, %s %v %s on %s",
+		auto.Name,
+		auto.Condition,
+		value,
+		s.Side,
+		s.Amount,
+		s.Pair,
+		s.Exchange)
+	if err != nil {
+		msg += " failed: " + err.Error()
+		log.Warnln(log.OrderMgr, msg)
+	} else {
+		msg += " submitted"
+		log.Infoln(log.OrderMgr, msg)
+	}
+	if saveErr != nil {
+		log.Errorf(log.OrderMgr, "Automation manager: unable to save automation executions: %s", saveErr)
+	}
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "automation",
+		Message: msg,
+	})
+}
+
+// automationValue returns the current value of the automation's price or
+// indicator
+func automationValue(auto *Automation) (float64, error) {
+	if auto.Indicator == AutomationPrice {
+		return conditionalPrice(&auto.Order)
+	}
+	exch := GetExchangeByName(auto.Order.Exchange)
+	if exch == nil {
+		return 0, ErrExchangeNotFound
+	}
+	// extra candles allow the exponential indicators to settle, candles built
+	// from the trade feed are used once enough have been built
+	live, err := trade.GetCandles(exch.GetName(), auto.Order.Pair, auto.Order.AssetType, auto.Interval)
+	if err == nil && len(live.Candles) > auto.Period*3 {
+		return automationIndicator(auto.Indicator, auto.Period, live.Candles)
+	}
+	end := clock.Now()
+	start := end.Add(-auto.Interval * time.Duration(auto.Period*3+1))
+	k, err := exch.GetHistoricCandles(auto.Order.Pair, auto.Order.AssetType, start, end, auto.Interval)
+	if err != nil {
+		return 0, err
+	}
+	return automationIndicator(auto.Indicator, auto.Period, k.Candles)
+}
+
+// automationIndicator calculates the most recent value of the indicator from
+// the candle closes
+func automationIndicator(indicator string, period int, candles []kline.Candle) (float64, error) {
+	if len(candles) <= period {
+		return 0, errAutomationInsufficientData
+	}
+	closes := indicators.Closes(candles)
+	var values []float64
+	switch indicator {
+	case AutomationRSI:
+		values = indicators.RSI(closes, period)
+	case AutomationSMA:
+		values = indicators.SMA(closes, period)
+	case AutomationEMA:
+		values = indicators.EMA(closes, period)
+	default:
+		return 0, errAutomationInvalidIndicator
+	}
+	return values[len(values)-1], nil
+}
+
+// automationMatched returns whether the value satisfies the automation's
+// condition
+func automationMatched(auto *Automation, value float64) bool {
+	switch auto.Operator {
+	case "<":
+		return value < auto.Threshold
+	case "<=":
+		return value <= auto.Threshold
+	case ">":
+		return value > auto.Threshold
+	case ">=":
+		return value >= auto.Threshold
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+const testAutomations = `
+automations:
+  - name: buy-the-dip
+    exchange: Gemini
+    pair: BTC-USD
+    when: RSI(14,1h) < 30
+    then:
+      side: buy
+      type: limit
+      quote_amount: 200
+    max: 3
+    per: week
+  - name: take-profit
+    exchange: Gemini
+    pair: BTCUSD
+    asset: spot
+    when: PRICE >= 10000
+    then:
+      side: sell
+      amount: 0.5
+`
+
+func TestParseAutomations(t *testing.T) {
+	automations, err := ParseAutomations([]byte(testAutomations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(automations) != 2 {
+		t.Fatalf("expected 2 automations, got %d", len(automations))
+	}
+	dip := automations[0]
+	if dip.Name != "buy-the-dip" ||
+		dip.Indicator != AutomationRSI ||
+		dip.Period != 14 ||
+		dip.Interval != kline.OneHour ||
+		dip.Operator != "<" ||
+		dip.Threshold != 30 ||
+		dip.Max != 3 ||
+		dip.Per != kline.OneWeek ||
+		dip.QuoteAmount != 200 {
+		t.Errorf("unexpected automation %+v", dip)
+	}
+	if dip.Order.Side != order.Buy || dip.Order.Type != order.Limit ||
+		dip.Order.AssetType != asset.Spot || dip.Order.Amount != 0 ||
+		!dip.Order.Pair.Equal(currency.NewPair(currency.BTC, currency.USD)) {
+		t.Errorf("unexpected automation order %+v", dip.Order)
+	}
+	profit := automations[1]
+	if profit.Indicator != AutomationPrice || profit.Order.Type != order.Market ||
+		profit.Order.Amount != 0.5 || profit.Max != 0 {
+		t.Errorf("unexpected automation %+v", profit)
+	}
+
+	for _, tc := range []struct {
+		yaml string
+		err  error
+	}{
+		{"automations:\n  - exchange: Gemini\n", errAutomationNameEmpty},
+		{"automations:\n  - name: a\n    pair: BTC-USD\n    when: MACD(1,1h) < 3\n", errAutomationInvalidIndicator},
+		{"automations:\n  - name: a\n    pair: BTC-USD\n    when: RSI(14,1h) < 30\n    then: {side: hold, amount: 1}\n", errAutomationInvalidSide},
+		{"automations:\n  - name: a\n    pair: BTC-USD\n    when: RSI(14,1h) < 30\n    then: {side: buy, type: stop, amount: 1}\n", errAutomationInvalidType},
+		{"automations:\n  - name: a\n    pair: BTC-USD\n    when: RSI(14,1h) < 30\n    then: {side: buy, amount: 1, quote_amount: 1}\n", errAutomationInvalidAmount},
+		{"automations:\n  - name: a\n    pair: BTC-USD\n    when: RSI(14,1h) < 30\n    then: {side: buy, amount: 1}\n    max: 3\n", errAutomationInvalidLimit},
+		{"automations:\n  - name: a\n    pair: BTC\n    when: RSI(14,1h) < 30\n    then: {side: buy, amount: 1}\n", errAutomationInvalidPair},
+	} {
+		if _, err = ParseAutomations([]byte(tc.yaml)); err == nil || !strings.HasSuffix(err.Error(), tc.err.Error()) {
+			t.Errorf("expected %v, got %v", tc.err, err)
+		}
+	}
+
+	duplicate := "automations:\n  - name: a\n    pair: BTC-USD\n    when: PRICE < 1\n    then: {side: buy, amount: 1}\n" +
+		"  - name: a\n    pair: BTC-USD\n    when: PRICE < 1\n    then: {side: buy, amount: 1}\n"
+	if _, err = ParseAutomations([]byte(duplicate)); err == nil || !strings.HasSuffix(err.Error(), errAutomationNameDuplicate.Error()) {
+		t.Errorf("expected %v, got %v", errAutomationNameDuplicate, err)
+	}
+	if _, err = ParseAutomations([]byte("automations:\n  - name: a\n    unknown: 1\n")); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+}
+
+func TestParseAutomationCondition(t *testing.T) {
+	indicator, period, interval, operator, threshold, err := parseAutomationCondition(" sma( 20 , 1d ) >= 9000.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indicator != AutomationSMA || period != 20 || interval != kline.OneDay || operator != ">=" || threshold != 9000.5 {
+		t.Errorf("unexpected condition %s %d %s %s %v", indicator, period, interval, operator, threshold)
+	}
+
+	for _, tc := range []struct {
+		condition string
+		err       error
+	}{
+		{"RSI < 30", errAutomationInvalidCondition},
+		{"PRICE(14,1h) < 30", errAutomationInvalidCondition},
+		{"RSI(14,1h) == 30", errAutomationInvalidCondition},
+		{"RSI(14,1s) < 30", errAutomationInvalidCondition},
+		{"RSI(1,1h) < 30", errAutomationInvalidPeriod},
+	} {
+		if _, _, _, _, _, err = parseAutomationCondition(tc.condition); err != tc.err {
+			t.Errorf("%s: expected %v, got %v", tc.condition, tc.err, err)
+		}
+	}
+}
+
+func TestParseAutomationPeriod(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"week": kline.OneWeek,
+		"Day":  kline.OneDay,
+		"3d":   kline.ThreeDay,
+		"2w":   kline.OneWeek * 2,
+		"4h":   kline.FourHour,
+	} {
+		if d, err := parseAutomationPeriod(s); err != nil || d != expected {
+			t.Errorf("%s: expected %s, got %s %v", s, expected, d, err)
+		}
+	}
+	if _, err := parseAutomationPeriod("fortnight"); err == nil {
+		t.Error("expected an error for an invalid period")
+	}
+}
+
+func TestAutomationIndicator(t *testing.T) {
+	candles := make([]kline.Candle, 5)
+	for x := range candles {
+		candles[x].Close = float64(x + 1)
+	}
+	if v, err := automationIndicator(AutomationSMA, 3, candles); err != nil || v != 4 {
+		t.Errorf("expected 4, got %v %v", v, err)
+	}
+	// a steadily rising close has no losses
+	if v, err := automationIndicator(AutomationRSI, 3, candles); err != nil || v != 100 {
+		t.Errorf("expected 100, got %v %v", v, err)
+	}
+	if _, err := automationIndicator(AutomationEMA, 5, candles); err != errAutomationInsufficientData {
+		t.Errorf("expected %v, got %v", errAutomationInsufficientData, err)
+	}
+}
+
+func TestAutomationAllowed(t *testing.T) {
+	now := time.Now()
+	auto := Automation{
+		Max:        2,
+		Per:        time.Hour,
+		Executions: []time.Time{now.Add(-time.Hour * 2), now.Add(-time.Minute)},
+	}
+	if !auto.allowed(now) || len(auto.Executions) != 1 {
+		t.Errorf("expected expired executions to be pruned, got %v", auto.Executions)
+	}
+	auto.Executions = append(auto.Executions, now)
+	if auto.allowed(now) {
+		t.Error("expected the execution limit to be reached")
+	}
+	auto.Max = 0
+	if !auto.allowed(now) {
+		t.Error("expected unlimited automations to be allowed")
+	}
+}
+
+func TestAutomationManager(t *testing.T) {
+	OrdersSetup(t)
+	dir, err := ioutil.TempDir("", "automations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldInterval := Bot.Config.Automations.Interval
+	Bot.Config.Automations.Interval = time.Hour
+	defer func() { Bot.Config.Automations.Interval = oldInterval }()
+
+	definitions := "automations:\n" +
+		"  - name: dip\n" +
+		"    exchange: " + fakePassExchange + "\n" +
+		"    pair: LTC-USDT\n" +
+		"    when: PRICE < 50\n" +
+		"    then: {side: buy, type: limit, quote_amount: 100}\n" +
+		"    max: 1\n" +
+		"    per: day\n"
+	path := filepath.Join(dir, automationsFileName)
+	if err = ioutil.WriteFile(path, []byte(definitions), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	a := automationManager{
+		path:      path,
+		statePath: filepath.Join(dir, automationsStateFileName),
+	}
+	if _, err = a.Reload(); err != errAutomationManagerNotStarted {
+		t.Errorf("expected %v, got %v", errAutomationManagerNotStarted, err)
+	}
+	if err = a.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = a.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	p := currency.NewPair(currency.LTC, currency.USDT)
+	setPrice := func(price float64) {
+		if err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: p, Last: price}, asset.Spot); err != nil {
+			t.Fatal(err)
+		}
+		a.check()
+	}
+
+	setPrice(60)
+	if auto := a.GetAll()[0]; auto.Matched || len(auto.Executions) != 0 || auto.LastValue != 60 {
+		t.Fatalf("expected the automation not to trigger, got %+v", auto)
+	}
+
+	setPrice(40)
+	defer func() {
+		// the fake exchange always returns the same order ID
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+	auto := a.GetAll()[0]
+	if !auto.Matched || len(auto.Executions) != 1 || auto.LastOrderID == "" || auto.LastError != "" {
+		t.Fatalf("expected the automation to trigger, got %+v", auto)
+	}
+
+	// the automation only triggers when its condition begins to hold and
+	// within its execution limit
+	setPrice(30)
+	setPrice(60)
+	setPrice(40)
+	if auto = a.GetAll()[0]; len(auto.Executions) != 1 {
+		t.Errorf("expected a single execution, got %v", auto.Executions)
+	}
+
+	// executions survive a reload and a restart
+	if n, err := a.Reload(); err != nil || n != 1 {
+		t.Fatalf("expected 1 automation to be reloaded, got %d %v", n, err)
+	}
+	restored := automationManager{path: a.path, statePath: a.statePath}
+	if _, err = restored.load(); err != nil {
+		t.Fatal(err)
+	}
+	if auto = restored.GetAll()[0]; len(auto.Executions) != 1 || auto.Matched {
+		t.Errorf("expected the execution to be restored, got %+v", auto)
+	}
+}
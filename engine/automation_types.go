@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Automation condition indicators
+const (
+	AutomationPrice = "PRICE"
+	AutomationRSI   = "RSI"
+	AutomationSMA   = "SMA"
+	AutomationEMA   = "EMA"
+)
+
+// automationsFile is the layout of the automations YAML file
+type automationsFile struct {
+	Automations []AutomationDefinition `yaml:"automations"`
+}
+
+// AutomationDefinition is an automation as declared in the automations YAML
+// file, for example:
+//
+//	automations:
+//	  - name: buy-the-dip
+//	    exchange: Gemini
+//	    pair: BTC-USD
+//	    when: RSI(14,1h) < 30
+//	    then:
+//	      side: buy
+//	      type: limit
+//	      quote_amount: 200
+//	    max: 3
+//	    per: week
+type AutomationDefinition struct {
+	Name     string `yaml:"name"`
+	Exchange string `yaml:"exchange"`
+	Pair     string `yaml:"pair"`
+	Asset    string `yaml:"asset"`
+	// When is the condition which triggers the automation, either PRICE or
+	// an indicator of the candle closes as INDICATOR(period,interval)
+	// compared against a value, e.g. "PRICE >= 10000" or "SMA(20,1d) > 9000"
+	When string           `yaml:"when"`
+	Then AutomationAction `yaml:"then"`
+	// Max is the amount of times the automation may trigger within Per, zero
+	// is unlimited
+	Max int    `yaml:"max"`
+	Per string `yaml:"per"`
+}
+
+// AutomationAction is the order an automation submits when triggered
+type AutomationAction struct {
+	Side string `yaml:"side"`
+	Type string `yaml:"type"`
+	// Amount is in the base currency, QuoteAmount is converted to the base
+	// currency at the price when triggered. Only one may be set
+	Amount      float64 `yaml:"amount"`
+	QuoteAmount float64 `yaml:"quote_amount"`
+	// Price is the limit price, limit orders without a price are placed at
+	// the price when triggered
+	Price float64 `yaml:"price"`
+}
+
+// Automation is an automation compiled from its definition and its state
+type Automation struct {
+	Name        string
+	Condition   string
+	Order       order.Submit
+	QuoteAmount float64
+	Indicator   string
+	Period      int
+	Interval    time.Duration
+	Operator    string
+	Threshold   float64
+	Max         int
+	Per         time.Duration
+	// Executions are the times the automation triggered within Per
+	Executions []time.Time
+	// Matched is whether the condition held when last checked, automations
+	// only trigger when their condition begins to hold
+	Matched     bool
+	LastValue   float64
+	LastChecked time.Time
+	LastOrderID string
+	LastError   string
+}
+
+type automationManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	done     chan struct{}
+	// interval is how often automations are checked, read from the config
+	// when the manager starts
+	interval time.Duration
+
+	m           sync.Mutex
+	automations []*Automation
+	// path is the automations YAML file and statePath the file executions
+	// are persisted to so execution limits survive restarts
+	path      string
+	statePath string
+}
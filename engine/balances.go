@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (b *balanceCache) Started() bool {
+	return atomic.LoadInt32(&b.started) == 1
+}
+
+func (b *balanceCache) Start() error {
+	if atomic.AddInt32(&b.started, 1) != 1 {
+		return errors.New("balance cache already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Balance cache starting...")
+	b.shutdown = make(chan struct{})
+	go b.run()
+	return nil
+}
+
+func (b *balanceCache) Stop() error {
+	if atomic.AddInt32(&b.stopped, 1) != 1 {
+		return errors.New("balance cache is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Balance cache shutting down...")
+	close(b.shutdown)
+	return nil
+}
+
+func (b *balanceCache) run() {
+	log.Debugln(log.PortfolioMgr, "Balance cache started.")
+	Bot.ServicesWG.Add(1)
+	// holdings are checked at half their TTL so they are refreshed before
+	// they expire
+	tick := time.NewTicker(Bot.Config.BalanceCache.TTL / 2)
+	defer func() {
+		atomic.CompareAndSwapInt32(&b.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&b.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Balance cache shutdown.")
+	}()
+
+	b.refresh()
+	for {
+		select {
+		case <-b.shutdown:
+			return
+		case <-tick.C:
+			b.refresh()
+		}
+	}
+}
+
+// refresh fetches the holdings of each authenticated exchange which have not
+// been updated within half the TTL. Holdings pushed by an exchange's
+// websocket keep the cache current without a request
+func (b *balanceCache) refresh() {
+	maxAge := Bot.Config.BalanceCache.TTL / 2
+	exchanges := getAuthenticatedExchanges()
+	for x := range exchanges {
+		if _, err := account.GetCachedHoldings(exchanges[x].GetName(), maxAge); err == nil {
+			continue
+		}
+		if _, err := exchanges[x].UpdateAccountInfo(); err != nil {
+			log.Errorf(log.PortfolioMgr,
+				"Balance cache: unable to refresh %s holdings: %v\n",
+				exchanges[x].GetName(),
+				err)
+		}
+	}
+}
+
+// getAccountInfo returns an exchange's holdings from the balance cache when it
+// is running and they are within its TTL, otherwise they are fetched from the
+// exchange
+func getAccountInfo(exch exchange.IBotExchange) (account.Holdings, error) {
+	if !Bot.BalanceCache.Started() {
+		return exch.FetchAccountInfo()
+	}
+	h, err := account.GetCachedHoldings(exch.GetName(), Bot.Config.BalanceCache.TTL)
+	if err == nil {
+		return h, nil
+	}
+	return exch.UpdateAccountInfo()
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+func TestGetAccountInfo(t *testing.T) {
+	SetupTestHelpers(t)
+	exch := GetExchangeByName(fakePassExchange)
+	if exch == nil {
+		t.Fatal("fake exchange not loaded")
+	}
+	c := clock.NewManual(time.Now())
+	clock.Set(c)
+	defer clock.Reset()
+
+	err := account.Process(&account.Holdings{
+		Exchange: exch.GetName(),
+		Accounts: []account.SubAccount{{
+			Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 5}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ttl := Bot.Config.BalanceCache.TTL
+	Bot.Config.BalanceCache.TTL = time.Minute
+	Bot.BalanceCache.started = 1
+	defer func() {
+		Bot.Config.BalanceCache.TTL = ttl
+		Bot.BalanceCache.started = 0
+	}()
+
+	h, err := getAccountInfo(exch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Accounts) != 1 || h.Accounts[0].Currencies[0].TotalValue != 5 {
+		t.Errorf("expected the cached holdings, got %+v", h)
+	}
+
+	// the fake exchange returns empty holdings when they are fetched
+	c.Advance(time.Minute * 2)
+	if h, err = getAccountInfo(exch); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Accounts) != 0 {
+		t.Errorf("expected expired holdings to be fetched from the exchange, got %+v", h)
+	}
+}
+
+func TestPortfolioProcessBalanceChanges(t *testing.T) {
+	SetupTestHelpers(t)
+	const exchName = "TestPortfolioProcessBalanceChanges"
+	err := account.Process(&account.Holdings{
+		Exchange: exchName,
+		Accounts: []account.SubAccount{{
+			Currencies: []account.Balance{{CurrencyName: currency.XRP, TotalValue: 100}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var p portfolioManager
+	p.processBalanceChanges([]account.BalanceChange{
+		{Exchange: exchName, Currency: currency.XRP},
+		{Exchange: exchName, Currency: currency.XRP},
+	})
+	if !portfolio.GetPortfolio().ExchangeAddressExists(exchName, currency.XRP) {
+		t.Error("expected the changed exchange holdings to be seeded")
+	}
+}
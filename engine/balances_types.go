@@ -0,0 +1,9 @@
+package engine
+
+// balanceCache refreshes the holdings of each authenticated exchange in the
+// background so reads are served from the account cache within its TTL
+type balanceCache struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+const botStateFile = "bot_state.json"
+
+func (b *botStateManager) Started() bool {
+	return atomic.LoadInt32(&b.started) == 1
+}
+
+func (b *botStateManager) Start() error {
+	if atomic.AddInt32(&b.started, 1) != 1 {
+		return errors.New("bot state manager already started")
+	}
+
+	log.Debugln(log.Global, "Bot state manager starting...")
+	if b.path == "" {
+		b.path = filepath.Join(Bot.Settings.DataDir, botStateFile)
+	}
+	if err := b.load(); err != nil {
+		atomic.CompareAndSwapInt32(&b.started, 1, 0)
+		return err
+	}
+	b.restorePortfolio()
+	b.shutdown = make(chan struct{})
+	go b.run()
+	return nil
+}
+
+func (b *botStateManager) Stop() error {
+	if atomic.AddInt32(&b.stopped, 1) != 1 {
+		return errors.New("bot state manager is already stopped")
+	}
+
+	log.Debugln(log.Global, "Bot state manager shutting down...")
+	close(b.shutdown)
+	return nil
+}
+
+func (b *botStateManager) run() {
+	log.Debugln(log.Global, "Bot state manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.BotState.Interval)
+	defer func() {
+		tick.Stop()
+		b.persist()
+		atomic.CompareAndSwapInt32(&b.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&b.started, 1, 0)
+		Bot.ServicesWG.Done()
+		log.Debugln(log.Global, "Bot state manager shutdown.")
+	}()
+
+	for {
+		select {
+		case <-b.shutdown:
+			return
+		case <-tick.C:
+			b.persist()
+		}
+	}
+}
+
+// persist saves the bot state and, when trades are persisted, the trade feed
+// so that trades already processed are not lost if the bot does not shut
+// down cleanly
+func (b *botStateManager) persist() {
+	if err := b.save(); err != nil {
+		log.Errorf(log.Global, "Bot state manager: unable to save bot state: %v\n", err)
+	}
+	if Bot.Settings.EnableTradePersistence {
+		if err := trade.Save(filepath.Join(Bot.Settings.DataDir, tradesFileName)); err != nil {
+			log.Errorf(log.Global, "Bot state manager: unable to persist trades: %v\n", err)
+		}
+	}
+}
+
+// save snapshots the state of the strategies and the portfolio and writes it
+// to disk. Strategies which have not run since the bot started keep their
+// restored state
+func (b *botStateManager) save() error {
+	states := Bot.StrategyManager.states()
+	var addresses []portfolio.Address
+	if Bot.PortfolioManager.Started() && Bot.Portfolio != nil {
+		addresses = append(addresses, Bot.Portfolio.Addresses...)
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.state.Strategies == nil {
+		b.state.Strategies = make(map[string]StrategyState)
+	}
+	for k, v := range states {
+		b.state.Strategies[k] = v
+	}
+	if addresses != nil {
+		b.state.Portfolio = addresses
+	}
+	b.state.Saved = clock.Now()
+	data, err := json.MarshalIndent(b.state, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(b.path, data)
+}
+
+func (b *botStateManager) load() error {
+	if !file.Exists(b.path) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(b.path)
+	if err != nil {
+		return err
+	}
+	var state BotState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("unable to load bot state from %s: %v", b.path, err)
+	}
+
+	b.m.Lock()
+	b.state = state
+	b.m.Unlock()
+	log.Debugf(log.Global, "Bot state manager: loaded bot state saved at %s with %d strategies\n",
+		state.Saved,
+		len(state.Strategies))
+	return nil
+}
+
+// restorePortfolio restores the balances of the portfolio's addresses and the
+// exchange holdings saved with the bot state, which are otherwise unknown
+// until the portfolio manager next syncs
+func (b *botStateManager) restorePortfolio() {
+	if !Bot.PortfolioManager.Started() || Bot.Portfolio == nil {
+		return
+	}
+	b.m.Lock()
+	defer b.m.Unlock()
+	for x := range b.state.Portfolio {
+		saved := &b.state.Portfolio[x]
+		var found bool
+		for y := range Bot.Portfolio.Addresses {
+			a := &Bot.Portfolio.Addresses[y]
+			if a.Address == saved.Address &&
+				a.Description == saved.Description &&
+				a.CoinType == saved.CoinType {
+				a.Balance = saved.Balance
+				found = true
+			}
+		}
+		if !found && saved.Description == portfolio.PortfolioAddressExchange {
+			Bot.Portfolio.AddExchangeAddress(saved.Address, saved.CoinType, saved.Balance)
+		}
+	}
+}
+
+// strategyState returns the restored state of a strategy instance by its
+// lower case name
+func (b *botStateManager) strategyState(key string) (StrategyState, bool) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	st, ok := b.state.Strategies[key]
+	return st, ok
+}
@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+const statefulTestStrategy = "statefultest"
+
+func init() {
+	err := strategies.Register(statefulTestStrategy, func() strategies.Strategy {
+		return new(statefulStrategy)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// statefulStrategy saves the state it was given and records the state it
+// was restored with
+type statefulStrategy struct {
+	testStrategy
+	state    []byte
+	restored string
+}
+
+func (s *statefulStrategy) SaveState() ([]byte, error) {
+	return s.state, nil
+}
+
+func (s *statefulStrategy) RestoreState(b []byte) error {
+	s.restored = string(b)
+	return nil
+}
+
+func TestBotStatePersistence(t *testing.T) {
+	SetupTestHelpers(t)
+	dir, err := ioutil.TempDir("", "botstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	last := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := newStrategyRunner(config.StrategyConfig{Name: "Resume", Strategy: statefulTestStrategy},
+		&statefulStrategy{state: []byte("saved")})
+	r.status.LastCandle = last
+	Bot.StrategyManager.m.Lock()
+	Bot.StrategyManager.runners = map[string]*strategyRunner{"resume": r}
+	Bot.StrategyManager.m.Unlock()
+	defer func() {
+		Bot.StrategyManager.m.Lock()
+		Bot.StrategyManager.runners = nil
+		Bot.StrategyManager.m.Unlock()
+	}()
+
+	oldPortfolio := Bot.Portfolio
+	atomic.StoreInt32(&Bot.PortfolioManager.started, 1)
+	defer func() {
+		atomic.StoreInt32(&Bot.PortfolioManager.started, 0)
+		Bot.Portfolio = oldPortfolio
+	}()
+	Bot.Portfolio = &portfolio.Base{Addresses: []portfolio.Address{
+		{Address: "1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", CoinType: currency.BTC, Balance: 2, Description: portfolio.PortfolioAddressPersonal},
+		{Address: testExchange, CoinType: currency.USD, Balance: 1000, Description: portfolio.PortfolioAddressExchange},
+	}}
+
+	b := botStateManager{path: filepath.Join(dir, botStateFile)}
+	if err = b.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a restart seeds the portfolio from the config without exchange holdings
+	Bot.Portfolio = &portfolio.Base{Addresses: []portfolio.Address{
+		{Address: "1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", CoinType: currency.BTC, Balance: 1, Description: portfolio.PortfolioAddressPersonal},
+	}}
+	restored := botStateManager{path: b.path}
+	if err = restored.load(); err != nil {
+		t.Fatal(err)
+	}
+	restored.restorePortfolio()
+	if bal, ok := Bot.Portfolio.GetAddressBalance("1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", portfolio.PortfolioAddressPersonal, currency.BTC); !ok || bal != 2 {
+		t.Errorf("expected the address balance to be restored, got %v", bal)
+	}
+	if bal, ok := Bot.Portfolio.GetAddressBalance(testExchange, portfolio.PortfolioAddressExchange, currency.USD); !ok || bal != 1000 {
+		t.Errorf("expected the exchange holding to be restored, got %v", bal)
+	}
+
+	st, ok := restored.strategyState("resume")
+	if !ok {
+		t.Fatal("expected the strategy state to be restored")
+	}
+	if st.Strategy != statefulTestStrategy || !st.LastCandle.Equal(last) || string(st.State) != "saved" {
+		t.Errorf("unexpected strategy state %+v", st)
+	}
+
+	// strategies which have not run keep their restored state
+	Bot.StrategyManager.m.Lock()
+	Bot.StrategyManager.runners = nil
+	Bot.StrategyManager.m.Unlock()
+	if err = restored.save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = restored.strategyState("resume"); !ok {
+		t.Error("expected the restored strategy state to be kept")
+	}
+
+	if err = ioutil.WriteFile(b.path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = restored.load(); err == nil {
+		t.Error("expected an error loading an invalid bot state")
+	}
+}
+
+func TestStrategyResume(t *testing.T) {
+	SetupTestHelpers(t)
+	last := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	oldStrategies := Bot.Config.Strategies.Strategies
+	defer func() { Bot.Config.Strategies.Strategies = oldStrategies }()
+	for _, name := range []string{"Resume", "Other"} {
+		Bot.Config.Strategies.Strategies = append(Bot.Config.Strategies.Strategies, config.StrategyConfig{
+			Name:      name,
+			Strategy:  statefulTestStrategy,
+			Exchange:  testExchange,
+			Pair:      currency.NewPair(currency.BTC, currency.USD),
+			AssetType: asset.Spot,
+			Interval:  kline.OneMin,
+		})
+	}
+
+	atomic.StoreInt32(&Bot.BotStateManager.started, 1)
+	Bot.BotStateManager.m.Lock()
+	Bot.BotStateManager.state.Strategies = map[string]StrategyState{
+		"resume": {Strategy: statefulTestStrategy, LastCandle: last, State: []byte("saved")},
+		"other":  {Strategy: "smacross", State: []byte("smacross")},
+	}
+	Bot.BotStateManager.m.Unlock()
+	defer func() {
+		atomic.StoreInt32(&Bot.BotStateManager.started, 0)
+		Bot.BotStateManager.m.Lock()
+		Bot.BotStateManager.state = BotState{}
+		Bot.BotStateManager.m.Unlock()
+	}()
+
+	s := strategyManager{started: 1, runners: make(map[string]*strategyRunner)}
+	if err := s.StartStrategy("resume"); err != nil {
+		t.Fatal(err)
+	}
+	r := s.runners["resume"]
+	if !r.getStatus().LastCandle.Equal(last) || r.strategy.(*statefulStrategy).restored != "saved" {
+		t.Errorf("expected the strategy to resume from its saved state, got %+v", r.getStatus())
+	}
+
+	// a restarted instance resumes from the state of its previous runner
+	if err := s.StopStrategy("resume"); err != nil {
+		t.Fatal(err)
+	}
+	r.strategy.(*statefulStrategy).state = []byte("updated")
+	if err := s.StartStrategy("resume"); err != nil {
+		t.Fatal(err)
+	}
+	if restored := s.runners["resume"].strategy.(*statefulStrategy).restored; restored != "updated" {
+		t.Errorf("expected the previous runner's state to be resumed, got %s", restored)
+	}
+	if err := s.StopStrategy("resume"); err != nil {
+		t.Error(err)
+	}
+
+	// state saved by a different strategy is not resumed
+	if err := s.StartStrategy("other"); err != nil {
+		t.Fatal(err)
+	}
+	if restored := s.runners["other"].strategy.(*statefulStrategy).restored; restored != "" {
+		t.Errorf("expected another strategy's state to be ignored, got %s", restored)
+	}
+	if err := s.StopStrategy("other"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+// BotState is the runtime state of the bot persisted so that it resumes where
+// it left off after a restart. Orders, conditional orders and synced trade
+// histories are persisted by their own managers
+type BotState struct {
+	Saved time.Time `json:"saved"`
+	// Strategies are keyed by the lower case name of the strategy instance
+	Strategies map[string]StrategyState `json:"strategies,omitempty"`
+	Portfolio  []portfolio.Address      `json:"portfolio,omitempty"`
+}
+
+// StrategyState is the persisted state of a strategy instance
+type StrategyState struct {
+	Strategy string `json:"strategy"`
+	// LastCandle is the open time of the last candle passed to the strategy,
+	// candles which closed while the bot was stopped are passed on resume
+	LastCandle time.Time `json:"lastCandle"`
+	// State is saved by strategies implementing strategies.Stateful
+	State []byte `json:"state,omitempty"`
+}
+
+// botStateManager persists the bot state at an interval and on shutdown and
+// restores it on startup
+type botStateManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	// path is the file the bot state is persisted to
+	path string
+
+	m     sync.Mutex
+	state BotState
+}
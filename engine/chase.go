@@ -0,0 +1,301 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the chase manager
+var (
+	ErrChaseNotFound         = errors.New("chase does not exist")
+	errChaseIsNil            = errors.New("chase is nil")
+	errChaseNotLimit         = errors.New("only limit orders can be chased")
+	errChaseInvalidTimeout   = errors.New("chase timeout must be greater than zero")
+	errChaseInvalidDeviation = errors.New("chase max deviation cannot be negative")
+
+	// DefaultChaseMaxAttempts is the amount of times an order is re-priced
+	// when no maximum is specified
+	DefaultChaseMaxAttempts int64 = 10
+)
+
+// Submit places the initial limit order of a chase. If the order is not fully
+// filled within the chase timeout it is cancelled and re-priced towards the
+// market until it fills, its maximum deviation or attempts are reached or the
+// remainder is converted to a market order
+func (c *chaseManager) Submit(ch *Chase) (*Chase, error) {
+	if ch == nil {
+		return nil, errChaseIsNil
+	}
+
+	if ch.Order.Type != order.Limit {
+		return nil, errChaseNotLimit
+	}
+
+	if ch.Options.Timeout <= 0 {
+		return nil, errChaseInvalidTimeout
+	}
+
+	if ch.Options.MaxDeviation < 0 {
+		return nil, errChaseInvalidDeviation
+	}
+
+	if ch.Options.MaxAttempts <= 0 {
+		ch.Options.MaxAttempts = DefaultChaseMaxAttempts
+	}
+
+	resp, err := Bot.OrderManager.Submit(&ch.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	ch.ID = id.String()
+	ch.Status = ChaseActive
+	ch.StartPrice = ch.Order.Price
+	ch.Price = ch.Order.Price
+	ch.Remaining = ch.Order.Amount
+	ch.OrderID = resp.OrderID
+	ch.InternalOrderID = resp.InternalOrderID
+	ch.Created = clock.Now()
+	ch.LastUpdated = ch.Created
+	if resp.FullyMatched {
+		ch.Status = ChaseFilled
+		ch.Filled = ch.Remaining
+		ch.Remaining = 0
+	}
+
+	c.m.Lock()
+	if c.chases == nil {
+		c.chases = make(map[string]*Chase)
+	}
+	c.chases[ch.ID] = ch
+	c.m.Unlock()
+
+	if ch.Status == ChaseActive {
+		go c.run(ch)
+	}
+	return c.GetByID(ch.ID)
+}
+
+// GetByID returns a copy of the chase matching the supplied ID
+func (c *chaseManager) GetByID(id string) (*Chase, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	ch, ok := c.chases[id]
+	if !ok {
+		return nil, ErrChaseNotFound
+	}
+	cp := *ch
+	return &cp, nil
+}
+
+// GetAll returns a copy of all chases
+func (c *chaseManager) GetAll() []Chase {
+	c.m.Lock()
+	defer c.m.Unlock()
+	var chases []Chase
+	for _, v := range c.chases {
+		chases = append(chases, *v)
+	}
+	return chases
+}
+
+// run re-prices the chase each time its timeout elapses until it completes or
+// the order manager is shut down
+func (c *chaseManager) run(ch *Chase) {
+	for {
+		select {
+		case <-Bot.OrderManager.shutdown:
+			return
+		case <-time.After(ch.Options.Timeout):
+			if c.check(ch) {
+				return
+			}
+		}
+	}
+}
+
+// check retrieves the fill state of the current order and re-prices it if it
+// has not been fully filled. Returns true once the chase has completed
+func (c *chaseManager) check(ch *Chase) bool {
+	exch := GetExchangeByName(ch.Order.Exchange)
+	if exch == nil {
+		c.fail(ch, ErrExchangeNotFound)
+		return true
+	}
+
+	od, err := exch.GetOrderInfo(ch.OrderID)
+	if err != nil {
+		c.fail(ch, err)
+		return true
+	}
+
+	executed := od.ExecutedAmount
+	if od.Status == order.Filled || executed > ch.Remaining {
+		executed = ch.Remaining
+	}
+	c.m.Lock()
+	ch.Filled += executed
+	ch.Remaining -= executed
+	ch.LastUpdated = clock.Now()
+	c.m.Unlock()
+	if ch.Remaining <= 0 {
+		c.finish(ch, ChaseFilled)
+		return true
+	}
+
+	tick, err := exch.FetchTicker(ch.Order.Pair, ch.Order.AssetType)
+	if err != nil {
+		c.fail(ch, err)
+		return true
+	}
+	if tick == nil {
+		c.fail(ch, fmt.Errorf("no ticker available for %s", ch.Order.Pair))
+		return true
+	}
+
+	price, ok := chasePrice(ch.Order.Side,
+		ch.StartPrice,
+		ch.Price,
+		ch.Options.MaxDeviation,
+		tick.Bid,
+		tick.Ask)
+	if ch.Attempts >= ch.Options.MaxAttempts {
+		ok = false
+	}
+	if !ok && !ch.Options.ConvertToMarket {
+		// the order is left resting at its last price
+		c.finish(ch, ChaseExhausted)
+		return true
+	}
+
+	err = Bot.OrderManager.Cancel(&order.Cancel{
+		Exchange:  ch.Order.Exchange,
+		ID:        ch.OrderID,
+		AccountID: ch.Order.AccountID,
+		ClientID:  ch.Order.ClientID,
+		Type:      ch.Order.Type,
+		Side:      ch.Order.Side,
+		Pair:      ch.Order.Pair,
+		AssetType: ch.Order.AssetType,
+	})
+	if err != nil {
+		c.fail(ch, err)
+		return true
+	}
+
+	next := ch.Order
+	next.Amount = ch.Remaining
+	next.Price = price
+	// the replacement order requires its own client order ID
+	next.ClientID = ""
+	if !ok {
+		next.Type = order.Market
+		next.Price = 0
+	}
+	resp, err := Bot.OrderManager.Submit(&next)
+	if err != nil {
+		c.fail(ch, err)
+		return true
+	}
+
+	c.m.Lock()
+	ch.OrderID = resp.OrderID
+	ch.InternalOrderID = resp.InternalOrderID
+	ch.Order.ClientID = next.ClientID
+	ch.Attempts++
+	if ok {
+		ch.Price = price
+	}
+	c.m.Unlock()
+
+	switch {
+	case !ok:
+		c.finish(ch, ChaseMarket)
+		return true
+	case resp.FullyMatched:
+		c.m.Lock()
+		ch.Filled += ch.Remaining
+		ch.Remaining = 0
+		c.m.Unlock()
+		c.finish(ch, ChaseFilled)
+		return true
+	}
+	c.notify(ch, fmt.Sprintf("re-priced to %v", price))
+	return false
+}
+
+func (c *chaseManager) finish(ch *Chase, status ChaseStatus) {
+	c.m.Lock()
+	ch.Status = status
+	ch.LastUpdated = clock.Now()
+	c.m.Unlock()
+	c.notify(ch, string(status))
+}
+
+func (c *chaseManager) fail(ch *Chase, err error) {
+	c.m.Lock()
+	ch.Error = err.Error()
+	c.m.Unlock()
+	log.Warnf(log.OrderMgr, "Chase manager: chase %s failed: %s", ch.ID, err)
+	c.finish(ch, ChaseFailed)
+}
+
+func (c *chaseManager) notify(ch *Chase, action string) {
+	c.m.Lock()
+	msg := fmt.Sprintf("Chase manager: chase %s %s %s %s %s. Filled %v remaining %v attempts %v",
+		ch.ID,
+		ch.Order.Exchange,
+		ch.Order.Side,
+		ch.Order.Pair,
+		action,
+		ch.Filled,
+		ch.Remaining,
+		ch.Attempts)
+	c.m.Unlock()
+	log.Debugln(log.OrderMgr, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "chase",
+		Message: msg,
+	})
+}
+
+// chasePrice returns the price to re-price a chased order to, crossing the
+// spread to the opposing side of the book but bounded by the max deviation
+// percentage from the start price. Returns false if the price would not
+// improve on the current price
+func chasePrice(side order.Side, start, current, maxDeviation, bid, ask float64) (float64, bool) {
+	switch side {
+	case order.Buy, order.Bid:
+		if ask <= 0 {
+			return current, false
+		}
+		limit := start * (1 + maxDeviation/100)
+		price := ask
+		if price > limit {
+			price = limit
+		}
+		return price, price > current
+	case order.Sell, order.Ask:
+		if bid <= 0 {
+			return current, false
+		}
+		limit := start * (1 - maxDeviation/100)
+		price := bid
+		if price < limit {
+			price = limit
+		}
+		return price, price < current
+	}
+	return current, false
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testChase(exch string) *Chase {
+	return &Chase{
+		Order: order.Submit{
+			Exchange:  exch,
+			Pair:      currency.NewPairFromString("BTCUSD"),
+			AssetType: asset.Spot,
+			Side:      order.Buy,
+			Type:      order.Limit,
+			Amount:    1,
+			Price:     100,
+		},
+		Options: ChaseOptions{
+			Timeout:      time.Minute,
+			MaxDeviation: 1,
+		},
+	}
+}
+
+func TestChaseSubmit(t *testing.T) {
+	OrdersSetup(t)
+	var c chaseManager
+	_, err := c.Submit(nil)
+	if err != errChaseIsNil {
+		t.Errorf("expected %v, got %v", errChaseIsNil, err)
+	}
+
+	ch := testChase(fakePassExchange)
+	ch.Order.Type = order.Market
+	_, err = c.Submit(ch)
+	if err != errChaseNotLimit {
+		t.Errorf("expected %v, got %v", errChaseNotLimit, err)
+	}
+
+	ch = testChase(fakePassExchange)
+	ch.Options.Timeout = 0
+	_, err = c.Submit(ch)
+	if err != errChaseInvalidTimeout {
+		t.Errorf("expected %v, got %v", errChaseInvalidTimeout, err)
+	}
+
+	ch = testChase(fakePassExchange)
+	ch.Options.MaxDeviation = -1
+	_, err = c.Submit(ch)
+	if err != errChaseInvalidDeviation {
+		t.Errorf("expected %v, got %v", errChaseInvalidDeviation, err)
+	}
+
+	resp, err := c.Submit(testChase(fakePassExchange))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		// the fake exchange always returns the same order ID
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+	if resp.Status != ChaseFilled ||
+		resp.Filled != 1 ||
+		resp.Remaining != 0 ||
+		resp.Options.MaxAttempts != DefaultChaseMaxAttempts {
+		t.Errorf("unexpected chase %+v", resp)
+	}
+
+	if _, err = c.GetByID(resp.ID); err != nil {
+		t.Error(err)
+	}
+	if _, err = c.GetByID("nope"); err != ErrChaseNotFound {
+		t.Errorf("expected %v, got %v", ErrChaseNotFound, err)
+	}
+	if len(c.GetAll()) != 1 {
+		t.Error("expected one chase")
+	}
+}
+
+func TestChasePrice(t *testing.T) {
+	price, ok := chasePrice(order.Buy, 100, 100, 1, 100, 100.5)
+	if !ok || price != 100.5 {
+		t.Errorf("expected buy to be re-priced to the ask, got %v %v", price, ok)
+	}
+
+	price, ok = chasePrice(order.Buy, 100, 100, 1, 102, 103)
+	if !ok || price != 101 {
+		t.Errorf("expected buy to be bounded by max deviation, got %v %v", price, ok)
+	}
+
+	_, ok = chasePrice(order.Buy, 100, 101, 1, 102, 103)
+	if ok {
+		t.Error("expected buy at max deviation to be exhausted")
+	}
+
+	price, ok = chasePrice(order.Sell, 100, 100, 2, 97, 98)
+	if !ok || price != 98 {
+		t.Errorf("expected sell to be bounded by max deviation, got %v %v", price, ok)
+	}
+
+	_, ok = chasePrice(order.Sell, 100, 100, 2, 0, 0)
+	if ok {
+		t.Error("expected sell without a bid to be exhausted")
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// ChaseStatus defines the state of a chased order
+type ChaseStatus string
+
+// All chase status types
+const (
+	ChaseActive    ChaseStatus = "ACTIVE"
+	ChaseFilled    ChaseStatus = "FILLED"
+	ChaseMarket    ChaseStatus = "CONVERTED_TO_MARKET"
+	ChaseExhausted ChaseStatus = "EXHAUSTED"
+	ChaseFailed    ChaseStatus = "FAILED"
+)
+
+// ChaseOptions determines how a limit order which has not been fully filled is
+// re-priced
+type ChaseOptions struct {
+	// Timeout is how long each limit order rests before it is re-priced
+	Timeout time.Duration
+	// MaxDeviation is the maximum percentage the limit price can move away
+	// from the original limit price
+	MaxDeviation float64
+	// MaxAttempts is the maximum amount of times the order is re-priced
+	MaxAttempts int64
+	// ConvertToMarket submits the remaining amount as a market order once the
+	// order can no longer be re-priced
+	ConvertToMarket bool
+}
+
+// Chase is a limit order which is re-priced towards the market until it is
+// filled, its maximum deviation is reached or it is converted to a market order
+type Chase struct {
+	ID              string
+	Order           order.Submit
+	Options         ChaseOptions
+	Status          ChaseStatus
+	StartPrice      float64
+	Price           float64
+	Remaining       float64
+	Filled          float64
+	Attempts        int64
+	OrderID         string
+	InternalOrderID string
+	Error           string
+	Created         time.Time
+	LastUpdated     time.Time
+}
+
+type chaseManager struct {
+	m      sync.Mutex
+	chases map[string]*Chase
+}
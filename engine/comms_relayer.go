@@ -2,6 +2,7 @@ package engine
 
 import (
 	"errors"
+	"sync"
 	"sync/atomic"
 
 	"github.com/thrasher-corp/gocryptotrader/communications"
@@ -16,6 +17,10 @@ type commsManager struct {
 	shutdown chan struct{}
 	relayMsg chan base.Event
 	comms    *communications.Communications
+
+	m sync.Mutex
+	// relayed counts the events relayed by type since they were last taken
+	relayed map[string]int
 }
 
 func (c *commsManager) Started() bool {
@@ -69,12 +74,31 @@ func (c *commsManager) Stop() error {
 }
 
 func (c *commsManager) PushEvent(evt base.Event) {
+	Bot.EventBus.publishAlert(evt)
 	if !c.Started() {
 		return
 	}
 	c.relayMsg <- evt
 }
 
+// SendEmail sends an email through the enabled relayers which send email
+func (c *commsManager) SendEmail(e base.Email) error {
+	if !c.Started() {
+		return errors.New("communications manager not started")
+	}
+	return c.comms.SendEmail(e)
+}
+
+// takeRelayed returns the amount of events relayed by type since it was last
+// called
+func (c *commsManager) takeRelayed() map[string]int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	relayed := c.relayed
+	c.relayed = nil
+	return relayed
+}
+
 func (c *commsManager) run() {
 	defer func() {
 		// TO-DO shutdown comms connections for connected services (Slack etc)
@@ -87,6 +111,12 @@ func (c *commsManager) run() {
 		select {
 		case msg := <-c.relayMsg:
 			c.comms.PushEvent(msg)
+			c.m.Lock()
+			if c.relayed == nil {
+				c.relayed = make(map[string]int)
+			}
+			c.relayed[msg.Type]++
+			c.m.Unlock()
 		case <-c.shutdown:
 			return
 		}
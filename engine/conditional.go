@@ -0,0 +1,445 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the conditional order manager
+var (
+	ErrConditionalNotFound          = errors.New("conditional order does not exist")
+	errConditionalIsNil             = errors.New("conditional order is nil")
+	errConditionalManagerNotStarted = errors.New("conditional order manager is not started")
+	errConditionalTypeInvalid       = errors.New("conditional order type is invalid")
+	errConditionalInvalidTrigger    = errors.New("conditional order trigger price must be greater than zero")
+	errConditionalInvalidTrail      = errors.New("trailing stop offset must be greater than zero and a percentage below 100")
+	errConditionalOrderType         = errors.New("conditional orders must be market or limit orders")
+	errConditionalNotPending        = errors.New("conditional order is not pending")
+	errConditionalNoPrice           = errors.New("no ticker price available")
+)
+
+const conditionalOrdersFile = "conditional_orders.json"
+
+func (c *conditionalManager) Started() bool {
+	return atomic.LoadInt32(&c.started) == 1
+}
+
+func (c *conditionalManager) Start() error {
+	if atomic.AddInt32(&c.started, 1) != 1 {
+		return errors.New("conditional order manager already started")
+	}
+
+	log.Debugln(log.OrderMgr, "Conditional order manager starting...")
+	if c.path == "" {
+		c.path = filepath.Join(Bot.Settings.DataDir, conditionalOrdersFile)
+	}
+	if err := c.load(); err != nil {
+		atomic.CompareAndSwapInt32(&c.started, 1, 0)
+		return err
+	}
+	c.interval = Bot.Config.ConditionalOrders.Interval
+	c.shutdown = make(chan struct{})
+	c.done = make(chan struct{})
+	go c.run()
+	return nil
+}
+
+func (c *conditionalManager) Stop() error {
+	if atomic.AddInt32(&c.stopped, 1) != 1 {
+		return errors.New("conditional order manager is already stopped")
+	}
+
+	log.Debugln(log.OrderMgr, "Conditional order manager shutting down...")
+	close(c.shutdown)
+	<-c.done
+	return nil
+}
+
+func (c *conditionalManager) run() {
+	log.Debugln(log.OrderMgr, "Conditional order manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(c.interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&c.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&c.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.OrderMgr, "Conditional order manager shutdown.")
+		close(c.done)
+	}()
+
+	for {
+		select {
+		case <-c.shutdown:
+			return
+		case <-tick.C:
+			c.check()
+		}
+	}
+}
+
+// Add validates and stores a conditional order. The order is persisted so it
+// survives restarts and is submitted through the order manager once the
+// ticker reaches its trigger price
+func (c *conditionalManager) Add(cond *ConditionalOrder) (*ConditionalOrder, error) {
+	if cond == nil {
+		return nil, errConditionalIsNil
+	}
+	if !c.Started() {
+		return nil, errConditionalManagerNotStarted
+	}
+	switch cond.Type {
+	case ConditionalStopLoss, ConditionalTakeProfit:
+		if cond.TriggerPrice <= 0 {
+			return nil, errConditionalInvalidTrigger
+		}
+	case ConditionalTrailingStop:
+		if cond.TrailOffset <= 0 || (cond.TrailPercent && cond.TrailOffset >= 100) {
+			return nil, errConditionalInvalidTrail
+		}
+		// the trail starts from the first price observed
+		cond.TriggerPrice = 0
+		cond.BestPrice = 0
+	default:
+		return nil, errConditionalTypeInvalid
+	}
+	if cond.Order.AssetType == "" {
+		cond.Order.AssetType = asset.Spot
+	}
+	if cond.Order.Type != order.Market && cond.Order.Type != order.Limit {
+		return nil, errConditionalOrderType
+	}
+	if err := cond.Order.Validate(); err != nil {
+		return nil, err
+	}
+	if GetExchangeByName(cond.Order.Exchange) == nil {
+		return nil, ErrExchangeNotFound
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	cond.ID = id.String()
+	cond.Status = ConditionalPending
+	cond.Created = clock.Now()
+
+	c.m.Lock()
+	if c.orders == nil {
+		c.orders = make(map[string]*ConditionalOrder)
+	}
+	c.orders[cond.ID] = cond
+	err = c.save()
+	if err != nil {
+		delete(c.orders, cond.ID)
+	}
+	c.m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if cond.Type == ConditionalTrailingStop {
+		c.notify(cond, fmt.Sprintf("added, trails by %s", trailDescription(cond)))
+	} else {
+		c.notify(cond, fmt.Sprintf("added, triggers at %v", cond.TriggerPrice))
+	}
+	return c.GetByID(cond.ID)
+}
+
+// GetByID returns a copy of the conditional order matching the supplied ID
+func (c *conditionalManager) GetByID(id string) (*ConditionalOrder, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	cond, ok := c.orders[id]
+	if !ok {
+		return nil, ErrConditionalNotFound
+	}
+	cp := *cond
+	return &cp, nil
+}
+
+// GetAll returns a copy of all conditional orders
+func (c *conditionalManager) GetAll() []ConditionalOrder {
+	c.m.Lock()
+	defer c.m.Unlock()
+	var orders []ConditionalOrder
+	for _, v := range c.orders {
+		orders = append(orders, *v)
+	}
+	return orders
+}
+
+// Cancel removes a pending conditional order so it is never submitted
+func (c *conditionalManager) Cancel(id string) error {
+	c.m.Lock()
+	cond, ok := c.orders[id]
+	if !ok {
+		c.m.Unlock()
+		return ErrConditionalNotFound
+	}
+	if cond.Status != ConditionalPending {
+		c.m.Unlock()
+		return errConditionalNotPending
+	}
+	cond.Status = ConditionalCancelled
+	err := c.save()
+	c.m.Unlock()
+
+	c.notify(cond, string(ConditionalCancelled))
+	return err
+}
+
+// check compares every pending conditional order against its ticker price and
+// submits the orders which have been triggered
+func (c *conditionalManager) check() {
+	c.m.Lock()
+	var pending []*ConditionalOrder
+	for _, v := range c.orders {
+		if v.Status == ConditionalPending {
+			pending = append(pending, v)
+		}
+	}
+	c.m.Unlock()
+
+	for x := range pending {
+		select {
+		case <-c.shutdown:
+			return
+		default:
+		}
+		// orders triggered during maintenance are held until it ends
+		if Bot.MaintenanceManager.InMaintenance(pending[x].Order.Exchange) {
+			continue
+		}
+		price, err := conditionalPrice(&pending[x].Order)
+		if err != nil {
+			if Bot.Settings.Verbose {
+				log.Debugf(log.OrderMgr, "Conditional order manager: %s %s %s unable to get price: %s",
+					pending[x].Order.Exchange,
+					pending[x].Order.Pair,
+					pending[x].Order.AssetType,
+					err)
+			}
+			continue
+		}
+		if pending[x].Type == ConditionalTrailingStop {
+			c.trail(pending[x], price)
+		}
+		if conditionalTriggered(pending[x], price) {
+			c.trigger(pending[x], price)
+		}
+	}
+}
+
+// trail moves the trigger price of a trailing stop when the price improves on
+// the best price observed. The trail is persisted whenever it moves so it
+// resumes from the same point after a restart
+func (c *conditionalManager) trail(cond *ConditionalOrder, price float64) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if cond.Status != ConditionalPending || price <= 0 {
+		return
+	}
+	buy := isBuySide(cond.Order.Side)
+	if cond.BestPrice > 0 &&
+		((buy && price >= cond.BestPrice) || (!buy && price <= cond.BestPrice)) {
+		return
+	}
+	cond.BestPrice = price
+	cond.TriggerPrice = trailingTriggerPrice(cond)
+	if err := c.save(); err != nil {
+		log.Errorf(log.OrderMgr, "Conditional order manager: unable to save conditional orders: %s", err)
+	}
+	if Bot.Settings.Verbose {
+		log.Debugf(log.OrderMgr, "Conditional order manager: %s %s trail moved to %v, triggers at %v",
+			cond.Type,
+			cond.ID,
+			cond.BestPrice,
+			cond.TriggerPrice)
+	}
+}
+
+// trigger submits the conditional order to the exchange
+func (c *conditionalManager) trigger(cond *ConditionalOrder, price float64) {
+	c.m.Lock()
+	// the order may have been cancelled since it was checked
+	if cond.Status != ConditionalPending {
+		c.m.Unlock()
+		return
+	}
+	cond.Status = ConditionalTriggered
+	cond.TriggeredPrice = price
+	cond.Triggered = clock.Now()
+	s := cond.Order
+	c.m.Unlock()
+
+	resp, err := Bot.OrderManager.Submit(&s)
+
+	c.m.Lock()
+	if err != nil {
+		cond.Status = ConditionalFailed
+		cond.Error = err.Error()
+	} else {
+		cond.OrderID = resp.OrderID
+		cond.InternalOrderID = resp.InternalOrderID
+	}
+	saveErr := c.save()
+	c.m.Unlock()
+
+	if err != nil {
+		log.Warnf(log.OrderMgr, "Conditional order manager: %s %s failed: %s", cond.Type, cond.ID, err)
+	}
+	if saveErr != nil {
+		log.Errorf(log.OrderMgr, "Conditional order manager: unable to save conditional orders: %s", saveErr)
+	}
+	c.notify(cond, fmt.Sprintf("%s at %v", cond.Status, price))
+}
+
+// load restores the pending conditional orders persisted by a previous run
+func (c *conditionalManager) load() error {
+	if !file.Exists(c.path) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	var orders []ConditionalOrder
+	if err = json.Unmarshal(data, &orders); err != nil {
+		return fmt.Errorf("unable to load conditional orders from %s: %v", c.path, err)
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.orders == nil {
+		c.orders = make(map[string]*ConditionalOrder)
+	}
+	for x := range orders {
+		if orders[x].Status != ConditionalPending {
+			continue
+		}
+		c.orders[orders[x].ID] = &orders[x]
+	}
+	log.Debugf(log.OrderMgr, "Conditional order manager: loaded %d pending conditional orders", len(orders))
+	return nil
+}
+
+// save persists the pending conditional orders. Must be called with the lock
+// held
+func (c *conditionalManager) save() error {
+	orders := []ConditionalOrder{}
+	for _, v := range c.orders {
+		if v.Status != ConditionalPending {
+			continue
+		}
+		cp := *v
+		// pairs are stored delimited so they are split correctly on load
+		cp.Order.Pair = cp.Order.Pair.Format("-", true)
+		orders = append(orders, cp)
+	}
+	data, err := json.MarshalIndent(orders, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(c.path, data)
+}
+
+func (c *conditionalManager) notify(cond *ConditionalOrder, action string) {
+	c.m.Lock()
+	msg := fmt.Sprintf("Conditional order manager: %s %s %s %s %s %v %s",
+		cond.Type,
+		cond.ID,
+		cond.Order.Exchange,
+		cond.Order.Side,
+		cond.Order.Pair,
+		cond.Order.Amount,
+		action)
+	c.m.Unlock()
+	log.Debugln(log.OrderMgr, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "conditional",
+		Message: msg,
+	})
+}
+
+// conditionalPrice returns the last traded price of the order's pair, falling
+// back to the mid price. The stored ticker is used before one is fetched
+func conditionalPrice(s *order.Submit) (float64, error) {
+	tick, err := ticker.GetTicker(s.Exchange, s.Pair, s.AssetType)
+	if err != nil {
+		exch := GetExchangeByName(s.Exchange)
+		if exch == nil {
+			return 0, ErrExchangeNotFound
+		}
+		tick, err = exch.FetchTicker(s.Pair, s.AssetType)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if tick == nil {
+		return 0, errConditionalNoPrice
+	}
+	if tick.Last > 0 {
+		return tick.Last, nil
+	}
+	if tick.Bid > 0 && tick.Ask > 0 {
+		return (tick.Bid + tick.Ask) / 2, nil
+	}
+	return 0, errConditionalNoPrice
+}
+
+// conditionalTriggered returns true when the price has reached the trigger
+// price of the conditional order
+func conditionalTriggered(cond *ConditionalOrder, price float64) bool {
+	if price <= 0 || cond.TriggerPrice <= 0 {
+		return false
+	}
+	buy := isBuySide(cond.Order.Side)
+	switch cond.Type {
+	case ConditionalStopLoss, ConditionalTrailingStop:
+		if buy {
+			return price >= cond.TriggerPrice
+		}
+		return price <= cond.TriggerPrice
+	case ConditionalTakeProfit:
+		if buy {
+			return price <= cond.TriggerPrice
+		}
+		return price >= cond.TriggerPrice
+	}
+	return false
+}
+
+// trailingTriggerPrice returns the trigger price of a trailing stop, the trail
+// offset below the best price for a sell or above it for a buy
+func trailingTriggerPrice(cond *ConditionalOrder) float64 {
+	offset := cond.TrailOffset
+	if cond.TrailPercent {
+		offset = cond.BestPrice * cond.TrailOffset / 100
+	}
+	if isBuySide(cond.Order.Side) {
+		return cond.BestPrice + offset
+	}
+	return cond.BestPrice - offset
+}
+
+func trailDescription(cond *ConditionalOrder) string {
+	if cond.TrailPercent {
+		return fmt.Sprintf("%v%%", cond.TrailOffset)
+	}
+	return fmt.Sprintf("%v", cond.TrailOffset)
+}
@@ -0,0 +1,296 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func testConditional(exch string, condType ConditionalType, side order.Side, trigger float64) *ConditionalOrder {
+	return &ConditionalOrder{
+		Type: condType,
+		Order: order.Submit{
+			Exchange:  exch,
+			Pair:      currency.NewPair(currency.BTC, currency.USDT),
+			AssetType: asset.Spot,
+			Side:      side,
+			Type:      order.Limit,
+			Amount:    1,
+			Price:     100,
+		},
+		TriggerPrice: trigger,
+	}
+}
+
+func TestConditionalTriggered(t *testing.T) {
+	tests := []struct {
+		condType ConditionalType
+		side     order.Side
+		price    float64
+		expected bool
+	}{
+		{ConditionalStopLoss, order.Sell, 99, true},
+		{ConditionalStopLoss, order.Sell, 101, false},
+		{ConditionalStopLoss, order.Buy, 101, true},
+		{ConditionalStopLoss, order.Buy, 99, false},
+		{ConditionalTakeProfit, order.Sell, 101, true},
+		{ConditionalTakeProfit, order.Sell, 99, false},
+		{ConditionalTakeProfit, order.Buy, 99, true},
+		{ConditionalTakeProfit, order.Buy, 101, false},
+		{ConditionalStopLoss, order.Sell, 0, false},
+	}
+	for x := range tests {
+		c := testConditional(fakePassExchange, tests[x].condType, tests[x].side, 100)
+		if conditionalTriggered(c, tests[x].price) != tests[x].expected {
+			t.Errorf("%s %s at %v expected triggered %v",
+				tests[x].condType,
+				tests[x].side,
+				tests[x].price,
+				tests[x].expected)
+		}
+	}
+}
+
+func TestConditionalManager(t *testing.T) {
+	OrdersSetup(t)
+	dir, err := ioutil.TempDir("", "conditional")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldInterval := Bot.Config.ConditionalOrders.Interval
+	Bot.Config.ConditionalOrders.Interval = time.Hour
+	defer func() { Bot.Config.ConditionalOrders.Interval = oldInterval }()
+
+	c := conditionalManager{path: filepath.Join(dir, conditionalOrdersFile)}
+	if _, err = c.Add(testConditional(fakePassExchange, ConditionalStopLoss, order.Sell, 95)); err != errConditionalManagerNotStarted {
+		t.Errorf("expected %v, got %v", errConditionalManagerNotStarted, err)
+	}
+	if err = c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = c.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if _, err = c.Add(nil); err != errConditionalIsNil {
+		t.Errorf("expected %v, got %v", errConditionalIsNil, err)
+	}
+	if _, err = c.Add(testConditional(fakePassExchange, "NOPE", order.Sell, 95)); err != errConditionalTypeInvalid {
+		t.Errorf("expected %v, got %v", errConditionalTypeInvalid, err)
+	}
+	if _, err = c.Add(testConditional(fakePassExchange, ConditionalStopLoss, order.Sell, 0)); err != errConditionalInvalidTrigger {
+		t.Errorf("expected %v, got %v", errConditionalInvalidTrigger, err)
+	}
+	cond := testConditional(fakePassExchange, ConditionalStopLoss, order.Sell, 95)
+	cond.Order.Type = order.Stop
+	if _, err = c.Add(cond); err != errConditionalOrderType {
+		t.Errorf("expected %v, got %v", errConditionalOrderType, err)
+	}
+
+	stop, err := c.Add(testConditional(fakePassExchange, ConditionalStopLoss, order.Sell, 95))
+	if err != nil {
+		t.Fatal(err)
+	}
+	profit, err := c.Add(testConditional(fakePassExchange, ConditionalTakeProfit, order.Sell, 110))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pending orders survive a restart
+	restored := conditionalManager{path: c.path}
+	if err = restored.load(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := restored.GetByID(stop.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != ConditionalPending ||
+		!resp.Order.Pair.Equal(stop.Order.Pair) ||
+		resp.TriggerPrice != 95 {
+		t.Errorf("unexpected restored conditional order %+v", resp)
+	}
+
+	if err = c.Cancel(profit.ID); err != nil {
+		t.Error(err)
+	}
+	if err = c.Cancel(profit.ID); err != errConditionalNotPending {
+		t.Errorf("expected %v, got %v", errConditionalNotPending, err)
+	}
+	if err = c.Cancel("nope"); err != ErrConditionalNotFound {
+		t.Errorf("expected %v, got %v", ErrConditionalNotFound, err)
+	}
+
+	err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: stop.Order.Pair, Last: 96}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.check()
+	if resp, err = c.GetByID(stop.ID); err != nil || resp.Status != ConditionalPending {
+		t.Fatalf("expected conditional order to remain pending, got %+v %v", resp, err)
+	}
+
+	err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: stop.Order.Pair, Last: 94}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.check()
+	defer func() {
+		// the fake exchange always returns the same order ID
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+	if resp, err = c.GetByID(stop.ID); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != ConditionalTriggered || resp.TriggeredPrice != 94 || resp.OrderID == "" {
+		t.Errorf("expected conditional order to be triggered, got %+v", resp)
+	}
+
+	// triggered and cancelled orders are no longer persisted
+	restored = conditionalManager{path: c.path}
+	if err = restored.load(); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.GetAll()) != 0 {
+		t.Errorf("expected no pending conditional orders, got %+v", restored.GetAll())
+	}
+}
+
+func TestTrailingTriggerPrice(t *testing.T) {
+	tests := []struct {
+		side     order.Side
+		percent  bool
+		expected float64
+	}{
+		{order.Sell, false, 95},
+		{order.Sell, true, 90},
+		{order.Buy, false, 105},
+		{order.Buy, true, 110},
+	}
+	for x := range tests {
+		c := testConditional(fakePassExchange, ConditionalTrailingStop, tests[x].side, 0)
+		c.BestPrice = 100
+		c.TrailOffset = 5
+		if tests[x].percent {
+			c.TrailOffset = 10
+		}
+		c.TrailPercent = tests[x].percent
+		if p := trailingTriggerPrice(c); p != tests[x].expected {
+			t.Errorf("%s percent %v expected trigger price %v, got %v",
+				tests[x].side,
+				tests[x].percent,
+				tests[x].expected,
+				p)
+		}
+	}
+}
+
+func TestConditionalTrailingStop(t *testing.T) {
+	OrdersSetup(t)
+	dir, err := ioutil.TempDir("", "conditional")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldInterval := Bot.Config.ConditionalOrders.Interval
+	Bot.Config.ConditionalOrders.Interval = time.Hour
+	defer func() { Bot.Config.ConditionalOrders.Interval = oldInterval }()
+
+	c := conditionalManager{path: filepath.Join(dir, conditionalOrdersFile)}
+	if err = c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = c.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cond := testConditional(fakePassExchange, ConditionalTrailingStop, order.Sell, 0)
+	if _, err = c.Add(cond); err != errConditionalInvalidTrail {
+		t.Errorf("expected %v, got %v", errConditionalInvalidTrail, err)
+	}
+	cond.TrailOffset = 100
+	cond.TrailPercent = true
+	if _, err = c.Add(cond); err != errConditionalInvalidTrail {
+		t.Errorf("expected %v, got %v", errConditionalInvalidTrail, err)
+	}
+	cond.TrailOffset = 10
+	trailing, err := c.Add(cond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prices := []struct {
+		last    float64
+		best    float64
+		trigger float64
+	}{
+		{100, 100, 90},
+		// the trail follows the price up
+		{120, 120, 108},
+		// and holds as it retraces within the offset
+		{110, 120, 108},
+	}
+	for x := range prices {
+		err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: cond.Order.Pair, Last: prices[x].last}, asset.Spot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.check()
+		resp, err := c.GetByID(trailing.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Status != ConditionalPending ||
+			resp.BestPrice != prices[x].best ||
+			resp.TriggerPrice != prices[x].trigger {
+			t.Errorf("at %v unexpected trailing stop %+v", prices[x].last, resp)
+		}
+	}
+
+	// the trail resumes from the same point after a restart
+	restored := conditionalManager{path: c.path}
+	if err = restored.load(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := restored.GetByID(trailing.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.BestPrice != 120 || resp.TriggerPrice != 108 || !resp.TrailPercent {
+		t.Errorf("unexpected restored trailing stop %+v", resp)
+	}
+
+	err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: cond.Order.Pair, Last: 107}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.check()
+	defer func() {
+		// the fake exchange always returns the same order ID
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+	if resp, err = c.GetByID(trailing.ID); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != ConditionalTriggered || resp.TriggeredPrice != 107 {
+		t.Errorf("expected trailing stop to be triggered, got %+v", resp)
+	}
+}
@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// ConditionalType defines when a conditional order is triggered relative to
+// its order side
+type ConditionalType string
+
+// All conditional order types
+const (
+	// ConditionalStopLoss triggers when the price moves against the order
+	// side, a sell below or a buy above the trigger price
+	ConditionalStopLoss ConditionalType = "STOP_LOSS"
+	// ConditionalTakeProfit triggers when the price moves in favour of the
+	// order side, a sell above or a buy below the trigger price
+	ConditionalTakeProfit ConditionalType = "TAKE_PROFIT"
+	// ConditionalTrailingStop is a stop loss whose trigger price follows the
+	// best price observed, triggering when the price retraces by the trail
+	// offset
+	ConditionalTrailingStop ConditionalType = "TRAILING_STOP"
+)
+
+// ConditionalStatus defines the state of a conditional order
+type ConditionalStatus string
+
+// All conditional order status types
+const (
+	ConditionalPending   ConditionalStatus = "PENDING"
+	ConditionalTriggered ConditionalStatus = "TRIGGERED"
+	ConditionalCancelled ConditionalStatus = "CANCELLED"
+	ConditionalFailed    ConditionalStatus = "FAILED"
+)
+
+// ConditionalOrder is an order held locally and submitted to the exchange once
+// the ticker reaches its trigger price
+type ConditionalOrder struct {
+	ID           string
+	Type         ConditionalType
+	Order        order.Submit
+	TriggerPrice float64
+	// TrailOffset is the retracement from the best price which triggers a
+	// trailing stop, a percentage of the best price when TrailPercent is set
+	TrailOffset  float64
+	TrailPercent bool
+	// BestPrice is the highest price observed for a trailing stop sell or the
+	// lowest for a buy
+	BestPrice float64
+	Status    ConditionalStatus
+	// TriggeredPrice is the ticker price which fired the order
+	TriggeredPrice  float64
+	OrderID         string
+	InternalOrderID string
+	Error           string
+	Created         time.Time
+	Triggered       time.Time
+}
+
+type conditionalManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	done     chan struct{}
+	// interval is how often tickers are checked, read from the config when
+	// the manager starts
+	interval time.Duration
+
+	m      sync.Mutex
+	orders map[string]*ConditionalOrder
+	// path is the file pending conditional orders are persisted to
+	path string
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+)
+
+var (
+	errNoEnabledPairs       = errors.New("no enabled pairs to request a ticker for")
+	errConnectionChecksFail = errors.New("exchange connection checks failed")
+)
+
+// CheckConnections sets up the enabled exchanges, makes a public and an
+// authenticated sanity request to each and prints a table of the results so
+// keys and connectivity can be validated before the bot is run. An error is
+// returned when any request failed
+func (e *Engine) CheckConnections(w io.Writer) error {
+	if e.Settings.ExchangePurgeCredentials {
+		e.Config.PurgeExchangeAPICredentials()
+	}
+	SetupExchanges()
+	exchanges := GetExchanges()
+	if len(exchanges) == 0 {
+		return errors.New("no exchanges are loaded")
+	}
+
+	results := make([]ConnectionCheck, len(exchanges))
+	var wg sync.WaitGroup
+	for x := range exchanges {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			results[x] = checkConnection(exchanges[x])
+		}(x)
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Exchange < results[j].Exchange
+	})
+
+	if err := printConnectionChecks(w, results); err != nil {
+		return err
+	}
+	for x := range results {
+		if results[x].Public.Status == ConnectionCheckFailed ||
+			results[x].Auth.Status == ConnectionCheckFailed {
+			return errConnectionChecksFail
+		}
+	}
+	return nil
+}
+
+// checkConnection requests a ticker of the exchange's first enabled pair and,
+// when authenticated support is enabled, validates its API key
+func checkConnection(exch exchange.IBotExchange) ConnectionCheck {
+	c := ConnectionCheck{Exchange: exch.GetName()}
+
+	start := time.Now()
+	err := checkPublicConnection(exch)
+	c.Public.Latency = time.Since(start)
+	if err != nil {
+		c.Public.Status, c.Public.Detail = ConnectionCheckFailed, err.Error()
+	} else {
+		c.Public.Status = ConnectionCheckOK
+	}
+
+	if !exch.GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+		c.Auth.Status, c.Auth.Detail = ConnectionCheckSkipped, "authenticated support disabled"
+		return c
+	}
+	start = time.Now()
+	v := validateKey(exch)
+	c.Auth.Latency = time.Since(start)
+	if !v.Read {
+		c.Auth.Status, c.Auth.Detail = ConnectionCheckFailed, v.Error
+		return c
+	}
+	c.Auth.Status = ConnectionCheckOK
+	if !v.Reported {
+		c.Auth.Detail = "permissions not reported"
+		return c
+	}
+	var permissions []string
+	if v.Trade {
+		permissions = append(permissions, "trade")
+	}
+	if v.Withdraw {
+		permissions = append(permissions, "withdraw")
+	}
+	if len(permissions) == 0 {
+		c.Auth.Detail = "read only"
+	} else {
+		c.Auth.Detail = "can " + strings.Join(permissions, " and ")
+	}
+	return c
+}
+
+func checkPublicConnection(exch exchange.IBotExchange) error {
+	assets := exch.GetAssetTypes()
+	for x := range assets {
+		pairs := exch.GetEnabledPairs(assets[x])
+		if len(pairs) == 0 {
+			continue
+		}
+		_, err := exch.UpdateTicker(pairs[0], assets[x])
+		return err
+	}
+	return errNoEnabledPairs
+}
+
+func printConnectionChecks(w io.Writer, results []ConnectionCheck) error {
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "EXCHANGE\tPUBLIC\tLATENCY\tAUTHENTICATED\tLATENCY\tDETAILS")
+	for x := range results {
+		var details []string
+		if results[x].Public.Detail != "" {
+			details = append(details, "public: "+results[x].Public.Detail)
+		}
+		if results[x].Auth.Detail != "" {
+			details = append(details, "authenticated: "+results[x].Auth.Detail)
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			results[x].Exchange,
+			results[x].Public.Status,
+			connectionCheckLatency(&results[x].Public),
+			results[x].Auth.Status,
+			connectionCheckLatency(&results[x].Auth),
+			strings.Join(details, "; "))
+	}
+	return t.Flush()
+}
+
+func connectionCheckLatency(r *ConnectionCheckResult) string {
+	if r.Status == ConnectionCheckSkipped {
+		return "-"
+	}
+	return r.Latency.Round(time.Millisecond).String()
+}
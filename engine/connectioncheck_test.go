@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// fakeTickerExchange has an enabled pair and fails ticker requests with its
+// error
+type fakeTickerExchange struct {
+	fakeKeyPermissionExchange
+	tickerErr error
+}
+
+func (f *fakeTickerExchange) GetEnabledPairs(_ asset.Item) currency.Pairs {
+	return currency.Pairs{currency.NewPair(currency.BTC, currency.USD)}
+}
+
+func (f *fakeTickerExchange) UpdateTicker(_ currency.Pair, _ asset.Item) (*ticker.Price, error) {
+	return nil, f.tickerErr
+}
+
+func TestCheckConnection(t *testing.T) {
+	t.Parallel()
+	c := checkConnection(&FakePassingExchange{Base: exchange.Base{Name: "nopairs"}})
+	if c.Public.Status != ConnectionCheckFailed || c.Public.Detail != errNoEnabledPairs.Error() {
+		t.Errorf("expected the public check to fail without enabled pairs, got %+v", c.Public)
+	}
+	if c.Auth.Status != ConnectionCheckOK || c.Auth.Detail != "permissions not reported" {
+		t.Errorf("unexpected authenticated check %+v", c.Auth)
+	}
+
+	f := &fakeTickerExchange{
+		fakeKeyPermissionExchange: fakeKeyPermissionExchange{
+			FakePassingExchange: FakePassingExchange{Base: exchange.Base{Name: "reported"}},
+			permissions:         exchange.KeyPermissions{Trade: true},
+		},
+	}
+	c = checkConnection(f)
+	if c.Public.Status != ConnectionCheckOK {
+		t.Errorf("unexpected public check %+v", c.Public)
+	}
+	if c.Auth.Status != ConnectionCheckOK || c.Auth.Detail != "can trade" {
+		t.Errorf("unexpected authenticated check %+v", c.Auth)
+	}
+
+	f.tickerErr = errors.New("connection refused")
+	f.err = errors.New("invalid API key")
+	c = checkConnection(f)
+	if c.Public.Status != ConnectionCheckFailed || c.Auth.Status != ConnectionCheckFailed ||
+		c.Auth.Detail != "invalid API key" {
+		t.Errorf("expected both checks to fail, got %+v", c)
+	}
+}
+
+func TestPrintConnectionChecks(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	err := printConnectionChecks(&b, []ConnectionCheck{
+		{
+			Exchange: "Bitstamp",
+			Public:   ConnectionCheckResult{Status: ConnectionCheckOK},
+			Auth:     ConnectionCheckResult{Status: ConnectionCheckSkipped, Detail: "authenticated support disabled"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and a row, got %q", b.String())
+	}
+	fields := strings.Fields(lines[1])
+	if fields[0] != "Bitstamp" || fields[1] != ConnectionCheckOK ||
+		fields[3] != ConnectionCheckSkipped || fields[4] != "-" {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+}
@@ -0,0 +1,25 @@
+package engine
+
+import "time"
+
+// Connection check statuses
+const (
+	ConnectionCheckOK      = "ok"
+	ConnectionCheckFailed  = "failed"
+	ConnectionCheckSkipped = "skipped"
+)
+
+// ConnectionCheck is the outcome of a public and an authenticated sanity
+// request made to an exchange before the bot is run
+type ConnectionCheck struct {
+	Exchange string
+	Public   ConnectionCheckResult
+	Auth     ConnectionCheckResult
+}
+
+// ConnectionCheckResult is the outcome of a sanity request
+type ConnectionCheckResult struct {
+	Status  string
+	Latency time.Duration
+	Detail  string
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/secrets"
+)
+
+// credentialsProvider returns the secret store an exchange's API credentials
+// are read from
+func credentialsProvider(exchCfg *config.ExchangeConfig) (secrets.Provider, error) {
+	p := exchCfg.API.CredentialsProvider
+	switch p.Type {
+	case secrets.EnvProvider:
+		return &secrets.Env{Prefix: p.EnvPrefix}, nil
+	case secrets.VaultProvider:
+		address := Bot.Config.SecretStores.Vault.Address
+		if address == "" {
+			address = os.Getenv("VAULT_ADDR")
+		}
+		return &secrets.Vault{
+			Client:  common.NewHTTPClientWithTimeout(exchCfg.HTTPTimeout),
+			Address: address,
+			Token:   os.Getenv("VAULT_TOKEN"),
+			Mount:   Bot.Config.SecretStores.Vault.Mount,
+			Path:    p.VaultPath,
+		}, nil
+	case secrets.KMSProvider:
+		region := Bot.Config.SecretStores.KMS.Region
+		if region == "" {
+			region = os.Getenv("AWS_REGION")
+		}
+		k := &secrets.KMS{
+			Client:          common.NewHTTPClientWithTimeout(exchCfg.HTTPTimeout),
+			Region:          region,
+			Endpoint:        Bot.Config.SecretStores.KMS.Endpoint,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		if p.Encrypted != nil {
+			k.Encrypted = secrets.Credentials{
+				Key:       p.Encrypted.Key,
+				Secret:    p.Encrypted.Secret,
+				ClientID:  p.Encrypted.ClientID,
+				PEMKey:    p.Encrypted.PEMKey,
+				OTPSecret: p.Encrypted.OTPSecret,
+			}
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("credentials provider %q is not supported", p.Type)
+}
+
+// loadProvidedCredentials sets an exchange's API credentials from its secret
+// store, the credentials are not saved to the config file
+func loadProvidedCredentials(exchCfg *config.ExchangeConfig) error {
+	p, err := credentialsProvider(exchCfg)
+	if err != nil {
+		return err
+	}
+	c, err := p.Credentials()
+	if err != nil {
+		return fmt.Errorf("%s credentials provider: %v", p.Name(), err)
+	}
+	exchCfg.API.Credentials = config.APICredentialsConfig{
+		Key:       c.Key,
+		Secret:    c.Secret,
+		ClientID:  c.ClientID,
+		PEMKey:    c.PEMKey,
+		OTPSecret: c.OTPSecret,
+	}
+	return nil
+}
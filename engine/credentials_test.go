@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"os"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/secrets"
+)
+
+func TestLoadProvidedCredentials(t *testing.T) {
+	SetupTestHelpers(t)
+	exchCfg := config.ExchangeConfig{
+		Name: testExchange,
+		API: config.APIConfig{
+			CredentialsProvider: &config.APICredentialsProviderConfig{
+				Type:      secrets.EnvProvider,
+				EnvPrefix: "GCT_ENGINE_TEST",
+			},
+		},
+	}
+	if err := loadProvidedCredentials(&exchCfg); err == nil {
+		t.Error("expected an error when the environment has no credentials")
+	}
+
+	os.Setenv("GCT_ENGINE_TEST_API_KEY", "key")
+	os.Setenv("GCT_ENGINE_TEST_API_SECRET", "secret")
+	defer os.Unsetenv("GCT_ENGINE_TEST_API_KEY")
+	defer os.Unsetenv("GCT_ENGINE_TEST_API_SECRET")
+	if err := loadProvidedCredentials(&exchCfg); err != nil {
+		t.Fatal(err)
+	}
+	if exchCfg.API.Credentials.Key != "key" || exchCfg.API.Credentials.Secret != "secret" {
+		t.Errorf("unexpected credentials %+v", exchCfg.API.Credentials)
+	}
+
+	exchCfg.API.CredentialsProvider.Type = "keychain"
+	if err := loadProvidedCredentials(&exchCfg); err == nil {
+		t.Error("expected an error for an unsupported provider")
+	}
+}
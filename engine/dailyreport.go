@@ -0,0 +1,351 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errDailyReportCommsRequired = errors.New("daily reporter requires the communications manager")
+
+// dailyReportFuncs format the numbers and times of a report for both of its
+// templates
+var dailyReportFuncs = map[string]interface{}{
+	"amount": func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	},
+	"value": func(v float64) string {
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	},
+	"percent": func(v float64) string {
+		return strconv.FormatFloat(v*100, 'f', 2, 64) + "%"
+	},
+	"time": func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	},
+}
+
+var dailyReportText = template.Must(template.New("text").Funcs(dailyReportFuncs).Parse(
+	`GoCryptoTrader daily report {{time .Start}} to {{time .End}}
+
+Balances
+{{range .Balances}}{{.Exchange}}: {{value .Value}}
+{{range .Currencies}}  {{.Currency}} {{amount .Amount}}{{if .Valued}} ({{value .Value}}){{else}} (not valued){{end}}
+{{end}}{{else}}No balances
+{{end}}Total: {{value .TotalValue}} {{.Currency}}
+
+24h P&L: {{if .HasPrevious}}{{value .PNL}} {{.Currency}}, realised {{value .RealisedPNL}} {{.Currency}}{{else}}available from the next report{{end}}
+
+Trades executed: {{len .Trades}}
+{{range .Trades}}  {{.Exchange}} {{.Pair}} {{.AssetType}} {{.Side}} {{amount .ExecutedAmount}} at {{amount .Price}}
+{{end}}
+Alerts fired: {{len .Alerts}} types
+{{range .Alerts}}  {{.Type}}: {{.Count}}
+{{end}}
+Exchange health
+{{range .Health}}  {{.Exchange}}: {{.Status}}, {{.Requests}} requests, {{percent .ErrorRate}} errors, {{.AverageLatency}} average latency{{if .Reason}} ({{.Reason}}){{end}}
+{{else}}  Not monitored
+{{end}}{{range .Errors}}
+Error: {{.}}{{end}}
+`))
+
+var dailyReportHTML = htmltemplate.Must(htmltemplate.New("html").Funcs(dailyReportFuncs).Parse(
+	`<html><body>
+<h2>GoCryptoTrader daily report</h2>
+<p>{{time .Start}} to {{time .End}}</p>
+<h3>Balances</h3>
+<table>
+<tr><th>Exchange</th><th>Currency</th><th>Amount</th><th>Value ({{.Currency}})</th></tr>
+{{range .Balances}}{{$exchange := .Exchange}}{{range .Currencies}}<tr><td>{{$exchange}}</td><td>{{.Currency}}</td><td>{{amount .Amount}}</td><td>{{if .Valued}}{{value .Value}}{{else}}not valued{{end}}</td></tr>
+{{end}}{{end}}<tr><th colspan="3">Total</th><th>{{value .TotalValue}}</th></tr>
+</table>
+<h3>24h P&amp;L</h3>
+<p>{{if .HasPrevious}}{{value .PNL}} {{.Currency}}, realised {{value .RealisedPNL}} {{.Currency}}{{else}}Available from the next report{{end}}</p>
+<h3>Trades executed: {{len .Trades}}</h3>
+<table>
+<tr><th>Exchange</th><th>Pair</th><th>Asset</th><th>Side</th><th>Amount</th><th>Price</th></tr>
+{{range .Trades}}<tr><td>{{.Exchange}}</td><td>{{.Pair}}</td><td>{{.AssetType}}</td><td>{{.Side}}</td><td>{{amount .ExecutedAmount}}</td><td>{{amount .Price}}</td></tr>
+{{end}}</table>
+<h3>Alerts fired</h3>
+<table>
+{{range .Alerts}}<tr><td>{{.Type}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+<h3>Exchange health</h3>
+<table>
+<tr><th>Exchange</th><th>Status</th><th>Requests</th><th>Error rate</th><th>Average latency</th><th>Reason</th></tr>
+{{range .Health}}<tr><td>{{.Exchange}}</td><td>{{.Status}}</td><td>{{.Requests}}</td><td>{{percent .ErrorRate}}</td><td>{{.AverageLatency}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>
+{{range .Errors}}<p>Error: {{.}}</p>
+{{end}}</body></html>
+`))
+
+func (d *dailyReporter) Started() bool {
+	return atomic.LoadInt32(&d.started) == 1
+}
+
+func (d *dailyReporter) Start() error {
+	if !Bot.CommsManager.Started() {
+		return errDailyReportCommsRequired
+	}
+	if atomic.AddInt32(&d.started, 1) != 1 {
+		return errors.New("daily reporter already started")
+	}
+
+	log.Debugln(log.CommunicationMgr, "Daily reporter starting...")
+	if err := d.load(); err != nil {
+		atomic.CompareAndSwapInt32(&d.started, 1, 0)
+		return err
+	}
+	d.shutdown = make(chan struct{})
+	go d.run()
+	return nil
+}
+
+func (d *dailyReporter) Stop() error {
+	if atomic.AddInt32(&d.stopped, 1) != 1 {
+		return errors.New("daily reporter is already stopped")
+	}
+
+	log.Debugln(log.CommunicationMgr, "Daily reporter shutting down...")
+	close(d.shutdown)
+	return nil
+}
+
+func (d *dailyReporter) run() {
+	log.Debugln(log.CommunicationMgr, "Daily reporter started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(dailyReportCheckInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&d.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&d.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.CommunicationMgr, "Daily reporter shutdown.")
+	}()
+
+	// a report missed while the bot was not running is not sent, the next
+	// report's profit and loss covers the time since the last one sent
+	next := nextSettlementClose(clock.Now(), Bot.Config.DailyReport.SendTime)
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case <-tick.C:
+			now := clock.Now()
+			if now.Before(next) {
+				continue
+			}
+			if err := d.send(d.report(next)); err != nil {
+				log.Errorf(log.CommunicationMgr,
+					"Daily reporter: unable to send %s report: %v\n",
+					next.Format(time.RFC3339),
+					err)
+			}
+			next = nextSettlementClose(now, Bot.Config.DailyReport.SendTime)
+		}
+	}
+}
+
+// report summarises the 24 hours up to the end time
+func (d *dailyReporter) report(end time.Time) *DailyReport {
+	r := &DailyReport{
+		Start:    end.AddDate(0, 0, -1),
+		End:      end,
+		Currency: Bot.Config.DailyReport.Currency,
+	}
+
+	exchanges := GetAuthAPISupportedExchanges()
+	sort.Strings(exchanges)
+	for x := range exchanges {
+		holdings, err := account.GetHoldings(exchanges[x])
+		if err != nil {
+			r.Errors = append(r.Errors,
+				fmt.Sprintf("%s balances unavailable: %v", exchanges[x], err))
+			continue
+		}
+		b := exchangeBalance(&holdings, r.Currency)
+		r.TotalValue += b.Value
+		r.Balances = append(r.Balances, b)
+	}
+
+	positions := Bot.PositionManager.GetAll()
+	for x := range positions {
+		v, ok := positions[x].valueIn(r.Currency)
+		if !ok {
+			r.Errors = append(r.Errors,
+				fmt.Sprintf("unable to value %s %s realised profit and loss in %s",
+					positions[x].Exchange,
+					positions[x].Pair,
+					r.Currency))
+			continue
+		}
+		r.CumulativeRealisedPNL += v.RealisedPNL
+	}
+
+	d.m.Lock()
+	if d.last != nil && d.last.Currency.Match(r.Currency) {
+		r.HasPrevious = true
+		r.PNL = r.TotalValue - d.last.TotalValue
+		r.RealisedPNL = r.CumulativeRealisedPNL - d.last.CumulativeRealisedPNL
+	}
+	d.m.Unlock()
+
+	r.Trades = executedOrders(r.Start, r.End)
+	for k, v := range Bot.CommsManager.takeRelayed() {
+		r.Alerts = append(r.Alerts, AlertCount{Type: k, Count: v})
+	}
+	sort.Slice(r.Alerts, func(i, j int) bool {
+		return r.Alerts[i].Type < r.Alerts[j].Type
+	})
+	if Bot.ExchangeHealthMonitor.Started() {
+		r.Health = Bot.ExchangeHealthMonitor.GetAll()
+	}
+	return r
+}
+
+// exchangeBalance totals the balance of each currency across an exchange's
+// accounts and values it in the target currency
+func exchangeBalance(holdings *account.Holdings, target currency.Code) ExchangeBalance {
+	b := ExchangeBalance{Exchange: holdings.Exchange}
+	index := make(map[string]int)
+	for x := range holdings.Accounts {
+		for y := range holdings.Accounts[x].Currencies {
+			c := &holdings.Accounts[x].Currencies[y]
+			key := c.CurrencyName.Upper().String()
+			i, ok := index[key]
+			if !ok {
+				i = len(b.Currencies)
+				index[key] = i
+				b.Currencies = append(b.Currencies, CurrencyBalance{Currency: c.CurrencyName.Upper()})
+			}
+			b.Currencies[i].Amount += c.TotalValue
+		}
+	}
+	sort.Slice(b.Currencies, func(i, j int) bool {
+		return b.Currencies[i].Currency.String() < b.Currencies[j].Currency.String()
+	})
+	for x := range b.Currencies {
+		c := &b.Currencies[x]
+		c.Value, c.Valued = convertValue(c.Amount, c.Currency, target)
+		b.Value += c.Value
+	}
+	return b
+}
+
+// executedOrders returns copies of the orders placed through the bot which
+// executed between start and end, oldest first, with their executed amount
+// and average price
+func executedOrders(start, end time.Time) []order.Detail {
+	var orders []order.Detail
+	Bot.OrderManager.orderStore.m.RLock()
+	for _, v := range Bot.OrderManager.orderStore.Orders {
+		for x := range v {
+			t := v[x].LastUpdated
+			if t.IsZero() {
+				t = v[x].Date
+			}
+			if t.Before(start) || !t.Before(end) {
+				continue
+			}
+			amount, value, _ := orderFill(v[x])
+			if amount <= 0 {
+				continue
+			}
+			cp := *v[x]
+			cp.ExecutedAmount = amount
+			cp.Price = value / amount
+			orders = append(orders, cp)
+		}
+	}
+	Bot.OrderManager.orderStore.m.RUnlock()
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Date.Before(orders[j].Date)
+	})
+	return orders
+}
+
+// email renders the report as plaintext and HTML
+func (r *DailyReport) email() (base.Email, error) {
+	var text, html bytes.Buffer
+	if err := dailyReportText.Execute(&text, r); err != nil {
+		return base.Email{}, err
+	}
+	if err := dailyReportHTML.Execute(&html, r); err != nil {
+		return base.Email{}, err
+	}
+	return base.Email{
+		Subject: "GoCryptoTrader daily report " + r.End.UTC().Format("2006-01-02"),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// send emails the report and records it as the report the next one's profit
+// and loss is measured from, whether or not it could be sent
+func (d *dailyReporter) send(r *DailyReport) error {
+	if err := d.record(r); err != nil {
+		log.Errorf(log.CommunicationMgr, "Daily reporter: unable to record report: %v\n", err)
+	}
+	e, err := r.email()
+	if err != nil {
+		return err
+	}
+	return Bot.CommsManager.SendEmail(e)
+}
+
+func (d *dailyReporter) record(r *DailyReport) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.last = r
+	data, err := json.MarshalIndent(r, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(d.reportPath(), data)
+}
+
+// load reads the last report sent before the bot restarted
+func (d *dailyReporter) load() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	path := d.reportPath()
+	if !file.Exists(path) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var r DailyReport
+	if err = json.Unmarshal(data, &r); err != nil {
+		return fmt.Errorf("unable to load daily report from %s: %v", path, err)
+	}
+	d.last = &r
+	return nil
+}
+
+// reportPath returns the file the last report is written to. Must be called
+// with the lock held
+func (d *dailyReporter) reportPath() string {
+	if d.path == "" {
+		d.path = filepath.Join(Bot.Settings.DataDir, dailyReportFile)
+	}
+	return d.path
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestExchangeBalance(t *testing.T) {
+	SetupTestHelpers(t)
+	holdings := &account.Holdings{
+		Exchange: "Bitstamp",
+		Accounts: []account.SubAccount{
+			{Currencies: []account.Balance{
+				{CurrencyName: currency.USD, TotalValue: 500},
+				{CurrencyName: currency.NewCode("NOPE"), TotalValue: 3},
+			}},
+			{Currencies: []account.Balance{
+				{CurrencyName: currency.NewCode("usd"), TotalValue: 250},
+			}},
+		},
+	}
+	b := exchangeBalance(holdings, currency.USD)
+	if b.Exchange != "Bitstamp" || len(b.Currencies) != 2 {
+		t.Fatalf("unexpected balance %+v", b)
+	}
+	if c := b.Currencies[1]; !c.Currency.Match(currency.USD) || c.Amount != 750 || !c.Valued || c.Value != 750 {
+		t.Errorf("expected the accounts' USD to be totalled and valued, got %+v", c)
+	}
+	if c := b.Currencies[0]; c.Valued || c.Amount != 3 {
+		t.Errorf("expected a currency without a rate not to be valued, got %+v", c)
+	}
+	if b.Value != 750 {
+		t.Errorf("expected the exchange to be valued at 750, got %v", b.Value)
+	}
+}
+
+func TestExecutedOrders(t *testing.T) {
+	SetupTestHelpers(t)
+	end := time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	Bot.OrderManager.orderStore.m.Lock()
+	old := Bot.OrderManager.orderStore.Orders
+	Bot.OrderManager.orderStore.Orders = map[string][]*order.Detail{
+		"bitstamp": {
+			{ID: "1", Pair: p, Status: order.Filled, Amount: 2, Price: 100, Date: end.Add(-time.Hour)},
+			{ID: "2", Pair: p, Status: order.PartiallyFilled, Amount: 2, Date: end.Add(-time.Hour * 2), Trades: []order.TradeHistory{
+				{Amount: 0.5, Price: 100},
+				{Amount: 0.5, Price: 200},
+			}},
+			{ID: "3", Pair: p, Status: order.Active, Amount: 2, Date: end.Add(-time.Hour)},
+			{ID: "4", Pair: p, Status: order.Filled, Amount: 2, Date: end.Add(-time.Hour * 25)},
+			{ID: "5", Pair: p, Status: order.Filled, Amount: 2, Date: end},
+		},
+	}
+	Bot.OrderManager.orderStore.m.Unlock()
+	defer func() {
+		Bot.OrderManager.orderStore.m.Lock()
+		Bot.OrderManager.orderStore.Orders = old
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+
+	orders := executedOrders(end.AddDate(0, 0, -1), end)
+	if len(orders) != 2 || orders[0].ID != "2" || orders[1].ID != "1" {
+		t.Fatalf("expected the orders executed during the day, oldest first, got %+v", orders)
+	}
+	if orders[0].ExecutedAmount != 1 || orders[0].Price != 150 {
+		t.Errorf("expected the executed amount and average price from the order's trades, got %v at %v",
+			orders[0].ExecutedAmount,
+			orders[0].Price)
+	}
+	if orders[1].ExecutedAmount != 2 || orders[1].Price != 100 {
+		t.Errorf("expected a filled order's amount to be executed, got %v at %v",
+			orders[1].ExecutedAmount,
+			orders[1].Price)
+	}
+}
+
+func TestDailyReport(t *testing.T) {
+	SetupTestHelpers(t)
+	dir, err := ioutil.TempDir("", "dailyreport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldCurrency := Bot.Config.DailyReport.Currency
+	Bot.Config.DailyReport.Currency = currency.USD
+	defer func() { Bot.Config.DailyReport.Currency = oldCurrency }()
+	Bot.CommsManager.m.Lock()
+	Bot.CommsManager.relayed = map[string]int{"settlement": 1, "health": 2}
+	Bot.CommsManager.m.Unlock()
+
+	end := time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)
+	d := dailyReporter{path: filepath.Join(dir, dailyReportFile)}
+	first := d.report(end)
+	if first.HasPrevious || !first.Start.Equal(end.AddDate(0, 0, -1)) {
+		t.Errorf("expected the first report to cover the day without a previous report, got %+v", first)
+	}
+	if len(first.Alerts) != 2 || first.Alerts[0].Type != "health" || first.Alerts[0].Count != 2 {
+		t.Errorf("expected the relayed events to be counted by type, got %+v", first.Alerts)
+	}
+	if Bot.CommsManager.takeRelayed() != nil {
+		t.Error("expected the relayed events to be reset")
+	}
+
+	// the report is recorded even when it cannot be emailed
+	if err = d.send(first); err == nil {
+		t.Error("expected an error emailing without the communications manager")
+	}
+	loaded := dailyReporter{path: d.path}
+	if err = loaded.load(); err != nil {
+		t.Fatal(err)
+	}
+	loaded.last.TotalValue -= 100
+	loaded.last.CumulativeRealisedPNL -= 10
+	second := loaded.report(end.AddDate(0, 0, 1))
+	if !second.HasPrevious || second.PNL != 100 || second.RealisedPNL != 10 {
+		t.Errorf("expected the profit and loss since the previous report, got %+v", second)
+	}
+
+	second.Errors = append(second.Errors, "<script>")
+	second.Trades = []order.Detail{{
+		Exchange:       "Bitstamp",
+		Pair:           currency.NewPair(currency.BTC, currency.USD),
+		AssetType:      asset.Spot,
+		Side:           order.Buy,
+		ExecutedAmount: 0.5,
+		Price:          9000,
+	}}
+	e, err := second.email()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Subject != "GoCryptoTrader daily report 2020-03-03" {
+		t.Errorf("unexpected subject %s", e.Subject)
+	}
+	for _, expected := range []string{"24h P&L: 100.00 USD, realised 10.00 USD", "Trades executed: 1", "Bitstamp BTCUSD spot BUY 0.5 at 9000", "Error: <script>"} {
+		if !strings.Contains(e.Text, expected) {
+			t.Errorf("expected the plaintext report to contain %q, got %s", expected, e.Text)
+		}
+	}
+	if !strings.Contains(e.HTML, "&lt;script&gt;") || strings.Contains(e.HTML, "<script>") {
+		t.Error("expected the HTML report to be escaped")
+	}
+}
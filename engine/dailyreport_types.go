@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const (
+	// dailyReportFile holds the last report sent, which the next report's
+	// profit and loss is measured from
+	dailyReportFile = "daily_report.json"
+	// dailyReportCheckInterval is how often the daily reporter checks whether
+	// the report is due
+	dailyReportCheckInterval = time.Minute
+)
+
+// DailyReport is the summary of the previous 24 hours emailed by the daily
+// reporter
+type DailyReport struct {
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Currency currency.Code `json:"currency"`
+	// Balances are the cached account balances of each authenticated
+	// exchange, TotalValue is their value in the report currency
+	Balances   []ExchangeBalance `json:"balances"`
+	TotalValue float64           `json:"totalValue"`
+	// PNL is the change in TotalValue and RealisedPNL the profit and loss
+	// realised by positions since the previous report. Both are only set when
+	// HasPrevious is set
+	HasPrevious           bool    `json:"hasPrevious"`
+	PNL                   float64 `json:"pnl"`
+	RealisedPNL           float64 `json:"realisedPnl"`
+	CumulativeRealisedPNL float64 `json:"cumulativeRealisedPnl"`
+	// Trades are the orders which executed during the report period
+	Trades []order.Detail `json:"-"`
+	// Alerts are the amount of events relayed by type
+	Alerts []AlertCount     `json:"-"`
+	Health []ExchangeHealth `json:"-"`
+	Errors []string         `json:"-"`
+}
+
+// ExchangeBalance is the balance of each currency held on an exchange
+type ExchangeBalance struct {
+	Exchange   string            `json:"exchange"`
+	Currencies []CurrencyBalance `json:"currencies"`
+	Value      float64           `json:"value"`
+}
+
+// CurrencyBalance is the balance of a currency and its value in the report
+// currency. Valued is not set when no conversion rate is available
+type CurrencyBalance struct {
+	Currency currency.Code `json:"currency"`
+	Amount   float64       `json:"amount"`
+	Value    float64       `json:"value"`
+	Valued   bool          `json:"valued"`
+}
+
+// AlertCount is the amount of events of a type relayed through the
+// communications manager
+type AlertCount struct {
+	Type  string
+	Count int
+}
+
+type dailyReporter struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	// path is the file the last report sent is written to
+	path string
+
+	m    sync.Mutex
+	last *DailyReport
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// dashboardAsset is a file of the web dashboard compiled into the binary
+type dashboardAsset struct {
+	contentType string
+	content     string
+}
+
+var dashboardAssets = map[string]dashboardAsset{
+	"index.html":    {contentType: "text/html; charset=utf-8", content: dashboardIndex},
+	"dashboard.js":  {contentType: "application/javascript; charset=utf-8", content: dashboardScript},
+	"dashboard.css": {contentType: "text/css; charset=utf-8", content: dashboardStyle},
+}
+
+// DashboardHandler serves the web dashboard. The dashboard is a static page
+// which reads its data from the websocket RPC server it is served by
+func DashboardHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["asset"]
+	if name == "" {
+		name = "index.html"
+	}
+	a, ok := dashboardAssets[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", a.contentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, a.content); err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
@@ -0,0 +1,492 @@
+package engine
+
+// The web dashboard's assets. The page connects to the websocket RPC server's
+// /ws endpoint, public data is shown straight away and the portfolio, open
+// orders and logs once the user has authenticated with the remote control
+// credentials
+
+const dashboardIndex = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>GoCryptoTrader dashboard</title>
+<link rel="stylesheet" href="/dashboard/dashboard.css">
+</head>
+<body>
+<header>
+	<h1>GoCryptoTrader</h1>
+	<span id="status">Connecting...</span>
+</header>
+<form id="login">
+	<input id="username" placeholder="Username" autocomplete="username" required>
+	<input id="password" type="password" placeholder="Password" autocomplete="current-password" required>
+	<button type="submit">Log in</button>
+	<span id="login-error"></span>
+</form>
+<main>
+	<section>
+		<h2>Tickers</h2>
+		<table>
+			<thead><tr><th>Exchange</th><th>Asset</th><th>Pair</th><th>Last</th><th>Bid</th><th>Ask</th><th>Volume</th></tr></thead>
+			<tbody id="tickers"></tbody>
+		</table>
+	</section>
+	<section>
+		<h2>Orderbook <small id="orderbook-name">select a ticker</small></h2>
+		<table>
+			<thead><tr><th>Price</th><th>Amount</th></tr></thead>
+			<tbody id="asks" class="asks"></tbody>
+			<tbody id="bids" class="bids"></tbody>
+		</table>
+	</section>
+	<section class="private">
+		<h2>Portfolio <small id="portfolio-total"></small></h2>
+		<table>
+			<thead><tr><th>Coin</th><th>Balance</th><th>Value</th><th>%</th></tr></thead>
+			<tbody id="portfolio"></tbody>
+		</table>
+	</section>
+	<section class="private">
+		<h2>Open orders</h2>
+		<table>
+			<thead><tr><th>Exchange</th><th>Pair</th><th>Side</th><th>Type</th><th>Price</th><th>Amount</th><th>Executed</th><th>Status</th><th>Placed</th></tr></thead>
+			<tbody id="orders"></tbody>
+		</table>
+	</section>
+	<section class="private wide">
+		<h2>Logs</h2>
+		<pre id="logs"></pre>
+	</section>
+</main>
+<script src="/dashboard/dashboard.js"></script>
+</body>
+</html>
+`
+
+const dashboardStyle = `body {
+	margin: 0;
+	font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+	font-size: 14px;
+	background: #f4f5f7;
+	color: #222;
+}
+header {
+	display: flex;
+	align-items: baseline;
+	justify-content: space-between;
+	padding: 8px 16px;
+	background: #1d2733;
+	color: #fff;
+}
+header h1 {
+	margin: 0;
+	font-size: 20px;
+}
+form {
+	padding: 8px 16px;
+	background: #fff;
+	border-bottom: 1px solid #ddd;
+}
+#login-error {
+	color: #c0392b;
+}
+main {
+	display: grid;
+	grid-template-columns: repeat(auto-fit, minmax(420px, 1fr));
+	gap: 16px;
+	padding: 16px;
+}
+section {
+	background: #fff;
+	border: 1px solid #ddd;
+	border-radius: 4px;
+	padding: 8px 12px;
+	overflow: auto;
+	max-height: 480px;
+}
+section.wide {
+	grid-column: 1 / -1;
+}
+body:not(.authenticated) .private {
+	display: none;
+}
+h2 {
+	margin: 4px 0 8px;
+	font-size: 16px;
+}
+small {
+	font-weight: normal;
+	color: #666;
+}
+table {
+	width: 100%;
+	border-collapse: collapse;
+}
+th, td {
+	padding: 3px 6px;
+	text-align: right;
+	white-space: nowrap;
+}
+th:first-child, td:first-child {
+	text-align: left;
+}
+thead th {
+	border-bottom: 1px solid #ddd;
+}
+#tickers tr {
+	cursor: pointer;
+}
+#tickers tr:hover, #tickers tr.selected {
+	background: #eef3f8;
+}
+.asks td {
+	color: #c0392b;
+}
+.bids td {
+	color: #1e8449;
+}
+pre {
+	margin: 0;
+	font-size: 12px;
+	white-space: pre-wrap;
+}
+`
+
+const dashboardScript = `(function () {
+	"use strict";
+
+	var socket;
+	var credentials;
+	var timers = [];
+	var tickers = {};
+	var orderbooks = {};
+	var selected = "";
+	var depth = 10;
+
+	function $(id) {
+		return document.getElementById(id);
+	}
+
+	function key(exchange, asset, pair) {
+		return exchange + " " + asset + " " + pair;
+	}
+
+	function format(v) {
+		if (typeof v === "number") {
+			return v.toLocaleString(undefined, {maximumFractionDigits: 8});
+		}
+		return v === undefined || v === null ? "" : String(v);
+	}
+
+	function clear(el) {
+		while (el.firstChild) {
+			el.removeChild(el.firstChild);
+		}
+	}
+
+	function row(body, cells) {
+		var tr = document.createElement("tr");
+		cells.forEach(function (c) {
+			var td = document.createElement("td");
+			td.textContent = format(c);
+			tr.appendChild(td);
+		});
+		body.appendChild(tr);
+		return tr;
+	}
+
+	function status(text) {
+		$("status").textContent = text;
+	}
+
+	// sha256 hashes the password the way the websocket RPC server expects it,
+	// crypto.subtle is only available to pages served over https
+	function sha256(message) {
+		var k = [];
+		var h = [];
+		var found = 0;
+		var i;
+		for (var c = 2; found < 64; c++) {
+			var prime = true;
+			for (var d = 2; d * d <= c; d++) {
+				if (c % d === 0) {
+					prime = false;
+					break;
+				}
+			}
+			if (!prime) {
+				continue;
+			}
+			if (found < 8) {
+				h[found] = (Math.pow(c, 1 / 2) * 0x100000000) | 0;
+			}
+			k[found++] = (Math.pow(c, 1 / 3) * 0x100000000) | 0;
+		}
+
+		function rotate(v, n) {
+			return (v >>> n) | (v << (32 - n));
+		}
+
+		var bytes = unescape(encodeURIComponent(message));
+		var words = [];
+		for (i = 0; i < bytes.length; i++) {
+			words[i >> 2] |= bytes.charCodeAt(i) << (24 - (i % 4) * 8);
+		}
+		words[bytes.length >> 2] |= 0x80 << (24 - (bytes.length % 4) * 8);
+		var n = ((bytes.length + 8) >> 6) * 16 + 16;
+		for (i = 0; i < n; i++) {
+			words[i] |= 0;
+		}
+		words[n - 1] = bytes.length * 8;
+
+		var w = [];
+		for (var j = 0; j < n; j += 16) {
+			var a = h.slice(0);
+			for (i = 0; i < 64; i++) {
+				if (i < 16) {
+					w[i] = words[j + i];
+				} else {
+					var s0 = rotate(w[i - 15], 7) ^ rotate(w[i - 15], 18) ^ (w[i - 15] >>> 3);
+					var s1 = rotate(w[i - 2], 17) ^ rotate(w[i - 2], 19) ^ (w[i - 2] >>> 10);
+					w[i] = (w[i - 16] + s0 + w[i - 7] + s1) | 0;
+				}
+				var t1 = (a[7] + (rotate(a[4], 6) ^ rotate(a[4], 11) ^ rotate(a[4], 25)) +
+					((a[4] & a[5]) ^ (~a[4] & a[6])) + k[i] + w[i]) | 0;
+				var t2 = ((rotate(a[0], 2) ^ rotate(a[0], 13) ^ rotate(a[0], 22)) +
+					((a[0] & a[1]) ^ (a[0] & a[2]) ^ (a[1] & a[2]))) | 0;
+				a = [(t1 + t2) | 0, a[0], a[1], a[2], (a[3] + t1) | 0, a[4], a[5], a[6]];
+			}
+			for (i = 0; i < 8; i++) {
+				h[i] = (h[i] + a[i]) | 0;
+			}
+		}
+
+		var hex = "";
+		for (i = 0; i < 8; i++) {
+			hex += ("00000000" + (h[i] >>> 0).toString(16)).slice(-8);
+		}
+		return hex;
+	}
+
+	// split separates the JSON messages the server batches into a single
+	// websocket frame
+	function split(data) {
+		var messages = [];
+		var level = 0;
+		var quoted = false;
+		var escaped = false;
+		var start = 0;
+		for (var i = 0; i < data.length; i++) {
+			var c = data.charAt(i);
+			if (quoted) {
+				if (escaped) {
+					escaped = false;
+				} else if (c === "\\") {
+					escaped = true;
+				} else if (c === "\"") {
+					quoted = false;
+				}
+				continue;
+			}
+			if (c === "\"") {
+				quoted = true;
+			} else if (c === "{" || c === "[") {
+				level++;
+			} else if (c === "}" || c === "]") {
+				level--;
+				if (level === 0) {
+					messages.push(JSON.parse(data.slice(start, i + 1)));
+					start = i + 1;
+				}
+			}
+		}
+		return messages;
+	}
+
+	function send(event, data) {
+		if (socket && socket.readyState === WebSocket.OPEN) {
+			socket.send(JSON.stringify({event: event, data: data === undefined ? null : data}));
+		}
+	}
+
+	function refresh() {
+		send("getportfolio");
+		send("getorders");
+		send("getlogs");
+	}
+
+	function startTimers() {
+		stopTimers();
+		timers.push(setInterval(function () { send("getportfolio"); }, 30000));
+		timers.push(setInterval(function () { send("getorders"); }, 10000));
+		timers.push(setInterval(function () { send("getlogs"); }, 5000));
+	}
+
+	function stopTimers() {
+		timers.forEach(clearInterval);
+		timers = [];
+	}
+
+	function updateTicker(t) {
+		if (t) {
+			tickers[key(t.exchangeName, t.assetType, t.Pair)] = t;
+		}
+	}
+
+	function renderTickers() {
+		var body = $("tickers");
+		clear(body);
+		Object.keys(tickers).sort().forEach(function (k) {
+			var t = tickers[k];
+			var tr = row(body, [t.exchangeName, t.assetType, t.Pair, t.Last, t.Bid, t.Ask, t.Volume]);
+			if (k === selected) {
+				tr.className = "selected";
+			}
+			tr.addEventListener("click", function () {
+				selected = k;
+				renderTickers();
+				renderOrderbook();
+			});
+		});
+	}
+
+	function updateOrderbook(o) {
+		if (o) {
+			orderbooks[key(o.exchangeName, o.assetType, o.pair)] = o;
+		}
+	}
+
+	function renderOrderbook() {
+		var asks = $("asks");
+		var bids = $("bids");
+		clear(asks);
+		clear(bids);
+		if (!selected) {
+			return;
+		}
+		$("orderbook-name").textContent = selected;
+		var o = orderbooks[selected];
+		if (!o) {
+			send("getorderbooks");
+			return;
+		}
+		(o.asks || []).slice().sort(function (x, y) { return x.Price - y.Price; }).slice(0, depth).reverse().forEach(function (a) {
+			row(asks, [a.Price, a.Amount]);
+		});
+		(o.bids || []).slice().sort(function (x, y) { return y.Price - x.Price; }).slice(0, depth).forEach(function (b) {
+			row(bids, [b.Price, b.Amount]);
+		});
+	}
+
+	function renderPortfolio(p) {
+		var body = $("portfolio");
+		clear(body);
+		if (!p) {
+			return;
+		}
+		$("portfolio-total").textContent = p.total_value ? format(p.total_value) + " " + p.base_currency : "";
+		(p.coin_totals || []).forEach(function (c) {
+			row(body, [c.coin, c.balance, c.value, c.percentage ? c.percentage.toFixed(2) : ""]);
+		});
+	}
+
+	function renderOrders(orders) {
+		var body = $("orders");
+		clear(body);
+		(orders || []).forEach(function (o) {
+			row(body, [o.Exchange, o.Pair + " " + o.AssetType, o.Side, o.Type, o.Price, o.Amount,
+				o.ExecutedAmount, o.Status, new Date(o.Date).toLocaleString()]);
+		});
+	}
+
+	function renderLogs(lines) {
+		var logs = $("logs");
+		var bottom = logs.parentNode.scrollTop + logs.parentNode.clientHeight >= logs.parentNode.scrollHeight - 4;
+		logs.textContent = (lines || []).join("");
+		if (bottom) {
+			logs.parentNode.scrollTop = logs.parentNode.scrollHeight;
+		}
+	}
+
+	function handle(m) {
+		var event = String(m.event || m.Event || "").toLowerCase();
+		var data = m.data !== undefined ? m.data : m.Data;
+		if (m.error) {
+			if (event === "auth") {
+				credentials = undefined;
+				$("login-error").textContent = m.error;
+			} else {
+				status(m.error);
+			}
+			return;
+		}
+		switch (event) {
+		case "auth":
+			$("login").hidden = true;
+			$("login-error").textContent = "";
+			document.body.classList.add("authenticated");
+			refresh();
+			startTimers();
+			break;
+		case "gettickers":
+			(data || []).forEach(function (e) { (e.exchangeValues || []).forEach(updateTicker); });
+			renderTickers();
+			break;
+		case "ticker_update":
+			updateTicker(data);
+			renderTickers();
+			break;
+		case "getorderbooks":
+			(data || []).forEach(function (e) { (e.exchangeValues || []).forEach(updateOrderbook); });
+			renderOrderbook();
+			break;
+		case "orderbook_update":
+			updateOrderbook(data);
+			if (data && key(data.exchangeName, data.assetType, data.pair) === selected) {
+				renderOrderbook();
+			}
+			break;
+		case "getportfolio":
+			renderPortfolio(data);
+			break;
+		case "getorders":
+			renderOrders(data);
+			break;
+		case "getlogs":
+			renderLogs(data);
+			break;
+		}
+	}
+
+	function connect() {
+		var scheme = location.protocol === "https:" ? "wss://" : "ws://";
+		socket = new WebSocket(scheme + location.host + "/ws");
+		socket.onopen = function () {
+			status("Connected");
+			send("gettickers");
+			send("getorderbooks");
+			if (credentials) {
+				send("auth", credentials);
+			}
+		};
+		socket.onclose = function () {
+			stopTimers();
+			status("Disconnected, reconnecting...");
+			setTimeout(connect, 5000);
+		};
+		socket.onmessage = function (msg) {
+			split(msg.data).forEach(handle);
+		};
+	}
+
+	$("login").addEventListener("submit", function (e) {
+		e.preventDefault();
+		credentials = {username: $("username").value, password: sha256($("password").value)};
+		$("password").value = "";
+		send("auth", credentials);
+	});
+
+	connect();
+})();
+`
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/database"
+	dbbolt "github.com/thrasher-corp/gocryptotrader/database/drivers/bolt"
 	dbpsql "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
 	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -57,6 +58,12 @@ func (a *databaseManager) Start() (err error) {
 				Bot.Config.Database.Database,
 				Bot.Config.Database.Driver)
 			dbConn, err = dbsqlite3.Connect()
+		} else if Bot.Config.Database.Driver == database.DBBolt {
+			log.Debugf(log.DatabaseMgr,
+				"Attempting to open database %s utilising %s driver\n",
+				Bot.Config.Database.Database,
+				Bot.Config.Database.Driver)
+			dbConn, err = dbbolt.Connect()
 		}
 		if err != nil {
 			return fmt.Errorf("database failed to connect: %v Some features that utilise a database will be unavailable", err)
@@ -85,7 +92,13 @@ func (a *databaseManager) Stop() error {
 		return errors.New("database manager is already stopping")
 	}
 
-	err := dbConn.SQL.Close()
+	var err error
+	if dbConn.KV != nil {
+		err = dbConn.KV.Close()
+		dbConn.KV = nil
+	} else {
+		err = dbConn.SQL.Close()
+	}
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Failed to close database: %v", err)
 	}
@@ -124,6 +137,11 @@ func (a *databaseManager) checkConnection() {
 	dbConn.Mu.Lock()
 	defer dbConn.Mu.Unlock()
 
+	// embedded databases hold their file open for the life of the connection
+	if dbConn.KV != nil {
+		return
+	}
+
 	err := dbConn.SQL.Ping()
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Database connection error: %v\n", err)
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+var errDataOnlyMode = errors.New("not available when running in data only mode")
+
+// dataOnlyRestrictedMethods lists the gRPC methods which require exchange
+// credentials or place, cancel or withdraw funds
+var dataOnlyRestrictedMethods = map[string]bool{
+	"GetExchangeOTPCode":                true,
+	"GetExchangeOTPCodes":               true,
+	"GetAccountInfo":                    true,
+	"GetAccountInfoStream":              true,
+	"GetOrders":                         true,
+	"GetOrder":                          true,
+	"GetPositions":                      true,
+	"ExportHistory":                     true,
+	"ExportTaxReport":                   true,
+	"SubmitOrder":                       true,
+	"CancelOrder":                       true,
+	"CancelAllOrders":                   true,
+	"SubmitIntent":                      true,
+	"ExecuteTriangularArbitrage":        true,
+	"SubmitAlgoOrder":                   true,
+	"CancelAlgoOrder":                   true,
+	"AddConditionalOrder":               true,
+	"CancelConditionalOrder":            true,
+	"ReloadAutomations":                 true,
+	"StartStrategy":                     true,
+	"SubmitOCO":                         true,
+	"CancelOCO":                         true,
+	"GetCryptocurrencyDepositAddresses": true,
+	"GetCryptocurrencyDepositAddress":   true,
+	"WithdrawCryptocurrencyFunds":       true,
+	"WithdrawFiatFunds":                 true,
+	"GCTScriptExecute":                  true,
+	"GCTScriptUpload":                   true,
+	"GCTScriptAutoLoadToggle":           true,
+}
+
+// dataOnlyRestrictedSubsystems lists the subsystems which cannot be enabled
+// when running in data only mode
+var dataOnlyRestrictedSubsystems = map[string]bool{
+	"orders":             true,
+	"portfolio":          true,
+	"transfer_times":     true,
+	"cold_storage_sweep": true,
+	"conditional_orders": true,
+	"positions":          true,
+	"settlement":         true,
+	"balance_cache":      true,
+	"strategies":         true,
+	"gctscript":          true,
+	"fix_gateway":        true,
+}
+
+// applyDataOnlySettings purges exchange credentials and disables every
+// subsystem which trades, withdraws or requires authenticated access so the
+// instance only collects and serves market data
+func applyDataOnlySettings(s *Settings) {
+	s.ExchangePurgeCredentials = true
+	s.EnableOrderManager = false
+	s.EnablePortfolioManager = false
+	s.EnableDepositAddressManager = false
+	s.EnableTransferTimeManager = false
+	s.EnableColdStorageSweep = false
+	s.EnableConditionalOrders = false
+	s.EnableAutomations = false
+	s.EnablePositions = false
+	s.EnableSettlement = false
+	s.EnableBalanceCache = false
+	s.EnableStrategies = false
+	s.EnableGCTScriptManager = false
+	s.EnableFIXGateway = false
+}
+
+// dataOnlyUnaryInterceptor rejects gRPC requests which are unavailable in
+// data only mode
+func dataOnlyUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if Bot.Settings.EnableDataOnlyMode && dataOnlyRestrictedMethods[path.Base(info.FullMethod)] {
+		return nil, errDataOnlyMode
+	}
+	return handler(ctx, req)
+}
+
+// dataOnlyStreamInterceptor rejects gRPC streams which are unavailable in
+// data only mode
+func dataOnlyStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if Bot.Settings.EnableDataOnlyMode && dataOnlyRestrictedMethods[path.Base(info.FullMethod)] {
+		return errDataOnlyMode
+	}
+	return handler(srv, ss)
+}
+
+// checkDataOnlySubsystem returns an error if the subsystem cannot be enabled
+// in data only mode
+func checkDataOnlySubsystem(subsys string) error {
+	if Bot.Settings.EnableDataOnlyMode && dataOnlyRestrictedSubsystems[strings.ToLower(subsys)] {
+		return errDataOnlyMode
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"google.golang.org/grpc"
+)
+
+func TestApplyDataOnlySettings(t *testing.T) {
+	s := Settings{
+		EnableOrderManager:          true,
+		EnablePortfolioManager:      true,
+		EnableDepositAddressManager: true,
+		EnableTransferTimeManager:   true,
+		EnableColdStorageSweep:      true,
+		EnableConditionalOrders:     true,
+		EnableAutomations:           true,
+		EnablePositions:             true,
+		EnableSettlement:            true,
+		EnableBalanceCache:          true,
+		EnableStrategies:            true,
+		EnableGCTScriptManager:      true,
+		EnableFIXGateway:            true,
+		EnableExchangeSyncManager:   true,
+	}
+	applyDataOnlySettings(&s)
+	if !s.ExchangePurgeCredentials ||
+		s.EnableOrderManager ||
+		s.EnablePortfolioManager ||
+		s.EnableDepositAddressManager ||
+		s.EnableTransferTimeManager ||
+		s.EnableColdStorageSweep ||
+		s.EnableConditionalOrders ||
+		s.EnableAutomations ||
+		s.EnablePositions ||
+		s.EnableSettlement ||
+		s.EnableBalanceCache ||
+		s.EnableStrategies ||
+		s.EnableGCTScriptManager ||
+		s.EnableFIXGateway {
+		t.Errorf("expected trading subsystems to be disabled, got %+v", s)
+	}
+	if !s.EnableExchangeSyncManager {
+		t.Error("expected market data syncing to remain enabled")
+	}
+}
+
+func TestDataOnlyUnaryInterceptor(t *testing.T) {
+	SetupTestHelpers(t)
+	defer func() { Bot.Settings.EnableDataOnlyMode = false }()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	submit := &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTrader/SubmitOrder"}
+	ticker := &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTrader/GetTicker"}
+
+	_, err := dataOnlyUnaryInterceptor(context.Background(), &gctrpc.SubmitOrderRequest{}, submit, handler)
+	if err != nil {
+		t.Error(err)
+	}
+
+	Bot.Settings.EnableDataOnlyMode = true
+	_, err = dataOnlyUnaryInterceptor(context.Background(), &gctrpc.SubmitOrderRequest{}, submit, handler)
+	if err != errDataOnlyMode {
+		t.Errorf("expected %v, got %v", errDataOnlyMode, err)
+	}
+
+	_, err = dataOnlyUnaryInterceptor(context.Background(), &gctrpc.GetTickerRequest{}, ticker, handler)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err = SetSubsystem("orders", true); err != errDataOnlyMode {
+		t.Errorf("expected %v, got %v", errDataOnlyMode, err)
+	}
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (d *derivativesCollector) Started() bool {
+	return atomic.LoadInt32(&d.started) == 1
+}
+
+func (d *derivativesCollector) Start() error {
+	if atomic.AddInt32(&d.started, 1) != 1 {
+		return errors.New("derivatives data collector already started")
+	}
+
+	log.Debugln(log.SyncMgr, "Derivatives data collector starting...")
+	derivative.SetHistoryLength(Bot.Config.DerivativesData.OpenInterestHistory,
+		Bot.Config.DerivativesData.LiquidationHistory)
+	d.shutdown = make(chan struct{})
+	go d.run()
+	return nil
+}
+
+func (d *derivativesCollector) Stop() error {
+	if atomic.AddInt32(&d.stopped, 1) != 1 {
+		return errors.New("derivatives data collector is already stopped")
+	}
+
+	log.Debugln(log.SyncMgr, "Derivatives data collector shutting down...")
+	close(d.shutdown)
+	return nil
+}
+
+func (d *derivativesCollector) run() {
+	log.Debugln(log.SyncMgr, "Derivatives data collector started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.DerivativesData.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&d.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&d.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.SyncMgr, "Derivatives data collector shutdown.")
+	}()
+
+	d.collectAll()
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			d.collectAll()
+		}
+	}
+}
+
+// collectAll collects the open interest and liquidations of every enabled
+// derivatives pair of each loaded exchange which publishes them, and the
+// funding of every enabled pair of each exchange which charges funding or
+// margin interest
+func (d *derivativesCollector) collectAll() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		provider, publishes := exchanges[x].(exchange.DerivativesDataProvider)
+		funder, funds := exchanges[x].(exchange.FundingDataProvider)
+		if !publishes && !funds {
+			continue
+		}
+		authenticated := exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication)
+		assets := exchanges[x].GetAssetTypes()
+		for y := range assets {
+			isDerivative := derivative.IsDerivative(assets[y])
+			if !isDerivative && !funds {
+				continue
+			}
+			pairs := exchanges[x].GetEnabledPairs(assets[y])
+			for z := range pairs {
+				select {
+				case <-d.shutdown:
+					return
+				default:
+				}
+				if publishes && isDerivative {
+					if err := collectDerivativesData(provider, pairs[z], assets[y]); err != nil {
+						log.Errorf(log.SyncMgr, "Derivatives data collector: %s %s %s unable to collect: %v",
+							exchanges[x].GetName(),
+							pairs[z],
+							assets[y],
+							err)
+					}
+				}
+				if funds {
+					if _, err := Bot.FundingRates.Collect(funder, exchanges[x].GetName(), pairs[z], assets[y], authenticated); err != nil {
+						log.Errorf(log.SyncMgr, "Derivatives data collector: %s %s %s unable to collect funding: %v",
+							exchanges[x].GetName(),
+							pairs[z],
+							assets[y],
+							err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// collectDerivativesData requests a contract's open interest and liquidations
+// and processes them into the derivative package's streams
+func collectDerivativesData(provider exchange.DerivativesDataProvider, p currency.Pair, a asset.Item) error {
+	oi, err := provider.GetOpenInterest(p, a)
+	if err != nil {
+		return err
+	}
+	if err = derivative.ProcessOpenInterest(&oi); err != nil {
+		return err
+	}
+
+	liquidations, err := provider.GetLiquidations(p, a)
+	if err != nil {
+		return err
+	}
+	for i := range liquidations {
+		if err = derivative.ProcessLiquidation(&liquidations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
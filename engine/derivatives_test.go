@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+type testDerivativesProvider struct {
+	err error
+}
+
+func (d testDerivativesProvider) GetOpenInterest(p currency.Pair, a asset.Item) (derivative.OpenInterest, error) {
+	return derivative.OpenInterest{
+		Exchange:  "DerivativesTest",
+		Pair:      p,
+		AssetType: a,
+		Amount:    1000,
+	}, d.err
+}
+
+func (d testDerivativesProvider) GetLiquidations(p currency.Pair, a asset.Item) ([]derivative.Liquidation, error) {
+	return []derivative.Liquidation{
+		{
+			Exchange:  "DerivativesTest",
+			Pair:      p,
+			AssetType: a,
+			ID:        "1",
+			Side:      order.Sell,
+			Price:     100,
+			Amount:    5,
+		},
+	}, nil
+}
+
+func TestCollectDerivativesData(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	errTest := errors.New("test error")
+	if err := collectDerivativesData(testDerivativesProvider{err: errTest}, p, asset.PerpetualSwap); err != errTest {
+		t.Errorf("expected %v, got %v", errTest, err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := collectDerivativesData(testDerivativesProvider{}, p, asset.PerpetualSwap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	history, err := derivative.GetOpenInterestHistory("DerivativesTest", p, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[1].Amount != 1000 {
+		t.Errorf("expected 2 open interest samples, got %+v", history)
+	}
+	liquidations, err := derivative.GetLiquidations("DerivativesTest", p, asset.PerpetualSwap, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(liquidations) != 1 {
+		t.Errorf("expected the liquidation to be processed once, got %d", len(liquidations))
+	}
+}
@@ -0,0 +1,7 @@
+package engine
+
+type derivativesCollector struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+}
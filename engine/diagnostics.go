@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const diagnosticsDir = "diagnostics"
+
+// vars for diagnostics bundles
+var (
+	errCaptureDisabled = errors.New("exchange HTTP capture is disabled, enable it with -exchangehttpcapture")
+	errNoCaptures      = errors.New("no failed requests have been captured")
+)
+
+// diagnosticsInfo describes the environment a diagnostics bundle was created
+// in
+type diagnosticsInfo struct {
+	Version   string    `json:"version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	GoVersion string    `json:"go_version"`
+	Created   time.Time `json:"created"`
+	Exchanges []string  `json:"exchanges"`
+}
+
+// CreateDiagnosticsBundle writes the redacted requests and responses of
+// failed exchange calls captured for an exchange, or all exchanges when
+// empty, to a zip file in the data directory so they can be attached to bug
+// reports. The captures are cleared once written and the file's path and the
+// amount of captures written are returned
+func CreateDiagnosticsBundle(exchName string) (string, int, error) {
+	if !request.IsCaptureEnabled() {
+		return "", 0, errCaptureDisabled
+	}
+
+	exchanges := GetExchanges()
+	if exchName != "" {
+		exch := GetExchangeByName(exchName)
+		if exch == nil {
+			return "", 0, ErrExchangeNotFound
+		}
+		exchanges = exchanges[:0:0]
+		exchanges = append(exchanges, exch)
+	}
+
+	info := diagnosticsInfo{
+		Version:   strings.TrimSpace(core.Version(true)),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		Created:   clock.Now(),
+	}
+	var captures []request.Capture
+	var requesters []*request.Requester
+	for x := range exchanges {
+		r := exchanges[x].GetBase().Requester
+		if r == nil {
+			continue
+		}
+		info.Exchanges = append(info.Exchanges, exchanges[x].GetName())
+		captures = append(captures, r.GetCaptures()...)
+		requesters = append(requesters, r)
+	}
+	if len(captures) == 0 {
+		return "", 0, errNoCaptures
+	}
+	sort.Slice(captures, func(i, j int) bool {
+		return captures[i].Time.Before(captures[j].Time)
+	})
+
+	dir := filepath.Join(Bot.Settings.DataDir, diagnosticsDir)
+	if err := common.CreateDir(dir); err != nil {
+		return "", 0, err
+	}
+	name := "all"
+	if exchName != "" {
+		name = strings.ToLower(exchName)
+	}
+	path := filepath.Join(dir,
+		fmt.Sprintf("%s-%s.zip", name, info.Created.Format("20060102-150405")))
+	if err := writeDiagnosticsBundle(path, &info, captures); err != nil {
+		return "", 0, err
+	}
+
+	for x := range requesters {
+		requesters[x].ClearCaptures()
+	}
+	log.Infof(log.ExchangeSys,
+		"Diagnostics bundle of %d failed requests written to %s.\n",
+		len(captures),
+		path)
+	return path, len(captures), nil
+}
+
+func writeDiagnosticsBundle(path string, info *diagnosticsInfo, captures []request.Capture) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	z := zip.NewWriter(f)
+	files := []struct {
+		name string
+		v    interface{}
+	}{
+		{"info.json", info},
+		{"captures.json", captures},
+	}
+	for x := range files {
+		var b []byte
+		b, err = json.MarshalIndent(files[x].v, "", " ")
+		if err != nil {
+			break
+		}
+		var w io.Writer
+		w, err = z.Create(files[x].name)
+		if err != nil {
+			break
+		}
+		if _, err = w.Write(b); err != nil {
+			break
+		}
+	}
+	if closeErr := z.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestCreateDiagnosticsBundle(t *testing.T) {
+	SetupTestHelpers(t)
+	if _, _, err := CreateDiagnosticsBundle(testExchange); err != errCaptureDisabled {
+		t.Errorf("expected %v, got %v", errCaptureDisabled, err)
+	}
+
+	request.EnableCapture(true)
+	defer request.EnableCapture(false)
+	if _, _, err := CreateDiagnosticsBundle("nope"); err != ErrExchangeNotFound {
+		t.Errorf("expected %v, got %v", ErrExchangeNotFound, err)
+	}
+	if _, _, err := CreateDiagnosticsBundle(testExchange); err != errNoCaptures {
+		t.Errorf("expected %v, got %v", errNoCaptures, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	err := GetExchangeByName(testExchange).GetBase().Requester.SendPayload(context.Background(),
+		&request.Item{
+			Method: http.MethodGet,
+			Path:   server.URL + "?key=secret",
+		})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	path, captures, err := CreateDiagnosticsBundle(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+	if captures != 1 {
+		t.Errorf("expected 1 capture, got %d", captures)
+	}
+
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer z.Close()
+	if len(z.File) != 2 || z.File[0].Name != "info.json" || z.File[1].Name != "captures.json" {
+		t.Fatalf("unexpected bundle contents %v", z.File)
+	}
+	f, err := z.File[1].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var c []request.Capture
+	if err = json.NewDecoder(f).Decode(&c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != 1 || c[0].URL != server.URL+"?key=%5BREDACTED%5D" || c[0].StatusCode != http.StatusBadRequest {
+		t.Errorf("unexpected captures %+v", c)
+	}
+
+	if _, _, err = CreateDiagnosticsBundle(testExchange); err != errNoCaptures {
+		t.Errorf("expected captures to be cleared once written, got %v", err)
+	}
+}
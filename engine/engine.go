@@ -7,6 +7,7 @@ import (
 	"log"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency/coinmarketcap"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	gctlog "github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
@@ -30,14 +33,51 @@ type Engine struct {
 	Config                      *config.Config
 	Portfolio                   *portfolio.Base
 	ExchangeCurrencyPairManager *ExchangeCurrencyPairSyncer
+	StartupCoordinator          startupCoordinator
 	NTPManager                  ntpManager
 	ConnectionManager           connectionManager
 	DatabaseManager             databaseManager
 	GctScriptManager            gctScriptManager
 	OrderManager                orderManager
+	IntentManager               intentManager
+	ChaseManager                chaseManager
+	AlgoManager                 algoManager
+	OCOManager                  ocoManager
+	ConditionalManager          conditionalManager
+	AutomationManager           automationManager
+	AllocationManager           allocationManager
 	PortfolioManager            portfolioManager
+	TransferTimeManager         transferTimeManager
+	SweepManager                sweepManager
+	LiquidityScreener           liquidityScreener
+	TriangularArbDetector       triangularArbDetector
+	EquityManager               equityManager
+	AuctionCollector            auctionCollector
+	FundingHistorySyncer        fundingHistorySyncer
+	PositionManager             positionManager
+	TradeHistory                tradeHistoryStore
+	FundingRates                fundingRatesStore
+	DerivativesCollector        derivativesCollector
+	ExchangeHealthMonitor       exchangeHealthMonitor
+	TimeSyncChecker             timeSyncChecker
+	MaintenanceManager          maintenanceManager
+	FIXGateway                  fixGateway
+	RiskManager                 riskManager
+	ResourceMonitor             resourceMonitor
+	SettlementManager           settlementManager
+	DailyReporter               dailyReporter
+	EventBus                    eventBusManager
+	TimeSeriesWriter            timeSeriesWriter
+	BalanceCache                balanceCache
+	SnapshotExporter            snapshotExporter
+	KeyValidator                keyValidator
+	StrategyManager             strategyManager
+	BotStateManager             botStateManager
+	RequestAuditor              requestAuditor
+	KeyMonitor                  keyMonitor
 	CommsManager                commsManager
 	exchangeManager             exchangeManager
+	tenants                     []*tenant
 	DepositAddressManager       *DepositAddressManager
 	Settings                    Settings
 	Uptime                      time.Time
@@ -122,6 +162,31 @@ func ValidateSettings(b *Engine, s *Settings) {
 	b.Settings.MaxVirtualMachines = s.MaxVirtualMachines
 	b.Settings.EnableDispatcher = s.EnableDispatcher
 	b.Settings.EnablePortfolioManager = s.EnablePortfolioManager
+	b.Settings.EnableTransferTimeManager = s.EnableTransferTimeManager
+	b.Settings.EnableColdStorageSweep = s.EnableColdStorageSweep
+	b.Settings.EnableLiquidityScreen = s.EnableLiquidityScreen
+	b.Settings.EnableTriangularArbitrage = s.EnableTriangularArbitrage
+	b.Settings.EnableEquitySnapshots = s.EnableEquitySnapshots
+	b.Settings.EnableConditionalOrders = s.EnableConditionalOrders
+	b.Settings.EnableAutomations = s.EnableAutomations
+	b.Settings.EnableAuctionHistory = s.EnableAuctionHistory
+	b.Settings.EnableFundingHistory = s.EnableFundingHistory
+	b.Settings.EnablePositions = s.EnablePositions
+	b.Settings.EnableDerivativesData = s.EnableDerivativesData
+	b.Settings.EnableExchangeHealth = s.EnableExchangeHealth
+	b.Settings.EnableTimeSync = s.EnableTimeSync
+	b.Settings.EnableMaintenance = s.EnableMaintenance
+	b.Settings.EnableFIXGateway = s.EnableFIXGateway
+	b.Settings.EnableResourceMonitor = s.EnableResourceMonitor
+	b.Settings.EnableSettlement = s.EnableSettlement
+	b.Settings.EnableBalanceCache = s.EnableBalanceCache
+	b.Settings.EnableSnapshotExport = s.EnableSnapshotExport
+	b.Settings.EnableStrategies = s.EnableStrategies
+	b.Settings.EnableRequestAudit = s.EnableRequestAudit
+	b.Settings.EnableBotState = s.EnableBotState
+	b.Settings.EnableDailyReport = s.EnableDailyReport
+	b.Settings.EnableEventBus = s.EnableEventBus
+	b.Settings.EnableTimeSeries = s.EnableTimeSeries
 	b.Settings.WithdrawCacheSize = s.WithdrawCacheSize
 	if b.Settings.EnablePortfolioManager {
 		if b.Settings.PortfolioManagerDelay != time.Duration(0) && s.PortfolioManagerDelay > 0 {
@@ -195,8 +260,11 @@ func ValidateSettings(b *Engine, s *Settings) {
 	b.Settings.EnableExchangeVerbose = s.EnableExchangeVerbose
 	b.Settings.EnableExchangeHTTPRateLimiter = s.EnableExchangeHTTPRateLimiter
 	b.Settings.EnableExchangeHTTPDebugging = s.EnableExchangeHTTPDebugging
+	b.Settings.EnableExchangeHTTPCapture = s.EnableExchangeHTTPCapture
+	request.EnableCapture(s.EnableExchangeHTTPCapture)
 	b.Settings.DisableExchangeAutoPairUpdates = s.DisableExchangeAutoPairUpdates
 	b.Settings.ExchangePurgeCredentials = s.ExchangePurgeCredentials
+	b.Settings.ExchangeKeyValidation = s.ExchangeKeyValidation
 	b.Settings.EnableWebsocketRoutine = s.EnableWebsocketRoutine
 
 	// Checks if the flag values are different from the defaults
@@ -226,7 +294,6 @@ func ValidateSettings(b *Engine, s *Settings) {
 	} else {
 		b.Settings.GlobalHTTPTimeout = b.Config.GlobalHTTPTimeout
 	}
-	common.HTTPClient = common.NewHTTPClientWithTimeout(b.Settings.GlobalHTTPTimeout)
 
 	b.Settings.GlobalHTTPUserAgent = s.GlobalHTTPUserAgent
 	if b.Settings.GlobalHTTPUserAgent != "" {
@@ -234,8 +301,41 @@ func ValidateSettings(b *Engine, s *Settings) {
 	}
 
 	b.Settings.GlobalHTTPProxy = s.GlobalHTTPProxy
+	if b.Settings.GlobalHTTPProxy == "" {
+		b.Settings.GlobalHTTPProxy = b.Config.GlobalHTTPClient.Proxy
+	}
+
+	httpClient, err := common.NewHTTPClient(&common.HTTPClientOptions{
+		Timeout:             b.Settings.GlobalHTTPTimeout,
+		Proxy:               b.Settings.GlobalHTTPProxy,
+		CACertFile:          b.Config.GlobalHTTPClient.CACertFile,
+		MaxIdleConns:        b.Config.GlobalHTTPClient.MaxIdleConns,
+		MaxIdleConnsPerHost: b.Config.GlobalHTTPClient.MaxIdleConnsPerHost,
+		IdleConnTimeout:     b.Config.GlobalHTTPClient.IdleConnTimeout,
+		KeepAlive:           b.Config.GlobalHTTPClient.KeepAlive,
+		TLSHandshakeTimeout: b.Config.GlobalHTTPClient.TLSHandshakeTimeout,
+		DisableKeepAlives:   b.Config.GlobalHTTPClient.DisableKeepAlives,
+	})
+	if err != nil {
+		gctlog.Errorf(gctlog.Global,
+			"Failed to configure common HTTP client, using default client. Err: %s\n",
+			err)
+		httpClient = common.NewHTTPClientWithTimeout(b.Settings.GlobalHTTPTimeout)
+	}
+	common.HTTPClient = httpClient
 	b.Settings.DispatchMaxWorkerAmount = s.DispatchMaxWorkerAmount
 	b.Settings.DispatchJobsLimit = s.DispatchJobsLimit
+	b.Settings.TickerHistoryLength = s.TickerHistoryLength
+	ticker.SetHistoryLength(s.TickerHistoryLength)
+	b.Settings.TradeBufferLength = s.TradeBufferLength
+	trade.SetBufferLength(s.TradeBufferLength)
+	b.Settings.EnableTradePersistence = s.EnableTradePersistence
+
+	if s.EnableDataOnlyMode {
+		b.Settings.EnableDataOnlyMode = true
+		applyDataOnlySettings(&b.Settings)
+		gctscript.GCTScriptConfig.Enabled = false
+	}
 }
 
 // PrintSettings returns the engine settings
@@ -245,11 +345,37 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "- CORE SETTINGS:")
 	gctlog.Debugf(gctlog.Global, "\t Verbose mode: %v", s.Verbose)
 	gctlog.Debugf(gctlog.Global, "\t Enable dry run mode: %v", s.EnableDryRun)
+	gctlog.Debugf(gctlog.Global, "\t Enable data only mode: %v", s.EnableDataOnlyMode)
 	gctlog.Debugf(gctlog.Global, "\t Enable all exchanges: %v", s.EnableAllExchanges)
 	gctlog.Debugf(gctlog.Global, "\t Enable all pairs: %v", s.EnableAllPairs)
 	gctlog.Debugf(gctlog.Global, "\t Enable coinmarketcap analaysis: %v", s.EnableCoinmarketcapAnalysis)
 	gctlog.Debugf(gctlog.Global, "\t Enable portfolio manager: %v", s.EnablePortfolioManager)
 	gctlog.Debugf(gctlog.Global, "\t Portfolio manager sleep delay: %v\n", s.PortfolioManagerDelay)
+	gctlog.Debugf(gctlog.Global, "\t Enable transfer time manager: %v", s.EnableTransferTimeManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable cold storage sweep: %v", s.EnableColdStorageSweep)
+	gctlog.Debugf(gctlog.Global, "\t Enable liquidity screen: %v", s.EnableLiquidityScreen)
+	gctlog.Debugf(gctlog.Global, "\t Enable triangular arbitrage: %v", s.EnableTriangularArbitrage)
+	gctlog.Debugf(gctlog.Global, "\t Enable equity snapshots: %v", s.EnableEquitySnapshots)
+	gctlog.Debugf(gctlog.Global, "\t Enable conditional orders: %v", s.EnableConditionalOrders)
+	gctlog.Debugf(gctlog.Global, "\t Enable automations: %v", s.EnableAutomations)
+	gctlog.Debugf(gctlog.Global, "\t Enable auction history: %v", s.EnableAuctionHistory)
+	gctlog.Debugf(gctlog.Global, "\t Enable funding history: %v", s.EnableFundingHistory)
+	gctlog.Debugf(gctlog.Global, "\t Enable positions: %v", s.EnablePositions)
+	gctlog.Debugf(gctlog.Global, "\t Enable derivatives data: %v", s.EnableDerivativesData)
+	gctlog.Debugf(gctlog.Global, "\t Enable exchange health monitor: %v", s.EnableExchangeHealth)
+	gctlog.Debugf(gctlog.Global, "\t Enable time sync checker: %v", s.EnableTimeSync)
+	gctlog.Debugf(gctlog.Global, "\t Enable maintenance manager: %v", s.EnableMaintenance)
+	gctlog.Debugf(gctlog.Global, "\t Enable FIX gateway: %v", s.EnableFIXGateway)
+	gctlog.Debugf(gctlog.Global, "\t Enable resource monitor: %v", s.EnableResourceMonitor)
+	gctlog.Debugf(gctlog.Global, "\t Enable settlement: %v", s.EnableSettlement)
+	gctlog.Debugf(gctlog.Global, "\t Enable balance cache: %v", s.EnableBalanceCache)
+	gctlog.Debugf(gctlog.Global, "\t Enable snapshot export: %v", s.EnableSnapshotExport)
+	gctlog.Debugf(gctlog.Global, "\t Enable strategies: %v", s.EnableStrategies)
+	gctlog.Debugf(gctlog.Global, "\t Enable request audit: %v", s.EnableRequestAudit)
+	gctlog.Debugf(gctlog.Global, "\t Enable bot state persistence: %v", s.EnableBotState)
+	gctlog.Debugf(gctlog.Global, "\t Enable daily report: %v", s.EnableDailyReport)
+	gctlog.Debugf(gctlog.Global, "\t Enable event bus: %v", s.EnableEventBus)
+	gctlog.Debugf(gctlog.Global, "\t Enable time-series: %v", s.EnableTimeSeries)
 	gctlog.Debugf(gctlog.Global, "\t Enable gPRC: %v", s.EnableGRPC)
 	gctlog.Debugf(gctlog.Global, "\t Enable gRPC Proxy: %v", s.EnableGRPCProxy)
 	gctlog.Debugf(gctlog.Global, "\t Enable websocket RPC: %v", s.EnableWebsocketRPC)
@@ -266,6 +392,9 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable dispatcher: %v", s.EnableDispatcher)
 	gctlog.Debugf(gctlog.Global, "\t Dispatch package max worker amount: %d", s.DispatchMaxWorkerAmount)
 	gctlog.Debugf(gctlog.Global, "\t Dispatch package jobs limit: %d", s.DispatchJobsLimit)
+	gctlog.Debugf(gctlog.Global, "\t Ticker history length: %d", s.TickerHistoryLength)
+	gctlog.Debugf(gctlog.Global, "\t Trade buffer length: %d", s.TradeBufferLength)
+	gctlog.Debugf(gctlog.Global, "\t Enable trade persistence: %v", s.EnableTradePersistence)
 	gctlog.Debugf(gctlog.Global, "- EXCHANGE SYNCER SETTINGS:\n")
 	gctlog.Debugf(gctlog.Global, "\t Exchange sync continuously: %v\n", s.SyncContinuously)
 	gctlog.Debugf(gctlog.Global, "\t Exchange sync workers: %v\n", s.SyncWorkers)
@@ -284,7 +413,9 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange websocket support: %v", s.EnableExchangeWebsocketSupport)
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange verbose mode: %v", s.EnableExchangeVerbose)
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange HTTP rate limiter: %v", s.EnableExchangeHTTPRateLimiter)
+	gctlog.Debugf(gctlog.Global, "\t Enable exchange API key validation: %v", s.ExchangeKeyValidation)
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange HTTP debugging: %v", s.EnableExchangeHTTPDebugging)
+	gctlog.Debugf(gctlog.Global, "\t Enable exchange HTTP capture: %v", s.EnableExchangeHTTPCapture)
 	gctlog.Debugf(gctlog.Global, "\t Max HTTP request jobs: %v", s.MaxHTTPRequestJobsLimit)
 	gctlog.Debugf(gctlog.Global, "\t HTTP request max retry attempts: %v", s.RequestMaxRetryAttempts)
 	gctlog.Debugf(gctlog.Global, "\t HTTP timeout: %v", s.HTTPTimeout)
@@ -358,12 +489,50 @@ func (e *Engine) Start() error {
 		e.Config.PurgeExchangeAPICredentials()
 	}
 
+	// authenticated requests are audited from the first made while setting
+	// up exchanges
+	if e.Settings.EnableRequestAudit && e.Config.RequestAudit.Enabled {
+		if err := e.RequestAuditor.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Request auditor unable to start: %v", err)
+		}
+	}
+
+	// exchange currency codes are translated from the first tickers and
+	// balances processed while setting up exchanges
+	currency.SetCodeTranslations(e.Config.Currency.CodeTranslations())
+
 	gctlog.Debugln(gctlog.Global, "Setting up exchanges..")
+	e.StartupCoordinator.begin(&e.Config.Startup)
 	SetupExchanges()
 	if Bot.exchangeManager.Len() == 0 {
 		return errors.New("no exchanges are loaded")
 	}
 
+	if e.Settings.ExchangeKeyValidation {
+		gctlog.Debugln(gctlog.Global, "Validating exchange API keys..")
+		e.KeyValidator.Validate()
+	}
+
+	if e.Config.CandleBuilder.Enabled {
+		trade.SetCandleIntervals(e.Config.CandleBuilder.Intervals, e.Config.CandleBuilder.Length)
+		gctlog.Debugf(gctlog.Global, "Building candles from trades at intervals: %v\n", e.Config.CandleBuilder.Intervals)
+	}
+
+	if e.Settings.EnableTradePersistence {
+		n, err := trade.Load(filepath.Join(e.Settings.DataDir, tradesFileName))
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to load persisted trades: %v\n", err)
+		} else {
+			gctlog.Debugf(gctlog.Global, "Loaded %d persisted trades.\n", n)
+		}
+	}
+
+	if e.Settings.EnableEventBus && e.Config.EventBus.Enabled {
+		if err := e.EventBus.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Event bus unable to start: %v\n", err)
+		}
+	}
+
 	if e.Settings.EnableCommsRelayer {
 		if err := e.CommsManager.Start(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Communications manager unable to start: %v\n", err)
@@ -391,6 +560,11 @@ func (e *Engine) Start() error {
 		gctlog.Errorf(gctlog.Global, "Currency updater system failed to start %v", err)
 	}
 
+	if e.Config.Currency.Pegs.Valuation || e.Config.Currency.Pegs.Arbitrage {
+		currency.SetPegGroups(e.Config.Currency.Pegs.PegGroups())
+	}
+
+	e.tenants = newTenants(e.Config.Tenants)
 	if e.Settings.EnableGRPC {
 		go StartRPCServer()
 	}
@@ -404,12 +578,96 @@ func (e *Engine) Start() error {
 		StartWebsocketHandler()
 	}
 
+	if e.Settings.EnableDailyReport && e.Config.DailyReport.Enabled {
+		if err = e.DailyReporter.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Daily reporter unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableBalanceCache && e.Config.BalanceCache.Enabled {
+		if err = e.BalanceCache.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Balance cache unable to start: %v", err)
+		}
+	}
+
 	if e.Settings.EnablePortfolioManager {
 		if err = e.PortfolioManager.Start(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Fund manager unable to start: %v", err)
 		}
 	}
 
+	if e.Settings.EnableTransferTimeManager {
+		if err = e.TransferTimeManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Transfer time manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableColdStorageSweep && e.Config.ColdStorageSweep.Enabled {
+		if err = e.SweepManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Cold storage sweep manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableLiquidityScreen && e.Config.LiquidityScreen.Enabled {
+		if err = e.LiquidityScreener.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Liquidity screener unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableTriangularArbitrage && e.Config.TriangularArb.Enabled {
+		if err = e.TriangularArbDetector.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Triangular arbitrage detector unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableEquitySnapshots && e.Config.EquitySnapshot.Enabled {
+		if err = e.EquityManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Equity snapshot manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableAuctionHistory && e.Config.AuctionHistory.Enabled {
+		if err = e.AuctionCollector.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Auction history collector unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableFundingHistory && e.Config.FundingHistory.Enabled {
+		if err = e.FundingHistorySyncer.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Funding history syncer unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableDerivativesData && e.Config.DerivativesData.Enabled {
+		if err = e.DerivativesCollector.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Derivatives data collector unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableExchangeHealth && e.Config.ExchangeHealth.Enabled {
+		if err = e.ExchangeHealthMonitor.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Exchange health monitor unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableTimeSync && e.Config.TimeSync.Enabled {
+		if err = e.TimeSyncChecker.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Time sync checker unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableMaintenance && e.Config.Maintenance.Enabled {
+		if err = e.MaintenanceManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Maintenance manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableResourceMonitor && e.Config.ResourceMonitor.Enabled {
+		if err = e.ResourceMonitor.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Resource monitor unable to start: %v", err)
+		}
+	}
+
 	if e.Settings.EnableDepositAddressManager {
 		e.DepositAddressManager = new(DepositAddressManager)
 		go e.DepositAddressManager.Sync()
@@ -421,6 +679,62 @@ func (e *Engine) Start() error {
 		}
 	}
 
+	if e.Settings.EnableConditionalOrders && e.Config.ConditionalOrders.Enabled {
+		if err = e.ConditionalManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Conditional order manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableAutomations && e.Config.Automations.Enabled {
+		if err = e.AutomationManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Automation manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnablePositions && e.Config.Positions.Enabled {
+		if err = e.PositionManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Position manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableSettlement && e.Config.Settlement.Enabled {
+		if err = e.SettlementManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Settlement manager unable to start: %v", err)
+		}
+	}
+
+	// state is restored once the portfolio is seeded and before strategies
+	// start so they resume where they left off
+	if e.Settings.EnableBotState && e.Config.BotState.Enabled {
+		if err = e.BotStateManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Bot state manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableStrategies && e.Config.Strategies.Enabled {
+		if err = e.StrategyManager.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Strategy manager unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableFIXGateway && e.Config.FIXGateway.Enabled {
+		if err = e.FIXGateway.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "FIX gateway unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableSnapshotExport && e.Config.SnapshotExport.Enabled {
+		if err = e.SnapshotExporter.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Snapshot exporter unable to start: %v", err)
+		}
+	}
+
+	if e.Settings.EnableTimeSeries && e.Config.TimeSeries.Enabled {
+		if err = e.TimeSeriesWriter.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Time-series writer unable to start: %v", err)
+		}
+	}
+
 	if e.Settings.EnableExchangeSyncManager {
 		exchangeSyncCfg := CurrencyPairSyncerConfig{
 			SyncTicker:       e.Settings.EnableTickerSyncing,
@@ -466,12 +780,28 @@ func (e *Engine) Stop() {
 	if len(portfolio.Portfolio.Addresses) != 0 {
 		e.Config.Portfolio = portfolio.Portfolio
 	}
+	storeTenantPortfolios(e.Config.Tenants, e.tenants)
 
 	if e.GctScriptManager.Started() {
 		if err := e.GctScriptManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "GCTScript manager unable to stop. Error: %v", err)
 		}
 	}
+	if e.FIXGateway.Started() {
+		if err := e.FIXGateway.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "FIX gateway unable to stop. Error: %v", err)
+		}
+	}
+	if e.StrategyManager.Started() {
+		if err := e.StrategyManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Strategy manager unable to stop. Error: %v", err)
+		}
+	}
+	if e.BotStateManager.Started() {
+		if err := e.BotStateManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Bot state manager unable to stop. Error: %v", err)
+		}
+	}
 	if e.OrderManager.Started() {
 		if err := e.OrderManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Order manager unable to stop. Error: %v", err)
@@ -490,18 +820,150 @@ func (e *Engine) Stop() {
 		}
 	}
 
+	if e.EventBus.Started() {
+		if err := e.EventBus.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Event bus unable to stop. Error: %v", err)
+		}
+	}
+
 	if e.PortfolioManager.Started() {
 		if err := e.PortfolioManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Fund manager unable to stop. Error: %v", err)
 		}
 	}
 
+	if e.TransferTimeManager.Started() {
+		if err := e.TransferTimeManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Transfer time manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.SweepManager.Started() {
+		if err := e.SweepManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Cold storage sweep manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.LiquidityScreener.Started() {
+		if err := e.LiquidityScreener.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Liquidity screener unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.TriangularArbDetector.Started() {
+		if err := e.TriangularArbDetector.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Triangular arbitrage detector unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.ConditionalManager.Started() {
+		if err := e.ConditionalManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Conditional order manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.AutomationManager.Started() {
+		if err := e.AutomationManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Automation manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.PositionManager.Started() {
+		if err := e.PositionManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Position manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.EquityManager.Started() {
+		if err := e.EquityManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Equity snapshot manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.AuctionCollector.Started() {
+		if err := e.AuctionCollector.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Auction history collector unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.FundingHistorySyncer.Started() {
+		if err := e.FundingHistorySyncer.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Funding history syncer unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.DerivativesCollector.Started() {
+		if err := e.DerivativesCollector.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Derivatives data collector unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.ExchangeHealthMonitor.Started() {
+		if err := e.ExchangeHealthMonitor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Exchange health monitor unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.TimeSyncChecker.Started() {
+		if err := e.TimeSyncChecker.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Time sync checker unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.MaintenanceManager.Started() {
+		if err := e.MaintenanceManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Maintenance manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.ResourceMonitor.Started() {
+		if err := e.ResourceMonitor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Resource monitor unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.SettlementManager.Started() {
+		if err := e.SettlementManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Settlement manager unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.DailyReporter.Started() {
+		if err := e.DailyReporter.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Daily reporter unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.BalanceCache.Started() {
+		if err := e.BalanceCache.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Balance cache unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.SnapshotExporter.Started() {
+		if err := e.SnapshotExporter.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Snapshot exporter unable to stop. Error: %v", err)
+		}
+	}
+
+	if e.TimeSeriesWriter.Started() {
+		if err := e.TimeSeriesWriter.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Time-series writer unable to stop. Error: %v", err)
+		}
+	}
+
 	if e.ConnectionManager.Started() {
 		if err := e.ConnectionManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Connection manager unable to stop. Error: %v", err)
 		}
 	}
 
+	if e.RequestAuditor.Started() {
+		if err := e.RequestAuditor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Request auditor unable to stop. Error: %v", err)
+		}
+	}
+
 	if e.DatabaseManager.Started() {
 		if err := e.DatabaseManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Database manager unable to stop. Error: %v", err)
@@ -514,6 +976,12 @@ func (e *Engine) Stop() {
 		}
 	}
 
+	if e.Settings.EnableTradePersistence {
+		if err := trade.Save(filepath.Join(e.Settings.DataDir, tradesFileName)); err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to persist trades. Error: %v", err)
+		}
+	}
+
 	if !e.Settings.EnableDryRun {
 		err := e.Config.SaveConfig(e.Settings.ConfigFile, false)
 		if err != nil {
@@ -530,3 +998,67 @@ func (e *Engine) Stop() {
 		log.Printf("Failed to close logger. Error: %v\n", err)
 	}
 }
+
+// configManagers returns the settings of the managers which run when enabled
+// in the config, keyed by the name used to disable them
+func (s *Settings) configManagers() map[string]*bool {
+	return map[string]*bool{
+		"transfertimemanager": &s.EnableTransferTimeManager,
+		"coldstoragesweep":    &s.EnableColdStorageSweep,
+		"liquidityscreen":     &s.EnableLiquidityScreen,
+		"triangulararbitrage": &s.EnableTriangularArbitrage,
+		"equitysnapshots":     &s.EnableEquitySnapshots,
+		"conditionalorders":   &s.EnableConditionalOrders,
+		"automations":         &s.EnableAutomations,
+		"auctionhistory":      &s.EnableAuctionHistory,
+		"fundinghistory":      &s.EnableFundingHistory,
+		"positions":           &s.EnablePositions,
+		"derivativesdata":     &s.EnableDerivativesData,
+		"exchangehealth":      &s.EnableExchangeHealth,
+		"timesync":            &s.EnableTimeSync,
+		"maintenance":         &s.EnableMaintenance,
+		"fixgateway":          &s.EnableFIXGateway,
+		"resourcemonitor":     &s.EnableResourceMonitor,
+		"settlement":          &s.EnableSettlement,
+		"balancecache":        &s.EnableBalanceCache,
+		"snapshotexport":      &s.EnableSnapshotExport,
+		"strategies":          &s.EnableStrategies,
+		"requestaudit":        &s.EnableRequestAudit,
+		"dailyreport":         &s.EnableDailyReport,
+		"eventbus":            &s.EnableEventBus,
+		"timeseries":          &s.EnableTimeSeries,
+		"botstate":            &s.EnableBotState,
+	}
+}
+
+// ConfigManagerNames returns the sorted names of the managers which run when
+// enabled in the config
+func ConfigManagerNames() []string {
+	var names []string
+	for name := range new(Settings).configManagers() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnableConfigManagers enables the managers which run when enabled in the
+// config, apart from those in the comma separated disabled list
+func (s *Settings) EnableConfigManagers(disabled string) error {
+	managers := s.configManagers()
+	for _, enabled := range managers {
+		*enabled = true
+	}
+	for _, name := range strings.Split(disabled, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		enabled, ok := managers[name]
+		if !ok {
+			return fmt.Errorf("cannot disable unknown manager %s", name)
+		}
+		*enabled = false
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package engine
+
+import "testing"
+
+func TestEnableConfigManagers(t *testing.T) {
+	var s Settings
+	if err := s.EnableConfigManagers(" Positions,fixgateway,"); err != nil {
+		t.Fatal(err)
+	}
+	if s.EnablePositions || s.EnableFIXGateway {
+		t.Error("expected the listed managers to be disabled")
+	}
+	if !s.EnableStrategies || !s.EnableBotState {
+		t.Error("expected the remaining managers to be enabled")
+	}
+	if len(ConfigManagerNames()) != len(s.configManagers()) {
+		t.Error("expected every manager to be named")
+	}
+
+	if err := s.EnableConfigManagers("positions,unknown"); err == nil {
+		t.Error("expected an error disabling an unknown manager")
+	}
+}
@@ -13,11 +13,37 @@ type Settings struct {
 
 	// Core Settings
 	EnableDryRun                bool
+	EnableDataOnlyMode          bool
 	EnableAllExchanges          bool
 	EnableAllPairs              bool
 	EnableCoinmarketcapAnalysis bool
 	EnablePortfolioManager      bool
 	PortfolioManagerDelay       time.Duration
+	EnableTransferTimeManager   bool
+	EnableColdStorageSweep      bool
+	EnableLiquidityScreen       bool
+	EnableTriangularArbitrage   bool
+	EnableEquitySnapshots       bool
+	EnableConditionalOrders     bool
+	EnableAutomations           bool
+	EnableAuctionHistory        bool
+	EnableFundingHistory        bool
+	EnablePositions             bool
+	EnableDerivativesData       bool
+	EnableExchangeHealth        bool
+	EnableTimeSync              bool
+	EnableMaintenance           bool
+	EnableFIXGateway            bool
+	EnableResourceMonitor       bool
+	EnableSettlement            bool
+	EnableBalanceCache          bool
+	EnableSnapshotExport        bool
+	EnableStrategies            bool
+	EnableRequestAudit          bool
+	EnableBotState              bool
+	EnableDailyReport           bool
+	EnableEventBus              bool
+	EnableTimeSeries            bool
 	EnableGRPC                  bool
 	EnableGRPCProxy             bool
 	EnableWebsocketRPC          bool
@@ -52,8 +78,10 @@ type Settings struct {
 	// Exchange tuning settings
 	EnableExchangeHTTPRateLimiter  bool
 	EnableExchangeHTTPDebugging    bool
+	EnableExchangeHTTPCapture      bool
 	EnableExchangeVerbose          bool
 	ExchangePurgeCredentials       bool
+	ExchangeKeyValidation          bool
 	EnableExchangeAutoPairUpdates  bool
 	DisableExchangeAutoPairUpdates bool
 	EnableExchangeRESTSupport      bool
@@ -76,6 +104,13 @@ type Settings struct {
 	DispatchMaxWorkerAmount int
 	DispatchJobsLimit       int
 
+	// Ticker settings
+	TickerHistoryLength int
+
+	// Trade feed settings
+	TradeBufferLength      int
+	EnableTradePersistence bool
+
 	// GCTscript settings
 	MaxVirtualMachines uint
 
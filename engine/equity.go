@@ -0,0 +1,248 @@
+package engine
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/equity"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (e *equityManager) Started() bool {
+	return atomic.LoadInt32(&e.started) == 1
+}
+
+func (e *equityManager) Start() error {
+	if !Bot.DatabaseManager.Started() {
+		return errors.New("equity snapshot manager requires the database manager")
+	}
+	if atomic.AddInt32(&e.started, 1) != 1 {
+		return errors.New("equity snapshot manager already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Equity snapshot manager starting...")
+	e.shutdown = make(chan struct{})
+	go e.run()
+	return nil
+}
+
+func (e *equityManager) Stop() error {
+	if atomic.AddInt32(&e.stopped, 1) != 1 {
+		return errors.New("equity snapshot manager is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Equity snapshot manager shutting down...")
+	close(e.shutdown)
+	return nil
+}
+
+func (e *equityManager) run() {
+	log.Debugln(log.PortfolioMgr, "Equity snapshot manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.EquitySnapshot.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&e.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&e.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Equity snapshot manager shutdown.")
+	}()
+
+	for {
+		select {
+		case <-e.shutdown:
+			return
+		case t := <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			snapshots := e.snapshot(t, Bot.Config.EquitySnapshot.Currency)
+			for i := range Bot.Config.EquitySnapshot.Numeraires {
+				snapshots = append(snapshots,
+					e.snapshot(t, Bot.Config.EquitySnapshot.Numeraires[i])...)
+			}
+			if err := equity.Insert(snapshots...); err != nil {
+				log.Errorf(log.PortfolioMgr,
+					"Equity snapshot manager: unable to store snapshots: %v\n",
+					err)
+			}
+		}
+	}
+}
+
+// snapshot values the portfolio and each strategy's positions in the target
+// currency
+func (e *equityManager) snapshot(t time.Time, target currency.Code) []equity.Snapshot {
+	holdings := make(map[currency.Code]float64)
+	if Bot.Portfolio != nil {
+		holdings = Bot.Portfolio.GetExchangePortfolio()
+		for code, amount := range Bot.Portfolio.GetPersonalPortfolio() {
+			holdings[code] += amount
+		}
+	}
+
+	p := equity.Snapshot{
+		Portfolio: equityPortfolioName,
+		Currency:  target.String(),
+		Time:      t,
+	}
+	for code, amount := range holdings {
+		if code.Match(target) {
+			p.Balance += amount
+		}
+		value, ok := convertValue(amount, code, target)
+		if !ok {
+			log.Debugf(log.PortfolioMgr,
+				"Equity snapshot manager: unable to value %v %s in %s, excluding.\n",
+				amount,
+				code,
+				target)
+			continue
+		}
+		p.Equity += value
+	}
+	snapshots := []equity.Snapshot{p}
+
+	// the balance of a strategy is its realised profit and loss, equity
+	// includes the unrealised profit and loss of its open positions
+	strategies := make(map[string]*equity.Snapshot)
+	var names []string
+	positions := Bot.AllocationManager.GetPositions()
+	for i := range positions {
+		s, ok := strategies[positions[i].Strategy]
+		if !ok {
+			s = &equity.Snapshot{
+				Portfolio: equityStrategyPrefix + positions[i].Strategy,
+				Currency:  target.String(),
+				Time:      t,
+			}
+			strategies[positions[i].Strategy] = s
+			names = append(names, positions[i].Strategy)
+		}
+
+		pnl := positions[i].RealisedPNL
+		realised, ok := convertValue(pnl, positions[i].Pair.Quote, target)
+		if !ok {
+			continue
+		}
+		s.Balance += realised
+		if positions[i].Amount == 0 {
+			s.Equity += realised
+			continue
+		}
+		tick, err := ticker.GetTicker(positions[i].Exchange, positions[i].Pair, positions[i].AssetType)
+		if err != nil || tick.Last <= 0 {
+			// value the open position at cost until a price is available
+			s.Equity += realised
+			continue
+		}
+		pnl += positions[i].Amount * (tick.Last - positions[i].AveragePrice)
+		total, _ := convertValue(pnl, positions[i].Pair.Quote, target)
+		s.Equity += total
+	}
+	for i := range names {
+		snapshots = append(snapshots, *strategies[names[i]])
+	}
+	return snapshots
+}
+
+// convertValue converts an amount of one currency to another using fiat
+// exchange rates or the last price of a loaded exchange's spot ticker.
+// Currencies which are not traded against each other are triangulated through
+// one of the valuation bridge currencies, such as LTC to USD through BTC, and
+// fiat currencies without tickers are converted to and from USD
+func convertValue(amount float64, from, to currency.Code) (float64, bool) {
+	if amount == 0 {
+		return 0, true
+	}
+	rate, ok := conversionRate(from, to)
+	if !ok {
+		return 0, false
+	}
+	return amount * rate, true
+}
+
+// conversionRate returns the value of one unit of from in to
+func conversionRate(from, to currency.Code) (float64, bool) {
+	if rate, ok := directRate(from, to); ok {
+		return rate, true
+	}
+	for x := range valuationBridges {
+		if valuationBridges[x].Match(from) || valuationBridges[x].Match(to) {
+			continue
+		}
+		in, ok := directRate(from, valuationBridges[x])
+		if !ok {
+			continue
+		}
+		if out, ok := directRate(valuationBridges[x], to); ok {
+			return in * out, true
+		}
+	}
+	// fiat currencies are mostly traded against USD, so their fiat rate to USD
+	// is used alongside a triangulated USD price
+	switch {
+	case to.IsFiatCurrency() && !to.Match(currency.USD):
+		usd, ok := conversionRate(from, currency.USD)
+		if !ok {
+			return 0, false
+		}
+		fiat, ok := directRate(currency.USD, to)
+		return usd * fiat, ok
+	case from.IsFiatCurrency() && !from.Match(currency.USD):
+		fiat, ok := directRate(from, currency.USD)
+		if !ok {
+			return 0, false
+		}
+		usd, ok := conversionRate(currency.USD, to)
+		return fiat * usd, ok
+	}
+	return 0, false
+}
+
+// directRate returns the value of one unit of from in to without routing
+// through another currency, pegged currencies are valued at their anchor's
+// rate when there is no market rate and peg valuation is enabled
+func directRate(from, to currency.Code) (float64, bool) {
+	if rate, ok := marketRate(from, to); ok {
+		return rate, true
+	}
+	if pegValuation() {
+		return peggedRate(from, to)
+	}
+	return 0, false
+}
+
+// marketRate returns the fiat or last traded rate of one unit of from in to
+func marketRate(from, to currency.Code) (float64, bool) {
+	if from.Match(to) {
+		return 1, true
+	}
+	if from.IsFiatCurrency() && to.IsFiatCurrency() {
+		v, err := currency.ConvertCurrency(1, from, to)
+		return v, err == nil && v > 0
+	}
+	return lastPrice(from, to)
+}
+
+// lastPrice returns the price of one unit of base in quote from the first
+// loaded exchange with a spot ticker for the pair or its inverse
+func lastPrice(base, quote currency.Code) (float64, bool) {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		name := exchanges[x].GetName()
+		t, err := ticker.GetTicker(name, currency.NewPair(base, quote), asset.Spot)
+		if err == nil && t.Last > 0 {
+			return t.Last, true
+		}
+		t, err = ticker.GetTicker(name, currency.NewPair(quote, base), asset.Spot)
+		if err == nil && t.Last > 0 {
+			return 1 / t.Last, true
+		}
+	}
+	return 0, false
+}
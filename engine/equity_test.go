@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+func TestConvertValue(t *testing.T) {
+	SetupTestHelpers(t)
+	err := ticker.ProcessTicker(testExchange,
+		&ticker.Price{Pair: currency.NewPair(currency.LTC, currency.USD), Last: 50},
+		asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := convertValue(2, currency.LTC, currency.USD); !ok || v != 100 {
+		t.Errorf("expected 100, got %v %v", v, ok)
+	}
+	if v, ok := convertValue(100, currency.USD, currency.LTC); !ok || v != 2 {
+		t.Errorf("expected inverse ticker to be used, got %v %v", v, ok)
+	}
+	if v, ok := convertValue(3, currency.USD, currency.USD); !ok || v != 3 {
+		t.Errorf("expected 3, got %v %v", v, ok)
+	}
+	if _, ok := convertValue(1, currency.NewCode("NOPE"), currency.LTC); ok {
+		t.Error("expected an unpriced currency not to be valued")
+	}
+
+	err = ticker.ProcessTicker(testExchange,
+		&ticker.Price{Pair: currency.NewPair(currency.BTC, currency.USD), Last: 400},
+		asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := convertValue(8, currency.LTC, currency.BTC); !ok || v != 1 {
+		t.Errorf("expected LTC to be valued in BTC through USD, got %v %v", v, ok)
+	}
+}
+
+func TestConvertValueTriangulation(t *testing.T) {
+	SetupTestHelpers(t)
+	for _, p := range []ticker.Price{
+		{Pair: currency.NewPair(currency.DASH, currency.BTC), Last: 0.01},
+		{Pair: currency.NewPair(currency.BTC, currency.USD), Last: 400},
+	} {
+		p := p
+		if err := ticker.ProcessTicker(testExchange, &p, asset.Spot); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if v, ok := convertValue(10, currency.DASH, currency.USD); !ok || v != 40 {
+		t.Errorf("expected DASH to be valued in USD through BTC, got %v %v", v, ok)
+	}
+	if v, ok := convertValue(40, currency.USD, currency.DASH); !ok || v != 10 {
+		t.Errorf("expected USD to be valued in DASH through BTC, got %v %v", v, ok)
+	}
+
+	oldPortfolio := Bot.Portfolio
+	defer func() { Bot.Portfolio = oldPortfolio }()
+	Bot.Portfolio = &portfolio.Base{}
+	Bot.Portfolio.AddExchangeAddress(testExchange, currency.DASH, 10)
+	Bot.Portfolio.AddExchangeAddress(testExchange, currency.BTC, 1)
+	s := getPortfolioSummary(Bot.Portfolio, currency.USD)
+	if s.TotalValue != 440 {
+		t.Errorf("expected total value 440, got %v", s.TotalValue)
+	}
+}
+
+func TestEquitySnapshot(t *testing.T) {
+	SetupTestHelpers(t)
+	p := currency.NewPair(currency.LTC, currency.USD)
+	err := ticker.ProcessTicker(testExchange, &ticker.Price{Pair: p, Last: 50}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPortfolio := Bot.Portfolio
+	defer func() { Bot.Portfolio = oldPortfolio }()
+	Bot.Portfolio = &portfolio.Base{}
+	Bot.Portfolio.AddExchangeAddress(testExchange, currency.LTC, 2)
+	Bot.Portfolio.AddExchangeAddress(testExchange, currency.USD, 100)
+
+	defer func() { Bot.AllocationManager = allocationManager{} }()
+	_, err = Bot.AllocationManager.Add(&StrategyOrder{
+		Strategy:  "a",
+		Exchange:  testExchange,
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Amount:    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Bot.AllocationManager.Allocate(testExchange, p, asset.Spot, order.Buy, 1, 40, AllocateFIFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var e equityManager
+	snapshots := e.snapshot(time.Now(), currency.USD)
+	if len(snapshots) != 2 {
+		t.Fatalf("expected portfolio and strategy snapshots, got %+v", snapshots)
+	}
+	if snapshots[0].Portfolio != equityPortfolioName ||
+		snapshots[0].Equity != 200 ||
+		snapshots[0].Balance != 100 {
+		t.Errorf("unexpected portfolio snapshot %+v", snapshots[0])
+	}
+	if snapshots[1].Portfolio != equityStrategyPrefix+"a" ||
+		snapshots[1].Equity != 10 ||
+		snapshots[1].Balance != 0 {
+		t.Errorf("unexpected strategy snapshot %+v", snapshots[1])
+	}
+}
@@ -0,0 +1,26 @@
+package engine
+
+import "github.com/thrasher-corp/gocryptotrader/currency"
+
+// equityPortfolioName is the name snapshots of the combined exchange and
+// address holdings are stored under
+const equityPortfolioName = "portfolio"
+
+// equityStrategyPrefix prefixes the name snapshots of each strategy are stored
+// under
+const equityStrategyPrefix = "strategy:"
+
+// valuationBridges are the currencies, in order of preference, that currencies
+// which are not traded against each other are valued through
+var valuationBridges = []currency.Code{
+	currency.BTC,
+	currency.USD,
+	currency.USDT,
+	currency.ETH,
+}
+
+type equityManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+}
@@ -0,0 +1,245 @@
+package engine
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/eventbus"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (e *eventBusManager) Started() bool {
+	return atomic.LoadInt32(&e.started) == 1
+}
+
+func (e *eventBusManager) Start() error {
+	if atomic.AddInt32(&e.started, 1) != 1 {
+		return errors.New("event bus already started")
+	}
+
+	log.Debugln(log.EventBusMgr, "Event bus starting...")
+	cfg := Bot.Config.EventBus.Outputs
+	outputs := make([]eventbus.OutputConfig, len(cfg))
+	for i := range cfg {
+		outputs[i] = eventbus.OutputConfig{
+			Name:       cfg[i].Name,
+			Sink:       cfg[i].Sink,
+			Address:    cfg[i].Address,
+			Encoding:   cfg[i].Encoding,
+			Subject:    cfg[i].Subject,
+			Events:     cfg[i].Events,
+			BufferSize: cfg[i].BufferSize,
+		}
+	}
+	bus, err := eventbus.New(outputs)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&e.started, 1, 0)
+		return err
+	}
+	e.m.Lock()
+	e.bus = bus
+	e.m.Unlock()
+	log.Debugf(log.EventBusMgr, "Event bus started with %d outputs.\n", len(outputs))
+	return nil
+}
+
+func (e *eventBusManager) Stop() error {
+	if atomic.LoadInt32(&e.started) == 0 {
+		return errors.New("event bus not started")
+	}
+	if atomic.AddInt32(&e.stopped, 1) != 1 {
+		return errors.New("event bus is already stopped")
+	}
+
+	log.Debugln(log.EventBusMgr, "Event bus shutting down...")
+	e.m.Lock()
+	bus := e.bus
+	e.bus = nil
+	e.m.Unlock()
+	// queued events are forwarded before the outputs are closed
+	bus.Close()
+	for name, dropped := range bus.Dropped() {
+		if dropped > 0 {
+			log.Warnf(log.EventBusMgr, "Event bus output %s dropped %d events.\n", name, dropped)
+		}
+	}
+	atomic.CompareAndSwapInt32(&e.stopped, 1, 0)
+	atomic.CompareAndSwapInt32(&e.started, 1, 0)
+	log.Debugln(log.EventBusMgr, "Event bus shutdown.")
+	return nil
+}
+
+// publish queues an event for the outputs when the event bus is running
+func (e *eventBusManager) publish(evt *eventbus.Event) {
+	e.m.RLock()
+	if e.bus != nil {
+		e.bus.Publish(evt)
+	}
+	e.m.RUnlock()
+}
+
+// publishTicker publishes a ticker fetched or received from an exchange
+func (e *eventBusManager) publishTicker(exchName string, p currency.Pair, a asset.Item, t *ticker.Price) {
+	if !e.Started() || t == nil {
+		return
+	}
+	ts := t.LastUpdated
+	if ts.IsZero() {
+		ts = clock.Now()
+	}
+	e.publish(&eventbus.Event{
+		Type:      eventbus.TickerEvent,
+		Exchange:  exchName,
+		Timestamp: ts,
+		Ticker: &eventbus.Ticker{
+			Pair:   currency.CanonicalPair(exchName, p).String(),
+			Asset:  a.String(),
+			Last:   t.Last,
+			Bid:    t.Bid,
+			Ask:    t.Ask,
+			High:   t.High,
+			Low:    t.Low,
+			Volume: t.Volume,
+		},
+	})
+}
+
+// publishOrderbook publishes the top of an exchange's orderbook, limited to
+// the configured depth
+func (e *eventBusManager) publishOrderbook(exchName string, b *orderbook.Base) {
+	if !e.Started() || b == nil {
+		return
+	}
+	depth := Bot.Config.EventBus.OrderbookDepth
+	ts := b.LastUpdated
+	if ts.IsZero() {
+		ts = clock.Now()
+	}
+	e.publish(&eventbus.Event{
+		Type:      eventbus.OrderbookEvent,
+		Exchange:  exchName,
+		Timestamp: ts,
+		Orderbook: &eventbus.Orderbook{
+			Pair:  currency.CanonicalPair(exchName, b.Pair).String(),
+			Asset: b.AssetType.String(),
+			Bids:  orderbookLevels(b.Bids, depth),
+			Asks:  orderbookLevels(b.Asks, depth),
+		},
+	})
+}
+
+// publishOrderbookUpdate publishes an orderbook updated over an exchange's
+// websocket. The orderbook is only retrieved when an output forwards
+// orderbooks
+func (e *eventBusManager) publishOrderbookUpdate(exchName string, p currency.Pair, a asset.Item) {
+	if !e.Started() || !e.forwards(eventbus.OrderbookEvent) {
+		return
+	}
+	b, err := orderbook.Get(exchName, p, a)
+	if err != nil {
+		log.Errorf(log.EventBusMgr, "Event bus unable to get %s %s %s orderbook: %v\n",
+			exchName,
+			p,
+			a,
+			err)
+		return
+	}
+	e.publishOrderbook(exchName, b)
+}
+
+// orderbookLevels returns up to depth levels of an orderbook side, all when
+// depth is not positive
+func orderbookLevels(items []orderbook.Item, depth int) []eventbus.Level {
+	if depth > 0 && len(items) > depth {
+		items = items[:depth]
+	}
+	levels := make([]eventbus.Level, len(items))
+	for i := range items {
+		levels[i] = eventbus.Level{Price: items[i].Price, Amount: items[i].Amount}
+	}
+	return levels
+}
+
+// publishTrades publishes an exchange's public trades which were not already
+// processed, so trades fetched again over REST are only published once
+func (e *eventBusManager) publishTrades(exchName string, trades []trade.Data) {
+	if !e.Started() {
+		return
+	}
+	for i := range trades {
+		e.publish(&eventbus.Event{
+			Type:      eventbus.TradeEvent,
+			Exchange:  exchName,
+			Timestamp: trades[i].Timestamp,
+			Trade: &eventbus.Trade{
+				ID:     trades[i].TID,
+				Pair:   currency.CanonicalPair(exchName, trades[i].Pair).String(),
+				Asset:  trades[i].AssetType.String(),
+				Side:   trades[i].Side.String(),
+				Price:  trades[i].Price,
+				Amount: trades[i].Amount,
+			},
+		})
+	}
+}
+
+// forwards returns whether any output forwards events of the type
+func (e *eventBusManager) forwards(eventType string) bool {
+	e.m.RLock()
+	defer e.m.RUnlock()
+	return e.bus != nil && e.bus.Forwards(eventType)
+}
+
+// publishOrder publishes an order which has been placed or updated
+func (e *eventBusManager) publishOrder(d *order.Detail) {
+	if !e.Started() || d == nil {
+		return
+	}
+	ts := d.LastUpdated
+	if ts.IsZero() {
+		ts = d.Date
+	}
+	if ts.IsZero() {
+		ts = clock.Now()
+	}
+	e.publish(&eventbus.Event{
+		Type:      eventbus.OrderEvent,
+		Exchange:  d.Exchange,
+		Timestamp: ts,
+		Order: &eventbus.Order{
+			ID:             d.ID,
+			ClientID:       d.ClientID,
+			Pair:           d.Pair.String(),
+			Asset:          d.AssetType.String(),
+			Side:           d.Side.String(),
+			Type:           d.Type.String(),
+			Status:         d.Status.String(),
+			Price:          d.Price,
+			Amount:         d.Amount,
+			ExecutedAmount: d.ExecutedAmount,
+		},
+	})
+}
+
+// publishAlert publishes an event relayed through the communications manager
+func (e *eventBusManager) publishAlert(evt base.Event) {
+	if !e.Started() {
+		return
+	}
+	e.publish(&eventbus.Event{
+		Type:      eventbus.AlertEvent,
+		Timestamp: clock.Now(),
+		Alert: &eventbus.Alert{
+			Type:    evt.Type,
+			Message: evt.Message,
+		},
+	})
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/eventbus"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+type testEventSink struct {
+	m        sync.Mutex
+	subjects []string
+	events   []eventbus.Event
+}
+
+func (s *testEventSink) Send(subject string, payload []byte) error {
+	var e eventbus.Event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return err
+	}
+	s.m.Lock()
+	s.subjects = append(s.subjects, subject)
+	s.events = append(s.events, e)
+	s.m.Unlock()
+	return nil
+}
+
+func (s *testEventSink) Close() error {
+	return nil
+}
+
+func TestEventBusManager(t *testing.T) {
+	SetupTestHelpers(t)
+	sink := &testEventSink{}
+	eventbus.RegisterSink("enginetest", func(string) (eventbus.Sink, error) {
+		return sink, nil
+	})
+	old := Bot.Config.EventBus
+	defer func() {
+		Bot.Config.EventBus = old
+	}()
+	Bot.Config.EventBus = config.EventBusConfig{
+		Enabled:        true,
+		OrderbookDepth: 1,
+		Outputs: []config.EventBusOutputConfig{{
+			Name:     "test",
+			Sink:     "enginetest",
+			Address:  "test",
+			Encoding: eventbus.JSONEncoding,
+			Subject:  "gct",
+		}},
+	}
+
+	var m eventBusManager
+	p := currency.NewPair(currency.BTC, currency.USD)
+	// events published before the event bus is started are discarded
+	m.publishAlert(base.Event{Type: "test", Message: "discarded"})
+	if err := m.Stop(); err == nil {
+		t.Error("expected error stopping event bus which is not started")
+	}
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	m.publishTicker("Bitstamp", p, asset.Spot, &ticker.Price{Last: 100, Bid: 99, Ask: 101})
+	m.publishOrder(&order.Detail{
+		Exchange:  "Bitstamp",
+		ID:        "1",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Status:    order.Active,
+		Price:     100,
+		Amount:    1,
+	})
+	m.publishAlert(base.Event{Type: "test", Message: "hello"})
+	m.publishOrderbook("Bitstamp", &orderbook.Base{
+		Pair:      p,
+		AssetType: asset.Spot,
+		Bids:      []orderbook.Item{{Price: 99, Amount: 1}, {Price: 98, Amount: 1}},
+		Asks:      []orderbook.Item{{Price: 101, Amount: 1}},
+	})
+
+	// trades which were already processed are only published once
+	for _, id := range []string{"1", "1", "2"} {
+		added, err := trade.AddTrades("TestEventBusManager", []trade.Data{
+			{TID: id, Pair: p, AssetType: asset.Spot, Price: 100, Amount: 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.publishTrades("TestEventBusManager", added)
+	}
+	if err := m.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Started() {
+		t.Error("expected event bus to be stopped")
+	}
+
+	sink.m.Lock()
+	defer sink.m.Unlock()
+	if len(sink.events) != 6 {
+		t.Fatalf("expected 6 events forwarded, received %d", len(sink.events))
+	}
+	if sink.subjects[0] != "gct.ticker.bitstamp" ||
+		sink.events[0].Ticker == nil ||
+		sink.events[0].Ticker.Last != 100 ||
+		sink.events[0].Ticker.Pair != "BTCUSD" {
+		t.Errorf("unexpected ticker event %s %+v", sink.subjects[0], sink.events[0])
+	}
+	if sink.subjects[1] != "gct.order.bitstamp" ||
+		sink.events[1].Order == nil ||
+		sink.events[1].Order.ID != "1" ||
+		sink.events[1].Order.Status != order.Active.String() {
+		t.Errorf("unexpected order event %s %+v", sink.subjects[1], sink.events[1])
+	}
+	if sink.subjects[2] != "gct.alert" ||
+		sink.events[2].Alert == nil ||
+		sink.events[2].Alert.Message != "hello" {
+		t.Errorf("unexpected alert event %s %+v", sink.subjects[2], sink.events[2])
+	}
+	if sink.subjects[3] != "gct.orderbook.bitstamp" ||
+		sink.events[3].Orderbook == nil ||
+		len(sink.events[3].Orderbook.Bids) != 1 ||
+		len(sink.events[3].Orderbook.Asks) != 1 {
+		t.Errorf("expected orderbook limited to the configured depth %s %+v", sink.subjects[3], sink.events[3])
+	}
+	for i, id := range []string{"1", "2"} {
+		if e := sink.events[4+i]; sink.subjects[4+i] != "gct.trade.testeventbusmanager" ||
+			e.Trade == nil ||
+			e.Trade.ID != id {
+			t.Errorf("unexpected trade event %s %+v", sink.subjects[4+i], e)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/eventbus"
+)
+
+// eventBusManager forwards tickers, trades, orderbooks, order updates and
+// alerts to the event bus outputs set in the config
+type eventBusManager struct {
+	started int32
+	stopped int32
+
+	m   sync.RWMutex
+	bus *eventbus.Bus
+}
@@ -21,6 +21,15 @@ import (
 const (
 	ItemPrice     = "PRICE"
 	ItemOrderbook = "ORDERBOOK"
+	// ItemSpread compares the orderbook's bid ask spread as a percentage of
+	// the mid price
+	ItemSpread = "SPREAD"
+	// ItemImbalance compares the orderbook's imbalance, from -1 when there
+	// are only asks to 1 when there are only bids
+	ItemImbalance = "IMBALANCE"
+	// ItemDepth compares the base currency amount of the orderbook within
+	// the depth percent of the mid price
+	ItemDepth = "DEPTH"
 
 	ConditionGreaterThan        = ">"
 	ConditionGreaterThanOrEqual = ">="
@@ -52,6 +61,10 @@ type EventConditionParams struct {
 	CheckBids        bool
 	CheckBidsAndAsks bool
 	OrderbookAmount  float64
+
+	// Threshold is compared with the orderbook analytics items
+	Threshold    float64
+	DepthPercent float64
 }
 
 // Event struct holds the event variables
@@ -226,11 +239,44 @@ func (e *Event) processOrderbook() bool {
 	return success
 }
 
+func (e *Event) processAnalytics() bool {
+	var depthPercents []float64
+	if e.Item == ItemDepth {
+		depthPercents = append(depthPercents, e.Condition.DepthPercent)
+	}
+	a, err := orderbook.GetAnalytics(e.Exchange, e.Pair, e.Asset, depthPercents...)
+	if err != nil {
+		if Bot.Settings.Verbose {
+			log.Debugf(log.EventMgr, "Events: Failed to get orderbook analytics. Err: %s\n", err)
+		}
+		return false
+	}
+
+	switch e.Item {
+	case ItemSpread:
+		return e.processCondition(a.SpreadPercent, e.Condition.Threshold)
+	case ItemImbalance:
+		return e.processCondition(a.Imbalance, e.Condition.Threshold)
+	}
+	d := a.Depth[0]
+	amount := d.AskAmount
+	switch {
+	case e.Condition.CheckBidsAndAsks:
+		amount = d.BidAmount + d.AskAmount
+	case e.Condition.CheckBids:
+		amount = d.BidAmount
+	}
+	return e.processCondition(amount, e.Condition.Threshold)
+}
+
 // CheckEventCondition will check the event structure to see if there is a condition
 // met
 func (e *Event) CheckEventCondition() bool {
-	if e.Item == ItemPrice {
+	switch e.Item {
+	case ItemPrice:
 		return e.processTicker()
+	case ItemSpread, ItemImbalance, ItemDepth:
+		return e.processAnalytics()
 	}
 	return e.processOrderbook()
 }
@@ -265,6 +311,21 @@ func IsValidEvent(exchange, item string, condition EventConditionParams, action
 		}
 	}
 
+	switch item {
+	case ItemSpread:
+		if condition.Threshold <= 0 {
+			return errInvalidCondition
+		}
+	case ItemImbalance:
+		if condition.Threshold < -1 || condition.Threshold > 1 {
+			return errInvalidCondition
+		}
+	case ItemDepth:
+		if condition.Threshold <= 0 || condition.DepthPercent <= 0 {
+			return errInvalidCondition
+		}
+	}
+
 	if strings.Contains(action, ",") {
 		a := strings.Split(action, ",")
 
@@ -342,7 +403,7 @@ func IsValidAction(action string) bool {
 func IsValidItem(item string) bool {
 	item = strings.ToUpper(item)
 	switch item {
-	case ItemPrice, ItemOrderbook:
+	case ItemPrice, ItemOrderbook, ItemSpread, ItemImbalance, ItemDepth:
 		return true
 	}
 	return false
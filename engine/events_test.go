@@ -115,7 +115,7 @@ func TestString(t *testing.T) {
 		Action: "SMS,ALL",
 	}
 
-	if r := e.String(); r != "If the BTCUSD [SPOT] PRICE on Bitstamp meets the following {> 1 false false 0} then SMS,ALL." {
+	if r := e.String(); r != "If the BTCUSD [SPOT] PRICE on Bitstamp meets the following {> 1 false false 0 0 0} then SMS,ALL." {
 		t.Error("unexpected result")
 	}
 }
@@ -220,6 +220,61 @@ func TestProcessOrderbook(t *testing.T) {
 	}
 }
 
+func TestProcessAnalytics(t *testing.T) {
+	if Bot == nil {
+		Bot = new(Engine)
+	}
+
+	e := Event{
+		Exchange: testExchange,
+		Item:     ItemSpread,
+		Pair:     currency.NewPair(currency.LTC, currency.USD),
+		Asset:    asset.Spot,
+		Condition: EventConditionParams{
+			Condition: ConditionGreaterThan,
+			Threshold: 1,
+		},
+	}
+	if r := e.processAnalytics(); r {
+		t.Error("unexpected result without an orderbook")
+	}
+
+	o := orderbook.Base{
+		Pair:         e.Pair,
+		Bids:         []orderbook.Item{{Amount: 3, Price: 98}, {Amount: 10, Price: 80}},
+		Asks:         []orderbook.Item{{Amount: 1, Price: 102}},
+		ExchangeName: e.Exchange,
+		AssetType:    e.Asset,
+	}
+	if err := o.Process(); err != nil {
+		t.Fatal("unexpected result:", err)
+	}
+
+	// 4% spread
+	if r := e.processAnalytics(); !r {
+		t.Error("expected the spread to trigger the event")
+	}
+
+	e.Item = ItemImbalance
+	e.Condition.Threshold = 0.8
+	if r := e.processAnalytics(); !r {
+		t.Error("expected the imbalance to trigger the event")
+	}
+
+	// only the bid and ask within 5% of the mid price are counted
+	e.Item = ItemDepth
+	e.Condition.DepthPercent = 5
+	e.Condition.CheckBidsAndAsks = true
+	e.Condition.Threshold = 4
+	if r := e.processAnalytics(); r {
+		t.Error("unexpected result")
+	}
+	e.Condition.Condition = ConditionIsEqual
+	if r := e.processAnalytics(); !r {
+		t.Error("expected the depth to trigger the event")
+	}
+}
+
 func TestCheckEventCondition(t *testing.T) {
 	t.Parallel()
 	if Bot == nil {
@@ -285,6 +340,22 @@ func TestIsValidEvent(t *testing.T) {
 	if err := IsValidEvent(testExchange, ItemOrderbook, c, "SMS,test"); err != nil {
 		t.Error("unexpected result:", err)
 	}
+
+	// analytics items are compared with the threshold
+	if err := IsValidEvent(testExchange, ItemSpread, c, ActionTest); err != errInvalidCondition {
+		t.Error("unexpected result:", err)
+	}
+	c.Threshold = 2
+	if err := IsValidEvent(testExchange, ItemImbalance, c, ActionTest); err != errInvalidCondition {
+		t.Error("unexpected result:", err)
+	}
+	if err := IsValidEvent(testExchange, ItemDepth, c, ActionTest); err != errInvalidCondition {
+		t.Error("unexpected result:", err)
+	}
+	c.DepthPercent = 1
+	if err := IsValidEvent(testExchange, ItemDepth, c, ActionTest); err != nil {
+		t.Error("unexpected result:", err)
+	}
 }
 
 func TestIsValidExchange(t *testing.T) {
@@ -2,10 +2,13 @@ package engine
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binance"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/bitfinex"
@@ -48,6 +51,9 @@ var (
 type exchangeManager struct {
 	m         sync.Mutex
 	exchanges map[string]exchange.IBotExchange
+	// accounts are the instances of exchanges authenticated as their
+	// additional accounts, by account exchange name
+	accounts map[string]exchange.IBotExchange
 }
 
 func dryrunParamInteraction(param string) {
@@ -57,7 +63,8 @@ func dryrunParamInteraction(param string) {
 
 	if !Bot.Settings.EnableDryRun && !flagSet["dryrun"] {
 		log.Warnf(log.Global,
-			"Command line argument '-%s' induces dry run mode."+
+			"Command line argument '-%s' induces dry run mode,"+
+				" orders, cancellations and withdrawals will not be sent."+
 				" Set -dryrun=false if you wish to override this.",
 			param)
 		Bot.Settings.EnableDryRun = true
@@ -95,9 +102,17 @@ func (e *exchangeManager) removeExchange(exchName string) error {
 	if exch == nil {
 		return ErrExchangeNotFound
 	}
+	if k, ok := exch.(exchange.HeartbeatKeeper); ok {
+		k.StopHeartbeat()
+	}
 	e.m.Lock()
 	defer e.m.Unlock()
 	delete(e.exchanges, strings.ToLower(exchName))
+	for k := range e.accounts {
+		if strings.HasPrefix(k, strings.ToLower(exchName)+config.AccountDelimiter) {
+			delete(e.accounts, k)
+		}
+	}
 	log.Infof(log.ExchangeSys, "%s exchange unloaded successfully.\n", exchName)
 	return nil
 }
@@ -110,11 +125,31 @@ func (e *exchangeManager) getExchangeByName(exchangeName string) exchange.IBotEx
 	defer e.m.Unlock()
 	exch, ok := e.exchanges[strings.ToLower(exchangeName)]
 	if !ok {
-		return nil
+		// additional accounts are referred to by their account exchange name
+		return e.accounts[strings.ToLower(exchangeName)]
 	}
 	return exch
 }
 
+func (e *exchangeManager) addAccount(exch exchange.IBotExchange) {
+	e.m.Lock()
+	if e.accounts == nil {
+		e.accounts = make(map[string]exchange.IBotExchange)
+	}
+	e.accounts[strings.ToLower(exch.GetName())] = exch
+	e.m.Unlock()
+}
+
+func (e *exchangeManager) getAccounts() []exchange.IBotExchange {
+	e.m.Lock()
+	defer e.m.Unlock()
+	var accounts []exchange.IBotExchange
+	for x := range e.accounts {
+		accounts = append(accounts, e.accounts[x])
+	}
+	return accounts
+}
+
 func (e *exchangeManager) Len() int {
 	e.m.Lock()
 	defer e.m.Unlock()
@@ -154,73 +189,13 @@ func GetExchanges() []exchange.IBotExchange {
 // LoadExchange loads an exchange by name
 func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 	nameLower := strings.ToLower(name)
-	var exch exchange.IBotExchange
-
 	if Bot.exchangeManager.getExchangeByName(nameLower) != nil {
 		return ErrExchangeAlreadyLoaded
 	}
 
-	switch nameLower {
-	case "binance":
-		exch = new(binance.Binance)
-	case "bitfinex":
-		exch = new(bitfinex.Bitfinex)
-	case "bitflyer":
-		exch = new(bitflyer.Bitflyer)
-	case "bithumb":
-		exch = new(bithumb.Bithumb)
-	case "bitmex":
-		exch = new(bitmex.Bitmex)
-	case "bitstamp":
-		exch = new(bitstamp.Bitstamp)
-	case "bittrex":
-		exch = new(bittrex.Bittrex)
-	case "btc markets":
-		exch = new(btcmarkets.BTCMarkets)
-	case "btse":
-		exch = new(btse.BTSE)
-	case "coinbene":
-		exch = new(coinbene.Coinbene)
-	case "coinut":
-		exch = new(coinut.COINUT)
-	case "exmo":
-		exch = new(exmo.EXMO)
-	case "coinbasepro":
-		exch = new(coinbasepro.CoinbasePro)
-	case "gateio":
-		exch = new(gateio.Gateio)
-	case "gemini":
-		exch = new(gemini.Gemini)
-	case "hitbtc":
-		exch = new(hitbtc.HitBTC)
-	case "huobi":
-		exch = new(huobi.HUOBI)
-	case "itbit":
-		exch = new(itbit.ItBit)
-	case "kraken":
-		exch = new(kraken.Kraken)
-	case "lakebtc":
-		exch = new(lakebtc.LakeBTC)
-	case "lbank":
-		exch = new(lbank.Lbank)
-	case "localbitcoins":
-		exch = new(localbitcoins.LocalBitcoins)
-	case "okcoin international":
-		exch = new(okcoin.OKCoin)
-	case "okex":
-		exch = new(okex.OKEX)
-	case "poloniex":
-		exch = new(poloniex.Poloniex)
-	case "yobit":
-		exch = new(yobit.Yobit)
-	case "zb":
-		exch = new(zb.ZB)
-	default:
-		return ErrExchangeNotFound
-	}
-
-	if exch == nil {
-		return ErrExchangeFailedToLoad
+	exch, err := newExchange(nameLower)
+	if err != nil {
+		return err
 	}
 
 	exch.SetDefaults()
@@ -311,6 +286,19 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 		}
 	}
 
+	if exchCfg.API.CredentialsProvider != nil &&
+		(exchCfg.API.AuthenticatedSupport || exchCfg.API.AuthenticatedWebsocketSupport) {
+		err = loadProvidedCredentials(exchCfg)
+		if err != nil {
+			log.Warnf(log.ExchangeSys,
+				"%s: Cannot load credentials, authenticated support has been disabled, Error: %s\n",
+				exch.GetName(),
+				err)
+			exchCfg.API.AuthenticatedSupport = false
+			exchCfg.API.AuthenticatedWebsocketSupport = false
+		}
+	}
+
 	exchCfg.Enabled = true
 	err = exch.Setup(exchCfg)
 	if err != nil {
@@ -320,6 +308,7 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 
 	Bot.exchangeManager.add(exch)
 
+	authenticated := exchCfg.API.AuthenticatedSupport
 	base := exch.GetBase()
 	if base.API.AuthenticatedSupport ||
 		base.API.AuthenticatedWebsocketSupport {
@@ -336,17 +325,119 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 		}
 	}
 
+	if authenticated {
+		loadExchangeAccounts(exchCfg)
+	}
+
 	if useWG {
-		exch.Start(wg)
+		wg.Add(1)
+		go func() {
+			startExchange(exch)
+			wg.Done()
+		}()
 	} else {
-		tempWG := sync.WaitGroup{}
-		exch.Start(&tempWG)
-		tempWG.Wait()
+		startExchange(exch)
 	}
 
+	startHeartbeat(exch)
+
 	return nil
 }
 
+// startExchange runs the exchange's startup, which fetches its symbols when
+// they are due for an update, as a startup step
+func startExchange(exch exchange.IBotExchange) {
+	release := Bot.StartupCoordinator.step(exch.GetName())
+	defer release()
+	var wg sync.WaitGroup
+	exch.Start(&wg)
+	wg.Wait()
+}
+
+// newExchange returns a new instance of an exchange by its name
+func newExchange(name string) (exchange.IBotExchange, error) {
+	var exch exchange.IBotExchange
+	switch strings.ToLower(name) {
+	case "binance":
+		exch = new(binance.Binance)
+	case "bitfinex":
+		exch = new(bitfinex.Bitfinex)
+	case "bitflyer":
+		exch = new(bitflyer.Bitflyer)
+	case "bithumb":
+		exch = new(bithumb.Bithumb)
+	case "bitmex":
+		exch = new(bitmex.Bitmex)
+	case "bitstamp":
+		exch = new(bitstamp.Bitstamp)
+	case "bittrex":
+		exch = new(bittrex.Bittrex)
+	case "btc markets":
+		exch = new(btcmarkets.BTCMarkets)
+	case "btse":
+		exch = new(btse.BTSE)
+	case "coinbene":
+		exch = new(coinbene.Coinbene)
+	case "coinut":
+		exch = new(coinut.COINUT)
+	case "exmo":
+		exch = new(exmo.EXMO)
+	case "coinbasepro":
+		exch = new(coinbasepro.CoinbasePro)
+	case "gateio":
+		exch = new(gateio.Gateio)
+	case "gemini":
+		exch = new(gemini.Gemini)
+	case "hitbtc":
+		exch = new(hitbtc.HitBTC)
+	case "huobi":
+		exch = new(huobi.HUOBI)
+	case "itbit":
+		exch = new(itbit.ItBit)
+	case "kraken":
+		exch = new(kraken.Kraken)
+	case "lakebtc":
+		exch = new(lakebtc.LakeBTC)
+	case "lbank":
+		exch = new(lbank.Lbank)
+	case "localbitcoins":
+		exch = new(localbitcoins.LocalBitcoins)
+	case "okcoin international":
+		exch = new(okcoin.OKCoin)
+	case "okex":
+		exch = new(okex.OKEX)
+	case "poloniex":
+		exch = new(poloniex.Poloniex)
+	case "yobit":
+		exch = new(yobit.Yobit)
+	case "zb":
+		exch = new(zb.ZB)
+	default:
+		return nil, ErrExchangeNotFound
+	}
+	return exch, nil
+}
+
+// startHeartbeat starts sending heartbeats for exchange sessions which require
+// them, pushing an event when heartbeats begin to fail
+func startHeartbeat(exch exchange.IBotExchange) {
+	k, ok := exch.(exchange.HeartbeatKeeper)
+	if !ok {
+		return
+	}
+	err := k.StartHeartbeat(func(heartbeatErr error) {
+		Bot.CommsManager.PushEvent(base.Event{
+			Type: "heartbeat",
+			Message: fmt.Sprintf("%s heartbeat failed, open orders may be cancelled by the exchange: %s",
+				exch.GetName(),
+				heartbeatErr),
+		})
+	})
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "%s failed to start heartbeat: %s\n", exch.GetName(), err)
+	}
+}
+
 // SetupExchanges sets up the exchanges used by the Bot
 func SetupExchanges() {
 	var wg sync.WaitGroup
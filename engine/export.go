@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/export"
+)
+
+// ExportHistory returns the fills tracked by the order manager and the
+// funding history of an exchange between start and end, oldest first
+func ExportHistory(exchName string, start, end time.Time) ([]export.Record, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+
+	var records []export.Record
+	orders, err := Bot.OrderManager.orderStore.GetByExchange(exch.GetName())
+	if err != nil && err != ErrExchangeNotFound {
+		return nil, err
+	}
+	for x := range orders {
+		records = append(records, orderRecords(orders[x])...)
+	}
+
+	funding, err := exch.GetFundingHistory()
+	switch {
+	case err == common.ErrFunctionNotSupported || err == common.ErrNotYetImplemented:
+		log.Warnf(log.OrderMgr,
+			"%s funding history is unavailable, exporting fills only.\n",
+			exch.GetName())
+	case err != nil:
+		return nil, err
+	}
+	for x := range funding {
+		if r, ok := transferRecord(exch.GetName(), &funding[x]); ok {
+			records = append(records, r)
+		}
+	}
+
+	filtered := records[:0]
+	for x := range records {
+		if records[x].Time.Before(start) || records[x].Time.After(end) {
+			continue
+		}
+		filtered = append(filtered, records[x])
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+	return filtered, nil
+}
+
+// orderRecords converts each trade of an order to a record, falling back to
+// the order's executed amount when the exchange does not return its trades.
+// Fees are assumed to be charged in the quote currency
+func orderRecords(d *order.Detail) []export.Record {
+	if len(d.Trades) == 0 {
+		if d.ExecutedAmount <= 0 || d.Price <= 0 {
+			return nil
+		}
+		tm := d.LastUpdated
+		if tm.IsZero() {
+			tm = d.Date
+		}
+		return []export.Record{tradeRecord(d, d.ID, d.Side, d.ExecutedAmount, d.Price, d.Fee, tm)}
+	}
+
+	var records []export.Record
+	for x := range d.Trades {
+		t := &d.Trades[x]
+		side := t.Side
+		if side == "" {
+			side = d.Side
+		}
+		id := t.TID
+		if id == "" {
+			id = d.ID
+		}
+		records = append(records, tradeRecord(d, id, side, t.Amount, t.Price, t.Fee, t.Timestamp))
+	}
+	return records
+}
+
+func tradeRecord(d *order.Detail, id string, side order.Side, amount, price, fee float64, tm time.Time) export.Record {
+	r := export.Record{
+		Type:        export.Trade,
+		Time:        tm,
+		Exchange:    d.Exchange,
+		FeeAmount:   fee,
+		FeeCurrency: d.Pair.Quote,
+		ID:          id,
+	}
+	if isBuySide(side) {
+		r.BuyAmount, r.BuyCurrency = amount, d.Pair.Base
+		r.SellAmount, r.SellCurrency = amount*price, d.Pair.Quote
+	} else {
+		r.BuyAmount, r.BuyCurrency = amount*price, d.Pair.Quote
+		r.SellAmount, r.SellCurrency = amount, d.Pair.Base
+	}
+	return r
+}
+
+// transferRecord converts a deposit or withdrawal to a record
+func transferRecord(exchName string, f *exchange.FundHistory) (export.Record, bool) {
+	direction, ok := transferDirection(f.TransferType)
+	if !ok || f.Amount <= 0 {
+		return export.Record{}, false
+	}
+	code := currency.NewCode(f.Currency)
+	r := export.Record{
+		Time:        f.Timestamp,
+		Exchange:    exchName,
+		FeeAmount:   f.Fee,
+		FeeCurrency: code,
+		ID:          f.TransferID,
+		TxID:        f.CryptoTxID,
+		Description: f.Description,
+	}
+	if direction == TransferDeposit {
+		r.Type = export.Deposit
+		r.BuyAmount, r.BuyCurrency = f.Amount, code
+	} else {
+		r.Type = export.Withdrawal
+		r.SellAmount, r.SellCurrency = f.Amount, code
+	}
+	return r, true
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/export"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/tax"
+)
+
+func TestOrderRecords(t *testing.T) {
+	tm := time.Now()
+	d := &order.Detail{
+		Exchange:       testExchange,
+		ID:             "1",
+		Pair:           currency.NewPair(currency.BTC, currency.USD),
+		Side:           order.Sell,
+		Price:          100,
+		ExecutedAmount: 2,
+		Fee:            0.5,
+		Date:           tm,
+	}
+	records := orderRecords(d)
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Type != export.Trade ||
+		r.SellAmount != 2 || r.SellCurrency != currency.BTC ||
+		r.BuyAmount != 200 || r.BuyCurrency != currency.USD ||
+		r.FeeAmount != 0.5 || r.FeeCurrency != currency.USD ||
+		!r.Time.Equal(tm) {
+		t.Errorf("unexpected record %+v", r)
+	}
+
+	d.Trades = []order.TradeHistory{
+		{TID: "a", Price: 100, Amount: 1, Side: order.Buy, Timestamp: tm},
+		{TID: "b", Price: 110, Amount: 1, Timestamp: tm.Add(time.Second)},
+	}
+	records = orderRecords(d)
+	if len(records) != 2 ||
+		records[0].ID != "a" || records[0].BuyCurrency != currency.BTC ||
+		records[1].ID != "b" || records[1].SellCurrency != currency.BTC || records[1].BuyAmount != 110 {
+		t.Errorf("unexpected records %+v", records)
+	}
+
+	if len(orderRecords(&order.Detail{Pair: d.Pair, Price: 100})) != 0 {
+		t.Error("expected an unfilled order not to be exported")
+	}
+}
+
+func TestTransferRecord(t *testing.T) {
+	r, ok := transferRecord(testExchange, &exchange.FundHistory{
+		TransferType: "withdrawal",
+		Currency:     "BTC",
+		Amount:       1,
+		Fee:          0.001,
+		CryptoTxID:   "txid",
+	})
+	if !ok || r.Type != export.Withdrawal || r.SellAmount != 1 || r.SellCurrency != currency.BTC || r.TxID != "txid" {
+		t.Errorf("unexpected record %+v", r)
+	}
+
+	r, ok = transferRecord(testExchange, &exchange.FundHistory{TransferType: "Deposit", Currency: "USD", Amount: 5})
+	if !ok || r.Type != export.Deposit || r.BuyAmount != 5 {
+		t.Errorf("unexpected record %+v", r)
+	}
+
+	if _, ok = transferRecord(testExchange, &exchange.FundHistory{TransferType: "rebate", Amount: 1}); ok {
+		t.Error("expected an unknown transfer type not to be exported")
+	}
+}
+
+func TestExportHistory(t *testing.T) {
+	OrdersSetup(t)
+	if _, err := ExportHistory("unloadedExchange", time.Time{}, time.Now()); err != ErrExchangeNotFound {
+		t.Errorf("expected %v, got %v", ErrExchangeNotFound, err)
+	}
+
+	err := Bot.OrderManager.orderStore.Add(&order.Detail{
+		Exchange:       fakePassExchange,
+		ID:             "exportTest",
+		Pair:           currency.NewPair(currency.BTC, currency.USD),
+		Side:           order.Buy,
+		Price:          100,
+		ExecutedAmount: 1,
+		Date:           time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+
+	records, err := ExportHistory(fakePassExchange, time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].ID != "exportTest" {
+		t.Errorf("unexpected records %+v", records)
+	}
+
+	records, err = ExportHistory(fakePassExchange, time.Now().Add(time.Hour), time.Now().Add(time.Hour*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Errorf("expected records outside the period to be excluded, got %+v", records)
+	}
+}
+
+func TestOrderTrades(t *testing.T) {
+	tm := time.Now()
+	d := &order.Detail{
+		Exchange:       testExchange,
+		ID:             "1",
+		Pair:           currency.NewPair(currency.BTC, currency.USD),
+		Side:           order.Sell,
+		Price:          100,
+		ExecutedAmount: 2,
+		Fee:            0.5,
+		Date:           tm,
+	}
+	trades := orderTrades(d)
+	if len(trades) != 1 ||
+		trades[0].Side != order.Sell ||
+		trades[0].Amount != 2 ||
+		trades[0].Fee != 0.5 ||
+		!trades[0].Time.Equal(tm) {
+		t.Errorf("unexpected trades %+v", trades)
+	}
+
+	d.Trades = []order.TradeHistory{
+		{TID: "a", Price: 100, Amount: 1, Side: order.Buy, Timestamp: tm},
+		{Price: 110, Amount: 1, Timestamp: tm.Add(time.Second)},
+	}
+	trades = orderTrades(d)
+	if len(trades) != 2 ||
+		trades[0].ID != "a" || trades[0].Side != order.Buy ||
+		trades[1].ID != "1" || trades[1].Side != order.Sell || trades[1].Price != 110 {
+		t.Errorf("unexpected trades %+v", trades)
+	}
+
+	if len(orderTrades(&order.Detail{Pair: d.Pair, Price: 100})) != 0 {
+		t.Error("expected an unfilled order not to be reported")
+	}
+}
+
+func TestTaxReport(t *testing.T) {
+	SetupTestHelpers(t)
+	if _, err := TaxReport("hifo", time.Time{}, time.Time{}, time.Now(), currency.USD); err == nil {
+		t.Error("expected an unsupported lot matching method to error")
+	}
+	disposals, err := TaxReport(tax.FIFO, time.Time{}, time.Time{}, time.Now(), currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disposals) != 0 {
+		t.Errorf("expected no disposals without trade history, got %+v", disposals)
+	}
+}
@@ -139,7 +139,21 @@ func (h *FakePassingExchange) GetDepositAddress(_ currency.Code, _ string) (stri
 	return "", nil
 }
 func (h *FakePassingExchange) GetOrderHistory(_ *order.GetOrdersRequest) ([]order.Detail, error) {
-	return nil, nil
+	return []order.Detail{
+		{
+			Price:          1337,
+			Amount:         1,
+			ExecutedAmount: 1,
+			Exchange:       fakePassExchange,
+			ID:             "fakeClosedOrder",
+			Type:           order.Market,
+			Side:           order.Buy,
+			Status:         order.Filled,
+			AssetType:      asset.Spot,
+			Date:           time.Now(),
+			Pair:           currency.NewPairFromString("BTCUSD"),
+		},
+	}, nil
 }
 func (h *FakePassingExchange) GetActiveOrders(_ *order.GetOrdersRequest) ([]order.Detail, error) {
 	return []order.Detail{
@@ -177,6 +191,8 @@ func (h *FakePassingExchange) GetSubscriptions() ([]wshandler.WebsocketChannelSu
 func (h *FakePassingExchange) GetDefaultConfig() (*config.ExchangeConfig, error) { return nil, nil }
 func (h *FakePassingExchange) GetBase() *exchange.Base                           { return nil }
 func (h *FakePassingExchange) SupportsAsset(_ asset.Item) bool                   { return true }
+func (h *FakePassingExchange) GetFeatures() exchange.Features                    { return h.Features }
+func (h *FakePassingExchange) SupportsOrderType(_ order.Type) bool               { return true }
 func (h *FakePassingExchange) GetHistoricCandles(_ currency.Pair, _ asset.Item, _, _ time.Time, _ time.Duration) (kline.Item, error) {
 	return kline.Item{}, nil
 }
@@ -0,0 +1,747 @@
+package engine
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/fix"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errFIXNotLogon             = errors.New("first message must be a logon")
+	errFIXSessionNotConfigured = errors.New("session not configured")
+	errFIXSessionLoggedOn      = errors.New("session already logged on")
+	errFIXInvalidPassword      = errors.New("invalid password")
+	errFIXExchangeNotAllowed   = errors.New("exchange not permitted for session")
+	errFIXDuplicateClOrdID     = errors.New("duplicate ClOrdID")
+	errFIXUnknownOrder         = errors.New("unknown order")
+	errFIXOrderDone            = errors.New("order is no longer open")
+)
+
+func (g *fixGateway) Started() bool {
+	return atomic.LoadInt32(&g.started) == 1
+}
+
+func (g *fixGateway) Start() error {
+	if atomic.AddInt32(&g.started, 1) != 1 {
+		return errors.New("FIX gateway already started")
+	}
+
+	log.Debugln(log.FIXSys, "FIX gateway starting...")
+	ln, err := net.Listen("tcp", Bot.Config.FIXGateway.ListenAddress)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&g.started, 1, 0)
+		return err
+	}
+	g.listener = ln
+	g.shutdown = make(chan struct{})
+	go g.run()
+	return nil
+}
+
+func (g *fixGateway) Stop() error {
+	if atomic.AddInt32(&g.stopped, 1) != 1 {
+		return errors.New("FIX gateway is already stopped")
+	}
+
+	log.Debugln(log.FIXSys, "FIX gateway shutting down...")
+	close(g.shutdown)
+	return nil
+}
+
+func (g *fixGateway) run() {
+	log.Debugf(log.FIXSys, "FIX gateway started. Listening on %s as %s.\n",
+		g.listener.Addr(),
+		Bot.Config.FIXGateway.SenderCompID)
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(fixGatewayInterval)
+	defer func() {
+		g.listener.Close()
+		g.m.Lock()
+		for _, s := range g.sessions {
+			s.conn.Close()
+		}
+		g.m.Unlock()
+		atomic.CompareAndSwapInt32(&g.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&g.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.FIXSys, "FIX gateway shutdown.")
+	}()
+
+	go g.accept(g.listener, g.shutdown)
+	for {
+		select {
+		case <-g.shutdown:
+			return
+		case <-tick.C:
+			g.update()
+		}
+	}
+}
+
+// accept accepts connections until the listener is closed
+func (g *fixGateway) accept(ln net.Listener, shutdown chan struct{}) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-shutdown:
+			default:
+				log.Errorf(log.FIXSys, "FIX gateway unable to accept connections: %v\n", err)
+			}
+			return
+		}
+		go g.handleConn(conn)
+	}
+}
+
+// handleConn logs on a session and handles its messages until it logs out or
+// disconnects. Sessions which send nothing for twice their heartbeat interval
+// are disconnected
+func (g *fixGateway) handleConn(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	s, err := g.logon(conn, r)
+	if err != nil {
+		log.Warnf(log.FIXSys, "FIX gateway rejected logon from %s: %v\n", conn.RemoteAddr(), err)
+		return
+	}
+	defer g.removeSession(s)
+	log.Infof(log.FIXSys, "FIX gateway session %s logged on from %s.\n", s.cfg.TargetCompID, conn.RemoteAddr())
+
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(s.heartbeat * 2)); err != nil {
+			return
+		}
+		var msg *fix.Message
+		msg, _, err = fix.ReadMessage(r)
+		if err != nil {
+			if err != io.EOF {
+				log.Warnf(log.FIXSys, "FIX gateway session %s disconnected: %v\n", s.cfg.TargetCompID, err)
+			}
+			return
+		}
+		process, ok := s.checkSequence(msg)
+		if !ok {
+			return
+		}
+		if process && !g.handleMessage(s, msg) {
+			return
+		}
+	}
+}
+
+// logon reads and validates the logon of a session, rejected logons are sent
+// a logout with the reason
+func (g *fixGateway) logon(conn net.Conn, r *bufio.Reader) (*fixSession, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(fixLogonTimeout)); err != nil {
+		return nil, err
+	}
+	msg, beginString, err := fix.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Type() != fix.MsgTypeLogon {
+		return nil, errFIXNotLogon
+	}
+
+	compID, _ := msg.Get(fix.TagSenderCompID)
+	s := &fixSession{
+		conn:         conn,
+		cfg:          config.FIXSessionConfig{TargetCompID: compID},
+		senderCompID: Bot.Config.FIXGateway.SenderCompID,
+		heartbeat:    fixDefaultHeartbeat,
+		outSeq:       1,
+		orders:       make(map[string]*fixOrder),
+	}
+	reject := func(err error) (*fixSession, error) {
+		_ = s.send(fix.NewMessage(fix.MsgTypeLogout).Set(fix.TagText, err.Error()))
+		return nil, err
+	}
+
+	if beginString != fix.BeginString44 {
+		return reject(fmt.Errorf("unsupported begin string %s", beginString))
+	}
+	if target, _ := msg.Get(fix.TagTargetCompID); target != s.senderCompID {
+		return reject(fmt.Errorf("unknown target comp ID %s", target))
+	}
+	var found bool
+	for x := range Bot.Config.FIXGateway.Sessions {
+		if Bot.Config.FIXGateway.Sessions[x].TargetCompID == compID {
+			s.cfg = Bot.Config.FIXGateway.Sessions[x]
+			found = true
+			break
+		}
+	}
+	if !found {
+		return reject(fmt.Errorf("%v %s", errFIXSessionNotConfigured, compID))
+	}
+	if password, _ := msg.Get(fix.TagPassword); s.cfg.Password != "" && password != s.cfg.Password {
+		return reject(errFIXInvalidPassword)
+	}
+	if hb, err := msg.GetInt(fix.TagHeartBtInt); err == nil && hb > 0 {
+		s.heartbeat = time.Duration(hb) * time.Second
+	}
+	seq, err := msg.GetInt(fix.TagMsgSeqNum)
+	if err != nil {
+		return reject(err)
+	}
+	s.inSeq = seq + 1
+
+	g.m.Lock()
+	if _, ok := g.sessions[compID]; ok {
+		g.m.Unlock()
+		return reject(errFIXSessionLoggedOn)
+	}
+	if g.sessions == nil {
+		g.sessions = make(map[string]*fixSession)
+	}
+	g.sessions[compID] = s
+	g.m.Unlock()
+
+	reply := fix.NewMessage(fix.MsgTypeLogon).
+		Set(fix.TagEncryptMethod, "0").
+		Set(fix.TagHeartBtInt, strconv.Itoa(int(s.heartbeat/time.Second)))
+	if v, _ := msg.Get(fix.TagResetSeqNumFlag); v == "Y" {
+		reply.Set(fix.TagResetSeqNumFlag, "Y")
+	}
+	if err = s.send(reply); err != nil {
+		g.removeSession(s)
+		return nil, err
+	}
+	return s, nil
+}
+
+func (g *fixGateway) removeSession(s *fixSession) {
+	g.m.Lock()
+	if g.sessions[s.cfg.TargetCompID] == s {
+		delete(g.sessions, s.cfg.TargetCompID)
+	}
+	g.m.Unlock()
+	log.Infof(log.FIXSys, "FIX gateway session %s logged out.\n", s.cfg.TargetCompID)
+}
+
+// checkSequence checks the sequence number of a message, returning whether it
+// is to be processed and whether the session remains logged on. Possible
+// duplicates which were already received are ignored and gaps are accepted as
+// messages are not persisted to be resent
+func (s *fixSession) checkSequence(msg *fix.Message) (process, ok bool) {
+	if msg.Type() == fix.MsgTypeSequenceReset {
+		if gapFill, _ := msg.Get(fix.TagGapFillFlag); gapFill != "Y" {
+			newSeq, err := msg.GetInt(fix.TagNewSeqNo)
+			if err != nil {
+				s.reject(msg, fix.SessionRejectReasonRequiredTagMissing, err.Error())
+				return false, true
+			}
+			s.inSeq = newSeq
+			return false, true
+		}
+	}
+
+	seq, err := msg.GetInt(fix.TagMsgSeqNum)
+	if err != nil {
+		_ = s.send(fix.NewMessage(fix.MsgTypeLogout).Set(fix.TagText, err.Error()))
+		return false, false
+	}
+	if seq < s.inSeq {
+		if possDup, _ := msg.Get(fix.TagPossDupFlag); possDup == "Y" {
+			return false, true
+		}
+		_ = s.send(fix.NewMessage(fix.MsgTypeLogout).Set(fix.TagText,
+			fmt.Sprintf("MsgSeqNum too low, expecting %d but received %d", s.inSeq, seq)))
+		return false, false
+	}
+	if seq > s.inSeq {
+		log.Warnf(log.FIXSys, "FIX gateway session %s sequence gap, expected %d but received %d.\n",
+			s.cfg.TargetCompID,
+			s.inSeq,
+			seq)
+	}
+	s.inSeq = seq + 1
+	return true, true
+}
+
+// handleMessage handles a message sent by a session and returns whether the
+// session remains logged on
+func (g *fixGateway) handleMessage(s *fixSession, msg *fix.Message) bool {
+	switch msg.Type() {
+	case fix.MsgTypeHeartbeat:
+	case fix.MsgTypeTestRequest:
+		reply := fix.NewMessage(fix.MsgTypeHeartbeat)
+		if id, ok := msg.Get(fix.TagTestReqID); ok {
+			reply.Set(fix.TagTestReqID, id)
+		}
+		s.sendOrLog(reply)
+	case fix.MsgTypeResendRequest:
+		begin, err := msg.GetInt(fix.TagBeginSeqNo)
+		if err != nil {
+			s.reject(msg, fix.SessionRejectReasonRequiredTagMissing, err.Error())
+			break
+		}
+		s.sendGapFill(begin)
+	case fix.MsgTypeSequenceReset:
+		if newSeq, err := msg.GetInt(fix.TagNewSeqNo); err == nil && newSeq > s.inSeq {
+			s.inSeq = newSeq
+		}
+	case fix.MsgTypeReject:
+		text, _ := msg.Get(fix.TagText)
+		log.Warnf(log.FIXSys, "FIX gateway session %s rejected a message: %s\n", s.cfg.TargetCompID, text)
+	case fix.MsgTypeLogout:
+		s.sendOrLog(fix.NewMessage(fix.MsgTypeLogout))
+		return false
+	case fix.MsgTypeNewOrderSingle:
+		s.newOrderSingle(msg)
+	case fix.MsgTypeOrderCancelRequest:
+		s.orderCancelRequest(msg)
+	default:
+		s.reject(msg, fix.SessionRejectReasonInvalidMsgType, "unsupported message type "+msg.Type())
+	}
+	return true
+}
+
+// newOrderSingle submits a NewOrderSingle to the order manager and reports
+// whether it was placed
+func (s *fixSession) newOrderSingle(msg *fix.Message) {
+	clOrdID, _ := msg.Get(fix.TagClOrdID)
+	if clOrdID == "" {
+		s.reject(msg, fix.SessionRejectReasonRequiredTagMissing, "ClOrdID missing")
+		return
+	}
+
+	submit, fo, err := s.parseNewOrderSingle(msg)
+	if err != nil {
+		s.rejectOrder(msg, err)
+		return
+	}
+	s.m.Lock()
+	_, exists := s.orders[clOrdID]
+	s.m.Unlock()
+	if exists {
+		s.rejectOrder(msg, errFIXDuplicateClOrdID)
+		return
+	}
+
+	resp, err := Bot.OrderManager.Submit(submit)
+	if err != nil {
+		log.Warnf(log.FIXSys, "FIX gateway session %s order %s rejected: %v\n", s.cfg.TargetCompID, clOrdID, err)
+		s.rejectOrder(msg, err)
+		return
+	}
+	fo.Exchange = submit.Exchange
+	fo.OrderID = resp.OrderID
+	fo.Status = order.New
+
+	s.m.Lock()
+	s.orders[clOrdID] = fo
+	report := s.executionReport(fo, fix.ExecTypeNew, 0)
+	s.m.Unlock()
+	s.sendOrLog(report)
+}
+
+// parseNewOrderSingle returns the order of a NewOrderSingle. Orders are placed
+// on the spot market of the ExDestination exchange, or the exchange's
+// additional account when Account is set
+func (s *fixSession) parseNewOrderSingle(msg *fix.Message) (*order.Submit, *fixOrder, error) {
+	clOrdID, _ := msg.Get(fix.TagClOrdID)
+	exchName, _ := msg.Get(fix.TagExDestination)
+	if exchName == "" {
+		return nil, nil, fmt.Errorf("tag %d missing", fix.TagExDestination)
+	}
+	if !s.allowed(exchName) {
+		return nil, nil, errFIXExchangeNotAllowed
+	}
+	if account, _ := msg.Get(fix.TagAccount); account != "" {
+		exchName = AccountExchangeName(exchName, account)
+	}
+
+	symbol, _ := msg.Get(fix.TagSymbol)
+	if len(symbol) < 3 {
+		return nil, nil, fmt.Errorf("invalid symbol %s", symbol)
+	}
+	p := currency.NewPairFromString(symbol)
+	if p.Base.IsEmpty() || p.Quote.IsEmpty() {
+		return nil, nil, fmt.Errorf("invalid symbol %s", symbol)
+	}
+
+	submit := &order.Submit{
+		Exchange:  exchName,
+		Pair:      currency.NewPair(p.Base, p.Quote),
+		AssetType: asset.Spot,
+	}
+	side, _ := msg.Get(fix.TagSide)
+	switch side {
+	case fix.SideBuy:
+		submit.Side = order.Buy
+	case fix.SideSell:
+		submit.Side = order.Sell
+	default:
+		return nil, nil, fmt.Errorf("unsupported side %s", side)
+	}
+
+	var err error
+	if submit.Amount, err = msg.GetFloat(fix.TagOrderQty); err != nil {
+		return nil, nil, err
+	}
+	ordType, _ := msg.Get(fix.TagOrdType)
+	switch ordType {
+	case fix.OrdTypeMarket:
+		submit.Type = order.Market
+	case fix.OrdTypeLimit:
+		submit.Type = order.Limit
+		if submit.Price, err = msg.GetFloat(fix.TagPrice); err != nil {
+			return nil, nil, err
+		}
+	case fix.OrdTypeStop:
+		submit.Type = order.Stop
+		if submit.TriggerPrice, err = msg.GetFloat(fix.TagStopPx); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported order type %s", ordType)
+	}
+
+	tif, _ := msg.Get(fix.TagTimeInForce)
+	switch tif {
+	case "", fix.TimeInForceDay, fix.TimeInForceGTC:
+	case fix.TimeInForceIOC:
+		submit.ImmediateOrCancel = true
+	case fix.TimeInForceFOK:
+		submit.FillOrKill = true
+	default:
+		return nil, nil, fmt.Errorf("unsupported time in force %s", tif)
+	}
+	if execInst, ok := msg.Get(fix.TagExecInst); ok {
+		for _, inst := range strings.Fields(execInst) {
+			if inst == fix.ExecInstParticipateDontInitiate {
+				submit.PostOnly = true
+			}
+		}
+	}
+
+	return submit, &fixOrder{
+		ClOrdID:   clOrdID,
+		Symbol:    symbol,
+		Pair:      submit.Pair,
+		AssetType: submit.AssetType,
+		Side:      side,
+		Quantity:  submit.Amount,
+		Price:     submit.Price,
+	}, nil
+}
+
+// allowed returns whether the session may route orders to an exchange
+func (s *fixSession) allowed(exchName string) bool {
+	if len(s.cfg.Exchanges) == 0 {
+		return true
+	}
+	for x := range s.cfg.Exchanges {
+		if strings.EqualFold(s.cfg.Exchanges[x], exchName) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderCancelRequest cancels an order submitted by the session, orders which
+// cannot be cancelled are sent an OrderCancelReject
+func (s *fixSession) orderCancelRequest(msg *fix.Message) {
+	clOrdID, _ := msg.Get(fix.TagClOrdID)
+	origClOrdID, _ := msg.Get(fix.TagOrigClOrdID)
+	if clOrdID == "" || origClOrdID == "" {
+		s.reject(msg, fix.SessionRejectReasonRequiredTagMissing, "ClOrdID and OrigClOrdID required")
+		return
+	}
+
+	s.m.Lock()
+	fo, ok := s.orders[origClOrdID]
+	var c order.Cancel
+	var done bool
+	if ok {
+		c = order.Cancel{
+			Exchange:  fo.Exchange,
+			ID:        fo.OrderID,
+			Pair:      fo.Pair,
+			AssetType: fo.AssetType,
+		}
+		done = orderDone(fo.Status)
+	}
+	s.m.Unlock()
+
+	switch {
+	case !ok:
+		s.cancelReject(msg, nil, fix.CxlRejReasonUnknown, errFIXUnknownOrder)
+		return
+	case done:
+		s.cancelReject(msg, fo, fix.CxlRejReasonTooLate, errFIXOrderDone)
+		return
+	}
+
+	if err := Bot.OrderManager.Cancel(&c); err != nil {
+		s.cancelReject(msg, fo, fix.CxlRejReasonOther, err)
+		return
+	}
+
+	s.m.Lock()
+	fo.Status = order.Cancelled
+	report := s.executionReport(fo, fix.ExecTypeCanceled, 0).
+		Set(fix.TagClOrdID, clOrdID).
+		Set(fix.TagOrigClOrdID, origClOrdID)
+	s.m.Unlock()
+	s.sendOrLog(report)
+}
+
+// update sends each logged on session the execution reports of its orders'
+// fills and status changes, and a heartbeat when it has been sent nothing
+// for its heartbeat interval
+func (g *fixGateway) update() {
+	g.m.Lock()
+	sessions := make([]*fixSession, 0, len(g.sessions))
+	for _, s := range g.sessions {
+		sessions = append(sessions, s)
+	}
+	g.m.Unlock()
+
+	for x := range sessions {
+		sessions[x].reportOrders()
+		sessions[x].m.Lock()
+		idle := time.Since(sessions[x].lastSent) >= sessions[x].heartbeat
+		sessions[x].m.Unlock()
+		if idle {
+			sessions[x].sendOrLog(fix.NewMessage(fix.MsgTypeHeartbeat))
+		}
+	}
+}
+
+// reportOrders sends execution reports for the session's open orders which
+// have been filled or changed status in the order manager
+func (s *fixSession) reportOrders() {
+	var reports []*fix.Message
+	s.m.Lock()
+	for _, fo := range s.orders {
+		if orderDone(fo.Status) {
+			continue
+		}
+		od, err := Bot.OrderManager.orderStore.GetByExchangeAndID(fo.Exchange, fo.OrderID)
+		if err != nil {
+			continue
+		}
+		executed := od.ExecutedAmount
+		if od.Status == order.Filled {
+			executed = fo.Quantity
+		}
+		if od.Status == fo.Status && executed == fo.CumQty {
+			continue
+		}
+
+		lastQty := executed - fo.CumQty
+		fo.CumQty = executed
+		fo.Status = od.Status
+		execType := fix.ExecTypeOrderStatus
+		switch {
+		case lastQty > 0:
+			execType = fix.ExecTypeTrade
+		case fo.Status == order.Cancelled, fo.Status == order.PartiallyCancelled:
+			execType = fix.ExecTypeCanceled
+		case fixOrdStatus(fo) == fix.OrdStatusRejected:
+			execType = fix.ExecTypeRejected
+		case fo.Status == order.Expired:
+			execType = fix.ExecTypeExpired
+		}
+		reports = append(reports, s.executionReport(fo, execType, lastQty))
+	}
+	s.m.Unlock()
+
+	for x := range reports {
+		s.sendOrLog(reports[x])
+	}
+}
+
+// executionReport returns an execution report of an order, s.m must be held
+func (s *fixSession) executionReport(fo *fixOrder, execType string, lastQty float64) *fix.Message {
+	leaves := fo.Quantity - fo.CumQty
+	if orderDone(fo.Status) || leaves < 0 {
+		leaves = 0
+	}
+	var avgPx float64
+	if fo.CumQty > 0 {
+		avgPx = fo.Price
+	}
+	m := fix.NewMessage(fix.MsgTypeExecutionReport).
+		Set(fix.TagOrderID, fo.OrderID).
+		Set(fix.TagClOrdID, fo.ClOrdID).
+		Set(fix.TagExecID, newFIXExecID()).
+		Set(fix.TagExecType, execType).
+		Set(fix.TagOrdStatus, fixOrdStatus(fo)).
+		Set(fix.TagSymbol, fo.Symbol).
+		Set(fix.TagSide, fo.Side).
+		SetFloat(fix.TagOrderQty, fo.Quantity).
+		SetFloat(fix.TagCumQty, fo.CumQty).
+		SetFloat(fix.TagLeavesQty, leaves).
+		SetFloat(fix.TagAvgPx, avgPx).
+		Set(fix.TagExDestination, fo.Exchange).
+		Set(fix.TagTransactTime, clock.Now().UTC().Format(fix.TimeFormat))
+	if fo.Price > 0 {
+		m.SetFloat(fix.TagPrice, fo.Price)
+	}
+	if execType == fix.ExecTypeTrade {
+		m.SetFloat(fix.TagLastQty, lastQty)
+		m.SetFloat(fix.TagLastPx, fo.Price)
+	}
+	return m
+}
+
+// rejectOrder sends a rejected execution report for a NewOrderSingle
+func (s *fixSession) rejectOrder(msg *fix.Message, err error) {
+	clOrdID, _ := msg.Get(fix.TagClOrdID)
+	report := fix.NewMessage(fix.MsgTypeExecutionReport).
+		Set(fix.TagOrderID, "NONE").
+		Set(fix.TagClOrdID, clOrdID).
+		Set(fix.TagExecID, newFIXExecID()).
+		Set(fix.TagExecType, fix.ExecTypeRejected).
+		Set(fix.TagOrdStatus, fix.OrdStatusRejected).
+		Set(fix.TagOrdRejReason, fix.OrdRejReasonOther)
+	for _, tag := range []int{fix.TagSymbol, fix.TagSide, fix.TagOrderQty} {
+		if v, ok := msg.Get(tag); ok {
+			report.Set(tag, v)
+		}
+	}
+	report.Set(fix.TagCumQty, "0").
+		Set(fix.TagLeavesQty, "0").
+		Set(fix.TagAvgPx, "0").
+		Set(fix.TagText, err.Error()).
+		Set(fix.TagTransactTime, clock.Now().UTC().Format(fix.TimeFormat))
+	s.sendOrLog(report)
+}
+
+// cancelReject sends an OrderCancelReject for an OrderCancelRequest, fo is
+// nil when the order is unknown
+func (s *fixSession) cancelReject(msg *fix.Message, fo *fixOrder, reason string, err error) {
+	clOrdID, _ := msg.Get(fix.TagClOrdID)
+	origClOrdID, _ := msg.Get(fix.TagOrigClOrdID)
+	orderID, ordStatus := "NONE", fix.OrdStatusRejected
+	if fo != nil {
+		s.m.Lock()
+		orderID, ordStatus = fo.OrderID, fixOrdStatus(fo)
+		s.m.Unlock()
+	}
+	s.sendOrLog(fix.NewMessage(fix.MsgTypeOrderCancelReject).
+		Set(fix.TagOrderID, orderID).
+		Set(fix.TagClOrdID, clOrdID).
+		Set(fix.TagOrigClOrdID, origClOrdID).
+		Set(fix.TagOrdStatus, ordStatus).
+		Set(fix.TagCxlRejResponseTo, fix.CxlRejResponseToCancel).
+		Set(fix.TagCxlRejReason, reason).
+		Set(fix.TagText, err.Error()))
+}
+
+// reject sends a session level Reject of a message
+func (s *fixSession) reject(msg *fix.Message, reason, text string) {
+	reply := fix.NewMessage(fix.MsgTypeReject).
+		Set(fix.TagRefMsgType, msg.Type()).
+		Set(fix.TagSessionRejectReason, reason).
+		Set(fix.TagText, text)
+	if seq, ok := msg.Get(fix.TagMsgSeqNum); ok {
+		reply.Set(fix.TagRefSeqNum, seq)
+	}
+	s.sendOrLog(reply)
+}
+
+// send sets the standard header of a message and sends it to the session
+func (s *fixSession) send(msg *fix.Message) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.write(msg, s.outSeq, false)
+}
+
+// sendGapFill answers a resend request with a sequence reset gap filling from
+// the requested sequence number, messages are not persisted to be resent
+func (s *fixSession) sendGapFill(begin int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if begin <= 0 || begin >= s.outSeq {
+		begin = s.outSeq
+	}
+	msg := fix.NewMessage(fix.MsgTypeSequenceReset).
+		Set(fix.TagGapFillFlag, "Y").
+		Set(fix.TagNewSeqNo, strconv.Itoa(s.outSeq+1))
+	if err := s.write(msg, begin, begin < s.outSeq); err != nil {
+		log.Errorf(log.FIXSys, "FIX gateway unable to send gap fill to session %s: %v\n", s.cfg.TargetCompID, err)
+	}
+}
+
+// write sends a message with a sequence number and advances the outgoing
+// sequence number past it, s.m must be held
+func (s *fixSession) write(msg *fix.Message, seq int, possDup bool) error {
+	msg.Set(fix.TagSenderCompID, s.senderCompID).
+		Set(fix.TagTargetCompID, s.cfg.TargetCompID).
+		Set(fix.TagMsgSeqNum, strconv.Itoa(seq)).
+		Set(fix.TagSendingTime, clock.Now().UTC().Format(fix.TimeFormat))
+	if possDup {
+		msg.Set(fix.TagPossDupFlag, "Y")
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(fixLogonTimeout)); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(msg.Bytes(fix.BeginString44)); err != nil {
+		return err
+	}
+	if seq >= s.outSeq {
+		s.outSeq = seq + 1
+	} else {
+		s.outSeq++
+	}
+	s.lastSent = time.Now()
+	return nil
+}
+
+func (s *fixSession) sendOrLog(msg *fix.Message) {
+	if err := s.send(msg); err != nil {
+		log.Errorf(log.FIXSys, "FIX gateway unable to send %s to session %s: %v\n",
+			msg.Type(),
+			s.cfg.TargetCompID,
+			err)
+	}
+}
+
+// fixOrdStatus returns the OrdStatus of an order
+func fixOrdStatus(fo *fixOrder) string {
+	switch fo.Status {
+	case order.Filled:
+		return fix.OrdStatusFilled
+	case order.Cancelled, order.PartiallyCancelled:
+		return fix.OrdStatusCanceled
+	case order.Rejected, order.InsufficientBalance, order.MarketUnavailable:
+		return fix.OrdStatusRejected
+	case order.Expired:
+		return fix.OrdStatusExpired
+	case order.PendingCancel:
+		return fix.OrdStatusPendingCancel
+	}
+	if fo.CumQty > 0 {
+		return fix.OrdStatusPartiallyFilled
+	}
+	return fix.OrdStatusNew
+}
+
+func newFIXExecID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return strconv.FormatInt(clock.Now().UnixNano(), 10)
+	}
+	return id.String()
+}
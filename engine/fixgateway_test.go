@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/fix"
+)
+
+type fixTestClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+	seq  int
+}
+
+func newFIXTestClient(t *testing.T, addr string) *fixTestClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fixTestClient{t: t, conn: conn, r: bufio.NewReader(conn), seq: 1}
+}
+
+func (c *fixTestClient) send(m *fix.Message) {
+	c.t.Helper()
+	m.Set(fix.TagSenderCompID, "CLIENT").
+		Set(fix.TagTargetCompID, "GCT").
+		Set(fix.TagMsgSeqNum, strconv.Itoa(c.seq)).
+		Set(fix.TagSendingTime, time.Now().UTC().Format(fix.TimeFormat))
+	c.seq++
+	if _, err := c.conn.Write(m.Bytes(fix.BeginString44)); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *fixTestClient) read(msgType string) *fix.Message {
+	c.t.Helper()
+	if err := c.conn.SetReadDeadline(time.Now().Add(time.Second * 5)); err != nil {
+		c.t.Fatal(err)
+	}
+	m, _, err := fix.ReadMessage(c.r)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if m.Type() != msgType {
+		c.t.Fatalf("expected message type %s, got %s", msgType, m)
+	}
+	return m
+}
+
+func expectFIXField(t *testing.T, m *fix.Message, tag int, expected string) {
+	t.Helper()
+	if v, _ := m.Get(tag); v != expected {
+		t.Errorf("expected tag %d to be %s, got %s", tag, expected, m)
+	}
+}
+
+func newOrderSingle(clOrdID, exchName string) *fix.Message {
+	return fix.NewMessage(fix.MsgTypeNewOrderSingle).
+		Set(fix.TagClOrdID, clOrdID).
+		Set(fix.TagExDestination, exchName).
+		Set(fix.TagSymbol, "BTC/USD").
+		Set(fix.TagSide, fix.SideBuy).
+		Set(fix.TagOrderQty, "1").
+		Set(fix.TagOrdType, fix.OrdTypeLimit).
+		Set(fix.TagPrice, "100")
+}
+
+func TestFIXGateway(t *testing.T) {
+	OrdersSetup(t)
+	Bot.Config.FIXGateway = config.FIXGatewayConfig{
+		ListenAddress: "127.0.0.1:0",
+		SenderCompID:  "GCT",
+		Sessions: []config.FIXSessionConfig{
+			{TargetCompID: "CLIENT", Password: "pw", Exchanges: []string{fakePassExchange}},
+		},
+	}
+	defer func() { Bot.Config.FIXGateway = config.FIXGatewayConfig{} }()
+	defer func() {
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+
+	g := &Bot.FIXGateway
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := g.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	addr := g.listener.Addr().String()
+
+	c := newFIXTestClient(t, addr)
+	c.send(fix.NewMessage(fix.MsgTypeLogon).Set(fix.TagHeartBtInt, "30").Set(fix.TagPassword, "nope"))
+	expectFIXField(t, c.read(fix.MsgTypeLogout), fix.TagText, errFIXInvalidPassword.Error())
+	c.conn.Close()
+
+	c = newFIXTestClient(t, addr)
+	defer c.conn.Close()
+	c.send(fix.NewMessage(fix.MsgTypeLogon).
+		Set(fix.TagEncryptMethod, "0").
+		Set(fix.TagHeartBtInt, "30").
+		Set(fix.TagPassword, "pw"))
+	logon := c.read(fix.MsgTypeLogon)
+	expectFIXField(t, logon, fix.TagHeartBtInt, "30")
+	expectFIXField(t, logon, fix.TagMsgSeqNum, "1")
+
+	c.send(newOrderSingle("1", testExchange))
+	rejected := c.read(fix.MsgTypeExecutionReport)
+	expectFIXField(t, rejected, fix.TagExecType, fix.ExecTypeRejected)
+	expectFIXField(t, rejected, fix.TagText, errFIXExchangeNotAllowed.Error())
+
+	c.send(newOrderSingle("2", fakePassExchange))
+	placed := c.read(fix.MsgTypeExecutionReport)
+	expectFIXField(t, placed, fix.TagExecType, fix.ExecTypeNew)
+	expectFIXField(t, placed, fix.TagOrdStatus, fix.OrdStatusNew)
+	expectFIXField(t, placed, fix.TagClOrdID, "2")
+	orderID, _ := placed.Get(fix.TagOrderID)
+	od, err := Bot.OrderManager.orderStore.GetByExchangeAndID(fakePassExchange, orderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if od.Side != order.Buy || od.Type != order.Limit || od.Price != 100 || od.Pair.String() != "BTCUSD" {
+		t.Errorf("unexpected order %+v", od)
+	}
+
+	c.send(newOrderSingle("2", fakePassExchange))
+	expectFIXField(t, c.read(fix.MsgTypeExecutionReport), fix.TagText, errFIXDuplicateClOrdID.Error())
+
+	od.Status = order.Filled
+	g.update()
+	filled := c.read(fix.MsgTypeExecutionReport)
+	expectFIXField(t, filled, fix.TagExecType, fix.ExecTypeTrade)
+	expectFIXField(t, filled, fix.TagOrdStatus, fix.OrdStatusFilled)
+	expectFIXField(t, filled, fix.TagCumQty, "1")
+	expectFIXField(t, filled, fix.TagLeavesQty, "0")
+
+	c.send(fix.NewMessage(fix.MsgTypeOrderCancelRequest).
+		Set(fix.TagClOrdID, "3").
+		Set(fix.TagOrigClOrdID, "2"))
+	tooLate := c.read(fix.MsgTypeOrderCancelReject)
+	expectFIXField(t, tooLate, fix.TagCxlRejReason, fix.CxlRejReasonTooLate)
+	expectFIXField(t, tooLate, fix.TagOrdStatus, fix.OrdStatusFilled)
+
+	c.send(fix.NewMessage(fix.MsgTypeOrderCancelRequest).
+		Set(fix.TagClOrdID, "4").
+		Set(fix.TagOrigClOrdID, "nope"))
+	expectFIXField(t, c.read(fix.MsgTypeOrderCancelReject), fix.TagCxlRejReason, fix.CxlRejReasonUnknown)
+
+	c.send(fix.NewMessage(fix.MsgTypeTestRequest).Set(fix.TagTestReqID, "ping"))
+	expectFIXField(t, c.read(fix.MsgTypeHeartbeat), fix.TagTestReqID, "ping")
+
+	c.send(fix.NewMessage("Z"))
+	expectFIXField(t, c.read(fix.MsgTypeReject), fix.TagSessionRejectReason, fix.SessionRejectReasonInvalidMsgType)
+
+	c.seq = 1
+	c.send(fix.NewMessage(fix.MsgTypeHeartbeat))
+	c.read(fix.MsgTypeLogout)
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const (
+	// fixGatewayInterval is how often logged on sessions are sent heartbeats
+	// and execution reports of their orders' fills
+	fixGatewayInterval = time.Second
+	fixLogonTimeout    = time.Second * 10
+	// fixDefaultHeartbeat is used when a session logs on without a valid
+	// heartbeat interval
+	fixDefaultHeartbeat = time.Second * 30
+)
+
+type fixGateway struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	listener net.Listener
+
+	m sync.Mutex
+	// sessions are the logged on sessions keyed by their TargetCompID
+	sessions map[string]*fixSession
+}
+
+// fixSession is a counterparty logged on to the FIX gateway. Sequence numbers
+// are not persisted and start from 1 at every logon
+type fixSession struct {
+	conn         net.Conn
+	cfg          config.FIXSessionConfig
+	senderCompID string
+	heartbeat    time.Duration
+	// inSeq is the next expected incoming sequence number, it is only
+	// accessed by the session's read routine
+	inSeq int
+
+	m        sync.Mutex
+	outSeq   int
+	lastSent time.Time
+	// orders are the orders submitted by the session keyed by ClOrdID
+	orders map[string]*fixOrder
+}
+
+// fixOrder is an order submitted over FIX along with what has been reported
+// to the session in execution reports
+type fixOrder struct {
+	ClOrdID   string
+	Exchange  string
+	OrderID   string
+	Symbol    string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Side      string
+	Quantity  float64
+	Price     float64
+	CumQty    float64
+	Status    order.Status
+}
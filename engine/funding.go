@@ -0,0 +1,212 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/funding"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+func (f *fundingHistorySyncer) Started() bool {
+	return atomic.LoadInt32(&f.started) == 1
+}
+
+func (f *fundingHistorySyncer) Start() error {
+	if !Bot.DatabaseManager.Started() {
+		return errors.New("funding history syncer requires the database manager")
+	}
+	if atomic.AddInt32(&f.started, 1) != 1 {
+		return errors.New("funding history syncer already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Funding history syncer starting...")
+	f.shutdown = make(chan struct{})
+	go f.run()
+	return nil
+}
+
+func (f *fundingHistorySyncer) Stop() error {
+	if atomic.AddInt32(&f.stopped, 1) != 1 {
+		return errors.New("funding history syncer is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Funding history syncer shutting down...")
+	close(f.shutdown)
+	return nil
+}
+
+func (f *fundingHistorySyncer) run() {
+	log.Debugln(log.PortfolioMgr, "Funding history syncer started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.FundingHistory.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&f.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&f.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Funding history syncer shutdown.")
+	}()
+
+	// sync immediately so the record is brought up to date on startup
+	f.syncAll()
+	for {
+		select {
+		case <-f.shutdown:
+			return
+		case <-tick.C:
+			f.syncAll()
+		}
+	}
+}
+
+// syncAll stores the funding history of every authenticated exchange and
+// additional account
+func (f *fundingHistorySyncer) syncAll() {
+	exchanges := getAuthenticatedExchanges()
+	for x := range exchanges {
+		select {
+		case <-f.shutdown:
+			return
+		default:
+		}
+		if err := f.sync(exchanges[x]); err != nil {
+			log.Errorf(log.PortfolioMgr, "Funding history syncer: %s unable to sync: %v\n",
+				exchanges[x].GetName(),
+				err)
+		}
+	}
+}
+
+// sync stores an exchange's deposits and withdrawals, updating those which
+// have progressed, and refreshes the portfolio's unsettled fiat transfers
+func (f *fundingHistorySyncer) sync(exch exchange.IBotExchange) error {
+	exchName := exch.GetName()
+	history, err := exch.GetFundingHistory()
+	if err != nil {
+		if err == common.ErrNotYetImplemented || err == common.ErrFunctionNotSupported {
+			return nil
+		}
+		return err
+	}
+	portfolio.GetPortfolio().SetPendingFiat(exchName, getPendingFiat(exchName, history))
+
+	records := fundingRecords(exchName, history)
+	if len(records) == 0 {
+		return nil
+	}
+	changed, err := funding.Upsert(records...)
+	if err != nil {
+		return err
+	}
+	if changed > 0 && Bot.Settings.Verbose {
+		log.Debugf(log.PortfolioMgr, "Funding history syncer: %s stored %d transfers\n",
+			exchName,
+			changed)
+	}
+	return nil
+}
+
+// fundingRecords converts funding history to stored records. Transfers which
+// are neither deposits nor withdrawals, or which have no ID to identify them
+// by when they progress, are skipped
+func fundingRecords(exchName string, history []exchange.FundHistory) []funding.Record {
+	records := make([]funding.Record, 0, len(history))
+	for x := range history {
+		direction, ok := transferDirection(history[x].TransferType)
+		if !ok || history[x].TransferID == "" {
+			continue
+		}
+		address := history[x].CryptoToAddress
+		if direction == TransferDeposit && history[x].CryptoFromAddress != "" {
+			address = history[x].CryptoFromAddress
+		}
+		records = append(records, funding.Record{
+			Exchange:     exchName,
+			TransferID:   history[x].TransferID,
+			TransferType: string(direction),
+			Currency:     strings.ToUpper(history[x].Currency),
+			Amount:       history[x].Amount,
+			Fee:          history[x].Fee,
+			Status:       history[x].Status,
+			Description:  history[x].Description,
+			Address:      address,
+			TxID:         history[x].CryptoTxID,
+			Time:         history[x].Timestamp,
+		})
+	}
+	return records
+}
+
+// GetCashFlow returns an exchange's stored transfers between start and end
+// and reconciles them with the exchange balances held by the portfolio
+func GetCashFlow(exchName string, start, end time.Time) ([]funding.Record, []CashFlow, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return nil, nil, ErrExchangeNotFound
+	}
+	records, err := funding.GetRecords(exch.GetName(), start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	balances := portfolio.GetPortfolio().GetPortfolioByExchange(exch.GetName())
+	return records, reconcileCashFlow(exch.GetName(), records, balances), nil
+}
+
+// reconcileCashFlow totals the transfers of each currency and compares the
+// completed net flow against the balance. Failed transfers are ignored
+func reconcileCashFlow(exchName string, records []funding.Record, balances map[currency.Code]float64) []CashFlow {
+	flows := make(map[string]*CashFlow)
+	get := func(c currency.Code) *CashFlow {
+		key := c.Upper().String()
+		cf, ok := flows[key]
+		if !ok {
+			cf = &CashFlow{Exchange: exchName, Currency: c.Upper()}
+			flows[key] = cf
+		}
+		return cf
+	}
+
+	for x := range records {
+		status := strings.ToLower(records[x].Status)
+		if containsAny(status, transferFailedKeywords) {
+			continue
+		}
+		cf := get(currency.NewCode(records[x].Currency))
+		cf.Transfers++
+		deposit := records[x].TransferType == string(TransferDeposit)
+		switch {
+		case !isTransferComplete(status) && deposit:
+			cf.PendingDeposits += records[x].Amount
+		case !isTransferComplete(status):
+			cf.PendingWithdrawals += records[x].Amount
+		case deposit:
+			cf.Deposits += records[x].Amount
+			cf.Fees += records[x].Fee
+		default:
+			cf.Withdrawals += records[x].Amount
+			cf.Fees += records[x].Fee
+		}
+	}
+	for c, balance := range balances {
+		get(c).Balance = balance
+	}
+
+	resp := make([]CashFlow, 0, len(flows))
+	for _, cf := range flows {
+		cf.NetFlow = cf.Deposits - cf.Withdrawals - cf.Fees
+		cf.Unexplained = cf.Balance - cf.NetFlow
+		resp = append(resp, *cf)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Currency.String() < resp[j].Currency.String()
+	})
+	return resp
+}
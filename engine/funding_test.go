@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/funding"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+)
+
+func TestFundingRecords(t *testing.T) {
+	history := []exchange.FundHistory{
+		{TransferID: "1", TransferType: "Withdrawal", Currency: "btc", Amount: 1, CryptoToAddress: "to"},
+		{TransferID: "2", TransferType: "deposit", Currency: "usd", Amount: 100, CryptoFromAddress: "from"},
+		{TransferID: "3", TransferType: "trade", Currency: "btc", Amount: 1},
+		{TransferType: "deposit", Currency: "btc", Amount: 1},
+	}
+	records := fundingRecords("Bitstamp", history)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].TransferType != string(TransferWithdrawal) ||
+		records[0].Currency != "BTC" ||
+		records[0].Address != "to" {
+		t.Errorf("unexpected withdrawal record %+v", records[0])
+	}
+	if records[1].TransferType != string(TransferDeposit) ||
+		records[1].Address != "from" {
+		t.Errorf("unexpected deposit record %+v", records[1])
+	}
+}
+
+func TestReconcileCashFlow(t *testing.T) {
+	records := []funding.Record{
+		{TransferType: "DEPOSIT", Currency: "BTC", Amount: 2, Status: "Complete"},
+		{TransferType: "WITHDRAWAL", Currency: "BTC", Amount: 0.5, Fee: 0.01, Status: "Complete"},
+		{TransferType: "WITHDRAWAL", Currency: "BTC", Amount: 0.2, Status: "Pending"},
+		{TransferType: "DEPOSIT", Currency: "BTC", Amount: 5, Status: "Cancelled"},
+		{TransferType: "DEPOSIT", Currency: "USD", Amount: 100, Status: "Pending"},
+	}
+	balances := map[currency.Code]float64{
+		currency.BTC: 1.6,
+		currency.ETH: 3,
+	}
+	flows := reconcileCashFlow("Bitstamp", records, balances)
+	if len(flows) != 3 {
+		t.Fatalf("expected 3 currencies, got %d", len(flows))
+	}
+
+	btc := flows[0]
+	if btc.Currency != currency.BTC || btc.Transfers != 3 {
+		t.Fatalf("unexpected BTC cash flow %+v", btc)
+	}
+	if btc.Deposits != 2 || btc.Withdrawals != 0.5 || btc.Fees != 0.01 ||
+		btc.PendingWithdrawals != 0.2 {
+		t.Errorf("unexpected BTC totals %+v", btc)
+	}
+	if btc.NetFlow != 1.49 || btc.Unexplained-0.11 > 1e-9 || btc.Unexplained-0.11 < -1e-9 {
+		t.Errorf("unexpected BTC reconciliation %+v", btc)
+	}
+
+	if flows[1].Currency != currency.ETH || flows[1].Unexplained != 3 {
+		t.Errorf("unexpected ETH cash flow %+v", flows[1])
+	}
+	if flows[2].Currency != currency.USD || flows[2].PendingDeposits != 100 || flows[2].Deposits != 0 {
+		t.Errorf("unexpected USD cash flow %+v", flows[2])
+	}
+}
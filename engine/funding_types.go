@@ -0,0 +1,30 @@
+package engine
+
+import "github.com/thrasher-corp/gocryptotrader/currency"
+
+// CashFlow reconciles an exchange's balance of a currency with its stored
+// deposits and withdrawals
+type CashFlow struct {
+	Exchange string
+	Currency currency.Code
+	// Deposits, Withdrawals and Fees are the totals of completed transfers
+	Deposits    float64
+	Withdrawals float64
+	Fees        float64
+	// NetFlow is the completed deposits less withdrawals and their fees
+	NetFlow            float64
+	PendingDeposits    float64
+	PendingWithdrawals float64
+	// Balance is the exchange balance held by the portfolio
+	Balance float64
+	// Unexplained is the balance not accounted for by transfers, the result
+	// of trading when the whole funding history is stored
+	Unexplained float64
+	Transfers   int
+}
+
+type fundingHistorySyncer struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+}
@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+)
+
+// Collect requests a contract's funding rates and, when authenticated, the
+// account's funding payments since those last collected, or since the
+// lookback when never collected, and persists those which are new or whose
+// accrued amount has changed. Returns the amount of rates and payments stored
+func (f *fundingRatesStore) Collect(provider exchange.FundingDataProvider, exchName string, p currency.Pair, a asset.Item, authenticated bool) (int, error) {
+	f.m.Lock()
+	err := f.load()
+	if err != nil {
+		f.m.Unlock()
+		return 0, err
+	}
+	rateStart := clock.Now().Add(-fundingRatesLookback)
+	paymentStart := rateStart
+	if h, ok := f.histories[tradeHistoryKey(exchName, p, a)]; ok {
+		if len(h.Rates) > 0 {
+			rateStart = h.Rates[len(h.Rates)-1].Timestamp
+		}
+		if len(h.Payments) > 0 {
+			paymentStart = h.Payments[len(h.Payments)-1].Timestamp
+		}
+	}
+	f.m.Unlock()
+
+	rates, err := provider.GetFundingRates(p, a, rateStart)
+	if err != nil {
+		return 0, err
+	}
+	var payments []derivative.FundingPayment
+	if authenticated {
+		payments, err = provider.GetFundingPayments(p, a, paymentStart)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return f.add(exchName, p, a, rates, payments)
+}
+
+// add merges funding rates and payments into a contract's history. Payments
+// are identified by their type and ID so that margin interest which accrues
+// over the life of a loan is updated
+func (f *fundingRatesStore) add(exchName string, p currency.Pair, a asset.Item, rates []derivative.FundingRate, payments []derivative.FundingPayment) (int, error) {
+	if len(rates) == 0 && len(payments) == 0 {
+		return 0, nil
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	if err := f.load(); err != nil {
+		return 0, err
+	}
+	key := tradeHistoryKey(exchName, p, a)
+	h, ok := f.histories[key]
+	if !ok {
+		h = &FundingHistory{
+			Exchange:  exchName,
+			Pair:      p,
+			AssetType: a,
+		}
+		f.histories[key] = h
+	}
+
+	var stored int
+	rateTimes := make(map[int64]struct{}, len(h.Rates))
+	for i := range h.Rates {
+		rateTimes[h.Rates[i].Timestamp.UnixNano()] = struct{}{}
+	}
+	for i := range rates {
+		if _, ok := rateTimes[rates[i].Timestamp.UnixNano()]; ok {
+			continue
+		}
+		rateTimes[rates[i].Timestamp.UnixNano()] = struct{}{}
+		h.Rates = append(h.Rates, rates[i])
+		stored++
+	}
+
+	paymentIndex := make(map[string]int, len(h.Payments))
+	for i := range h.Payments {
+		paymentIndex[fundingPaymentKey(&h.Payments[i])] = i
+	}
+	for i := range payments {
+		k := fundingPaymentKey(&payments[i])
+		if x, ok := paymentIndex[k]; ok {
+			if h.Payments[x].Amount != payments[i].Amount {
+				h.Payments[x] = payments[i]
+				stored++
+			}
+			continue
+		}
+		paymentIndex[k] = len(h.Payments)
+		h.Payments = append(h.Payments, payments[i])
+		stored++
+	}
+	if stored == 0 {
+		return 0, nil
+	}
+
+	sort.SliceStable(h.Rates, func(i, j int) bool {
+		return h.Rates[i].Timestamp.Before(h.Rates[j].Timestamp)
+	})
+	if len(h.Rates) > fundingRatesHistoryLength {
+		h.Rates = h.Rates[len(h.Rates)-fundingRatesHistoryLength:]
+	}
+	sort.SliceStable(h.Payments, func(i, j int) bool {
+		return h.Payments[i].Timestamp.Before(h.Payments[j].Timestamp)
+	})
+	return stored, f.save()
+}
+
+// Get returns a copy of the funding collected for an exchange's contract
+func (f *fundingRatesStore) Get(exchName string, p currency.Pair, a asset.Item) (FundingHistory, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if err := f.load(); err != nil {
+		return FundingHistory{}, err
+	}
+	h, ok := f.histories[tradeHistoryKey(exchName, p, a)]
+	if !ok {
+		return FundingHistory{Exchange: exchName, Pair: p, AssetType: a}, nil
+	}
+	resp := *h
+	resp.Rates = append([]derivative.FundingRate(nil), h.Rates...)
+	resp.Payments = append([]derivative.FundingPayment(nil), h.Payments...)
+	return resp, nil
+}
+
+// load reads the persisted funding once
+func (f *fundingRatesStore) load() error {
+	if f.histories != nil {
+		return nil
+	}
+	if f.path == "" {
+		f.path = filepath.Join(Bot.Settings.DataDir, fundingRatesFileName)
+	}
+
+	histories := make(map[string]*FundingHistory)
+	if file.Exists(f.path) {
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return err
+		}
+		var stored []*FundingHistory
+		if err = json.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("unable to load funding rates from %s: %v", f.path, err)
+		}
+		for x := range stored {
+			histories[tradeHistoryKey(stored[x].Exchange, stored[x].Pair, stored[x].AssetType)] = stored[x]
+		}
+	}
+	f.histories = histories
+	return nil
+}
+
+func (f *fundingRatesStore) save() error {
+	stored := make([]*FundingHistory, 0, len(f.histories))
+	for _, h := range f.histories {
+		stored = append(stored, h)
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		return tradeHistoryKey(stored[i].Exchange, stored[i].Pair, stored[i].AssetType) <
+			tradeHistoryKey(stored[j].Exchange, stored[j].Pair, stored[j].AssetType)
+	})
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return file.Write(f.path, data)
+}
+
+// fundingPaymentKey identifies a payment by its type and ID, falling back to
+// its time and currency when the exchange does not return payment IDs
+func fundingPaymentKey(f *derivative.FundingPayment) string {
+	if f.ID != "" {
+		return string(f.Type) + ":" + f.ID
+	}
+	return string(f.Type) + ":" + f.Currency.Upper().String() + ":" + f.Timestamp.UTC().Format(time.RFC3339Nano)
+}
+
+// accruedFunding returns the funding payments and margin interest accrued on
+// a position valued in its quote currency. Payments in the base currency are
+// valued at price, payments which cannot be valued are excluded
+func accruedFunding(payments []derivative.FundingPayment, pair currency.Pair, price float64) float64 {
+	var total float64
+	for i := range payments {
+		switch {
+		case payments[i].Currency.Match(pair.Quote):
+			total += payments[i].Amount
+		case payments[i].Currency.Match(pair.Base):
+			total += payments[i].Amount * price
+		default:
+			if v, ok := convertValue(payments[i].Amount, payments[i].Currency, pair.Quote); ok {
+				total += v
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+)
+
+type testFundingProvider struct {
+	rates        []derivative.FundingRate
+	payments     []derivative.FundingPayment
+	paymentStart time.Time
+}
+
+func (f *testFundingProvider) GetFundingRates(_ currency.Pair, _ asset.Item, start time.Time) ([]derivative.FundingRate, error) {
+	var resp []derivative.FundingRate
+	for i := range f.rates {
+		if !f.rates[i].Timestamp.Before(start) {
+			resp = append(resp, f.rates[i])
+		}
+	}
+	return resp, nil
+}
+
+func (f *testFundingProvider) GetFundingPayments(_ currency.Pair, _ asset.Item, start time.Time) ([]derivative.FundingPayment, error) {
+	f.paymentStart = start
+	return f.payments, nil
+}
+
+func TestFundingRatesStoreCollect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fundingrates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := time.Now().Add(-time.Hour * 24).Truncate(time.Hour)
+	p := currency.NewPair(currency.XBT, currency.USD)
+	provider := &testFundingProvider{
+		rates: []derivative.FundingRate{
+			{Rate: 0.0001, Timestamp: start},
+			{Rate: -0.0002, Timestamp: start.Add(time.Hour * 8)},
+		},
+		payments: []derivative.FundingPayment{
+			{Type: derivative.PerpetualFunding, ID: "1", Currency: currency.XBT, Amount: -0.001, Timestamp: start},
+			{Type: derivative.MarginInterest, ID: "1", Currency: currency.USD, Amount: -2, Timestamp: start.Add(time.Hour)},
+		},
+	}
+
+	store := fundingRatesStore{path: filepath.Join(dir, fundingRatesFileName)}
+	if stored, _ := store.Collect(provider, "Bitmex", p, asset.PerpetualContract, false); stored != 2 {
+		t.Errorf("expected only the rates to be stored when unauthenticated, got %d", stored)
+	}
+	stored, err := store.Collect(provider, "Bitmex", p, asset.PerpetualContract, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored != 2 {
+		t.Errorf("expected the payments to be stored and the rates to be skipped, got %d", stored)
+	}
+
+	// accrued interest is updated and collection resumes from the latest
+	// payment
+	provider.payments[1].Amount = -3
+	if stored, err = store.Collect(provider, "Bitmex", p, asset.PerpetualContract, true); err != nil || stored != 1 {
+		t.Errorf("expected the accrued interest to be updated, got %d %v", stored, err)
+	}
+	if !provider.paymentStart.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected payments to be requested from the latest payment, got %s", provider.paymentStart)
+	}
+
+	restored := fundingRatesStore{path: store.path}
+	h, err := restored.Get("bitmex", p, asset.PerpetualContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Rates) != 2 || len(h.Payments) != 2 || h.Payments[1].Amount != -3 {
+		t.Errorf("expected the funding to be persisted, got %+v", h)
+	}
+}
+
+func TestAccruedFunding(t *testing.T) {
+	SetupTestHelpers(t)
+	p := currency.NewPair(currency.XBT, currency.USD)
+	payments := []derivative.FundingPayment{
+		{Currency: currency.XBT, Amount: -0.001},
+		{Currency: currency.USD, Amount: 2},
+		{Currency: currency.NewCode("NOPE"), Amount: 100},
+	}
+	if funding := accruedFunding(payments, p, 10000); funding != -8 {
+		t.Errorf("expected the funding to be valued in the quote currency, got %v", funding)
+	}
+}
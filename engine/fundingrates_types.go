@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+)
+
+const (
+	fundingRatesFileName = "funding_rates.json"
+	// fundingRatesLookback is how far back funding is first collected for a
+	// contract
+	fundingRatesLookback = time.Hour * 24 * 7
+	// fundingRatesHistoryLength is the amount of funding rates retained for
+	// each contract, funding payments are all retained as they are included
+	// in the contract's profit and loss
+	fundingRatesHistoryLength = 1000
+)
+
+// FundingHistory is the funding rates and the account's funding payments and
+// margin interest collected for an exchange's contract, oldest first
+type FundingHistory struct {
+	Exchange  string                      `json:"exchange"`
+	Pair      currency.Pair               `json:"pair"`
+	AssetType asset.Item                  `json:"asset"`
+	Rates     []derivative.FundingRate    `json:"rates"`
+	Payments  []derivative.FundingPayment `json:"payments"`
+}
+
+// fundingRatesStore persists the funding collected from exchanges which
+// charge funding on perpetual contracts or interest on margin loans
+type fundingRatesStore struct {
+	// path is the file the collected funding is persisted to
+	path      string
+	m         sync.Mutex
+	histories map[string]*FundingHistory
+}
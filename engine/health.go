@@ -0,0 +1,280 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the exchange health monitor
+var (
+	ErrExchangeOffline   = errors.New("exchange is offline")
+	errNoHealthyFallback = errors.New("exchange is offline and no fallback exchange is available")
+)
+
+func (h *exchangeHealthMonitor) Started() bool {
+	return atomic.LoadInt32(&h.started) == 1
+}
+
+func (h *exchangeHealthMonitor) Start() error {
+	if atomic.AddInt32(&h.started, 1) != 1 {
+		return errors.New("exchange health monitor already started")
+	}
+
+	log.Debugln(log.ExchangeSys, "Exchange health monitor starting...")
+	h.shutdown = make(chan struct{})
+	go h.run()
+	return nil
+}
+
+func (h *exchangeHealthMonitor) Stop() error {
+	if atomic.AddInt32(&h.stopped, 1) != 1 {
+		return errors.New("exchange health monitor is already stopped")
+	}
+
+	log.Debugln(log.ExchangeSys, "Exchange health monitor shutting down...")
+	close(h.shutdown)
+	return nil
+}
+
+func (h *exchangeHealthMonitor) run() {
+	log.Debugln(log.ExchangeSys, "Exchange health monitor started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.ExchangeHealth.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&h.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&h.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.ExchangeSys, "Exchange health monitor shutdown.")
+	}()
+
+	for {
+		select {
+		case <-h.shutdown:
+			return
+		case <-tick.C:
+			h.checkAll()
+		}
+	}
+}
+
+// checkAll checks the requests and websocket connection of every loaded
+// exchange
+func (h *exchangeHealthMonitor) checkAll() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		var stats request.Stats
+		if r := exchanges[x].GetBase().Requester; r != nil {
+			stats = r.GetStats()
+		}
+		var wsEnabled, wsConnected bool
+		var disconnects int64
+		if ws, err := exchanges[x].GetWebsocket(); err == nil && ws != nil && ws.IsEnabled() {
+			wsEnabled = true
+			wsConnected = ws.IsConnected()
+			disconnects = ws.GetDisconnects()
+		}
+		h.check(exchanges[x].GetName(), &stats, wsEnabled, wsConnected, disconnects)
+	}
+}
+
+// check measures an exchange's health from its requester's stats and
+// websocket connection against the configured thresholds, notifying when its
+// status changes
+func (h *exchangeHealthMonitor) check(exchName string, stats *request.Stats, wsEnabled, wsConnected bool, disconnects int64) {
+	cfg := &Bot.Config.ExchangeHealth
+	h.m.Lock()
+	if h.states == nil {
+		h.states = make(map[string]*exchangeHealthState)
+	}
+	key := strings.ToLower(exchName)
+	s, ok := h.states[key]
+	if !ok {
+		// disconnects before the first check are not attributed to it
+		s = &exchangeHealthState{
+			requestStatus: ExchangeHealthy,
+			disconnects:   disconnects,
+			health:        ExchangeHealth{Status: ExchangeHealthy, Since: clock.Now()},
+		}
+		h.states[key] = s
+	}
+
+	window, maintenance := Bot.MaintenanceManager.Active(exchName)
+	if maintenance {
+		// failures during planned downtime are not held against the
+		// exchange once it ends
+		s.baseline = *stats
+		s.requestStatus = ExchangeHealthy
+		s.requestReason = ""
+	} else if requests := stats.Requests - s.baseline.Requests; requests >= cfg.MinRequests {
+		failures := stats.Failures - s.baseline.Failures
+		s.health.Requests = requests
+		s.health.ErrorRate = float64(failures) / float64(requests)
+		s.health.AverageLatency = (stats.TotalLatency - s.baseline.TotalLatency) / time.Duration(requests)
+		s.baseline = *stats
+		switch {
+		case s.health.ErrorRate >= cfg.OfflineErrorRate:
+			s.requestStatus = ExchangeOffline
+			s.requestReason = fmt.Sprintf("%.0f%% of requests failed", s.health.ErrorRate*100)
+		case s.health.ErrorRate >= cfg.DegradedErrorRate:
+			s.requestStatus = ExchangeDegraded
+			s.requestReason = fmt.Sprintf("%.0f%% of requests failed", s.health.ErrorRate*100)
+		case s.health.AverageLatency > cfg.MaxLatency:
+			s.requestStatus = ExchangeDegraded
+			s.requestReason = fmt.Sprintf("average request latency of %s", s.health.AverageLatency)
+		default:
+			s.requestStatus = ExchangeHealthy
+			s.requestReason = ""
+		}
+	}
+
+	status, reason := s.requestStatus, s.requestReason
+	s.health.Disconnects = disconnects - s.disconnects
+	s.disconnects = disconnects
+	s.health.WebsocketEnabled = wsEnabled
+	s.health.WebsocketConnected = wsConnected
+	switch {
+	case maintenance:
+		status, reason = ExchangeMaintenance, window.Name
+	case status == ExchangeHealthy:
+		switch {
+		case wsEnabled && !wsConnected:
+			status, reason = ExchangeDegraded, "websocket is not connected"
+		case s.health.Disconnects >= cfg.MaxDisconnects:
+			status, reason = ExchangeDegraded, fmt.Sprintf("websocket disconnected %d times", s.health.Disconnects)
+		}
+	}
+
+	prev := s.health.Status
+	s.health.Exchange = exchName
+	s.health.Status = status
+	s.health.Reason = reason
+	s.health.LastChecked = clock.Now()
+	if status != prev {
+		s.health.Since = s.health.LastChecked
+	}
+	h.m.Unlock()
+
+	if status == prev {
+		return
+	}
+	msg := fmt.Sprintf("Exchange health monitor: %s is %s", exchName, status)
+	if reason != "" {
+		msg += ": " + reason
+	}
+	if status == ExchangeHealthy || status == ExchangeMaintenance {
+		log.Infoln(log.ExchangeSys, msg)
+	} else {
+		log.Warnln(log.ExchangeSys, msg)
+	}
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "health",
+		Message: msg,
+	})
+}
+
+// status returns the health status of an exchange, exchanges which have not
+// been checked are healthy
+func (h *exchangeHealthMonitor) status(exchName string) ExchangeHealthStatus {
+	h.m.Lock()
+	defer h.m.Unlock()
+	s, ok := h.states[strings.ToLower(exchName)]
+	if !ok {
+		return ExchangeHealthy
+	}
+	return s.health.Status
+}
+
+// Allowed returns an error if orders may not be placed on the exchange because
+// it is in a maintenance window or offline. Offline exchanges are allowed when
+// the exchange health monitor is not running
+func (h *exchangeHealthMonitor) Allowed(exchName string) error {
+	// additional accounts share the availability of their exchange
+	exchName, _ = SplitAccountExchangeName(exchName)
+	if Bot.MaintenanceManager.InMaintenance(exchName) {
+		return ErrExchangeMaintenance
+	}
+	if h.Started() && h.status(exchName) == ExchangeOffline {
+		return ErrExchangeOffline
+	}
+	return nil
+}
+
+// Route returns the exchange an order should be placed on. This is the
+// order's exchange unless it is offline or in a maintenance window, in which
+// case the first healthy
+// fallback exchange with the order's pair enabled and a stored orderbook able
+// to fill the order is returned, or failing that the first healthy or
+// degraded one
+func (h *exchangeHealthMonitor) Route(s *order.Submit) (string, error) {
+	err := h.Allowed(s.Exchange)
+	if err == nil {
+		return s.Exchange, nil
+	}
+	if len(s.FallbackExchanges) == 0 {
+		return "", err
+	}
+
+	a := s.AssetType
+	if a == "" {
+		a = asset.Spot
+	}
+	var illiquid, degraded string
+	for x := range s.FallbackExchanges {
+		exch := GetExchangeByName(s.FallbackExchanges[x])
+		if exch == nil ||
+			!exch.GetEnabledPairs(a).Contains(s.Pair, true) ||
+			Bot.MaintenanceManager.InMaintenance(exch.GetName()) {
+			continue
+		}
+		switch h.status(exch.GetName()) {
+		case ExchangeHealthy:
+			if canFill(exch.GetName(), a, s) {
+				return exch.GetName(), nil
+			}
+			if illiquid == "" {
+				illiquid = exch.GetName()
+			}
+		case ExchangeDegraded:
+			if degraded == "" {
+				degraded = exch.GetName()
+			}
+		}
+	}
+	switch {
+	case illiquid != "":
+		return illiquid, nil
+	case degraded != "":
+		return degraded, nil
+	}
+	return "", errNoHealthyFallback
+}
+
+// canFill returns whether the exchange's stored orderbook has the liquidity to
+// fill the order, orders are assumed to fill when there is no orderbook
+func canFill(exchName string, a asset.Item, s *order.Submit) bool {
+	est, err := orderbook.GetSlippage(exchName, s.Pair, a, s.Amount, isBuySide(s.Side))
+	return err != nil || est.Sufficient
+}
+
+// GetAll returns a copy of the most recent health check of each exchange
+func (h *exchangeHealthMonitor) GetAll() []ExchangeHealth {
+	h.m.Lock()
+	defer h.m.Unlock()
+	health := make([]ExchangeHealth, 0, len(h.states))
+	for _, v := range h.states {
+		health = append(health, v.health)
+	}
+	return health
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func setupHealthThresholds() {
+	Bot.Config.ExchangeHealth.MinRequests = 5
+	Bot.Config.ExchangeHealth.MaxLatency = time.Second
+	Bot.Config.ExchangeHealth.DegradedErrorRate = 0.2
+	Bot.Config.ExchangeHealth.OfflineErrorRate = 0.5
+	Bot.Config.ExchangeHealth.MaxDisconnects = 3
+}
+
+func TestExchangeHealthCheck(t *testing.T) {
+	SetupTestHelpers(t)
+	setupHealthThresholds()
+	var h exchangeHealthMonitor
+
+	h.check(testExchange, &request.Stats{Requests: 2, Failures: 2}, false, false, 0)
+	if s := h.status(testExchange); s != ExchangeHealthy {
+		t.Errorf("expected too few requests to leave the exchange %s, got %s", ExchangeHealthy, s)
+	}
+
+	h.check(testExchange, &request.Stats{Requests: 10, Failures: 6, TotalLatency: time.Second}, false, false, 0)
+	if s := h.status(testExchange); s != ExchangeOffline {
+		t.Errorf("expected %s, got %s", ExchangeOffline, s)
+	}
+
+	// the previous evaluation is retained until enough requests are made
+	h.check(testExchange, &request.Stats{Requests: 13, Failures: 6, TotalLatency: time.Second}, false, false, 0)
+	if s := h.status(testExchange); s != ExchangeOffline {
+		t.Errorf("expected %s, got %s", ExchangeOffline, s)
+	}
+
+	h.check(testExchange, &request.Stats{Requests: 20, Failures: 7, TotalLatency: time.Second * 21}, false, false, 0)
+	if s := h.status(testExchange); s != ExchangeDegraded {
+		t.Errorf("expected high latency to degrade the exchange, got %s", s)
+	}
+
+	h.check(testExchange, &request.Stats{Requests: 30, Failures: 7, TotalLatency: time.Second * 22}, true, false, 0)
+	if s := h.status(testExchange); s != ExchangeDegraded {
+		t.Errorf("expected a disconnected websocket to degrade the exchange, got %s", s)
+	}
+
+	h.check(testExchange, &request.Stats{Requests: 30, Failures: 7, TotalLatency: time.Second * 22}, true, true, 3)
+	if s := h.status(testExchange); s != ExchangeDegraded {
+		t.Errorf("expected websocket disconnects to degrade the exchange, got %s", s)
+	}
+
+	h.check(testExchange, &request.Stats{Requests: 30, Failures: 7, TotalLatency: time.Second * 22}, true, true, 3)
+	health := h.GetAll()
+	if len(health) != 1 || health[0].Status != ExchangeHealthy ||
+		health[0].Requests != 10 || health[0].AverageLatency != time.Millisecond*100 {
+		t.Errorf("expected the exchange to recover, got %+v", health)
+	}
+}
+
+func TestExchangeHealthRoute(t *testing.T) {
+	SetupTestHelpers(t)
+	setupHealthThresholds()
+	var h exchangeHealthMonitor
+	s := &order.Submit{
+		Exchange:          fakePassExchange,
+		Pair:              currency.NewPairFromString("BTCUSD"),
+		AssetType:         asset.Spot,
+		FallbackExchanges: []string{"nope", testExchange},
+	}
+
+	h.check(fakePassExchange, &request.Stats{Requests: 10, Failures: 10}, false, false, 0)
+	if exch, err := h.Route(s); err != nil || exch != fakePassExchange {
+		t.Errorf("expected orders to be routed as submitted when stopped, got %s %v", exch, err)
+	}
+	if err := h.Allowed(fakePassExchange); err != nil {
+		t.Error(err)
+	}
+
+	atomic.StoreInt32(&h.started, 1)
+	defer atomic.StoreInt32(&h.started, 0)
+	if err := h.Allowed(fakePassExchange); err != ErrExchangeOffline {
+		t.Errorf("expected %v, got %v", ErrExchangeOffline, err)
+	}
+
+	exch, err := h.Route(s)
+	if err != nil || exch != testExchange {
+		t.Errorf("expected the order to be routed to %s, got %s %v", testExchange, exch, err)
+	}
+
+	h.check(testExchange, &request.Stats{Requests: 10, Failures: 10}, false, false, 0)
+	if _, err = h.Route(s); err != errNoHealthyFallback {
+		t.Errorf("expected %v, got %v", errNoHealthyFallback, err)
+	}
+
+	s.FallbackExchanges = nil
+	if _, err = h.Route(s); err != ErrExchangeOffline {
+		t.Errorf("expected %v, got %v", ErrExchangeOffline, err)
+	}
+}
+
+func TestExchangeHealthRouteLiquidity(t *testing.T) {
+	SetupTestHelpers(t)
+	setupHealthThresholds()
+	const offline = "TestExchangeHealthRouteLiquidity"
+	var h exchangeHealthMonitor
+	atomic.StoreInt32(&h.started, 1)
+	h.check(offline, &request.Stats{Requests: 10, Failures: 10}, false, false, 0)
+
+	p := currency.NewPair(currency.EUR, currency.USD)
+	s := &order.Submit{
+		Exchange:          offline,
+		Pair:              p,
+		AssetType:         asset.Spot,
+		Side:              order.Buy,
+		Amount:            5,
+		FallbackExchanges: []string{testExchange},
+	}
+	if !canFill(testExchange, asset.Spot, s) {
+		t.Error("expected orders to be assumed to fill without an orderbook")
+	}
+
+	ob := orderbook.Base{
+		ExchangeName: testExchange,
+		Pair:         p,
+		AssetType:    asset.Spot,
+		Bids:         []orderbook.Item{{Price: 1.1, Amount: 10}},
+		Asks:         []orderbook.Item{{Price: 1.2, Amount: 1}},
+	}
+	if err := ob.Process(); err != nil {
+		t.Fatal(err)
+	}
+	if canFill(testExchange, asset.Spot, s) {
+		t.Error("expected the orderbook to be unable to fill the order")
+	}
+	s.Side = order.Sell
+	if !canFill(testExchange, asset.Spot, s) {
+		t.Error("expected the orderbook to fill the sell order")
+	}
+
+	// healthy fallbacks unable to fill the order are still preferred to
+	// degraded ones
+	s.Side = order.Buy
+	if exch, err := h.Route(s); err != nil || exch != testExchange {
+		t.Errorf("expected the order to route to %s, got %s %v", testExchange, exch, err)
+	}
+}
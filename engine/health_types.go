@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+// ExchangeHealthStatus is the health of an exchange as measured by the
+// exchange health monitor
+type ExchangeHealthStatus string
+
+// All exchange health statuses
+const (
+	ExchangeHealthy  ExchangeHealthStatus = "healthy"
+	ExchangeDegraded ExchangeHealthStatus = "degraded"
+	ExchangeOffline  ExchangeHealthStatus = "offline"
+	// ExchangeMaintenance is the status of exchanges in a maintenance window,
+	// their requests are not evaluated until the window ends
+	ExchangeMaintenance ExchangeHealthStatus = "maintenance"
+)
+
+// ExchangeHealth is the outcome of the most recent health check of an
+// exchange
+type ExchangeHealth struct {
+	Exchange string
+	Status   ExchangeHealthStatus
+	Reason   string
+	// Requests, ErrorRate and AverageLatency are measured over the requests
+	// made since the latency and error rate were last evaluated
+	Requests           int64
+	ErrorRate          float64
+	AverageLatency     time.Duration
+	WebsocketEnabled   bool
+	WebsocketConnected bool
+	// Disconnects is the amount of websocket disconnects since the previous
+	// check
+	Disconnects int64
+	LastChecked time.Time
+	// Since is the time the exchange changed to its current status
+	Since time.Time
+}
+
+// exchangeHealthState is the health of an exchange and the counters it was
+// measured from
+type exchangeHealthState struct {
+	health ExchangeHealth
+	// baseline is the requester's stats when the latency and error rate were
+	// last evaluated
+	baseline request.Stats
+	// requestStatus and requestReason are the outcome of the last evaluation
+	// of the latency and error rate, retained until enough requests are made
+	// to evaluate them again
+	requestStatus ExchangeHealthStatus
+	requestReason string
+	disconnects   int64
+}
+
+type exchangeHealthMonitor struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m      sync.Mutex
+	states map[string]*exchangeHealthState
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
@@ -15,6 +16,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pquerna/otp/totp"
@@ -25,18 +27,24 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/utils"
 )
 
 var (
 	errCertExpired     = errors.New("gRPC TLS certificate has expired")
 	errCertDataIsNil   = errors.New("gRPC TLS certificate PEM data is nil")
 	errCertTypeInvalid = errors.New("gRPC TLS certificate type is invalid")
+
+	// certsMtx stops the remote control servers, which start concurrently,
+	// from generating the self-signed certificate at the same time
+	certsMtx sync.Mutex
 )
 
 // GetSubsystemsStatus returns the status of various subsystems
@@ -46,6 +54,25 @@ func GetSubsystemsStatus() map[string]bool {
 	systems["internet_monitor"] = Bot.ConnectionManager.Started()
 	systems["orders"] = Bot.OrderManager.Started()
 	systems["portfolio"] = Bot.PortfolioManager.Started()
+	systems["transfer_times"] = Bot.TransferTimeManager.Started()
+	systems["cold_storage_sweep"] = Bot.SweepManager.Started()
+	systems["conditional_orders"] = Bot.ConditionalManager.Started()
+	systems["automations"] = Bot.AutomationManager.Started()
+	systems["positions"] = Bot.PositionManager.Started()
+	systems["resource_monitor"] = Bot.ResourceMonitor.Started()
+	systems["settlement"] = Bot.SettlementManager.Started()
+	systems["daily_report"] = Bot.DailyReporter.Started()
+	systems["event_bus"] = Bot.EventBus.Started()
+	systems["balance_cache"] = Bot.BalanceCache.Started()
+	systems["snapshot_export"] = Bot.SnapshotExporter.Started()
+	systems["time_series"] = Bot.TimeSeriesWriter.Started()
+	systems["strategies"] = Bot.StrategyManager.Started()
+	systems["request_audit"] = Bot.RequestAuditor.Started()
+	systems["bot_state"] = Bot.BotStateManager.Started()
+	systems["triangular_arbitrage"] = Bot.TriangularArbDetector.Started()
+	systems["time_sync"] = Bot.TimeSyncChecker.Started()
+	systems["maintenance"] = Bot.MaintenanceManager.Started()
+	systems["fix_gateway"] = Bot.FIXGateway.Started()
 	systems["ntp_timekeeper"] = Bot.NTPManager.Started()
 	systems["database"] = Bot.DatabaseManager.Started()
 	systems["exchange_syncer"] = Bot.Settings.EnableExchangeSyncManager
@@ -73,21 +100,27 @@ func GetRPCEndpoints() map[string]RPCEndpoint {
 	}
 	endpoints["grpc_proxy"] = RPCEndpoint{
 		Started:    Bot.Settings.EnableGRPCProxy,
-		ListenAddr: "http://" + Bot.Config.RemoteControl.GRPC.GRPCProxyListenAddress,
+		ListenAddr: urlScheme("http", Bot.Config.RemoteControl.GRPC.GRPCProxyTLS) + Bot.Config.RemoteControl.GRPC.GRPCProxyListenAddress,
 	}
 	endpoints["deprecated_rpc"] = RPCEndpoint{
 		Started:    Bot.Settings.EnableDeprecatedRPC,
-		ListenAddr: "http://" + Bot.Config.RemoteControl.DeprecatedRPC.ListenAddress,
+		ListenAddr: urlScheme("http", Bot.Config.RemoteControl.DeprecatedRPC.TLS) + Bot.Config.RemoteControl.DeprecatedRPC.ListenAddress,
 	}
 	endpoints["websocket_rpc"] = RPCEndpoint{
 		Started:    Bot.Settings.EnableWebsocketRPC,
-		ListenAddr: "ws://" + Bot.Config.RemoteControl.WebsocketRPC.ListenAddress,
+		ListenAddr: urlScheme("ws", Bot.Config.RemoteControl.WebsocketRPC.TLS) + Bot.Config.RemoteControl.WebsocketRPC.ListenAddress,
 	}
 	return endpoints
 }
 
 // SetSubsystem enables or disables an engine subsystem
 func SetSubsystem(subsys string, enable bool) error {
+	if enable {
+		if err := checkDataOnlySubsystem(subsys); err != nil {
+			return err
+		}
+	}
+
 	switch strings.ToLower(subsys) {
 	case "communications":
 		if enable {
@@ -109,6 +142,96 @@ func SetSubsystem(subsys string, enable bool) error {
 			return Bot.PortfolioManager.Start()
 		}
 		return Bot.OrderManager.Stop()
+	case "transfer_times":
+		if enable {
+			return Bot.TransferTimeManager.Start()
+		}
+		return Bot.TransferTimeManager.Stop()
+	case "cold_storage_sweep":
+		if enable {
+			return Bot.SweepManager.Start()
+		}
+		return Bot.SweepManager.Stop()
+	case "conditional_orders":
+		if enable {
+			return Bot.ConditionalManager.Start()
+		}
+		return Bot.ConditionalManager.Stop()
+	case "automations":
+		if enable {
+			return Bot.AutomationManager.Start()
+		}
+		return Bot.AutomationManager.Stop()
+	case "positions":
+		if enable {
+			return Bot.PositionManager.Start()
+		}
+		return Bot.PositionManager.Stop()
+	case "settlement":
+		if enable {
+			return Bot.SettlementManager.Start()
+		}
+		return Bot.SettlementManager.Stop()
+	case "daily_report":
+		if enable {
+			return Bot.DailyReporter.Start()
+		}
+		return Bot.DailyReporter.Stop()
+	case "event_bus":
+		if enable {
+			return Bot.EventBus.Start()
+		}
+		return Bot.EventBus.Stop()
+	case "balance_cache":
+		if enable {
+			return Bot.BalanceCache.Start()
+		}
+		return Bot.BalanceCache.Stop()
+	case "snapshot_export":
+		if enable {
+			return Bot.SnapshotExporter.Start()
+		}
+		return Bot.SnapshotExporter.Stop()
+	case "time_series":
+		if enable {
+			return Bot.TimeSeriesWriter.Start()
+		}
+		return Bot.TimeSeriesWriter.Stop()
+	case "strategies":
+		if enable {
+			return Bot.StrategyManager.Start()
+		}
+		return Bot.StrategyManager.Stop()
+	case "request_audit":
+		if enable {
+			return Bot.RequestAuditor.Start()
+		}
+		return Bot.RequestAuditor.Stop()
+	case "bot_state":
+		if enable {
+			return Bot.BotStateManager.Start()
+		}
+		return Bot.BotStateManager.Stop()
+	case "triangular_arbitrage":
+		if enable {
+			return Bot.TriangularArbDetector.Start()
+		}
+		return Bot.TriangularArbDetector.Stop()
+	case "time_sync":
+		if enable {
+			return Bot.TimeSyncChecker.Start()
+		}
+		return Bot.TimeSyncChecker.Stop()
+	case "maintenance":
+		if enable {
+			return Bot.MaintenanceManager.Start()
+		}
+		return Bot.MaintenanceManager.Stop()
+	case "fix_gateway":
+		if enable {
+			return Bot.FIXGateway.Start()
+		}
+		return Bot.FIXGateway.Stop()
 	case "ntp_timekeeper":
 		if enable {
 			return Bot.NTPManager.Start()
@@ -180,10 +303,11 @@ func GetExchangeoOTPByName(exchName string) (string, error) {
 	return "", errors.New("exchange does not have a OTP secret stored")
 }
 
-// GetAuthAPISupportedExchanges returns a list of auth api enabled exchanges
+// GetAuthAPISupportedExchanges returns a list of auth api enabled exchanges,
+// including the account exchange names of their additional accounts
 func GetAuthAPISupportedExchanges() []string {
 	var exchangeNames []string
-	exchanges := GetExchanges()
+	exchanges := append(GetExchanges(), GetExchangeAccounts()...)
 	for x := range exchanges {
 		if !exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) &&
 			!exchanges[x].GetAuthenticatedAPISupport(exchange.WebsocketAuthentication) {
@@ -451,6 +575,90 @@ func GetSpecificTicker(p currency.Pair, exchangeName string, assetType asset.Ite
 	return exch.FetchTicker(p, assetType)
 }
 
+// GetOrderbookAnalytics returns the spread, imbalance and depth of the stored
+// orderbooks of the enabled pairs of loaded exchanges. An empty exchange name,
+// pair or asset type matches all, orderbooks which are not stored are skipped
+// unless a single orderbook is requested
+func GetOrderbookAnalytics(exchangeName string, p currency.Pair, assetType asset.Item, depthPercents []float64) ([]orderbook.Analytics, error) {
+	for x := range depthPercents {
+		if depthPercents[x] <= 0 {
+			return nil, orderbook.ErrInvalidDepthPercent
+		}
+	}
+	if exchangeName != "" && !p.IsEmpty() && assetType != "" {
+		exch := GetExchangeByName(exchangeName)
+		if exch == nil {
+			return nil, ErrExchangeNotFound
+		}
+		a, err := orderbook.GetAnalytics(exch.GetName(), p, assetType, depthPercents...)
+		if err != nil {
+			return nil, err
+		}
+		a.Exchange = exch.GetName()
+		return []orderbook.Analytics{*a}, nil
+	}
+
+	var exchanges []exchange.IBotExchange
+	if exchangeName != "" {
+		exch := GetExchangeByName(exchangeName)
+		if exch == nil {
+			return nil, ErrExchangeNotFound
+		}
+		exchanges = append(exchanges, exch)
+	} else {
+		exchanges = GetExchanges()
+	}
+
+	var analytics []orderbook.Analytics
+	for x := range exchanges {
+		assets := exchanges[x].GetAssetTypes()
+		for y := range assets {
+			if assetType != "" && assets[y] != assetType {
+				continue
+			}
+			pairs := exchanges[x].GetEnabledPairs(assets[y])
+			for z := range pairs {
+				if !p.IsEmpty() && !pairs[z].Equal(p) {
+					continue
+				}
+				a, err := orderbook.GetAnalytics(exchanges[x].GetName(), pairs[z], assets[y], depthPercents...)
+				if err != nil {
+					continue
+				}
+				a.Exchange = exchanges[x].GetName()
+				analytics = append(analytics, *a)
+			}
+		}
+	}
+	return analytics, nil
+}
+
+// EstimateSlippage returns the estimated fill of a market order for an amount
+// of the base currency against an exchange's stored orderbook
+func EstimateSlippage(exchangeName string, p currency.Pair, assetType asset.Item, side order.Side, amount float64) (*orderbook.Slippage, error) {
+	exch := GetExchangeByName(exchangeName)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	if assetType == "" {
+		assetType = asset.Spot
+	}
+	var buy bool
+	switch side {
+	case order.Buy, order.Bid:
+		buy = true
+	case order.Sell, order.Ask:
+	default:
+		return nil, fmt.Errorf("%v %s", order.ErrSideIsInvalid, side)
+	}
+	s, err := orderbook.GetSlippage(exch.GetName(), p, assetType, amount, buy)
+	if err != nil {
+		return nil, err
+	}
+	s.Exchange = exch.GetName()
+	return s, nil
+}
+
 // GetCollatedExchangeAccountInfoByCoin collates individual exchange account
 // information and turns into into a map string of
 // exchange.AccountCurrencyInfo
@@ -460,19 +668,22 @@ func GetCollatedExchangeAccountInfoByCoin(accounts []account.Holdings) map[curre
 		for y := range accounts[x].Accounts {
 			for z := range accounts[x].Accounts[y].Currencies {
 				currencyName := accounts[x].Accounts[y].Currencies[z].CurrencyName
-				avail := accounts[x].Accounts[y].Currencies[z].TotalValue
+				total := accounts[x].Accounts[y].Currencies[z].TotalValue
 				onHold := accounts[x].Accounts[y].Currencies[z].Hold
+				avail := accounts[x].Accounts[y].Currencies[z].Available
 				info, ok := result[currencyName]
 				if !ok {
 					accountInfo := account.Balance{
 						CurrencyName: currencyName,
 						Hold:         onHold,
-						TotalValue:   avail,
+						TotalValue:   total,
+						Available:    avail,
 					}
 					result[currencyName] = accountInfo
 				} else {
 					info.Hold += onHold
-					info.TotalValue += avail
+					info.TotalValue += total
+					info.Available += avail
 					result[currencyName] = info
 				}
 			}
@@ -503,6 +714,60 @@ func GetExchangeLowestPriceByCurrencyPair(p currency.Pair, assetType asset.Item)
 	return result[0].Exchange, nil
 }
 
+// SeedExchangePendingFiat updates the portfolio with unsettled fiat deposits
+// and withdrawals from each authenticated exchange's funding history
+func SeedExchangePendingFiat() {
+	port := portfolio.GetPortfolio()
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if !exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+			continue
+		}
+		exchName := exchanges[x].GetName()
+		history, err := exchanges[x].GetFundingHistory()
+		if err != nil {
+			if err != common.ErrNotYetImplemented && err != common.ErrFunctionNotSupported {
+				log.Errorf(log.PortfolioMgr,
+					"Portfolio: unable to get %s funding history: %s\n",
+					exchName,
+					err)
+			}
+			continue
+		}
+		port.SetPendingFiat(exchName, getPendingFiat(exchName, history))
+	}
+}
+
+// getPendingFiat returns the fiat transfers in the funding history which have
+// neither completed nor failed
+func getPendingFiat(exchName string, history []exchange.FundHistory) []portfolio.PendingFiat {
+	var pending []portfolio.PendingFiat
+	for i := range history {
+		c := currency.NewCode(history[i].Currency)
+		if !c.IsFiatCurrency() {
+			continue
+		}
+		direction, ok := transferDirection(history[i].TransferType)
+		if !ok {
+			continue
+		}
+		status := strings.ToLower(history[i].Status)
+		if isTransferComplete(status) || containsAny(status, transferFailedKeywords) {
+			continue
+		}
+		pending = append(pending, portfolio.PendingFiat{
+			Exchange:  exchName,
+			ID:        history[i].TransferID,
+			Currency:  c,
+			Amount:    history[i].Amount,
+			Deposit:   direction == TransferDeposit,
+			Status:    history[i].Status,
+			Timestamp: history[i].Timestamp,
+		})
+	}
+	return pending
+}
+
 // SeedExchangeAccountInfo seeds account info
 func SeedExchangeAccountInfo(accounts []account.Holdings) {
 	if len(accounts) == 0 {
@@ -521,6 +786,7 @@ func SeedExchangeAccountInfo(accounts []account.Holdings) {
 					if accounts[x].Accounts[y].Currencies[z].CurrencyName == currencies[i].CurrencyName {
 						currencies[i].Hold += accounts[x].Accounts[y].Currencies[z].Hold
 						currencies[i].TotalValue += accounts[x].Accounts[y].Currencies[z].TotalValue
+						currencies[i].Available += accounts[x].Accounts[y].Currencies[z].Available
 						update = true
 					}
 				}
@@ -533,6 +799,7 @@ func SeedExchangeAccountInfo(accounts []account.Holdings) {
 					CurrencyName: accounts[x].Accounts[y].Currencies[z].CurrencyName,
 					TotalValue:   accounts[x].Accounts[y].Currencies[z].TotalValue,
 					Hold:         accounts[x].Accounts[y].Currencies[z].Hold,
+					Available:    accounts[x].Accounts[y].Currencies[z].Available,
 				})
 			}
 		}
@@ -735,9 +1002,10 @@ func GetAllActiveTickers() []EnabledExchangeCurrencies {
 }
 
 // GetAllEnabledExchangeAccountInfo returns all the current enabled exchanges
+// and their additional accounts
 func GetAllEnabledExchangeAccountInfo() AllEnabledExchangeAccounts {
 	var response AllEnabledExchangeAccounts
-	exchanges := GetExchanges()
+	exchanges := append(GetExchanges(), GetExchangeAccounts()...)
 	for x := range exchanges {
 		if !exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
 			if Bot.Settings.Verbose {
@@ -745,7 +1013,7 @@ func GetAllEnabledExchangeAccountInfo() AllEnabledExchangeAccounts {
 			}
 			continue
 		}
-		accountInfo, err := exchanges[x].FetchAccountInfo()
+		accountInfo, err := getAccountInfo(exchanges[x])
 		if err != nil {
 			log.Errorf(log.ExchangeSys, "Error encountered retrieving exchange account info for %s. Error %s\n",
 				exchanges[x].GetName(), err)
@@ -878,3 +1146,33 @@ func genCert(targetDir string) error {
 	log.Infof(log.Global, "gRPC TLS key.pem and cert.pem files written to %s\n", targetDir)
 	return nil
 }
+
+// remoteControlCerts returns the certificate and key files the remote control
+// servers are served with. A self-signed certificate is generated in the data
+// directory when none is configured
+func remoteControlCerts() (certFile, keyFile string, err error) {
+	certsMtx.Lock()
+	defer certsMtx.Unlock()
+
+	cfg := Bot.Config.RemoteControl.TLS
+	if cfg.CertFile != "" {
+		if _, err = tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err != nil {
+			return "", "", fmt.Errorf("unable to load TLS certificate and key: %s", err)
+		}
+		var pemData []byte
+		pemData, err = ioutil.ReadFile(cfg.CertFile)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to open TLS cert file: %s", err)
+		}
+		if err = verifyCert(pemData); err != nil {
+			return "", "", err
+		}
+		return cfg.CertFile, cfg.KeyFile, nil
+	}
+
+	targetDir := utils.GetTLSDir(Bot.Settings.DataDir)
+	if err = checkCerts(targetDir); err != nil {
+		return "", "", err
+	}
+	return filepath.Join(targetDir, "cert.pem"), filepath.Join(targetDir, "key.pem"), nil
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"io/ioutil"
 	"math/big"
 	"net"
 	"os"
@@ -18,8 +19,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -436,6 +439,91 @@ func TestGetSpecificOrderbook(t *testing.T) {
 	UnloadExchange("Bitstamp")
 }
 
+func TestGetOrderbookAnalytics(t *testing.T) {
+	SetupTestHelpers(t)
+	exch := GetExchangeByName(testExchange)
+	pairs := exch.GetEnabledPairs(asset.Spot)
+	if len(pairs) == 0 {
+		t.Fatal("expected enabled pairs")
+	}
+	base := orderbook.Base{
+		Pair:         pairs[0],
+		Bids:         []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 101, Amount: 1}},
+		ExchangeName: testExchange,
+		AssetType:    asset.Spot,
+	}
+	if err := base.Process(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := GetOrderbookAnalytics(testExchange, pairs[0], asset.Spot, []float64{-1})
+	if err != orderbook.ErrInvalidDepthPercent {
+		t.Errorf("expected %v, got %v", orderbook.ErrInvalidDepthPercent, err)
+	}
+	if _, err = GetOrderbookAnalytics("meow", currency.Pair{}, "", nil); err != ErrExchangeNotFound {
+		t.Errorf("expected %v, got %v", ErrExchangeNotFound, err)
+	}
+
+	a, err := GetOrderbookAnalytics(testExchange, pairs[0], asset.Spot, []float64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 1 || a[0].Exchange != testExchange || a[0].SpreadPercent != 2 {
+		t.Errorf("unexpected analytics %+v", a)
+	}
+
+	// orderbooks which are not stored are skipped when listing
+	a, err = GetOrderbookAnalytics("", currency.Pair{}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for x := range a {
+		if a[x].Exchange == testExchange && a[x].Pair.Equal(pairs[0]) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the %s %s analytics, got %+v", testExchange, pairs[0], a)
+	}
+}
+
+func TestEstimateSlippage(t *testing.T) {
+	SetupTestHelpers(t)
+	p := currency.NewPair(currency.XRP, currency.EUR)
+	base := orderbook.Base{
+		Pair:         p,
+		Bids:         []orderbook.Item{{Price: 0.19, Amount: 100}},
+		Asks:         []orderbook.Item{{Price: 0.21, Amount: 100}, {Price: 0.22, Amount: 100}},
+		ExchangeName: testExchange,
+		AssetType:    asset.Spot,
+	}
+	if err := base.Process(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := EstimateSlippage("meow", p, asset.Spot, order.Buy, 1); err != ErrExchangeNotFound {
+		t.Errorf("expected %v, got %v", ErrExchangeNotFound, err)
+	}
+	if _, err := EstimateSlippage(testExchange, p, asset.Spot, order.AnySide, 1); err == nil {
+		t.Error("expected an error for an invalid side")
+	}
+	s, err := EstimateSlippage(testExchange, p, "", order.Bid, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Sufficient || s.AveragePrice != 0.215 || s.Exchange != testExchange {
+		t.Errorf("unexpected estimate %+v", s)
+	}
+	s, err = EstimateSlippage(testExchange, p, asset.Spot, order.Sell, 101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Sufficient || s.Filled != 100 {
+		t.Errorf("expected insufficient bid liquidity, got %+v", s)
+	}
+}
 func TestGetSpecificTicker(t *testing.T) {
 	SetupTestHelpers(t)
 
@@ -571,6 +659,27 @@ func TestGetExchangeLowestPriceByCurrencyPair(t *testing.T) {
 	}
 }
 
+func TestGetPendingFiat(t *testing.T) {
+	history := []exchange.FundHistory{
+		{TransferID: "1", Currency: "USD", Amount: 100, TransferType: "deposit", Status: "pending"},
+		{TransferID: "2", Currency: "EUR", Amount: 50, TransferType: "withdrawal", Status: "processing"},
+		{TransferID: "3", Currency: "USD", Amount: 10, TransferType: "deposit", Status: "completed"},
+		{TransferID: "4", Currency: "USD", Amount: 10, TransferType: "withdrawal", Status: "cancelled"},
+		{TransferID: "5", Currency: "BTC", Amount: 1, TransferType: "deposit", Status: "pending"},
+		{TransferID: "6", Currency: "USD", Amount: 1, TransferType: "trade", Status: "pending"},
+	}
+	pending := getPendingFiat(testExchange, history)
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending fiat transfers, got %d", len(pending))
+	}
+	if !pending[0].Deposit || pending[0].Currency != currency.USD || pending[0].Amount != 100 {
+		t.Errorf("unexpected pending deposit %+v", pending[0])
+	}
+	if pending[1].Deposit || pending[1].Currency != currency.EUR {
+		t.Errorf("unexpected pending withdrawal %+v", pending[1])
+	}
+}
+
 func TestGetCryptocurrenciesByExchange(t *testing.T) {
 	SetupTestHelpers(t)
 
@@ -743,3 +852,51 @@ func TestCheckAndGenCerts(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRemoteControlCerts(t *testing.T) {
+	SetupTestHelpers(t)
+	tempDir, err := ioutil.TempDir("", "gct-remote-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	oldDataDir, oldTLS := Bot.Settings.DataDir, Bot.Config.RemoteControl.TLS
+	defer func() {
+		Bot.Settings.DataDir = oldDataDir
+		Bot.Config.RemoteControl.TLS = oldTLS
+	}()
+
+	// a self-signed certificate is generated when none is configured
+	Bot.Settings.DataDir = tempDir
+	Bot.Config.RemoteControl.TLS = config.TLSConfig{}
+	certFile, keyFile, err := remoteControlCerts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certFile != filepath.Join(tempDir, "tls", "cert.pem") || keyFile != filepath.Join(tempDir, "tls", "key.pem") {
+		t.Errorf("expected the generated certificate, got %s %s", certFile, keyFile)
+	}
+
+	customDir := filepath.Join(tempDir, "custom")
+	if err = genCert(customDir); err != nil {
+		t.Fatal(err)
+	}
+	Bot.Config.RemoteControl.TLS = config.TLSConfig{
+		CertFile: filepath.Join(customDir, "cert.pem"),
+		KeyFile:  filepath.Join(customDir, "key.pem"),
+	}
+	certFile, keyFile, err = remoteControlCerts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certFile != Bot.Config.RemoteControl.TLS.CertFile || keyFile != Bot.Config.RemoteControl.TLS.KeyFile {
+		t.Errorf("expected the configured certificate, got %s %s", certFile, keyFile)
+	}
+
+	// a configured key which does not match the certificate is an error
+	Bot.Config.RemoteControl.TLS.KeyFile = filepath.Join(tempDir, "tls", "key.pem")
+	if _, _, err = remoteControlCerts(); err == nil {
+		t.Error("expected an error with a mismatched certificate and key")
+	}
+}
@@ -0,0 +1,394 @@
+package engine
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errHistoryExchangeRequired = errors.New("an exchange to download history from is required")
+	errHistoryInvalidPair      = errors.New("invalid currency pair")
+	errHistoryInvalidAsset     = errors.New("invalid asset type")
+	errHistoryInvalidDataType  = errors.New("data type must be candles or trades")
+	errHistoryInvalidRange     = errors.New("start must be before end")
+	errHistoryTradesToDatabase = errors.New("trades can only be downloaded to a CSV file")
+	errHistoryInvalidCSV       = errors.New("existing CSV file does not match the data type")
+)
+
+var (
+	historyCandleHeader = []string{"timestamp", "open", "high", "low", "close", "volume"}
+	historyTradeHeader  = []string{"timestamp", "id", "price", "amount", "type"}
+)
+
+// DownloadHistory bulk downloads an exchange pair's historic candles or
+// trades over a date range to a CSV file or the database, printing its
+// progress to w. Downloads resume after the last candle or trade already
+// written so an interrupted download can be rerun with the same settings
+func (e *Engine) DownloadHistory(w io.Writer, s *HistoryDownloadSettings) error {
+	d, err := newHistoryDownload(s, time.Now())
+	if err != nil {
+		return err
+	}
+	if e.Settings.ExchangePurgeCredentials {
+		e.Config.PurgeExchangeAPICredentials()
+	}
+	err = LoadExchange(d.Exchange, false, nil)
+	if err != nil && err != ErrExchangeAlreadyLoaded {
+		return err
+	}
+	exch := GetExchangeByName(d.Exchange)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+	d.Exchange = exch.GetName()
+
+	if d.Output == "" {
+		if err = e.DatabaseManager.Start(); err != nil {
+			return err
+		}
+		defer func() {
+			if errStop := e.DatabaseManager.Stop(); errStop != nil {
+				log.Errorf(log.Global, "Database manager unable to stop. Error: %v", errStop)
+			}
+		}()
+	}
+	return d.run(exch, w)
+}
+
+// newHistoryDownload validates history download settings and applies their
+// defaults
+func newHistoryDownload(s *HistoryDownloadSettings, now time.Time) (*historyDownload, error) {
+	d := &historyDownload{HistoryDownloadSettings: *s}
+	if d.Exchange == "" {
+		return nil, errHistoryExchangeRequired
+	}
+	if len(d.Pair) < 4 {
+		return nil, errHistoryInvalidPair
+	}
+	d.pair = currency.NewPairFromString(d.Pair)
+	if d.pair.Base.IsEmpty() || d.pair.Quote.IsEmpty() {
+		return nil, errHistoryInvalidPair
+	}
+
+	if d.Asset == "" {
+		d.Asset = asset.Spot.String()
+	}
+	d.asset = asset.Item(strings.ToLower(d.Asset))
+	if !asset.IsValid(d.asset) {
+		return nil, errHistoryInvalidAsset
+	}
+
+	switch strings.ToLower(d.DataType) {
+	case "", HistoryCandles:
+		d.DataType = HistoryCandles
+	case HistoryTrades:
+		d.DataType = HistoryTrades
+		if d.Output == "" {
+			return nil, errHistoryTradesToDatabase
+		}
+	default:
+		return nil, errHistoryInvalidDataType
+	}
+
+	if d.Interval <= 0 {
+		d.Interval = DefaultHistoryInterval
+	}
+	if d.BatchSize <= 0 {
+		d.BatchSize = DefaultHistoryBatchSize
+	}
+	if d.Delay < 0 {
+		d.Delay = 0
+	}
+
+	var err error
+	if d.start, err = parseHistoryTime(d.Start); err != nil {
+		return nil, fmt.Errorf("start: %v", err)
+	}
+	if d.End == "" {
+		d.end = now
+	} else if d.end, err = parseHistoryTime(d.End); err != nil {
+		return nil, fmt.Errorf("end: %v", err)
+	}
+	if !d.start.Before(d.end) {
+		return nil, errHistoryInvalidRange
+	}
+	return d, nil
+}
+
+// parseHistoryTime parses a date or an RFC3339 time
+func parseHistoryTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func (d *historyDownload) run(exch exchange.IBotExchange, w io.Writer) error {
+	var csvFile *os.File
+	var csvWriter *csv.Writer
+	var resume time.Time
+	var err error
+	if d.Output != "" {
+		if resume, err = d.csvResumeTime(); err != nil {
+			return err
+		}
+		csvFile, err = os.OpenFile(d.Output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer csvFile.Close()
+		csvWriter = csv.NewWriter(csvFile)
+		var info os.FileInfo
+		if info, err = csvFile.Stat(); err != nil {
+			return err
+		}
+		if info.Size() == 0 {
+			if err = d.writeCSV(csvWriter, [][]string{d.csvHeader()}); err != nil {
+				return err
+			}
+		}
+	} else if resume, err = candle.GetLastTime(d.series()); err != nil {
+		return err
+	}
+
+	// records written before the start of the range are from an earlier
+	// download and are not resumed from
+	start := d.start
+	resuming := !resume.IsZero() && !resume.Before(d.start)
+	if resuming {
+		start = resume
+		fmt.Fprintf(w, "Resuming %s %s %s %s download after %s\n",
+			d.Exchange, d.pair, d.asset, d.DataType, resume.UTC().Format(time.RFC3339))
+	}
+	if d.DataType == HistoryTrades {
+		return d.downloadTrades(exch, w, csvWriter, start, resuming)
+	}
+	return d.downloadCandles(exch, w, csvWriter, start, resuming)
+}
+
+// downloadCandles requests the candles between start and end in windows of the
+// batch size, pausing between requests, and writes each window as it is
+// received. When resuming, start is the open time of the last written candle
+// which is not written again
+func (d *historyDownload) downloadCandles(exch exchange.IBotExchange, w io.Writer, csvWriter *csv.Writer, start time.Time, resuming bool) error {
+	if resuming {
+		start = start.Add(d.Interval)
+	}
+	var total int
+	for cursor := start; cursor.Before(d.end); {
+		windowEnd := cursor.Add(d.Interval * time.Duration(d.BatchSize))
+		if windowEnd.After(d.end) {
+			windowEnd = d.end
+		}
+		item, err := d.getCandles(exch, cursor, windowEnd)
+		if err != nil {
+			return err
+		}
+
+		candles := make([]kline.Candle, 0, len(item.Candles))
+		for x := range item.Candles {
+			if item.Candles[x].Time.Before(cursor) || !item.Candles[x].Time.Before(windowEnd) {
+				continue
+			}
+			candles = append(candles, item.Candles[x])
+		}
+		sort.Slice(candles, func(i, j int) bool {
+			return candles[i].Time.Before(candles[j].Time)
+		})
+		if err = d.writeCandles(csvWriter, candles); err != nil {
+			return err
+		}
+		total += len(candles)
+		fmt.Fprintf(w, "%s %s %s: %d candles written up to %s (%.1f%%)\n",
+			d.Exchange, d.pair, d.asset, total,
+			windowEnd.UTC().Format(time.RFC3339),
+			float64(windowEnd.Sub(start))/float64(d.end.Sub(start))*100)
+
+		cursor = windowEnd
+		if cursor.Before(d.end) && d.Delay > 0 {
+			time.Sleep(d.Delay)
+		}
+	}
+	return nil
+}
+
+// getCandles requests candles, retrying failed requests with an increasing
+// pause. Requests unsupported by the exchange are not retried
+func (d *historyDownload) getCandles(exch exchange.IBotExchange, start, end time.Time) (kline.Item, error) {
+	for attempt := 1; ; attempt++ {
+		item, err := exch.GetHistoricCandles(d.pair, d.asset, start, end, d.Interval)
+		if err == nil ||
+			err == common.ErrNotYetImplemented ||
+			err == common.ErrFunctionNotSupported ||
+			attempt > historyDownloadRetries {
+			return item, err
+		}
+		wait := d.Delay * time.Duration(1<<uint(attempt))
+		log.Warnf(log.ExchangeSys, "%s historic candles request failed, retrying in %s: %v\n",
+			d.Exchange, wait, err)
+		time.Sleep(wait)
+	}
+}
+
+// downloadTrades writes the trades between start and end. Exchanges only
+// return their recent trades so trades older than those returned cannot be
+// downloaded. When resuming, trades at or before start are not written again
+func (d *historyDownload) downloadTrades(exch exchange.IBotExchange, w io.Writer, csvWriter *csv.Writer, start time.Time, resuming bool) error {
+	resp, err := exch.GetExchangeHistory(d.pair, d.asset)
+	if err != nil {
+		return err
+	}
+	trades := make([]exchange.TradeHistory, 0, len(resp))
+	for x := range resp {
+		if resp[x].Timestamp.Before(start) ||
+			(resuming && resp[x].Timestamp.Equal(start)) ||
+			!resp[x].Timestamp.Before(d.end) {
+			continue
+		}
+		trades = append(trades, resp[x])
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Timestamp.Before(trades[j].Timestamp)
+	})
+
+	records := make([][]string, len(trades))
+	for x := range trades {
+		records[x] = []string{
+			trades[x].Timestamp.UTC().Format(time.RFC3339Nano),
+			trades[x].TID,
+			strconv.FormatFloat(trades[x].Price, 'f', -1, 64),
+			strconv.FormatFloat(trades[x].Amount, 'f', -1, 64),
+			trades[x].Type,
+		}
+	}
+	if err = d.writeCSV(csvWriter, records); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s %s %s: %d trades written\n", d.Exchange, d.pair, d.asset, len(trades))
+	if len(resp) > 0 && !resuming {
+		earliest := resp[0].Timestamp
+		for x := range resp {
+			if resp[x].Timestamp.Before(earliest) {
+				earliest = resp[x].Timestamp
+			}
+		}
+		if earliest.After(start) {
+			fmt.Fprintf(w, "%s only returned trades from %s, earlier trades are unavailable\n",
+				d.Exchange, earliest.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+func (d *historyDownload) writeCandles(csvWriter *csv.Writer, candles []kline.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	if csvWriter == nil {
+		stored := make([]candle.Candle, len(candles))
+		for x := range candles {
+			stored[x] = candle.Candle{
+				Time:   candles[x].Time,
+				Open:   candles[x].Open,
+				High:   candles[x].High,
+				Low:    candles[x].Low,
+				Close:  candles[x].Close,
+				Volume: candles[x].Volume,
+			}
+		}
+		_, err := candle.Upsert(d.series(), stored...)
+		return err
+	}
+
+	records := make([][]string, len(candles))
+	for x := range candles {
+		records[x] = []string{
+			candles[x].Time.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(candles[x].Open, 'f', -1, 64),
+			strconv.FormatFloat(candles[x].High, 'f', -1, 64),
+			strconv.FormatFloat(candles[x].Low, 'f', -1, 64),
+			strconv.FormatFloat(candles[x].Close, 'f', -1, 64),
+			strconv.FormatFloat(candles[x].Volume, 'f', -1, 64),
+		}
+	}
+	return d.writeCSV(csvWriter, records)
+}
+
+// writeCSV writes and flushes records so that progress is kept should the
+// download be interrupted
+func (d *historyDownload) writeCSV(csvWriter *csv.Writer, records [][]string) error {
+	for x := range records {
+		if err := csvWriter.Write(records[x]); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func (d *historyDownload) csvHeader() []string {
+	if d.DataType == HistoryTrades {
+		return historyTradeHeader
+	}
+	return historyCandleHeader
+}
+
+// csvResumeTime returns the time of the last record of an existing CSV
+// file, or a zero time when the file does not exist or has no records
+func (d *historyDownload) csvResumeTime() (time.Time, error) {
+	f, err := os.Open(d.Output)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header := d.csvHeader()
+	var last []string
+	for {
+		var record []string
+		record, err = r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%v: %v", errHistoryInvalidCSV, err)
+		}
+		if last == nil && strings.Join(record, ",") != strings.Join(header, ",") {
+			return time.Time{}, errHistoryInvalidCSV
+		}
+		last = record
+	}
+	if len(last) == 0 || last[0] == header[0] {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, last[0])
+}
+
+func (d *historyDownload) series() *candle.Series {
+	return &candle.Series{
+		Exchange: d.Exchange,
+		Base:     d.pair.Base.String(),
+		Quote:    d.pair.Quote.String(),
+		Asset:    d.asset.String(),
+		Interval: d.Interval,
+	}
+}
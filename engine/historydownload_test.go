@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// fakeHistoryExchange returns a candle for every interval of a request, along
+// with a candle outside of it, and fails requests with its errors in turn
+type fakeHistoryExchange struct {
+	FakePassingExchange
+	requests int
+	errs     []error
+	trades   []exchange.TradeHistory
+}
+
+func (f *fakeHistoryExchange) GetHistoricCandles(p currency.Pair, a asset.Item, start, end time.Time, interval time.Duration) (kline.Item, error) {
+	f.requests++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return kline.Item{}, err
+	}
+	item := kline.Item{Pair: p, Asset: a, Interval: interval}
+	for t := start; t.Before(end); t = t.Add(interval) {
+		item.Candles = append(item.Candles, kline.Candle{Time: t, Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10})
+	}
+	item.Candles = append(item.Candles, kline.Candle{Time: end})
+	return item, nil
+}
+
+func (f *fakeHistoryExchange) GetExchangeHistory(_ currency.Pair, _ asset.Item) ([]exchange.TradeHistory, error) {
+	return f.trades, nil
+}
+
+func readHistoryCSV(t *testing.T, path string) []string {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func TestNewHistoryDownload(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2020, 5, 27, 0, 0, 0, 0, time.UTC)
+	d, err := newHistoryDownload(&HistoryDownloadSettings{
+		Exchange: "Bitstamp",
+		Pair:     "btc-usd",
+		Start:    "2020-05-01",
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.DataType != HistoryCandles || d.asset != asset.Spot || d.Interval != DefaultHistoryInterval ||
+		d.BatchSize != DefaultHistoryBatchSize || !d.end.Equal(now) ||
+		!d.start.Equal(time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)) ||
+		!d.pair.Base.Match(currency.BTC) || !d.pair.Quote.Match(currency.USD) {
+		t.Errorf("unexpected defaults %+v", d)
+	}
+
+	for _, tc := range []struct {
+		s   HistoryDownloadSettings
+		err error
+	}{
+		{HistoryDownloadSettings{Pair: "BTCUSD", Start: "2020-05-01"}, errHistoryExchangeRequired},
+		{HistoryDownloadSettings{Exchange: "Bitstamp", Pair: "BTC", Start: "2020-05-01"}, errHistoryInvalidPair},
+		{HistoryDownloadSettings{Exchange: "Bitstamp", Pair: "BTCUSD", Asset: "nope", Start: "2020-05-01"}, errHistoryInvalidAsset},
+		{HistoryDownloadSettings{Exchange: "Bitstamp", Pair: "BTCUSD", DataType: "nope", Start: "2020-05-01"}, errHistoryInvalidDataType},
+		{HistoryDownloadSettings{Exchange: "Bitstamp", Pair: "BTCUSD", DataType: HistoryTrades, Start: "2020-05-01"}, errHistoryTradesToDatabase},
+		{HistoryDownloadSettings{Exchange: "Bitstamp", Pair: "BTCUSD", Start: "2020-05-02", End: "2020-05-01T00:00:00Z"}, errHistoryInvalidRange},
+	} {
+		if _, err = newHistoryDownload(&tc.s, now); err != tc.err {
+			t.Errorf("%+v: expected %v, got %v", tc.s, tc.err, err)
+		}
+	}
+	if _, err = newHistoryDownload(&HistoryDownloadSettings{Exchange: "Bitstamp", Pair: "BTCUSD", Start: "yesterday"}, now); err == nil {
+		t.Error("expected error for an invalid start")
+	}
+}
+
+func TestDownloadHistoryCandles(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "candles.csv")
+	s := &HistoryDownloadSettings{
+		Exchange:  fakePassExchange,
+		Pair:      "BTCUSD",
+		Interval:  time.Hour,
+		Start:     "2020-05-01T00:00:00Z",
+		End:       "2020-05-01T05:00:00Z",
+		Output:    output,
+		BatchSize: 2,
+	}
+	d, err := newHistoryDownload(s, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeHistoryExchange{errs: []error{errors.New("rate limited")}}
+	var progress bytes.Buffer
+	if err = d.run(f, &progress); err != nil {
+		t.Fatal(err)
+	}
+	// the failed request is retried and the range is requested in 3 windows
+	if f.requests != 4 {
+		t.Errorf("expected 4 requests, got %d", f.requests)
+	}
+	lines := readHistoryCSV(t, output)
+	if len(lines) != 6 {
+		t.Fatalf("expected a header and 5 candles, got %v", lines)
+	}
+	if lines[0] != "timestamp,open,high,low,close,volume" ||
+		lines[1] != "2020-05-01T00:00:00Z,1,2,0.5,1.5,10" ||
+		!strings.HasPrefix(lines[5], "2020-05-01T04:00:00Z") {
+		t.Errorf("unexpected candles %v", lines)
+	}
+	if !strings.Contains(progress.String(), "5 candles written up to 2020-05-01T05:00:00Z (100.0%)") {
+		t.Errorf("unexpected progress %s", progress.String())
+	}
+
+	// extending the range resumes after the last written candle
+	s.End = "2020-05-01T07:00:00Z"
+	if d, err = newHistoryDownload(s, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	f.requests = 0
+	progress.Reset()
+	if err = d.run(f, &progress); err != nil {
+		t.Fatal(err)
+	}
+	if f.requests != 1 {
+		t.Errorf("expected 1 request, got %d", f.requests)
+	}
+	lines = readHistoryCSV(t, output)
+	if len(lines) != 8 || !strings.HasPrefix(lines[6], "2020-05-01T05:00:00Z") {
+		t.Errorf("expected 2 candles to be appended, got %v", lines)
+	}
+	if !strings.HasPrefix(progress.String(), "Resuming") {
+		t.Errorf("unexpected progress %s", progress.String())
+	}
+
+	f.errs = []error{common.ErrFunctionNotSupported}
+	s.End = "2020-05-01T08:00:00Z"
+	if d, err = newHistoryDownload(s, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	f.requests = 0
+	if err = d.run(f, ioutil.Discard); err != common.ErrFunctionNotSupported {
+		t.Errorf("expected %v, got %v", common.ErrFunctionNotSupported, err)
+	}
+	if f.requests != 1 {
+		t.Errorf("expected unsupported requests not to be retried, got %d requests", f.requests)
+	}
+
+	s.DataType = HistoryTrades
+	if d, err = newHistoryDownload(s, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.run(f, ioutil.Discard); err != errHistoryInvalidCSV {
+		t.Errorf("expected %v, got %v", errHistoryInvalidCSV, err)
+	}
+}
+
+func TestDownloadHistoryTrades(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	f := &fakeHistoryExchange{
+		trades: []exchange.TradeHistory{
+			{Timestamp: start.Add(time.Minute * 2), TID: "3", Price: 102, Amount: 1, Type: "sell"},
+			{Timestamp: start.Add(time.Minute), TID: "2", Price: 101, Amount: 0.5, Type: "buy"},
+			{Timestamp: start.Add(-time.Minute), TID: "1", Price: 100, Amount: 1, Type: "buy"},
+		},
+	}
+	s := &HistoryDownloadSettings{
+		Exchange: fakePassExchange,
+		Pair:     "BTCUSD",
+		DataType: HistoryTrades,
+		Start:    "2020-05-01",
+		End:      "2020-05-02",
+		Output:   filepath.Join(dir, "trades.csv"),
+	}
+	d, err := newHistoryDownload(s, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = d.run(f, ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+	lines := readHistoryCSV(t, s.Output)
+	if len(lines) != 3 || lines[1] != "2020-05-01T00:01:00Z,2,101,0.5,buy" ||
+		lines[2] != "2020-05-01T00:02:00Z,3,102,1,sell" {
+		t.Errorf("unexpected trades %v", lines)
+	}
+
+	// trades already written are skipped when resuming
+	f.trades = append(f.trades, exchange.TradeHistory{Timestamp: start.Add(time.Minute * 3), TID: "4", Price: 103, Amount: 1, Type: "buy"})
+	var progress bytes.Buffer
+	if err = d.run(f, &progress); err != nil {
+		t.Fatal(err)
+	}
+	lines = readHistoryCSV(t, s.Output)
+	if len(lines) != 4 || !strings.HasPrefix(lines[3], "2020-05-01T00:03:00Z,4") {
+		t.Errorf("expected 1 trade to be appended, got %v", lines)
+	}
+	if !strings.Contains(progress.String(), "1 trades written") {
+		t.Errorf("unexpected progress %s", progress.String())
+	}
+}
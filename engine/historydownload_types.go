@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// History download data types
+const (
+	HistoryCandles = "candles"
+	HistoryTrades  = "trades"
+)
+
+// History download defaults
+const (
+	DefaultHistoryInterval  = time.Hour
+	DefaultHistoryBatchSize = 500
+	DefaultHistoryDelay     = time.Second
+	// historyDownloadRetries is the amount of times a failed request is
+	// retried, backing off from the delay, before the download stops
+	historyDownloadRetries = 3
+)
+
+// HistoryDownloadSettings are the settings of a bulk download of an exchange
+// pair's historic candles or trades
+type HistoryDownloadSettings struct {
+	Exchange string
+	Pair     string
+	Asset    string
+	DataType string
+	Interval time.Duration
+	// Start and End are either dates or RFC3339 times, End defaults to now
+	Start string
+	End   string
+	// Output is the CSV file written to, candles are stored in the database
+	// when empty
+	Output string
+	// BatchSize is the amount of candles requested per request
+	BatchSize int
+	// Delay is the pause between requests
+	Delay time.Duration
+}
+
+// historyDownload is a validated history download
+type historyDownload struct {
+	HistoryDownloadSettings
+	pair  currency.Pair
+	asset asset.Item
+	start time.Time
+	end   time.Time
+}
@@ -0,0 +1,322 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the intent manager
+var (
+	ErrIntentNotFound      = errors.New("intent does not exist")
+	errIntentIsNil         = errors.New("intent is nil")
+	errIntentNotEnoughLegs = errors.New("intent requires at least two legs")
+	errIntentInvalidUnwind = errors.New("intent unwind rule is invalid")
+)
+
+// Submit validates and executes each leg of an intent in sequence. If a leg
+// fails to be placed, the remaining legs are skipped and the legs which have
+// already been placed are unwound according to the intents unwind rule
+func (i *intentManager) Submit(intent *Intent) (*Intent, error) {
+	if intent == nil {
+		return nil, errIntentIsNil
+	}
+
+	if len(intent.Legs) < 2 {
+		return nil, errIntentNotEnoughLegs
+	}
+
+	if intent.UnwindRule == "" {
+		intent.UnwindRule = UnwindCancel
+	}
+
+	switch intent.UnwindRule {
+	case UnwindNone, UnwindCancel, UnwindReverse:
+	default:
+		return nil, errIntentInvalidUnwind
+	}
+
+	for x := range intent.Legs {
+		if intent.Legs[x].Order.Exchange == "" {
+			return nil, fmt.Errorf("intent leg %d: order exchange name must be specified", x)
+		}
+		if err := intent.Legs[x].Order.Validate(); err != nil {
+			return nil, fmt.Errorf("intent leg %d: %v", x, err)
+		}
+		intent.Legs[x].Status = LegPending
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	intent.ID = id.String()
+	intent.Status = IntentPending
+	intent.Created = clock.Now()
+	intent.LastUpdated = intent.Created
+
+	i.m.Lock()
+	if i.intents == nil {
+		i.intents = make(map[string]*Intent)
+	}
+	i.intents[intent.ID] = intent
+	i.m.Unlock()
+
+	i.execute(intent)
+	return i.GetByID(intent.ID)
+}
+
+// GetByID returns a copy of the intent matching the supplied ID with the leg
+// statuses updated from the order manager
+func (i *intentManager) GetByID(id string) (*Intent, error) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	intent, ok := i.intents[id]
+	if !ok {
+		return nil, ErrIntentNotFound
+	}
+	i.refresh(intent)
+	return copyIntent(intent), nil
+}
+
+// GetAll returns a copy of all intents with leg statuses updated from the
+// order manager
+func (i *intentManager) GetAll() []Intent {
+	i.m.Lock()
+	defer i.m.Unlock()
+	var intents []Intent
+	for _, v := range i.intents {
+		i.refresh(v)
+		intents = append(intents, *copyIntent(v))
+	}
+	return intents
+}
+
+func (i *intentManager) execute(intent *Intent) {
+	i.setStatus(intent, IntentExecuting)
+	failed := -1
+	for x := range intent.Legs {
+		leg := intent.Legs[x].Order
+		resp, err := Bot.OrderManager.Submit(&leg)
+		i.m.Lock()
+		if err != nil {
+			intent.Legs[x].Status = LegFailed
+			intent.Legs[x].Error = err.Error()
+			i.m.Unlock()
+			failed = x
+			break
+		}
+		intent.Legs[x].Status = LegPlaced
+		intent.Legs[x].OrderID = resp.OrderID
+		intent.Legs[x].InternalOrderID = resp.InternalOrderID
+		i.m.Unlock()
+	}
+
+	if failed == -1 {
+		i.m.Lock()
+		i.refresh(intent)
+		i.m.Unlock()
+		i.notify(intent, "executed")
+		return
+	}
+
+	i.m.Lock()
+	for x := failed + 1; x < len(intent.Legs); x++ {
+		intent.Legs[x].Status = LegSkipped
+	}
+	i.m.Unlock()
+
+	log.Warnf(log.OrderMgr,
+		"Intent manager: intent %s leg %d failed: %s",
+		intent.ID,
+		failed,
+		intent.Legs[failed].Error)
+
+	if failed == 0 {
+		i.setStatus(intent, IntentFailed)
+		i.notify(intent, "failed")
+		return
+	}
+
+	if intent.UnwindRule == UnwindNone {
+		i.setStatus(intent, IntentPartiallyCompleted)
+		i.notify(intent, "partially completed")
+		return
+	}
+
+	i.setStatus(intent, IntentUnwinding)
+	if i.unwind(intent, failed) {
+		i.setStatus(intent, IntentUnwound)
+		i.notify(intent, "unwound")
+		return
+	}
+	i.setStatus(intent, IntentFailed)
+	i.notify(intent, "failed to unwind")
+}
+
+// unwind works backwards through the legs which were placed before the failed
+// leg and cancels or reverses them. Returns false if any leg could not be
+// unwound
+func (i *intentManager) unwind(intent *Intent, failed int) bool {
+	success := true
+	for x := failed - 1; x >= 0; x-- {
+		leg := &intent.Legs[x]
+		od, err := Bot.OrderManager.orderStore.GetByInternalOrderID(leg.InternalOrderID)
+		if err != nil {
+			i.setLegError(leg, err)
+			success = false
+			continue
+		}
+
+		if od.Status != order.Filled && od.Status != order.Cancelled {
+			err = Bot.OrderManager.Cancel(&order.Cancel{
+				Exchange:  od.Exchange,
+				ID:        od.ID,
+				AccountID: od.AccountID,
+				ClientID:  od.ClientID,
+				Type:      od.Type,
+				Side:      od.Side,
+				Pair:      od.Pair,
+				AssetType: od.AssetType,
+			})
+			if err != nil {
+				i.setLegError(leg, err)
+				success = false
+				continue
+			}
+			i.m.Lock()
+			leg.Status = LegCancelled
+			i.m.Unlock()
+		}
+
+		if intent.UnwindRule != UnwindReverse {
+			continue
+		}
+
+		amount := od.ExecutedAmount
+		if od.Status == order.Filled && amount == 0 {
+			amount = od.Amount
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		resp, err := Bot.OrderManager.Submit(&order.Submit{
+			Exchange:  od.Exchange,
+			Pair:      od.Pair,
+			AssetType: od.AssetType,
+			Side:      oppositeSide(od.Side),
+			Type:      order.Market,
+			Amount:    amount,
+			AccountID: od.AccountID,
+		})
+		if err != nil {
+			i.setLegError(leg, err)
+			success = false
+			continue
+		}
+		i.m.Lock()
+		leg.Status = LegUnwound
+		leg.UnwindOrderID = resp.OrderID
+		i.m.Unlock()
+	}
+	return success
+}
+
+// refresh updates the leg statuses from the order store. Must be called with
+// the intent manager lock held
+func (i *intentManager) refresh(intent *Intent) {
+	if intent.Status != IntentExecuting {
+		return
+	}
+
+	filled := 0
+	for x := range intent.Legs {
+		if intent.Legs[x].Status != LegPlaced && intent.Legs[x].Status != LegFilled {
+			continue
+		}
+		od, err := Bot.OrderManager.orderStore.GetByInternalOrderID(intent.Legs[x].InternalOrderID)
+		if err != nil {
+			continue
+		}
+		switch od.Status {
+		case order.Filled:
+			intent.Legs[x].Status = LegFilled
+			filled++
+		case order.Cancelled:
+			intent.Legs[x].Status = LegCancelled
+		}
+	}
+
+	if filled == len(intent.Legs) {
+		intent.Status = IntentCompleted
+		intent.LastUpdated = clock.Now()
+	}
+}
+
+func (i *intentManager) setStatus(intent *Intent, status IntentStatus) {
+	i.m.Lock()
+	intent.Status = status
+	intent.LastUpdated = clock.Now()
+	i.m.Unlock()
+}
+
+func (i *intentManager) setLegError(leg *IntentLeg, err error) {
+	i.m.Lock()
+	leg.Error = err.Error()
+	i.m.Unlock()
+}
+
+func (i *intentManager) notify(intent *Intent, action string) {
+	msg := fmt.Sprintf("Intent manager: intent %s [%s] %s. Legs: %s",
+		intent.ID,
+		intent.Description,
+		action,
+		intent.legSummary())
+	log.Debugln(log.OrderMgr, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "intent",
+		Message: msg,
+	})
+}
+
+func (in *Intent) legSummary() string {
+	var s []string
+	for x := range in.Legs {
+		s = append(s, fmt.Sprintf("%s %s %s %v@%v=%s",
+			in.Legs[x].Order.Exchange,
+			in.Legs[x].Order.Side,
+			in.Legs[x].Order.Pair,
+			in.Legs[x].Order.Amount,
+			in.Legs[x].Order.Price,
+			in.Legs[x].Status))
+	}
+	return strings.Join(s, ", ")
+}
+
+func copyIntent(in *Intent) *Intent {
+	c := *in
+	c.Legs = make([]IntentLeg, len(in.Legs))
+	copy(c.Legs, in.Legs)
+	return &c
+}
+
+func oppositeSide(s order.Side) order.Side {
+	switch s {
+	case order.Buy:
+		return order.Sell
+	case order.Sell:
+		return order.Buy
+	case order.Bid:
+		return order.Ask
+	case order.Ask:
+		return order.Bid
+	}
+	return order.UnknownSide
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testIntentLeg(exch string, side order.Side) IntentLeg {
+	return IntentLeg{
+		Order: order.Submit{
+			Exchange:  exch,
+			Pair:      currency.NewPairFromString("BTCUSD"),
+			AssetType: asset.Spot,
+			Side:      side,
+			Type:      order.Limit,
+			Amount:    1,
+			Price:     1,
+		},
+	}
+}
+
+func TestIntentSubmitValidation(t *testing.T) {
+	OrdersSetup(t)
+	var i intentManager
+	_, err := i.Submit(nil)
+	if err != errIntentIsNil {
+		t.Errorf("expected %v, got %v", errIntentIsNil, err)
+	}
+
+	_, err = i.Submit(&Intent{Legs: []IntentLeg{testIntentLeg(fakePassExchange, order.Buy)}})
+	if err != errIntentNotEnoughLegs {
+		t.Errorf("expected %v, got %v", errIntentNotEnoughLegs, err)
+	}
+
+	_, err = i.Submit(&Intent{
+		UnwindRule: "SOMETIMES",
+		Legs: []IntentLeg{
+			testIntentLeg(fakePassExchange, order.Buy),
+			testIntentLeg(fakePassExchange, order.Sell),
+		},
+	})
+	if err != errIntentInvalidUnwind {
+		t.Errorf("expected %v, got %v", errIntentInvalidUnwind, err)
+	}
+
+	badLeg := testIntentLeg(fakePassExchange, order.Sell)
+	badLeg.Order.Amount = 0
+	_, err = i.Submit(&Intent{
+		Legs: []IntentLeg{
+			testIntentLeg(fakePassExchange, order.Buy),
+			badLeg,
+		},
+	})
+	if err == nil {
+		t.Error("expected error from invalid leg")
+	}
+}
+
+func TestIntentSubmitFirstLegFails(t *testing.T) {
+	OrdersSetup(t)
+	var i intentManager
+	resp, err := i.Submit(&Intent{
+		Description: "test",
+		Legs: []IntentLeg{
+			testIntentLeg("unloadedExchange", order.Buy),
+			testIntentLeg(fakePassExchange, order.Sell),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Status != IntentFailed {
+		t.Errorf("expected intent status %s, got %s", IntentFailed, resp.Status)
+	}
+	if resp.Legs[0].Status != LegFailed || resp.Legs[0].Error == "" {
+		t.Errorf("expected first leg to fail with an error, got %s", resp.Legs[0].Status)
+	}
+	if resp.Legs[1].Status != LegSkipped {
+		t.Errorf("expected second leg to be skipped, got %s", resp.Legs[1].Status)
+	}
+	if resp.UnwindRule != UnwindCancel {
+		t.Errorf("expected default unwind rule %s, got %s", UnwindCancel, resp.UnwindRule)
+	}
+
+	_, err = i.GetByID(resp.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(i.GetAll()) != 1 {
+		t.Error("expected one intent to be stored")
+	}
+
+	_, err = i.GetByID("fake")
+	if err != ErrIntentNotFound {
+		t.Errorf("expected %v, got %v", ErrIntentNotFound, err)
+	}
+}
+
+func TestOppositeSide(t *testing.T) {
+	if oppositeSide(order.Buy) != order.Sell ||
+		oppositeSide(order.Sell) != order.Buy ||
+		oppositeSide(order.Bid) != order.Ask ||
+		oppositeSide(order.Ask) != order.Bid ||
+		oppositeSide(order.AnySide) != order.UnknownSide {
+		t.Error("unexpected opposite side")
+	}
+}
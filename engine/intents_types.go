@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// IntentStatus defines the overall state of a multi-leg intent
+type IntentStatus string
+
+// All intent status types
+const (
+	IntentPending            IntentStatus = "PENDING"
+	IntentExecuting          IntentStatus = "EXECUTING"
+	IntentCompleted          IntentStatus = "COMPLETED"
+	IntentPartiallyCompleted IntentStatus = "PARTIALLY_COMPLETED"
+	IntentUnwinding          IntentStatus = "UNWINDING"
+	IntentUnwound            IntentStatus = "UNWOUND"
+	IntentFailed             IntentStatus = "FAILED"
+)
+
+// LegStatus defines the state of an individual intent leg
+type LegStatus string
+
+// All intent leg status types
+const (
+	LegPending   LegStatus = "PENDING"
+	LegPlaced    LegStatus = "PLACED"
+	LegFilled    LegStatus = "FILLED"
+	LegFailed    LegStatus = "FAILED"
+	LegSkipped   LegStatus = "SKIPPED"
+	LegCancelled LegStatus = "CANCELLED"
+	LegUnwound   LegStatus = "UNWOUND"
+)
+
+// UnwindRule determines what happens to already placed legs when a
+// subsequent leg of an intent fails
+type UnwindRule string
+
+// All intent unwind rules
+const (
+	// UnwindNone leaves any placed legs as they are
+	UnwindNone UnwindRule = "NONE"
+	// UnwindCancel cancels any placed legs which are still open
+	UnwindCancel UnwindRule = "CANCEL"
+	// UnwindReverse cancels any open legs and submits opposing market orders
+	// for any amount already executed
+	UnwindReverse UnwindRule = "REVERSE"
+)
+
+// IntentLeg is a single order which forms part of an intent
+type IntentLeg struct {
+	Order           order.Submit
+	Status          LegStatus
+	OrderID         string
+	InternalOrderID string
+	UnwindOrderID   string
+	Error           string
+}
+
+// Intent is a group of orders which are expected to complete together such as
+// the buy and sell sides of an arbitrage, a spread or a hedge
+type Intent struct {
+	ID          string
+	Description string
+	UnwindRule  UnwindRule
+	Status      IntentStatus
+	Legs        []IntentLeg
+	Created     time.Time
+	LastUpdated time.Time
+}
+
+type intentManager struct {
+	m       sync.RWMutex
+	intents map[string]*Intent
+}
@@ -0,0 +1,357 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"google.golang.org/grpc"
+)
+
+const jsonRPCServiceName = "/gctrpc.GoCryptoTrader/"
+
+var (
+	errJSONRPCUnauthorised = errors.New("unauthorised request on authenticated API")
+	errJSONRPCInvalidAuth  = errors.New("invalid username/password")
+)
+
+// jsonRPCMethods are the unary gRPC methods callable over JSON-RPC, keyed by
+// their lower case name
+var jsonRPCMethods = getJSONRPCMethods()
+
+// getJSONRPCMethods returns the methods of the gRPC server which take a
+// request message and return a response message
+func getJSONRPCMethods() map[string]reflect.Method {
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	msgType := reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+	methods := make(map[string]reflect.Method)
+	t := reflect.TypeOf(&RPCServer{})
+	for x := 0; x < t.NumMethod(); x++ {
+		m := t.Method(x)
+		if m.Type.NumIn() != 3 || m.Type.NumOut() != 2 ||
+			m.Type.In(1) != ctxType ||
+			!m.Type.In(2).Implements(msgType) ||
+			m.Type.In(2).Kind() != reflect.Ptr ||
+			!m.Type.Out(0).Implements(msgType) ||
+			m.Type.Out(1) != errType {
+			continue
+		}
+		methods[strings.ToLower(m.Name)] = m
+	}
+	return methods
+}
+
+// JSONRPCClientHandler upgrades the HTTP connection to a websocket speaking
+// JSON-RPC 2.0
+func JSONRPCClientHandler(w http.ResponseWriter, r *http.Request) {
+	connectWebsocketClient(w, r, true)
+}
+
+// handleJSONRPC handles a JSON-RPC request or batch of requests sent by the
+// client
+func (c *WebsocketClient) handleJSONRPC(message []byte) {
+	message = bytes.TrimSpace(message)
+	if !json.Valid(message) {
+		c.sendJSONRPC(newJSONRPCErrorResponse(nil, JSONRPCParseError, "parse error"))
+		return
+	}
+
+	if message[0] != '[' {
+		if resp := c.processJSONRPCRequest(message); resp != nil {
+			c.sendJSONRPC(resp)
+		}
+		return
+	}
+
+	var batch []json.RawMessage
+	err := json.Unmarshal(message, &batch)
+	if err != nil || len(batch) == 0 {
+		c.sendJSONRPC(newJSONRPCErrorResponse(nil, JSONRPCInvalidRequest, "invalid request"))
+		return
+	}
+	var responses []*JSONRPCResponse
+	for x := range batch {
+		if resp := c.processJSONRPCRequest(batch[x]); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	if len(responses) > 0 {
+		c.sendJSONRPC(responses)
+	}
+}
+
+// processJSONRPCRequest calls the requested method and returns its response,
+// nil is returned for notifications
+func (c *WebsocketClient) processJSONRPCRequest(data []byte) *JSONRPCResponse {
+	var req JSONRPCRequest
+	err := json.Unmarshal(data, &req)
+	if err != nil || req.JSONRPC != JSONRPCVersion || req.Method == "" {
+		return newJSONRPCErrorResponse(req.ID, JSONRPCInvalidRequest, "invalid request")
+	}
+
+	log.Debugf(log.WebsocketMgr, "websocket: JSON-RPC request received: %s\n", req.Method)
+	result, rpcErr := c.callJSONRPC(req.Method, req.Params)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if rpcErr != nil {
+		return &JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Error: rpcErr}
+	}
+	return &JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Result: result}
+}
+
+// callJSONRPC calls a JSON-RPC method, methods other than auth, subscribe and
+// unsubscribe are served by the gRPC server and require authentication
+func (c *WebsocketClient) callJSONRPC(method string, params json.RawMessage) (interface{}, *JSONRPCError) {
+	switch strings.ToLower(method) {
+	case jsonRPCAuthMethod:
+		return c.jsonRPCAuth(params)
+	case jsonRPCSubscribeMethod:
+		return c.jsonRPCSubscribe(params, true)
+	case jsonRPCUnsubscribeMethod:
+		return c.jsonRPCSubscribe(params, false)
+	}
+
+	m, ok := jsonRPCMethods[strings.ToLower(method)]
+	if !ok {
+		return nil, &JSONRPCError{Code: JSONRPCMethodNotFound, Message: "method not found"}
+	}
+	c.m.Lock()
+	authenticated := c.Authenticated
+	c.m.Unlock()
+	if !authenticated {
+		log.Warnf(log.WebsocketMgr, "websocket: JSON-RPC request %s failed due to unauthenticated request on an authenticated API\n", method)
+		return nil, &JSONRPCError{Code: JSONRPCUnauthorised, Message: errJSONRPCUnauthorised.Error()}
+	}
+
+	req := reflect.New(m.Type.In(2).Elem()).Interface().(proto.Message)
+	params, err := jsonRPCParamsObject(params)
+	if err == nil && params != nil {
+		err = jsonpb.Unmarshal(bytes.NewReader(params), req)
+	}
+	if err != nil {
+		return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: err.Error()}
+	}
+
+	info := &grpc.UnaryServerInfo{Server: &RPCServer{}, FullMethod: jsonRPCServiceName + m.Name}
+	handler := func(ctx context.Context, r interface{}) (interface{}, error) {
+		out := m.Func.Call([]reflect.Value{
+			reflect.ValueOf(info.Server),
+			reflect.ValueOf(ctx),
+			reflect.ValueOf(r),
+		})
+		if err, ok := out[1].Interface().(error); ok && err != nil {
+			return nil, err
+		}
+		return out[0].Interface(), nil
+	}
+	resp, err := tenantUnaryInterceptor(c.rpcContext(), req, info,
+		func(ctx context.Context, r interface{}) (interface{}, error) {
+			return dataOnlyUnaryInterceptor(ctx, r, info, handler)
+		})
+	if err != nil {
+		return nil, &JSONRPCError{Code: JSONRPCServerError, Message: err.Error()}
+	}
+
+	var buf bytes.Buffer
+	marshaler := jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	if err = marshaler.Marshal(&buf, resp.(proto.Message)); err != nil {
+		return nil, &JSONRPCError{Code: JSONRPCInternalError, Message: err.Error()}
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+// jsonRPCAuth authenticates the client with the remote control or a tenant's
+// credentials. The client is disconnected once it reaches the maximum number
+// of authentication failures
+func (c *WebsocketClient) jsonRPCAuth(params json.RawMessage) (interface{}, *JSONRPCError) {
+	var auth JSONRPCAuthParams
+	if err := unmarshalJSONRPCParams(params, &auth); err != nil {
+		return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: err.Error()}
+	}
+
+	ctx := context.Background()
+	userMatch := subtle.ConstantTimeCompare([]byte(Bot.Config.RemoteControl.Username), []byte(auth.Username))
+	passMatch := subtle.ConstantTimeCompare([]byte(Bot.Config.RemoteControl.Password), []byte(auth.Password))
+	authenticated := userMatch&passMatch == 1
+	if !authenticated {
+		if t, ok := getTenantByCredentials(auth.Username, auth.Password); ok {
+			ctx = context.WithValue(ctx, tenantContextKey{}, t)
+			authenticated = true
+		}
+	}
+
+	if authenticated {
+		c.m.Lock()
+		c.ctx = ctx
+		c.Authenticated = true
+		c.m.Unlock()
+		log.Debugln(log.WebsocketMgr, "websocket: JSON-RPC client authenticated successfully")
+		return true, nil
+	}
+
+	c.authFailures++
+	if c.authFailures >= Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures {
+		log.Debugf(log.WebsocketMgr,
+			"websocket: disconnecting JSON-RPC client, maximum auth failures threshold reached (failures: %d limit: %d)\n",
+			c.authFailures, Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
+		c.Conn.Close()
+	}
+	return nil, &JSONRPCError{Code: JSONRPCUnauthorised, Message: errJSONRPCInvalidAuth.Error()}
+}
+
+// jsonRPCSubscribe subscribes or unsubscribes the client from websocket event
+// notifications and returns the events it is subscribed to
+func (c *WebsocketClient) jsonRPCSubscribe(params json.RawMessage, subscribe bool) (interface{}, *JSONRPCError) {
+	var sub JSONRPCSubscribeParams
+	if err := unmarshalJSONRPCParams(params, &sub); err != nil {
+		return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: err.Error()}
+	}
+	for x := range sub.Events {
+		if !jsonRPCEvents[strings.ToLower(sub.Events[x])] {
+			return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: "unsupported event " + sub.Events[x]}
+		}
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.subscriptions == nil {
+		c.subscriptions = make(map[string]bool)
+	}
+	for x := range sub.Events {
+		if subscribe {
+			c.subscriptions[strings.ToLower(sub.Events[x])] = true
+		} else {
+			delete(c.subscriptions, strings.ToLower(sub.Events[x]))
+		}
+	}
+	events := make([]string, 0, len(c.subscriptions))
+	for k := range c.subscriptions {
+		events = append(events, k)
+	}
+	sort.Strings(events)
+	return events, nil
+}
+
+// rpcContext returns the context gRPC methods are called with on behalf of
+// the client
+func (c *WebsocketClient) rpcContext() context.Context {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// subscribed returns whether the client is subscribed to a websocket event,
+// tenants are only notified of the events of the exchanges they own
+func (c *WebsocketClient) subscribed(evt *WebsocketEvent) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if !c.subscriptions[strings.ToLower(evt.Event)] {
+		return false
+	}
+	if c.ctx != nil {
+		if t := tenantFromContext(c.ctx); t != nil {
+			return tenantOwnsExchange(t, evt.Exchange)
+		}
+	}
+	return true
+}
+
+// notifyJSONRPCClients sends a websocket event as a notification to the
+// JSON-RPC clients subscribed to it
+func (h *WebsocketHub) notifyJSONRPCClients(evt WebsocketEvent) {
+	var data []byte
+	for client := range h.Clients {
+		if !client.jsonRPC || !client.subscribed(&evt) {
+			continue
+		}
+		if data == nil {
+			var err error
+			data, err = json.Marshal(JSONRPCNotification{
+				JSONRPC: JSONRPCVersion,
+				Method:  evt.Event,
+				Params: JSONRPCEventParams{
+					Exchange:  evt.Exchange,
+					AssetType: evt.AssetType,
+					Data:      evt.Data,
+				},
+			})
+			if err != nil {
+				log.Errorf(log.WebsocketMgr, "websocket: failed to marshal JSON-RPC notification: %s\n", err)
+				return
+			}
+		}
+		select {
+		case client.Send <- data:
+		default:
+			log.Debugln(log.WebsocketMgr, "websocket: disconnected client")
+			close(client.Send)
+			delete(h.Clients, client)
+		}
+	}
+}
+
+// sendJSONRPC sends a JSON-RPC response or batch of responses to the client
+func (c *WebsocketClient) sendJSONRPC(resp interface{}) {
+	if err := c.SendWebsocketMessage(resp); err != nil {
+		log.Errorf(log.WebsocketMgr, "websocket: failed to send JSON-RPC response: %s\n", err)
+	}
+}
+
+// newJSONRPCErrorResponse returns an error response to a request
+func newJSONRPCErrorResponse(id json.RawMessage, code int, message string) *JSONRPCResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &JSONRPCResponse{
+		JSONRPC: JSONRPCVersion,
+		ID:      id,
+		Error:   &JSONRPCError{Code: code, Message: message},
+	}
+}
+
+// jsonRPCParamsObject returns the params object of a request. Params passed by
+// position hold the object as their only element
+func jsonRPCParamsObject(params json.RawMessage) (json.RawMessage, error) {
+	params = bytes.TrimSpace(params)
+	if len(params) == 0 || bytes.Equal(params, []byte("null")) {
+		return nil, nil
+	}
+	if params[0] != '[' {
+		return params, nil
+	}
+	var positional []json.RawMessage
+	if err := json.Unmarshal(params, &positional); err != nil {
+		return nil, err
+	}
+	switch len(positional) {
+	case 0:
+		return nil, nil
+	case 1:
+		return positional[0], nil
+	}
+	return nil, errors.New("params must be an object")
+}
+
+// unmarshalJSONRPCParams decodes the params object of a request
+func unmarshalJSONRPCParams(params json.RawMessage, v interface{}) error {
+	params, err := jsonRPCParamsObject(params)
+	if err != nil || params == nil {
+		return err
+	}
+	return json.Unmarshal(params, v)
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func jsonRPCCall(t *testing.T, conn *websocket.Conn, req string, resp interface{}) {
+	t.Helper()
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(req)); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(time.Second * 5)); err != nil {
+		t.Fatal(err)
+	}
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(data, resp); err != nil {
+		t.Fatalf("%s: %v", data, err)
+	}
+}
+
+func TestJSONRPCClient(t *testing.T) {
+	SetupTestHelpers(t)
+	srv := httptest.NewServer(http.HandlerFunc(JSONRPCClientHandler))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var resp JSONRPCResponse
+	jsonRPCCall(t, conn, `{"jsonrpc":"2.0","id":1,"method":"getinfo"}`, &resp)
+	if resp.Error == nil || resp.Error.Code != JSONRPCUnauthorised {
+		t.Fatalf("expected unauthorised error, got %+v", resp)
+	}
+
+	resp = JSONRPCResponse{}
+	jsonRPCCall(t, conn, `{"jsonrpc":"2.0","id":2,"method":"auth","params":{"username":"`+
+		Bot.Config.RemoteControl.Username+`","password":"`+Bot.Config.RemoteControl.Password+`"}}`, &resp)
+	if resp.Error != nil || resp.Result != true || string(resp.ID) != "2" {
+		t.Fatalf("expected successful auth, got %+v", resp)
+	}
+
+	resp = JSONRPCResponse{}
+	jsonRPCCall(t, conn, `{"jsonrpc":"2.0","id":"a","method":"GetInfo","params":{}}`, &resp)
+	if resp.Error != nil {
+		t.Fatal(resp.Error.Message)
+	}
+	if result, ok := resp.Result.(map[string]interface{}); !ok || result["uptime"] == nil {
+		t.Errorf("unexpected getinfo result %+v", resp.Result)
+	}
+
+	var batch []JSONRPCResponse
+	jsonRPCCall(t, conn, `[
+		{"jsonrpc":"2.0","id":3,"method":"nope"},
+		{"jsonrpc":"2.0","method":"getinfo"},
+		{"jsonrpc":"1.0","id":4,"method":"getinfo"},
+		{"jsonrpc":"2.0","id":5,"method":"getexchanges","params":[{"enabled":true,"nope":1}]}
+	]`, &batch)
+	if len(batch) != 3 ||
+		batch[0].Error == nil || batch[0].Error.Code != JSONRPCMethodNotFound ||
+		batch[1].Error == nil || batch[1].Error.Code != JSONRPCInvalidRequest ||
+		batch[2].Error == nil || batch[2].Error.Code != JSONRPCInvalidParams {
+		t.Fatalf("unexpected batch responses %+v", batch)
+	}
+
+	resp = JSONRPCResponse{}
+	jsonRPCCall(t, conn, `{"jsonrpc":`, &resp)
+	if resp.Error == nil || resp.Error.Code != JSONRPCParseError {
+		t.Fatalf("expected parse error, got %+v", resp)
+	}
+
+	resp = JSONRPCResponse{}
+	jsonRPCCall(t, conn, `{"jsonrpc":"2.0","id":6,"method":"subscribe","params":{"events":["ticker_update"]}}`, &resp)
+	if events, ok := resp.Result.([]interface{}); !ok || len(events) != 1 || events[0] != "ticker_update" {
+		t.Fatalf("unexpected subscribe response %+v", resp)
+	}
+
+	go func() {
+		_ = BroadcastWebsocketMessage(WebsocketEvent{Event: "orderbook_update", Exchange: testExchange})
+		_ = BroadcastWebsocketMessage(WebsocketEvent{Event: "ticker_update", Exchange: testExchange, AssetType: "spot", Data: 1})
+	}()
+	if err = conn.SetReadDeadline(time.Now().Add(time.Second * 5)); err != nil {
+		t.Fatal(err)
+	}
+	var n JSONRPCNotification
+	if err = conn.ReadJSON(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n.JSONRPC != JSONRPCVersion || n.Method != "ticker_update" || n.Params.Exchange != testExchange {
+		t.Errorf("unexpected notification %+v", n)
+	}
+}
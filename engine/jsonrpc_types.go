@@ -0,0 +1,83 @@
+package engine
+
+import "encoding/json"
+
+// JSONRPCVersion is the JSON-RPC protocol version spoken by the JSON-RPC
+// websocket endpoint
+const JSONRPCVersion = "2.0"
+
+// JSON-RPC 2.0 error codes, codes from -32000 to -32099 are reserved for
+// server errors
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+	JSONRPCServerError    = -32000
+	JSONRPCUnauthorised   = -32001
+)
+
+// JSON-RPC methods handled by the websocket endpoint rather than the gRPC
+// server
+const (
+	jsonRPCAuthMethod        = "auth"
+	jsonRPCSubscribeMethod   = "subscribe"
+	jsonRPCUnsubscribeMethod = "unsubscribe"
+)
+
+// jsonRPCEvents are the websocket events JSON-RPC clients can subscribe to
+var jsonRPCEvents = map[string]bool{
+	"ticker_update":    true,
+	"orderbook_update": true,
+}
+
+// JSONRPCRequest is a JSON-RPC 2.0 request, requests without an ID are
+// notifications and are not responded to
+type JSONRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// JSONRPCResponse is a JSON-RPC 2.0 response
+type JSONRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+}
+
+// JSONRPCError is the error of a failed JSON-RPC 2.0 request
+type JSONRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSONRPCNotification is a JSON-RPC 2.0 notification sent to clients
+// subscribed to a websocket event
+type JSONRPCNotification struct {
+	JSONRPC string             `json:"jsonrpc"`
+	Method  string             `json:"method"`
+	Params  JSONRPCEventParams `json:"params"`
+}
+
+// JSONRPCEventParams are the params of a websocket event notification
+type JSONRPCEventParams struct {
+	Exchange  string      `json:"exchange"`
+	AssetType string      `json:"assetType"`
+	Data      interface{} `json:"data"`
+}
+
+// JSONRPCAuthParams are the params of the auth method
+type JSONRPCAuthParams struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// JSONRPCSubscribeParams are the params of the subscribe and unsubscribe
+// methods
+type JSONRPCSubscribeParams struct {
+	Events []string `json:"events"`
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Key alert types
+const (
+	KeyAlertOrder      = "order"
+	KeyAlertWithdrawal = "withdrawal"
+)
+
+// MaxKeyAlerts is the amount of key alerts retained in memory
+var MaxKeyAlerts = 100
+
+// RecordWithdrawal marks a withdrawal as submitted by this bot so it is not
+// flagged when discovered in the exchange's funding history
+func (k *keyMonitor) RecordWithdrawal(exchName, id string) {
+	if id == "" {
+		return
+	}
+	k.m.Lock()
+	k.init()
+	k.submitted[keyMonitorID(exchName, KeyAlertWithdrawal, id)] = true
+	k.m.Unlock()
+}
+
+// CheckOrders raises an alert for each order discovered on an exchange during
+// reconciliation which was not submitted by this bot. Orders found during the
+// first reconciliation of an exchange are treated as pre-existing
+func (k *keyMonitor) CheckOrders(exchName string, discovered []*order.Detail) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	k.init()
+	baseline := k.baseline(exchName, KeyAlertOrder)
+	for i := range discovered {
+		id := keyMonitorID(exchName, KeyAlertOrder, discovered[i].ID)
+		if k.known[id] {
+			continue
+		}
+		k.known[id] = true
+		if baseline || discovered[i].InternalOrderID != "" {
+			continue
+		}
+		k.alert(exchName, KeyAlertOrder, discovered[i].ID,
+			fmt.Sprintf("order %s %s %v %s @ %v",
+				discovered[i].Side,
+				discovered[i].Type,
+				discovered[i].Amount,
+				discovered[i].Pair,
+				discovered[i].Price))
+	}
+}
+
+// CheckWithdrawals raises an alert for each withdrawal in an exchange's
+// funding history which was not submitted by this bot. Withdrawals found on
+// the first check of an exchange are treated as pre-existing
+func (k *keyMonitor) CheckWithdrawals(exchName string, history []exchange.FundHistory) {
+	k.m.Lock()
+	defer k.m.Unlock()
+	k.init()
+	baseline := k.baseline(exchName, KeyAlertWithdrawal)
+	for i := range history {
+		if history[i].TransferID == "" {
+			continue
+		}
+		if direction, ok := transferDirection(history[i].TransferType); !ok || direction != TransferWithdrawal {
+			continue
+		}
+		id := keyMonitorID(exchName, KeyAlertWithdrawal, history[i].TransferID)
+		if k.known[id] {
+			continue
+		}
+		k.known[id] = true
+		if baseline || k.submitted[id] {
+			continue
+		}
+		k.alert(exchName, KeyAlertWithdrawal, history[i].TransferID,
+			fmt.Sprintf("withdrawal of %v %s to %s",
+				history[i].Amount,
+				history[i].Currency,
+				history[i].CryptoToAddress))
+	}
+}
+
+// GetAlerts returns previously raised key alerts
+func (k *keyMonitor) GetAlerts() []KeyAlert {
+	k.m.Lock()
+	defer k.m.Unlock()
+	return append([]KeyAlert(nil), k.alerts...)
+}
+
+// alert stores, logs and notifies on a key alert, must be called with the
+// lock held
+func (k *keyMonitor) alert(exchName, alertType, id, detail string) {
+	msg := fmt.Sprintf("CRITICAL: %s API key used for %s %s not originating from this bot. Rotate your %s API keys.",
+		exchName,
+		detail,
+		id,
+		exchName)
+	k.alerts = append(k.alerts, KeyAlert{
+		Time:     clock.Now(),
+		Exchange: exchName,
+		Type:     alertType,
+		ID:       id,
+		Message:  msg,
+	})
+	if len(k.alerts) > MaxKeyAlerts {
+		k.alerts = k.alerts[len(k.alerts)-MaxKeyAlerts:]
+	}
+	log.Errorln(log.Global, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "security",
+		Message: msg,
+	})
+}
+
+// baseline returns true the first time an exchange's activity type is
+// checked, must be called with the lock held
+func (k *keyMonitor) baseline(exchName, alertType string) bool {
+	key := strings.ToLower(exchName) + ":" + alertType
+	if k.baselined[key] {
+		return false
+	}
+	k.baselined[key] = true
+	return true
+}
+
+func (k *keyMonitor) init() {
+	if k.baselined == nil {
+		k.baselined = make(map[string]bool)
+	}
+	if k.known == nil {
+		k.known = make(map[string]bool)
+	}
+	if k.submitted == nil {
+		k.submitted = make(map[string]bool)
+	}
+}
+
+func keyMonitorID(exchName, alertType, id string) string {
+	return strings.ToLower(exchName) + ":" + alertType + ":" + id
+}
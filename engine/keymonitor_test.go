@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"testing"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestKeyMonitorCheckOrders(t *testing.T) {
+	SetupTestHelpers(t)
+	var k keyMonitor
+	k.CheckOrders(testExchange, []*order.Detail{{ID: "existing"}})
+	if len(k.GetAlerts()) != 0 {
+		t.Error("expected orders found on the first check to be treated as pre-existing")
+	}
+
+	k.CheckOrders(testExchange, []*order.Detail{
+		{ID: "existing"},
+		{ID: "bot", InternalOrderID: "1337"},
+		{ID: "foreign"},
+	})
+	alerts := k.GetAlerts()
+	if len(alerts) != 1 || alerts[0].ID != "foreign" || alerts[0].Type != KeyAlertOrder {
+		t.Fatalf("expected one alert for the foreign order, got %+v", alerts)
+	}
+
+	k.CheckOrders(testExchange, []*order.Detail{{ID: "foreign"}})
+	if len(k.GetAlerts()) != 1 {
+		t.Error("expected foreign order to only be alerted once")
+	}
+}
+
+func TestKeyMonitorCheckWithdrawals(t *testing.T) {
+	SetupTestHelpers(t)
+	var k keyMonitor
+	k.CheckWithdrawals(testExchange, []exchange.FundHistory{
+		{TransferID: "existing", TransferType: "withdrawal"},
+	})
+
+	k.RecordWithdrawal(testExchange, "bot")
+	k.CheckWithdrawals(testExchange, []exchange.FundHistory{
+		{TransferID: "existing", TransferType: "withdrawal"},
+		{TransferID: "bot", TransferType: "withdrawal"},
+		{TransferID: "deposit", TransferType: "deposit"},
+		{TransferID: "foreign", TransferType: "Withdrawal", Amount: 1, Currency: "BTC"},
+	})
+	alerts := k.GetAlerts()
+	if len(alerts) != 1 || alerts[0].ID != "foreign" || alerts[0].Type != KeyAlertWithdrawal {
+		t.Fatalf("expected one alert for the foreign withdrawal, got %+v", alerts)
+	}
+}
@@ -0,0 +1,24 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// KeyAlert is raised when activity is found on an exchange account which did
+// not originate from this bot, suggesting the API key may be compromised
+type KeyAlert struct {
+	Time     time.Time
+	Exchange string
+	Type     string
+	ID       string
+	Message  string
+}
+
+type keyMonitor struct {
+	m         sync.Mutex
+	baselined map[string]bool
+	known     map[string]bool
+	submitted map[string]bool
+	alerts    []KeyAlert
+}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the key validator
+var (
+	errKeyCannotTrade    = errors.New("API key is not permitted to trade")
+	errKeyCannotWithdraw = errors.New("API key is not permitted to withdraw")
+)
+
+// Validate checks the API key of each exchange with authenticated support
+// using a harmless authenticated request and logs a summary. Trading and
+// withdrawals are refused on exchanges whose key cannot perform them
+func (k *keyValidator) Validate() {
+	exchanges := GetExchanges()
+	results := make([]KeyValidation, len(exchanges))
+	checked := make([]bool, len(exchanges))
+	var wg sync.WaitGroup
+	for x := range exchanges {
+		if !exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+			continue
+		}
+		checked[x] = true
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			results[x] = validateKey(exchanges[x])
+		}(x)
+	}
+	wg.Wait()
+
+	k.m.Lock()
+	defer k.m.Unlock()
+	k.results = make(map[string]KeyValidation)
+	for x := range results {
+		if !checked[x] {
+			continue
+		}
+		k.results[strings.ToLower(results[x].Exchange)] = results[x]
+		logKeyValidation(&results[x])
+	}
+}
+
+// validateKey requests the key's permissions from exchanges which report
+// them, otherwise fetches the account's balances
+func validateKey(exch exchange.IBotExchange) KeyValidation {
+	v := KeyValidation{Exchange: exch.GetName()}
+	if c, ok := exch.(exchange.KeyPermissionChecker); ok {
+		p, err := c.GetKeyPermissions()
+		if err != nil {
+			v.Error = err.Error()
+			return v
+		}
+		v.Read, v.Reported = true, true
+		v.Trade, v.Withdraw = p.Trade, p.Withdraw
+		return v
+	}
+	if _, err := exch.UpdateAccountInfo(); err != nil {
+		v.Error = err.Error()
+		return v
+	}
+	v.Read, v.Trade, v.Withdraw = true, true, true
+	return v
+}
+
+func logKeyValidation(v *KeyValidation) {
+	if !v.Read {
+		log.Errorf(log.ExchangeSys,
+			"%s API key validation failed, trading and withdrawals are disabled: %s\n",
+			v.Exchange,
+			v.Error)
+		return
+	}
+	if !v.Reported {
+		log.Infof(log.ExchangeSys,
+			"%s API key validated, trade and withdraw permissions are not reported by the exchange.\n",
+			v.Exchange)
+		return
+	}
+	var refused []string
+	if !v.Trade {
+		refused = append(refused, "trading")
+	}
+	if !v.Withdraw {
+		refused = append(refused, "withdrawals")
+	}
+	if len(refused) == 0 {
+		log.Infof(log.ExchangeSys,
+			"%s API key validated, permitted to trade and withdraw.\n",
+			v.Exchange)
+		return
+	}
+	log.Warnf(log.ExchangeSys,
+		"%s API key validated, %s disabled as the key is not permitted.\n",
+		v.Exchange,
+		strings.Join(refused, " and "))
+}
+
+// CheckTrade returns an error when the exchange's API key was found to be
+// unable to trade. Exchanges which were not validated are allowed
+func (k *keyValidator) CheckTrade(exchName string) error {
+	k.m.Lock()
+	defer k.m.Unlock()
+	v, ok := k.results[strings.ToLower(exchName)]
+	if ok && (!v.Read || !v.Trade) {
+		return fmt.Errorf("%s %v", exchName, errKeyCannotTrade)
+	}
+	return nil
+}
+
+// CheckWithdraw returns an error when the exchange's API key was found to be
+// unable to withdraw. Exchanges which were not validated are allowed
+func (k *keyValidator) CheckWithdraw(exchName string) error {
+	k.m.Lock()
+	defer k.m.Unlock()
+	v, ok := k.results[strings.ToLower(exchName)]
+	if ok && (!v.Read || !v.Withdraw) {
+		return fmt.Errorf("%s %v", exchName, errKeyCannotWithdraw)
+	}
+	return nil
+}
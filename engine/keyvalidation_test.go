@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// fakeKeyPermissionExchange reports the permissions of its API key
+type fakeKeyPermissionExchange struct {
+	FakePassingExchange
+	permissions exchange.KeyPermissions
+	err         error
+}
+
+func (f *fakeKeyPermissionExchange) GetKeyPermissions() (exchange.KeyPermissions, error) {
+	return f.permissions, f.err
+}
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+	f := &FakePassingExchange{Base: exchange.Base{Name: "unreported"}}
+	v := validateKey(f)
+	if !v.Read || !v.Trade || !v.Withdraw || v.Reported {
+		t.Errorf("key which can read should be assumed able to trade and withdraw, got %+v", v)
+	}
+
+	r := &fakeKeyPermissionExchange{
+		FakePassingExchange: FakePassingExchange{Base: exchange.Base{Name: "reported"}},
+		permissions:         exchange.KeyPermissions{Trade: true},
+	}
+	v = validateKey(r)
+	if !v.Read || !v.Trade || v.Withdraw || !v.Reported {
+		t.Errorf("expected the reported permissions, got %+v", v)
+	}
+
+	r.err = errors.New("invalid API key")
+	v = validateKey(r)
+	if v.Read || v.Trade || v.Error != "invalid API key" {
+		t.Errorf("expected the key to fail validation, got %+v", v)
+	}
+}
+
+func TestKeyValidatorChecks(t *testing.T) {
+	t.Parallel()
+	k := keyValidator{
+		results: map[string]KeyValidation{
+			"readonly": {Exchange: "ReadOnly", Read: true, Reported: true},
+			"invalid":  {Exchange: "Invalid", Error: "invalid API key"},
+			"trader":   {Exchange: "Trader", Read: true, Trade: true, Reported: true},
+		},
+	}
+	if err := k.CheckTrade("ReadOnly"); err == nil {
+		t.Error("read only key should not be able to trade")
+	}
+	if err := k.CheckTrade("Invalid"); err == nil {
+		t.Error("invalid key should not be able to trade")
+	}
+	if err := k.CheckTrade("Trader"); err != nil {
+		t.Error(err)
+	}
+	if err := k.CheckWithdraw("Trader"); err == nil {
+		t.Error("key without the withdraw permission should not be able to withdraw")
+	}
+	if err := k.CheckTrade("unvalidated"); err != nil {
+		t.Error("exchanges which were not validated should be allowed")
+	}
+}
+
+func TestKeyValidatorRefusesOrders(t *testing.T) {
+	SetupTestHelpers(t)
+	OrdersSetup(t)
+	Bot.KeyValidator.m.Lock()
+	Bot.KeyValidator.results = map[string]KeyValidation{
+		strings.ToLower(fakePassExchange): {Exchange: fakePassExchange, Read: true, Reported: true},
+	}
+	Bot.KeyValidator.m.Unlock()
+	defer func() {
+		Bot.KeyValidator.m.Lock()
+		Bot.KeyValidator.results = nil
+		Bot.KeyValidator.m.Unlock()
+	}()
+
+	_, err := Bot.OrderManager.Submit(&order.Submit{
+		Exchange:  fakePassExchange,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     1,
+		Amount:    1,
+	})
+	if err == nil || !strings.Contains(err.Error(), errKeyCannotTrade.Error()) {
+		t.Errorf("expected %v, got %v", errKeyCannotTrade, err)
+	}
+
+}
@@ -0,0 +1,22 @@
+package engine
+
+import "sync"
+
+// KeyValidation is the outcome of validating an exchange's API key when the
+// bot started
+type KeyValidation struct {
+	Exchange string
+	// Read is whether an authenticated request succeeded
+	Read     bool
+	Trade    bool
+	Withdraw bool
+	// Reported is whether the exchange reported the key's trade and withdraw
+	// permissions, otherwise a key which can read is assumed able to
+	Reported bool
+	Error    string
+}
+
+type keyValidator struct {
+	m       sync.Mutex
+	results map[string]KeyValidation
+}
@@ -0,0 +1,235 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the liquidity screener
+var (
+	ErrPairSuspended   = errors.New("pair is suspended by the liquidity screen")
+	errPairNotScreened = errors.New("pair has not passed the liquidity screen")
+)
+
+func (l *liquidityScreener) Started() bool {
+	return atomic.LoadInt32(&l.started) == 1
+}
+
+func (l *liquidityScreener) Start() error {
+	if atomic.AddInt32(&l.started, 1) != 1 {
+		return errors.New("liquidity screener already started")
+	}
+
+	log.Debugln(log.SyncMgr, "Liquidity screener starting...")
+	l.shutdown = make(chan struct{})
+	go l.run()
+	return nil
+}
+
+func (l *liquidityScreener) Stop() error {
+	if atomic.AddInt32(&l.stopped, 1) != 1 {
+		return errors.New("liquidity screener is already stopped")
+	}
+
+	log.Debugln(log.SyncMgr, "Liquidity screener shutting down...")
+	close(l.shutdown)
+	return nil
+}
+
+func (l *liquidityScreener) run() {
+	log.Debugln(log.SyncMgr, "Liquidity screener started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.LiquidityScreen.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&l.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&l.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.SyncMgr, "Liquidity screener shutdown.")
+	}()
+
+	// screen immediately so strategies are not held back for a full interval
+	l.screenAll()
+	for {
+		select {
+		case <-l.shutdown:
+			return
+		case <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			l.screenAll()
+		}
+	}
+}
+
+// screenAll screens every enabled pair of every loaded exchange
+func (l *liquidityScreener) screenAll() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		assets := exchanges[x].GetAssetTypes()
+		for y := range assets {
+			pairs := exchanges[x].GetEnabledPairs(assets[y])
+			for z := range pairs {
+				select {
+				case <-l.shutdown:
+					return
+				default:
+				}
+				l.screen(exchanges[x], pairs[z], assets[y])
+			}
+		}
+	}
+}
+
+// screen fetches the pair's ticker and orderbook and evaluates them against
+// the configured thresholds
+func (l *liquidityScreener) screen(exch exchange.IBotExchange, p currency.Pair, a asset.Item) {
+	var reason string
+	t, err := exch.FetchTicker(p, a)
+	if err != nil {
+		reason = fmt.Sprintf("unable to fetch ticker: %v", err)
+	}
+	var ob *orderbook.Base
+	if reason == "" {
+		ob, err = exch.FetchOrderbook(p, a)
+		if err != nil {
+			reason = fmt.Sprintf("unable to fetch orderbook: %v", err)
+		}
+	}
+
+	r := LiquidityResult{
+		Exchange:  exch.GetName(),
+		Pair:      p,
+		AssetType: a,
+		Reason:    reason,
+	}
+	if reason == "" {
+		r.Volume, r.Spread, r.Depth, r.Reason = screenLiquidity(&Bot.Config.LiquidityScreen, t, ob)
+	}
+	l.update(&r)
+}
+
+// update stores a screen result, suspending or reinstating the pair when its
+// outcome changes
+func (l *liquidityScreener) update(r *LiquidityResult) {
+	r.Suspended = r.Reason != ""
+	r.LastChecked = clock.Now()
+
+	l.m.Lock()
+	if l.results == nil {
+		l.results = make(map[string]*LiquidityResult)
+	}
+	key := liquidityKey(r.Exchange, r.Pair, r.AssetType)
+	prev, ok := l.results[key]
+	l.results[key] = r
+	l.m.Unlock()
+
+	var msg string
+	switch {
+	case r.Suspended && (!ok || !prev.Suspended):
+		msg = fmt.Sprintf("Liquidity screen: %s %s %s suspended: %s",
+			r.Exchange,
+			r.Pair,
+			r.AssetType,
+			r.Reason)
+		log.Warnln(log.SyncMgr, msg)
+	case !r.Suspended && ok && prev.Suspended:
+		msg = fmt.Sprintf("Liquidity screen: %s %s %s reinstated",
+			r.Exchange,
+			r.Pair,
+			r.AssetType)
+		log.Infoln(log.SyncMgr, msg)
+	default:
+		return
+	}
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "liquidity",
+		Message: msg,
+	})
+}
+
+// Allowed returns an error if strategies are not allowed to trade the pair. All
+// pairs are allowed when the liquidity screener is not running
+func (l *liquidityScreener) Allowed(exchName string, p currency.Pair, a asset.Item) error {
+	if !l.Started() {
+		return nil
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	r, ok := l.results[liquidityKey(exchName, p, a)]
+	if !ok {
+		return errPairNotScreened
+	}
+	if r.Suspended {
+		return ErrPairSuspended
+	}
+	return nil
+}
+
+// GetResults returns a copy of the most recent screen result of each pair
+func (l *liquidityScreener) GetResults() []LiquidityResult {
+	l.m.Lock()
+	defer l.m.Unlock()
+	var results []LiquidityResult
+	for _, v := range l.results {
+		results = append(results, *v)
+	}
+	return results
+}
+
+func liquidityKey(exchName string, p currency.Pair, a asset.Item) string {
+	return strings.ToLower(exchName + ":" + p.String() + ":" + a.String())
+}
+
+// screenLiquidity returns the pair's volume, spread and depth and the reason
+// the pair fails the screen, which is empty if it passes. A zero threshold is
+// not screened
+func screenLiquidity(cfg *config.LiquidityScreenConfig, t *ticker.Price, ob *orderbook.Base) (volume, spread, depth float64, reason string) {
+	volume = t.Volume
+
+	var bidDepth, askDepth float64
+	for x := range ob.Bids {
+		bidDepth += ob.Bids[x].Amount
+	}
+	for x := range ob.Asks {
+		askDepth += ob.Asks[x].Amount
+	}
+	depth = bidDepth
+	if askDepth < depth {
+		depth = askDepth
+	}
+
+	bid, ask := t.Bid, t.Ask
+	if len(ob.Bids) > 0 && len(ob.Asks) > 0 {
+		bid, ask = ob.Bids[0].Price, ob.Asks[0].Price
+	}
+	if bid > 0 && ask > 0 {
+		spread = (ask - bid) / ((ask + bid) / 2) * 100
+	}
+
+	switch {
+	case cfg.MinVolume > 0 && volume < cfg.MinVolume:
+		reason = fmt.Sprintf("24h volume %v below minimum %v", volume, cfg.MinVolume)
+	case cfg.MaxSpread > 0 && (bid <= 0 || ask <= 0):
+		reason = "no bid or ask price available"
+	case cfg.MaxSpread > 0 && spread > cfg.MaxSpread:
+		reason = fmt.Sprintf("spread %.4f%% above maximum %v%%", spread, cfg.MaxSpread)
+	case cfg.MinDepth > 0 && depth < cfg.MinDepth:
+		reason = fmt.Sprintf("orderbook depth %v below minimum %v", depth, cfg.MinDepth)
+	}
+	return volume, spread, depth, reason
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func TestScreenLiquidity(t *testing.T) {
+	cfg := config.LiquidityScreenConfig{
+		MinVolume: 100,
+		MaxSpread: 1,
+		MinDepth:  5,
+	}
+	tick := ticker.Price{Volume: 150, Bid: 99, Ask: 101}
+	ob := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99.5, Amount: 4}, {Price: 99, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 100.5, Amount: 10}},
+	}
+
+	volume, spread, depth, reason := screenLiquidity(&cfg, &tick, &ob)
+	if reason != "" {
+		t.Errorf("expected pair to pass, got %s", reason)
+	}
+	if volume != 150 || spread != 1 || depth != 6 {
+		t.Errorf("unexpected volume %v spread %v depth %v", volume, spread, depth)
+	}
+
+	tick.Volume = 50
+	if _, _, _, reason = screenLiquidity(&cfg, &tick, &ob); reason == "" {
+		t.Error("expected low volume to fail")
+	}
+
+	tick.Volume = 150
+	ob.Asks[0].Price = 102
+	if _, _, _, reason = screenLiquidity(&cfg, &tick, &ob); reason == "" {
+		t.Error("expected wide spread to fail")
+	}
+
+	ob.Asks[0].Price = 100.5
+	ob.Bids = ob.Bids[:1]
+	if _, _, _, reason = screenLiquidity(&cfg, &tick, &ob); reason == "" {
+		t.Error("expected shallow book to fail")
+	}
+
+	if _, _, _, reason = screenLiquidity(&config.LiquidityScreenConfig{}, &ticker.Price{}, &orderbook.Base{}); reason != "" {
+		t.Errorf("expected zero thresholds not to be screened, got %s", reason)
+	}
+}
+
+func TestLiquidityAllowed(t *testing.T) {
+	SetupTestHelpers(t)
+	var l liquidityScreener
+	p := currency.NewPairFromString("BTCUSD")
+	if err := l.Allowed(testExchange, p, asset.Spot); err != nil {
+		t.Errorf("expected all pairs to be allowed when not started, got %v", err)
+	}
+
+	atomic.StoreInt32(&l.started, 1)
+	if err := l.Allowed(testExchange, p, asset.Spot); err != errPairNotScreened {
+		t.Errorf("expected %v, got %v", errPairNotScreened, err)
+	}
+
+	l.update(&LiquidityResult{Exchange: testExchange, Pair: p, AssetType: asset.Spot, Reason: "illiquid"})
+	if err := l.Allowed(testExchange, p, asset.Spot); err != ErrPairSuspended {
+		t.Errorf("expected %v, got %v", ErrPairSuspended, err)
+	}
+
+	l.update(&LiquidityResult{Exchange: testExchange, Pair: p, AssetType: asset.Spot})
+	if err := l.Allowed(testExchange, p, asset.Spot); err != nil {
+		t.Errorf("expected reinstated pair to be allowed, got %v", err)
+	}
+	if r := l.GetResults(); len(r) != 1 || r[0].Suspended {
+		t.Errorf("unexpected results %+v", r)
+	}
+}
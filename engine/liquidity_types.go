@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// LiquidityResult is the outcome of the most recent liquidity screen of a pair
+type LiquidityResult struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Volume    float64
+	// Spread is the bid ask spread as a percentage of the mid price
+	Spread float64
+	// Depth is the lesser of the base currency amounts on each side of the
+	// orderbook
+	Depth       float64
+	Suspended   bool
+	Reason      string
+	LastChecked time.Time
+}
+
+type liquidityScreener struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m       sync.Mutex
+	results map[string]*LiquidityResult
+}
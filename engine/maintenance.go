@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// ErrExchangeMaintenance is returned when an order is placed on an exchange
+// during one of its maintenance windows
+var ErrExchangeMaintenance = errors.New("exchange is under maintenance")
+
+const statusPageMaintenancesPath = "/api/v2/scheduled-maintenances.json"
+
+// defaultStatusPages are the Statuspage status pages of exchanges which are
+// polled when a status page is not configured
+var defaultStatusPages = map[string]string{
+	"gemini": "https://status.gemini.com",
+	"kraken": "https://status.kraken.com",
+}
+
+func (m *maintenanceManager) Started() bool {
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+func (m *maintenanceManager) Start() error {
+	if atomic.AddInt32(&m.started, 1) != 1 {
+		return errors.New("maintenance manager already started")
+	}
+
+	log.Debugln(log.ExchangeSys, "Maintenance manager starting...")
+	m.shutdown = make(chan struct{})
+	go m.run()
+	return nil
+}
+
+func (m *maintenanceManager) Stop() error {
+	if atomic.AddInt32(&m.stopped, 1) != 1 {
+		return errors.New("maintenance manager is already stopped")
+	}
+
+	log.Debugln(log.ExchangeSys, "Maintenance manager shutting down...")
+	close(m.shutdown)
+	return nil
+}
+
+func (m *maintenanceManager) run() {
+	log.Debugln(log.ExchangeSys, "Maintenance manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.Maintenance.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&m.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.ExchangeSys, "Maintenance manager shutdown.")
+	}()
+
+	m.fetchAll()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-tick.C:
+			m.fetchAll()
+		}
+	}
+}
+
+// fetchAll fetches the scheduled maintenances of every loaded exchange with a
+// status page
+func (m *maintenanceManager) fetchAll() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		select {
+		case <-m.shutdown:
+			return
+		default:
+		}
+		exchName := exchanges[x].GetName()
+		page := statusPage(exchName)
+		if page == "" {
+			continue
+		}
+		windows, err := fetchStatusPageMaintenances(exchName, page)
+		if err != nil {
+			log.Errorf(log.ExchangeSys, "Maintenance manager: unable to fetch %s status page %s: %v\n",
+				exchName,
+				page,
+				err)
+			continue
+		}
+		m.m.Lock()
+		if m.fetched == nil {
+			m.fetched = make(map[string][]ScheduledMaintenance)
+		}
+		m.fetched[strings.ToLower(exchName)] = windows
+		m.m.Unlock()
+	}
+}
+
+// statusPage returns the configured status page of an exchange, or its
+// default status page
+func statusPage(exchName string) string {
+	if Bot.Config != nil {
+		cfg, err := Bot.Config.GetExchangeConfig(exchName)
+		if err == nil && cfg.Maintenance != nil && cfg.Maintenance.StatusPage != "" {
+			return cfg.Maintenance.StatusPage
+		}
+	}
+	return defaultStatusPages[strings.ToLower(exchName)]
+}
+
+// fetchStatusPageMaintenances returns the scheduled maintenances of a
+// Statuspage status page which have not completed
+func fetchStatusPageMaintenances(exchName, page string) ([]ScheduledMaintenance, error) {
+	var resp statusPageMaintenances
+	err := common.SendHTTPGetRequest(page+statusPageMaintenancesPath, true, Bot.Settings.Verbose, &resp)
+	if err != nil {
+		return nil, err
+	}
+	var windows []ScheduledMaintenance
+	for x := range resp.ScheduledMaintenances {
+		sm := &resp.ScheduledMaintenances[x]
+		if sm.Status == "completed" || !sm.ScheduledUntil.After(sm.ScheduledFor) {
+			continue
+		}
+		windows = append(windows, ScheduledMaintenance{
+			Exchange: exchName,
+			Name:     sm.Name,
+			Source:   MaintenanceSourceStatusPage,
+			Start:    sm.ScheduledFor,
+			End:      sm.ScheduledUntil,
+		})
+	}
+	return windows, nil
+}
+
+// Windows returns the current and upcoming maintenance windows of an
+// exchange, configured windows which repeat return their next occurrence
+func (m *maintenanceManager) Windows(exchName string) []ScheduledMaintenance {
+	now := clock.Now()
+	var windows []ScheduledMaintenance
+	if Bot.Config != nil {
+		cfg, err := Bot.Config.GetExchangeConfig(exchName)
+		if err == nil && cfg.Maintenance != nil {
+			for x := range cfg.Maintenance.Windows {
+				start, end, ok := cfg.Maintenance.Windows[x].Occurrence(now)
+				if !ok {
+					continue
+				}
+				windows = append(windows, ScheduledMaintenance{
+					Exchange: cfg.Name,
+					Name:     cfg.Maintenance.Windows[x].Reason,
+					Source:   MaintenanceSourceConfig,
+					Start:    start,
+					End:      end,
+				})
+			}
+		}
+	}
+
+	m.m.Lock()
+	for _, w := range m.fetched[strings.ToLower(exchName)] {
+		if now.Before(w.End) {
+			windows = append(windows, w)
+		}
+	}
+	m.m.Unlock()
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	return windows
+}
+
+// GetAll returns the current and upcoming maintenance windows of every loaded
+// exchange
+func (m *maintenanceManager) GetAll() []ScheduledMaintenance {
+	var windows []ScheduledMaintenance
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		windows = append(windows, m.Windows(exchanges[x].GetName())...)
+	}
+	return windows
+}
+
+// Active returns the maintenance window an exchange is currently in. Exchanges
+// are never in maintenance when the maintenance manager is not running
+func (m *maintenanceManager) Active(exchName string) (*ScheduledMaintenance, bool) {
+	if !m.Started() {
+		return nil, false
+	}
+	now := clock.Now()
+	windows := m.Windows(exchName)
+	for x := range windows {
+		if !now.Before(windows[x].Start) {
+			return &windows[x], true
+		}
+	}
+	return nil, false
+}
+
+// InMaintenance returns whether an exchange is in a maintenance window
+func (m *maintenanceManager) InMaintenance(exchName string) bool {
+	_, ok := m.Active(exchName)
+	return ok
+}
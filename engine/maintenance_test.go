@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestFetchStatusPageMaintenances(t *testing.T) {
+	SetupTestHelpers(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != statusPageMaintenancesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"scheduled_maintenances":[
+			{"id":"1","name":"Database upgrade","status":"scheduled","scheduled_for":"2020-05-26T02:00:00.000Z","scheduled_until":"2020-05-26T03:00:00.000Z"},
+			{"id":"2","name":"Network upgrade","status":"completed","scheduled_for":"2020-05-19T02:00:00.000Z","scheduled_until":"2020-05-19T03:00:00.000Z"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	windows, err := fetchStatusPageMaintenances(testExchange, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(windows) != 1 ||
+		windows[0].Name != "Database upgrade" ||
+		windows[0].Source != MaintenanceSourceStatusPage ||
+		!windows[0].End.Equal(time.Date(2020, 5, 26, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected maintenance windows %+v", windows)
+	}
+}
+
+func TestMaintenanceManager(t *testing.T) {
+	SetupTestHelpers(t)
+	setupHealthThresholds()
+	cfg, err := Bot.Config.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	cfg.Maintenance = &config.ExchangeMaintenance{
+		Windows: []config.MaintenanceWindow{
+			{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Reason: "upgrade"},
+			{Start: now.Add(-time.Hour * 3), End: now.Add(-time.Hour * 2)},
+		},
+	}
+	defer func() { cfg.Maintenance = nil }()
+
+	m := &Bot.MaintenanceManager
+	m.m.Lock()
+	m.fetched = map[string][]ScheduledMaintenance{
+		"bitstamp": {{Exchange: testExchange, Source: MaintenanceSourceStatusPage, Start: now.Add(time.Hour * 2), End: now.Add(time.Hour * 3)}},
+	}
+	m.m.Unlock()
+	defer func() {
+		m.m.Lock()
+		m.fetched = nil
+		m.m.Unlock()
+	}()
+
+	if m.InMaintenance(testExchange) {
+		t.Error("expected exchanges not to be in maintenance when stopped")
+	}
+	atomic.StoreInt32(&m.started, 1)
+	defer atomic.StoreInt32(&m.started, 0)
+
+	windows := m.Windows(testExchange)
+	if len(windows) != 2 ||
+		windows[0].Source != MaintenanceSourceConfig ||
+		windows[1].Source != MaintenanceSourceStatusPage {
+		t.Fatalf("unexpected maintenance windows %+v", windows)
+	}
+	w, ok := m.Active(testExchange)
+	if !ok || w.Name != "upgrade" {
+		t.Fatalf("expected the configured window to be active, got %+v", w)
+	}
+
+	var h exchangeHealthMonitor
+	h.check(testExchange, &request.Stats{Requests: 10, Failures: 10}, false, false, 0)
+	if s := h.status(testExchange); s != ExchangeMaintenance {
+		t.Errorf("expected %s, got %s", ExchangeMaintenance, s)
+	}
+	if err = h.Allowed(testExchange); err != ErrExchangeMaintenance {
+		t.Errorf("expected %v, got %v", ErrExchangeMaintenance, err)
+	}
+
+	// failures during the window are not held against the exchange
+	cfg.Maintenance = nil
+	h.check(testExchange, &request.Stats{Requests: 10, Failures: 10}, false, false, 0)
+	if s := h.status(testExchange); s != ExchangeHealthy {
+		t.Errorf("expected %s after maintenance, got %s", ExchangeHealthy, s)
+	}
+	if err = h.Allowed(testExchange); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// Sources of scheduled maintenance
+const (
+	MaintenanceSourceConfig     = "config"
+	MaintenanceSourceStatusPage = "status page"
+)
+
+// ScheduledMaintenance is a period of planned exchange downtime
+type ScheduledMaintenance struct {
+	Exchange string
+	Name     string
+	Source   string
+	Start    time.Time
+	End      time.Time
+}
+
+// statusPageMaintenances is the scheduled maintenances response of a
+// Statuspage status page
+type statusPageMaintenances struct {
+	ScheduledMaintenances []struct {
+		ID             string    `json:"id"`
+		Name           string    `json:"name"`
+		Status         string    `json:"status"`
+		ScheduledFor   time.Time `json:"scheduled_for"`
+		ScheduledUntil time.Time `json:"scheduled_until"`
+	} `json:"scheduled_maintenances"`
+}
+
+type maintenanceManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m sync.Mutex
+	// fetched are the scheduled maintenances of each exchange's status page
+	fetched map[string][]ScheduledMaintenance
+}
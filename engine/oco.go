@@ -0,0 +1,423 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the OCO manager
+var (
+	ErrOCONotFound     = errors.New("oco order does not exist")
+	errOCOIsNil        = errors.New("oco order is nil")
+	errOCONotActive    = errors.New("oco order is not active")
+	errOCOLegNoTrigger = errors.New("oco conditional leg trigger price must be greater than zero")
+
+	// DefaultOCORefreshInterval is how often the legs of a synthetic OCO
+	// order are checked when no interval is specified
+	DefaultOCORefreshInterval = time.Second * 5
+)
+
+// Submit places both legs of an OCO order. The pair is sent as a single
+// native OCO order when the exchange supports it and the legs are a resting
+// limit order and a stop loss with the same pair, side and amount. Otherwise
+// each leg is placed separately and monitored until one executes, at which
+// point the other is cancelled
+func (c *ocoManager) Submit(oco *OCO) (*OCO, error) {
+	if oco == nil {
+		return nil, errOCOIsNil
+	}
+
+	for i := range oco.Legs {
+		if oco.Legs[i].Order.AssetType == "" {
+			oco.Legs[i].Order.AssetType = asset.Spot
+		}
+		if oco.Legs[i].Conditional != "" && oco.Legs[i].TriggerPrice <= 0 {
+			return nil, errOCOLegNoTrigger
+		}
+	}
+
+	if oco.RefreshInterval <= 0 {
+		oco.RefreshInterval = DefaultOCORefreshInterval
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	oco.ID = id.String()
+	oco.Status = OCOActive
+	oco.Created = clock.Now()
+	oco.LastUpdated = oco.Created
+
+	native, limit, ok := nativeOCO(oco)
+	if ok {
+		exch := GetExchangeByName(native.Limit.Exchange)
+		if _, ok = exch.(exchange.OCOSubmitter); !ok {
+			native = nil
+		}
+	}
+	if native != nil {
+		var resp *ocoSubmitResponse
+		resp, err = Bot.OrderManager.SubmitOCO(native)
+		if err != nil {
+			return nil, err
+		}
+		oco.Native = true
+		oco.ListID = resp.ListID
+		oco.Legs[limit].OrderID = resp.LimitOrderID
+		oco.Legs[limit].InternalOrderID = resp.LimitInternalOrderID
+		oco.Legs[1-limit].OrderID = resp.StopOrderID
+		oco.Legs[1-limit].InternalOrderID = resp.StopInternalOrderID
+	} else {
+		err = c.place(oco)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.m.Lock()
+	if c.ocos == nil {
+		c.ocos = make(map[string]*OCO)
+	}
+	c.ocos[oco.ID] = oco
+	c.m.Unlock()
+
+	c.notify(oco, string(oco.Status))
+	if oco.Status == OCOActive {
+		go c.run(oco)
+	}
+	return c.GetByID(oco.ID)
+}
+
+// place submits each leg of a synthetic OCO order. If a leg is fully matched
+// on placement the legs placed before it are cancelled and the rest are not
+// placed
+func (c *ocoManager) place(oco *OCO) error {
+	for i := range oco.Legs {
+		leg := &oco.Legs[i]
+		if leg.Conditional != "" {
+			cond, err := Bot.ConditionalManager.Add(&ConditionalOrder{
+				Type:         leg.Conditional,
+				Order:        leg.Order,
+				TriggerPrice: leg.TriggerPrice,
+			})
+			if err != nil {
+				c.cancelLegs(oco.Legs[:i])
+				return err
+			}
+			leg.ConditionalID = cond.ID
+			continue
+		}
+
+		s := leg.Order
+		resp, err := Bot.OrderManager.Submit(&s)
+		if err != nil {
+			c.cancelLegs(oco.Legs[:i])
+			return err
+		}
+		leg.OrderID = resp.OrderID
+		leg.InternalOrderID = resp.InternalOrderID
+		if resp.FullyMatched {
+			c.cancelLegs(oco.Legs[:i])
+			oco.Executed = i + 1
+			oco.Status = OCOFilled
+			return nil
+		}
+	}
+	return nil
+}
+
+// GetByID returns a copy of the OCO order matching the supplied ID
+func (c *ocoManager) GetByID(id string) (*OCO, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	oco, ok := c.ocos[id]
+	if !ok {
+		return nil, ErrOCONotFound
+	}
+	cp := *oco
+	return &cp, nil
+}
+
+// GetAll returns a copy of all OCO orders
+func (c *ocoManager) GetAll() []OCO {
+	c.m.Lock()
+	defer c.m.Unlock()
+	var ocos []OCO
+	for _, v := range c.ocos {
+		ocos = append(ocos, *v)
+	}
+	return ocos
+}
+
+// Cancel cancels both legs of an active OCO order. Cancelling either order of
+// a native OCO order cancels both on the exchange
+func (c *ocoManager) Cancel(id string) error {
+	c.m.Lock()
+	oco, ok := c.ocos[id]
+	if !ok {
+		c.m.Unlock()
+		return ErrOCONotFound
+	}
+	if oco.Status != OCOActive {
+		c.m.Unlock()
+		return errOCONotActive
+	}
+	c.m.Unlock()
+
+	var err error
+	if oco.Native {
+		err = c.cancelLeg(&oco.Legs[0])
+	} else {
+		for i := range oco.Legs {
+			if legErr := c.cancelLeg(&oco.Legs[i]); legErr != nil && err == nil {
+				err = legErr
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	c.finish(oco, OCOCancelled)
+	return nil
+}
+
+// run checks the legs each time the refresh interval elapses until the OCO
+// order completes or the order manager is shut down
+func (c *ocoManager) run(oco *OCO) {
+	for {
+		select {
+		case <-Bot.OrderManager.shutdown:
+			return
+		case <-time.After(oco.RefreshInterval):
+			if c.check(oco) {
+				return
+			}
+		}
+	}
+}
+
+// check retrieves the state of both legs. Once a leg has executed or been
+// closed outside of the OCO manager the other leg is cancelled, unless the
+// exchange has done so already. Returns true once the OCO order has completed
+func (c *ocoManager) check(oco *OCO) bool {
+	c.m.Lock()
+	status := oco.Status
+	c.m.Unlock()
+	if status != OCOActive {
+		return true
+	}
+
+	executed, closed := -1, -1
+	for i := range oco.Legs {
+		state, err := c.legState(&oco.Legs[i])
+		if state == ocoLegFailed {
+			c.fail(oco, fmt.Errorf("leg %d: %s", i+1, err))
+			return true
+		}
+		if err != nil {
+			log.Warnf(log.OrderMgr, "OCO manager: oco %s unable to check leg %d: %s", oco.ID, i+1, err)
+			return false
+		}
+		switch {
+		case state == ocoLegExecuted && executed < 0:
+			executed = i
+		case state == ocoLegClosed && closed < 0:
+			closed = i
+		}
+	}
+
+	switch {
+	case executed >= 0:
+		c.m.Lock()
+		oco.Executed = executed + 1
+		c.m.Unlock()
+		if !oco.Native {
+			if err := c.cancelLeg(&oco.Legs[1-executed]); err != nil {
+				c.fail(oco, fmt.Errorf("leg %d executed but leg %d could not be cancelled: %s", executed+1, 2-executed, err))
+				return true
+			}
+		}
+		c.finish(oco, OCOFilled)
+		return true
+	case closed >= 0:
+		if !oco.Native {
+			if err := c.cancelLeg(&oco.Legs[1-closed]); err != nil {
+				c.fail(oco, fmt.Errorf("leg %d closed but leg %d could not be cancelled: %s", closed+1, 2-closed, err))
+				return true
+			}
+		}
+		c.finish(oco, OCOCancelled)
+		return true
+	}
+	return false
+}
+
+// legState returns whether a leg is still open, has executed or has been
+// closed without executing. A conditional leg has executed once triggered and
+// has failed if its order could not be submitted
+func (c *ocoManager) legState(leg *OCOLeg) (ocoLegState, error) {
+	c.m.Lock()
+	condID, orderID := leg.ConditionalID, leg.OrderID
+	c.m.Unlock()
+
+	if orderID == "" && condID != "" {
+		cond, err := Bot.ConditionalManager.GetByID(condID)
+		if err != nil {
+			return ocoLegOpen, err
+		}
+		switch cond.Status {
+		case ConditionalTriggered:
+			c.m.Lock()
+			leg.OrderID = cond.OrderID
+			leg.InternalOrderID = cond.InternalOrderID
+			c.m.Unlock()
+			return ocoLegExecuted, nil
+		case ConditionalCancelled:
+			return ocoLegClosed, nil
+		case ConditionalFailed:
+			return ocoLegFailed, errors.New(cond.Error)
+		}
+		return ocoLegOpen, nil
+	}
+
+	exch := GetExchangeByName(leg.Order.Exchange)
+	if exch == nil {
+		return ocoLegOpen, ErrExchangeNotFound
+	}
+	od, err := exch.GetOrderInfo(orderID)
+	if err != nil {
+		return ocoLegOpen, err
+	}
+	switch {
+	case od.Status == order.Filled,
+		od.Status == order.PartiallyFilled,
+		od.ExecutedAmount > 0:
+		return ocoLegExecuted, nil
+	case od.Status == order.Cancelled,
+		od.Status == order.Rejected,
+		od.Status == order.Expired:
+		return ocoLegClosed, nil
+	}
+	return ocoLegOpen, nil
+}
+
+// cancelLeg cancels a leg resting on the exchange or held by the conditional
+// order manager
+func (c *ocoManager) cancelLeg(leg *OCOLeg) error {
+	c.m.Lock()
+	condID, orderID := leg.ConditionalID, leg.OrderID
+	c.m.Unlock()
+
+	if orderID == "" {
+		if condID == "" {
+			return nil
+		}
+		return Bot.ConditionalManager.Cancel(condID)
+	}
+	return Bot.OrderManager.Cancel(&order.Cancel{
+		Exchange:  leg.Order.Exchange,
+		ID:        orderID,
+		AccountID: leg.Order.AccountID,
+		ClientID:  leg.Order.ClientID,
+		Type:      leg.Order.Type,
+		Side:      leg.Order.Side,
+		Pair:      leg.Order.Pair,
+		AssetType: leg.Order.AssetType,
+	})
+}
+
+// cancelLegs cancels legs which have already been placed when placing a
+// later leg fails or fully matches
+func (c *ocoManager) cancelLegs(legs []OCOLeg) {
+	for i := range legs {
+		if err := c.cancelLeg(&legs[i]); err != nil {
+			log.Errorf(log.OrderMgr, "OCO manager: unable to cancel leg %d: %s", i+1, err)
+		}
+	}
+}
+
+func (c *ocoManager) finish(oco *OCO, status OCOStatus) {
+	c.m.Lock()
+	oco.Status = status
+	oco.LastUpdated = clock.Now()
+	c.m.Unlock()
+	c.notify(oco, string(status))
+}
+
+func (c *ocoManager) fail(oco *OCO, err error) {
+	c.m.Lock()
+	oco.Error = err.Error()
+	c.m.Unlock()
+	log.Warnf(log.OrderMgr, "OCO manager: oco %s failed: %s", oco.ID, err)
+	c.finish(oco, OCOFailed)
+}
+
+func (c *ocoManager) notify(oco *OCO, action string) {
+	c.m.Lock()
+	legs := make([]string, len(oco.Legs))
+	for i := range oco.Legs {
+		legs[i] = fmt.Sprintf("%s %s %s %v",
+			oco.Legs[i].Order.Exchange,
+			oco.Legs[i].Order.Side,
+			oco.Legs[i].Order.Pair,
+			oco.Legs[i].Order.Amount)
+		if oco.Legs[i].Conditional != "" {
+			legs[i] += fmt.Sprintf(" %s at %v", oco.Legs[i].Conditional, oco.Legs[i].TriggerPrice)
+		} else {
+			legs[i] += fmt.Sprintf(" %s at %v", oco.Legs[i].Order.Type, oco.Legs[i].Order.Price)
+		}
+	}
+	msg := fmt.Sprintf("OCO manager: oco %s [%s] native %v %s. Executed leg %v",
+		oco.ID,
+		strings.Join(legs, " | "),
+		oco.Native,
+		action,
+		oco.Executed)
+	c.m.Unlock()
+	log.Debugln(log.OrderMgr, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "oco",
+		Message: msg,
+	})
+}
+
+// nativeOCO returns the exchange OCO order for the legs and the index of the
+// limit leg when the legs are a resting limit order and a stop loss with the
+// same exchange, pair, asset, side and amount
+func nativeOCO(oco *OCO) (*order.OCO, int, bool) {
+	for i := range oco.Legs {
+		limit, stop := &oco.Legs[i], &oco.Legs[1-i]
+		if limit.Conditional != "" ||
+			limit.Order.Type != order.Limit ||
+			stop.Conditional != ConditionalStopLoss {
+			continue
+		}
+		if !strings.EqualFold(limit.Order.Exchange, stop.Order.Exchange) ||
+			!limit.Order.Pair.Equal(stop.Order.Pair) ||
+			limit.Order.AssetType != stop.Order.AssetType ||
+			isBuySide(limit.Order.Side) != isBuySide(stop.Order.Side) ||
+			limit.Order.Amount != stop.Order.Amount {
+			return nil, 0, false
+		}
+		native := &order.OCO{
+			Limit:     limit.Order,
+			StopPrice: stop.TriggerPrice,
+		}
+		if stop.Order.Type == order.Limit {
+			native.StopLimitPrice = stop.Order.Price
+		}
+		return native, i, true
+	}
+	return nil, 0, false
+}
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func testOCOLeg(exch string, condType ConditionalType, orderType order.Type, price, trigger float64) OCOLeg {
+	return OCOLeg{
+		Order: order.Submit{
+			Exchange:  exch,
+			Pair:      currency.NewPair(currency.BTC, currency.USDT),
+			AssetType: asset.Spot,
+			Side:      order.Sell,
+			Type:      orderType,
+			Amount:    1,
+			Price:     price,
+		},
+		Conditional:  condType,
+		TriggerPrice: trigger,
+	}
+}
+
+func TestNativeOCO(t *testing.T) {
+	oco := &OCO{Legs: [2]OCOLeg{
+		testOCOLeg(fakePassExchange, ConditionalStopLoss, order.Limit, 94, 95),
+		testOCOLeg(fakePassExchange, "", order.Limit, 110, 0),
+	}}
+	native, limit, ok := nativeOCO(oco)
+	if !ok {
+		t.Fatal("expected a resting limit order and stop loss to be supported natively")
+	}
+	if limit != 1 ||
+		native.Limit.Price != 110 ||
+		native.StopPrice != 95 ||
+		native.StopLimitPrice != 94 {
+		t.Errorf("unexpected native OCO order %+v limit leg %v", native, limit)
+	}
+
+	oco.Legs[0].Order.Type = order.Market
+	if native, _, ok = nativeOCO(oco); !ok || native.StopLimitPrice != 0 {
+		t.Errorf("expected a stop market order, got %+v", native)
+	}
+
+	oco.Legs[0].Order.Amount = 2
+	if _, _, ok = nativeOCO(oco); ok {
+		t.Error("expected legs with different amounts to be synthetic")
+	}
+
+	oco.Legs[0] = testOCOLeg(fakePassExchange, ConditionalTakeProfit, order.Limit, 120, 121)
+	if _, _, ok = nativeOCO(oco); ok {
+		t.Error("expected a take profit leg to be synthetic")
+	}
+}
+
+func TestOCOManager(t *testing.T) {
+	OrdersSetup(t)
+	dir, err := ioutil.TempDir("", "oco")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldInterval := Bot.Config.ConditionalOrders.Interval
+	oldPath := Bot.ConditionalManager.path
+	Bot.Config.ConditionalOrders.Interval = time.Hour
+	Bot.ConditionalManager.path = filepath.Join(dir, conditionalOrdersFile)
+	if err = Bot.ConditionalManager.Start(); err != nil {
+		Bot.Config.ConditionalOrders.Interval = oldInterval
+		Bot.ConditionalManager.path = oldPath
+		t.Fatal(err)
+	}
+	defer func() {
+		// restored once the manager has stopped so its run loop is finished
+		// with them
+		if stopErr := Bot.ConditionalManager.Stop(); stopErr != nil {
+			t.Error(stopErr)
+		}
+		Bot.Config.ConditionalOrders.Interval = oldInterval
+		Bot.ConditionalManager.path = oldPath
+	}()
+	// the fake exchange always returns the same order ID
+	clearOrders := func() {
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}
+	defer clearOrders()
+
+	var o ocoManager
+	if _, err = o.Submit(nil); err != errOCOIsNil {
+		t.Errorf("expected %v, got %v", errOCOIsNil, err)
+	}
+	if _, err = o.Submit(&OCO{Legs: [2]OCOLeg{
+		testOCOLeg(fakePassExchange, ConditionalStopLoss, order.Market, 0, 0),
+		testOCOLeg(fakePassExchange, ConditionalTakeProfit, order.Market, 0, 110),
+	}}); err != errOCOLegNoTrigger {
+		t.Errorf("expected %v, got %v", errOCOLegNoTrigger, err)
+	}
+
+	// the fake exchange fills limit orders on placement so the second leg is
+	// never placed
+	filled, err := o.Submit(&OCO{Legs: [2]OCOLeg{
+		testOCOLeg(fakePassExchange, "", order.Limit, 110, 0),
+		testOCOLeg(fakePassExchange, ConditionalStopLoss, order.Market, 0, 95),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filled.Native ||
+		filled.Status != OCOFilled ||
+		filled.Executed != 1 ||
+		filled.Legs[1].ConditionalID != "" {
+		t.Errorf("expected first leg to fill on placement, got %+v", filled)
+	}
+	clearOrders()
+
+	bracket := &OCO{
+		Legs: [2]OCOLeg{
+			testOCOLeg(fakePassExchange, ConditionalStopLoss, order.Market, 0, 95),
+			testOCOLeg(fakePassExchange, ConditionalTakeProfit, order.Market, 0, 110),
+		},
+		RefreshInterval: time.Hour,
+	}
+	resp, err := o.Submit(bracket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != OCOActive ||
+		resp.Legs[0].ConditionalID == "" ||
+		resp.Legs[1].ConditionalID == "" {
+		t.Fatalf("expected both legs to be held as conditional orders, got %+v", resp)
+	}
+
+	err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: bracket.Legs[0].Order.Pair, Last: 100}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Bot.ConditionalManager.check()
+	if o.check(bracket) {
+		t.Fatal("expected oco order to remain active")
+	}
+
+	err = ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: bracket.Legs[0].Order.Pair, Last: 94}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Bot.ConditionalManager.check()
+	if !o.check(bracket) {
+		t.Fatal("expected oco order to complete")
+	}
+	if resp, err = o.GetByID(bracket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != OCOFilled || resp.Executed != 1 || resp.Legs[0].OrderID == "" {
+		t.Errorf("expected stop loss leg to execute, got %+v", resp)
+	}
+	profit, err := Bot.ConditionalManager.GetByID(resp.Legs[1].ConditionalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profit.Status != ConditionalCancelled {
+		t.Errorf("expected take profit leg to be cancelled, got %s", profit.Status)
+	}
+	if err = o.Cancel(bracket.ID); err != errOCONotActive {
+		t.Errorf("expected %v, got %v", errOCONotActive, err)
+	}
+
+	cancelled, err := o.Submit(&OCO{
+		Legs: [2]OCOLeg{
+			testOCOLeg(fakePassExchange, ConditionalStopLoss, order.Market, 0, 90),
+			testOCOLeg(fakePassExchange, ConditionalTakeProfit, order.Market, 0, 110),
+		},
+		RefreshInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = o.Cancel(cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	for i := range cancelled.Legs {
+		cond, err := Bot.ConditionalManager.GetByID(cancelled.Legs[i].ConditionalID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cond.Status != ConditionalCancelled {
+			t.Errorf("expected leg %d to be cancelled, got %s", i+1, cond.Status)
+		}
+	}
+	if err = o.Cancel("nope"); err != ErrOCONotFound {
+		t.Errorf("expected %v, got %v", ErrOCONotFound, err)
+	}
+	if len(o.GetAll()) != 3 {
+		t.Errorf("expected 3 oco orders, got %d", len(o.GetAll()))
+	}
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// OCOStatus defines the state of a one-cancels-other order pair
+type OCOStatus string
+
+// All OCO status types
+const (
+	OCOActive    OCOStatus = "ACTIVE"
+	OCOFilled    OCOStatus = "FILLED"
+	OCOCancelled OCOStatus = "CANCELLED"
+	OCOFailed    OCOStatus = "FAILED"
+)
+
+// OCOLeg is one of the two orders of a one-cancels-other pair. A leg without a
+// conditional type rests on the order book, otherwise it is held by the
+// conditional order manager until its trigger price is reached
+type OCOLeg struct {
+	Order        order.Submit
+	Conditional  ConditionalType
+	TriggerPrice float64
+	// ConditionalID is set while the leg is held by the conditional order
+	// manager
+	ConditionalID   string
+	OrderID         string
+	InternalOrderID string
+}
+
+// OCO links two orders so that when one executes the other is cancelled.
+// Pairs are linked by the exchange when it supports OCO orders natively,
+// otherwise the legs are polled and cancelled by the OCO manager
+type OCO struct {
+	ID   string
+	Legs [2]OCOLeg
+	// Native is true when the exchange links the legs itself
+	Native bool
+	// ListID is the exchange's ID for a native OCO order
+	ListID string
+	Status OCOStatus
+	// Executed is the number of the leg which executed, 1 or 2, and is zero
+	// until either has
+	Executed int
+	// RefreshInterval is how often the legs are checked for executions
+	RefreshInterval time.Duration
+	Error           string
+	Created         time.Time
+	LastUpdated     time.Time
+}
+
+// ocoLegState is the state of an OCO leg as seen by the OCO manager
+type ocoLegState int
+
+const (
+	ocoLegOpen ocoLegState = iota
+	ocoLegExecuted
+	ocoLegClosed
+	ocoLegFailed
+)
+
+type ocoManager struct {
+	m    sync.Mutex
+	ocos map[string]*OCO
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+	"github.com/thrasher-corp/gocryptotrader/strategies/backtest"
+)
+
+var errNoStoredCandles = errors.New("no stored candles for the series over the period, candles can be stored with the history download mode")
+
+// OptimiseStrategy runs a walk-forward optimisation of a strategy's
+// parameters over the stored candles of its market between start and end
+// and returns the ranked parameter sets and the amount of candles replayed
+func OptimiseStrategy(o *backtest.Optimisation, start, end time.Time) ([]backtest.Result, int, error) {
+	candles, err := storedCandles(&o.Settings, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+	results, err := backtest.Optimise(o, candles)
+	return results, len(candles), err
+}
+
+// SimulateStrategy backtests a strategy over the stored candles of its market
+// between start and end and resamples its trades into Monte Carlo
+// simulations, returning its performance, the distribution of the simulated
+// results and the amount of candles replayed
+func SimulateStrategy(s *backtest.Settings, mc *performance.MonteCarloSettings, start, end time.Time) (performance.Report, performance.MonteCarloResult, int, error) {
+	candles, err := storedCandles(s, start, end)
+	if err != nil {
+		return performance.Report{}, performance.MonteCarloResult{}, 0, err
+	}
+	r, result, err := backtest.MonteCarlo(s, candles, mc)
+	return r, result, len(candles), err
+}
+
+// storedCandles returns the stored candles of the backtested market between
+// start and end
+func storedCandles(s *backtest.Settings, start, end time.Time) ([]kline.Candle, error) {
+	stored, err := candle.GetCandles(&candle.Series{
+		Exchange: s.Exchange,
+		Base:     s.Pair.Base.String(),
+		Quote:    s.Pair.Quote.String(),
+		Asset:    s.AssetType.String(),
+		Interval: s.Interval,
+	}, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		return nil, errNoStoredCandles
+	}
+	candles := make([]kline.Candle, len(stored))
+	for i := range stored {
+		candles[i] = kline.Candle{
+			Time:   stored[i].Time,
+			Open:   stored[i].Open,
+			High:   stored[i].High,
+			Low:    stored[i].Low,
+			Close:  stored[i].Close,
+			Volume: stored[i].Volume,
+		}
+	}
+	return candles, nil
+}
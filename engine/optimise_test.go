@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+	"github.com/thrasher-corp/gocryptotrader/strategies/backtest"
+)
+
+func TestOptimiseStrategy(t *testing.T) {
+	t.Parallel()
+	o := &backtest.Optimisation{
+		Settings: backtest.Settings{
+			Strategy:  "smacross",
+			Exchange:  testExchange,
+			Pair:      currency.NewPair(currency.BTC, currency.USD),
+			AssetType: asset.Spot,
+			Interval:  time.Hour,
+		},
+		Grid:  map[string][]string{"fast": {"5", "10"}},
+		Folds: 2,
+	}
+	_, _, err := OptimiseStrategy(o, time.Now().Add(-time.Hour*24), time.Now())
+	if err != database.ErrDatabaseSupportDisabled {
+		t.Errorf("expected %v, received %v", database.ErrDatabaseSupportDisabled, err)
+	}
+}
+
+func TestSimulateStrategy(t *testing.T) {
+	t.Parallel()
+	s := &backtest.Settings{
+		Strategy:  "smacross",
+		Exchange:  testExchange,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+		Interval:  time.Hour,
+	}
+	_, _, _, err := SimulateStrategy(s, &performance.MonteCarloSettings{}, time.Now().Add(-time.Hour*24), time.Now())
+	if err != database.ErrDatabaseSupportDisabled {
+		t.Errorf("expected %v, received %v", database.ErrDatabaseSupportDisabled, err)
+	}
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// get returns the trading rules of an exchange's pair, fetching the rules of
+// all of the exchange's pairs of the asset when they are due. Returns false
+// when the exchange has no rules for the pair
+func (l *orderLimitStore) get(exch exchange.IBotExchange, p currency.Pair, a asset.Item) (order.Limits, bool) {
+	fetcher, ok := exch.(exchange.OrderLimitsFetcher)
+	if !ok {
+		return order.Limits{}, false
+	}
+	if a == "" {
+		a = asset.Spot
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.limits == nil {
+		l.limits = make(map[string]order.Limits)
+		l.due = make(map[string]time.Time)
+	}
+	now := clock.Now()
+	dueKey := strings.ToLower(exch.GetName() + ":" + a.String())
+	if !now.Before(l.due[dueKey]) {
+		l.due[dueKey] = now.Add(orderLimitsRefresh)
+		limits, err := fetcher.GetOrderLimits(a)
+		switch {
+		case err == common.ErrFunctionNotSupported:
+			// the exchange has no trading rules for the asset
+		case err != nil:
+			log.Warnf(log.OrderMgr,
+				"Order manager: Unable to fetch %s %s trading rules, orders will not be conformed to them: %s\n",
+				exch.GetName(),
+				a,
+				err)
+			l.due[dueKey] = now.Add(orderLimitsRetry)
+		default:
+			for x := range limits {
+				l.limits[orderLimitKey(exch.GetName(), limits[x].Pair, a)] = limits[x]
+			}
+		}
+	}
+	limits, ok := l.limits[orderLimitKey(exch.GetName(), p, a)]
+	return limits, ok
+}
+
+func orderLimitKey(exchName string, p currency.Pair, a asset.Item) string {
+	return strings.ToLower(exchName + ":" + p.Base.String() + p.Quote.String() + ":" + a.String())
+}
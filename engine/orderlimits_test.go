@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// fakeLimitsExchange returns its trading rules, failing fetches with its
+// error
+type fakeLimitsExchange struct {
+	FakePassingExchange
+	fetches int
+	limits  []order.Limits
+	err     error
+}
+
+func (f *fakeLimitsExchange) GetOrderLimits(a asset.Item) ([]order.Limits, error) {
+	f.fetches++
+	if a != asset.Spot {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return f.limits, f.err
+}
+
+func TestOrderLimitStore(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	f := &fakeLimitsExchange{err: errors.New("unavailable")}
+	var l orderLimitStore
+	if _, ok := l.get(f, p, asset.Spot); ok {
+		t.Error("expected no limits when the fetch fails")
+	}
+	if _, ok := l.get(f, p, asset.Spot); ok || f.fetches != 1 {
+		t.Errorf("expected a failed fetch not to be retried immediately, got %d fetches", f.fetches)
+	}
+
+	f.err = nil
+	f.limits = []order.Limits{{Pair: currency.NewPairWithDelimiter("btc", "usd", "-"), AssetType: asset.Spot, MinAmount: 0.001, PriceIncrement: 0.01}}
+	l.due = nil
+	l.limits = nil
+	limits, ok := l.get(f, p, "")
+	if !ok || limits.MinAmount != 0.001 || limits.PriceIncrement != 0.01 {
+		t.Errorf("unexpected limits %+v", limits)
+	}
+	if _, ok = l.get(f, currency.NewPair(currency.ETH, currency.USD), asset.Spot); ok || f.fetches != 2 {
+		t.Errorf("expected cached limits to be used for unknown pairs, got %d fetches", f.fetches)
+	}
+	if _, ok = l.get(f, p, asset.Futures); ok || f.fetches != 3 {
+		t.Errorf("expected no limits for an unsupported asset, got %d fetches", f.fetches)
+	}
+	if _, ok = l.get(&FakePassingExchange{}, p, asset.Spot); ok {
+		t.Error("expected no limits for an exchange without trading rules")
+	}
+}
@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const (
+	// orderLimitsRefresh is how long an exchange's trading rules are used
+	// before they are fetched again
+	orderLimitsRefresh = time.Hour * 24
+	// orderLimitsRetry is how long after a failed fetch the trading rules are
+	// fetched again
+	orderLimitsRetry = time.Minute * 5
+)
+
+// orderLimitStore caches the trading rules of each exchange's pairs, which
+// are fetched when an order is first submitted to the exchange
+type orderLimitStore struct {
+	m      sync.Mutex
+	limits map[string]order.Limits
+	// due is when the trading rules of an exchange's asset are next fetched
+	due map[string]time.Time
+}
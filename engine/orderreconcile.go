@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// loadOrders reads the orders persisted by a previous run
+func loadOrders(path string) ([]order.Detail, error) {
+	if !file.Exists(path) {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var orders []order.Detail
+	if err = json.Unmarshal(data, &orders); err != nil {
+		return nil, fmt.Errorf("unable to load orders from %s: %v", path, err)
+	}
+	return orders, nil
+}
+
+// persist writes the open orders and the orders which finished within the
+// retention period to the persisted orders file
+func (o *orderManager) persist() {
+	if o.persistPath == "" {
+		return
+	}
+	cutoff := clock.Now().Add(-Bot.Config.OrderReconcile.Retention)
+	var orders []order.Detail
+	o.orderStore.m.RLock()
+	for _, v := range o.orderStore.Orders {
+		for i := range v {
+			if orderDone(v[i].Status) && v[i].LastUpdated.Before(cutoff) {
+				continue
+			}
+			orders = append(orders, *v[i])
+		}
+	}
+	o.orderStore.m.RUnlock()
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Exchange != orders[j].Exchange {
+			return orders[i].Exchange < orders[j].Exchange
+		}
+		return orders[i].Date.Before(orders[j].Date)
+	})
+
+	data, err := json.Marshal(orders)
+	if err == nil {
+		err = file.Write(o.persistPath, data)
+	}
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Order manager: Unable to persist orders: %s\n", err)
+	}
+}
+
+// reconcile adds the persisted orders to the order store and compares those
+// which were open when persisted with each exchange's open orders and order
+// history. Fills which occurred while the bot was not running are applied to
+// the stored orders, so that positions and profit and loss include them, and
+// a synthetic fill is emitted for each. Returns the synthetic fills
+func (o *orderManager) reconcile(persisted []order.Detail) []SyntheticFill {
+	open := make(map[string][]*order.Detail)
+	var exchanges []string
+	for i := range persisted {
+		d := &persisted[i]
+		if err := o.orderStore.Add(d); err != nil {
+			if err != ErrOrdersAlreadyExists {
+				log.Warnf(log.OrderMgr, "Order manager: Unable to restore %s order %s: %s\n", d.Exchange, d.ID, err)
+			}
+			continue
+		}
+		if orderDone(d.Status) {
+			continue
+		}
+		if _, ok := open[d.Exchange]; !ok {
+			exchanges = append(exchanges, d.Exchange)
+		}
+		open[d.Exchange] = append(open[d.Exchange], d)
+	}
+
+	var fills []SyntheticFill
+	for x := range exchanges {
+		current, err := exchangeOrders(GetExchangeByName(exchanges[x]), open[exchanges[x]])
+		if err != nil {
+			log.Warnf(log.OrderMgr, "Order manager: Unable to reconcile %s orders: %s\n", exchanges[x], err)
+			continue
+		}
+		for _, d := range open[exchanges[x]] {
+			update, ok := current[d.ID]
+			if !ok {
+				log.Warnf(log.OrderMgr, "Order manager: %s order %s was not found while reconciling orders.\n", d.Exchange, d.ID)
+				continue
+			}
+			fill, filled := o.applyUpdate(d, update)
+			Bot.StrategyManager.orderUpdated(d)
+			Bot.EventBus.publishOrder(d)
+			if !filled {
+				continue
+			}
+			fills = append(fills, fill)
+			msg := fmt.Sprintf("Order manager: Exchange %s order ID=%v filled amount=%v price=%v while the bot was not running, status=%v.",
+				fill.Exchange, fill.OrderID, fill.Amount, fill.Price, fill.Status)
+			log.Infof(log.OrderMgr, "%v\n", msg)
+			Bot.CommsManager.PushEvent(base.Event{
+				Type:    "fill",
+				Message: msg,
+			})
+		}
+	}
+	return fills
+}
+
+// exchangeOrders returns the current state of an exchange's orders keyed by
+// ID, read from its order history since the earliest order followed by its
+// open orders. Order history is optional as not every exchange supports it
+func exchangeOrders(exch exchange.IBotExchange, orders []*order.Detail) (map[string]*order.Detail, error) {
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	req := order.GetOrdersRequest{
+		Type:     order.AnyType,
+		Side:     order.AnySide,
+		EndTicks: clock.Now(),
+	}
+	var pairs currency.Pairs
+	for i := range orders {
+		if req.StartTicks.IsZero() || orders[i].Date.Before(req.StartTicks) {
+			req.StartTicks = orders[i].Date
+		}
+		if !pairs.Contains(orders[i].Pair, true) {
+			pairs = pairs.Add(orders[i].Pair)
+		}
+	}
+	req.Pairs = pairs
+
+	current := make(map[string]*order.Detail)
+	history, err := exch.GetOrderHistory(&req)
+	if err != nil {
+		log.Warnf(log.OrderMgr, "Order manager: Unable to get %s order history, only open orders will be reconciled: %s\n", exch.GetName(), err)
+	} else {
+		for i := range history {
+			current[history[i].ID] = &history[i]
+		}
+	}
+	active, err := exch.GetActiveOrders(&order.GetOrdersRequest{
+		Type:  order.AnyType,
+		Side:  order.AnySide,
+		Pairs: req.Pairs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range active {
+		current[active[i].ID] = &active[i]
+	}
+	return current, nil
+}
+
+// applyUpdate updates a stored order with its current state on the exchange,
+// returning the fill when more of the order has been filled
+func (o *orderManager) applyUpdate(stored, current *order.Detail) (SyntheticFill, bool) {
+	o.orderStore.m.Lock()
+	defer o.orderStore.m.Unlock()
+
+	prevAmount, prevValue, prevFee := orderFill(stored)
+	amount, value, fee := orderFill(current)
+	if current.Status != "" {
+		stored.Status = current.Status
+	}
+	if stored.Price == 0 {
+		stored.Price = current.Price
+	}
+	if len(current.Trades) > 0 {
+		stored.Trades = current.Trades
+	}
+	stored.LastUpdated = clock.Now()
+	if amount <= prevAmount {
+		return SyntheticFill{}, false
+	}
+	stored.ExecutedAmount = amount
+	stored.RemainingAmount = current.RemainingAmount
+	stored.Fee = fee
+
+	filled := amount - prevAmount
+	price := (value - prevValue) / filled
+	if price <= 0 {
+		price = current.Price
+	}
+	return SyntheticFill{
+		Exchange:        stored.Exchange,
+		OrderID:         stored.ID,
+		InternalOrderID: stored.InternalOrderID,
+		ClientID:        stored.ClientID,
+		Pair:            stored.Pair,
+		AssetType:       stored.AssetType,
+		Side:            stored.Side,
+		Amount:          filled,
+		Price:           price,
+		Fee:             fee - prevFee,
+		Status:          stored.Status,
+		Time:            stored.LastUpdated,
+	}, true
+}
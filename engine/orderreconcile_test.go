@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const reconcileExchange = "ReconcileExchange"
+
+// fakeReconcileExchange reports the orders filled while the bot was down
+type fakeReconcileExchange struct {
+	FakePassingExchange
+	active     []order.Detail
+	history    []order.Detail
+	historyErr error
+}
+
+func (f *fakeReconcileExchange) GetName() string { return reconcileExchange }
+
+func (f *fakeReconcileExchange) GetActiveOrders(_ *order.GetOrdersRequest) ([]order.Detail, error) {
+	return f.active, nil
+}
+
+func (f *fakeReconcileExchange) GetOrderHistory(_ *order.GetOrdersRequest) ([]order.Detail, error) {
+	return f.history, f.historyErr
+}
+
+func TestReconcileOrders(t *testing.T) {
+	SetupTestHelpers(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	f := &fakeReconcileExchange{
+		active: []order.Detail{
+			{ID: "partial", Exchange: reconcileExchange, Amount: 2, ExecutedAmount: 1.5, RemainingAmount: 0.5, Price: 100, Status: order.PartiallyFilled},
+			{ID: "untouched", Exchange: reconcileExchange, Amount: 1, Price: 90, Status: order.Active},
+		},
+		history: []order.Detail{
+			{ID: "filled", Exchange: reconcileExchange, Amount: 1, Status: order.Filled, Trades: []order.TradeHistory{
+				{Price: 110, Amount: 0.25, Fee: 0.1},
+				{Price: 120, Amount: 0.75, Fee: 0.3},
+			}},
+		},
+	}
+	Bot.exchangeManager.add(f)
+	defer func() {
+		if err := Bot.exchangeManager.removeExchange(reconcileExchange); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	date := clock.Now().Add(-time.Hour)
+	persisted := []order.Detail{
+		{ID: "partial", Exchange: reconcileExchange, Pair: p, AssetType: asset.Spot, Side: order.Buy, Amount: 2, ExecutedAmount: 0.5, Price: 100, Status: order.PartiallyFilled, Date: date},
+		{ID: "untouched", Exchange: reconcileExchange, Pair: p, AssetType: asset.Spot, Side: order.Buy, Amount: 1, Price: 90, Status: order.Active, Date: date},
+		{ID: "filled", Exchange: reconcileExchange, Pair: p, AssetType: asset.Spot, Side: order.Sell, Amount: 1, Status: order.New, Date: date},
+		{ID: "missing", Exchange: reconcileExchange, Pair: p, AssetType: asset.Spot, Side: order.Sell, Amount: 1, Status: order.New, Date: date},
+		{ID: "done", Exchange: reconcileExchange, Pair: p, AssetType: asset.Spot, Side: order.Sell, Amount: 1, ExecutedAmount: 1, Status: order.Filled, Date: date},
+		{ID: "unloaded", Exchange: "NotAnExchange", Pair: p, AssetType: asset.Spot, Side: order.Sell, Amount: 1, Status: order.New, Date: date},
+	}
+	var o orderManager
+	o.orderStore.Orders = make(map[string][]*order.Detail)
+	fills := o.reconcile(persisted)
+	if len(fills) != 2 {
+		t.Fatalf("expected 2 synthetic fills, got %+v", fills)
+	}
+	if fills[0].OrderID != "partial" || fills[0].Amount != 1 || fills[0].Price != 100 ||
+		fills[0].Side != order.Buy || !fills[0].Pair.Equal(p) {
+		t.Errorf("unexpected partial fill %+v", fills[0])
+	}
+	if fills[1].OrderID != "filled" || fills[1].Amount != 1 || fills[1].Price != 117.5 ||
+		fills[1].Fee != 0.4 || fills[1].Status != order.Filled {
+		t.Errorf("unexpected fill %+v", fills[1])
+	}
+
+	orders, err := o.orderStore.GetByExchange(reconcileExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 5 {
+		t.Errorf("expected the orders of loaded exchanges to be restored, got %d", len(orders))
+	}
+	d, err := o.orderStore.GetByExchangeAndID(reconcileExchange, "filled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Status != order.Filled || d.ExecutedAmount != 1 || len(d.Trades) != 2 {
+		t.Errorf("expected the stored order to be updated, got %+v", d)
+	}
+	if d, err = o.orderStore.GetByExchangeAndID(reconcileExchange, "missing"); err != nil || d.Status != order.New {
+		t.Errorf("expected the missing order to be left as is, got %+v %v", d, err)
+	}
+
+	// orders which are already stored are not reconciled again
+	if fills = o.reconcile(persisted); len(fills) != 0 {
+		t.Errorf("expected stored orders to be skipped, got %+v", fills)
+	}
+
+	// without order history only open orders can be reconciled
+	f.historyErr = errors.New("unsupported")
+	f.active[0].ExecutedAmount = 2
+	f.active[0].Status = order.Filled
+	o = orderManager{}
+	o.orderStore.Orders = make(map[string][]*order.Detail)
+	fills = o.reconcile([]order.Detail{
+		{ID: "partial", Exchange: reconcileExchange, Pair: p, Side: order.Buy, Amount: 2, ExecutedAmount: 1.5, Price: 100, Status: order.PartiallyFilled, Date: date},
+		{ID: "filled", Exchange: reconcileExchange, Pair: p, Side: order.Sell, Amount: 1, Status: order.New, Date: date},
+	})
+	if len(fills) != 1 || fills[0].OrderID != "partial" || fills[0].Amount != 0.5 || fills[0].Status != order.Filled {
+		t.Errorf("expected the open order to be reconciled, got %+v", fills)
+	}
+}
+
+func TestPersistOrders(t *testing.T) {
+	SetupTestHelpers(t)
+	dir, err := ioutil.TempDir("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := Bot.Config.OrderReconcile
+	Bot.Config.OrderReconcile = config.OrderReconcileConfig{Enabled: true, Retention: time.Hour}
+	defer func() { Bot.Config.OrderReconcile = cfg }()
+
+	path := filepath.Join(dir, ordersFileName)
+	orders, err := loadOrders(path)
+	if err != nil || orders != nil {
+		t.Fatalf("expected no orders before persisting, got %v %v", orders, err)
+	}
+
+	now := clock.Now()
+	o := orderManager{persistPath: path}
+	o.orderStore.Orders = map[string][]*order.Detail{
+		fakePassExchange: {
+			{ID: "open", Exchange: fakePassExchange, Pair: currency.NewPair(currency.BTC, currency.USD), Status: order.Active, Date: now, LastUpdated: now.Add(-time.Hour * 2)},
+			{ID: "recent", Exchange: fakePassExchange, Status: order.Filled, Date: now.Add(-time.Minute), LastUpdated: now},
+			{ID: "expired", Exchange: fakePassExchange, Status: order.Cancelled, Date: now.Add(-time.Hour * 3), LastUpdated: now.Add(-time.Hour * 2)},
+		},
+	}
+	o.persist()
+	if orders, err = loadOrders(path); err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 || orders[0].ID != "recent" || orders[1].ID != "open" ||
+		!orders[1].Pair.Equal(currency.NewPair(currency.BTC, currency.USD)) {
+		t.Errorf("expected the open and recently finished orders to be persisted, got %+v", orders)
+	}
+
+	if err = ioutil.WriteFile(path, []byte("nope"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loadOrders(path); err == nil {
+		t.Error("expected an error loading invalid orders")
+	}
+}
@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// ordersFileName is the data directory file the order manager persists its
+// orders to
+const ordersFileName = "orders.json"
+
+// SyntheticFill is the part of a persisted order found to have been filled
+// while the bot was not running. Price is zero when the exchange does not
+// report the order's fill price
+type SyntheticFill struct {
+	Exchange        string
+	OrderID         string
+	InternalOrderID string
+	ClientID        string
+	Pair            currency.Pair
+	AssetType       asset.Item
+	Side            order.Side
+	Amount          float64
+	Price           float64
+	Fee             float64
+	// Status is the order's status reported by the exchange
+	Status order.Status
+	Time   time.Time
+}
@@ -3,12 +3,18 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -18,6 +24,8 @@ var (
 	OrderManagerDelay      = time.Second * 10
 	ErrOrdersAlreadyExists = errors.New("order already exists")
 	ErrOrderNotFound       = errors.New("order does not exist")
+	ErrSelfTradePrevented  = errors.New("order would trade against an open order placed by this bot")
+	ErrOCONotSupported     = errors.New("exchange does not support oco orders natively")
 )
 
 // get returns all orders for all exchanges
@@ -46,6 +54,24 @@ func (o *orderStore) GetByExchangeAndID(exchange, id string) (*order.Detail, err
 	return nil, ErrOrderNotFound
 }
 
+// GetByExchangeAndClientID returns a specific order by exchange and the
+// client order ID it was submitted with
+func (o *orderStore) GetByExchangeAndClientID(exchange, clientID string) (*order.Detail, error) {
+	o.m.RLock()
+	defer o.m.RUnlock()
+	r, ok := o.Orders[exchange]
+	if !ok {
+		return nil, ErrExchangeNotFound
+	}
+
+	for x := range r {
+		if r[x].ClientID == clientID {
+			return r[x], nil
+		}
+	}
+	return nil, ErrOrderNotFound
+}
+
 // GetByExchange returns orders by exchange
 func (o *orderStore) GetByExchange(exchange string) ([]*order.Detail, error) {
 	o.m.RLock()
@@ -120,9 +146,51 @@ func (o *orderStore) Add(order *order.Detail) error {
 	orders = append(orders, order)
 	o.Orders[order.Exchange] = orders
 
+	Bot.StrategyManager.orderUpdated(order)
+	Bot.EventBus.publishOrder(order)
 	return nil
 }
 
+// Update merges the current state of a tracked order into the orderStore
+func (o *orderStore) Update(d *order.Detail) error {
+	if d == nil {
+		return errors.New("order store: Order is nil")
+	}
+	return o.modify(d.Exchange, d.ID, func(od *order.Detail) {
+		od.UpdateOrderFromDetail(d)
+	})
+}
+
+// modify applies a change to a tracked order
+func (o *orderStore) modify(exchange, id string, apply func(*order.Detail)) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+	r, ok := o.Orders[exchange]
+	if !ok {
+		return ErrExchangeNotFound
+	}
+	for x := range r {
+		if r[x].ID != id {
+			continue
+		}
+		status, executed := r[x].Status, r[x].ExecutedAmount
+		apply(r[x])
+		orderChanged(r[x], status, executed)
+		return nil
+	}
+	return ErrOrderNotFound
+}
+
+// orderChanged passes an order to the running strategies and the event bus
+// when its status or executed amount has changed
+func orderChanged(d *order.Detail, status order.Status, executed float64) {
+	if d.Status == status && d.ExecutedAmount == executed {
+		return
+	}
+	Bot.StrategyManager.orderUpdated(d)
+	Bot.EventBus.publishOrder(d)
+}
+
 // Started returns the status of the orderManager
 func (o *orderManager) Started() bool {
 	return atomic.LoadInt32(&o.started) == 1
@@ -138,6 +206,9 @@ func (o *orderManager) Start() error {
 
 	o.shutdown = make(chan struct{})
 	o.orderStore.Orders = make(map[string][]*order.Detail)
+	if Bot.Config.OrderReconcile.Enabled {
+		o.persistPath = filepath.Join(Bot.Settings.DataDir, ordersFileName)
+	}
 	go o.run()
 	return nil
 }
@@ -178,13 +249,24 @@ func (o *orderManager) run() {
 		Bot.ServicesWG.Done()
 	}()
 
+	if o.persistPath != "" {
+		persisted, err := loadOrders(o.persistPath)
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Order manager: Unable to load persisted orders: %s\n", err)
+		} else {
+			o.reconcile(persisted)
+		}
+	}
+
 	for {
 		select {
 		case <-o.shutdown:
 			o.gracefulShutdown()
+			o.persist()
 			return
 		case <-tick.C:
 			o.processOrders()
+			o.persist()
 		}
 	}
 }
@@ -259,17 +341,22 @@ func (o *orderManager) Cancel(cancel *order.Cancel) error {
 		return errors.New("order asset type not supported by exchange")
 	}
 
-	err := exch.CancelOrder(cancel)
-	if err != nil {
+	var err error
+	if Bot.Settings.EnableDryRun {
+		log.Warnf(log.OrderMgr,
+			"Order manager: Dry run enabled, cancel request for %s order ID=%v not sent.\n",
+			cancel.Exchange,
+			cancel.ID)
+	} else if err = exch.CancelOrder(cancel); err != nil {
 		return fmt.Errorf("%v - Failed to cancel order: %v", cancel.Exchange, err)
 	}
-	var od *order.Detail
-	od, err = o.orderStore.GetByExchangeAndID(cancel.Exchange, cancel.ID)
+	err = o.orderStore.modify(cancel.Exchange, cancel.ID, func(od *order.Detail) {
+		od.Status = order.Cancelled
+		od.LastUpdated = clock.Now()
+	})
 	if err != nil {
 		return fmt.Errorf("%v - Failed to retrieve order %v to update cancelled status: %v", cancel.Exchange, cancel.ID, err)
 	}
-
-	od.Status = order.Cancelled
 	return nil
 }
 
@@ -288,37 +375,106 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 		return nil, err
 	}
 
-	if o.cfg.EnforceLimitConfig {
-		if !o.cfg.AllowMarketOrders && newOrder.Type == order.Market {
-			return nil, errors.New("order market type is not allowed")
-		}
+	routed, err := Bot.ExchangeHealthMonitor.Route(newOrder)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(routed, newOrder.Exchange) {
+		log.Warnf(log.OrderMgr,
+			"Order manager: %s is unavailable, routing %s order to %s.\n",
+			newOrder.Exchange,
+			newOrder.Pair,
+			routed)
+		newOrder.Exchange = routed
+	}
 
-		if o.cfg.LimitAmount > 0 && newOrder.Amount > o.cfg.LimitAmount {
-			return nil, errors.New("order limit exceeds allowed limit")
-		}
+	if err = Bot.KeyValidator.CheckTrade(newOrder.Exchange); err != nil {
+		return nil, err
+	}
 
-		if len(o.cfg.AllowedExchanges) > 0 &&
-			!common.StringDataCompareInsensitive(o.cfg.AllowedExchanges, newOrder.Exchange) {
-			return nil, errors.New("order exchange not found in allowed list")
-		}
+	if err = o.checkLimits(newOrder); err != nil {
+		return nil, err
+	}
 
-		if len(o.cfg.AllowedPairs) > 0 && !o.cfg.AllowedPairs.Contains(newOrder.Pair, true) {
-			return nil, errors.New("order pair not found in allowed list")
-		}
+	if err = Bot.RiskManager.Check(newOrder); err != nil {
+		return nil, err
 	}
 
 	exch := GetExchangeByName(newOrder.Exchange)
 	if exch == nil {
 		return nil, ErrExchangeNotFound
 	}
-	result, err := exch.SubmitOrder(newOrder)
-	if err != nil {
+
+	// orders with client order IDs are idempotent, retrying a submission
+	// returns the order already placed instead of placing it again
+	if exch.GetFeatures().Supports.ClientOrderIDs {
+		if newOrder.ClientID == "" {
+			newOrder.ClientID = newClientOrderID()
+		} else if od, err := o.orderStore.GetByExchangeAndClientID(newOrder.Exchange, newOrder.ClientID); err == nil {
+			log.Debugf(log.OrderMgr,
+				"Order manager: Exchange %s order with client ID %v already submitted as order ID=%v.\n",
+				newOrder.Exchange,
+				newOrder.ClientID,
+				od.ID)
+			return &orderSubmitResponse{
+				SubmitResponse: order.SubmitResponse{
+					IsOrderPlaced: true,
+					OrderID:       od.ID,
+					FullyMatched:  od.Status == order.Filled,
+					ClientOrderID: od.ClientID,
+				},
+				InternalOrderID: od.InternalOrderID,
+			}, nil
+		}
+	}
+
+	if newOrder.Type == order.Market {
+		if err := applyMarketOrderProtection(exch, newOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	if !exch.SupportsOrderType(newOrder.Type) {
+		return nil, fmt.Errorf("order type %s is not supported by %s",
+			newOrder.Type,
+			newOrder.Exchange)
+	}
+
+	// orders are rounded to the pair's increments so they are not rejected
+	// for precision violations
+	if limits, ok := o.limits.get(exch, newOrder.Pair, newOrder.AssetType); ok {
+		if err := limits.Conform(newOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := o.preventSelfTrade(newOrder); err != nil {
+		return nil, err
+	}
+
+	var result order.SubmitResponse
+	if Bot.Settings.EnableDryRun {
+		log.Warnf(log.OrderMgr,
+			"Order manager: Dry run enabled, %s order not sent pair=%v price=%v amount=%v side=%v type=%v.\n",
+			newOrder.Exchange,
+			newOrder.Pair,
+			newOrder.Price,
+			newOrder.Amount,
+			newOrder.Side,
+			newOrder.Type)
+		result = order.SubmitResponse{IsOrderPlaced: true, OrderID: dryRunOrderID()}
+	} else if result, err = exch.SubmitOrder(newOrder); err != nil {
 		return nil, err
 	}
 
 	if !result.IsOrderPlaced {
 		return nil, errors.New("order unable to be placed")
 	}
+	// the client order ID sent is stored so the order can be matched to the
+	// exchange's updates and returned so retries use the same ID
+	if result.ClientOrderID != "" {
+		newOrder.ClientID = result.ClientOrderID
+	}
 
 	var id uuid.UUID
 	id, err = uuid.NewV4()
@@ -343,22 +499,26 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 		Message: msg,
 	})
 	status := order.New
+	executed, remaining := newOrder.ExecutedAmount, newOrder.RemainingAmount
 	if result.FullyMatched {
 		status = order.Filled
+		// no later update reports the fill of an order which matched in full
+		executed, remaining = newOrder.Amount, 0
 	}
 	err = o.orderStore.Add(&order.Detail{
 		ImmediateOrCancel: newOrder.ImmediateOrCancel,
 		HiddenOrder:       newOrder.HiddenOrder,
 		FillOrKill:        newOrder.FillOrKill,
 		PostOnly:          newOrder.PostOnly,
+		AuctionOnly:       newOrder.AuctionOnly,
 		Price:             newOrder.Price,
 		Amount:            newOrder.Amount,
 		LimitPriceUpper:   newOrder.LimitPriceUpper,
 		LimitPriceLower:   newOrder.LimitPriceLower,
 		TriggerPrice:      newOrder.TriggerPrice,
 		TargetAmount:      newOrder.TargetAmount,
-		ExecutedAmount:    newOrder.ExecutedAmount,
-		RemainingAmount:   newOrder.RemainingAmount,
+		ExecutedAmount:    executed,
+		RemainingAmount:   remaining,
 		Fee:               newOrder.Fee,
 		Exchange:          newOrder.Exchange,
 		InternalOrderID:   id.String(),
@@ -370,9 +530,10 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 		Side:              newOrder.Side,
 		Status:            status,
 		AssetType:         newOrder.AssetType,
-		Date:              time.Now(),
-		LastUpdated:       time.Now(),
+		Date:              clock.Now(),
+		LastUpdated:       clock.Now(),
 		Pair:              newOrder.Pair,
+		Session:           newOrder.Session,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to add %v order %v to orderStore: %s", newOrder.Exchange, result.OrderID, err)
@@ -380,12 +541,300 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 
 	return &orderSubmitResponse{
 		SubmitResponse: order.SubmitResponse{
-			OrderID: result.OrderID,
+			OrderID:       result.OrderID,
+			FullyMatched:  result.FullyMatched,
+			ClientOrderID: newOrder.ClientID,
 		},
 		InternalOrderID: id.String(),
 	}, nil
 }
 
+// dryRunOrderID returns a unique ID for orders which are not sent to the
+// exchange in dry run mode
+func dryRunOrderID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Sprintf("dryrun-%d", clock.Now().UnixNano())
+	}
+	return "dryrun-" + id.String()
+}
+
+// newClientOrderID returns a unique client order ID of 32 alphanumeric
+// characters beginning with a letter, which is accepted by every exchange
+// supporting client order IDs
+func newClientOrderID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Sprintf("gct%029d", clock.Now().UnixNano())
+	}
+	return "gct" + strings.Replace(id.String(), "-", "", -1)[:29]
+}
+
+// SubmitOCO validates a one-cancels-other order pair, sends it to an exchange
+// which supports OCO orders natively and populates both orders in the
+// orderManager if successful
+func (o *orderManager) SubmitOCO(oco *order.OCO) (*ocoSubmitResponse, error) {
+	if oco == nil {
+		return nil, errors.New("oco order cannot be nil")
+	}
+
+	if oco.Limit.Exchange == "" {
+		return nil, errors.New("order exchange name must be specified")
+	}
+
+	if err := oco.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := Bot.KeyValidator.CheckTrade(oco.Limit.Exchange); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkLimits(&oco.Limit); err != nil {
+		return nil, err
+	}
+
+	if err := Bot.RiskManager.Check(&oco.Limit); err != nil {
+		return nil, err
+	}
+
+	if err := Bot.ExchangeHealthMonitor.Allowed(oco.Limit.Exchange); err != nil {
+		return nil, err
+	}
+
+	exch := GetExchangeByName(oco.Limit.Exchange)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+
+	submitter, ok := exch.(exchange.OCOSubmitter)
+	if !ok {
+		return nil, ErrOCONotSupported
+	}
+
+	if err := o.preventSelfTrade(&oco.Limit); err != nil {
+		return nil, err
+	}
+
+	var result order.OCOResponse
+	var err error
+	if Bot.Settings.EnableDryRun {
+		log.Warnf(log.OrderMgr,
+			"Order manager: Dry run enabled, %s OCO order not sent pair=%v side=%v amount=%v price=%v stop price=%v stop limit price=%v.\n",
+			oco.Limit.Exchange,
+			oco.Limit.Pair,
+			oco.Limit.Side,
+			oco.Limit.Amount,
+			oco.Limit.Price,
+			oco.StopPrice,
+			oco.StopLimitPrice)
+		result = order.OCOResponse{
+			ListID:       dryRunOrderID(),
+			LimitOrderID: dryRunOrderID(),
+			StopOrderID:  dryRunOrderID(),
+		}
+	} else if result, err = submitter.SubmitOCOOrder(oco); err != nil {
+		return nil, err
+	}
+
+	stop := oco.Limit
+	stop.Type = order.Stop
+	stop.Price = oco.StopLimitPrice
+	stop.TriggerPrice = oco.StopPrice
+
+	resp := ocoSubmitResponse{OCOResponse: result}
+	resp.LimitInternalOrderID, err = o.add(&oco.Limit, result.LimitOrderID)
+	if err != nil {
+		return nil, err
+	}
+	resp.StopInternalOrderID, err = o.add(&stop, result.StopOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("Order manager: Exchange %s submitted OCO list ID=%v pair=%v side=%v amount=%v limit ID=%v price=%v stop ID=%v stop price=%v stop limit price=%v.",
+		oco.Limit.Exchange,
+		result.ListID,
+		oco.Limit.Pair,
+		oco.Limit.Side,
+		oco.Limit.Amount,
+		result.LimitOrderID,
+		oco.Limit.Price,
+		result.StopOrderID,
+		oco.StopPrice,
+		oco.StopLimitPrice)
+	log.Debugln(log.OrderMgr, msg)
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "order",
+		Message: msg,
+	})
+	return &resp, nil
+}
+
+// checkLimits enforces the configured order limits
+func (o *orderManager) checkLimits(newOrder *order.Submit) error {
+	if !o.cfg.EnforceLimitConfig {
+		return nil
+	}
+
+	if !o.cfg.AllowMarketOrders && newOrder.Type == order.Market {
+		return errors.New("order market type is not allowed")
+	}
+
+	if o.cfg.LimitAmount > 0 && newOrder.Amount > o.cfg.LimitAmount {
+		return errors.New("order limit exceeds allowed limit")
+	}
+
+	if len(o.cfg.AllowedExchanges) > 0 &&
+		!common.StringDataCompareInsensitive(o.cfg.AllowedExchanges, newOrder.Exchange) {
+		return errors.New("order exchange not found in allowed list")
+	}
+
+	if len(o.cfg.AllowedPairs) > 0 && !o.cfg.AllowedPairs.Contains(newOrder.Pair, true) {
+		return errors.New("order pair not found in allowed list")
+	}
+	return nil
+}
+
+// add populates an order placed on the exchange in the orderStore and returns
+// its internal order ID
+func (o *orderManager) add(s *order.Submit, orderID string) (string, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		log.Warnf(log.OrderMgr,
+			"Order manager: Unable to generate UUID. Err: %s",
+			err)
+	}
+	err = o.orderStore.Add(&order.Detail{
+		Price:           s.Price,
+		Amount:          s.Amount,
+		TriggerPrice:    s.TriggerPrice,
+		Exchange:        s.Exchange,
+		InternalOrderID: id.String(),
+		ID:              orderID,
+		AccountID:       s.AccountID,
+		ClientID:        s.ClientID,
+		Type:            s.Type,
+		Side:            s.Side,
+		Status:          order.New,
+		AssetType:       s.AssetType,
+		Date:            clock.Now(),
+		LastUpdated:     clock.Now(),
+		Pair:            s.Pair,
+		Session:         s.Session,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to add %v order %v to orderStore: %s", s.Exchange, orderID, err)
+	}
+	return id.String(), nil
+}
+
+// preventSelfTrade checks whether the order would trade against an open order
+// previously placed by this bot on the same exchange, which may belong to a
+// different strategy. The order's self trade prevention mode determines
+// whether the new order is rejected, the resting orders are cancelled or both.
+// Rejecting the new order is the default
+func (o *orderManager) preventSelfTrade(newOrder *order.Submit) error {
+	open, err := o.orderStore.GetByExchange(newOrder.Exchange)
+	if err != nil {
+		return nil
+	}
+
+	var crossing []*order.Detail
+	for x := range open {
+		if newOrder.Crosses(open[x]) {
+			crossing = append(crossing, open[x])
+		}
+	}
+	if len(crossing) == 0 {
+		return nil
+	}
+
+	mode := newOrder.SelfTradePrevention
+	if mode == "" {
+		mode = order.CancelNewest
+	}
+
+	log.Warnf(log.OrderMgr,
+		"Order manager: %s %s %s order would trade against %d open order(s), applying %s self trade prevention.\n",
+		newOrder.Exchange,
+		newOrder.Pair,
+		newOrder.Side,
+		len(crossing),
+		mode)
+
+	if mode == order.CancelNewest {
+		return ErrSelfTradePrevented
+	}
+
+	for x := range crossing {
+		err = o.Cancel(&order.Cancel{
+			Exchange:  crossing[x].Exchange,
+			ID:        crossing[x].ID,
+			AccountID: crossing[x].AccountID,
+			ClientID:  crossing[x].ClientID,
+			Type:      crossing[x].Type,
+			Side:      crossing[x].Side,
+			Pair:      crossing[x].Pair,
+			AssetType: crossing[x].AssetType,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if mode == order.CancelBoth {
+		return ErrSelfTradePrevented
+	}
+	return nil
+}
+
+// applyMarketOrderProtection converts a market order into an immediate or
+// cancel limit order priced at the exchange's configured protection percentage
+// from the last traded price, so a thin order book cannot fill the order at an
+// unreasonable price
+func applyMarketOrderProtection(exch exchange.IBotExchange, newOrder *order.Submit) error {
+	exchCfg, err := Bot.Config.GetExchangeConfig(newOrder.Exchange)
+	if err != nil || exchCfg.MarketOrderProtection <= 0 {
+		return nil
+	}
+
+	assetType := newOrder.AssetType
+	if assetType == "" {
+		assetType = asset.Spot
+	}
+	tick, err := exch.FetchTicker(newOrder.Pair, assetType)
+	if err != nil {
+		return fmt.Errorf("unable to determine market order protection price: %v", err)
+	}
+	if tick == nil {
+		return errors.New("unable to determine market order protection price: no ticker available")
+	}
+	reference := tick.Last
+	if reference <= 0 && tick.Bid > 0 && tick.Ask > 0 {
+		reference = (tick.Bid + tick.Ask) / 2
+	}
+	if reference <= 0 {
+		return errors.New("unable to determine market order protection price: no reference price")
+	}
+
+	price := reference * (1 + exchCfg.MarketOrderProtection/100)
+	if newOrder.Side == order.Sell || newOrder.Side == order.Ask {
+		price = reference * (1 - exchCfg.MarketOrderProtection/100)
+	}
+
+	log.Debugf(log.OrderMgr,
+		"Order manager: %s %s market order converted to immediate or cancel limit order at protection price %v.\n",
+		newOrder.Exchange,
+		newOrder.Pair,
+		price)
+	newOrder.Type = order.Limit
+	newOrder.Price = price
+	// fill or kill orders are already executed immediately or cancelled
+	newOrder.ImmediateOrCancel = !newOrder.FillOrKill
+	return nil
+}
+
 func (o *orderManager) processOrders() {
 	authExchanges := GetAuthAPISupportedExchanges()
 	for x := range authExchanges {
@@ -401,19 +850,96 @@ func (o *orderManager) processOrders() {
 			continue
 		}
 
+		var added []*order.Detail
+		active := make(map[string]bool, len(result))
 		for x := range result {
 			ord := &result[x]
-			result := o.orderStore.Add(ord)
-			if result != ErrOrdersAlreadyExists {
-				msg := fmt.Sprintf("Order manager: Exchange %s added order ID=%v pair=%v price=%v amount=%v side=%v type=%v.",
-					ord.Exchange, ord.ID, ord.Pair, ord.Price, ord.Amount, ord.Side, ord.Type)
-				log.Debugf(log.OrderMgr, "%v", msg)
-				Bot.CommsManager.PushEvent(base.Event{
-					Type:    "order",
-					Message: msg,
-				})
+			active[ord.ID] = true
+			if err = o.orderStore.Add(ord); err == ErrOrdersAlreadyExists {
+				// refreshes the fills and status of orders already tracked
+				if err = o.orderStore.Update(ord); err != nil {
+					log.Warnf(log.OrderMgr, "Order manager: Unable to update %s order %s: %s\n", ord.Exchange, ord.ID, err)
+				}
 				continue
 			}
+			added = append(added, ord)
+			msg := fmt.Sprintf("Order manager: Exchange %s added order ID=%v pair=%v price=%v amount=%v side=%v type=%v.",
+				ord.Exchange, ord.ID, ord.Pair, ord.Price, ord.Amount, ord.Side, ord.Type)
+			log.Debugf(log.OrderMgr, "%v", msg)
+			Bot.CommsManager.PushEvent(base.Event{
+				Type:    "order",
+				Message: msg,
+			})
+		}
+		o.updateClosedOrders(exch, active)
+		Bot.KeyMonitor.CheckOrders(authExchanges[x], added)
+	}
+}
+
+// updateClosedOrders refreshes the tracked open orders which are no longer
+// among an exchange's active orders from its order history, so that their
+// final fills and status are tracked
+func (o *orderManager) updateClosedOrders(exch exchange.IBotExchange, active map[string]bool) {
+	if Bot.Settings.EnableDryRun {
+		// dry run orders are never sent so are not in the order history
+		return
+	}
+	tracked, err := o.orderStore.GetByExchange(exch.GetName())
+	if err != nil {
+		return
+	}
+	closed := make(map[string]bool)
+	var pairs currency.Pairs
+	req := order.GetOrdersRequest{
+		Type:     order.AnyType,
+		Side:     order.AnySide,
+		EndTicks: clock.Now(),
+	}
+	o.orderStore.m.RLock()
+	for x := range tracked {
+		if orderDone(tracked[x].Status) || active[tracked[x].ID] {
+			continue
+		}
+		closed[tracked[x].ID] = true
+		if req.StartTicks.IsZero() || tracked[x].Date.Before(req.StartTicks) {
+			req.StartTicks = tracked[x].Date
+		}
+		if !pairs.Contains(tracked[x].Pair, true) {
+			pairs = pairs.Add(tracked[x].Pair)
 		}
 	}
+	o.orderStore.m.RUnlock()
+	if len(closed) == 0 {
+		return
+	}
+	req.Pairs = pairs
+	history, err := exch.GetOrderHistory(&req)
+	if err != nil {
+		log.Debugf(log.OrderMgr, "Order manager: Unable to get %s order history: %s\n", exch.GetName(), err)
+		return
+	}
+	for x := range history {
+		if !closed[history[x].ID] {
+			continue
+		}
+		d := &history[x]
+		err = o.orderStore.modify(exch.GetName(), d.ID, func(od *order.Detail) {
+			od.UpdateOrderFromDetail(d)
+		})
+		if err != nil {
+			log.Warnf(log.OrderMgr, "Order manager: Unable to update %s order %s: %s\n", exch.GetName(), d.ID, err)
+		}
+	}
+}
+
+// orderDone returns whether an order has finished and can no longer be
+// filled
+func orderDone(s order.Status) bool {
+	switch s {
+	case order.Filled, order.Cancelled, order.PartiallyCancelled,
+		order.Rejected, order.InsufficientBalance, order.MarketUnavailable,
+		order.Expired:
+		return true
+	}
+	return false
 }
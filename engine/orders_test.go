@@ -1,12 +1,15 @@
 package engine
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
 var ordersSetupRan bool
@@ -373,7 +376,312 @@ func TestSubmit(t *testing.T) {
 	}
 }
 
+func TestOrdersUpdate(t *testing.T) {
+	OrdersSetup(t)
+	Bot.Settings.EnableDryRun = true
+	p := currency.NewPair(currency.BTC, currency.USD)
+	r := newStrategyRunner(config.StrategyConfig{
+		Name:      "TestOrdersUpdate",
+		Exchange:  testExchange,
+		Pair:      p,
+		AssetType: asset.Spot,
+	}, new(testStrategy))
+	Bot.StrategyManager.started = 1
+	Bot.StrategyManager.runners = map[string]*strategyRunner{"test": r}
+	defer func() {
+		Bot.Settings.EnableDryRun = false
+		Bot.StrategyManager.started = 0
+		Bot.StrategyManager.runners = nil
+	}()
+
+	err := Bot.OrderManager.orderStore.Add(&order.Detail{
+		Exchange:  testExchange,
+		ID:        "TestOrdersUpdate",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Amount:    1,
+		Status:    order.New,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-r.orders
+
+	update := &order.Detail{
+		Exchange:       testExchange,
+		ID:             "TestOrdersUpdate",
+		Pair:           p,
+		AssetType:      asset.Spot,
+		Amount:         1,
+		ExecutedAmount: 0.5,
+		Status:         order.PartiallyFilled,
+	}
+	if err = Bot.OrderManager.orderStore.Update(update); err != nil {
+		t.Fatal(err)
+	}
+	if err = Bot.OrderManager.orderStore.Update(update); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.orders) != 1 {
+		t.Fatalf("expected 1 order update for the fill, got %d", len(r.orders))
+	}
+	if d := <-r.orders; d.ExecutedAmount != 0.5 || d.Status != order.PartiallyFilled {
+		t.Errorf("unexpected order update %+v", d)
+	}
+
+	err = Bot.OrderManager.Cancel(&order.Cancel{
+		Exchange: testExchange,
+		ID:       "TestOrdersUpdate",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.orders) != 1 {
+		t.Fatalf("expected 1 order update for the cancel, got %d", len(r.orders))
+	}
+	if d := <-r.orders; d.Status != order.Cancelled {
+		t.Errorf("expected %s, got %s", order.Cancelled, d.Status)
+	}
+
+	update.ID = "missing"
+	if err = Bot.OrderManager.orderStore.Update(update); err != ErrOrderNotFound {
+		t.Errorf("expected %v, got %v", ErrOrderNotFound, err)
+	}
+}
+
 func TestProcessOrders(t *testing.T) {
 	OrdersSetup(t)
+	// the order has filled and is no longer among the exchange's active orders
+	closed := &order.Detail{
+		Exchange: fakePassExchange,
+		ID:       "fakeClosedOrder",
+		Amount:   1,
+		Status:   order.New,
+		Date:     time.Now(),
+		Pair:     currency.NewPairFromString("BTCUSD"),
+	}
+	if err := Bot.OrderManager.orderStore.Add(closed); err != nil {
+		t.Fatal(err)
+	}
+	defer delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
 	Bot.OrderManager.processOrders()
+	if closed.Status != order.Filled || closed.ExecutedAmount != 1 {
+		t.Errorf("expected the closed order to be updated from the order history, got %+v", closed)
+	}
+}
+
+func TestApplyMarketOrderProtection(t *testing.T) {
+	OrdersSetup(t)
+	exchCfg, err := Bot.Config.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { exchCfg.MarketOrderProtection = 0 }()
+	exch := GetExchangeByName(testExchange)
+	p := currency.NewPairFromStrings("LTC", "EUR")
+	o := &order.Submit{
+		Exchange:  testExchange,
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    1,
+	}
+
+	err = applyMarketOrderProtection(exch, o)
+	if err != nil || o.Type != order.Market {
+		t.Fatalf("expected order to be unchanged without protection, got %v %v", o.Type, err)
+	}
+
+	exchCfg.MarketOrderProtection = 5
+	err = ticker.ProcessTicker(testExchange, &ticker.Price{Pair: p, Last: 100}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = applyMarketOrderProtection(exch, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Type != order.Limit || !o.ImmediateOrCancel || o.Price != 105 {
+		t.Errorf("expected immediate or cancel buy limit at 105, got %+v", o)
+	}
+
+	o.Type = order.Market
+	o.Side = order.Sell
+	err = applyMarketOrderProtection(exch, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Price != 95 {
+		t.Errorf("expected sell limit at 95, got %v", o.Price)
+	}
+}
+
+func TestPreventSelfTrade(t *testing.T) {
+	OrdersSetup(t)
+	p := currency.NewPair(currency.BTC, currency.DOGE)
+	resting := &order.Detail{
+		Exchange:  fakePassExchange,
+		ID:        "TestPreventSelfTrade",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Sell,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    1,
+		Status:    order.Active,
+	}
+	err := Bot.OrderManager.orderStore.Add(resting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		Bot.OrderManager.orderStore.m.Lock()
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+		Bot.OrderManager.orderStore.m.Unlock()
+	}()
+
+	o := &order.Submit{
+		Exchange:  fakePassExchange,
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     99,
+		Amount:    1,
+	}
+	if err = Bot.OrderManager.preventSelfTrade(o); err != nil {
+		t.Errorf("expected non crossing order to be allowed, got %v", err)
+	}
+
+	o.Price = 101
+	if err = Bot.OrderManager.preventSelfTrade(o); err != ErrSelfTradePrevented {
+		t.Errorf("expected %v, got %v", ErrSelfTradePrevented, err)
+	}
+	if resting.Status != order.Active {
+		t.Error("expected resting order to be left open")
+	}
+
+	o.SelfTradePrevention = order.CancelOldest
+	if err = Bot.OrderManager.preventSelfTrade(o); err != nil {
+		t.Error(err)
+	}
+	if resting.Status != order.Cancelled {
+		t.Error("expected resting order to be cancelled")
+	}
+
+	if err = Bot.OrderManager.preventSelfTrade(o); err != nil {
+		t.Errorf("expected cancelled order to no longer cross, got %v", err)
+	}
+}
+
+func TestSubmitUnsupportedOrderType(t *testing.T) {
+	OrdersSetup(t)
+	b := GetExchangeByName(testExchange).GetBase()
+	orderTypes := b.Features.Supports.OrderTypes
+	b.Features.Supports.OrderTypes = []order.Type{order.Market}
+	defer func() { b.Features.Supports.OrderTypes = orderTypes }()
+
+	_, err := Bot.OrderManager.Submit(&order.Submit{
+		Exchange:  testExchange,
+		Pair:      currency.NewPairFromString("BTCUSD"),
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     100,
+	})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expected unsupported order type error, got %v", err)
+	}
+}
+
+func TestSubmitDryRun(t *testing.T) {
+	OrdersSetup(t)
+	Bot.Settings.EnableDryRun = true
+	defer func() { Bot.Settings.EnableDryRun = false }()
+
+	// the test exchange has no credentials so any request sent would fail
+	resp, err := Bot.OrderManager.Submit(&order.Submit{
+		Exchange:  testExchange,
+		Pair:      currency.NewPairFromString("BTCUSD"),
+		AssetType: asset.Spot,
+		Side:      order.Sell,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     1000000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(resp.OrderID, "dryrun-") {
+		t.Errorf("expected a dry run order ID, got %s", resp.OrderID)
+	}
+
+	err = Bot.OrderManager.Cancel(&order.Cancel{
+		Exchange: testExchange,
+		ID:       resp.OrderID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := Bot.OrderManager.orderStore.GetByExchangeAndID(testExchange, resp.OrderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != order.Cancelled {
+		t.Errorf("expected %s, got %s", order.Cancelled, o.Status)
+	}
+}
+
+func TestSubmitClientOrderID(t *testing.T) {
+	OrdersSetup(t)
+	Bot.Settings.EnableDryRun = true
+	defer func() { Bot.Settings.EnableDryRun = false }()
+	b := GetExchangeByName(testExchange).GetBase()
+	b.Features.Supports.ClientOrderIDs = true
+	defer func() { b.Features.Supports.ClientOrderIDs = false }()
+
+	s := &order.Submit{
+		Exchange:  testExchange,
+		Pair:      currency.NewPairFromString("BTCUSD"),
+		AssetType: asset.Spot,
+		Side:      order.Sell,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     1000000,
+	}
+	resp, err := Bot.OrderManager.Submit(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.ClientID) != 32 || !strings.HasPrefix(s.ClientID, "gct") {
+		t.Errorf("expected a generated client order ID, got %s", s.ClientID)
+	}
+	o, err := Bot.OrderManager.orderStore.GetByExchangeAndClientID(testExchange, s.ClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.ID != resp.OrderID || resp.ClientOrderID != s.ClientID {
+		t.Errorf("expected order ID %s with client ID %s, got %s with %s",
+			o.ID,
+			s.ClientID,
+			resp.OrderID,
+			resp.ClientOrderID)
+	}
+
+	// retrying the submission returns the order already placed
+	retry, err := Bot.OrderManager.Submit(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry.OrderID != resp.OrderID ||
+		retry.InternalOrderID != resp.InternalOrderID ||
+		retry.ClientOrderID != resp.ClientOrderID {
+		t.Errorf("expected order %s to be returned, got %s", resp.OrderID, retry.OrderID)
+	}
+
+	if newClientOrderID() == newClientOrderID() {
+		t.Error("expected unique client order IDs")
+	}
 }
@@ -28,9 +28,19 @@ type orderManager struct {
 	shutdown   chan struct{}
 	orderStore orderStore
 	cfg        orderManagerConfig
+	limits     orderLimitStore
+	// persistPath is the file orders are persisted to so they can be
+	// reconciled on startup, empty when reconciliation is disabled
+	persistPath string
 }
 
 type orderSubmitResponse struct {
 	order.SubmitResponse
 	InternalOrderID string
 }
+
+type ocoSubmitResponse struct {
+	order.OCOResponse
+	LimitInternalOrderID string
+	StopInternalOrderID  string
+}
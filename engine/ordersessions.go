@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errOrderSessionUnset = errors.New("order session must be specified")
+
+// sessionOrders returns the open orders placed by a local session, such as
+// a strategy instance, keyed by exchange. shared holds the exchanges which
+// also have open orders placed outside of the session
+func (o *orderManager) sessionOrders(session string) (orders map[string][]*order.Detail, shared map[string]bool) {
+	orders = make(map[string][]*order.Detail)
+	shared = make(map[string]bool)
+	o.orderStore.m.RLock()
+	defer o.orderStore.m.RUnlock()
+	for exch, v := range o.orderStore.Orders {
+		for x := range v {
+			if orderDone(v[x].Status) {
+				continue
+			}
+			if v[x].Session != session {
+				shared[exch] = true
+				continue
+			}
+			orders[exch] = append(orders[exch], v[x])
+		}
+	}
+	return orders, shared
+}
+
+// CancelSessionOrders cancels the open orders placed by a local session. When
+// every open order on an exchange belongs to the session and the exchange can
+// cancel the orders of its API key's session, they are cancelled with a
+// single request. Otherwise, or when that request fails, each order is
+// cancelled individually so that orders placed outside of the session are
+// left open
+func (o *orderManager) CancelSessionOrders(session string) error {
+	if session == "" {
+		return errOrderSessionUnset
+	}
+	orders, shared := o.sessionOrders(session)
+	var failed int
+	for exchName, v := range orders {
+		exch := GetExchangeByName(exchName)
+		if exch == nil {
+			log.Errorf(log.OrderMgr, "Order manager: Unable to cancel session %s orders: %s %v\n", session, exchName, ErrExchangeNotFound)
+			failed += len(v)
+			continue
+		}
+		if c, ok := exch.(exchange.SessionOrderCanceller); ok && !shared[exchName] {
+			err := o.cancelExchangeSession(c, v)
+			if err == nil {
+				log.Infof(log.OrderMgr, "Order manager: Session %s orders on %s cancelled.\n", session, exchName)
+				continue
+			}
+			log.Warnf(log.OrderMgr, "Order manager: Unable to cancel the %s session, cancelling session %s orders individually: %s\n", exchName, session, err)
+		}
+		for x := range v {
+			if orderDone(v[x].Status) {
+				continue
+			}
+			err := o.Cancel(&order.Cancel{
+				Exchange:      exchName,
+				ID:            v[x].ID,
+				AccountID:     v[x].AccountID,
+				ClientID:      v[x].ClientID,
+				WalletAddress: v[x].WalletAddress,
+				Type:          v[x].Type,
+				Side:          v[x].Side,
+				Pair:          v[x].Pair,
+				AssetType:     v[x].AssetType,
+			})
+			if err != nil {
+				log.Error(log.OrderMgr, err)
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("unable to cancel %d session %s orders", failed, session)
+	}
+	return nil
+}
+
+// cancelExchangeSession cancels the orders placed by an exchange's API key
+// session and marks the stored orders cancelled, failing when the exchange
+// rejected cancelling any of them
+func (o *orderManager) cancelExchangeSession(c exchange.SessionOrderCanceller, orders []*order.Detail) error {
+	var rejected map[string]string
+	if Bot.Settings.EnableDryRun {
+		log.Warnf(log.OrderMgr, "Order manager: Dry run enabled, session cancel request for %s not sent.\n", orders[0].Exchange)
+	} else {
+		resp, err := c.CancelSessionOrders()
+		if err != nil {
+			return err
+		}
+		rejected = resp.Status
+	}
+	o.orderStore.m.Lock()
+	defer o.orderStore.m.Unlock()
+	var rejects int
+	for x := range orders {
+		if _, ok := rejected[orders[x].ID]; ok {
+			rejects++
+			continue
+		}
+		status := orders[x].Status
+		orders[x].Status = order.Cancelled
+		orderChanged(orders[x], status, orders[x].ExecutedAmount)
+	}
+	if rejects > 0 {
+		return fmt.Errorf("%d orders could not be cancelled", rejects)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const sessionExchange = "SessionExchange"
+
+// fakeSessionExchange cancels the orders of its API key session
+type fakeSessionExchange struct {
+	FakePassingExchange
+	sessionCancels int
+	rejects        map[string]string
+	err            error
+}
+
+func (f *fakeSessionExchange) GetName() string { return sessionExchange }
+
+func (f *fakeSessionExchange) CancelSessionOrders() (order.CancelAllResponse, error) {
+	f.sessionCancels++
+	return order.CancelAllResponse{Status: f.rejects}, f.err
+}
+
+func TestCancelSessionOrders(t *testing.T) {
+	SetupTestHelpers(t)
+	f := &fakeSessionExchange{}
+	Bot.exchangeManager.add(f)
+	defer func() {
+		if err := Bot.exchangeManager.removeExchange(sessionExchange); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var o orderManager
+	o.orderStore.Orders = map[string][]*order.Detail{
+		sessionExchange: {
+			{ID: "1", Exchange: sessionExchange, Status: order.Active, Session: "grid"},
+			{ID: "2", Exchange: sessionExchange, Status: order.PartiallyFilled, Session: "grid"},
+			{ID: "3", Exchange: sessionExchange, Status: order.Filled, Session: "dca"},
+		},
+		fakePassExchange: {
+			{ID: "4", Exchange: fakePassExchange, Status: order.Active, Session: "grid"},
+			{ID: "5", Exchange: fakePassExchange, Status: order.Active},
+		},
+	}
+	if err := o.CancelSessionOrders(""); err != errOrderSessionUnset {
+		t.Errorf("expected %v, got %v", errOrderSessionUnset, err)
+	}
+	if err := o.CancelSessionOrders("grid"); err != nil {
+		t.Fatal(err)
+	}
+	if f.sessionCancels != 1 {
+		t.Errorf("expected the exchange session to be cancelled once, got %d", f.sessionCancels)
+	}
+	for _, d := range []*order.Detail{
+		o.orderStore.Orders[sessionExchange][0],
+		o.orderStore.Orders[sessionExchange][1],
+		o.orderStore.Orders[fakePassExchange][0],
+	} {
+		if d.Status != order.Cancelled {
+			t.Errorf("expected session order %s to be cancelled, got %s", d.ID, d.Status)
+		}
+	}
+	if o.orderStore.Orders[sessionExchange][2].Status != order.Filled ||
+		o.orderStore.Orders[fakePassExchange][1].Status != order.Active {
+		t.Error("expected orders outside of the session to be left as is")
+	}
+
+	// the exchange session is not cancelled while it holds other sessions'
+	// orders, and rejected session cancels fall back to individual cancels
+	o.orderStore.Orders[sessionExchange] = []*order.Detail{
+		{ID: "6", Exchange: sessionExchange, Status: order.Active, Session: "grid"},
+		{ID: "7", Exchange: sessionExchange, Status: order.Active, Session: "dca"},
+	}
+	if err := o.CancelSessionOrders("grid"); err != nil {
+		t.Fatal(err)
+	}
+	if f.sessionCancels != 1 || o.orderStore.Orders[sessionExchange][0].Status != order.Cancelled ||
+		o.orderStore.Orders[sessionExchange][1].Status != order.Active {
+		t.Errorf("expected only the session's order to be cancelled individually, got %d session cancels", f.sessionCancels)
+	}
+
+	o.orderStore.Orders[sessionExchange] = []*order.Detail{
+		{ID: "8", Exchange: sessionExchange, Status: order.Active, Session: "dca"},
+		{ID: "9", Exchange: sessionExchange, Status: order.Active, Session: "dca"},
+	}
+	f.rejects = map[string]string{"9": "Could not cancel order"}
+	if err := o.CancelSessionOrders("dca"); err != nil {
+		t.Fatal(err)
+	}
+	if f.sessionCancels != 2 || o.orderStore.Orders[sessionExchange][1].Status != order.Cancelled {
+		t.Error("expected the rejected order to be cancelled individually")
+	}
+
+	o.orderStore.Orders[sessionExchange] = []*order.Detail{
+		{ID: "10", Exchange: sessionExchange, Status: order.Active, Session: "dca"},
+	}
+	f.err = errors.New("session cancel failed")
+	if err := o.CancelSessionOrders("dca"); err != nil {
+		t.Fatal(err)
+	}
+	if o.orderStore.Orders[sessionExchange][0].Status != order.Cancelled {
+		t.Error("expected the order to be cancelled individually")
+	}
+}
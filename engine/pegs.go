@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// pegValuation returns whether pegged currencies are valued at their anchor
+func pegValuation() bool {
+	return Bot.Config != nil && Bot.Config.Currency.Pegs.Valuation
+}
+
+// pegArbitrage returns whether pegged currencies are treated as their anchor
+// when scanning for arbitrage
+func pegArbitrage() bool {
+	return Bot.Config != nil && Bot.Config.Currency.Pegs.Arbitrage
+}
+
+// peggedAnchor returns the anchor a currency is treated as, a member is no
+// longer treated as its anchor when its market price has moved outside of the
+// group's tolerance
+func peggedAnchor(c currency.Code) (currency.Code, bool) {
+	g, ok := currency.GetPegGroup(c)
+	if !ok {
+		return c, false
+	}
+	if g.Anchor.Match(c) {
+		return c, true
+	}
+	if price, ok := lastPrice(c, g.Anchor); ok && !g.InTolerance(price) {
+		return c, false
+	}
+	return g.Anchor, true
+}
+
+// normalisePegged returns the anchor of a pegged currency or the currency
+func normalisePegged(c currency.Code) currency.Code {
+	anchor, _ := peggedAnchor(c)
+	return anchor
+}
+
+// pegEquivalents returns a currency followed by the currencies of its peg
+// group it is treated as equivalent to
+func pegEquivalents(c currency.Code) []currency.Code {
+	anchor, ok := peggedAnchor(c)
+	if !ok {
+		return []currency.Code{c}
+	}
+	g, _ := currency.GetPegGroup(c)
+	equivalents := []currency.Code{c}
+	for _, m := range append([]currency.Code{g.Anchor}, g.Members...) {
+		if m.Match(c) {
+			continue
+		}
+		if a, ok := peggedAnchor(m); ok && a.Match(anchor) {
+			equivalents = append(equivalents, m)
+		}
+	}
+	return equivalents
+}
+
+// peggedRate returns the market rate of one unit of from in to through the
+// currencies they are pegged to
+func peggedRate(from, to currency.Code) (float64, bool) {
+	fromEquivalents := pegEquivalents(from)
+	toEquivalents := pegEquivalents(to)
+	if len(fromEquivalents) == 1 && len(toEquivalents) == 1 {
+		return 0, false
+	}
+	for x := range fromEquivalents {
+		for y := range toEquivalents {
+			if rate, ok := marketRate(fromEquivalents[x], toEquivalents[y]); ok {
+				return rate, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func TestPeggedValuation(t *testing.T) {
+	SetupTestHelpers(t)
+	for _, p := range []ticker.Price{
+		{Pair: currency.NewPair(currency.XRP, currency.USDC), Last: 0.5},
+		{Pair: currency.NewPair(currency.NEO, currency.TUSD), Last: 10},
+	} {
+		p := p
+		if err := ticker.ProcessTicker(testExchange, &p, asset.Spot); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := convertValue(10, currency.XRP, currency.USD); ok {
+		t.Error("expected XRP not to be valued in USD without peg valuation")
+	}
+
+	currency.SetPegGroups(currency.DefaultPegGroups())
+	defer currency.SetPegGroups(nil)
+	Bot.Config.Currency.Pegs.Valuation = true
+	defer func() { Bot.Config.Currency.Pegs.Valuation = false }()
+
+	if v, ok := convertValue(10, currency.XRP, currency.USD); !ok || v != 5 {
+		t.Errorf("expected XRP to be valued in USD through USDC, got %v %v", v, ok)
+	}
+	if v, ok := convertValue(10, currency.USDT, currency.XRP); !ok || v != 20 {
+		t.Errorf("expected USDT to be valued in XRP through USDC, got %v %v", v, ok)
+	}
+	if v, ok := convertValue(2, currency.NEO, currency.USD); !ok || v != 20 {
+		t.Errorf("expected NEO to be valued in USD through TUSD, got %v %v", v, ok)
+	}
+
+	// a depegged member is valued at its market price
+	err := ticker.ProcessTicker(testExchange,
+		&ticker.Price{Pair: currency.NewPair(currency.TUSD, currency.USD), Last: 0.9},
+		asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, ok := peggedAnchor(currency.TUSD); ok || a != currency.TUSD {
+		t.Error("expected TUSD to be depegged")
+	}
+	if _, ok := convertValue(2, currency.NEO, currency.USD); ok {
+		t.Error("expected NEO not to be valued in USD through a depegged currency")
+	}
+}
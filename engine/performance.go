@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/equity"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+)
+
+// StrategyPerformance returns the performance between start and end of the
+// named strategy, or of each strategy allocated fills when the name is empty.
+// Trades are the strategy's allocated positions which have been closed or
+// reversed and its equity curve is read from the equity snapshots valued in
+// the currency, which are only available when the database is enabled.
+// Returns are measured on the capital plus the strategy's equity
+func StrategyPerformance(strategy string, c currency.Code, start, end time.Time, capital float64) ([]performance.Report, error) {
+	names := []string{strategy}
+	if strategy == "" {
+		names = Bot.AllocationManager.Strategies()
+	}
+	positions := Bot.AllocationManager.GetPositions()
+
+	reports := make([]performance.Report, 0, len(names))
+	for i := range names {
+		snapshots, err := equity.GetSnapshots(equityStrategyPrefix+names[i], c.Upper().String(), start, end)
+		if err != nil && err != database.ErrDatabaseSupportDisabled {
+			return nil, err
+		}
+		in := performance.Input{
+			Strategy: names[i],
+			Currency: c.Upper().String(),
+			Start:    start,
+			End:      end,
+			Capital:  capital,
+			Equity:   make([]performance.EquityPoint, len(snapshots)),
+			Trades:   Bot.AllocationManager.GetTrades(names[i]),
+		}
+		for x := range snapshots {
+			in.Equity[x] = performance.EquityPoint{
+				Time:   snapshots[x].Time,
+				Equity: snapshots[x].Equity,
+			}
+		}
+		for x := range positions {
+			if positions[x].Strategy == names[i] && !positions[x].Opened.IsZero() {
+				in.Open = append(in.Open, positions[x].Opened)
+			}
+		}
+		reports = append(reports, performance.Calculate(&in))
+	}
+	return reports, nil
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestStrategyPerformance(t *testing.T) {
+	SetupTestHelpers(t)
+	defer func() { Bot.AllocationManager = allocationManager{} }()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	allocate := func(strategy string, side order.Side, amount, price float64) {
+		_, err := Bot.AllocationManager.Add(&StrategyOrder{
+			Strategy:  strategy,
+			Exchange:  testExchange,
+			Pair:      p,
+			AssetType: asset.Spot,
+			Side:      side,
+			Amount:    amount,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = Bot.AllocationManager.Allocate(testExchange, p, asset.Spot, side, amount, price, AllocateFIFO)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	allocate("a", order.Buy, 1, 100)
+	allocate("a", order.Sell, 1, 110)
+	allocate("a", order.Buy, 1, 100)
+	allocate("a", order.Sell, 1, 95)
+	allocate("b", order.Buy, 1, 100)
+	end := time.Now().Add(time.Hour)
+
+	reports, err := StrategyPerformance("", currency.USD, start, end, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 || reports[0].Strategy != "a" || reports[1].Strategy != "b" {
+		t.Fatalf("expected a report for each strategy, received %+v", reports)
+	}
+	a := reports[0]
+	if a.Trades != 2 || a.WinRate != 50 || a.AverageWin != 10 || a.AverageLoss != -5 ||
+		a.RealisedPNL != 5 || a.Currency != "USD" {
+		t.Errorf("unexpected report %+v", a)
+	}
+	// strategy b's position is still open, exposing it for the remaining hour
+	if b := reports[1]; b.Trades != 0 || b.ExposureTime < 49 || b.ExposureTime > 51 {
+		t.Errorf("unexpected report %+v", b)
+	}
+
+	reports, err = StrategyPerformance("a", currency.USD, end, end.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].Trades != 0 {
+		t.Errorf("expected no trades after the period, received %+v", reports)
+	}
+}
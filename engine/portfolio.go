@@ -2,9 +2,12 @@ package engine
 
 import (
 	"errors"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
@@ -60,17 +63,64 @@ func (p *portfolioManager) run() {
 		log.Debugf(log.PortfolioMgr, "Portfolio manager shutdown.")
 	}()
 
+	// exchange holdings are seeded as their balances change, the ticker
+	// still updates address balances and catches up on missed changes
+	var changes chan interface{}
+	pipe, err := account.SubscribeToBalanceChanges()
+	if err != nil {
+		log.Errorf(log.PortfolioMgr,
+			"Portfolio manager: unable to subscribe to balance changes: %v\n",
+			err)
+	} else {
+		changes = pipe.C
+		defer func() {
+			if err := pipe.Release(); err != nil {
+				log.Errorln(log.PortfolioMgr, err)
+			}
+		}()
+	}
+
 	p.processPortfolio()
 	for {
 		select {
 		case <-p.shutdown:
 			return
+		case data, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			if d, ok := data.(*interface{}); ok {
+				if c, ok := (*d).([]account.BalanceChange); ok {
+					p.processBalanceChanges(c)
+				}
+			}
 		case <-tick.C:
 			p.processPortfolio()
 		}
 	}
 }
 
+// processBalanceChanges seeds the cached holdings of each exchange with a
+// changed balance
+func (p *portfolioManager) processBalanceChanges(changes []account.BalanceChange) {
+	seeded := make(map[string]bool)
+	var holdings []account.Holdings
+	for x := range changes {
+		exch := strings.ToLower(changes[x].Exchange)
+		if seeded[exch] {
+			continue
+		}
+		seeded[exch] = true
+		h, err := account.GetHoldings(changes[x].Exchange)
+		if err != nil {
+			continue
+		}
+		holdings = append(holdings, h)
+	}
+	SeedExchangeAccountInfo(holdings)
+}
+
 func (p *portfolioManager) processPortfolio() {
 	pf := portfolio.GetPortfolio()
 	data := pf.GetPortfolioGroupedCoin()
@@ -90,4 +140,16 @@ func (p *portfolioManager) processPortfolio() {
 			value)
 	}
 	SeedExchangeAccountInfo(GetAllEnabledExchangeAccountInfo().Data)
+	SeedExchangePendingFiat()
+}
+
+// getPortfolioSummary returns the portfolio summary with each holding valued
+// in the base currency, or the fiat display currency when none is supplied
+func getPortfolioSummary(p *portfolio.Base, base currency.Code) portfolio.Summary {
+	if base.IsEmpty() {
+		base = Bot.Config.Currency.FiatDisplayCurrency
+	}
+	result := p.GetPortfolioSummary()
+	result.Value(base, convertValue)
+	return result
 }
@@ -0,0 +1,319 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (p *positionManager) Started() bool {
+	return atomic.LoadInt32(&p.started) == 1
+}
+
+func (p *positionManager) Start() error {
+	if atomic.AddInt32(&p.started, 1) != 1 {
+		return errors.New("position manager already started")
+	}
+
+	log.Debugln(log.OrderMgr, "Position manager starting...")
+	p.shutdown = make(chan struct{})
+	go p.run()
+	return nil
+}
+
+func (p *positionManager) Stop() error {
+	if atomic.AddInt32(&p.stopped, 1) != 1 {
+		return errors.New("position manager is already stopped")
+	}
+
+	log.Debugln(log.OrderMgr, "Position manager shutting down...")
+	close(p.shutdown)
+	return nil
+}
+
+func (p *positionManager) run() {
+	log.Debugln(log.OrderMgr, "Position manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.Positions.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&p.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&p.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.OrderMgr, "Position manager shutdown.")
+	}()
+
+	p.update()
+	for {
+		select {
+		case <-p.shutdown:
+			return
+		case <-tick.C:
+			p.update()
+		}
+	}
+}
+
+// update applies the fills of orders held by the order manager followed by
+// the fills in each authenticated exchange's trade history since it was last
+// read, or its complete synced trade history when the exchange can page
+// through its trades
+func (p *positionManager) update() {
+	Bot.OrderManager.orderStore.m.RLock()
+	var orders []order.Detail
+	for _, v := range Bot.OrderManager.orderStore.Orders {
+		for i := range v {
+			orders = append(orders, *v[i])
+		}
+	}
+	Bot.OrderManager.orderStore.m.RUnlock()
+	p.process(orders)
+
+	exchanges := GetAuthAPISupportedExchanges()
+	for x := range exchanges {
+		select {
+		case <-p.shutdown:
+			return
+		default:
+		}
+		exch := GetExchangeByName(exchanges[x])
+		if exch == nil {
+			continue
+		}
+
+		now := clock.Now()
+		p.m.Lock()
+		if p.since == nil {
+			p.since = make(map[string]time.Time)
+		}
+		since, ok := p.since[exchanges[x]]
+		p.m.Unlock()
+		if !ok {
+			since = now.Add(-Bot.Config.Positions.Lookback)
+		}
+
+		var history []order.Detail
+		var err error
+		if _, ok := exch.(exchange.TradeHistoryPager); ok {
+			// the complete synced history is read so that orders filled
+			// across several syncs are processed as a whole, fills which
+			// have already been applied are skipped
+			history, err = syncedOrderHistory(exch, time.Time{}, now)
+		} else {
+			history, err = exch.GetOrderHistory(&order.GetOrdersRequest{
+				Type:       order.AnyType,
+				Side:       order.AnySide,
+				StartTicks: since,
+				EndTicks:   now,
+				Pairs:      exch.GetEnabledPairs(asset.Spot),
+			})
+		}
+		if err != nil {
+			log.Warnf(log.OrderMgr, "Position manager: unable to get %s trade history: %s", exchanges[x], err)
+			continue
+		}
+		for i := range history {
+			if history[i].Exchange == "" {
+				history[i].Exchange = exch.GetName()
+			}
+		}
+		p.process(history)
+
+		p.m.Lock()
+		p.since[exchanges[x]] = now
+		p.m.Unlock()
+	}
+}
+
+// process applies the part of each order which has been filled since the
+// order was last processed
+func (p *positionManager) process(orders []order.Detail) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.fills == nil {
+		p.fills = make(map[string]positionFill)
+	}
+	if p.positions == nil {
+		p.positions = make(map[string]*Position)
+	}
+
+	for i := range orders {
+		d := &orders[i]
+		if d.Exchange == "" || d.ID == "" || d.Pair.IsEmpty() {
+			continue
+		}
+		if d.Side != order.Buy && d.Side != order.Bid &&
+			d.Side != order.Sell && d.Side != order.Ask {
+			continue
+		}
+		if d.AssetType == "" {
+			d.AssetType = asset.Spot
+		}
+
+		executed, value, fee := orderFill(d)
+		fillKey := strings.ToLower(d.Exchange) + ":" + d.ID
+		applied := p.fills[fillKey]
+		amount := executed - applied.Amount
+		if amount <= 0 {
+			continue
+		}
+		// the price of the amount filled since the order was last processed
+		price := (value - applied.Value) / amount
+		if price <= 0 {
+			// market orders placed by the order manager are valued at the
+			// live price as their fill price is unknown
+			tick, err := ticker.GetTicker(d.Exchange, d.Pair, d.AssetType)
+			if err != nil || tick.Last <= 0 {
+				continue
+			}
+			price = tick.Last
+		}
+
+		posKey := positionKey(d.Exchange, d.Pair, d.AssetType)
+		pos, ok := p.positions[posKey]
+		if !ok {
+			pos = &Position{
+				Exchange:  d.Exchange,
+				Pair:      d.Pair,
+				AssetType: d.AssetType,
+			}
+			p.positions[posKey] = pos
+		}
+		var realised float64
+		pos.Amount, pos.AveragePrice, realised = applyFill(pos.Amount, pos.AveragePrice, d.Side, amount, price)
+		pos.RealisedPNL += realised
+		pos.Fees += fee - applied.Fee
+		pos.LastUpdated = clock.Now()
+		p.fills[fillKey] = positionFill{
+			Amount: executed,
+			Value:  applied.Value + amount*price,
+			Fee:    fee,
+		}
+	}
+}
+
+// GetAll returns a copy of all positions with the unrealised profit and loss
+// of each open position valued at its live ticker price and the funding
+// accrued on each position included in its realised profit and loss
+func (p *positionManager) GetAll() []Position {
+	p.m.Lock()
+	positions := make([]Position, 0, len(p.positions))
+	for _, v := range p.positions {
+		positions = append(positions, *v)
+	}
+	p.m.Unlock()
+
+	for i := range positions {
+		tick, err := ticker.GetTicker(positions[i].Exchange, positions[i].Pair, positions[i].AssetType)
+		if err == nil && tick.Last > 0 {
+			positions[i].MarkPrice = tick.Last
+			positions[i].UnrealisedPNL = positions[i].Amount * (tick.Last - positions[i].AveragePrice)
+		}
+		funding, err := Bot.FundingRates.Get(positions[i].Exchange, positions[i].Pair, positions[i].AssetType)
+		if err != nil {
+			log.Warnf(log.OrderMgr, "Position manager: unable to get %s %s %s funding: %s",
+				positions[i].Exchange,
+				positions[i].Pair,
+				positions[i].AssetType,
+				err)
+			continue
+		}
+		positions[i].Funding = accruedFunding(funding.Payments, positions[i].Pair, positions[i].MarkPrice)
+		positions[i].RealisedPNL += positions[i].Funding
+	}
+	return positions
+}
+
+// get returns a copy of the position on an exchange's pair, which is empty
+// when nothing has been filled
+func (p *positionManager) get(exchName string, pair currency.Pair, a asset.Item) Position {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if pos, ok := p.positions[positionKey(exchName, pair, a)]; ok {
+		return *pos
+	}
+	return Position{Exchange: exchName, Pair: pair, AssetType: a}
+}
+
+func positionKey(exchName string, pair currency.Pair, a asset.Item) string {
+	return strings.ToLower(exchName + ":" + pair.String() + ":" + a.String())
+}
+
+// valueIn returns a copy of the position with its profit and loss, funding and
+// fees converted from the quote currency to the target currency
+func (p *Position) valueIn(target currency.Code) (Position, bool) {
+	v := *p
+	var ok bool
+	if v.RealisedPNL, ok = convertValue(p.RealisedPNL, p.Pair.Quote, target); !ok {
+		return v, false
+	}
+	if v.UnrealisedPNL, ok = convertValue(p.UnrealisedPNL, p.Pair.Quote, target); !ok {
+		return v, false
+	}
+	if v.Funding, ok = convertValue(p.Funding, p.Pair.Quote, target); !ok {
+		return v, false
+	}
+	v.Fees, ok = convertValue(p.Fees, p.Pair.Quote, target)
+	return v, ok
+}
+
+// orderFill returns the executed amount, value and fee of an order, taken from
+// its trades when the exchange supplies them
+func orderFill(d *order.Detail) (amount, value, fee float64) {
+	if len(d.Trades) > 0 {
+		for i := range d.Trades {
+			amount += d.Trades[i].Amount
+			value += d.Trades[i].Amount * d.Trades[i].Price
+			fee += d.Trades[i].Fee
+		}
+		return amount, value, fee
+	}
+	amount = d.ExecutedAmount
+	if amount == 0 && d.Status == order.Filled {
+		amount = d.Amount
+	}
+	return amount, amount * d.Price, d.Fee
+}
+
+// applyFill applies a fill to a net position and its average entry price,
+// realising profit and loss on any amount which reduces the position. A
+// negative position is short. Returns the new position, average price and the
+// profit and loss realised by the fill
+func applyFill(position, averagePrice float64, side order.Side, amount, price float64) (newPosition, newAveragePrice, realised float64) {
+	delta := amount
+	if !isBuySide(side) {
+		delta = -amount
+	}
+
+	if position == 0 || (position > 0) == (delta > 0) {
+		total := math.Abs(position) + amount
+		return position + delta, (math.Abs(position)*averagePrice + amount*price) / total, 0
+	}
+
+	closed := math.Min(amount, math.Abs(position))
+	if position > 0 {
+		realised = closed * (price - averagePrice)
+	} else {
+		realised = closed * (averagePrice - price)
+	}
+	newPosition = position + delta
+	newAveragePrice = averagePrice
+	switch {
+	case amount > closed:
+		// the position has been reversed
+		newAveragePrice = price
+	case newPosition == 0:
+		newAveragePrice = 0
+	}
+	return newPosition, newAveragePrice, realised
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func TestApplyFill(t *testing.T) {
+	tests := []struct {
+		name              string
+		position, average float64
+		side              order.Side
+		amount, price     float64
+		expectedPosition  float64
+		expectedAverage   float64
+		expectedRealised  float64
+	}{
+		{"open long", 0, 0, order.Buy, 1, 100, 1, 100, 0},
+		{"add long", 1, 100, order.Buy, 1, 200, 2, 150, 0},
+		{"reduce long", 2, 150, order.Sell, 1, 200, 1, 150, 50},
+		{"close long", 1, 150, order.Ask, 1, 100, 0, 0, -50},
+		{"reverse long", 1, 100, order.Sell, 3, 120, -2, 120, 20},
+		{"open short", 0, 0, order.Sell, 2, 100, -2, 100, 0},
+		{"cover short", -2, 100, order.Bid, 1, 90, -1, 100, 10},
+	}
+	for x := range tests {
+		pos, avg, realised := applyFill(tests[x].position,
+			tests[x].average,
+			tests[x].side,
+			tests[x].amount,
+			tests[x].price)
+		if pos != tests[x].expectedPosition ||
+			avg != tests[x].expectedAverage ||
+			realised != tests[x].expectedRealised {
+			t.Errorf("%s: expected %v @ %v realised %v, got %v @ %v realised %v",
+				tests[x].name,
+				tests[x].expectedPosition,
+				tests[x].expectedAverage,
+				tests[x].expectedRealised,
+				pos,
+				avg,
+				realised)
+		}
+	}
+}
+
+func TestPositionManagerProcess(t *testing.T) {
+	SetupTestHelpers(t)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	var m positionManager
+
+	partial := order.Detail{
+		Exchange:       fakePassExchange,
+		ID:             "1",
+		Pair:           p,
+		Side:           order.Buy,
+		Type:           order.Limit,
+		Price:          100,
+		Amount:         2,
+		ExecutedAmount: 1,
+		Status:         order.PartiallyFilled,
+	}
+	m.process([]order.Detail{partial})
+
+	// the same order read again from trade history with its trades is only
+	// applied for the amount filled since
+	partial.Trades = []order.TradeHistory{
+		{Price: 100, Amount: 1, Fee: 0.1, TID: "a"},
+		{Price: 130, Amount: 1, Fee: 0.1, TID: "b"},
+	}
+	partial.Status = order.Filled
+	m.process([]order.Detail{partial, partial})
+
+	m.process([]order.Detail{
+		{
+			Exchange: fakePassExchange,
+			ID:       "2",
+			Pair:     p,
+			Side:     order.Sell,
+			Type:     order.Limit,
+			Price:    150,
+			Amount:   1,
+			Fee:      0.2,
+			Status:   order.Filled,
+		},
+		// orders with nothing filled or no side are ignored
+		{Exchange: fakePassExchange, ID: "3", Pair: p, Side: order.Buy, Price: 1, Amount: 5, Status: order.New},
+		{Exchange: fakePassExchange, ID: "4", Pair: p, Side: order.AnySide, Price: 1, Amount: 5, Status: order.Filled},
+	})
+
+	err := ticker.ProcessTicker(fakePassExchange, &ticker.Price{Pair: p, Last: 160}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions := m.GetAll()
+	if len(positions) != 1 {
+		t.Fatalf("expected 1 position, got %d", len(positions))
+	}
+	pos := positions[0]
+	// bought 1 @ 100 and 1 @ 130 then sold 1 @ 150
+	if pos.Amount != 1 ||
+		pos.AveragePrice != 115 ||
+		pos.RealisedPNL != 35 ||
+		pos.MarkPrice != 160 ||
+		pos.UnrealisedPNL != 45 ||
+		pos.AssetType != asset.Spot {
+		t.Errorf("unexpected position %+v", pos)
+	}
+	if math.Abs(pos.Fees-0.4) > 1e-9 {
+		t.Errorf("expected fees of 0.4, got %v", pos.Fees)
+	}
+}
+
+func TestPositionValueIn(t *testing.T) {
+	SetupTestHelpers(t)
+	err := ticker.ProcessTicker(testExchange,
+		&ticker.Price{Pair: currency.NewPair(currency.ETH, currency.USD), Last: 256},
+		asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pos := Position{
+		Pair:          currency.NewPair(currency.XRP, currency.USD),
+		RealisedPNL:   512,
+		UnrealisedPNL: -128,
+		Fees:          64,
+	}
+	v, ok := pos.valueIn(currency.ETH)
+	if !ok || v.RealisedPNL != 2 || v.UnrealisedPNL != -0.5 || v.Fees != 0.25 {
+		t.Errorf("unexpected position valued in ETH %+v %v", v, ok)
+	}
+	if pos.RealisedPNL != 512 {
+		t.Error("expected the position not to be modified")
+	}
+	if _, ok = pos.valueIn(currency.NewCode("NOPE")); ok {
+		t.Error("expected an unpriced currency not to be valued")
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Position is the account's net position and profit and loss on an
+// exchange's pair built from its fills. A negative amount is a short position.
+// Profit and loss, funding and fees are in the quote currency
+type Position struct {
+	Exchange     string
+	Pair         currency.Pair
+	AssetType    asset.Item
+	Amount       float64
+	AveragePrice float64
+	// RealisedPNL includes the funding payments and margin interest accrued
+	// on the position, which Funding holds
+	RealisedPNL float64
+	Funding     float64
+	Fees        float64
+	// MarkPrice is the live ticker price the open amount is valued at, zero
+	// when no ticker is available
+	MarkPrice     float64
+	UnrealisedPNL float64
+	LastUpdated   time.Time
+}
+
+// positionFill is the amount, value and fee of an order which has been applied
+// to a position
+type positionFill struct {
+	Amount float64
+	Value  float64
+	Fee    float64
+}
+
+type positionManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m         sync.Mutex
+	positions map[string]*Position
+	// fills is keyed by exchange and order ID so an order read from both the
+	// order manager and trade history is only applied once
+	fills map[string]positionFill
+	// since is the time trade history was last read for each exchange
+	since map[string]time.Time
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (r *requestAuditor) Started() bool {
+	return atomic.LoadInt32(&r.started) == 1
+}
+
+func (r *requestAuditor) Start() error {
+	if atomic.AddInt32(&r.started, 1) != 1 {
+		return errors.New("request auditor already started")
+	}
+
+	log.Debugln(log.RequestSys, "Request auditor starting...")
+	path := Bot.Config.RequestAudit.File
+	if path == "" {
+		path = filepath.Join(Bot.Settings.DataDir, requestAuditFileName)
+	}
+	f, err := openRequestAuditFile(path)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&r.started, 1, 0)
+		return err
+	}
+	r.m.Lock()
+	r.path, r.file = path, f
+	r.database = Bot.Config.RequestAudit.Database
+	r.m.Unlock()
+	request.SetAuditor(r)
+	log.Debugf(log.RequestSys, "Request auditor started, recording authenticated requests to %s.\n", path)
+	return nil
+}
+
+func (r *requestAuditor) Stop() error {
+	if atomic.AddInt32(&r.stopped, 1) != 1 {
+		return errors.New("request auditor is already stopped")
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&r.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&r.started, 1, 0)
+	}()
+
+	log.Debugln(log.RequestSys, "Request auditor shutting down...")
+	request.SetAuditor(nil)
+	r.m.Lock()
+	defer r.m.Unlock()
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Audit appends an attempt of an authenticated request to the audit file as a
+// line of JSON and records it as a database audit event when enabled
+func (r *requestAuditor) Audit(e *request.AuditEntry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf(log.RequestSys, "Request auditor: unable to encode %s request: %v\n", e.Exchange, err)
+		return
+	}
+
+	r.m.Lock()
+	if r.file == nil {
+		r.m.Unlock()
+		return
+	}
+	_, err = r.file.Write(append(b, '\n'))
+	database := r.database
+	r.m.Unlock()
+	if err != nil {
+		log.Errorf(log.RequestSys, "Request auditor: unable to record %s request: %v\n", e.Exchange, err)
+	}
+	if database {
+		audit.Event(e.Exchange, requestAuditEventType, string(b))
+	}
+}
+
+// openRequestAuditFile opens the audit file for appending, creating it and its
+// directory if required
+func openRequestAuditFile(path string) (*os.File, error) {
+	if err := common.CreateDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+}
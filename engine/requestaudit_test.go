@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestRequestAuditor(t *testing.T) {
+	SetupTestHelpers(t)
+	dir, err := ioutil.TempDir("", "requestaudit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldFile := Bot.Config.RequestAudit.File
+	Bot.Config.RequestAudit.File = filepath.Join(dir, "audit", requestAuditFileName)
+	defer func() { Bot.Config.RequestAudit.File = oldFile }()
+
+	var r requestAuditor
+	if err = r.Start(); err != nil {
+		t.Fatal(err)
+	}
+	entry := request.AuditEntry{
+		Capture: request.Capture{
+			Exchange:     testExchange,
+			Method:       http.MethodPost,
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id":1}`,
+		},
+		Attempt: 1,
+	}
+	r.Audit(&entry)
+	if err = r.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	// entries are appended to the existing file once restarted
+	if err = r.Start(); err != nil {
+		t.Fatal(err)
+	}
+	entry.Attempt = 2
+	r.Audit(&entry)
+	if err = r.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	// entries passed once stopped are not recorded
+	r.Audit(&entry)
+
+	f, err := os.Open(Bot.Config.RequestAudit.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []request.AuditEntry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e request.AuditEntry
+		if err = json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audited requests, got %d", len(entries))
+	}
+	if entries[0].Attempt != 1 || entries[1].Attempt != 2 ||
+		entries[1].Exchange != testExchange ||
+		entries[1].ResponseBody != `{"id":1}` {
+		t.Errorf("unexpected audited requests %+v", entries)
+	}
+}
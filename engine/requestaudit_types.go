@@ -0,0 +1,25 @@
+package engine
+
+import (
+	"os"
+	"sync"
+)
+
+const (
+	requestAuditFileName = "requests.audit"
+	// requestAuditEventType is the type of the database audit events
+	// authenticated requests are recorded as
+	requestAuditEventType = "exchange_request"
+)
+
+// requestAuditor appends each attempt of an authenticated exchange request and
+// its raw response to the audit file
+type requestAuditor struct {
+	started int32
+	stopped int32
+
+	m        sync.Mutex
+	path     string
+	file     *os.File
+	database bool
+}
@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// resourceRecoveryRatio is the fraction of each threshold usage must fall
+// below before a tier is lifted, so tiers don't flap around the thresholds
+const resourceRecoveryRatio = 0.8
+
+func (r *resourceMonitor) Started() bool {
+	return atomic.LoadInt32(&r.started) == 1
+}
+
+func (r *resourceMonitor) Start() error {
+	if atomic.AddInt32(&r.started, 1) != 1 {
+		return errors.New("resource monitor already started")
+	}
+
+	log.Debugln(log.Global, "Resource monitor starting...")
+	r.pollingMultiplier = Bot.Config.ResourceMonitor.PollingMultiplier
+	r.shutdown = make(chan struct{})
+	go r.run()
+	return nil
+}
+
+func (r *resourceMonitor) Stop() error {
+	if atomic.AddInt32(&r.stopped, 1) != 1 {
+		return errors.New("resource monitor is already stopped")
+	}
+
+	log.Debugln(log.Global, "Resource monitor shutting down...")
+	close(r.shutdown)
+	return nil
+}
+
+func (r *resourceMonitor) run() {
+	log.Debugln(log.Global, "Resource monitor started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.ResourceMonitor.Interval)
+	defer func() {
+		r.restore(&Bot.Config.ResourceMonitor)
+		atomic.CompareAndSwapInt32(&r.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&r.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.Global, "Resource monitor shutdown.")
+	}()
+
+	r.check()
+	for {
+		select {
+		case <-r.shutdown:
+			return
+		case <-tick.C:
+			r.check()
+		}
+	}
+}
+
+// check measures the process's CPU usage since the previous check and its
+// heap memory
+func (r *resourceMonitor) check() {
+	now := time.Now()
+	var cpu float64
+	cpuTime, err := processCPUTime()
+	if err != nil {
+		log.Warnf(log.Global, "Resource monitor: unable to measure CPU usage: %v\n", err)
+	}
+	r.m.Lock()
+	if err == nil {
+		if wall := now.Sub(r.lastWall); !r.lastWall.IsZero() && wall > 0 {
+			cpu = float64(cpuTime-r.lastCPU) / float64(wall) / float64(runtime.NumCPU()) * 100
+		}
+		r.lastCPU = cpuTime
+		r.lastWall = now
+	}
+	r.m.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	r.evaluate(&Bot.Config.ResourceMonitor, cpu, mem.HeapAlloc/1024/1024)
+}
+
+// evaluate applies the next tier when usage exceeds either threshold and lifts
+// the most recently applied tier once usage falls below the recovery ratio of
+// both thresholds. A single tier changes per check
+func (r *resourceMonitor) evaluate(cfg *config.ResourceMonitorConfig, cpu float64, memoryMB uint64) {
+	pressure := cpu > cfg.MaxCPU ||
+		(cfg.MaxMemoryMB > 0 && memoryMB > cfg.MaxMemoryMB)
+	recovered := cpu < cfg.MaxCPU*resourceRecoveryRatio &&
+		(cfg.MaxMemoryMB == 0 || float64(memoryMB) < float64(cfg.MaxMemoryMB)*resourceRecoveryRatio)
+
+	var msg string
+	r.m.Lock()
+	switch {
+	case pressure && r.applied < len(cfg.Tiers):
+		tier := cfg.Tiers[r.applied]
+		r.applied++
+		r.setTier(cfg, tier, true)
+		msg = fmt.Sprintf("Resource monitor: CPU %.1f%% memory %dMB under pressure, degrading %s.",
+			cpu,
+			memoryMB,
+			tier)
+	case recovered && r.applied > 0:
+		r.applied--
+		tier := cfg.Tiers[r.applied]
+		r.setTier(cfg, tier, false)
+		msg = fmt.Sprintf("Resource monitor: CPU %.1f%% memory %dMB recovered, restoring %s.",
+			cpu,
+			memoryMB,
+			tier)
+	}
+	r.usage = ResourceUsage{
+		CPU:         cpu,
+		MemoryMB:    memoryMB,
+		Pressure:    pressure,
+		Tiers:       append([]string(nil), cfg.Tiers[:r.applied]...),
+		LastChecked: time.Now(),
+	}
+	r.m.Unlock()
+
+	if msg != "" {
+		log.Warnln(log.Global, msg)
+		Bot.CommsManager.PushEvent(base.Event{
+			Type:    "resources",
+			Message: msg,
+		})
+	}
+}
+
+// restore lifts every applied tier
+func (r *resourceMonitor) restore(cfg *config.ResourceMonitorConfig) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	for r.applied > 0 {
+		r.applied--
+		r.setTier(cfg, cfg.Tiers[r.applied], false)
+	}
+	r.usage.Tiers = nil
+}
+
+func (r *resourceMonitor) setTier(cfg *config.ResourceMonitorConfig, tier string, degrade bool) {
+	var v int32
+	if degrade {
+		v = 1
+	}
+	switch tier {
+	case config.DegradeOrderbookDepth:
+		if degrade {
+			orderbook.SetDepthLimit(cfg.OrderbookDepth)
+		} else {
+			orderbook.SetDepthLimit(0)
+		}
+	case config.DegradePollingIntervals:
+		atomic.StoreInt32(&r.pollingDegraded, v)
+	case config.DegradeAnalytics:
+		atomic.StoreInt32(&r.analyticsPaused, v)
+	}
+}
+
+// PollingInterval returns the REST polling interval, widened while the polling
+// intervals tier is applied
+func (r *resourceMonitor) PollingInterval(d time.Duration) time.Duration {
+	if atomic.LoadInt32(&r.pollingDegraded) == 1 {
+		return time.Duration(float64(d) * r.pollingMultiplier)
+	}
+	return d
+}
+
+// AnalyticsPaused returns whether analytics are paused while the analytics
+// tier is applied
+func (r *resourceMonitor) AnalyticsPaused() bool {
+	return atomic.LoadInt32(&r.analyticsPaused) == 1
+}
+
+// GetUsage returns the resource usage measured by the most recent check
+func (r *resourceMonitor) GetUsage() ResourceUsage {
+	r.m.Lock()
+	defer r.m.Unlock()
+	usage := r.usage
+	usage.Tiers = append([]string(nil), r.usage.Tiers...)
+	return usage
+}
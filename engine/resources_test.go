@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestResourceMonitorEvaluate(t *testing.T) {
+	SetupTestHelpers(t)
+	cfg := config.ResourceMonitorConfig{
+		MaxCPU:            50,
+		MaxMemoryMB:       100,
+		OrderbookDepth:    5,
+		PollingMultiplier: 2,
+		Tiers: []string{
+			config.DegradeOrderbookDepth,
+			config.DegradePollingIntervals,
+			config.DegradeAnalytics,
+		},
+	}
+	r := resourceMonitor{pollingMultiplier: cfg.PollingMultiplier}
+	defer r.restore(&cfg)
+
+	r.evaluate(&cfg, 10, 10)
+	if u := r.GetUsage(); u.Pressure || len(u.Tiers) != 0 {
+		t.Fatalf("expected no pressure, got %+v", u)
+	}
+
+	// a tier is applied per check in the configured order
+	r.evaluate(&cfg, 90, 10)
+	if orderbook.GetDepthLimit() != 5 || r.PollingInterval(time.Second) != time.Second {
+		t.Errorf("expected only the orderbook depth to be degraded, got %+v", r.GetUsage())
+	}
+	r.evaluate(&cfg, 10, 200)
+	if r.PollingInterval(time.Second) != time.Second*2 || r.AnalyticsPaused() {
+		t.Errorf("expected polling intervals to be widened, got %+v", r.GetUsage())
+	}
+	r.evaluate(&cfg, 90, 200)
+	r.evaluate(&cfg, 90, 200)
+	if u := r.GetUsage(); !r.AnalyticsPaused() || len(u.Tiers) != 3 || !u.Pressure {
+		t.Errorf("expected every tier to be applied, got %+v", u)
+	}
+
+	// tiers are held until usage falls below the recovery ratio
+	r.evaluate(&cfg, 45, 10)
+	if !r.AnalyticsPaused() {
+		t.Error("expected analytics to remain paused")
+	}
+	r.evaluate(&cfg, 10, 10)
+	if r.AnalyticsPaused() || r.PollingInterval(time.Second) != time.Second*2 {
+		t.Errorf("expected the last tier to be lifted first, got %+v", r.GetUsage())
+	}
+
+	r.restore(&cfg)
+	if orderbook.GetDepthLimit() != 0 || r.PollingInterval(time.Second) != time.Second || len(r.GetUsage().Tiers) != 0 {
+		t.Errorf("expected every tier to be lifted, got %+v", r.GetUsage())
+	}
+}
+
+func TestProcessCPUTime(t *testing.T) {
+	d, err := processCPUTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d <= 0 {
+		t.Errorf("expected the process to have used CPU time, got %s", d)
+	}
+}
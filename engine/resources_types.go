@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// ResourceUsage is the process's resource usage measured by the most recent
+// check of the resource monitor and the degradation tiers applied
+type ResourceUsage struct {
+	// CPU is the percentage of the machine's total CPU capacity used by the
+	// process since the previous check
+	CPU      float64
+	MemoryMB uint64
+	Pressure bool
+	// Tiers are the degradation tiers applied, in the order they were
+	// applied
+	Tiers       []string
+	LastChecked time.Time
+}
+
+type resourceMonitor struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	// pollingDegraded and analyticsPaused are read by the syncer and
+	// analytics managers
+	pollingDegraded   int32
+	analyticsPaused   int32
+	pollingMultiplier float64
+
+	m       sync.Mutex
+	usage   ResourceUsage
+	applied int
+	lastCPU time.Duration
+	// lastWall is when the process's CPU time was last measured
+	lastWall time.Time
+}
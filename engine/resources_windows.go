@@ -0,0 +1,25 @@
+package engine
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user and kernel CPU time used by the process
+func processCPUTime() (time.Duration, error) {
+	h, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0, err
+	}
+	var creation, exit, kernel, user syscall.Filetime
+	if err = syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return 0, err
+	}
+	return filetimeDuration(kernel) + filetimeDuration(user), nil
+}
+
+// filetimeDuration converts a FILETIME duration held in 100 nanosecond
+// intervals
+func filetimeDuration(f syscall.Filetime) time.Duration {
+	return time.Duration(int64(f.HighDateTime)<<32|int64(f.LowDateTime)) * 100
+}
@@ -32,11 +32,11 @@ func RESTLogger(inner http.Handler, name string) http.Handler {
 
 // StartRESTServer starts a REST server
 func StartRESTServer() {
-	listenAddr := Bot.Config.RemoteControl.DeprecatedRPC.ListenAddress
+	cfg := Bot.Config.RemoteControl.DeprecatedRPC
 	log.Debugf(log.RESTSys,
-		"Deprecated RPC server support enabled. Listen URL: http://%s:%d\n",
-		common.ExtractHost(listenAddr), common.ExtractPort(listenAddr))
-	err := http.ListenAndServe(listenAddr, newRouter(true))
+		"Deprecated RPC server support enabled. Listen URL: %s%s:%d\n",
+		urlScheme("http", cfg.TLS), common.ExtractHost(cfg.ListenAddress), common.ExtractPort(cfg.ListenAddress))
+	err := listenAndServe(cfg.ListenAddress, cfg.TLS, newRouter(true))
 	if err != nil {
 		log.Errorf(log.RESTSys, "Failed to start deprecated RPC server. Err: %s", err)
 	}
@@ -44,16 +44,45 @@ func StartRESTServer() {
 
 // StartWebsocketServer starts a Websocket server
 func StartWebsocketServer() {
-	listenAddr := Bot.Config.RemoteControl.WebsocketRPC.ListenAddress
+	cfg := Bot.Config.RemoteControl.WebsocketRPC
+	host, port := common.ExtractHost(cfg.ListenAddress), common.ExtractPort(cfg.ListenAddress)
 	log.Debugf(log.RESTSys,
-		"Websocket RPC support enabled. Listen URL: ws://%s:%d/ws\n",
-		common.ExtractHost(listenAddr), common.ExtractPort(listenAddr))
-	err := http.ListenAndServe(listenAddr, newRouter(false))
+		"Websocket RPC support enabled. Listen URL: %s%s:%d/ws, JSON-RPC: %s%s:%d/jsonrpc\n",
+		urlScheme("ws", cfg.TLS), host, port,
+		urlScheme("ws", cfg.TLS), host, port)
+	if cfg.Dashboard {
+		log.Debugf(log.RESTSys,
+			"Web dashboard enabled. URL: %s%s:%d/dashboard\n",
+			urlScheme("http", cfg.TLS), host, port)
+	}
+	err := listenAndServe(cfg.ListenAddress, cfg.TLS, newRouter(false))
 	if err != nil {
 		log.Errorf(log.RESTSys, "Failed to start websocket RPC server. Err: %s", err)
 	}
 }
 
+// listenAndServe serves the handler, over TLS with the remote control
+// certificate when useTLS is set
+func listenAndServe(addr string, useTLS bool, handler http.Handler) error {
+	if !useTLS {
+		return http.ListenAndServe(addr, handler)
+	}
+	certFile, keyFile, err := remoteControlCerts()
+	if err != nil {
+		return err
+	}
+	return http.ListenAndServeTLS(addr, certFile, keyFile, handler)
+}
+
+// urlScheme returns the scheme of a server's URL, the secure scheme when the
+// server is served over TLS
+func urlScheme(scheme string, useTLS bool) string {
+	if useTLS {
+		return scheme + "s://"
+	}
+	return scheme + "://"
+}
+
 // newRouter takes in the exchange interfaces and returns a new multiplexor
 // router
 func newRouter(isREST bool) *mux.Router {
@@ -98,6 +127,14 @@ func newRouter(isREST bool) *mux.Router {
 	} else {
 		routes = []Route{
 			{"ws", http.MethodGet, "/ws", WebsocketClientHandler},
+			{"jsonrpc", http.MethodGet, "/jsonrpc", JSONRPCClientHandler},
+		}
+
+		if Bot.Config.RemoteControl.WebsocketRPC.Dashboard {
+			routes = append(routes,
+				Route{"dashboard", http.MethodGet, "/dashboard", DashboardHandler},
+				Route{"dashboard", http.MethodGet, "/dashboard/{asset}", DashboardHandler},
+			)
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
@@ -98,8 +99,8 @@ func RESTGetAllActiveOrderbooks(w http.ResponseWriter, r *http.Request) {
 
 // RESTGetPortfolio returns the Bot portfolio
 func RESTGetPortfolio(w http.ResponseWriter, r *http.Request) {
-	p := portfolio.GetPortfolio()
-	result := p.GetPortfolioSummary()
+	result := getPortfolioSummary(portfolio.GetPortfolio(),
+		currency.NewCode(r.URL.Query().Get("currency")))
 	err := RESTfulJSONResponse(w, result)
 	if err != nil {
 		RESTfulError(r.Method, err)
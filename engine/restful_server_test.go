@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -111,3 +112,35 @@ func TestProfilerEnabledShouldEnableProfileEndPoint(t *testing.T) {
 		t.Errorf("Response returned wrong status code expected %v got %v", http.StatusOK, status)
 	}
 }
+
+func TestDashboard(t *testing.T) {
+	SetupTestHelpers(t)
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "localhost:9051"
+		resp := httptest.NewRecorder()
+		newRouter(false).ServeHTTP(resp, req)
+		return resp
+	}
+
+	if resp := get("/dashboard"); resp.Code != http.StatusNotFound {
+		t.Errorf("expected the dashboard not to be served when disabled, got %v", resp.Code)
+	}
+
+	Bot.Config.RemoteControl.WebsocketRPC.Dashboard = true
+	defer func() { Bot.Config.RemoteControl.WebsocketRPC.Dashboard = false }()
+	resp := get("/dashboard")
+	if resp.Code != http.StatusOK || !strings.Contains(resp.Body.String(), "/dashboard/dashboard.js") {
+		t.Errorf("expected the dashboard page, got %v %s", resp.Code, resp.Body.String())
+	}
+	resp = get("/dashboard/dashboard.js")
+	if resp.Code != http.StatusOK || !strings.HasPrefix(resp.Header().Get("Content-Type"), "application/javascript") {
+		t.Errorf("expected the dashboard script, got %v %s", resp.Code, resp.Header().Get("Content-Type"))
+	}
+	if resp = get("/dashboard/config.json"); resp.Code != http.StatusNotFound {
+		t.Errorf("expected an unknown asset not to be found, got %v", resp.Code)
+	}
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the risk manager
+var (
+	errRiskPairBanned        = errors.New("pair is banned")
+	errRiskMaxOpenOrders     = errors.New("maximum open orders reached")
+	errRiskMaxOrderNotional  = errors.New("order notional exceeds the maximum")
+	errRiskMaxPositionSize   = errors.New("position size would exceed the maximum")
+	errRiskMaxDailyLoss      = errors.New("maximum daily loss reached")
+	errRiskMaxSlippage       = errors.New("estimated slippage exceeds the maximum")
+	errRiskUnpriceable       = errors.New("unable to value order in")
+	errRiskViolationRejected = errors.New("order rejected by risk management")
+)
+
+// Check returns an error when the order violates its exchange's risk rules.
+// Violations are logged and, when enabled, sent to the communications
+// subsystem
+func (r *riskManager) Check(s *order.Submit) error {
+	cfg := Bot.Config.RiskManagement
+	if !cfg.Enabled {
+		return nil
+	}
+	rules := riskRules(&cfg, s.Exchange)
+	if rules == nil {
+		return nil
+	}
+
+	err := r.check(rules, cfg.Currency, s)
+	if err == nil {
+		return nil
+	}
+	msg := fmt.Sprintf("Risk manager: rejected %s %s %s %s order amount %v: %v",
+		s.Exchange,
+		s.Pair,
+		s.AssetType,
+		s.Side,
+		s.Amount,
+		err)
+	log.Warnln(log.OrderMgr, msg)
+	if cfg.AlertViolations {
+		Bot.CommsManager.PushEvent(base.Event{
+			Type:    "risk",
+			Message: msg,
+		})
+	}
+	return fmt.Errorf("%v: %v", errRiskViolationRejected, err)
+}
+
+// check applies the rules in order of the cost of evaluating them
+func (r *riskManager) check(rules *config.ExchangeRiskConfig, valuation currency.Code, s *order.Submit) error {
+	if rules.BannedPairs.Contains(s.Pair, true) {
+		return fmt.Errorf("%s %v", s.Pair, errRiskPairBanned)
+	}
+
+	if rules.MaxOpenOrders > 0 {
+		var open int
+		orders, _ := Bot.OrderManager.orderStore.GetByExchange(s.Exchange)
+		for x := range orders {
+			if orders[x].IsOpen() {
+				open++
+			}
+		}
+		if open >= rules.MaxOpenOrders {
+			return fmt.Errorf("%v of %d", errRiskMaxOpenOrders, rules.MaxOpenOrders)
+		}
+	}
+
+	if rules.MaxSlippage > 0 && s.Type == order.Market {
+		a := s.AssetType
+		if a == "" {
+			a = asset.Spot
+		}
+		est, err := orderbook.GetSlippage(s.Exchange, s.Pair, a, s.Amount, isBuySide(s.Side))
+		if err != nil {
+			return fmt.Errorf("unable to estimate slippage: %v", err)
+		}
+		if !est.Sufficient {
+			return fmt.Errorf("%v, orderbook can only fill %v of %v", errRiskMaxSlippage, est.Filled, s.Amount)
+		}
+		if est.SlippagePercent > rules.MaxSlippage {
+			return fmt.Errorf("%v, %v%% exceeds %v%%", errRiskMaxSlippage, est.SlippagePercent, rules.MaxSlippage)
+		}
+	}
+
+	if rules.MaxOrderNotional <= 0 && rules.MaxPositionSize <= 0 && rules.MaxDailyLoss <= 0 {
+		return nil
+	}
+	pos := Bot.PositionManager.get(s.Exchange, s.Pair, s.AssetType)
+	after := pos.Amount - s.Amount
+	if isBuySide(s.Side) {
+		after = pos.Amount + s.Amount
+	}
+	// orders which only reduce the position are never blocked by the
+	// position or loss limits
+	reduces := math.Abs(after) < math.Abs(pos.Amount) && after*pos.Amount >= 0
+
+	if rules.MaxOrderNotional > 0 || (rules.MaxPositionSize > 0 && !reduces) {
+		price, ok := riskPrice(s, valuation)
+		if !ok {
+			return fmt.Errorf("%v %s", errRiskUnpriceable, valuation)
+		}
+		if notional := s.Amount * price; rules.MaxOrderNotional > 0 && notional > rules.MaxOrderNotional {
+			return fmt.Errorf("%v, %v %s exceeds %v", errRiskMaxOrderNotional, notional, valuation, rules.MaxOrderNotional)
+		}
+		if size := math.Abs(after) * price; rules.MaxPositionSize > 0 && !reduces && size > rules.MaxPositionSize {
+			return fmt.Errorf("%v, %v %s exceeds %v", errRiskMaxPositionSize, size, valuation, rules.MaxPositionSize)
+		}
+	}
+
+	if rules.MaxDailyLoss > 0 && !reduces {
+		loss, ok := r.dailyLoss(s.Exchange, valuation)
+		if !ok {
+			return fmt.Errorf("%v %s", errRiskUnpriceable, valuation)
+		}
+		if loss >= rules.MaxDailyLoss {
+			return fmt.Errorf("%v, lost %v %s of %v", errRiskMaxDailyLoss, loss, valuation, rules.MaxDailyLoss)
+		}
+	}
+	return nil
+}
+
+// dailyLoss returns the exchange's loss since its first order of the UTC day
+// was checked, which is zero or negative when it has made a profit
+func (r *riskManager) dailyLoss(exchName string, valuation currency.Code) (float64, bool) {
+	var pnl float64
+	positions := Bot.PositionManager.GetAll()
+	for x := range positions {
+		if !strings.EqualFold(positions[x].Exchange, exchName) {
+			continue
+		}
+		v, ok := positions[x].valueIn(valuation)
+		if !ok {
+			return 0, false
+		}
+		pnl += v.RealisedPNL + v.UnrealisedPNL - v.Fees
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	day := clock.Now().UTC().Truncate(time.Hour * 24)
+	if !day.Equal(r.day) || r.baselines == nil {
+		r.day = day
+		r.baselines = make(map[string]float64)
+	}
+	key := strings.ToLower(exchName)
+	baseline, ok := r.baselines[key]
+	if !ok {
+		r.baselines[key] = pnl
+		return 0, true
+	}
+	return baseline - pnl, true
+}
+
+// riskRules returns the risk rules of an exchange, nil when it has none
+func riskRules(cfg *config.RiskManagementConfig, exchName string) *config.ExchangeRiskConfig {
+	for x := range cfg.Exchanges {
+		if strings.EqualFold(cfg.Exchanges[x].Exchange, exchName) {
+			return &cfg.Exchanges[x]
+		}
+	}
+	return nil
+}
+
+// riskPrice returns the price of one unit of the order's base currency in the
+// valuation currency, using the live ticker price for market orders
+func riskPrice(s *order.Submit, valuation currency.Code) (float64, bool) {
+	price := s.Price
+	if s.Type == order.Market || price <= 0 {
+		price = s.TriggerPrice
+		tick, err := ticker.GetTicker(s.Exchange, s.Pair, s.AssetType)
+		if err == nil && tick.Last > 0 {
+			price = tick.Last
+		}
+	}
+	if price <= 0 {
+		return 0, false
+	}
+	return convertValue(price, s.Pair.Quote, valuation)
+}
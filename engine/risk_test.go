@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+func TestRiskManagerCheck(t *testing.T) {
+	SetupTestHelpers(t)
+	const exchName = "TestRiskManagerCheck"
+	p := currency.NewPair(currency.BTC, currency.USD)
+	c := clock.NewManual(time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC))
+	clock.Set(c)
+	defer clock.Reset()
+
+	Bot.PositionManager.process([]order.Detail{{
+		Exchange: exchName,
+		ID:       "1",
+		Pair:     p,
+		Side:     order.Buy,
+		Type:     order.Limit,
+		Price:    100,
+		Amount:   1,
+		Status:   order.Filled,
+	}})
+
+	var r riskManager
+	rules := &config.ExchangeRiskConfig{
+		Exchange:         exchName,
+		MaxOrderNotional: 150,
+		MaxPositionSize:  250,
+		BannedPairs:      currency.Pairs{currency.NewPair(currency.LTC, currency.USD)},
+	}
+	s := func(pair currency.Pair, side order.Side, amount float64) *order.Submit {
+		return &order.Submit{
+			Exchange:  exchName,
+			Pair:      pair,
+			AssetType: asset.Spot,
+			Side:      side,
+			Type:      order.Limit,
+			Price:     100,
+			Amount:    amount,
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		order  *order.Submit
+		expect error
+	}{
+		{"banned", s(currency.NewPair(currency.LTC, currency.USD), order.Buy, 1), errRiskPairBanned},
+		{"notional", s(p, order.Buy, 2), errRiskMaxOrderNotional},
+		{"within limits", s(p, order.Buy, 1), nil},
+		{"reduces", s(p, order.Sell, 0.5), nil},
+	} {
+		err := r.check(rules, currency.USD, tt.order)
+		if tt.expect == nil && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if tt.expect != nil && (err == nil || !strings.Contains(err.Error(), tt.expect.Error())) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, err)
+		}
+	}
+
+	rules.MaxOrderNotional = 0
+	if err := r.check(rules, currency.USD, s(p, order.Buy, 2)); err == nil ||
+		!strings.Contains(err.Error(), errRiskMaxPositionSize.Error()) {
+		t.Errorf("expected %v, got %v", errRiskMaxPositionSize, err)
+	}
+
+	// the loss is measured from the first check of the day
+	rules.MaxDailyLoss = 40
+	if err := r.check(rules, currency.USD, s(p, order.Buy, 1)); err != nil {
+		t.Fatal(err)
+	}
+	Bot.PositionManager.process([]order.Detail{{
+		Exchange: exchName,
+		ID:       "2",
+		Pair:     p,
+		Side:     order.Sell,
+		Type:     order.Limit,
+		Price:    50,
+		Amount:   1,
+		Status:   order.Filled,
+	}})
+	if err := r.check(rules, currency.USD, s(p, order.Buy, 1)); err == nil ||
+		!strings.Contains(err.Error(), errRiskMaxDailyLoss.Error()) {
+		t.Errorf("expected %v, got %v", errRiskMaxDailyLoss, err)
+	}
+	c.Advance(time.Hour * 24)
+	if err := r.check(rules, currency.USD, s(p, order.Buy, 1)); err != nil {
+		t.Errorf("expected the daily loss to reset, got %v", err)
+	}
+}
+
+func TestRiskManagerMaxOpenOrders(t *testing.T) {
+	OrdersSetup(t)
+	var open int
+	orders, _ := Bot.OrderManager.orderStore.GetByExchange(testExchange)
+	for x := range orders {
+		if orders[x].IsOpen() {
+			open++
+		}
+	}
+
+	var r riskManager
+	rules := &config.ExchangeRiskConfig{Exchange: testExchange, MaxOpenOrders: open + 1}
+	s := &order.Submit{
+		Exchange:  testExchange,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    1,
+	}
+	if err := r.check(rules, currency.USD, s); err != nil {
+		t.Fatal(err)
+	}
+	err := Bot.OrderManager.orderStore.Add(&order.Detail{
+		Exchange: testExchange,
+		ID:       "TestRiskManagerMaxOpenOrders",
+		Status:   order.Open,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.check(rules, currency.USD, s); err == nil ||
+		!strings.Contains(err.Error(), errRiskMaxOpenOrders.Error()) {
+		t.Errorf("expected %v, got %v", errRiskMaxOpenOrders, err)
+	}
+}
+
+func TestRiskManagerDisabled(t *testing.T) {
+	SetupTestHelpers(t)
+	var r riskManager
+	err := r.Check(&order.Submit{Exchange: testExchange, Pair: currency.NewPair(currency.BTC, currency.USD)})
+	if err != nil {
+		t.Errorf("expected orders to pass when risk management is disabled, got %v", err)
+	}
+}
+
+func TestRiskManagerMaxSlippage(t *testing.T) {
+	SetupTestHelpers(t)
+	const exchName = "TestRiskManagerMaxSlippage"
+	p := currency.NewPair(currency.BTC, currency.USD)
+	var r riskManager
+	rules := &config.ExchangeRiskConfig{Exchange: exchName, MaxSlippage: 1.5}
+	s := &order.Submit{
+		Exchange:  exchName,
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    1,
+	}
+	if err := r.check(rules, currency.USD, s); err == nil {
+		t.Error("expected market orders to be rejected without an orderbook")
+	}
+
+	ob := orderbook.Base{
+		ExchangeName: exchName,
+		Pair:         p,
+		AssetType:    asset.Spot,
+		Bids:         []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 101, Amount: 1}, {Price: 103, Amount: 1}},
+	}
+	if err := ob.Process(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name   string
+		typ    order.Type
+		amount float64
+		expect error
+	}{
+		{"within slippage", order.Market, 1, nil},
+		{"slippage", order.Market, 2, errRiskMaxSlippage},
+		{"insufficient liquidity", order.Market, 3, errRiskMaxSlippage},
+		{"limit", order.Limit, 3, nil},
+	} {
+		s.Type, s.Amount, s.Price = tt.typ, tt.amount, 101
+		err := r.check(rules, currency.USD, s)
+		if tt.expect == nil && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if tt.expect != nil && (err == nil || !strings.Contains(err.Error(), tt.expect.Error())) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, err)
+		}
+	}
+}
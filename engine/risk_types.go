@@ -0,0 +1,17 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// riskManager gates every order submitted through the order manager against
+// the configured per exchange risk rules
+type riskManager struct {
+	m sync.Mutex
+	// day is the UTC day the daily loss baselines were taken on
+	day time.Time
+	// baselines is the profit and loss of each exchange when its first order
+	// of the day was checked
+	baselines map[string]float64
+}
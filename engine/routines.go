@@ -68,7 +68,8 @@ func printTickerSummary(result *ticker.Price, p currency.Pair, assetType asset.I
 		return
 	}
 
-	stats.Add(exchangeName, p, assetType, result.Last, result.Volume)
+	stats.Add(exchangeName, currency.CanonicalPair(exchangeName, p), assetType, result.Last, result.Volume)
+	Bot.EventBus.publishTicker(exchangeName, p, assetType, result)
 	if p.Quote.IsFiatCurrency() &&
 		p.Quote != Bot.Config.Currency.FiatDisplayCurrency {
 		origCurrency := p.Quote.Upper()
@@ -124,6 +125,7 @@ func printOrderbookSummary(result *orderbook.Base, p currency.Pair, assetType as
 		return
 	}
 
+	Bot.EventBus.publishOrderbook(exchangeName, result)
 	bidsAmount, bidsValue := result.TotalBidsAmount()
 	asksAmount, asksValue := result.TotalAsksAmount()
 
@@ -234,7 +236,9 @@ func WebsocketRoutine() {
 					// Data handler routine
 					go WebsocketDataReceiver(ws)
 
+					release := Bot.StartupCoordinator.step(exchanges[i].GetName())
 					err = ws.Connect()
+					release()
 					if err != nil {
 						log.Errorf(log.WebsocketMgr, "%v\n", err)
 					}
@@ -284,6 +288,13 @@ func WebsocketDataHandler(exchName string, data interface{}) error {
 	case error:
 		return fmt.Errorf("routines.go exchange %s websocket error - %s", exchName, data)
 	case wshandler.TradeData:
+		if Bot.Settings.EnableExchangeSyncManager && Bot.ExchangeCurrencyPairManager != nil {
+			Bot.ExchangeCurrencyPairManager.update(exchName,
+				d.CurrencyPair,
+				d.AssetType,
+				SyncItemTrade,
+				nil)
+		}
 		if Bot.Settings.Verbose {
 			log.Infof(log.WebsocketMgr, "%s websocket %s %s trade updated %+v",
 				exchName,
@@ -291,6 +302,11 @@ func WebsocketDataHandler(exchName string, data interface{}) error {
 				d.AssetType,
 				d)
 		}
+		if err := processWebsocketTrade(exchName, &d); err != nil {
+			log.Errorf(log.WebsocketMgr, "%s websocket trade not processed. Error: %s\n",
+				exchName,
+				err)
+		}
 	case wshandler.FundingData:
 		if Bot.Settings.Verbose {
 			log.Infof(log.WebsocketMgr, "%s websocket %s %s funding updated %+v",
@@ -333,27 +349,18 @@ func WebsocketDataHandler(exchName string, data interface{}) error {
 				FormatCurrency(d.Pair),
 				d.Asset)
 		}
+		Bot.EventBus.publishOrderbookUpdate(exchName, d.Pair, d.Asset)
 	case *order.Detail:
-		if !Bot.OrderManager.orderStore.exists(d) {
-			err := Bot.OrderManager.orderStore.Add(d)
-			if err != nil {
-				return err
-			}
-		} else {
-			od, err := Bot.OrderManager.orderStore.GetByExchangeAndID(d.Exchange, d.ID)
-			if err != nil {
-				return err
-			}
-			od.UpdateOrderFromDetail(d)
+		if Bot.OrderManager.orderStore.exists(d) {
+			return Bot.OrderManager.orderStore.Update(d)
 		}
+		return Bot.OrderManager.orderStore.Add(d)
 	case *order.Cancel:
 		return Bot.OrderManager.Cancel(d)
 	case *order.Modify:
-		od, err := Bot.OrderManager.orderStore.GetByExchangeAndID(d.Exchange, d.ID)
-		if err != nil {
-			return err
-		}
-		od.UpdateOrderFromModify(d)
+		return Bot.OrderManager.orderStore.modify(d.Exchange, d.ID, func(od *order.Detail) {
+			od.UpdateOrderFromModify(d)
+		})
 	case order.ClassificationError:
 		return errors.New(d.Error())
 	case wshandler.UnhandledMessageWarning:
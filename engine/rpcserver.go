@@ -1,14 +1,15 @@
 package engine
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
-	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,20 +26,30 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
 	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/auction"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/equity"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/gemini"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/export"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/tax"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
-	"github.com/thrasher-corp/gocryptotrader/utils"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+	"github.com/thrasher-corp/gocryptotrader/strategies/backtest"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
@@ -77,17 +88,20 @@ func authenticateClient(ctx context.Context) (context.Context, error) {
 	username := strings.Split(string(decoded), ":")[0]
 	password := strings.Split(string(decoded), ":")[1]
 
-	if username != Bot.Config.RemoteControl.Username || password != Bot.Config.RemoteControl.Password {
-		return ctx, fmt.Errorf("username/password mismatch")
+	if username == Bot.Config.RemoteControl.Username && password == Bot.Config.RemoteControl.Password {
+		return ctx, nil
 	}
 
-	return ctx, nil
+	if t, ok := getTenantByCredentials(username, password); ok {
+		return context.WithValue(ctx, tenantContextKey{}, t), nil
+	}
+
+	return ctx, fmt.Errorf("username/password mismatch")
 }
 
 // StartRPCServer starts a gRPC server with TLS auth
 func StartRPCServer() {
-	targetDir := utils.GetTLSDir(Bot.Settings.DataDir)
-	err := checkCerts(targetDir)
+	certFile, keyFile, err := remoteControlCerts()
 	if err != nil {
 		log.Errorf(log.GRPCSys, "gRPC checkCerts failed. err: %s\n", err)
 		return
@@ -100,7 +114,7 @@ func StartRPCServer() {
 		return
 	}
 
-	creds, err := credentials.NewServerTLSFromFile(filepath.Join(targetDir, "cert.pem"), filepath.Join(targetDir, "key.pem"))
+	creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
 	if err != nil {
 		log.Errorf(log.GRPCSys, "gRPC server could not load TLS keys: %s\n", err)
 		return
@@ -108,7 +122,16 @@ func StartRPCServer() {
 
 	opts := []grpc.ServerOption{
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(authenticateClient)),
+		grpc.ChainUnaryInterceptor(
+			grpcauth.UnaryServerInterceptor(authenticateClient),
+			tenantUnaryInterceptor,
+			dataOnlyUnaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			grpcauth.StreamServerInterceptor(authenticateClient),
+			tenantStreamInterceptor,
+			dataOnlyStreamInterceptor,
+		),
 	}
 	server := grpc.NewServer(opts...)
 	s := RPCServer{}
@@ -130,10 +153,16 @@ func StartRPCServer() {
 
 // StartRPCRESTProxy starts a gRPC proxy
 func StartRPCRESTProxy() {
-	log.Debugf(log.GRPCSys, "gRPC proxy server support enabled. Starting gRPC proxy server on http://%v.\n", Bot.Config.RemoteControl.GRPC.GRPCProxyListenAddress)
+	cfg := Bot.Config.RemoteControl.GRPC
+	log.Debugf(log.GRPCSys, "gRPC proxy server support enabled. Starting gRPC proxy server on %s%v.\n",
+		urlScheme("http", cfg.GRPCProxyTLS), cfg.GRPCProxyListenAddress)
 
-	targetDir := utils.GetTLSDir(Bot.Settings.DataDir)
-	creds, err := credentials.NewClientTLSFromFile(filepath.Join(targetDir, "cert.pem"), "")
+	certFile, _, err := remoteControlCerts()
+	if err != nil {
+		log.Errorf(log.GRPCSys, "Unabled to start gRPC proxy. Err: %s\n", err)
+		return
+	}
+	creds, err := credentials.NewClientTLSFromFile(certFile, "")
 	if err != nil {
 		log.Errorf(log.GRPCSys, "Unabled to start gRPC proxy. Err: %s\n", err)
 		return
@@ -154,7 +183,7 @@ func StartRPCRESTProxy() {
 	}
 
 	go func() {
-		if err := http.ListenAndServe(Bot.Config.RemoteControl.GRPC.GRPCProxyListenAddress, mux); err != nil {
+		if err := listenAndServe(cfg.GRPCProxyListenAddress, cfg.GRPCProxyTLS, mux); err != nil {
 			log.Errorf(log.GRPCSys, "gRPC proxy failed to server: %s\n", err)
 			return
 		}
@@ -237,7 +266,17 @@ func (s *RPCServer) GetCommunicationRelayers(ctx context.Context, r *gctrpc.GetC
 // GetExchanges returns a list of exchanges
 // Param is whether or not you wish to list enabled exchanges
 func (s *RPCServer) GetExchanges(ctx context.Context, r *gctrpc.GetExchangesRequest) (*gctrpc.GetExchangesResponse, error) {
-	exchanges := strings.Join(GetExchangeNames(r.Enabled), ",")
+	names := GetExchangeNames(r.Enabled)
+	if t := tenantFromContext(ctx); t != nil {
+		var owned []string
+		for x := range names {
+			if tenantOwnsExchange(t, names[x]) {
+				owned = append(owned, names[x])
+			}
+		}
+		names = owned
+	}
+	exchanges := strings.Join(names, ",")
 	return &gctrpc.GetExchangesResponse{Exchanges: exchanges}, nil
 }
 
@@ -292,9 +331,157 @@ func (s *RPCServer) GetExchangeInfo(ctx context.Context, r *gctrpc.GenericExchan
 			AvailablePairs: exchCfg.CurrencyPairs.Get(a).Available.Join(),
 		}
 	}
+
+	// features are only available for loaded exchanges
+	if exch := GetExchangeByName(r.Exchange); exch != nil {
+		resp.Features = featuresToRPC(exch.GetFeatures())
+	}
 	return resp, nil
 }
 
+func featuresToRPC(f exchange.Features) *gctrpc.ExchangeFeatures {
+	resp := &gctrpc.ExchangeFeatures{
+		Rest:      f.Supports.REST,
+		Websocket: f.Supports.Websocket,
+		Margin:    f.Supports.Margin,
+		KlineFetching: f.Supports.RESTCapabilities.KlineFetching ||
+			f.Supports.WebsocketCapabilities.KlineFetching,
+		CryptoWithdrawal: f.Supports.RESTCapabilities.CryptoWithdrawal ||
+			f.Supports.WebsocketCapabilities.CryptoWithdrawal,
+		FiatWithdrawal: f.Supports.RESTCapabilities.FiatWithdraw ||
+			f.Supports.WebsocketCapabilities.FiatWithdraw,
+		SubmitOrder: f.Supports.RESTCapabilities.SubmitOrder ||
+			f.Supports.WebsocketCapabilities.SubmitOrder,
+		ModifyOrder: f.Supports.RESTCapabilities.ModifyOrder ||
+			f.Supports.WebsocketCapabilities.ModifyOrder,
+	}
+	for x := range f.Supports.OrderTypes {
+		resp.OrderTypes = append(resp.OrderTypes, f.Supports.OrderTypes[x].String())
+	}
+	for x := range f.Supports.RateLimits {
+		resp.RateLimits = append(resp.RateLimits, &gctrpc.RateLimit{
+			Name:     f.Supports.RateLimits[x].Name,
+			Requests: int64(f.Supports.RateLimits[x].Requests),
+			Interval: f.Supports.RateLimits[x].Interval.String(),
+		})
+	}
+	return resp
+}
+
+// GetExchangeHealth returns the most recent health check of each exchange, or
+// of the specified exchange
+func (s *RPCServer) GetExchangeHealth(ctx context.Context, r *gctrpc.GetExchangeHealthRequest) (*gctrpc.GetExchangeHealthResponse, error) {
+	if !Bot.ExchangeHealthMonitor.Started() {
+		return nil, errors.New("exchange health monitor is not enabled")
+	}
+
+	health := Bot.ExchangeHealthMonitor.GetAll()
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].Exchange < health[j].Exchange
+	})
+	var resp gctrpc.GetExchangeHealthResponse
+	for x := range health {
+		if r.Exchange != "" && !strings.EqualFold(health[x].Exchange, r.Exchange) {
+			continue
+		}
+		resp.Exchanges = append(resp.Exchanges, &gctrpc.ExchangeHealth{
+			Exchange:           health[x].Exchange,
+			Status:             string(health[x].Status),
+			Reason:             health[x].Reason,
+			Requests:           health[x].Requests,
+			ErrorRate:          health[x].ErrorRate,
+			AverageLatencyMs:   int64(health[x].AverageLatency / time.Millisecond),
+			WebsocketEnabled:   health[x].WebsocketEnabled,
+			WebsocketConnected: health[x].WebsocketConnected,
+			Disconnects:        health[x].Disconnects,
+			LastChecked:        health[x].LastChecked.UTC().Format(common.SimpleTimeFormat),
+			Since:              health[x].Since.UTC().Format(common.SimpleTimeFormat),
+		})
+	}
+	if r.Exchange != "" && len(resp.Exchanges) == 0 {
+		return nil, fmt.Errorf("%s has not been checked by the exchange health monitor", r.Exchange)
+	}
+	return &resp, nil
+}
+
+// GetClockDrift returns the most recent clock drift of each exchange, or of
+// the specified exchange
+func (s *RPCServer) GetClockDrift(ctx context.Context, r *gctrpc.GetClockDriftRequest) (*gctrpc.GetClockDriftResponse, error) {
+	if !Bot.TimeSyncChecker.Started() {
+		return nil, errors.New("time sync checker is not enabled")
+	}
+
+	drifts := Bot.TimeSyncChecker.GetAll()
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Exchange < drifts[j].Exchange
+	})
+	var resp gctrpc.GetClockDriftResponse
+	for x := range drifts {
+		if r.Exchange != "" && !strings.EqualFold(drifts[x].Exchange, r.Exchange) {
+			continue
+		}
+		resp.Exchanges = append(resp.Exchanges, &gctrpc.ClockDrift{
+			Exchange:    drifts[x].Exchange,
+			DriftMs:     int64(drifts[x].Drift / time.Millisecond),
+			RoundTripMs: int64(drifts[x].RoundTrip / time.Millisecond),
+			OffsetMs:    int64(drifts[x].Offset / time.Millisecond),
+			Exceeded:    drifts[x].Exceeded,
+			Error:       drifts[x].Error,
+			LastChecked: drifts[x].LastChecked.UTC().Format(common.SimpleTimeFormat),
+		})
+	}
+	if r.Exchange != "" && len(resp.Exchanges) == 0 {
+		return nil, fmt.Errorf("%s has not been checked by the time sync checker", r.Exchange)
+	}
+	return &resp, nil
+}
+
+// GetMaintenanceWindows returns the current and upcoming maintenance windows
+// of all exchanges or a specific exchange
+func (s *RPCServer) GetMaintenanceWindows(ctx context.Context, r *gctrpc.GetMaintenanceWindowsRequest) (*gctrpc.GetMaintenanceWindowsResponse, error) {
+	if !Bot.MaintenanceManager.Started() {
+		return nil, errors.New("maintenance manager is not enabled")
+	}
+
+	var windows []ScheduledMaintenance
+	if r.Exchange != "" {
+		exch := GetExchangeByName(r.Exchange)
+		if exch == nil {
+			return nil, ErrExchangeNotFound
+		}
+		windows = Bot.MaintenanceManager.Windows(exch.GetName())
+	} else {
+		windows = Bot.MaintenanceManager.GetAll()
+	}
+
+	now := time.Now()
+	var resp gctrpc.GetMaintenanceWindowsResponse
+	for x := range windows {
+		resp.Windows = append(resp.Windows, &gctrpc.MaintenanceWindow{
+			Exchange: windows[x].Exchange,
+			Name:     windows[x].Name,
+			Source:   windows[x].Source,
+			Start:    windows[x].Start.UTC().Format(common.SimpleTimeFormat),
+			End:      windows[x].End.UTC().Format(common.SimpleTimeFormat),
+			Active:   !now.Before(windows[x].Start),
+		})
+	}
+	return &resp, nil
+}
+
+// CreateDiagnosticsBundle writes the captured failed requests of the
+// specified exchange, or all exchanges, to a diagnostics bundle
+func (s *RPCServer) CreateDiagnosticsBundle(ctx context.Context, r *gctrpc.CreateDiagnosticsBundleRequest) (*gctrpc.CreateDiagnosticsBundleResponse, error) {
+	path, captures, err := CreateDiagnosticsBundle(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.CreateDiagnosticsBundleResponse{
+		Path:     path,
+		Captures: int64(captures),
+	}, nil
+}
+
 // GetTicker returns the ticker for a specified exchange, currency pair and
 // asset type
 func (s *RPCServer) GetTicker(ctx context.Context, r *gctrpc.GetTickerRequest) (*gctrpc.TickerResponse, error) {
@@ -446,6 +633,94 @@ func (s *RPCServer) GetOrderbooks(ctx context.Context, r *gctrpc.GetOrderbooksRe
 	return &gctrpc.GetOrderbooksResponse{Orderbooks: orderbooks}, nil
 }
 
+// GetOrderbookAnalytics returns the spread, imbalance and depth of stored
+// orderbooks, an empty exchange, pair or asset type matches all
+func (s *RPCServer) GetOrderbookAnalytics(ctx context.Context, r *gctrpc.GetOrderbookAnalyticsRequest) (*gctrpc.GetOrderbookAnalyticsResponse, error) {
+	var p currency.Pair
+	if r.Pair != nil {
+		p = currency.NewPairWithDelimiter(r.Pair.Base, r.Pair.Quote, r.Pair.Delimiter)
+	}
+	analytics, err := GetOrderbookAnalytics(r.Exchange,
+		p,
+		asset.Item(strings.ToLower(r.AssetType)),
+		r.DepthPercents)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp gctrpc.GetOrderbookAnalyticsResponse
+	for x := range analytics {
+		a := &gctrpc.OrderbookAnalytics{
+			Exchange: analytics[x].Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: analytics[x].Pair.Delimiter,
+				Base:      analytics[x].Pair.Base.String(),
+				Quote:     analytics[x].Pair.Quote.String(),
+			},
+			AssetType:     analytics[x].AssetType.String(),
+			Bid:           analytics[x].Bid,
+			Ask:           analytics[x].Ask,
+			Mid:           analytics[x].Mid,
+			Spread:        analytics[x].Spread,
+			SpreadPercent: analytics[x].SpreadPercent,
+			Imbalance:     analytics[x].Imbalance,
+			LastUpdated:   analytics[x].LastUpdated.Unix(),
+		}
+		for y := range analytics[x].Depth {
+			a.Depth = append(a.Depth, &gctrpc.OrderbookDepth{
+				Percent:   analytics[x].Depth[y].Percent,
+				BidAmount: analytics[x].Depth[y].BidAmount,
+				AskAmount: analytics[x].Depth[y].AskAmount,
+				Imbalance: analytics[x].Depth[y].Imbalance,
+			})
+		}
+		resp.Analytics = append(resp.Analytics, a)
+	}
+	return &resp, nil
+}
+
+// EstimateSlippage walks a stored orderbook to estimate the average fill price
+// and slippage of a market order
+func (s *RPCServer) EstimateSlippage(ctx context.Context, r *gctrpc.EstimateSlippageRequest) (*gctrpc.EstimateSlippageResponse, error) {
+	var p currency.Pair
+	if r.Pair != nil {
+		p = currency.NewPairWithDelimiter(r.Pair.Base, r.Pair.Quote, r.Pair.Delimiter)
+	}
+	est, err := EstimateSlippage(r.Exchange,
+		p,
+		asset.Item(strings.ToLower(r.AssetType)),
+		order.Side(strings.ToUpper(r.Side)),
+		r.Amount)
+	if err != nil {
+		return nil, err
+	}
+	side := order.Sell
+	if est.Buy {
+		side = order.Buy
+	}
+	return &gctrpc.EstimateSlippageResponse{
+		Exchange: est.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: est.Pair.Delimiter,
+			Base:      est.Pair.Base.String(),
+			Quote:     est.Pair.Quote.String(),
+		},
+		AssetType:       est.AssetType.String(),
+		Side:            side.String(),
+		Amount:          est.Amount,
+		Filled:          est.Filled,
+		Cost:            est.Cost,
+		AveragePrice:    est.AveragePrice,
+		BestPrice:       est.BestPrice,
+		WorstPrice:      est.WorstPrice,
+		Mid:             est.Mid,
+		SlippagePercent: est.SlippagePercent,
+		Levels:          int64(est.Levels),
+		Sufficient:      est.Sufficient,
+		LastUpdated:     est.LastUpdated.Unix(),
+	}, nil
+}
+
 // GetAccountInfo returns an account balance for a specific exchange
 func (s *RPCServer) GetAccountInfo(ctx context.Context, r *gctrpc.GetAccountInfoRequest) (*gctrpc.GetAccountInfoResponse, error) {
 	exch := GetExchangeByName(r.Exchange)
@@ -453,7 +728,7 @@ func (s *RPCServer) GetAccountInfo(ctx context.Context, r *gctrpc.GetAccountInfo
 		return nil, errors.New("exchange is not loaded/doesn't exist")
 	}
 
-	resp, err := exch.FetchAccountInfo()
+	resp, err := getAccountInfo(exch)
 	if err != nil {
 		return nil, err
 	}
@@ -467,6 +742,7 @@ func (s *RPCServer) GetAccountInfo(ctx context.Context, r *gctrpc.GetAccountInfo
 				Currency:   y.CurrencyName.String(),
 				Hold:       y.Hold,
 				TotalValue: y.TotalValue,
+				Available:  y.Available,
 			})
 		}
 		accounts = append(accounts, &a)
@@ -486,7 +762,7 @@ func (s *RPCServer) GetAccountInfoStream(r *gctrpc.GetAccountInfoRequest, stream
 		return errors.New("exchange is not loaded/doesn't exist")
 	}
 
-	initAcc, err := exch.FetchAccountInfo()
+	initAcc, err := getAccountInfo(exch)
 	if err != nil {
 		return err
 	}
@@ -499,6 +775,7 @@ func (s *RPCServer) GetAccountInfoStream(r *gctrpc.GetAccountInfoRequest, stream
 				Currency:   initAcc.Accounts[x].Currencies[y].CurrencyName.String(),
 				TotalValue: initAcc.Accounts[x].Currencies[y].TotalValue,
 				Hold:       initAcc.Accounts[x].Currencies[y].Hold,
+				Available:  initAcc.Accounts[x].Currencies[y].Available,
 			})
 		}
 		accounts = append(accounts, &gctrpc.Account{
@@ -538,6 +815,7 @@ func (s *RPCServer) GetAccountInfoStream(r *gctrpc.GetAccountInfoRequest, stream
 					Currency:   acc.Accounts[x].Currencies[y].CurrencyName.String(),
 					TotalValue: acc.Accounts[x].Currencies[y].TotalValue,
 					Hold:       acc.Accounts[x].Currencies[y].Hold,
+					Available:  acc.Accounts[x].Currencies[y].Available,
 				})
 			}
 			accounts = append(accounts, &gctrpc.Account{
@@ -564,7 +842,7 @@ func (s *RPCServer) GetConfig(ctx context.Context, r *gctrpc.GetConfigRequest) (
 // GetPortfolio returns the portfolio details
 func (s *RPCServer) GetPortfolio(ctx context.Context, r *gctrpc.GetPortfolioRequest) (*gctrpc.GetPortfolioResponse, error) {
 	var addrs []*gctrpc.PortfolioAddress
-	botAddrs := Bot.Portfolio.Addresses
+	botAddrs := portfolioViewFromContext(ctx).Addresses
 
 	for x := range botAddrs {
 		addrs = append(addrs, &gctrpc.PortfolioAddress{
@@ -584,8 +862,13 @@ func (s *RPCServer) GetPortfolio(ctx context.Context, r *gctrpc.GetPortfolioRequ
 
 // GetPortfolioSummary returns the portfolio summary
 func (s *RPCServer) GetPortfolioSummary(ctx context.Context, r *gctrpc.GetPortfolioSummaryRequest) (*gctrpc.GetPortfolioSummaryResponse, error) {
-	result := Bot.Portfolio.GetPortfolioSummary()
-	var resp gctrpc.GetPortfolioSummaryResponse
+	result := getPortfolioSummary(portfolioViewFromContext(ctx),
+		currency.NewCode(r.BaseCurrency))
+	resp := gctrpc.GetPortfolioSummaryResponse{
+		BaseCurrency: result.BaseCurrency.String(),
+		TotalValue:   result.TotalValue,
+		Unvalued:     result.Unvalued.Strings(),
+	}
 
 	p := func(coins []portfolio.Coin) []*gctrpc.Coin {
 		var c []*gctrpc.Coin
@@ -596,6 +879,7 @@ func (s *RPCServer) GetPortfolioSummary(ctx context.Context, r *gctrpc.GetPortfo
 					Balance:    coins[x].Balance,
 					Address:    coins[x].Address,
 					Percentage: coins[x].Percentage,
+					Value:      coins[x].Value,
 				},
 			)
 		}
@@ -640,7 +924,7 @@ func (s *RPCServer) GetPortfolioSummary(ctx context.Context, r *gctrpc.GetPortfo
 
 // AddPortfolioAddress adds an address to the portfolio manager
 func (s *RPCServer) AddPortfolioAddress(ctx context.Context, r *gctrpc.AddPortfolioAddressRequest) (*gctrpc.AddPortfolioAddressResponse, error) {
-	err := Bot.Portfolio.AddAddress(r.Address, r.Description, currency.NewCode(r.CoinType), r.Balance)
+	err := portfolioFromContext(ctx).AddAddress(r.Address, r.Description, currency.NewCode(r.CoinType), r.Balance)
 	if err != nil {
 		return nil, err
 	}
@@ -649,7 +933,7 @@ func (s *RPCServer) AddPortfolioAddress(ctx context.Context, r *gctrpc.AddPortfo
 
 // RemovePortfolioAddress removes an address from the portfolio manager
 func (s *RPCServer) RemovePortfolioAddress(ctx context.Context, r *gctrpc.RemovePortfolioAddressRequest) (*gctrpc.RemovePortfolioAddressResponse, error) {
-	err := Bot.Portfolio.RemoveAddress(r.Address, r.Description, currency.NewCode(r.CoinType))
+	err := portfolioFromContext(ctx).RemoveAddress(r.Address, r.Description, currency.NewCode(r.CoinType))
 	return &gctrpc.RemovePortfolioAddressResponse{}, err
 }
 
@@ -797,16 +1081,51 @@ func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderReques
 	}
 
 	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
-	resp, err := Bot.OrderManager.Submit(&order.Submit{
-		Pair:     p,
-		Side:     order.Side(r.Side),
-		Type:     order.Type(r.OrderType),
-		Amount:   r.Amount,
-		Price:    r.Price,
-		ClientID: r.ClientId,
-		Exchange: r.Exchange,
-	})
+	submit := order.Submit{
+		Pair:                p,
+		Side:                order.Side(r.Side),
+		Type:                order.Type(r.OrderType),
+		Amount:              r.Amount,
+		Price:               r.Price,
+		ClientID:            r.ClientId,
+		Exchange:            r.Exchange,
+		SelfTradePrevention: order.SelfTradePrevention(strings.ToUpper(r.SelfTradePrevention)),
+		FallbackExchanges:   r.FallbackExchanges,
+		PostOnly:            r.PostOnly,
+		ImmediateOrCancel:   r.ImmediateOrCancel,
+		FillOrKill:          r.FillOrKill,
+		AuctionOnly:         r.AuctionOnly,
+	}
+
+	if t := tenantFromContext(ctx); t != nil {
+		for x := range r.FallbackExchanges {
+			if !tenantOwnsExchange(t, r.FallbackExchanges[x]) {
+				return nil, errTenantExchangeNotAllowed
+			}
+		}
+	}
+
+	if r.Chase != nil {
+		ch, err := Bot.ChaseManager.Submit(&Chase{
+			Order: submit,
+			Options: ChaseOptions{
+				Timeout:         time.Duration(r.Chase.TimeoutSeconds) * time.Second,
+				MaxDeviation:    r.Chase.MaxDeviation,
+				MaxAttempts:     r.Chase.MaxAttempts,
+				ConvertToMarket: r.Chase.ConvertToMarket,
+			},
+		})
+		if err != nil {
+			return &gctrpc.SubmitOrderResponse{}, err
+		}
+		return &gctrpc.SubmitOrderResponse{
+			OrderId:     ch.OrderID,
+			OrderPlaced: true,
+			ChaseId:     ch.ID,
+		}, nil
+	}
 
+	resp, err := Bot.OrderManager.Submit(&submit)
 	if err != nil {
 		return &gctrpc.SubmitOrderResponse{}, err
 	}
@@ -814,6 +1133,8 @@ func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderReques
 	return &gctrpc.SubmitOrderResponse{
 		OrderId:     resp.OrderID,
 		OrderPlaced: resp.IsOrderPlaced,
+		Exchange:    submit.Exchange,
+		ClientId:    submit.ClientID,
 	}, err
 }
 
@@ -891,6 +1212,126 @@ func (s *RPCServer) WhaleBomb(ctx context.Context, r *gctrpc.WhaleBombRequest) (
 	return &resp, err
 }
 
+// SimulatePortfolioImpact simulates the impact of hypothetical orders on the
+// portfolio's exposure, margin usage and risk limits without placing them
+func (s *RPCServer) SimulatePortfolioImpact(ctx context.Context, r *gctrpc.SimulatePortfolioImpactRequest) (*gctrpc.SimulatePortfolioImpactResponse, error) {
+	var orders []order.Submit
+	for x := range r.Orders {
+		if r.Orders[x].Pair == nil {
+			return nil, order.ErrPairIsEmpty
+		}
+		orders = append(orders, order.Submit{
+			Exchange:  r.Orders[x].Exchange,
+			Pair:      currency.NewPairFromStrings(r.Orders[x].Pair.Base, r.Orders[x].Pair.Quote),
+			AssetType: asset.Item(strings.ToLower(r.Orders[x].AssetType)),
+			Side:      order.Side(strings.ToUpper(r.Orders[x].Side)),
+			Type:      order.Type(strings.ToUpper(r.Orders[x].OrderType)),
+			Amount:    r.Orders[x].Amount,
+			Price:     r.Orders[x].Price,
+		})
+	}
+
+	result, err := SimulatePortfolioImpact(orders)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := gctrpc.SimulatePortfolioImpactResponse{
+		Currency: result.Currency.String(),
+		Before:   riskExposureToRPC(&result.Before),
+		After:    riskExposureToRPC(&result.After),
+		Warnings: result.Warnings,
+	}
+	for x := range result.Fills {
+		resp.Fills = append(resp.Fills, whatIfFillToRPC(&result.Fills[x]))
+	}
+	for x := range result.Limits {
+		resp.Limits = append(resp.Limits, &gctrpc.RiskLimitUtilisation{
+			Limit:    result.Limits[x].Limit,
+			Value:    result.Limits[x].Value,
+			Before:   result.Limits[x].Before,
+			After:    result.Limits[x].After,
+			Breached: result.Limits[x].Breached,
+		})
+	}
+	return &resp, nil
+}
+
+// PreviewOrder returns the top of book, simulated fill and estimated fee of an
+// order without placing it
+func (s *RPCServer) PreviewOrder(ctx context.Context, r *gctrpc.PreviewOrderRequest) (*gctrpc.PreviewOrderResponse, error) {
+	if r.Pair == nil {
+		return nil, order.ErrPairIsEmpty
+	}
+
+	preview, err := PreviewOrder(&order.Submit{
+		Exchange:  r.Exchange,
+		Pair:      currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+		AssetType: asset.Item(strings.ToLower(r.AssetType)),
+		Side:      order.Side(strings.ToUpper(r.Side)),
+		Type:      order.Type(strings.ToUpper(r.OrderType)),
+		Amount:    r.Amount,
+		Price:     r.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.PreviewOrderResponse{
+		BestBid:       preview.BestBid,
+		BestBidAmount: preview.BestBidAmount,
+		BestAsk:       preview.BestAsk,
+		BestAskAmount: preview.BestAskAmount,
+		Fill:          whatIfFillToRPC(&preview.Fill),
+		Fee:           preview.Fee,
+		IsMaker:       preview.IsMaker,
+		Total:         preview.Total,
+		Warnings:      preview.Warnings,
+	}, nil
+}
+
+func whatIfFillToRPC(f *WhatIfFill) *gctrpc.WhatIfFill {
+	return &gctrpc.WhatIfFill{
+		Exchange: f.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: f.Pair.Delimiter,
+			Base:      f.Pair.Base.String(),
+			Quote:     f.Pair.Quote.String(),
+		},
+		AssetType:    f.AssetType.String(),
+		Side:         f.Side.String(),
+		Amount:       f.Amount,
+		BookAmount:   f.BookAmount,
+		AveragePrice: f.AveragePrice,
+		Slippage:     f.Slippage,
+		Value:        f.Value,
+	}
+}
+
+func riskExposureToRPC(r *RiskExposure) *gctrpc.RiskExposure {
+	resp := &gctrpc.RiskExposure{
+		Equity:        r.Equity,
+		GrossExposure: r.GrossExposure,
+		NetExposure:   r.NetExposure,
+		LargestPosition: &gctrpc.HoldingExposure{
+			Currency: r.LargestPosition.Currency.String(),
+			Amount:   r.LargestPosition.Amount,
+			Value:    r.LargestPosition.Value,
+		},
+		MarginUsed:  r.MarginUsed,
+		MarginUsage: r.MarginUsage,
+		Leverage:    r.Leverage,
+	}
+	for x := range r.Holdings {
+		resp.Holdings = append(resp.Holdings, &gctrpc.HoldingExposure{
+			Currency: r.Holdings[x].Currency.String(),
+			Amount:   r.Holdings[x].Amount,
+			Value:    r.Holdings[x].Value,
+		})
+	}
+	return resp
+}
+
 // CancelOrder cancels an order specified by exchange, currency pair and asset
 // type
 func (s *RPCServer) CancelOrder(ctx context.Context, r *gctrpc.CancelOrderRequest) (*gctrpc.CancelOrderResponse, error) {
@@ -899,6 +1340,14 @@ func (s *RPCServer) CancelOrder(ctx context.Context, r *gctrpc.CancelOrderReques
 		return nil, errors.New("exchange is not loaded/doesn't exist")
 	}
 
+	if Bot.Settings.EnableDryRun {
+		log.Warnf(log.GRPCSys,
+			"Dry run enabled, cancel request for %s order ID=%v not sent.\n",
+			r.Exchange,
+			r.OrderId)
+		return &gctrpc.CancelOrderResponse{}, nil
+	}
+
 	err := exch.CancelOrder(&order.Cancel{
 		AccountID:     r.AccountId,
 		ID:            r.OrderId,
@@ -910,125 +1359,887 @@ func (s *RPCServer) CancelOrder(ctx context.Context, r *gctrpc.CancelOrderReques
 	return &gctrpc.CancelOrderResponse{}, err
 }
 
-// CancelAllOrders cancels all orders, filterable by exchange
-func (s *RPCServer) CancelAllOrders(ctx context.Context, r *gctrpc.CancelAllOrdersRequest) (*gctrpc.CancelAllOrdersResponse, error) {
-	return &gctrpc.CancelAllOrdersResponse{}, common.ErrNotYetImplemented
-}
-
-// GetEvents returns the stored events list
-func (s *RPCServer) GetEvents(ctx context.Context, r *gctrpc.GetEventsRequest) (*gctrpc.GetEventsResponse, error) {
-	return &gctrpc.GetEventsResponse{}, common.ErrNotYetImplemented
-}
-
-// AddEvent adds an event
-func (s *RPCServer) AddEvent(ctx context.Context, r *gctrpc.AddEventRequest) (*gctrpc.AddEventResponse, error) {
-	evtCondition := EventConditionParams{
-		CheckBids:        r.ConditionParams.CheckBids,
-		CheckBidsAndAsks: r.ConditionParams.CheckBidsAndAsks,
-		Condition:        r.ConditionParams.Condition,
-		OrderbookAmount:  r.ConditionParams.OrderbookAmount,
-		Price:            r.ConditionParams.Price,
+// SubmitIntent submits a multi-leg intent, executing each leg in sequence and
+// unwinding any placed legs if a subsequent leg fails
+func (s *RPCServer) SubmitIntent(ctx context.Context, r *gctrpc.SubmitIntentRequest) (*gctrpc.IntentDetails, error) {
+	intent := &Intent{
+		Description: r.Description,
+		UnwindRule:  UnwindRule(strings.ToUpper(r.UnwindRule)),
+	}
+	for x := range r.Legs {
+		if r.Legs[x].Pair == nil {
+			return nil, errors.New(errCurrencyPairUnset)
+		}
+		a := asset.Item(r.Legs[x].AssetType)
+		if a == "" {
+			a = asset.Spot
+		}
+		intent.Legs = append(intent.Legs, IntentLeg{
+			Order: order.Submit{
+				Exchange:  r.Legs[x].Exchange,
+				Pair:      currency.NewPairFromStrings(r.Legs[x].Pair.Base, r.Legs[x].Pair.Quote),
+				AssetType: a,
+				Side:      order.Side(strings.ToUpper(r.Legs[x].Side)),
+				Type:      order.Type(strings.ToUpper(r.Legs[x].OrderType)),
+				Amount:    r.Legs[x].Amount,
+				Price:     r.Legs[x].Price,
+				ClientID:  r.Legs[x].ClientId,
+			},
+		})
 	}
 
-	p := currency.NewPairWithDelimiter(r.Pair.Base,
-		r.Pair.Quote, r.Pair.Delimiter)
-
-	id, err := Add(r.Exchange, r.Item, evtCondition, p, asset.Item(r.AssetType), r.Action)
+	resp, err := Bot.IntentManager.Submit(intent)
 	if err != nil {
 		return nil, err
 	}
+	return intentToRPC(resp), nil
+}
 
-	return &gctrpc.AddEventResponse{Id: id}, nil
+// GetIntent returns the leg and intent level status of a specific intent
+func (s *RPCServer) GetIntent(ctx context.Context, r *gctrpc.GetIntentRequest) (*gctrpc.IntentDetails, error) {
+	resp, err := Bot.IntentManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return intentToRPC(resp), nil
 }
 
-// RemoveEvent removes an event, specified by an event ID
-func (s *RPCServer) RemoveEvent(ctx context.Context, r *gctrpc.RemoveEventRequest) (*gctrpc.RemoveEventResponse, error) {
-	Remove(r.Id)
-	return &gctrpc.RemoveEventResponse{}, nil
+// GetIntents returns the leg and intent level status of all intents
+func (s *RPCServer) GetIntents(ctx context.Context, r *gctrpc.GetIntentsRequest) (*gctrpc.GetIntentsResponse, error) {
+	intents := Bot.IntentManager.GetAll()
+	var resp gctrpc.GetIntentsResponse
+	for x := range intents {
+		resp.Intents = append(resp.Intents, intentToRPC(&intents[x]))
+	}
+	return &resp, nil
 }
 
-// GetCryptocurrencyDepositAddresses returns a list of cryptocurrency deposit
-// addresses specified by an exchange
-func (s *RPCServer) GetCryptocurrencyDepositAddresses(ctx context.Context, r *gctrpc.GetCryptocurrencyDepositAddressesRequest) (*gctrpc.GetCryptocurrencyDepositAddressesResponse, error) {
-	exch := GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errors.New("exchange is not loaded/doesn't exist")
+// GetTriangularArbitrage returns the triangular arbitrage cycles of the most
+// recent scan sorted by their implied profit
+func (s *RPCServer) GetTriangularArbitrage(ctx context.Context, r *gctrpc.GetTriangularArbitrageRequest) (*gctrpc.GetTriangularArbitrageResponse, error) {
+	cycles := Bot.TriangularArbDetector.GetCycles(r.Exchange, r.OpportunitiesOnly)
+	var resp gctrpc.GetTriangularArbitrageResponse
+	for x := range cycles {
+		c := &gctrpc.TriangularCycle{
+			Exchange:    cycles[x].Exchange,
+			AssetType:   cycles[x].AssetType.String(),
+			Cycle:       cycles[x].String(),
+			Rate:        cycles[x].Rate,
+			Profit:      cycles[x].Profit,
+			Opportunity: cycles[x].Opportunity,
+			LastChecked: cycles[x].LastChecked.Unix(),
+			IntentId:    cycles[x].IntentID,
+			Error:       cycles[x].Error,
+		}
+		if !cycles[x].Detected.IsZero() {
+			c.Detected = cycles[x].Detected.Unix()
+		}
+		for y := range cycles[x].Legs {
+			l := &cycles[x].Legs[y]
+			c.Legs = append(c.Legs, &gctrpc.TriangularLeg{
+				Pair: &gctrpc.CurrencyPair{
+					Delimiter: l.Pair.Delimiter,
+					Base:      l.Pair.Base.String(),
+					Quote:     l.Pair.Quote.String(),
+				},
+				Side:  l.Side.String(),
+				From:  l.From.String(),
+				To:    l.To.String(),
+				Price: l.Price,
+				Fee:   l.Fee,
+				Rate:  l.Rate,
+			})
+		}
+		resp.Cycles = append(resp.Cycles, c)
 	}
+	return &resp, nil
+}
 
-	result, err := GetCryptocurrencyDepositAddressesByExchange(r.Exchange)
-	return &gctrpc.GetCryptocurrencyDepositAddressesResponse{Addresses: result}, err
+// ExecuteTriangularArbitrage submits the legs of a triangular arbitrage cycle
+// of the most recent scan as an intent, the amount of the start currency
+// defaults to its configured trade amount
+func (s *RPCServer) ExecuteTriangularArbitrage(ctx context.Context, r *gctrpc.ExecuteTriangularArbitrageRequest) (*gctrpc.IntentDetails, error) {
+	c, err := Bot.TriangularArbDetector.GetCycle(r.Exchange, r.Cycle)
+	if err != nil {
+		return nil, err
+	}
+	intent, err := Bot.TriangularArbDetector.Execute(c, r.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return intentToRPC(intent), nil
 }
 
-// GetCryptocurrencyDepositAddress returns a cryptocurrency deposit address
-// specified by exchange and cryptocurrency
-func (s *RPCServer) GetCryptocurrencyDepositAddress(ctx context.Context, r *gctrpc.GetCryptocurrencyDepositAddressRequest) (*gctrpc.GetCryptocurrencyDepositAddressResponse, error) {
-	exch := GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errors.New("exchange is not loaded/doesn't exist")
+func intentToRPC(i *Intent) *gctrpc.IntentDetails {
+	resp := &gctrpc.IntentDetails{
+		Id:           i.ID,
+		Description:  i.Description,
+		UnwindRule:   string(i.UnwindRule),
+		Status:       string(i.Status),
+		CreationTime: i.Created.Unix(),
+		LastUpdated:  i.LastUpdated.Unix(),
+	}
+	for x := range i.Legs {
+		resp.Legs = append(resp.Legs, &gctrpc.IntentLeg{
+			Exchange: i.Legs[x].Order.Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: i.Legs[x].Order.Pair.Delimiter,
+				Base:      i.Legs[x].Order.Pair.Base.String(),
+				Quote:     i.Legs[x].Order.Pair.Quote.String(),
+			},
+			AssetType:       i.Legs[x].Order.AssetType.String(),
+			Side:            i.Legs[x].Order.Side.String(),
+			OrderType:       i.Legs[x].Order.Type.String(),
+			Amount:          i.Legs[x].Order.Amount,
+			Price:           i.Legs[x].Order.Price,
+			ClientId:        i.Legs[x].Order.ClientID,
+			Status:          string(i.Legs[x].Status),
+			OrderId:         i.Legs[x].OrderID,
+			InternalOrderId: i.Legs[x].InternalOrderID,
+			UnwindOrderId:   i.Legs[x].UnwindOrderID,
+			Error:           i.Legs[x].Error,
+		})
 	}
+	return resp
+}
 
-	addr, err := GetExchangeCryptocurrencyDepositAddress(r.Exchange, "", currency.NewCode(r.Cryptocurrency))
-	return &gctrpc.GetCryptocurrencyDepositAddressResponse{Address: addr}, err
+// GetChase returns the status of a chased order
+func (s *RPCServer) GetChase(ctx context.Context, r *gctrpc.GetChaseRequest) (*gctrpc.ChaseDetails, error) {
+	resp, err := Bot.ChaseManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return chaseToRPC(resp), nil
 }
 
-// WithdrawCryptocurrencyFunds withdraws cryptocurrency funds specified by
-// exchange
-func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
-	exch := GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errors.New("exchange is not loaded/doesn't exist")
+// GetChases returns the status of all chased orders
+func (s *RPCServer) GetChases(ctx context.Context, r *gctrpc.GetChasesRequest) (*gctrpc.GetChasesResponse, error) {
+	chases := Bot.ChaseManager.GetAll()
+	var resp gctrpc.GetChasesResponse
+	for x := range chases {
+		resp.Chases = append(resp.Chases, chaseToRPC(&chases[x]))
 	}
+	return &resp, nil
+}
 
-	request := &withdraw.Request{
-		Amount:      r.Amount,
-		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
-		Type:        withdraw.Crypto,
-		Description: r.Description,
-		Crypto: &withdraw.CryptoRequest{
-			Address:    r.Address,
-			AddressTag: r.AddressTag,
-			FeeAmount:  r.Fee,
+func chaseToRPC(c *Chase) *gctrpc.ChaseDetails {
+	return &gctrpc.ChaseDetails{
+		Id:       c.ID,
+		Exchange: c.Order.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: c.Order.Pair.Delimiter,
+			Base:      c.Order.Pair.Base.String(),
+			Quote:     c.Order.Pair.Quote.String(),
+		},
+		Side: c.Order.Side.String(),
+		Options: &gctrpc.ChaseOptions{
+			TimeoutSeconds:  int64(c.Options.Timeout / time.Second),
+			MaxDeviation:    c.Options.MaxDeviation,
+			MaxAttempts:     c.Options.MaxAttempts,
+			ConvertToMarket: c.Options.ConvertToMarket,
+		},
+		Status:       string(c.Status),
+		StartPrice:   c.StartPrice,
+		Price:        c.Price,
+		Filled:       c.Filled,
+		Remaining:    c.Remaining,
+		Attempts:     c.Attempts,
+		OrderId:      c.OrderID,
+		Error:        c.Error,
+		CreationTime: c.Created.Unix(),
+		LastUpdated:  c.LastUpdated.Unix(),
+	}
+}
+
+// SubmitAlgoOrder executes a parent order as timed TWAP slices or refreshing
+// iceberg slices
+func (s *RPCServer) SubmitAlgoOrder(ctx context.Context, r *gctrpc.SubmitAlgoOrderRequest) (*gctrpc.AlgoDetails, error) {
+	if r.Pair == nil {
+		return nil, order.ErrPairIsEmpty
+	}
+	algo := Algo{
+		Type: AlgoType(strings.ToUpper(r.AlgoType)),
+		Order: order.Submit{
+			Exchange:  r.Exchange,
+			Pair:      currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+			AssetType: asset.Item(strings.ToLower(r.AssetType)),
+			Side:      order.Side(strings.ToUpper(r.Side)),
+			Type:      order.Type(strings.ToUpper(r.OrderType)),
+			Amount:    r.Amount,
+			Price:     r.Price,
 		},
 	}
-
-	resp, err := SubmitWithdrawal(r.Exchange, request)
+	if r.Options != nil {
+		algo.Options = AlgoOptions{
+			Duration:        time.Duration(r.Options.DurationSeconds) * time.Second,
+			Slices:          r.Options.Slices,
+			SliceSize:       r.Options.SliceSize,
+			Jitter:          r.Options.Jitter,
+			MaxAdverseMove:  r.Options.MaxAdverseMove,
+			RefreshInterval: time.Duration(r.Options.RefreshIntervalSeconds) * time.Second,
+		}
+	}
+	resp, err := Bot.AlgoManager.Submit(&algo)
 	if err != nil {
 		return nil, err
 	}
-
-	return &gctrpc.WithdrawResponse{
-		Id:     resp.ID.String(),
-		Status: resp.Exchange.Status,
-	}, nil
+	return algoToRPC(resp), nil
 }
 
-// WithdrawFiatFunds withdraws fiat funds specified by exchange
-func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFiatRequest) (*gctrpc.WithdrawResponse, error) {
-	exch := GetExchangeByName(r.Exchange)
-	if exch == nil {
-		return nil, errors.New("exchange is not loaded/doesn't exist")
+// GetAlgoOrder returns the status of an execution algo
+func (s *RPCServer) GetAlgoOrder(ctx context.Context, r *gctrpc.GetAlgoOrderRequest) (*gctrpc.AlgoDetails, error) {
+	resp, err := Bot.AlgoManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
 	}
+	return algoToRPC(resp), nil
+}
 
-	var bankAccount *banking.Account
-
-	bankAccount, err := banking.GetBankAccountByID(r.BankAccountId)
-	if err != nil {
-		bankAccount, err = exch.GetBase().GetExchangeBankAccounts(r.BankAccountId, r.Currency)
-		if err != nil {
-			return nil, err
-		}
+// GetAlgoOrders returns the status of all execution algos
+func (s *RPCServer) GetAlgoOrders(ctx context.Context, r *gctrpc.GetAlgoOrdersRequest) (*gctrpc.GetAlgoOrdersResponse, error) {
+	algos := Bot.AlgoManager.GetAll()
+	var resp gctrpc.GetAlgoOrdersResponse
+	for x := range algos {
+		resp.Algos = append(resp.Algos, algoToRPC(&algos[x]))
 	}
+	return &resp, nil
+}
 
-	request := &withdraw.Request{
-		Amount:      r.Amount,
-		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
-		Type:        withdraw.Fiat,
-		Description: r.Description,
-		Fiat: &withdraw.FiatRequest{
-			Bank: bankAccount,
-		},
+// CancelAlgoOrder stops an execution algo and cancels its working child order
+func (s *RPCServer) CancelAlgoOrder(ctx context.Context, r *gctrpc.CancelAlgoOrderRequest) (*gctrpc.AlgoDetails, error) {
+	err := Bot.AlgoManager.Cancel(r.Id)
+	if err != nil {
+		return nil, err
 	}
-	resp, err := SubmitWithdrawal(r.Exchange, request)
+	resp, err := Bot.AlgoManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return algoToRPC(resp), nil
+}
+
+func algoToRPC(a *Algo) *gctrpc.AlgoDetails {
+	return &gctrpc.AlgoDetails{
+		Id:       a.ID,
+		AlgoType: string(a.Type),
+		Exchange: a.Order.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: a.Order.Pair.Delimiter,
+			Base:      a.Order.Pair.Base.String(),
+			Quote:     a.Order.Pair.Quote.String(),
+		},
+		AssetType: a.Order.AssetType.String(),
+		Side:      a.Order.Side.String(),
+		OrderType: a.Order.Type.String(),
+		Amount:    a.Order.Amount,
+		Price:     a.Order.Price,
+		Options: &gctrpc.AlgoOptions{
+			DurationSeconds:        int64(a.Options.Duration / time.Second),
+			Slices:                 a.Options.Slices,
+			SliceSize:              a.Options.SliceSize,
+			Jitter:                 a.Options.Jitter,
+			MaxAdverseMove:         a.Options.MaxAdverseMove,
+			RefreshIntervalSeconds: int64(a.Options.RefreshInterval / time.Second),
+		},
+		Status:       string(a.Status),
+		ArrivalPrice: a.ArrivalPrice,
+		Filled:       a.Filled,
+		Remaining:    a.Remaining,
+		Working:      a.Working,
+		Slices:       a.Slices,
+		OrderId:      a.OrderID,
+		Error:        a.Error,
+		CreationTime: a.Created.Unix(),
+		LastUpdated:  a.LastUpdated.Unix(),
+	}
+}
+
+// AddConditionalOrder stores a stop-loss, take-profit or trailing stop order
+// which is submitted once the ticker reaches its trigger price
+func (s *RPCServer) AddConditionalOrder(ctx context.Context, r *gctrpc.AddConditionalOrderRequest) (*gctrpc.ConditionalOrderDetails, error) {
+	if r.Pair == nil {
+		return nil, order.ErrPairIsEmpty
+	}
+	resp, err := Bot.ConditionalManager.Add(&ConditionalOrder{
+		Type: ConditionalType(strings.ToUpper(r.ConditionalType)),
+		Order: order.Submit{
+			Exchange:  r.Exchange,
+			Pair:      currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+			AssetType: asset.Item(strings.ToLower(r.AssetType)),
+			Side:      order.Side(strings.ToUpper(r.Side)),
+			Type:      order.Type(strings.ToUpper(r.OrderType)),
+			Amount:    r.Amount,
+			Price:     r.Price,
+		},
+		TriggerPrice: r.TriggerPrice,
+		TrailOffset:  r.TrailOffset,
+		TrailPercent: r.TrailPercent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conditionalToRPC(resp), nil
+}
+
+// GetConditionalOrders returns all conditional orders
+func (s *RPCServer) GetConditionalOrders(ctx context.Context, r *gctrpc.GetConditionalOrdersRequest) (*gctrpc.GetConditionalOrdersResponse, error) {
+	orders := Bot.ConditionalManager.GetAll()
+	var resp gctrpc.GetConditionalOrdersResponse
+	for x := range orders {
+		resp.Orders = append(resp.Orders, conditionalToRPC(&orders[x]))
+	}
+	return &resp, nil
+}
+
+// CancelConditionalOrder cancels a pending conditional order
+func (s *RPCServer) CancelConditionalOrder(ctx context.Context, r *gctrpc.CancelConditionalOrderRequest) (*gctrpc.ConditionalOrderDetails, error) {
+	err := Bot.ConditionalManager.Cancel(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := Bot.ConditionalManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return conditionalToRPC(resp), nil
+}
+
+func conditionalToRPC(c *ConditionalOrder) *gctrpc.ConditionalOrderDetails {
+	resp := &gctrpc.ConditionalOrderDetails{
+		Id:              c.ID,
+		ConditionalType: string(c.Type),
+		Exchange:        c.Order.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: c.Order.Pair.Delimiter,
+			Base:      c.Order.Pair.Base.String(),
+			Quote:     c.Order.Pair.Quote.String(),
+		},
+		AssetType:      c.Order.AssetType.String(),
+		Side:           c.Order.Side.String(),
+		OrderType:      c.Order.Type.String(),
+		Amount:         c.Order.Amount,
+		Price:          c.Order.Price,
+		TriggerPrice:   c.TriggerPrice,
+		Status:         string(c.Status),
+		TriggeredPrice: c.TriggeredPrice,
+		OrderId:        c.OrderID,
+		Error:          c.Error,
+		CreationTime:   c.Created.Unix(),
+		TrailOffset:    c.TrailOffset,
+		TrailPercent:   c.TrailPercent,
+		BestPrice:      c.BestPrice,
+	}
+	if !c.Triggered.IsZero() {
+		resp.TriggeredTime = c.Triggered.Unix()
+	}
+	return resp
+}
+
+// GetAutomations returns the declarative automations and their state
+func (s *RPCServer) GetAutomations(ctx context.Context, r *gctrpc.GetAutomationsRequest) (*gctrpc.GetAutomationsResponse, error) {
+	if !Bot.AutomationManager.Started() {
+		return nil, errAutomationManagerNotStarted
+	}
+	automations := Bot.AutomationManager.GetAll()
+	var resp gctrpc.GetAutomationsResponse
+	for x := range automations {
+		a := &automations[x]
+		details := &gctrpc.AutomationDetails{
+			Name:      a.Name,
+			Condition: a.Condition,
+			Exchange:  a.Order.Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: a.Order.Pair.Delimiter,
+				Base:      a.Order.Pair.Base.String(),
+				Quote:     a.Order.Pair.Quote.String(),
+			},
+			AssetType:   a.Order.AssetType.String(),
+			Side:        a.Order.Side.String(),
+			OrderType:   a.Order.Type.String(),
+			Amount:      a.Order.Amount,
+			QuoteAmount: a.QuoteAmount,
+			Price:       a.Order.Price,
+			Max:         int64(a.Max),
+			PerSeconds:  int64(a.Per / time.Second),
+			Matched:     a.Matched,
+			LastValue:   a.LastValue,
+			LastOrderId: a.LastOrderID,
+			Error:       a.LastError,
+		}
+		for i := range a.Executions {
+			details.Executions = append(details.Executions, a.Executions[i].Unix())
+		}
+		if !a.LastChecked.IsZero() {
+			details.LastChecked = a.LastChecked.Unix()
+		}
+		resp.Automations = append(resp.Automations, details)
+	}
+	return &resp, nil
+}
+
+// ReloadAutomations recompiles the automations YAML file
+func (s *RPCServer) ReloadAutomations(ctx context.Context, r *gctrpc.ReloadAutomationsRequest) (*gctrpc.ReloadAutomationsResponse, error) {
+	loaded, err := Bot.AutomationManager.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.ReloadAutomationsResponse{Loaded: int64(loaded)}, nil
+}
+
+// GetStrategies returns the status of each configured strategy instance and
+// the strategies registered
+func (s *RPCServer) GetStrategies(ctx context.Context, r *gctrpc.GetStrategiesRequest) (*gctrpc.GetStrategiesResponse, error) {
+	if !Bot.StrategyManager.Started() {
+		return nil, errStrategyManagerNotStarted
+	}
+	statuses := Bot.StrategyManager.GetStatus()
+	resp := gctrpc.GetStrategiesResponse{Registered: strategies.Registered()}
+	t := tenantFromContext(ctx)
+	for x := range statuses {
+		if t != nil && !tenantOwnsExchange(t, statuses[x].Exchange) {
+			continue
+		}
+		resp.Strategies = append(resp.Strategies, strategyDetails(&statuses[x]))
+	}
+	return &resp, nil
+}
+
+// StartStrategy initialises and starts a configured strategy instance
+func (s *RPCServer) StartStrategy(ctx context.Context, r *gctrpc.StrategyRequest) (*gctrpc.StrategyDetails, error) {
+	if err := checkTenantStrategy(ctx, r.Name); err != nil {
+		return nil, err
+	}
+	if err := Bot.StrategyManager.StartStrategy(r.Name); err != nil {
+		return nil, err
+	}
+	return getStrategyDetails(r.Name)
+}
+
+// StopStrategy stops a running strategy instance
+func (s *RPCServer) StopStrategy(ctx context.Context, r *gctrpc.StrategyRequest) (*gctrpc.StrategyDetails, error) {
+	if err := checkTenantStrategy(ctx, r.Name); err != nil {
+		return nil, err
+	}
+	if err := Bot.StrategyManager.StopStrategy(r.Name); err != nil {
+		return nil, err
+	}
+	return getStrategyDetails(r.Name)
+}
+
+func getStrategyDetails(name string) (*gctrpc.StrategyDetails, error) {
+	statuses := Bot.StrategyManager.GetStatus()
+	for x := range statuses {
+		if strings.EqualFold(statuses[x].Name, name) {
+			return strategyDetails(&statuses[x]), nil
+		}
+	}
+	return nil, fmt.Errorf("%s %v", name, errStrategyNotConfigured)
+}
+
+func strategyDetails(st *StrategyStatus) *gctrpc.StrategyDetails {
+	details := &gctrpc.StrategyDetails{
+		Name:     st.Name,
+		Strategy: st.Strategy,
+		Exchange: st.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: st.Pair.Delimiter,
+			Base:      st.Pair.Base.String(),
+			Quote:     st.Pair.Quote.String(),
+		},
+		AssetType: st.AssetType.String(),
+		Interval:  int64(st.Interval),
+		Running:   st.Running,
+		Error:     st.LastError,
+	}
+	if !st.Started.IsZero() {
+		details.Started = st.Started.Unix()
+	}
+	if !st.LastCandle.IsZero() {
+		details.LastCandle = st.LastCandle.Unix()
+	}
+	return details
+}
+
+// SubmitOCO links two orders so that when one executes the other is
+// cancelled, natively where the exchange supports it
+func (s *RPCServer) SubmitOCO(ctx context.Context, r *gctrpc.SubmitOCORequest) (*gctrpc.OCODetails, error) {
+	if len(r.Legs) != 2 {
+		return nil, errors.New("oco orders require two legs")
+	}
+	oco := OCO{
+		RefreshInterval: time.Duration(r.RefreshIntervalSeconds) * time.Second,
+	}
+	for i := range r.Legs {
+		if r.Legs[i].Pair == nil {
+			return nil, order.ErrPairIsEmpty
+		}
+		oco.Legs[i] = OCOLeg{
+			Order: order.Submit{
+				Exchange:  r.Legs[i].Exchange,
+				Pair:      currency.NewPairFromStrings(r.Legs[i].Pair.Base, r.Legs[i].Pair.Quote),
+				AssetType: asset.Item(strings.ToLower(r.Legs[i].AssetType)),
+				Side:      order.Side(strings.ToUpper(r.Legs[i].Side)),
+				Type:      order.Type(strings.ToUpper(r.Legs[i].OrderType)),
+				Amount:    r.Legs[i].Amount,
+				Price:     r.Legs[i].Price,
+			},
+			Conditional:  ConditionalType(strings.ToUpper(r.Legs[i].ConditionalType)),
+			TriggerPrice: r.Legs[i].TriggerPrice,
+		}
+	}
+	resp, err := Bot.OCOManager.Submit(&oco)
+	if err != nil {
+		return nil, err
+	}
+	return ocoToRPC(resp), nil
+}
+
+// GetOCO returns the status of an OCO order
+func (s *RPCServer) GetOCO(ctx context.Context, r *gctrpc.GetOCORequest) (*gctrpc.OCODetails, error) {
+	resp, err := Bot.OCOManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return ocoToRPC(resp), nil
+}
+
+// GetOCOs returns the status of all OCO orders
+func (s *RPCServer) GetOCOs(ctx context.Context, r *gctrpc.GetOCOsRequest) (*gctrpc.GetOCOsResponse, error) {
+	ocos := Bot.OCOManager.GetAll()
+	var resp gctrpc.GetOCOsResponse
+	for x := range ocos {
+		resp.Ocos = append(resp.Ocos, ocoToRPC(&ocos[x]))
+	}
+	return &resp, nil
+}
+
+// CancelOCO cancels both legs of an active OCO order
+func (s *RPCServer) CancelOCO(ctx context.Context, r *gctrpc.CancelOCORequest) (*gctrpc.OCODetails, error) {
+	err := Bot.OCOManager.Cancel(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := Bot.OCOManager.GetByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return ocoToRPC(resp), nil
+}
+
+func ocoToRPC(o *OCO) *gctrpc.OCODetails {
+	resp := &gctrpc.OCODetails{
+		Id:           o.ID,
+		Native:       o.Native,
+		ListId:       o.ListID,
+		Status:       string(o.Status),
+		ExecutedLeg:  int64(o.Executed),
+		Error:        o.Error,
+		CreationTime: o.Created.Unix(),
+		LastUpdated:  o.LastUpdated.Unix(),
+	}
+	for i := range o.Legs {
+		resp.Legs = append(resp.Legs, &gctrpc.OCOLeg{
+			Exchange: o.Legs[i].Order.Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: o.Legs[i].Order.Pair.Delimiter,
+				Base:      o.Legs[i].Order.Pair.Base.String(),
+				Quote:     o.Legs[i].Order.Pair.Quote.String(),
+			},
+			AssetType:       o.Legs[i].Order.AssetType.String(),
+			Side:            o.Legs[i].Order.Side.String(),
+			OrderType:       o.Legs[i].Order.Type.String(),
+			Amount:          o.Legs[i].Order.Amount,
+			Price:           o.Legs[i].Order.Price,
+			ConditionalType: string(o.Legs[i].Conditional),
+			TriggerPrice:    o.Legs[i].TriggerPrice,
+			ConditionalId:   o.Legs[i].ConditionalID,
+			OrderId:         o.Legs[i].OrderID,
+		})
+	}
+	return resp
+}
+
+// AddStrategyOrder registers the amount a strategy intends to trade on an
+// account shared with other strategies
+func (s *RPCServer) AddStrategyOrder(ctx context.Context, r *gctrpc.AddStrategyOrderRequest) (*gctrpc.StrategyOrder, error) {
+	if r.Pair == nil {
+		return nil, errors.New(errCurrencyPairUnset)
+	}
+	resp, err := Bot.AllocationManager.Add(&StrategyOrder{
+		Strategy:  r.Strategy,
+		Exchange:  r.Exchange,
+		Pair:      currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+		AssetType: asset.Item(r.AssetType),
+		Side:      order.Side(strings.ToUpper(r.Side)),
+		Amount:    r.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return strategyOrderToRPC(resp), nil
+}
+
+// RemoveStrategyOrder stops any further fills being allocated to a strategy
+// order
+func (s *RPCServer) RemoveStrategyOrder(ctx context.Context, r *gctrpc.RemoveStrategyOrderRequest) (*gctrpc.RemoveStrategyOrderResponse, error) {
+	return &gctrpc.RemoveStrategyOrderResponse{}, Bot.AllocationManager.Remove(r.Id)
+}
+
+// AllocateFill splits a fill on the account between the strategy orders
+// waiting on the same exchange, pair, asset and side
+func (s *RPCServer) AllocateFill(ctx context.Context, r *gctrpc.AllocateFillRequest) (*gctrpc.AllocateFillResponse, error) {
+	if r.Pair == nil {
+		return nil, errors.New(errCurrencyPairUnset)
+	}
+	allocations, err := Bot.AllocationManager.Allocate(r.Exchange,
+		currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+		asset.Item(r.AssetType),
+		order.Side(strings.ToUpper(r.Side)),
+		r.Amount,
+		r.Price,
+		AllocationRule(strings.ToUpper(r.Rule)))
+	if err != nil {
+		return nil, err
+	}
+	var resp gctrpc.AllocateFillResponse
+	for x := range allocations {
+		resp.Allocations = append(resp.Allocations, &gctrpc.Allocation{
+			StrategyOrderId: allocations[x].StrategyOrderID,
+			Strategy:        allocations[x].Strategy,
+			Amount:          allocations[x].Amount,
+			Price:           allocations[x].Price,
+		})
+	}
+	return &resp, nil
+}
+
+// GetStrategyPositions returns each strategy's positions and the strategy
+// orders which have not been fully allocated, optionally filtered by strategy
+func (s *RPCServer) GetStrategyPositions(ctx context.Context, r *gctrpc.GetStrategyPositionsRequest) (*gctrpc.GetStrategyPositionsResponse, error) {
+	var resp gctrpc.GetStrategyPositionsResponse
+	positions := Bot.AllocationManager.GetPositions()
+	for x := range positions {
+		if r.Strategy != "" && !strings.EqualFold(r.Strategy, positions[x].Strategy) {
+			continue
+		}
+		resp.Positions = append(resp.Positions, &gctrpc.StrategyPosition{
+			Strategy: positions[x].Strategy,
+			Exchange: positions[x].Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: positions[x].Pair.Delimiter,
+				Base:      positions[x].Pair.Base.String(),
+				Quote:     positions[x].Pair.Quote.String(),
+			},
+			AssetType:    positions[x].AssetType.String(),
+			Amount:       positions[x].Amount,
+			AveragePrice: positions[x].AveragePrice,
+			RealisedPnl:  positions[x].RealisedPNL,
+			LastUpdated:  positions[x].LastUpdated.Unix(),
+		})
+	}
+	orders := Bot.AllocationManager.GetOrders()
+	for x := range orders {
+		if r.Strategy != "" && !strings.EqualFold(r.Strategy, orders[x].Strategy) {
+			continue
+		}
+		resp.Orders = append(resp.Orders, strategyOrderToRPC(&orders[x]))
+	}
+	return &resp, nil
+}
+
+// GetPositions returns the account's positions built from its fills, with the
+// unrealised profit and loss of open positions valued at the live ticker price
+func (s *RPCServer) GetPositions(ctx context.Context, r *gctrpc.GetPositionsRequest) (*gctrpc.GetPositionsResponse, error) {
+	if !Bot.PositionManager.Started() {
+		return nil, errors.New("position manager is not started")
+	}
+	var p currency.Pair
+	if r.Pair != nil {
+		p = currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+	}
+	var target currency.Code
+	if r.Currency != "" {
+		target = currency.NewCode(r.Currency)
+	}
+	var resp gctrpc.GetPositionsResponse
+	positions := Bot.PositionManager.GetAll()
+	for x := range positions {
+		if r.Exchange != "" && !strings.EqualFold(r.Exchange, positions[x].Exchange) {
+			continue
+		}
+		if !p.IsEmpty() && !p.Equal(positions[x].Pair) {
+			continue
+		}
+		if r.AssetType != "" && !strings.EqualFold(r.AssetType, positions[x].AssetType.String()) {
+			continue
+		}
+		pnlCurrency := positions[x].Pair.Quote
+		if !target.IsEmpty() {
+			var ok bool
+			positions[x], ok = positions[x].valueIn(target)
+			if !ok {
+				return nil, fmt.Errorf("%s %s %s: unable to value profit and loss in %s",
+					positions[x].Exchange,
+					positions[x].Pair,
+					positions[x].AssetType,
+					target)
+			}
+			pnlCurrency = target
+		}
+		resp.Positions = append(resp.Positions, &gctrpc.Position{
+			Exchange: positions[x].Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: positions[x].Pair.Delimiter,
+				Base:      positions[x].Pair.Base.String(),
+				Quote:     positions[x].Pair.Quote.String(),
+			},
+			AssetType:     positions[x].AssetType.String(),
+			Amount:        positions[x].Amount,
+			AveragePrice:  positions[x].AveragePrice,
+			RealisedPnl:   positions[x].RealisedPNL,
+			Funding:       positions[x].Funding,
+			Fees:          positions[x].Fees,
+			MarkPrice:     positions[x].MarkPrice,
+			UnrealisedPnl: positions[x].UnrealisedPNL,
+			LastUpdated:   positions[x].LastUpdated.Unix(),
+			PnlCurrency:   pnlCurrency.String(),
+		})
+	}
+	return &resp, nil
+}
+
+// SyncTradeHistory pages through the account's trades of an exchange's pair
+// from where the previous sync finished, persisting the trades added
+func (s *RPCServer) SyncTradeHistory(ctx context.Context, r *gctrpc.SyncTradeHistoryRequest) (*gctrpc.SyncTradeHistoryResponse, error) {
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	if r.Pair == nil {
+		return nil, errors.New("currency pair must be specified")
+	}
+	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+	a := asset.Item(strings.ToLower(r.AssetType))
+	if a == "" {
+		a = asset.Spot
+	}
+	added, err := Bot.TradeHistory.Sync(exch, p, a)
+	if err != nil {
+		return nil, err
+	}
+	h, err := Bot.TradeHistory.Get(exch.GetName(), p, a)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.SyncTradeHistoryResponse{
+		Added:  int64(added),
+		Trades: int64(len(h.Trades)),
+		Cursor: h.Cursor.Unix(),
+	}, nil
+}
+
+func strategyOrderToRPC(o *StrategyOrder) *gctrpc.StrategyOrder {
+	return &gctrpc.StrategyOrder{
+		Id:       o.ID,
+		Strategy: o.Strategy,
+		Exchange: o.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: o.Pair.Delimiter,
+			Base:      o.Pair.Base.String(),
+			Quote:     o.Pair.Quote.String(),
+		},
+		AssetType:    o.AssetType.String(),
+		Side:         o.Side.String(),
+		Amount:       o.Amount,
+		Filled:       o.Filled,
+		CreationTime: o.Created.Unix(),
+	}
+}
+
+// CancelAllOrders cancels all orders, filterable by exchange
+func (s *RPCServer) CancelAllOrders(ctx context.Context, r *gctrpc.CancelAllOrdersRequest) (*gctrpc.CancelAllOrdersResponse, error) {
+	return &gctrpc.CancelAllOrdersResponse{}, common.ErrNotYetImplemented
+}
+
+// GetEvents returns the stored events list
+func (s *RPCServer) GetEvents(ctx context.Context, r *gctrpc.GetEventsRequest) (*gctrpc.GetEventsResponse, error) {
+	return &gctrpc.GetEventsResponse{}, common.ErrNotYetImplemented
+}
+
+// AddEvent adds an event
+func (s *RPCServer) AddEvent(ctx context.Context, r *gctrpc.AddEventRequest) (*gctrpc.AddEventResponse, error) {
+	evtCondition := EventConditionParams{
+		CheckBids:        r.ConditionParams.CheckBids,
+		CheckBidsAndAsks: r.ConditionParams.CheckBidsAndAsks,
+		Condition:        r.ConditionParams.Condition,
+		OrderbookAmount:  r.ConditionParams.OrderbookAmount,
+		Price:            r.ConditionParams.Price,
+		Threshold:        r.ConditionParams.Threshold,
+		DepthPercent:     r.ConditionParams.DepthPercent,
+	}
+
+	p := currency.NewPairWithDelimiter(r.Pair.Base,
+		r.Pair.Quote, r.Pair.Delimiter)
+
+	id, err := Add(r.Exchange, r.Item, evtCondition, p, asset.Item(r.AssetType), r.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.AddEventResponse{Id: id}, nil
+}
+
+// RemoveEvent removes an event, specified by an event ID
+func (s *RPCServer) RemoveEvent(ctx context.Context, r *gctrpc.RemoveEventRequest) (*gctrpc.RemoveEventResponse, error) {
+	Remove(r.Id)
+	return &gctrpc.RemoveEventResponse{}, nil
+}
+
+// GetCryptocurrencyDepositAddresses returns a list of cryptocurrency deposit
+// addresses specified by an exchange
+func (s *RPCServer) GetCryptocurrencyDepositAddresses(ctx context.Context, r *gctrpc.GetCryptocurrencyDepositAddressesRequest) (*gctrpc.GetCryptocurrencyDepositAddressesResponse, error) {
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, errors.New("exchange is not loaded/doesn't exist")
+	}
+
+	result, err := GetCryptocurrencyDepositAddressesByExchange(r.Exchange)
+	return &gctrpc.GetCryptocurrencyDepositAddressesResponse{Addresses: result}, err
+}
+
+// GetCryptocurrencyDepositAddress returns a cryptocurrency deposit address
+// specified by exchange and cryptocurrency
+func (s *RPCServer) GetCryptocurrencyDepositAddress(ctx context.Context, r *gctrpc.GetCryptocurrencyDepositAddressRequest) (*gctrpc.GetCryptocurrencyDepositAddressResponse, error) {
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, errors.New("exchange is not loaded/doesn't exist")
+	}
+
+	addr, err := GetExchangeCryptocurrencyDepositAddress(r.Exchange, "", currency.NewCode(r.Cryptocurrency))
+	return &gctrpc.GetCryptocurrencyDepositAddressResponse{Address: addr}, err
+}
+
+// WithdrawCryptocurrencyFunds withdraws cryptocurrency funds specified by
+// exchange
+func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, errors.New("exchange is not loaded/doesn't exist")
+	}
+
+	request := &withdraw.Request{
+		Amount:      r.Amount,
+		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
+		Type:        withdraw.Crypto,
+		Description: r.Description,
+		Crypto: &withdraw.CryptoRequest{
+			Address:    r.Address,
+			AddressTag: r.AddressTag,
+			FeeAmount:  r.Fee,
+		},
+	}
+
+	resp, err := SubmitWithdrawal(r.Exchange, request)
 	if err != nil {
 		return nil, err
 	}
@@ -1039,357 +2250,980 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 	}, nil
 }
 
-// WithdrawalEventByID returns previous withdrawal request details
-func (s *RPCServer) WithdrawalEventByID(ctx context.Context, r *gctrpc.WithdrawalEventByIDRequest) (*gctrpc.WithdrawalEventByIDResponse, error) {
-	if !Bot.Config.Database.Enabled {
-		return nil, database.ErrDatabaseSupportDisabled
+// WithdrawFiatFunds withdraws fiat funds specified by exchange
+func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFiatRequest) (*gctrpc.WithdrawResponse, error) {
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, errors.New("exchange is not loaded/doesn't exist")
+	}
+
+	var bankAccount *banking.Account
+
+	bankAccount, err := banking.GetBankAccountByID(r.BankAccountId)
+	if err != nil {
+		bankAccount, err = exch.GetBase().GetExchangeBankAccounts(r.BankAccountId, r.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request := &withdraw.Request{
+		Amount:      r.Amount,
+		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
+		Type:        withdraw.Fiat,
+		Description: r.Description,
+		Fiat: &withdraw.FiatRequest{
+			Bank: bankAccount,
+		},
+	}
+	resp, err := SubmitWithdrawal(r.Exchange, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.WithdrawResponse{
+		Id:     resp.ID.String(),
+		Status: resp.Exchange.Status,
+	}, nil
+}
+
+// WithdrawalEventByID returns previous withdrawal request details
+func (s *RPCServer) WithdrawalEventByID(ctx context.Context, r *gctrpc.WithdrawalEventByIDRequest) (*gctrpc.WithdrawalEventByIDResponse, error) {
+	if !Bot.Config.Database.Enabled {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	v, err := WithdrawalEventByID(r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &gctrpc.WithdrawalEventByIDResponse{
+		Event: &gctrpc.WithdrawalEventResponse{
+			Id: v.ID.String(),
+			Exchange: &gctrpc.WithdrawlExchangeEvent{
+				Name:   v.Exchange.Name,
+				Id:     v.Exchange.Name,
+				Status: v.Exchange.Status,
+			},
+			Request: &gctrpc.WithdrawalRequestEvent{
+				Currency:    v.RequestDetails.Currency.String(),
+				Description: v.RequestDetails.Description,
+				Amount:      v.RequestDetails.Amount,
+				Type:        int32(v.RequestDetails.Type),
+			},
+		},
+	}
+	createdAtPtype, err := ptypes.TimestampProto(v.CreatedAt)
+	if err != nil {
+		log.Errorf(log.Global, "failed to convert time: %v", err)
+	}
+	resp.Event.CreatedAt = createdAtPtype
+
+	updatedAtPtype, err := ptypes.TimestampProto(v.UpdatedAt)
+	if err != nil {
+		log.Errorf(log.Global, "failed to convert time: %v", err)
+	}
+	resp.Event.UpdatedAt = updatedAtPtype
+
+	if v.RequestDetails.Type == withdraw.Crypto {
+		resp.Event.Request.Crypto = new(gctrpc.CryptoWithdrawalEvent)
+		resp.Event.Request.Crypto = &gctrpc.CryptoWithdrawalEvent{
+			Address:    v.RequestDetails.Crypto.Address,
+			AddressTag: v.RequestDetails.Crypto.AddressTag,
+			Fee:        v.RequestDetails.Crypto.FeeAmount,
+		}
+	} else if v.RequestDetails.Type == withdraw.Fiat {
+		if v.RequestDetails.Fiat != nil {
+			resp.Event.Request.Fiat = new(gctrpc.FiatWithdrawalEvent)
+			resp.Event.Request.Fiat = &gctrpc.FiatWithdrawalEvent{
+				BankName:      v.RequestDetails.Fiat.Bank.BankName,
+				AccountName:   v.RequestDetails.Fiat.Bank.AccountName,
+				AccountNumber: v.RequestDetails.Fiat.Bank.AccountNumber,
+				Bsb:           v.RequestDetails.Fiat.Bank.BSBNumber,
+				Swift:         v.RequestDetails.Fiat.Bank.SWIFTCode,
+				Iban:          v.RequestDetails.Fiat.Bank.IBAN,
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// WithdrawalEventsByExchange returns previous withdrawal request details by exchange
+func (s *RPCServer) WithdrawalEventsByExchange(ctx context.Context, r *gctrpc.WithdrawalEventsByExchangeRequest) (*gctrpc.WithdrawalEventsByExchangeResponse, error) {
+	if !Bot.Config.Database.Enabled {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if r.Id == "" {
+		ret, err := WithdrawalEventByExchange(r.Exchange, int(r.Limit))
+		if err != nil {
+			return nil, err
+		}
+		return parseMultipleEvents(ret), nil
+	}
+
+	ret, err := WithdrawalEventByExchangeID(r.Exchange, r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSingleEvents(ret), nil
+}
+
+// WithdrawalEventsByDate returns previous withdrawal request details by exchange
+func (s *RPCServer) WithdrawalEventsByDate(ctx context.Context, r *gctrpc.WithdrawalEventsByDateRequest) (*gctrpc.WithdrawalEventsByExchangeResponse, error) {
+	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	UTCSEndTime, err := time.Parse(common.SimpleTimeFormat, r.End)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := WithdrawEventByDate(r.Exchange, UTCStartTime, UTCSEndTime, int(r.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return parseMultipleEvents(ret), nil
+}
+
+// GetLoggerDetails returns a loggers details
+func (s *RPCServer) GetLoggerDetails(ctx context.Context, r *gctrpc.GetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
+	levels, err := log.Level(r.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.GetLoggerDetailsResponse{
+		Info:  levels.Info,
+		Debug: levels.Debug,
+		Warn:  levels.Warn,
+		Error: levels.Error,
+	}, nil
+}
+
+// SetLoggerDetails sets a loggers details
+func (s *RPCServer) SetLoggerDetails(ctx context.Context, r *gctrpc.SetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
+	levels, err := log.SetLevel(r.Logger, r.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.GetLoggerDetailsResponse{
+		Info:  levels.Info,
+		Debug: levels.Debug,
+		Warn:  levels.Warn,
+		Error: levels.Error,
+	}, nil
+}
+
+// GetExchangePairs returns a list of exchange supported assets and related pairs
+func (s *RPCServer) GetExchangePairs(ctx context.Context, r *gctrpc.GetExchangePairsRequest) (*gctrpc.GetExchangePairsResponse, error) {
+	exchCfg, err := Bot.Config.GetExchangeConfig(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Asset != "" &&
+		!exchCfg.CurrencyPairs.GetAssetTypes().Contains(asset.Item(r.Asset)) {
+		return nil, errors.New("specified asset type does not exist")
+	}
+
+	var resp gctrpc.GetExchangePairsResponse
+	resp.SupportedAssets = make(map[string]*gctrpc.PairsSupported)
+	assetTypes := exchCfg.CurrencyPairs.GetAssetTypes()
+	for x := range assetTypes {
+		a := assetTypes[x]
+		if r.Asset != "" && !strings.EqualFold(a.String(), r.Asset) {
+			continue
+		}
+		resp.SupportedAssets[a.String()] = &gctrpc.PairsSupported{
+			AvailablePairs: exchCfg.CurrencyPairs.Get(a).Available.Join(),
+			EnabledPairs:   exchCfg.CurrencyPairs.Get(a).Enabled.Join(),
+		}
+	}
+	return &resp, nil
+}
+
+// EnableExchangePair enables the specified pair on an exchange
+func (s *RPCServer) EnableExchangePair(ctx context.Context, r *gctrpc.ExchangePairRequest) (*gctrpc.GenericExchangeNameResponse, error) {
+	exchCfg, err := Bot.Config.GetExchangeConfig(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.AssetType != "" &&
+		!exchCfg.CurrencyPairs.GetAssetTypes().Contains(asset.Item(r.AssetType)) {
+		return nil, errors.New("specified asset type does not exist")
+	}
+
+	// Default to spot asset type unless set
+	a := asset.Spot
+	if r.AssetType != "" {
+		a = asset.Item(r.AssetType)
+	}
+
+	pairFmt, err := Bot.Config.GetPairFormat(r.Exchange, a)
+	if err != nil {
+		return nil, err
+	}
+
+	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote).Format(
+		pairFmt.Delimiter, pairFmt.Uppercase)
+	err = exchCfg.CurrencyPairs.EnablePair(a, p)
+	if err != nil {
+		return nil, err
+	}
+	err = GetExchangeByName(r.Exchange).GetBase().CurrencyPairs.EnablePair(
+		asset.Item(r.AssetType), p)
+	return &gctrpc.GenericExchangeNameResponse{}, err
+}
+
+// DisableExchangePair disables the specified pair on an exchange
+func (s *RPCServer) DisableExchangePair(ctx context.Context, r *gctrpc.ExchangePairRequest) (*gctrpc.GenericExchangeNameResponse, error) {
+	exchCfg, err := Bot.Config.GetExchangeConfig(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.AssetType != "" &&
+		!exchCfg.CurrencyPairs.GetAssetTypes().Contains(asset.Item(r.AssetType)) {
+		return nil, errors.New("specified asset type does not exist")
+	}
+
+	// Default to spot asset type unless set
+	a := asset.Spot
+	if r.AssetType != "" {
+		a = asset.Item(r.AssetType)
+	}
+
+	pairFmt, err := Bot.Config.GetPairFormat(r.Exchange, a)
+	if err != nil {
+		return nil, err
+	}
+
+	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote).Format(
+		pairFmt.Delimiter, pairFmt.Uppercase)
+	err = exchCfg.CurrencyPairs.DisablePair(asset.Item(r.AssetType), p)
+	if err != nil {
+		return nil, err
+	}
+	err = GetExchangeByName(r.Exchange).GetBase().CurrencyPairs.DisablePair(
+		asset.Item(r.AssetType), p)
+	return &gctrpc.GenericExchangeNameResponse{}, err
+}
+
+// GetOrderbookStream streams the requested updated orderbook
+func (s *RPCServer) GetOrderbookStream(r *gctrpc.GetOrderbookStreamRequest, stream gctrpc.GoCryptoTrader_GetOrderbookStreamServer) error {
+	if r.Exchange == "" {
+		return errors.New(errExchangeNameUnset)
+	}
+
+	if r.Pair.String() == "" {
+		return errors.New(errCurrencyPairUnset)
+	}
+
+	if r.AssetType == "" {
+		return errors.New(errAssetTypeUnset)
+	}
+
+	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+
+	pipe, err := orderbook.SubscribeOrderbook(r.Exchange, p, asset.Item(r.AssetType))
+	if err != nil {
+		return err
+	}
+
+	defer pipe.Release()
+
+	for {
+		data, ok := <-pipe.C
+		if !ok {
+			return errors.New(errDispatchSystem)
+		}
+
+		ob := (*data.(*interface{})).(orderbook.Base)
+		var bids, asks []*gctrpc.OrderbookItem
+		for i := range ob.Bids {
+			bids = append(bids, &gctrpc.OrderbookItem{
+				Amount: ob.Bids[i].Amount,
+				Price:  ob.Bids[i].Price,
+				Id:     ob.Bids[i].ID,
+			})
+		}
+		for i := range ob.Asks {
+			asks = append(asks, &gctrpc.OrderbookItem{
+				Amount: ob.Asks[i].Amount,
+				Price:  ob.Asks[i].Price,
+				Id:     ob.Asks[i].ID,
+			})
+		}
+		err := stream.Send(&gctrpc.OrderbookResponse{
+			Pair: &gctrpc.CurrencyPair{Base: ob.Pair.Base.String(),
+				Quote: ob.Pair.Quote.String()},
+			Bids:      bids,
+			Asks:      asks,
+			AssetType: ob.AssetType.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GetExchangeOrderbookStream streams all orderbooks associated with an exchange
+func (s *RPCServer) GetExchangeOrderbookStream(r *gctrpc.GetExchangeOrderbookStreamRequest, stream gctrpc.GoCryptoTrader_GetExchangeOrderbookStreamServer) error {
+	if r.Exchange == "" {
+		return errors.New(errExchangeNameUnset)
+	}
+
+	pipe, err := orderbook.SubscribeToExchangeOrderbooks(r.Exchange)
+	if err != nil {
+		return err
+	}
+
+	defer pipe.Release()
+
+	for {
+		data, ok := <-pipe.C
+		if !ok {
+			return errors.New(errDispatchSystem)
+		}
+
+		ob := (*data.(*interface{})).(orderbook.Base)
+		var bids, asks []*gctrpc.OrderbookItem
+		for i := range ob.Bids {
+			bids = append(bids, &gctrpc.OrderbookItem{
+				Amount: ob.Bids[i].Amount,
+				Price:  ob.Bids[i].Price,
+				Id:     ob.Bids[i].ID,
+			})
+		}
+		for i := range ob.Asks {
+			asks = append(asks, &gctrpc.OrderbookItem{
+				Amount: ob.Asks[i].Amount,
+				Price:  ob.Asks[i].Price,
+				Id:     ob.Asks[i].ID,
+			})
+		}
+		err := stream.Send(&gctrpc.OrderbookResponse{
+			Pair: &gctrpc.CurrencyPair{Base: ob.Pair.Base.String(),
+				Quote: ob.Pair.Quote.String()},
+			Bids:      bids,
+			Asks:      asks,
+			AssetType: ob.AssetType.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GetTickerStream streams the requested updated ticker
+func (s *RPCServer) GetTickerStream(r *gctrpc.GetTickerStreamRequest, stream gctrpc.GoCryptoTrader_GetTickerStreamServer) error {
+	if r.Exchange == "" {
+		return errors.New(errExchangeNameUnset)
+	}
+
+	if r.Pair.String() == "" {
+		return errors.New(errCurrencyPairUnset)
+	}
+
+	if r.AssetType == "" {
+		return errors.New(errAssetTypeUnset)
+	}
+
+	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+
+	pipe, err := ticker.SubscribeTicker(r.Exchange, p, asset.Item(r.AssetType))
+	if err != nil {
+		return err
+	}
+
+	defer pipe.Release()
+
+	for {
+		data, ok := <-pipe.C
+		if !ok {
+			return errors.New(errDispatchSystem)
+		}
+		t := (*data.(*interface{})).(ticker.Price)
+
+		err := stream.Send(&gctrpc.TickerResponse{
+			Pair: &gctrpc.CurrencyPair{
+				Base:      t.Pair.Base.String(),
+				Quote:     t.Pair.Quote.String(),
+				Delimiter: t.Pair.Delimiter},
+			LastUpdated: t.LastUpdated.Unix(),
+			Last:        t.Last,
+			High:        t.High,
+			Low:         t.Low,
+			Bid:         t.Bid,
+			Ask:         t.Ask,
+			Volume:      t.Volume,
+			PriceAth:    t.PriceATH,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GetExchangeTickerStream streams all tickers associated with an exchange
+func (s *RPCServer) GetExchangeTickerStream(r *gctrpc.GetExchangeTickerStreamRequest, stream gctrpc.GoCryptoTrader_GetExchangeTickerStreamServer) error {
+	if r.Exchange == "" {
+		return errors.New(errExchangeNameUnset)
+	}
+
+	pipe, err := ticker.SubscribeToExchangeTickers(r.Exchange)
+	if err != nil {
+		return err
+	}
+
+	defer pipe.Release()
+
+	for {
+		data, ok := <-pipe.C
+		if !ok {
+			return errors.New(errDispatchSystem)
+		}
+		t := (*data.(*interface{})).(ticker.Price)
+
+		err := stream.Send(&gctrpc.TickerResponse{
+			Pair: &gctrpc.CurrencyPair{
+				Base:      t.Pair.Base.String(),
+				Quote:     t.Pair.Quote.String(),
+				Delimiter: t.Pair.Delimiter},
+			LastUpdated: t.LastUpdated.Unix(),
+			Last:        t.Last,
+			High:        t.High,
+			Low:         t.Low,
+			Bid:         t.Bid,
+			Ask:         t.Ask,
+			Volume:      t.Volume,
+			PriceAth:    t.PriceATH,
+		})
+		if err != nil {
+			return err
+		}
 	}
-	v, err := WithdrawalEventByID(r.Id)
+}
+
+// GetAuditEvent returns matching audit events from database
+func (s *RPCServer) GetAuditEvent(ctx context.Context, r *gctrpc.GetAuditEventRequest) (*gctrpc.GetAuditEventResponse, error) {
+	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &gctrpc.WithdrawalEventByIDResponse{
-		Event: &gctrpc.WithdrawalEventResponse{
-			Id: v.ID.String(),
-			Exchange: &gctrpc.WithdrawlExchangeEvent{
-				Name:   v.Exchange.Name,
-				Id:     v.Exchange.Name,
-				Status: v.Exchange.Status,
-			},
-			Request: &gctrpc.WithdrawalRequestEvent{
-				Currency:    v.RequestDetails.Currency.String(),
-				Description: v.RequestDetails.Description,
-				Amount:      v.RequestDetails.Amount,
-				Type:        int32(v.RequestDetails.Type),
-			},
-		},
-	}
-	createdAtPtype, err := ptypes.TimestampProto(v.CreatedAt)
+	UTCSEndTime, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
 	if err != nil {
-		log.Errorf(log.Global, "failed to convert time: %v", err)
+		return nil, err
 	}
-	resp.Event.CreatedAt = createdAtPtype
 
-	updatedAtPtype, err := ptypes.TimestampProto(v.UpdatedAt)
+	loc := time.FixedZone("", int(r.Offset))
+
+	events, err := audit.GetEvent(UTCStartTime, UTCSEndTime, r.OrderBy, int(r.Limit))
 	if err != nil {
-		log.Errorf(log.Global, "failed to convert time: %v", err)
+		return nil, err
 	}
-	resp.Event.UpdatedAt = updatedAtPtype
 
-	if v.RequestDetails.Type == withdraw.Crypto {
-		resp.Event.Request.Crypto = new(gctrpc.CryptoWithdrawalEvent)
-		resp.Event.Request.Crypto = &gctrpc.CryptoWithdrawalEvent{
-			Address:    v.RequestDetails.Crypto.Address,
-			AddressTag: v.RequestDetails.Crypto.AddressTag,
-			Fee:        v.RequestDetails.Crypto.FeeAmount,
+	resp := gctrpc.GetAuditEventResponse{}
+
+	switch v := events.(type) {
+	case postgres.AuditEventSlice:
+		for x := range v {
+			tempEvent := &gctrpc.AuditEvent{
+				Type:       v[x].Type,
+				Identifier: v[x].Identifier,
+				Message:    v[x].Message,
+				Timestamp:  v[x].CreatedAt.In(loc).Format(common.SimpleTimeFormat),
+			}
+
+			resp.Events = append(resp.Events, tempEvent)
 		}
-	} else if v.RequestDetails.Type == withdraw.Fiat {
-		if v.RequestDetails.Fiat != nil {
-			resp.Event.Request.Fiat = new(gctrpc.FiatWithdrawalEvent)
-			resp.Event.Request.Fiat = &gctrpc.FiatWithdrawalEvent{
-				BankName:      v.RequestDetails.Fiat.Bank.BankName,
-				AccountName:   v.RequestDetails.Fiat.Bank.AccountName,
-				AccountNumber: v.RequestDetails.Fiat.Bank.AccountNumber,
-				Bsb:           v.RequestDetails.Fiat.Bank.BSBNumber,
-				Swift:         v.RequestDetails.Fiat.Bank.SWIFTCode,
-				Iban:          v.RequestDetails.Fiat.Bank.IBAN,
+	case sqlite3.AuditEventSlice:
+		for x := range v {
+			tempEvent := &gctrpc.AuditEvent{
+				Type:       v[x].Type,
+				Identifier: v[x].Identifier,
+				Message:    v[x].Message,
+				Timestamp:  v[x].CreatedAt,
 			}
+			resp.Events = append(resp.Events, tempEvent)
 		}
 	}
 
-	return resp, nil
+	return &resp, nil
 }
 
-// WithdrawalEventsByExchange returns previous withdrawal request details by exchange
-func (s *RPCServer) WithdrawalEventsByExchange(ctx context.Context, r *gctrpc.WithdrawalEventsByExchangeRequest) (*gctrpc.WithdrawalEventsByExchangeResponse, error) {
-	if !Bot.Config.Database.Enabled {
-		return nil, database.ErrDatabaseSupportDisabled
-	}
-	if r.Id == "" {
-		ret, err := WithdrawalEventByExchange(r.Exchange, int(r.Limit))
-		if err != nil {
-			return nil, err
-		}
-		return parseMultipleEvents(ret), nil
+// GetEquityCurve returns the stored equity snapshots of the portfolio or a
+// strategy and the max drawdown over the period
+func (s *RPCServer) GetEquityCurve(ctx context.Context, r *gctrpc.GetEquityCurveRequest) (*gctrpc.GetEquityCurveResponse, error) {
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, err
 	}
 
-	ret, err := WithdrawalEventByExchangeID(r.Exchange, r.Id)
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseSingleEvents(ret), nil
-}
+	if r.Portfolio == "" {
+		r.Portfolio = equityPortfolioName
+	}
+	if r.Currency == "" {
+		r.Currency = Bot.Config.EquitySnapshot.Currency.String()
+	}
 
-// WithdrawalEventsByDate returns previous withdrawal request details by exchange
-func (s *RPCServer) WithdrawalEventsByDate(ctx context.Context, r *gctrpc.WithdrawalEventsByDateRequest) (*gctrpc.WithdrawalEventsByExchangeResponse, error) {
-	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.Start)
+	snapshots, err := equity.GetSnapshots(r.Portfolio, strings.ToUpper(r.Currency), start, end)
 	if err != nil {
 		return nil, err
 	}
 
-	UTCSEndTime, err := time.Parse(common.SimpleTimeFormat, r.End)
+	resp := gctrpc.GetEquityCurveResponse{
+		Portfolio:   r.Portfolio,
+		Currency:    strings.ToUpper(r.Currency),
+		MaxDrawdown: equity.MaxDrawdown(snapshots),
+		TotalReturn: equity.Return(snapshots),
+	}
+	if r.Benchmark != "" {
+		resp.Benchmark = strings.ToUpper(r.Benchmark)
+		benchmark, err := equity.GetSnapshots(r.Portfolio, resp.Benchmark, start, end)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		resp.BenchmarkReturn, ok = equity.BenchmarkReturn(snapshots, benchmark)
+		if !ok {
+			return nil, fmt.Errorf("%s has no snapshots valued in %s over the period to benchmark against",
+				r.Portfolio,
+				resp.Benchmark)
+		}
+		resp.ExcessReturn = resp.TotalReturn - resp.BenchmarkReturn
+	}
+	for x := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, &gctrpc.EquitySnapshot{
+			Equity:    snapshots[x].Equity,
+			Balance:   snapshots[x].Balance,
+			Timestamp: snapshots[x].Time.UTC().Format(common.SimpleTimeFormat),
+		})
+	}
+	return &resp, nil
+}
+
+// GetStrategyPerformance returns the performance statistics of a strategy, or
+// of each strategy allocated fills, over the period and optionally exports
+// them as JSON or CSV
+func (s *RPCServer) GetStrategyPerformance(ctx context.Context, r *gctrpc.GetStrategyPerformanceRequest) (*gctrpc.GetStrategyPerformanceResponse, error) {
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
 	if err != nil {
 		return nil, err
 	}
 
-	ret, err := WithdrawEventByDate(r.Exchange, UTCStartTime, UTCSEndTime, int(r.Limit))
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
 	if err != nil {
 		return nil, err
 	}
-	return parseMultipleEvents(ret), nil
-}
 
-// GetLoggerDetails returns a loggers details
-func (s *RPCServer) GetLoggerDetails(ctx context.Context, r *gctrpc.GetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
-	levels, err := log.Level(r.Logger)
+	c := Bot.Config.EquitySnapshot.Currency
+	if r.Currency != "" {
+		c = currency.NewCode(r.Currency)
+	}
+
+	reports, err := StrategyPerformance(r.Strategy, c, start, end, r.Capital)
 	if err != nil {
 		return nil, err
 	}
 
-	return &gctrpc.GetLoggerDetailsResponse{
-		Info:  levels.Info,
-		Debug: levels.Debug,
-		Warn:  levels.Warn,
-		Error: levels.Error,
-	}, nil
+	resp := gctrpc.GetStrategyPerformanceResponse{Format: strings.ToLower(r.Format)}
+	if resp.Format != "" {
+		var buf bytes.Buffer
+		if err = performance.Write(&buf, resp.Format, reports); err != nil {
+			return nil, err
+		}
+		resp.Data = buf.String()
+	}
+	for x := range reports {
+		resp.Strategies = append(resp.Strategies, strategyPerformanceToRPC(&reports[x]))
+	}
+	return &resp, nil
 }
 
-// SetLoggerDetails sets a loggers details
-func (s *RPCServer) SetLoggerDetails(ctx context.Context, r *gctrpc.SetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
-	levels, err := log.SetLevel(r.Logger, r.Level)
+func strategyPerformanceToRPC(r *performance.Report) *gctrpc.StrategyPerformance {
+	return &gctrpc.StrategyPerformance{
+		Strategy:     r.Strategy,
+		Currency:     r.Currency,
+		RealisedPnl:  r.RealisedPNL,
+		SharpeRatio:  r.SharpeRatio,
+		SortinoRatio: r.SortinoRatio,
+		MaxDrawdown:  r.MaxDrawdown,
+		Trades:       int64(r.Trades),
+		Wins:         int64(r.Wins),
+		Losses:       int64(r.Losses),
+		WinRate:      r.WinRate,
+		AverageWin:   r.AverageWin,
+		AverageLoss:  r.AverageLoss,
+		ExposureTime: r.ExposureTime,
+	}
+}
+
+// OptimiseStrategy backtests a grid of a strategy's parameters over the stored
+// candles of a market with walk-forward splits and returns the parameter sets
+// ranked by their out-of-sample performance
+func (s *RPCServer) OptimiseStrategy(ctx context.Context, r *gctrpc.OptimiseStrategyRequest) (*gctrpc.OptimiseStrategyResponse, error) {
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
 	if err != nil {
 		return nil, err
 	}
 
-	return &gctrpc.GetLoggerDetailsResponse{
-		Info:  levels.Info,
-		Debug: levels.Debug,
-		Warn:  levels.Warn,
-		Error: levels.Error,
-	}, nil
-}
-
-// GetExchangePairs returns a list of exchange supported assets and related pairs
-func (s *RPCServer) GetExchangePairs(ctx context.Context, r *gctrpc.GetExchangePairsRequest) (*gctrpc.GetExchangePairsResponse, error) {
-	exchCfg, err := Bot.Config.GetExchangeConfig(r.Exchange)
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
 	if err != nil {
 		return nil, err
 	}
 
-	if r.Asset != "" &&
-		!exchCfg.CurrencyPairs.GetAssetTypes().Contains(asset.Item(r.Asset)) {
-		return nil, errors.New("specified asset type does not exist")
+	if r.Pair == nil {
+		return nil, errors.New(errCurrencyPairUnset)
+	}
+	a := asset.Spot
+	if r.AssetType != "" {
+		a = asset.Item(strings.ToLower(r.AssetType))
+		if !asset.IsValid(a) {
+			return nil, errors.New("asset type is invalid")
+		}
 	}
 
-	var resp gctrpc.GetExchangePairsResponse
-	resp.SupportedAssets = make(map[string]*gctrpc.PairsSupported)
-	assetTypes := exchCfg.CurrencyPairs.GetAssetTypes()
-	for x := range assetTypes {
-		a := assetTypes[x]
-		if r.Asset != "" && !strings.EqualFold(a.String(), r.Asset) {
-			continue
+	o := backtest.Optimisation{
+		Settings: backtest.Settings{
+			Strategy:   r.Strategy,
+			Exchange:   r.Exchange,
+			Pair:       currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+			AssetType:  a,
+			Interval:   time.Duration(r.Interval),
+			Parameters: r.Parameters,
+			Fee:        r.Fee,
+			Capital:    r.Capital,
+		},
+		Grid:       make(map[string][]string, len(r.Grid)),
+		Folds:      int(r.Folds),
+		TrainRatio: r.TrainRatio,
+		Metric:     r.Metric,
+	}
+	for k, v := range r.Grid {
+		o.Grid[k] = strings.Split(v, ",")
+	}
+
+	results, candles, err := OptimiseStrategy(&o, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := gctrpc.OptimiseStrategyResponse{Candles: int64(candles)}
+	for x := range results {
+		result := &gctrpc.OptimisationResult{
+			Parameters:       results[x].Parameters,
+			InSampleScore:    results[x].InSampleScore,
+			OutOfSampleScore: results[x].OutOfSampleScore,
+			OutOfSamplePnl:   results[x].OutOfSamplePNL,
 		}
-		resp.SupportedAssets[a.String()] = &gctrpc.PairsSupported{
-			AvailablePairs: exchCfg.CurrencyPairs.Get(a).Available.Join(),
-			EnabledPairs:   exchCfg.CurrencyPairs.Get(a).Enabled.Join(),
+		for y := range results[x].Folds {
+			result.Folds = append(result.Folds, &gctrpc.OptimisationFold{
+				InSample:    strategyPerformanceToRPC(&results[x].Folds[y].InSample),
+				OutOfSample: strategyPerformanceToRPC(&results[x].Folds[y].OutOfSample),
+			})
 		}
+		resp.Results = append(resp.Results, result)
 	}
 	return &resp, nil
 }
 
-// EnableExchangePair enables the specified pair on an exchange
-func (s *RPCServer) EnableExchangePair(ctx context.Context, r *gctrpc.ExchangePairRequest) (*gctrpc.GenericExchangeNameResponse, error) {
-	exchCfg, err := Bot.Config.GetExchangeConfig(r.Exchange)
+// SimulateStrategy backtests a strategy over stored candles and resamples its
+// trades into Monte Carlo simulations
+func (s *RPCServer) SimulateStrategy(ctx context.Context, r *gctrpc.SimulateStrategyRequest) (*gctrpc.SimulateStrategyResponse, error) {
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
 	if err != nil {
 		return nil, err
 	}
 
-	if r.AssetType != "" &&
-		!exchCfg.CurrencyPairs.GetAssetTypes().Contains(asset.Item(r.AssetType)) {
-		return nil, errors.New("specified asset type does not exist")
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, err
 	}
 
-	// Default to spot asset type unless set
+	if r.Pair == nil {
+		return nil, errors.New(errCurrencyPairUnset)
+	}
 	a := asset.Spot
 	if r.AssetType != "" {
-		a = asset.Item(r.AssetType)
+		a = asset.Item(strings.ToLower(r.AssetType))
+		if !asset.IsValid(a) {
+			return nil, errors.New("asset type is invalid")
+		}
 	}
 
-	pairFmt, err := Bot.Config.GetPairFormat(r.Exchange, a)
+	report, result, candles, err := SimulateStrategy(&backtest.Settings{
+		Strategy:   r.Strategy,
+		Exchange:   r.Exchange,
+		Pair:       currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote),
+		AssetType:  a,
+		Interval:   time.Duration(r.Interval),
+		Parameters: r.Parameters,
+		Fee:        r.Fee,
+		Capital:    r.Capital,
+	}, &performance.MonteCarloSettings{
+		Simulations:    int(r.Simulations),
+		Confidence:     r.Confidence,
+		StartingEquity: r.StartingEquity,
+		Seed:           r.Seed,
+	}, start, end)
 	if err != nil {
 		return nil, err
 	}
 
-	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote).Format(
-		pairFmt.Delimiter, pairFmt.Uppercase)
-	err = exchCfg.CurrencyPairs.EnablePair(a, p)
+	return &gctrpc.SimulateStrategyResponse{
+		Candles:     int64(candles),
+		Performance: strategyPerformanceToRPC(&report),
+		Simulations: int64(result.Simulations),
+		Confidence:  result.Confidence,
+		Seed:        result.Seed,
+		FinalEquity: &gctrpc.SimulatedDistribution{
+			Lower:  result.FinalEquity.Lower,
+			Median: result.FinalEquity.Median,
+			Upper:  result.FinalEquity.Upper,
+			Mean:   result.FinalEquity.Mean,
+		},
+		MaxDrawdown: &gctrpc.SimulatedDistribution{
+			Lower:  result.MaxDrawdown.Lower,
+			Median: result.MaxDrawdown.Median,
+			Upper:  result.MaxDrawdown.Upper,
+			Mean:   result.MaxDrawdown.Mean,
+		},
+		ProbabilityOfLoss: result.ProbabilityOfLoss,
+	}, nil
+}
+
+// GetDCAReport returns the amount a dollar-cost averaging strategy has
+// acquired and its average cost over time
+func (s *RPCServer) GetDCAReport(ctx context.Context, r *gctrpc.StrategyRequest) (*gctrpc.GetDCAReportResponse, error) {
+	if err := checkTenantStrategy(ctx, r.Name); err != nil {
+		return nil, err
+	}
+	report, err := Bot.StrategyManager.DCAReport(r.Name)
 	if err != nil {
 		return nil, err
 	}
-	err = GetExchangeByName(r.Exchange).GetBase().CurrencyPairs.EnablePair(
-		asset.Item(r.AssetType), p)
-	return &gctrpc.GenericExchangeNameResponse{}, err
+	details, err := getStrategyDetails(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := gctrpc.GetDCAReportResponse{
+		Strategy:    details,
+		Amount:      report.Amount,
+		Cost:        report.Cost,
+		AverageCost: report.AverageCost,
+		Carried:     report.Carried,
+	}
+	if !report.Next.IsZero() {
+		resp.Next = report.Next.Unix()
+	}
+	for x := range report.Purchases {
+		resp.Purchases = append(resp.Purchases, &gctrpc.DCAPurchase{
+			Time:        report.Purchases[x].Time.Unix(),
+			Price:       report.Purchases[x].Price,
+			Amount:      report.Purchases[x].Amount,
+			Cost:        report.Purchases[x].Cost,
+			AverageCost: report.Purchases[x].AverageCost,
+		})
+	}
+	return &resp, nil
 }
 
-// DisableExchangePair disables the specified pair on an exchange
-func (s *RPCServer) DisableExchangePair(ctx context.Context, r *gctrpc.ExchangePairRequest) (*gctrpc.GenericExchangeNameResponse, error) {
-	exchCfg, err := Bot.Config.GetExchangeConfig(r.Exchange)
+// GetAuctionHistory returns the auction events collected for an exchange pair
+func (s *RPCServer) GetAuctionHistory(ctx context.Context, r *gctrpc.GetAuctionHistoryRequest) (*gctrpc.GetAuctionHistoryResponse, error) {
+	if r.Pair == nil {
+		return nil, order.ErrPairIsEmpty
+	}
+
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
 	if err != nil {
 		return nil, err
 	}
 
-	if r.AssetType != "" &&
-		!exchCfg.CurrencyPairs.GetAssetTypes().Contains(asset.Item(r.AssetType)) {
-		return nil, errors.New("specified asset type does not exist")
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, err
 	}
 
-	// Default to spot asset type unless set
-	a := asset.Spot
-	if r.AssetType != "" {
-		a = asset.Item(r.AssetType)
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	g, ok := exch.(*gemini.Gemini)
+	if !ok {
+		return nil, errAuctionsUnsupported
+	}
+
+	symbol := auctionSymbol(g, currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote))
+	events, err := auction.GetEvents(exch.GetName(), symbol, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := gctrpc.GetAuctionHistoryResponse{
+		Exchange: exch.GetName(),
+		Pair:     symbol,
+	}
+	for x := range events {
+		resp.Events = append(resp.Events, &gctrpc.AuctionEvent{
+			AuctionId:       events[x].AuctionID,
+			EventId:         events[x].EventID,
+			EventType:       events[x].EventType,
+			AuctionResult:   events[x].AuctionResult,
+			AuctionPrice:    events[x].AuctionPrice,
+			AuctionQuantity: events[x].AuctionQuantity,
+			HighestBidPrice: events[x].HighestBidPrice,
+			LowestAskPrice:  events[x].LowestAskPrice,
+			Timestamp:       events[x].Time.UTC().Format(common.SimpleTimeFormat),
+		})
+	}
+	return &resp, nil
+}
+
+// GetCashFlow returns the deposits and withdrawals synced for an exchange and
+// reconciles them with its portfolio balances
+func (s *RPCServer) GetCashFlow(ctx context.Context, r *gctrpc.GetCashFlowRequest) (*gctrpc.GetCashFlowResponse, error) {
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, err
 	}
 
-	pairFmt, err := Bot.Config.GetPairFormat(r.Exchange, a)
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
 	if err != nil {
 		return nil, err
 	}
 
-	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote).Format(
-		pairFmt.Delimiter, pairFmt.Uppercase)
-	err = exchCfg.CurrencyPairs.DisablePair(asset.Item(r.AssetType), p)
+	records, flows, err := GetCashFlow(r.Exchange, start, end)
 	if err != nil {
 		return nil, err
 	}
-	err = GetExchangeByName(r.Exchange).GetBase().CurrencyPairs.DisablePair(
-		asset.Item(r.AssetType), p)
-	return &gctrpc.GenericExchangeNameResponse{}, err
-}
 
-// GetOrderbookStream streams the requested updated orderbook
-func (s *RPCServer) GetOrderbookStream(r *gctrpc.GetOrderbookStreamRequest, stream gctrpc.GoCryptoTrader_GetOrderbookStreamServer) error {
-	if r.Exchange == "" {
-		return errors.New(errExchangeNameUnset)
+	resp := gctrpc.GetCashFlowResponse{
+		Exchange: r.Exchange,
+	}
+	for x := range flows {
+		resp.Currencies = append(resp.Currencies, &gctrpc.CurrencyCashFlow{
+			Currency:           flows[x].Currency.String(),
+			Deposits:           flows[x].Deposits,
+			Withdrawals:        flows[x].Withdrawals,
+			Fees:               flows[x].Fees,
+			NetFlow:            flows[x].NetFlow,
+			PendingDeposits:    flows[x].PendingDeposits,
+			PendingWithdrawals: flows[x].PendingWithdrawals,
+			Balance:            flows[x].Balance,
+			Unexplained:        flows[x].Unexplained,
+			Transfers:          int64(flows[x].Transfers),
+		})
+	}
+	for x := range records {
+		resp.Transfers = append(resp.Transfers, &gctrpc.FundingRecord{
+			TransferId:   records[x].TransferID,
+			TransferType: records[x].TransferType,
+			Currency:     records[x].Currency,
+			Amount:       records[x].Amount,
+			Fee:          records[x].Fee,
+			Status:       records[x].Status,
+			Description:  records[x].Description,
+			Address:      records[x].Address,
+			TxId:         records[x].TxID,
+			Timestamp:    records[x].Time.UTC().Format(common.SimpleTimeFormat),
+		})
 	}
+	return &resp, nil
+}
 
-	if r.Pair.String() == "" {
-		return errors.New(errCurrencyPairUnset)
+// GetOpenInterest returns the latest and retained open interest of a
+// derivatives contract collected by the derivatives data collector
+func (s *RPCServer) GetOpenInterest(ctx context.Context, r *gctrpc.GetOpenInterestRequest) (*gctrpc.GetOpenInterestResponse, error) {
+	if r.Pair == nil {
+		return nil, order.ErrPairIsEmpty
 	}
 
-	if r.AssetType == "" {
-		return errors.New(errAssetTypeUnset)
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
 	}
 
 	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
-
-	pipe, err := orderbook.SubscribeOrderbook(r.Exchange, p, asset.Item(r.AssetType))
+	history, err := derivative.GetOpenInterestHistory(exch.GetName(), p, asset.Item(r.AssetType))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	defer pipe.Release()
+	resp := gctrpc.GetOpenInterestResponse{
+		Exchange:  exch.GetName(),
+		Pair:      p.String(),
+		AssetType: r.AssetType,
+	}
+	for x := range history {
+		resp.History = append(resp.History, &gctrpc.OpenInterest{
+			Amount:        history[x].Amount,
+			Value:         history[x].Value,
+			ValueCurrency: history[x].ValueCurrency.String(),
+			Timestamp:     history[x].Timestamp.UTC().Format(common.SimpleTimeFormat),
+		})
+	}
+	if len(resp.History) > 0 {
+		resp.Latest = resp.History[len(resp.History)-1]
+	}
+	return &resp, nil
+}
 
-	for {
-		data, ok := <-pipe.C
-		if !ok {
-			return errors.New(errDispatchSystem)
-		}
+// GetLiquidations returns the retained liquidations of a derivatives contract
+// since the start date
+func (s *RPCServer) GetLiquidations(ctx context.Context, r *gctrpc.GetLiquidationsRequest) (*gctrpc.GetLiquidationsResponse, error) {
+	if r.Pair == nil {
+		return nil, order.ErrPairIsEmpty
+	}
 
-		ob := (*data.(*interface{})).(orderbook.Base)
-		var bids, asks []*gctrpc.OrderbookItem
-		for i := range ob.Bids {
-			bids = append(bids, &gctrpc.OrderbookItem{
-				Amount: ob.Bids[i].Amount,
-				Price:  ob.Bids[i].Price,
-				Id:     ob.Bids[i].ID,
-			})
-		}
-		for i := range ob.Asks {
-			asks = append(asks, &gctrpc.OrderbookItem{
-				Amount: ob.Asks[i].Amount,
-				Price:  ob.Asks[i].Price,
-				Id:     ob.Asks[i].ID,
-			})
-		}
-		err := stream.Send(&gctrpc.OrderbookResponse{
-			Pair: &gctrpc.CurrencyPair{Base: ob.Pair.Base.String(),
-				Quote: ob.Pair.Quote.String()},
-			Bids:      bids,
-			Asks:      asks,
-			AssetType: ob.AssetType.String(),
-		})
+	var start time.Time
+	if r.StartDate != "" {
+		var err error
+		start, err = time.Parse(common.SimpleTimeFormat, r.StartDate)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-}
 
-// GetExchangeOrderbookStream streams all orderbooks associated with an exchange
-func (s *RPCServer) GetExchangeOrderbookStream(r *gctrpc.GetExchangeOrderbookStreamRequest, stream gctrpc.GoCryptoTrader_GetExchangeOrderbookStreamServer) error {
-	if r.Exchange == "" {
-		return errors.New(errExchangeNameUnset)
+	exch := GetExchangeByName(r.Exchange)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
 	}
 
-	pipe, err := orderbook.SubscribeToExchangeOrderbooks(r.Exchange)
+	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+	liquidations, err := derivative.GetLiquidations(exch.GetName(), p, asset.Item(r.AssetType), start)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	defer pipe.Release()
-
-	for {
-		data, ok := <-pipe.C
-		if !ok {
-			return errors.New(errDispatchSystem)
-		}
-
-		ob := (*data.(*interface{})).(orderbook.Base)
-		var bids, asks []*gctrpc.OrderbookItem
-		for i := range ob.Bids {
-			bids = append(bids, &gctrpc.OrderbookItem{
-				Amount: ob.Bids[i].Amount,
-				Price:  ob.Bids[i].Price,
-				Id:     ob.Bids[i].ID,
-			})
-		}
-		for i := range ob.Asks {
-			asks = append(asks, &gctrpc.OrderbookItem{
-				Amount: ob.Asks[i].Amount,
-				Price:  ob.Asks[i].Price,
-				Id:     ob.Asks[i].ID,
-			})
-		}
-		err := stream.Send(&gctrpc.OrderbookResponse{
-			Pair: &gctrpc.CurrencyPair{Base: ob.Pair.Base.String(),
-				Quote: ob.Pair.Quote.String()},
-			Bids:      bids,
-			Asks:      asks,
-			AssetType: ob.AssetType.String(),
-		})
-		if err != nil {
-			return err
-		}
+	var resp gctrpc.GetLiquidationsResponse
+	for x := range liquidations {
+		resp.Liquidations = append(resp.Liquidations, liquidationToRPC(&liquidations[x]))
 	}
+	return &resp, nil
 }
 
-// GetTickerStream streams the requested updated ticker
-func (s *RPCServer) GetTickerStream(r *gctrpc.GetTickerStreamRequest, stream gctrpc.GoCryptoTrader_GetTickerStreamServer) error {
+// GetLiquidationStream streams the liquidations of an exchange's derivatives
+// contracts as they are collected
+func (s *RPCServer) GetLiquidationStream(r *gctrpc.GetLiquidationStreamRequest, stream gctrpc.GoCryptoTrader_GetLiquidationStreamServer) error {
 	if r.Exchange == "" {
 		return errors.New(errExchangeNameUnset)
 	}
 
-	if r.Pair.String() == "" {
-		return errors.New(errCurrencyPairUnset)
-	}
-
-	if r.AssetType == "" {
-		return errors.New(errAssetTypeUnset)
-	}
-
-	p := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
-
-	pipe, err := ticker.SubscribeTicker(r.Exchange, p, asset.Item(r.AssetType))
+	pipe, err := derivative.SubscribeToExchange(r.Exchange)
 	if err != nil {
 		return err
 	}
@@ -1401,113 +3235,115 @@ func (s *RPCServer) GetTickerStream(r *gctrpc.GetTickerStreamRequest, stream gct
 		if !ok {
 			return errors.New(errDispatchSystem)
 		}
-		t := (*data.(*interface{})).(ticker.Price)
+		l, ok := (*data.(*interface{})).(derivative.Liquidation)
+		if !ok {
+			// open interest updates are published on the same stream
+			continue
+		}
 
-		err := stream.Send(&gctrpc.TickerResponse{
-			Pair: &gctrpc.CurrencyPair{
-				Base:      t.Pair.Base.String(),
-				Quote:     t.Pair.Quote.String(),
-				Delimiter: t.Pair.Delimiter},
-			LastUpdated: t.LastUpdated.Unix(),
-			Last:        t.Last,
-			High:        t.High,
-			Low:         t.Low,
-			Bid:         t.Bid,
-			Ask:         t.Ask,
-			Volume:      t.Volume,
-			PriceAth:    t.PriceATH,
-		})
+		err := stream.Send(liquidationToRPC(&l))
 		if err != nil {
 			return err
 		}
 	}
 }
 
-// GetExchangeTickerStream streams all tickers associated with an exchange
-func (s *RPCServer) GetExchangeTickerStream(r *gctrpc.GetExchangeTickerStreamRequest, stream gctrpc.GoCryptoTrader_GetExchangeTickerStreamServer) error {
-	if r.Exchange == "" {
-		return errors.New(errExchangeNameUnset)
+func liquidationToRPC(l *derivative.Liquidation) *gctrpc.Liquidation {
+	return &gctrpc.Liquidation{
+		Exchange:  l.Exchange,
+		Pair:      l.Pair.String(),
+		AssetType: l.AssetType.String(),
+		Id:        l.ID,
+		Side:      l.Side.String(),
+		Price:     l.Price,
+		Amount:    l.Amount,
+		Timestamp: l.Timestamp.UTC().Format(common.SimpleTimeFormat),
 	}
+}
 
-	pipe, err := ticker.SubscribeToExchangeTickers(r.Exchange)
+// ExportHistory exports an exchange's fills and transfers in the import format
+// of a portfolio tracker
+func (s *RPCServer) ExportHistory(ctx context.Context, r *gctrpc.ExportHistoryRequest) (*gctrpc.ExportHistoryResponse, error) {
+	format, err := export.ParseFormat(r.Format)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	defer pipe.Release()
-
-	for {
-		data, ok := <-pipe.C
-		if !ok {
-			return errors.New(errDispatchSystem)
-		}
-		t := (*data.(*interface{})).(ticker.Price)
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, err
+	}
 
-		err := stream.Send(&gctrpc.TickerResponse{
-			Pair: &gctrpc.CurrencyPair{
-				Base:      t.Pair.Base.String(),
-				Quote:     t.Pair.Quote.String(),
-				Delimiter: t.Pair.Delimiter},
-			LastUpdated: t.LastUpdated.Unix(),
-			Last:        t.Last,
-			High:        t.High,
-			Low:         t.Low,
-			Bid:         t.Bid,
-			Ask:         t.Ask,
-			Volume:      t.Volume,
-			PriceAth:    t.PriceATH,
-		})
-		if err != nil {
-			return err
-		}
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// GetAuditEvent returns matching audit events from database
-func (s *RPCServer) GetAuditEvent(ctx context.Context, r *gctrpc.GetAuditEventRequest) (*gctrpc.GetAuditEventResponse, error) {
-	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	records, err := ExportHistory(r.Exchange, start, end)
 	if err != nil {
 		return nil, err
 	}
 
-	UTCSEndTime, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	var buf bytes.Buffer
+	err = export.Write(&buf, format, records)
 	if err != nil {
 		return nil, err
 	}
 
-	loc := time.FixedZone("", int(r.Offset))
+	return &gctrpc.ExportHistoryResponse{
+		Format:  string(format),
+		Records: int64(len(records)),
+		Data:    buf.String(),
+	}, nil
+}
 
-	events, err := audit.GetEvent(UTCStartTime, UTCSEndTime, r.OrderBy, int(r.Limit))
+// ExportTaxReport matches the trade history of all authenticated exchanges
+// into lots and returns the disposals in the period as CSV with the total gain
+// in the fiat display currency
+func (s *RPCServer) ExportTaxReport(ctx context.Context, r *gctrpc.ExportTaxReportRequest) (*gctrpc.ExportTaxReportResponse, error) {
+	method, err := tax.ParseMethod(r.Method)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := gctrpc.GetAuditEventResponse{}
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, err
+	}
 
-	switch v := events.(type) {
-	case postgres.AuditEventSlice:
-		for x := range v {
-			tempEvent := &gctrpc.AuditEvent{
-				Type:       v[x].Type,
-				Identifier: v[x].Identifier,
-				Message:    v[x].Message,
-				Timestamp:  v[x].CreatedAt.In(loc).Format(common.SimpleTimeFormat),
-			}
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, err
+	}
 
-			resp.Events = append(resp.Events, tempEvent)
-		}
-	case sqlite3.AuditEventSlice:
-		for x := range v {
-			tempEvent := &gctrpc.AuditEvent{
-				Type:       v[x].Type,
-				Identifier: v[x].Identifier,
-				Message:    v[x].Message,
-				Timestamp:  v[x].CreatedAt,
-			}
-			resp.Events = append(resp.Events, tempEvent)
+	historyStart := start
+	if r.HistoryStartDate != "" {
+		historyStart, err = time.Parse(common.SimpleTimeFormat, r.HistoryStartDate)
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	disposals, err := TaxReport(method, historyStart, start, end, Bot.Config.Currency.FiatDisplayCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tax.WriteCSV(&buf, disposals)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := gctrpc.ExportTaxReportResponse{
+		Method:    string(method),
+		Disposals: int64(len(disposals)),
+		Gains:     make(map[string]float64),
+		Data:      buf.String(),
+	}
+	for x := range disposals {
+		resp.Gains[disposals[x].Currency.Upper().String()] += disposals[x].Gain
+	}
 	return &resp, nil
 }
 
@@ -1538,15 +3374,64 @@ func (s *RPCServer) GetHistoricCandles(ctx context.Context, req *gctrpc.GetHisto
 	if err != nil {
 		return nil, err
 	}
+	if candles.Interval == 0 {
+		candles.Interval = time.Duration(req.TimeInterval)
+	}
+	if req.FillMissing {
+		candles.FillMissing()
+	} else {
+		candles.UpdateChecksums()
+	}
+	resp := gctrpc.GetHistoricCandlesResponse{}
+	for i := range candles.Candles {
+		resp.Candle = append(resp.Candle, &gctrpc.Candle{
+			Time:     candles.Candles[i].Time.Unix(),
+			Low:      candles.Candles[i].Low,
+			High:     candles.Candles[i].High,
+			Open:     candles.Candles[i].Open,
+			Close:    candles.Candles[i].Close,
+			Volume:   candles.Candles[i].Volume,
+			Source:   candles.Candles[i].Source.String(),
+			Checksum: candles.Candles[i].Checksum,
+		})
+	}
+	return &resp, nil
+}
+
+// GetLiveCandles returns the rolling candles built from the trade feed of an
+// exchange's currency pair
+func (s *RPCServer) GetLiveCandles(ctx context.Context, req *gctrpc.GetLiveCandlesRequest) (*gctrpc.GetHistoricCandlesResponse, error) {
+	if req.Exchange == "" {
+		return nil, errors.New(errExchangeNameUnset)
+	}
+
+	if req.Pair.String() == "" {
+		return nil, errors.New(errCurrencyPairUnset)
+	}
+
+	candles, err := trade.GetCandles(req.Exchange,
+		currency.Pair{
+			Delimiter: req.Pair.Delimiter,
+			Base:      currency.NewCode(req.Pair.Base),
+			Quote:     currency.NewCode(req.Pair.Quote),
+		},
+		asset.Item(req.AssetType),
+		time.Duration(req.TimeInterval))
+	if err != nil {
+		return nil, err
+	}
+	candles.UpdateChecksums()
 	resp := gctrpc.GetHistoricCandlesResponse{}
 	for i := range candles.Candles {
 		resp.Candle = append(resp.Candle, &gctrpc.Candle{
-			Time:   candles.Candles[i].Time.Unix(),
-			Low:    candles.Candles[i].Low,
-			High:   candles.Candles[i].High,
-			Open:   candles.Candles[i].Open,
-			Close:  candles.Candles[i].Close,
-			Volume: candles.Candles[i].Volume,
+			Time:     candles.Candles[i].Time.Unix(),
+			Low:      candles.Candles[i].Low,
+			High:     candles.Candles[i].High,
+			Open:     candles.Candles[i].Open,
+			Close:    candles.Candles[i].Close,
+			Volume:   candles.Candles[i].Volume,
+			Source:   candles.Candles[i].Source.String(),
+			Checksum: candles.Candles[i].Checksum,
 		})
 	}
 	return &resp, nil
@@ -0,0 +1,339 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/export"
+)
+
+func (s *settlementManager) Started() bool {
+	return atomic.LoadInt32(&s.started) == 1
+}
+
+func (s *settlementManager) Start() error {
+	if atomic.AddInt32(&s.started, 1) != 1 {
+		return errors.New("settlement manager already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Settlement manager starting...")
+	if err := s.load(); err != nil {
+		atomic.CompareAndSwapInt32(&s.started, 1, 0)
+		return err
+	}
+	s.shutdown = make(chan struct{})
+	go s.run()
+	return nil
+}
+
+func (s *settlementManager) Stop() error {
+	if atomic.AddInt32(&s.stopped, 1) != 1 {
+		return errors.New("settlement manager is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Settlement manager shutting down...")
+	close(s.shutdown)
+	return nil
+}
+
+func (s *settlementManager) run() {
+	log.Debugln(log.PortfolioMgr, "Settlement manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(settlementCheckInterval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&s.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&s.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Settlement manager shutdown.")
+	}()
+
+	// a close missed while the bot was not running is not settled, the next
+	// close reconciles against the last recorded one
+	next := nextSettlementClose(clock.Now(), Bot.Config.Settlement.CloseTime)
+	for {
+		select {
+		case <-s.shutdown:
+			return
+		case <-tick.C:
+			now := clock.Now()
+			if now.Before(next) {
+				continue
+			}
+			if _, err := s.settle(next); err != nil {
+				log.Errorf(log.PortfolioMgr,
+					"Settlement manager: unable to settle %s close: %v\n",
+					next.Format(time.RFC3339),
+					err)
+			}
+			next = nextSettlementClose(now, Bot.Config.Settlement.CloseTime)
+		}
+	}
+}
+
+// nextSettlementClose returns the first daily close in UTC after now
+func nextSettlementClose(now time.Time, closeTime string) time.Time {
+	t, err := time.Parse(config.SettlementCloseTimeFormat, closeTime)
+	if err != nil {
+		t = time.Time{}
+	}
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// GetSettlements returns the recorded daily closes, oldest first
+func (s *settlementManager) GetSettlements() []Settlement {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]Settlement(nil), s.ledger...)
+}
+
+// settle snapshots the balances of every authenticated exchange, reconciles
+// them against the previous close and the day's fills and transfers, rolls
+// the day's realised profit and loss into the ledger, archives the day's
+// fills and sends the settlement report
+func (s *settlementManager) settle(closeTime time.Time) (*Settlement, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	start := closeTime.AddDate(0, 0, -1)
+	st := Settlement{
+		Close:    closeTime,
+		Currency: Bot.Config.Settlement.Currency,
+	}
+	var previous *Settlement
+	if len(s.ledger) > 0 {
+		previous = &s.ledger[len(s.ledger)-1]
+	}
+
+	var fills []export.Record
+	exchanges := GetAuthAPISupportedExchanges()
+	for x := range exchanges {
+		exch := GetExchangeByName(exchanges[x])
+		if exch == nil {
+			continue
+		}
+		holdings, err := exch.UpdateAccountInfo()
+		if err != nil {
+			st.Errors = append(st.Errors,
+				fmt.Sprintf("%s unable to update account info: %v", exchanges[x], err))
+			continue
+		}
+		records, err := ExportHistory(exchanges[x], start, closeTime)
+		if err != nil {
+			st.Errors = append(st.Errors,
+				fmt.Sprintf("%s unable to get fills: %v", exchanges[x], err))
+			continue
+		}
+		// the close belongs to the following day
+		day := records[:0]
+		for y := range records {
+			if records[y].Time.Before(closeTime) {
+				day = append(day, records[y])
+			}
+		}
+		fills = append(fills, day...)
+		st.Balances = append(st.Balances, reconcileBalances(exch.GetName(),
+			&holdings,
+			day,
+			previous,
+			Bot.Config.Settlement.Tolerance)...)
+	}
+	for x := range st.Balances {
+		if st.Balances[x].Break {
+			st.Breaks++
+		}
+	}
+	st.Fills = len(fills)
+
+	positions := Bot.PositionManager.GetAll()
+	for x := range positions {
+		v, ok := positions[x].valueIn(st.Currency)
+		if !ok {
+			st.Errors = append(st.Errors,
+				fmt.Sprintf("unable to value %s %s realised profit and loss in %s",
+					positions[x].Exchange,
+					positions[x].Pair,
+					st.Currency))
+			continue
+		}
+		st.CumulativePNL += v.RealisedPNL
+	}
+	st.RealisedPNL = st.CumulativePNL
+	if previous != nil {
+		st.RealisedPNL -= previous.CumulativePNL
+	}
+
+	if err := s.record(&st, start, fills); err != nil {
+		return nil, err
+	}
+
+	report := settlementReport(&st)
+	if st.Breaks > 0 || len(st.Errors) > 0 {
+		log.Warnln(log.PortfolioMgr, report)
+	} else {
+		log.Infoln(log.PortfolioMgr, report)
+	}
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "settlement",
+		Message: report,
+	})
+	return &st, nil
+}
+
+// reconcileBalances compares the close balance of each currency held on an
+// exchange with its previous close balance adjusted by the day's records.
+// Balances are only reconciled once the exchange has a previous close
+func reconcileBalances(exchName string, holdings *account.Holdings, records []export.Record, previous *Settlement, tolerance float64) []VenueBalance {
+	balances := make(map[string]*VenueBalance)
+	var codes []string
+	get := func(c currency.Code) *VenueBalance {
+		key := c.Upper().String()
+		b, ok := balances[key]
+		if !ok {
+			b = &VenueBalance{Exchange: exchName, Currency: c.Upper()}
+			balances[key] = b
+			codes = append(codes, key)
+		}
+		return b
+	}
+
+	for x := range holdings.Accounts {
+		for y := range holdings.Accounts[x].Currencies {
+			c := holdings.Accounts[x].Currencies[y]
+			get(c.CurrencyName).Balance += c.TotalValue
+		}
+	}
+
+	var hasPrevious bool
+	if previous != nil {
+		for x := range previous.Balances {
+			if !strings.EqualFold(previous.Balances[x].Exchange, exchName) {
+				continue
+			}
+			hasPrevious = true
+			get(previous.Balances[x].Currency).Expected += previous.Balances[x].Balance
+		}
+	}
+	for x := range records {
+		if !records[x].BuyCurrency.IsEmpty() {
+			get(records[x].BuyCurrency).Expected += records[x].BuyAmount
+		}
+		if !records[x].SellCurrency.IsEmpty() {
+			get(records[x].SellCurrency).Expected -= records[x].SellAmount
+		}
+		if !records[x].FeeCurrency.IsEmpty() {
+			get(records[x].FeeCurrency).Expected -= records[x].FeeAmount
+		}
+	}
+
+	sort.Strings(codes)
+	resp := make([]VenueBalance, 0, len(codes))
+	for x := range codes {
+		b := balances[codes[x]]
+		if !hasPrevious {
+			b.Expected = b.Balance
+		}
+		b.Difference = b.Balance - b.Expected
+		b.Break = math.Abs(b.Difference) > tolerance
+		resp = append(resp, *b)
+	}
+	return resp
+}
+
+// record archives the day's fills and appends the settlement to the ledger.
+// Must be called with the lock held
+func (s *settlementManager) record(st *Settlement, day time.Time, fills []export.Record) error {
+	if s.dir == "" {
+		s.dir = filepath.Join(Bot.Settings.DataDir, settlementDir)
+	}
+	if fills == nil {
+		fills = []export.Record{}
+	}
+	data, err := json.MarshalIndent(fills, "", " ")
+	if err != nil {
+		return err
+	}
+	st.FillsArchive = filepath.Join(s.dir, "fills-"+day.Format("20060102")+".json")
+	if err = file.Write(st.FillsArchive, data); err != nil {
+		return err
+	}
+
+	ledger := append(s.ledger, *st)
+	data, err = json.MarshalIndent(ledger, "", " ")
+	if err != nil {
+		return err
+	}
+	if err = file.Write(filepath.Join(s.dir, settlementLedgerFile), data); err != nil {
+		return err
+	}
+	s.ledger = ledger
+	return nil
+}
+
+// load reads the ledger written by previous settlements
+func (s *settlementManager) load() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.dir == "" {
+		s.dir = filepath.Join(Bot.Settings.DataDir, settlementDir)
+	}
+	path := filepath.Join(s.dir, settlementLedgerFile)
+	if !file.Exists(path) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var ledger []Settlement
+	if err = json.Unmarshal(data, &ledger); err != nil {
+		return fmt.Errorf("unable to load settlement ledger from %s: %v", path, err)
+	}
+	s.ledger = ledger
+	return nil
+}
+
+// settlementReport formats a settlement for operations users
+func settlementReport(st *Settlement) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Settlement report for close %s\n", st.Close.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Realised PnL: %v %s (cumulative %v %s)\n",
+		st.RealisedPNL,
+		st.Currency,
+		st.CumulativePNL,
+		st.Currency)
+	fmt.Fprintf(&b, "Fills archived: %d (%s)\n", st.Fills, st.FillsArchive)
+	fmt.Fprintf(&b, "Reconciliation breaks: %d\n", st.Breaks)
+	for x := range st.Balances {
+		v := &st.Balances[x]
+		status := "OK"
+		if v.Break {
+			status = fmt.Sprintf("BREAK expected %v difference %v", v.Expected, v.Difference)
+		}
+		fmt.Fprintf(&b, "%s %s: %v %s\n", v.Exchange, v.Currency, v.Balance, status)
+	}
+	for x := range st.Errors {
+		fmt.Fprintf(&b, "Error: %s\n", st.Errors[x])
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/export"
+)
+
+func TestNextSettlementClose(t *testing.T) {
+	now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	if next := nextSettlementClose(now, "00:00"); !next.Equal(time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the following midnight, got %v", next)
+	}
+	if next := nextSettlementClose(now, "17:30"); !next.Equal(time.Date(2020, 3, 1, 17, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected the same day's close, got %v", next)
+	}
+	if next := nextSettlementClose(now, "12:00"); !next.Equal(time.Date(2020, 3, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected a close at now to roll to the following day, got %v", next)
+	}
+}
+
+func TestReconcileBalances(t *testing.T) {
+	holdings := &account.Holdings{Accounts: []account.SubAccount{{
+		Currencies: []account.Balance{
+			{CurrencyName: currency.BTC, TotalValue: 1.5},
+			{CurrencyName: currency.USD, TotalValue: 500},
+		},
+	}}}
+	records := []export.Record{{
+		Type:         export.Trade,
+		BuyAmount:    0.5,
+		BuyCurrency:  currency.BTC,
+		SellAmount:   490,
+		SellCurrency: currency.USD,
+		FeeAmount:    10,
+		FeeCurrency:  currency.USD,
+	}}
+
+	// the first close has nothing to reconcile against
+	balances := reconcileBalances("Bitstamp", holdings, records, nil, 0.0001)
+	if len(balances) != 2 || balances[0].Break || balances[1].Break || balances[0].Expected != 1.5 {
+		t.Fatalf("unexpected balances %+v", balances)
+	}
+
+	previous := &Settlement{Balances: []VenueBalance{
+		{Exchange: "bitstamp", Currency: currency.BTC, Balance: 1},
+		{Exchange: "bitstamp", Currency: currency.USD, Balance: 1000},
+		{Exchange: "bitstamp", Currency: currency.LTC, Balance: 3},
+		{Exchange: "Other", Currency: currency.USD, Balance: 5},
+	}}
+	balances = reconcileBalances("Bitstamp", holdings, records, previous, 0.0001)
+	if len(balances) != 3 {
+		t.Fatalf("expected 3 balances, got %+v", balances)
+	}
+	for x := range balances {
+		switch {
+		case balances[x].Currency.Match(currency.BTC), balances[x].Currency.Match(currency.USD):
+			if balances[x].Break {
+				t.Errorf("expected %s to reconcile, got %+v", balances[x].Currency, balances[x])
+			}
+		case balances[x].Currency.Match(currency.LTC):
+			if !balances[x].Break || balances[x].Difference != -3 {
+				t.Errorf("expected missing LTC to break, got %+v", balances[x])
+			}
+		default:
+			t.Errorf("unexpected balance %+v", balances[x])
+		}
+	}
+}
+
+func TestSettlementRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "settlement")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := settlementManager{dir: dir}
+	closeTime := time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)
+	st := Settlement{Close: closeTime, Currency: currency.USD, RealisedPNL: 10, CumulativePNL: 10}
+	err = s.record(&st, closeTime.AddDate(0, 0, -1), []export.Record{{Type: export.Trade, ID: "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !file.Exists(st.FillsArchive) {
+		t.Errorf("expected fills to be archived to %s", st.FillsArchive)
+	}
+
+	loaded := settlementManager{dir: dir}
+	if err = loaded.load(); err != nil {
+		t.Fatal(err)
+	}
+	settlements := loaded.GetSettlements()
+	if len(settlements) != 1 || settlements[0].CumulativePNL != 10 || !settlements[0].Close.Equal(closeTime) {
+		t.Errorf("expected the ledger to be restored, got %+v", settlements)
+	}
+
+	report := settlementReport(&settlements[0])
+	if report == "" {
+		t.Error("expected a settlement report")
+	}
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+const (
+	// settlementDir is the data directory folder the ledger and archived
+	// fills are written to
+	settlementDir        = "settlement"
+	settlementLedgerFile = "ledger.json"
+	// settlementCheckInterval is how often the settlement manager checks
+	// whether the daily close has passed
+	settlementCheckInterval = time.Minute
+)
+
+// VenueBalance is the balance of a currency on an exchange at the daily
+// close, reconciled against the previous close and the day's fills and
+// transfers
+type VenueBalance struct {
+	Exchange string        `json:"exchange"`
+	Currency currency.Code `json:"currency"`
+	Balance  float64       `json:"balance"`
+	// Expected is the previous close balance adjusted by the day's fills,
+	// transfers and fees
+	Expected   float64 `json:"expected"`
+	Difference float64 `json:"difference"`
+	// Break is set when the balance differs from the expected balance by
+	// more than the configured tolerance
+	Break bool `json:"break"`
+}
+
+// Settlement is the daily close of every authenticated exchange
+type Settlement struct {
+	Close    time.Time      `json:"close"`
+	Currency currency.Code  `json:"currency"`
+	Balances []VenueBalance `json:"balances"`
+	Breaks   int            `json:"breaks"`
+	Fills    int            `json:"fills"`
+	// FillsArchive is the file the day's fills and transfers were written to
+	FillsArchive string `json:"fills_archive"`
+	// RealisedPNL is the profit and loss realised since the previous close
+	// in the settlement currency, CumulativePNL is the total realised
+	RealisedPNL   float64  `json:"realised_pnl"`
+	CumulativePNL float64  `json:"cumulative_pnl"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+type settlementManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m sync.Mutex
+	// dir is the folder the ledger and archived fills are written to
+	dir    string
+	ledger []Settlement
+}
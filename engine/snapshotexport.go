@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (s *snapshotExporter) Started() bool {
+	return atomic.LoadInt32(&s.started) == 1
+}
+
+func (s *snapshotExporter) Start() error {
+	if atomic.AddInt32(&s.started, 1) != 1 {
+		return errors.New("snapshot exporter already started")
+	}
+
+	log.Debugln(log.SyncMgr, "Snapshot exporter starting...")
+	s.dir = Bot.Config.SnapshotExport.Directory
+	if s.dir == "" {
+		s.dir = filepath.Join(Bot.Settings.DataDir, snapshotExportDir)
+	}
+	if err := common.CreateDir(s.dir); err != nil {
+		atomic.CompareAndSwapInt32(&s.started, 1, 0)
+		return err
+	}
+	s.shutdown = make(chan struct{})
+	go s.run()
+	return nil
+}
+
+func (s *snapshotExporter) Stop() error {
+	if atomic.AddInt32(&s.stopped, 1) != 1 {
+		return errors.New("snapshot exporter is already stopped")
+	}
+
+	log.Debugln(log.SyncMgr, "Snapshot exporter shutting down...")
+	close(s.shutdown)
+	return nil
+}
+
+func (s *snapshotExporter) run() {
+	log.Debugf(log.SyncMgr, "Snapshot exporter started, writing snapshots to %s.\n", s.dir)
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.SnapshotExport.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&s.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&s.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.SyncMgr, "Snapshot exporter shutdown.")
+	}()
+
+	for {
+		select {
+		case <-s.shutdown:
+			return
+		case t := <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			if err := s.export(t, &Bot.Config.SnapshotExport); err != nil {
+				log.Errorf(log.SyncMgr, "Snapshot exporter: %v\n", err)
+			}
+			s.prune(t, Bot.Config.SnapshotExport.Retention)
+		}
+	}
+}
+
+// export writes a snapshot of each enabled pair which has a synced ticker or
+// orderbook to the file of its exchange, pair and asset covering t
+func (s *snapshotExporter) export(t time.Time, cfg *config.SnapshotExportConfig) error {
+	files := make(map[string][]MarketSnapshot)
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		assets := exchanges[x].GetAssetTypes()
+		for y := range assets {
+			pairs := exchanges[x].GetEnabledPairs(assets[y])
+			for z := range pairs {
+				snapshot, ok := marketSnapshot(t, exchanges[x].GetName(), pairs[z], assets[y])
+				if !ok {
+					continue
+				}
+				path := s.filePath(&snapshot, t, cfg)
+				files[path] = append(files[path], snapshot)
+			}
+		}
+	}
+
+	var failed int
+	for path, snapshots := range files {
+		if err := writeSnapshots(path, cfg.Format, snapshots); err != nil {
+			log.Errorf(log.SyncMgr, "Snapshot exporter: unable to write %s: %v\n", path, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d snapshot files could not be written", failed, len(files))
+	}
+	return nil
+}
+
+// marketSnapshot returns the ticker and top of book of a pair, returning false
+// when neither has been synced
+func marketSnapshot(t time.Time, exchName string, p currency.Pair, a asset.Item) (MarketSnapshot, bool) {
+	snapshot := MarketSnapshot{
+		Time:     t.UTC(),
+		Exchange: exchName,
+		Pair:     p.Base.Upper().String() + "-" + p.Quote.Upper().String(),
+		Asset:    a.String(),
+	}
+	tick, tickErr := ticker.GetTicker(exchName, p, a)
+	if tickErr == nil {
+		snapshot.Last = tick.Last
+		snapshot.Bid = tick.Bid
+		snapshot.Ask = tick.Ask
+		snapshot.Volume = tick.Volume
+		snapshot.TickerUpdated = tick.LastUpdated.UTC()
+	}
+	ob, obErr := orderbook.Get(exchName, p, a)
+	if obErr == nil {
+		if len(ob.Bids) > 0 {
+			snapshot.BestBid, snapshot.BestBidAmount = ob.Bids[0].Price, ob.Bids[0].Amount
+		}
+		if len(ob.Asks) > 0 {
+			snapshot.BestAsk, snapshot.BestAskAmount = ob.Asks[0].Price, ob.Asks[0].Amount
+		}
+		snapshot.OrderbookUpdated = ob.LastUpdated.UTC()
+	}
+	return snapshot, tickErr == nil || obErr == nil
+}
+
+// filePath returns the file a snapshot is written to, named after the start
+// of the rotation period covering t
+func (s *snapshotExporter) filePath(snapshot *MarketSnapshot, t time.Time, cfg *config.SnapshotExportConfig) string {
+	period := t.UTC().Truncate(cfg.Rotation)
+	return filepath.Join(s.dir, snapshot.Exchange, fmt.Sprintf("%s_%s_%s.%s",
+		snapshot.Pair,
+		snapshot.Asset,
+		period.Format(snapshotExportTimeFormat),
+		cfg.Format))
+}
+
+// writeSnapshots appends snapshots to a CSV or JSON lines file, writing the
+// CSV header when the file is created
+func writeSnapshots(path, format string, snapshots []MarketSnapshot) error {
+	if err := common.CreateDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if format == config.SnapshotExportJSON {
+		enc := json.NewEncoder(f)
+		for x := range snapshots {
+			if err = enc.Encode(&snapshots[x]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		if err = w.Write(snapshotExportHeader); err != nil {
+			return err
+		}
+	}
+	for x := range snapshots {
+		if err = w.Write(snapshots[x].record()); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (m *MarketSnapshot) record() []string {
+	return []string{
+		m.Time.Format(time.RFC3339Nano),
+		m.Exchange,
+		m.Pair,
+		m.Asset,
+		strconv.FormatFloat(m.Last, 'f', -1, 64),
+		strconv.FormatFloat(m.Bid, 'f', -1, 64),
+		strconv.FormatFloat(m.Ask, 'f', -1, 64),
+		strconv.FormatFloat(m.Volume, 'f', -1, 64),
+		snapshotTime(m.TickerUpdated),
+		strconv.FormatFloat(m.BestBid, 'f', -1, 64),
+		strconv.FormatFloat(m.BestBidAmount, 'f', -1, 64),
+		strconv.FormatFloat(m.BestAsk, 'f', -1, 64),
+		strconv.FormatFloat(m.BestAskAmount, 'f', -1, 64),
+		snapshotTime(m.OrderbookUpdated),
+	}
+}
+
+// snapshotTime formats a time, leaving unsynced times empty
+func snapshotTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// prune removes the snapshot files which have not been written to within the
+// retention period
+func (s *snapshotExporter) prune(now time.Time, retention time.Duration) {
+	cutoff := now.Add(-retention)
+	var expired []string
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != "."+config.SnapshotExportCSV && ext != "."+config.SnapshotExportJSON {
+			return nil
+		}
+		if info.ModTime().Before(cutoff) {
+			expired = append(expired, path)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(log.SyncMgr, "Snapshot exporter: unable to list snapshot files: %v\n", err)
+		return
+	}
+	sort.Strings(expired)
+	for x := range expired {
+		if err = os.Remove(expired[x]); err != nil {
+			log.Errorf(log.SyncMgr, "Snapshot exporter: unable to remove %s: %v\n", expired[x], err)
+			continue
+		}
+		log.Debugf(log.SyncMgr, "Snapshot exporter: removed expired snapshot file %s.\n", expired[x])
+	}
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func TestMarketSnapshot(t *testing.T) {
+	t.Parallel()
+	const exchName = "snapshotexport"
+	p := currency.NewPair(currency.BTC, currency.USD)
+	now := time.Now()
+	if _, ok := marketSnapshot(now, exchName, p, asset.Spot); ok {
+		t.Error("expected no snapshot for an unsynced pair")
+	}
+
+	err := ticker.ProcessTicker(exchName, &ticker.Price{Pair: p, Last: 100, Bid: 99, Ask: 101, Volume: 5}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, ok := marketSnapshot(now, exchName, p, asset.Spot)
+	if !ok || snapshot.Last != 100 || snapshot.Bid != 99 || snapshot.Volume != 5 ||
+		snapshot.TickerUpdated.IsZero() || !snapshot.OrderbookUpdated.IsZero() {
+		t.Errorf("unexpected ticker only snapshot %+v", snapshot)
+	}
+
+	ob := orderbook.Base{
+		Pair:         p,
+		Bids:         []orderbook.Item{{Price: 99.5, Amount: 2}, {Price: 99, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 100.5, Amount: 3}},
+		ExchangeName: exchName,
+		AssetType:    asset.Spot,
+	}
+	if err = ob.Process(); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, ok = marketSnapshot(now, exchName, p, asset.Spot)
+	if !ok || snapshot.Pair != "BTC-USD" || snapshot.Asset != "spot" ||
+		snapshot.BestBid != 99.5 || snapshot.BestBidAmount != 2 ||
+		snapshot.BestAsk != 100.5 || snapshot.BestAskAmount != 3 {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+}
+
+func TestWriteSnapshots(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := snapshotExporter{dir: dir}
+	cfg := config.SnapshotExportConfig{Format: config.SnapshotExportCSV, Rotation: time.Hour * 24}
+	now := time.Date(2020, 5, 27, 13, 30, 0, 0, time.UTC)
+	snapshot := MarketSnapshot{
+		Time:     now,
+		Exchange: "Bitstamp",
+		Pair:     "BTC-USD",
+		Asset:    "spot",
+		Last:     9000.5,
+		BestBid:  9000,
+	}
+	path := s.filePath(&snapshot, now, &cfg)
+	if expected := filepath.Join(dir, "Bitstamp", "BTC-USD_spot_20200527T000000Z.csv"); path != expected {
+		t.Errorf("expected %s, got %s", expected, path)
+	}
+	for x := 0; x < 2; x++ {
+		if err = writeSnapshots(path, cfg.Format, []MarketSnapshot{snapshot}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 3 || lines[0] != strings.Join(snapshotExportHeader, ",") ||
+		lines[1] != "2020-05-27T13:30:00Z,Bitstamp,BTC-USD,spot,9000.5,0,0,0,,9000,0,0,0," {
+		t.Errorf("unexpected CSV snapshots %v", lines)
+	}
+
+	cfg.Format = config.SnapshotExportJSON
+	cfg.Rotation = time.Hour
+	path = s.filePath(&snapshot, now, &cfg)
+	if !strings.HasSuffix(path, "BTC-USD_spot_20200527T130000Z.json") {
+		t.Errorf("unexpected rotated file %s", path)
+	}
+	if err = writeSnapshots(path, cfg.Format, []MarketSnapshot{snapshot, snapshot}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var count int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var read MarketSnapshot
+		if err = json.Unmarshal(scanner.Bytes(), &read); err != nil {
+			t.Fatal(err)
+		}
+		if read.Last != snapshot.Last || !read.Time.Equal(now) {
+			t.Errorf("unexpected JSON snapshot %+v", read)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("expected 2 JSON snapshots, got %d", count)
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	files := map[string]time.Time{
+		filepath.Join(dir, "Bitstamp", "old.csv"):   now.Add(-time.Hour * 48),
+		filepath.Join(dir, "Bitstamp", "old.json"):  now.Add(-time.Hour * 48),
+		filepath.Join(dir, "Bitstamp", "new.csv"):   now,
+		filepath.Join(dir, "Bitstamp", "notes.txt"): now.Add(-time.Hour * 48),
+	}
+	if err = os.MkdirAll(filepath.Join(dir, "Bitstamp"), 0770); err != nil {
+		t.Fatal(err)
+	}
+	for path, modified := range files {
+		if err = ioutil.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := snapshotExporter{dir: dir}
+	s.prune(now, time.Hour*24)
+	for path := range files {
+		_, err = os.Stat(path)
+		expired := strings.Contains(path, "old")
+		if expired && !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", path)
+		}
+		if !expired && err != nil {
+			t.Errorf("expected %s to be retained: %v", path, err)
+		}
+	}
+}
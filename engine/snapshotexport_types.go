@@ -0,0 +1,58 @@
+package engine
+
+import "time"
+
+const (
+	// snapshotExportDir is the data directory folder snapshots are written
+	// to when no directory is configured
+	snapshotExportDir = "snapshots"
+	// snapshotExportTimeFormat formats the start of the rotation period a
+	// snapshot file covers in its name
+	snapshotExportTimeFormat = "20060102T150405Z"
+)
+
+// snapshotExportHeader is the header of CSV snapshot files
+var snapshotExportHeader = []string{
+	"time",
+	"exchange",
+	"pair",
+	"asset",
+	"last",
+	"bid",
+	"ask",
+	"volume",
+	"ticker_updated",
+	"best_bid",
+	"best_bid_amount",
+	"best_ask",
+	"best_ask_amount",
+	"orderbook_updated",
+}
+
+// MarketSnapshot is the ticker and top of book of an exchange pair at a point
+// in time. Fields of a ticker or orderbook which has not been synced are zero
+type MarketSnapshot struct {
+	Time             time.Time `json:"time"`
+	Exchange         string    `json:"exchange"`
+	Pair             string    `json:"pair"`
+	Asset            string    `json:"asset"`
+	Last             float64   `json:"last"`
+	Bid              float64   `json:"bid"`
+	Ask              float64   `json:"ask"`
+	Volume           float64   `json:"volume"`
+	TickerUpdated    time.Time `json:"ticker_updated"`
+	BestBid          float64   `json:"best_bid"`
+	BestBidAmount    float64   `json:"best_bid_amount"`
+	BestAsk          float64   `json:"best_ask"`
+	BestAskAmount    float64   `json:"best_ask_amount"`
+	OrderbookUpdated time.Time `json:"orderbook_updated"`
+}
+
+type snapshotExporter struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	// dir is the folder snapshot files are written to, in a folder per
+	// exchange
+	dir string
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// begin starts coordinating startup steps for the configured window
+func (s *startupCoordinator) begin(cfg *config.StartupConfig) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if cfg.Window <= 0 || cfg.MaxConcurrent <= 0 {
+		s.slots = nil
+		return
+	}
+	s.slots = make(chan struct{}, cfg.MaxConcurrent)
+	s.stagger = cfg.ExchangeStagger
+	s.until = time.Now().Add(cfg.Window)
+	s.next = make(map[string]time.Time)
+	log.Debugf(log.ExchangeSys,
+		"Startup coordinator: staggering exchange startup for %v, %d steps at once and %v apart on each exchange.\n",
+		cfg.Window,
+		cfg.MaxConcurrent,
+		cfg.ExchangeStagger)
+}
+
+// step blocks until a startup step of the exchange may start and returns the
+// func to call once it has finished. Steps started after the startup window
+// has passed are not coordinated
+func (s *startupCoordinator) step(exchName string) func() {
+	s.m.Lock()
+	now := time.Now()
+	if s.slots == nil || !now.Before(s.until) {
+		s.m.Unlock()
+		return func() {}
+	}
+	slots := s.slots
+	key := strings.ToLower(exchName)
+	at := s.next[key]
+	if at.Before(now) {
+		at = now
+	}
+	s.next[key] = at.Add(s.stagger)
+	s.m.Unlock()
+
+	time.Sleep(at.Sub(now))
+	slots <- struct{}{}
+	return func() {
+		<-slots
+	}
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+func TestStartupCoordinatorDisabled(t *testing.T) {
+	t.Parallel()
+	var s startupCoordinator
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		s.step("Bitstamp")()
+	}
+	s.begin(&config.StartupConfig{})
+	s.step("Bitstamp")()
+	if time.Since(start) > time.Millisecond*100 {
+		t.Error("expected steps not to be coordinated")
+	}
+}
+
+func TestStartupCoordinatorStagger(t *testing.T) {
+	t.Parallel()
+	var s startupCoordinator
+	s.begin(&config.StartupConfig{
+		Window:          time.Minute,
+		MaxConcurrent:   10,
+		ExchangeStagger: time.Millisecond * 50,
+	})
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.step("Bitstamp")()
+		}()
+	}
+	// steps of other exchanges are not delayed by Bitstamp's
+	s.step("Binance")()
+	if time.Since(start) > time.Millisecond*40 {
+		t.Error("expected the first step of an exchange to start immediately")
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < time.Millisecond*150 {
+		t.Errorf("expected 4 steps to be spaced 50ms apart, took %v", elapsed)
+	}
+}
+
+func TestStartupCoordinatorConcurrency(t *testing.T) {
+	t.Parallel()
+	var s startupCoordinator
+	s.begin(&config.StartupConfig{Window: time.Minute, MaxConcurrent: 2})
+	var running, peak int32
+	var wg sync.WaitGroup
+	for _, exch := range []string{"a", "b", "c", "d", "e"} {
+		wg.Add(1)
+		go func(exch string) {
+			defer wg.Done()
+			release := s.step(exch)
+			defer release()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 20)
+			atomic.AddInt32(&running, -1)
+		}(exch)
+	}
+	wg.Wait()
+	if peak != 2 {
+		t.Errorf("expected at most 2 steps at once, received %d", peak)
+	}
+
+	// steps after the window has passed are not limited
+	s.begin(&config.StartupConfig{Window: time.Nanosecond, MaxConcurrent: 1})
+	time.Sleep(time.Millisecond)
+	s.step("a")()
+	s.step("a")()
+}
@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// startupCoordinator staggers the bursts of requests exchanges make as the bot
+// starts, such as fetching their symbols, connecting and subscribing their
+// websockets and fetching the initial ticker and orderbook snapshots of each
+// pair. Steps are limited to a number at once across all exchanges and are
+// spaced apart on each exchange, so that starting with many exchanges and
+// pairs enabled does not exceed their rate limits. The zero value does not
+// coordinate steps
+type startupCoordinator struct {
+	m       sync.Mutex
+	slots   chan struct{}
+	stagger time.Duration
+	until   time.Time
+	// next is when the next step of each exchange may start, keyed by the
+	// lower case exchange name
+	next map[string]time.Time
+}
@@ -0,0 +1,507 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+	"github.com/thrasher-corp/gocryptotrader/strategies/dca"
+	// strategies compiled into the bot
+	_ "github.com/thrasher-corp/gocryptotrader/strategies/smacross"
+)
+
+// vars for the strategy manager
+var (
+	errStrategyManagerNotStarted = errors.New("strategy manager is not started")
+	errStrategyNotConfigured     = errors.New("strategy is not configured")
+	errStrategyRunning           = errors.New("strategy is already running")
+	errStrategyNotRunning        = errors.New("strategy is not running")
+	errStrategyNotDCA            = errors.New("strategy is not a " + dca.Name + " strategy")
+)
+
+func (s *strategyManager) Started() bool {
+	return atomic.LoadInt32(&s.started) == 1
+}
+
+func (s *strategyManager) Start() error {
+	if atomic.AddInt32(&s.started, 1) != 1 {
+		return errors.New("strategy manager already started")
+	}
+
+	log.Debugln(log.OrderMgr, "Strategy manager starting...")
+	for x := range Bot.Config.Strategies.Plugins {
+		name, err := strategies.LoadPlugin(Bot.Config.Strategies.Plugins[x])
+		if err != nil {
+			log.Errorf(log.OrderMgr,
+				"Strategy manager: unable to load plugin %s: %v\n",
+				Bot.Config.Strategies.Plugins[x],
+				err)
+			continue
+		}
+		log.Debugf(log.OrderMgr,
+			"Strategy manager: loaded strategy %s from plugin %s.\n",
+			name,
+			Bot.Config.Strategies.Plugins[x])
+	}
+
+	s.m.Lock()
+	s.runners = make(map[string]*strategyRunner)
+	s.m.Unlock()
+	s.shutdown = make(chan struct{})
+	go s.run()
+
+	for x := range Bot.Config.Strategies.Strategies {
+		if !Bot.Config.Strategies.Strategies[x].Enabled {
+			continue
+		}
+		if err := s.StartStrategy(Bot.Config.Strategies.Strategies[x].Name); err != nil {
+			log.Errorf(log.OrderMgr, "Strategy manager: %v\n", err)
+		}
+	}
+	return nil
+}
+
+func (s *strategyManager) Stop() error {
+	if atomic.AddInt32(&s.stopped, 1) != 1 {
+		return errors.New("strategy manager is already stopped")
+	}
+
+	log.Debugln(log.OrderMgr, "Strategy manager shutting down...")
+	close(s.shutdown)
+	return nil
+}
+
+func (s *strategyManager) run() {
+	log.Debugln(log.OrderMgr, "Strategy manager started.")
+	Bot.ServicesWG.Add(1)
+	defer func() {
+		atomic.CompareAndSwapInt32(&s.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&s.started, 1, 0)
+		Bot.ServicesWG.Done()
+		log.Debugln(log.OrderMgr, "Strategy manager shutdown.")
+	}()
+
+	<-s.shutdown
+	s.m.Lock()
+	runners := make([]*strategyRunner, 0, len(s.runners))
+	for _, r := range s.runners {
+		runners = append(runners, r)
+	}
+	s.m.Unlock()
+	// the lock is not held while waiting as hooks placing orders pass the
+	// order updates back through the manager
+	for x := range runners {
+		runners[x].stop()
+	}
+}
+
+// StartStrategy initialises a configured strategy instance and starts calling
+// its hooks
+func (s *strategyManager) StartStrategy(name string) error {
+	if !s.Started() {
+		return errStrategyManagerNotStarted
+	}
+	cfg, err := strategyConfig(name)
+	if err != nil {
+		return err
+	}
+
+	key := strings.ToLower(cfg.Name)
+	if s.isRunning(key) {
+		return fmt.Errorf("%s %v", cfg.Name, errStrategyRunning)
+	}
+	strategy, err := strategies.New(cfg.Strategy)
+	if err != nil {
+		return err
+	}
+	saved, resume := s.savedState(key, cfg.Strategy)
+	err = strategy.Init(&strategies.Context{
+		Name:       cfg.Name,
+		Exchange:   cfg.Exchange,
+		Pair:       cfg.Pair,
+		AssetType:  cfg.AssetType,
+		Interval:   cfg.Interval,
+		Parameters: cfg.Parameters,
+		Trader:     strategyTrader{session: cfg.Name},
+	})
+	if err != nil {
+		return fmt.Errorf("strategy %s unable to initialise: %v", cfg.Name, err)
+	}
+	if st, ok := strategy.(strategies.Stateful); ok && resume && len(saved.State) > 0 {
+		if err = st.RestoreState(saved.State); err != nil {
+			return fmt.Errorf("strategy %s unable to restore its state: %v", cfg.Name, err)
+		}
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if r, ok := s.runners[key]; ok && r.running() {
+		return fmt.Errorf("%s %v", cfg.Name, errStrategyRunning)
+	}
+	r := newStrategyRunner(cfg, strategy)
+	if resume {
+		r.status.LastCandle = saved.LastCandle
+	}
+	s.runners[key] = r
+	go r.run()
+	log.Infof(log.OrderMgr, "Strategy manager: started %s.\n", cfg.Name)
+	return nil
+}
+
+// StopStrategy stops calling the hooks of a running strategy instance
+func (s *strategyManager) StopStrategy(name string) error {
+	if !s.Started() {
+		return errStrategyManagerNotStarted
+	}
+	s.m.Lock()
+	r, ok := s.runners[strings.ToLower(name)]
+	s.m.Unlock()
+	if !ok || !r.running() {
+		return fmt.Errorf("%s %v", name, errStrategyNotRunning)
+	}
+	r.stop()
+	log.Infof(log.OrderMgr, "Strategy manager: stopped %s.\n", r.cfg.Name)
+	return nil
+}
+
+func (s *strategyManager) isRunning(key string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	r, ok := s.runners[key]
+	return ok && r.running()
+}
+
+// savedState returns the state a strategy instance resumes from when bot state
+// is persisted. This is the state of its previous runner or, when it has not
+// run since the bot started, the state restored from disk. State saved by a
+// different strategy under the same name is not resumed
+func (s *strategyManager) savedState(key, strategy string) (StrategyState, bool) {
+	if !Bot.BotStateManager.Started() {
+		return StrategyState{}, false
+	}
+	s.m.Lock()
+	r, ok := s.runners[key]
+	s.m.Unlock()
+	var st StrategyState
+	if ok {
+		var err error
+		if st, err = r.state(); err != nil {
+			log.Errorf(log.OrderMgr, "Strategy %s unable to save its state: %v\n", r.cfg.Name, err)
+			return StrategyState{}, false
+		}
+	} else if st, ok = Bot.BotStateManager.strategyState(key); !ok {
+		return StrategyState{}, false
+	}
+	return st, strings.EqualFold(st.Strategy, strategy)
+}
+
+// states returns the state of each strategy instance which has run since the
+// strategy manager started, keyed by its lower case name. Instances unable to
+// save their state are left out
+func (s *strategyManager) states() map[string]StrategyState {
+	s.m.Lock()
+	runners := make(map[string]*strategyRunner, len(s.runners))
+	for k, v := range s.runners {
+		runners[k] = v
+	}
+	s.m.Unlock()
+	states := make(map[string]StrategyState, len(runners))
+	for k, r := range runners {
+		st, err := r.state()
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Strategy %s unable to save its state: %v\n", r.cfg.Name, err)
+			continue
+		}
+		states[k] = st
+	}
+	return states
+}
+
+// GetStatus returns the status of each configured strategy instance
+func (s *strategyManager) GetStatus() []StrategyStatus {
+	s.m.Lock()
+	defer s.m.Unlock()
+	resp := make([]StrategyStatus, 0, len(Bot.Config.Strategies.Strategies))
+	for x := range Bot.Config.Strategies.Strategies {
+		cfg := &Bot.Config.Strategies.Strategies[x]
+		if r, ok := s.runners[strings.ToLower(cfg.Name)]; ok {
+			resp = append(resp, r.getStatus())
+			continue
+		}
+		resp = append(resp, newStrategyStatus(cfg))
+	}
+	return resp
+}
+
+// DCAReport returns the amount acquired and its average cost by a running or
+// stopped dollar-cost averaging strategy instance
+func (s *strategyManager) DCAReport(name string) (dca.Report, error) {
+	if !s.Started() {
+		return dca.Report{}, errStrategyManagerNotStarted
+	}
+	s.m.Lock()
+	r, ok := s.runners[strings.ToLower(name)]
+	s.m.Unlock()
+	if !ok {
+		return dca.Report{}, fmt.Errorf("%s %v", name, errStrategyNotRunning)
+	}
+	d, ok := r.strategy.(*dca.Strategy)
+	if !ok {
+		return dca.Report{}, fmt.Errorf("%s %v", name, errStrategyNotDCA)
+	}
+	r.hooks.Lock()
+	defer r.hooks.Unlock()
+	return d.Report(), nil
+}
+
+// orderUpdated passes a copy of a placed or updated order to the running
+// strategies on its market
+func (s *strategyManager) orderUpdated(d *order.Detail) {
+	if d == nil || !s.Started() {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, r := range s.runners {
+		if !r.running() ||
+			!strings.EqualFold(r.cfg.Exchange, d.Exchange) ||
+			!r.cfg.Pair.Equal(d.Pair) ||
+			r.cfg.AssetType != d.AssetType {
+			continue
+		}
+		cp := *d
+		select {
+		case r.orders <- &cp:
+		default:
+			log.Warnf(log.OrderMgr,
+				"Strategy manager: %s order updates are not being processed, dropping order %s update.\n",
+				r.cfg.Name,
+				d.ID)
+		}
+	}
+}
+
+// strategyConfig returns the config of a strategy instance by its name
+func strategyConfig(name string) (config.StrategyConfig, error) {
+	for x := range Bot.Config.Strategies.Strategies {
+		if strings.EqualFold(Bot.Config.Strategies.Strategies[x].Name, name) {
+			return Bot.Config.Strategies.Strategies[x], nil
+		}
+	}
+	return config.StrategyConfig{}, fmt.Errorf("%s %v", name, errStrategyNotConfigured)
+}
+
+func newStrategyStatus(cfg *config.StrategyConfig) StrategyStatus {
+	return StrategyStatus{
+		Name:      cfg.Name,
+		Strategy:  cfg.Strategy,
+		Exchange:  cfg.Exchange,
+		Pair:      cfg.Pair,
+		AssetType: cfg.AssetType,
+		Interval:  cfg.Interval,
+	}
+}
+
+func newStrategyRunner(cfg config.StrategyConfig, strategy strategies.Strategy) *strategyRunner {
+	r := &strategyRunner{
+		cfg:      cfg,
+		strategy: strategy,
+		shutdown: make(chan struct{}),
+		done:     make(chan struct{}),
+		orders:   make(chan *order.Detail, strategyOrderBuffer),
+		status:   newStrategyStatus(&cfg),
+	}
+	r.status.Running = true
+	r.status.Started = clock.Now()
+	return r
+}
+
+func (r *strategyRunner) running() bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.status.Running
+}
+
+func (r *strategyRunner) getStatus() StrategyStatus {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.status
+}
+
+// stop signals the runner to return and waits until it has, then cancels its
+// open orders when configured to
+func (r *strategyRunner) stop() {
+	r.m.Lock()
+	if !r.status.Running {
+		r.m.Unlock()
+		return
+	}
+	r.status.Running = false
+	r.m.Unlock()
+	close(r.shutdown)
+	<-r.done
+	if r.cfg.CancelOrdersOnStop {
+		if err := Bot.OrderManager.CancelSessionOrders(r.cfg.Name); err != nil {
+			log.Errorf(log.OrderMgr, "Strategy %s: %v\n", r.cfg.Name, err)
+		}
+	}
+}
+
+func (r *strategyRunner) run() {
+	check := time.NewTicker(strategyCheckInterval)
+	var pipe dispatch.Pipe
+	var ticks chan interface{}
+	defer func() {
+		check.Stop()
+		if ticks != nil {
+			if err := pipe.Release(); err != nil {
+				log.Errorf(log.OrderMgr, "Strategy %s: %v\n", r.cfg.Name, err)
+			}
+		}
+		close(r.done)
+	}()
+
+	// the ticker is subscribed to once the market's first ticker has been
+	// processed
+	subscribe := func() {
+		p, err := ticker.SubscribeTicker(r.cfg.Exchange, r.cfg.Pair, r.cfg.AssetType)
+		if err == nil {
+			pipe, ticks = p, p.C
+		}
+	}
+	subscribe()
+	for {
+		select {
+		case <-r.shutdown:
+			return
+		case data, ok := <-ticks:
+			if !ok {
+				ticks = nil
+				continue
+			}
+			if d, ok := data.(*interface{}); ok {
+				if p, ok := (*d).(ticker.Price); ok {
+					r.tick(&p)
+				}
+			}
+		case d := <-r.orders:
+			r.hook("order update", func() error {
+				return r.strategy.OnOrderUpdate(d)
+			})
+		case <-check.C:
+			if ticks == nil {
+				subscribe()
+			}
+			r.checkCandles()
+		}
+	}
+}
+
+func (r *strategyRunner) tick(p *ticker.Price) {
+	r.hook("tick", func() error {
+		return r.strategy.OnTick(p)
+	})
+}
+
+// checkCandles passes each candle of the strategy's interval which has closed
+// since the last candle passed, or since the strategy started when it has not
+// resumed from a last candle
+func (r *strategyRunner) checkCandles() {
+	k, err := trade.GetCandles(r.cfg.Exchange, r.cfg.Pair, r.cfg.AssetType, r.cfg.Interval)
+	if err != nil {
+		return
+	}
+	r.m.Lock()
+	started, last := r.status.Started, r.status.LastCandle
+	r.m.Unlock()
+
+	now := clock.Now()
+	for x := range k.Candles {
+		c := &k.Candles[x]
+		closed := c.Time.Add(r.cfg.Interval)
+		// the most recent candle closes when its interval has passed
+		if x == len(k.Candles)-1 && now.Before(closed) {
+			break
+		}
+		if !c.Time.After(last) || (last.IsZero() && !closed.After(started)) {
+			continue
+		}
+		r.hook("candle", func() error {
+			return r.strategy.OnCandle(c)
+		})
+		last = c.Time
+		r.m.Lock()
+		r.status.LastCandle = last
+		r.m.Unlock()
+	}
+}
+
+// hook calls a strategy hook, logging and recording the error it returns
+func (r *strategyRunner) hook(name string, f func() error) {
+	r.hooks.Lock()
+	err := f()
+	r.hooks.Unlock()
+	if err == nil {
+		return
+	}
+	log.Errorf(log.OrderMgr, "Strategy %s %s: %v\n", r.cfg.Name, name, err)
+	r.m.Lock()
+	r.status.LastError = err.Error()
+	r.m.Unlock()
+}
+
+// state returns the strategy instance's state to persist, the strategy's own
+// state is saved between hooks
+func (r *strategyRunner) state() (StrategyState, error) {
+	st := StrategyState{
+		Strategy:   r.cfg.Strategy,
+		LastCandle: r.getStatus().LastCandle,
+	}
+	s, ok := r.strategy.(strategies.Stateful)
+	if !ok {
+		return st, nil
+	}
+	r.hooks.Lock()
+	defer r.hooks.Unlock()
+	var err error
+	st.State, err = s.SaveState()
+	return st, err
+}
+
+// SubmitOrder places a strategy's order through the order manager
+func (t strategyTrader) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
+	s.Session = t.session
+	resp, err := Bot.OrderManager.Submit(s)
+	if err != nil {
+		return order.SubmitResponse{}, err
+	}
+	resp.IsOrderPlaced = true
+	return resp.SubmitResponse, nil
+}
+
+// CancelOrder cancels a strategy's order through the order manager
+func (strategyTrader) CancelOrder(c *order.Cancel) error {
+	return Bot.OrderManager.Cancel(c)
+}
+
+// OrderLimits returns the trading rules the order manager conforms orders on
+// the market to
+func (strategyTrader) OrderLimits(exchName string, p currency.Pair, a asset.Item) (order.Limits, bool) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return order.Limits{}, false
+	}
+	return Bot.OrderManager.limits.get(exch, p, a)
+}
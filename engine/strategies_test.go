@@ -0,0 +1,274 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+	"github.com/thrasher-corp/gocryptotrader/strategies/dca"
+)
+
+var errTestStrategy = errors.New("test strategy error")
+
+type testStrategy struct {
+	candles []kline.Candle
+	ticks   int
+}
+
+func (s *testStrategy) Init(*strategies.Context) error { return nil }
+
+func (s *testStrategy) OnTick(*ticker.Price) error {
+	s.ticks++
+	return errTestStrategy
+}
+
+func (s *testStrategy) OnCandle(c *kline.Candle) error {
+	s.candles = append(s.candles, *c)
+	return nil
+}
+
+func (s *testStrategy) OnOrderUpdate(*order.Detail) error { return nil }
+
+func TestStrategyRunnerCheckCandles(t *testing.T) {
+	trade.SetCandleIntervals([]time.Duration{kline.OneMin}, trade.DefaultCandleLength)
+	defer trade.SetCandleIntervals(nil, trade.DefaultCandleLength)
+
+	start := time.Date(2020, 3, 1, 12, 0, 30, 0, time.UTC)
+	c := clock.NewManual(start)
+	clock.Set(c)
+	defer clock.Reset()
+
+	p := currency.NewPair(currency.BTC, currency.USD)
+	s := new(testStrategy)
+	r := newStrategyRunner(config.StrategyConfig{
+		Name:      "test",
+		Exchange:  "TestStrategyRunnerCheckCandles",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Interval:  kline.OneMin,
+	}, s)
+
+	// the candle open when the strategy started is its first candle, earlier
+	// candles are not replayed
+	err := trade.ProcessTrades("TestStrategyRunnerCheckCandles", []trade.Data{
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 1, Amount: 1, Timestamp: start.Add(-time.Minute)},
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 2, Amount: 1, Timestamp: start},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.checkCandles()
+	if len(s.candles) != 0 {
+		t.Fatalf("expected no candles before the open candle closes, got %+v", s.candles)
+	}
+
+	c.Advance(time.Minute)
+	err = trade.ProcessTrades("TestStrategyRunnerCheckCandles", []trade.Data{
+		{TID: "3", Pair: p, AssetType: asset.Spot, Price: 3, Amount: 1, Timestamp: c.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.checkCandles()
+	r.checkCandles()
+	if len(s.candles) != 1 || s.candles[0].Close != 2 {
+		t.Fatalf("expected the closed candle once, got %+v", s.candles)
+	}
+	if st := r.getStatus(); !st.LastCandle.Equal(s.candles[0].Time) {
+		t.Errorf("expected the last candle to be recorded, got %v", st.LastCandle)
+	}
+
+	r.tick(&ticker.Price{})
+	if st := r.getStatus(); s.ticks != 1 || st.LastError != errTestStrategy.Error() {
+		t.Errorf("expected the hook error to be recorded, got %+v", st)
+	}
+}
+
+func TestStrategyRunnerResumeCandles(t *testing.T) {
+	trade.SetCandleIntervals([]time.Duration{kline.OneMin}, trade.DefaultCandleLength)
+	defer trade.SetCandleIntervals(nil, trade.DefaultCandleLength)
+
+	start := time.Date(2020, 3, 1, 12, 0, 30, 0, time.UTC)
+	clock.Set(clock.NewManual(start))
+	defer clock.Reset()
+
+	p := currency.NewPair(currency.BTC, currency.USD)
+	s := new(testStrategy)
+	r := newStrategyRunner(config.StrategyConfig{
+		Name:      "test",
+		Exchange:  "TestStrategyRunnerResumeCandles",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Interval:  kline.OneMin,
+	}, s)
+	r.status.LastCandle = start.Add(-time.Minute * 2).Truncate(time.Minute)
+
+	// candles which closed after the last candle passed before the restart
+	// are passed on resume
+	err := trade.ProcessTrades("TestStrategyRunnerResumeCandles", []trade.Data{
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 1, Amount: 1, Timestamp: start.Add(-time.Minute * 2)},
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 2, Amount: 1, Timestamp: start.Add(-time.Minute)},
+		{TID: "3", Pair: p, AssetType: asset.Spot, Price: 3, Amount: 1, Timestamp: start},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.checkCandles()
+	if len(s.candles) != 1 || s.candles[0].Close != 2 {
+		t.Errorf("expected the candle closed while stopped to be passed, got %+v", s.candles)
+	}
+}
+
+func TestStrategyManagerOrderUpdated(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	r := newStrategyRunner(config.StrategyConfig{
+		Name:      "test",
+		Exchange:  "Bitstamp",
+		Pair:      p,
+		AssetType: asset.Spot,
+	}, new(testStrategy))
+	s := strategyManager{
+		started: 1,
+		runners: map[string]*strategyRunner{"test": r},
+	}
+
+	s.orderUpdated(&order.Detail{ID: "1", Exchange: "bitstamp", Pair: p, AssetType: asset.Spot})
+	s.orderUpdated(&order.Detail{ID: "2", Exchange: "bitstamp", Pair: p, AssetType: asset.Futures})
+	s.orderUpdated(&order.Detail{ID: "3", Exchange: "Bitfinex", Pair: p, AssetType: asset.Spot})
+	if len(r.orders) != 1 {
+		t.Fatalf("expected 1 order update, got %d", len(r.orders))
+	}
+	if d := <-r.orders; d.ID != "1" {
+		t.Errorf("unexpected order update %+v", d)
+	}
+}
+
+func TestStrategyManagerNotStarted(t *testing.T) {
+	var s strategyManager
+	if err := s.StartStrategy("test"); err != errStrategyManagerNotStarted {
+		t.Errorf("expected %v, got %v", errStrategyManagerNotStarted, err)
+	}
+	if err := s.StopStrategy("test"); err != errStrategyManagerNotStarted {
+		t.Errorf("expected %v, got %v", errStrategyManagerNotStarted, err)
+	}
+	if _, err := s.DCAReport("test"); err != errStrategyManagerNotStarted {
+		t.Errorf("expected %v, got %v", errStrategyManagerNotStarted, err)
+	}
+}
+
+func TestStrategyManagerDCAReport(t *testing.T) {
+	d := dca.New()
+	err := d.Init(&strategies.Context{
+		Name:       "dca",
+		Parameters: map[string]string{"amount": "100"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := strategyManager{
+		started: 1,
+		runners: map[string]*strategyRunner{
+			"dca":   newStrategyRunner(config.StrategyConfig{Name: "DCA"}, d),
+			"other": newStrategyRunner(config.StrategyConfig{Name: "other"}, new(testStrategy)),
+		},
+	}
+	if _, err = s.DCAReport("DCA"); err != nil {
+		t.Error(err)
+	}
+	if _, err = s.DCAReport("other"); err == nil {
+		t.Error("expected an error reporting a strategy which is not dca")
+	}
+	if _, err = s.DCAReport("missing"); err == nil {
+		t.Error("expected an error reporting a strategy which has not run")
+	}
+}
+
+func TestStrategyDCAOrderFills(t *testing.T) {
+	OrdersSetup(t)
+	p := currency.NewPairFromString("BTCUSD")
+	runDCA := func(exchName string) (*strategyRunner, *dca.Strategy) {
+		d := dca.New().(*dca.Strategy)
+		err := d.Init(&strategies.Context{
+			Name:       "TestStrategyDCAOrderFills",
+			Exchange:   exchName,
+			Pair:       p,
+			AssetType:  asset.Spot,
+			Parameters: map[string]string{"amount": "100"},
+			Trader:     strategyTrader{session: "TestStrategyDCAOrderFills"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := newStrategyRunner(config.StrategyConfig{
+			Name:      "TestStrategyDCAOrderFills",
+			Exchange:  exchName,
+			Pair:      p,
+			AssetType: asset.Spot,
+		}, d)
+		Bot.StrategyManager.runners = map[string]*strategyRunner{"dca": r}
+		r.tick(&ticker.Price{Ask: 100, LastUpdated: time.Now()})
+		return r, d
+	}
+	// passes the next order update to the strategy as its runner would
+	update := func(r *strategyRunner) *order.Detail {
+		select {
+		case u := <-r.orders:
+			r.hook("order update", func() error {
+				return r.strategy.OnOrderUpdate(u)
+			})
+			return u
+		default:
+			t.Fatal("expected an order update")
+		}
+		return nil
+	}
+	// the fake exchange places every order with the same ID and open orders
+	// would prevent the purchases trading against them
+	delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+	delete(Bot.OrderManager.orderStore.Orders, testExchange)
+	Bot.StrategyManager.started = 1
+	defer func() {
+		Bot.Settings.EnableDryRun = false
+		Bot.StrategyManager.started = 0
+		Bot.StrategyManager.runners = nil
+		delete(Bot.OrderManager.orderStore.Orders, testExchange)
+		delete(Bot.OrderManager.orderStore.Orders, fakePassExchange)
+	}()
+
+	// an order which is matched in full when submitted is reported filled
+	r, d := runDCA(fakePassExchange)
+	update(r)
+	if rep := d.Report(); rep.Amount != 1 || rep.AverageCost != 100 {
+		t.Errorf("expected 1 bought at 100, got %+v", rep)
+	}
+
+	// a dry run order is filled by a later update
+	Bot.Settings.EnableDryRun = true
+	r, d = runDCA(testExchange)
+	placed := update(r)
+	if rep := d.Report(); rep.Amount != 0 {
+		t.Errorf("expected nothing bought before the order fills, got %+v", rep)
+	}
+	err := Bot.OrderManager.orderStore.Update(&order.Detail{
+		Exchange:       placed.Exchange,
+		ID:             placed.ID,
+		ExecutedAmount: 1,
+		Status:         order.Filled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	update(r)
+	if rep := d.Report(); rep.Amount != 1 || rep.AverageCost != 100 || len(rep.Purchases) != 1 {
+		t.Errorf("expected 1 bought at 100, got %+v", rep)
+	}
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+const (
+	// strategyCheckInterval is how often a strategy's ticker subscription is
+	// retried and its candles checked for a close
+	strategyCheckInterval = time.Second
+	// strategyOrderBuffer is the amount of order updates queued for a
+	// strategy before updates are dropped
+	strategyOrderBuffer = 100
+)
+
+// StrategyStatus is the state of a configured strategy instance
+type StrategyStatus struct {
+	Name       string
+	Strategy   string
+	Exchange   string
+	Pair       currency.Pair
+	AssetType  asset.Item
+	Interval   time.Duration
+	Running    bool
+	Started    time.Time
+	LastCandle time.Time
+	LastError  string
+}
+
+// strategyRunner calls the hooks of a strategy instance from a single
+// goroutine
+type strategyRunner struct {
+	cfg      config.StrategyConfig
+	strategy strategies.Strategy
+	shutdown chan struct{}
+	done     chan struct{}
+	orders   chan *order.Detail
+	// hooks is held while a hook of the strategy is called so that its state
+	// is only saved between hooks
+	hooks sync.Mutex
+
+	m      sync.Mutex
+	status StrategyStatus
+}
+
+// strategyTrader places strategy orders through the order manager, tagging
+// them with the strategy instance's name as their session
+type strategyTrader struct {
+	session string
+}
+
+type strategyManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m       sync.Mutex
+	runners map[string]*strategyRunner
+}
@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// MaxSweepHistory is the amount of sweep audit records retained in memory
+var MaxSweepHistory = 100
+
+var errSweepAddressNotColdStorage = errors.New("address is not a cold storage address")
+
+func (s *sweepManager) Started() bool {
+	return atomic.LoadInt32(&s.started) == 1
+}
+
+func (s *sweepManager) Start() error {
+	if atomic.AddInt32(&s.started, 1) != 1 {
+		return errors.New("cold storage sweep manager already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Cold storage sweep manager starting...")
+	s.shutdown = make(chan struct{})
+	go s.run()
+	return nil
+}
+
+func (s *sweepManager) Stop() error {
+	if atomic.AddInt32(&s.stopped, 1) != 1 {
+		return errors.New("cold storage sweep manager is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Cold storage sweep manager shutting down...")
+	close(s.shutdown)
+	return nil
+}
+
+func (s *sweepManager) run() {
+	log.Debugln(log.PortfolioMgr, "Cold storage sweep manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.ColdStorageSweep.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&s.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&s.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Cold storage sweep manager shutdown.")
+	}()
+
+	for {
+		select {
+		case <-s.shutdown:
+			return
+		case <-tick.C:
+			rules := Bot.Config.ColdStorageSweep.Rules
+			for i := range rules {
+				s.sweep(&rules[i])
+			}
+		}
+	}
+}
+
+// sweep checks a single rule against the exchange's current holdings and
+// withdraws any excess balance to the rule's cold storage address
+func (s *sweepManager) sweep(rule *config.SweepRule) {
+	exch := GetExchangeByName(rule.Exchange)
+	if exch == nil {
+		log.Errorf(log.PortfolioMgr,
+			"Cold storage sweep: exchange %s not loaded\n",
+			rule.Exchange)
+		return
+	}
+
+	holdings, err := exch.UpdateAccountInfo()
+	if err != nil {
+		log.Errorf(log.PortfolioMgr,
+			"Cold storage sweep: unable to update %s account info: %s\n",
+			rule.Exchange,
+			err)
+		return
+	}
+
+	balance, amount := sweepAmount(rule, &holdings)
+	if amount <= 0 {
+		return
+	}
+
+	record := SweepRecord{
+		Time:     clock.Now(),
+		Exchange: exch.GetName(),
+		Currency: rule.Currency,
+		Balance:  balance,
+		Amount:   amount,
+		Address:  rule.Address,
+	}
+
+	if !portfolio.IsColdStorage(rule.Address) {
+		record.Status = StatusError
+		record.Error = errSweepAddressNotColdStorage.Error()
+		s.audit(&record)
+		return
+	}
+
+	resp, err := SubmitWithdrawal(exch.GetName(), &withdraw.Request{
+		Exchange:    exch.GetName(),
+		Currency:    rule.Currency,
+		Description: "cold storage sweep",
+		Amount:      amount,
+		Type:        withdraw.Crypto,
+		Crypto: &withdraw.CryptoRequest{
+			Address:    rule.Address,
+			AddressTag: rule.AddressTag,
+		},
+	})
+	switch {
+	case err != nil:
+		record.Status = StatusError
+		record.Error = err.Error()
+	case resp.Exchange.ID == StatusError:
+		record.Status = StatusError
+		record.Error = resp.Exchange.Status
+	default:
+		record.WithdrawalID = resp.ID.String()
+		record.Status = resp.Exchange.Status
+	}
+	s.audit(&record)
+}
+
+// audit stores, logs and notifies on a sweep attempt
+func (s *sweepManager) audit(r *SweepRecord) {
+	s.m.Lock()
+	s.history = append(s.history, *r)
+	if len(s.history) > MaxSweepHistory {
+		s.history = s.history[len(s.history)-MaxSweepHistory:]
+	}
+	s.m.Unlock()
+
+	msg := fmt.Sprintf("Cold storage sweep: %s %v %s (balance %v) to %s",
+		r.Exchange,
+		r.Amount,
+		r.Currency,
+		r.Balance,
+		r.Address)
+	if r.Error != "" {
+		msg += " failed: " + r.Error
+		log.Errorln(log.PortfolioMgr, msg)
+	} else {
+		msg += " submitted. Withdrawal ID: " + r.WithdrawalID
+		log.Infoln(log.PortfolioMgr, msg)
+	}
+	Bot.CommsManager.PushEvent(base.Event{
+		Type:    "sweep",
+		Message: msg,
+	})
+}
+
+// GetHistory returns the audit records of previous sweep attempts
+func (s *sweepManager) GetHistory() []SweepRecord {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]SweepRecord(nil), s.history...)
+}
+
+// sweepAmount returns the available balance of the rule's currency and the
+// amount to sweep, which is zero unless the balance exceeds the threshold
+func sweepAmount(rule *config.SweepRule, holdings *account.Holdings) (balance, amount float64) {
+	for x := range holdings.Accounts {
+		for y := range holdings.Accounts[x].Currencies {
+			c := &holdings.Accounts[x].Currencies[y]
+			if c.CurrencyName.Match(rule.Currency) {
+				balance += c.Available
+			}
+		}
+	}
+	if balance <= rule.Threshold {
+		return balance, 0
+	}
+	return balance, balance - rule.Retain
+}
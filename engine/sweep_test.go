@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+)
+
+func TestSweepAmount(t *testing.T) {
+	rule := config.SweepRule{
+		Currency:  currency.BTC,
+		Threshold: 10,
+		Retain:    2,
+	}
+	holdings := account.Holdings{
+		Accounts: []account.SubAccount{
+			{Currencies: []account.Balance{
+				{CurrencyName: currency.BTC, TotalValue: 8, Hold: 1, Available: 7},
+				{CurrencyName: currency.LTC, TotalValue: 100},
+			}},
+			{Currencies: []account.Balance{
+				{CurrencyName: currency.BTC, TotalValue: 3, Available: 3},
+			}},
+		},
+	}
+
+	balance, amount := sweepAmount(&rule, &holdings)
+	if balance != 10 || amount != 0 {
+		t.Errorf("expected no sweep at threshold, got balance %v amount %v", balance, amount)
+	}
+
+	holdings.Accounts[1].Currencies[0].Available = 5
+	balance, amount = sweepAmount(&rule, &holdings)
+	if balance != 12 || amount != 10 {
+		t.Errorf("expected sweep of 10, got balance %v amount %v", balance, amount)
+	}
+}
+
+func TestSweepNotColdStorage(t *testing.T) {
+	SetupTestHelpers(t)
+	var s sweepManager
+	s.sweep(&config.SweepRule{Exchange: "unloadedExchange"})
+	if len(s.GetHistory()) != 0 {
+		t.Error("expected no sweep attempt for an unloaded exchange")
+	}
+
+	// the fake exchange holds no balance so force a sweep with negative
+	// amounts which are otherwise rejected by the config check
+	s.sweep(&config.SweepRule{
+		Exchange:  fakePassExchange,
+		Currency:  currency.BTC,
+		Threshold: -1,
+		Retain:    -1,
+		Address:   "notcoldstorage",
+	})
+	h := s.GetHistory()
+	if len(h) != 1 {
+		t.Fatal("expected one sweep attempt")
+	}
+	if h[0].Amount != 1 || h[0].Error != errSweepAddressNotColdStorage.Error() {
+		t.Errorf("expected %v, got %+v", errSweepAddressNotColdStorage, h[0])
+	}
+}
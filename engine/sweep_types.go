@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// SweepRecord is an audit entry for a single cold storage sweep attempt
+type SweepRecord struct {
+	Time         time.Time
+	Exchange     string
+	Currency     currency.Code
+	Balance      float64
+	Amount       float64
+	Address      string
+	WithdrawalID string
+	Status       string
+	Error        string
+}
+
+type sweepManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m       sync.Mutex
+	history []SweepRecord
+}
@@ -6,6 +6,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -53,6 +55,8 @@ func NewCurrencyPairSyncer(c CurrencyPairSyncerConfig) (*ExchangeCurrencyPairSyn
 	}
 
 	s.tickerBatchLastRequested = make(map[string]time.Time)
+	s.tickerJobs = make(chan tickerJob, s.Cfg.NumWorkers)
+	s.shutdownC = make(chan struct{})
 
 	log.Debugf(log.SyncMgr,
 		"Exchange currency pair syncer config: continuous: %v ticker: %v"+
@@ -134,7 +138,7 @@ func (e *ExchangeCurrencyPairSyncer) add(c *CurrencyPairSyncAgent) {
 		}
 	}
 
-	c.Created = time.Now()
+	c.Created = clock.Now()
 	e.CurrencyPairs = append(e.CurrencyPairs, *c)
 }
 
@@ -194,6 +198,38 @@ func (e *ExchangeCurrencyPairSyncer) setProcessing(exchangeName string, p curren
 	}
 }
 
+// tryProcessing marks the sync item as processing, returning false if it is
+// already being processed so that requests for the same item are not
+// duplicated
+func (e *ExchangeCurrencyPairSyncer) tryProcessing(exchangeName string, p currency.Pair, a asset.Item, syncType int) bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	for x := range e.CurrencyPairs {
+		if e.CurrencyPairs[x].Exchange == exchangeName &&
+			e.CurrencyPairs[x].Pair.Equal(p) &&
+			e.CurrencyPairs[x].AssetType == a {
+			var s *SyncBase
+			switch syncType {
+			case SyncItemTicker:
+				s = &e.CurrencyPairs[x].Ticker
+			case SyncItemOrderbook:
+				s = &e.CurrencyPairs[x].Orderbook
+			case SyncItemTrade:
+				s = &e.CurrencyPairs[x].Trade
+			default:
+				return false
+			}
+			if s.IsProcessing {
+				return false
+			}
+			s.IsProcessing = true
+			return true
+		}
+	}
+	return false
+}
+
 func (e *ExchangeCurrencyPairSyncer) update(exchangeName string, p currency.Pair, a asset.Item, syncType int, err error) {
 	if atomic.LoadInt32(&e.initSyncStarted) != 1 {
 		return
@@ -227,7 +263,7 @@ func (e *ExchangeCurrencyPairSyncer) update(exchangeName string, p currency.Pair
 			switch syncType {
 			case SyncItemTicker:
 				origHadData := e.CurrencyPairs[x].Ticker.HaveData
-				e.CurrencyPairs[x].Ticker.LastUpdated = time.Now()
+				e.CurrencyPairs[x].Ticker.LastUpdated = clock.Now()
 				if err != nil {
 					e.CurrencyPairs[x].Ticker.NumErrors++
 				}
@@ -242,7 +278,7 @@ func (e *ExchangeCurrencyPairSyncer) update(exchangeName string, p currency.Pair
 
 			case SyncItemOrderbook:
 				origHadData := e.CurrencyPairs[x].Orderbook.HaveData
-				e.CurrencyPairs[x].Orderbook.LastUpdated = time.Now()
+				e.CurrencyPairs[x].Orderbook.LastUpdated = clock.Now()
 				if err != nil {
 					e.CurrencyPairs[x].Orderbook.NumErrors++
 				}
@@ -257,7 +293,7 @@ func (e *ExchangeCurrencyPairSyncer) update(exchangeName string, p currency.Pair
 
 			case SyncItemTrade:
 				origHadData := e.CurrencyPairs[x].Trade.HaveData
-				e.CurrencyPairs[x].Trade.LastUpdated = time.Now()
+				e.CurrencyPairs[x].Trade.LastUpdated = clock.Now()
 				if err != nil {
 					e.CurrencyPairs[x].Trade.NumErrors++
 				}
@@ -320,6 +356,7 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 							Exchange:  exchangeName,
 							Pair:      enabledPairs[i],
 						}
+						c.Priority, c.PollInterval = pairPollingInterval(exchangeName, c.Pair, c.AssetType)
 
 						if e.Cfg.SyncTicker {
 							c.Ticker = SyncBase{
@@ -358,9 +395,9 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 					}
 					if e.Cfg.SyncTicker {
 						if !e.isProcessing(exchangeName, c.Pair, c.AssetType, SyncItemTicker) {
-							if c.Ticker.LastUpdated.IsZero() || time.Since(c.Ticker.LastUpdated) > e.Cfg.SyncTimeout {
+							if c.Ticker.LastUpdated.IsZero() || clock.Since(c.Ticker.LastUpdated) > e.pollInterval(c) {
 								if c.Ticker.IsUsingWebsocket {
-									if time.Since(c.Created) < e.Cfg.SyncTimeout {
+									if clock.Since(c.Created) < e.Cfg.SyncTimeout {
 										continue
 									}
 
@@ -381,42 +418,13 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 								}
 
 								if c.Ticker.IsUsingREST {
-									e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemTicker, true)
-									var result *ticker.Price
-									var err error
-
-									if supportsRESTTickerBatching {
-										e.mux.Lock()
-										batchLastDone, ok := e.tickerBatchLastRequested[exchangeName]
-										if !ok {
-											e.tickerBatchLastRequested[exchangeName] = time.Time{}
-										}
-										e.mux.Unlock()
-
-										if batchLastDone.IsZero() || time.Since(batchLastDone) > e.Cfg.SyncTimeout {
-											e.mux.Lock()
-											if e.Cfg.Verbose {
-												log.Debugf(log.SyncMgr, "%s Init'ing REST ticker batching\n", exchangeName)
-											}
-											result, err = exchanges[x].UpdateTicker(c.Pair, c.AssetType)
-											e.tickerBatchLastRequested[exchangeName] = time.Now()
-											e.mux.Unlock()
-										} else {
-											if e.Cfg.Verbose {
-												log.Debugf(log.SyncMgr, "%s Using recent batching cache\n", exchangeName)
-											}
-											result, err = exchanges[x].FetchTicker(c.Pair, c.AssetType)
-										}
-									} else {
-										result, err = exchanges[x].UpdateTicker(c.Pair, c.AssetType)
-									}
-									printTickerSummary(result, c.Pair, c.AssetType, exchangeName, "REST", err)
-									if err == nil {
-										if Bot.Config.RemoteControl.WebsocketRPC.Enabled {
-											relayWebsocketEvent(result, "ticker_update", c.AssetType.String(), exchangeName)
-										}
-									}
-									e.update(c.Exchange, c.Pair, c.AssetType, SyncItemTicker, err)
+									e.queueTicker(tickerJob{
+										exch:     exchanges[x],
+										pair:     c.Pair,
+										asset:    c.AssetType,
+										batching: supportsRESTTickerBatching,
+										interval: e.pollInterval(c),
+									})
 								}
 							} else {
 								time.Sleep(time.Millisecond * 50)
@@ -426,9 +434,9 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 
 					if e.Cfg.SyncOrderbook {
 						if !e.isProcessing(exchangeName, c.Pair, c.AssetType, SyncItemOrderbook) {
-							if c.Orderbook.LastUpdated.IsZero() || time.Since(c.Orderbook.LastUpdated) > e.Cfg.SyncTimeout {
+							if c.Orderbook.LastUpdated.IsZero() || clock.Since(c.Orderbook.LastUpdated) > e.pollInterval(c) {
 								if c.Orderbook.IsUsingWebsocket {
-									if time.Since(c.Created) < e.Cfg.SyncTimeout {
+									if clock.Since(c.Created) < e.Cfg.SyncTimeout {
 										continue
 									}
 									if supportsREST {
@@ -448,7 +456,9 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 								}
 
 								e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemOrderbook, true)
+								release := Bot.StartupCoordinator.step(c.Exchange)
 								result, err := exchanges[x].UpdateOrderbook(c.Pair, c.AssetType)
+								release()
 								printOrderbookSummary(result, c.Pair, c.AssetType, exchangeName, "REST", err)
 								if err == nil {
 									if Bot.Config.RemoteControl.WebsocketRPC.Enabled {
@@ -462,9 +472,17 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 						}
 						if e.Cfg.SyncTrades {
 							if !e.isProcessing(exchangeName, c.Pair, c.AssetType, SyncItemTrade) {
-								if c.Trade.LastUpdated.IsZero() || time.Since(c.Trade.LastUpdated) > e.Cfg.SyncTimeout {
+								if c.Trade.LastUpdated.IsZero() || clock.Since(c.Trade.LastUpdated) > e.pollInterval(c) {
 									e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemTrade, true)
-									e.update(c.Exchange, c.Pair, c.AssetType, SyncItemTrade, nil)
+									err := updateTrades(exchanges[x], c.Pair, c.AssetType)
+									if err != nil {
+										log.Errorf(log.SyncMgr, "%s %s %s: Failed to sync trades. Err: %s\n",
+											exchangeName,
+											FormatCurrency(c.Pair).String(),
+											strings.ToUpper(c.AssetType.String()),
+											err)
+									}
+									e.update(c.Exchange, c.Pair, c.AssetType, SyncItemTrade, err)
 								}
 							}
 						}
@@ -475,6 +493,77 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 	}
 }
 
+// queueTicker hands a REST ticker fetch to the ticker worker pool. Fetches
+// already in flight for the same pair are skipped, as are fetches when every
+// worker is busy and the queue is full, which are retried on the next pass
+func (e *ExchangeCurrencyPairSyncer) queueTicker(j tickerJob) {
+	exchangeName := j.exch.GetName()
+	if !e.tryProcessing(exchangeName, j.pair, j.asset, SyncItemTicker) {
+		return
+	}
+
+	select {
+	case e.tickerJobs <- j:
+	default:
+		e.setProcessing(exchangeName, j.pair, j.asset, SyncItemTicker, false)
+	}
+}
+
+// tickerWorker fetches queued tickers until the syncer is stopped
+func (e *ExchangeCurrencyPairSyncer) tickerWorker() {
+	for {
+		select {
+		case <-e.shutdownC:
+			return
+		case j := <-e.tickerJobs:
+			e.fetchTicker(j)
+		}
+	}
+}
+
+func (e *ExchangeCurrencyPairSyncer) fetchTicker(j tickerJob) {
+	exchangeName := j.exch.GetName()
+	var result *ticker.Price
+	var err error
+	release := Bot.StartupCoordinator.step(exchangeName)
+	defer release()
+
+	if j.batching {
+		e.mux.Lock()
+		batchLastDone, ok := e.tickerBatchLastRequested[exchangeName]
+		if !ok {
+			e.tickerBatchLastRequested[exchangeName] = time.Time{}
+		}
+		e.mux.Unlock()
+
+		if batchLastDone.IsZero() || clock.Since(batchLastDone) > j.interval {
+			e.mux.Lock()
+			if e.Cfg.Verbose {
+				log.Debugf(log.SyncMgr, "%s Init'ing REST ticker batching\n", exchangeName)
+			}
+			result, err = j.exch.UpdateTicker(j.pair, j.asset)
+			e.tickerBatchLastRequested[exchangeName] = clock.Now()
+			e.mux.Unlock()
+		} else {
+			if e.Cfg.Verbose {
+				log.Debugf(log.SyncMgr, "%s Using recent batching cache\n", exchangeName)
+			}
+			result, err = j.exch.FetchTicker(j.pair, j.asset)
+		}
+	} else {
+		result, err = j.exch.UpdateTicker(j.pair, j.asset)
+	}
+	printTickerSummary(result, j.pair, j.asset, exchangeName, "REST", err)
+	if err == nil {
+		if Bot.Config.RemoteControl.WebsocketRPC.Enabled {
+			relayWebsocketEvent(result, "ticker_update", j.asset.String(), exchangeName)
+		}
+	}
+	e.update(exchangeName, j.pair, j.asset, SyncItemTicker, err)
+	// update is a no-op until the initial sync has started
+	e.setProcessing(exchangeName, j.pair, j.asset, SyncItemTicker, false)
+}
+
 // Start starts an exchange currency pair syncer
 func (e *ExchangeCurrencyPairSyncer) Start() {
 	log.Debugln(log.SyncMgr, "Exchange CurrencyPairSyncer started.")
@@ -505,7 +594,9 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 			if !ws.IsConnected() && !ws.IsConnecting() {
 				go WebsocketDataReceiver(ws)
 
+				release := Bot.StartupCoordinator.step(exchangeName)
 				err = ws.Connect()
+				release()
 				if err != nil {
 					log.Errorf(log.SyncMgr, "%s websocket failed to connect. Err: %s\n",
 						exchangeName, err)
@@ -563,14 +654,14 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 		log.Debugf(log.SyncMgr,
 			"Exchange CurrencyPairSyncer initial sync started. %d items to process.\n",
 			createdCounter)
-		e.initSyncStartTime = time.Now()
+		e.initSyncStartTime = clock.Now()
 	}
 
 	go func() {
 		e.initSyncWG.Wait()
 		if atomic.CompareAndSwapInt32(&e.initSyncCompleted, 0, 1) {
 			log.Debugf(log.SyncMgr, "Exchange CurrencyPairSyncer initial sync is complete.\n")
-			completedTime := time.Now()
+			completedTime := clock.Now()
 			log.Debugf(log.SyncMgr, "Exchange CurrencyPairSyncer initial sync took %v [%v sync items].\n",
 				completedTime.Sub(e.initSyncStartTime), createdCounter)
 
@@ -589,12 +680,39 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 	for i := 0; i < e.Cfg.NumWorkers; i++ {
 		go e.worker()
 	}
+
+	if e.Cfg.SyncTicker {
+		for i := 0; i < e.Cfg.NumWorkers; i++ {
+			go e.tickerWorker()
+		}
+	}
 }
 
 // Stop shuts down the exchange currency pair syncer
 func (e *ExchangeCurrencyPairSyncer) Stop() {
 	stopped := atomic.CompareAndSwapInt32(&e.shutdown, 0, 1)
 	if stopped {
+		close(e.shutdownC)
 		log.Debugln(log.SyncMgr, "Exchange CurrencyPairSyncer stopped.")
 	}
 }
+
+// pollInterval returns how often the REST data of a pair is fetched, widened
+// while the resource monitor has degraded polling intervals
+func (e *ExchangeCurrencyPairSyncer) pollInterval(c *CurrencyPairSyncAgent) time.Duration {
+	interval := e.Cfg.SyncTimeout
+	if c.PollInterval > 0 {
+		interval = c.PollInterval
+	}
+	return Bot.ResourceMonitor.PollingInterval(interval)
+}
+
+// pairPollingInterval returns the polling priority tier and interval of an
+// exchange's pair set in its config, zero polls at the syncer's timeout
+func pairPollingInterval(exchangeName string, p currency.Pair, a asset.Item) (string, time.Duration) {
+	exchCfg, err := Bot.Config.GetExchangeConfig(exchangeName)
+	if err != nil || exchCfg.PairPolling == nil {
+		return config.PollingPriorityNormal, 0
+	}
+	return exchCfg.PairPolling.PollingInterval(p, a)
+}
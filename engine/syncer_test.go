@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
 func TestNewCurrencyPairSyncer(t *testing.T) {
@@ -43,3 +46,116 @@ func TestNewCurrencyPairSyncer(t *testing.T) {
 	time.Sleep(time.Second * 15)
 	Bot.ExchangeCurrencyPairManager.Stop()
 }
+
+func TestSyncerTryProcessing(t *testing.T) {
+	s, err := NewCurrencyPairSyncer(CurrencyPairSyncerConfig{SyncTicker: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := currency.NewPairFromString("BTCUSD")
+	if s.tryProcessing(fakePassExchange, p, asset.Spot, SyncItemTicker) {
+		t.Error("expected unknown sync item not to be processed")
+	}
+
+	s.CurrencyPairs = append(s.CurrencyPairs, CurrencyPairSyncAgent{
+		Exchange:  fakePassExchange,
+		Pair:      p,
+		AssetType: asset.Spot,
+	})
+	if !s.tryProcessing(fakePassExchange, p, asset.Spot, SyncItemTicker) {
+		t.Error("expected idle sync item to be processed")
+	}
+	if s.tryProcessing(fakePassExchange, p, asset.Spot, SyncItemTicker) {
+		t.Error("expected in flight sync item not to be processed again")
+	}
+	if !s.tryProcessing(fakePassExchange, p, asset.Spot, SyncItemOrderbook) {
+		t.Error("expected sync item types to be processed independently")
+	}
+}
+
+func TestSyncerQueueTicker(t *testing.T) {
+	s, err := NewCurrencyPairSyncer(CurrencyPairSyncerConfig{
+		SyncTicker: true,
+		NumWorkers: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := &FakePassingExchange{Base: exchange.Base{Name: fakePassExchange}}
+	btc := currency.NewPairFromString("BTCUSD")
+	ltc := currency.NewPairFromString("LTCUSD")
+	for _, p := range []currency.Pair{btc, ltc} {
+		s.CurrencyPairs = append(s.CurrencyPairs, CurrencyPairSyncAgent{
+			Exchange:  fakePassExchange,
+			Pair:      p,
+			AssetType: asset.Spot,
+		})
+	}
+
+	s.queueTicker(tickerJob{exch: exch, pair: btc, asset: asset.Spot})
+	s.queueTicker(tickerJob{exch: exch, pair: btc, asset: asset.Spot})
+	if len(s.tickerJobs) != 1 {
+		t.Errorf("expected duplicate fetch to be skipped, got %d queued", len(s.tickerJobs))
+	}
+
+	s.queueTicker(tickerJob{exch: exch, pair: ltc, asset: asset.Spot})
+	if len(s.tickerJobs) != 1 {
+		t.Errorf("expected fetch to be skipped when the queue is full, got %d queued", len(s.tickerJobs))
+	}
+	if s.isProcessing(fakePassExchange, ltc, asset.Spot, SyncItemTicker) {
+		t.Error("expected skipped fetch to be retried on the next pass")
+	}
+}
+
+func TestSyncerPairPolling(t *testing.T) {
+	SetupTestHelpers(t)
+	exchCfg, err := Bot.Config.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	btc := currency.NewPairFromString("BTCUSD")
+	ltc := currency.NewPairFromString("LTCUSD")
+	xrp := currency.NewPairFromString("XRPUSD")
+	exchCfg.PairPolling = &config.PairPollingConfig{
+		Intervals: map[string]time.Duration{
+			config.PollingPriorityHigh: time.Second * 2,
+			config.PollingPriorityLow:  time.Minute,
+		},
+		DefaultPriority: config.PollingPriorityLow,
+		Pairs: []config.PairPriority{
+			{Pair: btc, Asset: asset.Spot, Priority: config.PollingPriorityHigh},
+			{Pair: ltc, Asset: asset.Spot, Priority: config.PollingPriorityNormal},
+			{Pair: xrp, Asset: asset.Spot, Interval: time.Second * 5},
+		},
+	}
+	defer func() { exchCfg.PairPolling = nil }()
+
+	s, err := NewCurrencyPairSyncer(CurrencyPairSyncerConfig{SyncTicker: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		pair     currency.Pair
+		priority string
+		interval time.Duration
+	}{
+		{btc, config.PollingPriorityHigh, time.Second * 2},
+		// normal priority pairs poll at the syncer's timeout
+		{ltc, config.PollingPriorityNormal, s.Cfg.SyncTimeout},
+		{xrp, config.PollingPriorityLow, time.Second * 5},
+		{currency.NewPairFromString("ETHUSD"), config.PollingPriorityLow, time.Minute},
+	} {
+		c := CurrencyPairSyncAgent{Exchange: testExchange, Pair: tc.pair, AssetType: asset.Spot}
+		c.Priority, c.PollInterval = pairPollingInterval(testExchange, tc.pair, asset.Spot)
+		if c.Priority != tc.priority {
+			t.Errorf("%s: expected priority %s, got %s", tc.pair, tc.priority, c.Priority)
+		}
+		if interval := s.pollInterval(&c); interval != tc.interval {
+			t.Errorf("%s: expected polling interval %s, got %s", tc.pair, tc.interval, interval)
+		}
+	}
+
+	if priority, interval := pairPollingInterval(fakePassExchange, btc, asset.Spot); priority != config.PollingPriorityNormal || interval != 0 {
+		t.Errorf("expected exchanges without pair polling to poll at the syncer's timeout, got %s %s", priority, interval)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
@@ -30,6 +31,7 @@ type ExchangeCurrencyPairSyncer struct {
 	Cfg                      CurrencyPairSyncerConfig
 	CurrencyPairs            []CurrencyPairSyncAgent
 	tickerBatchLastRequested map[string]time.Time
+	tickerJobs               chan tickerJob
 	mux                      sync.Mutex
 	initSyncWG               sync.WaitGroup
 
@@ -37,6 +39,18 @@ type ExchangeCurrencyPairSyncer struct {
 	initSyncStarted   int32
 	initSyncStartTime time.Time
 	shutdown          int32
+	shutdownC         chan struct{}
+}
+
+// tickerJob is a REST ticker fetch handled by the ticker worker pool
+type tickerJob struct {
+	exch     exchange.IBotExchange
+	pair     currency.Pair
+	asset    asset.Item
+	batching bool
+	// interval is the pair's polling interval, batched tickers are refreshed
+	// once older than it
+	interval time.Duration
 }
 
 // SyncBase stores information
@@ -55,7 +69,11 @@ type CurrencyPairSyncAgent struct {
 	Exchange  string
 	AssetType asset.Item
 	Pair      currency.Pair
-	Ticker    SyncBase
-	Orderbook SyncBase
-	Trade     SyncBase
+	// Priority is the polling priority tier of the pair, PollInterval
+	// overrides the syncer's timeout when set
+	Priority     string
+	PollInterval time.Duration
+	Ticker       SyncBase
+	Orderbook    SyncBase
+	Trade        SyncBase
 }
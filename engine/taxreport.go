@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/tax"
+)
+
+// TaxReport pulls the trade history of each authenticated exchange from
+// historyStart to end, syncing it first when the exchange can page through its
+// trades, matches the sales against the lots they were acquired
+// in using the method and returns the disposals between start and end. Trades
+// are valued in the reporting currency at the conversion rates currently
+// available
+func TaxReport(m tax.Method, historyStart, start, end time.Time, reporting currency.Code) ([]tax.Disposal, error) {
+	var trades []tax.Trade
+	exchanges := GetAuthAPISupportedExchanges()
+	for x := range exchanges {
+		exch := GetExchangeByName(exchanges[x])
+		if exch == nil {
+			continue
+		}
+		var history []order.Detail
+		var err error
+		if _, ok := exch.(exchange.TradeHistoryPager); ok {
+			history, err = syncedOrderHistory(exch, historyStart, end)
+		} else {
+			history, err = exch.GetOrderHistory(&order.GetOrdersRequest{
+				Type:       order.AnyType,
+				Side:       order.AnySide,
+				StartTicks: historyStart,
+				EndTicks:   end,
+				Pairs:      exch.GetEnabledPairs(asset.Spot),
+			})
+		}
+		switch {
+		case err == common.ErrFunctionNotSupported || err == common.ErrNotYetImplemented:
+			log.Warnf(log.OrderMgr,
+				"%s trade history is unavailable, excluding it from the tax report.\n",
+				exch.GetName())
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%s unable to get trade history: %v", exch.GetName(), err)
+		}
+		for i := range history {
+			if history[i].Exchange == "" {
+				history[i].Exchange = exch.GetName()
+			}
+			trades = append(trades, orderTrades(&history[i])...)
+		}
+	}
+
+	disposals, err := tax.MatchLots(trades, m, reporting, convertValue)
+	if err != nil {
+		return nil, err
+	}
+	filtered := disposals[:0]
+	for x := range disposals {
+		if disposals[x].Disposed.Before(start) || disposals[x].Disposed.After(end) {
+			continue
+		}
+		filtered = append(filtered, disposals[x])
+	}
+	return filtered, nil
+}
+
+// orderTrades converts each trade of an order to a tax trade, falling back to
+// the order's executed amount when the exchange does not return its trades.
+// Fees are assumed to be charged in the quote currency
+func orderTrades(d *order.Detail) []tax.Trade {
+	if len(d.Trades) == 0 {
+		if d.ExecutedAmount <= 0 || d.Price <= 0 {
+			return nil
+		}
+		tm := d.LastUpdated
+		if tm.IsZero() {
+			tm = d.Date
+		}
+		return []tax.Trade{{
+			Exchange: d.Exchange,
+			ID:       d.ID,
+			Time:     tm,
+			Pair:     d.Pair,
+			Side:     d.Side,
+			Amount:   d.ExecutedAmount,
+			Price:    d.Price,
+			Fee:      d.Fee,
+		}}
+	}
+
+	trades := make([]tax.Trade, 0, len(d.Trades))
+	for x := range d.Trades {
+		t := tax.Trade{
+			Exchange: d.Exchange,
+			ID:       d.Trades[x].TID,
+			Time:     d.Trades[x].Timestamp,
+			Pair:     d.Pair,
+			Side:     d.Trades[x].Side,
+			Amount:   d.Trades[x].Amount,
+			Price:    d.Trades[x].Price,
+			Fee:      d.Trades[x].Fee,
+		}
+		if t.ID == "" {
+			t.ID = d.ID
+		}
+		if t.Side == "" {
+			t.Side = d.Side
+		}
+		trades = append(trades, t)
+	}
+	return trades
+}
@@ -0,0 +1,251 @@
+package engine
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"google.golang.org/grpc"
+)
+
+var (
+	errTenantMethodNotAllowed   = errors.New("method is not available to tenants")
+	errTenantExchangeNotAllowed = errors.New("exchange is not owned by tenant")
+	errTenantStrategyNotAllowed = errors.New("strategy does not trade on an exchange owned by tenant")
+)
+
+type tenantContextKey struct{}
+
+// tenant is the runtime state of a tenant sharing the bot, seeded from its
+// config when the engine starts
+type tenant struct {
+	name      string
+	username  string
+	password  string
+	exchanges []string
+	portfolio portfolio.Base
+}
+
+// newTenants returns the runtime state of the configured tenants
+func newTenants(cfg []config.TenantConfig) []*tenant {
+	tenants := make([]*tenant, len(cfg))
+	for x := range cfg {
+		tenants[x] = &tenant{
+			name:      cfg[x].Name,
+			username:  cfg[x].Username,
+			password:  cfg[x].Password,
+			exchanges: append([]string(nil), cfg[x].Exchanges...),
+		}
+		tenants[x].portfolio.Seed(portfolio.Base{
+			Addresses: append([]portfolio.Address(nil), cfg[x].Portfolio.Addresses...),
+		})
+	}
+	return tenants
+}
+
+// storeTenantPortfolios stores the portfolio addresses of the tenants in the
+// config so they are saved on shutdown along with the shared portfolio
+func storeTenantPortfolios(cfg []config.TenantConfig, tenants []*tenant) {
+	for x := range cfg {
+		for y := range tenants {
+			if tenants[y].name == cfg[x].Name {
+				cfg[x].Portfolio = portfolio.Base{Addresses: tenants[y].portfolio.Addresses}
+			}
+		}
+	}
+}
+
+// exchangeRequest is implemented by all gRPC requests scoped to an exchange
+type exchangeRequest interface {
+	GetExchange() string
+}
+
+// tenantMethods lists the gRPC methods available to tenants. Methods set to
+// true are scoped to an exchange and are only permitted for exchanges owned by
+// the tenant, the remainder filter their responses by tenant
+var tenantMethods = map[string]bool{
+	"GetExchanges":                      false,
+	"GetStrategies":                     false,
+	"StartStrategy":                     false,
+	"StopStrategy":                      false,
+	"GetDCAReport":                      false,
+	"GetPortfolio":                      false,
+	"GetPortfolioSummary":               false,
+	"AddPortfolioAddress":               false,
+	"RemovePortfolioAddress":            false,
+	"GetExchangeInfo":                   true,
+	"GetExchangeOTPCode":                true,
+	"GetExchangePairs":                  true,
+	"GetTicker":                         true,
+	"GetOrderbook":                      true,
+	"GetAccountInfo":                    true,
+	"GetOrders":                         true,
+	"GetOrder":                          true,
+	"SubmitOrder":                       true,
+	"SimulateOrder":                     true,
+	"PreviewOrder":                      true,
+	"WhaleBomb":                         true,
+	"CancelOrder":                       true,
+	"CancelAllOrders":                   true,
+	"GetCryptocurrencyDepositAddresses": true,
+	"GetCryptocurrencyDepositAddress":   true,
+	"WithdrawCryptocurrencyFunds":       true,
+	"WithdrawFiatFunds":                 true,
+	"WithdrawalEventsByExchange":        true,
+	"WithdrawalEventsByDate":            true,
+	"GetHistoricCandles":                true,
+	"GetLiveCandles":                    true,
+	"ExportHistory":                     true,
+	"SyncTradeHistory":                  true,
+	"GetAccountInfoStream":              true,
+	"GetOrderbookStream":                true,
+	"GetExchangeOrderbookStream":        true,
+	"GetTickerStream":                   true,
+	"GetExchangeTickerStream":           true,
+	"GetLiquidationStream":              true,
+}
+
+// getTenantByCredentials returns the tenant matching the supplied management
+// API credentials. Credentials are compared in constant time
+func getTenantByCredentials(username, password string) (*tenant, bool) {
+	for x := range Bot.tenants {
+		userMatch := subtle.ConstantTimeCompare([]byte(Bot.tenants[x].username), []byte(username))
+		passMatch := subtle.ConstantTimeCompare([]byte(Bot.tenants[x].password), []byte(password))
+		if userMatch&passMatch == 1 {
+			return Bot.tenants[x], true
+		}
+	}
+	return nil, false
+}
+
+// tenantFromContext returns the tenant an RPC request was authenticated as,
+// or nil for the administrator
+func tenantFromContext(ctx context.Context) *tenant {
+	t, _ := ctx.Value(tenantContextKey{}).(*tenant)
+	return t
+}
+
+// tenantOwnsExchange returns whether the exchange belongs to the tenant
+func tenantOwnsExchange(t *tenant, exchName string) bool {
+	for x := range t.exchanges {
+		if strings.EqualFold(t.exchanges[x], exchName) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTenantStrategy returns an error when an RPC request made by a tenant
+// is for a strategy which does not trade on an exchange the tenant owns
+func checkTenantStrategy(ctx context.Context, name string) error {
+	t := tenantFromContext(ctx)
+	if t == nil {
+		return nil
+	}
+	statuses := Bot.StrategyManager.GetStatus()
+	for x := range statuses {
+		if strings.EqualFold(statuses[x].Name, name) &&
+			tenantOwnsExchange(t, statuses[x].Exchange) {
+			return nil
+		}
+	}
+	return errTenantStrategyNotAllowed
+}
+
+// tenantUnaryInterceptor restricts tenants to the methods and exchanges they
+// are permitted to use
+func tenantUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	t := tenantFromContext(ctx)
+	if t == nil {
+		return handler(ctx, req)
+	}
+
+	exchangeScoped, ok := tenantMethods[path.Base(info.FullMethod)]
+	if !ok {
+		return nil, errTenantMethodNotAllowed
+	}
+
+	if exchangeScoped {
+		r, ok := req.(exchangeRequest)
+		if !ok || !tenantOwnsExchange(t, r.GetExchange()) {
+			return nil, errTenantExchangeNotAllowed
+		}
+	}
+	return handler(ctx, req)
+}
+
+// tenantServerStream checks the requests received on a tenant's stream are for
+// exchanges the tenant owns
+type tenantServerStream struct {
+	grpc.ServerStream
+	tenant *tenant
+}
+
+// RecvMsg receives a request, rejecting it when it is for an exchange not
+// owned by the tenant
+func (t *tenantServerStream) RecvMsg(m interface{}) error {
+	if err := t.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	r, ok := m.(exchangeRequest)
+	if !ok || !tenantOwnsExchange(t.tenant, r.GetExchange()) {
+		return errTenantExchangeNotAllowed
+	}
+	return nil
+}
+
+// tenantStreamInterceptor restricts tenants to the streams and exchanges they
+// are permitted to use
+func tenantStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	t := tenantFromContext(ss.Context())
+	if t == nil {
+		return handler(srv, ss)
+	}
+
+	exchangeScoped, ok := tenantMethods[path.Base(info.FullMethod)]
+	if !ok {
+		return errTenantMethodNotAllowed
+	}
+	if exchangeScoped {
+		ss = &tenantServerStream{ServerStream: ss, tenant: t}
+	}
+	return handler(srv, ss)
+}
+
+// portfolioFromContext returns the portfolio to be modified by an RPC request
+func portfolioFromContext(ctx context.Context) *portfolio.Base {
+	if t := tenantFromContext(ctx); t != nil {
+		return &t.portfolio
+	}
+	return Bot.Portfolio
+}
+
+// portfolioViewFromContext returns the portfolio to be viewed by an RPC
+// request. Tenants see their own addresses along with the exchange balances
+// of the exchanges they own
+func portfolioViewFromContext(ctx context.Context) *portfolio.Base {
+	t := tenantFromContext(ctx)
+	if t == nil {
+		return Bot.Portfolio
+	}
+
+	view := portfolio.Base{
+		Addresses: append([]portfolio.Address(nil), t.portfolio.Addresses...),
+	}
+	for x := range Bot.Portfolio.Addresses {
+		if Bot.Portfolio.Addresses[x].Description == portfolio.PortfolioAddressExchange &&
+			tenantOwnsExchange(t, Bot.Portfolio.Addresses[x].Address) {
+			view.Addresses = append(view.Addresses, Bot.Portfolio.Addresses[x])
+		}
+	}
+	for x := range Bot.Portfolio.PendingFiat {
+		if tenantOwnsExchange(t, Bot.Portfolio.PendingFiat[x].Exchange) {
+			view.PendingFiat = append(view.PendingFiat, Bot.Portfolio.PendingFiat[x])
+		}
+	}
+	return &view
+}
@@ -0,0 +1,212 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTenantUnaryInterceptor(t *testing.T) {
+	alice := &tenant{name: "alice", exchanges: []string{"Bitstamp"}}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	info := func(method string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTrader/" + method}
+	}
+
+	// administrator is unrestricted
+	_, err := tenantUnaryInterceptor(context.Background(), &gctrpc.GetConfigRequest{}, info("GetConfig"), handler)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.WithValue(context.Background(), tenantContextKey{}, alice)
+	_, err = tenantUnaryInterceptor(ctx, &gctrpc.GetConfigRequest{}, info("GetConfig"), handler)
+	if err != errTenantMethodNotAllowed {
+		t.Errorf("expected %v, got %v", errTenantMethodNotAllowed, err)
+	}
+
+	_, err = tenantUnaryInterceptor(ctx, &gctrpc.GetTickerRequest{Exchange: "bitstamp"}, info("GetTicker"), handler)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = tenantUnaryInterceptor(ctx, &gctrpc.GetTickerRequest{Exchange: "Kraken"}, info("GetTicker"), handler)
+	if err != errTenantExchangeNotAllowed {
+		t.Errorf("expected %v, got %v", errTenantExchangeNotAllowed, err)
+	}
+
+	_, err = tenantUnaryInterceptor(ctx, &gctrpc.GetPortfolioRequest{}, info("GetPortfolio"), handler)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetTenantByCredentials(t *testing.T) {
+	SetupTestHelpers(t)
+	old := Bot.tenants
+	defer func() { Bot.tenants = old }()
+	Bot.tenants = newTenants([]config.TenantConfig{{Name: "alice", Username: "alice", Password: "pw"}})
+
+	alice, ok := getTenantByCredentials("alice", "pw")
+	if !ok || alice.name != "alice" {
+		t.Error("expected tenant to be found")
+	}
+
+	_, ok = getTenantByCredentials("alice", "wrong")
+	if ok {
+		t.Error("expected tenant not to be found")
+	}
+}
+
+func TestPortfolioViewFromContext(t *testing.T) {
+	SetupTestHelpers(t)
+	oldPortfolio := Bot.Portfolio
+	defer func() { Bot.Portfolio = oldPortfolio }()
+	Bot.Portfolio = &portfolio.Base{}
+	Bot.Portfolio.AddExchangeAddress("Bitstamp", currency.BTC, 1)
+	Bot.Portfolio.AddExchangeAddress("Kraken", currency.BTC, 2)
+	err := Bot.Portfolio.AddAddress("adminaddress", portfolio.PortfolioAddressPersonal, currency.BTC, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := &tenant{name: "alice", exchanges: []string{"Bitstamp"}}
+	ctx := context.WithValue(context.Background(), tenantContextKey{}, alice)
+	err = portfolioFromContext(ctx).AddAddress("tenantaddress", portfolio.PortfolioAddressPersonal, currency.BTC, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if Bot.Portfolio.AddressExists("tenantaddress") {
+		t.Error("expected tenant address to be isolated from the shared portfolio")
+	}
+
+	view := portfolioViewFromContext(ctx)
+	if len(view.Addresses) != 2 ||
+		!view.AddressExists("tenantaddress") ||
+		!view.ExchangeExists("Bitstamp") {
+		t.Errorf("unexpected tenant portfolio view %+v", view.Addresses)
+	}
+
+	if portfolioViewFromContext(context.Background()) != Bot.Portfolio {
+		t.Error("expected administrator to view the shared portfolio")
+	}
+}
+
+// testServerStream receives a single request for an exchange
+type testServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	exchange string
+}
+
+func (t *testServerStream) Context() context.Context {
+	return t.ctx
+}
+
+func (t *testServerStream) RecvMsg(m interface{}) error {
+	m.(*gctrpc.GetAccountInfoRequest).Exchange = t.exchange
+	return nil
+}
+
+func TestTenantStreamInterceptor(t *testing.T) {
+	SetupTestHelpers(t)
+	old := Bot.tenants
+	defer func() { Bot.tenants = old }()
+	Bot.tenants = newTenants([]config.TenantConfig{
+		{Name: "alice", Username: "alice", Password: "pw", Exchanges: []string{"Bitstamp"}},
+		{Name: "bob", Username: "bob", Password: "pw", Exchanges: []string{"Kraken"}},
+	})
+
+	interceptor := grpc_middleware.ChainStreamServer(
+		grpcauth.StreamServerInterceptor(authenticateClient),
+		tenantStreamInterceptor,
+	)
+	info := &grpc.StreamServerInfo{FullMethod: "/gctrpc.GoCryptoTrader/GetAccountInfoStream"}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&gctrpc.GetAccountInfoRequest{})
+	}
+	stream := func(username, exch string) *testServerStream {
+		auth := "Basic " + crypto.Base64Encode([]byte(username+":pw"))
+		return &testServerStream{
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", auth)),
+			exchange: exch,
+		}
+	}
+
+	if err := interceptor(nil, stream("alice", "Bitstamp"), info, handler); err != nil {
+		t.Error(err)
+	}
+	if err := interceptor(nil, stream("alice", "Kraken"), info, handler); err != errTenantExchangeNotAllowed {
+		t.Errorf("expected %v, got %v", errTenantExchangeNotAllowed, err)
+	}
+	if err := interceptor(nil, stream("mallory", "Kraken"), info, handler); err == nil {
+		t.Error("expected unauthenticated stream to be rejected")
+	}
+
+	info.FullMethod = "/gctrpc.GoCryptoTrader/GetAuditEventStream"
+	if err := interceptor(nil, stream("bob", "Kraken"), info, handler); err != errTenantMethodNotAllowed {
+		t.Errorf("expected %v, got %v", errTenantMethodNotAllowed, err)
+	}
+}
+
+func TestTenantPortfolios(t *testing.T) {
+	cfg := []config.TenantConfig{{Name: "alice", Exchanges: []string{"Bitstamp"}}}
+	err := cfg[0].Portfolio.AddAddress("configured", portfolio.PortfolioAddressPersonal, currency.BTC, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenants := newTenants(cfg)
+	if !tenants[0].portfolio.AddressExists("configured") {
+		t.Error("expected the tenant portfolio to be seeded from its config")
+	}
+
+	// changes to the runtime portfolio are stored in the config on shutdown
+	err = tenants[0].portfolio.AddAddress("added", portfolio.PortfolioAddressPersonal, currency.BTC, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg[0].Portfolio.AddressExists("added") {
+		t.Error("expected the config not to be modified while running")
+	}
+	storeTenantPortfolios(cfg, tenants)
+	if !cfg[0].Portfolio.AddressExists("added") {
+		t.Error("expected the tenant portfolio to be stored in the config")
+	}
+}
+
+func TestCheckTenantStrategy(t *testing.T) {
+	SetupTestHelpers(t)
+	old := Bot.Config.Strategies.Strategies
+	defer func() { Bot.Config.Strategies.Strategies = old }()
+	Bot.Config.Strategies.Strategies = []config.StrategyConfig{
+		{Name: "alicedca", Strategy: "dca", Exchange: "Bitstamp"},
+		{Name: "bobdca", Strategy: "dca", Exchange: "Kraken"},
+	}
+
+	if err := checkTenantStrategy(context.Background(), "bobdca"); err != nil {
+		t.Error(err)
+	}
+	alice := &tenant{name: "alice", exchanges: []string{"Bitstamp"}}
+	ctx := context.WithValue(context.Background(), tenantContextKey{}, alice)
+	if err := checkTenantStrategy(ctx, "AliceDCA"); err != nil {
+		t.Error(err)
+	}
+	if err := checkTenantStrategy(ctx, "bobdca"); err != errTenantStrategyNotAllowed {
+		t.Errorf("expected %v, got %v", errTenantStrategyNotAllowed, err)
+	}
+	if err := checkTenantStrategy(ctx, "missing"); err != errTenantStrategyNotAllowed {
+		t.Errorf("expected %v, got %v", errTenantStrategyNotAllowed, err)
+	}
+}
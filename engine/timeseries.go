@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/equity"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/timeseries"
+)
+
+func (w *timeSeriesWriter) Started() bool {
+	return atomic.LoadInt32(&w.started) == 1
+}
+
+func (w *timeSeriesWriter) Start() error {
+	if atomic.AddInt32(&w.started, 1) != 1 {
+		return errors.New("time-series writer already started")
+	}
+
+	log.Debugln(log.SyncMgr, "Time-series writer starting...")
+	writer, err := newTimeSeriesDB(&Bot.Config.TimeSeries)
+	if err != nil {
+		atomic.CompareAndSwapInt32(&w.started, 1, 0)
+		return err
+	}
+	w.writer = writer
+	w.shutdown = make(chan struct{})
+	go w.run()
+	return nil
+}
+
+func (w *timeSeriesWriter) Stop() error {
+	if atomic.AddInt32(&w.stopped, 1) != 1 {
+		return errors.New("time-series writer is already stopped")
+	}
+
+	log.Debugln(log.SyncMgr, "Time-series writer shutting down...")
+	close(w.shutdown)
+	return nil
+}
+
+// newTimeSeriesDB returns a writer for the configured time-series database
+func newTimeSeriesDB(cfg *config.TimeSeriesConfig) (timeseries.Writer, error) {
+	switch cfg.Driver {
+	case timeseries.InfluxDB:
+		return timeseries.NewInfluxDB(timeseries.InfluxDBSettings{
+			URL:          cfg.InfluxDB.URL,
+			Database:     cfg.InfluxDB.Database,
+			Organisation: cfg.InfluxDB.Organisation,
+			Token:        cfg.InfluxDB.Token,
+			Username:     cfg.InfluxDB.Username,
+			Password:     cfg.InfluxDB.Password,
+		})
+	case timeseries.TimescaleDB:
+		return timeseries.NewTimescaleDB(cfg.TimescaleDB)
+	}
+	return nil, fmt.Errorf("time-series driver %s is unsupported", cfg.Driver)
+}
+
+func (w *timeSeriesWriter) run() {
+	log.Debugf(log.SyncMgr, "Time-series writer started, writing to %s.\n", Bot.Config.TimeSeries.Driver)
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.TimeSeries.Interval)
+	defer func() {
+		if err := w.writer.Close(); err != nil {
+			log.Errorf(log.SyncMgr, "Time-series writer: unable to close database: %v\n", err)
+		}
+		atomic.CompareAndSwapInt32(&w.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&w.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.SyncMgr, "Time-series writer shutdown.")
+	}()
+
+	for {
+		select {
+		case <-w.shutdown:
+			return
+		case t := <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			if err := w.write(t, Bot.Config.TimeSeries.Currency); err != nil {
+				log.Errorf(log.SyncMgr, "Time-series writer: unable to write points: %v\n", err)
+			}
+		}
+	}
+}
+
+// write writes the ticker of each enabled pair and the portfolio's valuation
+// at t
+func (w *timeSeriesWriter) write(t time.Time, valuation currency.Code) error {
+	var points []timeseries.Point
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		assets := exchanges[x].GetAssetTypes()
+		for y := range assets {
+			pairs := exchanges[x].GetEnabledPairs(assets[y])
+			for z := range pairs {
+				if p, ok := tickerPoint(t, exchanges[x].GetName(), pairs[z], assets[y]); ok {
+					points = append(points, p)
+				}
+			}
+		}
+	}
+	points = append(points, valuationPoints(Bot.EquityManager.snapshot(t, valuation))...)
+	return w.writer.Write(points)
+}
+
+// tickerPoint returns the prices and spread of a pair's ticker, returning
+// false when it has not been synced
+func tickerPoint(t time.Time, exchName string, p currency.Pair, a asset.Item) (timeseries.Point, bool) {
+	tick, err := ticker.GetTicker(exchName, p, a)
+	if err != nil {
+		return timeseries.Point{}, false
+	}
+	fields := map[string]float64{
+		"last":   tick.Last,
+		"bid":    tick.Bid,
+		"ask":    tick.Ask,
+		"volume": tick.Volume,
+	}
+	if tick.Bid > 0 && tick.Ask > 0 {
+		spread := tick.Ask - tick.Bid
+		fields["spread"] = spread
+		fields["spread_pct"] = spread / ((tick.Ask + tick.Bid) / 2) * 100
+	}
+	return timeseries.Point{
+		Measurement: timeSeriesTicker,
+		Tags: map[string]string{
+			"exchange": exchName,
+			"pair":     p.Base.Upper().String() + "-" + p.Quote.Upper().String(),
+			"asset":    a.String(),
+		},
+		Fields: fields,
+		Time:   t,
+	}, true
+}
+
+// valuationPoints returns the balance and equity of the portfolio and each
+// strategy
+func valuationPoints(snapshots []equity.Snapshot) []timeseries.Point {
+	points := make([]timeseries.Point, len(snapshots))
+	for i := range snapshots {
+		points[i] = timeseries.Point{
+			Measurement: timeSeriesPortfolio,
+			Tags: map[string]string{
+				"portfolio": snapshots[i].Portfolio,
+				"currency":  snapshots[i].Currency,
+			},
+			Fields: map[string]float64{
+				"balance": snapshots[i].Balance,
+				"equity":  snapshots[i].Equity,
+			},
+			Time: snapshots[i].Time,
+		}
+	}
+	return points
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/equity"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/timeseries"
+)
+
+func TestTickerPoint(t *testing.T) {
+	t.Parallel()
+	const exchName = "timeseries"
+	p := currency.NewPair(currency.BTC, currency.USD)
+	now := time.Now()
+	if _, ok := tickerPoint(now, exchName, p, asset.Spot); ok {
+		t.Error("expected no point for an unsynced pair")
+	}
+
+	err := ticker.ProcessTicker(exchName, &ticker.Price{Pair: p, Last: 100, Bid: 99, Ask: 101, Volume: 5}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point, ok := tickerPoint(now, exchName, p, asset.Spot)
+	if !ok {
+		t.Fatal("expected a point for a synced pair")
+	}
+	if point.Measurement != timeSeriesTicker ||
+		point.Tags["exchange"] != exchName ||
+		point.Tags["pair"] != "BTC-USD" ||
+		point.Tags["asset"] != "spot" ||
+		!point.Time.Equal(now) {
+		t.Errorf("unexpected point %+v", point)
+	}
+	if point.Fields["last"] != 100 || point.Fields["volume"] != 5 ||
+		point.Fields["spread"] != 2 || point.Fields["spread_pct"] != 2 {
+		t.Errorf("unexpected fields %v", point.Fields)
+	}
+}
+
+func TestValuationPoints(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	points := valuationPoints([]equity.Snapshot{
+		{Portfolio: equityPortfolioName, Currency: "USD", Time: now, Balance: 10, Equity: 25},
+		{Portfolio: equityStrategyPrefix + "dca", Currency: "USD", Time: now, Balance: 1, Equity: 2},
+	})
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, received %d", len(points))
+	}
+	if points[0].Measurement != timeSeriesPortfolio ||
+		points[0].Tags["portfolio"] != equityPortfolioName ||
+		points[0].Tags["currency"] != "USD" ||
+		points[0].Fields["balance"] != 10 ||
+		points[0].Fields["equity"] != 25 {
+		t.Errorf("unexpected point %+v", points[0])
+	}
+	if points[1].Tags["portfolio"] != "strategy:dca" {
+		t.Errorf("unexpected point %+v", points[1])
+	}
+}
+
+func TestNewTimeSeriesDB(t *testing.T) {
+	t.Parallel()
+	w, err := newTimeSeriesDB(&config.TimeSeriesConfig{
+		Driver:   timeseries.InfluxDB,
+		InfluxDB: config.TimeSeriesInfluxDBConfig{URL: "http://localhost:8086", Database: "gct"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := w.(*timeseries.Influx); !ok {
+		t.Errorf("expected InfluxDB writer, received %T", w)
+	}
+	if _, err = newTimeSeriesDB(&config.TimeSeriesConfig{Driver: "graphite"}); err == nil {
+		t.Error("expected error for unsupported driver")
+	}
+}
@@ -0,0 +1,16 @@
+package engine
+
+import "github.com/thrasher-corp/gocryptotrader/timeseries"
+
+// Measurements written to the time-series database
+const (
+	timeSeriesTicker    = "ticker"
+	timeSeriesPortfolio = "portfolio"
+)
+
+type timeSeriesWriter struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	writer   timeseries.Writer
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func (t *timeSyncChecker) Started() bool {
+	return atomic.LoadInt32(&t.started) == 1
+}
+
+func (t *timeSyncChecker) Start() error {
+	if atomic.AddInt32(&t.started, 1) != 1 {
+		return errors.New("time sync checker already started")
+	}
+
+	log.Debugln(log.ExchangeSys, "Time sync checker starting...")
+	t.shutdown = make(chan struct{})
+	go t.run()
+	return nil
+}
+
+func (t *timeSyncChecker) Stop() error {
+	if atomic.AddInt32(&t.stopped, 1) != 1 {
+		return errors.New("time sync checker is already stopped")
+	}
+
+	log.Debugln(log.ExchangeSys, "Time sync checker shutting down...")
+	close(t.shutdown)
+	return nil
+}
+
+func (t *timeSyncChecker) run() {
+	log.Debugln(log.ExchangeSys, "Time sync checker started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.TimeSync.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&t.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&t.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.ExchangeSys, "Time sync checker shutdown.")
+	}()
+
+	// drift is checked on startup as it causes authenticated requests to be
+	// rejected
+	t.checkAll()
+	for {
+		select {
+		case <-t.shutdown:
+			return
+		case <-tick.C:
+			t.checkAll()
+		}
+	}
+}
+
+// checkAll checks the clock of every loaded exchange which publishes its
+// server time and has authenticated API support
+func (t *timeSyncChecker) checkAll() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		f, ok := exchanges[x].(exchange.ServerTimeFetcher)
+		if !ok || !exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+			continue
+		}
+		before := clock.Now()
+		serverTime, err := f.FetchServerTime()
+		roundTrip := clock.Now().Sub(before)
+		if err != nil {
+			t.fail(exchanges[x].GetName(), err)
+			continue
+		}
+		t.record(exchanges[x].GetName(),
+			exchanges[x].GetBase().Requester,
+			serverTime.Sub(before.Add(roundTrip/2)),
+			roundTrip)
+	}
+}
+
+// fail records a server time request which failed, the previous drift and
+// offset are retained
+func (t *timeSyncChecker) fail(exchName string, err error) {
+	log.Warnf(log.ExchangeSys, "Time sync checker: unable to get %s server time: %v\n", exchName, err)
+	t.m.Lock()
+	d := t.drift(exchName)
+	d.Error = err.Error()
+	d.LastChecked = clock.Now()
+	t.m.Unlock()
+}
+
+// record compares the drift of an exchange's clock against the maximum,
+// offsetting the timestamps of its requests when adjusting is enabled and
+// notifying when the drift first exceeds the maximum
+func (t *timeSyncChecker) record(exchName string, r *request.Requester, drift, roundTrip time.Duration) {
+	cfg := &Bot.Config.TimeSync
+	exceeded := drift > cfg.MaxDrift || drift < -cfg.MaxDrift
+
+	t.m.Lock()
+	d := t.drift(exchName)
+	wasExceeded := d.Exceeded
+	d.Drift = drift
+	d.RoundTrip = roundTrip
+	d.Exceeded = exceeded
+	d.Error = ""
+	d.LastChecked = clock.Now()
+	if cfg.AdjustTimestamps && r != nil {
+		d.Offset = 0
+		if exceeded {
+			d.Offset = drift
+		}
+		r.SetClockOffset(d.Offset)
+	}
+	offset := d.Offset
+	t.m.Unlock()
+
+	if !exceeded {
+		if wasExceeded {
+			log.Infof(log.ExchangeSys, "Time sync checker: %s clock drift of %s is within %s.\n", exchName, drift, cfg.MaxDrift)
+		}
+		return
+	}
+	msg := fmt.Sprintf("Time sync checker: %s clock drift of %s exceeds %s", exchName, drift, cfg.MaxDrift)
+	if offset != 0 {
+		msg += ", request timestamps have been adjusted"
+	} else {
+		msg += ", authenticated requests may be rejected. Please synchronise the system clock"
+	}
+	log.Warnln(log.ExchangeSys, msg)
+	if !wasExceeded {
+		Bot.CommsManager.PushEvent(base.Event{
+			Type:    "timesync",
+			Message: msg,
+		})
+	}
+}
+
+// drift returns the stored drift of an exchange, the lock must be held
+func (t *timeSyncChecker) drift(exchName string) *ClockDrift {
+	if t.drifts == nil {
+		t.drifts = make(map[string]*ClockDrift)
+	}
+	key := strings.ToLower(exchName)
+	d, ok := t.drifts[key]
+	if !ok {
+		d = &ClockDrift{Exchange: exchName}
+		t.drifts[key] = d
+	}
+	return d
+}
+
+// GetAll returns a copy of the most recent drift of each exchange
+func (t *timeSyncChecker) GetAll() []ClockDrift {
+	t.m.Lock()
+	defer t.m.Unlock()
+	drifts := make([]ClockDrift, 0, len(t.drifts))
+	for _, v := range t.drifts {
+		drifts = append(drifts, *v)
+	}
+	return drifts
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestTimeSyncRecord(t *testing.T) {
+	SetupTestHelpers(t)
+	Bot.Config.TimeSync.MaxDrift = time.Second
+	Bot.Config.TimeSync.AdjustTimestamps = true
+	defer func() {
+		Bot.Config.TimeSync.AdjustTimestamps = false
+	}()
+
+	var s timeSyncChecker
+	r := request.New("test", new(http.Client))
+
+	s.record(testExchange, r, 500*time.Millisecond, 50*time.Millisecond)
+	drifts := s.GetAll()
+	if len(drifts) != 1 || drifts[0].Exceeded || drifts[0].Offset != 0 {
+		t.Fatalf("expected drift within the maximum not to be offset, got %+v", drifts)
+	}
+	if r.ClockOffset() != 0 {
+		t.Errorf("expected no clock offset, got %s", r.ClockOffset())
+	}
+
+	s.record(testExchange, r, -3*time.Second, 50*time.Millisecond)
+	drifts = s.GetAll()
+	if len(drifts) != 1 || !drifts[0].Exceeded || drifts[0].Offset != -3*time.Second {
+		t.Fatalf("expected drift over the maximum to be offset, got %+v", drifts)
+	}
+	if r.ClockOffset() != -3*time.Second {
+		t.Errorf("expected a clock offset of -3s, got %s", r.ClockOffset())
+	}
+
+	s.fail(testExchange, errors.New("test error"))
+	drifts = s.GetAll()
+	if drifts[0].Error == "" || drifts[0].Offset != -3*time.Second {
+		t.Errorf("expected a failed check to retain the offset, got %+v", drifts[0])
+	}
+
+	s.record(testExchange, r, 0, 50*time.Millisecond)
+	if r.ClockOffset() != 0 {
+		t.Errorf("expected the clock offset to be cleared, got %s", r.ClockOffset())
+	}
+}
@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// ClockDrift is the outcome of the most recent check of the local clock
+// against an exchange's server time
+type ClockDrift struct {
+	Exchange string
+	// Drift is the exchange's server time less the local time, positive when
+	// the local clock is behind
+	Drift time.Duration
+	// RoundTrip is the latency of the server time request, the server time
+	// is compared against the local time halfway through it
+	RoundTrip time.Duration
+	// Offset is added to the local time when timestamping the exchange's
+	// requests
+	Offset      time.Duration
+	Exceeded    bool
+	Error       string
+	LastChecked time.Time
+}
+
+type timeSyncChecker struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+	m        sync.Mutex
+	drifts   map[string]*ClockDrift
+}
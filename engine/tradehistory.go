@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+const tradeHistoryFileName = "trade_history.json"
+
+var errTradeHistoryPagingUnsupported = errors.New("exchange does not support paging through its trade history")
+
+// Sync pages through the account's trades of an exchange's pair from the
+// cursor persisted by the previous sync, or the start of the account's history
+// when never synced, adding the trades which have not been synced. Paging stops
+// early when more trades share a time than fit in a page. Progress is
+// persisted even when a page fails. Returns the amount of trades added
+func (t *tradeHistoryStore) Sync(exch exchange.IBotExchange, p currency.Pair, a asset.Item) (int, error) {
+	pager, ok := exch.(exchange.TradeHistoryPager)
+	if !ok {
+		return 0, errTradeHistoryPagingUnsupported
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	if err := t.load(); err != nil {
+		return 0, err
+	}
+	key := tradeHistoryKey(exch.GetName(), p, a)
+	h, ok := t.histories[key]
+	if !ok {
+		h = &SyncedTradeHistory{
+			Exchange:  exch.GetName(),
+			Pair:      p,
+			AssetType: a,
+		}
+		t.histories[key] = h
+	}
+	seen := make(map[string]struct{}, len(h.Trades))
+	for i := range h.Trades {
+		seen[tradeID(&h.Trades[i])] = struct{}{}
+	}
+
+	var added int
+	var err error
+	for {
+		var page []order.Detail
+		page, err = pager.GetTradeHistoryPage(p, a, h.Cursor)
+		if err != nil {
+			break
+		}
+		// pages start at the cursor so the trades at the cursor's time are
+		// returned again, paging ends once a page holds no new trades
+		var pageAdded int
+		for i := range page {
+			id := tradeID(&page[i])
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			if page[i].Exchange == "" {
+				page[i].Exchange = exch.GetName()
+			}
+			if page[i].Pair.IsEmpty() {
+				page[i].Pair = p
+			}
+			if page[i].AssetType == "" {
+				page[i].AssetType = a
+			}
+			h.Trades = append(h.Trades, page[i])
+			if page[i].Date.After(h.Cursor) {
+				h.Cursor = page[i].Date
+			}
+			pageAdded++
+		}
+		if pageAdded == 0 {
+			break
+		}
+		added += pageAdded
+	}
+
+	if added > 0 {
+		sort.SliceStable(h.Trades, func(i, j int) bool {
+			return h.Trades[i].Date.Before(h.Trades[j].Date)
+		})
+		if saveErr := t.save(); err == nil {
+			err = saveErr
+		}
+	}
+	return added, err
+}
+
+// Get returns a copy of the synced trade history of an exchange's pair
+func (t *tradeHistoryStore) Get(exchName string, p currency.Pair, a asset.Item) (SyncedTradeHistory, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if err := t.load(); err != nil {
+		return SyncedTradeHistory{}, err
+	}
+	h, ok := t.histories[tradeHistoryKey(exchName, p, a)]
+	if !ok {
+		return SyncedTradeHistory{}, fmt.Errorf("%s %s %s trade history has not been synced", exchName, p, a)
+	}
+	c := *h
+	c.Trades = append([]order.Detail(nil), h.Trades...)
+	return c, nil
+}
+
+// GetOrders returns the synced trades of an exchange between start and end,
+// with no upper bound when end is zero, as an order detail per order holding
+// the order's trades. Pairs are translated to their canonical currency codes
+func (t *tradeHistoryStore) GetOrders(exchName string, start, end time.Time) ([]order.Detail, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+
+	var orders []order.Detail
+	index := make(map[string]int)
+	for _, h := range t.histories {
+		if !strings.EqualFold(h.Exchange, exchName) {
+			continue
+		}
+		for i := range h.Trades {
+			trade := &h.Trades[i]
+			if trade.Date.Before(start) || (!end.IsZero() && trade.Date.After(end)) {
+				continue
+			}
+			key := tradeHistoryKey(h.Exchange, h.Pair, h.AssetType) + ":" + trade.ID
+			x, ok := index[key]
+			if !ok {
+				index[key] = len(orders)
+				d := *trade
+				d.Pair = currency.CanonicalPair(h.Exchange, d.Pair)
+				d.Trades = append([]order.TradeHistory(nil), trade.Trades...)
+				orders = append(orders, d)
+				continue
+			}
+			d := &orders[x]
+			value := d.Price*d.ExecutedAmount + trade.Price*trade.ExecutedAmount
+			d.Amount += trade.Amount
+			d.ExecutedAmount += trade.ExecutedAmount
+			if d.ExecutedAmount > 0 {
+				d.Price = value / d.ExecutedAmount
+			}
+			d.Fee += trade.Fee
+			d.Trades = append(d.Trades, trade.Trades...)
+			if trade.LastUpdated.After(d.LastUpdated) {
+				d.LastUpdated = trade.LastUpdated
+			}
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Date.Before(orders[j].Date)
+	})
+	return orders, nil
+}
+
+// syncedOrderHistory syncs the trade history of each of an exchange's enabled
+// spot pairs and returns its orders filled between start and end
+func syncedOrderHistory(exch exchange.IBotExchange, start, end time.Time) ([]order.Detail, error) {
+	pairs := exch.GetEnabledPairs(asset.Spot)
+	for x := range pairs {
+		if _, err := Bot.TradeHistory.Sync(exch, pairs[x], asset.Spot); err != nil {
+			return nil, fmt.Errorf("%s unable to sync %s trade history: %v", exch.GetName(), pairs[x], err)
+		}
+	}
+	return Bot.TradeHistory.GetOrders(exch.GetName(), start, end)
+}
+
+// load reads the persisted trade histories once
+func (t *tradeHistoryStore) load() error {
+	if t.histories != nil {
+		return nil
+	}
+	if t.path == "" {
+		t.path = filepath.Join(Bot.Settings.DataDir, tradeHistoryFileName)
+	}
+
+	histories := make(map[string]*SyncedTradeHistory)
+	if file.Exists(t.path) {
+		data, err := ioutil.ReadFile(t.path)
+		if err != nil {
+			return err
+		}
+		var stored []*SyncedTradeHistory
+		if err = json.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("unable to load trade history from %s: %v", t.path, err)
+		}
+		for x := range stored {
+			histories[tradeHistoryKey(stored[x].Exchange, stored[x].Pair, stored[x].AssetType)] = stored[x]
+		}
+	}
+	t.histories = histories
+	return nil
+}
+
+func (t *tradeHistoryStore) save() error {
+	stored := make([]*SyncedTradeHistory, 0, len(t.histories))
+	for _, h := range t.histories {
+		stored = append(stored, h)
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		return tradeHistoryKey(stored[i].Exchange, stored[i].Pair, stored[i].AssetType) <
+			tradeHistoryKey(stored[j].Exchange, stored[j].Pair, stored[j].AssetType)
+	})
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return file.Write(t.path, data)
+}
+
+func tradeHistoryKey(exchName string, p currency.Pair, a asset.Item) string {
+	return strings.ToLower(exchName + ":" + p.Base.String() + p.Quote.String() + ":" + a.String())
+}
+
+// tradeID returns the ID of a trade, falling back to its order's ID when the
+// exchange does not return trade IDs
+func tradeID(d *order.Detail) string {
+	if len(d.Trades) > 0 && d.Trades[0].TID != "" {
+		return d.Trades[0].TID
+	}
+	return d.ID
+}
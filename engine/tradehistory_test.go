@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// testTradePager returns pages of up to three trades on or after since
+type testTradePager struct {
+	exchange.IBotExchange
+	trades []order.Detail
+	calls  int
+}
+
+func (e *testTradePager) GetName() string {
+	return "PagerTest"
+}
+
+func (e *testTradePager) GetTradeHistoryPage(_ currency.Pair, _ asset.Item, since time.Time) ([]order.Detail, error) {
+	e.calls++
+	var page []order.Detail
+	for i := range e.trades {
+		if e.trades[i].Date.Before(since) {
+			continue
+		}
+		page = append(page, e.trades[i])
+		if len(page) == 3 {
+			break
+		}
+	}
+	return page, nil
+}
+
+func testTrade(orderID, tid string, tm time.Time, amount, price float64) order.Detail {
+	return order.Detail{
+		ID:             orderID,
+		Side:           order.Buy,
+		Price:          price,
+		Amount:         amount,
+		ExecutedAmount: amount,
+		Date:           tm,
+		LastUpdated:    tm,
+		Trades:         []order.TradeHistory{{TID: tid, Price: price, Amount: amount, Timestamp: tm}},
+	}
+}
+
+func TestTradeHistorySync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tradehistory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	exch := &testTradePager{trades: []order.Detail{
+		testTrade("1", "10", start, 1, 100),
+		testTrade("1", "11", start.Add(time.Minute), 1, 200),
+		// trades sharing a time are returned again by the following page
+		testTrade("2", "12", start.Add(time.Minute), 2, 300),
+		testTrade("3", "13", start.Add(time.Hour), 1, 400),
+	}}
+	p := currency.NewPair(currency.BTC, currency.USD)
+
+	store := tradeHistoryStore{path: filepath.Join(dir, tradeHistoryFileName)}
+	if _, err = store.Sync(&FakePassingExchange{}, p, asset.Spot); err != errTradeHistoryPagingUnsupported {
+		t.Errorf("expected %v, got %v", errTradeHistoryPagingUnsupported, err)
+	}
+	added, err := store.Sync(exch, p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 4 {
+		t.Errorf("expected 4 trades to be added, got %d", added)
+	}
+
+	orders, err := store.GetOrders("pagertest", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 3 {
+		t.Fatalf("expected 3 orders, got %d", len(orders))
+	}
+	if orders[0].ID != "1" || len(orders[0].Trades) != 2 ||
+		orders[0].ExecutedAmount != 2 || orders[0].Price != 150 ||
+		orders[0].Exchange != "PagerTest" {
+		t.Errorf("expected the trades of order 1 to be grouped, got %+v", orders[0])
+	}
+	if orders, err = store.GetOrders("PagerTest", start.Add(time.Minute*30), time.Time{}); err != nil || len(orders) != 1 {
+		t.Errorf("expected a single order after the start, got %d %v", len(orders), err)
+	}
+
+	// the cursor and trades are persisted and only new trades are added
+	restored := tradeHistoryStore{path: store.path}
+	h, err := restored.Get("PagerTest", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Trades) != 4 || !h.Cursor.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected 4 trades and the cursor to be restored, got %d %s", len(h.Trades), h.Cursor)
+	}
+	exch.trades = append(exch.trades, testTrade("4", "14", start.Add(time.Hour*2), 1, 500))
+	exch.calls = 0
+	if added, err = restored.Sync(exch, p, asset.Spot); err != nil || added != 1 {
+		t.Errorf("expected a single trade to be added, got %d %v", added, err)
+	}
+	if exch.calls != 2 {
+		t.Errorf("expected paging to resume from the cursor, got %d pages", exch.calls)
+	}
+}
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// SyncedTradeHistory is the account's trade history of an exchange's pair
+// synced so far, oldest first, and the cursor the next sync resumes from
+type SyncedTradeHistory struct {
+	Exchange  string         `json:"exchange"`
+	Pair      currency.Pair  `json:"pair"`
+	AssetType asset.Item     `json:"asset"`
+	Cursor    time.Time      `json:"cursor"`
+	Trades    []order.Detail `json:"trades"`
+}
+
+// tradeHistoryStore persists the trade histories synced from exchanges which
+// can page through the account's trades
+type tradeHistoryStore struct {
+	// path is the file the synced trade histories are persisted to
+	path      string
+	m         sync.Mutex
+	histories map[string]*SyncedTradeHistory
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
+)
+
+const tradesFileName = "trades.json"
+
+// updateTrades fetches the recent public trades of an exchange's pair over
+// REST and adds them to the trade feed. Exchanges which do not provide their
+// public trades are skipped
+func updateTrades(exch exchange.IBotExchange, p currency.Pair, a asset.Item) error {
+	history, err := exch.GetExchangeHistory(p, a)
+	if err != nil {
+		if err == common.ErrNotYetImplemented || err == common.ErrFunctionNotSupported {
+			return nil
+		}
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	trades := make([]trade.Data, len(history))
+	for x := range history {
+		// sides are informational, unrecognised sides are left unset
+		side, _ := order.StringToOrderSide(history[x].Type)
+		trades[x] = trade.Data{
+			TID:       history[x].TID,
+			Pair:      p,
+			AssetType: a,
+			Side:      side,
+			Price:     history[x].Price,
+			Amount:    history[x].Amount,
+			Timestamp: history[x].Timestamp,
+		}
+	}
+	added, err := trade.AddTrades(exch.GetName(), trades)
+	Bot.EventBus.publishTrades(exch.GetName(), added)
+	return err
+}
+
+// processWebsocketTrade adds a trade streamed by an exchange's websocket to
+// the trade feed
+func processWebsocketTrade(exchName string, d *wshandler.TradeData) error {
+	added, err := trade.AddTrades(exchName, []trade.Data{{
+		Pair:      d.CurrencyPair,
+		AssetType: d.AssetType,
+		Side:      d.Side,
+		Price:     d.Price,
+		Amount:    d.Amount,
+		Timestamp: d.Timestamp,
+	}})
+	Bot.EventBus.publishTrades(exchName, added)
+	return err
+}
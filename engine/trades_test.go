@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
+)
+
+// testTradeFeed returns its public trades, or its error when set
+type testTradeFeed struct {
+	exchange.IBotExchange
+	trades []exchange.TradeHistory
+	err    error
+}
+
+func (e *testTradeFeed) GetName() string {
+	return "TradeFeedTest"
+}
+
+func (e *testTradeFeed) GetExchangeHistory(_ currency.Pair, _ asset.Item) ([]exchange.TradeHistory, error) {
+	return e.trades, e.err
+}
+
+func TestUpdateTrades(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	exch := &testTradeFeed{err: common.ErrNotYetImplemented}
+	if err := updateTrades(exch, p, asset.Spot); err != nil {
+		t.Errorf("expected unimplemented trades to be skipped, got %v", err)
+	}
+
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	exch = &testTradeFeed{trades: []exchange.TradeHistory{
+		{TID: "2", Price: 101, Amount: 1, Type: "sell", Timestamp: start.Add(time.Second)},
+		{TID: "1", Price: 100, Amount: 2, Type: "buy", Timestamp: start},
+	}}
+	if err := updateTrades(exch, p, asset.Spot); err != nil {
+		t.Fatal(err)
+	}
+	// websocket trades are added to the same feed
+	err := processWebsocketTrade(exch.GetName(), &wshandler.TradeData{
+		CurrencyPair: p,
+		AssetType:    asset.Spot,
+		Side:         order.Buy,
+		Price:        102,
+		Amount:       1,
+		Timestamp:    start.Add(time.Second * 2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trades, err := trade.GetRecentTrades(exch.GetName(), p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 3 {
+		t.Fatalf("expected 3 trades, got %d", len(trades))
+	}
+	if trades[0].TID != "1" || trades[0].Side != order.Buy || trades[1].Side != order.Sell || trades[2].Price != 102 {
+		t.Errorf("unexpected trades %+v", trades)
+	}
+}
@@ -0,0 +1,301 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// vars for the transfer time manager
+var (
+	TransferTimeSleepDelay = time.Minute
+	// MaxTransferTimeSamples is the amount of completed transfers retained per
+	// exchange, currency and direction
+	MaxTransferTimeSamples = 100
+	// DefaultWithdrawalDuration is used when no withdrawals have been learned
+	DefaultWithdrawalDuration = time.Hour
+	// DefaultDepositDuration is used when no deposits have been learned
+	DefaultDepositDuration = time.Hour
+
+	errInvalidTransferDirection = errors.New("invalid transfer direction")
+
+	transferCompleteKeywords = []string{"complete", "success", "done", "finish", "confirmed", "credited"}
+	transferPendingKeywords  = []string{"pending", "processing", "unconfirmed", "waiting"}
+	transferFailedKeywords   = []string{"fail", "cancel", "reject", "error"}
+)
+
+func (t *transferTimeManager) Started() bool {
+	return atomic.LoadInt32(&t.started) == 1
+}
+
+func (t *transferTimeManager) Start() error {
+	if atomic.AddInt32(&t.started, 1) != 1 {
+		return errors.New("transfer time manager already started")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Transfer time manager starting...")
+	t.shutdown = make(chan struct{})
+	go t.run()
+	return nil
+}
+
+func (t *transferTimeManager) Stop() error {
+	if atomic.AddInt32(&t.stopped, 1) != 1 {
+		return errors.New("transfer time manager is already stopped")
+	}
+
+	log.Debugln(log.PortfolioMgr, "Transfer time manager shutting down...")
+	close(t.shutdown)
+	return nil
+}
+
+func (t *transferTimeManager) run() {
+	log.Debugln(log.PortfolioMgr, "Transfer time manager started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(TransferTimeSleepDelay)
+	defer func() {
+		atomic.CompareAndSwapInt32(&t.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&t.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.PortfolioMgr, "Transfer time manager shutdown.")
+	}()
+
+	t.processFundingHistory()
+	for {
+		select {
+		case <-t.shutdown:
+			return
+		case <-tick.C:
+			t.processFundingHistory()
+		}
+	}
+}
+
+// processFundingHistory polls every authenticated exchange for its funding
+// history and records the completion time of any tracked transfers
+func (t *transferTimeManager) processFundingHistory() {
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		if !exchanges[x].GetAuthenticatedAPISupport(exchange.RestAuthentication) {
+			continue
+		}
+		exchName := exchanges[x].GetName()
+		history, err := exchanges[x].GetFundingHistory()
+		if err != nil {
+			if err != common.ErrNotYetImplemented && err != common.ErrFunctionNotSupported {
+				log.Errorf(log.PortfolioMgr,
+					"Transfer time manager unable to get %s funding history: %s\n",
+					exchName,
+					err)
+			}
+			continue
+		}
+		Bot.KeyMonitor.CheckWithdrawals(exchName, history)
+		now := clock.Now()
+		for i := range history {
+			t.observe(exchName, &history[i], now)
+		}
+	}
+}
+
+// TrackWithdrawal starts tracking a submitted withdrawal so its completion
+// time can be learned once it appears as complete in the funding history
+func (t *transferTimeManager) TrackWithdrawal(exchName, curr, id string) {
+	if id == "" {
+		return
+	}
+	t.m.Lock()
+	t.init()
+	t.pending[transferID(exchName, id)] = pendingTransfer{
+		key:   newTransferKey(exchName, curr, TransferWithdrawal),
+		start: clock.Now(),
+	}
+	t.m.Unlock()
+}
+
+// observe updates tracking state for a single funding history entry seen at
+// the supplied time
+func (t *transferTimeManager) observe(exchName string, f *exchange.FundHistory, now time.Time) {
+	if f.TransferID == "" {
+		return
+	}
+	direction, ok := transferDirection(f.TransferType)
+	if !ok {
+		return
+	}
+
+	id := transferID(exchName, f.TransferID)
+	status := strings.ToLower(f.Status)
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.init()
+	p, tracked := t.pending[id]
+	switch {
+	case containsAny(status, transferFailedKeywords):
+		delete(t.pending, id)
+	case isTransferComplete(status):
+		if !tracked {
+			// Completion time is unknown when a transfer is first seen as
+			// already complete
+			return
+		}
+		delete(t.pending, id)
+		start := p.start
+		if !f.Timestamp.IsZero() && f.Timestamp.Before(start) {
+			start = f.Timestamp
+		}
+		t.addSample(p.key, now.Sub(start))
+		log.Debugf(log.PortfolioMgr,
+			"Transfer time manager: %s %s %s %s completed in %s\n",
+			exchName,
+			p.key.currency,
+			strings.ToLower(string(p.key.direction)),
+			f.TransferID,
+			now.Sub(start))
+	case !tracked:
+		start := now
+		if !f.Timestamp.IsZero() && f.Timestamp.Before(now) {
+			start = f.Timestamp
+		}
+		t.pending[id] = pendingTransfer{
+			key:   newTransferKey(exchName, f.Currency, direction),
+			start: start,
+		}
+	}
+}
+
+// AddSample records a completed transfer duration
+func (t *transferTimeManager) AddSample(exchName, curr string, direction TransferDirection, d time.Duration) error {
+	if direction != TransferWithdrawal && direction != TransferDeposit {
+		return errInvalidTransferDirection
+	}
+	t.m.Lock()
+	t.init()
+	t.addSample(newTransferKey(exchName, curr, direction), d)
+	t.m.Unlock()
+	return nil
+}
+
+func (t *transferTimeManager) addSample(key transferKey, d time.Duration) {
+	if d < 0 {
+		return
+	}
+	s := append(t.samples[key], d)
+	if len(s) > MaxTransferTimeSamples {
+		s = s[len(s)-MaxTransferTimeSamples:]
+	}
+	t.samples[key] = s
+}
+
+// Estimate returns the learned completion time distribution for the supplied
+// exchange, currency and direction. When nothing has been learned yet the
+// static default duration is returned for all statistics
+func (t *transferTimeManager) Estimate(exchName, curr string, direction TransferDirection) TransferTimeEstimate {
+	key := newTransferKey(exchName, curr, direction)
+	t.m.Lock()
+	samples := append([]time.Duration(nil), t.samples[key]...)
+	t.m.Unlock()
+
+	e := TransferTimeEstimate{
+		Exchange:  exchName,
+		Currency:  key.currency,
+		Direction: direction,
+		Samples:   len(samples),
+	}
+	if len(samples) == 0 {
+		d := DefaultDepositDuration
+		if direction == TransferWithdrawal {
+			d = DefaultWithdrawalDuration
+		}
+		e.Median, e.Mean, e.P90, e.Max = d, d, d, d
+		return e
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	var total time.Duration
+	for i := range samples {
+		total += samples[i]
+	}
+	e.Learned = true
+	e.Mean = total / time.Duration(len(samples))
+	e.Median = percentile(samples, 50)
+	e.P90 = percentile(samples, 90)
+	e.Max = samples[len(samples)-1]
+	return e
+}
+
+// TransferFeasible returns the expected duration of moving a currency from
+// one exchange to another, using the 90th percentile of the learned withdrawal
+// and deposit times, and whether it completes within maxDuration
+func (t *transferTimeManager) TransferFeasible(from, to, curr string, maxDuration time.Duration) (time.Duration, bool) {
+	d := t.Estimate(from, curr, TransferWithdrawal).P90 +
+		t.Estimate(to, curr, TransferDeposit).P90
+	return d, d <= maxDuration
+}
+
+func (t *transferTimeManager) init() {
+	if t.pending == nil {
+		t.pending = make(map[string]pendingTransfer)
+	}
+	if t.samples == nil {
+		t.samples = make(map[transferKey][]time.Duration)
+	}
+}
+
+// percentile returns the nearest rank percentile of a sorted slice
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func transferDirection(transferType string) (TransferDirection, bool) {
+	s := strings.ToLower(transferType)
+	switch {
+	case strings.Contains(s, "withdraw"):
+		return TransferWithdrawal, true
+	case strings.Contains(s, "deposit"):
+		return TransferDeposit, true
+	}
+	return "", false
+}
+
+func newTransferKey(exchName, curr string, direction TransferDirection) transferKey {
+	return transferKey{
+		exchange:  strings.ToLower(exchName),
+		currency:  strings.ToUpper(curr),
+		direction: direction,
+	}
+}
+
+func transferID(exchName, id string) string {
+	return strings.ToLower(exchName) + ":" + id
+}
+
+func isTransferComplete(status string) bool {
+	if status == "ok" {
+		return true
+	}
+	return containsAny(status, transferCompleteKeywords) &&
+		!containsAny(status, transferPendingKeywords)
+}
+
+func containsAny(s string, keywords []string) bool {
+	for i := range keywords {
+		if strings.Contains(s, keywords[i]) {
+			return true
+		}
+	}
+	return false
+}
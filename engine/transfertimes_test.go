@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+)
+
+func TestTransferTimeObserve(t *testing.T) {
+	var m transferTimeManager
+	start := time.Now().Add(-time.Hour)
+	f := exchange.FundHistory{
+		TransferID:   "1337",
+		TransferType: "withdrawal",
+		Currency:     "btc",
+		Status:       "pending",
+		Timestamp:    start,
+	}
+	m.observe("Bitstamp", &f, start.Add(time.Minute))
+	if len(m.pending) != 1 {
+		t.Fatal("expected transfer to be tracked")
+	}
+
+	f.Status = "Completed"
+	m.observe("Bitstamp", &f, start.Add(time.Hour))
+	if len(m.pending) != 0 {
+		t.Error("expected transfer to no longer be pending")
+	}
+
+	e := m.Estimate("bitstamp", "BTC", TransferWithdrawal)
+	if !e.Learned || e.Samples != 1 || e.Median != time.Hour {
+		t.Errorf("unexpected estimate %+v", e)
+	}
+
+	// transfers first seen as complete cannot be timed
+	f.TransferID = "1338"
+	m.observe("Bitstamp", &f, start.Add(time.Hour))
+	if m.Estimate("bitstamp", "BTC", TransferWithdrawal).Samples != 1 {
+		t.Error("expected untracked completed transfer to be ignored")
+	}
+
+	f.TransferID = "1339"
+	f.Status = "unconfirmed"
+	m.observe("Bitstamp", &f, start)
+	f.Status = "cancelled"
+	m.observe("Bitstamp", &f, start)
+	if len(m.pending) != 0 {
+		t.Error("expected cancelled transfer to be dropped")
+	}
+
+	f.TransferType = "trade"
+	m.observe("Bitstamp", &f, start)
+	if len(m.pending) != 0 {
+		t.Error("expected unknown transfer type to be ignored")
+	}
+}
+
+func TestTransferTimeTrackWithdrawal(t *testing.T) {
+	var m transferTimeManager
+	m.TrackWithdrawal("Bitstamp", "BTC", "")
+	if len(m.pending) != 0 {
+		t.Error("expected empty ID to be ignored")
+	}
+
+	m.TrackWithdrawal("Bitstamp", "BTC", "1337")
+	m.observe("Bitstamp", &exchange.FundHistory{
+		TransferID:   "1337",
+		TransferType: "Withdrawal",
+		Status:       "ok",
+	}, time.Now().Add(time.Minute))
+	e := m.Estimate("Bitstamp", "btc", TransferWithdrawal)
+	if !e.Learned {
+		t.Error("expected tracked withdrawal to be learned")
+	}
+}
+
+func TestTransferTimeEstimate(t *testing.T) {
+	var m transferTimeManager
+	e := m.Estimate("Bitstamp", "BTC", TransferDeposit)
+	if e.Learned || e.P90 != DefaultDepositDuration {
+		t.Errorf("expected default estimate, got %+v", e)
+	}
+
+	err := m.AddSample("Bitstamp", "BTC", "SIDEWAYS", time.Minute)
+	if err != errInvalidTransferDirection {
+		t.Errorf("expected %v, got %v", errInvalidTransferDirection, err)
+	}
+
+	for i := 10; i > 0; i-- {
+		err = m.AddSample("Bitstamp", "BTC", TransferDeposit, time.Duration(i)*time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	e = m.Estimate("Bitstamp", "BTC", TransferDeposit)
+	if e.Samples != 10 ||
+		e.Median != 5*time.Minute ||
+		e.P90 != 9*time.Minute ||
+		e.Max != 10*time.Minute ||
+		e.Mean != 330*time.Second {
+		t.Errorf("unexpected estimate %+v", e)
+	}
+
+	old := MaxTransferTimeSamples
+	MaxTransferTimeSamples = 5
+	defer func() { MaxTransferTimeSamples = old }()
+	err = m.AddSample("Bitstamp", "BTC", TransferDeposit, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Estimate("Bitstamp", "BTC", TransferDeposit).Samples != 5 {
+		t.Error("expected samples to be capped")
+	}
+}
+
+func TestTransferFeasible(t *testing.T) {
+	var m transferTimeManager
+	err := m.AddSample("Bitstamp", "BTC", TransferWithdrawal, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.AddSample("Kraken", "BTC", TransferDeposit, 20*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, ok := m.TransferFeasible("Bitstamp", "Kraken", "BTC", time.Hour)
+	if !ok || d != 30*time.Minute {
+		t.Errorf("expected feasible transfer of 30m, got %v %v", d, ok)
+	}
+
+	_, ok = m.TransferFeasible("Bitstamp", "Kraken", "BTC", 15*time.Minute)
+	if ok {
+		t.Error("expected transfer to be infeasible")
+	}
+
+	// unlearned routes fall back to the static defaults
+	d, _ = m.TransferFeasible("Kraken", "Bitstamp", "BTC", time.Hour)
+	if d != DefaultWithdrawalDuration+DefaultDepositDuration {
+		t.Errorf("expected default durations, got %v", d)
+	}
+}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// TransferDirection defines whether a transfer leaves or enters an exchange
+type TransferDirection string
+
+// Transfer directions
+const (
+	TransferWithdrawal TransferDirection = "WITHDRAWAL"
+	TransferDeposit    TransferDirection = "DEPOSIT"
+)
+
+// TransferTimeEstimate holds the learned completion time distribution for an
+// exchange, currency and transfer direction
+type TransferTimeEstimate struct {
+	Exchange  string
+	Currency  string
+	Direction TransferDirection
+	Samples   int
+	Learned   bool
+	Median    time.Duration
+	Mean      time.Duration
+	P90       time.Duration
+	Max       time.Duration
+}
+
+type transferKey struct {
+	exchange  string
+	currency  string
+	direction TransferDirection
+}
+
+type pendingTransfer struct {
+	key   transferKey
+	start time.Time
+}
+
+type transferTimeManager struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m       sync.Mutex
+	pending map[string]pendingTransfer
+	samples map[transferKey][]time.Duration
+}
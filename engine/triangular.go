@@ -0,0 +1,394 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errNoTradeAmount = errors.New("no trade amount is configured for the start currency")
+
+func (t *triangularArbDetector) Started() bool {
+	return atomic.LoadInt32(&t.started) == 1
+}
+
+func (t *triangularArbDetector) Start() error {
+	if atomic.AddInt32(&t.started, 1) != 1 {
+		return errors.New("triangular arbitrage detector already started")
+	}
+
+	log.Debugln(log.SyncMgr, "Triangular arbitrage detector starting...")
+	t.shutdown = make(chan struct{})
+	go t.run()
+	return nil
+}
+
+func (t *triangularArbDetector) Stop() error {
+	if atomic.AddInt32(&t.stopped, 1) != 1 {
+		return errors.New("triangular arbitrage detector is already stopped")
+	}
+
+	log.Debugln(log.SyncMgr, "Triangular arbitrage detector shutting down...")
+	close(t.shutdown)
+	return nil
+}
+
+func (t *triangularArbDetector) run() {
+	log.Debugln(log.SyncMgr, "Triangular arbitrage detector started.")
+	Bot.ServicesWG.Add(1)
+	tick := time.NewTicker(Bot.Config.TriangularArb.Interval)
+	defer func() {
+		atomic.CompareAndSwapInt32(&t.stopped, 1, 0)
+		atomic.CompareAndSwapInt32(&t.started, 1, 0)
+		tick.Stop()
+		Bot.ServicesWG.Done()
+		log.Debugln(log.SyncMgr, "Triangular arbitrage detector shutdown.")
+	}()
+
+	for {
+		select {
+		case <-t.shutdown:
+			return
+		case <-tick.C:
+			if Bot.ResourceMonitor.AnalyticsPaused() {
+				continue
+			}
+			t.scanAll()
+		}
+	}
+}
+
+// scanAll scans the spot markets of each configured exchange
+func (t *triangularArbDetector) scanAll() {
+	cfg := &Bot.Config.TriangularArb
+	exchanges := GetExchanges()
+	for x := range exchanges {
+		select {
+		case <-t.shutdown:
+			return
+		default:
+		}
+		if len(cfg.Exchanges) > 0 && !common.StringDataCompareInsensitive(cfg.Exchanges, exchanges[x].GetName()) {
+			continue
+		}
+		t.scan(exchanges[x])
+	}
+}
+
+// scan evaluates the cycles of an exchange from its stored tickers and
+// executes the cycles which have become opportunities if enabled
+func (t *triangularArbDetector) scan(exch exchange.IBotExchange) {
+	cfg := &Bot.Config.TriangularArb
+	pairs := exch.GetEnabledPairs(asset.Spot)
+	tickers := make([]ticker.Price, 0, len(pairs))
+	for x := range pairs {
+		tp, err := ticker.GetTicker(exch.GetName(), pairs[x], asset.Spot)
+		if err != nil {
+			continue
+		}
+		tickers = append(tickers, *tp)
+	}
+
+	var normalise func(currency.Code) currency.Code
+	if pegArbitrage() {
+		normalise = normalisePegged
+	}
+	cycles := findTriangularCycles(exch.GetName(),
+		asset.Spot,
+		tickers,
+		func(p currency.Pair) float64 { return t.fee(exch, p, cfg.DefaultFee) },
+		normalise,
+		cfg.StartCurrencies)
+
+	var detected []*TriangularCycle
+	now := clock.Now()
+	t.m.Lock()
+	if t.cycles == nil {
+		t.cycles = make(map[string]map[string]*TriangularCycle)
+	}
+	prev := t.cycles[strings.ToLower(exch.GetName())]
+	current := make(map[string]*TriangularCycle, len(cycles))
+	for x := range cycles {
+		c := &cycles[x]
+		c.LastChecked = now
+		c.Opportunity = c.Profit > cfg.MinProfit
+		key := c.key()
+		if p, ok := prev[key]; ok && p.Opportunity && c.Opportunity {
+			c.Detected, c.IntentID, c.Error = p.Detected, p.IntentID, p.Error
+		} else if c.Opportunity {
+			c.Detected = now
+			detected = append(detected, c)
+		}
+		current[key] = c
+	}
+	t.cycles[strings.ToLower(exch.GetName())] = current
+	t.m.Unlock()
+
+	for x := range detected {
+		msg := fmt.Sprintf("Triangular arbitrage: %s %s implies a %.4f%% profit",
+			detected[x].Exchange,
+			detected[x],
+			detected[x].Profit)
+		log.Infoln(log.SyncMgr, msg)
+		Bot.CommsManager.PushEvent(base.Event{
+			Type:    "arbitrage",
+			Message: msg,
+		})
+		if !cfg.Execute {
+			continue
+		}
+		intent, err := t.Execute(detected[x], 0)
+		t.m.Lock()
+		if err != nil {
+			detected[x].Error = err.Error()
+			log.Errorf(log.SyncMgr, "Triangular arbitrage: %s %s unable to execute: %v\n",
+				detected[x].Exchange,
+				detected[x],
+				err)
+		} else {
+			detected[x].IntentID = intent.ID
+		}
+		t.m.Unlock()
+	}
+}
+
+// fee returns the exchange's taker fee for a pair as a percentage, fees are
+// requested once and the default is used when the exchange is unable to
+// provide one
+func (t *triangularArbDetector) fee(exch exchange.IBotExchange, p currency.Pair, defaultFee float64) float64 {
+	key := strings.ToLower(exch.GetName() + ":" + p.String())
+	t.m.Lock()
+	f, ok := t.fees[key]
+	t.m.Unlock()
+	if ok {
+		return f
+	}
+
+	f = defaultFee
+	// the fee of a trade with a notional value of one is its rate
+	fee, err := exch.GetFeeByType(&exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		Pair:          p,
+		PurchasePrice: 1,
+		Amount:        1,
+	})
+	if err == nil && fee >= 0 && fee < 1 {
+		f = fee * 100
+	}
+	t.m.Lock()
+	if t.fees == nil {
+		t.fees = make(map[string]float64)
+	}
+	t.fees[key] = f
+	t.m.Unlock()
+	return f
+}
+
+// Execute submits the legs of a cycle as market orders through the order
+// manager as an intent, which reverses the legs already executed if a leg
+// fails. The amount of the start currency traded defaults to its configured
+// trade amount
+func (t *triangularArbDetector) Execute(c *TriangularCycle, amount float64) (*Intent, error) {
+	if len(c.Legs) == 0 {
+		return nil, errors.New("triangular arbitrage cycle has no legs")
+	}
+	if amount <= 0 {
+		amount = tradeAmount(Bot.Config.TriangularArb.TradeAmounts, c.Legs[0].From)
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("%s %v", c.Legs[0].From, errNoTradeAmount)
+	}
+
+	legs := make([]IntentLeg, len(c.Legs))
+	held := amount
+	for x := range c.Legs {
+		// orders are placed in the base currency of each pair
+		baseAmount := held
+		if c.Legs[x].Side == order.Buy {
+			baseAmount = held / c.Legs[x].Price
+		}
+		legs[x].Order = order.Submit{
+			Exchange:  c.Exchange,
+			Pair:      c.Legs[x].Pair,
+			AssetType: c.AssetType,
+			Side:      c.Legs[x].Side,
+			Type:      order.Market,
+			Amount:    baseAmount,
+			Price:     c.Legs[x].Price,
+		}
+		held *= c.Legs[x].Rate
+	}
+	return Bot.IntentManager.Submit(&Intent{
+		Description: "triangular arbitrage " + c.String(),
+		UnwindRule:  UnwindReverse,
+		Legs:        legs,
+	})
+}
+
+// GetCycles returns a copy of the cycles of the most recent scan sorted by
+// profit, optionally only those of an exchange or which are opportunities
+func (t *triangularArbDetector) GetCycles(exchName string, opportunities bool) []TriangularCycle {
+	t.m.Lock()
+	defer t.m.Unlock()
+	var cycles []TriangularCycle
+	for exch, v := range t.cycles {
+		if exchName != "" && !strings.EqualFold(exch, exchName) {
+			continue
+		}
+		for _, c := range v {
+			if opportunities && !c.Opportunity {
+				continue
+			}
+			cycles = append(cycles, *c)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].Profit > cycles[j].Profit
+	})
+	return cycles
+}
+
+// GetCycle returns a copy of a cycle of the most recent scan by its
+// exchange and currencies, such as BTC-ETH-USD or BTC-ETH-USD-BTC
+func (t *triangularArbDetector) GetCycle(exchName, currencies string) (*TriangularCycle, error) {
+	key := strings.ToUpper(currencies)
+	if codes := strings.Split(key, "-"); len(codes) > 1 && codes[0] == codes[len(codes)-1] {
+		key = strings.Join(codes[:len(codes)-1], "-")
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+	c, ok := t.cycles[strings.ToLower(exchName)][key]
+	if !ok {
+		return nil, fmt.Errorf("%s triangular arbitrage cycle %s not found", exchName, currencies)
+	}
+	cp := *c
+	return &cp, nil
+}
+
+// String returns the currencies of the cycle in order, such as BTC-ETH-USD-BTC
+func (c *TriangularCycle) String() string {
+	if len(c.Legs) == 0 {
+		return ""
+	}
+	return c.key() + "-" + c.Legs[0].From.Upper().String()
+}
+
+func (c *TriangularCycle) key() string {
+	codes := make([]string, len(c.Legs))
+	for x := range c.Legs {
+		codes[x] = c.Legs[x].From.Upper().String()
+	}
+	return strings.Join(codes, "-")
+}
+
+func tradeAmount(amounts map[string]float64, c currency.Code) float64 {
+	for k, v := range amounts {
+		if strings.EqualFold(k, c.String()) {
+			return v
+		}
+	}
+	return 0
+}
+
+// findTriangularCycles evaluates every cycle of three conversions between the
+// currencies of the tickers. Cycles start in each of the start currencies or,
+// when there are none, once from their first currency alphabetically.
+// Currencies are joined by their normalised codes when normalise is set, such
+// as pegged currencies by their anchor, while the legs retain the exchange
+// pairs and currencies
+func findTriangularCycles(exchName string, a asset.Item, tickers []ticker.Price, fee func(currency.Pair) float64, normalise func(currency.Code) currency.Code, startCurrencies []string) []TriangularCycle {
+	if normalise == nil {
+		normalise = func(c currency.Code) currency.Code { return c }
+	}
+	edges := make(map[string]map[string]TriangularLeg)
+	addEdge := func(l TriangularLeg) {
+		from, to := normalise(l.From).Upper().String(), normalise(l.To).Upper().String()
+		if edges[from] == nil {
+			edges[from] = make(map[string]TriangularLeg)
+		}
+		if existing, ok := edges[from][to]; ok && existing.Rate >= l.Rate {
+			return
+		}
+		edges[from][to] = l
+	}
+	for x := range tickers {
+		tp := &tickers[x]
+		if tp.Bid <= 0 || tp.Ask <= 0 || normalise(tp.Pair.Base).Match(normalise(tp.Pair.Quote)) {
+			continue
+		}
+		f := fee(tp.Pair)
+		// selling the base currency for the quote at the bid
+		addEdge(TriangularLeg{
+			Pair:  tp.Pair,
+			Side:  order.Sell,
+			From:  tp.Pair.Base,
+			To:    tp.Pair.Quote,
+			Price: tp.Bid,
+			Fee:   f,
+			Rate:  tp.Bid * (1 - f/100),
+		})
+		// buying the base currency with the quote at the ask
+		addEdge(TriangularLeg{
+			Pair:  tp.Pair,
+			Side:  order.Buy,
+			From:  tp.Pair.Quote,
+			To:    tp.Pair.Base,
+			Price: tp.Ask,
+			Fee:   f,
+			Rate:  1 / tp.Ask * (1 - f/100),
+		})
+	}
+
+	starts := make(map[string]bool, len(startCurrencies))
+	for x := range startCurrencies {
+		starts[normalise(currency.NewCode(startCurrencies[x])).Upper().String()] = true
+	}
+	codes := make([]string, 0, len(edges))
+	for k := range edges {
+		codes = append(codes, k)
+	}
+	sort.Strings(codes)
+
+	var cycles []TriangularCycle
+	for _, first := range codes {
+		if len(starts) > 0 && !starts[first] {
+			continue
+		}
+		for second, l1 := range edges[first] {
+			if len(starts) == 0 && second < first {
+				continue
+			}
+			for third, l2 := range edges[second] {
+				if third == first || (len(starts) == 0 && third < first) {
+					continue
+				}
+				l3, ok := edges[third][first]
+				if !ok {
+					continue
+				}
+				rate := l1.Rate * l2.Rate * l3.Rate
+				cycles = append(cycles, TriangularCycle{
+					Exchange:  exchName,
+					AssetType: a,
+					Legs:      []TriangularLeg{l1, l2, l3},
+					Rate:      rate,
+					Profit:    (rate - 1) * 100,
+				})
+			}
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// testTriangularTickers imply buying ETH with BTC, selling it for USD and
+// buying BTC back returns 3.69% before fees
+func testTriangularTickers() []ticker.Price {
+	return []ticker.Price{
+		{Pair: currency.NewPair(currency.BTC, currency.USD), Bid: 10000, Ask: 10010},
+		{Pair: currency.NewPair(currency.ETH, currency.BTC), Bid: 0.05, Ask: 0.0501},
+		{Pair: currency.NewPair(currency.ETH, currency.USD), Bid: 520, Ask: 521},
+		{Pair: currency.NewPair(currency.LTC, currency.USD)},
+	}
+}
+
+// fakeTriangularExchange has the pairs of the test tickers enabled
+type fakeTriangularExchange struct {
+	FakePassingExchange
+}
+
+func (f *fakeTriangularExchange) GetEnabledPairs(_ asset.Item) currency.Pairs {
+	tickers := testTriangularTickers()
+	pairs := make(currency.Pairs, len(tickers))
+	for x := range tickers {
+		pairs[x] = tickers[x].Pair
+	}
+	return pairs
+}
+
+func TestFindTriangularCycles(t *testing.T) {
+	t.Parallel()
+	noFee := func(currency.Pair) float64 { return 0 }
+	cycles := findTriangularCycles("test", asset.Spot, testTriangularTickers(), noFee, nil, nil)
+	if len(cycles) != 2 {
+		t.Fatalf("expected each direction of the cycle once, got %d cycles", len(cycles))
+	}
+	var profitable *TriangularCycle
+	for x := range cycles {
+		if cycles[x].Legs[0].From != currency.BTC {
+			t.Errorf("expected cycles to start from BTC, got %s", cycles[x].String())
+		}
+		if cycles[x].String() == "BTC-ETH-USD-BTC" {
+			profitable = &cycles[x]
+		}
+	}
+	if profitable == nil {
+		t.Fatal("expected the BTC-ETH-USD-BTC cycle")
+	}
+	expected := 1 / 0.0501 * 520 / 10010
+	if math.Abs(profitable.Rate-expected) > 1e-12 {
+		t.Errorf("expected a rate of %v, got %v", expected, profitable.Rate)
+	}
+	if profitable.Legs[0].Side != order.Buy || profitable.Legs[1].Side != order.Sell ||
+		profitable.Legs[2].Side != order.Buy {
+		t.Errorf("unexpected leg sides %+v", profitable.Legs)
+	}
+
+	fee := func(currency.Pair) float64 { return 0.1 }
+	cycles = findTriangularCycles("test", asset.Spot, testTriangularTickers(), fee, nil, []string{"usd"})
+	if len(cycles) != 2 {
+		t.Fatalf("expected each direction of the cycle from USD, got %d cycles", len(cycles))
+	}
+	for x := range cycles {
+		if cycles[x].String() != "USD-BTC-ETH-USD" {
+			continue
+		}
+		expected = 1 / 10010.0 / 0.0501 * 520 * math.Pow(0.999, 3)
+		if math.Abs(cycles[x].Rate-expected) > 1e-12 {
+			t.Errorf("expected a rate of %v after fees, got %v", expected, cycles[x].Rate)
+		}
+		return
+	}
+	t.Error("expected the USD-BTC-ETH-USD cycle")
+}
+
+func TestTriangularArbScan(t *testing.T) {
+	SetupTestHelpers(t)
+	cfg := Bot.Config.TriangularArb
+	defer func() { Bot.Config.TriangularArb = cfg }()
+	Bot.Config.TriangularArb.MinProfit = 1
+
+	exch := &fakeTriangularExchange{}
+	tickers := testTriangularTickers()
+	for x := range tickers {
+		tickers[x].ExchangeName = exch.GetName()
+		tickers[x].AssetType = asset.Spot
+		if err := ticker.ProcessTicker(exch.GetName(), &tickers[x], asset.Spot); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var d triangularArbDetector
+	d.scan(exch)
+	cycles := d.GetCycles(exch.GetName(), false)
+	if len(cycles) != 2 {
+		t.Fatalf("expected two cycles, got %d", len(cycles))
+	}
+	opportunities := d.GetCycles(strings.ToUpper(exch.GetName()), true)
+	if len(opportunities) != 1 || opportunities[0].String() != "BTC-ETH-USD-BTC" {
+		t.Fatalf("expected the BTC-ETH-USD-BTC opportunity, got %+v", opportunities)
+	}
+	detected := opportunities[0].Detected
+	if detected.IsZero() {
+		t.Error("expected the detection time to be set")
+	}
+
+	d.scan(exch)
+	c, err := d.GetCycle(exch.GetName(), "btc-eth-usd-btc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Detected.Equal(detected) {
+		t.Error("expected the detection time to be kept while the cycle remains an opportunity")
+	}
+	if _, err = d.GetCycle(exch.GetName(), "BTC-LTC-USD"); err == nil {
+		t.Error("expected an error for a cycle which was not found")
+	}
+}
+
+func TestFindTriangularCyclesPegged(t *testing.T) {
+	t.Parallel()
+	tickers := []ticker.Price{
+		{Pair: currency.NewPair(currency.BTC, currency.USD), Bid: 10000, Ask: 10010},
+		{Pair: currency.NewPair(currency.ETH, currency.BTC), Bid: 0.05, Ask: 0.0501},
+		{Pair: currency.NewPair(currency.ETH, currency.USDT), Bid: 520, Ask: 521},
+		{Pair: currency.NewPair(currency.USDT, currency.USD), Bid: 1, Ask: 1},
+	}
+	noFee := func(currency.Pair) float64 { return 0 }
+	if cycles := findTriangularCycles("test", asset.Spot, tickers, noFee, nil, []string{"BTC"}); len(cycles) != 0 {
+		t.Errorf("expected no three leg cycles without pegs, got %d", len(cycles))
+	}
+
+	normalise := func(c currency.Code) currency.Code {
+		if c.Match(currency.USDT) {
+			return currency.USD
+		}
+		return c
+	}
+	cycles := findTriangularCycles("test", asset.Spot, tickers, noFee, normalise, []string{"BTC"})
+	if len(cycles) != 2 {
+		t.Fatalf("expected each direction of the pegged cycle, got %d cycles", len(cycles))
+	}
+	for x := range cycles {
+		for _, l := range cycles[x].Legs {
+			if l.Pair.Base.Match(currency.USDT) {
+				t.Errorf("expected the USDT-USD pair not to be used as a leg, got %s", cycles[x].String())
+			}
+		}
+		if cycles[x].String() != "BTC-ETH-USD-BTC" {
+			continue
+		}
+		if !cycles[x].Legs[1].Pair.Equal(currency.NewPair(currency.ETH, currency.USDT)) {
+			t.Errorf("expected the exchange pair to be retained, got %s", cycles[x].Legs[1].Pair)
+		}
+		return
+	}
+	t.Error("expected the BTC-ETH-USD-BTC cycle")
+}
+
+func TestTriangularArbExecute(t *testing.T) {
+	OrdersSetup(t)
+	cycles := findTriangularCycles(fakePassExchange,
+		asset.Spot,
+		testTriangularTickers(),
+		func(currency.Pair) float64 { return 0 },
+		nil,
+		nil)
+	var c *TriangularCycle
+	for x := range cycles {
+		if cycles[x].String() == "BTC-ETH-USD-BTC" {
+			c = &cycles[x]
+		}
+	}
+	if c == nil {
+		t.Fatal("expected the BTC-ETH-USD-BTC cycle")
+	}
+
+	var d triangularArbDetector
+	if _, err := d.Execute(c, 0); err == nil {
+		t.Error("expected an error without a configured trade amount")
+	}
+
+	intent, err := d.Execute(c, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intent.Legs) != 3 || intent.UnwindRule != UnwindReverse {
+		t.Fatalf("unexpected intent %+v", intent)
+	}
+	// 1 BTC buys 19.96 ETH which sells for 10379 USD buying 1.0369 BTC
+	eth := 1 / 0.0501
+	if math.Abs(intent.Legs[0].Order.Amount-eth) > 1e-9 ||
+		math.Abs(intent.Legs[1].Order.Amount-eth) > 1e-9 ||
+		math.Abs(intent.Legs[2].Order.Amount-eth*520/10010) > 1e-9 {
+		t.Errorf("unexpected leg amounts %+v", intent.Legs)
+	}
+	for x := range intent.Legs {
+		if intent.Legs[x].Order.Type != order.Market {
+			t.Errorf("expected market orders, got %s", intent.Legs[x].Order.Type)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// TriangularLeg is a conversion between two currencies of a triangular
+// arbitrage cycle
+type TriangularLeg struct {
+	Pair currency.Pair
+	Side order.Side
+	From currency.Code
+	To   currency.Code
+	// Price is the best bid when selling and the best ask when buying
+	Price float64
+	// Fee is the taker fee as a percentage
+	Fee float64
+	// Rate is the amount of the To currency received for each unit of the
+	// From currency after fees
+	Rate float64
+}
+
+// TriangularCycle is a cycle of conversions which starts and ends in the same
+// currency on a single exchange
+type TriangularCycle struct {
+	Exchange  string
+	AssetType asset.Item
+	Legs      []TriangularLeg
+	// Rate is the amount of the start currency returned for each unit traded
+	// through the cycle after fees
+	Rate float64
+	// Profit is the implied profit of the cycle as a percentage
+	Profit float64
+	// Opportunity is whether the profit exceeds the configured minimum
+	Opportunity bool
+	// Detected is when the cycle most recently became an opportunity
+	Detected    time.Time
+	LastChecked time.Time
+	// IntentID is the intent the cycle was most recently executed as
+	IntentID string
+	Error    string
+}
+
+type triangularArbDetector struct {
+	started  int32
+	stopped  int32
+	shutdown chan struct{}
+
+	m      sync.Mutex
+	cycles map[string]map[string]*TriangularCycle
+	fees   map[string]float64
+}
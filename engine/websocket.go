@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/gorilla/websocket"
@@ -11,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -40,6 +42,8 @@ var wsHandlers = map[string]wsCommandHandler{
 	"getorderbook":     {authRequired: false, handler: wsGetOrderbook},
 	"getexchangerates": {authRequired: false, handler: wsGetExchangeRates},
 	"getportfolio":     {authRequired: true, handler: wsGetPortfolio},
+	"getorders":        {authRequired: true, handler: wsGetOrders},
+	"getlogs":          {authRequired: true, handler: wsGetLogs},
 }
 
 // NewWebsocketHub Creates a new websocket hub
@@ -49,6 +53,7 @@ func NewWebsocketHub() *WebsocketHub {
 		Register:   make(chan *WebsocketClient),
 		Unregister: make(chan *WebsocketClient),
 		Clients:    make(map[*WebsocketClient]bool),
+		notify:     make(chan WebsocketEvent),
 	}
 }
 
@@ -65,6 +70,9 @@ func (h *WebsocketHub) run() {
 			}
 		case message := <-h.Broadcast:
 			for client := range h.Clients {
+				if client.jsonRPC {
+					continue
+				}
 				select {
 				case client.Send <- message:
 				default:
@@ -73,6 +81,8 @@ func (h *WebsocketHub) run() {
 					delete(h.Clients, client)
 				}
 			}
+		case evt := <-h.notify:
+			h.notifyJSONRPCClients(evt)
 		}
 	}
 }
@@ -105,6 +115,11 @@ func (c *WebsocketClient) read() {
 		}
 
 		if msgType == websocket.TextMessage {
+			if c.jsonRPC {
+				c.handleJSONRPC(message)
+				continue
+			}
+
 			var evt WebsocketEvent
 			err := json.Unmarshal(message, &evt)
 			if err != nil {
@@ -203,12 +218,20 @@ func BroadcastWebsocketMessage(evt WebsocketEvent) error {
 	}
 
 	wsHub.Broadcast <- data
+	wsHub.notify <- evt
 	return nil
 }
 
 // WebsocketClientHandler upgrades the HTTP connection to a websocket
 // compatible one
 func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
+	connectWebsocketClient(w, r, false)
+}
+
+// connectWebsocketClient upgrades the HTTP connection and registers the
+// client with the websocket hub, clients speak either the event protocol or
+// JSON-RPC 2.0
+func connectWebsocketClient(w http.ResponseWriter, r *http.Request, jsonRPC bool) {
 	if !wsHubStarted {
 		StartWebsocketHandler()
 	}
@@ -241,7 +264,7 @@ func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	client := &WebsocketClient{Hub: wsHub, Conn: conn, Send: make(chan []byte, 1024)}
+	client := &WebsocketClient{Hub: wsHub, Conn: conn, Send: make(chan []byte, 1024), jsonRPC: jsonRPC}
 	client.Hub.Register <- client
 	log.Debugf(log.WebsocketMgr,
 		"websocket: client connected. Connected clients: %d. Limit %d.\n",
@@ -413,6 +436,42 @@ func wsGetPortfolio(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetPortfolio",
 	}
-	wsResp.Data = Bot.Portfolio.GetPortfolioSummary()
+	wsResp.Data = getPortfolioSummary(Bot.Portfolio, currency.Code{})
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsGetOrders(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetOrders",
+	}
+	wsResp.Data = openOrders()
 	return client.SendWebsocketMessage(wsResp)
 }
+
+func wsGetLogs(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetLogs",
+	}
+	wsResp.Data = log.Recent(0)
+	return client.SendWebsocketMessage(wsResp)
+}
+
+// openOrders returns the orders tracked by the order manager which are not
+// done, oldest first
+func openOrders() []order.Detail {
+	var orders []order.Detail
+	Bot.OrderManager.orderStore.m.RLock()
+	for _, v := range Bot.OrderManager.orderStore.Orders {
+		for x := range v {
+			if orderDone(v[x].Status) {
+				continue
+			}
+			orders = append(orders, *v[x])
+		}
+	}
+	Bot.OrderManager.orderStore.m.RUnlock()
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Date.Before(orders[j].Date)
+	})
+	return orders
+}
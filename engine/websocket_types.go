@@ -1,6 +1,11 @@
 package engine
 
-import "github.com/gorilla/websocket"
+import (
+	"context"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
 
 // WebsocketClient stores information related to the websocket client
 type WebsocketClient struct {
@@ -9,6 +14,14 @@ type WebsocketClient struct {
 	Authenticated bool
 	authFailures  int
 	Send          chan []byte
+
+	// jsonRPC is set for clients connected to the JSON-RPC 2.0 endpoint
+	jsonRPC bool
+	m       sync.Mutex
+	// ctx is the context RPC methods are called with once authenticated,
+	// carrying the client's tenant
+	ctx           context.Context
+	subscriptions map[string]bool
 }
 
 // WebsocketHub stores the data for managing websocket clients
@@ -17,6 +30,7 @@ type WebsocketHub struct {
 	Broadcast  chan []byte
 	Register   chan *WebsocketClient
 	Unregister chan *WebsocketClient
+	notify     chan WebsocketEvent
 }
 
 // WebsocketEvent is the struct used for websocket events
@@ -0,0 +1,305 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// vars for the what-if simulator
+var (
+	errNoWhatIfOrders    = errors.New("at least one order must be specified")
+	errNoBookLiquidity   = errors.New("orderbook has no liquidity on the opposing side")
+	errWhatIfUnpriceable = errors.New("unable to value order in the valuation currency")
+)
+
+// SimulatePortfolioImpact simulates executing orders against the live
+// orderbooks and returns the resulting exposure, margin usage and risk limit
+// utilisation using the current holdings. Multiple orders can be supplied to
+// model a strategy change. Nothing is submitted to the exchanges
+func SimulatePortfolioImpact(orders []order.Submit) (*WhatIfResult, error) {
+	if len(orders) == 0 {
+		return nil, errNoWhatIfOrders
+	}
+	limits := Bot.Config.RiskLimits
+	if limits.Currency.IsEmpty() {
+		limits.Currency = Bot.Config.Currency.FiatDisplayCurrency
+	}
+
+	holdings := make(map[currency.Code]float64)
+	if Bot.Portfolio != nil {
+		holdings = Bot.Portfolio.GetExchangePortfolio()
+	}
+	result := &WhatIfResult{Currency: limits.Currency}
+	var warnings []string
+	result.Before, warnings = riskExposure(holdings, limits.Currency)
+	result.Warnings = append(result.Warnings, warnings...)
+
+	exchHoldings := make(map[string]map[currency.Code]float64)
+	var maxOrderValue float64
+	for i := range orders {
+		s := &orders[i]
+		if s.AssetType == "" {
+			s.AssetType = asset.Spot
+		}
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+		exch := GetExchangeByName(s.Exchange)
+		if exch == nil {
+			return nil, ErrExchangeNotFound
+		}
+		ob, err := exch.FetchOrderbook(s.Pair, s.AssetType)
+		if err != nil {
+			return nil, err
+		}
+		levels := ob.Bids
+		if isBuySide(s.Side) {
+			levels = ob.Asks
+		}
+		fill, err := simulateFill(levels, s)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s %s: %v", s.Exchange, s.Pair, s.AssetType, err)
+		}
+		if fill.BookAmount < fill.Amount && s.Type == order.Market {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"%s %s orderbook can only fill %v of %v, the remainder is valued at the worst price",
+				s.Exchange, s.Pair, fill.BookAmount, fill.Amount))
+		}
+
+		cost := fill.Amount * fill.AveragePrice
+		value, ok := convertValue(cost, s.Pair.Quote, limits.Currency)
+		if !ok {
+			return nil, fmt.Errorf("%s %s: %v %s", s.Exchange, s.Pair, errWhatIfUnpriceable, limits.Currency)
+		}
+		fill.Value = value
+		maxOrderValue = math.Max(maxOrderValue, value)
+		result.Fills = append(result.Fills, fill)
+
+		balances, ok := exchHoldings[s.Exchange]
+		if !ok {
+			balances = make(map[currency.Code]float64)
+			if Bot.Portfolio != nil {
+				balances = Bot.Portfolio.GetPortfolioByExchange(s.Exchange)
+			}
+			exchHoldings[s.Exchange] = balances
+		}
+		spend, spendAmount := s.Pair.Quote, cost
+		receive, receiveAmount := s.Pair.Base, fill.Amount
+		if !isBuySide(s.Side) {
+			spend, spendAmount = s.Pair.Base, fill.Amount
+			receive, receiveAmount = s.Pair.Quote, cost
+		}
+		holdings[spend] -= spendAmount
+		holdings[receive] += receiveAmount
+		balances[spend] -= spendAmount
+		balances[receive] += receiveAmount
+		if s.AssetType == asset.Spot && balances[spend] < 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"%s has insufficient %s balance for the spot order, short by %v",
+				s.Exchange, spend, -balances[spend]))
+		}
+	}
+
+	result.After, warnings = riskExposure(holdings, limits.Currency)
+	result.Warnings = append(result.Warnings, warnings...)
+	result.Limits = riskLimitUtilisation(&limits, &result.Before, &result.After, maxOrderValue)
+	return result, nil
+}
+
+// PreviewOrder simulates executing an order against the live orderbook and
+// estimates its fee so it can be reviewed before submission. Nothing is
+// submitted to the exchange
+func PreviewOrder(s *order.Submit) (*OrderPreview, error) {
+	if s.AssetType == "" {
+		s.AssetType = asset.Spot
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	exch := GetExchangeByName(s.Exchange)
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	ob, err := exch.FetchOrderbook(s.Pair, s.AssetType)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &OrderPreview{}
+	if len(ob.Bids) > 0 {
+		preview.BestBid, preview.BestBidAmount = ob.Bids[0].Price, ob.Bids[0].Amount
+	}
+	if len(ob.Asks) > 0 {
+		preview.BestAsk, preview.BestAskAmount = ob.Asks[0].Price, ob.Asks[0].Amount
+	}
+	buy := isBuySide(s.Side)
+	levels := ob.Bids
+	if buy {
+		levels = ob.Asks
+	}
+	preview.Fill, err = simulateFill(levels, s)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s %s: %v", s.Exchange, s.Pair, s.AssetType, err)
+	}
+	if preview.Fill.BookAmount < preview.Fill.Amount {
+		if s.Type == order.Limit {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf(
+				"%v of %v rests on the orderbook at %v",
+				preview.Fill.Amount-preview.Fill.BookAmount, preview.Fill.Amount, s.Price))
+		} else {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf(
+				"orderbook can only fill %v of %v, the remainder is valued at the worst price",
+				preview.Fill.BookAmount, preview.Fill.Amount))
+		}
+	}
+
+	// limit orders which do not cross the book are charged maker fees
+	preview.IsMaker = s.Type == order.Limit && preview.Fill.BookAmount == 0
+	preview.Fee, err = exch.GetFeeByType(&exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		Pair:          s.Pair,
+		IsMaker:       preview.IsMaker,
+		PurchasePrice: preview.Fill.AveragePrice,
+		Amount:        preview.Fill.Amount,
+	})
+	if err != nil {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("unable to estimate fee: %v", err))
+	}
+
+	preview.Total = preview.Fill.Amount*preview.Fill.AveragePrice - preview.Fee
+	if buy {
+		preview.Total = preview.Fill.Amount*preview.Fill.AveragePrice + preview.Fee
+	}
+	return preview, nil
+}
+
+// simulateFill walks the opposing side of the orderbook to fill the order.
+// Limit orders only take levels at or better than their price and the
+// remainder is assumed to rest and fill at the limit price
+func simulateFill(levels []orderbook.Item, s *order.Submit) (WhatIfFill, error) {
+	fill := WhatIfFill{
+		Exchange:  s.Exchange,
+		Pair:      s.Pair,
+		AssetType: s.AssetType,
+		Side:      s.Side,
+		Amount:    s.Amount,
+	}
+	buy := isBuySide(s.Side)
+	if len(levels) == 0 && s.Type != order.Limit {
+		return fill, errNoBookLiquidity
+	}
+
+	var cost, worst float64
+	remaining := s.Amount
+	for x := range levels {
+		if remaining <= 0 {
+			break
+		}
+		if s.Type == order.Limit &&
+			((buy && levels[x].Price > s.Price) || (!buy && levels[x].Price < s.Price)) {
+			break
+		}
+		take := math.Min(remaining, levels[x].Amount)
+		cost += take * levels[x].Price
+		remaining -= take
+		worst = levels[x].Price
+	}
+	fill.BookAmount = s.Amount - remaining
+	if remaining > 0 {
+		price := s.Price
+		if s.Type != order.Limit {
+			price = worst
+		}
+		cost += remaining * price
+	}
+	fill.AveragePrice = cost / s.Amount
+	if len(levels) > 0 && levels[0].Price > 0 {
+		fill.Slippage = math.Abs(fill.AveragePrice-levels[0].Price) / levels[0].Price * 100
+	}
+	return fill, nil
+}
+
+// riskExposure values holdings in the target currency. Currencies which
+// cannot be valued are excluded and returned as warnings
+func riskExposure(holdings map[currency.Code]float64, target currency.Code) (RiskExposure, []string) {
+	var r RiskExposure
+	var warnings []string
+	for code, amount := range holdings {
+		if amount == 0 {
+			continue
+		}
+		value, ok := convertValue(amount, code, target)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unable to value %v %s in %s, excluding", amount, code, target))
+			continue
+		}
+		h := HoldingExposure{Currency: code, Amount: amount, Value: value}
+		r.Holdings = append(r.Holdings, h)
+		r.Equity += value
+		if value < 0 {
+			r.MarginUsed -= value
+		}
+		if code.Match(target) {
+			continue
+		}
+		r.GrossExposure += math.Abs(value)
+		r.NetExposure += value
+		if math.Abs(value) > math.Abs(r.LargestPosition.Value) {
+			r.LargestPosition = h
+		}
+	}
+	sort.Slice(r.Holdings, func(i, j int) bool {
+		return r.Holdings[i].Currency.String() < r.Holdings[j].Currency.String()
+	})
+	sort.Strings(warnings)
+	if r.Equity > 0 {
+		r.MarginUsage = r.MarginUsed / r.Equity * 100
+		r.Leverage = r.GrossExposure / r.Equity
+	}
+	return r, warnings
+}
+
+// riskLimitUtilisation returns the utilisation of each enabled risk limit
+// before and after the simulated orders
+func riskLimitUtilisation(limits *config.RiskLimitsConfig, before, after *RiskExposure, maxOrderValue float64) []RiskLimitUtilisation {
+	var resp []RiskLimitUtilisation
+	add := func(name string, limit, b, a float64, breached bool) {
+		if limit <= 0 {
+			return
+		}
+		resp = append(resp, RiskLimitUtilisation{
+			Limit:    name,
+			Value:    limit,
+			Before:   b / limit * 100,
+			After:    a / limit * 100,
+			Breached: breached || a > limit,
+		})
+	}
+	add(RiskLimitOrderValue, limits.MaxOrderValue, 0, maxOrderValue, false)
+	add(RiskLimitPositionValue,
+		limits.MaxPositionValue,
+		math.Abs(before.LargestPosition.Value),
+		math.Abs(after.LargestPosition.Value),
+		false)
+	add(RiskLimitGrossExposure,
+		limits.MaxGrossExposure,
+		before.GrossExposure,
+		after.GrossExposure,
+		false)
+	// leverage is unbounded once equity has been exhausted
+	add(RiskLimitLeverage,
+		limits.MaxLeverage,
+		before.Leverage,
+		after.Leverage,
+		after.Equity <= 0 && after.GrossExposure > 0)
+	return resp
+}
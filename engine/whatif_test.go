@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+func TestSimulateFill(t *testing.T) {
+	asks := []orderbook.Item{{Price: 100, Amount: 1}, {Price: 102, Amount: 1}}
+	s := order.Submit{Side: order.Buy, Type: order.Market, Amount: 2}
+	fill, err := simulateFill(asks, &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fill.BookAmount != 2 || fill.AveragePrice != 101 || fill.Slippage != 1 {
+		t.Errorf("unexpected market fill %+v", fill)
+	}
+
+	// the limit order only takes the first level and rests for the remainder
+	s = order.Submit{Side: order.Buy, Type: order.Limit, Amount: 2, Price: 101}
+	fill, err = simulateFill(asks, &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fill.BookAmount != 1 || fill.AveragePrice != 100.5 {
+		t.Errorf("unexpected limit fill %+v", fill)
+	}
+
+	// market orders exceeding the book are valued at the worst price
+	s = order.Submit{Side: order.Buy, Type: order.Market, Amount: 3}
+	fill, err = simulateFill(asks, &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fill.BookAmount != 2 || fill.AveragePrice != (100+102+102)/3.0 {
+		t.Errorf("unexpected fill exceeding the book %+v", fill)
+	}
+
+	s = order.Submit{Side: order.Sell, Type: order.Market, Amount: 1}
+	if _, err = simulateFill(nil, &s); err != errNoBookLiquidity {
+		t.Errorf("expected %v, got %v", errNoBookLiquidity, err)
+	}
+}
+
+func TestRiskLimitUtilisation(t *testing.T) {
+	limits := config.RiskLimitsConfig{
+		MaxOrderValue:    100,
+		MaxGrossExposure: 1000,
+		MaxLeverage:      2,
+	}
+	before := RiskExposure{Equity: 500, GrossExposure: 250, Leverage: 0.5}
+	after := RiskExposure{Equity: 0, GrossExposure: 500}
+	resp := riskLimitUtilisation(&limits, &before, &after, 50)
+	if len(resp) != 3 {
+		t.Fatalf("expected only enabled limits, got %+v", resp)
+	}
+	if resp[0].Limit != RiskLimitOrderValue || resp[0].After != 50 || resp[0].Breached {
+		t.Errorf("unexpected order value utilisation %+v", resp[0])
+	}
+	if resp[1].Limit != RiskLimitGrossExposure || resp[1].Before != 25 || resp[1].After != 50 {
+		t.Errorf("unexpected gross exposure utilisation %+v", resp[1])
+	}
+	if resp[2].Limit != RiskLimitLeverage || !resp[2].Breached {
+		t.Errorf("expected exhausted equity to breach leverage, got %+v", resp[2])
+	}
+}
+
+func TestSimulatePortfolioImpact(t *testing.T) {
+	SetupTestHelpers(t)
+	if _, err := SimulatePortfolioImpact(nil); err != errNoWhatIfOrders {
+		t.Errorf("expected %v, got %v", errNoWhatIfOrders, err)
+	}
+
+	p := currency.NewPair(currency.LTC, currency.USD)
+	err := ticker.ProcessTicker(testExchange, &ticker.Price{Pair: p, Last: 50}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ob := orderbook.Base{
+		ExchangeName: testExchange,
+		Pair:         p,
+		AssetType:    asset.Spot,
+		Bids:         []orderbook.Item{{Price: 49, Amount: 5}},
+		Asks:         []orderbook.Item{{Price: 51, Amount: 1}, {Price: 52, Amount: 2}},
+	}
+	if err = ob.Process(); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPortfolio := Bot.Portfolio
+	oldLimits := Bot.Config.RiskLimits
+	defer func() {
+		Bot.Portfolio = oldPortfolio
+		Bot.Config.RiskLimits = oldLimits
+	}()
+	Bot.Portfolio = &portfolio.Base{}
+	Bot.Portfolio.AddExchangeAddress(testExchange, currency.LTC, 2)
+	Bot.Portfolio.AddExchangeAddress(testExchange, currency.USD, 100)
+	Bot.Config.RiskLimits = config.RiskLimitsConfig{
+		Currency:      currency.USD,
+		MaxOrderValue: 100,
+	}
+
+	resp, err := SimulatePortfolioImpact([]order.Submit{{
+		Exchange: testExchange,
+		Pair:     p,
+		Side:     order.Buy,
+		Type:     order.Market,
+		Amount:   2,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Fills) != 1 || resp.Fills[0].AveragePrice != 51.5 || resp.Fills[0].Value != 103 {
+		t.Fatalf("unexpected fills %+v", resp.Fills)
+	}
+	if resp.Before.Equity != 200 || resp.Before.GrossExposure != 100 || resp.Before.Leverage != 0.5 {
+		t.Errorf("unexpected exposure before %+v", resp.Before)
+	}
+	if resp.After.Equity != 197 ||
+		resp.After.GrossExposure != 200 ||
+		resp.After.MarginUsed != 3 ||
+		!resp.After.LargestPosition.Currency.Match(currency.LTC) ||
+		math.Abs(resp.After.Leverage-200.0/197) > 1e-9 {
+		t.Errorf("unexpected exposure after %+v", resp.After)
+	}
+	if len(resp.Limits) != 1 || !resp.Limits[0].Breached || resp.Limits[0].After != 103 {
+		t.Errorf("expected order value limit to be breached, got %+v", resp.Limits)
+	}
+	if len(resp.Warnings) != 1 {
+		t.Errorf("expected insufficient balance warning, got %v", resp.Warnings)
+	}
+	if h := Bot.Portfolio.GetExchangePortfolio(); h[currency.LTC] != 2 || h[currency.USD] != 100 {
+		t.Errorf("expected holdings to be unchanged, got %v", h)
+	}
+}
+
+func TestPreviewOrder(t *testing.T) {
+	SetupTestHelpers(t)
+	p := currency.NewPair(currency.XRP, currency.USD)
+	ob := orderbook.Base{
+		ExchangeName: testExchange,
+		Pair:         p,
+		AssetType:    asset.Spot,
+		Bids:         []orderbook.Item{{Price: 49, Amount: 5}},
+		Asks:         []orderbook.Item{{Price: 51, Amount: 1}, {Price: 52, Amount: 2}},
+	}
+	if err := ob.Process(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := PreviewOrder(&order.Submit{
+		Exchange: "nope",
+		Pair:     p,
+		Side:     order.Buy,
+		Type:     order.Market,
+		Amount:   2,
+	}); err != ErrExchangeNotFound {
+		t.Errorf("expected %v, got %v", ErrExchangeNotFound, err)
+	}
+
+	resp, err := PreviewOrder(&order.Submit{
+		Exchange: testExchange,
+		Pair:     p,
+		Side:     order.Buy,
+		Type:     order.Market,
+		Amount:   2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.BestBid != 49 || resp.BestAsk != 51 || resp.BestAskAmount != 1 {
+		t.Errorf("unexpected top of book %+v", resp)
+	}
+	if resp.Fill.AveragePrice != 51.5 || resp.IsMaker || len(resp.Warnings) != 0 {
+		t.Errorf("unexpected fill %+v", resp)
+	}
+	// the offline taker fee is 0.25%
+	if math.Abs(resp.Fee-0.2575) > 1e-9 || math.Abs(resp.Total-103.2575) > 1e-9 {
+		t.Errorf("unexpected fee %v and total %v", resp.Fee, resp.Total)
+	}
+
+	resp, err = PreviewOrder(&order.Submit{
+		Exchange: testExchange,
+		Pair:     p,
+		Side:     order.Sell,
+		Type:     order.Limit,
+		Amount:   1,
+		Price:    50,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.IsMaker || len(resp.Warnings) != 1 || math.Abs(resp.Total-49.875) > 1e-9 {
+		t.Errorf("expected a resting limit order to be a maker, got %+v", resp)
+	}
+}
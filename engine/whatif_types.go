@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Risk limit names
+const (
+	RiskLimitOrderValue    = "maxOrderValue"
+	RiskLimitPositionValue = "maxPositionValue"
+	RiskLimitGrossExposure = "maxGrossExposure"
+	RiskLimitLeverage      = "maxLeverage"
+)
+
+// WhatIfFill is the simulated execution of a hypothetical order against the
+// live orderbook
+type WhatIfFill struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Side      order.Side
+	Amount    float64
+	// BookAmount is the amount the current orderbook can fill, the remainder
+	// of a limit order is assumed to fill at its price
+	BookAmount   float64
+	AveragePrice float64
+	// Slippage is the percentage difference between the average price and
+	// the best price on the orderbook
+	Slippage float64
+	// Value is the order's value in the valuation currency
+	Value float64
+}
+
+// HoldingExposure is the amount and value of a currency held
+type HoldingExposure struct {
+	Currency currency.Code
+	Amount   float64
+	Value    float64
+}
+
+// RiskExposure is the portfolio's exposure valued in a single currency. A
+// negative holding is treated as borrowed on margin
+type RiskExposure struct {
+	Holdings []HoldingExposure
+	Equity   float64
+	// GrossExposure is the sum of the absolute values held in currencies
+	// other than the valuation currency
+	GrossExposure float64
+	// NetExposure is the sum of the values held in currencies other than the
+	// valuation currency
+	NetExposure     float64
+	LargestPosition HoldingExposure
+	MarginUsed      float64
+	// MarginUsage is the margin used as a percentage of equity
+	MarginUsage float64
+	Leverage    float64
+}
+
+// RiskLimitUtilisation is how much of a configured risk limit is used before
+// and after the simulated orders, as a percentage of the limit
+type RiskLimitUtilisation struct {
+	Limit    string
+	Value    float64
+	Before   float64
+	After    float64
+	Breached bool
+}
+
+// WhatIfResult is the simulated impact of hypothetical orders on the
+// portfolio
+type WhatIfResult struct {
+	Currency currency.Code
+	Fills    []WhatIfFill
+	Before   RiskExposure
+	After    RiskExposure
+	Limits   []RiskLimitUtilisation
+	// Warnings lists anything which would prevent the orders executing as
+	// simulated or currencies which could not be valued
+	Warnings []string
+}
+
+// OrderPreview is the top of book, simulated fill and estimated fee of an
+// order before it is submitted
+type OrderPreview struct {
+	BestBid       float64
+	BestBidAmount float64
+	BestAsk       float64
+	BestAskAmount float64
+	Fill          WhatIfFill
+	// Fee is the exchange's estimated fee for the order in the quote currency
+	Fee     float64
+	IsMaker bool
+	// Total is the cost of a buy including the fee or the proceeds of a sell
+	// net of the fee in the quote currency
+	Total    float64
+	Warnings []string
+}
@@ -38,6 +38,11 @@ func SubmitWithdrawal(exchName string, req *withdraw.Request) (*withdraw.Respons
 		return nil, err
 	}
 
+	err = Bot.KeyValidator.CheckWithdraw(req.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
 	exch := GetExchangeByName(exchName)
 	if exch == nil {
 		return nil, ErrExchangeNotFound
@@ -79,6 +84,10 @@ func SubmitWithdrawal(exchName string, req *withdraw.Request) (*withdraw.Respons
 	}
 	if err == nil {
 		withdraw.Cache.Add(resp.ID, resp)
+		if !Bot.Settings.EnableDryRun {
+			Bot.TransferTimeManager.TrackWithdrawal(exchName, req.Currency.String(), resp.Exchange.ID)
+			Bot.KeyMonitor.RecordWithdrawal(exchName, resp.Exchange.ID)
+		}
 	}
 	return resp, nil
 }
@@ -0,0 +1,323 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var errNilEvent = errors.New("event is nil")
+
+// jsonEncoder encodes events as JSON
+type jsonEncoder struct{}
+
+// Encode encodes an event as JSON
+func (jsonEncoder) Encode(e *Event) ([]byte, error) {
+	if e == nil {
+		return nil, errNilEvent
+	}
+	return json.Marshal(e)
+}
+
+// msgpackEncoder encodes events as MessagePack maps keyed by the same names
+// as their JSON encoding. Timestamps are encoded as unix nanoseconds
+type msgpackEncoder struct{}
+
+// Encode encodes an event as MessagePack
+func (msgpackEncoder) Encode(e *Event) ([]byte, error) {
+	if e == nil {
+		return nil, errNilEvent
+	}
+	var w msgpackWriter
+	fields := 2
+	if e.Exchange != "" {
+		fields++
+	}
+	if e.Ticker != nil || e.Trade != nil || e.Orderbook != nil || e.Order != nil || e.Alert != nil {
+		fields++
+	}
+	w.mapHeader(fields)
+	w.str("type")
+	w.str(e.Type)
+	if e.Exchange != "" {
+		w.str("exchange")
+		w.str(e.Exchange)
+	}
+	w.str("timestamp")
+	w.int64(e.Timestamp.UnixNano())
+	switch {
+	case e.Ticker != nil:
+		w.str("ticker")
+		w.mapHeader(8)
+		w.str("pair")
+		w.str(e.Ticker.Pair)
+		w.str("asset")
+		w.str(e.Ticker.Asset)
+		w.str("last")
+		w.float64(e.Ticker.Last)
+		w.str("bid")
+		w.float64(e.Ticker.Bid)
+		w.str("ask")
+		w.float64(e.Ticker.Ask)
+		w.str("high")
+		w.float64(e.Ticker.High)
+		w.str("low")
+		w.float64(e.Ticker.Low)
+		w.str("volume")
+		w.float64(e.Ticker.Volume)
+	case e.Trade != nil:
+		w.str("trade")
+		w.mapHeader(6)
+		w.str("id")
+		w.str(e.Trade.ID)
+		w.str("pair")
+		w.str(e.Trade.Pair)
+		w.str("asset")
+		w.str(e.Trade.Asset)
+		w.str("side")
+		w.str(e.Trade.Side)
+		w.str("price")
+		w.float64(e.Trade.Price)
+		w.str("amount")
+		w.float64(e.Trade.Amount)
+	case e.Orderbook != nil:
+		w.str("orderbook")
+		w.mapHeader(4)
+		w.str("pair")
+		w.str(e.Orderbook.Pair)
+		w.str("asset")
+		w.str(e.Orderbook.Asset)
+		w.str("bids")
+		w.levels(e.Orderbook.Bids)
+		w.str("asks")
+		w.levels(e.Orderbook.Asks)
+	case e.Order != nil:
+		w.str("order")
+		w.mapHeader(10)
+		w.str("id")
+		w.str(e.Order.ID)
+		w.str("clientId")
+		w.str(e.Order.ClientID)
+		w.str("pair")
+		w.str(e.Order.Pair)
+		w.str("asset")
+		w.str(e.Order.Asset)
+		w.str("side")
+		w.str(e.Order.Side)
+		w.str("orderType")
+		w.str(e.Order.Type)
+		w.str("status")
+		w.str(e.Order.Status)
+		w.str("price")
+		w.float64(e.Order.Price)
+		w.str("amount")
+		w.float64(e.Order.Amount)
+		w.str("executedAmount")
+		w.float64(e.Order.ExecutedAmount)
+	case e.Alert != nil:
+		w.str("alert")
+		w.mapHeader(2)
+		w.str("alertType")
+		w.str(e.Alert.Type)
+		w.str("message")
+		w.str(e.Alert.Message)
+	}
+	return w.buf, nil
+}
+
+// msgpackWriter writes the subset of MessagePack used to encode events
+type msgpackWriter struct {
+	buf []byte
+}
+
+func (w *msgpackWriter) mapHeader(n int) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		w.buf = append(w.buf, 0xde, byte(n>>8), byte(n))
+	default:
+		w.buf = append(w.buf, 0xdf, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func (w *msgpackWriter) arrayHeader(n int) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		w.buf = append(w.buf, 0xdc, byte(n>>8), byte(n))
+	default:
+		w.buf = append(w.buf, 0xdd, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+// levels writes orderbook levels as an array of price and amount maps
+func (w *msgpackWriter) levels(l []Level) {
+	w.arrayHeader(len(l))
+	for i := range l {
+		w.mapHeader(2)
+		w.str("price")
+		w.float64(l[i].Price)
+		w.str("amount")
+		w.float64(l[i].Amount)
+	}
+}
+
+func (w *msgpackWriter) str(s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		w.buf = append(w.buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = append(w.buf, 0xda, byte(n>>8), byte(n))
+	default:
+		w.buf = append(w.buf, 0xdb, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	w.buf = append(w.buf, s...)
+}
+
+func (w *msgpackWriter) int64(v int64) {
+	w.buf = append(w.buf, 0xd3)
+	w.uint64(uint64(v))
+}
+
+func (w *msgpackWriter) float64(v float64) {
+	w.buf = append(w.buf, 0xcb)
+	w.uint64(math.Float64bits(v))
+}
+
+func (w *msgpackWriter) uint64(v uint64) {
+	w.buf = append(w.buf,
+		byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// Protobuf wire types used by the event schema
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// protobufEncoder encodes events as the Event message defined in event.proto
+type protobufEncoder struct{}
+
+// Encode encodes an event as protobuf
+func (protobufEncoder) Encode(e *Event) ([]byte, error) {
+	if e == nil {
+		return nil, errNilEvent
+	}
+	var w protobufWriter
+	w.str(1, e.Type)
+	w.str(2, e.Exchange)
+	if !e.Timestamp.IsZero() {
+		w.varint(3, uint64(e.Timestamp.UnixNano()))
+	}
+	var m protobufWriter
+	switch {
+	case e.Ticker != nil:
+		m.str(1, e.Ticker.Pair)
+		m.str(2, e.Ticker.Asset)
+		m.double(3, e.Ticker.Last)
+		m.double(4, e.Ticker.Bid)
+		m.double(5, e.Ticker.Ask)
+		m.double(6, e.Ticker.High)
+		m.double(7, e.Ticker.Low)
+		m.double(8, e.Ticker.Volume)
+		w.message(4, &m)
+	case e.Trade != nil:
+		m.str(1, e.Trade.ID)
+		m.str(2, e.Trade.Pair)
+		m.str(3, e.Trade.Asset)
+		m.str(4, e.Trade.Side)
+		m.double(5, e.Trade.Price)
+		m.double(6, e.Trade.Amount)
+		w.message(5, &m)
+	case e.Orderbook != nil:
+		m.str(1, e.Orderbook.Pair)
+		m.str(2, e.Orderbook.Asset)
+		m.levels(3, e.Orderbook.Bids)
+		m.levels(4, e.Orderbook.Asks)
+		w.message(8, &m)
+	case e.Order != nil:
+		m.str(1, e.Order.ID)
+		m.str(2, e.Order.ClientID)
+		m.str(3, e.Order.Pair)
+		m.str(4, e.Order.Asset)
+		m.str(5, e.Order.Side)
+		m.str(6, e.Order.Type)
+		m.str(7, e.Order.Status)
+		m.double(8, e.Order.Price)
+		m.double(9, e.Order.Amount)
+		m.double(10, e.Order.ExecutedAmount)
+		w.message(6, &m)
+	case e.Alert != nil:
+		m.str(1, e.Alert.Type)
+		m.str(2, e.Alert.Message)
+		w.message(7, &m)
+	}
+	return w.Bytes(), w.err
+}
+
+// protobufWriter writes proto3 fields, omitting those with default values
+type protobufWriter struct {
+	proto.Buffer
+	err error
+}
+
+func (w *protobufWriter) tag(field, wireType uint64) {
+	w.setErr(w.EncodeVarint(field<<3 | wireType))
+}
+
+func (w *protobufWriter) str(field uint64, s string) {
+	if s == "" {
+		return
+	}
+	w.tag(field, wireBytes)
+	w.setErr(w.EncodeStringBytes(s))
+}
+
+func (w *protobufWriter) varint(field, v uint64) {
+	if v == 0 {
+		return
+	}
+	w.tag(field, wireVarint)
+	w.setErr(w.EncodeVarint(v))
+}
+
+func (w *protobufWriter) double(field uint64, v float64) {
+	if v == 0 {
+		return
+	}
+	w.tag(field, wireFixed64)
+	w.setErr(w.EncodeFixed64(math.Float64bits(v)))
+}
+
+// levels writes orderbook levels as repeated Level messages
+func (w *protobufWriter) levels(field uint64, l []Level) {
+	for i := range l {
+		var m protobufWriter
+		m.double(1, l[i].Price)
+		m.double(2, l[i].Amount)
+		w.message(field, &m)
+	}
+}
+
+// message writes an embedded message, which is written even when empty so
+// that the event's payload type is always set
+func (w *protobufWriter) message(field uint64, m *protobufWriter) {
+	w.setErr(m.err)
+	w.tag(field, wireBytes)
+	w.setErr(w.EncodeRawBytes(m.Bytes()))
+}
+
+func (w *protobufWriter) setErr(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
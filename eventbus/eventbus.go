@@ -0,0 +1,197 @@
+package eventbus
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	registryMtx sync.RWMutex
+	encoders    = map[string]Encoder{
+		JSONEncoding:     jsonEncoder{},
+		MsgpackEncoding:  msgpackEncoder{},
+		ProtobufEncoding: protobufEncoder{},
+	}
+	sinks = map[string]SinkFactory{
+		NATSSink:  newNATSSink,
+		KafkaSink: newKafkaSink,
+		TCPSink:   newTCPSink,
+	}
+)
+
+// RegisterEncoder registers an encoding which outputs can select by name
+func RegisterEncoder(name string, e Encoder) {
+	registryMtx.Lock()
+	encoders[strings.ToLower(name)] = e
+	registryMtx.Unlock()
+}
+
+// RegisterSink registers a sink which outputs can select by name, allowing
+// transports such as Kafka or ZeroMQ to be plugged in
+func RegisterSink(name string, f SinkFactory) {
+	registryMtx.Lock()
+	sinks[strings.ToLower(name)] = f
+	registryMtx.Unlock()
+}
+
+// New returns a bus forwarding events to the outputs
+func New(outputs []OutputConfig) (*Bus, error) {
+	b := &Bus{}
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+	for i := range outputs {
+		encoder, ok := encoders[strings.ToLower(outputs[i].Encoding)]
+		if !ok {
+			b.closeSinks()
+			return nil, fmt.Errorf("event bus output %s: unsupported encoding %s",
+				outputs[i].Name,
+				outputs[i].Encoding)
+		}
+		newSink, ok := sinks[strings.ToLower(outputs[i].Sink)]
+		if !ok {
+			b.closeSinks()
+			return nil, fmt.Errorf("event bus output %s: unsupported sink %s",
+				outputs[i].Name,
+				outputs[i].Sink)
+		}
+		sink, err := newSink(outputs[i].Address)
+		if err != nil {
+			b.closeSinks()
+			return nil, fmt.Errorf("event bus output %s: %v", outputs[i].Name, err)
+		}
+		o := &output{
+			cfg:     outputs[i],
+			encoder: encoder,
+			sink:    sink,
+		}
+		if len(outputs[i].Events) > 0 {
+			o.events = make(map[string]struct{}, len(outputs[i].Events))
+			for x := range outputs[i].Events {
+				o.events[strings.ToLower(outputs[i].Events[x])] = struct{}{}
+			}
+		}
+		size := outputs[i].BufferSize
+		if size <= 0 {
+			size = DefaultBufferSize
+		}
+		o.queue = make(chan *Event, size)
+		b.outputs = append(b.outputs, o)
+	}
+	for i := range b.outputs {
+		b.wg.Add(1)
+		go b.forward(b.outputs[i])
+	}
+	return b, nil
+}
+
+// Publish queues an event for each output which forwards its type. Events
+// are dropped for outputs whose queue is full
+func (b *Bus) Publish(e *Event) {
+	b.m.RLock()
+	defer b.m.RUnlock()
+	if b.closed {
+		return
+	}
+	for i := range b.outputs {
+		if b.outputs[i].events != nil {
+			if _, ok := b.outputs[i].events[e.Type]; !ok {
+				continue
+			}
+		}
+		select {
+		case b.outputs[i].queue <- e:
+		default:
+			atomic.AddUint64(&b.outputs[i].dropped, 1)
+		}
+	}
+}
+
+// Forwards returns whether any output forwards events of the type, allowing
+// publishers to skip building events which would be discarded
+func (b *Bus) Forwards(eventType string) bool {
+	for i := range b.outputs {
+		if b.outputs[i].events == nil {
+			return true
+		}
+		if _, ok := b.outputs[i].events[eventType]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Dropped returns the amount of events each output has dropped by name
+func (b *Bus) Dropped() map[string]uint64 {
+	resp := make(map[string]uint64, len(b.outputs))
+	for i := range b.outputs {
+		resp[b.outputs[i].cfg.Name] = atomic.LoadUint64(&b.outputs[i].dropped)
+	}
+	return resp
+}
+
+// Close stops accepting events, forwards the events already queued and closes
+// each output's sink
+func (b *Bus) Close() {
+	b.m.Lock()
+	if b.closed {
+		b.m.Unlock()
+		return
+	}
+	b.closed = true
+	for i := range b.outputs {
+		close(b.outputs[i].queue)
+	}
+	b.m.Unlock()
+	b.wg.Wait()
+	b.closeSinks()
+}
+
+func (b *Bus) closeSinks() {
+	for i := range b.outputs {
+		if err := b.outputs[i].sink.Close(); err != nil {
+			log.Errorf(log.EventBusMgr, "Event bus output %s unable to close: %v",
+				b.outputs[i].cfg.Name,
+				err)
+		}
+	}
+}
+
+// forward encodes and sends an output's queued events until it is closed
+func (b *Bus) forward(o *output) {
+	defer b.wg.Done()
+	for e := range o.queue {
+		payload, err := o.encoder.Encode(e)
+		if err != nil {
+			log.Errorf(log.EventBusMgr, "Event bus output %s unable to encode %s event: %v",
+				o.cfg.Name,
+				e.Type,
+				err)
+			continue
+		}
+		if err = o.sink.Send(Subject(o.cfg.Subject, e), payload); err != nil {
+			atomic.AddUint64(&o.dropped, 1)
+			log.Errorf(log.EventBusMgr, "Event bus output %s unable to send %s event: %v",
+				o.cfg.Name,
+				e.Type,
+				err)
+		}
+	}
+}
+
+// Subject returns the subject an event is sent under, the prefix followed by
+// the event type and exchange separated by dots
+func Subject(prefix string, e *Event) string {
+	parts := make([]string, 0, 3)
+	if prefix != "" {
+		parts = append(parts, prefix)
+	}
+	parts = append(parts, e.Type)
+	if e.Exchange != "" {
+		parts = append(parts, strings.ToLower(e.Exchange))
+	}
+	return strings.Join(parts, ".")
+}
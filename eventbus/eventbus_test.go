@@ -0,0 +1,359 @@
+package eventbus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testAlert = &Event{
+	Type:      AlertEvent,
+	Timestamp: time.Unix(1, 0),
+	Alert: &Alert{
+		Type:    "x",
+		Message: "hi",
+	},
+}
+
+func TestJSONEncode(t *testing.T) {
+	b, err := jsonEncoder{}.Encode(testAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e Event
+	if err = json.Unmarshal(b, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != AlertEvent || e.Alert == nil || e.Alert.Message != "hi" {
+		t.Errorf("unexpected decoded event %+v", e)
+	}
+	if _, err = (jsonEncoder{}).Encode(nil); err != errNilEvent {
+		t.Errorf("expected %v, received %v", errNilEvent, err)
+	}
+}
+
+func TestMsgpackEncode(t *testing.T) {
+	b, err := msgpackEncoder{}.Encode(testAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "83" +
+		"a474797065" + "a5616c657274" +
+		"a974696d657374616d70" + "d3000000003b9aca00" +
+		"a5616c657274" + "82" +
+		"a9616c65727454797065" + "a178" +
+		"a76d657373616765" + "a26869"
+	if hex.EncodeToString(b) != expected {
+		t.Errorf("expected %s, received %x", expected, b)
+	}
+
+	b, err = msgpackEncoder{}.Encode(&Event{
+		Type:     TickerEvent,
+		Exchange: "Bitstamp",
+		Ticker:   &Ticker{Pair: "BTCUSD", Last: 1.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0x84 {
+		t.Errorf("expected map of 4 fields, received %x", b[0])
+	}
+	if !bytes.Contains(b, []byte("\xa4last\xcb\x3f\xf8\x00\x00\x00\x00\x00\x00")) {
+		t.Errorf("expected float64 last price in %x", b)
+	}
+
+	b, err = msgpackEncoder{}.Encode(&Event{
+		Type:      OrderbookEvent,
+		Orderbook: &Orderbook{Bids: []Level{{Price: 1, Amount: 2}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte("\xa4bids\x91\x82\xa5price\xcb\x3f\xf0")) ||
+		!bytes.HasSuffix(b, []byte("\xa4asks\x90")) {
+		t.Errorf("expected orderbook levels in %x", b)
+	}
+}
+
+func TestProtobufEncode(t *testing.T) {
+	b, err := protobufEncoder{}.Encode(testAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "0a05616c657274" +
+		"188094ebdc03" +
+		"3a07" + "0a0178" + "12026869"
+	if hex.EncodeToString(b) != expected {
+		t.Errorf("expected %s, received %x", expected, b)
+	}
+
+	b, err = protobufEncoder{}.Encode(&Event{
+		Type:  TradeEvent,
+		Trade: &Trade{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(b) != "0a057472616465"+"2a00" {
+		t.Errorf("expected empty trade to be encoded, received %x", b)
+	}
+
+	b, err = protobufEncoder{}.Encode(&Event{
+		Type:      OrderbookEvent,
+		Orderbook: &Orderbook{Pair: "A", Bids: []Level{{Price: 1, Amount: 2}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "0a096f72646572626f6f6b" +
+		"4217" + "0a0141" +
+		"1a12" + "09000000000000f03f" + "110000000000000040"
+	if hex.EncodeToString(b) != expected {
+		t.Errorf("expected %s, received %x", expected, b)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	e := &Event{Type: TradeEvent, Exchange: "Binance"}
+	if s := Subject("gct", e); s != "gct.trade.binance" {
+		t.Errorf("unexpected subject %s", s)
+	}
+	if s := Subject("", &Event{Type: AlertEvent}); s != "alert" {
+		t.Errorf("unexpected subject %s", s)
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New([]OutputConfig{{Name: "test", Sink: "kafka", Address: "localhost:9092", Encoding: JSONEncoding}})
+	if err == nil {
+		t.Error("expected error for unsupported sink")
+	}
+	_, err = New([]OutputConfig{{Name: "test", Sink: NATSSink, Address: "localhost:4222", Encoding: "avro"}})
+	if err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+	_, err = New([]OutputConfig{{Name: "test", Sink: NATSSink, Encoding: JSONEncoding}})
+	if err == nil {
+		t.Error("expected error for empty address")
+	}
+}
+
+type testSink struct {
+	m        sync.Mutex
+	subjects []string
+	payloads [][]byte
+	block    chan struct{}
+	closed   bool
+}
+
+func (s *testSink) Send(subject string, payload []byte) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.m.Lock()
+	s.subjects = append(s.subjects, subject)
+	s.payloads = append(s.payloads, payload)
+	s.m.Unlock()
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.m.Lock()
+	s.closed = true
+	s.m.Unlock()
+	return nil
+}
+
+func TestPublish(t *testing.T) {
+	sink := &testSink{}
+	RegisterSink("TestPublish", func(string) (Sink, error) {
+		return sink, nil
+	})
+	b, err := New([]OutputConfig{{
+		Name:     "test",
+		Sink:     "testpublish",
+		Encoding: JSONEncoding,
+		Subject:  "gct",
+		Events:   []string{TradeEvent, AlertEvent},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Forwards(TickerEvent) || !b.Forwards(TradeEvent) {
+		t.Error("expected only trade and alert events to be forwarded")
+	}
+	b.Publish(&Event{Type: TickerEvent, Exchange: "Bitstamp", Ticker: &Ticker{}})
+	b.Publish(&Event{Type: TradeEvent, Exchange: "Bitstamp", Trade: &Trade{ID: "1"}})
+	b.Publish(testAlert)
+	b.Close()
+	b.Publish(testAlert)
+
+	sink.m.Lock()
+	defer sink.m.Unlock()
+	if !sink.closed {
+		t.Error("expected sink to be closed")
+	}
+	if len(sink.subjects) != 2 {
+		t.Fatalf("expected 2 events forwarded, received %d", len(sink.subjects))
+	}
+	if sink.subjects[0] != "gct.trade.bitstamp" || sink.subjects[1] != "gct.alert" {
+		t.Errorf("unexpected subjects %v", sink.subjects)
+	}
+	if !bytes.Contains(sink.payloads[0], []byte(`"id":"1"`)) {
+		t.Errorf("unexpected payload %s", sink.payloads[0])
+	}
+}
+
+func TestPublishDropped(t *testing.T) {
+	sink := &testSink{block: make(chan struct{})}
+	RegisterSink("TestPublishDropped", func(string) (Sink, error) {
+		return sink, nil
+	})
+	b, err := New([]OutputConfig{{
+		Name:       "test",
+		Sink:       "testpublishdropped",
+		Encoding:   MsgpackEncoding,
+		BufferSize: 1,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first event is held by the blocked sink, the second is queued
+	for i := 0; i < 2; i++ {
+		b.Publish(testAlert)
+		time.Sleep(time.Millisecond * 50)
+	}
+	b.Publish(testAlert)
+	if d := b.Dropped()["test"]; d != 1 {
+		t.Errorf("expected 1 dropped event, received %d", d)
+	}
+	close(sink.block)
+	b.Close()
+	if len(sink.payloads) != 2 {
+		t.Errorf("expected 2 events forwarded, received %d", len(sink.payloads))
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		if _, err = conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n")); err != nil {
+			return
+		}
+		connect, err := r.ReadString('\n')
+		if err != nil || !strings.HasPrefix(connect, "CONNECT ") {
+			return
+		}
+		if _, err = conn.Write([]byte("PING\r\n")); err != nil {
+			return
+		}
+		var lines []string
+		var pong, pub bool
+		for !pong || !pub {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines = append(lines, strings.TrimSpace(line))
+			pong = pong || strings.HasPrefix(line, "PONG")
+			if strings.HasPrefix(line, "PUB ") {
+				pub = true
+				fields := strings.Fields(line)
+				size, err := strconv.Atoi(fields[2])
+				if err != nil {
+					return
+				}
+				payload := make([]byte, size+2)
+				if _, err = io.ReadFull(r, payload); err != nil {
+					return
+				}
+				lines = append(lines, string(payload[:size]))
+			}
+		}
+		received <- strings.Join(lines, "|")
+	}()
+
+	s, err := newNATSSink("nats://" + l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Send("gct.alert", []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case r := <-received:
+		if !strings.Contains(r, "PUB gct.alert 5|hello") || !strings.Contains(r, "PONG") {
+			t.Errorf("unexpected messages received %s", r)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for published event")
+	}
+	if err = s.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTCPSink(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		frame := make([]byte, 2+9+4+5)
+		if _, err = io.ReadFull(conn, frame); err != nil {
+			return
+		}
+		received <- frame
+	}()
+
+	s, err := newTCPSink(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Send("gct.alert", []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case frame := <-received:
+		if binary.BigEndian.Uint16(frame) != 9 ||
+			string(frame[2:11]) != "gct.alert" ||
+			binary.BigEndian.Uint32(frame[11:]) != 5 ||
+			string(frame[15:]) != "hello" {
+			t.Errorf("unexpected frame %x", frame)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for frame")
+	}
+	if err = s.Close(); err != nil {
+		t.Error(err)
+	}
+}
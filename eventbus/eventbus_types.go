@@ -0,0 +1,155 @@
+package eventbus
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published on the bus
+const (
+	TickerEvent    = "ticker"
+	TradeEvent     = "trade"
+	OrderbookEvent = "orderbook"
+	OrderEvent     = "order"
+	AlertEvent     = "alert"
+)
+
+// Encodings supported by the bus
+const (
+	JSONEncoding     = "json"
+	MsgpackEncoding  = "msgpack"
+	ProtobufEncoding = "protobuf"
+)
+
+// Sinks supported by the bus
+const (
+	NATSSink  = "nats"
+	KafkaSink = "kafka"
+	TCPSink   = "tcp"
+)
+
+const (
+	// DefaultBufferSize is the default amount of events queued for an output
+	DefaultBufferSize = 1000
+	// sinkTimeout is how long a sink waits to connect or send before the
+	// event is dropped
+	sinkTimeout = time.Second * 5
+)
+
+// Event is an event published on the bus. The payload matching Type is set
+type Event struct {
+	Type      string     `json:"type"`
+	Exchange  string     `json:"exchange,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+	Ticker    *Ticker    `json:"ticker,omitempty"`
+	Trade     *Trade     `json:"trade,omitempty"`
+	Orderbook *Orderbook `json:"orderbook,omitempty"`
+	Order     *Order     `json:"order,omitempty"`
+	Alert     *Alert     `json:"alert,omitempty"`
+}
+
+// Ticker is a ticker received from an exchange
+type Ticker struct {
+	Pair   string  `json:"pair"`
+	Asset  string  `json:"asset"`
+	Last   float64 `json:"last"`
+	Bid    float64 `json:"bid"`
+	Ask    float64 `json:"ask"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Volume float64 `json:"volume"`
+}
+
+// Trade is a public trade received from an exchange
+type Trade struct {
+	ID     string  `json:"id,omitempty"`
+	Pair   string  `json:"pair"`
+	Asset  string  `json:"asset"`
+	Side   string  `json:"side"`
+	Price  float64 `json:"price"`
+	Amount float64 `json:"amount"`
+}
+
+// Orderbook is the top of an exchange's orderbook after it has been updated
+type Orderbook struct {
+	Pair  string  `json:"pair"`
+	Asset string  `json:"asset"`
+	Bids  []Level `json:"bids"`
+	Asks  []Level `json:"asks"`
+}
+
+// Level is a price level of an orderbook
+type Level struct {
+	Price  float64 `json:"price"`
+	Amount float64 `json:"amount"`
+}
+
+// Order is an account's order which has been added or updated
+type Order struct {
+	ID             string  `json:"id"`
+	ClientID       string  `json:"clientId,omitempty"`
+	Pair           string  `json:"pair"`
+	Asset          string  `json:"asset"`
+	Side           string  `json:"side"`
+	Type           string  `json:"orderType"`
+	Status         string  `json:"status"`
+	Price          float64 `json:"price"`
+	Amount         float64 `json:"amount"`
+	ExecutedAmount float64 `json:"executedAmount"`
+}
+
+// Alert is an event relayed through the communications manager
+type Alert struct {
+	Type    string `json:"alertType"`
+	Message string `json:"message"`
+}
+
+// Encoder serialises events for external consumers
+type Encoder interface {
+	Encode(e *Event) ([]byte, error)
+}
+
+// Sink forwards encoded events to an external consumer under a subject
+type Sink interface {
+	Send(subject string, payload []byte) error
+	Close() error
+}
+
+// SinkFactory returns a sink which forwards events to address
+type SinkFactory func(address string) (Sink, error)
+
+// OutputConfig defines where an output forwards events and how they are
+// encoded
+type OutputConfig struct {
+	Name     string
+	Sink     string
+	Address  string
+	Encoding string
+	// Subject prefixes the subject of each event, which is followed by the
+	// event type and exchange separated by dots
+	Subject string
+	// Events are the event types forwarded, all when empty
+	Events []string
+	// BufferSize is the amount of events queued for the output, events
+	// published while the queue is full are dropped
+	BufferSize int
+}
+
+// Bus fans out published events to its outputs. Each output encodes and
+// sends its events in its own routine so a slow consumer never blocks the
+// publisher
+type Bus struct {
+	outputs []*output
+	wg      sync.WaitGroup
+	m       sync.RWMutex
+	closed  bool
+}
+
+type output struct {
+	cfg     OutputConfig
+	encoder Encoder
+	sink    Sink
+	events  map[string]struct{}
+	queue   chan *Event
+	dropped uint64
+}
@@ -0,0 +1,372 @@
+package eventbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kafka API keys and versions used by the producer. Produce v3 is the oldest
+// version accepted by current brokers and carries v2 record batches
+const (
+	kafkaProduceKey      = 0
+	kafkaProduceVersion  = 3
+	kafkaMetadataKey     = 3
+	kafkaMetadataVersion = 1
+	kafkaClientID        = "gocryptotrader"
+	// kafkaAcks waits for the partition leader to write each record
+	kafkaAcks = 1
+	// kafkaMaxResponse limits the size of a response read from a broker
+	kafkaMaxResponse = 1 << 24
+)
+
+var (
+	errKafkaTopicRequired = errors.New("kafka address must be formatted as host:port/topic")
+	crc32c                = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// kafkaSink produces events to a Kafka topic as records keyed by their
+// subject, so each exchange's events of a type are kept in order on one
+// partition. The topic's partition leaders are looked up through the broker
+// addressed when the first event is sent and again after a failure
+type kafkaSink struct {
+	bootstrap string
+	topic     string
+
+	m             sync.Mutex
+	partitions    []int32
+	leaders       map[int32]string
+	conns         map[string]net.Conn
+	correlationID int32
+}
+
+func newKafkaSink(address string) (Sink, error) {
+	address = strings.TrimPrefix(address, "kafka://")
+	if address == "" {
+		return nil, errEmptyAddress
+	}
+	i := strings.LastIndex(address, "/")
+	if i <= 0 || i == len(address)-1 {
+		return nil, errKafkaTopicRequired
+	}
+	return &kafkaSink{
+		bootstrap: address[:i],
+		topic:     address[i+1:],
+		conns:     make(map[string]net.Conn),
+	}, nil
+}
+
+// Send produces a record keyed by the subject to the topic
+func (k *kafkaSink) Send(subject string, payload []byte) error {
+	k.m.Lock()
+	defer k.m.Unlock()
+	err := k.send(subject, payload)
+	if err != nil {
+		// partition leaders may have moved, they are looked up again by the
+		// next event sent
+		k.closeConns()
+		k.partitions = nil
+	}
+	return err
+}
+
+func (k *kafkaSink) send(subject string, payload []byte) error {
+	if k.partitions == nil {
+		if err := k.loadMetadata(); err != nil {
+			return err
+		}
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(subject))
+	partition := k.partitions[h.Sum32()%uint32(len(k.partitions))]
+
+	var w kafkaWriter
+	w.int16(-1) // transactional ID
+	w.int16(kafkaAcks)
+	w.int32(int32(sinkTimeout / time.Millisecond))
+	w.int32(1)
+	w.str(k.topic)
+	w.int32(1)
+	w.int32(partition)
+	w.bytes(kafkaRecordBatch([]byte(subject), payload, time.Now()))
+	resp, err := k.roundTrip(k.leaders[partition], kafkaProduceKey, kafkaProduceVersion, w.buf)
+	if err != nil {
+		return err
+	}
+
+	r := kafkaReader{buf: resp}
+	for topics := r.int32(); topics > 0 && r.err == nil; topics-- {
+		r.str()
+		for partitions := r.int32(); partitions > 0 && r.err == nil; partitions-- {
+			p := r.int32()
+			code := r.int16()
+			r.int64() // base offset
+			r.int64() // log append time
+			if r.err == nil && code != 0 {
+				return fmt.Errorf("kafka topic %s partition %d produce error code %d", k.topic, p, code)
+			}
+		}
+	}
+	return r.err
+}
+
+// loadMetadata looks up the topic's partitions and their leaders
+func (k *kafkaSink) loadMetadata() error {
+	var w kafkaWriter
+	w.int32(1)
+	w.str(k.topic)
+	resp, err := k.roundTrip(k.bootstrap, kafkaMetadataKey, kafkaMetadataVersion, w.buf)
+	if err != nil {
+		return err
+	}
+
+	r := kafkaReader{buf: resp}
+	brokers := make(map[int32]string)
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		id := r.int32()
+		host := r.str()
+		port := r.int32()
+		r.str() // rack
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	r.int32() // controller ID
+	var partitions []int32
+	leaders := make(map[int32]string)
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		code := r.int16()
+		name := r.str()
+		r.int8() // internal
+		if r.err == nil && name == k.topic && code != 0 {
+			return fmt.Errorf("kafka topic %s metadata error code %d", k.topic, code)
+		}
+		for p := r.int32(); p > 0 && r.err == nil; p-- {
+			r.int16() // partition error code
+			id := r.int32()
+			leader := r.int32()
+			for replicas := r.int32(); replicas > 0 && r.err == nil; replicas-- {
+				r.int32()
+			}
+			for isr := r.int32(); isr > 0 && r.err == nil; isr-- {
+				r.int32()
+			}
+			addr, ok := brokers[leader]
+			if name != k.topic || !ok {
+				continue
+			}
+			partitions = append(partitions, id)
+			leaders[id] = addr
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if len(partitions) == 0 {
+		return fmt.Errorf("kafka topic %s has no partitions with a leader", k.topic)
+	}
+	k.partitions = partitions
+	k.leaders = leaders
+	return nil
+}
+
+// roundTrip sends a request to a broker and returns the response body
+func (k *kafkaSink) roundTrip(addr string, apiKey, version int16, body []byte) ([]byte, error) {
+	conn, ok := k.conns[addr]
+	if !ok {
+		var err error
+		conn, err = net.DialTimeout("tcp", addr, sinkTimeout)
+		if err != nil {
+			return nil, err
+		}
+		k.conns[addr] = conn
+	}
+	k.correlationID++
+	var w kafkaWriter
+	w.int32(0) // size, set below
+	w.int16(apiKey)
+	w.int16(version)
+	w.int32(k.correlationID)
+	w.str(kafkaClientID)
+	w.buf = append(w.buf, body...)
+	binary.BigEndian.PutUint32(w.buf, uint32(len(w.buf)-4))
+
+	err := conn.SetDeadline(time.Now().Add(sinkTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = conn.Write(w.buf); err != nil {
+		return nil, err
+	}
+	var size [4]byte
+	if _, err = io.ReadFull(conn, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n < 4 || n > kafkaMaxResponse {
+		return nil, fmt.Errorf("kafka response size %d is invalid", n)
+	}
+	resp := make([]byte, n)
+	if _, err = io.ReadFull(conn, resp); err != nil {
+		return nil, err
+	}
+	if id := int32(binary.BigEndian.Uint32(resp)); id != k.correlationID {
+		return nil, fmt.Errorf("kafka response correlation ID %d does not match request %d", id, k.correlationID)
+	}
+	return resp[4:], nil
+}
+
+// Close closes the connections to the brokers
+func (k *kafkaSink) Close() error {
+	k.m.Lock()
+	defer k.m.Unlock()
+	k.closeConns()
+	return nil
+}
+
+func (k *kafkaSink) closeConns() {
+	for addr, conn := range k.conns {
+		conn.Close()
+		delete(k.conns, addr)
+	}
+}
+
+// kafkaRecordBatch returns a v2 record batch holding a single record
+func kafkaRecordBatch(key, value []byte, t time.Time) []byte {
+	var rec kafkaWriter
+	rec.int8(0) // attributes
+	rec.varint(0)
+	rec.varint(0)
+	rec.varint(int64(len(key)))
+	rec.buf = append(rec.buf, key...)
+	rec.varint(int64(len(value)))
+	rec.buf = append(rec.buf, value...)
+	rec.varint(0) // headers
+
+	ts := t.UnixNano() / int64(time.Millisecond)
+	var b kafkaWriter
+	b.int16(0) // attributes
+	b.int32(0) // last offset delta
+	b.int64(ts)
+	b.int64(ts)
+	b.int64(-1) // producer ID
+	b.int16(-1) // producer epoch
+	b.int32(-1) // base sequence
+	b.int32(1)
+	b.varint(int64(len(rec.buf)))
+	b.buf = append(b.buf, rec.buf...)
+
+	var batch kafkaWriter
+	batch.int64(0) // base offset
+	batch.int32(int32(4 + 1 + 4 + len(b.buf)))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(2)   // magic
+	batch.int32(int32(crc32.Checksum(b.buf, crc32c)))
+	batch.buf = append(batch.buf, b.buf...)
+	return batch.buf
+}
+
+// kafkaWriter writes the primitive types of the Kafka protocol
+type kafkaWriter struct {
+	buf []byte
+}
+
+func (w *kafkaWriter) int8(v int8) {
+	w.buf = append(w.buf, byte(v))
+}
+
+func (w *kafkaWriter) int16(v int16) {
+	w.buf = append(w.buf, byte(v>>8), byte(v))
+}
+
+func (w *kafkaWriter) int32(v int32) {
+	w.buf = append(w.buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (w *kafkaWriter) int64(v int64) {
+	w.int32(int32(v >> 32))
+	w.int32(int32(v))
+}
+
+func (w *kafkaWriter) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf = append(w.buf, b[:binary.PutVarint(b[:], v)]...)
+}
+
+func (w *kafkaWriter) str(s string) {
+	w.int16(int16(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *kafkaWriter) bytes(b []byte) {
+	w.int32(int32(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+// kafkaReader reads the primitive types of the Kafka protocol, recording the
+// first read past the end of the buffer
+type kafkaReader struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (r *kafkaReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.off+n > len(r.buf) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := r.buf[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *kafkaReader) int8() int8 {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+	return int8(b[0])
+}
+
+func (r *kafkaReader) int16() int16 {
+	b := r.next(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (r *kafkaReader) int32() int32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (r *kafkaReader) int64() int64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+// str reads a string, returning an empty string when it is null
+func (r *kafkaReader) str() string {
+	n := r.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(r.next(int(n)))
+}
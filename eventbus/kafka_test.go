@@ -0,0 +1,144 @@
+package eventbus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+)
+
+// fakeKafkaBroker answers metadata requests with itself as the leader of the
+// topic's only partition and records the record batches produced to it
+type fakeKafkaBroker struct {
+	l       net.Listener
+	topic   string
+	batches chan []byte
+}
+
+func (f *fakeKafkaBroker) serve(t *testing.T) {
+	conn, err := f.l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	host, portStr, _ := net.SplitHostPort(f.l.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	for {
+		var size [4]byte
+		if _, err = io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err = io.ReadFull(conn, req); err != nil {
+			return
+		}
+		r := kafkaReader{buf: req}
+		apiKey := r.int16()
+		version := r.int16()
+		correlationID := r.int32()
+		r.str() // client ID
+
+		var w kafkaWriter
+		w.int32(0)
+		w.int32(correlationID)
+		switch {
+		case apiKey == kafkaMetadataKey && version == kafkaMetadataVersion:
+			w.int32(1)
+			w.int32(1)
+			w.str(host)
+			w.int32(int32(port))
+			w.int16(-1)
+			w.int32(1)
+			w.int32(1)
+			w.int16(0)
+			w.str(f.topic)
+			w.int8(0)
+			w.int32(1)
+			w.int16(0)
+			w.int32(0)
+			w.int32(1)
+			w.int32(1)
+			w.int32(1)
+			w.int32(1)
+			w.int32(1)
+		case apiKey == kafkaProduceKey && version == kafkaProduceVersion:
+			r.int16() // transactional ID
+			if r.int16() != kafkaAcks {
+				t.Error("unexpected acks")
+			}
+			r.int32()
+			r.int32()
+			topic := r.str()
+			r.int32()
+			partition := r.int32()
+			batch := r.next(int(r.int32()))
+			if r.err != nil || topic != f.topic || partition != 0 {
+				t.Errorf("unexpected produce request %x", req)
+			}
+			f.batches <- batch
+			w.int32(1)
+			w.str(f.topic)
+			w.int32(1)
+			w.int32(0)
+			w.int16(0)
+			w.int64(0)
+			w.int64(-1)
+			w.int32(0)
+		default:
+			t.Errorf("unexpected request API key %d version %d", apiKey, version)
+			return
+		}
+		binary.BigEndian.PutUint32(w.buf, uint32(len(w.buf)-4))
+		if _, err = conn.Write(w.buf); err != nil {
+			return
+		}
+	}
+}
+
+func TestKafkaSink(t *testing.T) {
+	_, err := newKafkaSink("localhost:9092")
+	if err != errKafkaTopicRequired {
+		t.Errorf("expected %v, received %v", errKafkaTopicRequired, err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	broker := &fakeKafkaBroker{l: l, topic: "marketdata", batches: make(chan []byte, 2)}
+	go broker.serve(t)
+
+	s, err := newKafkaSink("kafka://" + l.Addr().String() + "/marketdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for i := 0; i < 2; i++ {
+		if err = s.Send("gct.trade.binance", []byte("hello")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		batch := <-broker.batches
+		r := kafkaReader{buf: batch}
+		r.int64()
+		length := r.int32()
+		r.int32()
+		magic := r.int8()
+		crc := uint32(r.int32())
+		if r.err != nil || int(length) != len(batch)-12 || magic != 2 {
+			t.Fatalf("unexpected record batch %x", batch)
+		}
+		if crc32.Checksum(batch[21:], crc32c) != crc {
+			t.Error("record batch CRC does not match")
+		}
+		if !bytes.Contains(batch, []byte("gct.trade.binance")) || !bytes.HasSuffix(batch, []byte("hello\x00")) {
+			t.Errorf("expected record key and value in batch %x", batch)
+		}
+	}
+}
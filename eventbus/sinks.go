@@ -0,0 +1,206 @@
+package eventbus
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errEmptyAddress = errors.New("sink address cannot be empty")
+
+// natsSink publishes events to a NATS server using its text protocol. The
+// connection is established when the first event is sent and re-established
+// after it fails
+type natsSink struct {
+	address string
+	m       sync.Mutex
+	conn    net.Conn
+}
+
+func newNATSSink(address string) (Sink, error) {
+	address = strings.TrimPrefix(address, "nats://")
+	if address == "" {
+		return nil, errEmptyAddress
+	}
+	return &natsSink{address: address}, nil
+}
+
+// Send publishes a payload to the subject
+func (n *natsSink) Send(subject string, payload []byte) error {
+	n.m.Lock()
+	defer n.m.Unlock()
+	if n.conn == nil {
+		if err := n.connect(); err != nil {
+			return err
+		}
+	}
+	msg := make([]byte, 0, len(subject)+len(payload)+32)
+	msg = append(msg, "PUB "+subject+" "+strconv.Itoa(len(payload))+"\r\n"...)
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\n"...)
+	if err := n.write(msg); err != nil {
+		n.conn.Close()
+		n.conn = nil
+		return err
+	}
+	return nil
+}
+
+// connect dials the server, reads its INFO and sends CONNECT. The
+// connection's reader answers the server's keepalive pings
+func (n *natsSink) connect() error {
+	conn, err := net.DialTimeout("tcp", n.address, sinkTimeout)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(conn)
+	err = conn.SetReadDeadline(time.Now().Add(sinkTimeout))
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	info, err := r.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if !strings.HasPrefix(info, "INFO") {
+		conn.Close()
+		return fmt.Errorf("unexpected NATS server greeting %q", strings.TrimSpace(info))
+	}
+	err = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	n.conn = conn
+	err = n.write([]byte("CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"gocryptotrader\"}\r\n"))
+	if err != nil {
+		conn.Close()
+		n.conn = nil
+		return err
+	}
+	go n.read(conn, r)
+	return nil
+}
+
+// read answers pings until the connection fails, after which the next event
+// sent reconnects
+func (n *natsSink) read(conn net.Conn, r *bufio.Reader) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			n.m.Lock()
+			if n.conn == conn {
+				n.conn.Close()
+				n.conn = nil
+			}
+			n.m.Unlock()
+			return
+		}
+		if strings.TrimSpace(line) == "PING" {
+			n.m.Lock()
+			if n.conn == conn {
+				err = n.write([]byte("PONG\r\n"))
+			}
+			n.m.Unlock()
+			if err != nil {
+				conn.Close()
+			}
+		}
+	}
+}
+
+func (n *natsSink) write(b []byte) error {
+	err := n.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	if err != nil {
+		return err
+	}
+	_, err = n.conn.Write(b)
+	return err
+}
+
+// Close closes the connection to the server
+func (n *natsSink) Close() error {
+	n.m.Lock()
+	defer n.m.Unlock()
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn = nil
+	return err
+}
+
+// tcpSink writes events to a TCP consumer as frames of the subject's length
+// as a big endian uint16, the subject, the payload's length as a big endian
+// uint32 and the payload. The connection is established when the first event
+// is sent and re-established after it fails
+type tcpSink struct {
+	address string
+	m       sync.Mutex
+	conn    net.Conn
+}
+
+func newTCPSink(address string) (Sink, error) {
+	address = strings.TrimPrefix(address, "tcp://")
+	if address == "" {
+		return nil, errEmptyAddress
+	}
+	return &tcpSink{address: address}, nil
+}
+
+// Send writes a frame of the subject and payload
+func (t *tcpSink) Send(subject string, payload []byte) error {
+	if len(subject) > math.MaxUint16 {
+		return fmt.Errorf("subject length %d exceeds maximum", len(subject))
+	}
+	if uint64(len(payload)) > math.MaxUint32 {
+		return fmt.Errorf("payload length %d exceeds maximum", len(payload))
+	}
+	frame := make([]byte, 2, 6+len(subject)+len(payload))
+	binary.BigEndian.PutUint16(frame, uint16(len(subject)))
+	frame = append(frame, subject...)
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(payload)))
+	frame = append(frame, size[:]...)
+	frame = append(frame, payload...)
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.conn == nil {
+		conn, err := net.DialTimeout("tcp", t.address, sinkTimeout)
+		if err != nil {
+			return err
+		}
+		t.conn = conn
+	}
+	err := t.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	if err == nil {
+		_, err = t.conn.Write(frame)
+	}
+	if err != nil {
+		t.conn.Close()
+		t.conn = nil
+	}
+	return err
+}
+
+// Close closes the connection to the consumer
+func (t *tcpSink) Close() error {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
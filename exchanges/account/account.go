@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 )
 
@@ -31,7 +34,21 @@ func SubscribeToExchangeAccount(exchange string) (dispatch.Pipe, error) {
 	return service.mux.Subscribe(acc.ID)
 }
 
-// Process processes new account holdings updates
+// SubscribeToBalanceChanges subscribes to the balance changes of all
+// exchanges. Each update of an exchange's holdings which changes a balance
+// publishes its changes as a []BalanceChange
+func SubscribeToBalanceChanges() (dispatch.Pipe, error) {
+	service.Lock()
+	defer service.Unlock()
+	id, err := service.changesID()
+	if err != nil {
+		return dispatch.Pipe{}, err
+	}
+	return service.mux.Subscribe(id)
+}
+
+// Process processes new account holdings updates. Currencies the exchange
+// uses its own codes for are translated to their canonical codes
 func Process(h *Holdings) error {
 	if h == nil {
 		return errors.New("cannot be nil")
@@ -41,9 +58,25 @@ func Process(h *Holdings) error {
 		return errors.New("exchange name unset")
 	}
 
+	for x := range h.Accounts {
+		for y := range h.Accounts[x].Currencies {
+			h.Accounts[x].Currencies[y].CurrencyName = currency.Canonical(h.Exchange,
+				h.Accounts[x].Currencies[y].CurrencyName)
+			h.Accounts[x].Currencies[y].setAvailable()
+		}
+	}
+
 	return service.Update(h)
 }
 
+// setAvailable derives the available amount from the total and hold amounts
+// for exchanges which do not report it
+func (b *Balance) setAvailable() {
+	if b.Available == 0 && b.TotalValue > b.Hold {
+		b.Available = b.TotalValue - b.Hold
+	}
+}
+
 // GetHoldings returns full holdings for an exchange
 func GetHoldings(exch string) (Holdings, error) {
 	if exch == "" {
@@ -61,25 +94,131 @@ func GetHoldings(exch string) (Holdings, error) {
 	return *h.h, nil
 }
 
+// GetCachedHoldings returns an exchange's holdings when they were updated
+// within maxAge, otherwise ErrHoldingsExpired is returned so they can be
+// fetched from the exchange
+func GetCachedHoldings(exch string, maxAge time.Duration) (Holdings, error) {
+	if exch == "" {
+		return Holdings{}, errors.New("exchange name unset")
+	}
+
+	service.Lock()
+	defer service.Unlock()
+	acc, ok := service.accounts[strings.ToLower(exch)]
+	if !ok {
+		return Holdings{}, errors.New("exchange account holdings not found")
+	}
+	if clock.Since(acc.updated) > maxAge {
+		return Holdings{}, fmt.Errorf("%s %v", exch, ErrHoldingsExpired)
+	}
+	return *acc.h, nil
+}
+
 // Update updates holdings with new account info
 func (s *Service) Update(a *Holdings) error {
 	exch := strings.ToLower(a.Exchange)
+	now := clock.Now()
 	s.Lock()
+	defer s.Unlock()
 	acc, ok := s.accounts[exch]
 	if !ok {
 		id, err := s.mux.GetID()
 		if err != nil {
-			s.Unlock()
 			return err
 		}
 
-		s.accounts[exch] = &Account{h: a, ID: id}
-		s.Unlock()
-		return nil
+		s.accounts[exch] = &Account{h: a, ID: id, updated: now}
+		return s.publishChanges(balanceChanges(a.Exchange, nil, a.Accounts, now))
 	}
 
+	changes := balanceChanges(a.Exchange, acc.h.Accounts, a.Accounts, now)
 	acc.h.Accounts = a.Accounts
-	defer s.Unlock()
+	acc.updated = now
+	if err := s.mux.Publish([]uuid.UUID{acc.ID}, acc.h); err != nil {
+		return err
+	}
+	return s.publishChanges(changes)
+}
+
+// changesID returns the balance changes stream ID, creating it when it does
+// not exist. Must be called with the service lock held
+func (s *Service) changesID() (uuid.UUID, error) {
+	if s.changes != (uuid.UUID{}) {
+		return s.changes, nil
+	}
+	id, err := s.mux.GetID()
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	s.changes = id
+	return id, nil
+}
+
+// publishChanges publishes balance changes. Must be called with the service
+// lock held
+func (s *Service) publishChanges(changes []BalanceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	id, err := s.changesID()
+	if err != nil {
+		return err
+	}
+	return s.mux.Publish([]uuid.UUID{id}, &changes)
+}
+
+// balanceChanges returns the balances which differ between an exchange's
+// previous and current sub accounts
+func balanceChanges(exch string, previous, current []SubAccount, t time.Time) []BalanceChange {
+	prev := make(map[string]Balance)
+	for x := range previous {
+		for y := range previous[x].Currencies {
+			prev[balanceKey(previous[x].ID, &previous[x].Currencies[y])] = previous[x].Currencies[y]
+		}
+	}
+
+	var changes []BalanceChange
+	for x := range current {
+		for y := range current[x].Currencies {
+			b := current[x].Currencies[y]
+			key := balanceKey(current[x].ID, &b)
+			p, ok := prev[key]
+			delete(prev, key)
+			if ok && p.TotalValue == b.TotalValue && p.Hold == b.Hold && p.Available == b.Available {
+				continue
+			}
+			if !ok && b.TotalValue == 0 && b.Hold == 0 && b.Available == 0 {
+				continue
+			}
+			changes = append(changes, BalanceChange{
+				Exchange: exch,
+				Account:  current[x].ID,
+				Currency: b.CurrencyName,
+				Previous: p,
+				Current:  b,
+				Time:     t,
+			})
+		}
+	}
+	for x := range previous {
+		for y := range previous[x].Currencies {
+			p, ok := prev[balanceKey(previous[x].ID, &previous[x].Currencies[y])]
+			if !ok || (p.TotalValue == 0 && p.Hold == 0 && p.Available == 0) {
+				continue
+			}
+			changes = append(changes, BalanceChange{
+				Exchange: exch,
+				Account:  previous[x].ID,
+				Currency: p.CurrencyName,
+				Previous: p,
+				Current:  Balance{CurrencyName: p.CurrencyName},
+				Time:     t,
+			})
+		}
+	}
+	return changes
+}
 
-	return s.mux.Publish([]uuid.UUID{acc.ID}, acc.h)
+func balanceKey(accountID string, b *Balance) string {
+	return accountID + ":" + b.CurrencyName.Upper().String()
 }
@@ -84,6 +84,11 @@ func TestHoldings(t *testing.T) {
 			u.Accounts[0].Currencies[0].Hold)
 	}
 
+	if u.Accounts[0].Currencies[0].Available != 80 {
+		t.Errorf("expecting 80 but receieved %f",
+			u.Accounts[0].Currencies[0].Available)
+	}
+
 	_, err = SubscribeToExchangeAccount("nonsense")
 	if err == nil {
 		t.Fatal("error cannot be nil")
@@ -127,3 +132,133 @@ func TestHoldings(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestBalanceChanges(t *testing.T) {
+	now := time.Now()
+	previous := []SubAccount{{
+		ID: "1",
+		Currencies: []Balance{
+			{CurrencyName: currency.BTC, TotalValue: 1, Available: 1},
+			{CurrencyName: currency.ETH, TotalValue: 5, Available: 5},
+			{CurrencyName: currency.LTC, TotalValue: 2, Available: 2},
+		},
+	}}
+	current := []SubAccount{{
+		ID: "1",
+		Currencies: []Balance{
+			{CurrencyName: currency.BTC, TotalValue: 1, Available: 1},
+			{CurrencyName: currency.ETH, TotalValue: 5, Hold: 1, Available: 4},
+			{CurrencyName: currency.USD, TotalValue: 100, Available: 100},
+			{CurrencyName: currency.XRP},
+		},
+	}}
+
+	changes := balanceChanges("Test", previous, current, now)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	for x := range changes {
+		switch {
+		case changes[x].Currency.Match(currency.ETH):
+			if changes[x].Previous.Hold != 0 || changes[x].Current.Hold != 1 {
+				t.Errorf("unexpected ETH change %+v", changes[x])
+			}
+		case changes[x].Currency.Match(currency.USD):
+			if changes[x].Previous.TotalValue != 0 || changes[x].Current.TotalValue != 100 {
+				t.Errorf("unexpected USD change %+v", changes[x])
+			}
+		case changes[x].Currency.Match(currency.LTC):
+			if changes[x].Previous.TotalValue != 2 || changes[x].Current.TotalValue != 0 {
+				t.Errorf("unexpected LTC change %+v", changes[x])
+			}
+		default:
+			t.Errorf("unexpected change %+v", changes[x])
+		}
+		if changes[x].Exchange != "Test" || changes[x].Account != "1" || !changes[x].Time.Equal(now) {
+			t.Errorf("unexpected change details %+v", changes[x])
+		}
+	}
+
+	if changes = balanceChanges("Test", current, current, now); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestGetCachedHoldings(t *testing.T) {
+	if !dispatch.IsRunning() {
+		err := dispatch.Start(dispatch.DefaultMaxWorkers, dispatch.DefaultJobsLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := SubscribeToBalanceChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Release()
+
+	received := make(chan []BalanceChange, 1)
+	go func() {
+		data, ok := <-p.C
+		if ok {
+			received <- (*data.(*interface{})).([]BalanceChange)
+		}
+	}()
+
+	// the dispatcher drops data when the subscriber is not ready to receive,
+	// so balances keep changing until the change is received
+	var changes []BalanceChange
+	for i := 1; changes == nil && i <= 100; i++ {
+		err = Process(&Holdings{
+			Exchange: "TestGetCachedHoldings",
+			Accounts: []SubAccount{{
+				Currencies: []Balance{{CurrencyName: currency.BTC, TotalValue: float64(i)}},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case changes = <-received:
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+	if len(changes) != 1 || changes[0].Exchange != "TestGetCachedHoldings" || changes[0].Current.TotalValue == 0 {
+		t.Errorf("unexpected changes %+v", changes)
+	}
+
+	if _, err = GetCachedHoldings("TestGetCachedHoldings", time.Minute); err != nil {
+		t.Error(err)
+	}
+	time.Sleep(time.Millisecond * 10)
+	if _, err = GetCachedHoldings("TestGetCachedHoldings", time.Millisecond); err == nil {
+		t.Error("expected holdings older than the maximum age to have expired")
+	}
+	if _, err = GetCachedHoldings("TestGetCachedHoldingsMissing", time.Minute); err == nil {
+		t.Error("expected an error for an exchange without holdings")
+	}
+}
+
+func TestProcessCanonicalCodes(t *testing.T) {
+	h := Holdings{
+		Exchange: "Kraken",
+		Accounts: []SubAccount{{
+			Currencies: []Balance{
+				{CurrencyName: currency.XXBT, TotalValue: 1},
+				{CurrencyName: currency.NewCode("ZUSD"), TotalValue: 100},
+			},
+		}},
+	}
+	if err := Process(&h); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := GetHoldings("Kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := stored.Accounts[0].Currencies
+	if !c[0].CurrencyName.Match(currency.BTC) || !c[1].CurrencyName.Match(currency.USD) {
+		t.Errorf("expected the exchange's codes to be translated, got %v %v", c[0].CurrencyName, c[1].CurrencyName)
+	}
+}
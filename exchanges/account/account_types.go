@@ -1,29 +1,40 @@
 package account
 
 import (
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
 // Vars for the ticker package
 var (
 	service *Service
+
+	// ErrHoldingsExpired is returned when an exchange's holdings were last
+	// updated before the requested age
+	ErrHoldingsExpired = errors.New("exchange account holdings have expired")
 )
 
 // Service holds ticker information for each individual exchange
 type Service struct {
 	accounts map[string]*Account
 	mux      *dispatch.Mux
+	// changes is the stream ID balance changes on all exchanges are
+	// published to
+	changes uuid.UUID
 	sync.Mutex
 }
 
 // Account holds a stream ID and a pointer to the exchange holdings
 type Account struct {
-	h  *Holdings
-	ID uuid.UUID
+	h       *Holdings
+	ID      uuid.UUID
+	updated time.Time
 }
 
 // Holdings is a generic type to hold each exchange's holdings for all enabled
@@ -37,11 +48,45 @@ type Holdings struct {
 type SubAccount struct {
 	ID         string
 	Currencies []Balance
+	Positions  []Position
 }
 
-// Balance is a sub type to store currency name and individual totals
+// Balance is a sub type to store currency name and individual totals. Hold is
+// the amount locked in open orders or pending withdrawals and Available is the
+// amount free to trade or withdraw
 type Balance struct {
 	CurrencyName currency.Code
 	TotalValue   float64
 	Hold         float64
+	Available    float64
+}
+
+// Position defines an open leveraged derivatives position. Size is positive
+// for long positions and negative for short positions, while margin and profit
+// and loss values are denominated in MarginCurrency
+type Position struct {
+	Pair             currency.Pair
+	AssetType        asset.Item
+	Size             float64
+	EntryPrice       float64
+	MarkPrice        float64
+	LiquidationPrice float64
+	Leverage         float64
+	CrossMargin      bool
+	MarginCurrency   currency.Code
+	Margin           float64
+	UnrealisedPNL    float64
+	RealisedPNL      float64
+}
+
+// BalanceChange is a change to a currency balance of an exchange sub account.
+// Previous is empty when the currency was not held and Current is empty when
+// it is no longer reported
+type BalanceChange struct {
+	Exchange string
+	Account  string
+	Currency currency.Code
+	Previous Balance
+	Current  Balance
+	Time     time.Time
 }
@@ -28,6 +28,7 @@ const (
 	apiURL = "https://api.binance.com"
 
 	// Public endpoints
+	serverTime        = "/api/v3/time"
 	exchangeInfo      = "/api/v3/exchangeInfo"
 	orderBookDepth    = "/api/v3/depth"
 	recentTrades      = "/api/v3/trades"
@@ -44,6 +45,7 @@ const (
 	// Authenticated endpoints
 	newOrderTest = "/api/v3/order/test"
 	newOrder     = "/api/v3/order"
+	newOCOOrder  = "/api/v3/order/oco"
 	cancelOrder  = "/api/v3/order"
 	queryOrder   = "/api/v3/order"
 	openOrders   = "/api/v3/openOrders"
@@ -59,6 +61,11 @@ const (
 	dustLog           = "/wapi/v3/userAssetDribbletLog.html"
 	tradeFee          = "/wapi/v3/tradeFee.html"
 	assetDetail       = "/wapi/v3/assetDetail.html"
+
+	// brokerOrderIDPrefix is prepended to the broker ID in client order IDs
+	// so orders are attributed to the broker
+	brokerOrderIDPrefix    = "x-"
+	maxClientOrderIDLength = 36
 )
 
 // Binance is the overarching type across the Bithumb package
@@ -71,6 +78,18 @@ type Binance struct {
 	validIntervals []TimeInterval
 }
 
+// GetServerTime returns the exchange's server time
+func (b *Binance) GetServerTime() (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	path := b.API.Endpoints.URL + serverTime
+	if err := b.SendHTTPRequest(path, limitDefault, &resp); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, resp.ServerTime*int64(time.Millisecond)), nil
+}
+
 // GetExchangeInfo returns exchange information. Check binance_types for more
 // information
 func (b *Binance) GetExchangeInfo() (ExchangeInfo, error) {
@@ -318,6 +337,28 @@ func (b *Binance) GetBestPrice(symbol string) (BestPrice, error) {
 	return resp, b.SendHTTPRequest(path, bestPriceLimit(symbol), &resp)
 }
 
+// clientOrderID returns the client order ID sent with an order, attributed to
+// the configured broker ID if set. A unique ID is generated if none is
+// supplied and the ID is truncated to fit Binance's length limit. IDs already
+// attributed to the broker ID are returned unchanged so retrying with the ID
+// sent is idempotent
+func (b *Binance) clientOrderID(clientID string) string {
+	if clientID == "" {
+		clientID = strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	var prefix string
+	if b.BrokerID != "" && len(brokerOrderIDPrefix+b.BrokerID) < maxClientOrderIDLength {
+		prefix = brokerOrderIDPrefix + b.BrokerID
+	}
+	if strings.HasPrefix(clientID, prefix) && len(clientID) <= maxClientOrderIDLength {
+		return clientID
+	}
+	if len(prefix)+len(clientID) > maxClientOrderIDLength {
+		clientID = clientID[len(prefix)+len(clientID)-maxClientOrderIDLength:]
+	}
+	return prefix + clientID
+}
+
 // NewOrder sends a new order to Binance
 func (b *Binance) NewOrder(o *NewOrderRequest) (NewOrderResponse, error) {
 	var resp NewOrderResponse
@@ -337,7 +378,7 @@ func (b *Binance) NewOrder(o *NewOrderRequest) (NewOrderResponse, error) {
 	}
 
 	if o.NewClientOrderID != "" {
-		params.Set("newClientOrderID", o.NewClientOrderID)
+		params.Set("newClientOrderId", o.NewClientOrderID)
 	}
 
 	if o.StopPrice != 0 {
@@ -362,6 +403,39 @@ func (b *Binance) NewOrder(o *NewOrderRequest) (NewOrderResponse, error) {
 	return resp, nil
 }
 
+// NewOCOOrder sends a one-cancels-other order pair to Binance, a limit maker
+// order and a stop loss order which cancel each other when either executes
+func (b *Binance) NewOCOOrder(o *NewOCOOrderRequest) (NewOCOOrderResponse, error) {
+	var resp NewOCOOrderResponse
+
+	path := b.API.Endpoints.URL + newOCOOrder
+
+	params := url.Values{}
+	params.Set("symbol", o.Symbol)
+	params.Set("side", o.Side)
+	params.Set("quantity", strconv.FormatFloat(o.Quantity, 'f', -1, 64))
+	params.Set("price", strconv.FormatFloat(o.Price, 'f', -1, 64))
+	params.Set("stopPrice", strconv.FormatFloat(o.StopPrice, 'f', -1, 64))
+
+	if o.StopLimitPrice != 0 {
+		params.Set("stopLimitPrice", strconv.FormatFloat(o.StopLimitPrice, 'f', -1, 64))
+		params.Set("stopLimitTimeInForce", string(o.StopLimitTimeInForce))
+	}
+
+	if o.ListClientOrderID != "" {
+		params.Set("listClientOrderId", o.ListClientOrderID)
+	}
+
+	if err := b.SendAuthHTTPRequest(http.MethodPost, path, params, limitOrder, &resp); err != nil {
+		return resp, err
+	}
+
+	if resp.Code != 0 {
+		return resp, errors.New(resp.Msg)
+	}
+	return resp, nil
+}
+
 // CancelExistingOrder sends a cancel order to Binance
 func (b *Binance) CancelExistingOrder(symbol string, orderID int64, origClientOrderID string) (CancelOrderResponse, error) {
 	var resp CancelOrderResponse
@@ -492,19 +566,21 @@ func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, f
 		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
 	}
 
+	creds := b.GetCredentials(exchange.MethodPermission(method))
+
 	if params == nil {
 		params = url.Values{}
 	}
 	recvWindow := 5 * time.Second
 	params.Set("recvWindow", strconv.FormatInt(convert.RecvWindow(recvWindow), 10))
-	params.Set("timestamp", strconv.FormatInt(time.Now().Unix()*1000, 10))
+	params.Set("timestamp", strconv.FormatInt(b.Requester.Now().UnixNano()/int64(time.Millisecond), 10))
 
 	signature := params.Encode()
-	hmacSigned := crypto.GetHMAC(crypto.HashSHA256, []byte(signature), []byte(b.API.Credentials.Secret))
+	hmacSigned := crypto.GetHMAC(crypto.HashSHA256, []byte(signature), []byte(creds.Secret))
 	hmacSignedStr := crypto.HexEncodeToString(hmacSigned)
 
 	headers := make(map[string]string)
-	headers["X-MBX-APIKEY"] = b.API.Credentials.Key
+	headers["X-MBX-APIKEY"] = creds.Key
 
 	if b.Verbose {
 		log.Debugf(log.ExchangeSys, "sent path: %s", path)
@@ -517,6 +593,7 @@ func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, f
 
 	errCap := struct {
 		Success bool   `json:"success"`
+		Code    int64  `json:"code"`
 		Message string `json:"msg"`
 	}{}
 
@@ -539,13 +616,57 @@ func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, f
 
 	if err := json.Unmarshal(interim, &errCap); err == nil {
 		if !errCap.Success && errCap.Message != "" {
-			return errors.New(errCap.Message)
+			return &request.Error{
+				Exchange: b.Name,
+				Code:     strconv.FormatInt(errCap.Code, 10),
+				Message:  errCap.Message,
+				Type:     errorType(errCap.Code, errCap.Message),
+			}
 		}
 	}
 
 	return json.Unmarshal(interim, result)
 }
 
+// parseError maps Binance's error response code to an exchange API error type
+func parseError(_ int, body []byte) (string, error) {
+	var e struct {
+		Code    int64  `json:"code"`
+		Message string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &e); err != nil || e.Code == 0 {
+		return "", nil
+	}
+	return strconv.FormatInt(e.Code, 10), errorType(e.Code, e.Message)
+}
+
+// errorType returns the exchange API error type for a Binance error code and
+// message
+func errorType(code int64, msg string) error {
+	switch code {
+	case -1003, -1015:
+		// too many requests or new orders
+		return request.ErrRateLimited
+	case -1021:
+		// timestamp is outside of the receive window
+		return request.ErrInvalidNonce
+	case -2013:
+		// order does not exist
+		return request.ErrOrderNotFound
+	case -2010, -2011:
+		// new order and cancel rejections share codes across reasons
+		lower := strings.ToLower(msg)
+		switch {
+		case strings.Contains(lower, "insufficient balance"):
+			return request.ErrInsufficientFunds
+		case strings.Contains(lower, "unknown order"),
+			strings.Contains(lower, "does not exist"):
+			return request.ErrOrderNotFound
+		}
+	}
+	return nil
+}
+
 // CheckLimit checks value against a variable list
 func (b *Binance) CheckLimit(limit int) error {
 	for x := range b.validLimits {
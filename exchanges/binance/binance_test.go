@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -44,6 +46,22 @@ func TestGetExchangeInfo(t *testing.T) {
 	}
 }
 
+func TestGetOrderLimits(t *testing.T) {
+	t.Parallel()
+	if _, err := b.GetOrderLimits(asset.Margin); err != common.ErrFunctionNotSupported {
+		t.Errorf("expected %v, got %v", common.ErrFunctionNotSupported, err)
+	}
+	limits, err := b.GetOrderLimits(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := range limits {
+		if limits[x].Pair.IsEmpty() || limits[x].PriceIncrement <= 0 || limits[x].AmountIncrement <= 0 {
+			t.Errorf("unexpected limits %+v", limits[x])
+		}
+	}
+}
+
 func TestFetchTradablePairs(t *testing.T) {
 	t.Parallel()
 
@@ -356,6 +374,41 @@ func TestGetOrderHistory(t *testing.T) {
 // Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
 // -----------------------------------------------------------------------------------------------------------------------------
 
+func TestClientOrderID(t *testing.T) {
+	t.Parallel()
+	var bi Binance
+	if id := bi.clientOrderID("1337"); id != "1337" {
+		t.Errorf("expected client ID to be unchanged without a broker ID, got %s", id)
+	}
+	if id := bi.clientOrderID(""); id == "" {
+		t.Error("expected a client order ID to be generated without a broker ID")
+	}
+	if id := bi.clientOrderID(strings.Repeat("a", 40)); len(id) != maxClientOrderIDLength {
+		t.Errorf("expected truncated client order ID, got %s", id)
+	}
+
+	bi.BrokerID = "GCT"
+	if id := bi.clientOrderID("1337"); id != "x-GCT1337" {
+		t.Errorf("expected x-GCT1337, got %s", id)
+	}
+	if id := bi.clientOrderID("x-GCT1337"); id != "x-GCT1337" {
+		t.Errorf("expected an attributed client order ID to be unchanged, got %s", id)
+	}
+
+	id := bi.clientOrderID("")
+	if !strings.HasPrefix(id, "x-GCT") || len(id) == len("x-GCT") {
+		t.Errorf("expected generated broker client order ID, got %s", id)
+	}
+
+	id = bi.clientOrderID(strings.Repeat("a", 40))
+	if len(id) != maxClientOrderIDLength || !strings.HasPrefix(id, "x-GCT") {
+		t.Errorf("expected truncated broker client order ID, got %s", id)
+	}
+	if bi.clientOrderID(id) != id {
+		t.Errorf("expected the client order ID sent to be unchanged, got %s", bi.clientOrderID(id))
+	}
+}
+
 func TestSubmitOrder(t *testing.T) {
 	t.Parallel()
 
@@ -387,6 +440,43 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitOCOOrder(t *testing.T) {
+	t.Parallel()
+
+	if areTestAPIKeysSet() && !canManipulateRealOrders && !mockTests {
+		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
+	}
+
+	var ocoSubmission = &order.OCO{
+		Limit: order.Submit{
+			Pair: currency.Pair{
+				Delimiter: "_",
+				Base:      currency.LTC,
+				Quote:     currency.BTC,
+			},
+			Side:     order.Sell,
+			Type:     order.Limit,
+			Price:    1,
+			Amount:   1,
+			ClientID: "meowOCO",
+		},
+		StopPrice:      0.5,
+		StopLimitPrice: 0.49,
+	}
+
+	resp, err := b.SubmitOCOOrder(ocoSubmission)
+	switch {
+	case areTestAPIKeysSet() && err != nil:
+		t.Error("SubmitOCOOrder() error", err)
+	case !areTestAPIKeysSet() && err == nil && !mockTests:
+		t.Error("SubmitOCOOrder() expecting an error when no keys are set")
+	case mockTests && err != nil:
+		t.Error("Mock SubmitOCOOrder() error", err)
+	case mockTests && (resp.LimitOrderID != "3" || resp.StopOrderID != "2"):
+		t.Errorf("Mock SubmitOCOOrder() unexpected order IDs %+v", resp)
+	}
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	t.Parallel()
 
@@ -922,3 +1012,23 @@ func TestParseInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+	code, err := parseError(400, []byte(`{"code":-2010,"msg":"Account has insufficient balance for requested action."}`))
+	if code != "-2010" || err != request.ErrInsufficientFunds {
+		t.Errorf("expected insufficient funds, got %s %v", code, err)
+	}
+	if _, err = parseError(400, []byte(`{"code":-2011,"msg":"Unknown order sent."}`)); err != request.ErrOrderNotFound {
+		t.Errorf("expected order not found, got %v", err)
+	}
+	if _, err = parseError(429, []byte(`{"code":-1003,"msg":"Too many requests."}`)); err != request.ErrRateLimited {
+		t.Errorf("expected rate limited, got %v", err)
+	}
+	if _, err = parseError(400, []byte(`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`)); err != request.ErrInvalidNonce {
+		t.Errorf("expected invalid nonce, got %v", err)
+	}
+	if code, err = parseError(500, []byte(`<html></html>`)); code != "" || err != nil {
+		t.Errorf("expected unparsed error, got %s %v", code, err)
+	}
+}
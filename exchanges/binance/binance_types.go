@@ -313,6 +313,56 @@ type NewOrderResponse struct {
 	} `json:"fills"`
 }
 
+// NewOCOOrderRequest request type for a one-cancels-other order pair
+type NewOCOOrderRequest struct {
+	Symbol            string
+	ListClientOrderID string
+	// Side Buy or Sell, applies to both orders
+	Side     string
+	Quantity float64
+	// Price is the limit maker order price
+	Price float64
+	// StopPrice triggers the stop loss order
+	StopPrice float64
+	// StopLimitPrice places a stop loss limit order instead of a stop loss
+	// order when set, StopLimitTimeInForce is then required
+	StopLimitPrice       float64
+	StopLimitTimeInForce RequestParamsTimeForceType
+}
+
+// NewOCOOrderResponse is the return structured response from the exchange
+type NewOCOOrderResponse struct {
+	Code              int    `json:"code"`
+	Msg               string `json:"msg"`
+	OrderListID       int64  `json:"orderListId"`
+	ContingencyType   string `json:"contingencyType"`
+	ListStatusType    string `json:"listStatusType"`
+	ListOrderStatus   string `json:"listOrderStatus"`
+	ListClientOrderID string `json:"listClientOrderId"`
+	TransactionTime   int64  `json:"transactionTime"`
+	Symbol            string `json:"symbol"`
+	Orders            []struct {
+		Symbol        string `json:"symbol"`
+		OrderID       int64  `json:"orderId"`
+		ClientOrderID string `json:"clientOrderId"`
+	} `json:"orders"`
+	OrderReports []struct {
+		Symbol          string  `json:"symbol"`
+		OrderID         int64   `json:"orderId"`
+		OrderListID     int64   `json:"orderListId"`
+		ClientOrderID   string  `json:"clientOrderId"`
+		TransactionTime int64   `json:"transactTime"`
+		Price           float64 `json:"price,string"`
+		OrigQty         float64 `json:"origQty,string"`
+		ExecutedQty     float64 `json:"executedQty,string"`
+		Status          string  `json:"status"`
+		TimeInForce     string  `json:"timeInForce"`
+		Type            string  `json:"type"`
+		Side            string  `json:"side"`
+		StopPrice       float64 `json:"stopPrice,string"`
+	} `json:"orderReports"`
+}
+
 // CancelOrderResponse is the return structured response from the exchange
 type CancelOrderResponse struct {
 	Symbol            string `json:"symbol"`
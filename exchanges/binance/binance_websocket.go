@@ -402,16 +402,31 @@ func stringToOrderStatus(status string) (order.Status, error) {
 
 // SeedLocalCache seeds depth data
 func (b *Binance) SeedLocalCache(p currency.Pair) error {
-	var newOrderBook orderbook.Base
+	newOrderBook, lastUpdateID, err := b.orderbookSnapshot(p, asset.Spot)
+	if err != nil {
+		return err
+	}
+	return b.Websocket.Orderbook.LoadSnapshotWithSequence(newOrderBook, lastUpdateID)
+}
+
+// orderbookSnapshot returns a REST snapshot of the depth of a pair and the ID
+// of the last update it includes, which depth stream updates are sequenced
+// from
+func (b *Binance) orderbookSnapshot(p currency.Pair, a asset.Item) (*orderbook.Base, int64, error) {
 	orderbookNew, err := b.GetOrderBook(
 		OrderBookDataRequestParams{
-			Symbol: b.FormatExchangeCurrency(p, asset.Spot).String(),
+			Symbol: b.FormatExchangeCurrency(p, a).String(),
 			Limit:  1000,
 		})
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
+	newOrderBook := orderbook.Base{
+		Pair:         p,
+		AssetType:    a,
+		ExchangeName: b.Name,
+	}
 	for i := range orderbookNew.Bids {
 		newOrderBook.Bids = append(newOrderBook.Bids, orderbook.Item{
 			Amount: orderbookNew.Bids[i].Quantity,
@@ -424,12 +439,7 @@ func (b *Binance) SeedLocalCache(p currency.Pair) error {
 			Price:  orderbookNew.Asks[i].Price,
 		})
 	}
-
-	newOrderBook.Pair = p
-	newOrderBook.AssetType = asset.Spot
-	newOrderBook.ExchangeName = b.Name
-
-	return b.Websocket.Orderbook.LoadSnapshot(&newOrderBook)
+	return &newOrderBook, orderbookNew.LastUpdateID, nil
 }
 
 // UpdateLocalCache updates and returns the most recent iteration of the orderbook
@@ -468,5 +478,8 @@ func (b *Binance) UpdateLocalCache(wsdp *WebsocketDepthStream) error {
 		Pair:     currencyPair,
 		UpdateID: wsdp.LastUpdateID,
 		Asset:    asset.Spot,
+		// each event holds the updates from the first to the last update ID
+		FirstSequence: wsdp.FirstUpdateID,
+		Sequence:      wsdp.LastUpdateID,
 	})
 }
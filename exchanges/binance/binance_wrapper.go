@@ -73,8 +73,9 @@ func (b *Binance) SetDefaults() {
 
 	b.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerBatching:      true,
 				TickerFetching:      true,
@@ -110,6 +111,15 @@ func (b *Binance) SetDefaults() {
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto |
 				exchange.NoFiatWithdrawals,
+			OrderTypes: []order.Type{
+				order.Limit,
+				order.Market,
+			},
+			RateLimits: []exchange.RateLimit{
+				{Name: "global", Requests: binanceGlobalRequestRate, Interval: binanceGlobalInterval},
+				{Name: "orders", Requests: binanceOrderRequestRate, Interval: binanceOrderInterval},
+				{Name: "daily orders", Requests: binanceOrderDailyMaxRequests, Interval: binanceOrderDailyInterval},
+			},
 		},
 		Enabled: exchange.FeaturesEnabled{
 			AutoPairUpdates: true,
@@ -118,7 +128,8 @@ func (b *Binance) SetDefaults() {
 
 	b.Requester = request.New(b.Name,
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
-		request.WithLimiter(SetRateLimit()))
+		request.WithLimiter(SetRateLimit()),
+		request.WithErrorParser(parseError))
 
 	b.API.Endpoints.URLDefault = apiURL
 	b.API.Endpoints.URL = b.API.Endpoints.URLDefault
@@ -174,6 +185,7 @@ func (b *Binance) Setup(exch *config.ExchangeConfig) error {
 		true,
 		false,
 		exch.Name)
+	b.Websocket.Orderbook.SetSnapshotFetcher(b.orderbookSnapshot)
 	return nil
 }
 
@@ -197,37 +209,7 @@ func (b *Binance) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	forceUpdate := false
-	delim := b.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(b.GetEnabledPairs(asset.Spot).Strings(), delim) ||
-		!common.StringDataContains(b.GetAvailablePairs(asset.Spot).Strings(), delim) {
-		enabledPairs := currency.NewPairsFromStrings(
-			[]string{currency.BTC.String() + delim + currency.USDT.String()},
-		)
-		log.Warn(log.ExchangeSys,
-			"Available pairs for Binance reset due to config upgrade, please enable the ones you would like to use again")
-		forceUpdate = true
-
-		err := b.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s failed to update currencies. Err: %s\n",
-				b.Name,
-				err)
-		}
-	}
-
-	if !b.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := b.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			b.Name,
-			err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.NewPair(currency.BTC, currency.USDT), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -403,6 +385,57 @@ func (b *Binance) FetchAccountInfo() (account.Holdings, error) {
 	return acc, nil
 }
 
+// GetKeyPermissions returns whether the API key is permitted to trade and
+// withdraw
+func (b *Binance) GetKeyPermissions() (exchange.KeyPermissions, error) {
+	raw, err := b.GetAccount()
+	if err != nil {
+		return exchange.KeyPermissions{}, err
+	}
+	return exchange.KeyPermissions{
+		Trade:    raw.CanTrade,
+		Withdraw: raw.CanWithdraw,
+	}, nil
+}
+
+// FetchServerTime returns the exchange's server time
+func (b *Binance) FetchServerTime() (time.Time, error) {
+	return b.GetServerTime()
+}
+
+// GetOrderLimits returns the trading rules of each spot pair from the lot
+// size, price and minimum notional filters of the exchange info
+func (b *Binance) GetOrderLimits(a asset.Item) ([]order.Limits, error) {
+	if a != asset.Spot {
+		return nil, common.ErrFunctionNotSupported
+	}
+	info, err := b.GetExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	limits := make([]order.Limits, 0, len(info.Symbols))
+	for x := range info.Symbols {
+		l := order.Limits{
+			Pair:      currency.NewPairFromStrings(info.Symbols[x].BaseAsset, info.Symbols[x].QuoteAsset),
+			AssetType: asset.Spot,
+		}
+		for _, f := range info.Symbols[x].Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				l.PriceIncrement = f.TickSize
+			case "LOT_SIZE":
+				l.MinAmount = f.MinQty
+				l.MaxAmount = f.MaxQty
+				l.AmountIncrement = f.StepSize
+			case "MIN_NOTIONAL":
+				l.MinNotional = f.MinNotional
+			}
+		}
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Binance) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -440,18 +473,20 @@ func (b *Binance) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
 	}
 
 	var orderRequest = NewOrderRequest{
-		Symbol:      s.Pair.Base.String() + s.Pair.Quote.String(),
-		Side:        sideType,
-		Price:       s.Price,
-		Quantity:    s.Amount,
-		TradeType:   requestParamsOrderType,
-		TimeInForce: BinanceRequestParamsTimeGTC,
+		Symbol:           s.Pair.Base.String() + s.Pair.Quote.String(),
+		Side:             sideType,
+		Price:            s.Price,
+		Quantity:         s.Amount,
+		TradeType:        requestParamsOrderType,
+		TimeInForce:      BinanceRequestParamsTimeGTC,
+		NewClientOrderID: b.clientOrderID(s.ClientID),
 	}
 
 	response, err := b.NewOrder(&orderRequest)
 	if err != nil {
 		return submitOrderResponse, err
 	}
+	submitOrderResponse.ClientOrderID = orderRequest.NewClientOrderID
 	if response.OrderID > 0 {
 		submitOrderResponse.OrderID = strconv.FormatInt(response.OrderID, 10)
 	}
@@ -463,6 +498,50 @@ func (b *Binance) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
 	return submitOrderResponse, nil
 }
 
+// SubmitOCOOrder submits a limit maker order and a stop loss order which
+// Binance cancels together when either executes
+func (b *Binance) SubmitOCOOrder(o *order.OCO) (order.OCOResponse, error) {
+	var resp order.OCOResponse
+	if err := o.Validate(); err != nil {
+		return resp, err
+	}
+
+	var sideType string
+	if o.Limit.Side == order.Buy || o.Limit.Side == order.Bid {
+		sideType = order.Buy.String()
+	} else {
+		sideType = order.Sell.String()
+	}
+
+	var ocoRequest = NewOCOOrderRequest{
+		Symbol:         o.Limit.Pair.Base.String() + o.Limit.Pair.Quote.String(),
+		Side:           sideType,
+		Quantity:       o.Limit.Amount,
+		Price:          o.Limit.Price,
+		StopPrice:      o.StopPrice,
+		StopLimitPrice: o.StopLimitPrice,
+	}
+	if o.StopLimitPrice != 0 {
+		ocoRequest.StopLimitTimeInForce = BinanceRequestParamsTimeGTC
+	}
+	ocoRequest.ListClientOrderID = b.clientOrderID(o.Limit.ClientID)
+
+	response, err := b.NewOCOOrder(&ocoRequest)
+	if err != nil {
+		return resp, err
+	}
+	resp.ListID = strconv.FormatInt(response.OrderListID, 10)
+	for i := range response.OrderReports {
+		id := strconv.FormatInt(response.OrderReports[i].OrderID, 10)
+		if response.OrderReports[i].Type == string(BinanceRequestParamsOrderLimitMarker) {
+			resp.LimitOrderID = id
+		} else {
+			resp.StopOrderID = id
+		}
+	}
+	return resp, nil
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Binance) ModifyOrder(action *order.Modify) (string, error) {
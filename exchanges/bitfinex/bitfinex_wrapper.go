@@ -127,7 +127,8 @@ func (b *Bitfinex) SetDefaults() {
 
 	b.Requester = request.New(b.Name,
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
-		request.WithLimiter(SetRateLimit()))
+		request.WithLimiter(SetRateLimit()),
+		request.WithRateLimitCooldown(rateLimitCooldown))
 
 	b.API.Endpoints.URLDefault = bitfinexAPIURLBase
 	b.API.Endpoints.URL = b.API.Endpoints.URLDefault
@@ -214,15 +215,7 @@ func (b *Bitfinex) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	if !b.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := b.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s", b.Name, err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -9,6 +9,10 @@ import (
 )
 
 const (
+	// rateLimitCooldown is how long Bitfinex blocks an IP address which
+	// exceeded a rate limit
+	rateLimitCooldown = time.Minute
+
 	// Bitfinex rate limits - Public
 	requestLimitInterval      = time.Minute
 	platformStatusReqRate     = 15
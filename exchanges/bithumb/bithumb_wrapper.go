@@ -137,14 +137,7 @@ func (b *Bithumb) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	if !b.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := b.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", b.Name, err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -28,6 +28,9 @@ const (
 	bitmexAPIURL        = "https://www.bitmex.com/api/v1"
 	bitmexAPItestnetURL = "https://testnet.bitmex.com/api/v1"
 
+	// satoshisPerBitcoin converts XBt denominated values to XBT
+	satoshisPerBitcoin = 1e8
+
 	// Public endpoints
 	bitmexEndpointAnnouncement              = "/announcement"
 	bitmexEndpointAnnouncementUrgent        = "/announcement/urgent"
@@ -228,6 +231,16 @@ func (b *Bitmex) GetFullFundingHistory() ([]Funding, error) {
 		&fundingHistory)
 }
 
+// GetFundingRateHistory returns the funding history of a contract filtered by
+// params
+func (b *Bitmex) GetFundingRateHistory(params *GenericRequestParams) ([]Funding, error) {
+	var fundingHistory []Funding
+
+	return fundingHistory, b.SendHTTPRequest(bitmexEndpointFundingHistory,
+		params,
+		&fundingHistory)
+}
+
 // GetInstruments returns instrument data
 func (b *Bitmex) GetInstruments(params *GenericRequestParams) ([]Instrument, error) {
 	var instruments []Instrument
@@ -805,6 +818,8 @@ func (b *Bitmex) SendAuthenticatedHTTPRequest(verb, path string, params Paramete
 			b.Name)
 	}
 
+	creds := b.GetCredentials(exchange.MethodPermission(verb))
+
 	expires := time.Now().Add(time.Second * 10)
 	timestamp := expires.UnixNano()
 	timestampStr := strconv.FormatInt(timestamp, 10)
@@ -813,7 +828,7 @@ func (b *Bitmex) SendAuthenticatedHTTPRequest(verb, path string, params Paramete
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/json"
 	headers["api-expires"] = timestampNew
-	headers["api-key"] = b.API.Credentials.Key
+	headers["api-key"] = creds.Key
 
 	var payload string
 	if params != nil {
@@ -830,7 +845,7 @@ func (b *Bitmex) SendAuthenticatedHTTPRequest(verb, path string, params Paramete
 
 	hmac := crypto.GetHMAC(crypto.HashSHA256,
 		[]byte(verb+"/api/v1"+path+timestampNew+payload),
-		[]byte(b.API.Credentials.Secret))
+		[]byte(creds.Secret))
 
 	headers["api-signature"] = crypto.HexEncodeToString(hmac)
 
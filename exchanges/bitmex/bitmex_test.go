@@ -14,6 +14,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
@@ -297,6 +298,42 @@ func TestGetPositions(t *testing.T) {
 	}
 }
 
+func TestConvertPositions(t *testing.T) {
+	positions := convertPositions([]Position{
+		{
+			Symbol:        "XBTUSD",
+			Underlying:    "XBT",
+			QuoteCurrency: "USD",
+			Currency:      "XBt",
+			IsOpen:        true,
+			CurrentQty:    -100,
+			AvgEntryPrice: 9000,
+			Leverage:      10,
+			PosMargin:     50000000,
+			UnrealisedPnl: -25000000,
+		},
+		{Symbol: "XBTM20", IsOpen: false},
+	})
+	if len(positions) != 1 {
+		t.Fatalf("expected 1 open position, got %d", len(positions))
+	}
+	p := positions[0]
+	if p.Pair.String() != "XBTUSD" ||
+		p.AssetType != asset.PerpetualContract ||
+		p.Size != -100 ||
+		p.MarginCurrency != currency.XBT ||
+		p.Margin != 0.5 ||
+		p.UnrealisedPNL != -0.25 {
+		t.Errorf("unexpected position %+v", p)
+	}
+
+	if positionAssetType("XBTM20") != asset.Futures ||
+		positionAssetType("XBT7D_D95") != asset.DownsideProfitContract ||
+		positionAssetType("XBT7D_U105") != asset.UpsideProfitContract {
+		t.Error("unexpected position asset type")
+	}
+}
+
 func TestIsolatePosition(t *testing.T) {
 	_, err := b.IsolatePosition(PositionIsolateMarginParams{Symbol: "XBT"})
 	if err == nil {
@@ -931,3 +968,46 @@ func TestWsTrades(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetOpenInterest(t *testing.T) {
+	p := currency.NewPair(currency.XBT, currency.USD)
+	oi, err := b.GetOpenInterest(p, asset.PerpetualContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !oi.Pair.Equal(p) || oi.ValueCurrency != currency.XBT {
+		t.Errorf("unexpected open interest %+v", oi)
+	}
+}
+
+func TestGetLiquidations(t *testing.T) {
+	_, err := b.GetLiquidations(currency.NewPair(currency.XBT, currency.USD), asset.PerpetualContract)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFundingRates(t *testing.T) {
+	p := currency.NewPair(currency.XBT, currency.USD)
+	rates, err := b.GetFundingRates(p, asset.PerpetualContract, time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) == 0 || !rates[0].Pair.Equal(p) {
+		t.Errorf("unexpected funding rates %+v", rates)
+	}
+	if rates, err = b.GetFundingRates(p, asset.Futures, time.Time{}); err != nil || rates != nil {
+		t.Errorf("expected futures not to be funded, got %v %v", rates, err)
+	}
+}
+
+func TestGetFundingPayments(t *testing.T) {
+	_, err := b.GetFundingPayments(currency.NewPair(currency.XBT, currency.USD),
+		asset.PerpetualContract,
+		time.Now().AddDate(0, 0, -1))
+	if areTestAPIKeysSet() && err != nil {
+		t.Error(err)
+	} else if !areTestAPIKeysSet() && err == nil {
+		t.Error("expected an error without API keys")
+	}
+}
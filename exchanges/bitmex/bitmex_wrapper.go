@@ -2,6 +2,7 @@ package bitmex
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"strings"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -215,14 +217,7 @@ func (b *Bitmex) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	if !b.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := b.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", b.Name, err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -392,9 +387,17 @@ func (b *Bitmex) UpdateAccountInfo() (account.Holdings, error) {
 		})
 	}
 
+	positions, err := b.GetPositions(PositionGetParams{
+		Filter: `{"isOpen": true}`,
+	})
+	if err != nil {
+		return info, err
+	}
+
 	info.Exchange = b.Name
 	info.Accounts = append(info.Accounts, account.SubAccount{
 		Currencies: balances,
+		Positions:  convertPositions(positions),
 	})
 
 	err = account.Process(&info)
@@ -405,6 +408,52 @@ func (b *Bitmex) UpdateAccountInfo() (account.Holdings, error) {
 	return info, nil
 }
 
+// convertPositions converts open positions to account positions, with margin
+// and profit and loss values converted from satoshis when margined in XBt
+func convertPositions(positions []Position) []account.Position {
+	var resp []account.Position
+	for i := range positions {
+		if !positions[i].IsOpen {
+			continue
+		}
+		marginCurrency, divisor := currency.NewCode(positions[i].Currency), 1.0
+		if strings.EqualFold(positions[i].Currency, "XBt") {
+			marginCurrency, divisor = currency.XBT, satoshisPerBitcoin
+		}
+		resp = append(resp, account.Position{
+			Pair: currency.NewPairWithDelimiter(positions[i].Underlying,
+				positions[i].QuoteCurrency,
+				""),
+			AssetType:        positionAssetType(positions[i].Symbol),
+			Size:             float64(positions[i].CurrentQty),
+			EntryPrice:       positions[i].AvgEntryPrice,
+			MarkPrice:        positions[i].MarkPrice,
+			LiquidationPrice: positions[i].LiquidationPrice,
+			Leverage:         positions[i].Leverage,
+			CrossMargin:      positions[i].CrossMargin,
+			MarginCurrency:   marginCurrency,
+			Margin:           float64(positions[i].PosMargin) / divisor,
+			UnrealisedPNL:    float64(positions[i].UnrealisedPnl) / divisor,
+			RealisedPNL:      float64(positions[i].RealisedPnl) / divisor,
+		})
+	}
+	return resp
+}
+
+// positionAssetType returns the asset type of a contract symbol, following
+// the same rules used when updating tradable pairs
+func positionAssetType(symbol string) asset.Item {
+	switch {
+	case strings.Contains(symbol, "_D"):
+		return asset.DownsideProfitContract
+	case strings.Contains(symbol, "_U"):
+		return asset.UpsideProfitContract
+	case strings.Contains(symbol, "20"):
+		return asset.Futures
+	}
+	return asset.PerpetualContract
+}
+
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitmex) FetchAccountInfo() (account.Holdings, error) {
 	acc, err := account.GetHoldings(b.Name)
@@ -693,3 +742,123 @@ func (b *Bitmex) ValidateCredentials() error {
 func (b *Bitmex) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end time.Time, interval time.Duration) (kline.Item, error) {
 	return kline.Item{}, common.ErrNotYetImplemented
 }
+
+// GetOpenInterest returns the open interest of a contract
+func (b *Bitmex) GetOpenInterest(p currency.Pair, a asset.Item) (derivative.OpenInterest, error) {
+	instruments, err := b.GetInstruments(&GenericRequestParams{
+		Symbol: b.FormatExchangeCurrency(p, a).String(),
+	})
+	if err != nil {
+		return derivative.OpenInterest{}, err
+	}
+	if len(instruments) == 0 {
+		return derivative.OpenInterest{}, fmt.Errorf("%s instrument %s not found", b.Name, p)
+	}
+	valueCurrency, divisor := currency.NewCode(instruments[0].SettlCurrency), 1.0
+	if strings.EqualFold(instruments[0].SettlCurrency, "XBt") {
+		valueCurrency, divisor = currency.XBT, satoshisPerBitcoin
+	}
+	return derivative.OpenInterest{
+		Exchange:      b.Name,
+		Pair:          p,
+		AssetType:     a,
+		Amount:        float64(instruments[0].OpenInterest),
+		Value:         float64(instruments[0].OpenValue) / divisor,
+		ValueCurrency: valueCurrency,
+		Timestamp:     instruments[0].Timestamp,
+	}, nil
+}
+
+// GetLiquidations returns the active liquidation orders of a contract. BitMEX
+// does not timestamp liquidations so they are timestamped when returned
+func (b *Bitmex) GetLiquidations(p currency.Pair, a asset.Item) ([]derivative.Liquidation, error) {
+	orders, err := b.GetLiquidationOrders(&GenericRequestParams{
+		Symbol: b.FormatExchangeCurrency(p, a).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	resp := make([]derivative.Liquidation, len(orders))
+	for i := range orders {
+		resp[i] = derivative.Liquidation{
+			Exchange:  b.Name,
+			Pair:      p,
+			AssetType: a,
+			ID:        orders[i].OrderID,
+			Side:      order.Side(strings.ToUpper(orders[i].Side)),
+			Price:     orders[i].Price,
+			Amount:    float64(orders[i].LeavesQty),
+			Timestamp: now,
+		}
+	}
+	return resp, nil
+}
+
+// GetFundingRates returns the funding rates of a perpetual contract applied
+// on or after start. Other contracts are not funded
+func (b *Bitmex) GetFundingRates(p currency.Pair, a asset.Item, start time.Time) ([]derivative.FundingRate, error) {
+	if a != asset.PerpetualContract {
+		return nil, nil
+	}
+	funding, err := b.GetFundingRateHistory(&GenericRequestParams{
+		Symbol:    b.FormatExchangeCurrency(p, a).String(),
+		StartTime: start.UTC().Format(time.RFC3339),
+		Count:     500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]derivative.FundingRate, len(funding))
+	for i := range funding {
+		resp[i] = derivative.FundingRate{
+			Exchange:  b.Name,
+			Pair:      p,
+			AssetType: a,
+			Rate:      funding[i].FundingRate,
+			Timestamp: funding[i].Timestamp,
+		}
+	}
+	return resp, nil
+}
+
+// GetFundingPayments returns the account's funding payments on a perpetual
+// contract on or after start. BitMEX charges funding as an execution whose
+// commission is the funding rate
+func (b *Bitmex) GetFundingPayments(p currency.Pair, a asset.Item, start time.Time) ([]derivative.FundingPayment, error) {
+	if a != asset.PerpetualContract {
+		return nil, nil
+	}
+	executions, err := b.GetAccountExecutionTradeHistory(&GenericRequestParams{
+		Symbol:    b.FormatExchangeCurrency(p, a).String(),
+		Filter:    `{"execType":"Funding"}`,
+		StartTime: start.UTC().Format(time.RFC3339),
+		Count:     500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]derivative.FundingPayment, 0, len(executions))
+	for i := range executions {
+		if executions[i].ExecType != "Funding" {
+			continue
+		}
+		paid, divisor := currency.NewCode(executions[i].SettlCurrency), 1.0
+		if strings.EqualFold(executions[i].SettlCurrency, "XBt") {
+			paid, divisor = currency.XBT, satoshisPerBitcoin
+		}
+		resp = append(resp, derivative.FundingPayment{
+			Exchange:  b.Name,
+			Pair:      p,
+			AssetType: a,
+			Type:      derivative.PerpetualFunding,
+			ID:        executions[i].ExecID,
+			Currency:  paid,
+			// a positive commission is paid by the account
+			Amount:    -float64(executions[i].ExecComm) / divisor,
+			Rate:      executions[i].Commission,
+			Timestamp: executions[i].Timestamp,
+		})
+	}
+	return resp, nil
+}
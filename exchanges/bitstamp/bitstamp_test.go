@@ -4,9 +4,11 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -195,6 +197,22 @@ func TestGetTradingPairs(t *testing.T) {
 	}
 }
 
+func TestGetOrderLimits(t *testing.T) {
+	t.Parallel()
+	if _, err := b.GetOrderLimits(asset.Futures); err != common.ErrFunctionNotSupported {
+		t.Errorf("expected %v, got %v", common.ErrFunctionNotSupported, err)
+	}
+	limits, err := b.GetOrderLimits(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := range limits {
+		if limits[x].Pair.IsEmpty() || limits[x].PriceIncrement <= 0 || limits[x].AmountIncrement <= 0 {
+			t.Errorf("unexpected limits %+v", limits[x])
+		}
+	}
+}
+
 func TestGetTransactions(t *testing.T) {
 	t.Parallel()
 
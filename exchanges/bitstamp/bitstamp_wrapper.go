@@ -2,6 +2,8 @@ package bitstamp
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -103,6 +105,13 @@ func (b *Bitstamp) SetDefaults() {
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto |
 				exchange.AutoWithdrawFiat,
+			OrderTypes: []order.Type{
+				order.Limit,
+				order.Market,
+			},
+			RateLimits: []exchange.RateLimit{
+				{Name: "global", Requests: bitstampRequestRate, Interval: bitstampRateInterval},
+			},
 		},
 		Enabled: exchange.FeaturesEnabled{
 			AutoPairUpdates: true,
@@ -183,17 +192,7 @@ func (b *Bitstamp) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	if !b.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := b.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			b.Name,
-			err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -227,6 +226,40 @@ func (b *Bitstamp) UpdateTradablePairs(forceUpdate bool) error {
 	return b.UpdatePairs(currency.NewPairsFromStrings(pairs), asset.Spot, false, forceUpdate)
 }
 
+// GetOrderLimits returns the quantity and price precision and minimum order
+// value of each pair from the trading pairs info
+func (b *Bitstamp) GetOrderLimits(a asset.Item) ([]order.Limits, error) {
+	if a != asset.Spot {
+		return nil, common.ErrFunctionNotSupported
+	}
+	pairs, err := b.GetTradingPairs()
+	if err != nil {
+		return nil, err
+	}
+	limits := make([]order.Limits, 0, len(pairs))
+	for x := range pairs {
+		symbols := strings.Split(pairs[x].Name, "/")
+		if len(symbols) != 2 {
+			continue
+		}
+		l := order.Limits{
+			Pair:            currency.NewPairFromStrings(symbols[0], symbols[1]),
+			AssetType:       asset.Spot,
+			AmountIncrement: math.Pow10(-pairs[x].BaseDecimals),
+			PriceIncrement:  math.Pow10(-pairs[x].CounterDecimals),
+		}
+		// minimum orders are formatted as the value and quote currency,
+		// such as 25.0 USD
+		if fields := strings.Fields(pairs[x].MinimumOrder); len(fields) > 0 {
+			if l.MinNotional, err = strconv.ParseFloat(fields[0], 64); err != nil {
+				return nil, fmt.Errorf("%s minimum order %s is invalid: %v", pairs[x].Name, pairs[x].MinimumOrder, err)
+			}
+		}
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Bitstamp) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
 	tickerPrice := new(ticker.Price)
@@ -132,39 +132,7 @@ func (b *Bittrex) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	forceUpdate := false
-	delim := b.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(b.GetEnabledPairs(asset.Spot).Strings(), delim) ||
-		!common.StringDataContains(b.GetAvailablePairs(asset.Spot).Strings(), delim) {
-		forceUpdate = true
-		log.Warn(log.ExchangeSys, "Available pairs for Bittrex reset due to config upgrade, please enable the ones you would like again")
-
-		err := b.UpdatePairs(currency.NewPairsFromStrings(
-			[]string{currency.USDT.String() + delim + currency.BTC.String()},
-		),
-			asset.Spot,
-			true,
-			true,
-		)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s failed to update currencies. Err: %s\n",
-				b.Name,
-				err)
-		}
-	}
-
-	if !b.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := b.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			b.Name,
-			err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.NewPair(currency.USDT, currency.BTC), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -758,7 +758,9 @@ func (b *BTCMarkets) SendAuthenticatedRequest(method, path string, data, result
 			b.Name)
 	}
 
-	now := time.Now()
+	creds := b.GetCredentials(exchange.MethodPermission(method))
+
+	now := b.Requester.Now()
 	strTime := strconv.FormatInt(now.UTC().UnixNano()/1000000, 10)
 
 	var body io.Reader
@@ -772,19 +774,19 @@ func (b *BTCMarkets) SendAuthenticatedRequest(method, path string, data, result
 		body = bytes.NewBuffer(payload)
 		strMsg := method + btcMarketsAPIVersion + path + strTime + string(payload)
 		hmac = crypto.GetHMAC(crypto.HashSHA512,
-			[]byte(strMsg), []byte(b.API.Credentials.Secret))
+			[]byte(strMsg), []byte(creds.Secret))
 	default:
 		strArray := strings.Split(path, "?")
 		hmac = crypto.GetHMAC(crypto.HashSHA512,
 			[]byte(method+btcMarketsAPIVersion+strArray[0]+strTime),
-			[]byte(b.API.Credentials.Secret))
+			[]byte(creds.Secret))
 	}
 
 	headers := make(map[string]string)
 	headers["Accept"] = "application/json"
 	headers["Accept-Charset"] = "UTF-8"
 	headers["Content-Type"] = "application/json"
-	headers["BM-AUTH-APIKEY"] = b.API.Credentials.Key
+	headers["BM-AUTH-APIKEY"] = creds.Key
 	headers["BM-AUTH-TIMESTAMP"] = strTime
 	headers["BM-AUTH-SIGNATURE"] = crypto.Base64Encode(hmac)
 
@@ -75,8 +75,9 @@ func (b *BTCMarkets) SetDefaults() {
 
 	b.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerBatching:      true,
 				TickerFetching:      true,
@@ -192,39 +193,8 @@ func (b *BTCMarkets) Run() {
 			btcMarketsWSURL)
 		b.PrintEnabledPairs()
 	}
-	forceUpdate := false
-	delim := b.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(b.GetEnabledPairs(asset.Spot).Strings(), delim) ||
-		!common.StringDataContains(b.GetAvailablePairs(asset.Spot).Strings(), delim) {
-		log.Warnln(log.ExchangeSys, "Available pairs for BTC Markets reset due to config upgrade, please enable the pairs you would like again.")
-		forceUpdate = true
-	}
-	if forceUpdate {
-		enabledPairs := currency.Pairs{currency.Pair{
-			Base:      currency.BTC.Lower(),
-			Quote:     currency.AUD.Lower(),
-			Delimiter: delim,
-		},
-		}
-		err := b.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s Failed to update enabled currencies.\n",
-				b.Name)
-		}
-	}
-
-	if !b.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
 
-	err := b.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			b.Name,
-			err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.NewPair(currency.BTC.Lower(), currency.AUD.Lower()), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -363,6 +333,11 @@ func (b *BTCMarkets) FetchAccountInfo() (account.Holdings, error) {
 	return acc, nil
 }
 
+// FetchServerTime returns the exchange's server time
+func (b *BTCMarkets) FetchServerTime() (time.Time, error) {
+	return b.GetServerTime()
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *BTCMarkets) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -205,10 +205,12 @@ func (b *BTSE) SendAuthenticatedHTTPRequest(method, endpoint string, req map[str
 		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
 			b.Name)
 	}
+
+	creds := b.GetCredentials(exchange.MethodPermission(method))
 	path := btseAPIPath + endpoint
 	headers := make(map[string]string)
-	headers["btse-api"] = b.API.Credentials.Key
-	nonce := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+	headers["btse-api"] = creds.Key
+	nonce := strconv.FormatInt(b.Requester.Now().UnixNano()/int64(time.Millisecond), 10)
 	headers["btse-nonce"] = nonce
 	var body io.Reader
 	var hmac []byte
@@ -223,13 +225,13 @@ func (b *BTSE) SendAuthenticatedHTTPRequest(method, endpoint string, req map[str
 		hmac = crypto.GetHMAC(
 			crypto.HashSHA512_384,
 			[]byte((path + nonce + string(payload))),
-			[]byte(b.API.Credentials.Secret),
+			[]byte(creds.Secret),
 		)
 	} else {
 		hmac = crypto.GetHMAC(
 			crypto.HashSHA512_384,
 			[]byte((path + nonce)),
-			[]byte(b.API.Credentials.Secret),
+			[]byte(creds.Secret),
 		)
 	}
 	headers["btse-sign"] = crypto.HexEncodeToString(hmac)
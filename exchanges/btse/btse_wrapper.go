@@ -73,8 +73,9 @@ func (b *BTSE) SetDefaults() {
 
 	b.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerFetching:      true,
 				KlineFetching:       true,
@@ -182,15 +183,7 @@ func (b *BTSE) Run() {
 		b.PrintEnabledPairs()
 	}
 
-	if !b.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := b.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s Failed to update tradable pairs. Error: %s", b.Name, err)
-	}
+	b.UpdateAvailablePairs(b.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -342,6 +335,15 @@ func (b *BTSE) FetchAccountInfo() (account.Holdings, error) {
 	return acc, nil
 }
 
+// FetchServerTime returns the exchange's server time
+func (b *BTSE) FetchServerTime() (time.Time, error) {
+	t, err := b.GetServerTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.ISO, nil
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *BTSE) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -738,6 +738,8 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 			c.Name)
 	}
 
+	creds := c.GetCredentials(exchange.MethodPermission(method))
+
 	payload := []byte("")
 
 	if params != nil {
@@ -751,15 +753,15 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 		}
 	}
 
-	now := time.Now()
+	now := c.Requester.Now()
 	n := strconv.FormatInt(now.Unix(), 10)
 	message := n + method + "/" + path + string(payload)
-	hmac := crypto.GetHMAC(crypto.HashSHA256, []byte(message), []byte(c.API.Credentials.Secret))
+	hmac := crypto.GetHMAC(crypto.HashSHA256, []byte(message), []byte(creds.Secret))
 	headers := make(map[string]string)
 	headers["CB-ACCESS-SIGN"] = crypto.Base64Encode(hmac)
 	headers["CB-ACCESS-TIMESTAMP"] = n
-	headers["CB-ACCESS-KEY"] = c.API.Credentials.Key
-	headers["CB-ACCESS-PASSPHRASE"] = c.API.Credentials.ClientID
+	headers["CB-ACCESS-KEY"] = creds.Key
+	headers["CB-ACCESS-PASSPHRASE"] = creds.ClientID
 	headers["Content-Type"] = "application/json"
 
 	// Timestamp must be within 30 seconds of the api service time
@@ -778,6 +780,32 @@ func (c *CoinbasePro) SendAuthenticatedHTTPRequest(method, path string, params m
 	})
 }
 
+// parseError maps Coinbase Pro's error response message to an exchange API
+// error type
+func parseError(statusCode int, body []byte) (string, error) {
+	var e struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		return "", nil
+	}
+	msg := strings.ToLower(e.Message)
+	switch {
+	case strings.Contains(msg, "rate limit exceeded"):
+		return e.Message, request.ErrRateLimited
+	case strings.Contains(msg, "insufficient funds"):
+		return e.Message, request.ErrInsufficientFunds
+	case strings.Contains(msg, "request timestamp expired"),
+		strings.Contains(msg, "invalid timestamp"):
+		return e.Message, request.ErrInvalidNonce
+	case msg == "notfound",
+		strings.Contains(msg, "order not found"),
+		statusCode == http.StatusNotFound && strings.Contains(msg, "order"):
+		return e.Message, request.ErrOrderNotFound
+	}
+	return e.Message, nil
+}
+
 // GetFee returns an estimate of fee based on type of transaction
 func (c *CoinbasePro) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 	var fee float64
@@ -14,6 +14,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
@@ -77,6 +78,13 @@ func TestGetTrades(t *testing.T) {
 	}
 }
 
+func TestGetExchangeHistory(t *testing.T) {
+	_, err := c.GetExchangeHistory(currency.NewPairFromString(testPair), asset.Spot)
+	if err != nil {
+		t.Error("GetExchangeHistory() error", err)
+	}
+}
+
 func TestGetHistoricRatesGranularityCheck(t *testing.T) {
 	end := time.Now().UTC()
 	start := end.Add(-time.Hour * 24)
@@ -925,3 +933,32 @@ func TestStatusToStandardStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestSelfTradePrevention(t *testing.T) {
+	if selfTradePrevention(order.CancelNewest) != "cn" ||
+		selfTradePrevention(order.CancelOldest) != "co" ||
+		selfTradePrevention(order.CancelBoth) != "cb" ||
+		selfTradePrevention("") != "" {
+		t.Error("unexpected self trade prevention flag")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+	code, err := parseError(400, []byte(`{"message":"Insufficient funds"}`))
+	if code != "Insufficient funds" || err != request.ErrInsufficientFunds {
+		t.Errorf("expected insufficient funds, got %s %v", code, err)
+	}
+	if _, err = parseError(404, []byte(`{"message":"NotFound"}`)); err != request.ErrOrderNotFound {
+		t.Errorf("expected order not found, got %v", err)
+	}
+	if _, err = parseError(429, []byte(`{"message":"Private rate limit exceeded"}`)); err != request.ErrRateLimited {
+		t.Errorf("expected rate limited, got %v", err)
+	}
+	if _, err = parseError(400, []byte(`{"message":"request timestamp expired"}`)); err != request.ErrInvalidNonce {
+		t.Errorf("expected invalid nonce, got %v", err)
+	}
+	if _, err = parseError(400, []byte(`{"message":"size is too small"}`)); err != nil {
+		t.Errorf("expected unmapped message, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package coinbasepro
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -112,6 +113,14 @@ func (c *CoinbasePro) SetDefaults() {
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCryptoWithAPIPermission |
 				exchange.AutoWithdrawFiatWithAPIPermission,
+			OrderTypes: []order.Type{
+				order.Limit,
+				order.Market,
+			},
+			RateLimits: []exchange.RateLimit{
+				{Name: "auth", Requests: coinbaseproAuthRate, Interval: coinbaseproRateInterval},
+				{Name: "unauth", Requests: coinbaseproUnauthRate, Interval: coinbaseproRateInterval},
+			},
 		},
 		Enabled: exchange.FeaturesEnabled{
 			AutoPairUpdates: true,
@@ -120,7 +129,8 @@ func (c *CoinbasePro) SetDefaults() {
 
 	c.Requester = request.New(c.Name,
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
-		request.WithLimiter(SetRateLimit()))
+		request.WithLimiter(SetRateLimit()),
+		request.WithErrorParser(parseError))
 
 	c.API.Endpoints.URLDefault = coinbaseproAPIURL
 	c.API.Endpoints.URL = c.API.Endpoints.URLDefault
@@ -200,33 +210,7 @@ func (c *CoinbasePro) Run() {
 		c.PrintEnabledPairs()
 	}
 
-	forceUpdate := false
-	delim := c.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(c.CurrencyPairs.GetPairs(asset.Spot,
-		true).Strings(), delim) ||
-		!common.StringDataContains(c.CurrencyPairs.GetPairs(asset.Spot,
-			false).Strings(), delim) {
-		enabledPairs := currency.NewPairsFromStrings(
-			[]string{currency.BTC.String() + delim + currency.USD.String()},
-		)
-		log.Warn(log.ExchangeSys,
-			"Enabled pairs for CoinbasePro reset due to config upgrade, please enable the ones you would like to use again")
-		forceUpdate = true
-
-		err := c.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s failed to update currencies. Err: %s\n", c.Name, err)
-		}
-	}
-
-	if !c.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := c.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", c.Name, err)
-	}
+	c.UpdateAvailablePairs(c.UpdateTradablePairs, currency.NewPair(currency.BTC, currency.USD), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -379,6 +363,15 @@ func (c *CoinbasePro) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*o
 	return orderbook.Get(c.Name, p, assetType)
 }
 
+// FetchServerTime returns the exchange's server time
+func (c *CoinbasePro) FetchServerTime() (time.Time, error) {
+	t, err := c.GetServerTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(t.Epoch*float64(time.Second))), nil
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (c *CoinbasePro) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -387,7 +380,27 @@ func (c *CoinbasePro) GetFundingHistory() ([]exchange.FundHistory, error) {
 
 // GetExchangeHistory returns historic trade data since exchange opening.
 func (c *CoinbasePro) GetExchangeHistory(p currency.Pair, assetType asset.Item) ([]exchange.TradeHistory, error) {
-	return nil, common.ErrNotYetImplemented
+	trades, err := c.GetTrades(c.FormatExchangeCurrency(p, assetType).String())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		td, errTd := time.Parse(time.RFC3339, trades[i].Time)
+		if errTd != nil {
+			return nil, fmt.Errorf("error parsing trade time: %s", errTd)
+		}
+		resp[i] = exchange.TradeHistory{
+			Timestamp: td,
+			TID:       strconv.FormatInt(trades[i].TradeID, 10),
+			Price:     trades[i].Price,
+			Amount:    trades[i].Size,
+			Exchange:  c.Name,
+			Type:      strings.ToUpper(trades[i].Side),
+		}
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
@@ -406,7 +419,7 @@ func (c *CoinbasePro) SubmitOrder(s *order.Submit) (order.SubmitResponse, error)
 			s.Amount,
 			s.Side.Lower(),
 			c.FormatExchangeCurrency(s.Pair, asset.Spot).String(),
-			"")
+			selfTradePrevention(s.SelfTradePrevention))
 	case order.Limit:
 		response, err = c.PlaceLimitOrder("",
 			s.Price,
@@ -415,7 +428,7 @@ func (c *CoinbasePro) SubmitOrder(s *order.Submit) (order.SubmitResponse, error)
 			"",
 			"",
 			c.FormatExchangeCurrency(s.Pair, asset.Spot).String(),
-			"",
+			selfTradePrevention(s.SelfTradePrevention),
 			false)
 	default:
 		err = errors.New("order type not supported")
@@ -435,6 +448,20 @@ func (c *CoinbasePro) SubmitOrder(s *order.Submit) (order.SubmitResponse, error)
 	return submitOrderResponse, nil
 }
 
+// selfTradePrevention converts a self trade prevention mode to its Coinbase Pro
+// stp flag
+func selfTradePrevention(s order.SelfTradePrevention) string {
+	switch s {
+	case order.CancelNewest:
+		return "cn"
+	case order.CancelOldest:
+		return "co"
+	case order.CancelBoth:
+		return "cb"
+	}
+	return ""
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (c *CoinbasePro) ModifyOrder(action *order.Modify) (string, error) {
@@ -504,7 +531,7 @@ func (c *CoinbasePro) GetOrderInfo(orderID string) (order.Detail, error) {
 		}
 		response.Trades = append(response.Trades, order.TradeHistory{
 			Timestamp: td,
-			TID:       string(fillResponse[i].TradeID),
+			TID:       strconv.Itoa(fillResponse[i].TradeID),
 			Price:     fillResponse[i].Price,
 			Amount:    fillResponse[i].Size,
 			Exchange:  c.GetName(),
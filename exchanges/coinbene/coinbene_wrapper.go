@@ -84,8 +84,9 @@ func (c *Coinbene) SetDefaults() {
 
 	c.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerFetching:    true,
 				TradeFetching:     true,
@@ -203,17 +204,7 @@ func (c *Coinbene) Run() {
 		c.PrintEnabledPairs()
 	}
 
-	if !c.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := c.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s Failed to update tradable pairs. Error: %s",
-			c.Name,
-			err)
-	}
+	c.UpdateAvailablePairs(c.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of exchange tradable pairs
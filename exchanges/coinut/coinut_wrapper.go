@@ -194,33 +194,7 @@ func (c *COINUT) Run() {
 		c.PrintEnabledPairs()
 	}
 
-	forceUpdate := false
-	delim := c.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(c.CurrencyPairs.GetPairs(asset.Spot,
-		true).Strings(), delim) ||
-		!common.StringDataContains(c.CurrencyPairs.GetPairs(asset.Spot,
-			false).Strings(), delim) {
-		enabledPairs := currency.NewPairsFromStrings(
-			[]string{currency.LTC.String() + delim + currency.USDT.String()},
-		)
-		log.Warn(log.ExchangeSys,
-			"Enabled pairs for Coinut reset due to config upgrade, please enable the ones you would like to use again")
-		forceUpdate = true
-
-		err := c.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s failed to update currencies. Err: %s\n", c.Name, err)
-		}
-	}
-
-	if !c.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := c.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", c.Name, err)
-	}
+	c.UpdateAvailablePairs(c.UpdateTradablePairs, currency.NewPair(currency.LTC, currency.USDT), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -0,0 +1,268 @@
+package derivative
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func init() {
+	service = new(Service)
+	service.Items = make(map[string]map[key]*Item)
+	service.Exchange = make(map[string]uuid.UUID)
+	service.mux = dispatch.GetNewMux()
+	service.openInterestHistory = DefaultOpenInterestHistory
+	service.liquidationHistory = DefaultLiquidationHistory
+}
+
+// IsDerivative returns whether an asset type is a derivatives contract with
+// open interest
+func IsDerivative(a asset.Item) bool {
+	switch a {
+	case asset.Futures,
+		asset.PerpetualContract,
+		asset.PerpetualSwap,
+		asset.UpsideProfitContract,
+		asset.DownsideProfitContract:
+		return true
+	}
+	return false
+}
+
+// SetHistoryLength sets the amount of open interest samples and liquidations
+// retained for each exchange, pair and asset. Lengths below one are ignored
+func SetHistoryLength(openInterest, liquidations int) {
+	service.Lock()
+	defer service.Unlock()
+	if openInterest > 0 {
+		service.openInterestHistory = openInterest
+	}
+	if liquidations > 0 {
+		service.liquidationHistory = liquidations
+	}
+}
+
+// SubscribeToItem subscribes to the open interest and liquidation updates of
+// a contract. Updates are received as OpenInterest or Liquidation values
+func SubscribeToItem(exchange string, p currency.Pair, a asset.Item) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	service.RLock()
+	defer service.RUnlock()
+
+	item, ok := service.Items[exchange][newKey(p, a)]
+	if !ok {
+		return dispatch.Pipe{}, fmt.Errorf("derivative item not found for %s %s %s",
+			exchange,
+			p,
+			a)
+	}
+
+	return service.mux.Subscribe(item.Main)
+}
+
+// SubscribeToExchange subscribes to the open interest and liquidation
+// updates of all contracts on an exchange
+func SubscribeToExchange(exchange string) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	service.RLock()
+	defer service.RUnlock()
+	id, ok := service.Exchange[exchange]
+	if !ok {
+		return dispatch.Pipe{}, fmt.Errorf("%s exchange derivatives not found",
+			exchange)
+	}
+
+	return service.mux.Subscribe(id)
+}
+
+// GetOpenInterest returns the latest open interest of a contract
+func GetOpenInterest(exchange string, p currency.Pair, a asset.Item) (*OpenInterest, error) {
+	service.RLock()
+	defer service.RUnlock()
+	item, err := service.getItem(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	if len(item.OpenInterest) == 0 {
+		return nil, fmt.Errorf("no open interest for %s %s %s", exchange, p, a)
+	}
+	oi := item.OpenInterest[len(item.OpenInterest)-1]
+	return &oi, nil
+}
+
+// GetOpenInterestHistory returns a copy of the retained open interest samples
+// of a contract, oldest first
+func GetOpenInterestHistory(exchange string, p currency.Pair, a asset.Item) ([]OpenInterest, error) {
+	service.RLock()
+	defer service.RUnlock()
+	item, err := service.getItem(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	return append([]OpenInterest(nil), item.OpenInterest...), nil
+}
+
+// GetLiquidations returns a copy of the retained liquidations of a contract
+// since the supplied time, oldest first
+func GetLiquidations(exchange string, p currency.Pair, a asset.Item, since time.Time) ([]Liquidation, error) {
+	service.RLock()
+	defer service.RUnlock()
+	item, err := service.getItem(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	var liquidations []Liquidation
+	for i := range item.Liquidations {
+		if item.Liquidations[i].Timestamp.Before(since) {
+			continue
+		}
+		liquidations = append(liquidations, item.Liquidations[i])
+	}
+	return liquidations, nil
+}
+
+// ProcessOpenInterest stores an open interest sample and publishes it to
+// subscribers
+func ProcessOpenInterest(oi *OpenInterest) error {
+	if oi == nil {
+		return errors.New(errDataIsNil)
+	}
+	if err := validate(oi.Exchange, oi.Pair, oi.AssetType); err != nil {
+		return err
+	}
+	oi.Exchange = strings.ToLower(oi.Exchange)
+	if oi.Timestamp.IsZero() {
+		oi.Timestamp = time.Now()
+	}
+
+	service.Lock()
+	item, err := service.setItem(oi.Exchange, oi.Pair, oi.AssetType)
+	if err != nil {
+		service.Unlock()
+		return err
+	}
+	item.OpenInterest = append(item.OpenInterest, *oi)
+	if len(item.OpenInterest) > service.openInterestHistory {
+		item.OpenInterest = item.OpenInterest[len(item.OpenInterest)-service.openInterestHistory:]
+	}
+	ids := append([]uuid.UUID{item.Main}, item.Assoc...)
+	service.Unlock()
+	return service.mux.Publish(ids, oi)
+}
+
+// ProcessLiquidation stores a liquidation and publishes it to subscribers.
+// Liquidations which have already been processed are ignored
+func ProcessLiquidation(l *Liquidation) error {
+	if l == nil {
+		return errors.New(errDataIsNil)
+	}
+	if err := validate(l.Exchange, l.Pair, l.AssetType); err != nil {
+		return err
+	}
+	l.Exchange = strings.ToLower(l.Exchange)
+	if l.Timestamp.IsZero() {
+		l.Timestamp = time.Now()
+	}
+
+	service.Lock()
+	item, err := service.setItem(l.Exchange, l.Pair, l.AssetType)
+	if err != nil {
+		service.Unlock()
+		return err
+	}
+	k := l.key()
+	if _, ok := item.seen[k]; ok {
+		service.Unlock()
+		return nil
+	}
+	item.seen[k] = struct{}{}
+	item.Liquidations = append(item.Liquidations, *l)
+	if excess := len(item.Liquidations) - service.liquidationHistory; excess > 0 {
+		for i := 0; i < excess; i++ {
+			delete(item.seen, item.Liquidations[i].key())
+		}
+		item.Liquidations = item.Liquidations[excess:]
+	}
+	ids := append([]uuid.UUID{item.Main}, item.Assoc...)
+	service.Unlock()
+	return service.mux.Publish(ids, l)
+}
+
+// key returns the exchange's ID for a liquidation or, when it has none, a
+// key built from the liquidation's details
+func (l *Liquidation) key() string {
+	if l.ID != "" {
+		return l.ID
+	}
+	return strconv.FormatInt(l.Timestamp.UnixNano(), 10) + ":" +
+		l.Side.String() + ":" +
+		strconv.FormatFloat(l.Price, 'f', -1, 64) + ":" +
+		strconv.FormatFloat(l.Amount, 'f', -1, 64)
+}
+
+func validate(exchange string, p currency.Pair, a asset.Item) error {
+	if exchange == "" {
+		return errors.New(errExchangeNameUnset)
+	}
+	if p.IsEmpty() {
+		return fmt.Errorf("%s %s", exchange, errPairNotSet)
+	}
+	if a == "" {
+		return fmt.Errorf("%s %s %s", exchange, p, errAssetTypeNotSet)
+	}
+	return nil
+}
+
+func newKey(p currency.Pair, a asset.Item) key {
+	return key{Base: p.Base.Item, Quote: p.Quote.Item, Asset: a}
+}
+
+// getItem returns a stored item, the service must be locked for reading
+func (s *Service) getItem(exchange string, p currency.Pair, a asset.Item) (*Item, error) {
+	item, ok := s.Items[strings.ToLower(exchange)][newKey(p, a)]
+	if !ok {
+		return nil, fmt.Errorf("no derivatives data for %s %s %s", exchange, p, a)
+	}
+	return item, nil
+}
+
+// setItem returns a stored item, creating it and its dispatch IDs when it
+// does not exist. The service must be locked
+func (s *Service) setItem(exchange string, p currency.Pair, a asset.Item) (*Item, error) {
+	k := newKey(p, a)
+	if item, ok := s.Items[exchange][k]; ok {
+		return item, nil
+	}
+
+	exchangeID, ok := s.Exchange[exchange]
+	if !ok {
+		var err error
+		exchangeID, err = s.mux.GetID()
+		if err != nil {
+			return nil, err
+		}
+		s.Exchange[exchange] = exchangeID
+	}
+	mainID, err := s.mux.GetID()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Items[exchange] == nil {
+		s.Items[exchange] = make(map[key]*Item)
+	}
+	item := &Item{
+		Main:  mainID,
+		Assoc: []uuid.UUID{exchangeID},
+		seen:  make(map[string]struct{}),
+	}
+	s.Items[exchange][k] = item
+	return item, nil
+}
@@ -0,0 +1,157 @@
+package derivative
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestMain(m *testing.M) {
+	err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestIsDerivative(t *testing.T) {
+	t.Parallel()
+	if !IsDerivative(asset.PerpetualSwap) || !IsDerivative(asset.Futures) {
+		t.Error("expected perpetual swaps and futures to be derivatives")
+	}
+	if IsDerivative(asset.Spot) || IsDerivative(asset.Index) {
+		t.Error("expected spot and index assets not to be derivatives")
+	}
+}
+
+func TestProcessOpenInterest(t *testing.T) {
+	p := currency.NewPair(currency.XBT, currency.USD)
+	if err := ProcessOpenInterest(nil); err == nil {
+		t.Error("expected error processing nil open interest")
+	}
+	if err := ProcessOpenInterest(&OpenInterest{Pair: p, AssetType: asset.PerpetualContract}); err == nil {
+		t.Error("expected error processing open interest without an exchange")
+	}
+	if err := ProcessOpenInterest(&OpenInterest{Exchange: "OITest", AssetType: asset.PerpetualContract}); err == nil {
+		t.Error("expected error processing open interest without a pair")
+	}
+	if err := ProcessOpenInterest(&OpenInterest{Exchange: "OITest", Pair: p}); err == nil {
+		t.Error("expected error processing open interest without an asset type")
+	}
+	if _, err := GetOpenInterest("OITest", p, asset.PerpetualContract); err == nil {
+		t.Error("expected error getting unprocessed open interest")
+	}
+
+	SetHistoryLength(2, 0)
+	defer SetHistoryLength(DefaultOpenInterestHistory, DefaultLiquidationHistory)
+	for i := 1; i <= 3; i++ {
+		err := ProcessOpenInterest(&OpenInterest{
+			Exchange:  "OITest",
+			Pair:      p,
+			AssetType: asset.PerpetualContract,
+			Amount:    float64(i * 100),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oi, err := GetOpenInterest("oitest", p, asset.PerpetualContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.Amount != 300 || oi.Exchange != "oitest" || oi.Timestamp.IsZero() {
+		t.Errorf("unexpected latest open interest %+v", oi)
+	}
+	history, err := GetOpenInterestHistory("OITest", p, asset.PerpetualContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].Amount != 200 {
+		t.Errorf("expected the 2 most recent samples to be retained, got %+v", history)
+	}
+}
+
+func TestProcessLiquidation(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	start := time.Now().Add(-time.Minute)
+	SetHistoryLength(0, 2)
+	defer SetHistoryLength(DefaultOpenInterestHistory, DefaultLiquidationHistory)
+
+	liquidations := []Liquidation{
+		{ID: "1", Side: order.Sell, Price: 100, Amount: 1, Timestamp: start},
+		{ID: "1", Side: order.Sell, Price: 100, Amount: 1, Timestamp: start},
+		{Side: order.Buy, Price: 110, Amount: 2, Timestamp: start.Add(time.Second)},
+		{Side: order.Buy, Price: 110, Amount: 2, Timestamp: start.Add(time.Second)},
+	}
+	for i := range liquidations {
+		liquidations[i].Exchange = "LiquidationTest"
+		liquidations[i].Pair = p
+		liquidations[i].AssetType = asset.Futures
+		if err := ProcessLiquidation(&liquidations[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l, err := GetLiquidations("LiquidationTest", p, asset.Futures, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 2 || l[0].ID != "1" || l[1].Price != 110 {
+		t.Fatalf("expected duplicate liquidations to be ignored, got %+v", l)
+	}
+	if l, err = GetLiquidations("LiquidationTest", p, asset.Futures, start.Add(time.Second)); err != nil || len(l) != 1 {
+		t.Errorf("expected 1 liquidation since the start, got %d %v", len(l), err)
+	}
+
+	// the oldest liquidation is no longer retained and can be processed again
+	err = ProcessLiquidation(&Liquidation{
+		Exchange:  "LiquidationTest",
+		Pair:      p,
+		AssetType: asset.Futures,
+		ID:        "3",
+		Timestamp: start.Add(time.Second * 2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l, _ = GetLiquidations("LiquidationTest", p, asset.Futures, start); len(l) != 2 || l[0].Price != 110 {
+		t.Errorf("expected the 2 most recent liquidations to be retained, got %+v", l)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	p := currency.NewPair(currency.ETH, currency.USD)
+	if _, err := SubscribeToItem("SubscribeTest", p, asset.PerpetualSwap); err == nil {
+		t.Error("expected error subscribing to an unknown item")
+	}
+	if _, err := SubscribeToExchange("SubscribeTest"); err == nil {
+		t.Error("expected error subscribing to an unknown exchange")
+	}
+
+	err := ProcessOpenInterest(&OpenInterest{Exchange: "SubscribeTest", Pair: p, AssetType: asset.PerpetualSwap, Amount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipe, err := SubscribeToExchange("SubscribeTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipe.Release()
+	itemPipe, err := SubscribeToItem("SubscribeTest", p, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer itemPipe.Release()
+
+	err = ProcessLiquidation(&Liquidation{Exchange: "SubscribeTest", Pair: p, AssetType: asset.PerpetualSwap, ID: "1", Price: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
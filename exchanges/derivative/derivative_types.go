@@ -0,0 +1,132 @@
+package derivative
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// const values for the derivative package
+const (
+	errExchangeNameUnset = "derivative exchange name not set"
+	errPairNotSet        = "derivative currency pair not set"
+	errAssetTypeNotSet   = "derivative asset type not set"
+	errDataIsNil         = "derivative data is nil"
+
+	// DefaultOpenInterestHistory is the default amount of open interest
+	// samples retained for each exchange, pair and asset
+	DefaultOpenInterestHistory = 1440
+	// DefaultLiquidationHistory is the default amount of liquidations
+	// retained for each exchange, pair and asset
+	DefaultLiquidationHistory = 1000
+)
+
+// Vars for the derivative package
+var (
+	service *Service
+)
+
+// Service holds the open interest and liquidations received for each
+// exchange's derivatives contracts
+type Service struct {
+	Items    map[string]map[key]*Item
+	Exchange map[string]uuid.UUID
+	mux      *dispatch.Mux
+
+	openInterestHistory int
+	liquidationHistory  int
+	sync.RWMutex
+}
+
+// key identifies a contract on an exchange
+type key struct {
+	Base  *currency.Item
+	Quote *currency.Item
+	Asset asset.Item
+}
+
+// Item holds the retained open interest and liquidations of a contract
+type Item struct {
+	OpenInterest []OpenInterest
+	Liquidations []Liquidation
+	Main         uuid.UUID
+	Assoc        []uuid.UUID
+	// seen holds the keys of retained liquidations so that liquidations
+	// returned again by an exchange are only processed once
+	seen map[string]struct{}
+}
+
+// OpenInterest is the total amount of open contracts of a derivative at a
+// point in time
+type OpenInterest struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	// Amount is the number of open contracts
+	Amount float64
+	// Value is the notional value of the open contracts in ValueCurrency,
+	// zero when the exchange does not supply it
+	Value         float64
+	ValueCurrency currency.Code
+	Timestamp     time.Time
+}
+
+// Liquidation is a public forced liquidation order
+type Liquidation struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	// ID is the exchange's ID for the liquidation, when the exchange does not
+	// supply one the liquidation is identified by its details
+	ID string
+	// Side is the side of the liquidation order, a sell liquidates a long
+	// position
+	Side      order.Side
+	Price     float64
+	Amount    float64
+	Timestamp time.Time
+}
+
+// FundingPaymentType is the charge a funding payment is for
+type FundingPaymentType string
+
+// Funding payment types
+const (
+	// PerpetualFunding is a funding payment exchanged between the longs and
+	// shorts of a perpetual contract
+	PerpetualFunding FundingPaymentType = "FUNDING"
+	// MarginInterest is the interest charged on a margin loan
+	MarginInterest FundingPaymentType = "INTEREST"
+)
+
+// FundingRate is the funding rate applied to a perpetual contract at a funding
+// time
+type FundingRate struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Rate      float64
+	Timestamp time.Time
+}
+
+// FundingPayment is funding paid or received, or margin interest charged, on
+// an account's position in a contract
+type FundingPayment struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Type      FundingPaymentType
+	// ID is the exchange's ID for the payment. Margin interest which accrues
+	// over the life of a loan keeps the same ID as its amount grows
+	ID       string
+	Currency currency.Code
+	// Amount is positive when received and negative when paid
+	Amount    float64
+	Rate      float64
+	Timestamp time.Time
+}
@@ -7,12 +7,16 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -71,6 +75,20 @@ func (e *Base) GetHTTPClientUserAgent() string {
 	return e.HTTPUserAgent
 }
 
+// SetHTTPClientHeaders sets additional HTTP headers sent with every exchange
+// request, such as broker or affiliate identification. Headers set by the
+// exchange wrapper for a specific request take precedence
+func (e *Base) SetHTTPClientHeaders(headers map[string]string) {
+	e.checkAndInitRequester()
+	e.Requester.Headers = headers
+	e.HTTPHeaders = headers
+}
+
+// GetHTTPClientHeaders gets the exchanges additional HTTP headers
+func (e *Base) GetHTTPClientHeaders() map[string]string {
+	return e.HTTPHeaders
+}
+
 // SetClientProxyAddress sets a proxy address for REST and websocket requests
 func (e *Base) SetClientProxyAddress(addr string) error {
 	if addr != "" {
@@ -115,7 +133,7 @@ func (e *Base) SetFeatureDefaults() {
 			s.Supports.RESTCapabilities.AutoPairUpdates = e.Features.Supports.RESTCapabilities.AutoPairUpdates
 			s.Enabled.AutoPairUpdates = e.Features.Supports.RESTCapabilities.AutoPairUpdates
 			if !s.Supports.RESTCapabilities.AutoPairUpdates {
-				e.Config.CurrencyPairs.LastUpdated = time.Now().Unix()
+				e.Config.CurrencyPairs.LastUpdated = clock.Now().Unix()
 				e.CurrencyPairs.LastUpdated = e.Config.CurrencyPairs.LastUpdated
 			}
 		}
@@ -126,7 +144,7 @@ func (e *Base) SetFeatureDefaults() {
 			e.Config.Features.Supports.RESTCapabilities.AutoPairUpdates = e.Features.Supports.RESTCapabilities.AutoPairUpdates
 
 			if !e.Config.Features.Supports.RESTCapabilities.AutoPairUpdates {
-				e.Config.CurrencyPairs.LastUpdated = time.Now().Unix()
+				e.Config.CurrencyPairs.LastUpdated = clock.Now().Unix()
 			}
 		}
 
@@ -143,6 +161,7 @@ func (e *Base) SetFeatureDefaults() {
 		}
 
 		e.Features.Enabled.AutoPairUpdates = e.Config.Features.Enabled.AutoPairUpdates
+		e.Features.Enabled.AutoEnableQuotes = e.Config.Features.Enabled.AutoEnableQuotes
 	}
 }
 
@@ -313,7 +332,20 @@ func (e *Base) GetEnabledFeatures() FeaturesEnabled {
 
 // GetSupportedFeatures returns the exchanges supported features
 func (e *Base) GetSupportedFeatures() FeaturesSupported {
-	return e.Features.Supports
+	s := e.Features.Supports
+	s.Margin = s.Margin || e.SupportsAsset(asset.Margin)
+	s.OrderTypes = append([]order.Type(nil), e.Features.Supports.OrderTypes...)
+	s.RateLimits = append([]RateLimit(nil), e.Features.Supports.RateLimits...)
+	return s
+}
+
+// GetFeatures returns the exchanges supported and enabled features so callers
+// can adapt their behaviour to the exchange at runtime
+func (e *Base) GetFeatures() Features {
+	return Features{
+		Supports: e.GetSupportedFeatures(),
+		Enabled:  e.GetEnabledFeatures(),
+	}
 }
 
 // GetPairFormat returns the pair format based on the exchange and
@@ -433,6 +465,87 @@ func (e *Base) SetAPIKeys(apiKey, apiSecret, clientID string) {
 	}
 }
 
+// SetAPIKeyPairs sets the additional API keys requests can be signed with.
+// Keys without permissions can sign all requests
+func (e *Base) SetAPIKeyPairs(keys []config.APIKeyConfig) {
+	e.API.Keys = nil
+	for x := range keys {
+		k := APIKey{
+			Name: keys[x].Name,
+			Credentials: Credentials{
+				Key:      keys[x].Key,
+				Secret:   keys[x].Secret,
+				ClientID: keys[x].ClientID,
+				PEMKey:   keys[x].PEMKey,
+			},
+		}
+		if e.API.CredentialsValidator.RequiresBase64DecodeSecret {
+			result, err := crypto.Base64Decode(keys[x].Secret)
+			if err != nil {
+				log.Warnf(log.ExchangeSys,
+					"exchange %s unable to base64 decode secret of API key %q, ignoring.\n",
+					e.Name,
+					keys[x].Name)
+				continue
+			}
+			k.Credentials.Secret = string(result)
+		}
+		for y := range keys[x].Permissions {
+			switch strings.ToLower(keys[x].Permissions[y]) {
+			case config.APIKeyPermissionRead:
+				k.Permissions |= ReadPermission
+			case config.APIKeyPermissionTrade:
+				k.Permissions |= TradePermission
+			}
+		}
+		if k.Permissions == 0 {
+			k.Permissions = ReadPermission | TradePermission
+		}
+		e.API.Keys = append(e.API.Keys, k)
+	}
+}
+
+// GetCredentials returns the credentials to sign a request requiring the
+// permission with. Read requests rotate across every key permitted to read to
+// share the exchange's rate limits between them, other requests always use the
+// first key with the permission so orders stay with one key. The exchange's
+// credentials are returned when no additional key has the permission
+func (e *Base) GetCredentials(p Permission) Credentials {
+	var n uint32
+	for x := range e.API.Keys {
+		if e.API.Keys[x].Permissions&p == p {
+			n++
+		}
+	}
+	if n == 0 {
+		return e.API.Credentials
+	}
+
+	var i uint32
+	if p == ReadPermission {
+		i = (atomic.AddUint32(&e.API.nextKey, 1) - 1) % n
+	}
+	for x := range e.API.Keys {
+		if e.API.Keys[x].Permissions&p != p {
+			continue
+		}
+		if i == 0 {
+			return e.API.Keys[x].Credentials
+		}
+		i--
+	}
+	return e.API.Credentials
+}
+
+// MethodPermission returns the permission an authenticated REST request needs
+// by its HTTP method, for exchanges which only read with GET requests
+func MethodPermission(method string) Permission {
+	if method == http.MethodGet {
+		return ReadPermission
+	}
+	return TradePermission
+}
+
 // SetupDefaults sets the exchange settings based on the supplied config
 func (e *Base) SetupDefaults(exch *config.ExchangeConfig) error {
 	e.Enabled = true
@@ -446,6 +559,7 @@ func (e *Base) SetupDefaults(exch *config.ExchangeConfig) error {
 		e.SetAPIKeys(exch.API.Credentials.Key,
 			exch.API.Credentials.Secret,
 			exch.API.Credentials.ClientID)
+		e.SetAPIKeyPairs(exch.API.Keys)
 	}
 
 	if exch.HTTPTimeout <= time.Duration(0) {
@@ -460,6 +574,10 @@ func (e *Base) SetupDefaults(exch *config.ExchangeConfig) error {
 
 	e.HTTPDebugging = exch.HTTPDebugging
 	e.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+	e.SetHTTPClientHeaders(exch.HTTPHeaders)
+	e.BrokerID = exch.BrokerID
+	e.HeartbeatInterval = exch.HeartbeatInterval
+	e.CancelOnDisconnect = exch.CancelOnDisconnect
 	e.SetAssetTypes()
 	e.SetCurrencyPairFormat()
 	e.SetConfigPairs()
@@ -620,9 +738,88 @@ func (e *Base) UpdatePairs(exchangeProducts currency.Pairs, assetType asset.Item
 		e.Config.CurrencyPairs.StorePairs(assetType, products, enabled)
 		e.CurrencyPairs.StorePairs(assetType, products, enabled)
 	}
+
+	// pairs are only newly listed when there were available pairs to compare
+	// against
+	if !enabled && !force && len(targetPairs) > 0 && len(newPairs) > 0 {
+		e.autoEnablePairs(newPairs, assetType)
+	}
 	return nil
 }
 
+// autoEnablePairs enables newly listed pairs quoted in one of the exchange's
+// auto enable quote currencies
+func (e *Base) autoEnablePairs(newPairs currency.Pairs, assetType asset.Item) {
+	quotes := e.Features.Enabled.AutoEnableQuotes
+	if len(quotes) == 0 {
+		return
+	}
+
+	enabledPairs := e.CurrencyPairs.GetPairs(assetType, true)
+	var added currency.Pairs
+	for x := range newPairs {
+		if !quotes.Contains(newPairs[x].Quote) || enabledPairs.Contains(newPairs[x], true) {
+			continue
+		}
+		added = append(added, newPairs[x])
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	log.Debugf(log.ExchangeSys,
+		"%s Enabling newly listed pairs [%v] - %s.\n", e.Name,
+		strings.ToUpper(assetType.String()), added)
+	enabledPairs = append(append(currency.Pairs(nil), enabledPairs...), added...)
+	e.Config.CurrencyPairs.StorePairs(assetType, enabledPairs, true)
+	e.CurrencyPairs.StorePairs(assetType, enabledPairs, true)
+}
+
+// UpdateAvailablePairs is run by each exchange when it starts to keep its
+// pairs current using its UpdateTradablePairs method. Spot pairs stored
+// without the exchange's configured delimiter are from an older config, as is
+// any config the exchange forces a reset of, and are reset by enabling only
+// defaultPair and refetching the available pairs. Otherwise the available
+// pairs are only updated when auto pair updates are enabled. An empty
+// defaultPair, or an exchange without spot trading, leaves the enabled pairs
+// unchanged
+func (e *Base) UpdateAvailablePairs(update func(forceUpdate bool) error, defaultPair currency.Pair, force bool) {
+	resetSpot := !defaultPair.IsEmpty() && e.SupportsAsset(asset.Spot)
+	var delim string
+	if resetSpot {
+		delim = e.GetPairFormat(asset.Spot, false).Delimiter
+		if !common.StringDataContains(e.CurrencyPairs.GetPairs(asset.Spot, true).Strings(), delim) ||
+			!common.StringDataContains(e.CurrencyPairs.GetPairs(asset.Spot, false).Strings(), delim) {
+			force = true
+		}
+	}
+
+	if force && resetSpot {
+		log.Warnf(log.ExchangeSys,
+			"Available and enabled pairs for %s reset due to config upgrade, please enable the ones you would like to use again.\n",
+			e.Name)
+		defaultPair.Delimiter = delim
+		err := e.UpdatePairs(currency.Pairs{defaultPair}, asset.Spot, true, true)
+		if err != nil {
+			log.Errorf(log.ExchangeSys,
+				"%s failed to update enabled currencies. Err: %s\n",
+				e.Name,
+				err)
+		}
+	}
+
+	if !e.GetEnabledFeatures().AutoPairUpdates && !force {
+		return
+	}
+
+	if err := update(force); err != nil {
+		log.Errorf(log.ExchangeSys,
+			"%s failed to update tradable pairs. Err: %s\n",
+			e.Name,
+			err)
+	}
+}
+
 // SetAPIURL sets configuration API URL for an exchange
 func (e *Base) SetAPIURL() error {
 	if e.Config.API.Endpoints.URL == "" || e.Config.API.Endpoints.URLSecondary == "" {
@@ -766,6 +963,21 @@ func (e *Base) SupportsAsset(a asset.Item) bool {
 	return e.CurrencyPairs.AssetTypes.Contains(a)
 }
 
+// SupportsOrderType returns whether the exchange supports submitting the
+// order type. Exchanges which do not list their supported order types are
+// assumed to support all order types
+func (e *Base) SupportsOrderType(t order.Type) bool {
+	if len(e.Features.Supports.OrderTypes) == 0 {
+		return true
+	}
+	for i := range e.Features.Supports.OrderTypes {
+		if e.Features.Supports.OrderTypes[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
 // PrintEnabledPairs prints the exchanges enabled asset pairs
 func (e *Base) PrintEnabledPairs() {
 	for k, v := range e.CurrencyPairs.Pairs {
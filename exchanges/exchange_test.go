@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
@@ -85,6 +87,12 @@ func TestHTTPClient(t *testing.T) {
 	if !strings.Contains(b.GetHTTPClientUserAgent(), "epicUserAgent") {
 		t.Error("user agent not set properly")
 	}
+
+	b.SetHTTPClientHeaders(map[string]string{"X-Broker-ID": "gct"})
+	if b.GetHTTPClientHeaders()["X-Broker-ID"] != "gct" ||
+		b.Requester.Headers["X-Broker-ID"] != "gct" {
+		t.Error("headers not set properly")
+	}
 }
 
 func TestSetClientProxyAddress(t *testing.T) {
@@ -908,6 +916,72 @@ func TestSetAPIKeys(t *testing.T) {
 	}
 }
 
+func TestSetAPIKeyPairs(t *testing.T) {
+	t.Parallel()
+	var b Base
+	b.API.CredentialsValidator.RequiresBase64DecodeSecret = true
+	b.SetAPIKeyPairs([]config.APIKeyConfig{
+		{Name: "reader", Key: "r", Secret: "aGVsbG8gd29ybGQ=", Permissions: []string{"READ"}},
+		{Name: "invalid", Key: "i", Secret: "%%"},
+		{Name: "all", Key: "a", Secret: "aGVsbG8gd29ybGQ="},
+	})
+	if len(b.API.Keys) != 2 {
+		t.Fatalf("expected the key with an invalid secret to be ignored, got %d keys", len(b.API.Keys))
+	}
+	if b.API.Keys[0].Permissions != ReadPermission ||
+		b.API.Keys[0].Credentials.Secret != "hello world" {
+		t.Errorf("unexpected read key %+v", b.API.Keys[0])
+	}
+	if b.API.Keys[1].Permissions != ReadPermission|TradePermission {
+		t.Error("key without permissions should sign all requests")
+	}
+}
+
+func TestGetCredentials(t *testing.T) {
+	t.Parallel()
+	var b Base
+	b.API.Credentials.Key = "primary"
+	if c := b.GetCredentials(ReadPermission); c.Key != "primary" {
+		t.Errorf("expected the exchange credentials without keys, got %s", c.Key)
+	}
+
+	b.SetAPIKeyPairs([]config.APIKeyConfig{
+		{Key: "read1", Permissions: []string{config.APIKeyPermissionRead}},
+		{Key: "trade", Permissions: []string{config.APIKeyPermissionTrade}},
+		{Key: "read2", Permissions: []string{config.APIKeyPermissionRead}},
+	})
+	var keys []string
+	for x := 0; x < 4; x++ {
+		keys = append(keys, b.GetCredentials(ReadPermission).Key)
+	}
+	if strings.Join(keys, ",") != "read1,read2,read1,read2" {
+		t.Errorf("expected read requests to rotate across read keys, got %v", keys)
+	}
+	for x := 0; x < 2; x++ {
+		if c := b.GetCredentials(TradePermission); c.Key != "trade" {
+			t.Errorf("expected the trade key, got %s", c.Key)
+		}
+	}
+
+	b.SetAPIKeyPairs([]config.APIKeyConfig{
+		{Key: "read", Permissions: []string{config.APIKeyPermissionRead}},
+	})
+	if c := b.GetCredentials(TradePermission); c.Key != "primary" {
+		t.Errorf("expected the exchange credentials without a trade key, got %s", c.Key)
+	}
+}
+
+func TestMethodPermission(t *testing.T) {
+	t.Parallel()
+	if MethodPermission(http.MethodGet) != ReadPermission {
+		t.Error("GET requests should require the read permission")
+	}
+	if MethodPermission(http.MethodPost) != TradePermission ||
+		MethodPermission(http.MethodDelete) != TradePermission {
+		t.Error("requests other than GET should require the trade permission")
+	}
+}
+
 func TestSetupDefaults(t *testing.T) {
 	t.Parallel()
 
@@ -1189,6 +1263,129 @@ func TestUpdatePairs(t *testing.T) {
 	}
 }
 
+func newPairUpdateBase() *Base {
+	b := &Base{
+		Name: "test",
+		Config: &config.ExchangeConfig{
+			CurrencyPairs: &currency.PairsManager{},
+		},
+	}
+	b.CurrencyPairs.UseGlobalFormat = true
+	b.CurrencyPairs.ConfigFormat = &currency.PairFormat{Delimiter: "-", Uppercase: true}
+	b.CurrencyPairs.AssetTypes = asset.Items{asset.Spot}
+	b.CurrencyPairs.StorePairs(asset.Spot,
+		currency.Pairs{currency.NewPairWithDelimiter("BTC", "USD", "-")}, true)
+	b.CurrencyPairs.StorePairs(asset.Spot,
+		currency.Pairs{currency.NewPairWithDelimiter("BTC", "USD", "-")}, false)
+	return b
+}
+
+func TestUpdatePairsAutoEnable(t *testing.T) {
+	t.Parallel()
+	b := newPairUpdateBase()
+	b.Features.Enabled.AutoEnableQuotes = currency.Currencies{currency.USDT}
+
+	usdt := currency.NewPairWithDelimiter("LTC", "USDT", "-")
+	eur := currency.NewPairWithDelimiter("LTC", "EUR", "-")
+	err := b.UpdatePairs(currency.Pairs{
+		currency.NewPairWithDelimiter("BTC", "USD", "-"), usdt, eur,
+	}, asset.Spot, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabled := b.GetEnabledPairs(asset.Spot)
+	if !enabled.Contains(usdt, true) {
+		t.Error("newly listed pair quoted in an auto enable quote should be enabled")
+	}
+	if enabled.Contains(eur, true) {
+		t.Error("newly listed pair not quoted in an auto enable quote should not be enabled")
+	}
+	if !b.Config.CurrencyPairs.GetPairs(asset.Spot, true).Contains(usdt, true) {
+		t.Error("auto enabled pair should be stored in the config")
+	}
+
+	// forced updates replace the available pairs so are not newly listed
+	b = newPairUpdateBase()
+	b.Features.Enabled.AutoEnableQuotes = currency.Currencies{currency.USDT}
+	err = b.UpdatePairs(currency.Pairs{usdt}, asset.Spot, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.GetEnabledPairs(asset.Spot).Contains(usdt, true) {
+		t.Error("forced update should not auto enable pairs")
+	}
+}
+
+func TestUpdateAvailablePairs(t *testing.T) {
+	t.Parallel()
+	b := newPairUpdateBase()
+	var called, forced bool
+	update := func(force bool) error {
+		called, forced = true, force
+		return nil
+	}
+
+	b.UpdateAvailablePairs(update, currency.NewPair(currency.BTC, currency.USD), false)
+	if called {
+		t.Error("update should not be called with auto pair updates disabled")
+	}
+
+	b.Features.Enabled.AutoPairUpdates = true
+	b.UpdateAvailablePairs(update, currency.Pair{}, false)
+	if !called || forced {
+		t.Error("update should be called without forcing")
+	}
+
+	// pairs stored without the configured delimiter are reset to the default
+	b.Features.Enabled.AutoPairUpdates = false
+	called = false
+	b.CurrencyPairs.StorePairs(asset.Spot,
+		currency.Pairs{currency.NewPairWithDelimiter("LTC", "BTC", "")}, true)
+	b.UpdateAvailablePairs(update, currency.NewPair(currency.ETH, currency.USD), false)
+	if !called || !forced {
+		t.Error("update should be forced when the stored pairs need a reset")
+	}
+	enabled := b.GetEnabledPairs(asset.Spot)
+	if len(enabled) != 1 || enabled[0].String() != "ETH-USD" {
+		t.Errorf("enabled pairs should be reset to the default pair, got %v", enabled)
+	}
+
+	b.UpdateAvailablePairs(func(bool) error { return errors.New("test") },
+		currency.Pair{}, true)
+}
+
+func TestUpdateAvailablePairsNonSpot(t *testing.T) {
+	t.Parallel()
+	b := &Base{
+		Name: "test",
+		Config: &config.ExchangeConfig{
+			CurrencyPairs: &currency.PairsManager{},
+		},
+	}
+	pair := currency.NewPairWithDelimiter("XBT", "USD", "")
+	b.CurrencyPairs.Store(asset.PerpetualContract, currency.PairStore{
+		Enabled:       currency.Pairs{pair},
+		Available:     currency.Pairs{pair},
+		ConfigFormat:  &currency.PairFormat{Uppercase: true},
+		RequestFormat: &currency.PairFormat{Uppercase: true},
+	})
+	b.Features.Enabled.AutoPairUpdates = true
+
+	var called, forced bool
+	update := func(force bool) error {
+		called, forced = true, force
+		return nil
+	}
+	b.UpdateAvailablePairs(update, currency.NewPair(currency.BTC, currency.USD), false)
+	if !called || forced {
+		t.Error("update should be called without forcing a reset of spot pairs")
+	}
+	enabled := b.GetEnabledPairs(asset.PerpetualContract)
+	if len(enabled) != 1 || !enabled[0].Equal(pair) {
+		t.Errorf("enabled pairs should be unchanged, got %v", enabled)
+	}
+}
+
 func TestSetAPIURL(t *testing.T) {
 	t.Parallel()
 
@@ -1390,6 +1587,41 @@ func TestSupportsAsset(t *testing.T) {
 	}
 }
 
+func TestGetFeaturesCopy(t *testing.T) {
+	t.Parallel()
+	var b Base
+	b.Features.Supports.OrderTypes = []order.Type{order.Limit}
+	b.Features.Supports.RateLimits = []RateLimit{{Name: "global", Requests: 1, Interval: time.Second}}
+	f := b.GetFeatures()
+	if f.Supports.Margin {
+		t.Error("margin shouldn't be supported")
+	}
+	f.Supports.OrderTypes[0] = order.Market
+	if b.Features.Supports.OrderTypes[0] != order.Limit {
+		t.Error("features should be a copy")
+	}
+
+	b.CurrencyPairs.AssetTypes = asset.Items{asset.Margin}
+	if !b.GetFeatures().Supports.Margin {
+		t.Error("margin should be supported when the margin asset is")
+	}
+}
+
+func TestSupportsOrderType(t *testing.T) {
+	t.Parallel()
+	var b Base
+	if !b.SupportsOrderType(order.Stop) {
+		t.Error("all order types should be supported when none are listed")
+	}
+	b.Features.Supports.OrderTypes = []order.Type{order.Limit}
+	if !b.SupportsOrderType(order.Limit) {
+		t.Error("limit orders should be supported")
+	}
+	if b.SupportsOrderType(order.Market) {
+		t.Error("market orders shouldn't be supported")
+	}
+}
+
 func TestPrintEnabledPairs(t *testing.T) {
 	t.Parallel()
 
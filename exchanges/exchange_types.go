@@ -5,6 +5,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
@@ -146,7 +147,8 @@ type Features struct {
 
 // FeaturesEnabled stores the exchange enabled features
 type FeaturesEnabled struct {
-	AutoPairUpdates bool
+	AutoPairUpdates  bool
+	AutoEnableQuotes currency.Currencies
 }
 
 // FeaturesSupported stores the exchanges supported features
@@ -156,6 +158,21 @@ type FeaturesSupported struct {
 	Websocket             bool
 	WebsocketCapabilities protocol.Features
 	WithdrawPermissions   uint32
+	Margin                bool
+	// OrderTypes lists the order types which can be submitted, all order
+	// types are assumed to be supported when empty
+	OrderTypes []order.Type
+	// ClientOrderIDs is whether orders can be submitted with a client order
+	// ID, the order manager generates one for orders submitted without
+	ClientOrderIDs bool
+	RateLimits     []RateLimit
+}
+
+// RateLimit stores an exchange's documented request rate limit
+type RateLimit struct {
+	Name     string
+	Requests int
+	Interval time.Duration
 }
 
 // API stores the exchange API settings
@@ -172,12 +189,10 @@ type API struct {
 		WebsocketURL        string
 	}
 
-	Credentials struct {
-		Key      string
-		Secret   string
-		ClientID string
-		PEMKey   string
-	}
+	Credentials Credentials
+	Keys        []APIKey
+	// nextKey rotates read requests across the keys
+	nextKey uint32
 
 	CredentialsValidator struct {
 		// For Huobi (optional)
@@ -190,6 +205,37 @@ type API struct {
 	}
 }
 
+// Permission is a type of authenticated request an API key can sign
+type Permission uint8
+
+// Authenticated request permissions
+const (
+	ReadPermission Permission = 1 << iota
+	TradePermission
+)
+
+// Credentials are the API credentials requests are signed with
+type Credentials struct {
+	Key      string
+	Secret   string
+	ClientID string
+	PEMKey   string
+}
+
+// APIKey is an additional API key and the permissions of the requests it
+// signs
+type APIKey struct {
+	Name        string
+	Credentials Credentials
+	Permissions Permission
+}
+
+// KeyPermissions are the permissions an exchange reports its API key has
+type KeyPermissions struct {
+	Trade    bool
+	Withdraw bool
+}
+
 // Base stores the individual exchange information
 type Base struct {
 	Name                          string
@@ -203,6 +249,10 @@ type Base struct {
 	Features                      Features
 	HTTPTimeout                   time.Duration
 	HTTPUserAgent                 string
+	HTTPHeaders                   map[string]string
+	BrokerID                      string
+	HeartbeatInterval             time.Duration
+	CancelOnDisconnect            bool
 	HTTPRecording                 bool
 	HTTPDebugging                 bool
 	WebsocketResponseCheckTimeout time.Duration
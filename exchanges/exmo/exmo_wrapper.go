@@ -139,14 +139,7 @@ func (e *EXMO) Run() {
 		e.PrintEnabledPairs()
 	}
 
-	if !e.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := e.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", e.Name, err)
-	}
+	e.UpdateAvailablePairs(e.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/common/decimal"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -22,9 +25,15 @@ const (
 	geminiAPIURL        = "https://api.gemini.com"
 	geminiSandboxAPIURL = "https://api.sandbox.gemini.com"
 	geminiAPIVersion    = "1"
+	// geminiAPIVersion2 serves market data with richer responses, such as
+	// tickers with open, high and low prices and candles. Trading remains on
+	// version 1 of the API
+	geminiAPIVersion2 = "2"
 
 	geminiSymbols            = "symbols"
 	geminiTicker             = "pubticker"
+	geminiTickerV2           = "ticker"
+	geminiCandles            = "candles"
 	geminiAuction            = "auction"
 	geminiAuctionHistory     = "history"
 	geminiOrderbook          = "book"
@@ -41,12 +50,32 @@ const (
 	geminiDeposit            = "deposit"
 	geminiNewAddress         = "newAddress"
 	geminiWithdraw           = "withdraw/"
+	geminiTransfers          = "transfers"
 	geminiHeartbeat          = "heartbeat"
 	geminiVolume             = "notionalvolume"
 
+	// geminiMaxTradesLimit is the most trades returned per trade history
+	// request
+	geminiMaxTradesLimit = 500
+
+	// geminiMaxTransfersLimit is the most transfers returned per transfers
+	// request
+	geminiMaxTransfersLimit = 50
+
 	// Too many requests returns this
 	geminiRateError = "429"
 
+	// Orders of sessions which require heartbeats are cancelled when no
+	// heartbeat is received within the timeout
+	geminiHeartbeatTimeout         = 30 * time.Second
+	geminiDefaultHeartbeatInterval = 15 * time.Second
+
+	// Order execution options, only one may be used per order
+	geminiMakerOrCancel     = "maker-or-cancel"
+	geminiImmediateOrCancel = "immediate-or-cancel"
+	geminiFillOrKill        = "fill-or-kill"
+	geminiAuctionOnly       = "auction-only"
+
 	// Assigned API key roles on creation
 	geminiRoleTrader      = "trader"
 	geminiRoleFundManager = "fundmanager"
@@ -63,19 +92,22 @@ type Gemini struct {
 	exchange.Base
 	Role              string
 	RequiresHeartBeat bool
+
+	heartbeatShutdown chan struct{}
+	heartbeatMtx      sync.Mutex
 }
 
 // GetSymbols returns all available symbols for trading
 func (g *Gemini) GetSymbols() ([]string, error) {
 	var symbols []string
-	path := fmt.Sprintf("%s/v%s/%s", g.API.Endpoints.URL, geminiAPIVersion, geminiSymbols)
+	path := g.apiPath(geminiAPIVersion, geminiSymbols)
 	return symbols, g.SendHTTPRequest(path, &symbols)
 }
 
 // GetTicker returns information about recent trading activity for the symbol
 func (g *Gemini) GetTicker(currencyPair string) (TickerV2, error) {
 	ticker := TickerV2{}
-	path := fmt.Sprintf("%s/v2/ticker/%s", g.API.Endpoints.URL, currencyPair)
+	path := g.apiPath(geminiAPIVersion2, geminiTickerV2, currencyPair)
 	err := g.SendHTTPRequest(path, &ticker)
 	if err != nil {
 		return ticker, err
@@ -90,19 +122,23 @@ func (g *Gemini) GetTicker(currencyPair string) (TickerV2, error) {
 	return ticker, nil
 }
 
+// GetCandles returns the most recent candles of a symbol, newest first. Older
+// candles are not available
+//
+// timeFrame - one of 1m, 5m, 15m, 30m, 1hr, 6hr or 1day
+func (g *Gemini) GetCandles(currencyPair, timeFrame string) ([]Candle, error) {
+	var candles []Candle
+	path := g.apiPath(geminiAPIVersion2, geminiCandles, currencyPair, timeFrame)
+	return candles, g.SendHTTPRequest(path, &candles)
+}
+
 // GetOrderbook returns the current order book, as two arrays, one of bids, and
 // one of asks
 //
 // params - limit_bids or limit_asks [OPTIONAL] default 50, 0 returns all Values
 // Type is an integer ie "params.Set("limit_asks", 30)"
 func (g *Gemini) GetOrderbook(currencyPair string, params url.Values) (Orderbook, error) {
-	path := common.EncodeURLValues(
-		fmt.Sprintf("%s/v%s/%s/%s",
-			g.API.Endpoints.URL,
-			geminiAPIVersion,
-			geminiOrderbook,
-			currencyPair),
-		params)
+	path := common.EncodeURLValues(g.apiPath(geminiAPIVersion, geminiOrderbook, currencyPair), params)
 
 	var orderbook Orderbook
 	return orderbook, g.SendHTTPRequest(path, &orderbook)
@@ -118,7 +154,7 @@ func (g *Gemini) GetOrderbook(currencyPair string, params url.Values) (Orderbook
 // include_breaks	boolean	Optional. Whether to display broken trades. False by
 // default. Can be '1' or 'true' to activate
 func (g *Gemini) GetTrades(currencyPair string, params url.Values) ([]Trade, error) {
-	path := common.EncodeURLValues(fmt.Sprintf("%s/v%s/%s/%s", g.API.Endpoints.URL, geminiAPIVersion, geminiTrades, currencyPair), params)
+	path := common.EncodeURLValues(g.apiPath(geminiAPIVersion, geminiTrades, currencyPair), params)
 	var trades []Trade
 
 	return trades, g.SendHTTPRequest(path, &trades)
@@ -126,7 +162,7 @@ func (g *Gemini) GetTrades(currencyPair string, params url.Values) ([]Trade, err
 
 // GetAuction returns auction information
 func (g *Gemini) GetAuction(currencyPair string) (Auction, error) {
-	path := fmt.Sprintf("%s/v%s/%s/%s", g.API.Endpoints.URL, geminiAPIVersion, geminiAuction, currencyPair)
+	path := g.apiPath(geminiAPIVersion, geminiAuction, currencyPair)
 	auction := Auction{}
 
 	return auction, g.SendHTTPRequest(path, &auction)
@@ -144,20 +180,35 @@ func (g *Gemini) GetAuction(currencyPair string) (Auction, error) {
 //          include_indicative - [bool] Whether to include publication of
 // indicative prices and quantities.
 func (g *Gemini) GetAuctionHistory(currencyPair string, params url.Values) ([]AuctionHistory, error) {
-	path := common.EncodeURLValues(fmt.Sprintf("%s/v%s/%s/%s/%s", g.API.Endpoints.URL, geminiAPIVersion, geminiAuction, currencyPair, geminiAuctionHistory), params)
+	path := common.EncodeURLValues(g.apiPath(geminiAPIVersion, geminiAuction, currencyPair, geminiAuctionHistory), params)
 	var auctionHist []AuctionHistory
 	return auctionHist, g.SendHTTPRequest(path, &auctionHist)
 }
 
 // NewOrder Only limit orders are supported through the API at present.
 // returns order ID if successful
-func (g *Gemini) NewOrder(symbol string, amount, price float64, side, orderType string) (int64, error) {
+//
+// clientOrderID -- [optional] an ID returned with the order so it can be
+// identified if the response is lost
+// options -- [optional] a single order execution option of maker-or-cancel,
+// immediate-or-cancel, fill-or-kill or auction-only
+func (g *Gemini) NewOrder(symbol string, amount, price decimal.Decimal, side, orderType, clientOrderID string, options []string) (int64, error) {
+	if len(options) > 1 {
+		return 0, errors.New("only one order execution option may be set")
+	}
+
 	req := make(map[string]interface{})
 	req["symbol"] = symbol
-	req["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
-	req["price"] = strconv.FormatFloat(price, 'f', -1, 64)
+	req["amount"] = amount.String()
+	req["price"] = price.String()
 	req["side"] = side
 	req["type"] = orderType
+	if clientOrderID != "" {
+		req["client_order_id"] = clientOrderID
+	}
+	if len(options) > 0 {
+		req["options"] = options
+	}
 
 	response := Order{}
 	err := g.SendAuthenticatedHTTPRequest(http.MethodPost, geminiOrderNew, req, &response)
@@ -249,7 +300,9 @@ func (g *Gemini) GetOrders() ([]Order, error) {
 //
 // currencyPair - example "btcusd"
 // timestamp - [optional] Only return trades on or after this timestamp.
-func (g *Gemini) GetTradeHistory(currencyPair string, timestamp int64) ([]TradeHistory, error) {
+// limit - [optional] the maximum amount of trades returned, defaults to 50 and
+// is capped at 500
+func (g *Gemini) GetTradeHistory(currencyPair string, timestamp int64, limit int) ([]TradeHistory, error) {
 	var response []TradeHistory
 	req := make(map[string]interface{})
 	req["symbol"] = currencyPair
@@ -257,6 +310,9 @@ func (g *Gemini) GetTradeHistory(currencyPair string, timestamp int64) ([]TradeH
 	if timestamp > 0 {
 		req["timestamp"] = timestamp
 	}
+	if limit > 0 {
+		req["limit_trades"] = limit
+	}
 
 	return response,
 		g.SendAuthenticatedHTTPRequest(http.MethodPost, geminiMyTrades, req, &response)
@@ -286,6 +342,25 @@ func (g *Gemini) GetBalances() ([]Balance, error) {
 		g.SendAuthenticatedHTTPRequest(http.MethodPost, geminiBalances, nil, &response)
 }
 
+// GetTransfers returns the account's deposits and withdrawals, most recent
+// first
+// timestamp - [optional] Only return transfers on or after this timestamp.
+// limit - [optional] the maximum amount of transfers returned, defaults to 10
+// and is capped at 50
+func (g *Gemini) GetTransfers(timestamp int64, limit int) ([]Transfer, error) {
+	var response []Transfer
+	req := make(map[string]interface{})
+	if timestamp > 0 {
+		req["timestamp"] = timestamp
+	}
+	if limit > 0 {
+		req["limit_transfers"] = limit
+	}
+
+	return response,
+		g.SendAuthenticatedHTTPRequest(http.MethodPost, geminiTransfers, req, &response)
+}
+
 // GetCryptoDepositAddress returns a deposit address
 func (g *Gemini) GetCryptoDepositAddress(depositAddlabel, currency string) (DepositAddress, error) {
 	response := DepositAddress{}
@@ -306,11 +381,11 @@ func (g *Gemini) GetCryptoDepositAddress(depositAddlabel, currency string) (Depo
 }
 
 // WithdrawCrypto withdraws crypto currency to a whitelisted address
-func (g *Gemini) WithdrawCrypto(address, currency string, amount float64) (WithdrawalAddress, error) {
+func (g *Gemini) WithdrawCrypto(address, currency string, amount decimal.Decimal) (WithdrawalAddress, error) {
 	response := WithdrawalAddress{}
 	req := make(map[string]interface{})
 	req["address"] = address
-	req["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	req["amount"] = amount.String()
 
 	err := g.SendAuthenticatedHTTPRequest(http.MethodPost, geminiWithdraw+strings.ToLower(currency), req, &response)
 	if err != nil {
@@ -341,6 +416,116 @@ func (g *Gemini) PostHeartbeat() (string, error) {
 	return response.Result, nil
 }
 
+// StartHeartbeat starts sending heartbeats at the heartbeat interval for
+// sessions created with "Require Heartbeat" enabled, otherwise it does nothing
+func (g *Gemini) StartHeartbeat(onFailure func(error)) error {
+	if !g.RequiresHeartBeat || !g.AllowAuthenticatedRequest() {
+		return nil
+	}
+
+	g.heartbeatMtx.Lock()
+	defer g.heartbeatMtx.Unlock()
+	if g.heartbeatShutdown != nil {
+		return errors.New("heartbeat already started")
+	}
+	g.heartbeatShutdown = make(chan struct{})
+	go g.heartbeat(g.heartbeatShutdown, onFailure)
+	return nil
+}
+
+// StopHeartbeat stops sending heartbeats
+func (g *Gemini) StopHeartbeat() {
+	g.heartbeatMtx.Lock()
+	defer g.heartbeatMtx.Unlock()
+	if g.heartbeatShutdown == nil {
+		return
+	}
+	close(g.heartbeatShutdown)
+	g.heartbeatShutdown = nil
+}
+
+// heartbeat sends heartbeats until shutdown, calling onFailure when a
+// heartbeat fails after succeeding
+func (g *Gemini) heartbeat(shutdown chan struct{}, onFailure func(error)) {
+	tick := time.NewTicker(g.HeartbeatInterval)
+	defer tick.Stop()
+	var failing bool
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-tick.C:
+			_, err := g.PostHeartbeat()
+			if err != nil {
+				log.Errorf(log.ExchangeSys,
+					"%s heartbeat failed, open orders will be cancelled by the exchange if heartbeats fail for %v. Err: %s\n",
+					g.Name,
+					geminiHeartbeatTimeout,
+					err)
+				if !failing && onFailure != nil {
+					onFailure(err)
+				}
+				failing = true
+				continue
+			}
+			if failing {
+				log.Infof(log.ExchangeSys, "%s heartbeat recovered.\n", g.Name)
+			}
+			failing = false
+		}
+	}
+}
+
+// apiPath returns the URL of an endpoint of a version of the API
+func (g *Gemini) apiPath(version string, path ...string) string {
+	return g.API.Endpoints.URL + "/v" + version + "/" + strings.Join(path, "/")
+}
+
+// candleTimeFrame returns the candle time frame of an interval
+func candleTimeFrame(interval time.Duration) (string, error) {
+	switch interval {
+	case kline.OneMin:
+		return "1m", nil
+	case kline.FiveMin:
+		return "5m", nil
+	case kline.FifteenMin:
+		return "15m", nil
+	case kline.ThirtyMin:
+		return "30m", nil
+	case kline.OneHour:
+		return "1hr", nil
+	case kline.SixHour:
+		return "6hr", nil
+	case kline.OneDay:
+		return "1day", nil
+	default:
+		return "", errInvalidInterval
+	}
+}
+
+// UnmarshalJSON decodes a candle from its array of time in milliseconds,
+// open, high, low, close and volume
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 6 {
+		return fmt.Errorf("invalid candle %s", data)
+	}
+	var ms float64
+	if err := json.Unmarshal(fields[0], &ms); err != nil {
+		return err
+	}
+	c.Time = time.Unix(0, int64(ms)*int64(time.Millisecond))
+	for i, v := range []*decimal.Decimal{&c.Open, &c.High, &c.Low, &c.Close, &c.Volume} {
+		if err := v.UnmarshalJSON(fields[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SendHTTPRequest sends an unauthenticated request
 func (g *Gemini) SendHTTPRequest(path string, result interface{}) error {
 	return g.SendPayload(context.Background(), &request.Item{
@@ -390,7 +575,7 @@ func (g *Gemini) SendAuthenticatedHTTPRequest(method, path string, params map[st
 
 	return g.SendPayload(context.Background(), &request.Item{
 		Method:        method,
-		Path:          g.API.Endpoints.URL + "/v1/" + path,
+		Path:          g.apiPath(geminiAPIVersion, path),
 		Headers:       headers,
 		Result:        result,
 		AuthRequest:   true,
@@ -402,6 +587,25 @@ func (g *Gemini) SendAuthenticatedHTTPRequest(method, path string, params map[st
 	})
 }
 
+// parseError maps Gemini's error response reason to an exchange API error type
+func parseError(_ int, body []byte) (string, error) {
+	var e ErrorCapture
+	if err := json.Unmarshal(body, &e); err != nil || e.Result != "error" {
+		return "", nil
+	}
+	switch e.Reason {
+	case "RateLimit", "RateLimited":
+		return e.Reason, request.ErrRateLimited
+	case "InsufficientFunds":
+		return e.Reason, request.ErrInsufficientFunds
+	case "InvalidNonce":
+		return e.Reason, request.ErrInvalidNonce
+	case "OrderNotFound":
+		return e.Reason, request.ErrOrderNotFound
+	}
+	return e.Reason, nil
+}
+
 // GetFee returns an estimate of fee based on type of transaction
 func (g *Gemini) GetFee(feeBuilder *exchange.FeeBuilder) (float64, error) {
 	var fee float64
@@ -7,10 +7,13 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/decimal"
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -49,6 +52,51 @@ func TestGetTicker(t *testing.T) {
 	}
 }
 
+func TestGetCandles(t *testing.T) {
+	t.Parallel()
+	candles, err := g.GetCandles("BTCUSD", "1m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) == 0 {
+		t.Fatal("expected candles")
+	}
+	if mockTests && (!candles[0].Time.Equal(time.Unix(1590000120, 0)) ||
+		candles[0].Close.String() != "9662.94" ||
+		candles[0].Volume.String() != "0.65712466") {
+		t.Errorf("unexpected candle %+v", candles[0])
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	if _, err := g.GetHistoricCandles(p, asset.Spot, time.Unix(0, 0), time.Now(), time.Minute*2); err != errInvalidInterval {
+		t.Errorf("expected %v, got %v", errInvalidInterval, err)
+	}
+	k, err := g.GetHistoricCandles(p, asset.Spot, time.Unix(0, 0), time.Now(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k.Candles) == 0 {
+		t.Fatal("expected candles")
+	}
+	for x := 1; x < len(k.Candles); x++ {
+		if !k.Candles[x].Time.After(k.Candles[x-1].Time) {
+			t.Fatal("expected candles to be returned oldest first")
+		}
+	}
+	if mockTests {
+		k, err = g.GetHistoricCandles(p, asset.Spot, time.Unix(1590000060, 0), time.Unix(1590000120, 0), time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(k.Candles) != 1 || k.Candles[0].Open != 9660.12 || k.Candles[0].Volume != 1.2013 {
+			t.Errorf("expected the candles between start and end, got %+v", k.Candles)
+		}
+	}
+}
+
 func TestGetOrderbook(t *testing.T) {
 	t.Parallel()
 	_, err := g.GetOrderbook(testCurrency, url.Values{})
@@ -65,6 +113,17 @@ func TestGetTrades(t *testing.T) {
 	}
 }
 
+func TestGetExchangeHistory(t *testing.T) {
+	t.Parallel()
+	trades, err := g.GetExchangeHistory(currency.NewPairFromString(testCurrency), asset.Spot)
+	if err != nil {
+		t.Fatal("GetExchangeHistory() error", err)
+	}
+	if len(trades) == 0 || trades[0].TID == "" || trades[0].Timestamp.IsZero() {
+		t.Errorf("GetExchangeHistory() unexpected trades %+v", trades)
+	}
+}
+
 func TestGetNotionalVolume(t *testing.T) {
 	t.Parallel()
 	_, err := g.GetNotionalVolume()
@@ -94,15 +153,28 @@ func TestGetAuctionHistory(t *testing.T) {
 func TestNewOrder(t *testing.T) {
 	t.Parallel()
 	_, err := g.NewOrder(testCurrency,
-		1,
-		9000000,
+		decimal.New(1, 0),
+		decimal.New(9000000, 0),
 		order.Sell.Lower(),
-		"exchange limit")
+		"exchange limit",
+		"",
+		nil)
 	if err != nil && mockTests {
 		t.Error("NewOrder() error", err)
 	} else if err == nil && !mockTests {
 		t.Error("NewOrder() error cannot be nil")
 	}
+
+	_, err = g.NewOrder(testCurrency,
+		decimal.New(1, 0),
+		decimal.New(9000000, 0),
+		order.Sell.Lower(),
+		"exchange limit",
+		"",
+		[]string{geminiMakerOrCancel, geminiAuctionOnly})
+	if err == nil {
+		t.Error("NewOrder() expected an error when multiple options are set")
+	}
 }
 
 func TestCancelExistingOrder(t *testing.T) {
@@ -147,7 +219,7 @@ func TestGetOrders(t *testing.T) {
 
 func TestGetTradeHistory(t *testing.T) {
 	t.Parallel()
-	_, err := g.GetTradeHistory(testCurrency, 0)
+	_, err := g.GetTradeHistory(testCurrency, 0, 0)
 	if err != nil && mockTests {
 		t.Error("GetTradeHistory() error", err)
 	} else if err == nil && !mockTests {
@@ -155,6 +227,64 @@ func TestGetTradeHistory(t *testing.T) {
 	}
 }
 
+func TestGetTradeHistoryPage(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPairFromString(testCurrency)
+	if _, err := g.GetTradeHistoryPage(p, asset.Futures, time.Time{}); err == nil {
+		t.Error("GetTradeHistoryPage() expected an error for a non spot asset")
+	}
+}
+
+func TestGetOrderLimits(t *testing.T) {
+	t.Parallel()
+	if _, err := g.GetOrderLimits(asset.Futures); err != common.ErrFunctionNotSupported {
+		t.Errorf("expected %v, got %v", common.ErrFunctionNotSupported, err)
+	}
+	limits, err := g.GetOrderLimits(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := range limits {
+		if !limits[x].Pair.Equal(currency.NewPair(currency.BTC, currency.USD)) {
+			continue
+		}
+		if limits[x].MinAmount != 0.00001 || limits[x].PriceIncrement != 0.01 {
+			t.Errorf("unexpected BTCUSD limits %+v", limits[x])
+		}
+		return
+	}
+	t.Error("expected BTCUSD limits")
+}
+
+func TestGetTransfers(t *testing.T) {
+	t.Parallel()
+	_, err := g.GetTransfers(0, geminiMaxTransfersLimit)
+	if err != nil && mockTests {
+		t.Error("GetTransfers() error", err)
+	} else if err == nil && !mockTests {
+		t.Error("GetTransfers() error cannot be nil")
+	}
+}
+
+func TestGetFundingHistory(t *testing.T) {
+	t.Parallel()
+	history, err := g.GetFundingHistory()
+	if !mockTests {
+		if err == nil {
+			t.Error("GetFundingHistory() error cannot be nil")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal("GetFundingHistory() error", err)
+	}
+	if len(history) != 2 ||
+		history[0].TransferID != "320013281" ||
+		history[1].CryptoToAddress == "" {
+		t.Errorf("GetFundingHistory() unexpected history %+v", history)
+	}
+}
+
 func TestGetTradeVolume(t *testing.T) {
 	t.Parallel()
 	_, err := g.GetTradeVolume()
@@ -175,6 +305,28 @@ func TestGetBalances(t *testing.T) {
 	}
 }
 
+func TestUpdateAccountInfo(t *testing.T) {
+	t.Parallel()
+	resp, err := g.UpdateAccountInfo()
+	if err != nil && mockTests {
+		t.Fatal("UpdateAccountInfo() error", err)
+	} else if err == nil && !mockTests {
+		t.Error("UpdateAccountInfo() error cannot be nil")
+	}
+	if !mockTests {
+		return
+	}
+
+	if len(resp.Accounts) == 0 || resp.Accounts[0].ID != "exchange" {
+		t.Fatalf("expected balances grouped by account type, got %+v", resp.Accounts)
+	}
+	for _, c := range resp.Accounts[0].Currencies {
+		if c.Available+c.Hold != c.TotalValue {
+			t.Errorf("expected available and hold to sum to total for %s", c.CurrencyName)
+		}
+	}
+}
+
 func TestGetCryptoDepositAddress(t *testing.T) {
 	t.Parallel()
 	_, err := g.GetCryptoDepositAddress("LOL123", "btc")
@@ -185,7 +337,7 @@ func TestGetCryptoDepositAddress(t *testing.T) {
 
 func TestWithdrawCrypto(t *testing.T) {
 	t.Parallel()
-	_, err := g.WithdrawCrypto("LOL123", "btc", 1)
+	_, err := g.WithdrawCrypto("LOL123", "btc", decimal.New(1, 0))
 	if err == nil {
 		t.Error("WithdrawCrypto() Expected error")
 	}
@@ -201,6 +353,46 @@ func TestPostHeartbeat(t *testing.T) {
 	}
 }
 
+func TestHeartbeat(t *testing.T) {
+	if !areTestAPIKeysSet() && !mockTests {
+		t.Skip("API keys not set, skipping test")
+	}
+	interval := g.HeartbeatInterval
+	endpoint := g.API.Endpoints.URL
+	defer func() {
+		g.StopHeartbeat()
+		g.RequiresHeartBeat = false
+		g.HeartbeatInterval = interval
+		g.API.Endpoints.URL = endpoint
+	}()
+
+	if err := g.StartHeartbeat(nil); err != nil || g.heartbeatShutdown != nil {
+		t.Fatalf("expected heartbeats not to be sent when not required, got %v", err)
+	}
+
+	g.RequiresHeartBeat = true
+	g.HeartbeatInterval = time.Millisecond * 10
+	g.API.Endpoints.URL = "http://127.0.0.1:1"
+	failed := make(chan error, 1)
+	if err := g.StartHeartbeat(func(err error) { failed <- err }); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartHeartbeat(nil); err == nil {
+		t.Error("expected an error when the heartbeat is already started")
+	}
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the heartbeat failure to be reported")
+	}
+
+	g.StopHeartbeat()
+	g.StopHeartbeat()
+	if g.heartbeatShutdown != nil {
+		t.Error("expected the heartbeat to be stopped")
+	}
+}
+
 func setFeeBuilder() *exchange.FeeBuilder {
 	return &exchange.FeeBuilder{
 		Amount:  1,
@@ -469,6 +661,27 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 	}
 }
 
+func TestCancelSessionOrders(t *testing.T) {
+	t.Parallel()
+	if areTestAPIKeysSet() && !canManipulateRealOrders && !mockTests {
+		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
+	}
+
+	resp, err := g.CancelSessionOrders()
+	switch {
+	case !areTestAPIKeysSet() && err == nil && !mockTests:
+		t.Error("Expecting an error when no keys are set")
+	case areTestAPIKeysSet() && err != nil:
+		t.Errorf("Could not cancel session orders: %v", err)
+	case mockTests && err != nil:
+		t.Errorf("Could not cancel session orders: %v", err)
+	}
+
+	if mockTests && (len(resp.Status) != 1 || resp.Status["4"] == "") {
+		t.Errorf("expected the rejected order to be reported, got %v", resp.Status)
+	}
+}
+
 func TestModifyOrder(t *testing.T) {
 	t.Parallel()
 	_, err := g.ModifyOrder(&order.Modify{})
@@ -1061,3 +1274,23 @@ func TestResponseToOrderType(t *testing.T) {
 		}
 	}
 }
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+	code, err := parseError(400, []byte(`{"result":"error","reason":"InvalidNonce","message":"Nonce '1' has not increased since your last call"}`))
+	if code != "InvalidNonce" || err != request.ErrInvalidNonce {
+		t.Errorf("expected invalid nonce, got %s %v", code, err)
+	}
+	if _, err = parseError(400, []byte(`{"result":"error","reason":"InsufficientFunds","message":"Failed to place order"}`)); err != request.ErrInsufficientFunds {
+		t.Errorf("expected insufficient funds, got %v", err)
+	}
+	if _, err = parseError(400, []byte(`{"result":"error","reason":"OrderNotFound","message":"Order 1 not found"}`)); err != request.ErrOrderNotFound {
+		t.Errorf("expected order not found, got %v", err)
+	}
+	if _, err = parseError(429, []byte(`{"result":"error","reason":"RateLimit","message":"Requests were made too frequently"}`)); err != request.ErrRateLimited {
+		t.Errorf("expected rate limited, got %v", err)
+	}
+	if code, err = parseError(400, []byte(`{"result":"error","reason":"InvalidSymbol","message":"Invalid symbol"}`)); code != "InvalidSymbol" || err != nil {
+		t.Errorf("expected unmapped reason, got %s %v", code, err)
+	}
+}
@@ -1,34 +1,52 @@
 package gemini
 
-import "github.com/thrasher-corp/gocryptotrader/currency"
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+var errInvalidInterval = errors.New("invalid interval")
 
 // Ticker holds returned ticker data from the exchange
 type Ticker struct {
-	Ask    float64 `json:"ask,string"`
-	Bid    float64 `json:"bid,string"`
-	Last   float64 `json:"last,string"`
+	Ask    decimal.Decimal `json:"ask"`
+	Bid    decimal.Decimal `json:"bid"`
+	Last   decimal.Decimal `json:"last"`
 	Volume struct {
-		Currency  float64
-		USD       float64
-		BTC       float64
-		ETH       float64
+		Currency  decimal.Decimal
+		USD       decimal.Decimal
+		BTC       decimal.Decimal
+		ETH       decimal.Decimal
 		Timestamp int64
 	}
 }
 
 // TickerV2 holds returned ticker data from the exchange
 type TickerV2 struct {
-	Ask     float64       `json:"ask,string"`
-	Bid     float64       `json:"bid,string"`
-	Changes []string      `json:"changes"`
-	Close   float64       `json:"close,string"`
-	High    float64       `json:"high,string"`
-	Low     float64       `json:"low,string"`
-	Open    float64       `json:"open,string"`
-	Message string        `json:"message,omitempty"`
-	Reason  string        `json:"reason,omitempty"`
-	Result  string        `json:"result,omitempty"`
-	Symbol  currency.Pair `json:"symbol"`
+	Ask     decimal.Decimal `json:"ask"`
+	Bid     decimal.Decimal `json:"bid"`
+	Changes []string        `json:"changes"`
+	Close   decimal.Decimal `json:"close"`
+	High    decimal.Decimal `json:"high"`
+	Low     decimal.Decimal `json:"low"`
+	Open    decimal.Decimal `json:"open"`
+	Message string          `json:"message,omitempty"`
+	Reason  string          `json:"reason,omitempty"`
+	Result  string          `json:"result,omitempty"`
+	Symbol  currency.Pair   `json:"symbol"`
+}
+
+// Candle is an OHLCV candle returned by the version 2 candles endpoint
+type Candle struct {
+	Time   time.Time
+	Open   decimal.Decimal
+	High   decimal.Decimal
+	Low    decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
 }
 
 // Orderbook contains orderbook information for both bid and ask side
@@ -39,49 +57,49 @@ type Orderbook struct {
 
 // OrderbookEntry subtype of orderbook information
 type OrderbookEntry struct {
-	Price  float64 `json:"price,string"`
-	Amount float64 `json:"amount,string"`
+	Price  decimal.Decimal `json:"price"`
+	Amount decimal.Decimal `json:"amount"`
 }
 
 // Trade holds trade history for a specific currency pair
 type Trade struct {
-	Timestamp   int64   `json:"timestamp"`
-	Timestampms int64   `json:"timestampms"`
-	TID         int64   `json:"tid"`
-	Price       float64 `json:"price,string"`
-	Amount      float64 `json:"amount,string"`
-	Exchange    string  `json:"exchange"`
-	Side        string  `json:"type"`
+	Timestamp   int64           `json:"timestamp"`
+	Timestampms int64           `json:"timestampms"`
+	TID         int64           `json:"tid"`
+	Price       decimal.Decimal `json:"price"`
+	Amount      decimal.Decimal `json:"amount"`
+	Exchange    string          `json:"exchange"`
+	Side        string          `json:"type"`
 }
 
 // Auction is generalized response type
 type Auction struct {
-	LastAuctionEID               int64   `json:"last_auction_eid"`
-	ClosedUntilMs                int64   `json:"closed_until_ms"`
-	LastAuctionPrice             float64 `json:"last_auction_price,string"`
-	LastAuctionQuantity          float64 `json:"last_auction_quantity,string"`
-	LastHighestBidPrice          float64 `json:"last_highest_bid_price,string"`
-	LastLowestAskPrice           float64 `json:"last_lowest_ask_price,string"`
-	NextAuctionMS                int64   `json:"next_auction_ms"`
-	NextUpdateMS                 int64   `json:"next_update_ms"`
-	MostRecentIndicativePrice    float64 `json:"most_recent_indicative_price,string"`
-	MostRecentIndicativeQuantity float64 `json:"most_recent_indicative_quantity,string"`
-	MostRecentHighestBidPrice    float64 `json:"most_recent_highest_bid_price,string"`
-	MostRecentLowestAskPrice     float64 `json:"most_recent_lowest_ask_price,string"`
+	LastAuctionEID               int64           `json:"last_auction_eid"`
+	ClosedUntilMs                int64           `json:"closed_until_ms"`
+	LastAuctionPrice             decimal.Decimal `json:"last_auction_price"`
+	LastAuctionQuantity          decimal.Decimal `json:"last_auction_quantity"`
+	LastHighestBidPrice          decimal.Decimal `json:"last_highest_bid_price"`
+	LastLowestAskPrice           decimal.Decimal `json:"last_lowest_ask_price"`
+	NextAuctionMS                int64           `json:"next_auction_ms"`
+	NextUpdateMS                 int64           `json:"next_update_ms"`
+	MostRecentIndicativePrice    decimal.Decimal `json:"most_recent_indicative_price"`
+	MostRecentIndicativeQuantity decimal.Decimal `json:"most_recent_indicative_quantity"`
+	MostRecentHighestBidPrice    decimal.Decimal `json:"most_recent_highest_bid_price"`
+	MostRecentLowestAskPrice     decimal.Decimal `json:"most_recent_lowest_ask_price"`
 }
 
 // AuctionHistory holds auction history information
 type AuctionHistory struct {
-	AuctionID       int64   `json:"auction_id"`
-	AuctionPrice    float64 `json:"auction_price,string"`
-	AuctionQuantity float64 `json:"auction_quantity,string"`
-	EID             int64   `json:"eid"`
-	HighestBidPrice float64 `json:"highest_bid_price,string"`
-	LowestAskPrice  float64 `json:"lowest_ask_price,string"`
-	AuctionResult   string  `json:"auction_result"`
-	Timestamp       int64   `json:"timestamp"`
-	TimestampMS     int64   `json:"timestampms"`
-	EventType       string  `json:"event_type"`
+	AuctionID       int64           `json:"auction_id"`
+	AuctionPrice    decimal.Decimal `json:"auction_price"`
+	AuctionQuantity decimal.Decimal `json:"auction_quantity"`
+	EID             int64           `json:"eid"`
+	HighestBidPrice decimal.Decimal `json:"highest_bid_price"`
+	LowestAskPrice  decimal.Decimal `json:"lowest_ask_price"`
+	AuctionResult   string          `json:"auction_result"`
+	Timestamp       int64           `json:"timestamp"`
+	TimestampMS     int64           `json:"timestampms"`
+	EventType       string          `json:"event_type"`
 }
 
 // OrderResult holds cancelled order information
@@ -96,42 +114,42 @@ type OrderResult struct {
 
 // Order contains order information
 type Order struct {
-	OrderID           int64    `json:"order_id,string"`
-	ID                int64    `json:"id,string"`
-	ClientOrderID     string   `json:"client_order_id"`
-	Symbol            string   `json:"symbol"`
-	Exchange          string   `json:"exchange"`
-	Price             float64  `json:"price,string"`
-	AvgExecutionPrice float64  `json:"avg_execution_price,string"`
-	Side              string   `json:"side"`
-	Type              string   `json:"type"`
-	Timestamp         int64    `json:"timestamp,string"`
-	TimestampMS       int64    `json:"timestampms"`
-	IsLive            bool     `json:"is_live"`
-	IsCancelled       bool     `json:"is_cancelled"`
-	IsHidden          bool     `json:"is_hidden"`
-	Options           []string `json:"options"`
-	WasForced         bool     `json:"was_forced"`
-	ExecutedAmount    float64  `json:"executed_amount,string"`
-	RemainingAmount   float64  `json:"remaining_amount,string"`
-	OriginalAmount    float64  `json:"original_amount,string"`
-	Message           string   `json:"message"`
+	OrderID           int64           `json:"order_id,string"`
+	ID                int64           `json:"id,string"`
+	ClientOrderID     string          `json:"client_order_id"`
+	Symbol            string          `json:"symbol"`
+	Exchange          string          `json:"exchange"`
+	Price             decimal.Decimal `json:"price"`
+	AvgExecutionPrice decimal.Decimal `json:"avg_execution_price"`
+	Side              string          `json:"side"`
+	Type              string          `json:"type"`
+	Timestamp         int64           `json:"timestamp,string"`
+	TimestampMS       int64           `json:"timestampms"`
+	IsLive            bool            `json:"is_live"`
+	IsCancelled       bool            `json:"is_cancelled"`
+	IsHidden          bool            `json:"is_hidden"`
+	Options           []string        `json:"options"`
+	WasForced         bool            `json:"was_forced"`
+	ExecutedAmount    decimal.Decimal `json:"executed_amount"`
+	RemainingAmount   decimal.Decimal `json:"remaining_amount"`
+	OriginalAmount    decimal.Decimal `json:"original_amount"`
+	Message           string          `json:"message"`
 }
 
 // TradeHistory holds trade history information
 type TradeHistory struct {
-	Price           float64 `json:"price,string"`
-	Amount          float64 `json:"amount,string"`
-	Timestamp       int64   `json:"timestamp"`
-	TimestampMS     int64   `json:"timestampms"`
-	Type            string  `json:"type"`
-	FeeCurrency     string  `json:"fee_currency"`
-	FeeAmount       float64 `json:"fee_amount,string"`
-	TID             int64   `json:"tid"`
-	OrderID         int64   `json:"order_id,string"`
-	Exchange        string  `json:"exchange"`
-	IsAuctionFilled bool    `json:"is_auction_fill"`
-	ClientOrderID   string  `json:"client_order_id"`
+	Price           decimal.Decimal `json:"price"`
+	Amount          decimal.Decimal `json:"amount"`
+	Timestamp       int64           `json:"timestamp"`
+	TimestampMS     int64           `json:"timestampms"`
+	Type            string          `json:"type"`
+	FeeCurrency     string          `json:"fee_currency"`
+	FeeAmount       decimal.Decimal `json:"fee_amount"`
+	TID             int64           `json:"tid"`
+	OrderID         int64           `json:"order_id,string"`
+	Exchange        string          `json:"exchange"`
+	IsAuctionFilled bool            `json:"is_auction_fill"`
+	ClientOrderID   string          `json:"client_order_id"`
 	// Used to store values
 	BaseCurrency  string
 	QuoteCurrency string
@@ -187,9 +205,27 @@ type OneDayNotionalVolume struct {
 
 // Balance is a simple balance type
 type Balance struct {
-	Currency  string  `json:"currency"`
-	Amount    float64 `json:"amount,string"`
-	Available float64 `json:"available,string"`
+	Type                   string          `json:"type"`
+	Currency               string          `json:"currency"`
+	Amount                 decimal.Decimal `json:"amount"`
+	Available              decimal.Decimal `json:"available"`
+	AvailableForWithdrawal decimal.Decimal `json:"availableForWithdrawal"`
+}
+
+// Transfer is a deposit or withdrawal of the account
+type Transfer struct {
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	TimestampMS int64           `json:"timestampms"`
+	EID         int64           `json:"eid"`
+	AdvanceEID  int64           `json:"advanceEid"`
+	Currency    string          `json:"currency"`
+	Amount      decimal.Decimal `json:"amount"`
+	Method      string          `json:"method"`
+	TxHash      string          `json:"txHash"`
+	OutputIndex int64           `json:"outputIdx"`
+	Destination string          `json:"destination"`
+	Purpose     string          `json:"purpose"`
 }
 
 // DepositAddress holds assigned deposit address for a specific currency
@@ -202,12 +238,12 @@ type DepositAddress struct {
 
 // WithdrawalAddress holds withdrawal information
 type WithdrawalAddress struct {
-	Address string  `json:"address"`
-	Amount  float64 `json:"amount"`
-	TXHash  string  `json:"txHash"`
-	Message string  `json:"message"`
-	Result  string  `json:"result"`
-	Reason  string  `json:"reason"`
+	Address string          `json:"address"`
+	Amount  decimal.Decimal `json:"amount"`
+	TXHash  string          `json:"txHash"`
+	Message string          `json:"message"`
+	Result  string          `json:"result"`
+	Reason  string          `json:"reason"`
 }
 
 // ErrorCapture is a generlized error response from the server
@@ -234,14 +270,14 @@ type WsMarketUpdateResponse struct {
 
 // Event defines orderbook and trade data
 type Event struct {
-	Type      string  `json:"type"`
-	Reason    string  `json:"reason"`
-	Price     float64 `json:"price,string"`
-	Delta     float64 `json:"delta,string"`
-	Remaining float64 `json:"remaining,string"`
-	Side      string  `json:"side"`
-	MakerSide string  `json:"makerSide"`
-	Amount    float64 `json:"amount,string"`
+	Type      string          `json:"type"`
+	Reason    string          `json:"reason"`
+	Price     decimal.Decimal `json:"price"`
+	Delta     decimal.Decimal `json:"delta"`
+	Remaining decimal.Decimal `json:"remaining"`
+	Side      string          `json:"side"`
+	MakerSide string          `json:"makerSide"`
+	Amount    decimal.Decimal `json:"amount"`
 }
 
 // ReadData defines read data from the websocket connection
@@ -282,11 +318,11 @@ type WsOrderResponse struct {
 	IsHidden          bool              `json:"is_hidden"`
 	SocketSequence    int64             `json:"socket_sequence"`
 	Timestampms       int64             `json:"timestampms"`
-	AvgExecutionPrice float64           `json:"avg_execution_price,string"`
-	ExecutedAmount    float64           `json:"executed_amount,string"`
-	RemainingAmount   float64           `json:"remaining_amount,string"`
-	OriginalAmount    float64           `json:"original_amount,string"`
-	Price             float64           `json:"price,string"`
+	AvgExecutionPrice decimal.Decimal   `json:"avg_execution_price"`
+	ExecutedAmount    decimal.Decimal   `json:"executed_amount"`
+	RemainingAmount   decimal.Decimal   `json:"remaining_amount"`
+	OriginalAmount    decimal.Decimal   `json:"original_amount"`
+	Price             decimal.Decimal   `json:"price"`
 	EventID           string            `json:"event_id"`
 	CancelCommandID   string            `json:"cancel_command_id"`
 	Reason            string            `json:"reason"`
@@ -302,12 +338,12 @@ type WsOrderResponse struct {
 
 // WsOrderFilledData ws response data
 type WsOrderFilledData struct {
-	TradeID     string  `json:"trade_id"`
-	Liquidity   string  `json:"liquidity"`
-	Price       float64 `json:"price,string"`
-	Amount      float64 `json:"amount,string"`
-	Fee         float64 `json:"fee,string"`
-	FeeCurrency string  `json:"fee_currency"`
+	TradeID     string          `json:"trade_id"`
+	Liquidity   string          `json:"liquidity"`
+	Price       decimal.Decimal `json:"price"`
+	Amount      decimal.Decimal `json:"amount"`
+	Fee         decimal.Decimal `json:"fee"`
+	FeeCurrency string          `json:"fee_currency"`
 }
 
 type wsUnsubscribeResponse struct {
@@ -319,7 +355,39 @@ type wsUnsubscribeResponse struct {
 }
 
 type wsCandleResponse struct {
-	Type    string      `json:"type"`
-	Symbol  string      `json:"symbol"`
-	Changes [][]float64 `json:"changes"`
+	Type    string   `json:"type"`
+	Symbol  string   `json:"symbol"`
+	Changes []Candle `json:"changes"`
+}
+
+// symbolLimit is the minimum order size and quantity and price increments of
+// a symbol, which Gemini documents but does not publish through its API
+type symbolLimit struct {
+	minAmount       float64
+	amountIncrement float64
+	priceIncrement  float64
+}
+
+// symbolLimits are Gemini's documented trading rules keyed by base and quote
+// currency
+var symbolLimits = map[[2]string]symbolLimit{
+	{"BTC", "USD"}:  {0.00001, 0.00000001, 0.01},
+	{"ETH", "USD"}:  {0.001, 0.000001, 0.01},
+	{"ETH", "BTC"}:  {0.001, 0.000001, 0.00001},
+	{"ZEC", "USD"}:  {0.001, 0.000001, 0.01},
+	{"ZEC", "BTC"}:  {0.001, 0.000001, 0.00001},
+	{"ZEC", "ETH"}:  {0.001, 0.000001, 0.0001},
+	{"ZEC", "BCH"}:  {0.001, 0.000001, 0.0001},
+	{"ZEC", "LTC"}:  {0.001, 0.000001, 0.00001},
+	{"BCH", "USD"}:  {0.001, 0.000001, 0.01},
+	{"BCH", "BTC"}:  {0.001, 0.000001, 0.00001},
+	{"BCH", "ETH"}:  {0.001, 0.000001, 0.0001},
+	{"LTC", "USD"}:  {0.01, 0.00001, 0.01},
+	{"LTC", "BTC"}:  {0.01, 0.00001, 0.00001},
+	{"LTC", "ETH"}:  {0.01, 0.00001, 0.0001},
+	{"LTC", "BCH"}:  {0.01, 0.00001, 0.0001},
+	{"BAT", "USD"}:  {1, 0.000001, 0.00001},
+	{"DAI", "USD"}:  {0.1, 0.000001, 0.00001},
+	{"LINK", "USD"}: {0.1, 0.000001, 0.00001},
+	{"OXT", "USD"}:  {1, 0.000001, 0.00001},
 }
@@ -142,6 +142,9 @@ func (g *Gemini) wsFunnelConnectionData(ws *wshandler.WebsocketConnection, c cur
 			resp, err := ws.ReadMessage()
 			if err != nil {
 				g.Websocket.DataHandler <- err
+				if ws == g.AuthenticatedWebsocketConn && g.CancelOnDisconnect {
+					g.cancelOnDisconnect()
+				}
 				return
 			}
 			g.Websocket.TrafficAlert <- struct{}{}
@@ -150,6 +153,17 @@ func (g *Gemini) wsFunnelConnectionData(ws *wshandler.WebsocketConnection, c cur
 	}
 }
 
+// cancelOnDisconnect cancels the session's open orders once its order events
+// are no longer received, including when the websocket is shut down
+func (g *Gemini) cancelOnDisconnect() {
+	resp, err := g.CancelExistingOrders(true)
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "%v order events disconnected, unable to cancel session orders: %v\n", g.Name, err)
+		return
+	}
+	log.Warnf(log.ExchangeSys, "%v order events disconnected, %d session orders cancelled.\n", g.Name, len(resp.Details.CancelledOrders))
+}
+
 // wsReadData receives and passes on websocket messages for processing
 func (g *Gemini) wsReadData() {
 	g.Websocket.Wg.Add(1)
@@ -215,10 +229,10 @@ func (g *Gemini) wsHandleData(respRaw []byte, curr currency.Pair) error {
 			}
 			g.Websocket.DataHandler <- &order.Detail{
 				HiddenOrder:     result[i].IsHidden,
-				Price:           result[i].Price,
-				Amount:          result[i].OriginalAmount,
-				ExecutedAmount:  result[i].ExecutedAmount,
-				RemainingAmount: result[i].RemainingAmount,
+				Price:           result[i].Price.Float64(),
+				Amount:          result[i].OriginalAmount.Float64(),
+				ExecutedAmount:  result[i].ExecutedAmount.Float64(),
+				RemainingAmount: result[i].RemainingAmount.Float64(),
 				Exchange:        g.Name,
 				ID:              result[i].OrderID,
 				Type:            oType,
@@ -285,22 +299,22 @@ func (g *Gemini) wsHandleData(respRaw []byte, curr currency.Pair) error {
 			"candles_6h_updates",
 			"candles_1d_updates":
 			var candle wsCandleResponse
-			err := json.Unmarshal(respRaw, &result)
+			err := json.Unmarshal(respRaw, &candle)
 			if err != nil {
 				return err
 			}
 			for i := range candle.Changes {
 				g.Websocket.DataHandler <- wshandler.KlineData{
-					Timestamp:  time.Unix(int64(candle.Changes[i][0])*1000, 0),
+					Timestamp:  candle.Changes[i].Time,
 					Pair:       curr,
 					AssetType:  asset.Spot,
 					Exchange:   g.Name,
-					Interval:   result["type"].(string),
-					OpenPrice:  candle.Changes[i][1],
-					ClosePrice: candle.Changes[i][4],
-					HighPrice:  candle.Changes[i][2],
-					LowPrice:   candle.Changes[i][3],
-					Volume:     candle.Changes[i][5],
+					Interval:   candle.Type,
+					OpenPrice:  candle.Changes[i].Open.Float64(),
+					ClosePrice: candle.Changes[i].Close.Float64(),
+					HighPrice:  candle.Changes[i].High.Float64(),
+					LowPrice:   candle.Changes[i].Low.Float64(),
+					Volume:     candle.Changes[i].Volume.Float64(),
 				}
 			}
 
@@ -367,13 +381,13 @@ func (g *Gemini) wsProcessUpdate(result WsMarketUpdateResponse, pair currency.Pa
 			}
 			if result.Events[i].Side == "ask" {
 				asks = append(asks, orderbook.Item{
-					Amount: result.Events[i].Remaining,
-					Price:  result.Events[i].Price,
+					Amount: result.Events[i].Remaining.Float64(),
+					Price:  result.Events[i].Price.Float64(),
 				})
 			} else {
 				bids = append(bids, orderbook.Item{
-					Amount: result.Events[i].Remaining,
-					Price:  result.Events[i].Price,
+					Amount: result.Events[i].Remaining.Float64(),
+					Price:  result.Events[i].Price.Float64(),
 				})
 			}
 		}
@@ -408,14 +422,14 @@ func (g *Gemini) wsProcessUpdate(result WsMarketUpdateResponse, pair currency.Pa
 					CurrencyPair: pair,
 					AssetType:    asset.Spot,
 					Exchange:     g.Name,
-					Price:        result.Events[i].Price,
-					Amount:       result.Events[i].Amount,
+					Price:        result.Events[i].Price.Float64(),
+					Amount:       result.Events[i].Amount.Float64(),
 					Side:         tSide,
 				}
 			case "change":
 				item := orderbook.Item{
-					Amount: result.Events[i].Remaining,
-					Price:  result.Events[i].Price,
+					Amount: result.Events[i].Remaining.Float64(),
+					Price:  result.Events[i].Price.Float64(),
 				}
 				if strings.EqualFold(result.Events[i].Side, order.Ask.String()) {
 					asks = append(asks, item)
@@ -2,13 +2,16 @@ package gemini
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/decimal"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -71,8 +74,9 @@ func (g *Gemini) SetDefaults() {
 
 	g.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerFetching:      true,
 				TradeFetching:       true,
@@ -89,6 +93,7 @@ func (g *Gemini) SetDefaults() {
 				TradeFee:            true,
 				FiatWithdrawalFee:   true,
 				CryptoWithdrawalFee: true,
+				KlineFetching:       true,
 			},
 			WebsocketCapabilities: protocol.Features{
 				OrderbookFetching:      true,
@@ -102,6 +107,13 @@ func (g *Gemini) SetDefaults() {
 			WithdrawPermissions: exchange.AutoWithdrawCryptoWithAPIPermission |
 				exchange.AutoWithdrawCryptoWithSetup |
 				exchange.WithdrawFiatViaWebsiteOnly,
+			OrderTypes: []order.Type{
+				order.Limit,
+			},
+			RateLimits: []exchange.RateLimit{
+				{Name: "auth", Requests: geminiAuthRate, Interval: geminiRateInterval},
+				{Name: "unauth", Requests: geminiUnauthRate, Interval: geminiRateInterval},
+			},
 		},
 		Enabled: exchange.FeaturesEnabled{
 			AutoPairUpdates: true,
@@ -110,7 +122,8 @@ func (g *Gemini) SetDefaults() {
 
 	g.Requester = request.New(g.Name,
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
-		request.WithLimiter(SetRateLimit()))
+		request.WithLimiter(SetRateLimit()),
+		request.WithErrorParser(parseError))
 
 	g.API.Endpoints.URLDefault = geminiAPIURL
 	g.API.Endpoints.URL = g.API.Endpoints.URLDefault
@@ -137,6 +150,17 @@ func (g *Gemini) Setup(exch *config.ExchangeConfig) error {
 		g.API.Endpoints.URL = geminiSandboxAPIURL
 	}
 
+	// A heartbeat interval is only set for sessions which require heartbeats
+	g.RequiresHeartBeat = g.HeartbeatInterval > 0
+	if g.RequiresHeartBeat && g.HeartbeatInterval >= geminiHeartbeatTimeout {
+		log.Warnf(log.ExchangeSys,
+			"%s heartbeat interval must be less than %v, defaulting to %v.\n",
+			g.Name,
+			geminiHeartbeatTimeout,
+			geminiDefaultHeartbeatInterval)
+		g.HeartbeatInterval = geminiDefaultHeartbeatInterval
+	}
+
 	err = g.Websocket.Setup(
 		&wshandler.WebsocketSetup{
 			Enabled:                          exch.Features.Enabled.Websocket,
@@ -187,14 +211,7 @@ func (g *Gemini) Run() {
 		g.PrintEnabledPairs()
 	}
 
-	if !g.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := g.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", g.Name, err)
-	}
+	g.UpdateAvailablePairs(g.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -223,18 +240,30 @@ func (g *Gemini) UpdateAccountInfo() (account.Holdings, error) {
 		return response, err
 	}
 
-	var currencies []account.Balance
+	// Balances are split by account type, e.g. exchange and custody
 	for i := range accountBalance {
-		var exchangeCurrency account.Balance
-		exchangeCurrency.CurrencyName = currency.NewCode(accountBalance[i].Currency)
-		exchangeCurrency.TotalValue = accountBalance[i].Amount
-		exchangeCurrency.Hold = accountBalance[i].Available
-		currencies = append(currencies, exchangeCurrency)
-	}
+		balance := account.Balance{
+			CurrencyName: currency.NewCode(accountBalance[i].Currency),
+			TotalValue:   accountBalance[i].Amount.Float64(),
+			Hold:         accountBalance[i].Amount.Sub(accountBalance[i].Available).Float64(),
+			Available:    accountBalance[i].Available.Float64(),
+		}
 
-	response.Accounts = append(response.Accounts, account.SubAccount{
-		Currencies: currencies,
-	})
+		var found bool
+		for j := range response.Accounts {
+			if response.Accounts[j].ID == accountBalance[i].Type {
+				response.Accounts[j].Currencies = append(response.Accounts[j].Currencies, balance)
+				found = true
+				break
+			}
+		}
+		if !found {
+			response.Accounts = append(response.Accounts, account.SubAccount{
+				ID:         accountBalance[i].Type,
+				Currencies: []account.Balance{balance},
+			})
+		}
+	}
 
 	err = account.Process(&response)
 	if err != nil {
@@ -262,12 +291,13 @@ func (g *Gemini) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return tickerPrice, err
 	}
 	tickerPrice = &ticker.Price{
-		High:  tick.High,
-		Low:   tick.Low,
-		Bid:   tick.Bid,
-		Ask:   tick.Ask,
-		Open:  tick.Open,
-		Close: tick.Close,
+		High:  tick.High.Float64(),
+		Low:   tick.Low.Float64(),
+		Bid:   tick.Bid.Float64(),
+		Ask:   tick.Ask.Float64(),
+		Open:  tick.Open.Float64(),
+		Close: tick.Close.Float64(),
+		Last:  tick.Close.Float64(),
 		Pair:  p,
 	}
 	err = ticker.ProcessTicker(g.Name, tickerPrice, assetType)
@@ -305,11 +335,11 @@ func (g *Gemini) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 	}
 
 	for x := range orderbookNew.Bids {
-		orderBook.Bids = append(orderBook.Bids, orderbook.Item{Amount: orderbookNew.Bids[x].Amount, Price: orderbookNew.Bids[x].Price})
+		orderBook.Bids = append(orderBook.Bids, orderbook.Item{Amount: orderbookNew.Bids[x].Amount.Float64(), Price: orderbookNew.Bids[x].Price.Float64()})
 	}
 
 	for x := range orderbookNew.Asks {
-		orderBook.Asks = append(orderBook.Asks, orderbook.Item{Amount: orderbookNew.Asks[x].Amount, Price: orderbookNew.Asks[x].Price})
+		orderBook.Asks = append(orderBook.Asks, orderbook.Item{Amount: orderbookNew.Asks[x].Amount.Float64(), Price: orderbookNew.Asks[x].Price.Float64()})
 	}
 
 	orderBook.Pair = p
@@ -327,12 +357,50 @@ func (g *Gemini) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (g *Gemini) GetFundingHistory() ([]exchange.FundHistory, error) {
-	return nil, common.ErrFunctionNotSupported
+	transfers, err := g.GetTransfers(0, geminiMaxTransfersLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.FundHistory, len(transfers))
+	for x := range transfers {
+		resp[x] = exchange.FundHistory{
+			ExchangeName: g.Name,
+			Status:       transfers[x].Status,
+			TransferID:   strconv.FormatInt(transfers[x].EID, 10),
+			Description:  transfers[x].Method,
+			Timestamp:    time.Unix(0, transfers[x].TimestampMS*int64(time.Millisecond)),
+			Currency:     transfers[x].Currency,
+			Amount:       transfers[x].Amount.Float64(),
+			TransferType: transfers[x].Type,
+			CryptoTxID:   transfers[x].TxHash,
+		}
+		if strings.EqualFold(transfers[x].Type, "withdrawal") {
+			resp[x].CryptoToAddress = transfers[x].Destination
+		}
+	}
+	return resp, nil
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
 func (g *Gemini) GetExchangeHistory(p currency.Pair, assetType asset.Item) ([]exchange.TradeHistory, error) {
-	return nil, common.ErrNotYetImplemented
+	trades, err := g.GetTrades(strings.ToLower(g.FormatExchangeCurrency(p, assetType).String()), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for x := range trades {
+		resp[x] = exchange.TradeHistory{
+			Timestamp: time.Unix(0, trades[x].Timestampms*int64(time.Millisecond)),
+			TID:       strconv.FormatInt(trades[x].TID, 10),
+			Price:     trades[x].Price.Float64(),
+			Amount:    trades[x].Amount.Float64(),
+			Exchange:  g.Name,
+			Type:      trades[x].Side,
+		}
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
@@ -347,12 +415,33 @@ func (g *Gemini) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
 			errors.New("only limit orders are enabled through this exchange")
 	}
 
+	var options []string
+	switch {
+	case s.PostOnly:
+		options = append(options, geminiMakerOrCancel)
+	case s.ImmediateOrCancel:
+		options = append(options, geminiImmediateOrCancel)
+	case s.FillOrKill:
+		options = append(options, geminiFillOrKill)
+	case s.AuctionOnly:
+		options = append(options, geminiAuctionOnly)
+	}
+
+	// amounts and prices are sent at the symbol's precision, as float64
+	// values such as 0.1 + 0.2 are not exact
+	amount, price := decimal.NewFromFloat(s.Amount), decimal.NewFromFloat(s.Price)
+	if limits, ok := symbolOrderLimits(s.Pair); ok {
+		amount = limits.RoundAmount(s.Amount)
+		price = limits.RoundPrice(s.Price, s.Side)
+	}
 	response, err := g.NewOrder(
 		g.FormatExchangeCurrency(s.Pair, asset.Spot).String(),
-		s.Amount,
-		s.Price,
+		amount,
+		price,
 		s.Side.String(),
-		"exchange limit")
+		"exchange limit",
+		s.ClientID,
+		options)
 	if err != nil {
 		return submitOrderResponse, err
 	}
@@ -384,10 +473,20 @@ func (g *Gemini) CancelOrder(order *order.Cancel) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (g *Gemini) CancelAllOrders(_ *order.Cancel) (order.CancelAllResponse, error) {
+	return g.cancelOrders(false)
+}
+
+// CancelSessionOrders cancels the open orders placed by the session of the API
+// key, leaving orders placed by other sessions and through the website open
+func (g *Gemini) CancelSessionOrders() (order.CancelAllResponse, error) {
+	return g.cancelOrders(true)
+}
+
+func (g *Gemini) cancelOrders(cancelBySession bool) (order.CancelAllResponse, error) {
 	cancelAllOrdersResponse := order.CancelAllResponse{
 		Status: make(map[string]string),
 	}
-	resp, err := g.CancelExistingOrders(false)
+	resp, err := g.CancelExistingOrders(cancelBySession)
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
@@ -417,7 +516,7 @@ func (g *Gemini) GetDepositAddress(cryptocurrency currency.Code, _ string) (stri
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
 // submitted
 func (g *Gemini) WithdrawCryptocurrencyFunds(withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
-	resp, err := g.WithdrawCrypto(withdrawRequest.Crypto.Address, withdrawRequest.Currency.String(), withdrawRequest.Amount)
+	resp, err := g.WithdrawCrypto(withdrawRequest.Crypto.Address, withdrawRequest.Currency.String(), decimal.NewFromFloat(withdrawRequest.Amount))
 	if err != nil {
 		return nil, err
 	}
@@ -478,14 +577,15 @@ func (g *Gemini) GetActiveOrders(req *order.GetOrdersRequest) ([]order.Detail, e
 		orderDate := time.Unix(resp[i].Timestamp, 0)
 
 		orders = append(orders, order.Detail{
-			Amount:          resp[i].OriginalAmount,
-			RemainingAmount: resp[i].RemainingAmount,
+			Amount:          resp[i].OriginalAmount.Float64(),
+			RemainingAmount: resp[i].RemainingAmount.Float64(),
 			ID:              strconv.FormatInt(resp[i].OrderID, 10),
-			ExecutedAmount:  resp[i].ExecutedAmount,
+			ClientID:        resp[i].ClientOrderID,
+			ExecutedAmount:  resp[i].ExecutedAmount.Float64(),
 			Exchange:        g.Name,
 			Type:            orderType,
 			Side:            side,
-			Price:           resp[i].Price,
+			Price:           resp[i].Price.Float64(),
 			Pair:            symbol,
 			Date:            orderDate,
 		})
@@ -509,7 +609,8 @@ func (g *Gemini) GetOrderHistory(req *order.GetOrdersRequest) ([]order.Detail, e
 	for j := range req.Pairs {
 		resp, err := g.GetTradeHistory(g.FormatExchangeCurrency(req.Pairs[j],
 			asset.Spot).String(),
-			req.StartTicks.Unix())
+			req.StartTicks.Unix(),
+			0)
 		if err != nil {
 			return nil, err
 		}
@@ -527,13 +628,13 @@ func (g *Gemini) GetOrderHistory(req *order.GetOrdersRequest) ([]order.Detail, e
 		orderDate := time.Unix(trades[i].Timestamp, 0)
 
 		orders = append(orders, order.Detail{
-			Amount:   trades[i].Amount,
+			Amount:   trades[i].Amount.Float64(),
 			ID:       strconv.FormatInt(trades[i].OrderID, 10),
 			Exchange: g.Name,
 			Date:     orderDate,
 			Side:     side,
-			Fee:      trades[i].FeeAmount,
-			Price:    trades[i].Price,
+			Fee:      trades[i].FeeAmount.Float64(),
+			Price:    trades[i].Price.Float64(),
 			Pair: currency.NewPairWithDelimiter(trades[i].BaseCurrency,
 				trades[i].QuoteCurrency,
 				g.GetPairFormat(asset.Spot, false).Delimiter),
@@ -545,6 +646,89 @@ func (g *Gemini) GetOrderHistory(req *order.GetOrdersRequest) ([]order.Detail, e
 	return orders, nil
 }
 
+// GetTradeHistoryPage returns up to a page of the account's trades of a pair
+// on or after since, oldest first, as an order detail per trade
+func (g *Gemini) GetTradeHistoryPage(p currency.Pair, a asset.Item, since time.Time) ([]order.Detail, error) {
+	if a != asset.Spot {
+		return nil, fmt.Errorf("%s trade history is only available for spot, got %s", g.Name, a)
+	}
+	// the most recent trades are returned when no timestamp is sent
+	timestamp := since.Unix()
+	if timestamp <= 0 {
+		timestamp = 1
+	}
+	resp, err := g.GetTradeHistory(g.FormatExchangeCurrency(p, a).String(),
+		timestamp,
+		geminiMaxTradesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].TimestampMS == resp[j].TimestampMS {
+			return resp[i].TID < resp[j].TID
+		}
+		return resp[i].TimestampMS < resp[j].TimestampMS
+	})
+	trades := make([]order.Detail, len(resp))
+	for i := range resp {
+		side := order.Side(strings.ToUpper(resp[i].Type))
+		tradeTime := time.Unix(0, resp[i].TimestampMS*int64(time.Millisecond))
+		trades[i] = order.Detail{
+			Exchange:       g.Name,
+			ID:             strconv.FormatInt(resp[i].OrderID, 10),
+			ClientID:       resp[i].ClientOrderID,
+			Pair:           p,
+			AssetType:      a,
+			Side:           side,
+			Price:          resp[i].Price.Float64(),
+			Amount:         resp[i].Amount.Float64(),
+			ExecutedAmount: resp[i].Amount.Float64(),
+			Fee:            resp[i].FeeAmount.Float64(),
+			Date:           tradeTime,
+			LastUpdated:    tradeTime,
+			Trades: []order.TradeHistory{{
+				Price:     resp[i].Price.Float64(),
+				Amount:    resp[i].Amount.Float64(),
+				Fee:       resp[i].FeeAmount.Float64(),
+				Exchange:  g.Name,
+				TID:       strconv.FormatInt(resp[i].TID, 10),
+				Side:      side,
+				Timestamp: tradeTime,
+			}},
+		}
+	}
+	return trades, nil
+}
+
+// GetOrderLimits returns the documented trading rules of each spot symbol
+func (g *Gemini) GetOrderLimits(a asset.Item) ([]order.Limits, error) {
+	if a != asset.Spot {
+		return nil, common.ErrFunctionNotSupported
+	}
+	limits := make([]order.Limits, 0, len(symbolLimits))
+	for symbol := range symbolLimits {
+		l, _ := symbolOrderLimits(currency.NewPairFromStrings(symbol[0], symbol[1]))
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
+// symbolOrderLimits returns the documented trading rules of a spot pair
+func symbolOrderLimits(p currency.Pair) (order.Limits, bool) {
+	l, ok := symbolLimits[[2]string{p.Base.Upper().String(), p.Quote.Upper().String()}]
+	if !ok {
+		return order.Limits{}, false
+	}
+	return order.Limits{
+		Pair:            p,
+		AssetType:       asset.Spot,
+		MinAmount:       l.minAmount,
+		AmountIncrement: l.amountIncrement,
+		PriceIncrement:  l.priceIncrement,
+	}, true
+}
+
 // SubscribeToWebsocketChannels appends to ChannelsToSubscribe
 // which lets websocket.manageSubscriptions handle subscribing
 func (g *Gemini) SubscribeToWebsocketChannels(channels []wshandler.WebsocketChannelSubscription) error {
@@ -574,7 +758,40 @@ func (g *Gemini) ValidateCredentials() error {
 	return g.CheckTransientError(err)
 }
 
-// GetHistoricCandles returns candles between a time period for a set time interval
+// GetHistoricCandles returns candles between a time period for a set time interval.
+// Only recent candles are available, candles before them are not returned
 func (g *Gemini) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end time.Time, interval time.Duration) (kline.Item, error) {
-	return kline.Item{}, common.ErrFunctionNotSupported
+	if a != asset.Spot {
+		return kline.Item{}, common.ErrFunctionNotSupported
+	}
+	timeFrame, err := candleTimeFrame(interval)
+	if err != nil {
+		return kline.Item{}, err
+	}
+	candles, err := g.GetCandles(g.FormatExchangeCurrency(pair, a).String(), timeFrame)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	ret := kline.Item{
+		Exchange: g.Name,
+		Pair:     pair,
+		Asset:    a,
+		Interval: interval,
+	}
+	// candles are returned newest first
+	for x := len(candles) - 1; x >= 0; x-- {
+		if candles[x].Time.Before(start) || !candles[x].Time.Before(end) {
+			continue
+		}
+		ret.Candles = append(ret.Candles, kline.Candle{
+			Time:   candles[x].Time,
+			Open:   candles[x].Open.Float64(),
+			High:   candles[x].High.Float64(),
+			Low:    candles[x].Low.Float64(),
+			Close:  candles[x].Close.Float64(),
+			Volume: candles[x].Volume.Float64(),
+		})
+	}
+	return ret, nil
 }
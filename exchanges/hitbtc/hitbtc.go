@@ -542,8 +542,10 @@ func (h *HitBTC) SendAuthenticatedHTTPRequest(method, endpoint string, values ur
 		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet,
 			h.Name)
 	}
+
+	creds := h.GetCredentials(exchange.MethodPermission(method))
 	headers := make(map[string]string)
-	headers["Authorization"] = "Basic " + crypto.Base64Encode([]byte(h.API.Credentials.Key+":"+h.API.Credentials.Secret))
+	headers["Authorization"] = "Basic " + crypto.Base64Encode([]byte(creds.Key+":"+creds.Secret))
 
 	path := fmt.Sprintf("%s/%s", h.API.Endpoints.URL, endpoint)
 
@@ -194,28 +194,7 @@ func (h *HitBTC) Run() {
 		h.PrintEnabledPairs()
 	}
 
-	forceUpdate := false
-	delim := h.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(h.GetEnabledPairs(asset.Spot).Strings(), delim) ||
-		!common.StringDataContains(h.GetAvailablePairs(asset.Spot).Strings(), delim) {
-		enabledPairs := []string{currency.BTC.String() + delim + currency.USD.String()}
-		log.Warn(log.ExchangeSys, "Available pairs for HitBTC reset due to config upgrade, please enable the ones you would like again.")
-		forceUpdate = true
-
-		err := h.UpdatePairs(currency.NewPairsFromStrings(enabledPairs), asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s failed to update enabled currencies.\n", h.Name)
-		}
-	}
-
-	if !h.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := h.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", h.Name, err)
-	}
+	h.UpdateAvailablePairs(h.UpdateTradablePairs, currency.NewPair(currency.BTC, currency.USD), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -727,12 +727,14 @@ func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url
 		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, h.Name)
 	}
 
+	creds := h.GetCredentials(exchange.MethodPermission(method))
+
 	if values == nil {
 		values = url.Values{}
 	}
 
 	now := time.Now()
-	values.Set("AccessKeyId", h.API.Credentials.Key)
+	values.Set("AccessKeyId", creds.Key)
 	values.Set("SignatureMethod", "HmacSHA256")
 	values.Set("SignatureVersion", "2")
 	values.Set("Timestamp", now.UTC().Format("2006-01-02T15:04:05"))
@@ -754,7 +756,7 @@ func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url
 		headers["Content-Type"] = "application/json"
 	}
 
-	hmac := crypto.GetHMAC(crypto.HashSHA256, []byte(payload), []byte(h.API.Credentials.Secret))
+	hmac := crypto.GetHMAC(crypto.HashSHA256, []byte(payload), []byte(creds.Secret))
 	values.Set("Signature", crypto.Base64Encode(hmac))
 	urlPath := h.API.Endpoints.URL + common.EncodeURLValues(endpoint, values)
 
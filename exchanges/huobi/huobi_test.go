@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
@@ -148,6 +149,14 @@ func TestGetTradeHistory(t *testing.T) {
 	}
 }
 
+func TestGetExchangeHistory(t *testing.T) {
+	t.Parallel()
+	_, err := h.GetExchangeHistory(currency.NewPairFromString(testSymbol), asset.Spot)
+	if err != nil {
+		t.Errorf("Huobi TestGetExchangeHistory: %s", err)
+	}
+}
+
 func TestGetMarketDetail(t *testing.T) {
 	t.Parallel()
 	_, err := h.GetMarketDetail(testSymbol)
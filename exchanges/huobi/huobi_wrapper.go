@@ -225,35 +225,9 @@ func (h *HUOBI) Run() {
 		h.BaseCurrencies = currency.Currencies{currency.USD}
 	}
 
-	if forceUpdate {
-		enabledPairs := currency.Pairs{currency.Pair{
-			Base:      currency.BTC.Lower(),
-			Quote:     currency.USDT.Lower(),
-			Delimiter: h.GetPairFormat(asset.Spot, false).Delimiter,
-		},
-		}
-		log.Warn(log.ExchangeSys,
-			"Available and enabled pairs for Huobi reset due to config upgrade, please enable the ones you would like again")
-
-		err := h.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s Failed to update enabled currencies.\n",
-				h.Name)
-		}
-	}
-
-	if !h.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := h.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			h.Name,
-			err)
-	}
+	h.UpdateAvailablePairs(h.UpdateTradablePairs,
+		currency.NewPair(currency.BTC.Lower(), currency.USDT.Lower()),
+		forceUpdate)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -500,7 +474,25 @@ func (h *HUOBI) GetFundingHistory() ([]exchange.FundHistory, error) {
 
 // GetExchangeHistory returns historic trade data since exchange opening.
 func (h *HUOBI) GetExchangeHistory(p currency.Pair, assetType asset.Item) ([]exchange.TradeHistory, error) {
-	return nil, common.ErrNotYetImplemented
+	history, err := h.GetTradeHistory(h.FormatExchangeCurrency(p, assetType).String(), "2000")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []exchange.TradeHistory
+	for i := range history {
+		for j := range history[i].Trades {
+			resp = append(resp, exchange.TradeHistory{
+				Timestamp: time.Unix(0, history[i].Trades[j].Timestamp*int64(time.Millisecond)),
+				TID:       strconv.FormatFloat(history[i].Trades[j].ID, 'f', -1, 64),
+				Price:     history[i].Trades[j].Price,
+				Amount:    history[i].Trades[j].Amount,
+				Exchange:  h.Name,
+				Type:      strings.ToUpper(history[i].Trades[j].Direction),
+			})
+		}
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
@@ -8,6 +8,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -73,7 +74,78 @@ type IBotExchange interface {
 	GetDefaultConfig() (*config.ExchangeConfig, error)
 	GetBase() *Base
 	SupportsAsset(assetType asset.Item) bool
+	GetFeatures() Features
+	SupportsOrderType(t order.Type) bool
 	GetHistoricCandles(p currency.Pair, a asset.Item, timeStart, timeEnd time.Time, interval time.Duration) (kline.Item, error)
 	DisableRateLimiter() error
 	EnableRateLimiter() error
 }
+
+// OCOSubmitter is implemented by exchanges which natively support
+// one-cancels-other orders, linking a resting limit order and a stop order so
+// that when one executes the other is cancelled by the exchange
+type OCOSubmitter interface {
+	SubmitOCOOrder(o *order.OCO) (order.OCOResponse, error)
+}
+
+// HeartbeatKeeper is implemented by exchanges whose sessions may require
+// periodic heartbeats, without which the exchange cancels the session's orders
+type HeartbeatKeeper interface {
+	// StartHeartbeat starts sending heartbeats if the session requires them,
+	// calling onFailure when a heartbeat fails after succeeding
+	StartHeartbeat(onFailure func(error)) error
+	StopHeartbeat()
+}
+
+// SessionOrderCanceller is implemented by exchanges whose API keys are
+// sessions which can cancel the orders they placed with a single request
+type SessionOrderCanceller interface {
+	// CancelSessionOrders cancels the open orders placed by the session of
+	// the API key, leaving orders placed by other sessions open
+	CancelSessionOrders() (order.CancelAllResponse, error)
+}
+
+// DerivativesDataProvider is implemented by exchanges which publish the open
+// interest and public liquidations of their derivatives contracts
+type DerivativesDataProvider interface {
+	GetOpenInterest(p currency.Pair, a asset.Item) (derivative.OpenInterest, error)
+	GetLiquidations(p currency.Pair, a asset.Item) ([]derivative.Liquidation, error)
+}
+
+// FundingDataProvider is implemented by exchanges which charge funding on
+// perpetual contracts or interest on margin loans
+type FundingDataProvider interface {
+	// GetFundingRates returns the funding rates applied to a contract on or
+	// after start, oldest first
+	GetFundingRates(p currency.Pair, a asset.Item, start time.Time) ([]derivative.FundingRate, error)
+	// GetFundingPayments returns the account's funding payments and margin
+	// interest charged on a contract on or after start, oldest first
+	GetFundingPayments(p currency.Pair, a asset.Item, start time.Time) ([]derivative.FundingPayment, error)
+}
+
+// TradeHistoryPager is implemented by exchanges which can page through the
+// account's complete trade history of a pair
+type TradeHistoryPager interface {
+	// GetTradeHistoryPage returns up to a page of the account's trades of the
+	// pair on or after since, oldest first, as an order detail per trade with
+	// the trade's ID set
+	GetTradeHistoryPage(p currency.Pair, a asset.Item, since time.Time) ([]order.Detail, error)
+}
+
+// ServerTimeFetcher is implemented by exchanges which publish their server
+// time, which the local clock is checked against for drift
+type ServerTimeFetcher interface {
+	FetchServerTime() (time.Time, error)
+}
+
+// KeyPermissionChecker is implemented by exchanges which can report what their
+// API key is permitted to do
+type KeyPermissionChecker interface {
+	GetKeyPermissions() (KeyPermissions, error)
+}
+
+// OrderLimitsFetcher is implemented by exchanges which publish, or have
+// maintained in code, the trading rules orders must meet on each pair
+type OrderLimitsFetcher interface {
+	GetOrderLimits(a asset.Item) ([]order.Limits, error)
+}
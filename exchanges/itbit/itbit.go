@@ -32,6 +32,8 @@ const (
 	itbitOrders         = "orders"
 	itbitCryptoDeposits = "cryptocurrency_deposits"
 	itbitWalletTransfer = "wallet_transfers"
+
+	itbitFundingTimeFormat = "2006-01-02T15:04:05.9999999"
 )
 
 // ItBit is the overarching type across the ItBit package
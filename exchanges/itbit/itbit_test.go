@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -73,6 +74,14 @@ func TestGetTradeHistory(t *testing.T) {
 	}
 }
 
+func TestGetExchangeHistory(t *testing.T) {
+	t.Parallel()
+	_, err := i.GetExchangeHistory(currency.NewPairFromString("XBTUSD"), asset.Spot)
+	if err != nil {
+		t.Error("GetExchangeHistory() error", err)
+	}
+}
+
 func TestGetWallets(t *testing.T) {
 	_, err := i.GetWallets(url.Values{})
 	if err == nil {
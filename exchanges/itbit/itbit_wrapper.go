@@ -301,12 +301,70 @@ func (i *ItBit) FetchAccountInfo() (account.Holdings, error) {
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (i *ItBit) GetFundingHistory() ([]exchange.FundHistory, error) {
-	return nil, common.ErrFunctionNotSupported
+	wallets, err := i.GetWallets(url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []exchange.FundHistory
+	for x := range wallets {
+		records, err := i.GetFundingHistoryForWallet(wallets[x].ID, url.Values{})
+		if err != nil {
+			return nil, err
+		}
+		for y := range records.FundingHistory {
+			f := &records.FundingHistory[y]
+			// times are returned in UTC without a time zone
+			ts, err := time.Parse(itbitFundingTimeFormat, strings.TrimSuffix(f.Time, "Z"))
+			if err != nil {
+				log.Errorf(log.ExchangeSys, "%s funding history time %s: %v\n", i.Name, f.Time, err)
+			}
+			// only withdrawals are numbered, deposits are identified by
+			// their transaction hash
+			id := f.TxnHash
+			if f.WithdrawalID > 0 {
+				id = strconv.FormatInt(f.WithdrawalID, 10)
+			}
+			resp = append(resp, exchange.FundHistory{
+				ExchangeName:    i.Name,
+				Status:          f.Status,
+				TransferID:      id,
+				Description:     f.WalletName,
+				Timestamp:       ts,
+				Currency:        f.Currency,
+				Amount:          f.Amount,
+				TransferType:    f.TransactionType,
+				CryptoToAddress: f.DestinationAddress,
+				CryptoTxID:      f.TxnHash,
+				BankTo:          f.BankName,
+			})
+		}
+	}
+	return resp, nil
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
 func (i *ItBit) GetExchangeHistory(p currency.Pair, assetType asset.Item) ([]exchange.TradeHistory, error) {
-	return nil, common.ErrNotYetImplemented
+	history, err := i.GetTradeHistory(i.FormatExchangeCurrency(p, assetType).String(), "0")
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, 0, len(history.RecentTrades))
+	for x := range history.RecentTrades {
+		tradeTime, err := time.Parse(time.RFC3339, history.RecentTrades[x].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: tradeTime,
+			TID:       history.RecentTrades[x].MatchNumber,
+			Price:     history.RecentTrades[x].Price,
+			Amount:    history.RecentTrades[x].Amount,
+			Exchange:  i.Name,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
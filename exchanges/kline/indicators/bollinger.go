@@ -0,0 +1,84 @@
+package indicators
+
+import "math"
+
+// BollingerValue holds the bands a number of standard deviations above and
+// below the simple moving average
+type BollingerValue struct {
+	Upper  float64
+	Middle float64
+	Lower  float64
+}
+
+// BollingerStream is a set of Bollinger bands updated one value at a time
+type BollingerStream struct {
+	sma        *SMAStream
+	deviations float64
+	value      BollingerValue
+}
+
+// NewBollingerStream returns Bollinger bands of the period placed the number
+// of standard deviations from the moving average
+func NewBollingerStream(period int, deviations float64) (*BollingerStream, error) {
+	sma, err := NewSMAStream(period)
+	if err != nil {
+		return nil, err
+	}
+	return &BollingerStream{sma: sma, deviations: deviations}, nil
+}
+
+// Update adds the next value, the bands are ready once period values have
+// been added
+func (b *BollingerStream) Update(value float64) (BollingerValue, bool) {
+	mean, ok := b.sma.Update(value)
+	if !ok {
+		return BollingerValue{}, false
+	}
+	// recalculated over the window as a running sum of squares loses
+	// precision as values are added and dropped
+	var variance float64
+	for x := range b.sma.window {
+		d := b.sma.window[x] - mean
+		variance += d * d
+	}
+	variance = math.Max(variance/float64(b.sma.period), 0)
+	width := math.Sqrt(variance) * b.deviations
+	b.value = BollingerValue{
+		Upper:  mean + width,
+		Middle: mean,
+		Lower:  mean - width,
+	}
+	return b.value, true
+}
+
+// Value returns the most recent bands
+func (b *BollingerStream) Value() BollingerValue {
+	return b.value
+}
+
+// Ready returns whether period values have been added
+func (b *BollingerStream) Ready() bool {
+	return b.sma.Ready()
+}
+
+// BollingerBands returns the upper, middle and lower Bollinger bands for each
+// value
+func BollingerBands(values []float64, period int, deviations float64) (upper, middle, lower []float64) {
+	upper = make([]float64, len(values))
+	middle = make([]float64, len(values))
+	lower = make([]float64, len(values))
+	b, err := NewBollingerStream(period, deviations)
+	if err != nil {
+		return
+	}
+	for x := range values {
+		v, ok := b.Update(values[x])
+		if !ok {
+			continue
+		}
+		upper[x] = v.Upper
+		middle[x] = v.Middle
+		lower[x] = v.Lower
+	}
+	return
+}
@@ -0,0 +1,49 @@
+// Package indicators calculates technical indicators from the candles built
+// or fetched by the bot. Each indicator is available as a function over a
+// full series and as a stream which is updated one value at a time, so a
+// strategy can keep its indicators current on every new candle without
+// recomputing their whole windows.
+//
+// Series functions return a value for every input, values before the
+// indicator has enough data are zero.
+package indicators
+
+import (
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// ErrInvalidPeriod is returned when an indicator's period is below one
+var ErrInvalidPeriod = errors.New("indicator period must be at least one")
+
+// Indicator is a single valued indicator updated one value at a time
+type Indicator interface {
+	// Update adds the next value and returns the indicator's value and
+	// whether it has enough data to be used
+	Update(value float64) (float64, bool)
+	// Value returns the indicator's most recent value
+	Value() float64
+	// Ready returns whether the indicator has enough data to be used
+	Ready() bool
+}
+
+// Closes returns the close of each candle
+func Closes(candles []kline.Candle) []float64 {
+	resp := make([]float64, len(candles))
+	for x := range candles {
+		resp[x] = candles[x].Close
+	}
+	return resp
+}
+
+// series applies every value to an indicator, returning its value once ready
+func series(ind Indicator, values []float64) []float64 {
+	resp := make([]float64, len(values))
+	for x := range values {
+		if v, ok := ind.Update(values[x]); ok {
+			resp[x] = v
+		}
+	}
+	return resp
+}
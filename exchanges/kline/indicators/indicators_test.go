@@ -0,0 +1,173 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+var testValues = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestCloses(t *testing.T) {
+	c := Closes([]kline.Candle{{Close: 1}, {Close: 2}})
+	if len(c) != 2 || c[0] != 1 || c[1] != 2 {
+		t.Errorf("unexpected closes %v", c)
+	}
+}
+
+func TestSMA(t *testing.T) {
+	if _, err := NewSMAStream(0); err != ErrInvalidPeriod {
+		t.Errorf("expected %v, got %v", ErrInvalidPeriod, err)
+	}
+	v := SMA([]float64{1, 2, 3, 4, 5}, 3)
+	if v[0] != 0 || v[1] != 0 || v[2] != 2 || v[3] != 3 || v[4] != 4 {
+		t.Errorf("unexpected moving averages %v", v)
+	}
+	if v = SMA([]float64{1, 2}, 0); len(v) != 2 || v[0] != 0 {
+		t.Errorf("expected zeroes for an invalid period, got %v", v)
+	}
+
+	s, err := NewSMAStream(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Update(1)
+	if values := s.Values(); len(values) != 1 || values[0] != 1 {
+		t.Errorf("unexpected window %v", values)
+	}
+	for _, x := range []float64{2, 3, 4, 5} {
+		s.Update(x)
+	}
+	if values := s.Values(); len(values) != 3 || values[0] != 3 || values[1] != 4 || values[2] != 5 {
+		t.Errorf("expected the window oldest first, got %v", values)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	v := EMA([]float64{1, 2, 3, 4, 5}, 3)
+	// seeded with the first simple moving average then weighted by 0.5
+	if v[1] != 0 || v[2] != 2 || v[3] != 3 || v[4] != 4 {
+		t.Errorf("unexpected moving averages %v", v)
+	}
+
+	e, err := NewEMAStream(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{2, 2, 2} {
+		e.Update(x)
+	}
+	if got, ok := e.Update(6); !ok || got != 4 || e.Value() != 4 {
+		t.Errorf("expected 4, got %v %v", got, ok)
+	}
+}
+
+func TestRSI(t *testing.T) {
+	v := RSI(testValues, 14)
+	for x := 0; x < 14; x++ {
+		if v[x] != 0 {
+			t.Fatalf("expected no value before the period, got %v at %d", v[x], x)
+		}
+	}
+	if !approx(v[14], 70.46413502) {
+		t.Errorf("expected 70.46413502, got %v", v[14])
+	}
+	if !approx(v[15], 66.24961855) {
+		t.Errorf("expected 66.24961855, got %v", v[15])
+	}
+
+	if v = RSI([]float64{1, 2, 3, 4}, 3); v[3] != 100 {
+		t.Errorf("expected a rising close to have an index of 100, got %v", v)
+	}
+	if v = RSI([]float64{1, 1, 1, 1}, 3); v[3] != 50 {
+		t.Errorf("expected a flat close to have an index of 50, got %v", v)
+	}
+}
+
+func TestMACD(t *testing.T) {
+	if _, err := NewMACDStream(26, 12, 9); err == nil {
+		t.Error("expected an error when the fast period is not faster")
+	}
+
+	macd, signal, histogram := MACD(testValues, 3, 6, 4)
+	// the slow average is ready at the sixth value and the signal line after
+	// four MACD values
+	for x := 0; x < 8; x++ {
+		if macd[x] != 0 || signal[x] != 0 {
+			t.Fatalf("expected no value before the signal is ready, got %v at %d", macd[x], x)
+		}
+	}
+	fast := EMA(testValues, 3)
+	slow := EMA(testValues, 6)
+	for x := 8; x < len(testValues); x++ {
+		if !approx(macd[x], fast[x]-slow[x]) || !approx(histogram[x], macd[x]-signal[x]) {
+			t.Errorf("unexpected MACD at %d: %v %v %v", x, macd[x], signal[x], histogram[x])
+		}
+	}
+}
+
+func TestBollingerBands(t *testing.T) {
+	upper, middle, lower := BollingerBands([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 8, 2)
+	// the population standard deviation of the window is 2
+	if middle[7] != 5 || !approx(upper[7], 9) || !approx(lower[7], 1) {
+		t.Errorf("unexpected bands %v %v %v", upper[7], middle[7], lower[7])
+	}
+	if upper[6] != 0 {
+		t.Errorf("expected no bands before the period, got %v", upper[6])
+	}
+}
+
+func TestBollingerStreamPrecision(t *testing.T) {
+	b, _ := NewBollingerStream(3, 2)
+	var v BollingerValue
+	for x := 0; x < 10000; x++ {
+		v, _ = b.Update(1e9 + float64(x%3))
+	}
+	// each window holds 0, 1 and 2 above the offset
+	if expected := 2 * math.Sqrt(2.0/3); !approx(v.Upper-v.Middle, expected) {
+		t.Errorf("expected a band width of %v, got %v", expected, v.Upper-v.Middle)
+	}
+}
+
+func TestStreamsMatchSeries(t *testing.T) {
+	sma, _ := NewSMAStream(5)
+	ema, _ := NewEMAStream(5)
+	rsi, _ := NewRSIStream(5)
+	bb, _ := NewBollingerStream(5, 2)
+	smaSeries := SMA(testValues, 5)
+	emaSeries := EMA(testValues, 5)
+	rsiSeries := RSI(testValues, 5)
+	upper, _, _ := BollingerBands(testValues, 5, 2)
+	for x := range testValues {
+		sma.Update(testValues[x])
+		ema.Update(testValues[x])
+		rsi.Update(testValues[x])
+		bb.Update(testValues[x])
+		if !sma.Ready() {
+			continue
+		}
+		if sma.Value() != smaSeries[x] || ema.Value() != emaSeries[x] ||
+			rsi.Value() != rsiSeries[x] || bb.Value().Upper != upper[x] {
+			t.Fatalf("stream and series differ at %d", x)
+		}
+	}
+
+	// the running sums match a moving average recalculated over each window
+	for x := 4; x < len(testValues); x++ {
+		var sum float64
+		for _, v := range testValues[x-4 : x+1] {
+			sum += v
+		}
+		if !approx(smaSeries[x], sum/5) {
+			t.Errorf("expected %v, got %v at %d", sum/5, smaSeries[x], x)
+		}
+	}
+}
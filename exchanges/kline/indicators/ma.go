@@ -0,0 +1,120 @@
+package indicators
+
+// SMAStream is a simple moving average updated one value at a time
+type SMAStream struct {
+	period int
+	window []float64
+	next   int
+	count  int
+	sum    float64
+	value  float64
+}
+
+// NewSMAStream returns a simple moving average of the period
+func NewSMAStream(period int) (*SMAStream, error) {
+	if period < 1 {
+		return nil, ErrInvalidPeriod
+	}
+	return &SMAStream{period: period, window: make([]float64, period)}, nil
+}
+
+// Update adds the next value to the moving average, the average is ready
+// once period values have been added
+func (s *SMAStream) Update(value float64) (float64, bool) {
+	s.sum += value - s.window[s.next]
+	s.window[s.next] = value
+	s.next = (s.next + 1) % s.period
+	if s.count < s.period {
+		s.count++
+	}
+	if !s.Ready() {
+		return 0, false
+	}
+	s.value = s.sum / float64(s.period)
+	return s.value, true
+}
+
+// Value returns the most recent moving average
+func (s *SMAStream) Value() float64 {
+	return s.value
+}
+
+// Ready returns whether period values have been added
+func (s *SMAStream) Ready() bool {
+	return s.count == s.period
+}
+
+// Values returns the values in the moving average's window, oldest first.
+// Adding them to a new stream of the same period restores the average
+func (s *SMAStream) Values() []float64 {
+	values := make([]float64, 0, s.count)
+	for x := s.count; x > 0; x-- {
+		values = append(values, s.window[(s.next-x+s.period)%s.period])
+	}
+	return values
+}
+
+// EMAStream is an exponential moving average updated one value at a time. It
+// is seeded with the simple moving average of the first period values
+type EMAStream struct {
+	seed       *SMAStream
+	multiplier float64
+	value      float64
+	ready      bool
+}
+
+// NewEMAStream returns an exponential moving average of the period
+func NewEMAStream(period int) (*EMAStream, error) {
+	seed, err := NewSMAStream(period)
+	if err != nil {
+		return nil, err
+	}
+	return &EMAStream{
+		seed:       seed,
+		multiplier: 2 / (float64(period) + 1),
+	}, nil
+}
+
+// Update adds the next value to the moving average, the average is ready
+// once period values have been added
+func (e *EMAStream) Update(value float64) (float64, bool) {
+	if !e.ready {
+		v, ok := e.seed.Update(value)
+		if !ok {
+			return 0, false
+		}
+		e.value = v
+		e.ready = true
+		return e.value, true
+	}
+	e.value += (value - e.value) * e.multiplier
+	return e.value, true
+}
+
+// Value returns the most recent moving average
+func (e *EMAStream) Value() float64 {
+	return e.value
+}
+
+// Ready returns whether period values have been added
+func (e *EMAStream) Ready() bool {
+	return e.ready
+}
+
+// SMA returns the simple moving average of the period for each value
+func SMA(values []float64, period int) []float64 {
+	s, err := NewSMAStream(period)
+	if err != nil {
+		return make([]float64, len(values))
+	}
+	return series(s, values)
+}
+
+// EMA returns the exponential moving average of the period for each value
+func EMA(values []float64, period int) []float64 {
+	e, err := NewEMAStream(period)
+	if err != nil {
+		return make([]float64, len(values))
+	}
+	return series(e, values)
+}
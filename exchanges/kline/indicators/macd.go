@@ -0,0 +1,96 @@
+package indicators
+
+import "errors"
+
+var errMACDPeriods = errors.New("MACD fast period must be less than its slow period")
+
+// MACDValue is the moving average convergence divergence, its signal line and
+// their difference
+type MACDValue struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+// MACDStream is a moving average convergence divergence updated one value at
+// a time
+type MACDStream struct {
+	fast   *EMAStream
+	slow   *EMAStream
+	signal *EMAStream
+	value  MACDValue
+}
+
+// NewMACDStream returns a moving average convergence divergence of the fast
+// and slow periods with a signal line of the signal period
+func NewMACDStream(fastPeriod, slowPeriod, signalPeriod int) (*MACDStream, error) {
+	if fastPeriod >= slowPeriod {
+		return nil, errMACDPeriods
+	}
+	fast, err := NewEMAStream(fastPeriod)
+	if err != nil {
+		return nil, err
+	}
+	slow, err := NewEMAStream(slowPeriod)
+	if err != nil {
+		return nil, err
+	}
+	signal, err := NewEMAStream(signalPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return &MACDStream{fast: fast, slow: slow, signal: signal}, nil
+}
+
+// Update adds the next value, the MACD is ready once its signal line has
+// enough MACD values
+func (m *MACDStream) Update(value float64) (MACDValue, bool) {
+	fast, _ := m.fast.Update(value)
+	slow, ok := m.slow.Update(value)
+	if !ok {
+		return MACDValue{}, false
+	}
+	macd := fast - slow
+	signal, ok := m.signal.Update(macd)
+	if !ok {
+		return MACDValue{}, false
+	}
+	m.value = MACDValue{
+		MACD:      macd,
+		Signal:    signal,
+		Histogram: macd - signal,
+	}
+	return m.value, true
+}
+
+// Value returns the most recent MACD
+func (m *MACDStream) Value() MACDValue {
+	return m.value
+}
+
+// Ready returns whether the signal line has enough MACD values
+func (m *MACDStream) Ready() bool {
+	return m.signal.Ready()
+}
+
+// MACD returns the moving average convergence divergence, signal line and
+// histogram for each value
+func MACD(values []float64, fastPeriod, slowPeriod, signalPeriod int) (macd, signal, histogram []float64) {
+	macd = make([]float64, len(values))
+	signal = make([]float64, len(values))
+	histogram = make([]float64, len(values))
+	m, err := NewMACDStream(fastPeriod, slowPeriod, signalPeriod)
+	if err != nil {
+		return
+	}
+	for x := range values {
+		v, ok := m.Update(values[x])
+		if !ok {
+			continue
+		}
+		macd[x] = v.MACD
+		signal[x] = v.Signal
+		histogram[x] = v.Histogram
+	}
+	return
+}
@@ -0,0 +1,79 @@
+package indicators
+
+// RSIStream is a relative strength index updated one value at a time. Gains
+// and losses are smoothed with Wilder's moving average
+type RSIStream struct {
+	period  int
+	prev    float64
+	changes int
+	avgGain float64
+	avgLoss float64
+	value   float64
+}
+
+// NewRSIStream returns a relative strength index of the period
+func NewRSIStream(period int) (*RSIStream, error) {
+	if period < 1 {
+		return nil, ErrInvalidPeriod
+	}
+	return &RSIStream{period: period, changes: -1}, nil
+}
+
+// Update adds the next value to the index, the index is ready once period
+// changes have been added
+func (r *RSIStream) Update(value float64) (float64, bool) {
+	change := value - r.prev
+	r.prev = value
+	r.changes++
+	if r.changes == 0 {
+		return 0, false
+	}
+
+	var gain, loss float64
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+	p := float64(r.period)
+	if r.changes <= r.period {
+		// the first averages are the mean of the first period changes
+		r.avgGain += gain / p
+		r.avgLoss += loss / p
+		if r.changes < r.period {
+			return 0, false
+		}
+	} else {
+		r.avgGain = (r.avgGain*(p-1) + gain) / p
+		r.avgLoss = (r.avgLoss*(p-1) + loss) / p
+	}
+
+	switch {
+	case r.avgLoss == 0 && r.avgGain == 0:
+		r.value = 50
+	case r.avgLoss == 0:
+		r.value = 100
+	default:
+		r.value = 100 - 100/(1+r.avgGain/r.avgLoss)
+	}
+	return r.value, true
+}
+
+// Value returns the most recent index
+func (r *RSIStream) Value() float64 {
+	return r.value
+}
+
+// Ready returns whether period changes have been added
+func (r *RSIStream) Ready() bool {
+	return r.changes >= r.period
+}
+
+// RSI returns the relative strength index of the period for each value
+func RSI(values []float64, period int) []float64 {
+	r, err := NewRSIStream(period)
+	if err != nil {
+		return make([]float64, len(values))
+	}
+	return series(r, values)
+}
@@ -1,9 +1,12 @@
 package kline
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -72,17 +75,19 @@ func CreateKline(trades []order.TradeHistory, interval time.Duration, p currency
 	for x := range timeIntervalCache {
 		if len(timeIntervalCache[x]) == 0 {
 			candles.Candles = append(candles.Candles, Candle{
-				Time:  candleStart[x],
-				High:  closePriceOfLast,
-				Low:   closePriceOfLast,
-				Close: closePriceOfLast,
-				Open:  closePriceOfLast})
+				Time:   candleStart[x],
+				High:   closePriceOfLast,
+				Low:    closePriceOfLast,
+				Close:  closePriceOfLast,
+				Open:   closePriceOfLast,
+				Source: Healed})
 			continue
 		}
 
 		var newCandle = Candle{
-			Open: timeIntervalCache[x][0].Price,
-			Time: candleStart[x],
+			Open:   timeIntervalCache[x][0].Price,
+			Time:   candleStart[x],
+			Source: TradeAggregated,
 		}
 
 		for y := range timeIntervalCache[x] {
@@ -100,6 +105,7 @@ func CreateKline(trades []order.TradeHistory, interval time.Duration, p currency
 		}
 		candles.Candles = append(candles.Candles, newCandle)
 	}
+	candles.UpdateChecksums()
 	return candles, nil
 }
 
@@ -130,3 +136,92 @@ func validateData(trades []order.TradeHistory) error {
 	})
 	return nil
 }
+
+// String returns the name of the candle source
+func (s Source) String() string {
+	switch s {
+	case Native:
+		return "native"
+	case TradeAggregated:
+		return "trade_aggregated"
+	case Healed:
+		return "healed"
+	}
+	return "unknown"
+}
+
+// FillMissing inserts healed candles at the previous close for each interval
+// without a candle between the first and last candle and updates the
+// checksums
+func (k *Item) FillMissing() {
+	if k.Interval <= 0 || len(k.Candles) < 2 {
+		return
+	}
+	sort.Slice(k.Candles, func(i, j int) bool {
+		return k.Candles[i].Time.Before(k.Candles[j].Time)
+	})
+	filled := []Candle{k.Candles[0]}
+	for x := 1; x < len(k.Candles); x++ {
+		last := filled[len(filled)-1]
+		for t := last.Time.Add(k.Interval); t.Before(k.Candles[x].Time); t = t.Add(k.Interval) {
+			filled = append(filled, Candle{
+				Time:   t,
+				Open:   last.Close,
+				High:   last.Close,
+				Low:    last.Close,
+				Close:  last.Close,
+				Source: Healed,
+			})
+		}
+		filled = append(filled, k.Candles[x])
+	}
+	k.Candles = filled
+	k.UpdateChecksums()
+}
+
+// UpdateChecksums sets the rolling checksum of each candle. Each checksum
+// covers the candle's data and source chained from the previous checksum so
+// any change to an earlier candle invalidates every checksum after it
+func (k *Item) UpdateChecksums() {
+	prev := k.checksumSeed()
+	for x := range k.Candles {
+		k.Candles[x].Checksum = k.Candles[x].checksum(prev)
+		prev = k.Candles[x].Checksum
+	}
+}
+
+// VerifyChecksums returns the index of the first candle whose rolling
+// checksum does not match its data, or -1 when all candles match
+func (k *Item) VerifyChecksums() int {
+	prev := k.checksumSeed()
+	for x := range k.Candles {
+		if k.Candles[x].Checksum != k.Candles[x].checksum(prev) {
+			return x
+		}
+		prev = k.Candles[x].Checksum
+	}
+	return -1
+}
+
+// checksumSeed binds the checksums to the exchange, pair, asset and interval
+// of the candles
+func (k *Item) checksumSeed() string {
+	return k.Exchange + "|" + k.Pair.String() + "|" + k.Asset.String() + "|" + k.Interval.String()
+}
+
+func (c *Candle) checksum(prev string) string {
+	h := sha256.New()
+	h.Write([]byte(prev))
+	for _, v := range []string{
+		strconv.FormatInt(c.Time.UnixNano(), 10),
+		strconv.FormatFloat(c.Open, 'g', -1, 64),
+		strconv.FormatFloat(c.High, 'g', -1, 64),
+		strconv.FormatFloat(c.Low, 'g', -1, 64),
+		strconv.FormatFloat(c.Close, 'g', -1, 64),
+		strconv.FormatFloat(c.Volume, 'g', -1, 64),
+		c.Source.String(),
+	} {
+		h.Write([]byte("|" + v))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -113,3 +113,104 @@ func TestCreateKline(t *testing.T) {
 		t.Fatal("no data returned, expecting a lot.")
 	}
 }
+
+func TestCreateKlineProvenance(t *testing.T) {
+	tn := time.Now().Truncate(time.Minute)
+	trades := []order.TradeHistory{
+		{Timestamp: tn.Add(time.Second), TID: "1", Amount: 1, Price: 100},
+		{Timestamp: tn.Add(time.Minute * 2).Add(time.Second), TID: "2", Amount: 1, Price: 105},
+	}
+	c, err := CreateKline(trades,
+		OneMin,
+		currency.NewPair(currency.BTC, currency.USD),
+		asset.Spot,
+		"Binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Candles) != 3 {
+		t.Fatalf("expected 3 candles, got %v", len(c.Candles))
+	}
+	if c.Candles[0].Source != TradeAggregated ||
+		c.Candles[1].Source != Healed ||
+		c.Candles[2].Source != TradeAggregated {
+		t.Errorf("unexpected candle sources %v %v %v",
+			c.Candles[0].Source,
+			c.Candles[1].Source,
+			c.Candles[2].Source)
+	}
+	if c.VerifyChecksums() != -1 {
+		t.Error("expected checksums to be set")
+	}
+}
+
+func TestFillMissing(t *testing.T) {
+	tn := time.Now().Truncate(time.Hour)
+	k := Item{
+		Exchange: "Binance",
+		Pair:     currency.NewPair(currency.BTC, currency.USD),
+		Asset:    asset.Spot,
+		Interval: OneHour,
+		Candles: []Candle{
+			{Time: tn.Add(OneHour * 3), Open: 102, High: 103, Low: 101, Close: 102},
+			{Time: tn, Open: 100, High: 101, Low: 99, Close: 100.5},
+		},
+	}
+	k.FillMissing()
+	if len(k.Candles) != 4 {
+		t.Fatalf("expected 4 candles, got %v", len(k.Candles))
+	}
+	for x := 1; x < 3; x++ {
+		if k.Candles[x].Source != Healed ||
+			k.Candles[x].Close != 100.5 ||
+			!k.Candles[x].Time.Equal(tn.Add(OneHour*time.Duration(x))) {
+			t.Errorf("unexpected healed candle %+v", k.Candles[x])
+		}
+	}
+	if k.Candles[0].Source != Native || k.Candles[3].Source != Native {
+		t.Error("expected exchange candles to remain native")
+	}
+}
+
+func TestChecksums(t *testing.T) {
+	tn := time.Now().Truncate(time.Hour)
+	k := Item{
+		Exchange: "Binance",
+		Pair:     currency.NewPair(currency.BTC, currency.USD),
+		Asset:    asset.Spot,
+		Interval: OneHour,
+		Candles: []Candle{
+			{Time: tn, Open: 100, High: 101, Low: 99, Close: 100.5, Volume: 10},
+			{Time: tn.Add(OneHour), Open: 100.5, High: 102, Low: 100, Close: 101, Volume: 5},
+			{Time: tn.Add(OneHour * 2), Open: 101, High: 101, Low: 101, Close: 101, Source: Healed},
+		},
+	}
+	if k.VerifyChecksums() != 0 {
+		t.Error("expected unset checksums not to verify")
+	}
+	k.UpdateChecksums()
+	if k.VerifyChecksums() != -1 {
+		t.Fatal("expected checksums to verify")
+	}
+	if k.Candles[0].Checksum == k.Candles[1].Checksum {
+		t.Error("expected each candle to have a distinct checksum")
+	}
+
+	k.Candles[1].Volume = 6
+	if i := k.VerifyChecksums(); i != 1 {
+		t.Errorf("expected modified candle to fail verification, got %v", i)
+	}
+
+	k.Candles[1].Volume = 5
+	k.Candles[2].Source = Native
+	if i := k.VerifyChecksums(); i != 2 {
+		t.Errorf("expected changed source to fail verification, got %v", i)
+	}
+
+	// a valid chain from a different exchange does not verify
+	k.Candles[2].Source = Healed
+	k.Exchange = "Bitstamp"
+	if i := k.VerifyChecksums(); i != 0 {
+		t.Errorf("expected checksums to be bound to the exchange, got %v", i)
+	}
+}
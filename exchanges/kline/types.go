@@ -24,6 +24,17 @@ const (
 	OneWeek    = 168 * time.Hour
 )
 
+// Source defines where a candle's data originated
+type Source uint8
+
+// Candle sources, the zero value is data returned by an exchange's candle
+// endpoint
+const (
+	Native Source = iota
+	TradeAggregated
+	Healed
+)
+
 // Item holds all the relevant information for internal kline elements
 type Item struct {
 	Exchange string
@@ -41,4 +52,9 @@ type Candle struct {
 	Low    float64
 	Close  float64
 	Volume float64
+	// Source is where the candle's data originated
+	Source Source
+	// Checksum is the rolling checksum of the candle chained from the
+	// checksum of the previous candle, set by UpdateChecksums
+	Checksum string
 }
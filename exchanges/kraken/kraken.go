@@ -851,13 +851,37 @@ func GetError(apiErrors []string) error {
 		case 'W':
 			log.Warnf(log.ExchangeSys, "%s API warning: %v\n", exchangeName, e[1:])
 		default:
-			return fmt.Errorf("%s API error: %v", exchangeName, e[1:])
+			return &request.Error{
+				Exchange: exchangeName,
+				Code:     e,
+				Message:  e[1:],
+				Type:     errorType(e),
+			}
 		}
 	}
 
 	return nil
 }
 
+// errorType returns the exchange API error type for a Kraken error message
+func errorType(apiError string) error {
+	parts := strings.Split(apiError, ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	switch strings.ToLower(parts[1]) {
+	case "rate limit exceeded", "too many requests":
+		return request.ErrRateLimited
+	case "insufficient funds":
+		return request.ErrInsufficientFunds
+	case "invalid nonce":
+		return request.ErrInvalidNonce
+	case "unknown order":
+		return request.ErrOrderNotFound
+	}
+	return nil
+}
+
 // SendHTTPRequest sends an unauthenticated HTTP requests
 func (k *Kraken) SendHTTPRequest(path string, result interface{}) error {
 	return k.SendPayload(context.Background(), &request.Item{
@@ -13,6 +13,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/websocket/wshandler"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -1355,3 +1356,26 @@ func TestWsCancelOrderJSON(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetError(t *testing.T) {
+	t.Parallel()
+	if err := GetError([]string{"WGeneral:Unknown warning"}); err != nil {
+		t.Error(err)
+	}
+	err := GetError([]string{"EAPI:Invalid nonce"})
+	if !request.IsError(err, request.ErrInvalidNonce) {
+		t.Errorf("expected invalid nonce, got %v", err)
+	}
+	if err.Error() != "Kraken API error: API:Invalid nonce" {
+		t.Errorf("unexpected error message %s", err)
+	}
+	if err = GetError([]string{"EOrder:Insufficient funds"}); !request.IsError(err, request.ErrInsufficientFunds) {
+		t.Errorf("expected insufficient funds, got %v", err)
+	}
+	if err = GetError([]string{"EOrder:Unknown order"}); !request.IsError(err, request.ErrOrderNotFound) {
+		t.Errorf("expected order not found, got %v", err)
+	}
+	if err = GetError([]string{"EAPI:Rate limit exceeded"}); !request.IsError(err, request.ErrRateLimited) {
+		t.Errorf("expected rate limited, got %v", err)
+	}
+}
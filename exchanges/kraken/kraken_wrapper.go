@@ -119,6 +119,13 @@ func (k *Kraken) SetDefaults() {
 				exchange.WithdrawCryptoWith2FA |
 				exchange.AutoWithdrawFiatWithSetup |
 				exchange.WithdrawFiatWith2FA,
+			OrderTypes: []order.Type{
+				order.Limit,
+				order.Market,
+			},
+			RateLimits: []exchange.RateLimit{
+				{Name: "global", Requests: krakenRequestRate, Interval: krakenRateInterval},
+			},
 		},
 		Enabled: exchange.FeaturesEnabled{
 			AutoPairUpdates: true,
@@ -213,36 +220,7 @@ func (k *Kraken) Run() {
 		k.PrintEnabledPairs()
 	}
 
-	forceUpdate := false
-	delim := k.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(k.GetEnabledPairs(asset.Spot).Strings(), delim) ||
-		!common.StringDataContains(k.GetAvailablePairs(asset.Spot).Strings(), delim) {
-		enabledPairs := currency.NewPairsFromStrings(
-			[]string{currency.XBT.String() + delim + currency.USD.String()},
-		)
-		log.Warn(log.ExchangeSys, "Available pairs for Kraken reset due to config upgrade, please enable the ones you would like again")
-		forceUpdate = true
-
-		err := k.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s failed to update currencies. Err: %s\n",
-				k.Name,
-				err)
-		}
-	}
-
-	if !k.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := k.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			k.Name,
-			err)
-	}
+	k.UpdateAvailablePairs(k.UpdateTradablePairs, currency.NewPair(currency.XBT, currency.USD), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -417,6 +395,15 @@ func (k *Kraken) FetchAccountInfo() (account.Holdings, error) {
 	return acc, nil
 }
 
+// FetchServerTime returns the exchange's server time
+func (k *Kraken) FetchServerTime() (time.Time, error) {
+	t, err := k.GetServerTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(t.Unixtime, 0), nil
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (k *Kraken) GetFundingHistory() ([]exchange.FundHistory, error) {
@@ -1,6 +1,8 @@
 package okcoin
 
 import (
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/okgroup"
@@ -14,9 +16,28 @@ const (
 	okCoinAPIVersion          = "/v3/"
 	okCoinExchangeName        = "OKCOIN International"
 	okCoinWebsocketURL        = "wss://real.okcoin.com:8443/ws/v3"
+	// CNY venue, selected by setting the exchange config region to cn
+	okCoinRegionCN          = "cn"
+	okCoinCNAPIURL          = "https://www.okcoin.cn/" + okCoinAPIPath
+	okCoinCNWebsocketURL    = "wss://real.okcoin.cn:8443/ws/v3"
+	okCoinFuturesSubsection = "futures"
 )
 
 // OKCoin bases all methods off okgroup implementation
 type OKCoin struct {
 	okgroup.OKGroup
 }
+
+// GetFuturesTickers returns the last traded price, best bid/ask price and 24
+// hour trading volume of all futures contracts
+func (o *OKCoin) GetFuturesTickers() (resp []okgroup.GetFuturesTokenInfoResponse, _ error) {
+	requestURL := fmt.Sprintf("%v/%v", okgroup.OKGroupInstruments, okgroup.OKGroupTicker)
+	return resp, o.SendHTTPRequest(http.MethodGet, okCoinFuturesSubsection, requestURL, nil, &resp, false)
+}
+
+// GetFuturesTicker returns the last traded price, best bid/ask price and 24
+// hour trading volume of a futures contract, e.g. "BTC-USD-200626"
+func (o *OKCoin) GetFuturesTicker(instrumentID string) (resp okgroup.GetFuturesTokenInfoResponse, _ error) {
+	requestURL := fmt.Sprintf("%v/%v/%v", okgroup.OKGroupInstruments, instrumentID, okgroup.OKGroupTicker)
+	return resp, o.SendHTTPRequest(http.MethodGet, okCoinFuturesSubsection, requestURL, nil, &resp, false)
+}
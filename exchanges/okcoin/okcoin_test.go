@@ -481,6 +481,19 @@ func TestGetSpotFilledOrdersInformation(t *testing.T) {
 	}
 }
 
+// TestGetExchangeHistory wrapper test
+func TestGetExchangeHistory(t *testing.T) {
+	_, err := o.GetExchangeHistory(currency.NewPairFromString(spotCurrency), asset.Spot)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = o.GetExchangeHistory(currency.NewPairFromString(spotCurrency), asset.Futures)
+	if err == nil {
+		t.Error("expected error for unsupported asset type")
+	}
+}
+
 // TestGetSpotMarketData API endpoint test
 func TestGetSpotMarketData(t *testing.T) {
 	request := okgroup.GetSpotMarketDataRequest{
@@ -1095,3 +1108,51 @@ func TestGetOrderbook(t *testing.T) {
 		t.Error("error cannot be nil")
 	}
 }
+
+func TestSetupRegion(t *testing.T) {
+	var cn OKCoin
+	cn.SetDefaults()
+	cfg, err := config.GetConfig().GetExchangeConfig(OKGroupExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := *cfg
+	exch.Enabled = true
+	exch.API.Endpoints.WebsocketURL = config.WebsocketURLNonDefaultMessage
+	exch.Region = "CN"
+	if err = cn.Setup(&exch); err != nil {
+		t.Fatal(err)
+	}
+	if cn.API.Endpoints.URL != okCoinCNAPIURL || cn.Websocket.GetWebsocketURL() != okCoinCNWebsocketURL {
+		t.Errorf("expected the cn endpoints, got %s and %s",
+			cn.API.Endpoints.URL, cn.Websocket.GetWebsocketURL())
+	}
+
+	exch.Region = "jp"
+	if err = cn.Setup(&exch); err == nil {
+		t.Error("expected an error setting up an unsupported region")
+	}
+}
+
+// TestGetFuturesTickers API endpoint test
+func TestGetFuturesTickers(t *testing.T) {
+	_, err := o.GetFuturesTickers()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetFuturesTicker API endpoint test
+func TestGetFuturesTicker(t *testing.T) {
+	contracts, err := o.GetFuturesTickers()
+	if err != nil {
+		t.Skip(err)
+	}
+	if len(contracts) == 0 {
+		t.Skip("no futures contracts listed")
+	}
+	_, err = o.GetFuturesTicker(contracts[0].InstrumentID)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -1,6 +1,8 @@
 package okcoin
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -72,8 +74,9 @@ func (o *OKCoin) SetDefaults() {
 
 	o.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerBatching:      true,
 				TickerFetching:      true,
@@ -133,6 +136,22 @@ func (o *OKCoin) SetDefaults() {
 	o.WebsocketOrderbookBufferLimit = exchange.DefaultWebsocketOrderbookBufferLimit
 }
 
+// Setup sets exchange configuration parameters, selecting the endpoints of
+// the venue set by the config region. Endpoints set in the config take
+// precedence over the venue's endpoints
+func (o *OKCoin) Setup(exch *config.ExchangeConfig) error {
+	switch strings.ToLower(exch.Region) {
+	case "":
+	case okCoinRegionCN:
+		o.API.Endpoints.URLDefault = okCoinCNAPIURL
+		o.API.Endpoints.URL = okCoinCNAPIURL
+		o.API.Endpoints.WebsocketURL = okCoinCNWebsocketURL
+	default:
+		return fmt.Errorf("%s unsupported region %q", o.Name, exch.Region)
+	}
+	return o.OKGroup.Setup(exch)
+}
+
 // Start starts the OKGroup go routine
 func (o *OKCoin) Start(wg *sync.WaitGroup) {
 	wg.Add(1)
@@ -152,40 +171,7 @@ func (o *OKCoin) Run() {
 			o.WebsocketURL)
 	}
 
-	forceUpdate := false
-	delim := o.GetPairFormat(asset.Spot, false).Delimiter
-	if !common.StringDataContains(o.CurrencyPairs.GetPairs(asset.Spot,
-		true).Strings(), delim) ||
-		!common.StringDataContains(o.CurrencyPairs.GetPairs(asset.Spot,
-			false).Strings(), delim) {
-		enabledPairs := currency.NewPairsFromStrings(
-			[]string{currency.BTC.String() + delim + currency.USD.String()},
-		)
-		log.Warnf(log.ExchangeSys,
-			"Enabled pairs for %v reset due to config upgrade, please enable the ones you would like again.\n",
-			o.Name)
-		forceUpdate = true
-
-		err := o.UpdatePairs(enabledPairs, asset.Spot, true, true)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s failed to update currencies.\n",
-				o.Name)
-			return
-		}
-	}
-
-	if !o.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := o.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			o.Name,
-			err)
-	}
+	o.UpdateAvailablePairs(o.UpdateTradablePairs, currency.NewPair(currency.BTC, currency.USD), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -345,7 +345,7 @@ func (o *OKEX) GetSwapExchangeRates() (resp okgroup.GetSwapExchangeRatesResponse
 }
 
 // GetSwapOpenInterest Get the open interest of a contract.
-func (o *OKEX) GetSwapOpenInterest(instrumentID string) (resp okgroup.GetSwapExchangeRatesResponse, _ error) {
+func (o *OKEX) GetSwapOpenInterest(instrumentID string) (resp okgroup.GetSwapOpenInterestResponse, _ error) {
 	requestURL := fmt.Sprintf("%v/%v/%v", okgroup.OKGroupInstruments, instrumentID, okGroupOpenInterest)
 	return resp, o.SendHTTPRequest(http.MethodGet, okGroupSwapSubsection, requestURL, nil, &resp, false)
 }
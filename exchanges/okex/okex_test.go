@@ -2096,3 +2096,49 @@ func TestStringToOrderStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestGetOpenInterest(t *testing.T) {
+	t.Parallel()
+	swap := currency.NewPairWithDelimiter("BTC-USD", "SWAP", delimiterUnderscore)
+	_, err := o.GetOpenInterest(swap, asset.PerpetualSwap)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = o.GetOpenInterest(swap, asset.Spot)
+	if err == nil {
+		t.Error("expected error for spot open interest")
+	}
+}
+
+func TestGetLiquidations(t *testing.T) {
+	t.Parallel()
+	swap := currency.NewPairWithDelimiter("BTC-USD", "SWAP", delimiterUnderscore)
+	_, err := o.GetLiquidations(swap, asset.PerpetualSwap)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = o.GetLiquidations(swap, asset.Spot)
+	if err == nil {
+		t.Error("expected error for spot liquidations")
+	}
+}
+
+func TestGetFundingRates(t *testing.T) {
+	t.Parallel()
+	swap := currency.NewPairWithDelimiter("BTC-USD", "SWAP", delimiterUnderscore)
+	_, err := o.GetFundingRates(swap, asset.PerpetualSwap, time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Error(err)
+	}
+	rates, err := o.GetFundingRates(swap, asset.Spot, time.Time{})
+	if err != nil || rates != nil {
+		t.Errorf("expected spot not to be funded, got %v %v", rates, err)
+	}
+}
+
+func TestGetFundingPayments(t *testing.T) {
+	t.Parallel()
+	swap := currency.NewPairWithDelimiter("BTC-USD", "SWAP", delimiterUnderscore)
+	_, err := o.GetFundingPayments(swap, asset.PerpetualSwap, time.Now().AddDate(0, 0, -1))
+	testStandardErrorHandling(t, err)
+}
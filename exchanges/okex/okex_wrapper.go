@@ -3,6 +3,7 @@ package okex
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +13,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/okgroup"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -23,6 +27,9 @@ import (
 const (
 	delimiterDash       = "-"
 	delimiterUnderscore = "_"
+
+	// okexSwapFundingLedgerType is the ledger type of a swap funding fee
+	okexSwapFundingLedgerType = "funding"
 )
 
 // GetDefaultConfig returns a default exchange config
@@ -106,8 +113,9 @@ func (o *OKEX) SetDefaults() {
 
 	o.Features = exchange.Features{
 		Supports: exchange.FeaturesSupported{
-			REST:      true,
-			Websocket: true,
+			ClientOrderIDs: true,
+			REST:           true,
+			Websocket:      true,
 			RESTCapabilities: protocol.Features{
 				TickerBatching:      true,
 				TickerFetching:      true,
@@ -186,38 +194,7 @@ func (o *OKEX) Run() {
 			o.API.Endpoints.WebsocketURL)
 	}
 
-	delim := o.GetPairFormat(asset.Spot, false).Delimiter
-	forceUpdate := false
-	if !common.StringDataContains(o.GetEnabledPairs(asset.Spot).Strings(), delim) ||
-		!common.StringDataContains(o.GetAvailablePairs(asset.Spot).Strings(), delim) {
-		forceUpdate = true
-		enabledPairs := currency.NewPairsFromStrings(
-			[]string{currency.BTC.String() + delim + currency.USDT.String()},
-		)
-		log.Warnf(log.ExchangeSys,
-			"Enabled pairs for %v reset due to config upgrade, please enable the ones you would like again.",
-			o.Name)
-
-		err := o.UpdatePairs(enabledPairs, asset.Spot, true, forceUpdate)
-		if err != nil {
-			log.Errorf(log.ExchangeSys,
-				"%s failed to update currencies.\n",
-				o.Name)
-			return
-		}
-	}
-
-	if !o.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := o.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys,
-			"%s failed to update tradable pairs. Err: %s",
-			o.Name,
-			err)
-	}
+	o.UpdateAvailablePairs(o.UpdateTradablePairs, currency.NewPair(currency.BTC, currency.USDT), false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -422,3 +399,190 @@ func (o *OKEX) FetchTicker(p currency.Pair, assetType asset.Item) (tickerData *t
 func (o *OKEX) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end time.Time, interval time.Duration) (kline.Item, error) {
 	return kline.Item{}, common.ErrFunctionNotSupported
 }
+
+// GetOpenInterest returns the open interest of a futures or perpetual swap
+// contract
+func (o *OKEX) GetOpenInterest(p currency.Pair, a asset.Item) (derivative.OpenInterest, error) {
+	instrumentID := o.FormatExchangeCurrency(p, a).String()
+	resp := derivative.OpenInterest{
+		Exchange:  o.Name,
+		Pair:      p,
+		AssetType: a,
+	}
+	switch a {
+	case asset.Futures:
+		oi, err := o.GetFuturesOpenInterests(instrumentID)
+		if err != nil {
+			return resp, err
+		}
+		resp.Amount, resp.Timestamp = oi.Amount, oi.Timestamp
+	case asset.PerpetualSwap:
+		oi, err := o.GetSwapOpenInterest(instrumentID)
+		if err != nil {
+			return resp, err
+		}
+		resp.Amount, resp.Timestamp = oi.Amount, oi.Timestamp
+	default:
+		return resp, fmt.Errorf("%s open interest not supported for asset type %s", o.Name, a)
+	}
+	return resp, nil
+}
+
+// GetLiquidations returns the liquidation orders of a futures or perpetual
+// swap contract filled within the last seven days
+func (o *OKEX) GetLiquidations(p currency.Pair, a asset.Item) ([]derivative.Liquidation, error) {
+	instrumentID := o.FormatExchangeCurrency(p, a).String()
+	var liquidations []okgroup.GetSwapForceLiquidatedOrdersResponse
+	switch a {
+	case asset.Futures:
+		resp, err := o.GetFuturesForceLiquidatedOrders(okgroup.GetFuturesForceLiquidatedOrdersRequest{
+			InstrumentID: instrumentID,
+			Status:       "1",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp {
+			liquidations = append(liquidations, okgroup.GetSwapForceLiquidatedOrdersResponse(resp[i]))
+		}
+	case asset.PerpetualSwap:
+		var err error
+		liquidations, err = o.GetSwapForceLiquidatedOrders(okgroup.GetSwapForceLiquidatedOrdersRequest{
+			InstrumentID: instrumentID,
+			Status:       "1",
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s liquidations not supported for asset type %s", o.Name, a)
+	}
+
+	resp := make([]derivative.Liquidation, 0, len(liquidations))
+	for i := range liquidations {
+		timestamp, err := time.Parse(time.RFC3339Nano, liquidations[i].CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		// type 3 closes a long position and type 4 closes a short position
+		side := order.Sell
+		if liquidations[i].Type == 4 {
+			side = order.Buy
+		}
+		resp = append(resp, derivative.Liquidation{
+			Exchange:  o.Name,
+			Pair:      p,
+			AssetType: a,
+			Side:      side,
+			Price:     liquidations[i].Price,
+			Amount:    float64(liquidations[i].Size),
+			Timestamp: timestamp,
+		})
+	}
+	return resp, nil
+}
+
+// GetFundingRates returns the funding rates of a perpetual swap applied on or
+// after start. Only the latest hundred funding rates are available
+func (o *OKEX) GetFundingRates(p currency.Pair, a asset.Item, start time.Time) ([]derivative.FundingRate, error) {
+	if a != asset.PerpetualSwap {
+		return nil, nil
+	}
+	history, err := o.GetSwapFundingRateHistory(okgroup.GetSwapFundingRateHistoryRequest{
+		InstrumentID: o.FormatExchangeCurrency(p, a).String(),
+		Limit:        100,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]derivative.FundingRate, 0, len(history))
+	// funding rates are returned newest first
+	for i := len(history) - 1; i >= 0; i-- {
+		timestamp, err := time.Parse(time.RFC3339Nano, history[i].FundingTime)
+		if err != nil {
+			return nil, err
+		}
+		if timestamp.Before(start) {
+			continue
+		}
+		resp = append(resp, derivative.FundingRate{
+			Exchange:  o.Name,
+			Pair:      p,
+			AssetType: a,
+			Rate:      history[i].RealizedRate,
+			Timestamp: timestamp,
+		})
+	}
+	return resp, nil
+}
+
+// GetFundingPayments returns the account's funding payments on a perpetual
+// swap, or the interest accrued by its margin loans of a spot pair, on or
+// after start. Margin loans are returned with the interest accrued so far
+func (o *OKEX) GetFundingPayments(p currency.Pair, a asset.Item, start time.Time) ([]derivative.FundingPayment, error) {
+	instrumentID := o.FormatExchangeCurrency(p, a).String()
+	switch a {
+	case asset.PerpetualSwap:
+		ledger, err := o.GetSwapBillDetails(okgroup.GetSpotBillDetailsForCurrencyRequest{
+			Currency: instrumentID,
+			Limit:    100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// swaps settle in their base currency unless margined in USDT
+		settlement := currency.NewCode(strings.Split(instrumentID, delimiterDash)[0])
+		if strings.Contains(instrumentID, currency.USDT.String()) {
+			settlement = currency.USDT
+		}
+		resp := make([]derivative.FundingPayment, 0, len(ledger))
+		for i := len(ledger) - 1; i >= 0; i-- {
+			if !strings.EqualFold(ledger[i].Type, okexSwapFundingLedgerType) ||
+				ledger[i].Timestamp.Before(start) {
+				continue
+			}
+			amount, err := strconv.ParseFloat(ledger[i].Amount, 64)
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, derivative.FundingPayment{
+				Exchange:  o.Name,
+				Pair:      p,
+				AssetType: a,
+				Type:      derivative.PerpetualFunding,
+				ID:        ledger[i].LedgerID,
+				Currency:  settlement,
+				Amount:    amount,
+				Timestamp: ledger[i].Timestamp,
+			})
+		}
+		return resp, nil
+	case asset.Spot:
+		loans, err := o.GetMarginLoanHistory(okgroup.GetMarginLoanHistoryRequest{
+			InstrumentID: instrumentID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp := make([]derivative.FundingPayment, 0, len(loans))
+		for i := len(loans) - 1; i >= 0; i-- {
+			if loans[i].Timestamp.Before(start) && loans[i].ReturnedAmount >= loans[i].Amount {
+				// loans repaid before start no longer accrue interest
+				continue
+			}
+			resp = append(resp, derivative.FundingPayment{
+				Exchange:  o.Name,
+				Pair:      p,
+				AssetType: a,
+				Type:      derivative.MarginInterest,
+				ID:        strconv.FormatInt(loans[i].BorrowID, 10),
+				Currency:  currency.NewCode(loans[i].Currency),
+				Amount:    -loans[i].Interest,
+				Rate:      loans[i].Rate,
+				Timestamp: loans[i].Timestamp,
+			})
+		}
+		return resp, nil
+	}
+	return nil, nil
+}
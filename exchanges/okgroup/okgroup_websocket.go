@@ -657,12 +657,15 @@ func (o *OKGroup) WsProcessPartialOrderBook(wsEventData *WebsocketOrderBook, ins
 }
 
 // WsProcessUpdateOrderbook updates an existing orderbook using websocket data
-// After merging WS data, it will sort, validate and finally update the existing orderbook
+// After merging WS data, it will sort, verify the checksum and finally update the existing orderbook
 func (o *OKGroup) WsProcessUpdateOrderbook(wsEventData *WebsocketOrderBook, instrument currency.Pair, a asset.Item) error {
 	update := wsorderbook.WebsocketOrderbookUpdate{
 		Asset:      a,
 		Pair:       instrument,
 		UpdateTime: wsEventData.Timestamp,
+		Checksum: func(ob *orderbook.Base) bool {
+			return o.CalculateUpdateOrderbookChecksum(ob) == wsEventData.Checksum
+		},
 	}
 
 	var err error
@@ -675,22 +678,14 @@ func (o *OKGroup) WsProcessUpdateOrderbook(wsEventData *WebsocketOrderBook, inst
 		return err
 	}
 
+	// the merged orderbook is verified against the checksum before it is
+	// published, a failed checksum resubscribes to the channel for a new
+	// partial orderbook
 	err = o.Websocket.Orderbook.Update(&update)
 	if err != nil {
 		return err
 	}
 
-	updatedOb := o.Websocket.Orderbook.GetOrderbook(instrument, a)
-	checksum := o.CalculateUpdateOrderbookChecksum(updatedOb)
-
-	if checksum != wsEventData.Checksum {
-		// re-sub
-		log.Warnf(log.ExchangeSys, "%s checksum failure for item %s",
-			o.Name,
-			wsEventData.InstrumentID)
-		return errors.New("checksum failed")
-	}
-
 	o.Websocket.DataHandler <- wshandler.WebsocketOrderbookUpdate{
 		Exchange: o.Name,
 		Asset:    a,
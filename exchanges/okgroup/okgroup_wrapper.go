@@ -263,7 +263,37 @@ func (o *OKGroup) GetFundingHistory() (resp []exchange.FundHistory, err error) {
 
 // GetExchangeHistory returns historic trade data since exchange opening.
 func (o *OKGroup) GetExchangeHistory(p currency.Pair, assetType asset.Item) ([]exchange.TradeHistory, error) {
-	return nil, common.ErrNotYetImplemented
+	if assetType != asset.Spot {
+		return nil, fmt.Errorf("%s asset type %v not supported", o.Name, assetType)
+	}
+
+	trades, err := o.GetSpotFilledOrdersInformation(GetSpotFilledOrdersInformationRequest{
+		InstrumentID: o.FormatExchangeCurrency(p, assetType).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		price, convErr := strconv.ParseFloat(trades[i].Price, 64)
+		if convErr != nil {
+			return nil, convErr
+		}
+		amount, convErr := strconv.ParseFloat(trades[i].Size, 64)
+		if convErr != nil {
+			return nil, convErr
+		}
+		resp[i] = exchange.TradeHistory{
+			Timestamp: trades[i].Timestamp,
+			TID:       trades[i].TradeID,
+			Price:     price,
+			Amount:    amount,
+			Exchange:  o.Name,
+			Type:      strings.ToUpper(trades[i].Side),
+		}
+	}
+	return resp, nil
 }
 
 // SubmitOrder submits a new order
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
 func TestValidate(t *testing.T) {
@@ -81,6 +82,202 @@ func TestValidate(t *testing.T) {
 			t.Errorf("Unexpected result. Got: %s, want: %s", err, tester[x].ExpectedErr)
 		}
 	}
+
+	s := Submit{
+		Pair:                testPair,
+		Side:                Ask,
+		Type:                Limit,
+		Amount:              1,
+		Price:               1000,
+		SelfTradePrevention: "CANCEL_EVERYTHING",
+	}
+	if err := s.Validate(); err != ErrSelfTradePreventionInvalid {
+		t.Errorf("Unexpected result. Got: %s, want: %s", err, ErrSelfTradePreventionInvalid)
+	}
+
+	s.SelfTradePrevention = ""
+	s.PostOnly = true
+	if err := s.Validate(); err != nil {
+		t.Errorf("Unexpected result. Got: %s, want: %v", err, nil)
+	}
+	s.AuctionOnly = true
+	if err := s.Validate(); err != ErrExecutionOptionsConflict {
+		t.Errorf("Unexpected result. Got: %s, want: %s", err, ErrExecutionOptionsConflict)
+	}
+	s.AuctionOnly = false
+	s.Type = Market
+	if err := s.Validate(); err != ErrExecutionOptionLimitOnly {
+		t.Errorf("Unexpected result. Got: %s, want: %s", err, ErrExecutionOptionLimitOnly)
+	}
+	s.PostOnly = false
+	s.ImmediateOrCancel = true
+	if err := s.Validate(); err != nil {
+		t.Errorf("Unexpected result. Got: %s, want: %v", err, nil)
+	}
+}
+
+func TestOCOValidate(t *testing.T) {
+	var o *OCO
+	if err := o.Validate(); err != ErrSubmissionIsNil {
+		t.Errorf("Unexpected result. Got: %v, want: %s", err, ErrSubmissionIsNil)
+	}
+
+	testPair := currency.NewPair(currency.BTC, currency.USD)
+	tester := []struct {
+		Side
+		Type
+		Price       float64
+		StopPrice   float64
+		ExpectedErr error
+	}{
+		{Side: Sell, Type: Market, StopPrice: 90, ExpectedErr: ErrOCOLimitTypeInvalid},
+		{Side: Sell, Type: Limit, StopPrice: 90, ExpectedErr: ErrPriceMustBeSetIfLimitOrder},
+		{Side: Sell, Type: Limit, Price: 110, ExpectedErr: ErrOCOStopPriceInvalid},
+		{Side: Sell, Type: Limit, Price: 80, StopPrice: 90, ExpectedErr: ErrOCOPricesInvalid},
+		{Side: Buy, Type: Limit, Price: 110, StopPrice: 90, ExpectedErr: ErrOCOPricesInvalid},
+		{Side: Sell, Type: Limit, Price: 110, StopPrice: 90},
+		{Side: Buy, Type: Limit, Price: 80, StopPrice: 90},
+	}
+	for x := range tester {
+		o = &OCO{
+			Limit: Submit{
+				Pair:   testPair,
+				Side:   tester[x].Side,
+				Type:   tester[x].Type,
+				Amount: 1,
+				Price:  tester[x].Price,
+			},
+			StopPrice: tester[x].StopPrice,
+		}
+		if err := o.Validate(); err != tester[x].ExpectedErr {
+			t.Errorf("Unexpected result. Got: %v, want: %v", err, tester[x].ExpectedErr)
+		}
+	}
+}
+
+func TestCrosses(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	s := Submit{
+		Exchange:  "test",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      Buy,
+		Type:      Limit,
+		Price:     100,
+	}
+	d := &Detail{
+		Exchange:  "test",
+		Pair:      p,
+		AssetType: asset.Spot,
+		Side:      Sell,
+		Type:      Limit,
+		Price:     100,
+		Status:    Active,
+	}
+	if !s.Crosses(d) {
+		t.Error("expected buy at the resting sell price to cross")
+	}
+
+	d.Price = 101
+	if s.Crosses(d) {
+		t.Error("expected buy below the resting sell price not to cross")
+	}
+
+	s.Type = Market
+	if !s.Crosses(d) {
+		t.Error("expected market buy to cross a resting sell")
+	}
+
+	s.Type = Limit
+	s.Side = Sell
+	d.Side = Buy
+	d.Price = 99
+	if s.Crosses(d) {
+		t.Error("expected sell above the resting buy price not to cross")
+	}
+
+	d.Price = 100
+	if !s.Crosses(d) {
+		t.Error("expected sell at the resting buy price to cross")
+	}
+
+	d.Side = Sell
+	if s.Crosses(d) {
+		t.Error("expected orders on the same side not to cross")
+	}
+
+	d.Side = Buy
+	d.Status = Cancelled
+	if s.Crosses(d) {
+		t.Error("expected cancelled orders not to cross")
+	}
+
+	d.Status = Active
+	d.Exchange = "other"
+	if s.Crosses(d) {
+		t.Error("expected orders on other exchanges not to cross")
+	}
+}
+
+func TestLimitsConform(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	l := Limits{
+		Pair:            p,
+		AssetType:       asset.Spot,
+		MinAmount:       0.001,
+		MaxAmount:       100,
+		AmountIncrement: 0.0001,
+		PriceIncrement:  0.01,
+		MinNotional:     10,
+	}
+	for _, tc := range []struct {
+		side          Side
+		amount, price float64
+		expectAmount  float64
+		expectPrice   float64
+		err           bool
+	}{
+		{Buy, 0.12345678, 9000.129, 0.1234, 9000.12, false},
+		{Sell, 0.12345678, 9000.121, 0.1234, 9000.13, false},
+		{Bid, 0.3, 0.07, 0.3, 0.07, true},
+		{Sell, 1.1, 9000.1, 1.1, 9000.1, false},
+		{Buy, 0.00099, 20000, 0.0009, 20000, true},
+		{Buy, 0.00009, 20000, 0, 20000, true},
+		{Buy, 100.1, 1, 100.1, 1, true},
+		{Buy, 0.5, 19.99, 0.5, 19.99, true},
+		{Buy, 0.5, 0.001, 0.5, 0, true},
+	} {
+		s := Submit{Pair: p, Side: tc.side, Type: Limit, Amount: tc.amount, Price: tc.price}
+		err := l.Conform(&s)
+		if (err != nil) != tc.err {
+			t.Errorf("%v %v @ %v: unexpected error %v", tc.side, tc.amount, tc.price, err)
+		}
+		if s.Amount != tc.expectAmount || s.Price != tc.expectPrice {
+			t.Errorf("%v %v @ %v: expected %v @ %v, got %v @ %v",
+				tc.side, tc.amount, tc.price, tc.expectAmount, tc.expectPrice, s.Amount, s.Price)
+		}
+	}
+
+	// market orders without a price are only checked against amounts
+	s := Submit{Pair: p, Side: Buy, Type: Market, Amount: 0.001}
+	if err := l.Conform(&s); err != nil {
+		t.Error(err)
+	}
+	// amounts are rounded exactly rather than in binary floating point,
+	// where 0.3 / 0.1 is 2.9999999999999996
+	l.AmountIncrement = 0.1
+	if a := l.RoundAmount(0.1 + 0.2); a.String() != "0.3" {
+		t.Errorf("expected an amount of 0.3, got %s", a)
+	}
+	if p := l.RoundPrice(1.005, Sell); p.String() != "1.01" {
+		t.Errorf("expected a price of 1.01, got %s", p)
+	}
+
+	var unset Limits
+	s = Submit{Pair: p, Side: Buy, Type: Limit, Amount: 0.123456789, Price: 1.23456789}
+	if err := unset.Conform(&s); err != nil || s.Amount != 0.123456789 || s.Price != 1.23456789 {
+		t.Errorf("expected unset limits not to be enforced, got %v @ %v %v", s.Amount, s.Price, err)
+	}
 }
 
 func TestOrderSides(t *testing.T) {
@@ -17,6 +17,12 @@ var (
 	ErrTypeIsInvalid              = errors.New("order type is invalid")
 	ErrAmountIsInvalid            = errors.New("order amount is invalid")
 	ErrPriceMustBeSetIfLimitOrder = errors.New("order price must be set if limit order type is desired")
+	ErrSelfTradePreventionInvalid = errors.New("order self trade prevention mode is invalid")
+	ErrExecutionOptionsConflict   = errors.New("order can only be one of post only, immediate or cancel, fill or kill or auction only")
+	ErrExecutionOptionLimitOnly   = errors.New("order post only and auction only options require a limit order type")
+	ErrOCOLimitTypeInvalid        = errors.New("oco limit order must be a limit order type")
+	ErrOCOStopPriceInvalid        = errors.New("oco stop price is invalid")
+	ErrOCOPricesInvalid           = errors.New("oco limit price must be on the opposite side of the stop price to the order side")
 )
 
 // Submit contains all properties of an order that may be required
@@ -24,34 +30,42 @@ var (
 // Each exchange has their own requirements, so not all fields
 // are required to be populated
 type Submit struct {
-	ImmediateOrCancel bool
-	HiddenOrder       bool
-	FillOrKill        bool
-	PostOnly          bool
-	Leverage          string
-	Price             float64
-	Amount            float64
-	LimitPriceUpper   float64
-	LimitPriceLower   float64
-	TriggerPrice      float64
-	TargetAmount      float64
-	ExecutedAmount    float64
-	RemainingAmount   float64
-	Fee               float64
-	Exchange          string
-	InternalOrderID   string
-	ID                string
-	AccountID         string
-	ClientID          string
-	WalletAddress     string
-	Type              Type
-	Side              Side
-	Status            Status
-	AssetType         asset.Item
-	Date              time.Time
-	LastUpdated       time.Time
-	Pair              currency.Pair
-	Trades            []TradeHistory
+	ImmediateOrCancel   bool
+	HiddenOrder         bool
+	FillOrKill          bool
+	PostOnly            bool
+	AuctionOnly         bool
+	SelfTradePrevention SelfTradePrevention
+	Leverage            string
+	Price               float64
+	Amount              float64
+	LimitPriceUpper     float64
+	LimitPriceLower     float64
+	TriggerPrice        float64
+	TargetAmount        float64
+	ExecutedAmount      float64
+	RemainingAmount     float64
+	Fee                 float64
+	Exchange            string
+	InternalOrderID     string
+	ID                  string
+	AccountID           string
+	ClientID            string
+	WalletAddress       string
+	Type                Type
+	Side                Side
+	Status              Status
+	AssetType           asset.Item
+	Date                time.Time
+	LastUpdated         time.Time
+	Pair                currency.Pair
+	Trades              []TradeHistory
+	// FallbackExchanges are the exchanges, in order of preference, the order
+	// is routed to when its exchange is offline
+	FallbackExchanges []string
+	// Session is the local session placing the order, such as a strategy
+	// instance, so that the session's orders can be cancelled together
+	Session string
 }
 
 // SubmitResponse is what is returned after submitting an order to an exchange
@@ -59,6 +73,46 @@ type SubmitResponse struct {
 	IsOrderPlaced bool
 	FullyMatched  bool
 	OrderID       string
+	// ClientOrderID is the client order ID sent to the exchange, which can
+	// differ from the one submitted when the exchange requires a prefix or a
+	// shorter ID
+	ClientOrderID string
+}
+
+// OCO contains a resting limit order and a stop order with the same pair, side
+// and amount which an exchange links so that when one executes the other is
+// cancelled
+type OCO struct {
+	Limit Submit
+	// StopPrice is the price which triggers the stop order
+	StopPrice float64
+	// StopLimitPrice is the limit price of the stop order once triggered, a
+	// stop market order is placed when zero
+	StopLimitPrice float64
+}
+
+// OCOResponse is what is returned after submitting an OCO order to an exchange
+type OCOResponse struct {
+	ListID       string
+	LimitOrderID string
+	StopOrderID  string
+}
+
+// Limits are the trading rules of an exchange's pair which orders must meet to
+// be accepted by the exchange. Zero values are not enforced
+type Limits struct {
+	Pair      currency.Pair
+	AssetType asset.Item
+	MinAmount float64
+	MaxAmount float64
+	// AmountIncrement is the step order amounts must be a multiple of, set
+	// from the quantity precision of exchanges which publish decimal places
+	AmountIncrement float64
+	// PriceIncrement is the tick size order prices must be a multiple of
+	PriceIncrement float64
+	// MinNotional is the minimum amount multiplied by price of an order in
+	// the quote currency
+	MinNotional float64
 }
 
 // Modify contains all properties of an order
@@ -109,6 +163,7 @@ type Detail struct {
 	HiddenOrder       bool
 	FillOrKill        bool
 	PostOnly          bool
+	AuctionOnly       bool
 	Leverage          string
 	Price             float64
 	Amount            float64
@@ -133,6 +188,8 @@ type Detail struct {
 	LastUpdated       time.Time
 	Pair              currency.Pair
 	Trades            []TradeHistory
+	// Session is the local session which placed the order
+	Session string
 }
 
 // Cancel contains all properties that may be required
@@ -223,6 +280,17 @@ const (
 	UnknownType       Type = "UNKNOWN"
 )
 
+// SelfTradePrevention determines which orders are cancelled when an order
+// would trade against another order from the same account
+type SelfTradePrevention string
+
+// Self trade prevention modes
+const (
+	CancelNewest SelfTradePrevention = "CANCEL_NEWEST"
+	CancelOldest SelfTradePrevention = "CANCEL_OLDEST"
+	CancelBoth   SelfTradePrevention = "CANCEL_BOTH"
+)
+
 // Side enforces a standard for order sides across the code base
 type Side string
 
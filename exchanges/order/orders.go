@@ -2,10 +2,13 @@ package order
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/decimal"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 )
 
@@ -38,9 +41,150 @@ func (s *Submit) Validate() error {
 		return ErrPriceMustBeSetIfLimitOrder
 	}
 
+	var options int
+	for _, set := range []bool{s.PostOnly, s.ImmediateOrCancel, s.FillOrKill, s.AuctionOnly} {
+		if set {
+			options++
+		}
+	}
+	if options > 1 {
+		return ErrExecutionOptionsConflict
+	}
+
+	if (s.PostOnly || s.AuctionOnly) && s.Type != Limit {
+		return ErrExecutionOptionLimitOnly
+	}
+
+	switch s.SelfTradePrevention {
+	case "", CancelNewest, CancelOldest, CancelBoth:
+	default:
+		return ErrSelfTradePreventionInvalid
+	}
+
+	return nil
+}
+
+// Validate checks the OCO limit order and that its prices are on either side
+// of the market, a sell above or a buy below the stop price
+func (o *OCO) Validate() error {
+	if o == nil {
+		return ErrSubmissionIsNil
+	}
+
+	if o.Limit.Type != Limit {
+		return ErrOCOLimitTypeInvalid
+	}
+
+	if err := o.Limit.Validate(); err != nil {
+		return err
+	}
+
+	if o.StopPrice <= 0 || o.StopLimitPrice < 0 {
+		return ErrOCOStopPriceInvalid
+	}
+
+	switch o.Limit.Side {
+	case Buy, Bid:
+		if o.Limit.Price >= o.StopPrice {
+			return ErrOCOPricesInvalid
+		}
+	case Sell, Ask:
+		if o.Limit.Price <= o.StopPrice {
+			return ErrOCOPricesInvalid
+		}
+	}
 	return nil
 }
 
+// Crosses returns true if the submission would trade against the supplied
+// open order
+func (s *Submit) Crosses(d *Detail) bool {
+	if d == nil ||
+		!strings.EqualFold(s.Exchange, d.Exchange) ||
+		!s.Pair.Equal(d.Pair) ||
+		(s.AssetType != "" && d.AssetType != "" && s.AssetType != d.AssetType) {
+		return false
+	}
+
+	if !d.IsOpen() {
+		return false
+	}
+
+	switch {
+	case (s.Side == Buy || s.Side == Bid) && (d.Side == Sell || d.Side == Ask):
+		return s.Type == Market || d.Type == Market || s.Price >= d.Price
+	case (s.Side == Sell || s.Side == Ask) && (d.Side == Buy || d.Side == Bid):
+		return s.Type == Market || d.Type == Market || s.Price <= d.Price
+	}
+	return false
+}
+
+// IsOpen returns whether the order is resting on the exchange and can still
+// be filled
+func (d *Detail) IsOpen() bool {
+	switch d.Status {
+	case New, Active, Open, PartiallyFilled:
+		return true
+	}
+	return false
+}
+
+// Conform rounds a submission's amount and price to the increments of the
+// pair, as RoundAmount and RoundPrice, then checks the submission against the
+// minimum and maximum amounts and minimum notional. Notional is not checked
+// for market orders without a price
+func (l *Limits) Conform(s *Submit) error {
+	s.Amount = l.RoundAmount(s.Amount).Float64()
+	if s.Price > 0 {
+		s.Price = l.RoundPrice(s.Price, s.Side).Float64()
+		if s.Price <= 0 {
+			return fmt.Errorf("order price is below the %s price increment of %v",
+				s.Pair,
+				l.PriceIncrement)
+		}
+	}
+
+	if s.Amount <= 0 || s.Amount < l.MinAmount {
+		return fmt.Errorf("order amount %v is below the %s minimum of %v",
+			s.Amount,
+			s.Pair,
+			math.Max(l.MinAmount, l.AmountIncrement))
+	}
+	if l.MaxAmount > 0 && s.Amount > l.MaxAmount {
+		return fmt.Errorf("order amount %v exceeds the %s maximum of %v",
+			s.Amount,
+			s.Pair,
+			l.MaxAmount)
+	}
+	if l.MinNotional > 0 && s.Price > 0 {
+		notional := decimal.NewFromFloat(s.Amount).Mul(decimal.NewFromFloat(s.Price))
+		if notional.Cmp(decimal.NewFromFloat(l.MinNotional)) < 0 {
+			return fmt.Errorf("order value %v is below the %s minimum of %v",
+				notional,
+				s.Pair,
+				l.MinNotional)
+		}
+	}
+	return nil
+}
+
+// RoundAmount returns an amount rounded down to the amount increment, so an
+// order never exceeds the amount requested
+func (l *Limits) RoundAmount(amount float64) decimal.Decimal {
+	return decimal.NewFromFloat(amount).FloorToStep(decimal.NewFromFloat(l.AmountIncrement))
+}
+
+// RoundPrice returns a price rounded to the price increment, down for buys
+// and up for sells so the price is never more aggressive than requested
+func (l *Limits) RoundPrice(price float64, side Side) decimal.Decimal {
+	d := decimal.NewFromFloat(price)
+	increment := decimal.NewFromFloat(l.PriceIncrement)
+	if side == Buy || side == Bid {
+		return d.FloorToStep(increment)
+	}
+	return d.CeilToStep(increment)
+}
+
 // UpdateOrderFromDetail Will update an order detail (used in order management)
 // by comparing passed in and existing values
 func (d *Detail) UpdateOrderFromDetail(m *Detail) {
@@ -0,0 +1,130 @@
+package orderbook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// DefaultDepthPercents are the distances from the mid price, as percentages,
+// the depth of an orderbook is measured within when none are specified
+var DefaultDepthPercents = []float64{0.1, 0.5, 1, 2}
+
+// ErrInvalidDepthPercent is returned when a depth is measured within a
+// percentage of the mid price which is not positive
+var ErrInvalidDepthPercent = errors.New("depth percent must be greater than zero")
+
+// Analytics are the spread, imbalance and depth of an orderbook
+type Analytics struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Bid       float64
+	Ask       float64
+	Mid       float64
+	// Spread is the difference between the best ask and bid prices
+	Spread float64
+	// SpreadPercent is the spread as a percentage of the mid price
+	SpreadPercent float64
+	// Imbalance is the difference between the bid and ask amounts of the
+	// whole orderbook divided by their sum, from -1 when there are only asks
+	// to 1 when there are only bids
+	Imbalance   float64
+	Depth       []Depth
+	LastUpdated time.Time
+}
+
+// Depth is the base currency amounts of the orderbook within a percentage of
+// the mid price
+type Depth struct {
+	Percent   float64
+	BidAmount float64
+	AskAmount float64
+	Imbalance float64
+}
+
+// GetAnalytics returns the analytics of a stored orderbook, depth is measured
+// within each of the percentages of the mid price or DefaultDepthPercents
+func GetAnalytics(exchange string, p currency.Pair, a asset.Item, depthPercents ...float64) (*Analytics, error) {
+	b, err := Get(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	return b.Analyse(depthPercents...)
+}
+
+// Analyse returns the orderbook's analytics, depth is measured within each of
+// the percentages of the mid price or DefaultDepthPercents
+func (b *Base) Analyse(depthPercents ...float64) (*Analytics, error) {
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return nil, errors.New(errNoOrderbook)
+	}
+	if len(depthPercents) == 0 {
+		depthPercents = DefaultDepthPercents
+	}
+	for x := range depthPercents {
+		if depthPercents[x] <= 0 {
+			return nil, ErrInvalidDepthPercent
+		}
+	}
+
+	r := Analytics{
+		Exchange:    b.ExchangeName,
+		Pair:        b.Pair,
+		AssetType:   b.AssetType,
+		Bid:         b.Bids[0].Price,
+		Ask:         b.Asks[0].Price,
+		LastUpdated: b.LastUpdated,
+	}
+	r.Mid = (r.Bid + r.Ask) / 2
+	r.Spread = r.Ask - r.Bid
+	if r.Mid > 0 {
+		r.SpreadPercent = r.Spread / r.Mid * 100
+	}
+	bids, _ := b.TotalBidsAmount()
+	asks, _ := b.TotalAsksAmount()
+	r.Imbalance = imbalance(bids, asks)
+
+	r.Depth = make([]Depth, len(depthPercents))
+	for x := range depthPercents {
+		d := Depth{Percent: depthPercents[x]}
+		minPrice := r.Mid * (1 - depthPercents[x]/100)
+		maxPrice := r.Mid * (1 + depthPercents[x]/100)
+		// bids descend and asks ascend in price from the mid price
+		for y := range b.Bids {
+			if b.Bids[y].Price < minPrice {
+				break
+			}
+			d.BidAmount += b.Bids[y].Amount
+		}
+		for y := range b.Asks {
+			if b.Asks[y].Price > maxPrice {
+				break
+			}
+			d.AskAmount += b.Asks[y].Amount
+		}
+		d.Imbalance = imbalance(d.BidAmount, d.AskAmount)
+		r.Depth[x] = d
+	}
+	return &r, nil
+}
+
+// DepthWithin returns the depth measured within the percentage of the mid
+// price
+func (a *Analytics) DepthWithin(percent float64) (Depth, bool) {
+	for x := range a.Depth {
+		if a.Depth[x].Percent == percent {
+			return a.Depth[x], true
+		}
+	}
+	return Depth{}, false
+}
+
+func imbalance(bids, asks float64) float64 {
+	if bids+asks == 0 {
+		return 0
+	}
+	return (bids - asks) / (bids + asks)
+}
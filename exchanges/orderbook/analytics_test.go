@@ -0,0 +1,83 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestAnalyse(t *testing.T) {
+	t.Parallel()
+	b := testSetup()
+	b.Bids = append(b.Bids, Item{Price: 6000, Amount: 5})
+
+	_, err := b.Analyse(0)
+	if err != ErrInvalidDepthPercent {
+		t.Errorf("expected %v, got %v", ErrInvalidDepthPercent, err)
+	}
+	empty := Base{Bids: b.Bids}
+	if _, err = empty.Analyse(); err == nil {
+		t.Error("expected an error analysing an orderbook without asks")
+	}
+
+	r, err := b.Analyse(0.01, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Bid != 6999 || r.Ask != 7000 || r.Mid != 6999.5 || r.Spread != 1 {
+		t.Errorf("unexpected prices %+v", r)
+	}
+	if math.Abs(r.SpreadPercent-1/6999.5*100) > 1e-9 {
+		t.Errorf("unexpected spread percent %v", r.SpreadPercent)
+	}
+	// 8 bid to 3 ask
+	if math.Abs(r.Imbalance-5.0/11) > 1e-9 {
+		t.Errorf("unexpected imbalance %v", r.Imbalance)
+	}
+
+	d, ok := r.DepthWithin(0.01)
+	if !ok {
+		t.Fatal("expected the depth within 0.01%")
+	}
+	if d.BidAmount != 1 || d.AskAmount != 1 || d.Imbalance != 0 {
+		t.Errorf("unexpected depth within 0.01%% %+v", d)
+	}
+	d, _ = r.DepthWithin(1)
+	if d.BidAmount != 3 || d.AskAmount != 3 {
+		t.Errorf("unexpected depth within 1%% %+v", d)
+	}
+	if _, ok = r.DepthWithin(5); ok {
+		t.Error("depth within 5% was not requested")
+	}
+
+	r, err = b.Analyse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Depth) != len(DefaultDepthPercents) {
+		t.Errorf("expected the default depth percents, got %+v", r.Depth)
+	}
+}
+
+func TestGetAnalytics(t *testing.T) {
+	p := currency.NewPairWithDelimiter("ANALYTICS", "USD", "-")
+	if _, err := GetAnalytics("analytics", p, asset.Spot); err == nil {
+		t.Error("expected an error for an orderbook which is not stored")
+	}
+	b := testSetup()
+	b.ExchangeName = "analytics"
+	b.Pair = p
+	b.AssetType = asset.Spot
+	if err := b.Process(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := GetAnalytics("analytics", p, asset.Spot, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Exchange != "analytics" || r.Depth[0].BidAmount != 3 {
+		t.Errorf("unexpected analytics %+v", r)
+	}
+}
@@ -5,14 +5,34 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
+	"sync/atomic"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
+// depthLimit is the amount of levels per side processed orderbooks are
+// truncated to, zero when unlimited
+var depthLimit int32
+
+// SetDepthLimit truncates the bids and asks of orderbooks processed from now on
+// to the limit of levels per side, a limit of zero or less removes the limit
+func SetDepthLimit(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	atomic.StoreInt32(&depthLimit, int32(limit))
+}
+
+// GetDepthLimit returns the amount of levels per side orderbooks are truncated
+// to, zero when unlimited
+func GetDepthLimit() int {
+	return int(atomic.LoadInt32(&depthLimit))
+}
+
 // Get checks and returns the orderbook given an exchange name and currency pair
 // if it exists
 func Get(exchange string, p currency.Pair, a asset.Item) (*Base, error) {
@@ -205,7 +225,7 @@ func (b *Base) TotalAsksAmount() (amountCollated, total float64) {
 func (b *Base) Update(bids, asks []Item) {
 	b.Bids = bids
 	b.Asks = asks
-	b.LastUpdated = time.Now()
+	b.LastUpdated = clock.Now()
 }
 
 // Verify ensures that the orderbook items are correctly sorted
@@ -262,10 +282,22 @@ func (b *Base) Process() error {
 	}
 
 	if b.LastUpdated.IsZero() {
-		b.LastUpdated = time.Now()
+		b.LastUpdated = clock.Now()
 	}
 
 	b.Verify()
 
+	if limit := GetDepthLimit(); limit > 0 && (len(b.Bids) > limit || len(b.Asks) > limit) {
+		// the caller's levels are left intact as websocket orderbooks apply
+		// updates to them
+		truncated := *b
+		if len(truncated.Bids) > limit {
+			truncated.Bids = truncated.Bids[:limit]
+		}
+		if len(truncated.Asks) > limit {
+			truncated.Asks = truncated.Asks[:limit]
+		}
+		return service.Update(&truncated)
+	}
 	return service.Update(b)
 }
@@ -269,6 +269,36 @@ func TestGetOrderbook(t *testing.T) {
 	}
 }
 
+func TestSetDepthLimit(t *testing.T) {
+	SetDepthLimit(1)
+	defer SetDepthLimit(0)
+	if GetDepthLimit() != 1 {
+		t.Fatalf("expected a depth limit of 1, got %d", GetDepthLimit())
+	}
+
+	c := currency.NewPairFromStrings("LTC", "USD")
+	base := &Base{
+		Pair:         c,
+		Asks:         []Item{{Price: 100, Amount: 10}, {Price: 101, Amount: 10}},
+		Bids:         []Item{{Price: 99, Amount: 10}, {Price: 98, Amount: 10}},
+		ExchangeName: "DepthLimit",
+		AssetType:    asset.Spot,
+	}
+	if err := base.Process(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Get("DepthLimit", c, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Bids) != 1 || len(result.Asks) != 1 {
+		t.Errorf("expected the orderbook to be truncated, got %d bids %d asks", len(result.Bids), len(result.Asks))
+	}
+	if len(base.Bids) != 2 || len(base.Asks) != 2 {
+		t.Error("expected the processed orderbook to be left intact")
+	}
+}
+
 func TestCreateNewOrderbook(t *testing.T) {
 	c := currency.NewPairFromStrings("BTC", "USD")
 	base := &Base{
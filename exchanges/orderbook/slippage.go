@@ -0,0 +1,100 @@
+package orderbook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// ErrInvalidSlippageAmount is returned when slippage is estimated for an
+// amount which is not positive
+var ErrInvalidSlippageAmount = errors.New("slippage amount must be greater than zero")
+
+// Slippage is the estimated fill of a market order for an amount of the base
+// currency, found by walking the orderbook from the best price
+type Slippage struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Buy       bool
+	Amount    float64
+	// Filled is the amount the orderbook is able to fill, which is less than
+	// the amount when liquidity is insufficient
+	Filled float64
+	// Cost is the quote currency amount paid or received for the filled
+	// amount
+	Cost         float64
+	AveragePrice float64
+	BestPrice    float64
+	WorstPrice   float64
+	Mid          float64
+	// SlippagePercent is the difference between the average fill price and
+	// the mid price as a percentage of the mid price, positive when the fill
+	// is worse than the mid price
+	SlippagePercent float64
+	Levels          int
+	Sufficient      bool
+	LastUpdated     time.Time
+}
+
+// GetSlippage returns the estimated fill of a market order for an amount of
+// the base currency against a stored orderbook
+func GetSlippage(exchange string, p currency.Pair, a asset.Item, amount float64, buy bool) (*Slippage, error) {
+	b, err := Get(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	return b.EstimateSlippage(amount, buy)
+}
+
+// EstimateSlippage returns the estimated fill of a market order for an amount
+// of the base currency, buys walk the asks and sells walk the bids
+func (b *Base) EstimateSlippage(amount float64, buy bool) (*Slippage, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidSlippageAmount
+	}
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return nil, errors.New(errNoOrderbook)
+	}
+
+	s := Slippage{
+		Exchange:    b.ExchangeName,
+		Pair:        b.Pair,
+		AssetType:   b.AssetType,
+		Buy:         buy,
+		Amount:      amount,
+		Mid:         (b.Bids[0].Price + b.Asks[0].Price) / 2,
+		LastUpdated: b.LastUpdated,
+	}
+	levels := b.Bids
+	if buy {
+		levels = b.Asks
+	}
+	s.BestPrice = levels[0].Price
+	for x := range levels {
+		if s.Filled >= amount {
+			break
+		}
+		fill := levels[x].Amount
+		if s.Filled+fill > amount {
+			fill = amount - s.Filled
+		}
+		s.Filled += fill
+		s.Cost += fill * levels[x].Price
+		s.WorstPrice = levels[x].Price
+		s.Levels++
+	}
+	s.Sufficient = s.Filled >= amount
+	if s.Filled > 0 {
+		s.AveragePrice = s.Cost / s.Filled
+	}
+	if s.Mid > 0 && s.AveragePrice > 0 {
+		s.SlippagePercent = (s.AveragePrice - s.Mid) / s.Mid * 100
+		if !buy {
+			s.SlippagePercent = -s.SlippagePercent
+		}
+	}
+	return &s, nil
+}
@@ -0,0 +1,74 @@
+package orderbook
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestEstimateSlippage(t *testing.T) {
+	t.Parallel()
+	b := testSetup()
+
+	if _, err := b.EstimateSlippage(0, true); err != ErrInvalidSlippageAmount {
+		t.Errorf("expected %v, got %v", ErrInvalidSlippageAmount, err)
+	}
+	empty := Base{Asks: b.Asks}
+	if _, err := empty.EstimateSlippage(1, true); err == nil {
+		t.Error("expected an error estimating slippage without bids")
+	}
+
+	s, err := b.EstimateSlippage(2, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Sufficient || s.Filled != 2 || s.Cost != 14001 || s.AveragePrice != 7000.5 ||
+		s.BestPrice != 7000 || s.WorstPrice != 7001 || s.Levels != 2 {
+		t.Errorf("unexpected buy estimate %+v", s)
+	}
+	if math.Abs(s.SlippagePercent-1/6999.5*100) > 1e-9 {
+		t.Errorf("unexpected buy slippage %v", s.SlippagePercent)
+	}
+
+	s, err = b.EstimateSlippage(2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Sufficient || s.AveragePrice != 6998.5 || s.WorstPrice != 6998 {
+		t.Errorf("unexpected sell estimate %+v", s)
+	}
+	if math.Abs(s.SlippagePercent-1/6999.5*100) > 1e-9 {
+		t.Errorf("expected slippage to be positive for a sell below the mid price, got %v", s.SlippagePercent)
+	}
+
+	s, err = b.EstimateSlippage(5, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Sufficient || s.Filled != 3 || s.Levels != 2 {
+		t.Errorf("expected insufficient liquidity, got %+v", s)
+	}
+}
+
+func TestGetSlippage(t *testing.T) {
+	p := currency.NewPairWithDelimiter("SLIPPAGE", "USD", "-")
+	if _, err := GetSlippage("slippage", p, asset.Spot, 1, true); err == nil {
+		t.Error("expected an error for an orderbook which is not stored")
+	}
+	b := testSetup()
+	b.ExchangeName = "slippage"
+	b.Pair = p
+	b.AssetType = asset.Spot
+	if err := b.Process(); err != nil {
+		t.Fatal(err)
+	}
+	s, err := GetSlippage("slippage", p, asset.Spot, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Exchange != "slippage" || s.AveragePrice != 6999 || s.SlippagePercent <= 0 {
+		t.Errorf("unexpected slippage %+v", s)
+	}
+}
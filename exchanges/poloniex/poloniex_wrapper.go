@@ -198,14 +198,7 @@ func (p *Poloniex) Run() {
 		forceUpdate = true
 	}
 
-	if !p.GetEnabledFeatures().AutoPairUpdates && !forceUpdate {
-		return
-	}
-
-	err := p.UpdateTradablePairs(forceUpdate)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", p.Name, err)
-	}
+	p.UpdateAvailablePairs(p.UpdateTradablePairs, currency.Pair{}, forceUpdate)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
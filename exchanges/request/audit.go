@@ -0,0 +1,63 @@
+package request
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	auditor    Auditor
+	auditorMtx sync.RWMutex
+)
+
+// Auditor records each attempt of an authenticated request and its raw
+// response
+type Auditor interface {
+	Audit(e *AuditEntry)
+}
+
+// AuditEntry is an attempt of an authenticated request with its secrets
+// redacted and the response body as received
+type AuditEntry struct {
+	Capture
+	Attempt int           `json:"attempt"`
+	Latency time.Duration `json:"latency"`
+}
+
+// SetAuditor sets the auditor authenticated requests are passed to, nil stops
+// requests being audited
+func SetAuditor(a Auditor) {
+	auditorMtx.Lock()
+	auditor = a
+	auditorMtx.Unlock()
+}
+
+func getAuditor() Auditor {
+	auditorMtx.RLock()
+	defer auditorMtx.RUnlock()
+	return auditor
+}
+
+// audit passes an attempt of an authenticated request to the auditor. Bodies
+// of responses which are retried are drained before they are read so only
+// their status is recorded
+func (r *Requester) audit(req *http.Request, p *Item, attempt int, latency time.Duration, resp *http.Response, contents []byte, err error) {
+	if !p.AuthRequest {
+		return
+	}
+	a := getAuditor()
+	if a == nil {
+		return
+	}
+	if len(contents) > maxCaptureBodyLength {
+		contents = contents[:maxCaptureBodyLength]
+	}
+	e := AuditEntry{
+		Capture: r.newCapture(req, resp, err),
+		Attempt: attempt,
+		Latency: latency,
+	}
+	e.ResponseBody = string(contents)
+	a.Audit(&e)
+}
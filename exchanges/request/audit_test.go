@@ -0,0 +1,86 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testAuditor struct {
+	m       sync.Mutex
+	name    string
+	entries []AuditEntry
+}
+
+func (a *testAuditor) Audit(e *AuditEntry) {
+	if e.Exchange != a.name {
+		return
+	}
+	a.m.Lock()
+	a.entries = append(a.entries, *e)
+	a.m.Unlock()
+}
+
+func TestAudit(t *testing.T) {
+	var hits int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"orderId":"1","listenKey":"abc"}`))
+	}))
+	defer s.Close()
+
+	a := &testAuditor{name: "audit"}
+	SetAuditor(a)
+	defer SetAuditor(nil)
+
+	r := New(a.name, new(http.Client), WithBackoff(func(int) time.Duration { return 0 }))
+	err := r.SendPayload(context.Background(), &Item{
+		Method:      http.MethodPost,
+		Path:        s.URL + "/order?symbol=BTCUSD&signature=s",
+		Headers:     map[string]string{"X-API-KEY": "k"},
+		Body:        strings.NewReader(`{"secret":"s","amount":1}`),
+		AuthRequest: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// public requests are not audited
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   s.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+	if len(a.entries) != 2 {
+		t.Fatalf("expected each attempt to be audited, got %d entries", len(a.entries))
+	}
+	retried, sent := a.entries[0], a.entries[1]
+	if retried.Attempt != 1 || retried.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected retried attempt %+v", retried)
+	}
+	if sent.Attempt != 2 || sent.StatusCode != http.StatusOK || sent.Error != "" {
+		t.Errorf("unexpected sent attempt %+v", sent)
+	}
+	if strings.Contains(sent.URL, "signature=s") ||
+		sent.RequestHeaders["X-Api-Key"][0] != redacted ||
+		strings.Contains(sent.RequestBody, `"s"`) {
+		t.Errorf("expected secrets to be redacted, got %+v", sent)
+	}
+	if sent.ResponseBody != `{"orderId":"1","listenKey":"abc"}` {
+		t.Errorf("expected the raw response to be recorded, got %s", sent.ResponseBody)
+	}
+}
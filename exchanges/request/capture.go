@@ -0,0 +1,235 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+)
+
+// Const vars for request capturing
+const (
+	// MaxCaptures is the amount of failed requests retained by a requester,
+	// the oldest are discarded first
+	MaxCaptures = 50
+	// maxCaptureBodyLength truncates captured request and response bodies
+	maxCaptureBodyLength = 65536
+	redacted             = "[REDACTED]"
+)
+
+var captureEnabled int32
+
+// sensitiveKeys are matched against lowercased header, query and body field
+// names to determine whether their values are redacted from captures
+var sensitiveKeys = []string{
+	"key",
+	"sign",
+	"secret",
+	"passphrase",
+	"password",
+	"token",
+	"auth",
+	"otp",
+	"cookie",
+}
+
+// Capture is the redacted raw request and response of a failed request
+type Capture struct {
+	Time            time.Time           `json:"time"`
+	Exchange        string              `json:"exchange"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	RequestHeaders  map[string][]string `json:"request_headers,omitempty"`
+	RequestBody     string              `json:"request_body,omitempty"`
+	StatusCode      int                 `json:"status_code,omitempty"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+	ResponseBody    string              `json:"response_body,omitempty"`
+	Error           string              `json:"error,omitempty"`
+}
+
+// EnableCapture sets whether requesters capture the redacted raw request and
+// response of failed requests
+func EnableCapture(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&captureEnabled, v)
+}
+
+// IsCaptureEnabled returns whether failed requests are captured
+func IsCaptureEnabled() bool {
+	return atomic.LoadInt32(&captureEnabled) == 1
+}
+
+// GetCaptures returns a copy of the requester's captured failed requests,
+// oldest first
+func (r *Requester) GetCaptures() []Capture {
+	r.captureMtx.Lock()
+	defer r.captureMtx.Unlock()
+	captures := make([]Capture, len(r.captures))
+	copy(captures, r.captures)
+	return captures
+}
+
+// ClearCaptures discards the requester's captured failed requests
+func (r *Requester) ClearCaptures() {
+	r.captureMtx.Lock()
+	r.captures = nil
+	r.captureMtx.Unlock()
+}
+
+// capture records the redacted request and response of a failed request when
+// capturing is enabled and returns the request's error
+func (r *Requester) capture(req *http.Request, resp *http.Response, contents []byte, err error) error {
+	if err == nil || !IsCaptureEnabled() {
+		return err
+	}
+
+	c := r.newCapture(req, resp, err)
+	if resp != nil {
+		c.ResponseBody = redactBody(contents)
+	}
+
+	r.captureMtx.Lock()
+	if len(r.captures) >= MaxCaptures {
+		r.captures = r.captures[1:]
+	}
+	r.captures = append(r.captures, c)
+	r.captureMtx.Unlock()
+	return err
+}
+
+// newCapture returns the redacted request, response status and headers of a
+// request
+func (r *Requester) newCapture(req *http.Request, resp *http.Response, err error) Capture {
+	c := Capture{
+		Time:           clock.Now(),
+		Exchange:       r.Name,
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	if req.GetBody != nil {
+		if body, bodyErr := req.GetBody(); bodyErr == nil {
+			var buf bytes.Buffer
+			if _, bodyErr = buf.ReadFrom(body); bodyErr == nil {
+				c.RequestBody = redactBody(buf.Bytes())
+			}
+			body.Close()
+		}
+	}
+	if resp != nil {
+		c.StatusCode = resp.StatusCode
+		c.ResponseHeaders = redactHeaders(resp.Header)
+	}
+	return c
+}
+
+// isSensitive returns whether the value of a header, query or body field
+// should be redacted
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for x := range sensitiveKeys {
+		if strings.Contains(name, sensitiveKeys[x]) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeaders(h http.Header) map[string][]string {
+	if len(h) == 0 {
+		return nil
+	}
+	headers := make(map[string][]string, len(h))
+	for k, v := range h {
+		if isSensitive(k) {
+			headers[k] = []string{redacted}
+			continue
+		}
+		headers[k] = append([]string(nil), v...)
+	}
+	return headers
+}
+
+func redactValues(v url.Values) {
+	for k := range v {
+		if isSensitive(k) {
+			v[k] = []string{redacted}
+		}
+	}
+}
+
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redactedURL := *u
+	query := u.Query()
+	redactValues(query)
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactBody redacts sensitive fields of JSON and form encoded bodies, other
+// bodies are captured as is
+func redactBody(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) > maxCaptureBodyLength {
+		body = body[:maxCaptureBodyLength]
+	}
+	if len(body) == 0 {
+		return ""
+	}
+
+	if body[0] == '{' || body[0] == '[' {
+		d := json.NewDecoder(bytes.NewReader(body))
+		// numbers are retained as sent
+		d.UseNumber()
+		var v interface{}
+		if err := d.Decode(&v); err == nil {
+			if b, err := json.Marshal(redactJSON(v)); err == nil {
+				return string(b)
+			}
+		}
+		return string(body)
+	}
+
+	if bytes.ContainsRune(body, '=') && !bytes.ContainsAny(body, " \n") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			redactValues(form)
+			return form.Encode()
+		}
+	}
+	return string(body)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k := range t {
+			if isSensitive(k) {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactJSON(t[k])
+		}
+	case []interface{}:
+		for x := range t {
+			t[x] = redactJSON(t[x])
+		}
+	}
+	return v
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// isRateLimited returns whether the response signals the exchange's rate
+// limits were exceeded, either by its status code or as mapped from its body
+// by the exchange's error parser
+func (r *Requester) isRateLimited(resp *http.Response, body []byte) bool {
+	if resp == nil {
+		return false
+	}
+	if statusCodeErrorType(resp.StatusCode) == ErrRateLimited {
+		return true
+	}
+	if body == nil || r.errorParser == nil {
+		return false
+	}
+	_, errType := r.errorParser(resp.StatusCode, body)
+	return errType == ErrRateLimited
+}
+
+// pause holds every request of the exchange until the cooldown of a rate
+// limited response has passed. The Retry-After header is respected, otherwise
+// the exchange's cooldown is used
+func (r *Requester) pause(resp *http.Response) {
+	d := RetryAfter(resp, time.Now())
+	if d <= 0 {
+		d = r.rateLimitCooldown
+	}
+	until := time.Now().Add(d).UnixNano()
+	for {
+		current := atomic.LoadInt64(&r.pausedUntil)
+		if current >= until {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&r.pausedUntil, current, until) {
+			break
+		}
+	}
+	log.Warnf(log.RequestSys,
+		"%s rate limited, pausing requests for %s.\n",
+		r.Name,
+		d)
+}
+
+// awaitCooldown waits until requests are no longer paused, failing when the
+// request's context ends before then
+func (r *Requester) awaitCooldown(ctx context.Context) error {
+	until := time.Unix(0, atomic.LoadInt64(&r.pausedUntil))
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+	if d, ok := ctx.Deadline(); ok && d.Before(until) {
+		return &Error{
+			Exchange: r.Name,
+			Message:  "requests are paused by a rate limit cooldown until " + until.String(),
+			Type:     ErrRateLimited,
+		}
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PausedUntil returns the time requests are paused until after the exchange
+// rate limited a request
+func (r *Requester) PausedUntil() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&r.pausedUntil))
+}
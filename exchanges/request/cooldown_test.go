@@ -0,0 +1,146 @@
+package request
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRequest_RateLimitCooldown(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	var first, second int64
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			atomic.StoreInt64(&first, time.Now().UnixNano())
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		atomic.CompareAndSwapInt64(&second, 0, time.Now().UnixNano())
+		_, _ = w.Write([]byte(`{"response":true}`))
+	}))
+	defer s.Close()
+
+	cooldown := time.Millisecond * 100
+	r := New("test", new(http.Client), WithRateLimitCooldown(cooldown))
+	var resp struct {
+		Response bool `json:"response"`
+	}
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   s.URL,
+		Result: &resp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Response {
+		t.Error("expected the rate limited request to be retried")
+	}
+	if wait := time.Duration(second - first); wait < cooldown {
+		t.Errorf("expected the retry to wait out the cooldown, waited %s", wait)
+	}
+	if !r.PausedUntil().After(time.Unix(0, first)) {
+		t.Error("expected requests to be paused")
+	}
+
+	// requests made while paused wait until the cooldown has passed
+	r.pause(&http.Response{Header: http.Header{}})
+	ctx, cancel := context.WithTimeout(context.Background(), cooldown/2)
+	defer cancel()
+	err = r.SendPayload(ctx, &Item{
+		Method: http.MethodGet,
+		Path:   s.URL,
+	})
+	if !IsError(err, ErrRateLimited) {
+		t.Errorf("expected %v, got %v", ErrRateLimited, err)
+	}
+	if h := atomic.LoadInt32(&hits); h != 2 {
+		t.Errorf("expected no request to be sent while paused, got %d requests", h)
+	}
+}
+
+func TestDoRequest_RateLimitErrorBody(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"slow down"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	parser := func(_ int, body []byte) (string, error) {
+		if string(body) == `{"error":"slow down"}` {
+			return "slow down", ErrRateLimited
+		}
+		return "", nil
+	}
+	r := New("test", new(http.Client),
+		WithErrorParser(parser),
+		WithRateLimitCooldown(time.Millisecond))
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   s.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := atomic.LoadInt32(&hits); h != 2 {
+		t.Errorf("expected the rate limited request to be retried, got %d requests", h)
+	}
+
+	// the body of a retried request is sent again, including bodies the
+	// HTTP client cannot rewind itself
+	var bodies []string
+	var m sync.Mutex
+	post := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		m.Lock()
+		bodies = append(bodies, string(body))
+		retried := len(bodies) > 1
+		m.Unlock()
+		if !retried {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"slow down"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer post.Close()
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodPost,
+		Path:   post.URL,
+		Body:   ioutil.NopCloser(strings.NewReader(`{"amount":1}`)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Lock()
+	if len(bodies) != 2 || bodies[1] != bodies[0] || bodies[0] != `{"amount":1}` {
+		t.Errorf("expected the retried request to send the same body, got %q", bodies)
+	}
+	m.Unlock()
+
+	// requests using a nonce cannot be retried
+	atomic.StoreInt32(&hits, 0)
+	err = r.SendPayload(context.Background(), &Item{
+		Method:       http.MethodGet,
+		Path:         s.URL,
+		NonceEnabled: true,
+	})
+	if !IsError(err, ErrRateLimited) {
+		t.Errorf("expected %v, got %v", ErrRateLimited, err)
+	}
+}
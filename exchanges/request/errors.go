@@ -0,0 +1,93 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Exchange API error types parsed from each exchange's error responses so
+// callers can react to them programmatically
+var (
+	ErrRateLimited       = errors.New("rate limited")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidNonce      = errors.New("invalid nonce")
+	ErrOrderNotFound     = errors.New("order not found")
+)
+
+// Error is an error returned by an exchange API, either as an unsuccessful
+// HTTP status code or as an error payload in an otherwise successful response
+type Error struct {
+	Exchange string
+	// StatusCode is the HTTP status code of the response, zero when the error
+	// was returned in a successful response
+	StatusCode int
+	// Code is the exchange specific error code or reason
+	Code    string
+	Message string
+	// Type is one of the exchange API error types, nil when the error could
+	// not be mapped
+	Type error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s unsuccessful HTTP status code: %d raw response: %s",
+			e.Exchange,
+			e.StatusCode,
+			e.Message)
+	}
+	return fmt.Sprintf("%s API error: %s", e.Exchange, e.Message)
+}
+
+// Unwrap returns the exchange API error type
+func (e *Error) Unwrap() error {
+	return e.Type
+}
+
+// IsError returns whether the error is or was mapped to the target exchange
+// API error type
+func IsError(err, target error) bool {
+	if err == nil {
+		return false
+	}
+	if err == target {
+		return true
+	}
+	e, ok := err.(*Error)
+	return ok && e.Type != nil && e.Type == target
+}
+
+// ErrorParser parses an exchange's unsuccessful response body and returns the
+// exchange specific error code and the matching exchange API error type, if
+// any
+type ErrorParser func(statusCode int, body []byte) (code string, errType error)
+
+// responseError returns the error for an unsuccessful response
+func (r *Requester) responseError(statusCode int, body []byte) error {
+	e := &Error{
+		Exchange:   r.Name,
+		StatusCode: statusCode,
+		Message:    string(body),
+	}
+	if r.errorParser != nil {
+		e.Code, e.Type = r.errorParser(statusCode, body)
+	}
+	if e.Type == nil {
+		e.Type = statusCodeErrorType(statusCode)
+	}
+	return e
+}
+
+// statusCodeErrorType maps HTTP status codes which are used consistently
+// across exchanges to an exchange API error type
+func statusCodeErrorType(statusCode int) error {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		// HTTP 418 is used by some exchanges to signal an IP ban after
+		// repeatedly exceeding rate limits
+		return ErrRateLimited
+	}
+	return nil
+}
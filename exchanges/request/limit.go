@@ -60,7 +60,7 @@ func NewBasicRateLimit(interval time.Duration, actions int) Limiter {
 
 // InitiateRateLimit sleeps for designated end point rate limits
 func (r *Requester) InitiateRateLimit(e EndpointLimit) error {
-	if atomic.LoadInt32(&r.disableRateLimiter) == 1 {
+	if r.rateLimiterDisabled() {
 		return nil
 	}
 
@@ -71,6 +71,10 @@ func (r *Requester) InitiateRateLimit(e EndpointLimit) error {
 	return nil
 }
 
+func (r *Requester) rateLimiterDisabled() bool {
+	return atomic.LoadInt32(&r.disableRateLimiter) == 1
+}
+
 // DisableRateLimiter disables the rate limiting system for the exchange
 func (r *Requester) DisableRateLimiter() error {
 	if !atomic.CompareAndSwapInt32(&r.disableRateLimiter, 0, 1) {
@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 // WithBackoff configures the backoff strategy for a Requester.
 func WithBackoff(b Backoff) RequesterOption {
 	return func(r *Requester) {
@@ -14,6 +16,23 @@ func WithLimiter(l Limiter) RequesterOption {
 	}
 }
 
+// WithErrorParser configures how a Requester maps the exchange's unsuccessful
+// responses to exchange API error types.
+func WithErrorParser(p ErrorParser) RequesterOption {
+	return func(r *Requester) {
+		r.errorParser = p
+	}
+}
+
+// WithRateLimitCooldown configures how long the Requester pauses all requests
+// after a rate limited response without a Retry-After header, following the
+// exchange's documented cooldown
+func WithRateLimitCooldown(d time.Duration) RequesterOption {
+	return func(r *Requester) {
+		r.rateLimitCooldown = d
+	}
+}
+
 // WithRetryPolicy configures the retry policy for a Requester.
 func WithRetryPolicy(p RetryPolicy) RequesterOption {
 	return func(r *Requester) {
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,9 +12,11 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
@@ -23,12 +26,13 @@ import (
 // New returns a new Requester
 func New(name string, httpRequester *http.Client, opts ...RequesterOption) *Requester {
 	r := &Requester{
-		HTTPClient:  httpRequester,
-		Name:        name,
-		backoff:     DefaultBackoff(),
-		retryPolicy: DefaultRetryPolicy,
-		maxRetries:  MaxRetryAttempts,
-		timedLock:   timedmutex.NewTimedMutex(DefaultMutexLockTimeout),
+		HTTPClient:        httpRequester,
+		Name:              name,
+		backoff:           DefaultBackoff(),
+		retryPolicy:       DefaultRetryPolicy,
+		maxRetries:        MaxRetryAttempts,
+		timedLock:         timedmutex.NewTimedMutex(DefaultMutexLockTimeout),
+		rateLimitCooldown: DefaultRateLimitCooldown,
 	}
 
 	for _, o := range opts {
@@ -44,6 +48,19 @@ func (r *Requester) SendPayload(ctx context.Context, i *Item) error {
 		r.timedLock.LockForDuration()
 	}
 
+	switch i.Body.(type) {
+	case nil, *bytes.Buffer, *bytes.Reader, *strings.Reader:
+	default:
+		// Buffer the body so it can be sent again when the request is
+		// retried and read again when capturing a failure
+		body, err := ioutil.ReadAll(i.Body)
+		if err != nil {
+			r.timedLock.UnlockIfLocked()
+			return err
+		}
+		i.Body = bytes.NewReader(body)
+	}
+
 	req, err := i.validateRequest(ctx, r)
 	if err != nil {
 		r.timedLock.UnlockIfLocked()
@@ -96,6 +113,12 @@ func (i *Item) validateRequest(ctx context.Context, r *Requester) (*http.Request
 		req.Header.Add(userAgent, r.UserAgent)
 	}
 
+	for k, v := range r.Headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Add(k, v)
+		}
+	}
+
 	return req, nil
 }
 
@@ -132,19 +155,44 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 	}
 
 	for attempt := 1; ; attempt++ {
-		// Initiate a rate limit reservation and sleep on requested endpoint
-		err := r.InitiateRateLimit(p.Endpoint)
+		// Wait out any cooldown after the exchange rate limited a request
+		err := r.awaitCooldown(req.Context())
+		if err != nil {
+			return err
+		}
+
+		if attempt > 1 {
+			// the previous attempt consumed the body
+			if err = rewindBody(req); err != nil {
+				return err
+			}
+		}
+
+		// Wait for the request's turn on the endpoint's rate limit, then
+		// initiate a rate limit reservation and sleep on it
+		err = r.scheduleRateLimit(req, p)
 		if err != nil {
 			return err
 		}
 
+		start := time.Now()
 		resp, err := r.HTTPClient.Do(req)
+		latency := time.Since(start)
+		r.recordAttempt(latency, resp, err)
+		if err == nil {
+			r.scheduler.observe(endpointKey(req), latency)
+		}
 		if retry, checkErr := r.retryPolicy(resp, err); checkErr != nil {
-			return checkErr
+			r.audit(req, p, attempt, latency, resp, nil, checkErr)
+			return r.capture(req, resp, nil, checkErr)
 		} else if retry {
+			r.audit(req, p, attempt, latency, resp, nil, err)
 			if err == nil {
 				// If the body isn't fully read, the connection cannot be re-used
 				r.drainBody(resp.Body)
+				if r.isRateLimited(resp, nil) {
+					r.pause(resp)
+				}
 			}
 
 			// Can't currently regenerate nonce and signatures with fresh values for retries, so for now, we must not retry
@@ -156,9 +204,9 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 
 			if attempt > r.maxRetries {
 				if err != nil {
-					return fmt.Errorf("request.go error - failed to retry request, err: %v", err)
+					return r.capture(req, nil, nil, fmt.Errorf("request.go error - failed to retry request, err: %v", err))
 				}
-				return fmt.Errorf("request.go error - failed to retry request, status: %s", resp.Status)
+				return r.capture(req, resp, nil, r.responseError(resp.StatusCode, []byte(resp.Status)))
 			}
 
 			after := RetryAfter(resp, time.Now())
@@ -188,6 +236,7 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 		}
 
 		contents, err := ioutil.ReadAll(resp.Body)
+		r.audit(req, p, attempt, latency, resp, contents, err)
 		if err != nil {
 			return err
 		}
@@ -202,10 +251,16 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 
 		if resp.StatusCode < http.StatusOK ||
 			resp.StatusCode > http.StatusAccepted {
-			return fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
-				r.Name,
-				resp.StatusCode,
-				string(contents))
+			// rate limits signalled in the body are retried once the
+			// exchange's requests have cooled down
+			if r.isRateLimited(resp, contents) {
+				r.pause(resp)
+				if !p.NonceEnabled && attempt <= r.maxRetries {
+					resp.Body.Close()
+					continue
+				}
+			}
+			return r.capture(req, resp, contents, r.responseError(resp.StatusCode, contents))
 		}
 
 		if p.HTTPDebugging {
@@ -231,21 +286,52 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 			}
 		}
 		if p.Result != nil {
-			return json.Unmarshal(contents, p.Result)
+			return r.capture(req, resp, contents, json.Unmarshal(contents, p.Result))
 		}
 		return nil
 	}
 }
 
+// rewindBody resets the body of a request being sent again
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("request.go error - unable to retry request, err: %v", err)
+	}
+	req.Body = body
+	return nil
+}
+
+// SetClockOffset sets the offset added to the local time when timestamping
+// requests and generating nonces, correcting drift from the exchange's clock
+func (r *Requester) SetClockOffset(d time.Duration) {
+	atomic.StoreInt64(&r.clockOffset, int64(d))
+}
+
+// ClockOffset returns the offset added to the local time when timestamping
+// requests
+func (r *Requester) ClockOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&r.clockOffset))
+}
+
+// Now returns the local time adjusted by the clock offset, authenticated
+// requests are timestamped with it
+func (r *Requester) Now() time.Time {
+	return clock.Now().Add(r.ClockOffset())
+}
+
 // GetNonce returns a nonce for requests. This locks and enforces concurrent
 // nonce FIFO on the buffered job channel
 func (r *Requester) GetNonce(isNano bool) nonce.Value {
 	r.timedLock.LockForDuration()
 	if r.Nonce.Get() == 0 {
 		if isNano {
-			r.Nonce.Set(time.Now().UnixNano())
+			r.Nonce.Set(r.Now().UnixNano())
 		} else {
-			r.Nonce.Set(time.Now().Unix())
+			r.Nonce.Set(r.Now().Unix())
 		}
 		return r.Nonce.Get()
 	}
@@ -258,7 +344,7 @@ func (r *Requester) GetNonce(isNano bool) nonce.Value {
 func (r *Requester) GetNonceMilli() nonce.Value {
 	r.timedLock.LockForDuration()
 	if r.Nonce.Get() == 0 {
-		r.Nonce.Set(time.Now().UnixNano() / int64(time.Millisecond))
+		r.Nonce.Set(r.Now().UnixNano() / int64(time.Millisecond))
 		return r.Nonce.Get()
 	}
 	r.Nonce.Inc()
@@ -271,6 +357,13 @@ func (r *Requester) SetProxy(p *url.URL) error {
 		return errors.New("no proxy URL supplied")
 	}
 
+	// Retain any transport tuning such as custom CA certificates or keep
+	// alive settings, the default transport is shared so it is not modified
+	if t, ok := r.HTTPClient.Transport.(*http.Transport); ok && t != http.DefaultTransport {
+		t.Proxy = http.ProxyURL(p)
+		return nil
+	}
+
 	r.HTTPClient.Transport = &http.Transport{
 		Proxy:               http.ProxyURL(p),
 		TLSHandshakeTimeout: proxyTLSTimeout,
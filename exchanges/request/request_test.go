@@ -163,6 +163,24 @@ func TestCheckRequest(t *testing.T) {
 	if req.UserAgent() != "r00t axxs" {
 		t.Fatal(unexpected)
 	}
+
+	// Test requester headers do not override request headers
+	r.Headers = map[string]string{
+		"Content-Type": "boring",
+		"X-Broker-ID":  "gct",
+	}
+	req, err = check.validateRequest(ctx, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Header.Get("Content-Type") != "Super awesome HTTP party experience" {
+		t.Fatal(unexpected)
+	}
+
+	if req.Header.Get("X-Broker-ID") != "gct" {
+		t.Fatal(unexpected)
+	}
 }
 
 type GlobalLimitTest struct {
@@ -396,6 +414,71 @@ func TestDoRequest_RetryNonRecoverable(t *testing.T) {
 	if payloadError == nil {
 		t.Fatal("expected an error")
 	}
+	if !IsError(payloadError, ErrRateLimited) {
+		t.Errorf("expected %v, got %v", ErrRateLimited, payloadError)
+	}
+}
+
+func TestDoRequest_ErrorParser(t *testing.T) {
+	t.Parallel()
+
+	r := New("test", new(http.Client))
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/error",
+	})
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected request error, got %v", err)
+	}
+	if e.StatusCode != http.StatusBadRequest || e.Type != nil {
+		t.Errorf("unexpected error %+v", e)
+	}
+	if err.Error() != `test unsuccessful HTTP status code: 400 raw response: {"error":true}` {
+		t.Errorf("unexpected error message %s", err)
+	}
+
+	parser := func(statusCode int, body []byte) (string, error) {
+		if statusCode == http.StatusBadRequest && strings.Contains(string(body), "error") {
+			return "InsufficientFunds", ErrInsufficientFunds
+		}
+		return "", nil
+	}
+	r = New("test", new(http.Client), WithErrorParser(parser))
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/error",
+	})
+	if !IsError(err, ErrInsufficientFunds) {
+		t.Errorf("expected %v, got %v", ErrInsufficientFunds, err)
+	}
+	if err.(*Error).Code != "InsufficientFunds" {
+		t.Errorf("unexpected error code %s", err.(*Error).Code)
+	}
+}
+
+func TestIsError(t *testing.T) {
+	t.Parallel()
+
+	if IsError(nil, ErrOrderNotFound) {
+		t.Error("expected nil error not to match")
+	}
+	if !IsError(ErrOrderNotFound, ErrOrderNotFound) {
+		t.Error("expected error type to match itself")
+	}
+	if IsError(errors.New("order not found"), ErrOrderNotFound) {
+		t.Error("expected unrelated error not to match")
+	}
+	if IsError(&Error{Exchange: "test"}, ErrOrderNotFound) {
+		t.Error("expected unmapped error not to match")
+	}
+	err := &Error{Exchange: "test", Message: "Unknown order", Type: ErrOrderNotFound}
+	if !IsError(err, ErrOrderNotFound) || IsError(err, ErrInvalidNonce) {
+		t.Error("expected error to only match its type")
+	}
+	if err.Error() != "test API error: Unknown order" {
+		t.Errorf("unexpected error message %s", err)
+	}
 }
 
 func TestDoRequest_NotRetryable(t *testing.T) {
@@ -451,6 +534,23 @@ func TestGetNonceMillis(t *testing.T) {
 	}
 }
 
+func TestClockOffset(t *testing.T) {
+	t.Parallel()
+	r := New("test",
+		new(http.Client),
+		WithLimiter(&globalshell))
+	r.SetClockOffset(time.Hour)
+	if r.ClockOffset() != time.Hour {
+		t.Fatalf("expected an offset of %s, got %s", time.Hour, r.ClockOffset())
+	}
+	if d := r.Now().Sub(time.Now()); d < time.Minute*59 {
+		t.Errorf("expected the time to be offset, got %s", d)
+	}
+	if n := r.GetNonce(false); int64(n) < time.Now().Add(time.Minute*59).Unix() {
+		t.Errorf("expected the nonce to be offset, got %v", n)
+	}
+}
+
 func TestSetProxy(t *testing.T) {
 	t.Parallel()
 	r := New("test",
@@ -472,6 +572,20 @@ func TestSetProxy(t *testing.T) {
 	if err == nil {
 		t.Fatal("error cannot be nil")
 	}
+
+	transport := &http.Transport{MaxIdleConnsPerHost: 1337}
+	r.HTTPClient.Transport = transport
+	u, err = url.Parse("socks5://127.0.0.1:1080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SetProxy(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.HTTPClient.Transport != transport || transport.Proxy == nil {
+		t.Error("expected proxy to be set on the existing transport")
+	}
 }
 
 func TestBasicLimiter(t *testing.T) {
@@ -564,3 +678,112 @@ func TestEnableDisableRateLimit(t *testing.T) {
 		// Correct test
 	}
 }
+
+func TestGetStats(t *testing.T) {
+	t.Parallel()
+
+	r := New("test", new(http.Client), WithBackoff(func(n int) time.Duration { return 0 }))
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/error",
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	s := r.GetStats()
+	if s.Requests != 2 || s.Failures != 0 || s.TotalLatency <= 0 || s.AverageLatency() <= 0 {
+		t.Errorf("expected client errors not to be counted as failures, got %+v", s)
+	}
+
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/timeout",
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	s = r.GetStats()
+	if s.Requests != 3 || s.Failures != 1 || s.LastFailure.IsZero() {
+		t.Errorf("expected the server error to be counted as a failure, got %+v", s)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	r := New("test", new(http.Client))
+	body := `{"symbol":"BTCUSD","amount":0.10000000,"apiKey":"k","nested":[{"signature":"s"}]}`
+	item := func() *Item {
+		return &Item{
+			Method:  http.MethodPost,
+			Path:    testURL + "/error?symbol=BTCUSD&api_key=k",
+			Headers: map[string]string{"X-MBX-APIKEY": "k", "Content-Type": "application/json"},
+			Body:    strings.NewReader(body),
+		}
+	}
+	if err := r.SendPayload(context.Background(), item()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if c := r.GetCaptures(); len(c) != 0 {
+		t.Fatalf("expected requests not to be captured when disabled, got %+v", c)
+	}
+
+	EnableCapture(true)
+	defer EnableCapture(false)
+	if err := r.SendPayload(context.Background(), item()); err == nil {
+		t.Fatal("expected an error")
+	}
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.GetCaptures()
+	if len(c) != 1 {
+		t.Fatalf("expected only the failed request to be captured, got %+v", c)
+	}
+	if c[0].Exchange != "test" || c[0].Method != http.MethodPost ||
+		c[0].StatusCode != http.StatusBadRequest || c[0].ResponseBody != `{"error":true}` ||
+		c[0].Error == "" {
+		t.Errorf("unexpected capture %+v", c[0])
+	}
+	if c[0].URL != testURL+"/error?api_key=%5BREDACTED%5D&symbol=BTCUSD" {
+		t.Errorf("expected the query to be redacted, got %s", c[0].URL)
+	}
+	if c[0].RequestHeaders["X-Mbx-Apikey"][0] != redacted ||
+		c[0].RequestHeaders["Content-Type"][0] != "application/json" {
+		t.Errorf("expected the headers to be redacted, got %v", c[0].RequestHeaders)
+	}
+	expected := `{"amount":0.10000000,"apiKey":"[REDACTED]","nested":[{"signature":"[REDACTED]"}],"symbol":"BTCUSD"}`
+	if c[0].RequestBody != expected {
+		t.Errorf("expected %s, got %s", expected, c[0].RequestBody)
+	}
+
+	r.ClearCaptures()
+	if c = r.GetCaptures(); len(c) != 0 {
+		t.Errorf("expected captures to be cleared, got %+v", c)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+	for body, expected := range map[string]string{
+		"":                         "",
+		"symbol=BTCUSD&secret=abc": "secret=%5BREDACTED%5D&symbol=BTCUSD",
+		"not a form=body":          "not a form=body",
+		"{invalid":                 "{invalid",
+		`["a",{"token":1}]`:        `["a",{"token":"[REDACTED]"}]`,
+	} {
+		if s := redactBody([]byte(body)); s != expected {
+			t.Errorf("%q: expected %s, got %s", body, expected, s)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package request
 import (
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
@@ -14,9 +15,12 @@ const (
 	DefaultMaxRequestJobs   int32 = 50
 	DefaultMaxRetryAttempts       = 3
 	DefaultMutexLockTimeout       = 50 * time.Millisecond
-	drainBodyLimit                = 100000
-	proxyTLSTimeout               = 15 * time.Second
-	userAgent                     = "User-Agent"
+	// DefaultRateLimitCooldown is how long an exchange's requests are paused
+	// after a rate limited response without a Retry-After header
+	DefaultRateLimitCooldown = time.Second
+	drainBodyLimit           = 100000
+	proxyTLSTimeout          = 15 * time.Second
+	userAgent                = "User-Agent"
 )
 
 // Vars for rate limiter
@@ -27,17 +31,30 @@ var (
 
 // Requester struct for the request client
 type Requester struct {
+	// pausedUntil is the unix nano time requests are paused until after a
+	// rate limited response and clockOffset is the nanoseconds added to the
+	// local time to match the exchange's clock, first for 64 bit alignment
+	pausedUntil        int64
+	clockOffset        int64
 	HTTPClient         *http.Client
 	limiter            Limiter
 	Name               string
 	UserAgent          string
+	Headers            map[string]string
 	maxRetries         int
 	jobs               int32
 	Nonce              nonce.Nonce
 	disableRateLimiter int32
 	backoff            Backoff
 	retryPolicy        RetryPolicy
+	errorParser        ErrorParser
+	rateLimitCooldown  time.Duration
 	timedLock          *timedmutex.TimedMutex
+	stats              Stats
+	statsMtx           sync.Mutex
+	captures           []Capture
+	captureMtx         sync.Mutex
+	scheduler          scheduler
 }
 
 // Item is a temp item for requests
@@ -54,6 +71,7 @@ type Item struct {
 	HTTPRecording bool
 	IsReserved    bool
 	Endpoint      EndpointLimit
+	Priority      Priority
 }
 
 // Backoff determines how long to wait between request attempts.
@@ -0,0 +1,223 @@
+package request
+
+import (
+	"container/heap"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Priority determines the order in which requests waiting on the same rate
+// limit are sent
+type Priority uint8
+
+// Request priorities, higher priority requests waiting on a rate limit are
+// sent before lower priority requests regardless of when they were made
+const (
+	// PriorityAuto infers the priority of a request. Authenticated requests
+	// which are not reads, such as order placement and cancellation, are high
+	// priority and all other requests are normal priority
+	PriorityAuto Priority = iota
+	PriorityLow
+	PriorityNormal
+	PriorityHigh
+)
+
+// latencySmoothing is the weight of the latest latency of an endpoint in its
+// moving average
+const latencySmoothing = 0.2
+
+// scheduler orders the requests waiting on each of an exchange's rate limits.
+// Only one request at a time waits on a rate limit, the others are queued by
+// priority and then by how soon they must be sent to meet their deadline given
+// the measured latency of their endpoint. The zero value is ready to use
+type scheduler struct {
+	m        sync.Mutex
+	queues   map[EndpointLimit]*requestQueue
+	latency  map[string]time.Duration
+	sequence uint64
+}
+
+// requestQueue holds the requests waiting for their turn on a rate limit
+type requestQueue struct {
+	busy    bool
+	waiting scheduledRequests
+}
+
+// scheduledRequest is a request waiting for its turn on a rate limit
+type scheduledRequest struct {
+	priority Priority
+	// sendBy is the latest time the request can be sent and still be
+	// expected to complete by its deadline, zero when it has no deadline
+	sendBy   time.Time
+	sequence uint64
+	ready    chan struct{}
+	index    int
+}
+
+// scheduledRequests is a heap of requests, the next request to be sent first
+type scheduledRequests []*scheduledRequest
+
+func (s scheduledRequests) Len() int { return len(s) }
+
+func (s scheduledRequests) Less(i, j int) bool {
+	if s[i].priority != s[j].priority {
+		return s[i].priority > s[j].priority
+	}
+	if !s[i].sendBy.Equal(s[j].sendBy) {
+		if s[i].sendBy.IsZero() || s[j].sendBy.IsZero() {
+			return s[j].sendBy.IsZero()
+		}
+		return s[i].sendBy.Before(s[j].sendBy)
+	}
+	return s[i].sequence < s[j].sequence
+}
+
+func (s scheduledRequests) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+	s[i].index = i
+	s[j].index = j
+}
+
+func (s *scheduledRequests) Push(x interface{}) {
+	r := x.(*scheduledRequest)
+	r.index = len(*s)
+	*s = append(*s, r)
+}
+
+func (s *scheduledRequests) Pop() interface{} {
+	old := *s
+	r := old[len(old)-1]
+	old[len(old)-1] = nil
+	r.index = -1
+	*s = old[:len(old)-1]
+	return r
+}
+
+// scheduleRateLimit waits for the request's turn on its rate limit and then
+// for the rate limit itself
+func (r *Requester) scheduleRateLimit(req *http.Request, p *Item) error {
+	if r.limiter == nil || r.rateLimiterDisabled() {
+		return nil
+	}
+	err := r.scheduler.acquire(req.Context(), p.Endpoint, r.newScheduledRequest(req, p))
+	if err != nil {
+		return err
+	}
+	defer r.scheduler.release(p.Endpoint)
+	return r.InitiateRateLimit(p.Endpoint)
+}
+
+// newScheduledRequest returns the scheduling of a request. Requests using a
+// nonce must be sent in the order their nonces were generated, so they are
+// always high priority and sent in the order they were made
+func (r *Requester) newScheduledRequest(req *http.Request, p *Item) *scheduledRequest {
+	s := &scheduledRequest{priority: p.Priority}
+	if p.NonceEnabled {
+		s.priority = PriorityHigh
+		return s
+	}
+	if s.priority == PriorityAuto {
+		s.priority = PriorityNormal
+		if p.AuthRequest && req.Method != http.MethodGet {
+			s.priority = PriorityHigh
+		}
+	}
+	if d, ok := req.Context().Deadline(); ok {
+		s.sendBy = d.Add(-r.scheduler.expectedLatency(endpointKey(req)))
+	}
+	return s
+}
+
+// acquire waits until it is the request's turn on a rate limit, failing when
+// the request's context ends before then
+func (s *scheduler) acquire(ctx context.Context, e EndpointLimit, req *scheduledRequest) error {
+	s.m.Lock()
+	if s.queues == nil {
+		s.queues = make(map[EndpointLimit]*requestQueue)
+	}
+	q, ok := s.queues[e]
+	if !ok {
+		q = new(requestQueue)
+		s.queues[e] = q
+	}
+	if !q.busy {
+		q.busy = true
+		s.m.Unlock()
+		return nil
+	}
+	req.sequence = s.sequence
+	s.sequence++
+	req.ready = make(chan struct{})
+	heap.Push(&q.waiting, req)
+	s.m.Unlock()
+
+	select {
+	case <-req.ready:
+		return nil
+	case <-ctx.Done():
+		s.m.Lock()
+		if req.index >= 0 {
+			heap.Remove(&q.waiting, req.index)
+			s.m.Unlock()
+			return ctx.Err()
+		}
+		s.m.Unlock()
+		// the turn was handed over as the context ended, so pass it on
+		s.release(e)
+		return ctx.Err()
+	}
+}
+
+// release hands the rate limit over to the next waiting request
+func (s *scheduler) release(e EndpointLimit) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	q := s.queues[e]
+	if q.waiting.Len() == 0 {
+		q.busy = false
+		return
+	}
+	close(heap.Pop(&q.waiting).(*scheduledRequest).ready)
+}
+
+// observe records the latency of a response from an endpoint
+func (s *scheduler) observe(endpoint string, latency time.Duration) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.latency == nil {
+		s.latency = make(map[string]time.Duration)
+	}
+	avg, ok := s.latency[endpoint]
+	if !ok {
+		s.latency[endpoint] = latency
+		return
+	}
+	s.latency[endpoint] = avg + time.Duration(latencySmoothing*float64(latency-avg))
+}
+
+// expectedLatency returns the moving average latency of an endpoint
+func (s *scheduler) expectedLatency(endpoint string) time.Duration {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.latency[endpoint]
+}
+
+// GetEndpointLatencies returns the moving average latency of each endpoint
+// the requester has received a response from, keyed by method and URL
+// without its query
+func (r *Requester) GetEndpointLatencies() map[string]time.Duration {
+	r.scheduler.m.Lock()
+	defer r.scheduler.m.Unlock()
+	latencies := make(map[string]time.Duration, len(r.scheduler.latency))
+	for k, v := range r.scheduler.latency {
+		latencies[k] = v
+	}
+	return latencies
+}
+
+// endpointKey returns the method and URL without its query of a request
+func endpointKey(req *http.Request) string {
+	return req.Method + " " + req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+}
@@ -0,0 +1,165 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// queued returns the number of requests waiting on a rate limit
+func (s *scheduler) queued(e EndpointLimit) int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if q, ok := s.queues[e]; ok {
+		return q.waiting.Len()
+	}
+	return 0
+}
+
+// busy returns whether a request holds a rate limit
+func (s *scheduler) busy(e EndpointLimit) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	q, ok := s.queues[e]
+	return ok && q.busy
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	t.Parallel()
+
+	var s scheduler
+	if err := s.acquire(context.Background(), Auth, &scheduledRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	requests := []struct {
+		name string
+		req  *scheduledRequest
+	}{
+		{"poll", &scheduledRequest{priority: PriorityNormal}},
+		{"background", &scheduledRequest{priority: PriorityLow}},
+		{"deadline", &scheduledRequest{priority: PriorityNormal, sendBy: now.Add(time.Second)}},
+		{"cancel", &scheduledRequest{priority: PriorityHigh}},
+		{"urgent deadline", &scheduledRequest{priority: PriorityNormal, sendBy: now.Add(time.Millisecond)}},
+		{"submit", &scheduledRequest{priority: PriorityHigh}},
+	}
+	order := make(chan string, len(requests))
+	for i := range requests {
+		go func(name string, req *scheduledRequest) {
+			if err := s.acquire(context.Background(), Auth, req); err != nil {
+				t.Error(err)
+			}
+			order <- name
+			s.release(Auth)
+		}(requests[i].name, requests[i].req)
+		// queue the requests in order so that ties are sent in that order
+		for s.queued(Auth) != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	s.release(Auth)
+	for _, expected := range []string{"cancel", "submit", "urgent deadline", "deadline", "poll", "background"} {
+		if name := <-order; name != expected {
+			t.Errorf("expected %s to be sent next, got %s", expected, name)
+		}
+	}
+	for deadline := time.Now().Add(time.Second); s.busy(Auth); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the rate limit to be free once every request was sent")
+		}
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	t.Parallel()
+
+	var s scheduler
+	if err := s.acquire(context.Background(), UnAuth, &scheduledRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := s.acquire(ctx, UnAuth, &scheduledRequest{}); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if s.queued(UnAuth) != 0 {
+		t.Error("expected the cancelled request to be removed from the queue")
+	}
+
+	// other rate limits are not held up by a busy one
+	if err := s.acquire(ctx, Auth, &scheduledRequest{}); err != nil {
+		t.Error(err)
+	}
+	s.release(UnAuth)
+	if err := s.acquire(context.Background(), UnAuth, &scheduledRequest{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewScheduledRequest(t *testing.T) {
+	t.Parallel()
+
+	r := New("test", new(http.Client))
+	get := httptest.NewRequest(http.MethodGet, "https://api.test/v1/ticker", nil)
+	post := httptest.NewRequest(http.MethodPost, "https://api.test/v1/order", nil)
+	for _, tc := range []struct {
+		req      *http.Request
+		item     Item
+		expected Priority
+	}{
+		{get, Item{}, PriorityNormal},
+		{get, Item{AuthRequest: true}, PriorityNormal},
+		{post, Item{AuthRequest: true}, PriorityHigh},
+		{post, Item{}, PriorityNormal},
+		{post, Item{AuthRequest: true, Priority: PriorityLow}, PriorityLow},
+		{get, Item{NonceEnabled: true, Priority: PriorityLow}, PriorityHigh},
+	} {
+		item := tc.item
+		if s := r.newScheduledRequest(tc.req, &item); s.priority != tc.expected {
+			t.Errorf("%s %+v: expected priority %d, got %d", tc.req.Method, tc.item, tc.expected, s.priority)
+		}
+	}
+
+	r.scheduler.observe(endpointKey(get), time.Millisecond*100)
+	r.scheduler.observe(endpointKey(get), time.Millisecond*200)
+	if l := r.GetEndpointLatencies()["GET https://api.test/v1/ticker"]; l != time.Millisecond*120 {
+		t.Errorf("expected a moving average latency of 120ms, got %s", l)
+	}
+	deadline := time.Now().Add(time.Second)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	s := r.newScheduledRequest(get.WithContext(ctx), &Item{})
+	if !s.sendBy.Equal(deadline.Add(-time.Millisecond * 120)) {
+		t.Errorf("expected the request to be sent by its deadline less its latency, got %s", s.sendBy)
+	}
+}
+
+func TestScheduleRateLimit(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	r := New("test", new(http.Client), WithLimiter(NewBasicRateLimit(time.Second, 100)))
+	for i := 0; i < 3; i++ {
+		err := r.SendPayload(context.Background(), &Item{
+			Method: http.MethodGet,
+			Path:   s.URL + "/ticker?pair=btcusd",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := r.GetEndpointLatencies()["GET "+s.URL+"/ticker"]; !ok {
+		t.Errorf("expected the endpoint's latency to be measured, got %v", r.GetEndpointLatencies())
+	}
+	if r.scheduler.busy(Unset) {
+		t.Error("expected the rate limit to be released")
+	}
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+)
+
+// Stats holds the outcome of every HTTP request attempt made by a requester
+// since it was created. Retried requests are counted once per attempt
+type Stats struct {
+	Requests int64
+	// Failures are attempts which received no response or a server error
+	// status, which reflect the exchange's health rather than the request
+	Failures     int64
+	TotalLatency time.Duration
+	LastLatency  time.Duration
+	LastFailure  time.Time
+}
+
+// AverageLatency returns the mean latency of the requests
+func (s *Stats) AverageLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Requests)
+}
+
+// GetStats returns a copy of the requester's request statistics
+func (r *Requester) GetStats() Stats {
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
+	return r.stats
+}
+
+// recordAttempt records the latency and outcome of a request attempt
+func (r *Requester) recordAttempt(latency time.Duration, resp *http.Response, err error) {
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
+	r.stats.Requests++
+	r.stats.TotalLatency += latency
+	r.stats.LastLatency = latency
+	if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+		r.stats.Failures++
+		r.stats.LastFailure = clock.Now()
+	}
+}
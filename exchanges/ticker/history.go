@@ -0,0 +1,186 @@
+package ticker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// DefaultHistoryLength is the default amount of ticks retained for each
+// exchange, pair and asset
+const DefaultHistoryLength = 3600
+
+var (
+	historyLength int64 = DefaultHistoryLength
+
+	errInsufficientHistory = errors.New("ticker history does not cover the requested period")
+	errNoVolume            = errors.New("ticker history contains no traded volume for the requested period")
+)
+
+// Tick is a single historic ticker update
+type Tick struct {
+	Last   float64
+	Volume float64
+	Time   time.Time
+}
+
+// history is a fixed length ring buffer of ticks which also tracks the high
+// and low since the ticker was first processed
+type history struct {
+	ticks []Tick
+	next  int
+	full  bool
+	high  float64
+	low   float64
+	since time.Time
+}
+
+// SetHistoryLength sets the amount of ticks retained for each ticker created
+// after the call. Lengths below one are ignored
+func SetHistoryLength(length int) {
+	if length < 1 {
+		return
+	}
+	atomic.StoreInt64(&historyLength, int64(length))
+}
+
+// add records a price update, ignoring updates without a last price
+func (h *history) add(p *Price) {
+	if p.Last <= 0 {
+		return
+	}
+	if h.ticks == nil {
+		h.ticks = make([]Tick, atomic.LoadInt64(&historyLength))
+		h.since = p.LastUpdated
+		h.high = p.Last
+		h.low = p.Last
+	}
+	if p.Last > h.high {
+		h.high = p.Last
+	}
+	if p.Last < h.low {
+		h.low = p.Last
+	}
+	h.ticks[h.next] = Tick{Last: p.Last, Volume: p.Volume, Time: p.LastUpdated}
+	h.next++
+	if h.next == len(h.ticks) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// ordered returns a copy of the retained ticks, oldest first
+func (h *history) ordered() []Tick {
+	if !h.full {
+		return append([]Tick(nil), h.ticks[:h.next]...)
+	}
+	resp := make([]Tick, 0, len(h.ticks))
+	resp = append(resp, h.ticks[h.next:]...)
+	return append(resp, h.ticks[:h.next]...)
+}
+
+// getHistory returns the history of a ticker. Must be called with the service
+// lock held
+func (s *Service) getHistory(exchange string, p currency.Pair, a asset.Item) (*history, error) {
+	base, quote := pairKey(exchange, p)
+	t, ok := s.Tickers[strings.ToLower(exchange)][base][quote][a]
+	if !ok {
+		return nil, fmt.Errorf("ticker item not found for %s %s %s",
+			exchange,
+			p,
+			a)
+	}
+	if len(t.history.ticks) == 0 {
+		return nil, errInsufficientHistory
+	}
+	return &t.history, nil
+}
+
+// GetHistory returns the retained ticks of a ticker, oldest first
+func GetHistory(exchange string, p currency.Pair, a asset.Item) ([]Tick, error) {
+	service.RLock()
+	defer service.RUnlock()
+	h, err := service.getHistory(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	return h.ordered(), nil
+}
+
+// PercentChange returns the percentage change in the last price over the
+// period ending at the most recent tick, e.g. 1m, 5m or 1h
+func PercentChange(exchange string, p currency.Pair, a asset.Item, period time.Duration) (float64, error) {
+	ticks, err := GetHistory(exchange, p, a)
+	if err != nil {
+		return 0, err
+	}
+	return percentChange(ticks, period)
+}
+
+// VWAP returns the volume weighted average price over the period ending at the
+// most recent tick. Ticker volume is a rolling total so the volume traded at
+// each tick is approximated by the increase in volume since the prior tick
+func VWAP(exchange string, p currency.Pair, a asset.Item, period time.Duration) (float64, error) {
+	ticks, err := GetHistory(exchange, p, a)
+	if err != nil {
+		return 0, err
+	}
+	return vwap(ticks, period)
+}
+
+// HighLowSinceStart returns the highest and lowest last price since the ticker
+// was first processed and the time it was first processed
+func HighLowSinceStart(exchange string, p currency.Pair, a asset.Item) (high, low float64, since time.Time, err error) {
+	service.RLock()
+	defer service.RUnlock()
+	h, err := service.getHistory(exchange, p, a)
+	if err != nil {
+		return 0, 0, time.Time{}, err
+	}
+	return h.high, h.low, h.since, nil
+}
+
+// percentChange compares the most recent tick to the latest tick at or before
+// the start of the period
+func percentChange(ticks []Tick, period time.Duration) (float64, error) {
+	if len(ticks) == 0 {
+		return 0, errInsufficientHistory
+	}
+	latest := ticks[len(ticks)-1]
+	start := latest.Time.Add(-period)
+	for i := len(ticks) - 1; i >= 0; i-- {
+		if ticks[i].Time.After(start) {
+			continue
+		}
+		return (latest.Last - ticks[i].Last) / ticks[i].Last * 100, nil
+	}
+	return 0, errInsufficientHistory
+}
+
+func vwap(ticks []Tick, period time.Duration) (float64, error) {
+	if len(ticks) == 0 {
+		return 0, errInsufficientHistory
+	}
+	start := ticks[len(ticks)-1].Time.Add(-period)
+	var value, volume float64
+	for i := len(ticks) - 1; i > 0; i-- {
+		if ticks[i].Time.Before(start) {
+			break
+		}
+		traded := ticks[i].Volume - ticks[i-1].Volume
+		if traded <= 0 {
+			continue
+		}
+		value += ticks[i].Last * traded
+		volume += traded
+	}
+	if volume == 0 {
+		return 0, errNoVolume
+	}
+	return value / volume, nil
+}
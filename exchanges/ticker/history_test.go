@@ -0,0 +1,123 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestHistoryRingBuffer(t *testing.T) {
+	SetHistoryLength(3)
+	defer SetHistoryLength(DefaultHistoryLength)
+
+	var h history
+	start := time.Now()
+	for i := 1; i <= 5; i++ {
+		h.add(&Price{Last: float64(i), LastUpdated: start.Add(time.Duration(i) * time.Second)})
+	}
+	h.add(&Price{})
+
+	ticks := h.ordered()
+	if len(ticks) != 3 || ticks[0].Last != 3 || ticks[2].Last != 5 {
+		t.Errorf("unexpected ticks %+v", ticks)
+	}
+	if h.high != 5 || h.low != 1 || !h.since.Equal(start.Add(time.Second)) {
+		t.Errorf("unexpected high %v low %v since %v", h.high, h.low, h.since)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	start := time.Now()
+	ticks := []Tick{
+		{Last: 100, Time: start},
+		{Last: 110, Time: start.Add(time.Minute)},
+		{Last: 121, Time: start.Add(time.Minute * 2)},
+	}
+	change, err := percentChange(ticks, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change != 10 {
+		t.Errorf("expected 10, got %v", change)
+	}
+	change, err = percentChange(ticks, time.Second*90)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change != 21 {
+		t.Errorf("expected 21, got %v", change)
+	}
+	if _, err = percentChange(ticks, time.Hour); err != errInsufficientHistory {
+		t.Errorf("expected %v, got %v", errInsufficientHistory, err)
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	start := time.Now()
+	ticks := []Tick{
+		{Last: 100, Volume: 10, Time: start},
+		{Last: 110, Volume: 11, Time: start.Add(time.Minute)},
+		{Last: 120, Volume: 14, Time: start.Add(time.Minute * 2)},
+		{Last: 130, Volume: 12, Time: start.Add(time.Minute * 3)},
+	}
+	v, err := vwap(ticks, time.Minute*5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 117.5 {
+		t.Errorf("expected 117.5, got %v", v)
+	}
+	if _, err = vwap(ticks, time.Second); err != errNoVolume {
+		t.Errorf("expected %v, got %v", errNoVolume, err)
+	}
+}
+
+func TestTickerHistory(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.AUD)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		err := ProcessTicker("historytest", &Price{
+			Pair:        p,
+			Last:        float64(100 + i*10),
+			Volume:      float64(i),
+			LastUpdated: start.Add(time.Duration(i) * time.Minute),
+		}, asset.Spot)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ticks, err := GetHistory("historytest", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticks) != 3 {
+		t.Fatalf("expected 3 ticks, got %d", len(ticks))
+	}
+
+	change, err := PercentChange("historytest", p, asset.Spot, time.Minute*2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change != 20 {
+		t.Errorf("expected 20, got %v", change)
+	}
+
+	if _, err = VWAP("historytest", p, asset.Spot, time.Hour); err != nil {
+		t.Error(err)
+	}
+
+	high, low, since, err := HighLowSinceStart("historytest", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if high != 120 || low != 100 || !since.Equal(start) {
+		t.Errorf("unexpected high %v low %v since %v", high, low, since)
+	}
+
+	if _, err = GetHistory("historytest", currency.NewPair(currency.LTC, currency.AUD), asset.Spot); err == nil {
+		t.Error("expected an error for an unknown ticker")
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -26,7 +26,8 @@ func SubscribeTicker(exchange string, p currency.Pair, a asset.Item) (dispatch.P
 	service.RLock()
 	defer service.RUnlock()
 
-	tick, ok := service.Tickers[exchange][p.Base.Item][p.Quote.Item][a]
+	base, quote := pairKey(exchange, p)
+	tick, ok := service.Tickers[exchange][base][quote][a]
 	if !ok {
 		return dispatch.Pipe{}, fmt.Errorf("ticker item not found for %s %s %s",
 			exchange,
@@ -51,7 +52,16 @@ func SubscribeToExchangeTickers(exchange string) (dispatch.Pipe, error) {
 	return service.mux.Subscribe(id)
 }
 
-// GetTicker checks and returns a requested ticker if it exists
+// pairKey returns the currencies a pair's ticker is stored under. Tickers are
+// stored under the canonical codes of the pair's currencies so they can be
+// retrieved by either the exchange's codes or the canonical codes
+func pairKey(exchange string, p currency.Pair) (base, quote *currency.Item) {
+	c := currency.CanonicalPair(exchange, p)
+	return c.Base.Item, c.Quote.Item
+}
+
+// GetTicker checks and returns a requested ticker if it exists. The pair may
+// use the exchange's currency codes or the canonical codes
 func GetTicker(exchange string, p currency.Pair, tickerType asset.Item) (*Price, error) {
 	exchange = strings.ToLower(exchange)
 	service.RLock()
@@ -60,22 +70,23 @@ func GetTicker(exchange string, p currency.Pair, tickerType asset.Item) (*Price,
 		return nil, fmt.Errorf("no tickers for %s exchange", exchange)
 	}
 
-	if service.Tickers[exchange][p.Base.Item] == nil {
+	base, quote := pairKey(exchange, p)
+	if service.Tickers[exchange][base] == nil {
 		return nil, fmt.Errorf("no tickers associated with base currency %s",
 			p.Base)
 	}
 
-	if service.Tickers[exchange][p.Base.Item][p.Quote.Item] == nil {
+	if service.Tickers[exchange][base][quote] == nil {
 		return nil, fmt.Errorf("no tickers associated with quote currency %s",
 			p.Quote)
 	}
 
-	if service.Tickers[exchange][p.Base.Item][p.Quote.Item][tickerType] == nil {
+	if service.Tickers[exchange][base][quote][tickerType] == nil {
 		return nil, fmt.Errorf("no tickers associated with asset type %s",
 			tickerType)
 	}
 
-	return &service.Tickers[exchange][p.Base.Item][p.Quote.Item][tickerType].Price, nil
+	return &service.Tickers[exchange][base][quote][tickerType].Price, nil
 }
 
 // ProcessTicker processes incoming tickers, creating or updating the Tickers
@@ -100,7 +111,7 @@ func ProcessTicker(exchangeName string, tickerNew *Price, assetType asset.Item)
 	tickerNew.AssetType = assetType
 
 	if tickerNew.LastUpdated.IsZero() {
-		tickerNew.LastUpdated = time.Now()
+		tickerNew.LastUpdated = clock.Now()
 	}
 
 	return service.Update(tickerNew)
@@ -109,37 +120,38 @@ func ProcessTicker(exchangeName string, tickerNew *Price, assetType asset.Item)
 // Update updates ticker price
 func (s *Service) Update(p *Price) error {
 	var ids []uuid.UUID
+	base, quote := pairKey(p.ExchangeName, p.Pair)
 
 	s.Lock()
 	switch {
 	case s.Tickers[p.ExchangeName] == nil:
 		s.Tickers[p.ExchangeName] = make(map[*currency.Item]map[*currency.Item]map[asset.Item]*Ticker)
-		s.Tickers[p.ExchangeName][p.Pair.Base.Item] = make(map[*currency.Item]map[asset.Item]*Ticker)
-		s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item] = make(map[asset.Item]*Ticker)
+		s.Tickers[p.ExchangeName][base] = make(map[*currency.Item]map[asset.Item]*Ticker)
+		s.Tickers[p.ExchangeName][base][quote] = make(map[asset.Item]*Ticker)
 		err := s.SetItemID(p)
 		if err != nil {
 			s.Unlock()
 			return err
 		}
 
-	case s.Tickers[p.ExchangeName][p.Pair.Base.Item] == nil:
-		s.Tickers[p.ExchangeName][p.Pair.Base.Item] = make(map[*currency.Item]map[asset.Item]*Ticker)
-		s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item] = make(map[asset.Item]*Ticker)
+	case s.Tickers[p.ExchangeName][base] == nil:
+		s.Tickers[p.ExchangeName][base] = make(map[*currency.Item]map[asset.Item]*Ticker)
+		s.Tickers[p.ExchangeName][base][quote] = make(map[asset.Item]*Ticker)
 		err := s.SetItemID(p)
 		if err != nil {
 			s.Unlock()
 			return err
 		}
 
-	case s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item] == nil:
-		s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item] = make(map[asset.Item]*Ticker)
+	case s.Tickers[p.ExchangeName][base][quote] == nil:
+		s.Tickers[p.ExchangeName][base][quote] = make(map[asset.Item]*Ticker)
 		err := s.SetItemID(p)
 		if err != nil {
 			s.Unlock()
 			return err
 		}
 
-	case s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item][p.AssetType] == nil:
+	case s.Tickers[p.ExchangeName][base][quote][p.AssetType] == nil:
 		err := s.SetItemID(p)
 		if err != nil {
 			s.Unlock()
@@ -147,7 +159,7 @@ func (s *Service) Update(p *Price) error {
 		}
 
 	default:
-		ticker := s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item][p.AssetType]
+		ticker := s.Tickers[p.ExchangeName][base][quote][p.AssetType]
 		ticker.Last = p.Last
 		ticker.High = p.High
 		ticker.Low = p.Low
@@ -159,6 +171,7 @@ func (s *Service) Update(p *Price) error {
 		ticker.Open = p.Open
 		ticker.Close = p.Close
 		ticker.LastUpdated = p.LastUpdated
+		ticker.history.add(p)
 		ids = ticker.Assoc
 		ids = append(ids, ticker.Main)
 	}
@@ -181,9 +194,12 @@ func (s *Service) SetItemID(p *Price) error {
 		return err
 	}
 
-	s.Tickers[p.ExchangeName][p.Pair.Base.Item][p.Pair.Quote.Item][p.AssetType] = &Ticker{Price: *p,
+	t := &Ticker{Price: *p,
 		Main:  singleID,
 		Assoc: ids}
+	t.history.add(p)
+	base, quote := pairKey(p.ExchangeName, p.Pair)
+	s.Tickers[p.ExchangeName][base][quote][p.AssetType] = t
 	return nil
 }
 
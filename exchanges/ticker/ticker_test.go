@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -357,3 +358,39 @@ func TestGetAssociation(t *testing.T) {
 
 	service.mux = cpyMux
 }
+
+func TestProcessTickerClock(t *testing.T) {
+	replayed := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock.Set(clock.NewManual(replayed))
+	defer clock.Reset()
+
+	p := currency.NewPairFromStrings("CLOCK", "USD")
+	err := ProcessTicker("clockexchange", &Price{Pair: p, Last: 1}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tick, err := GetTicker("clockexchange", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tick.LastUpdated.Equal(replayed) {
+		t.Errorf("expected the ticker to be updated at %s, got %s", replayed, tick.LastUpdated)
+	}
+}
+
+func TestGetTickerCanonicalCodes(t *testing.T) {
+	native := currency.NewPair(currency.XBT, currency.USD)
+	err := ProcessTicker("TestGetTickerCanonicalCodes", &Price{Pair: native, Last: 1}, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []currency.Pair{native, currency.NewPair(currency.BTC, currency.USD)} {
+		tick, err := GetTicker("TestGetTickerCanonicalCodes", p, asset.Spot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tick.Pair.Equal(native) {
+			t.Errorf("expected the ticker to keep the exchange's pair, got %v", tick.Pair)
+		}
+	}
+}
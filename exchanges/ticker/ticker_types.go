@@ -52,6 +52,7 @@ type Price struct {
 // Ticker struct holds the ticker information for a currency pair and type
 type Ticker struct {
 	Price
-	Main  uuid.UUID
-	Assoc []uuid.UUID
+	Main    uuid.UUID
+	Assoc   []uuid.UUID
+	history history
 }
@@ -0,0 +1,150 @@
+package trade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// SetCandleIntervals sets the intervals rolling candles are built at as trades
+// are added to each feed and the amount of candles retained for each
+// interval. Candles of intervals no longer built are dropped, lengths below
+// one are ignored
+func SetCandleIntervals(intervals []time.Duration, length int) {
+	service.Lock()
+	defer service.Unlock()
+	service.candleIntervals = nil
+	for x := range intervals {
+		if intervals[x] > 0 {
+			service.candleIntervals = append(service.candleIntervals, intervals[x])
+		}
+	}
+	if length > 0 {
+		service.candleLength = length
+	}
+	for _, bases := range service.Trades {
+		for _, quotes := range bases {
+			for _, assets := range quotes {
+				for _, feed := range assets {
+					for interval := range feed.candles {
+						if !service.buildsInterval(interval) {
+							delete(feed.candles, interval)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// GetCandles returns a copy of the rolling candles built from the trades of a
+// currency pair at the interval, oldest first. The most recent candle is
+// updated as trades arrive until its interval has passed
+func GetCandles(exchange string, p currency.Pair, a asset.Item, interval time.Duration) (kline.Item, error) {
+	exchange = strings.ToLower(exchange)
+	service.RLock()
+	defer service.RUnlock()
+	if !service.buildsInterval(interval) {
+		return kline.Item{}, fmt.Errorf("%s %v", errIntervalNotBuilt, interval)
+	}
+	feed, ok := service.Trades[exchange][p.Base.Item][p.Quote.Item][a]
+	if !ok {
+		return kline.Item{}, fmt.Errorf("trade feed not found for %s %s %s",
+			exchange,
+			p,
+			a)
+	}
+	return kline.Item{
+		Exchange: exchange,
+		Pair:     p,
+		Asset:    a,
+		Interval: interval,
+		Candles:  append([]kline.Candle(nil), feed.candles[interval]...),
+	}, nil
+}
+
+// buildsInterval returns whether candles are built at the interval. Must be
+// called with the service lock held
+func (s *Service) buildsInterval(interval time.Duration) bool {
+	for x := range s.candleIntervals {
+		if s.candleIntervals[x] == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCandles applies the trades newly added to a feed to its candles at
+// each interval. Must be called with the service lock held
+func (s *Service) buildCandles(f *Feed, trades []Data) {
+	if len(s.candleIntervals) == 0 || len(trades) == 0 {
+		return
+	}
+	if f.candles == nil {
+		f.candles = make(map[time.Duration][]kline.Candle)
+	}
+	sorted := append([]Data(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	for _, interval := range s.candleIntervals {
+		candles := f.candles[interval]
+		for x := range sorted {
+			candles = addToCandles(candles, &sorted[x], interval, !sorted[x].Timestamp.Before(f.candleLatest))
+		}
+		if len(candles) > s.candleLength {
+			candles = append([]kline.Candle(nil), candles[len(candles)-s.candleLength:]...)
+		}
+		f.candles[interval] = candles
+	}
+	if latest := sorted[len(sorted)-1].Timestamp; latest.After(f.candleLatest) {
+		f.candleLatest = latest
+	}
+}
+
+// addToCandles applies a trade to the candle of its interval, starting a new
+// candle when none exists. Trades arriving after a more recent trade extend
+// the high, low and volume of their candle without changing its close
+func addToCandles(candles []kline.Candle, d *Data, interval time.Duration, latest bool) []kline.Candle {
+	start := d.Timestamp.UTC().Truncate(interval)
+	i := sort.Search(len(candles), func(i int) bool {
+		return !candles[i].Time.Before(start)
+	})
+	if i == len(candles) || !candles[i].Time.Equal(start) {
+		if i == 0 && len(candles) > 0 && !latest {
+			// older than every retained candle
+			return candles
+		}
+		candles = append(candles, kline.Candle{})
+		copy(candles[i+1:], candles[i:])
+		candles[i] = kline.Candle{
+			Time:   start,
+			Open:   d.Price,
+			High:   d.Price,
+			Low:    d.Price,
+			Close:  d.Price,
+			Volume: d.Amount,
+			Source: kline.TradeAggregated,
+		}
+		return candles
+	}
+
+	c := &candles[i]
+	if d.Price > c.High {
+		c.High = d.Price
+	}
+	if d.Price < c.Low {
+		c.Low = d.Price
+	}
+	c.Volume += d.Amount
+	if latest {
+		c.Close = d.Price
+	}
+	return candles
+}
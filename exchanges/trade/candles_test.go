@@ -0,0 +1,84 @@
+package trade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestGetCandles(t *testing.T) {
+	exch := testExchange(t)
+	SetCandleIntervals([]time.Duration{kline.OneMin, kline.FiveMin, 0}, 2)
+	defer SetCandleIntervals(nil, DefaultCandleLength)
+
+	p := currency.NewPair(currency.DOGE, currency.USD)
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := ProcessTrades(exch, []Data{
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 3, Amount: 1, Timestamp: start.Add(time.Second * 30)},
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 2, Amount: 1, Timestamp: start},
+		{TID: "3", Pair: p, AssetType: asset.Spot, Price: 4, Amount: 2, Timestamp: start.Add(time.Minute)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a late trade extends its candle without changing the close
+	err = ProcessTrades(exch, []Data{
+		{TID: "4", Pair: p, AssetType: asset.Spot, Price: 1, Amount: 1, Timestamp: start.Add(time.Second * 10)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := GetCandles(exch, p, asset.Spot, kline.OneMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k.Candles) != 2 {
+		t.Fatalf("expected 2 one minute candles, got %+v", k.Candles)
+	}
+	c := k.Candles[0]
+	if !c.Time.Equal(start) || c.Open != 2 || c.High != 3 || c.Low != 1 || c.Close != 3 || c.Volume != 3 || c.Source != kline.TradeAggregated {
+		t.Errorf("unexpected first candle %+v", c)
+	}
+	if k.Candles[1].Open != 4 || k.Candles[1].Volume != 2 {
+		t.Errorf("unexpected second candle %+v", k.Candles[1])
+	}
+
+	k, err = GetCandles(exch, p, asset.Spot, kline.FiveMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k.Candles) != 1 || k.Candles[0].Close != 4 || k.Candles[0].Volume != 5 {
+		t.Errorf("unexpected five minute candles %+v", k.Candles)
+	}
+
+	// the oldest candles are dropped beyond the candle length
+	err = ProcessTrades(exch, []Data{
+		{TID: "5", Pair: p, AssetType: asset.Spot, Price: 5, Amount: 1, Timestamp: start.Add(time.Minute * 2)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err = GetCandles(exch, p, asset.Spot, kline.OneMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k.Candles) != 2 || !k.Candles[0].Time.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected the two most recent candles, got %+v", k.Candles)
+	}
+
+	if _, err = GetCandles(exch, p, asset.Spot, kline.OneHour); err == nil {
+		t.Error("expected an error for an interval which is not built")
+	}
+	if _, err = GetCandles(exch, p, asset.Futures, kline.OneMin); err == nil {
+		t.Error("expected an error for an unknown feed")
+	}
+
+	SetCandleIntervals([]time.Duration{kline.FiveMin}, 0)
+	if _, err = GetCandles(exch, p, asset.Spot, kline.OneMin); err == nil {
+		t.Error("expected candles of a removed interval to be dropped")
+	}
+}
@@ -0,0 +1,315 @@
+package trade
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func init() {
+	service = new(Service)
+	service.Trades = make(map[string]map[*currency.Item]map[*currency.Item]map[asset.Item]*Feed)
+	service.Exchange = make(map[string]uuid.UUID)
+	service.mux = dispatch.GetNewMux()
+	service.candleLength = DefaultCandleLength
+}
+
+// SetBufferLength sets the amount of trades retained for each exchange, pair
+// and asset. Lengths below one are ignored
+func SetBufferLength(length int) {
+	if length < 1 {
+		return
+	}
+	atomic.StoreInt64(&bufferLength, int64(length))
+}
+
+// SubscribeTrades subscribes to the trades of a currency pair and returns a
+// communication channel streaming each batch of new trades
+func SubscribeTrades(exchange string, p currency.Pair, a asset.Item) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	service.RLock()
+	defer service.RUnlock()
+
+	feed, ok := service.Trades[exchange][p.Base.Item][p.Quote.Item][a]
+	if !ok {
+		return dispatch.Pipe{}, fmt.Errorf("trade feed not found for %s %s %s",
+			exchange,
+			p,
+			a)
+	}
+
+	return service.mux.Subscribe(feed.Main)
+}
+
+// SubscribeToExchangeTrades subscribes to the trades of all pairs on an
+// exchange
+func SubscribeToExchangeTrades(exchange string) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	service.RLock()
+	defer service.RUnlock()
+	id, ok := service.Exchange[exchange]
+	if !ok {
+		return dispatch.Pipe{}, fmt.Errorf("%s exchange trades not found",
+			exchange)
+	}
+
+	return service.mux.Subscribe(id)
+}
+
+// GetRecentTrades returns a copy of the retained trades of a currency pair,
+// oldest first
+func GetRecentTrades(exchange string, p currency.Pair, a asset.Item) ([]Data, error) {
+	exchange = strings.ToLower(exchange)
+	service.RLock()
+	defer service.RUnlock()
+	feed, ok := service.Trades[exchange][p.Base.Item][p.Quote.Item][a]
+	if !ok {
+		return nil, fmt.Errorf("trade feed not found for %s %s %s",
+			exchange,
+			p,
+			a)
+	}
+	return append([]Data(nil), feed.Trades...), nil
+}
+
+// ProcessTrades adds incoming trades to their exchange, pair and asset feeds.
+// Trades already retained are ignored so overlapping REST fetches and
+// websocket updates can be processed as they arrive
+func ProcessTrades(exchangeName string, trades []Data) error {
+	_, err := AddTrades(exchangeName, trades)
+	return err
+}
+
+// AddTrades processes incoming trades as ProcessTrades does and returns the
+// trades which were not already retained
+func AddTrades(exchangeName string, trades []Data) ([]Data, error) {
+	if exchangeName == "" {
+		return nil, errors.New(errExchangeNameUnset)
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("%s %s", exchangeName, errNoTrades)
+	}
+
+	exchangeName = strings.ToLower(exchangeName)
+	for x := range trades {
+		if trades[x].Pair.IsEmpty() {
+			return nil, fmt.Errorf("%s %s", exchangeName, errPairNotSet)
+		}
+		if trades[x].AssetType == "" {
+			return nil, fmt.Errorf("%s %s %s", exchangeName,
+				trades[x].Pair,
+				errAssetTypeNotSet)
+		}
+	}
+
+	processed := make([]Data, len(trades))
+	copy(processed, trades)
+	for x := range processed {
+		processed[x].Exchange = exchangeName
+		if processed[x].Timestamp.IsZero() {
+			processed[x].Timestamp = clock.Now()
+		}
+	}
+	return service.update(processed)
+}
+
+// Update adds trades to their feeds and publishes the trades which were not
+// already retained
+func (s *Service) Update(trades []Data) error {
+	_, err := s.update(trades)
+	return err
+}
+
+// update adds trades to their feeds, publishes and returns the trades which
+// were not already retained
+func (s *Service) update(trades []Data) ([]Data, error) {
+	s.Lock()
+	added := make(map[*Feed][]Data)
+	var feeds []*Feed
+	for x := range trades {
+		feed, err := s.getFeed(&trades[x])
+		if err != nil {
+			s.Unlock()
+			return nil, err
+		}
+		key := trades[x].key()
+		if _, ok := feed.seen[key]; ok {
+			continue
+		}
+		feed.seen[key] = struct{}{}
+		feed.Trades = append(feed.Trades, trades[x])
+		if _, ok := added[feed]; !ok {
+			feeds = append(feeds, feed)
+		}
+		added[feed] = append(added[feed], trades[x])
+	}
+
+	limit := int(atomic.LoadInt64(&bufferLength))
+	for x := range feeds {
+		f := feeds[x]
+		s.buildCandles(f, added[f])
+		sort.SliceStable(f.Trades, func(i, j int) bool {
+			return f.Trades[i].Timestamp.Before(f.Trades[j].Timestamp)
+		})
+		if len(f.Trades) > limit {
+			for y := range f.Trades[:len(f.Trades)-limit] {
+				delete(f.seen, f.Trades[y].key())
+			}
+			f.Trades = append([]Data(nil), f.Trades[len(f.Trades)-limit:]...)
+		}
+	}
+	s.Unlock()
+
+	var resp []Data
+	for x := range feeds {
+		ids := append([]uuid.UUID{feeds[x].Main}, feeds[x].Assoc...)
+		batch := added[feeds[x]]
+		resp = append(resp, batch...)
+		if err := s.mux.Publish(ids, &batch); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// getFeed returns the feed of a trade, creating it and its dispatch IDs when
+// it does not exist. Must be called with the service lock held
+func (s *Service) getFeed(d *Data) (*Feed, error) {
+	if feed, ok := s.Trades[d.Exchange][d.Pair.Base.Item][d.Pair.Quote.Item][d.AssetType]; ok {
+		return feed, nil
+	}
+
+	exchangeID, ok := s.Exchange[d.Exchange]
+	if !ok {
+		var err error
+		exchangeID, err = s.mux.GetID()
+		if err != nil {
+			return nil, err
+		}
+		s.Exchange[d.Exchange] = exchangeID
+	}
+	singleID, err := s.mux.GetID()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Trades[d.Exchange] == nil {
+		s.Trades[d.Exchange] = make(map[*currency.Item]map[*currency.Item]map[asset.Item]*Feed)
+	}
+	if s.Trades[d.Exchange][d.Pair.Base.Item] == nil {
+		s.Trades[d.Exchange][d.Pair.Base.Item] = make(map[*currency.Item]map[asset.Item]*Feed)
+	}
+	if s.Trades[d.Exchange][d.Pair.Base.Item][d.Pair.Quote.Item] == nil {
+		s.Trades[d.Exchange][d.Pair.Base.Item][d.Pair.Quote.Item] = make(map[asset.Item]*Feed)
+	}
+	feed := &Feed{
+		Main:  singleID,
+		Assoc: []uuid.UUID{exchangeID},
+		seen:  make(map[string]struct{}),
+	}
+	s.Trades[d.Exchange][d.Pair.Base.Item][d.Pair.Quote.Item][d.AssetType] = feed
+	return feed, nil
+}
+
+// key identifies a trade by its ID, or by its contents when the exchange
+// does not provide one
+func (d *Data) key() string {
+	if d.TID != "" {
+		return d.TID
+	}
+	return strconv.FormatInt(d.Timestamp.UnixNano(), 10) + "|" +
+		d.Side.String() + "|" +
+		strconv.FormatFloat(d.Price, 'f', -1, 64) + "|" +
+		strconv.FormatFloat(d.Amount, 'f', -1, 64)
+}
+
+// ConvertToTradeHistory converts trades for use with the candle builder
+func ConvertToTradeHistory(trades []Data) []order.TradeHistory {
+	resp := make([]order.TradeHistory, len(trades))
+	for x := range trades {
+		resp[x] = order.TradeHistory{
+			Price:     trades[x].Price,
+			Amount:    trades[x].Amount,
+			Exchange:  trades[x].Exchange,
+			TID:       trades[x].TID,
+			Side:      trades[x].Side,
+			Timestamp: trades[x].Timestamp,
+		}
+	}
+	return resp
+}
+
+// CreateKline builds candles of the interval from the retained trades of a
+// currency pair
+func CreateKline(exchange string, p currency.Pair, a asset.Item, interval time.Duration) (kline.Item, error) {
+	trades, err := GetRecentTrades(exchange, p, a)
+	if err != nil {
+		return kline.Item{}, err
+	}
+	return kline.CreateKline(ConvertToTradeHistory(trades), interval, p, a, exchange)
+}
+
+// Save writes the retained trades of every feed to a file so they can be
+// restored with Load
+func Save(path string) error {
+	service.RLock()
+	var trades []Data
+	for _, bases := range service.Trades {
+		for _, quotes := range bases {
+			for _, assets := range quotes {
+				for _, feed := range assets {
+					trades = append(trades, feed.Trades...)
+				}
+			}
+		}
+	}
+	service.RUnlock()
+
+	payload, err := json.MarshalIndent(trades, "", " ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, payload, 0600)
+}
+
+// Load restores the trades written by Save, a missing file is not an error.
+// The amount of trades read is returned
+func Load(path string) (int, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var trades []Data
+	if err = json.Unmarshal(payload, &trades); err != nil {
+		return 0, err
+	}
+
+	byExchange := make(map[string][]Data)
+	for x := range trades {
+		byExchange[trades[x].Exchange] = append(byExchange[trades[x].Exchange], trades[x])
+	}
+	for exchangeName, exchangeTrades := range byExchange {
+		if err = ProcessTrades(exchangeName, exchangeTrades); err != nil {
+			return 0, err
+		}
+	}
+	return len(trades), nil
+}
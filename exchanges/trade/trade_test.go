@@ -0,0 +1,252 @@
+package trade
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestMain(m *testing.M) {
+	err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+// testExchange returns an exchange name unique to the test run, as feeds
+// persist between runs of the tests
+func testExchange(t *testing.T) string {
+	return t.Name() + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func TestProcessTrades(t *testing.T) {
+	exch := testExchange(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	if err := ProcessTrades("", []Data{{Pair: p, AssetType: asset.Spot}}); err == nil {
+		t.Error("expected an error for an unset exchange name")
+	}
+	if err := ProcessTrades(exch, nil); err == nil {
+		t.Error("expected an error for no trades")
+	}
+	if err := ProcessTrades(exch, []Data{{AssetType: asset.Spot}}); err == nil {
+		t.Error("expected an error for an unset pair")
+	}
+	if err := ProcessTrades(exch, []Data{{Pair: p}}); err == nil {
+		t.Error("expected an error for an unset asset type")
+	}
+
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := ProcessTrades(exch, []Data{
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 101, Amount: 1, Timestamp: start.Add(time.Second)},
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 100, Amount: 1, Timestamp: start},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// overlapping trades are ignored, trades without IDs are matched by their
+	// contents
+	err = ProcessTrades(exch, []Data{
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 101, Amount: 1, Timestamp: start.Add(time.Second)},
+		{Pair: p, AssetType: asset.Spot, Side: order.Buy, Price: 102, Amount: 2, Timestamp: start.Add(time.Second * 2)},
+		{Pair: p, AssetType: asset.Spot, Side: order.Buy, Price: 102, Amount: 2, Timestamp: start.Add(time.Second * 2)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trades, err := GetRecentTrades(strings.ToLower(exch), p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 3 {
+		t.Fatalf("expected 3 trades, got %d", len(trades))
+	}
+	if trades[0].TID != "1" || trades[2].Price != 102 || trades[0].Exchange != strings.ToLower(exch) {
+		t.Errorf("expected trades oldest first, got %+v", trades)
+	}
+
+	if _, err = GetRecentTrades(strings.ToLower(exch), p, asset.Futures); err == nil {
+		t.Error("expected an error for an unknown feed")
+	}
+}
+
+func TestAddTrades(t *testing.T) {
+	exch := testExchange(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	if _, err := AddTrades(exch, nil); err == nil {
+		t.Error("expected an error for no trades")
+	}
+	added, err := AddTrades(exch, []Data{
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 100, Amount: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 1 || added[0].Exchange != strings.ToLower(exch) || added[0].Timestamp.IsZero() {
+		t.Errorf("expected the processed trade to be returned, got %+v", added)
+	}
+	added, err = AddTrades(exch, []Data{
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 100, Amount: 1},
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 101, Amount: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 1 || added[0].TID != "2" {
+		t.Errorf("expected only the new trade to be returned, got %+v", added)
+	}
+}
+
+func TestBufferLength(t *testing.T) {
+	exch := testExchange(t)
+	SetBufferLength(2)
+	defer SetBufferLength(DefaultBufferLength)
+	SetBufferLength(0)
+
+	p := currency.NewPair(currency.LTC, currency.USD)
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	for x := 0; x < 3; x++ {
+		err := ProcessTrades(exch, []Data{
+			{Pair: p, AssetType: asset.Spot, Price: float64(x + 1), Amount: 1, Timestamp: start.Add(time.Duration(x) * time.Second)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	trades, err := GetRecentTrades(exch, p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2 || trades[0].Price != 2 || trades[1].Price != 3 {
+		t.Errorf("expected the two most recent trades, got %+v", trades)
+	}
+}
+
+func TestSubscribeTrades(t *testing.T) {
+	exch := testExchange(t)
+	p := currency.NewPair(currency.ETH, currency.USD)
+	if _, err := SubscribeTrades(exch, p, asset.Spot); err == nil {
+		t.Error("expected an error for an unknown feed")
+	}
+	if _, err := SubscribeToExchangeTrades(exch); err == nil {
+		t.Error("expected an error for an unknown exchange")
+	}
+
+	err := ProcessTrades(exch, []Data{{TID: "1", Pair: p, AssetType: asset.Spot, Price: 1, Amount: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipe, err := SubscribeTrades(exch, p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchangePipe, err := SubscribeToExchangeTrades(exch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = exchangePipe.Release(); err != nil {
+		t.Error(err)
+	}
+
+	defer func() {
+		if err = pipe.Release(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	received := make(chan struct{})
+	go func() {
+		for data := range pipe.C {
+			trades, ok := (*data.(*interface{})).([]Data)
+			if ok && len(trades) == 1 && trades[0].TID != "1" {
+				close(received)
+				return
+			}
+		}
+	}()
+	// the dispatcher only hands over trades to ready receivers, so new trades
+	// are published until the subscriber receives one
+	timeout := time.After(time.Second * 5)
+	for x := 2; ; x++ {
+		err = ProcessTrades(exch, []Data{{TID: strconv.Itoa(x), Pair: p, AssetType: asset.Spot, Price: 2, Amount: 1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-received:
+			return
+		case <-timeout:
+			t.Fatal("timed out waiting for published trades")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+}
+
+func TestCreateKline(t *testing.T) {
+	exch := testExchange(t)
+	p := currency.NewPair(currency.XRP, currency.USD)
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := ProcessTrades(exch, []Data{
+		{TID: "1", Pair: p, AssetType: asset.Spot, Price: 1, Amount: 1, Timestamp: start},
+		{TID: "2", Pair: p, AssetType: asset.Spot, Price: 3, Amount: 1, Timestamp: start.Add(time.Second * 30)},
+		{TID: "3", Pair: p, AssetType: asset.Spot, Price: 2, Amount: 1, Timestamp: start.Add(time.Minute)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := CreateKline(exch, p, asset.Spot, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k.Candles) == 0 || k.Candles[0].Open != 1 || k.Candles[0].High != 3 {
+		t.Errorf("unexpected candles %+v", k.Candles)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	exch := testExchange(t)
+	dir, err := ioutil.TempDir("", "trades")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "trades.json")
+
+	if n, err := Load(path); err != nil || n != 0 {
+		t.Errorf("expected a missing file to be ignored, got %d %v", n, err)
+	}
+
+	p := currency.NewPair(currency.BTC, currency.AUD)
+	err = ProcessTrades(exch, []Data{{TID: "1", Pair: p, AssetType: asset.Spot, Price: 1, Amount: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	service.Lock()
+	delete(service.Trades, strings.ToLower(exch))
+	service.Unlock()
+	n, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Error("expected trades to be loaded")
+	}
+	if trades, err := GetRecentTrades(exch, p, asset.Spot); err != nil || len(trades) != 1 {
+		t.Errorf("expected the trade to be restored, got %+v %v", trades, err)
+	}
+}
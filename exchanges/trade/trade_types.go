@@ -0,0 +1,72 @@
+package trade
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// DefaultBufferLength is the default amount of trades retained for each
+// exchange, pair and asset
+const DefaultBufferLength = 1000
+
+// DefaultCandleLength is the default amount of candles retained for each
+// exchange, pair, asset and interval
+const DefaultCandleLength = 500
+
+// const values for the trade package
+const (
+	errExchangeNameUnset = "trade exchange name not set"
+	errPairNotSet        = "trade currency pair not set"
+	errAssetTypeNotSet   = "trade asset type not set"
+	errNoTrades          = "no trades to process"
+	errIntervalNotBuilt  = "candles are not built at interval"
+)
+
+// Vars for the trade package
+var (
+	service      *Service
+	bufferLength int64 = DefaultBufferLength
+)
+
+// Service holds the recent public trades for each individual exchange
+type Service struct {
+	Trades   map[string]map[*currency.Item]map[*currency.Item]map[asset.Item]*Feed
+	Exchange map[string]uuid.UUID
+	mux      *dispatch.Mux
+	// candleIntervals are the intervals candles are built at as trades are
+	// added, candleLength is the amount retained for each interval
+	candleIntervals []time.Duration
+	candleLength    int
+	sync.RWMutex
+}
+
+// Data is a single public trade
+type Data struct {
+	TID       string        `json:"tid,omitempty"`
+	Exchange  string        `json:"exchange"`
+	Pair      currency.Pair `json:"pair"`
+	AssetType asset.Item    `json:"assetType"`
+	Side      order.Side    `json:"side,omitempty"`
+	Price     float64       `json:"price"`
+	Amount    float64       `json:"amount"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// Feed holds the recent trades of a currency pair and asset, oldest first
+type Feed struct {
+	Trades []Data
+	Main   uuid.UUID
+	Assoc  []uuid.UUID
+	seen   map[string]struct{}
+	// candles are built from the feed's trades for each interval, oldest
+	// first. candleLatest is the time of the most recent trade applied
+	candles      map[time.Duration][]kline.Candle
+	candleLatest time.Time
+}
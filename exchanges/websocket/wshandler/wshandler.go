@@ -12,9 +12,11 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -146,6 +148,7 @@ func (w *Websocket) connectionMonitor() {
 		case err := <-w.ReadMessageErrors:
 			// check if this error is a disconnection error
 			if isDisconnectionError(err) {
+				atomic.AddInt64(&w.disconnects, 1)
 				w.setConnectedStatus(false)
 				w.setConnectingStatus(false)
 				w.setInit(false)
@@ -243,6 +246,7 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 			if w.verbose {
 				log.Warnf(log.WebsocketMgr, "%v has not received a traffic alert in %v. Reconnecting", w.exchangeName, w.trafficTimeout)
 			}
+			atomic.AddInt64(&w.disconnects, 1)
 			go w.Shutdown()
 		}
 	}
@@ -254,6 +258,12 @@ func (w *Websocket) setConnectedStatus(b bool) {
 	w.connectionMutex.Unlock()
 }
 
+// GetDisconnects returns the amount of times the connection has been lost or
+// dropped for a lack of traffic since the websocket was created
+func (w *Websocket) GetDisconnects() int64 {
+	return atomic.LoadInt64(&w.disconnects)
+}
+
 // IsConnected returns status of connection
 func (w *Websocket) IsConnected() bool {
 	w.connectionMutex.RLock()
@@ -880,9 +890,9 @@ func (w *WebsocketConnection) parseBinaryResponse(resp []byte) ([]byte, error) {
 // GenerateMessageID Creates a messageID to checkout
 func (w *WebsocketConnection) GenerateMessageID(useNano bool) int64 {
 	if useNano {
-		return time.Now().UnixNano()
+		return clock.Now().UnixNano()
 	}
-	return time.Now().Unix()
+	return clock.Now().Unix()
 }
 
 // isDisconnectionError Determines if the error sent over chan ReadMessageErrors is a disconnection error
@@ -112,6 +112,9 @@ outer:
 			break outer
 		}
 	}
+	if ws.GetDisconnects() == 0 {
+		t.Error("expected the disconnection to be counted")
+	}
 }
 
 func TestWebsocket(t *testing.T) {
@@ -37,6 +37,7 @@ type Websocket struct {
 	verbose                      bool
 	connectionMonitorRunning     bool
 	trafficTimeout               time.Duration
+	disconnects                  int64
 	proxyAddr                    string
 	defaultURL                   string
 	runningURL                   string
@@ -8,6 +8,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 // Setup sets private variables
@@ -20,11 +21,23 @@ func (w *WebsocketOrderbookLocal) Setup(obBufferLimit int, bufferEnabled, sortBu
 	w.exchangeName = exchangeName
 }
 
+// SetSnapshotFetcher sets the function used to fetch a REST snapshot of an
+// orderbook which has desynchronised from its websocket updates
+func (w *WebsocketOrderbookLocal) SetSnapshotFetcher(f SnapshotFetcher) {
+	w.m.Lock()
+	w.snapshotFetcher = f
+	w.m.Unlock()
+}
+
 // Update updates a local cache using bid targets and ask targets then updates
 // main orderbook
 // Volume == 0; deletion at price target
 // Price target not found; append of price target
 // Price target found; amend volume of price target
+// An update which desynchronises the orderbook drops the local cache. When a
+// snapshot fetcher is set the orderbook is reseeded from a REST snapshot in
+// the background, holding updates until it is loaded, otherwise the desync is
+// returned so the exchange can resubscribe
 func (w *WebsocketOrderbookLocal) Update(u *WebsocketOrderbookUpdate) error {
 	if (u.Bids == nil && u.Asks == nil) || (len(u.Bids) == 0 && len(u.Asks) == 0) {
 		return fmt.Errorf("%v cannot have bids and ask targets both nil",
@@ -32,31 +45,165 @@ func (w *WebsocketOrderbookLocal) Update(u *WebsocketOrderbookUpdate) error {
 	}
 	w.m.Lock()
 	defer w.m.Unlock()
+	if r := w.resyncs[u.Pair][u.Asset]; r != nil {
+		r.pending = append(r.pending, u)
+		return nil
+	}
+	held, err := w.update(u)
+	if held == nil || w.snapshotFetcher == nil {
+		return err
+	}
+	log.Warnf(log.WebsocketMgr, "%v, resynchronising from REST snapshot", err)
+	w.resync(u.Pair, u.Asset, held)
+	return nil
+}
+
+// update applies an update to the local cache. When the update desynchronises
+// the orderbook it returns the updates not reflected in the orderbook, which
+// are applied again once a new snapshot is loaded
+func (w *WebsocketOrderbookLocal) update(u *WebsocketOrderbookUpdate) ([]*WebsocketOrderbookUpdate, error) {
 	obLookup, ok := w.ob[u.Pair][u.Asset]
 	if !ok {
-		return fmt.Errorf("ob.Base could not be found for Exchange %s CurrencyPair: %s AssetType: %s",
+		return nil, fmt.Errorf("ob.Base could not be found for Exchange %s CurrencyPair: %s AssetType: %s",
 			w.exchangeName,
 			u.Pair,
 			u.Asset)
 	}
 
+	if u.Sequence != 0 {
+		last := w.sequences[u.Pair][u.Asset]
+		first := u.FirstSequence
+		if first == 0 {
+			first = u.Sequence
+		}
+		if last != 0 {
+			if u.Sequence <= last {
+				// the update is already included in the orderbook
+				return nil, nil
+			}
+			if first > last+1 {
+				held := w.drop(u)
+				return held, fmt.Errorf("%s %s %s orderbook desynchronised, expected update %d but received %d",
+					w.exchangeName,
+					u.Pair,
+					u.Asset,
+					last+1,
+					first)
+			}
+		}
+		w.setSequence(u.Pair, u.Asset, u.Sequence)
+	}
+
 	if w.bufferEnabled {
 		overBufferLimit := w.processBufferUpdate(obLookup, u)
 		if !overBufferLimit {
-			return nil
+			return nil, nil
 		}
 	} else {
 		w.processObUpdate(obLookup, u)
 	}
+	if u.Checksum != nil && !u.Checksum(obLookup) {
+		held := w.drop(u)
+		return held, fmt.Errorf("%s %s %s orderbook desynchronised, checksum failed",
+			w.exchangeName,
+			u.Pair,
+			u.Asset)
+	}
 	err := obLookup.Process()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if w.bufferEnabled {
 		// Reset the buffer
 		w.buffer[u.Pair][u.Asset] = nil
 	}
-	return nil
+	return nil, nil
+}
+
+// resync fetches a REST snapshot off the caller's goroutine, holding the
+// updates received meanwhile. Once loaded, the held updates newer than the
+// snapshot are applied
+func (w *WebsocketOrderbookLocal) resync(p currency.Pair, a asset.Item, held []*WebsocketOrderbookUpdate) {
+	if w.resyncs == nil {
+		w.resyncs = make(map[currency.Pair]map[asset.Item]*resync)
+	}
+	if w.resyncs[p] == nil {
+		w.resyncs[p] = make(map[asset.Item]*resync)
+	}
+	r := &resync{pending: held}
+	w.resyncs[p][a] = r
+	fetcher := w.snapshotFetcher
+	go func() {
+		ob, sequence, err := fetcher(p, a)
+		w.m.Lock()
+		defer w.m.Unlock()
+		if w.resyncs[p][a] != r {
+			// the cache was flushed or a snapshot loaded while fetching
+			return
+		}
+		delete(w.resyncs[p], a)
+		if err == nil {
+			err = w.loadSnapshot(ob, sequence)
+		}
+		if err != nil {
+			log.Errorf(log.WebsocketMgr,
+				"%s %s %s unable to resynchronise orderbook from REST snapshot: %v",
+				w.exchangeName,
+				p,
+				a,
+				err)
+			return
+		}
+		for i := range r.pending {
+			held, err := w.update(r.pending[i])
+			if held != nil {
+				// the snapshot is older than the held updates
+				log.Warnf(log.WebsocketMgr, "%v, resynchronising from REST snapshot", err)
+				w.resync(p, a, append(held, r.pending[i+1:]...))
+				return
+			}
+			if err != nil {
+				log.Errorf(log.WebsocketMgr, "%s websocket orderbook update error: %v",
+					w.exchangeName,
+					err)
+			}
+		}
+		log.Warnf(log.WebsocketMgr, "%s %s %s orderbook resynchronised from REST snapshot",
+			w.exchangeName,
+			p,
+			a)
+	}()
+}
+
+func (w *WebsocketOrderbookLocal) setSequence(p currency.Pair, a asset.Item, sequence int64) {
+	if w.sequences == nil {
+		w.sequences = make(map[currency.Pair]map[asset.Item]int64)
+	}
+	if w.sequences[p] == nil {
+		w.sequences[p] = make(map[asset.Item]int64)
+	}
+	w.sequences[p][a] = sequence
+}
+
+// drop removes the orderbook desynchronised by u from the local cache so that
+// its updates are rejected until a new snapshot is loaded, returning the
+// buffered updates and u
+func (w *WebsocketOrderbookLocal) drop(u *WebsocketOrderbookUpdate) []*WebsocketOrderbookUpdate {
+	held := append([]*WebsocketOrderbookUpdate(nil), w.buffer[u.Pair][u.Asset]...)
+	buffered := false
+	for i := range held {
+		if held[i] == u {
+			buffered = true
+			break
+		}
+	}
+	if !buffered {
+		held = append(held, u)
+	}
+	delete(w.ob[u.Pair], u.Asset)
+	delete(w.sequences[u.Pair], u.Asset)
+	delete(w.buffer[u.Pair], u.Asset)
+	return held
 }
 
 func (w *WebsocketOrderbookLocal) processBufferUpdate(o *orderbook.Base, u *WebsocketOrderbookUpdate) bool {
@@ -226,6 +373,24 @@ func (w *WebsocketOrderbookLocal) updateByIDAndAction(o *orderbook.Base, u *Webs
 // ob to be completely rewritten because the exchange is a doing a full
 // update not an incremental one
 func (w *WebsocketOrderbookLocal) LoadSnapshot(newOrderbook *orderbook.Base) error {
+	return w.LoadSnapshotWithSequence(newOrderbook, 0)
+}
+
+// LoadSnapshotWithSequence loads a snapshot which includes the updates up to
+// sequence, updates up to and including sequence are then skipped. A zero
+// sequence accepts the next update in any sequence
+func (w *WebsocketOrderbookLocal) LoadSnapshotWithSequence(newOrderbook *orderbook.Base, sequence int64) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	err := w.loadSnapshot(newOrderbook, sequence)
+	if err == nil {
+		// the snapshot supersedes one being fetched
+		delete(w.resyncs[newOrderbook.Pair], newOrderbook.AssetType)
+	}
+	return err
+}
+
+func (w *WebsocketOrderbookLocal) loadSnapshot(newOrderbook *orderbook.Base, sequence int64) error {
 	if len(newOrderbook.Asks) == 0 || len(newOrderbook.Bids) == 0 {
 		return fmt.Errorf("%v snapshot ask and bids are nil", w.exchangeName)
 	}
@@ -242,8 +407,6 @@ func (w *WebsocketOrderbookLocal) LoadSnapshot(newOrderbook *orderbook.Base) err
 		return errors.New("websocket orderbook exchange name unset")
 	}
 
-	w.m.Lock()
-	defer w.m.Unlock()
 	if w.ob == nil {
 		w.ob = make(map[currency.Pair]map[asset.Item]*orderbook.Base)
 	}
@@ -252,6 +415,8 @@ func (w *WebsocketOrderbookLocal) LoadSnapshot(newOrderbook *orderbook.Base) err
 	}
 
 	w.ob[newOrderbook.Pair][newOrderbook.AssetType] = newOrderbook
+	w.setSequence(newOrderbook.Pair, newOrderbook.AssetType, sequence)
+	delete(w.buffer[newOrderbook.Pair], newOrderbook.AssetType)
 	return newOrderbook.Process()
 }
 
@@ -270,5 +435,7 @@ func (w *WebsocketOrderbookLocal) FlushCache() {
 	w.m.Lock()
 	w.ob = nil
 	w.buffer = nil
+	w.sequences = nil
+	w.resyncs = nil
 	w.m.Unlock()
 }
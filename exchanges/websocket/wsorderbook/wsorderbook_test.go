@@ -3,6 +3,7 @@ package wsorderbook
 import (
 	"fmt"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -777,3 +778,150 @@ func TestEnsureMultipleUpdatesViaPrice(t *testing.T) {
 		t.Errorf("Insufficient updates")
 	}
 }
+
+func TestUpdateSequence(t *testing.T) {
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := obl.ob[cp][asset.Spot]
+	if err = obl.LoadSnapshotWithSequence(snapshot, 10); err != nil {
+		t.Fatal(err)
+	}
+	update := func(first, last int64, price float64) error {
+		return obl.Update(&WebsocketOrderbookUpdate{
+			Bids:          []orderbook.Item{{Price: price, Amount: 1}},
+			Pair:          cp,
+			Asset:         asset.Spot,
+			FirstSequence: first,
+			Sequence:      last,
+		})
+	}
+
+	// updates included in the snapshot are skipped
+	if err = update(5, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(obl.ob[cp][asset.Spot].Bids) != 1 {
+		t.Error("expected an update included in the snapshot to be skipped")
+	}
+	// the first update may overlap the snapshot
+	if err = update(9, 12, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err = update(13, 13, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(obl.ob[cp][asset.Spot].Bids) != 3 || obl.sequences[cp][asset.Spot] != 13 {
+		t.Errorf("expected contiguous updates to be applied, got %+v", obl.ob[cp][asset.Spot].Bids)
+	}
+
+	// a gap desynchronises the orderbook, dropping it until a new snapshot
+	if err = update(15, 15, 4); err == nil {
+		t.Fatal("expected an error for a gap in the sequence")
+	}
+	if obl.GetOrderbook(cp, asset.Spot) != nil {
+		t.Error("expected the desynchronised orderbook to be dropped")
+	}
+	if err = update(16, 16, 5); err == nil {
+		t.Error("expected updates to be rejected until a new snapshot is loaded")
+	}
+}
+
+func TestUpdateChecksum(t *testing.T) {
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := func(ob *orderbook.Base) bool { return len(ob.Bids) == 2 }
+	err = obl.Update(&WebsocketOrderbookUpdate{
+		Bids:     []orderbook.Item{{Price: 3000, Amount: 1}},
+		Pair:     cp,
+		Asset:    asset.Spot,
+		Checksum: valid,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = obl.Update(&WebsocketOrderbookUpdate{
+		Bids:     []orderbook.Item{{Price: 2000, Amount: 1}},
+		Pair:     cp,
+		Asset:    asset.Spot,
+		Checksum: valid,
+	})
+	if err == nil {
+		t.Fatal("expected an error for a failed checksum")
+	}
+	if obl.GetOrderbook(cp, asset.Spot) != nil {
+		t.Error("expected the desynchronised orderbook to be dropped")
+	}
+	ob, err := orderbook.Get(exchangeName, cp, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.Bids) != 2 {
+		t.Errorf("expected the orderbook failing its checksum not to be published, got %+v", ob.Bids)
+	}
+}
+
+func TestUpdateResync(t *testing.T) {
+	obl, _, _, err := createSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = obl.LoadSnapshotWithSequence(obl.ob[cp][asset.Spot], 1); err != nil {
+		t.Fatal(err)
+	}
+	var fetched int32
+	release := make(chan struct{})
+	obl.SetSnapshotFetcher(func(p currency.Pair, a asset.Item) (*orderbook.Base, int64, error) {
+		atomic.AddInt32(&fetched, 1)
+		<-release
+		return &orderbook.Base{
+			ExchangeName: exchangeName,
+			Pair:         p,
+			AssetType:    a,
+			Asks:         []orderbook.Item{{Price: 5000, Amount: 1}},
+			Bids:         []orderbook.Item{{Price: 1000, Amount: 1}},
+		}, 4, nil
+	})
+	update := func(sequence int64, price float64) {
+		err = obl.Update(&WebsocketOrderbookUpdate{
+			Bids:     []orderbook.Item{{Price: price, Amount: 1}},
+			Pair:     cp,
+			Asset:    asset.Spot,
+			Sequence: sequence,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the gap starts a resync, which holds the updates received while the
+	// snapshot is fetched
+	update(5, 900)
+	update(3, 100)
+	update(6, 800)
+	if obl.GetOrderbook(cp, asset.Spot) != nil {
+		t.Fatal("expected the desynchronised orderbook to be dropped while resynchronising")
+	}
+	close(release)
+
+	var ob *orderbook.Base
+	for i := 0; i < 100 && ob == nil; i++ {
+		time.Sleep(time.Millisecond * 10)
+		ob = obl.GetOrderbook(cp, asset.Spot)
+	}
+	if ob == nil {
+		t.Fatal("expected the orderbook to be resynchronised from the snapshot")
+	}
+	obl.m.Lock()
+	defer obl.m.Unlock()
+	if atomic.LoadInt32(&fetched) != 1 || ob.Asks[0].Price != 5000 || obl.sequences[cp][asset.Spot] != 6 {
+		t.Errorf("expected the orderbook to be resynchronised from the snapshot, got %+v", ob)
+	}
+	// the update which desynchronised the orderbook and the one after it are
+	// applied, the one included in the snapshot is skipped
+	if len(ob.Bids) != 3 || ob.Bids[1].Price != 900 || ob.Bids[2].Price != 800 {
+		t.Errorf("expected the held updates newer than the snapshot to be applied, got %+v", ob.Bids)
+	}
+}
@@ -20,9 +20,26 @@ type WebsocketOrderbookLocal struct {
 	sortBufferByUpdateIDs bool // When timestamps aren't provided, an id can help sort
 	updateEntriesByID     bool // Use the update IDs to match ob entries
 	exchangeName          string
-	m                     sync.Mutex
+	// sequences holds the sequence number of the last update applied to each
+	// orderbook when the exchange sequences its updates
+	sequences       map[currency.Pair]map[asset.Item]int64
+	snapshotFetcher SnapshotFetcher
+	// resyncs holds the orderbooks being resynchronised from a REST snapshot
+	resyncs map[currency.Pair]map[asset.Item]*resync
+	m       sync.Mutex
 }
 
+// resync holds the updates received while a REST snapshot is fetched, which
+// are applied once it is loaded
+type resync struct {
+	pending []*WebsocketOrderbookUpdate
+}
+
+// SnapshotFetcher returns a REST snapshot of an orderbook and the sequence
+// number of the last update it includes, zero when the exchange does not
+// sequence its updates
+type SnapshotFetcher func(p currency.Pair, a asset.Item) (*orderbook.Base, int64, error)
+
 // WebsocketOrderbookUpdate stores orderbook updates and dictates what features to use when processing
 type WebsocketOrderbookUpdate struct {
 	UpdateID   int64 // Used when no time is provided
@@ -32,4 +49,13 @@ type WebsocketOrderbookUpdate struct {
 	Bids       []orderbook.Item
 	Asks       []orderbook.Item
 	Pair       currency.Pair
+	// Sequence is the sequence number of the update when the exchange
+	// sequences its updates. FirstSequence is set when an update spans a
+	// range of sequence numbers. An update which does not follow the last
+	// update applied desynchronises the orderbook
+	FirstSequence int64
+	Sequence      int64
+	// Checksum verifies the orderbook once the update has been applied, a
+	// failed checksum desynchronises the orderbook
+	Checksum func(*orderbook.Base) bool
 }
@@ -183,14 +183,7 @@ func (z *ZB) Run() {
 		z.PrintEnabledPairs()
 	}
 
-	if !z.GetEnabledFeatures().AutoPairUpdates {
-		return
-	}
-
-	err := z.UpdateTradablePairs(false)
-	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s failed to update tradable pairs. Err: %s", z.Name, err)
-	}
+	z.UpdateAvailablePairs(z.UpdateTradablePairs, currency.Pair{}, false)
 }
 
 // FetchTradablePairs returns a list of the exchanges tradable pairs
@@ -0,0 +1,226 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxBodyLength bounds the body length of messages read so a corrupt body
+// length cannot exhaust memory
+const maxBodyLength = 1 << 20
+
+var (
+	errInvalidField      = errors.New("invalid field")
+	errInvalidBodyLength = errors.New("invalid body length")
+	errInvalidChecksum   = errors.New("invalid checksum")
+)
+
+// headerTags are the standard header fields which follow the message type,
+// in the order they are encoded
+var headerTags = []int{
+	TagSenderCompID,
+	TagTargetCompID,
+	TagMsgSeqNum,
+	TagPossDupFlag,
+	TagSendingTime,
+}
+
+// NewMessage returns a message of a message type
+func NewMessage(msgType string) *Message {
+	return &Message{Fields: []Field{{Tag: TagMsgType, Value: msgType}}}
+}
+
+// Type returns the message type
+func (m *Message) Type() string {
+	v, _ := m.Get(TagMsgType)
+	return v
+}
+
+// Get returns the value of a field
+func (m *Message) Get(tag int) (string, bool) {
+	for x := range m.Fields {
+		if m.Fields[x].Tag == tag {
+			return m.Fields[x].Value, true
+		}
+	}
+	return "", false
+}
+
+// GetInt returns the value of an integer field
+func (m *Message) GetInt(tag int) (int, error) {
+	v, ok := m.Get(tag)
+	if !ok {
+		return 0, fmt.Errorf("tag %d missing", tag)
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("tag %d: %v", tag, err)
+	}
+	return i, nil
+}
+
+// GetFloat returns the value of a decimal field
+func (m *Message) GetFloat(tag int) (float64, error) {
+	v, ok := m.Get(tag)
+	if !ok {
+		return 0, fmt.Errorf("tag %d missing", tag)
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("tag %d: %v", tag, err)
+	}
+	return f, nil
+}
+
+// Set sets the value of a field, replacing its existing value
+func (m *Message) Set(tag int, value string) *Message {
+	for x := range m.Fields {
+		if m.Fields[x].Tag == tag {
+			m.Fields[x].Value = value
+			return m
+		}
+	}
+	m.Fields = append(m.Fields, Field{Tag: tag, Value: value})
+	return m
+}
+
+// SetFloat sets the value of a decimal field
+func (m *Message) SetFloat(tag int, value float64) *Message {
+	return m.Set(tag, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Bytes encodes the message with its begin string, body length and checksum.
+// The message type and standard header fields are encoded before the body
+func (m *Message) Bytes(beginString string) []byte {
+	var body bytes.Buffer
+	writeField := func(f Field) {
+		body.WriteString(strconv.Itoa(f.Tag))
+		body.WriteByte('=')
+		body.WriteString(f.Value)
+		body.WriteByte(SOH)
+	}
+	if v, ok := m.Get(TagMsgType); ok {
+		writeField(Field{Tag: TagMsgType, Value: v})
+	}
+	for _, tag := range headerTags {
+		if v, ok := m.Get(tag); ok {
+			writeField(Field{Tag: tag, Value: v})
+		}
+	}
+	for x := range m.Fields {
+		if m.Fields[x].Tag == TagMsgType || isHeaderTag(m.Fields[x].Tag) {
+			continue
+		}
+		writeField(m.Fields[x])
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("8=" + beginString + string(SOH))
+	msg.WriteString("9=" + strconv.Itoa(body.Len()) + string(SOH))
+	msg.Write(body.Bytes())
+	msg.WriteString(fmt.Sprintf("10=%03d%c", checksum(msg.Bytes()), SOH))
+	return msg.Bytes()
+}
+
+// String returns the encoded message with its fields delimited by | for
+// logging
+func (m *Message) String() string {
+	return strings.Replace(string(m.Bytes(BeginString44)), string(SOH), "|", -1)
+}
+
+// ReadMessage reads a message from r and returns it along with its begin
+// string. The body length and checksum of the message are verified
+func ReadMessage(r *bufio.Reader) (*Message, string, error) {
+	var raw bytes.Buffer
+	beginString, err := readField(r, &raw, TagBeginString)
+	if err != nil {
+		return nil, "", err
+	}
+	length, err := readField(r, &raw, TagBodyLength)
+	if err != nil {
+		return nil, "", err
+	}
+	n, err := strconv.Atoi(length)
+	if err != nil || n <= 0 || n > maxBodyLength {
+		return nil, "", errInvalidBodyLength
+	}
+
+	body := make([]byte, n)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return nil, "", err
+	}
+	raw.Write(body)
+	sum := checksum(raw.Bytes())
+
+	var trailer bytes.Buffer
+	v, err := readField(r, &trailer, TagCheckSum)
+	if err != nil {
+		return nil, "", err
+	}
+	if received, err := strconv.Atoi(v); err != nil || received != sum {
+		return nil, "", errInvalidChecksum
+	}
+
+	m, err := parseFields(body)
+	if err != nil {
+		return nil, "", err
+	}
+	if m.Type() == "" {
+		return nil, "", fmt.Errorf("tag %d missing", TagMsgType)
+	}
+	return m, beginString, nil
+}
+
+// readField reads a field which must have the expected tag, appending its
+// raw bytes to raw
+func readField(r *bufio.Reader, raw *bytes.Buffer, tag int) (string, error) {
+	s, err := r.ReadString(SOH)
+	if err != nil {
+		return "", err
+	}
+	raw.WriteString(s)
+	prefix := strconv.Itoa(tag) + "="
+	if !strings.HasPrefix(s, prefix) {
+		return "", fmt.Errorf("%v, expected tag %d", errInvalidField, tag)
+	}
+	return strings.TrimSuffix(s[len(prefix):], string(SOH)), nil
+}
+
+// parseFields parses the fields of a message body
+func parseFields(body []byte) (*Message, error) {
+	m := &Message{}
+	for _, f := range bytes.Split(bytes.TrimSuffix(body, []byte{SOH}), []byte{SOH}) {
+		i := bytes.IndexByte(f, '=')
+		if i <= 0 {
+			return nil, errInvalidField
+		}
+		tag, err := strconv.Atoi(string(f[:i]))
+		if err != nil {
+			return nil, errInvalidField
+		}
+		m.Fields = append(m.Fields, Field{Tag: tag, Value: string(f[i+1:])})
+	}
+	return m, nil
+}
+
+func isHeaderTag(tag int) bool {
+	for x := range headerTags {
+		if headerTags[x] == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func checksum(b []byte) int {
+	var sum int
+	for x := range b {
+		sum += int(b[x])
+	}
+	return sum % 256
+}
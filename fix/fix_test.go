@@ -0,0 +1,66 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMessageBytes(t *testing.T) {
+	m := NewMessage(MsgTypeHeartbeat).
+		Set(TagTestReqID, "1").
+		Set(TagSendingTime, "20200526-02:00:00.000").
+		Set(TagMsgSeqNum, "2").
+		Set(TagTargetCompID, "CLIENT").
+		Set(TagSenderCompID, "GCT")
+	expected := "8=FIX.4.4|9=58|35=0|49=GCT|56=CLIENT|34=2|52=20200526-02:00:00.000|112=1|10=093|"
+	if m.String() != expected {
+		t.Errorf("expected header fields first, got %s", m.String())
+	}
+
+	r := bufio.NewReader(bytes.NewReader(append(m.Bytes(BeginString44), m.Bytes(BeginString44)...)))
+	for x := 0; x < 2; x++ {
+		read, beginString, err := ReadMessage(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if beginString != BeginString44 || read.Type() != MsgTypeHeartbeat {
+			t.Errorf("unexpected message %s %s", beginString, read)
+		}
+		if seq, err := read.GetInt(TagMsgSeqNum); err != nil || seq != 2 {
+			t.Errorf("unexpected sequence number %d %v", seq, err)
+		}
+	}
+}
+
+func TestReadMessage(t *testing.T) {
+	valid := NewMessage(MsgTypeNewOrderSingle).
+		Set(TagClOrdID, "abc").
+		SetFloat(TagOrderQty, 0.5).
+		Bytes(BeginString44)
+
+	m, _, err := ReadMessage(bufio.NewReader(bytes.NewReader(valid)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qty, err := m.GetFloat(TagOrderQty); err != nil || qty != 0.5 {
+		t.Errorf("unexpected order qty %v %v", qty, err)
+	}
+	if _, err = m.GetFloat(TagPrice); err == nil {
+		t.Error("expected error for missing tag")
+	}
+
+	corrupt := append([]byte{}, valid...)
+	corrupt[len(corrupt)-2]++
+	if _, _, err = ReadMessage(bufio.NewReader(bytes.NewReader(corrupt))); err != errInvalidChecksum {
+		t.Errorf("expected %v, got %v", errInvalidChecksum, err)
+	}
+
+	if _, _, err = ReadMessage(bufio.NewReader(strings.NewReader("8=FIX.4.4\x019=abc\x01"))); err != errInvalidBodyLength {
+		t.Errorf("expected %v, got %v", errInvalidBodyLength, err)
+	}
+	if _, _, err = ReadMessage(bufio.NewReader(strings.NewReader("9=5\x01"))); err == nil {
+		t.Error("expected error for missing begin string")
+	}
+}
@@ -0,0 +1,155 @@
+package fix
+
+// BeginString44 is the begin string of FIX 4.4 messages
+const BeginString44 = "FIX.4.4"
+
+// SOH is the delimiter between the fields of a message
+const SOH = '\x01'
+
+// TimeFormat is the format of UTCTimestamp fields
+const TimeFormat = "20060102-15:04:05.000"
+
+// Message types
+const (
+	MsgTypeHeartbeat          = "0"
+	MsgTypeTestRequest        = "1"
+	MsgTypeResendRequest      = "2"
+	MsgTypeReject             = "3"
+	MsgTypeSequenceReset      = "4"
+	MsgTypeLogout             = "5"
+	MsgTypeExecutionReport    = "8"
+	MsgTypeOrderCancelReject  = "9"
+	MsgTypeLogon              = "A"
+	MsgTypeNewOrderSingle     = "D"
+	MsgTypeOrderCancelRequest = "F"
+)
+
+// Field tags
+const (
+	TagAccount             = 1
+	TagAvgPx               = 6
+	TagBeginSeqNo          = 7
+	TagBeginString         = 8
+	TagBodyLength          = 9
+	TagCheckSum            = 10
+	TagClOrdID             = 11
+	TagCumQty              = 14
+	TagEndSeqNo            = 16
+	TagExecID              = 17
+	TagExecInst            = 18
+	TagLastPx              = 31
+	TagLastQty             = 32
+	TagMsgSeqNum           = 34
+	TagMsgType             = 35
+	TagNewSeqNo            = 36
+	TagOrderID             = 37
+	TagOrderQty            = 38
+	TagOrdStatus           = 39
+	TagOrdType             = 40
+	TagOrigClOrdID         = 41
+	TagPossDupFlag         = 43
+	TagPrice               = 44
+	TagRefSeqNum           = 45
+	TagSenderCompID        = 49
+	TagSendingTime         = 52
+	TagSide                = 54
+	TagSymbol              = 55
+	TagTargetCompID        = 56
+	TagText                = 58
+	TagTimeInForce         = 59
+	TagTransactTime        = 60
+	TagEncryptMethod       = 98
+	TagStopPx              = 99
+	TagExDestination       = 100
+	TagCxlRejReason        = 102
+	TagOrdRejReason        = 103
+	TagHeartBtInt          = 108
+	TagTestReqID           = 112
+	TagGapFillFlag         = 123
+	TagResetSeqNumFlag     = 141
+	TagExecType            = 150
+	TagLeavesQty           = 151
+	TagSecurityType        = 167
+	TagRefMsgType          = 372
+	TagSessionRejectReason = 373
+	TagCxlRejResponseTo    = 434
+	TagPassword            = 554
+)
+
+// Side values
+const (
+	SideBuy  = "1"
+	SideSell = "2"
+)
+
+// OrdType values
+const (
+	OrdTypeMarket    = "1"
+	OrdTypeLimit     = "2"
+	OrdTypeStop      = "3"
+	OrdTypeStopLimit = "4"
+)
+
+// TimeInForce values
+const (
+	TimeInForceDay = "0"
+	TimeInForceGTC = "1"
+	TimeInForceIOC = "3"
+	TimeInForceFOK = "4"
+)
+
+// ExecInstParticipateDontInitiate is the ExecInst of post only orders
+const ExecInstParticipateDontInitiate = "6"
+
+// ExecType values
+const (
+	ExecTypeNew         = "0"
+	ExecTypeCanceled    = "4"
+	ExecTypeRejected    = "8"
+	ExecTypeExpired     = "C"
+	ExecTypeTrade       = "F"
+	ExecTypeOrderStatus = "I"
+)
+
+// OrdStatus values
+const (
+	OrdStatusNew             = "0"
+	OrdStatusPartiallyFilled = "1"
+	OrdStatusFilled          = "2"
+	OrdStatusCanceled        = "4"
+	OrdStatusPendingCancel   = "6"
+	OrdStatusRejected        = "8"
+	OrdStatusExpired         = "C"
+)
+
+// CxlRejResponseTo and CxlRejReason values
+const (
+	CxlRejResponseToCancel = "1"
+	CxlRejReasonTooLate    = "0"
+	CxlRejReasonUnknown    = "1"
+	CxlRejReasonOther      = "99"
+)
+
+// OrdRejReasonOther is the OrdRejReason of orders rejected by the exchange
+// or the order manager
+const OrdRejReasonOther = "99"
+
+// SessionRejectReason values
+const (
+	SessionRejectReasonRequiredTagMissing = "1"
+	SessionRejectReasonValueIncorrect     = "5"
+	SessionRejectReasonInvalidMsgType     = "11"
+	SessionRejectReasonCompIDProblem      = "9"
+)
+
+// Field is a tag and its value
+type Field struct {
+	Tag   int
+	Value string
+}
+
+// Message is a FIX message. Its begin string, body length and checksum are
+// set when it is encoded
+type Message struct {
+	Fields []Field
+}
@@ -28,6 +28,8 @@ var exchangeModule = map[string]objects.Object{
 	"withdrawcrypto": &objects.UserFunction{Name: "withdrawcrypto", Value: ExchangeWithdrawCrypto},
 	"withdrawfiat":   &objects.UserFunction{Name: "withdrawfiat", Value: ExchangeWithdrawFiat},
 	"ohlcv":          &objects.UserFunction{Name: "ohlcv", Value: exchangeOHLCV},
+	"openinterest":   &objects.UserFunction{Name: "openinterest", Value: ExchangeOpenInterest},
+	"liquidations":   &objects.UserFunction{Name: "liquidations", Value: ExchangeLiquidations},
 }
 
 // ExchangeOrderbook returns orderbook for requested exchange & currencypair
@@ -211,10 +213,11 @@ func ExchangeAccountInfo(args ...objects.Object) (objects.Object, error) {
 	var funds objects.Array
 	for x := range rtnValue.Accounts {
 		for y := range rtnValue.Accounts[x].Currencies {
-			temp := make(map[string]objects.Object, 3)
+			temp := make(map[string]objects.Object, 4)
 			temp["name"] = &objects.String{Value: rtnValue.Accounts[x].Currencies[y].CurrencyName.String()}
 			temp["total"] = &objects.Float{Value: rtnValue.Accounts[x].Currencies[y].TotalValue}
 			temp["hold"] = &objects.Float{Value: rtnValue.Accounts[x].Currencies[y].Hold}
+			temp["available"] = &objects.Float{Value: rtnValue.Accounts[x].Currencies[y].Available}
 			funds.Value = append(funds.Value, &objects.Map{Value: temp})
 		}
 	}
@@ -583,6 +586,115 @@ func exchangeOHLCV(args ...objects.Object) (objects.Object, error) {
 	return c, nil
 }
 
+// ExchangeOpenInterest returns the latest open interest collected for a
+// derivatives contract along with the retained history, oldest first
+func ExchangeOpenInterest(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	currencyPair, ok := objects.ToString(args[1])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, currencyPair)
+	}
+	delimiter, ok := objects.ToString(args[2])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, delimiter)
+	}
+	assetTypeParam, ok := objects.ToString(args[3])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, assetTypeParam)
+	}
+
+	pairs := currency.NewPairDelimiter(currencyPair, delimiter)
+	assetType := asset.Item(strings.ToLower(assetTypeParam))
+
+	rtnValue, err := wrappers.GetWrapper().OpenInterest(exchangeName, pairs, assetType)
+	if err != nil {
+		return nil, err
+	}
+	if len(rtnValue) == 0 {
+		return nil, fmt.Errorf("no open interest for %s %s %s", exchangeName, pairs, assetType)
+	}
+
+	history := objects.Array{}
+	for x := range rtnValue {
+		temp := make(map[string]objects.Object, 3)
+		temp["amount"] = &objects.Float{Value: rtnValue[x].Amount}
+		temp["value"] = &objects.Float{Value: rtnValue[x].Value}
+		temp["updated"] = &objects.Time{Value: rtnValue[x].Timestamp}
+		history.Value = append(history.Value, &objects.Map{Value: temp})
+	}
+
+	latest := rtnValue[len(rtnValue)-1]
+	data := make(map[string]objects.Object, 8)
+	data["exchange"] = &objects.String{Value: latest.Exchange}
+	data["pair"] = &objects.String{Value: latest.Pair.String()}
+	data["asset"] = &objects.String{Value: latest.AssetType.String()}
+	data["amount"] = &objects.Float{Value: latest.Amount}
+	data["value"] = &objects.Float{Value: latest.Value}
+	data["currency"] = &objects.String{Value: latest.ValueCurrency.String()}
+	data["updated"] = &objects.Time{Value: latest.Timestamp}
+	data["history"] = &history
+
+	return &objects.Map{
+		Value: data,
+	}, nil
+}
+
+// ExchangeLiquidations returns the liquidations collected for a derivatives
+// contract since the supplied time, oldest first
+func ExchangeLiquidations(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 5 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	currencyPair, ok := objects.ToString(args[1])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, currencyPair)
+	}
+	delimiter, ok := objects.ToString(args[2])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, delimiter)
+	}
+	assetTypeParam, ok := objects.ToString(args[3])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, assetTypeParam)
+	}
+	since, ok := objects.ToTime(args[4])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, since)
+	}
+
+	pairs := currency.NewPairDelimiter(currencyPair, delimiter)
+	assetType := asset.Item(strings.ToLower(assetTypeParam))
+
+	rtnValue, err := wrappers.GetWrapper().Liquidations(exchangeName, pairs, assetType, since)
+	if err != nil {
+		return nil, err
+	}
+
+	r := objects.Array{}
+	for x := range rtnValue {
+		temp := make(map[string]objects.Object, 5)
+		temp["id"] = &objects.String{Value: rtnValue[x].ID}
+		temp["side"] = &objects.String{Value: rtnValue[x].Side.String()}
+		temp["price"] = &objects.Float{Value: rtnValue[x].Price}
+		temp["amount"] = &objects.Float{Value: rtnValue[x].Amount}
+		temp["time"] = &objects.Time{Value: rtnValue[x].Timestamp}
+		r.Value = append(r.Value, &objects.Map{Value: temp})
+	}
+	return &r, nil
+}
+
 // parseInterval will parse the interval param of indictors that have them and convert to time.Duration
 func parseInterval(in string) (time.Duration, error) {
 	if !common.StringDataContainsInsensitive(supportedDurations, in) {
@@ -211,6 +211,52 @@ func TestExchangeOrderSubmit(t *testing.T) {
 	}
 }
 
+func TestExchangeOpenInterest(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeOpenInterest()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Fatal(err)
+	}
+
+	perpetual := &objects.String{Value: "PERPETUALSWAP"}
+	oi, err := ExchangeOpenInterest(exch, currencyPair, delimiter, perpetual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, ok := oi.(*objects.Map)
+	if !ok || data.Value["asset"].(*objects.String).Value != "perpetualswap" {
+		t.Errorf("unexpected open interest %v", oi)
+	}
+
+	_, err = ExchangeOpenInterest(exchError, currencyPair, delimiter, perpetual)
+	if err != nil && !errors.Is(err, errTestFailed) {
+		t.Fatal(err)
+	}
+}
+
+func TestExchangeLiquidations(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeLiquidations()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Fatal(err)
+	}
+
+	perpetual := &objects.String{Value: "perpetualswap"}
+	since := &objects.Time{Value: time.Now().Add(-time.Hour)}
+	liquidations, err := ExchangeLiquidations(exch, currencyPair, delimiter, perpetual, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := liquidations.(*objects.Array); !ok || len(data.Value) != 1 {
+		t.Errorf("unexpected liquidations %v", liquidations)
+	}
+
+	_, err = ExchangeLiquidations(exchError, currencyPair, delimiter, perpetual, since)
+	if err != nil && !errors.Is(err, errTestFailed) {
+		t.Fatal(err)
+	}
+}
+
 func TestAllModuleNames(t *testing.T) {
 	x := AllModuleNames()
 	xType := reflect.TypeOf(x).Kind()
@@ -12,6 +12,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -228,3 +229,23 @@ func (e Exchange) OHLCV(exch string, pair currency.Pair, item asset.Item, start,
 
 	return ret, nil
 }
+
+// OpenInterest returns the open interest samples collected for a derivatives
+// contract, oldest first
+func (e Exchange) OpenInterest(exch string, pair currency.Pair, item asset.Item) ([]derivative.OpenInterest, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return derivative.GetOpenInterestHistory(ex.GetName(), pair, item)
+}
+
+// Liquidations returns the liquidations collected for a derivatives contract
+// since the supplied time, oldest first
+func (e Exchange) Liquidations(exch string, pair currency.Pair, item asset.Item, since time.Time) ([]derivative.Liquidation, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return derivative.GetLiquidations(ex.GetName(), pair, item, since)
+}
@@ -5,10 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/engine"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -168,6 +170,54 @@ func TestExchange_CancelOrder(t *testing.T) {
 	}
 }
 
+func TestExchange_OpenInterest(t *testing.T) {
+	t.Parallel()
+	c := currency.NewPairDelimiter(pairs, delimiter)
+	err := derivative.ProcessOpenInterest(&derivative.OpenInterest{
+		Exchange:  exchName,
+		Pair:      c,
+		AssetType: asset.Futures,
+		Amount:    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi, err := exchangeTest.OpenInterest(exchName, c, asset.Futures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oi) != 1 {
+		t.Errorf("expected 1 open interest sample, got %d", len(oi))
+	}
+	_, err = exchangeTest.OpenInterest("fake_exchange", c, asset.Futures)
+	if err == nil {
+		t.Error("expected error for unknown exchange")
+	}
+}
+
+func TestExchange_Liquidations(t *testing.T) {
+	t.Parallel()
+	c := currency.NewPairDelimiter(pairs, delimiter)
+	err := derivative.ProcessLiquidation(&derivative.Liquidation{
+		Exchange:  exchName,
+		Pair:      c,
+		AssetType: asset.PerpetualSwap,
+		ID:        "1",
+		Price:     1,
+		Amount:    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := exchangeTest.Liquidations(exchName, c, asset.PerpetualSwap, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 1 {
+		t.Errorf("expected 1 liquidation, got %d", len(l))
+	}
+}
+
 func setupEngine() (err error) {
 	engine.Bot, err = engine.NewFromSettings(&settings)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/derivative"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -20,6 +21,8 @@ const (
 	validatorLow   float64 = 5500
 	validatorClose float64 = 5700
 	validatorVol   float64 = 10
+
+	validatorOpenInterest float64 = 100000
 )
 
 // Exchanges validator for test execution/scripts
@@ -260,3 +263,42 @@ func (w Wrapper) OHLCV(exch string, p currency.Pair, a asset.Item, start, end ti
 		Candles:  candles,
 	}, nil
 }
+
+// OpenInterest validator for test execution/scripts
+func (w Wrapper) OpenInterest(exch string, p currency.Pair, a asset.Item) ([]derivative.OpenInterest, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+
+	return []derivative.OpenInterest{
+		{
+			Exchange:      exch,
+			Pair:          p,
+			AssetType:     a,
+			Amount:        validatorOpenInterest,
+			Value:         validatorOpenInterest / validatorClose,
+			ValueCurrency: p.Base,
+			Timestamp:     time.Now(),
+		},
+	}, nil
+}
+
+// Liquidations validator for test execution/scripts
+func (w Wrapper) Liquidations(exch string, p currency.Pair, a asset.Item, since time.Time) ([]derivative.Liquidation, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+
+	return []derivative.Liquidation{
+		{
+			Exchange:  exch,
+			Pair:      p,
+			AssetType: a,
+			ID:        "1",
+			Side:      order.Sell,
+			Price:     validatorClose,
+			Amount:    validatorVol,
+			Timestamp: since,
+		},
+	}, nil
+}
@@ -218,3 +218,30 @@ func TestWrapper_OHLCV(t *testing.T) {
 		t.Fatal("expected OHLCV to return error with invalid name")
 	}
 }
+
+func TestWrapper_OpenInterest(t *testing.T) {
+	c := currency.NewPairDelimiter(pairs, delimiter)
+	oi, err := testWrapper.OpenInterest("test", c, asset.PerpetualSwap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oi) != 1 || oi[0].Amount != validatorOpenInterest {
+		t.Errorf("unexpected open interest %+v", oi)
+	}
+	_, err = testWrapper.OpenInterest(exchError.String(), c, asset.PerpetualSwap)
+	if err == nil {
+		t.Fatal("expected OpenInterest to return error with invalid name")
+	}
+}
+
+func TestWrapper_Liquidations(t *testing.T) {
+	c := currency.NewPairDelimiter(pairs, delimiter)
+	_, err := testWrapper.Liquidations("test", c, asset.PerpetualSwap, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testWrapper.Liquidations(exchError.String(), c, asset.PerpetualSwap, time.Now())
+	if err == nil {
+		t.Fatal("expected Liquidations to return error with invalid name")
+	}
+}
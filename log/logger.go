@@ -41,6 +41,7 @@ func (l *Logger) newLogEvent(data, header, slName string, w io.Writer) error {
 		e.data = append(e.data, '\n')
 	}
 	_, err := e.output.Write(e.data)
+	recent.add(string(e.data))
 
 	e.data = e.data[:0]
 	eventPool.Put(e)
@@ -0,0 +1,50 @@
+package log
+
+import "sync"
+
+// historyLength is the amount of log lines kept in memory for Recent
+const historyLength = 500
+
+// history is a ring buffer of the most recent log lines written
+type history struct {
+	m     sync.Mutex
+	lines []string
+	next  int
+}
+
+var recent = &history{}
+
+func (h *history) add(line string) {
+	h.m.Lock()
+	if len(h.lines) < historyLength {
+		h.lines = append(h.lines, line)
+	} else {
+		h.lines[h.next] = line
+	}
+	h.next = (h.next + 1) % historyLength
+	h.m.Unlock()
+}
+
+func (h *history) get(n int) []string {
+	h.m.Lock()
+	defer h.m.Unlock()
+	if n <= 0 || n > len(h.lines) {
+		n = len(h.lines)
+	}
+	lines := make([]string, 0, n)
+	start := h.next - n
+	if len(h.lines) < historyLength {
+		start = len(h.lines) - n
+	}
+	for i := 0; i < n; i++ {
+		lines = append(lines, h.lines[(start+i+historyLength)%historyLength])
+	}
+	return lines
+}
+
+// Recent returns up to n of the most recent log lines written by all sub
+// loggers, oldest first. All of the lines kept are returned when n is not
+// positive
+func Recent(n int) []string {
+	return recent.get(n)
+}
@@ -148,11 +148,13 @@ func init() {
 	WebsocketMgr = registerNewSubLogger("WEBSOCKET")
 	EventMgr = registerNewSubLogger("EVENT")
 	DispatchMgr = registerNewSubLogger("DISPATCH")
+	EventBusMgr = registerNewSubLogger("EVENTBUS")
 
 	RequestSys = registerNewSubLogger("REQUESTER")
 	ExchangeSys = registerNewSubLogger("EXCHANGE")
 	GRPCSys = registerNewSubLogger("GRPC")
 	RESTSys = registerNewSubLogger("REST")
+	FIXSys = registerNewSubLogger("FIX")
 
 	Ticker = registerNewSubLogger("TICKER")
 	OrderBook = registerNewSubLogger("ORDERBOOK")
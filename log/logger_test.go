@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -218,6 +219,25 @@ func TestNewLogEvent(t *testing.T) {
 	}
 }
 
+func TestRecent(t *testing.T) {
+	var h history
+	for i := 0; i < historyLength+2; i++ {
+		h.add(strconv.Itoa(i))
+	}
+	lines := h.get(3)
+	if len(lines) != 3 || lines[0] != "499" || lines[2] != "501" {
+		t.Errorf("expected the most recent lines oldest first, got %v", lines)
+	}
+	if lines = h.get(0); len(lines) != historyLength || lines[0] != "2" {
+		t.Errorf("expected all of the lines kept, got %d starting at %v", len(lines), lines[0])
+	}
+
+	Info(Global, "TestRecent")
+	if lines = Recent(1); len(lines) != 1 || !strings.Contains(lines[0], "TestRecent") {
+		t.Errorf("expected the logged line to be kept, got %v", lines)
+	}
+}
+
 func TestInfo(t *testing.T) {
 	w := &bytes.Buffer{}
 
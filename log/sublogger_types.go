@@ -17,11 +17,13 @@ var (
 	WebsocketMgr     *subLogger
 	EventMgr         *subLogger
 	DispatchMgr      *subLogger
+	EventBusMgr      *subLogger
 
 	RequestSys  *subLogger
 	ExchangeSys *subLogger
 	GRPCSys     *subLogger
 	RESTSys     *subLogger
+	FIXSys      *subLogger
 
 	Ticker    *subLogger
 	OrderBook *subLogger
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
@@ -14,6 +15,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/engine"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctscript"
 	gctscriptVM "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	gctlog "github.com/thrasher-corp/gocryptotrader/log"
@@ -25,16 +28,20 @@ func main() {
 	// Handle flags
 	var settings engine.Settings
 	versionFlag := flag.Bool("version", false, "retrieves current GoCryptoTrader version")
+	checkFlag := flag.Bool("check", false, "checks the connectivity and API keys of enabled exchanges, prints the results and exits")
+	downloadFlag := flag.Bool("downloadhistory", false, "downloads an exchange pair's historic candles or trades set by the download flags, resuming a previous download, and exits")
 
 	// Core settings
 	flag.StringVar(&settings.ConfigFile, "config", config.DefaultFilePath(), "config file to load")
 	flag.StringVar(&settings.DataDir, "datadir", common.GetDefaultDataDir(runtime.GOOS), "default data directory for GoCryptoTrader files")
 	flag.IntVar(&settings.GoMaxProcs, "gomaxprocs", runtime.GOMAXPROCS(-1), "sets the runtime GOMAXPROCS value")
-	flag.BoolVar(&settings.EnableDryRun, "dryrun", false, "dry runs bot, doesn't save config file")
+	flag.BoolVar(&settings.EnableDryRun, "dryrun", false, "dry runs bot, doesn't save config file or send orders, cancellations and withdrawals to exchanges")
+	flag.BoolVar(&settings.EnableDataOnlyMode, "dataonly", false, "runs as a market data only node, purging exchange credentials and disabling trading and withdrawals")
 	flag.BoolVar(&settings.EnableAllExchanges, "enableallexchanges", false, "enables all exchanges")
 	flag.BoolVar(&settings.EnableAllPairs, "enableallpairs", false, "enables all pairs for enabled exchanges")
 	flag.BoolVar(&settings.EnablePortfolioManager, "portfoliomanager", true, "enables the portfolio manager")
 	flag.DurationVar(&settings.PortfolioManagerDelay, "portfoliomanagerdelay", time.Duration(0), "sets the portfolio managers sleep delay between updates")
+	disableFlag := flag.String("disable", "", "comma separated list of managers to disable which otherwise run when enabled in the config: "+strings.Join(engine.ConfigManagerNames(), ", "))
 	flag.BoolVar(&settings.EnableGRPC, "grpc", true, "enables the grpc server")
 	flag.BoolVar(&settings.EnableGRPCProxy, "grpcproxy", false, "enables the grpc proxy server")
 	flag.BoolVar(&settings.EnableWebsocketRPC, "websocketrpc", true, "enables the websocket RPC server")
@@ -55,6 +62,9 @@ func main() {
 	flag.BoolVar(&settings.EnableDispatcher, "dispatch", true, "enables the dispatch system")
 	flag.IntVar(&settings.DispatchMaxWorkerAmount, "dispatchworkers", dispatch.DefaultMaxWorkers, "sets the dispatch package max worker generation limit")
 	flag.IntVar(&settings.DispatchJobsLimit, "dispatchjobslimit", dispatch.DefaultJobsLimit, "sets the dispatch package max jobs limit")
+	flag.IntVar(&settings.TickerHistoryLength, "tickerhistory", ticker.DefaultHistoryLength, "sets the amount of ticks retained for each ticker's history")
+	flag.IntVar(&settings.TradeBufferLength, "tradebuffer", trade.DefaultBufferLength, "sets the amount of recent public trades retained for each exchange pair")
+	flag.BoolVar(&settings.EnableTradePersistence, "tradepersistence", false, "persists the recent public trades to the data directory across restarts")
 
 	// Exchange syncer settings
 	flag.BoolVar(&settings.EnableTickerSyncing, "tickersync", true, "enables ticker syncing for all enabled exchanges")
@@ -78,6 +88,7 @@ func main() {
 	flag.BoolVar(&settings.EnableExchangeRESTSupport, "exchangerestsupport", true, "enables REST support for exchanges")
 	flag.BoolVar(&settings.EnableExchangeVerbose, "exchangeverbose", false, "increases exchange logging verbosity")
 	flag.BoolVar(&settings.ExchangePurgeCredentials, "exchangepurgecredentials", false, "purges the stored exchange API credentials")
+	flag.BoolVar(&settings.ExchangeKeyValidation, "exchangekeyvalidation", true, "validates exchange API key permissions on startup, disabling trading and withdrawals the keys cannot perform")
 	flag.BoolVar(&settings.EnableExchangeHTTPRateLimiter, "ratelimiter", true, "enables the rate limiter for HTTP requests")
 	flag.IntVar(&settings.MaxHTTPRequestJobsLimit, "requestjobslimit", int(request.DefaultMaxRequestJobs), "sets the max amount of jobs the HTTP request package stores")
 	flag.IntVar(&settings.RequestMaxRetryAttempts, "httpmaxretryattempts", request.DefaultMaxRetryAttempts, "sets the number of retry attempts after a retryable HTTP failure")
@@ -85,6 +96,7 @@ func main() {
 	flag.StringVar(&settings.HTTPUserAgent, "httpuseragent", "", "sets the HTTP user agent")
 	flag.StringVar(&settings.HTTPProxy, "httpproxy", "", "sets the HTTP proxy server")
 	flag.BoolVar(&settings.EnableExchangeHTTPDebugging, "exchangehttpdebugging", false, "sets the exchanges HTTP debugging")
+	flag.BoolVar(&settings.EnableExchangeHTTPCapture, "exchangehttpcapture", false, "captures the redacted HTTP requests and responses of failed exchange calls for diagnostics bundles")
 
 	// Common tuning settings
 	flag.DurationVar(&settings.GlobalHTTPTimeout, "globalhttptimeout", time.Duration(0), "sets common HTTP timeout value for HTTP requests")
@@ -97,7 +109,23 @@ func main() {
 	// Withdraw Cache tuning settings
 	flag.Uint64Var(&settings.WithdrawCacheSize, "withdrawcachesize", withdraw.CacheSize, "set cache size for withdrawal requests")
 
+	// History download settings
+	var download engine.HistoryDownloadSettings
+	flag.StringVar(&download.Exchange, "downloadexchange", "", "the exchange to download history from")
+	flag.StringVar(&download.Pair, "downloadpair", "", "the currency pair to download history of")
+	flag.StringVar(&download.Asset, "downloadasset", "spot", "the asset type of the currency pair")
+	flag.StringVar(&download.DataType, "downloaddatatype", engine.HistoryCandles, "the history to download, candles or trades (trades are limited to those recent enough to be returned by the exchange)")
+	flag.DurationVar(&download.Interval, "downloadinterval", engine.DefaultHistoryInterval, "the candle interval")
+	flag.StringVar(&download.Start, "downloadstart", "", "the date or RFC3339 time to download history from")
+	flag.StringVar(&download.End, "downloadend", "", "the date or RFC3339 time to download history to, defaults to now")
+	flag.StringVar(&download.Output, "downloadoutput", "", "the CSV file to write history to, candles are stored in the database when empty")
+	flag.IntVar(&download.BatchSize, "downloadbatchsize", engine.DefaultHistoryBatchSize, "the amount of candles requested per request")
+	flag.DurationVar(&download.Delay, "downloaddelay", engine.DefaultHistoryDelay, "the pause between requests")
+
 	flag.Parse()
+	if err := settings.EnableConfigManagers(*disableFlag); err != nil {
+		log.Fatal(err)
+	}
 
 	if *versionFlag {
 		fmt.Print(core.Version(true))
@@ -114,6 +142,22 @@ func main() {
 		log.Fatalf("Unable to initialise bot engine. Error: %s\n", err)
 	}
 
+	if *checkFlag {
+		if err = engine.Bot.CheckConnections(os.Stdout); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if *downloadFlag {
+		if err = engine.Bot.DownloadHistory(os.Stdout, &download); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	gctscript.Setup()
 
 	engine.PrintSettings(&engine.Bot.Settings)
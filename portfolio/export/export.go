@@ -0,0 +1,146 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	koinlyTimeFormat       = "2006-01-02 15:04:05 UTC"
+	coinTrackingTimeFormat = "2006-01-02 15:04:05"
+)
+
+var (
+	errFormatUnsupported = errors.New("export format is not supported")
+
+	koinlyHeader = []string{
+		"Date",
+		"Sent Amount",
+		"Sent Currency",
+		"Received Amount",
+		"Received Currency",
+		"Fee Amount",
+		"Fee Currency",
+		"Net Worth Amount",
+		"Net Worth Currency",
+		"Label",
+		"Description",
+		"TxHash",
+	}
+
+	coinTrackingHeader = []string{
+		"Type",
+		"Buy Amount",
+		"Buy Currency",
+		"Sell Amount",
+		"Sell Currency",
+		"Fee",
+		"Fee Currency",
+		"Exchange",
+		"Trade-Group",
+		"Comment",
+		"Date",
+		"Tx-ID",
+	}
+)
+
+// Formats returns all supported formats
+func Formats() []Format {
+	return []Format{Koinly, CoinTracking}
+}
+
+// ParseFormat returns the format matching the case insensitive name
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(name))
+	switch f {
+	case Koinly, CoinTracking:
+		return f, nil
+	}
+	return "", errFormatUnsupported
+}
+
+// Write writes the records as CSV in the import schema of the format
+func Write(w io.Writer, f Format, records []Record) error {
+	var header []string
+	var row func(r *Record) []string
+	switch f {
+	case Koinly:
+		header, row = koinlyHeader, koinlyRow
+	case CoinTracking:
+		header, row = coinTrackingHeader, coinTrackingRow
+	default:
+		return errFormatUnsupported
+	}
+
+	c := csv.NewWriter(w)
+	if err := c.Write(header); err != nil {
+		return err
+	}
+	for i := range records {
+		if err := c.Write(row(&records[i])); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func koinlyRow(r *Record) []string {
+	description := r.Exchange
+	if r.ID != "" {
+		description += " " + string(r.Type) + " " + r.ID
+	}
+	if r.Description != "" {
+		description += " " + r.Description
+	}
+	return []string{
+		r.Time.UTC().Format(koinlyTimeFormat),
+		formatAmount(r.SellAmount),
+		formatCode(r.SellAmount, r.SellCurrency.Upper().String()),
+		formatAmount(r.BuyAmount),
+		formatCode(r.BuyAmount, r.BuyCurrency.Upper().String()),
+		formatAmount(r.FeeAmount),
+		formatCode(r.FeeAmount, r.FeeCurrency.Upper().String()),
+		"",
+		"",
+		"",
+		description,
+		r.TxID,
+	}
+}
+
+func coinTrackingRow(r *Record) []string {
+	return []string{
+		string(r.Type),
+		formatAmount(r.BuyAmount),
+		formatCode(r.BuyAmount, r.BuyCurrency.Upper().String()),
+		formatAmount(r.SellAmount),
+		formatCode(r.SellAmount, r.SellCurrency.Upper().String()),
+		formatAmount(r.FeeAmount),
+		formatCode(r.FeeAmount, r.FeeCurrency.Upper().String()),
+		r.Exchange,
+		"",
+		strings.TrimSpace(r.ID + " " + r.Description),
+		r.Time.UTC().Format(coinTrackingTimeFormat),
+		r.TxID,
+	}
+}
+
+// formatAmount leaves zero amounts empty as the trackers treat an empty
+// column as an absent leg
+func formatAmount(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatCode(amount float64, code string) string {
+	if amount == 0 {
+		return ""
+	}
+	return code
+}
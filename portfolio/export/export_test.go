@@ -0,0 +1,76 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func testRecords() []Record {
+	tm := time.Date(2020, 5, 1, 12, 30, 0, 0, time.UTC)
+	return []Record{
+		{
+			Type:         Trade,
+			Time:         tm,
+			Exchange:     "Bitstamp",
+			BuyAmount:    0.5,
+			BuyCurrency:  currency.BTC,
+			SellAmount:   4500,
+			SellCurrency: currency.USD,
+			FeeAmount:    1.25,
+			FeeCurrency:  currency.USD,
+			ID:           "1337",
+		},
+		{
+			Type:         Withdrawal,
+			Time:         tm.Add(time.Hour),
+			Exchange:     "Bitstamp",
+			SellAmount:   0.4,
+			SellCurrency: currency.BTC,
+			ID:           "42",
+			TxID:         "0xdeadbeef",
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("KOINLY")
+	if err != nil || f != Koinly {
+		t.Errorf("expected %v, got %v %v", Koinly, f, err)
+	}
+	if _, err = ParseFormat("nope"); err != errFormatUnsupported {
+		t.Errorf("expected %v, got %v", errFormatUnsupported, err)
+	}
+}
+
+func TestWriteKoinly(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Koinly, testRecords()); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash\n" +
+		"2020-05-01 12:30:00 UTC,4500,USD,0.5,BTC,1.25,USD,,,,Bitstamp Trade 1337,\n" +
+		"2020-05-01 13:30:00 UTC,0.4,BTC,,,,,,,,Bitstamp Withdrawal 42,0xdeadbeef\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteCoinTracking(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CoinTracking, testRecords()); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Type,Buy Amount,Buy Currency,Sell Amount,Sell Currency,Fee,Fee Currency,Exchange,Trade-Group,Comment,Date,Tx-ID\n" +
+		"Trade,0.5,BTC,4500,USD,1.25,USD,Bitstamp,,1337,2020-05-01 12:30:00,\n" +
+		"Withdrawal,,,0.4,BTC,,,Bitstamp,,42,2020-05-01 13:30:00,0xdeadbeef\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	if err := Write(&buf, "nope", nil); err != errFormatUnsupported {
+		t.Errorf("expected %v, got %v", errFormatUnsupported, err)
+	}
+}
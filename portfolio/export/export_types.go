@@ -0,0 +1,46 @@
+package export
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// Format is a portfolio tracker import schema
+type Format string
+
+// All supported formats
+const (
+	// Koinly is Koinly's universal CSV import format
+	Koinly Format = "koinly"
+	// CoinTracking is CoinTracking's custom CSV import format
+	CoinTracking Format = "cointracking"
+)
+
+// RecordType is the kind of activity a record represents
+type RecordType string
+
+// All record types
+const (
+	Trade      RecordType = "Trade"
+	Deposit    RecordType = "Deposit"
+	Withdrawal RecordType = "Withdrawal"
+)
+
+// Record is a fill or transfer in a format independent of any portfolio
+// tracker. Trades set both the buy and sell legs, deposits only the buy leg
+// and withdrawals only the sell leg
+type Record struct {
+	Type         RecordType
+	Time         time.Time
+	Exchange     string
+	BuyAmount    float64
+	BuyCurrency  currency.Code
+	SellAmount   float64
+	SellCurrency currency.Code
+	FeeAmount    float64
+	FeeCurrency  currency.Code
+	ID           string
+	TxID         string
+	Description  string
+}
@@ -0,0 +1,91 @@
+package performance
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// MonteCarlo bootstraps the trades into simulated sequences and returns the
+// distribution of their final equity and max drawdown, showing how much a
+// strategy's result depends on the order and selection of its trades
+func MonteCarlo(trades []Trade, s *MonteCarloSettings) (MonteCarloResult, error) {
+	if len(trades) == 0 {
+		return MonteCarloResult{}, errNoTrades
+	}
+	simulations := s.Simulations
+	if simulations <= 0 {
+		simulations = DefaultSimulations
+	}
+	confidence := s.Confidence
+	if confidence == 0 {
+		confidence = DefaultConfidence
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return MonteCarloResult{}, errConfidenceInvalid
+	}
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed)) // nolint:gosec // resampling does not need a secure source
+
+	finals := make([]float64, simulations)
+	drawdowns := make([]float64, simulations)
+	var losses int
+	for i := 0; i < simulations; i++ {
+		equity := s.StartingEquity
+		peak := equity
+		var drawdown float64
+		for range trades {
+			equity += trades[r.Intn(len(trades))].PNL
+			if equity > peak {
+				peak = equity
+			} else if d := peak - equity; d > drawdown {
+				drawdown = d
+			}
+		}
+		finals[i] = equity
+		drawdowns[i] = drawdown
+		if equity < s.StartingEquity {
+			losses++
+		}
+	}
+
+	return MonteCarloResult{
+		Simulations:       simulations,
+		Trades:            len(trades),
+		Confidence:        confidence,
+		Seed:              seed,
+		FinalEquity:       distribution(finals, confidence),
+		MaxDrawdown:       distribution(drawdowns, confidence),
+		ProbabilityOfLoss: float64(losses) / float64(simulations) * 100,
+	}, nil
+}
+
+// distribution returns the mean, median and the percentiles bounding the
+// confidence interval of the values
+func distribution(values []float64, confidence float64) Distribution {
+	sort.Float64s(values)
+	tail := (1 - confidence) / 2
+	return Distribution{
+		Lower:  percentile(values, tail),
+		Median: percentile(values, 0.5),
+		Upper:  percentile(values, 1-tail),
+		Mean:   mean(values),
+	}
+}
+
+// percentile linearly interpolates the value at a rank of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
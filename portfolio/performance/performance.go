@@ -0,0 +1,254 @@
+package performance
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calculate returns the performance of a strategy over the input's period.
+// Trades are counted when they were closed at or after the start and before
+// the end of the period
+func Calculate(in *Input) Report {
+	r := Report{
+		Strategy:     in.Strategy,
+		Currency:     in.Currency,
+		Start:        in.Start,
+		End:          in.End,
+		SharpeRatio:  SharpeRatio(in.Equity, in.Capital),
+		SortinoRatio: SortinoRatio(in.Equity, in.Capital),
+		MaxDrawdown:  MaxDrawdown(in.Equity),
+	}
+
+	var won, lost float64
+	intervals := make([]interval, 0, len(in.Trades)+len(in.Open))
+	for i := range in.Trades {
+		t := &in.Trades[i]
+		intervals = append(intervals, interval{t.Opened, t.Closed})
+		if t.Closed.Before(in.Start) || !t.Closed.Before(in.End) {
+			continue
+		}
+		r.Trades++
+		r.RealisedPNL += t.PNL
+		switch {
+		case t.PNL > 0:
+			r.Wins++
+			won += t.PNL
+		case t.PNL < 0:
+			r.Losses++
+			lost += t.PNL
+		}
+	}
+	if r.Trades > 0 {
+		r.WinRate = float64(r.Wins) / float64(r.Trades) * 100
+	}
+	if r.Wins > 0 {
+		r.AverageWin = won / float64(r.Wins)
+	}
+	if r.Losses > 0 {
+		r.AverageLoss = lost / float64(r.Losses)
+	}
+	for i := range in.Open {
+		intervals = append(intervals, interval{in.Open[i], in.End})
+	}
+	r.ExposureTime = exposure(intervals, in.Start, in.End)
+	return r
+}
+
+// SharpeRatio returns the annualised mean return divided by its standard
+// deviation, zero when there are fewer than three points or the returns did
+// not vary. Returns are measured on the capital plus the equity
+func SharpeRatio(points []EquityPoint, capital float64) float64 {
+	returns, periods := equityReturns(points, capital)
+	if len(returns) < 2 {
+		return 0
+	}
+	avg := mean(returns)
+	var variance float64
+	for i := range returns {
+		variance += (returns[i] - avg) * (returns[i] - avg)
+	}
+	deviation := math.Sqrt(variance / float64(len(returns)-1))
+	if deviation == 0 {
+		return 0
+	}
+	return avg / deviation * math.Sqrt(periods)
+}
+
+// SortinoRatio returns the annualised mean return divided by the deviation of
+// the negative returns, zero when there are fewer than three points or the
+// equity never decreased. Returns are measured on the capital plus the equity
+func SortinoRatio(points []EquityPoint, capital float64) float64 {
+	returns, periods := equityReturns(points, capital)
+	if len(returns) < 2 {
+		return 0
+	}
+	var downside float64
+	for i := range returns {
+		if returns[i] < 0 {
+			downside += returns[i] * returns[i]
+		}
+	}
+	deviation := math.Sqrt(downside / float64(len(returns)))
+	if deviation == 0 {
+		return 0
+	}
+	return mean(returns) / deviation * math.Sqrt(periods)
+}
+
+// MaxDrawdown returns the largest decline in equity from a peak to a
+// following trough
+func MaxDrawdown(points []EquityPoint) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	peak := points[0].Equity
+	var drawdown float64
+	for i := range points {
+		if points[i].Equity > peak {
+			peak = points[i].Equity
+			continue
+		}
+		if d := peak - points[i].Equity; d > drawdown {
+			drawdown = d
+		}
+	}
+	return drawdown
+}
+
+// equityReturns returns the return between consecutive points on the capital
+// plus the equity at the first of them and the amount of their average
+// interval in a year. No returns are returned once the capital plus equity is
+// not positive as they are undefined
+func equityReturns(points []EquityPoint, capital float64) (returns []float64, periods float64) {
+	if len(points) < 2 {
+		return nil, 0
+	}
+	returns = make([]float64, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		base := capital + points[i-1].Equity
+		if base <= 0 {
+			return nil, 0
+		}
+		returns[i-1] = (points[i].Equity - points[i-1].Equity) / base
+	}
+	elapsed := points[len(points)-1].Time.Sub(points[0].Time)
+	if elapsed <= 0 {
+		return returns, 1
+	}
+	return returns, float64(year) / (float64(elapsed) / float64(len(returns)))
+}
+
+func mean(v []float64) float64 {
+	var sum float64
+	for i := range v {
+		sum += v[i]
+	}
+	return sum / float64(len(v))
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// exposure returns the percentage of the period from start to end covered by
+// the union of the intervals
+func exposure(intervals []interval, start, end time.Time) float64 {
+	period := end.Sub(start)
+	if period <= 0 {
+		return 0
+	}
+	clipped := intervals[:0]
+	for i := range intervals {
+		s, e := intervals[i].start, intervals[i].end
+		if s.Before(start) {
+			s = start
+		}
+		if e.After(end) {
+			e = end
+		}
+		if e.After(s) {
+			clipped = append(clipped, interval{s, e})
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool {
+		return clipped[i].start.Before(clipped[j].start)
+	})
+
+	var covered time.Duration
+	var last time.Time
+	for i := range clipped {
+		s := clipped[i].start
+		if s.Before(last) {
+			s = last
+		}
+		if clipped[i].end.After(s) {
+			covered += clipped[i].end.Sub(s)
+			last = clipped[i].end
+		}
+	}
+	return float64(covered) / float64(period) * 100
+}
+
+// Write writes the reports in the json or csv format
+func Write(w io.Writer, format string, reports []Report) error {
+	switch strings.ToLower(format) {
+	case JSON:
+		return WriteJSON(w, reports)
+	case CSV:
+		return WriteCSV(w, reports)
+	}
+	return errFormatUnsupported
+}
+
+// WriteJSON writes the reports as an indented JSON array
+func WriteJSON(w io.Writer, reports []Report) error {
+	if reports == nil {
+		reports = []Report{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(reports)
+}
+
+// WriteCSV writes the reports as CSV, one row per strategy
+func WriteCSV(w io.Writer, reports []Report) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := range reports {
+		r := &reports[i]
+		err := c.Write([]string{
+			r.Strategy,
+			r.Currency,
+			r.Start.UTC().Format(time.RFC3339),
+			r.End.UTC().Format(time.RFC3339),
+			formatFloat(r.RealisedPNL),
+			formatFloat(r.SharpeRatio),
+			formatFloat(r.SortinoRatio),
+			formatFloat(r.MaxDrawdown),
+			strconv.Itoa(r.Trades),
+			strconv.Itoa(r.Wins),
+			strconv.Itoa(r.Losses),
+			formatFloat(r.WinRate),
+			formatFloat(r.AverageWin),
+			formatFloat(r.AverageLoss),
+			formatFloat(r.ExposureTime),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,194 @@
+package performance
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func curve(equities ...float64) []EquityPoint {
+	points := make([]EquityPoint, len(equities))
+	for i := range equities {
+		points[i] = EquityPoint{Time: start.Add(time.Hour * 24 * time.Duration(i)), Equity: equities[i]}
+	}
+	return points
+}
+
+func TestSharpeRatio(t *testing.T) {
+	if r := SharpeRatio(curve(1, 2), 100); r != 0 {
+		t.Errorf("expected 0 for a single change, received %v", r)
+	}
+	if r := SharpeRatio(curve(100, 110, 121), 0); r != 0 {
+		t.Errorf("expected 0 for constant returns, received %v", r)
+	}
+	if r := SharpeRatio(curve(0, 2, 2), 0); r != 0 {
+		t.Errorf("expected 0 without capital, received %v", r)
+	}
+	// returns of 10% and 0% on 100 capital have a mean of 5% and a deviation
+	// of 5% * sqrt(2)
+	expected := 1 / math.Sqrt2 * math.Sqrt(365)
+	if r := SharpeRatio(curve(0, 10, 10), 100); math.Abs(r-expected) > 1e-9 {
+		t.Errorf("expected %v, received %v", expected, r)
+	}
+}
+
+func TestSortinoRatio(t *testing.T) {
+	if r := SortinoRatio(curve(0, 1, 2), 100); r != 0 {
+		t.Errorf("expected 0 without decreases, received %v", r)
+	}
+	// returns of 30% and -20% have a mean of 5% and a downside deviation of
+	// sqrt(0.02)
+	expected := 0.05 / math.Sqrt(0.02) * math.Sqrt(365)
+	if r := SortinoRatio(curve(0, 30, 4), 100); math.Abs(r-expected) > 1e-9 {
+		t.Errorf("expected %v, received %v", expected, r)
+	}
+	if r := SortinoRatio(curve(0, -100, -90), 100); r != 0 {
+		t.Errorf("expected 0 once the capital is lost, received %v", r)
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	if d := MaxDrawdown(nil); d != 0 {
+		t.Errorf("expected 0, received %v", d)
+	}
+	if d := MaxDrawdown(curve(0, -5, 10, 4, 8, 1)); d != 9 {
+		t.Errorf("expected 9, received %v", d)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	end := start.Add(time.Hour * 10)
+	r := Calculate(&Input{
+		Strategy: "dca",
+		Currency: "USD",
+		Start:    start,
+		End:      end,
+		Capital:  100,
+		Equity:   curve(0, 2, 2),
+		Trades: []Trade{
+			// opened before the period, only the hour within is exposed
+			{Opened: start.Add(-time.Hour), Closed: start.Add(time.Hour), PNL: 4},
+			{Opened: start.Add(time.Hour * 2), Closed: start.Add(time.Hour * 4), PNL: -1},
+			// overlaps the previous trade by an hour
+			{Opened: start.Add(time.Hour * 3), Closed: start.Add(time.Hour * 5), PNL: 2},
+			{Opened: start.Add(time.Hour * 5), Closed: start.Add(time.Hour * 5), PNL: 0},
+			// closed at the end and after the period
+			{Opened: start.Add(time.Hour * 9), Closed: end, PNL: 7},
+			{Opened: start.Add(time.Hour * 9), Closed: end.Add(time.Hour), PNL: 10},
+		},
+		Open: []time.Time{start.Add(time.Hour * 8)},
+	})
+	if r.Trades != 4 || r.Wins != 2 || r.Losses != 1 || r.RealisedPNL != 5 {
+		t.Errorf("unexpected trade counts %+v", r)
+	}
+	if r.WinRate != 50 || r.AverageWin != 3 || r.AverageLoss != -1 {
+		t.Errorf("unexpected trade statistics %+v", r)
+	}
+	// 1 hour, 3 hours from 2 to 5 and 2 hours from 8 to 10
+	if r.ExposureTime != 60 {
+		t.Errorf("expected 60%% exposure, received %v", r.ExposureTime)
+	}
+	if r.SharpeRatio == 0 || r.MaxDrawdown != 0 {
+		t.Errorf("unexpected equity statistics %+v", r)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	reports := []Report{{Strategy: "dca", Currency: "USD", Start: start, End: start.Add(time.Hour), WinRate: 62.5, Trades: 8}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "JSON", reports); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || decoded[0].WinRate != 62.5 {
+		t.Errorf("unexpected decoded reports %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, CSV, reports); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != strings.Join(csvHeader, ",") {
+		t.Fatalf("unexpected CSV %s", buf.String())
+	}
+	if lines[1] != "dca,USD,2020-01-01T00:00:00Z,2020-01-01T01:00:00Z,0,0,0,0,8,0,0,62.5,0,0,0" {
+		t.Errorf("unexpected row %s", lines[1])
+	}
+
+	buf.Reset()
+	if err := Write(&buf, JSON, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected an empty array, received %s %v", buf.String(), err)
+	}
+	if err := Write(&buf, "xml", reports); err != errFormatUnsupported {
+		t.Errorf("expected %v, received %v", errFormatUnsupported, err)
+	}
+}
+
+func TestMonteCarlo(t *testing.T) {
+	if _, err := MonteCarlo(nil, &MonteCarloSettings{}); err != errNoTrades {
+		t.Errorf("expected %v, received %v", errNoTrades, err)
+	}
+	trades := []Trade{{PNL: 10}, {PNL: -5}, {PNL: 3}}
+	if _, err := MonteCarlo(trades, &MonteCarloSettings{Confidence: 1}); err != errConfidenceInvalid {
+		t.Errorf("expected %v, received %v", errConfidenceInvalid, err)
+	}
+
+	s := &MonteCarloSettings{Simulations: 500, StartingEquity: 100, Seed: 1}
+	r, err := MonteCarlo(trades, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Simulations != 500 || r.Trades != 3 || r.Confidence != DefaultConfidence || r.Seed != 1 {
+		t.Errorf("unexpected result %+v", r)
+	}
+	// three trades can finish between three losses and three wins
+	if r.FinalEquity.Lower < 85 || r.FinalEquity.Upper > 130 ||
+		r.FinalEquity.Lower > r.FinalEquity.Median || r.FinalEquity.Median > r.FinalEquity.Upper {
+		t.Errorf("unexpected final equity %+v", r.FinalEquity)
+	}
+	if r.MaxDrawdown.Lower < 0 || r.MaxDrawdown.Upper > 15 {
+		t.Errorf("unexpected max drawdown %+v", r.MaxDrawdown)
+	}
+	if r.ProbabilityOfLoss <= 0 || r.ProbabilityOfLoss >= 100 {
+		t.Errorf("unexpected probability of loss %v", r.ProbabilityOfLoss)
+	}
+
+	again, err := MonteCarlo(trades, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != r {
+		t.Error("expected the same seed to reproduce the simulations")
+	}
+
+	r, err = MonteCarlo([]Trade{{PNL: 2}}, &MonteCarloSettings{Simulations: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.FinalEquity.Lower != 2 || r.FinalEquity.Upper != 2 || r.MaxDrawdown.Mean != 0 || r.ProbabilityOfLoss != 0 {
+		t.Errorf("unexpected result of a single trade %+v", r)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	v := []float64{1, 2, 3, 4, 5}
+	if p := percentile(v, 0.5); p != 3 {
+		t.Errorf("expected 3, received %v", p)
+	}
+	if p := percentile(v, 0.125); p != 1.5 {
+		t.Errorf("expected 1.5, received %v", p)
+	}
+	if p := percentile(v, 1); p != 5 {
+		t.Errorf("expected 5, received %v", p)
+	}
+}
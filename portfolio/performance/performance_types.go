@@ -0,0 +1,141 @@
+package performance
+
+import (
+	"errors"
+	"time"
+)
+
+// Supported export formats
+const (
+	JSON = "json"
+	CSV  = "csv"
+)
+
+// year annualises ratios calculated from equity sampled at any interval
+const year = time.Hour * 24 * 365
+
+// Monte Carlo defaults
+const (
+	DefaultSimulations = 1000
+	DefaultConfidence  = 0.95
+)
+
+var (
+	errFormatUnsupported = errors.New("performance export format must be json or csv")
+	errNoTrades          = errors.New("no trades to resample")
+	errConfidenceInvalid = errors.New("confidence must be greater than zero and less than one")
+)
+
+// csvHeader is the header of CSV exports
+var csvHeader = []string{
+	"strategy",
+	"currency",
+	"start",
+	"end",
+	"realised_pnl",
+	"sharpe_ratio",
+	"sortino_ratio",
+	"max_drawdown",
+	"trades",
+	"wins",
+	"losses",
+	"win_rate",
+	"average_win",
+	"average_loss",
+	"exposure_time",
+}
+
+// EquityPoint is the equity of a strategy at a point in time
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Trade is a round trip of a strategy's position from being opened until it
+// was closed or reversed, with the profit or loss realised over it
+type Trade struct {
+	Opened time.Time
+	Closed time.Time
+	PNL    float64
+}
+
+// Input is a strategy's equity curve, closed trades and the times its open
+// positions were opened, measured over the period from Start to End. Trades
+// closed at or after Start and before End are counted, so a trade closed at
+// the end of one period is counted in the next
+type Input struct {
+	Strategy string
+	Currency string
+	Start    time.Time
+	End      time.Time
+	// Capital is the amount the strategy trades with, which is added to the
+	// equity to measure returns when the equity curve is the strategy's
+	// profit and loss starting from zero
+	Capital float64
+	Equity  []EquityPoint
+	Trades  []Trade
+	Open    []time.Time
+}
+
+// Report is the performance of a strategy over a period. The Sharpe and
+// Sortino ratios are annualised from the returns between consecutive equity
+// points on the capital plus equity, and are zero when the capital plus
+// equity is not positive. Max drawdown is in the strategy's currency and
+// percentages are 0 to 100
+type Report struct {
+	Strategy     string    `json:"strategy"`
+	Currency     string    `json:"currency"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	RealisedPNL  float64   `json:"realised_pnl"`
+	SharpeRatio  float64   `json:"sharpe_ratio"`
+	SortinoRatio float64   `json:"sortino_ratio"`
+	MaxDrawdown  float64   `json:"max_drawdown"`
+	Trades       int       `json:"trades"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+	WinRate      float64   `json:"win_rate"`
+	AverageWin   float64   `json:"average_win"`
+	AverageLoss  float64   `json:"average_loss"`
+	// ExposureTime is the percentage of the period a position was open
+	ExposureTime float64 `json:"exposure_time"`
+}
+
+// MonteCarloSettings defines how trades are resampled. Each simulation draws
+// as many trades as were made with replacement and applies their profit and
+// loss in the order drawn to the starting equity
+type MonteCarloSettings struct {
+	// Simulations defaults to DefaultSimulations
+	Simulations int
+	// Confidence is the share of simulations the interval bounds hold, such
+	// as 0.95 for the 2.5th to 97.5th percentiles, defaulting to
+	// DefaultConfidence
+	Confidence     float64
+	StartingEquity float64
+	// Seed seeds the random source so simulations can be reproduced, a
+	// seed is chosen when zero
+	Seed int64
+}
+
+// Distribution is the spread of a value across simulations. Lower and Upper
+// bound the confidence interval
+type Distribution struct {
+	Lower  float64 `json:"lower"`
+	Median float64 `json:"median"`
+	Upper  float64 `json:"upper"`
+	Mean   float64 `json:"mean"`
+}
+
+// MonteCarloResult is the distribution of the final equity and max drawdown
+// of resampled trade sequences
+type MonteCarloResult struct {
+	Simulations int          `json:"simulations"`
+	Trades      int          `json:"trades"`
+	Confidence  float64      `json:"confidence"`
+	Seed        int64        `json:"seed"`
+	FinalEquity Distribution `json:"final_equity"`
+	MaxDrawdown Distribution `json:"max_drawdown"`
+	// ProbabilityOfLoss is the percentage of simulations which finished
+	// below the starting equity
+	ProbabilityOfLoss float64 `json:"probability_of_loss"`
+}
@@ -163,6 +163,43 @@ func (p *Base) UpdateExchangeAddressBalance(exchangeName string, coinType curren
 	}
 }
 
+// SetPendingFiat replaces the pending fiat transfers for an exchange
+func (p *Base) SetPendingFiat(exchangeName string, pending []PendingFiat) {
+	target := p.PendingFiat[:0]
+	for x := range p.PendingFiat {
+		if p.PendingFiat[x].Exchange != exchangeName {
+			target = append(target, p.PendingFiat[x])
+		}
+	}
+	p.PendingFiat = append(target, pending...)
+}
+
+// GetPendingFiat returns the total of unsettled fiat deposits and withdrawals
+// for an exchange and currency
+func (p *Base) GetPendingFiat(exchangeName string, coinType currency.Code) (deposits, withdrawals float64) {
+	for x := range p.PendingFiat {
+		if p.PendingFiat[x].Exchange != exchangeName ||
+			!p.PendingFiat[x].Currency.Match(coinType) {
+			continue
+		}
+		if p.PendingFiat[x].Deposit {
+			deposits += p.PendingFiat[x].Amount
+		} else {
+			withdrawals += p.PendingFiat[x].Amount
+		}
+	}
+	return
+}
+
+// GetBuyingPower returns the exchange balance of a currency including any
+// fiat deposits which are still in flight. Pending withdrawals are not
+// subtracted as exchanges deduct them from the balance when requested
+func (p *Base) GetBuyingPower(exchangeName string, coinType currency.Code) float64 {
+	balance, _ := p.GetAddressBalance(exchangeName, PortfolioAddressExchange, coinType)
+	deposits, _ := p.GetPendingFiat(exchangeName, coinType)
+	return balance + deposits
+}
+
 // AddAddress adds an address to the portfolio base
 func (p *Base) AddAddress(address, description string, coinType currency.Code, balance float64) error {
 	if address == "" {
@@ -425,9 +462,40 @@ func (p *Base) GetPortfolioSummary() Summary {
 		}
 	}
 	portfolioOutput.OfflineSummary = offlineSummary
+	portfolioOutput.PendingFiat = append([]PendingFiat(nil), p.PendingFiat...)
 	return portfolioOutput
 }
 
+// Value values every coin of the summary in the base currency. Coins which
+// cannot be converted are listed as unvalued and excluded from the total value
+func (s *Summary) Value(base currency.Code, convert Converter) {
+	s.BaseCurrency = base
+	s.TotalValue = 0
+	s.Unvalued = nil
+	values := make(map[currency.Code]float64)
+	value := func(coins []Coin) {
+		for x := range coins {
+			v, ok := values[coins[x].Coin]
+			if !ok {
+				if v, ok = convert(1, coins[x].Coin, base); !ok {
+					if !s.Unvalued.Contains(coins[x].Coin) {
+						s.Unvalued = append(s.Unvalued, coins[x].Coin)
+					}
+					continue
+				}
+				values[coins[x].Coin] = v
+			}
+			coins[x].Value = coins[x].Balance * v
+		}
+	}
+	value(s.Totals)
+	value(s.Offline)
+	value(s.Online)
+	for x := range s.Totals {
+		s.TotalValue += s.Totals[x].Value
+	}
+}
+
 // GetPortfolioGroupedCoin returns portfolio base information grouped by coin
 func (p *Base) GetPortfolioGroupedCoin() map[currency.Code][]string {
 	result := make(map[currency.Code][]string)
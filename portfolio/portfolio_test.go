@@ -143,6 +143,41 @@ func TestAddExchangeAddress(t *testing.T) {
 	}
 }
 
+func TestPendingFiat(t *testing.T) {
+	newbase := Base{}
+	newbase.AddExchangeAddress("Kraken", currency.USD, 100)
+	newbase.SetPendingFiat("Kraken", []PendingFiat{
+		{Exchange: "Kraken", ID: "1", Currency: currency.USD, Amount: 50, Deposit: true},
+		{Exchange: "Kraken", ID: "2", Currency: currency.USD, Amount: 25},
+		{Exchange: "Kraken", ID: "3", Currency: currency.EUR, Amount: 10, Deposit: true},
+	})
+	newbase.SetPendingFiat("Bitstamp", []PendingFiat{
+		{Exchange: "Bitstamp", ID: "4", Currency: currency.USD, Amount: 1000, Deposit: true},
+	})
+
+	deposits, withdrawals := newbase.GetPendingFiat("Kraken", currency.USD)
+	if deposits != 50 || withdrawals != 25 {
+		t.Errorf("unexpected pending fiat deposits %v withdrawals %v", deposits, withdrawals)
+	}
+
+	if b := newbase.GetBuyingPower("Kraken", currency.USD); b != 150 {
+		t.Errorf("expected buying power of 150, got %v", b)
+	}
+
+	if b := newbase.GetBuyingPower("Bitstamp", currency.USD); b != 1000 {
+		t.Errorf("expected buying power of 1000, got %v", b)
+	}
+
+	newbase.SetPendingFiat("Kraken", nil)
+	if len(newbase.PendingFiat) != 1 || newbase.PendingFiat[0].Exchange != "Bitstamp" {
+		t.Error("expected only Kraken pending fiat to be replaced")
+	}
+
+	if len(newbase.GetPortfolioSummary().PendingFiat) != 1 {
+		t.Error("expected pending fiat in portfolio summary")
+	}
+}
+
 func TestUpdateAddressBalance(t *testing.T) {
 	newbase := Base{}
 	newbase.AddAddress("someaddress",
@@ -454,6 +489,43 @@ func TestGetPortfolioSummary(t *testing.T) {
 	}
 }
 
+func TestSummaryValue(t *testing.T) {
+	t.Parallel()
+	var b Base
+	b.AddExchangeAddress("Bitfinex", currency.LTC, 2)
+	b.AddExchangeAddress("Bitfinex", currency.USD, 100)
+	b.AddExchangeAddress("Bitfinex", currency.XRP, 5)
+	if err := b.AddAddress("someaddress", "desc", currency.LTC, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	rates := map[currency.Code]float64{currency.LTC: 50, currency.USD: 1}
+	s := b.GetPortfolioSummary()
+	s.Value(currency.USD, func(amount float64, from, _ currency.Code) (float64, bool) {
+		rate, ok := rates[from]
+		return amount * rate, ok
+	})
+	if s.BaseCurrency != currency.USD {
+		t.Errorf("expected base currency USD, got %s", s.BaseCurrency)
+	}
+	if s.TotalValue != 250 {
+		t.Errorf("expected total value 250, got %v", s.TotalValue)
+	}
+	if len(s.Unvalued) != 1 || s.Unvalued[0] != currency.XRP {
+		t.Errorf("expected XRP to be unvalued, got %v", s.Unvalued)
+	}
+	for x := range s.Online {
+		if s.Online[x].Coin == currency.LTC && s.Online[x].Value != 100 {
+			t.Errorf("expected online LTC value 100, got %v", s.Online[x].Value)
+		}
+	}
+	for x := range s.Offline {
+		if s.Offline[x].Coin == currency.LTC && s.Offline[x].Value != 50 {
+			t.Errorf("expected offline LTC value 50, got %v", s.Offline[x].Value)
+		}
+	}
+}
+
 func TestGetPortfolioGroupedCoin(t *testing.T) {
 	newbase := Base{}
 	newbase.AddAddress("someaddress", currency.LTC.String(), currency.LTC, 0.02)
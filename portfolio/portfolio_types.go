@@ -8,7 +8,8 @@ import (
 
 // Base holds the portfolio base addresses
 type Base struct {
-	Addresses []Address `json:"addresses"`
+	Addresses   []Address     `json:"addresses"`
+	PendingFiat []PendingFiat `json:"-"`
 }
 
 // Address sub type holding address information for portfolio
@@ -22,6 +23,17 @@ type Address struct {
 	SupportedExchanges string
 }
 
+// PendingFiat holds an unsettled fiat deposit or withdrawal on an exchange
+type PendingFiat struct {
+	Exchange  string
+	ID        string
+	Currency  currency.Code
+	Amount    float64
+	Deposit   bool
+	Status    string
+	Timestamp time.Time
+}
+
 // EtherchainBalanceResponse holds JSON incoming and outgoing data for
 // Etherchain
 type EtherchainBalanceResponse struct {
@@ -90,6 +102,7 @@ type ExchangeAccountCurrencyInfo struct {
 	CurrencyName string
 	TotalValue   float64
 	Hold         float64
+	Available    float64
 }
 
 // Coin stores a coin type, balance, address and percentage relative to the total
@@ -99,6 +112,7 @@ type Coin struct {
 	Balance    float64       `json:"balance"`
 	Address    string        `json:"address,omitempty"`
 	Percentage float64       `json:"percentage,omitempty"`
+	Value      float64       `json:"value,omitempty"`
 }
 
 // OfflineCoinSummary stores a coin types address, balance and percentage
@@ -123,8 +137,16 @@ type Summary struct {
 	OfflineSummary map[currency.Code][]OfflineCoinSummary         `json:"offline_summary"`
 	Online         []Coin                                         `json:"coins_online"`
 	OnlineSummary  map[string]map[currency.Code]OnlineCoinSummary `json:"online_summary"`
+	PendingFiat    []PendingFiat                                  `json:"pending_fiat,omitempty"`
+	BaseCurrency   currency.Code                                  `json:"base_currency,omitempty"`
+	TotalValue     float64                                        `json:"total_value,omitempty"`
+	Unvalued       currency.Currencies                            `json:"unvalued,omitempty"`
 }
 
+// Converter converts an amount of one currency to another, returning false
+// when the amount cannot be valued
+type Converter func(amount float64, from, to currency.Code) (float64, bool)
+
 // XRPScanAccount defines the return type for account data
 type XRPScanAccount struct {
 	Sequence                                  int     `json:"sequence"`
@@ -0,0 +1,214 @@
+package tax
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+const (
+	dateFormat = "2006-01-02 15:04:05"
+	// dust is the amount below which a lot or the unmatched amount of a sale
+	// is treated as fully used, so float rounding does not leave lots or
+	// disposals of negligible amounts
+	dust = 1e-10
+)
+
+var (
+	errMethodUnsupported    = errors.New("lot matching method is not supported")
+	errNoReportingCurrency  = errors.New("reporting currency is not set")
+	errValuationUnavailable = errors.New("unable to value trade in the reporting currency")
+
+	csvHeader = []string{
+		"Asset",
+		"Amount",
+		"Date Acquired",
+		"Date Sold",
+		"Proceeds",
+		"Cost Basis",
+		"Gain",
+		"Currency",
+		"Exchange",
+		"Trade ID",
+	}
+)
+
+// ParseMethod returns the lot matching method matching the case insensitive
+// name
+func ParseMethod(name string) (Method, error) {
+	m := Method(strings.ToLower(name))
+	switch m {
+	case FIFO, LIFO:
+		return m, nil
+	}
+	return "", errMethodUnsupported
+}
+
+// MatchLots matches the sales in the trades against the acquisitions
+// preceding them using the method. Trades without a buy or sell side are
+// ignored. Lots are held per asset across all exchanges and quote currencies,
+// so an asset bought with USD and sold for USDT or EUR is matched, with each
+// trade valued in the reporting currency using convert. Sales exceeding the
+// acquired amount are disposed of with no cost basis
+func MatchLots(trades []Trade, m Method, reporting currency.Code, convert portfolio.Converter) ([]Disposal, error) {
+	if m != FIFO && m != LIFO {
+		return nil, errMethodUnsupported
+	}
+	if reporting.IsEmpty() {
+		return nil, errNoReportingCurrency
+	}
+
+	sorted := append([]Trade(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	inventory := make(map[string][]lot)
+	var disposals []Disposal
+	for i := range sorted {
+		t := &sorted[i]
+		if t.Amount <= 0 || t.Price <= 0 {
+			continue
+		}
+		k := t.Pair.Base.Upper().String()
+		switch t.Side {
+		case order.Buy, order.Bid:
+			cost, err := value(t, t.Amount*t.Price+t.Fee, reporting, convert)
+			if err != nil {
+				return nil, err
+			}
+			inventory[k] = append(inventory[k], lot{
+				acquired: t.Time,
+				amount:   t.Amount,
+				cost:     cost / t.Amount,
+			})
+			continue
+		case order.Sell, order.Ask:
+		default:
+			continue
+		}
+
+		// the proceeds per unit are net of the sale's fee
+		proceeds, err := value(t, t.Amount*t.Price-t.Fee, reporting, convert)
+		if err != nil {
+			return nil, err
+		}
+		proceeds /= t.Amount
+		remaining := t.Amount
+		lots := inventory[k]
+		for remaining > 0 && len(lots) > 0 {
+			idx := 0
+			if m == LIFO {
+				idx = len(lots) - 1
+			}
+			l := &lots[idx]
+			amount := remaining
+			if l.amount < amount {
+				amount = l.amount
+			}
+			disposals = append(disposals, newDisposal(t, amount, proceeds, reporting, l))
+			l.amount -= amount
+			remaining -= amount
+			if remaining < dust {
+				remaining = 0
+			}
+			if l.amount >= dust {
+				continue
+			}
+			if m == LIFO {
+				lots = lots[:idx]
+			} else {
+				lots = lots[1:]
+			}
+		}
+		inventory[k] = lots
+		if remaining > 0 {
+			disposals = append(disposals, newDisposal(t, remaining, proceeds, reporting, nil))
+		}
+	}
+	return disposals, nil
+}
+
+// value returns an amount of the trade's quote currency in the reporting
+// currency
+func value(t *Trade, amount float64, reporting currency.Code, convert portfolio.Converter) (float64, error) {
+	if t.Pair.Quote.Match(reporting) {
+		return amount, nil
+	}
+	if convert != nil {
+		if v, ok := convert(amount, t.Pair.Quote, reporting); ok {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("%s trade %s %s: %v %s",
+		t.Exchange,
+		t.ID,
+		t.Pair,
+		errValuationUnavailable,
+		reporting)
+}
+
+// newDisposal returns the disposal of an amount of a sale at its proceeds per
+// unit in the reporting currency, matched against a lot or, when nil, with no
+// cost basis
+func newDisposal(t *Trade, amount, proceeds float64, reporting currency.Code, l *lot) Disposal {
+	d := Disposal{
+		Exchange: t.Exchange,
+		ID:       t.ID,
+		Asset:    t.Pair.Base,
+		Currency: reporting,
+		Amount:   amount,
+		Disposed: t.Time,
+		Proceeds: amount * proceeds,
+	}
+	if l != nil {
+		d.Acquired = l.acquired
+		d.CostBasis = amount * l.cost
+	}
+	d.Gain = d.Proceeds - d.CostBasis
+	return d
+}
+
+// WriteCSV writes the disposals as CSV, one row per matched lot
+func WriteCSV(w io.Writer, disposals []Disposal) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := range disposals {
+		d := &disposals[i]
+		acquired := ""
+		if !d.Acquired.IsZero() {
+			acquired = d.Acquired.UTC().Format(dateFormat)
+		}
+		err := c.Write([]string{
+			d.Asset.Upper().String(),
+			formatFloat(d.Amount),
+			acquired,
+			d.Disposed.UTC().Format(dateFormat),
+			formatFloat(d.Proceeds),
+			formatFloat(d.CostBasis),
+			formatFloat(d.Gain),
+			d.Currency.Upper().String(),
+			d.Exchange,
+			d.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,173 @@
+package tax
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+var testTime = time.Date(2020, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func testTrades() []Trade {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	return []Trade{
+		// the sale is listed first to ensure trades are matched in time order
+		{Exchange: "Kraken", ID: "3", Time: testTime.Add(time.Hour * 48), Pair: p, Side: order.Sell, Amount: 1.5, Price: 300, Fee: 3},
+		{Exchange: "Bitstamp", ID: "1", Time: testTime, Pair: p, Side: order.Buy, Amount: 1, Price: 100, Fee: 1},
+		{Exchange: "Kraken", ID: "2", Time: testTime.Add(time.Hour * 24), Pair: p, Side: order.Bid, Amount: 1, Price: 200},
+		{Exchange: "Kraken", ID: "4", Time: testTime.Add(time.Hour * 72), Pair: p, Side: order.AnySide, Amount: 1, Price: 300},
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	m, err := ParseMethod("LIFO")
+	if err != nil || m != LIFO {
+		t.Errorf("expected %v, got %v %v", LIFO, m, err)
+	}
+	if _, err = ParseMethod("hifo"); err != errMethodUnsupported {
+		t.Errorf("expected %v, got %v", errMethodUnsupported, err)
+	}
+}
+
+func TestMatchLotsFIFO(t *testing.T) {
+	d, err := MatchLots(testTrades(), FIFO, currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d) != 2 {
+		t.Fatalf("expected the sale to be matched against 2 lots, got %+v", d)
+	}
+	// proceeds are 447 USD for 1.5 BTC after the sale's fee
+	if d[0].Amount != 1 || !d[0].Acquired.Equal(testTime) ||
+		d[0].CostBasis != 101 || d[0].Proceeds != 298 || d[0].Gain != 197 {
+		t.Errorf("unexpected first disposal %+v", d[0])
+	}
+	if d[1].Amount != 0.5 || d[1].CostBasis != 100 || d[1].ID != "3" || d[1].Exchange != "Kraken" {
+		t.Errorf("unexpected second disposal %+v", d[1])
+	}
+}
+
+func TestMatchLotsLIFO(t *testing.T) {
+	d, err := MatchLots(testTrades(), LIFO, currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d) != 2 {
+		t.Fatalf("expected the sale to be matched against 2 lots, got %+v", d)
+	}
+	if d[0].Amount != 1 || d[0].CostBasis != 200 || !d[0].Acquired.Equal(testTime.Add(time.Hour*24)) {
+		t.Errorf("unexpected first disposal %+v", d[0])
+	}
+	if d[1].Amount != 0.5 || d[1].CostBasis != 50.5 {
+		t.Errorf("unexpected second disposal %+v", d[1])
+	}
+
+	if _, err = MatchLots(nil, "hifo", currency.USD, nil); err != errMethodUnsupported {
+		t.Errorf("expected %v, got %v", errMethodUnsupported, err)
+	}
+}
+
+func TestMatchLotsUnmatched(t *testing.T) {
+	trades := []Trade{
+		{Time: testTime, Pair: currency.NewPair(currency.ETH, currency.USD), Side: order.Sell, Amount: 2, Price: 10},
+		// acquired after the sale so it cannot be matched
+		{Time: testTime.Add(time.Hour), Pair: currency.NewPair(currency.ETH, currency.USD), Side: order.Buy, Amount: 2, Price: 5},
+	}
+	d, err := MatchLots(trades, FIFO, currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d) != 1 || !d[0].Acquired.IsZero() || d[0].CostBasis != 0 || d[0].Gain != 20 {
+		t.Errorf("expected an unmatched sale to have no cost basis, got %+v", d)
+	}
+}
+
+func TestMatchLotsCrossQuote(t *testing.T) {
+	convert := func(amount float64, from, to currency.Code) (float64, bool) {
+		if !to.Match(currency.USD) {
+			return 0, false
+		}
+		switch {
+		case from.Match(currency.USDT):
+			return amount, true
+		case from.Match(currency.EUR):
+			return amount * 1.1, true
+		}
+		return 0, false
+	}
+	trades := []Trade{
+		{ID: "1", Time: testTime, Pair: currency.NewPair(currency.BTC, currency.USD), Side: order.Buy, Amount: 1, Price: 100},
+		{ID: "2", Time: testTime.Add(time.Hour), Pair: currency.NewPair(currency.BTC, currency.USDT), Side: order.Sell, Amount: 0.4, Price: 150},
+		{ID: "3", Time: testTime.Add(time.Hour * 2), Pair: currency.NewPair(currency.BTC, currency.EUR), Side: order.Sell, Amount: 0.6, Price: 200},
+	}
+	d, err := MatchLots(trades, FIFO, currency.USD, convert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d) != 2 {
+		t.Fatalf("expected both sales to be matched against the USD lot, got %+v", d)
+	}
+	if d[0].Currency != currency.USD || math.Abs(d[0].CostBasis-40) > 1e-9 || math.Abs(d[0].Gain-20) > 1e-9 {
+		t.Errorf("unexpected USDT disposal %+v", d[0])
+	}
+	if math.Abs(d[1].CostBasis-60) > 1e-9 || math.Abs(d[1].Proceeds-132) > 1e-9 || d[1].Acquired.IsZero() {
+		t.Errorf("unexpected EUR disposal %+v", d[1])
+	}
+
+	trades[2].Pair = currency.NewPair(currency.BTC, currency.JPY)
+	if _, err = MatchLots(trades, FIFO, currency.USD, convert); err == nil {
+		t.Error("expected a trade which cannot be valued to error")
+	}
+	if _, err = MatchLots(trades, FIFO, currency.Code{}, convert); err != errNoReportingCurrency {
+		t.Errorf("expected %v, got %v", errNoReportingCurrency, err)
+	}
+}
+
+func TestMatchLotsDust(t *testing.T) {
+	p := currency.NewPair(currency.BTC, currency.USD)
+	trades := []Trade{
+		{Time: testTime, Pair: p, Side: order.Buy, Amount: 0.3, Price: 100},
+		{Time: testTime.Add(time.Hour), Pair: p, Side: order.Sell, Amount: 0.1, Price: 100},
+		{Time: testTime.Add(time.Hour * 2), Pair: p, Side: order.Sell, Amount: 0.2, Price: 100},
+		{Time: testTime.Add(time.Hour * 3), Pair: p, Side: order.Buy, Amount: 1, Price: 200},
+		{Time: testTime.Add(time.Hour * 4), Pair: p, Side: order.Sell, Amount: 1, Price: 200},
+	}
+	d, err := MatchLots(trades, FIFO, currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0.3 - 0.1 - 0.2 leaves float dust which must not be matched
+	if len(d) != 3 || !d[2].Acquired.Equal(testTime.Add(time.Hour*3)) || d[2].Amount != 1 {
+		t.Errorf("expected the exhausted lot not to be matched, got %+v", d)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	d, err := MatchLots(testTrades(), FIFO, currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d = append(d, Disposal{
+		Asset:    currency.ETH,
+		Currency: currency.USD,
+		Amount:   2,
+		Disposed: testTime,
+		Proceeds: 20,
+		Gain:     20,
+	})
+
+	var buf bytes.Buffer
+	if err = WriteCSV(&buf, d[1:]); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Asset,Amount,Date Acquired,Date Sold,Proceeds,Cost Basis,Gain,Currency,Exchange,Trade ID\n" +
+		"BTC,0.5,2020-03-02 09:00:00,2020-03-03 09:00:00,149,100,49,USD,Kraken,3\n" +
+		"ETH,2,,2020-03-01 09:00:00,20,0,20,USD,,\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package tax
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Method is the order acquired lots are matched against disposals in
+type Method string
+
+// All supported lot matching methods
+const (
+	// FIFO disposes of the earliest acquired lots first
+	FIFO Method = "fifo"
+	// LIFO disposes of the most recently acquired lots first
+	LIFO Method = "lifo"
+)
+
+// Trade is a fill from any exchange's trade history. Fees are in the quote
+// currency
+type Trade struct {
+	Exchange string
+	ID       string
+	Time     time.Time
+	Pair     currency.Pair
+	Side     order.Side
+	Amount   float64
+	Price    float64
+	Fee      float64
+}
+
+// lot is the remaining amount of an acquisition and its cost per unit in the
+// reporting currency, including the acquisition's fee
+type lot struct {
+	acquired time.Time
+	amount   float64
+	cost     float64
+}
+
+// Disposal is the sale of an amount of an asset matched against the lots it
+// was acquired in. Proceeds, cost basis and gain are in the reporting currency
+type Disposal struct {
+	Exchange string
+	ID       string
+	Asset    currency.Code
+	Currency currency.Code
+	Amount   float64
+	// Acquired is the time the matched lot was acquired, zero when the amount
+	// sold exceeds the acquisitions in the trade history and has no cost
+	// basis
+	Acquired  time.Time
+	Disposed  time.Time
+	Proceeds  float64
+	CostBasis float64
+	Gain      float64
+}
@@ -0,0 +1,187 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/clock"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+)
+
+const (
+	kmsService    = "kms"
+	kmsDecrypt    = "TrentService.Decrypt"
+	kmsAlgorithm  = "AWS4-HMAC-SHA256"
+	kmsDateFormat = "20060102T150405Z"
+)
+
+// KMS decrypts API credentials encrypted with an AWS KMS key, the encrypted
+// credentials are the base64 ciphertext returned by the KMS Encrypt API.
+// Requests are signed with the AWS access keys
+type KMS struct {
+	Client *http.Client
+	Region string
+	// Endpoint overrides the regional KMS endpoint
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Encrypted       Credentials
+}
+
+// Name returns the provider name
+func (k *KMS) Name() string {
+	return KMSProvider
+}
+
+// Credentials returns the decrypted API credentials
+func (k *KMS) Credentials() (*Credentials, error) {
+	if k.Region == "" {
+		return nil, errors.New("kms region must be set")
+	}
+	if k.AccessKeyID == "" || k.SecretAccessKey == "" {
+		return nil, errors.New("aws access key ID and secret access key must be set")
+	}
+	if k.Encrypted.Key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	var c Credentials
+	for _, f := range []struct {
+		ciphertext string
+		plaintext  *string
+	}{
+		{k.Encrypted.Key, &c.Key},
+		{k.Encrypted.Secret, &c.Secret},
+		{k.Encrypted.ClientID, &c.ClientID},
+		{k.Encrypted.PEMKey, &c.PEMKey},
+		{k.Encrypted.OTPSecret, &c.OTPSecret},
+	} {
+		if f.ciphertext == "" {
+			continue
+		}
+		plaintext, err := k.decrypt(f.ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		*f.plaintext = plaintext
+	}
+	return &c, nil
+}
+
+// decrypt decrypts a base64 ciphertext with the KMS Decrypt API
+func (k *KMS) decrypt(ciphertext string) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"CiphertextBlob": ciphertext,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := k.Endpoint
+	if endpoint == "" {
+		endpoint = "https://kms." + k.Region + ".amazonaws.com/"
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", kmsDecrypt)
+	k.sign(req, body, clock.Now().UTC())
+
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("kms returned status %d decrypting credentials: %s",
+			resp.StatusCode,
+			strings.TrimSpace(string(contents)))
+	}
+
+	var result struct {
+		Plaintext string `json:"Plaintext"`
+	}
+	if err = json.Unmarshal(contents, &result); err != nil {
+		return "", err
+	}
+	plaintext, err := crypto.Base64Decode(result.Plaintext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// sign signs a request with AWS signature version 4
+func (k *KMS) sign(req *http.Request, body []byte, t time.Time) {
+	amzDate := t.Format(kmsDateFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if k.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", k.SessionToken)
+	}
+
+	headers := []string{"content-type", "host", "x-amz-date", "x-amz-target"}
+	if k.SessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+	// headers are signed in sorted order
+	sort.Strings(headers)
+
+	var canonicalHeaders strings.Builder
+	for x := range headers {
+		v := req.Header.Get(headers[x])
+		if headers[x] == "host" {
+			v = req.URL.Host
+		}
+		canonicalHeaders.WriteString(headers[x] + ":" + strings.TrimSpace(v) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		crypto.HexEncodeToString(crypto.GetSHA256(body)),
+	}, "\n")
+
+	scope := date + "/" + k.Region + "/" + kmsService + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		kmsAlgorithm,
+		amzDate,
+		scope,
+		crypto.HexEncodeToString(crypto.GetSHA256([]byte(canonicalRequest))),
+	}, "\n")
+
+	key := crypto.GetHMAC(crypto.HashSHA256, []byte(date), []byte("AWS4"+k.SecretAccessKey))
+	key = crypto.GetHMAC(crypto.HashSHA256, []byte(k.Region), key)
+	key = crypto.GetHMAC(crypto.HashSHA256, []byte(kmsService), key)
+	key = crypto.GetHMAC(crypto.HashSHA256, []byte("aws4_request"), key)
+	signature := crypto.HexEncodeToString(crypto.GetHMAC(crypto.HashSHA256, []byte(stringToSign), key))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		kmsAlgorithm,
+		k.AccessKeyID,
+		scope,
+		signedHeaders,
+		signature))
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// Provider names
+const (
+	ConfigProvider = "config"
+	EnvProvider    = "env"
+	VaultProvider  = "vault"
+	KMSProvider    = "kms"
+)
+
+// ErrNoCredentials is returned when a provider holds no API key for an
+// exchange
+var ErrNoCredentials = errors.New("no API credentials found")
+
+// Credentials are the API credentials of an exchange
+type Credentials struct {
+	Key       string `json:"key"`
+	Secret    string `json:"secret"`
+	ClientID  string `json:"clientID"`
+	PEMKey    string `json:"pemKey"`
+	OTPSecret string `json:"otpSecret"`
+}
+
+// Provider retrieves the API credentials of an exchange from a secret store
+type Provider interface {
+	Name() string
+	Credentials() (*Credentials, error)
+}
+
+// Env reads API credentials from environment variables named with a prefix,
+// such as PREFIX_API_KEY and PREFIX_API_SECRET
+type Env struct {
+	Prefix string
+}
+
+// DefaultEnvPrefix returns the environment variable prefix of an exchange's
+// API credentials, such as GCT_BINANCE
+func DefaultEnvPrefix(exchName string) string {
+	return "GCT_" + strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", ".", "_").Replace(exchName))
+}
+
+// Name returns the provider name
+func (e *Env) Name() string {
+	return EnvProvider
+}
+
+// Credentials returns the API credentials set in the environment
+func (e *Env) Credentials() (*Credentials, error) {
+	c := Credentials{
+		Key:       os.Getenv(e.Prefix + "_API_KEY"),
+		Secret:    os.Getenv(e.Prefix + "_API_SECRET"),
+		ClientID:  os.Getenv(e.Prefix + "_CLIENT_ID"),
+		PEMKey:    os.Getenv(e.Prefix + "_PEM_KEY"),
+		OTPSecret: os.Getenv(e.Prefix + "_OTP_SECRET"),
+	}
+	if c.Key == "" {
+		return nil, ErrNoCredentials
+	}
+	return &c, nil
+}
@@ -0,0 +1,125 @@
+package secrets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+)
+
+func TestDefaultEnvPrefix(t *testing.T) {
+	t.Parallel()
+	if p := DefaultEnvPrefix("Coinbase Pro"); p != "GCT_COINBASE_PRO" {
+		t.Errorf("unexpected prefix %s", p)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	e := Env{Prefix: "GCT_SECRETS_TEST"}
+	if _, err := e.Credentials(); err != ErrNoCredentials {
+		t.Errorf("expected %v, got %v", ErrNoCredentials, err)
+	}
+
+	os.Setenv("GCT_SECRETS_TEST_API_KEY", "key")
+	os.Setenv("GCT_SECRETS_TEST_API_SECRET", "secret")
+	defer os.Unsetenv("GCT_SECRETS_TEST_API_KEY")
+	defer os.Unsetenv("GCT_SECRETS_TEST_API_SECRET")
+	c, err := e.Credentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Key != "key" || c.Secret != "secret" || c.ClientID != "" {
+		t.Errorf("unexpected credentials %+v", c)
+	}
+}
+
+func TestVault(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/gocryptotrader/binance" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"key":"key","secret":"secret"}}}`))
+	}))
+	defer s.Close()
+
+	v := Vault{
+		Client:  s.Client(),
+		Address: s.URL,
+		Token:   "token",
+		Mount:   DefaultVaultMount,
+		Path:    "gocryptotrader/binance",
+	}
+	c, err := v.Credentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Key != "key" || c.Secret != "secret" {
+		t.Errorf("unexpected credentials %+v", c)
+	}
+
+	v.Token = "bad"
+	if _, err = v.Credentials(); err == nil {
+		t.Error("expected an error when vault rejects the token")
+	}
+}
+
+func TestKMS(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != kmsDecrypt ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), kmsAlgorithm+" Credential=id/") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			CiphertextBlob string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// the test ciphertext is the reversed plaintext
+		b := []byte(req.CiphertextBlob)
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"Plaintext": crypto.Base64Encode(b),
+		})
+	}))
+	defer s.Close()
+
+	k := KMS{
+		Client:          s.Client(),
+		Region:          "us-east-1",
+		Endpoint:        s.URL,
+		AccessKeyID:     "id",
+		SecretAccessKey: "secret",
+		Encrypted: Credentials{
+			Key:    "yek",
+			Secret: "terces",
+		},
+	}
+	c, err := k.Credentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Key != "key" || c.Secret != "secret" || c.ClientID != "" {
+		t.Errorf("unexpected credentials %+v", c)
+	}
+
+	k.AccessKeyID = ""
+	if _, err = k.Credentials(); err == nil {
+		t.Error("expected an error without AWS access keys")
+	}
+}
@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DefaultVaultMount is the mount of the KV version 2 secrets engine
+// credentials are read from
+const DefaultVaultMount = "secret"
+
+// Vault reads API credentials from a HashiCorp Vault KV version 2 secret, the
+// secret's fields are named as the credentials are in the config file
+type Vault struct {
+	Client  *http.Client
+	Address string
+	Token   string
+	Mount   string
+	Path    string
+}
+
+// Name returns the provider name
+func (v *Vault) Name() string {
+	return VaultProvider
+}
+
+// Credentials returns the API credentials stored in the secret
+func (v *Vault) Credentials() (*Credentials, error) {
+	if v.Address == "" || v.Token == "" {
+		return nil, errors.New("vault address and token must be set")
+	}
+	if v.Path == "" {
+		return nil, errors.New("vault secret path must be set")
+	}
+
+	path := fmt.Sprintf("%s/v1/%s/data/%s",
+		strings.TrimRight(v.Address, "/"),
+		strings.Trim(v.Mount, "/"),
+		strings.Trim(v.Path, "/"))
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d reading %s: %s",
+			resp.StatusCode,
+			v.Path,
+			strings.TrimSpace(string(contents)))
+	}
+
+	var secret struct {
+		Data struct {
+			Data Credentials `json:"data"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(contents, &secret); err != nil {
+		return nil, err
+	}
+	if secret.Data.Data.Key == "" {
+		return nil, ErrNoCredentials
+	}
+	return &secret.Data.Data, nil
+}
@@ -0,0 +1,188 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+// Run replays candles through a new instance of the strategy and returns its
+// performance over the candles
+func Run(s *Settings, candles []kline.Candle) (performance.Report, error) {
+	sim, err := simulate(s, candles)
+	if err != nil {
+		return performance.Report{}, err
+	}
+	return sim.report(candles[0].Time, candles[len(candles)-1].Time.Add(s.Interval)), nil
+}
+
+// MonteCarlo replays candles through a new instance of the strategy and
+// resamples the trades it made to estimate the variance of its final equity
+// and max drawdown
+func MonteCarlo(s *Settings, candles []kline.Candle, mc *performance.MonteCarloSettings) (performance.Report, performance.MonteCarloResult, error) {
+	sim, err := simulate(s, candles)
+	if err != nil {
+		return performance.Report{}, performance.MonteCarloResult{}, err
+	}
+	r := sim.report(candles[0].Time, candles[len(candles)-1].Time.Add(s.Interval))
+	result, err := performance.MonteCarlo(sim.trades, mc)
+	return r, result, err
+}
+
+// simulate replays candles through a new instance of the strategy, calling
+// OnCandle with each candle followed by OnOrderUpdate with the orders it
+// filled
+func simulate(s *Settings, candles []kline.Candle) (*simulation, error) {
+	if len(candles) == 0 {
+		return nil, errNoCandles
+	}
+	strategy, err := strategies.New(s.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	sim := &simulation{settings: s}
+	err = strategy.Init(&strategies.Context{
+		Name:       s.Strategy,
+		Exchange:   s.Exchange,
+		Pair:       s.Pair,
+		AssetType:  s.AssetType,
+		Interval:   s.Interval,
+		Parameters: s.Parameters,
+		Trader:     sim,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candles {
+		sim.close = candles[i].Close
+		sim.now = candles[i].Time.Add(s.Interval)
+		if err = strategy.OnCandle(&candles[i]); err != nil {
+			return nil, fmt.Errorf("%s candle %v: %v", s.Strategy, candles[i].Time, err)
+		}
+		for len(sim.updates) > 0 {
+			d := sim.updates[0]
+			sim.updates = sim.updates[1:]
+			if err = strategy.OnOrderUpdate(d); err != nil {
+				return nil, fmt.Errorf("%s order update %v: %v", s.Strategy, candles[i].Time, err)
+			}
+		}
+		sim.equity = append(sim.equity, performance.EquityPoint{
+			Time:   sim.now,
+			Equity: sim.realised - sim.fees + sim.position*(sim.close-sim.averagePrice),
+		})
+	}
+	return sim, nil
+}
+
+// SubmitOrder fills a market order at the close of the current candle
+func (sim *simulation) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
+	if s.Type != order.Market {
+		return order.SubmitResponse{}, errOrderTypeUnsupported
+	}
+	if s.Amount <= 0 {
+		return order.SubmitResponse{}, errOrderAmount
+	}
+	sim.orders++
+	id := strconv.Itoa(sim.orders)
+	fee := s.Amount * sim.close * sim.settings.Fee
+	sim.fees += fee
+	sim.fill(s.Side, s.Amount, sim.close)
+	sim.updates = append(sim.updates, &order.Detail{
+		Exchange:       s.Exchange,
+		ID:             id,
+		Pair:           s.Pair,
+		AssetType:      s.AssetType,
+		Side:           s.Side,
+		Type:           order.Market,
+		Status:         order.Filled,
+		Price:          sim.close,
+		Amount:         s.Amount,
+		ExecutedAmount: s.Amount,
+		Fee:            fee,
+		Date:           sim.now,
+		LastUpdated:    sim.now,
+	})
+	return order.SubmitResponse{
+		IsOrderPlaced: true,
+		FullyMatched:  true,
+		OrderID:       id,
+	}, nil
+}
+
+// CancelOrder always fails as market orders are filled when submitted
+func (sim *simulation) CancelOrder(_ *order.Cancel) error {
+	return errCancelUnsupported
+}
+
+// fill applies a fill to the position, recording a trade when the position
+// is closed or reversed
+func (sim *simulation) fill(side order.Side, amount, price float64) {
+	delta := amount
+	if side != order.Buy && side != order.Bid {
+		delta = -amount
+	}
+	previous := sim.position
+	if previous == 0 || (previous > 0) == (delta > 0) {
+		total := math.Abs(previous) + amount
+		sim.averagePrice = (math.Abs(previous)*sim.averagePrice + amount*price) / total
+		sim.position += delta
+		if previous == 0 {
+			sim.opened = sim.now
+		}
+		return
+	}
+
+	closed := math.Min(amount, math.Abs(previous))
+	realised := closed * (price - sim.averagePrice)
+	if previous < 0 {
+		realised = -realised
+	}
+	sim.realised += realised
+	sim.tripPNL += realised
+	sim.position += delta
+	if amount < math.Abs(previous) {
+		return
+	}
+	sim.trades = append(sim.trades, performance.Trade{
+		Opened: sim.opened,
+		Closed: sim.now,
+		PNL:    sim.tripPNL,
+	})
+	sim.tripPNL = 0
+	sim.opened = time.Time{}
+	sim.averagePrice = 0
+	if amount > closed {
+		// the position has been reversed
+		sim.position = delta + previous
+		sim.averagePrice = price
+		sim.opened = sim.now
+	}
+}
+
+// report returns the simulation's performance between start and end
+func (sim *simulation) report(start, end time.Time) performance.Report {
+	in := performance.Input{
+		Strategy: sim.settings.Strategy,
+		Currency: sim.settings.Pair.Quote.Upper().String(),
+		Start:    start,
+		End:      end,
+		Capital:  sim.settings.Capital,
+		Trades:   sim.trades,
+	}
+	for i := range sim.equity {
+		if !sim.equity[i].Time.Before(start) && !sim.equity[i].Time.After(end) {
+			in.Equity = append(in.Equity, sim.equity[i])
+		}
+	}
+	if !sim.opened.IsZero() {
+		in.Open = append(in.Open, sim.opened)
+	}
+	return performance.Calculate(&in)
+}
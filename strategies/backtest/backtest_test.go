@@ -0,0 +1,282 @@
+package backtest
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+const testStrategy = "backtesttest"
+
+// thresholdStrategy buys the amount when the close is at or below the buy
+// parameter and sells it when the close is at or above the sell parameter
+type thresholdStrategy struct {
+	ctx       *strategies.Context
+	buy, sell float64
+	long      bool
+	filled    int
+}
+
+func (s *thresholdStrategy) Init(ctx *strategies.Context) error {
+	var err error
+	if s.buy, err = ctx.Float("buy", 0); err != nil {
+		return err
+	}
+	if s.sell, err = ctx.Float("sell", 0); err != nil {
+		return err
+	}
+	if s.buy >= s.sell {
+		return errors.New("buy must be less than sell")
+	}
+	s.ctx = ctx
+	return nil
+}
+
+func (s *thresholdStrategy) OnTick(_ *ticker.Price) error {
+	return nil
+}
+
+func (s *thresholdStrategy) OnCandle(c *kline.Candle) error {
+	switch {
+	case !s.long && c.Close <= s.buy:
+		s.long = true
+		return s.submit(order.Buy)
+	case s.long && c.Close >= s.sell:
+		s.long = false
+		return s.submit(order.Sell)
+	}
+	return nil
+}
+
+func (s *thresholdStrategy) OnOrderUpdate(d *order.Detail) error {
+	if d.Status == order.Filled {
+		s.filled++
+	}
+	return nil
+}
+
+func (s *thresholdStrategy) submit(side order.Side) error {
+	_, err := s.ctx.Trader.SubmitOrder(&order.Submit{
+		Exchange:  s.ctx.Exchange,
+		Pair:      s.ctx.Pair,
+		AssetType: s.ctx.AssetType,
+		Side:      side,
+		Type:      order.Market,
+		Amount:    1,
+	})
+	return err
+}
+
+func init() {
+	err := strategies.Register(testStrategy, func() strategies.Strategy {
+		return new(thresholdStrategy)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+var testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testCandles(closes ...float64) []kline.Candle {
+	candles := make([]kline.Candle, len(closes))
+	for i := range closes {
+		candles[i] = kline.Candle{Time: testStart.Add(time.Hour * time.Duration(i)), Close: closes[i]}
+	}
+	return candles
+}
+
+func testSettings(params map[string]string) *Settings {
+	return &Settings{
+		Strategy:   testStrategy,
+		Exchange:   "test",
+		Pair:       currency.NewPair(currency.BTC, currency.USD),
+		AssetType:  asset.Spot,
+		Interval:   time.Hour,
+		Parameters: params,
+	}
+}
+
+func TestRun(t *testing.T) {
+	s := testSettings(map[string]string{"buy": "100", "sell": "110"})
+	if _, err := Run(s, nil); err != errNoCandles {
+		t.Errorf("expected %v, received %v", errNoCandles, err)
+	}
+
+	s.Fee = 0.01
+	r, err := Run(s, testCandles(105, 100, 105, 110, 104, 98, 95, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// bought at 100 and sold at 110, then bought at 98 and still held
+	if r.Trades != 1 || r.RealisedPNL != 10 || r.WinRate != 100 || r.Currency != "USD" {
+		t.Errorf("unexpected report %+v", r)
+	}
+	// held from the close of the second to the fourth candle and from the
+	// sixth to the end
+	if r.ExposureTime != 50 {
+		t.Errorf("expected 50%% exposure, received %v", r.ExposureTime)
+	}
+	// fees of 1, 1.1 and 0.98 are deducted from the peak equity of 8.9 down
+	// to 8.9 - 0.98 - 3 at the 95 close
+	if math.Abs(r.MaxDrawdown-3.98) > 1e-9 {
+		t.Errorf("expected 3.98 drawdown, received %v", r.MaxDrawdown)
+	}
+	if r.SharpeRatio != 0 {
+		t.Errorf("expected no Sharpe ratio without capital, received %v", r.SharpeRatio)
+	}
+	s.Capital = 1000
+	if r, err = Run(s, testCandles(105, 100, 105, 110, 104, 98, 95, 100)); err != nil || r.SharpeRatio == 0 {
+		t.Errorf("expected a Sharpe ratio on the capital, received %v %v", r.SharpeRatio, err)
+	}
+
+	if _, err = Run(testSettings(map[string]string{"buy": "2", "sell": "1"}), testCandles(1)); err == nil {
+		t.Error("expected the strategy's initialisation error")
+	}
+}
+
+func TestMonteCarlo(t *testing.T) {
+	s := testSettings(map[string]string{"buy": "100", "sell": "110"})
+	// the trailing candle is after the second trade closes, so it is within
+	// the period
+	candles := testCandles(105, 100, 105, 110, 104, 98, 95, 100, 110, 108)
+	r, mc, err := MonteCarlo(s, candles, &performance.MonteCarloSettings{Simulations: 100, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Trades != 2 || mc.Trades != 2 || mc.Simulations != 100 {
+		t.Errorf("unexpected report %+v and simulations %+v", r, mc)
+	}
+	// both trades are winners, so every resampled sequence is too
+	if mc.FinalEquity.Median != r.RealisedPNL || mc.ProbabilityOfLoss != 0 {
+		t.Errorf("unexpected final equity %+v", mc.FinalEquity)
+	}
+
+	if _, _, err = MonteCarlo(s, testCandles(105, 100), &performance.MonteCarloSettings{}); err == nil {
+		t.Error("expected an error when the strategy makes no trades")
+	}
+}
+
+func TestSimulationFill(t *testing.T) {
+	sim := &simulation{settings: testSettings(nil), now: testStart}
+	sim.fill(order.Sell, 2, 100)
+	sim.now = testStart.Add(time.Hour)
+	// reverses the short into a long of 1 at 90
+	sim.fill(order.Buy, 3, 90)
+	if sim.position != 1 || sim.averagePrice != 90 || sim.realised != 20 {
+		t.Errorf("unexpected position %v at %v realising %v", sim.position, sim.averagePrice, sim.realised)
+	}
+	if len(sim.trades) != 1 || sim.trades[0].PNL != 20 || !sim.opened.Equal(sim.now) {
+		t.Errorf("unexpected trades %+v opened %v", sim.trades, sim.opened)
+	}
+
+	if _, err := sim.SubmitOrder(&order.Submit{Type: order.Limit, Amount: 1}); err != errOrderTypeUnsupported {
+		t.Errorf("expected %v, received %v", errOrderTypeUnsupported, err)
+	}
+	if err := sim.CancelOrder(&order.Cancel{}); err != errCancelUnsupported {
+		t.Errorf("expected %v, received %v", errCancelUnsupported, err)
+	}
+}
+
+func TestWalkForward(t *testing.T) {
+	w, err := walkForward(100, 3, 0.7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []window{{0, 70, 80}, {10, 80, 90}, {20, 90, 100}}
+	for i := range expected {
+		if w[i] != expected[i] {
+			t.Errorf("expected window %v, received %v", expected[i], w[i])
+		}
+	}
+	if _, err = walkForward(5, 3, 0.7); err != errNotEnoughCandles {
+		t.Errorf("expected %v, received %v", errNotEnoughCandles, err)
+	}
+}
+
+func TestParameterSets(t *testing.T) {
+	sets := parameterSets(map[string]string{"amount": "1", "fast": "3"}, map[string][]string{
+		"slow": {"20", "30"},
+		"fast": {"5", "10"},
+	})
+	if len(sets) != 4 {
+		t.Fatalf("expected 4 parameter sets, received %v", sets)
+	}
+	if sets[0]["fast"] != "5" || sets[0]["slow"] != "20" || sets[3]["fast"] != "10" || sets[3]["slow"] != "30" {
+		t.Errorf("unexpected parameter sets %v", sets)
+	}
+	for i := range sets {
+		if sets[i]["amount"] != "1" {
+			t.Errorf("expected fixed parameters in each set, received %v", sets[i])
+		}
+	}
+}
+
+func TestOptimise(t *testing.T) {
+	// oscillates between 90 and 120 every 10 candles
+	closes := make([]float64, 100)
+	for i := range closes {
+		closes[i] = 90
+		if (i/10)%2 == 1 {
+			closes[i] = 120
+		}
+	}
+	candles := testCandles(closes...)
+	o := &Optimisation{
+		Settings: *testSettings(map[string]string{"sell": "110"}),
+		Grid:     map[string][]string{"buy": {"80", "95", "115"}},
+		Folds:    2,
+		Metric:   MetricPNL,
+		Workers:  2,
+	}
+	results, err := Optimise(o, candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the strategy rejects buying at 115 above the sell price and buying at
+	// 80 never fills
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, received %d", len(results))
+	}
+	if results[0].Parameters["buy"] != "95" || results[0].OutOfSamplePNL <= 0 {
+		t.Errorf("expected the trading parameter set to rank first, received %+v", results[0])
+	}
+	if results[1].Parameters["buy"] != "80" || results[1].OutOfSampleScore != 0 {
+		t.Errorf("expected the idle parameter set to rank last, received %+v", results[1])
+	}
+	if len(results[0].Folds) != 2 {
+		t.Errorf("expected 2 folds, received %d", len(results[0].Folds))
+	}
+
+	o.Grid = map[string][]string{"buy": {"120", "130"}}
+	if _, err = Optimise(o, candles); err == nil || !strings.Contains(err.Error(), errAllParameterSetsFailed.Error()) {
+		t.Errorf("expected %v, received %v", errAllParameterSetsFailed, err)
+	}
+	o.Grid = map[string][]string{"buy": nil}
+	if _, err = Optimise(o, candles); err != errGridEmpty {
+		t.Errorf("expected %v, received %v", errGridEmpty, err)
+	}
+	o.Grid = map[string][]string{"buy": {"95"}}
+	o.Metric = "calmar"
+	if _, err = Optimise(o, candles); err != errMetricUnsupported {
+		t.Errorf("expected %v, received %v", errMetricUnsupported, err)
+	}
+	o.Metric = MetricSharpe
+	if _, err = Optimise(o, candles); err != errCapitalRequired {
+		t.Errorf("expected %v, received %v", errCapitalRequired, err)
+	}
+	o.Settings.Capital = 1000
+	if _, err = Optimise(o, candles); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,114 @@
+package backtest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+)
+
+// Metrics parameter sets are ranked by
+const (
+	MetricSharpe  = "sharpe"
+	MetricSortino = "sortino"
+	MetricPNL     = "pnl"
+)
+
+// defaultTrainRatio is the share of each walk-forward window used to fit the
+// parameters when none is set
+const defaultTrainRatio = 0.7
+
+var (
+	errNoCandles              = errors.New("no candles to backtest")
+	errOrderTypeUnsupported   = errors.New("backtests only fill market orders")
+	errOrderAmount            = errors.New("order amount must be greater than zero")
+	errCancelUnsupported      = errors.New("backtested market orders are filled immediately and cannot be cancelled")
+	errGridEmpty              = errors.New("parameter grid must have at least one value for each parameter")
+	errFoldsInvalid           = errors.New("walk-forward folds must be greater than zero")
+	errTrainRatioInvalid      = errors.New("train ratio must be greater than zero and less than one")
+	errMetricUnsupported      = errors.New("metric must be sharpe, sortino or pnl")
+	errCapitalRequired        = errors.New("capital must be greater than zero to rank by sharpe or sortino ratio")
+	errNotEnoughCandles       = errors.New("not enough candles for each walk-forward window to hold a candle")
+	errAllParameterSetsFailed = errors.New("all parameter sets failed")
+)
+
+// Settings are the strategy, market and parameters a backtest replays candles
+// through
+type Settings struct {
+	Strategy   string
+	Exchange   string
+	Pair       currency.Pair
+	AssetType  asset.Item
+	Interval   time.Duration
+	Parameters map[string]string
+	// Fee is the rate of the value of each fill charged as a fee, such as
+	// 0.001 for 0.1%. Fees reduce the equity curve but are not included in
+	// the profit and loss of trades
+	Fee float64
+	// Capital is the amount of the quote currency the strategy trades with,
+	// which the returns the Sharpe and Sortino ratios are calculated from are
+	// measured against as the equity curve starts from zero. The ratios are
+	// zero without capital
+	Capital float64
+}
+
+// simulation is a strategy instance replayed over candles. Orders are filled
+// in full at the close of the candle the strategy is called with
+type simulation struct {
+	settings *Settings
+	close    float64
+	now      time.Time
+	orders   int
+	updates  []*order.Detail
+
+	position     float64
+	averagePrice float64
+	realised     float64
+	fees         float64
+	opened       time.Time
+	tripPNL      float64
+	trades       []performance.Trade
+	equity       []performance.EquityPoint
+}
+
+// Optimisation is a grid of strategy parameters backtested with walk-forward
+// splits of the candles. The candles are split into Folds windows which each
+// advance by the length of the out-of-sample period, the first TrainRatio of
+// each window is in-sample and the remainder is out-of-sample. Strategies run
+// across each whole window so indicators are warmed up by the in-sample
+// candles before the out-of-sample period is measured
+type Optimisation struct {
+	Settings Settings
+	// Grid is the values tried for each parameter, every combination is
+	// backtested with the fixed parameters of the settings
+	Grid       map[string][]string
+	Folds      int
+	TrainRatio float64
+	// Metric is sharpe, sortino or pnl, defaulting to sharpe
+	Metric string
+	// Workers is the amount of backtests run in parallel, defaulting to the
+	// amount of CPU cores
+	Workers int
+}
+
+// Fold is the performance of a parameter set over a walk-forward window's
+// in-sample and out-of-sample periods
+type Fold struct {
+	InSample    performance.Report
+	OutOfSample performance.Report
+}
+
+// Result is the walk-forward performance of a parameter set. Scores are the
+// mean of the metric over each fold's periods
+type Result struct {
+	Parameters       map[string]string
+	Folds            []Fold
+	InSampleScore    float64
+	OutOfSampleScore float64
+	// OutOfSamplePNL is the profit and loss realised across the
+	// out-of-sample periods
+	OutOfSamplePNL float64
+}
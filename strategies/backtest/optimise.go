@@ -0,0 +1,188 @@
+package backtest
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/performance"
+)
+
+// Optimise backtests every parameter set of the grid over walk-forward
+// splits of the candles in parallel and returns the results ranked by their
+// out-of-sample score, best first. Parameter sets which fail, such as those
+// the strategy rejects when initialised, are excluded from the results
+func Optimise(o *Optimisation, candles []kline.Candle) ([]Result, error) {
+	if len(o.Grid) == 0 {
+		return nil, errGridEmpty
+	}
+	for k := range o.Grid {
+		if len(o.Grid[k]) == 0 {
+			return nil, errGridEmpty
+		}
+	}
+	if o.Folds <= 0 {
+		return nil, errFoldsInvalid
+	}
+	ratio := o.TrainRatio
+	if ratio == 0 {
+		ratio = defaultTrainRatio
+	}
+	if ratio <= 0 || ratio >= 1 {
+		return nil, errTrainRatioInvalid
+	}
+	metric := strings.ToLower(o.Metric)
+	switch metric {
+	case "":
+		metric = MetricSharpe
+	case MetricSharpe, MetricSortino, MetricPNL:
+	default:
+		return nil, errMetricUnsupported
+	}
+	if metric != MetricPNL && o.Settings.Capital <= 0 {
+		return nil, errCapitalRequired
+	}
+	windows, err := walkForward(len(candles), o.Folds, ratio)
+	if err != nil {
+		return nil, err
+	}
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	sets := parameterSets(o.Settings.Parameters, o.Grid)
+	results := make([]Result, len(sets))
+	errs := make([]error, len(sets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j], errs[j] = evaluate(&o.Settings, sets[j], candles, windows, metric)
+			}
+		}()
+	}
+	for i := range sets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	ranked := results[:0]
+	var firstErr error
+	for i := range results {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		ranked = append(ranked, results[i])
+	}
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("%v: %v", errAllParameterSetsFailed, firstErr)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].OutOfSampleScore > ranked[j].OutOfSampleScore
+	})
+	return ranked, nil
+}
+
+// window is the candle indices of a walk-forward window's in-sample period
+// from start to split and out-of-sample period from split to end
+type window struct {
+	start, split, end int
+}
+
+// walkForward returns the rolling windows of candles, the out-of-sample
+// periods of which cover the last share of the candles not used to train
+// without overlapping
+func walkForward(candles, folds int, ratio float64) ([]window, error) {
+	test := int(float64(candles) * (1 - ratio) / float64(folds))
+	train := candles - folds*test
+	if test < 1 || train < 1 {
+		return nil, errNotEnoughCandles
+	}
+	windows := make([]window, folds)
+	for i := range windows {
+		start := i * test
+		windows[i] = window{start: start, split: start + train, end: start + train + test}
+	}
+	return windows, nil
+}
+
+// parameterSets returns every combination of the grid's values merged with
+// the fixed parameters, ordered by the grid's parameter names
+func parameterSets(fixed map[string]string, grid map[string][]string) []map[string]string {
+	names := make([]string, 0, len(grid))
+	for k := range grid {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	sets := []map[string]string{{}}
+	for _, name := range names {
+		next := make([]map[string]string, 0, len(sets)*len(grid[name]))
+		for i := range sets {
+			for _, v := range grid[name] {
+				set := make(map[string]string, len(sets[i])+1)
+				for k := range sets[i] {
+					set[k] = sets[i][k]
+				}
+				set[name] = v
+				next = append(next, set)
+			}
+		}
+		sets = next
+	}
+	for i := range sets {
+		for k, v := range fixed {
+			if _, ok := sets[i][k]; !ok {
+				sets[i][k] = v
+			}
+		}
+	}
+	return sets
+}
+
+// evaluate backtests a parameter set over each walk-forward window
+func evaluate(s *Settings, params map[string]string, candles []kline.Candle, windows []window, metric string) (Result, error) {
+	settings := *s
+	settings.Parameters = params
+	r := Result{Parameters: params}
+	for i := range windows {
+		w := windows[i]
+		sim, err := simulate(&settings, candles[w.start:w.end])
+		if err != nil {
+			return Result{}, err
+		}
+		split := candles[w.split].Time
+		f := Fold{
+			InSample:    sim.report(candles[w.start].Time, split),
+			OutOfSample: sim.report(split, candles[w.end-1].Time.Add(s.Interval)),
+		}
+		r.Folds = append(r.Folds, f)
+		r.InSampleScore += score(&f.InSample, metric)
+		r.OutOfSampleScore += score(&f.OutOfSample, metric)
+		r.OutOfSamplePNL += f.OutOfSample.RealisedPNL
+	}
+	r.InSampleScore /= float64(len(windows))
+	r.OutOfSampleScore /= float64(len(windows))
+	return r, nil
+}
+
+func score(r *performance.Report, metric string) float64 {
+	switch metric {
+	case MetricSortino:
+		return r.SortinoRatio
+	case MetricPNL:
+		return r.RealisedPNL
+	}
+	return r.SharpeRatio
+}
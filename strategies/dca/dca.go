@@ -0,0 +1,283 @@
+// Package dca is a dollar-cost averaging strategy which buys a fixed amount of
+// the quote currency's worth of the pair on a schedule, whatever its price.
+// Buying several pairs or on several exchanges is configured as an instance of
+// the strategy for each
+package dca
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+// Name is the name the strategy is registered under
+const Name = "dca"
+
+// DefaultEvery is how often the amount is bought when not configured
+const DefaultEvery = time.Hour * 24
+
+// maxPurchases is the amount of recent purchases kept in the report, the
+// totals include all purchases
+const maxPurchases = 1000
+
+var (
+	errInvalidAmount = errors.New("amount parameter must be greater than zero")
+	errInvalidEvery  = errors.New("every parameter must be greater than zero")
+	errInvalidFee    = errors.New("fee parameter cannot be negative")
+)
+
+func init() {
+	if err := strategies.Register(Name, New); err != nil {
+		panic(err)
+	}
+}
+
+// Purchase is a fill of one of the strategy's orders
+type Purchase struct {
+	Time   time.Time `json:"time"`
+	Price  float64   `json:"price"`
+	Amount float64   `json:"amount"`
+	// Cost is the amount of the quote currency spent including fees
+	Cost float64 `json:"cost"`
+	// AverageCost is the average cost of the base currency acquired by the
+	// strategy after the purchase
+	AverageCost float64 `json:"averageCost"`
+}
+
+// Report is the amount of the base currency acquired by the strategy and what
+// it cost in the quote currency including fees
+type Report struct {
+	Amount      float64 `json:"amount"`
+	Cost        float64 `json:"cost"`
+	AverageCost float64 `json:"averageCost"`
+	// Carried is the amount of the quote currency left unspent, such as when
+	// an order would have been below the market's minimum order size, which
+	// is added to the next purchase
+	Carried float64 `json:"carried"`
+	// Next is when the next purchase is due
+	Next time.Time `json:"next"`
+	// Purchases are the most recent purchases, showing the average cost over
+	// time
+	Purchases []Purchase `json:"purchases"`
+}
+
+// pending is an order placed by the strategy which has not finished filling
+type pending struct {
+	// Price is the price the order was sized at, used for fills reported
+	// without a price
+	Price    float64 `json:"price"`
+	Executed float64 `json:"executed"`
+	Fee      float64 `json:"fee"`
+}
+
+// state is the strategy state persisted across restarts
+type state struct {
+	Report
+	Pending map[string]pending `json:"pending,omitempty"`
+}
+
+// Strategy holds the schedule and the purchases made
+type Strategy struct {
+	ctx     *strategies.Context
+	amount  float64
+	fee     float64
+	every   time.Duration
+	report  Report
+	pending map[string]pending
+}
+
+// New returns a new instance of the strategy
+func New() strategies.Strategy {
+	return new(Strategy)
+}
+
+// Init reads the amount, every and fee parameters. Amount is in the quote
+// currency and includes the fee, which is the rate charged on the value of
+// each order such as 0.001 for 0.1%
+func (s *Strategy) Init(ctx *strategies.Context) error {
+	var err error
+	if s.amount, err = ctx.Float("amount", 0); err != nil {
+		return err
+	}
+	if s.amount <= 0 {
+		return errInvalidAmount
+	}
+	if s.every, err = ctx.Duration("every", DefaultEvery); err != nil {
+		return err
+	}
+	if s.every <= 0 {
+		return errInvalidEvery
+	}
+	if s.fee, err = ctx.Float("fee", 0); err != nil {
+		return err
+	}
+	if s.fee < 0 {
+		return errInvalidFee
+	}
+	s.pending = make(map[string]pending)
+	s.ctx = ctx
+	return nil
+}
+
+// OnTick buys at the ask when a purchase is due
+func (s *Strategy) OnTick(t *ticker.Price) error {
+	price := t.Ask
+	if price <= 0 {
+		price = t.Last
+	}
+	at := t.LastUpdated
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return s.buy(price, at)
+}
+
+// OnCandle buys at the close when a purchase is due, so the strategy can be
+// backtested over candles
+func (s *Strategy) OnCandle(c *kline.Candle) error {
+	return s.buy(c.Close, c.Time.Add(s.ctx.Interval))
+}
+
+// OnOrderUpdate records the fills of the strategy's orders
+func (s *Strategy) OnOrderUpdate(d *order.Detail) error {
+	p, ok := s.pending[d.ID]
+	if !ok {
+		return nil
+	}
+	if filled := d.ExecutedAmount - p.Executed; filled > 0 {
+		price := d.Price
+		if price <= 0 {
+			price = p.Price
+		}
+		fee := filled * price * s.fee
+		if d.Fee > p.Fee {
+			fee = d.Fee - p.Fee
+			p.Fee = d.Fee
+		}
+		p.Executed = d.ExecutedAmount
+		at := d.LastUpdated
+		if at.IsZero() {
+			at = d.Date
+		}
+		s.record(at, price, filled, filled*price+fee)
+	}
+	switch d.Status {
+	case order.Filled,
+		order.Cancelled,
+		order.PartiallyCancelled,
+		order.Rejected,
+		order.Expired,
+		order.InsufficientBalance,
+		order.MarketUnavailable:
+		delete(s.pending, d.ID)
+	default:
+		s.pending[d.ID] = p
+	}
+	return nil
+}
+
+// Report returns the base currency acquired and its average cost
+func (s *Strategy) Report() Report {
+	r := s.report
+	r.Purchases = append([]Purchase(nil), s.report.Purchases...)
+	return r
+}
+
+// SaveState returns the purchases made, the schedule and the orders which
+// have not finished filling
+func (s *Strategy) SaveState() ([]byte, error) {
+	return json.Marshal(state{
+		Report:  s.report,
+		Pending: s.pending,
+	})
+}
+
+// RestoreState resumes the schedule and the average cost of the purchases
+// made before a restart
+func (s *Strategy) RestoreState(b []byte) error {
+	var st state
+	if err := json.Unmarshal(b, &st); err != nil {
+		return err
+	}
+	s.report = st.Report
+	for k, v := range st.Pending {
+		s.pending[k] = v
+	}
+	return nil
+}
+
+// buy places a market order for the amount, plus any amount carried, when a
+// purchase is due. Purchases missed while the strategy was not running are
+// not caught up
+func (s *Strategy) buy(price float64, at time.Time) error {
+	if price <= 0 || at.Before(s.report.Next) {
+		return nil
+	}
+	if s.report.Next.IsZero() {
+		s.report.Next = at
+	}
+	// scheduled from when the purchase was due so purchases do not drift
+	s.report.Next = s.report.Next.Add(s.every * (at.Sub(s.report.Next)/s.every + 1))
+
+	budget := s.amount + s.report.Carried
+	amount := budget / (price * (1 + s.fee))
+	if l, ok := s.ctx.OrderLimits(); ok {
+		amount = l.RoundAmount(amount).Float64()
+		if amount <= 0 || amount < l.MinAmount || amount*price < l.MinNotional {
+			s.report.Carried = budget
+			log.Infof(log.OrderMgr, "Strategy %s: %v %s is below the %s minimum order size, carrying it to the next purchase.\n",
+				s.ctx.Name,
+				budget,
+				s.ctx.Pair.Quote,
+				s.ctx.Pair)
+			return nil
+		}
+	}
+
+	resp, err := s.ctx.Trader.SubmitOrder(&order.Submit{
+		Exchange:  s.ctx.Exchange,
+		Pair:      s.ctx.Pair,
+		AssetType: s.ctx.AssetType,
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    amount,
+	})
+	if err != nil {
+		return err
+	}
+	s.report.Carried = budget - amount*price*(1+s.fee)
+	if s.report.Carried < 0 {
+		s.report.Carried = 0
+	}
+	s.pending[resp.OrderID] = pending{Price: price}
+	log.Infof(log.OrderMgr, "Strategy %s: BUY %v %s at market, order ID %s. Next purchase due %v.\n",
+		s.ctx.Name,
+		amount,
+		s.ctx.Pair,
+		resp.OrderID,
+		s.report.Next)
+	return nil
+}
+
+// record adds a fill to the amount acquired and its cost
+func (s *Strategy) record(at time.Time, price, amount, cost float64) {
+	s.report.Amount += amount
+	s.report.Cost += cost
+	s.report.AverageCost = s.report.Cost / s.report.Amount
+	s.report.Purchases = append(s.report.Purchases, Purchase{
+		Time:        at,
+		Price:       price,
+		Amount:      amount,
+		Cost:        cost,
+		AverageCost: s.report.AverageCost,
+	})
+	if len(s.report.Purchases) > maxPurchases {
+		s.report.Purchases = s.report.Purchases[len(s.report.Purchases)-maxPurchases:]
+	}
+}
@@ -0,0 +1,208 @@
+package dca
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+var testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testTrader accepts each order, holding it for the test to fill
+type testTrader struct {
+	orders []*order.Submit
+	limits *order.Limits
+}
+
+func (t *testTrader) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
+	t.orders = append(t.orders, s)
+	return order.SubmitResponse{IsOrderPlaced: true, OrderID: strconv.Itoa(len(t.orders))}, nil
+}
+
+func (t *testTrader) CancelOrder(*order.Cancel) error {
+	return nil
+}
+
+func (t *testTrader) OrderLimits(string, currency.Pair, asset.Item) (order.Limits, bool) {
+	if t.limits == nil {
+		return order.Limits{}, false
+	}
+	return *t.limits, true
+}
+
+func newTestStrategy(t *testing.T, params map[string]string) (*Strategy, *testTrader) {
+	t.Helper()
+	trader := &testTrader{}
+	s := &Strategy{}
+	err := s.Init(&strategies.Context{
+		Name:       "test",
+		Exchange:   "test",
+		Pair:       currency.NewPair(currency.BTC, currency.USD),
+		AssetType:  asset.Spot,
+		Interval:   time.Hour,
+		Parameters: params,
+		Trader:     trader,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, trader
+}
+
+func candle(hour int, price float64) *kline.Candle {
+	return &kline.Candle{Time: testStart.Add(time.Hour * time.Duration(hour)), Close: price}
+}
+
+func TestInit(t *testing.T) {
+	for _, params := range []map[string]string{
+		nil,
+		{"amount": "-1"},
+		{"amount": "10", "every": "0s"},
+		{"amount": "10", "every": "daily"},
+		{"amount": "10", "fee": "-0.1"},
+	} {
+		if err := New().Init(&strategies.Context{Parameters: params}); err == nil {
+			t.Errorf("expected an error initialising with %v", params)
+		}
+	}
+	s, _ := newTestStrategy(t, map[string]string{"amount": "10"})
+	if s.every != DefaultEvery || s.fee != 0 {
+		t.Errorf("unexpected defaults every %v fee %v", s.every, s.fee)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	s, trader := newTestStrategy(t, map[string]string{"amount": "100", "every": "2h"})
+	for hour := 0; hour < 5; hour++ {
+		if err := s.OnCandle(candle(hour, 50)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// due at the close of the first candle and every two hours after
+	if len(trader.orders) != 3 {
+		t.Fatalf("expected 3 orders, received %d", len(trader.orders))
+	}
+	if trader.orders[0].Amount != 2 || trader.orders[0].Side != order.Buy || trader.orders[0].Type != order.Market {
+		t.Errorf("unexpected order %+v", trader.orders[0])
+	}
+	// purchases missed while not running are skipped
+	if err := s.OnCandle(candle(20, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if len(trader.orders) != 4 || !s.report.Next.Equal(testStart.Add(time.Hour*23)) {
+		t.Errorf("expected a single purchase and the next due at 23:00, received %d orders due %v",
+			len(trader.orders),
+			s.report.Next)
+	}
+}
+
+func TestOnOrderUpdate(t *testing.T) {
+	s, _ := newTestStrategy(t, map[string]string{"amount": "101", "fee": "0.01"})
+	if err := s.OnCandle(candle(0, 100)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.OnOrderUpdate(&order.Detail{ID: "other", Status: order.Filled, ExecutedAmount: 5}); err != nil {
+		t.Fatal(err)
+	}
+	// partially filled without a price, the fee is charged at the fee rate
+	if err := s.OnOrderUpdate(&order.Detail{ID: "1", Status: order.PartiallyFilled, ExecutedAmount: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.OnOrderUpdate(&order.Detail{ID: "1", Status: order.Filled, Price: 110, ExecutedAmount: 1, Fee: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.pending) != 0 {
+		t.Errorf("expected the filled order to be removed, received %v", s.pending)
+	}
+	r := s.Report()
+	if r.Amount != 1 || math.Abs(r.Cost-(50.5+57)) > 1e-9 || len(r.Purchases) != 2 {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if r.AverageCost != r.Cost || r.Purchases[0].AverageCost != 101 {
+		t.Errorf("unexpected average costs %+v", r)
+	}
+
+	if err := s.OnCandle(candle(24, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.OnOrderUpdate(&order.Detail{ID: "2", Status: order.Filled, Price: 50, ExecutedAmount: 2, Fee: 1}); err != nil {
+		t.Fatal(err)
+	}
+	r = s.Report()
+	if math.Abs(r.AverageCost-(107.5+101)/3) > 1e-9 {
+		t.Errorf("unexpected average cost %v", r.AverageCost)
+	}
+}
+
+func TestMinimumOrderSize(t *testing.T) {
+	s, trader := newTestStrategy(t, map[string]string{"amount": "10", "every": "1h"})
+	trader.limits = &order.Limits{MinNotional: 25, AmountIncrement: 0.001}
+	for hour := 0; hour < 3; hour++ {
+		if err := s.OnCandle(candle(hour, 3000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 10 and 20 are carried until 30 meets the minimum
+	if len(trader.orders) != 1 || trader.orders[0].Amount != 0.01 {
+		t.Fatalf("expected one order of 0.01, received %+v", trader.orders)
+	}
+	if math.Abs(s.report.Carried) > 1e-9 {
+		t.Errorf("expected nothing carried, received %v", s.report.Carried)
+	}
+
+	trader.limits = &order.Limits{AmountIncrement: 0.004}
+	if err := s.OnCandle(candle(3, 3000)); err != nil {
+		t.Fatal(err)
+	}
+	// rounded down to zero, then 20 buys 0.004 leaving 8 carried
+	if len(trader.orders) != 1 || math.Abs(s.report.Carried-10) > 1e-9 {
+		t.Errorf("expected 10 carried, received %v", s.report.Carried)
+	}
+	if err := s.OnCandle(candle(4, 3000)); err != nil {
+		t.Fatal(err)
+	}
+	if len(trader.orders) != 2 || trader.orders[1].Amount != 0.004 || math.Abs(s.report.Carried-8) > 1e-9 {
+		t.Errorf("unexpected orders %+v carrying %v", trader.orders, s.report.Carried)
+	}
+}
+
+func TestState(t *testing.T) {
+	s, _ := newTestStrategy(t, map[string]string{"amount": "100"})
+	if err := s.OnCandle(candle(0, 100)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.OnOrderUpdate(&order.Detail{ID: "1", Status: order.PartiallyFilled, Price: 100, ExecutedAmount: 0.4}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.SaveState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, trader := newTestStrategy(t, map[string]string{"amount": "100"})
+	if err = restored.RestoreState(b); err != nil {
+		t.Fatal(err)
+	}
+	if restored.report.Amount != 0.4 || !restored.report.Next.Equal(s.report.Next) {
+		t.Errorf("unexpected restored report %+v", restored.report)
+	}
+	if err = restored.OnOrderUpdate(&order.Detail{ID: "1", Status: order.Filled, Price: 100, ExecutedAmount: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if restored.report.Amount != 1 || restored.report.Cost != 100 {
+		t.Errorf("expected the pending order to finish filling, received %+v", restored.report)
+	}
+	if err = restored.OnCandle(candle(1, 100)); err != nil || len(trader.orders) != 0 {
+		t.Errorf("expected no purchase before the next is due, received %d orders %v", len(trader.orders), err)
+	}
+	if err = restored.RestoreState([]byte("{")); err == nil {
+		t.Error("expected an error restoring invalid state")
+	}
+}
@@ -0,0 +1,30 @@
+//go:build (linux && cgo) || (darwin && cgo)
+// +build linux,cgo darwin,cgo
+
+package strategies
+
+import (
+	"path/filepath"
+	"plugin"
+	"strings"
+)
+
+// LoadPlugin opens a strategy built with go build -buildmode=plugin and
+// registers it under the plugin's file name. The plugin must be built with the
+// same version of the bot it is loaded into and export a NewStrategy function
+func LoadPlugin(path string) (string, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return "", err
+	}
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return "", err
+	}
+	f, ok := sym.(func() Strategy)
+	if !ok {
+		return "", errPluginSymbolInvalid
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return name, Register(name, f)
+}
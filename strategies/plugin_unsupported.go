@@ -0,0 +1,10 @@
+//go:build !cgo || (!linux && !darwin)
+// +build !cgo !linux,!darwin
+
+package strategies
+
+// LoadPlugin is unavailable as Go plugins are only supported on Linux and
+// macOS with cgo enabled
+func LoadPlugin(_ string) (string, error) {
+	return "", errPluginsUnsupported
+}
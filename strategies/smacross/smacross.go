@@ -0,0 +1,179 @@
+// Package smacross is a strategy which buys when a fast simple moving average
+// of the candle closes crosses above a slow one and sells the amount bought
+// when it crosses back below
+package smacross
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline/indicators"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/strategies"
+)
+
+// Name is the name the strategy is registered under
+const Name = "smacross"
+
+// Default moving average periods
+const (
+	DefaultFastPeriod = 10
+	DefaultSlowPeriod = 30
+)
+
+var (
+	errInvalidPeriods = errors.New("fast period must be less than the slow period")
+	errInvalidAmount  = errors.New("amount parameter must be greater than zero")
+)
+
+func init() {
+	if err := strategies.Register(Name, New); err != nil {
+		panic(err)
+	}
+}
+
+// Strategy holds the moving averages and whether the amount is held
+type Strategy struct {
+	ctx    *strategies.Context
+	amount float64
+	fast   *indicators.SMAStream
+	slow   *indicators.SMAStream
+	// prev is the difference between the averages at the previous close,
+	// crosses are only traded once both averages were ready
+	prev    float64
+	hasPrev bool
+	long    bool
+}
+
+// state is the strategy state persisted across restarts. Closes holds the
+// slow moving average's window which includes the fast one's
+type state struct {
+	Closes  []float64 `json:"closes"`
+	Prev    float64   `json:"prev"`
+	HasPrev bool      `json:"hasPrev"`
+	Long    bool      `json:"long"`
+}
+
+// New returns a new instance of the strategy
+func New() strategies.Strategy {
+	return new(Strategy)
+}
+
+// Init reads the fast, slow and amount parameters. Amount is in the base
+// currency
+func (s *Strategy) Init(ctx *strategies.Context) error {
+	fast, err := ctx.Int("fast", DefaultFastPeriod)
+	if err != nil {
+		return err
+	}
+	slow, err := ctx.Int("slow", DefaultSlowPeriod)
+	if err != nil {
+		return err
+	}
+	if fast >= slow {
+		return errInvalidPeriods
+	}
+	if s.amount, err = ctx.Float("amount", 0); err != nil {
+		return err
+	}
+	if s.amount <= 0 {
+		return errInvalidAmount
+	}
+	if s.fast, err = indicators.NewSMAStream(fast); err != nil {
+		return err
+	}
+	if s.slow, err = indicators.NewSMAStream(slow); err != nil {
+		return err
+	}
+	s.ctx = ctx
+	return nil
+}
+
+// OnTick is unused, the strategy only trades on candle closes
+func (s *Strategy) OnTick(_ *ticker.Price) error {
+	return nil
+}
+
+// OnCandle updates the moving averages and trades when they cross
+func (s *Strategy) OnCandle(c *kline.Candle) error {
+	fast, _ := s.fast.Update(c.Close)
+	slow, ok := s.slow.Update(c.Close)
+	if !ok {
+		return nil
+	}
+	diff := fast - slow
+	prev, hasPrev := s.prev, s.hasPrev
+	s.prev, s.hasPrev = diff, true
+	if !hasPrev {
+		return nil
+	}
+
+	switch {
+	case !s.long && prev <= 0 && diff > 0:
+		if err := s.submit(order.Buy); err != nil {
+			return err
+		}
+		s.long = true
+	case s.long && prev >= 0 && diff < 0:
+		if err := s.submit(order.Sell); err != nil {
+			return err
+		}
+		s.long = false
+	}
+	return nil
+}
+
+// OnOrderUpdate is unused, orders are placed at market
+func (s *Strategy) OnOrderUpdate(_ *order.Detail) error {
+	return nil
+}
+
+// SaveState returns the closes in the moving averages and whether the amount
+// is held
+func (s *Strategy) SaveState() ([]byte, error) {
+	return json.Marshal(state{
+		Closes:  s.slow.Values(),
+		Prev:    s.prev,
+		HasPrev: s.hasPrev,
+		Long:    s.long,
+	})
+}
+
+// RestoreState adds the saved closes to the moving averages so that a
+// restarted strategy resumes trading without waiting for them to fill again
+func (s *Strategy) RestoreState(b []byte) error {
+	var st state
+	if err := json.Unmarshal(b, &st); err != nil {
+		return err
+	}
+	for x := range st.Closes {
+		s.fast.Update(st.Closes[x])
+		s.slow.Update(st.Closes[x])
+	}
+	s.prev, s.hasPrev, s.long = st.Prev, st.HasPrev, st.Long
+	return nil
+}
+
+func (s *Strategy) submit(side order.Side) error {
+	resp, err := s.ctx.Trader.SubmitOrder(&order.Submit{
+		Exchange:  s.ctx.Exchange,
+		Pair:      s.ctx.Pair,
+		AssetType: s.ctx.AssetType,
+		Side:      side,
+		Type:      order.Market,
+		Amount:    s.amount,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof(log.OrderMgr, "Strategy %s: %s %v %s at market, order ID %s.\n",
+		s.ctx.Name,
+		side,
+		s.amount,
+		s.ctx.Pair,
+		resp.OrderID)
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package strategies defines the interface trading strategies implement and
+// the registry strategies compiled into the bot or loaded from Go plugins are
+// created from
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// Register adds a strategy which can be created by its name. Strategies
+// compiled into the bot register themselves when their package is imported
+func Register(name string, f Factory) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return errStrategyNameEmpty
+	}
+	if f == nil {
+		return errStrategyFactoryNil
+	}
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("%s %v", name, errStrategyRegistered)
+	}
+	registry[name] = f
+	return nil
+}
+
+// New creates an instance of a registered strategy
+func New(name string) (Strategy, error) {
+	m.RLock()
+	f, ok := registry[strings.ToLower(name)]
+	m.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s %v", name, ErrStrategyNotFound)
+	}
+	return f(), nil
+}
+
+// Registered returns the names of the registered strategies, sorted
+func Registered() []string {
+	m.RLock()
+	defer m.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Float returns a parameter parsed as a float or the fallback when it is not
+// set
+func (c *Context) Float(key string, fallback float64) (float64, error) {
+	v, ok := c.Parameters[key]
+	if !ok || v == "" {
+		return fallback, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("strategy %s parameter %s: %v", c.Name, key, err)
+	}
+	return f, nil
+}
+
+// Int returns a parameter parsed as an integer or the fallback when it is not
+// set
+func (c *Context) Int(key string, fallback int) (int, error) {
+	v, ok := c.Parameters[key]
+	if !ok || v == "" {
+		return fallback, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("strategy %s parameter %s: %v", c.Name, key, err)
+	}
+	return i, nil
+}
+
+// Duration returns a parameter parsed as a duration, such as 24h, or the
+// fallback when it is not set
+func (c *Context) Duration(key string, fallback time.Duration) (time.Duration, error) {
+	v, ok := c.Parameters[key]
+	if !ok || v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("strategy %s parameter %s: %v", c.Name, key, err)
+	}
+	return d, nil
+}
+
+// OrderLimits returns the trading rules of the market, returning false when
+// the Trader does not know them
+func (c *Context) OrderLimits() (order.Limits, bool) {
+	t, ok := c.Trader.(LimitsTrader)
+	if !ok {
+		return order.Limits{}, false
+	}
+	return t.OrderLimits(c.Exchange, c.Pair, c.AssetType)
+}
+
+// OrderbookAnalytics returns the spread, imbalance and depth of the market's
+// stored orderbook, depth is measured within each of the percentages of the
+// mid price or orderbook.DefaultDepthPercents
+func (c *Context) OrderbookAnalytics(depthPercents ...float64) (*orderbook.Analytics, error) {
+	return orderbook.GetAnalytics(c.Exchange, c.Pair, c.AssetType, depthPercents...)
+}
@@ -0,0 +1,159 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+type testStrategy struct{}
+
+func (testStrategy) Init(*Context) error               { return nil }
+func (testStrategy) OnTick(*ticker.Price) error        { return nil }
+func (testStrategy) OnCandle(*kline.Candle) error      { return nil }
+func (testStrategy) OnOrderUpdate(*order.Detail) error { return nil }
+
+func newTestStrategy() Strategy {
+	return testStrategy{}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register(" ", newTestStrategy); err != errStrategyNameEmpty {
+		t.Errorf("expected %v, got %v", errStrategyNameEmpty, err)
+	}
+	if err := Register("TestRegister", nil); err != errStrategyFactoryNil {
+		t.Errorf("expected %v, got %v", errStrategyFactoryNil, err)
+	}
+	if err := Register("TestRegister", newTestStrategy); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register("testregister", newTestStrategy); err == nil {
+		t.Error("expected an error registering a strategy name twice")
+	}
+
+	s, err := New("TESTREGISTER")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(testStrategy); !ok {
+		t.Errorf("unexpected strategy %T", s)
+	}
+	if _, err = New("TestRegisterMissing"); err == nil {
+		t.Error("expected an error creating an unregistered strategy")
+	}
+
+	var found bool
+	names := Registered()
+	for x := range names {
+		if names[x] == "testregister" {
+			found = true
+		}
+		if x > 0 && names[x-1] > names[x] {
+			t.Errorf("expected sorted names, got %v", names)
+		}
+	}
+	if !found {
+		t.Errorf("expected testregister in %v", names)
+	}
+}
+
+func TestContextParameters(t *testing.T) {
+	c := &Context{Parameters: map[string]string{
+		"amount": "0.5",
+		"period": "14",
+		"bad":    "abc",
+		"empty":  "",
+	}}
+	if f, err := c.Float("amount", 1); err != nil || f != 0.5 {
+		t.Errorf("expected 0.5, got %v %v", f, err)
+	}
+	if f, err := c.Float("empty", 1); err != nil || f != 1 {
+		t.Errorf("expected the fallback, got %v %v", f, err)
+	}
+	if _, err := c.Float("bad", 1); err == nil {
+		t.Error("expected an error parsing an invalid float")
+	}
+	if i, err := c.Int("period", 10); err != nil || i != 14 {
+		t.Errorf("expected 14, got %v %v", i, err)
+	}
+	if i, err := c.Int("missing", 10); err != nil || i != 10 {
+		t.Errorf("expected the fallback, got %v %v", i, err)
+	}
+	if _, err := c.Int("amount", 10); err == nil {
+		t.Error("expected an error parsing a float as an integer")
+	}
+	c.Parameters["every"] = "36h"
+	if d, err := c.Duration("every", time.Hour); err != nil || d != time.Hour*36 {
+		t.Errorf("expected 36h, got %v %v", d, err)
+	}
+	if d, err := c.Duration("missing", time.Hour); err != nil || d != time.Hour {
+		t.Errorf("expected the fallback, got %v %v", d, err)
+	}
+	if _, err := c.Duration("period", time.Hour); err == nil {
+		t.Error("expected an error parsing a duration without a unit")
+	}
+}
+
+type testTrader struct{}
+
+func (testTrader) SubmitOrder(*order.Submit) (order.SubmitResponse, error) {
+	return order.SubmitResponse{}, nil
+}
+
+func (testTrader) CancelOrder(*order.Cancel) error { return nil }
+
+func (testTrader) OrderLimits(_ string, p currency.Pair, a asset.Item) (order.Limits, bool) {
+	return order.Limits{Pair: p, AssetType: a, MinAmount: 0.001}, true
+}
+
+func TestContextOrderLimits(t *testing.T) {
+	c := &Context{Pair: currency.NewPair(currency.BTC, currency.USD), AssetType: asset.Spot}
+	if _, ok := c.OrderLimits(); ok {
+		t.Error("expected no limits without a trader")
+	}
+	c.Trader = testTrader{}
+	l, ok := c.OrderLimits()
+	if !ok || l.MinAmount != 0.001 || !l.Pair.Equal(c.Pair) {
+		t.Errorf("unexpected limits %+v", l)
+	}
+}
+
+func TestContextOrderbookAnalytics(t *testing.T) {
+	c := &Context{
+		Exchange:  "strategyanalytics",
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+	}
+	if _, err := c.OrderbookAnalytics(); err == nil {
+		t.Error("expected an error without a stored orderbook")
+	}
+	b := orderbook.Base{
+		ExchangeName: c.Exchange,
+		Pair:         c.Pair,
+		AssetType:    c.AssetType,
+		Bids:         []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks:         []orderbook.Item{{Price: 101, Amount: 3}},
+	}
+	if err := b.Process(); err != nil {
+		t.Fatal(err)
+	}
+	a, err := c.OrderbookAnalytics(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Mid != 100 || a.Imbalance != -0.5 || a.Depth[0].AskAmount != 3 {
+		t.Errorf("unexpected analytics %+v", a)
+	}
+}
+
+func TestLoadPluginMissing(t *testing.T) {
+	if _, err := LoadPlugin("missing.so"); err == nil {
+		t.Error("expected an error loading a missing plugin")
+	}
+}
@@ -0,0 +1,86 @@
+package strategies
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// PluginSymbol is the function a strategy plugin exports to create instances
+// of its strategy. It must have the signature func() strategies.Strategy
+const PluginSymbol = "NewStrategy"
+
+// vars for the strategies package
+var (
+	ErrStrategyNotFound    = errors.New("strategy not found")
+	errStrategyNameEmpty   = errors.New("strategy name is empty")
+	errStrategyFactoryNil  = errors.New("strategy factory is nil")
+	errStrategyRegistered  = errors.New("strategy is already registered")
+	errPluginsUnsupported  = errors.New("strategy plugins are not supported on this platform")
+	errPluginSymbolInvalid = errors.New("strategy plugin " + PluginSymbol + " must be a func() strategies.Strategy")
+
+	m        sync.RWMutex
+	registry = make(map[string]Factory)
+)
+
+// Strategy is implemented by trading strategies. An instance runs on a single
+// exchange, currency pair and asset and its hooks are called one at a time.
+// Errors returned by the hooks are logged and do not stop the strategy
+type Strategy interface {
+	// Init is called once before any other hook with the market the
+	// strategy runs on and its configured parameters
+	Init(ctx *Context) error
+	// OnTick is called with each ticker update of the market
+	OnTick(t *ticker.Price) error
+	// OnCandle is called with each candle of the configured interval once it
+	// has closed. Candles are built from the trade feed, strategies may read
+	// the candles already built with trade.GetCandles when initialised
+	OnCandle(c *kline.Candle) error
+	// OnOrderUpdate is called when an order on the market is placed or
+	// updated
+	OnOrderUpdate(o *order.Detail) error
+}
+
+// Stateful is implemented by strategies which hold state, such as open
+// positions or indicator values, that must survive a restart of the bot.
+// SaveState is called between hooks and RestoreState is called once after
+// Init with the state last saved by the strategy instance of the same name
+type Stateful interface {
+	SaveState() ([]byte, error)
+	RestoreState(state []byte) error
+}
+
+// Factory creates a new instance of a strategy
+type Factory func() Strategy
+
+// Context is the market a strategy instance runs on and the access to the bot
+// it is given
+type Context struct {
+	// Name is the configured name of the strategy instance
+	Name       string
+	Exchange   string
+	Pair       currency.Pair
+	AssetType  asset.Item
+	Interval   time.Duration
+	Parameters map[string]string
+	Trader     Trader
+}
+
+// Trader places and cancels a strategy's orders through the bot's order
+// manager so they are subject to its limits and tracking
+type Trader interface {
+	SubmitOrder(s *order.Submit) (order.SubmitResponse, error)
+	CancelOrder(c *order.Cancel) error
+}
+
+// LimitsTrader is implemented by Traders which know the trading rules of the
+// markets orders are placed on, so strategies can size orders to meet them
+type LimitsTrader interface {
+	OrderLimits(exchange string, p currency.Pair, a asset.Item) (order.Limits, bool)
+}
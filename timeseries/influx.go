@@ -0,0 +1,141 @@
+package timeseries
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	errURLRequired      = errors.New("InfluxDB URL cannot be empty")
+	errDatabaseRequired = errors.New("InfluxDB database cannot be empty")
+)
+
+// NewInfluxDB returns a writer for an InfluxDB server
+func NewInfluxDB(s InfluxDBSettings) (*Influx, error) {
+	if s.URL == "" {
+		return nil, errURLRequired
+	}
+	if s.Database == "" {
+		return nil, errDatabaseRequired
+	}
+	s.URL = strings.TrimSuffix(s.URL, "/")
+	return &Influx{
+		settings: s,
+		client:   &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Write writes points in a single request
+func (i *Influx) Write(points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	for x := range points {
+		if len(points[x].Fields) == 0 {
+			continue
+		}
+		writeLine(&body, &points[x])
+	}
+
+	params := url.Values{}
+	params.Set("precision", "ns")
+	var path string
+	if i.settings.Organisation != "" {
+		path = "/api/v2/write"
+		params.Set("org", i.settings.Organisation)
+		params.Set("bucket", i.settings.Database)
+	} else {
+		path = "/write"
+		params.Set("db", i.settings.Database)
+	}
+	req, err := http.NewRequest(http.MethodPost, i.settings.URL+path+"?"+params.Encode(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	switch {
+	case i.settings.Token != "":
+		req.Header.Set("Authorization", "Token "+i.settings.Token)
+	case i.settings.Username != "":
+		req.SetBasicAuth(i.settings.Username, i.settings.Password)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("InfluxDB write failed with status %d: %s",
+			resp.StatusCode,
+			strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Close releases the writer's idle connections
+func (i *Influx) Close() error {
+	i.client.CloseIdleConnections()
+	return nil
+}
+
+// writeLine writes a point in line protocol, with its tags and fields sorted
+// by key
+func writeLine(b *bytes.Buffer, p *Point) {
+	b.WriteString(escape(p.Measurement, ", "))
+	tags := make([]string, 0, len(p.Tags))
+	for k := range p.Tags {
+		if p.Tags[k] != "" {
+			tags = append(tags, k)
+		}
+	}
+	sort.Strings(tags)
+	for _, k := range tags {
+		b.WriteByte(',')
+		b.WriteString(escape(k, ",= "))
+		b.WriteByte('=')
+		b.WriteString(escape(p.Tags[k], ",= "))
+	}
+	fields := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	for x, k := range fields {
+		if x == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(escape(k, ",= "))
+		b.WriteByte('=')
+		b.WriteString(strconv.FormatFloat(p.Fields[k], 'f', -1, 64))
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatInt(p.Time.UnixNano(), 10))
+	b.WriteByte('\n')
+}
+
+// escape backslash escapes the characters of a line protocol element
+func escape(s, chars string) string {
+	if !strings.ContainsAny(s, chars) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
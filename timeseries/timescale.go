@@ -0,0 +1,225 @@
+package timeseries
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+)
+
+var errHostRequired = errors.New("TimescaleDB host cannot be empty")
+
+// NewTimescaleDB connects to a TimescaleDB database
+func NewTimescaleDB(c drivers.ConnectionDetails) (*Timescale, error) {
+	if c.Host == "" {
+		return nil, errHostRequired
+	}
+	if c.SSLMode == "" {
+		c.SSLMode = "disable"
+	}
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		c.Username,
+		c.Password,
+		c.Host,
+		c.Port,
+		c.Database,
+		c.SSLMode)
+	db, err := sql.Open(database.DBPostgreSQL, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(time.Hour)
+	return &Timescale{
+		db:      db,
+		columns: make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// Write inserts points into their measurement's table in a single
+// transaction, creating the table and any new columns first
+func (t *Timescale) Write(points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	var measurements []string
+	grouped := make(map[string][]Point)
+	for i := range points {
+		m := points[i].Measurement
+		if _, ok := grouped[m]; !ok {
+			measurements = append(measurements, m)
+		}
+		grouped[m] = append(grouped[m], points[i])
+	}
+	for _, m := range measurements {
+		if err := t.ensureTable(m, grouped[m]); err != nil {
+			return err
+		}
+	}
+
+	tx, err := t.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, m := range measurements {
+		query, args := insertStatement(m, grouped[m])
+		if _, err = tx.Exec(query, args...); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				return fmt.Errorf("%v, rollback failed: %v", err, rbErr)
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ensureTable creates a measurement's hypertable and adds the columns of
+// the points which have not been written before
+func (t *Timescale) ensureTable(measurement string, points []Point) error {
+	known, ok := t.columns[measurement]
+	if !ok {
+		for _, stmt := range createTableStatements(measurement) {
+			if _, err := t.db.Exec(stmt); err != nil {
+				return fmt.Errorf("creating table for %s: %v", measurement, err)
+			}
+		}
+		known = make(map[string]struct{})
+		t.columns[measurement] = known
+	}
+	tags, fields := columns(points)
+	for _, stmt := range addColumnStatements(measurement, tags, fields, known) {
+		if _, err := t.db.Exec(stmt); err != nil {
+			return fmt.Errorf("adding column to %s: %v", measurement, err)
+		}
+	}
+	for i := range tags {
+		known[tags[i]] = struct{}{}
+	}
+	for i := range fields {
+		known[fields[i]] = struct{}{}
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (t *Timescale) Close() error {
+	return t.db.Close()
+}
+
+func tableName(measurement string) string {
+	return pq.QuoteIdentifier(timescaleTablePrefix + measurement)
+}
+
+// createTableStatements returns the statements creating a measurement's
+// table and converting it to a hypertable partitioned by time
+func createTableStatements(measurement string) []string {
+	return []string{
+		"CREATE TABLE IF NOT EXISTS " + tableName(measurement) + " (time TIMESTAMPTZ NOT NULL)",
+		"SELECT create_hypertable('" + strings.Replace(tableName(measurement), "'", "''", -1) +
+			"', 'time', if_not_exists => TRUE)",
+	}
+}
+
+// addColumnStatements returns the statements adding the tag and field
+// columns which are not known to exist
+func addColumnStatements(measurement string, tags, fields []string, known map[string]struct{}) []string {
+	var stmts []string
+	add := func(column, dataType string) {
+		if _, ok := known[column]; ok {
+			return
+		}
+		stmts = append(stmts, "ALTER TABLE "+tableName(measurement)+
+			" ADD COLUMN IF NOT EXISTS "+pq.QuoteIdentifier(column)+" "+dataType)
+	}
+	for i := range tags {
+		add(tags[i], "TEXT")
+	}
+	for i := range fields {
+		add(fields[i], "DOUBLE PRECISION")
+	}
+	return stmts
+}
+
+// insertStatement returns a multi-row insert of points and its arguments.
+// Tags or fields missing from a point are inserted as null
+func insertStatement(measurement string, points []Point) (string, []interface{}) {
+	tags, fields := columns(points)
+	cols := make([]string, 0, 1+len(tags)+len(fields))
+	cols = append(cols, "time")
+	for i := range tags {
+		cols = append(cols, pq.QuoteIdentifier(tags[i]))
+	}
+	for i := range fields {
+		cols = append(cols, pq.QuoteIdentifier(fields[i]))
+	}
+
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + tableName(measurement) + " (" + strings.Join(cols, ", ") + ") VALUES ")
+	args := make([]interface{}, 0, len(points)*len(cols))
+	for i := range points {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for x := range cols {
+			if x > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("$" + strconv.Itoa(len(args)+x+1))
+		}
+		b.WriteByte(')')
+		args = append(args, points[i].Time.UTC())
+		for x := range tags {
+			if v, ok := points[i].Tags[tags[x]]; ok {
+				args = append(args, v)
+			} else {
+				args = append(args, nil)
+			}
+		}
+		for x := range fields {
+			if v, ok := points[i].Fields[fields[x]]; ok {
+				args = append(args, v)
+			} else {
+				args = append(args, nil)
+			}
+		}
+	}
+	return b.String(), args
+}
+
+// columns returns the sorted tag and field names used by points
+func columns(points []Point) (tags, fields []string) {
+	seenTags := make(map[string]struct{})
+	seenFields := make(map[string]struct{})
+	for i := range points {
+		for k := range points[i].Tags {
+			if _, ok := seenTags[k]; !ok {
+				seenTags[k] = struct{}{}
+				tags = append(tags, k)
+			}
+		}
+		for k := range points[i].Fields {
+			if _, ok := seenFields[k]; !ok {
+				seenFields[k] = struct{}{}
+				fields = append(fields, k)
+			}
+		}
+	}
+	sort.Strings(tags)
+	sort.Strings(fields)
+	return tags, fields
+}
@@ -0,0 +1,139 @@
+package timeseries
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+)
+
+var testPoints = []Point{
+	{
+		Measurement: "ticker",
+		Tags:        map[string]string{"exchange": "Bitstamp", "pair": "BTC-USD"},
+		Fields:      map[string]float64{"last": 9000.5, "spread": 1},
+		Time:        time.Unix(1, 5),
+	},
+	{
+		Measurement: "portfolio",
+		Tags:        map[string]string{"name": "main account"},
+		Fields:      map[string]float64{"equity": 100},
+		Time:        time.Unix(2, 0),
+	},
+}
+
+func TestInfluxDBWrite(t *testing.T) {
+	var path, auth, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.String()
+		auth = r.Header.Get("Authorization")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		if r.URL.Query().Get("db") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"database not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if _, err := NewInfluxDB(InfluxDBSettings{}); err != errURLRequired {
+		t.Errorf("expected %v, received %v", errURLRequired, err)
+	}
+	if _, err := NewInfluxDB(InfluxDBSettings{URL: srv.URL}); err != errDatabaseRequired {
+		t.Errorf("expected %v, received %v", errDatabaseRequired, err)
+	}
+
+	w, err := NewInfluxDB(InfluxDBSettings{
+		URL:      srv.URL + "/",
+		Database: "gct",
+		Username: "user",
+		Password: "pass",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Write(testPoints); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/write?db=gct&precision=ns" {
+		t.Errorf("unexpected path %s", path)
+	}
+	if !strings.HasPrefix(auth, "Basic ") {
+		t.Errorf("expected basic auth, received %s", auth)
+	}
+	expected := "ticker,exchange=Bitstamp,pair=BTC-USD last=9000.5,spread=1 1000000005\n" +
+		"portfolio,name=main\\ account equity=100 2000000000\n"
+	if body != expected {
+		t.Errorf("expected %q, received %q", expected, body)
+	}
+
+	w, err = NewInfluxDB(InfluxDBSettings{
+		URL:          srv.URL,
+		Database:     "bucket",
+		Organisation: "org",
+		Token:        "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Write(testPoints[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/api/v2/write?bucket=bucket&org=org&precision=ns" {
+		t.Errorf("unexpected path %s", path)
+	}
+	if auth != "Token secret" {
+		t.Errorf("unexpected authorization %s", auth)
+	}
+
+	w, err = NewInfluxDB(InfluxDBSettings{URL: srv.URL, Database: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Write(testPoints)
+	if err == nil || !strings.Contains(err.Error(), "database not found") {
+		t.Errorf("expected write error, received %v", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTimescaleStatements(t *testing.T) {
+	if _, err := NewTimescaleDB(drivers.ConnectionDetails{}); err != errHostRequired {
+		t.Errorf("expected %v, received %v", errHostRequired, err)
+	}
+
+	stmts := createTableStatements("ticker")
+	if stmts[0] != `CREATE TABLE IF NOT EXISTS "gct_ticker" (time TIMESTAMPTZ NOT NULL)` ||
+		stmts[1] != `SELECT create_hypertable('"gct_ticker"', 'time', if_not_exists => TRUE)` {
+		t.Errorf("unexpected statements %v", stmts)
+	}
+
+	tags, fields := columns(testPoints[:1])
+	stmts = addColumnStatements("ticker", tags, fields, map[string]struct{}{"pair": {}})
+	if len(stmts) != 3 ||
+		stmts[0] != `ALTER TABLE "gct_ticker" ADD COLUMN IF NOT EXISTS "exchange" TEXT` ||
+		stmts[2] != `ALTER TABLE "gct_ticker" ADD COLUMN IF NOT EXISTS "spread" DOUBLE PRECISION` {
+		t.Errorf("unexpected statements %v", stmts)
+	}
+
+	points := []Point{
+		testPoints[0],
+		{Measurement: "ticker", Tags: map[string]string{"exchange": "Binance"}, Fields: map[string]float64{"last": 1}},
+	}
+	query, args := insertStatement("ticker", points)
+	expected := `INSERT INTO "gct_ticker" (time, "exchange", "pair", "last", "spread") VALUES ($1, $2, $3, $4, $5), ($6, $7, $8, $9, $10)`
+	if query != expected {
+		t.Errorf("expected %s, received %s", expected, query)
+	}
+	if len(args) != 10 || args[1] != "Bitstamp" || args[7] != nil || args[8] != 1.0 || args[9] != nil {
+		t.Errorf("unexpected arguments %v", args)
+	}
+}
@@ -0,0 +1,63 @@
+package timeseries
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Supported time-series databases
+const (
+	InfluxDB    = "influxdb"
+	TimescaleDB = "timescaledb"
+)
+
+const (
+	// requestTimeout is how long a write to InfluxDB may take
+	requestTimeout = time.Second * 15
+	// timescaleTablePrefix prefixes the table each measurement is written to
+	timescaleTablePrefix = "gct_"
+)
+
+// Point is a measurement of numeric fields identified by its tags at a point
+// in time
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Writer writes points to a time-series database
+type Writer interface {
+	Write(points []Point) error
+	Close() error
+}
+
+// InfluxDBSettings defines the InfluxDB server points are written to. The
+// v2 API is used when Organisation is set, with Database as the bucket
+type InfluxDBSettings struct {
+	URL          string
+	Database     string
+	Organisation string
+	Token        string
+	Username     string
+	Password     string
+}
+
+// Influx writes points to InfluxDB using its line protocol
+type Influx struct {
+	settings InfluxDBSettings
+	client   *http.Client
+}
+
+// Timescale writes points to a TimescaleDB hypertable per measurement,
+// named after the measurement prefixed with gct_. Tags are stored as text
+// columns and fields as double precision columns
+type Timescale struct {
+	db *sql.DB
+	m  sync.Mutex
+	// columns are the columns of each table which are known to exist
+	columns map[string]map[string]struct{}
+}